@@ -26,15 +26,18 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/edp"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/events"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/health"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/instancespurge"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/kubeconfig"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/metrics"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
 	orchestrate "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/handlers"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/manager"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/parametershistory"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/deprovisioning"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
@@ -46,6 +49,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeoverrides"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimestatescleanup"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeversion"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager"
 	uaa "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager/xsuaa"
@@ -139,6 +143,13 @@ type Config struct {
 	TrialRegionMappingFilePath string
 	MaxPaginationPage          int `envconfig:"default=100"`
 
+	RuntimeStatesCleanup runtimestatescleanup.Config
+	InstancesPurge       instancespurge.Config
+
+	Events events.Config
+
+	ParametersHistory parametershistory.Config
+
 	LogLevel string `envconfig:"default=info"`
 
 	// FreemiumProviders is a list of providers for freemium
@@ -272,6 +283,22 @@ func main() {
 	// metrics collectors
 	metrics.RegisterAll(eventBroker, db.Operations(), db.Instances())
 
+	if cfg.RuntimeStatesCleanup.Enabled {
+		runtimeStatesCleanup := runtimestatescleanup.NewService(db.RuntimeStates(), cfg.RuntimeStatesCleanup, logs.WithField("service", "runtimeStatesCleanup"))
+		go runtimeStatesCleanup.Run(ctx.Done())
+	}
+
+	if cfg.InstancesPurge.Enabled {
+		instancesPurge := instancespurge.NewService(db.Instances(), cfg.InstancesPurge, logs.WithField("service", "instancesPurge"))
+		go instancesPurge.Run(ctx.Done())
+	}
+
+	eventsCleanup := events.NewCleanupService(db.Events(), cfg.Events, logs.WithField("service", "eventsCleanup"))
+	go eventsCleanup.Run(ctx.Done())
+
+	parametersHistoryCleanup := parametershistory.NewCleanupService(db.Instances(), cfg.ParametersHistory, logs.WithField("service", "parametersHistoryCleanup"))
+	go parametersHistoryCleanup.Run(ctx.Done())
+
 	//setup runtime overrides appender
 	runtimeOverrides := runtimeoverrides.NewRuntimeOverrides(ctx, cli)
 
@@ -807,7 +834,7 @@ func NewKymaOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerS
 		}
 	}
 
-	orchestrateKymaManager := manager.NewUpgradeKymaManager(db.Orchestrations(), db.Operations(), db.Instances(),
+	orchestrateKymaManager := manager.NewUpgradeKymaManager(db.Orchestrations(), db.Operations(), db.Instances(), db.ChangeFeed(),
 		upgradeKymaManager, runtimeResolver, pollingInterval, smcf, logs.WithField("upgradeKyma", "orchestration"))
 	queue := process.NewQueue(orchestrateKymaManager, logs)
 
@@ -840,7 +867,7 @@ func NewClusterOrchestrationProcessingQueue(ctx context.Context, db storage.Brok
 		}
 	}
 
-	orchestrateClusterManager := manager.NewUpgradeClusterManager(db.Orchestrations(), db.Operations(), db.Instances(),
+	orchestrateClusterManager := manager.NewUpgradeClusterManager(db.Orchestrations(), db.Operations(), db.Instances(), db.ChangeFeed(),
 		upgradeClusterManager, runtimeResolver, pollingInterval, logs.WithField("upgradeCluster", "orchestration"))
 	queue := process.NewQueue(orchestrateClusterManager, logs)
 
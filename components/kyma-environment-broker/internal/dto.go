@@ -94,6 +94,7 @@ type ERSContext struct {
 	GlobalAccountID string                  `json:"globalaccount_id"`
 	ServiceManager  *ServiceManagerEntryDTO `json:"sm_platform_credentials,omitempty"`
 	Active          *bool                   `json:"active,omitempty"`
+	LicenseType     *string                 `json:"license_type,omitempty"`
 	UserID          string                  `json:"user_id"`
 }
 
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type Config struct {
+	// Interval between subsequent cleanups
+	CleanupInterval time.Duration `envconfig:"default=1h"`
+	// Retention defines how long events are kept
+	Retention time.Duration `envconfig:"default=720h"`
+}
+
+// CleanupService deletes events older than the retention
+type CleanupService struct {
+	events storage.Events
+	cfg    Config
+	log    logrus.FieldLogger
+}
+
+func NewCleanupService(events storage.Events, cfg Config, log logrus.FieldLogger) *CleanupService {
+	return &CleanupService{
+		events: events,
+		cfg:    cfg,
+		log:    log,
+	}
+}
+
+// Run performs the cleanup periodically until the stop channel is closed
+func (s *CleanupService) Run(stop <-chan struct{}) {
+	wait.Until(func() {
+		deleted, err := s.PerformCleanup()
+		if err != nil {
+			s.log.Errorf("while cleaning up events: %s", err)
+		}
+		s.log.Infof("Events cleanup finished: deleted %d events", deleted)
+	}, s.cfg.CleanupInterval, stop)
+}
+
+func (s *CleanupService) PerformCleanup() (int, error) {
+	return s.events.DeleteEventsOlderThan(time.Now().Add(-s.cfg.Retention))
+}
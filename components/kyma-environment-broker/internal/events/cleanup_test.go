@@ -0,0 +1,48 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupService_PerformCleanup(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+
+	now := time.Now()
+	fixEvent(t, db, "old-event", now.Add(-48*time.Hour))
+	fixEvent(t, db, "older-event", now.Add(-72*time.Hour))
+	fixEvent(t, db, "new-event", now.Add(-time.Hour))
+
+	svc := NewCleanupService(db.Events(), Config{Retention: 24 * time.Hour}, logger.NewLogDummy())
+
+	// when
+	deleted, err := svc.PerformCleanup()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	events, _, _, err := db.Events().ListEvents(dbmodel.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "new-event", events[0].ID)
+}
+
+func fixEvent(t *testing.T, db storage.BrokerStorage, id string, createdAt time.Time) {
+	err := db.Events().InsertEvent(internal.Event{
+		ID:         id,
+		InstanceID: "instance-id",
+		Level:      internal.EventInfo,
+		Message:    id,
+		CreatedAt:  createdAt,
+	})
+	require.NoError(t, err)
+}
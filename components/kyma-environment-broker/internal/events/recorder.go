@@ -0,0 +1,46 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Recorder stores events of instances. Failures are only logged, so recording an event never fails the caller.
+type Recorder struct {
+	events storage.Events
+	log    logrus.FieldLogger
+}
+
+func NewRecorder(events storage.Events, log logrus.FieldLogger) *Recorder {
+	return &Recorder{
+		events: events,
+		log:    log,
+	}
+}
+
+func (r *Recorder) Infof(instanceID, operationID, format string, args ...interface{}) {
+	r.record(instanceID, operationID, internal.EventInfo, fmt.Sprintf(format, args...))
+}
+
+func (r *Recorder) Errorf(instanceID, operationID, format string, args ...interface{}) {
+	r.record(instanceID, operationID, internal.EventError, fmt.Sprintf(format, args...))
+}
+
+func (r *Recorder) record(instanceID, operationID string, level internal.EventLevel, message string) {
+	err := r.events.InsertEvent(internal.Event{
+		ID:          uuid.New().String(),
+		InstanceID:  instanceID,
+		OperationID: operationID,
+		Level:       level,
+		Message:     message,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		r.log.Warnf("unable to store event %q of instance %s: %s", message, instanceID, err)
+	}
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Run("should store events of the instance", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		recorder := NewRecorder(db.Events(), logger.NewLogDummy())
+
+		// when
+		recorder.Infof("instance-id", "operation-id", "provisioning started")
+		recorder.Errorf("instance-id", "operation-id", "step %s retried", "create_runtime")
+		recorder.Infof("other-instance-id", "", "upgrade triggered by orchestration %s", "orchestration-id")
+
+		// then
+		events, count, totalCount, err := db.Events().ListEvents(dbmodel.EventFilter{InstanceIDs: []string{"instance-id"}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 2, totalCount)
+		assert.Equal(t, "provisioning started", events[0].Message)
+		assert.Equal(t, internal.EventInfo, events[0].Level)
+		assert.Equal(t, "step create_runtime retried", events[1].Message)
+		assert.Equal(t, internal.EventError, events[1].Level)
+		assert.Equal(t, "operation-id", events[1].OperationID)
+	})
+
+	t.Run("should only log failure of storing the event", func(t *testing.T) {
+		// given
+		logSpy := logger.NewLogSpy()
+		recorder := NewRecorder(failingEvents{}, logSpy.Logger)
+
+		// when
+		recorder.Infof("instance-id", "operation-id", "provisioning started")
+
+		// then
+		logSpy.AssertLogged(t, logrus.WarnLevel, "unable to store event \"provisioning started\" of instance instance-id: connection refused")
+	})
+}
+
+type failingEvents struct{}
+
+func (failingEvents) InsertEvent(internal.Event) error {
+	return errors.New("connection refused")
+}
+
+func (failingEvents) ListEvents(dbmodel.EventFilter) ([]internal.Event, int, int, error) {
+	return nil, -1, -1, errors.New("connection refused")
+}
+
+func (failingEvents) DeleteEventsOlderThan(time.Time) (int, error) {
+	return 0, errors.New("connection refused")
+}
@@ -0,0 +1,48 @@
+package instancespurge
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type Config struct {
+	Enabled bool `envconfig:"default=false"`
+	// Interval between subsequent purges
+	Interval time.Duration `envconfig:"default=24h"`
+	// Retention defines how long deleted instances are kept
+	Retention time.Duration `envconfig:"default=2160h"`
+}
+
+// Service permanently removes instances which were deleted longer than the retention ago.
+// Deleted instances are kept in the meantime for the audit trail, e.g. the billing reconciliation.
+type Service struct {
+	instances storage.Instances
+	cfg       Config
+	log       logrus.FieldLogger
+}
+
+func NewService(instances storage.Instances, cfg Config, log logrus.FieldLogger) *Service {
+	return &Service{
+		instances: instances,
+		cfg:       cfg,
+		log:       log,
+	}
+}
+
+// Run performs the purge periodically until the stop channel is closed
+func (s *Service) Run(stop <-chan struct{}) {
+	wait.Until(func() {
+		purged, err := s.PerformPurge()
+		if err != nil {
+			s.log.Errorf("while purging deleted instances: %s", err)
+		}
+		s.log.Infof("Instances purge finished: removed %d deleted instances", purged)
+	}, s.cfg.Interval, stop)
+}
+
+func (s *Service) PerformPurge() (int, error) {
+	return s.instances.PurgeDeletedOlderThan(s.cfg.Retention)
+}
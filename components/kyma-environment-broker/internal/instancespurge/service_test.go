@@ -0,0 +1,49 @@
+package instancespurge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PerformPurge(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+
+	require.NoError(t, db.Instances().Insert(fixture.FixInstance("deleted-instance-id")))
+	require.NoError(t, db.Instances().Insert(fixture.FixInstance("instance-id")))
+	require.NoError(t, db.Instances().Delete("deleted-instance-id"))
+
+	// when
+	purged, err := NewService(db.Instances(), Config{Retention: time.Hour}, logger.NewLogDummy()).PerformPurge()
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	assertInstances(t, db, "deleted-instance-id", "instance-id")
+
+	// when
+	purged, err = NewService(db.Instances(), Config{Retention: 0}, logger.NewLogDummy()).PerformPurge()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assertInstances(t, db, "instance-id")
+}
+
+func assertInstances(t *testing.T, db storage.BrokerStorage, expectedIDs ...string) {
+	instances, _, _, err := db.Instances().List(dbmodel.InstanceFilter{IncludeDeleted: true})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.InstanceID)
+	}
+	assert.ElementsMatch(t, expectedIDs, ids)
+}
@@ -11,7 +11,7 @@ import (
 // - compass_keb_instances_total - total number of all instances
 // - compass_keb_global_account_id_instances_total - total number of all instances per global account
 type InstancesStatsGetter interface {
-	GetInstanceStats() (internal.InstanceStats, error)
+	GetInstanceStats(includeSuspended bool) (internal.InstanceStats, error)
 }
 
 type InstancesCollector struct {
@@ -45,7 +45,7 @@ func (c *InstancesCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implements the prometheus.Collector interface.
 func (c *InstancesCollector) Collect(ch chan<- prometheus.Metric) {
-	stats, err := c.statsGetter.GetInstanceStats()
+	stats, err := c.statsGetter.GetInstanceStats(true)
 	if err != nil {
 		logrus.Error(err)
 		return
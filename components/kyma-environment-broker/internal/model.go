@@ -117,6 +117,15 @@ type Instance struct {
 	Provider CloudProvider
 }
 
+// InstanceParametersHistoryEntry holds the provisioning parameters of the instance before they were changed.
+// OperationID is empty if the change was not made by an operation, e.g. by the update of the instance context.
+type InstanceParametersHistoryEntry struct {
+	InstanceID  string
+	OperationID string
+	Parameters  ProvisioningParameters
+	CreatedAt   time.Time
+}
+
 func (i *Instance) GetInstanceDetails() (InstanceDetails, error) {
 	result := i.InstanceDetails
 	if result.ShootName == "" {
@@ -357,16 +366,82 @@ type RuntimeState struct {
 	ClusterConfig gqlschema.GardenerConfigInput `json:"clusterConfig"`
 }
 
+type EventLevel string
+
+const (
+	EventInfo  EventLevel = "info"
+	EventError EventLevel = "error"
+)
+
+// Event describes what happened to the instance, e.g. a step of the operation was retried
+type Event struct {
+	ID          string
+	InstanceID  string
+	OperationID string
+	Level       EventLevel
+	Message     string
+	CreatedAt   time.Time
+}
+
+type ChangeType string
+
+const (
+	OperationChange     ChangeType = "operation"
+	OrchestrationChange ChangeType = "orchestration"
+	// ResyncChange is published when changes could have been missed, e.g. the database connection was lost,
+	// subscribers must read the current state from the storage
+	ResyncChange ChangeType = "resync"
+)
+
+// Change describes that the state of the operation or orchestration was changed
+type Change struct {
+	Type            ChangeType `json:"type"`
+	ID              string     `json:"id"`
+	State           string     `json:"state"`
+	OrchestrationID string     `json:"orchestrationId"`
+}
+
 // OperationStats provide number of operations per type and state
 type OperationStats struct {
 	Provisioning   map[domain.LastOperationState]int
 	Deprovisioning map[domain.LastOperationState]int
 }
 
-// InstanceStats provide number of instances per Global Account ID
+// InstanceStats provide number of instances per Global Account ID, plan, region and license type
 type InstanceStats struct {
 	TotalNumberOfInstances int
 	PerGlobalAccountID     map[string]int
+	PerServicePlan         map[string]int
+	PerProviderRegion      map[string]int
+	PerLicenseType         map[string]int
+
+	// Suspended instances are always counted separately, they are included in other numbers only if requested
+	NumberOfSuspendedInstances int
+}
+
+func NewInstanceStats() InstanceStats {
+	return InstanceStats{
+		PerGlobalAccountID: make(map[string]int),
+		PerServicePlan:     make(map[string]int),
+		PerProviderRegion:  make(map[string]int),
+		PerLicenseType:     make(map[string]int),
+	}
+}
+
+// Add counts number of instances with the same global account, plan, region, license type and suspension state
+func (s *InstanceStats) Add(globalAccountID, servicePlan, providerRegion, licenseType string, suspended bool, count int, includeSuspended bool) {
+	if suspended {
+		s.NumberOfSuspendedInstances += count
+		if !includeSuspended {
+			return
+		}
+	}
+
+	s.TotalNumberOfInstances += count
+	s.PerGlobalAccountID[globalAccountID] += count
+	s.PerServicePlan[servicePlan] += count
+	s.PerProviderRegion[providerRegion] += count
+	s.PerLicenseType[licenseType] += count
 }
 
 // NewProvisioningOperation creates a fresh (just starting) instance of the ProvisioningOperation
@@ -11,7 +11,6 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type OperationFactory interface {
@@ -24,6 +23,7 @@ type orchestrationManager struct {
 	orchestrationStorage storage.Orchestrations
 	operationStorage     storage.Operations
 	instanceStorage      storage.Instances
+	changeFeed           storage.ChangeFeed
 	resolver             orchestration.RuntimeResolver
 	factory              OperationFactory
 	executor             orchestration.OperationExecutor
@@ -139,7 +139,10 @@ func (m *orchestrationManager) waitForCompletion(o *internal.Orchestration, stra
 	canceled := false
 	var err error
 	var stats map[string]int
-	err = wait.PollImmediateInfinite(m.pollingInterval, func() (bool, error) {
+	changes, unsubscribe := m.changeFeed.Subscribe()
+	defer unsubscribe()
+
+	err = m.pollUntil(changes, o.OrchestrationID, func() (bool, error) {
 		// check if orchestration wasn't canceled
 		o, err = m.orchestrationStorage.GetByID(o.OrchestrationID)
 		switch {
@@ -185,6 +188,39 @@ func (m *orchestrationManager) waitForCompletion(o *internal.Orchestration, stra
 
 	return m.resolveOrchestration(o, strategy, execID, stats)
 }
+
+// pollUntil checks the condition every polling interval or as soon as the change of the orchestration
+// or one of its operations is received, the change feed only shortens the time between checks
+func (m *orchestrationManager) pollUntil(changes <-chan internal.Change, orchestrationID string, condition func() (bool, error)) error {
+	for {
+		done, err := condition()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		timeout := time.NewTimer(m.pollingInterval)
+	wait:
+		for {
+			select {
+			case change, ok := <-changes:
+				if !ok {
+					changes = nil
+					continue
+				}
+				if change.Type == internal.ResyncChange || change.OrchestrationID == orchestrationID {
+					break wait
+				}
+			case <-timeout.C:
+				break wait
+			}
+		}
+		timeout.Stop()
+	}
+}
+
 func (m *orchestrationManager) resolveOrchestration(o *internal.Orchestration, strategy orchestration.Strategy, execID string, stats map[string]int) (*internal.Orchestration, error) {
 	if o.State == orchestration.Canceling {
 		err := m.factory.CancelOperations(o.OrchestrationID)
@@ -17,13 +17,14 @@ type upgradeClusterFactory struct {
 	operationStorage storage.Operations
 }
 
-func NewUpgradeClusterManager(orchestrationStorage storage.Orchestrations, operationStorage storage.Operations, instanceStorage storage.Instances,
+func NewUpgradeClusterManager(orchestrationStorage storage.Orchestrations, operationStorage storage.Operations, instanceStorage storage.Instances, changeFeed storage.ChangeFeed,
 	kymaClusterExecutor orchestration.OperationExecutor, resolver orchestration.RuntimeResolver,
 	pollingInterval time.Duration, log logrus.FieldLogger) process.Executor {
 	return &orchestrationManager{
 		orchestrationStorage: orchestrationStorage,
 		operationStorage:     operationStorage,
 		instanceStorage:      instanceStorage,
+		changeFeed:           changeFeed,
 		resolver:             resolver,
 		factory: &upgradeClusterFactory{
 			operationStorage: operationStorage,
@@ -32,7 +32,7 @@ func TestUpgradeClusterManager_Execute(t *testing.T) {
 		err := store.Orchestrations().Insert(internal.Orchestration{OrchestrationID: id, State: orchestration.Pending})
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, 20*time.Millisecond, logrus.New())
+		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), nil, resolver, 20*time.Millisecond, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -63,7 +63,7 @@ func TestUpgradeClusterManager_Execute(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), &testExecutor{}, resolver, poolingInterval, logrus.New())
+		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), &testExecutor{}, resolver, poolingInterval, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -93,7 +93,7 @@ func TestUpgradeClusterManager_Execute(t *testing.T) {
 			}})
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, poolingInterval, logrus.New())
+		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), nil, resolver, poolingInterval, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -152,7 +152,7 @@ func TestUpgradeClusterManager_Execute(t *testing.T) {
 		err = store.Orchestrations().Insert(givenO)
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), &testExecutor{}, resolver, poolingInterval, logrus.New())
+		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), &testExecutor{}, resolver, poolingInterval, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -190,7 +190,7 @@ func TestUpgradeClusterManager_Execute(t *testing.T) {
 			},
 		})
 
-		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), &testExecutor{}, resolver, poolingInterval, logrus.New())
+		svc := manager.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), &testExecutor{}, resolver, poolingInterval, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -20,13 +20,14 @@ type upgradeKymaFactory struct {
 	smcf             *servicemanager.ClientFactory
 }
 
-func NewUpgradeKymaManager(orchestrationStorage storage.Orchestrations, operationStorage storage.Operations, instanceStorage storage.Instances,
+func NewUpgradeKymaManager(orchestrationStorage storage.Orchestrations, operationStorage storage.Operations, instanceStorage storage.Instances, changeFeed storage.ChangeFeed,
 	kymaUpgradeExecutor orchestration.OperationExecutor, resolver orchestration.RuntimeResolver,
 	pollingInterval time.Duration, smcf *servicemanager.ClientFactory, log logrus.FieldLogger) process.Executor {
 	return &orchestrationManager{
 		orchestrationStorage: orchestrationStorage,
 		operationStorage:     operationStorage,
 		instanceStorage:      instanceStorage,
+		changeFeed:           changeFeed,
 		resolver:             resolver,
 		factory: &upgradeKymaFactory{
 			operationStorage: operationStorage,
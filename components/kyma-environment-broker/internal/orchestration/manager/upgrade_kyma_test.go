@@ -34,7 +34,7 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 		err := store.Orchestrations().Insert(internal.Orchestration{OrchestrationID: id, State: orchestration.Pending})
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, 20*time.Millisecond, nil, logrus.New())
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), nil, resolver, 20*time.Millisecond, nil, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -65,7 +65,7 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), &testExecutor{}, resolver, poolingInterval, nil, logrus.New())
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), &testExecutor{}, resolver, poolingInterval, nil, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -95,7 +95,7 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 			}})
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, poolingInterval, nil, logrus.New())
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), nil, resolver, poolingInterval, nil, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -154,7 +154,7 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 		err = store.Orchestrations().Insert(givenO)
 		require.NoError(t, err)
 
-		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), &testExecutor{}, resolver, poolingInterval, nil, logrus.New())
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), &testExecutor{}, resolver, poolingInterval, nil, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -192,7 +192,7 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 			},
 		})
 
-		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), &testExecutor{}, resolver, poolingInterval, nil, logrus.New())
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), store.ChangeFeed(), &testExecutor{}, resolver, poolingInterval, nil, logrus.New())
 
 		// when
 		_, err = svc.Execute(id)
@@ -208,6 +208,72 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 
 		assert.Equal(t, orchestration.Canceled, string(op.State))
 	})
+
+	t.Run("InProgressWithChangeFeed", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+		changeFeed := &testChangeFeed{changes: make(chan internal.Change, 1)}
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.InProgress,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: orchestration.Immediate,
+			}},
+		})
+		require.NoError(t, err)
+		err = store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{
+				ID:              id,
+				OrchestrationID: id,
+				State:           orchestration.InProgress,
+			},
+		})
+		require.NoError(t, err)
+
+		svc := manager.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), changeFeed, &testExecutor{}, resolver, time.Hour, nil, logrus.New())
+
+		finished := make(chan error)
+		go func() {
+			_, err := svc.Execute(id)
+			finished <- err
+		}()
+
+		// when
+		op, err := store.Operations().GetUpgradeKymaOperationByID(id)
+		require.NoError(t, err)
+		op.State = orchestration.Succeeded
+		_, err = store.Operations().UpdateUpgradeKymaOperation(*op)
+		require.NoError(t, err)
+
+		changeFeed.changes <- internal.Change{Type: internal.OperationChange, ID: id, State: orchestration.Succeeded, OrchestrationID: id}
+
+		// then
+		select {
+		case err := <-finished:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			require.FailNow(t, "orchestration was not finished after the change")
+		}
+
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+
+		assert.Equal(t, orchestration.Succeeded, o.State)
+	})
+}
+
+type testChangeFeed struct {
+	changes chan internal.Change
+}
+
+func (f *testChangeFeed) Subscribe() (<-chan internal.Change, func()) {
+	return f.changes, func() {}
 }
 
 type testExecutor struct{}
@@ -0,0 +1,46 @@
+package parametershistory
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type Config struct {
+	// Interval between subsequent cleanups
+	CleanupInterval time.Duration `envconfig:"default=24h"`
+	// Retention defines how long the previous parameters of instances are kept
+	Retention time.Duration `envconfig:"default=8760h"`
+}
+
+// CleanupService deletes entries of the instance parameters history older than the retention
+type CleanupService struct {
+	instances storage.Instances
+	cfg       Config
+	log       logrus.FieldLogger
+}
+
+func NewCleanupService(instances storage.Instances, cfg Config, log logrus.FieldLogger) *CleanupService {
+	return &CleanupService{
+		instances: instances,
+		cfg:       cfg,
+		log:       log,
+	}
+}
+
+// Run performs the cleanup periodically until the stop channel is closed
+func (s *CleanupService) Run(stop <-chan struct{}) {
+	wait.Until(func() {
+		deleted, err := s.PerformCleanup()
+		if err != nil {
+			s.log.Errorf("while cleaning up instance parameters history: %s", err)
+		}
+		s.log.Infof("Instance parameters history cleanup finished: deleted %d entries", deleted)
+	}, s.cfg.CleanupInterval, stop)
+}
+
+func (s *CleanupService) PerformCleanup() (int, error) {
+	return s.instances.DeleteParameterHistoryOlderThan(time.Now().Add(-s.cfg.Retention))
+}
@@ -0,0 +1,66 @@
+package parametershistory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupService_PerformCleanup(t *testing.T) {
+	t.Run("should keep entries within the retention", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		fixChangedInstance(t, db, "instance-id")
+
+		svc := NewCleanupService(db.Instances(), Config{Retention: 24 * time.Hour}, logger.NewLogDummy())
+
+		// when
+		deleted, err := svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted)
+
+		history, err := db.Instances().ListParameterHistory("instance-id", 10)
+		require.NoError(t, err)
+		assert.Len(t, history, 1)
+	})
+
+	t.Run("should delete entries older than the retention", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		fixChangedInstance(t, db, "instance-id")
+		time.Sleep(time.Millisecond)
+
+		svc := NewCleanupService(db.Instances(), Config{Retention: 0}, logger.NewLogDummy())
+
+		// when
+		deleted, err := svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		history, err := db.Instances().ListParameterHistory("instance-id", 10)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+}
+
+func fixChangedInstance(t *testing.T, db storage.BrokerStorage, id string) {
+	err := db.Instances().Insert(internal.Instance{InstanceID: id})
+	require.NoError(t, err)
+
+	instance, err := db.Instances().GetByID(id)
+	require.NoError(t, err)
+	instance.Parameters.Parameters.MachineType = ptr.String("m5.xlarge")
+
+	_, err = db.Instances().UpdateByOperation(*instance, "operation-id")
+	require.NoError(t, err)
+}
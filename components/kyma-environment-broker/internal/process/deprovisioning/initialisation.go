@@ -228,7 +228,7 @@ func (s *InitialisationStep) removeRuntimeID(op internal.DeprovisioningOperation
 
 	// empty RuntimeID means there is no runtime in the Provisioner Domain
 	inst.RuntimeID = ""
-	_, err = s.instanceStorage.Update(*inst)
+	_, err = s.instanceStorage.UpdateByOperation(*inst, op.ID)
 	if err != nil {
 		log.Errorf("cannot update instance with ID: %s", inst.InstanceID)
 		return err
@@ -100,13 +100,13 @@ func (s *CreateRuntimeStep) Run(operation internal.ProvisioningOperation, log lo
 		return operation, 10 * time.Second, nil
 	}
 
-	err = s.updateInstance(operation.InstanceID,
+	err = s.updateInstance(operation.InstanceID, operation.ID,
 		*provisionerResponse.RuntimeID,
 		requestInput.ClusterConfig.GardenerConfig.Region)
 	switch {
 	case err == nil:
 	case dberr.IsConflict(err):
-		err := s.updateInstance(operation.InstanceID, *provisionerResponse.RuntimeID, requestInput.ClusterConfig.GardenerConfig.Region)
+		err := s.updateInstance(operation.InstanceID, operation.ID, *provisionerResponse.RuntimeID, requestInput.ClusterConfig.GardenerConfig.Region)
 		if err != nil {
 			log.Errorf("cannot update instance: %s", err)
 			return operation, 1 * time.Minute, nil
@@ -117,14 +117,14 @@ func (s *CreateRuntimeStep) Run(operation internal.ProvisioningOperation, log lo
 	return operation, 0, nil
 }
 
-func (s *CreateRuntimeStep) updateInstance(id, runtimeID, region string) error {
+func (s *CreateRuntimeStep) updateInstance(id, operationID, runtimeID, region string) error {
 	instance, err := s.instanceStorage.GetByID(id)
 	if err != nil {
 		return errors.Wrap(err, "while getting instance")
 	}
 	instance.RuntimeID = runtimeID
 	instance.ProviderRegion = region
-	_, err = s.instanceStorage.Update(*instance)
+	_, err = s.instanceStorage.UpdateByOperation(*instance, operationID)
 	if err != nil {
 		return errors.Wrap(err, "while updating instance")
 	}
@@ -85,7 +85,7 @@ func (s *InitialisationStep) Run(operation internal.ProvisioningOperation, log l
 	case err == nil:
 		operation.InputCreator = creator
 
-		err := s.updateInstance(operation.InstanceID, creator.Provider())
+		err := s.updateInstance(operation.InstanceID, operation.ID, creator.Provider())
 		if err != nil {
 			return s.operationManager.RetryOperation(operation, err.Error(), 1*time.Second, 5*time.Second, log)
 		}
@@ -118,13 +118,13 @@ func (s *InitialisationStep) configureKymaVersion(operation *internal.Provisioni
 	return nil
 }
 
-func (s *InitialisationStep) updateInstance(id string, provider internal.CloudProvider) error {
+func (s *InitialisationStep) updateInstance(id, operationID string, provider internal.CloudProvider) error {
 	instance, err := s.instanceStorage.GetByID(id)
 	if err != nil {
 		return errors.Wrap(err, "while getting instance")
 	}
 	instance.Provider = provider
-	_, err = s.instanceStorage.Update(*instance)
+	_, err = s.instanceStorage.UpdateByOperation(*instance, operationID)
 	if err != nil {
 		return errors.Wrap(err, "while updating instance")
 	}
@@ -0,0 +1,77 @@
+package runtimestatescleanup
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type Config struct {
+	Enabled bool `envconfig:"default=false"`
+	// Interval between subsequent cleanups
+	Interval time.Duration `envconfig:"default=24h"`
+	// KeepLast is the number of the newest states kept for every runtime regardless of their age
+	KeepLast int `envconfig:"default=5"`
+	// Retention defines how long states are kept
+	Retention time.Duration `envconfig:"default=720h"`
+}
+
+// Result contains the number of processed runtimes and deleted runtime states
+type Result struct {
+	Runtimes      int
+	DeletedStates int
+}
+
+// Service deletes runtime states which are no longer needed. The newest states of every runtime,
+// states newer than the retention window and the state of the last operation of the instance are kept.
+type Service struct {
+	runtimeStates storage.RuntimeStates
+	cfg           Config
+	log           logrus.FieldLogger
+}
+
+func NewService(runtimeStates storage.RuntimeStates, cfg Config, log logrus.FieldLogger) *Service {
+	return &Service{
+		runtimeStates: runtimeStates,
+		cfg:           cfg,
+		log:           log,
+	}
+}
+
+// Run performs the cleanup periodically until the stop channel is closed
+func (s *Service) Run(stop <-chan struct{}) {
+	wait.Until(func() {
+		result, err := s.PerformCleanup()
+		if err != nil {
+			s.log.Errorf("while cleaning up runtime states: %s", err)
+		}
+		s.log.Infof("Runtime states cleanup finished: deleted %d states of %d runtimes", result.DeletedStates, result.Runtimes)
+	}, s.cfg.Interval, stop)
+}
+
+func (s *Service) PerformCleanup() (Result, error) {
+	result := Result{}
+
+	runtimeIDs, err := s.runtimeStates.ListRuntimeIDs()
+	if err != nil {
+		return result, err
+	}
+
+	olderThan := time.Now().Add(-s.cfg.Retention)
+	for _, runtimeID := range runtimeIDs {
+		deleted, err := s.runtimeStates.DeleteStatesOlderThan(runtimeID, s.cfg.KeepLast, olderThan)
+		result.DeletedStates += deleted
+		if err != nil {
+			return result, err
+		}
+		result.Runtimes++
+
+		if deleted > 0 {
+			s.log.Debugf("Deleted %d states of runtime %s", deleted, runtimeID)
+		}
+	}
+
+	return result, nil
+}
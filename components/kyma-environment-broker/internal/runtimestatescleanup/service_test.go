@@ -0,0 +1,69 @@
+package runtimestatescleanup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PerformCleanup(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+
+	instance := fixture.FixInstance("instance-id")
+	require.NoError(t, db.Instances().Insert(instance))
+
+	lastOperation := fixture.FixProvisioningOperation("last-operation-id", instance.InstanceID)
+	require.NoError(t, db.Operations().InsertProvisioningOperation(lastOperation))
+
+	now := time.Now()
+	// states of the runtime, from the oldest one which belongs to the last operation to the newest one
+	fixRuntimeState(t, db, "state-0", instance.RuntimeID, lastOperation.ID, now.Add(-100*time.Hour))
+	for i := 1; i <= 5; i++ {
+		fixRuntimeState(t, db, fmt.Sprintf("state-%d", i), instance.RuntimeID, fmt.Sprintf("operation-%d", i), now.Add(-time.Duration(50-i)*time.Hour))
+	}
+	fixRuntimeState(t, db, "state-6", instance.RuntimeID, "operation-6", now.Add(-time.Minute))
+	// states of the runtime without an instance
+	fixRuntimeState(t, db, "other-state-0", "other-runtime-id", "other-operation-0", now.Add(-100*time.Hour))
+	fixRuntimeState(t, db, "other-state-1", "other-runtime-id", "other-operation-1", now.Add(-90*time.Hour))
+
+	svc := NewService(db.RuntimeStates(), Config{KeepLast: 2, Retention: 24 * time.Hour}, logger.NewLogDummy())
+
+	// when
+	result, err := svc.PerformCleanup()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Result{Runtimes: 2, DeletedStates: 4}, result)
+
+	assertRuntimeStates(t, db, instance.RuntimeID, "state-0", "state-5", "state-6")
+	assertRuntimeStates(t, db, "other-runtime-id", "other-state-0", "other-state-1")
+}
+
+func fixRuntimeState(t *testing.T, db storage.BrokerStorage, id, runtimeID, operationID string, createdAt time.Time) {
+	err := db.RuntimeStates().Insert(internal.RuntimeState{
+		ID:          id,
+		RuntimeID:   runtimeID,
+		OperationID: operationID,
+		CreatedAt:   createdAt,
+	})
+	require.NoError(t, err)
+}
+
+func assertRuntimeStates(t *testing.T, db storage.BrokerStorage, runtimeID string, expectedIDs ...string) {
+	states, err := db.RuntimeStates().ListByRuntimeID(runtimeID)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(states))
+	for _, state := range states {
+		ids = append(ids, state.ID)
+	}
+	assert.ElementsMatch(t, expectedIDs, ids)
+}
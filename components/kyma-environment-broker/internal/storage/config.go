@@ -22,6 +22,23 @@ type Config struct {
 	MaxOpenConns    int           `envconfig:"default=8"`
 	MaxIdleConns    int           `envconfig:"default=2"`
 	ConnMaxLifetime time.Duration `envconfig:"default=30m"`
+
+	ChangeFeed ChangeFeedConfig
+	Tracing    TracingConfig
+}
+
+type ChangeFeedConfig struct {
+	// Enabled turns on listening for changes of operations and orchestrations notified by the database
+	Enabled bool `envconfig:"default=false"`
+	// PollInterval defines how often subscribers are asked to resync while the database listener is disconnected
+	PollInterval time.Duration `envconfig:"default=5s"`
+}
+
+type TracingConfig struct {
+	// Enabled turns on reporting the database queries and transactions as OpenTelemetry spans
+	Enabled bool `envconfig:"default=false"`
+	// SlowQueryThreshold defines the duration above which the query span is marked as slow
+	SlowQueryThreshold time.Duration `envconfig:"default=500ms"`
 }
 
 func (cfg *Config) ConnectionURL() string {
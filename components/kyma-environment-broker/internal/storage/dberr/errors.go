@@ -18,6 +18,7 @@ type Error interface {
 type dbError struct {
 	code    int
 	message string
+	cause   error
 }
 
 func errorf(code int, format string, a ...interface{}) Error {
@@ -28,6 +29,11 @@ func Internal(format string, a ...interface{}) Error {
 	return errorf(CodeInternal, format, a...)
 }
 
+// InternalWithCause keeps the error returned by the database driver so that it can be inspected with errors.As
+func InternalWithCause(cause error, format string, a ...interface{}) Error {
+	return dbError{code: CodeInternal, message: fmt.Sprintf(format, a...), cause: cause}
+}
+
 func NotFound(format string, a ...interface{}) Error {
 	return errorf(CodeNotFound, format, a...)
 }
@@ -49,7 +55,7 @@ func Conflict(format string, a ...interface{}) Error {
 
 func (e dbError) Append(additionalFormat string, a ...interface{}) Error {
 	format := additionalFormat + ", " + e.message
-	return errorf(e.code, format, a...)
+	return dbError{code: e.code, message: fmt.Sprintf(format, a...), cause: e.cause}
 }
 
 func (e dbError) Code() int {
@@ -60,6 +66,10 @@ func (e dbError) Error() string {
 	return e.message
 }
 
+func (e dbError) Unwrap() error {
+	return e.cause
+}
+
 func IsConflict(err error) bool {
 	dbe, ok := err.(Error)
 	if !ok {
@@ -1,6 +1,7 @@
 package dberr
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -72,4 +73,18 @@ func TestAppError(t *testing.T) {
 		assert.False(t, checkOne)
 		assert.True(t, checkTwo)
 	})
+
+	t.Run("should keep the cause of internal error when appended", func(t *testing.T) {
+		//given
+		cause := errors.New("driver error")
+
+		//when
+		err := InternalWithCause(cause, "Failed to update: %s", cause).Append("while updating")
+
+		//then
+		assert.Equal(t, CodeInternal, err.Code())
+		assert.Equal(t, "while updating, Failed to update: driver error", err.Error())
+		assert.True(t, errors.Is(err, cause))
+		assert.False(t, errors.Is(Internal("Failed to update: %s", cause), cause))
+	})
 }
@@ -0,0 +1,25 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+// EventFilter holds the filters when listing events. Zero From and To values do not limit the time range.
+type EventFilter struct {
+	Page         int
+	PageSize     int
+	InstanceIDs  []string
+	OperationIDs []string
+	Levels       []string
+	From         time.Time
+	To           time.Time
+}
+
+type EventDTO struct {
+	ID          string
+	InstanceID  string
+	OperationID string
+	Level       string
+	Message     string
+	CreatedAt   time.Time
+}
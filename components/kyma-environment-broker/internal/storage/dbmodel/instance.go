@@ -29,6 +29,8 @@ type InstanceFilter struct {
 	Plans            []string
 	Domains          []string
 	States           []InstanceState
+	// IncludeDeleted returns also the soft deleted instances
+	IncludeDeleted bool
 }
 
 type InstanceDTO struct {
@@ -53,6 +55,15 @@ type InstanceDTO struct {
 	Version int
 }
 
+// InstanceParametersHistoryDTO holds the provisioning parameters of the instance before they were changed by the update
+type InstanceParametersHistoryDTO struct {
+	ID                     int64
+	InstanceID             string
+	OperationID            string
+	ProvisioningParameters string
+	CreatedAt              time.Time
+}
+
 type InstanceWithOperationDTO struct {
 	InstanceDTO
 
@@ -11,6 +11,7 @@ import (
 type OperationFilter struct {
 	Page     int
 	PageSize int
+	Types    []string
 	States   []string
 }
 
@@ -39,7 +40,11 @@ type OperationStatEntry struct {
 	PlanID string
 }
 
-type InstanceByGlobalAccountIDStatEntry struct {
+type InstanceStatEntry struct {
 	GlobalAccountID string
+	ServicePlanName string
+	ProviderRegion  string
+	LicenseType     string
+	Suspended       bool
 	Total           int
 }
@@ -15,6 +15,9 @@ type OrchestrationFilter struct {
 	PageSize int
 	Types    []string
 	States   []string
+	// From and To limit the creation time of the orchestrations, zero value means no limit
+	From time.Time
+	To   time.Time
 }
 
 type OrchestrationDTO struct {
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+)
+
+type events struct {
+	mu sync.Mutex
+
+	events map[string]internal.Event
+}
+
+func NewEvents() *events {
+	return &events{
+		events: make(map[string]internal.Event, 0),
+	}
+}
+
+func (s *events) InsertEvent(event internal.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.events[event.ID]; exists {
+		return dberr.AlreadyExists("event with id %s already exist", event.ID)
+	}
+	s.events[event.ID] = event
+
+	return nil
+}
+
+func (s *events) ListEvents(filter dbmodel.EventFilter) ([]internal.Event, int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Event, 0)
+	offset := pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)
+
+	events := s.filter(filter)
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(events); i++ {
+		result = append(result, events[i])
+	}
+
+	return result,
+		len(result),
+		len(events),
+		nil
+}
+
+func (s *events) DeleteEventsOlderThan(olderThan time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for id, event := range s.events {
+		if event.CreatedAt.Before(olderThan) {
+			delete(s.events, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func (s *events) filter(filter dbmodel.EventFilter) []internal.Event {
+	events := make([]internal.Event, 0, len(s.events))
+	equal := func(a, b string) bool { return a == b }
+	for _, v := range s.events {
+		if ok := matchFilter(v.InstanceID, filter.InstanceIDs, equal); !ok {
+			continue
+		}
+		if ok := matchFilter(v.OperationID, filter.OperationIDs, equal); !ok {
+			continue
+		}
+		if ok := matchFilter(string(v.Level), filter.Levels, equal); !ok {
+			continue
+		}
+		if !filter.From.IsZero() && v.CreatedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !v.CreatedAt.Before(filter.To) {
+			continue
+		}
+
+		events = append(events, v)
+	}
+
+	return events
+}
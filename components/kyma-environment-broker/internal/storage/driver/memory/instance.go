@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -19,6 +20,8 @@ import (
 type instances struct {
 	mu                sync.Mutex
 	instances         map[string]internal.Instance
+	deleted           []internal.Instance
+	parametersHistory []internal.InstanceParametersHistoryEntry
 	operationsStorage *operations
 }
 
@@ -161,10 +164,33 @@ func (s *instances) Delete(instanceID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	inst, ok := s.instances[instanceID]
+	if !ok {
+		return nil
+	}
+	inst.DeletedAt = time.Now()
+	s.deleted = append(s.deleted, inst)
 	delete(s.instances, instanceID)
 	return nil
 }
 
+func (s *instances) PurgeDeletedOlderThan(olderThan time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deletedBefore := time.Now().Add(-olderThan)
+	kept := make([]internal.Instance, 0, len(s.deleted))
+	for _, inst := range s.deleted {
+		if inst.DeletedAt.After(deletedBefore) {
+			kept = append(kept, inst)
+		}
+	}
+	purged := len(s.deleted) - len(kept)
+	s.deleted = kept
+
+	return purged, nil
+}
+
 func (s *instances) Insert(instance internal.Instance) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -174,6 +200,10 @@ func (s *instances) Insert(instance internal.Instance) error {
 }
 
 func (s *instances) Update(instance internal.Instance) (*internal.Instance, error) {
+	return s.UpdateByOperation(instance, "")
+}
+
+func (s *instances) UpdateByOperation(instance internal.Instance, operationID string) (*internal.Instance, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	oldInst, exists := s.instances[instance.InstanceID]
@@ -183,14 +213,63 @@ func (s *instances) Update(instance internal.Instance) (*internal.Instance, erro
 	if oldInst.Version != instance.Version {
 		return nil, dberr.Conflict("unable to update instance %s - conflict", instance.InstanceID)
 	}
+	s.parametersHistory = append(s.parametersHistory, internal.InstanceParametersHistoryEntry{
+		InstanceID:  oldInst.InstanceID,
+		OperationID: operationID,
+		Parameters:  oldInst.Parameters,
+		CreatedAt:   time.Now(),
+	})
 	instance.Version = instance.Version + 1
 	s.instances[instance.InstanceID] = instance
 
 	return &instance, nil
 }
 
-func (s *instances) GetInstanceStats() (internal.InstanceStats, error) {
-	return internal.InstanceStats{}, fmt.Errorf("not implemented")
+func (s *instances) ListParameterHistory(instanceID string, limit int) ([]internal.InstanceParametersHistoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []internal.InstanceParametersHistoryEntry
+	for i := len(s.parametersHistory) - 1; i >= 0 && len(result) < limit; i-- {
+		if s.parametersHistory[i].InstanceID == instanceID {
+			result = append(result, s.parametersHistory[i])
+		}
+	}
+
+	return result, nil
+}
+
+func (s *instances) DeleteParameterHistoryOlderThan(olderThan time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.parametersHistory[:0]
+	for _, entry := range s.parametersHistory {
+		if !entry.CreatedAt.Before(olderThan) {
+			kept = append(kept, entry)
+		}
+	}
+	deleted := len(s.parametersHistory) - len(kept)
+	s.parametersHistory = kept
+
+	return deleted, nil
+}
+
+func (s *instances) GetInstanceStats(includeSuspended bool) (internal.InstanceStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := internal.NewInstanceStats()
+	for _, inst := range s.instances {
+		licenseType := ""
+		if inst.Parameters.ErsContext.LicenseType != nil {
+			licenseType = *inst.Parameters.ErsContext.LicenseType
+		}
+		suspended := inst.Parameters.ErsContext.Active != nil && !*inst.Parameters.ErsContext.Active
+
+		result.Add(inst.GlobalAccountID, inst.ServicePlanName, inst.ProviderRegion, licenseType, suspended, 1, includeSuspended)
+	}
+	return result, nil
 }
 
 func (s *instances) List(filter dbmodel.InstanceFilter) ([]internal.Instance, int, int, error) {
@@ -204,7 +283,7 @@ func (s *instances) List(filter dbmodel.InstanceFilter) ([]internal.Instance, in
 	sortInstancesByCreatedAt(instances)
 
 	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(instances); i++ {
-		toReturn = append(toReturn, s.instances[instances[i].InstanceID])
+		toReturn = append(toReturn, instances[i])
 	}
 
 	return toReturn,
@@ -234,7 +313,15 @@ func (s *instances) filterInstances(filter dbmodel.InstanceFilter) []internal.In
 		return err == nil && matched
 	}
 
+	candidates := make([]internal.Instance, 0, len(s.instances)+len(s.deleted))
 	for _, v := range s.instances {
+		candidates = append(candidates, v)
+	}
+	if filter.IncludeDeleted {
+		candidates = append(candidates, s.deleted...)
+	}
+
+	for _, v := range candidates {
 		if ok = matchFilter(v.InstanceID, filter.InstanceIDs, equal); !ok {
 			continue
 		}
@@ -3,6 +3,7 @@ package memory
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
@@ -313,6 +314,33 @@ func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, e
 	return &rows[0], nil
 }
 
+func (s *operations) GetLastOperationByTypes(instanceID string, types []internal.OperationType) (*internal.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows []internal.Operation
+	ops, _ := s.getAll()
+	for _, op := range ops {
+		if op.InstanceID != instanceID {
+			continue
+		}
+		for _, opType := range types {
+			if op.Type == opType {
+				rows = append(rows, op)
+				break
+			}
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, dberr.NotFound("instance operation with instance_id %s and types %v not found", instanceID, types)
+	}
+
+	s.sortByCreatedAt(rows)
+
+	return &rows[len(rows)-1], nil
+}
+
 func (s *operations) GetOperationByID(operationID string) (*internal.Operation, error) {
 	var res *internal.Operation
 
@@ -487,6 +515,48 @@ func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.
 		nil
 }
 
+func (s *operations) ListOperationsByStates(states []string, limit, offset int) ([]internal.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Operation, 0)
+	ops, _ := s.getAll()
+	for _, op := range ops {
+		if matchFilter(string(op.State), states, s.equalFilter) {
+			result = append(result, op)
+		}
+	}
+	s.sortByCreatedAt(result)
+
+	return s.paginate(result, limit, offset), nil
+}
+
+func (s *operations) ListOperationsInTimeRange(from, to time.Time, filter dbmodel.OperationFilter) ([]internal.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Operation, 0)
+	ops, _ := s.getAll()
+	for _, op := range ops {
+		if !from.IsZero() && op.CreatedAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !op.CreatedAt.Before(to) {
+			continue
+		}
+		if !s.matchOperationFilter(op, filter) {
+			continue
+		}
+		result = append(result, op)
+	}
+	s.sortByCreatedAt(result)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		return s.paginate(result, filter.PageSize, pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)), nil
+	}
+	return result, nil
+}
+
 func (s *operations) ListUpgradeKymaOperations() ([]internal.UpgradeKymaOperation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -588,24 +658,45 @@ func (s *operations) sortDeprovisioningByCreatedAtDesc(operations []internal.Dep
 	})
 }
 
+// sortByCreatedAt sorts the operations by the creation time, operations created at the same time are sorted by ID as in the database
 func (s *operations) sortByCreatedAt(operations []internal.Operation) {
 	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].CreatedAt.Equal(operations[j].CreatedAt) {
+			return operations[i].ID < operations[j].ID
+		}
 		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
 	})
 }
 
+// paginate returns at most limit operations starting from the offset, all operations from the offset when the limit is not positive
+func (s *operations) paginate(operations []internal.Operation, limit, offset int) []internal.Operation {
+	if offset >= len(operations) {
+		return []internal.Operation{}
+	}
+	operations = operations[offset:]
+	if limit > 0 && limit < len(operations) {
+		operations = operations[:limit]
+	}
+	return operations
+}
+
+// getAll returns all operations with the type set according to the kind of the stored operation
 func (s *operations) getAll() ([]internal.Operation, error) {
 	ops := make([]internal.Operation, 0)
 	for _, op := range s.upgradeKymaOperations {
+		op.Operation.Type = internal.OperationTypeUpgradeKyma
 		ops = append(ops, op.Operation)
 	}
 	for _, op := range s.upgradeClusterOperations {
+		op.Operation.Type = internal.OperationTypeUpgradeCluster
 		ops = append(ops, op.Operation)
 	}
 	for _, op := range s.provisioningOperations {
+		op.Operation.Type = internal.OperationTypeProvision
 		ops = append(ops, op.Operation)
 	}
 	for _, op := range s.deprovisioningOperations {
+		op.Operation.Type = internal.OperationTypeDeprovision
 		ops = append(ops, op.Operation)
 	}
 	if len(ops) == 0 {
@@ -622,7 +713,7 @@ func (s *operations) filterAll(filter dbmodel.OperationFilter) ([]internal.Opera
 		return nil, err
 	}
 	for _, op := range ops {
-		if ok := matchFilter(string(op.State), filter.States, s.equalFilter); !ok {
+		if !s.matchOperationFilter(op, filter) {
 			continue
 		}
 		result = append(result, op)
@@ -630,6 +721,16 @@ func (s *operations) filterAll(filter dbmodel.OperationFilter) ([]internal.Opera
 	return result, nil
 }
 
+func (s *operations) matchOperationFilter(op internal.Operation, filter dbmodel.OperationFilter) bool {
+	if ok := matchFilter(string(op.Type), filter.Types, s.equalFilter); !ok {
+		return false
+	}
+	if ok := matchFilter(string(op.State), filter.States, s.equalFilter); !ok {
+		return false
+	}
+	return true
+}
+
 func (s *operations) filterUpgradeKyma(orchestrationID string, filter dbmodel.OperationFilter) []internal.UpgradeKymaOperation {
 	operations := make([]internal.UpgradeKymaOperation, 0, len(s.upgradeKymaOperations))
 	for _, v := range s.upgradeKymaOperations {
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperations_QueriesWithIdenticalCreationTime(t *testing.T) {
+	// given
+	createdAt := time.Now()
+	svc := NewOperation()
+
+	provisioning := fixture.FixProvisioningOperation("operation-id-1", "inst-id")
+	provisioning.State = domain.Succeeded
+	provisioning.CreatedAt = createdAt
+	require.NoError(t, svc.InsertProvisioningOperation(provisioning))
+
+	otherProvisioning := fixture.FixProvisioningOperation("operation-id-4", "other-inst-id")
+	otherProvisioning.State = domain.Failed
+	otherProvisioning.CreatedAt = createdAt.Add(time.Hour)
+	require.NoError(t, svc.InsertProvisioningOperation(otherProvisioning))
+
+	for _, id := range []string{"operation-id-3", "operation-id-2"} {
+		deprovisioning := fixture.FixDeprovisioningOperation(id, "inst-id")
+		deprovisioning.State = domain.Failed
+		deprovisioning.CreatedAt = createdAt.Add(time.Hour)
+		require.NoError(t, svc.InsertDeprovisioningOperation(deprovisioning))
+	}
+
+	t.Run("should return last operation of types ordered by ID", func(t *testing.T) {
+		// when
+		lastOp, err := svc.GetLastOperationByTypes("inst-id", []internal.OperationType{internal.OperationTypeDeprovision})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "operation-id-3", lastOp.ID)
+
+		// when
+		_, err = svc.GetLastOperationByTypes("inst-id", []internal.OperationType{internal.OperationTypeUpgradeCluster})
+
+		// then
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should list operations by states ordered by ID", func(t *testing.T) {
+		// when
+		ops, err := svc.ListOperationsByStates([]string{string(domain.Failed)}, 2, 1)
+
+		// then
+		require.NoError(t, err)
+		assertOperationIDs(t, []string{"operation-id-3", "operation-id-4"}, ops)
+	})
+
+	t.Run("should list operations in time range ordered by ID", func(t *testing.T) {
+		// when
+		ops, err := svc.ListOperationsInTimeRange(createdAt.Add(time.Hour), createdAt.Add(2*time.Hour), dbmodel.OperationFilter{
+			Types:  []string{string(internal.OperationTypeDeprovision)},
+			States: []string{string(domain.Failed)},
+		})
+
+		// then
+		require.NoError(t, err)
+		assertOperationIDs(t, []string{"operation-id-2", "operation-id-3"}, ops)
+
+		// when
+		ops, err = svc.ListOperationsInTimeRange(time.Time{}, createdAt.Add(time.Hour), dbmodel.OperationFilter{})
+
+		// then
+		require.NoError(t, err)
+		assertOperationIDs(t, []string{"operation-id-1"}, ops)
+	})
+}
+
+func assertOperationIDs(t *testing.T, expected []string, got []internal.Operation) {
+	ids := make([]string, 0, len(got))
+	for _, op := range got {
+		ids = append(ids, op.ID)
+	}
+	assert.Equal(t, expected, ids)
+}
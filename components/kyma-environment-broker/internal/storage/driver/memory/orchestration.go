@@ -6,6 +6,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -64,6 +65,22 @@ func (s *orchestrations) List(filter dbmodel.OrchestrationFilter) ([]internal.Or
 		nil
 }
 
+func (s *orchestrations) GetOldestPending(orchestrationType orchestration.Type) (*internal.Orchestration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orchestrations := s.filter(dbmodel.OrchestrationFilter{
+		Types:  []string{string(orchestrationType)},
+		States: []string{orchestration.Pending},
+	})
+	if len(orchestrations) == 0 {
+		return nil, dberr.NotFound("pending %s orchestration not exist", orchestrationType)
+	}
+	s.sortByCreatedAt(orchestrations)
+
+	return &orchestrations[0], nil
+}
+
 func (s *orchestrations) Update(orchestration internal.Orchestration) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -92,6 +109,12 @@ func (s *orchestrations) filter(filter dbmodel.OrchestrationFilter) []internal.O
 		if ok := matchFilter(v.State, filter.States, equal); !ok {
 			continue
 		}
+		if !filter.From.IsZero() && v.CreatedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !v.CreatedAt.Before(filter.To) {
+			continue
+		}
 
 		orchestrations = append(orchestrations, v)
 	}
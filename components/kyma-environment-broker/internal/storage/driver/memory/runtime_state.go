@@ -1,7 +1,9 @@
 package memory
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 
@@ -11,12 +13,14 @@ import (
 type runtimeState struct {
 	mu sync.Mutex
 
-	runtimeStates map[string]internal.RuntimeState
+	runtimeStates    map[string]internal.RuntimeState
+	instancesStorage *instances
 }
 
-func NewRuntimeStates() *runtimeState {
+func NewRuntimeStates(instances *instances) *runtimeState {
 	return &runtimeState{
-		runtimeStates: make(map[string]internal.RuntimeState, 0),
+		runtimeStates:    make(map[string]internal.RuntimeState, 0),
+		instancesStorage: instances,
 	}
 }
 
@@ -55,3 +59,72 @@ func (s *runtimeState) GetByOperationID(operationID string) (internal.RuntimeSta
 
 	return internal.RuntimeState{}, dberr.NotFound("runtime state with operation ID %s not found", operationID)
 }
+
+func (s *runtimeState) ListRuntimeIDs() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unique := make(map[string]struct{})
+	result := make([]string, 0)
+
+	for _, state := range s.runtimeStates {
+		if _, found := unique[state.RuntimeID]; !found {
+			unique[state.RuntimeID] = struct{}{}
+			result = append(result, state.RuntimeID)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *runtimeState) DeleteStatesOlderThan(runtimeID string, keepLast int, olderThan time.Time) (int, error) {
+	protectedOperations, err := s.lastOperationIDs(runtimeID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]internal.RuntimeState, 0)
+	for _, state := range s.runtimeStates {
+		if state.RuntimeID == runtimeID {
+			states = append(states, state)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].CreatedAt.After(states[j].CreatedAt)
+	})
+
+	deleted := 0
+	for i, state := range states {
+		if i < keepLast || !state.CreatedAt.Before(olderThan) || protectedOperations[state.OperationID] {
+			continue
+		}
+		delete(s.runtimeStates, state.ID)
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func (s *runtimeState) lastOperationIDs(runtimeID string) (map[string]bool, error) {
+	instances, err := s.instancesStorage.FindAllInstancesForRuntimes([]string{runtimeID})
+	if err != nil && !dberr.IsNotFound(err) {
+		return nil, err
+	}
+
+	result := make(map[string]bool)
+	for _, instance := range instances {
+		operation, err := s.instancesStorage.operationsStorage.GetLastOperation(instance.InstanceID)
+		switch {
+		case err == nil:
+			result[operation.ID] = true
+		case dberr.IsNotFound(err):
+		default:
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -5,4 +5,10 @@ import "time"
 const (
 	defaultRetryTimeout  = time.Second * 5
 	defaultRetryInterval = time.Millisecond * 500
+
+	runtimeStatesDeleteBatchSize = 100
+	eventsDeleteBatchSize        = 1000
+	instancesPurgeBatchSize      = 1000
+
+	parametersHistoryDeleteBatchSize = 1000
 )
@@ -0,0 +1,95 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type events struct {
+	postsql.Factory
+}
+
+func NewEvents(sess postsql.Factory) *events {
+	return &events{
+		Factory: sess,
+	}
+}
+
+// InsertEvent stores the event outside of any transaction and without retries, as events are not worth slowing down the caller
+func (s *events) InsertEvent(event internal.Event) error {
+	sess := s.NewWriteSession()
+	return sess.InsertEvent(dbmodel.EventDTO{
+		ID:          event.ID,
+		InstanceID:  event.InstanceID,
+		OperationID: event.OperationID,
+		Level:       string(event.Level),
+		Message:     event.Message,
+		CreatedAt:   event.CreatedAt,
+	})
+}
+
+func (s *events) ListEvents(filter dbmodel.EventFilter) ([]internal.Event, int, int, error) {
+	sess := s.NewReadSession()
+	var (
+		dtos              []dbmodel.EventDTO
+		lastErr           error
+		count, totalCount int
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, count, totalCount, lastErr = sess.ListEvents(filter)
+		if lastErr != nil {
+			log.Errorf("while getting events: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, -1, -1, lastErr
+	}
+
+	result := make([]internal.Event, 0, len(dtos))
+	for _, dto := range dtos {
+		result = append(result, internal.Event{
+			ID:          dto.ID,
+			InstanceID:  dto.InstanceID,
+			OperationID: dto.OperationID,
+			Level:       internal.EventLevel(dto.Level),
+			Message:     dto.Message,
+			CreatedAt:   dto.CreatedAt,
+		})
+	}
+
+	return result, count, totalCount, nil
+}
+
+// DeleteEventsOlderThan deletes events created before olderThan. Events are deleted in batches to avoid long locks.
+func (s *events) DeleteEventsOlderThan(olderThan time.Time) (int, error) {
+	sess := s.NewWriteSession()
+	total := 0
+	for {
+		deleted := 0
+		var lastErr dberr.Error
+		err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+			deleted, lastErr = sess.DeleteEvents(olderThan, eventsDeleteBatchSize)
+			if lastErr != nil {
+				log.Errorf("while deleting events older than %s: %v", olderThan, lastErr)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			return total, lastErr
+		}
+
+		total += deleted
+		if deleted < eventsDeleteBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package postsql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("should list events with filters and delete old events", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		svc := brokerStorage.Events()
+
+		now := time.Now()
+		for i, event := range []internal.Event{
+			{ID: "event-0", InstanceID: "instance-1", OperationID: "operation-1", Level: internal.EventInfo, CreatedAt: now.Add(-100 * time.Hour)},
+			{ID: "event-1", InstanceID: "instance-1", OperationID: "operation-1", Level: internal.EventError, CreatedAt: now.Add(-90 * time.Hour)},
+			{ID: "event-2", InstanceID: "instance-1", OperationID: "operation-2", Level: internal.EventInfo, CreatedAt: now.Add(-2 * time.Hour)},
+			{ID: "event-3", InstanceID: "instance-1", Level: internal.EventInfo, CreatedAt: now.Add(-time.Hour)},
+			{ID: "event-4", InstanceID: "instance-2", OperationID: "operation-3", Level: internal.EventInfo, CreatedAt: now.Add(-time.Hour)},
+		} {
+			event.Message = event.ID
+			err = svc.InsertEvent(event)
+			require.NoError(t, err, "event %d", i)
+		}
+
+		// when
+		events, count, totalCount, err := svc.ListEvents(dbmodel.EventFilter{
+			Page:        1,
+			PageSize:    2,
+			InstanceIDs: []string{"instance-1"},
+			From:        now.Add(-95 * time.Hour),
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 3, totalCount)
+		assert.Equal(t, "event-1", events[0].ID)
+		assert.Equal(t, internal.EventError, events[0].Level)
+		assert.Equal(t, "event-2", events[1].ID)
+
+		// when
+		events, _, totalCount, err = svc.ListEvents(dbmodel.EventFilter{
+			OperationIDs: []string{"operation-1"},
+			Levels:       []string{string(internal.EventInfo)},
+			To:           now.Add(-95 * time.Hour),
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalCount)
+		assert.Equal(t, "event-0", events[0].ID)
+
+		// when
+		deleted, err := svc.DeleteEventsOlderThan(now.Add(-24 * time.Hour))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		events, _, totalCount, err = svc.ListEvents(dbmodel.EventFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, totalCount)
+		var ids []string
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		assert.ElementsMatch(t, []string{"event-2", "event-3", "event-4"}, ids)
+	})
+}
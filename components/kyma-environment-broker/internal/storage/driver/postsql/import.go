@@ -0,0 +1,170 @@
+package postsql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	"github.com/pkg/errors"
+)
+
+// ConflictMode defines how the records whose IDs already exist in the storage are handled by the Importer
+type ConflictMode string
+
+const (
+	// FailOnConflict rolls back the batch containing the existing record and stops the import
+	FailOnConflict ConflictMode = "fail"
+	// SkipOnConflict keeps the existing records and inserts the other ones
+	SkipOnConflict ConflictMode = "skip"
+)
+
+type ImportConfig struct {
+	// BatchSize is the number of records inserted with a single statement in a single transaction
+	BatchSize  int          `envconfig:"default=500"`
+	OnConflict ConflictMode `envconfig:"default=fail"`
+}
+
+func (c ImportConfig) Validate() error {
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch size %d has to be greater than 0", c.BatchSize)
+	}
+	if c.OnConflict != FailOnConflict && c.OnConflict != SkipOnConflict {
+		return fmt.Errorf("unknown conflict mode %s, has to be either %s or %s", c.OnConflict, FailOnConflict, SkipOnConflict)
+	}
+
+	return nil
+}
+
+// ImportResult counts the records inserted by the Importer and the ones skipped because they already existed
+type ImportResult struct {
+	Inserted int
+	Skipped  int
+}
+
+// Importer bulk-loads instances, operations and runtime states migrated from another broker installation.
+// Every batch is inserted all-or-nothing within a transaction, the batches committed before a failure are kept.
+type Importer struct {
+	postsql.Factory
+
+	instances     *Instance
+	operations    *operations
+	runtimeStates *runtimeState
+	config        ImportConfig
+}
+
+func NewImporter(sess postsql.Factory, cipher Cipher, config ImportConfig) *Importer {
+	operations := NewOperation(sess, cipher)
+	return &Importer{
+		Factory:       sess,
+		instances:     NewInstance(sess, operations, cipher),
+		operations:    operations,
+		runtimeStates: NewRuntimeStates(sess, cipher),
+		config:        config,
+	}
+}
+
+// InsertInstances inserts the instances with their timestamps, the current time is used for the ones which are not set
+func (s *Importer) InsertInstances(instances []internal.Instance) (ImportResult, error) {
+	now := time.Now()
+	dtos := make([]dbmodel.InstanceDTO, 0, len(instances))
+	for _, instance := range instances {
+		dto, err := s.instances.toInstanceDTO(instance)
+		if err != nil {
+			return ImportResult{}, errors.Wrapf(err, "while converting instance %s", instance.InstanceID)
+		}
+		dto.CreatedAt = timeOrDefault(dto.CreatedAt, now)
+		dto.UpdatedAt = timeOrDefault(dto.UpdatedAt, now)
+		dtos = append(dtos, dto)
+	}
+
+	return s.insertInBatches("instances", len(dtos), func(ws postsql.WriteSession, from, to int, skipExisting bool) (int, error) {
+		return ws.InsertInstances(dtos[from:to], skipExisting)
+	})
+}
+
+// InsertOperations inserts the operations with the data serialized from the common operation fields,
+// the fields specific to the operation type are not imported
+func (s *Importer) InsertOperations(operations []internal.Operation) (ImportResult, error) {
+	now := time.Now()
+	dtos := make([]dbmodel.OperationDTO, 0, len(operations))
+	for _, operation := range operations {
+		data, err := json.Marshal(operation)
+		if err != nil {
+			return ImportResult{}, errors.Wrapf(err, "while serializing operation %s", operation.ID)
+		}
+		dto, err := s.operations.operationToDB(operation)
+		if err != nil {
+			return ImportResult{}, errors.Wrapf(err, "while converting operation %s", operation.ID)
+		}
+		dto.Data = string(data)
+		dto.Type = operation.Type
+		dto.CreatedAt = timeOrDefault(dto.CreatedAt, now)
+		dto.UpdatedAt = timeOrDefault(dto.UpdatedAt, now)
+		dtos = append(dtos, dto)
+	}
+
+	return s.insertInBatches("operations", len(dtos), func(ws postsql.WriteSession, from, to int, skipExisting bool) (int, error) {
+		return ws.InsertOperations(dtos[from:to], skipExisting)
+	})
+}
+
+func (s *Importer) InsertRuntimeStates(states []internal.RuntimeState) (ImportResult, error) {
+	now := time.Now()
+	dtos := make([]dbmodel.RuntimeStateDTO, 0, len(states))
+	for _, state := range states {
+		dto, err := s.runtimeStates.runtimeStateToDB(state)
+		if err != nil {
+			return ImportResult{}, errors.Wrapf(err, "while converting runtime state %s", state.ID)
+		}
+		dto.CreatedAt = timeOrDefault(dto.CreatedAt, now)
+		dtos = append(dtos, dto)
+	}
+
+	return s.insertInBatches("runtime states", len(dtos), func(ws postsql.WriteSession, from, to int, skipExisting bool) (int, error) {
+		return ws.InsertRuntimeStates(dtos[from:to], skipExisting)
+	})
+}
+
+// insertInBatches converts all records before the first batch is inserted, so that the import does not stop halfway on invalid records
+func (s *Importer) insertInBatches(kind string, count int, insert func(ws postsql.WriteSession, from, to int, skipExisting bool) (int, error)) (ImportResult, error) {
+	if err := s.config.Validate(); err != nil {
+		return ImportResult{}, errors.Wrap(err, "while validating import config")
+	}
+
+	result := ImportResult{}
+	skipExisting := s.config.OnConflict == SkipOnConflict
+	for from := 0; from < count; from += s.config.BatchSize {
+		to := from + s.config.BatchSize
+		if to > count {
+			to = count
+		}
+
+		var inserted int
+		err := s.InTransaction(context.Background(), func(tx *dbr.Tx) error {
+			var err error
+			inserted, err = insert(postsql.NewWriteSessionWithinTx(tx), from, to, skipExisting)
+			return err
+		})
+		if err != nil {
+			return result, errors.Wrapf(err, "while inserting %s %d-%d", kind, from, to-1)
+		}
+
+		result.Inserted += inserted
+		result.Skipped += to - from - inserted
+	}
+
+	return result, nil
+}
+
+func timeOrDefault(value, defaultValue time.Time) time.Time {
+	if value.IsZero() {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,160 @@
+package postsql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	postgres "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/postsql"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should import instances, operations and runtime states in batches", func(t *testing.T) {
+		// given
+		brokerStorage, importer := setupImporter(t, ctx, postgres.ImportConfig{BatchSize: 2, OnConflict: postgres.FailOnConflict})
+
+		instances := []internal.Instance{fixture.FixInstance("instance-1"), fixture.FixInstance("instance-2"), fixture.FixInstance("instance-3")}
+		operations := []internal.Operation{
+			fixture.FixOperation("operation-1", "instance-1", internal.OperationTypeProvision),
+			fixture.FixOperation("operation-2", "instance-2", internal.OperationTypeProvision),
+			fixture.FixOperation("operation-3", "instance-3", internal.OperationTypeDeprovision),
+		}
+		states := fixRuntimeStates("state", 5)
+
+		// when
+		instancesResult, err := importer.InsertInstances(instances)
+		require.NoError(t, err)
+		operationsResult, err := importer.InsertOperations(operations)
+		require.NoError(t, err)
+		statesResult, err := importer.InsertRuntimeStates(states)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, postgres.ImportResult{Inserted: 3}, instancesResult)
+		assert.Equal(t, postgres.ImportResult{Inserted: 3}, operationsResult)
+		assert.Equal(t, postgres.ImportResult{Inserted: 5}, statesResult)
+
+		instance, err := brokerStorage.Instances().GetByID("instance-2")
+		require.NoError(t, err)
+		assert.Equal(t, instances[1].Parameters, instance.Parameters)
+
+		operation, err := brokerStorage.Operations().GetOperationByID("operation-3")
+		require.NoError(t, err)
+		assert.Equal(t, internal.OperationTypeDeprovision, operation.Type)
+		assert.Equal(t, "instance-3", operation.InstanceID)
+
+		imported, err := brokerStorage.RuntimeStates().ListByRuntimeID("runtime-id")
+		require.NoError(t, err)
+		assert.Len(t, imported, 5)
+	})
+
+	t.Run("should skip existing records", func(t *testing.T) {
+		// given
+		brokerStorage, importer := setupImporter(t, ctx, postgres.ImportConfig{BatchSize: 2, OnConflict: postgres.SkipOnConflict})
+		states := fixRuntimeStates("state", 3)
+		require.NoError(t, brokerStorage.RuntimeStates().Insert(states[1]))
+
+		// when
+		result, err := importer.InsertRuntimeStates(states)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, postgres.ImportResult{Inserted: 2, Skipped: 1}, result)
+	})
+
+	t.Run("should roll back the whole batch containing existing record", func(t *testing.T) {
+		// given
+		brokerStorage, importer := setupImporter(t, ctx, postgres.ImportConfig{BatchSize: 2, OnConflict: postgres.FailOnConflict})
+		states := fixRuntimeStates("state", 4)
+		require.NoError(t, brokerStorage.RuntimeStates().Insert(states[3]))
+
+		// when
+		result, err := importer.InsertRuntimeStates(states)
+
+		// then
+		require.Error(t, err)
+		dbErr, ok := errors.Cause(err).(dberr.Error)
+		require.True(t, ok)
+		assert.Equal(t, dberr.CodeAlreadyExists, dbErr.Code())
+		assert.Equal(t, postgres.ImportResult{Inserted: 2}, result)
+
+		_, err = brokerStorage.RuntimeStates().GetByOperationID(states[2].OperationID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
+
+// BenchmarkImporter compares inserting the runtime states one by one with the batch import,
+// run it with: go test -run=^$ -bench=BenchmarkImporter ./internal/storage/driver/postsql/...
+func BenchmarkImporter(b *testing.B) {
+	ctx := context.Background()
+	const count = 1000
+
+	b.Run("row by row", func(b *testing.B) {
+		brokerStorage, _ := setupImporter(b, ctx, postgres.ImportConfig{BatchSize: 500, OnConflict: postgres.FailOnConflict})
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for _, state := range fixRuntimeStates(fmt.Sprintf("row-%d", n), count) {
+				if err := brokerStorage.RuntimeStates().Insert(state); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		_, importer := setupImporter(b, ctx, postgres.ImportConfig{BatchSize: 500, OnConflict: postgres.FailOnConflict})
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			if _, err := importer.InsertRuntimeStates(fixRuntimeStates(fmt.Sprintf("batch-%d", n), count)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func setupImporter(t testing.TB, ctx context.Context, config postgres.ImportConfig) (storage.BrokerStorage, *postgres.Importer) {
+	containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+	require.NoError(t, err)
+	t.Cleanup(containerCleanupFunc)
+
+	tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+	require.NoError(t, err)
+	t.Cleanup(tablesCleanupFunc)
+
+	cipher := storage.NewEncrypter(cfg.SecretKey)
+	brokerStorage, connection, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+	require.NoError(t, err)
+
+	return brokerStorage, postgres.NewImporter(postsql.NewFactory(connection), cipher, config)
+}
+
+func fixRuntimeStates(prefix string, count int) []internal.RuntimeState {
+	states := make([]internal.RuntimeState, 0, count)
+	for i := 0; i < count; i++ {
+		states = append(states, internal.RuntimeState{
+			ID:            fmt.Sprintf("%s-%d", prefix, i),
+			OperationID:   fmt.Sprintf("%s-operation-%d", prefix, i),
+			RuntimeID:     "runtime-id",
+			CreatedAt:     time.Now(),
+			KymaConfig:    gqlschema.KymaConfigInput{Version: "2.0.0"},
+			ClusterConfig: gqlschema.GardenerConfigInput{KubernetesVersion: "1.21"},
+		})
+	}
+
+	return states
+}
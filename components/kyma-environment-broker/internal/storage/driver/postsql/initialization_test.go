@@ -21,7 +21,7 @@ func TestInitialization(t *testing.T) {
 		defer containerCleanupFunc()
 
 		// when
-		connection, err := postsql.InitializeDatabase(cfg.ConnectionURL(), 1, logrus.New())
+		connection, err := postsql.InitializeDatabase(cfg.ConnectionURL(), 1, nil, logrus.New())
 		require.NoError(t, err)
 		require.NotNil(t, connection)
 
@@ -40,7 +40,7 @@ func TestInitialization(t *testing.T) {
 		connString := "bad connection string"
 
 		// when
-		connection, err := postsql.InitializeDatabase(connString, 1, logrus.New())
+		connection, err := postsql.InitializeDatabase(connString, 1, nil, logrus.New())
 
 		// then
 		assert.Error(t, err)
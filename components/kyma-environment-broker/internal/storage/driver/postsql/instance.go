@@ -1,8 +1,11 @@
 package postsql
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
+	"github.com/gocraft/dbr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
@@ -388,14 +391,18 @@ func (s *Instance) Insert(instance internal.Instance) error {
 }
 
 func (s *Instance) Update(instance internal.Instance) (*internal.Instance, error) {
-	sess := s.NewWriteSession()
+	return s.UpdateByOperation(instance, "")
+}
+
+// UpdateByOperation updates the instance and records its previous parameters in the history with the ID of the operation making the change
+func (s *Instance) UpdateByOperation(instance internal.Instance, operationID string) (*internal.Instance, error) {
 	dto, err := s.toInstanceDTO(instance)
 	if err != nil {
 		return nil, err
 	}
 	var lastErr dberr.Error
 	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
-		lastErr = sess.UpdateInstance(dto)
+		lastErr = s.updateWithHistory(dto, operationID)
 
 		switch {
 		case dberr.IsNotFound(lastErr):
@@ -424,6 +431,91 @@ func (s *Instance) Update(instance internal.Instance) (*internal.Instance, error
 	return &instance, nil
 }
 
+// updateWithHistory records the previous parameters in the same transaction as the update, so the history never diverges from the instance
+func (s *Instance) updateWithHistory(dto dbmodel.InstanceDTO, operationID string) dberr.Error {
+	err := s.InTransaction(context.Background(), func(tx *dbr.Tx) error {
+		sess := postsql.NewWriteSessionWithinTx(tx)
+		if err := sess.InsertInstanceParametersHistory(dto.InstanceID, dto.Version, operationID, time.Now()); err != nil {
+			return err
+		}
+		return sess.UpdateInstance(dto)
+	})
+	if err == nil {
+		return nil
+	}
+
+	var dbErr dberr.Error
+	if errors.As(err, &dbErr) {
+		return dbErr
+	}
+	return dberr.Internal("Failed to update instance: %s", err)
+}
+
+// ListParameterHistory returns at most limit newest entries of the parameters history of the instance, starting from the newest one
+func (s *Instance) ListParameterHistory(instanceID string, limit int) ([]internal.InstanceParametersHistoryEntry, error) {
+	sess := s.NewReadSession()
+	var (
+		dtos    []dbmodel.InstanceParametersHistoryDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListInstanceParametersHistory(instanceID, limit)
+		if lastErr != nil {
+			log.Errorf("while getting parameters history of instance ID %s: %v", instanceID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	result := make([]internal.InstanceParametersHistoryEntry, 0, len(dtos))
+	for _, dto := range dtos {
+		var params internal.ProvisioningParameters
+		if err := json.Unmarshal([]byte(dto.ProvisioningParameters), &params); err != nil {
+			return nil, errors.Wrap(err, "while unmarshal parameters")
+		}
+		if err := s.cipher.DecryptBasicAuth(&params); err != nil {
+			return nil, errors.Wrap(err, "while decrypting parameters")
+		}
+		result = append(result, internal.InstanceParametersHistoryEntry{
+			InstanceID:  dto.InstanceID,
+			OperationID: dto.OperationID,
+			Parameters:  params,
+			CreatedAt:   dto.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// DeleteParameterHistoryOlderThan deletes parameters history entries created before olderThan. Entries are deleted in batches to avoid long locks.
+func (s *Instance) DeleteParameterHistoryOlderThan(olderThan time.Time) (int, error) {
+	sess := s.NewWriteSession()
+	total := 0
+	for {
+		deleted := 0
+		var lastErr dberr.Error
+		err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+			deleted, lastErr = sess.DeleteInstanceParametersHistory(olderThan, parametersHistoryDeleteBatchSize)
+			if lastErr != nil {
+				log.Errorf("while deleting parameters history older than %s: %v", olderThan, lastErr)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			return total, lastErr
+		}
+
+		total += deleted
+		if deleted < parametersHistoryDeleteBatchSize {
+			return total, nil
+		}
+	}
+}
+
 func (s *Instance) toInstanceDTO(instance internal.Instance) (dbmodel.InstanceDTO, error) {
 	err := s.cipher.EncryptBasicAuth(&instance.Parameters)
 	if err != nil {
@@ -453,23 +545,48 @@ func (s *Instance) toInstanceDTO(instance internal.Instance) (dbmodel.InstanceDT
 	}, nil
 }
 
+// Delete soft deletes the instance, it is no longer returned unless the deleted instances are requested explicitly
 func (s *Instance) Delete(instanceID string) error {
 	sess := s.NewWriteSession()
 	return sess.DeleteInstance(instanceID)
 }
 
-func (s *Instance) GetInstanceStats() (internal.InstanceStats, error) {
+// PurgeDeletedOlderThan permanently removes instances soft deleted more than olderThan ago. Instances are removed in batches to avoid long locks.
+func (s *Instance) PurgeDeletedOlderThan(olderThan time.Duration) (int, error) {
+	sess := s.NewWriteSession()
+	deletedBefore := time.Now().Add(-olderThan)
+	total := 0
+	for {
+		purged := 0
+		var lastErr dberr.Error
+		err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+			purged, lastErr = sess.PurgeInstances(deletedBefore, instancesPurgeBatchSize)
+			if lastErr != nil {
+				log.Errorf("while purging instances deleted before %s: %v", deletedBefore, lastErr)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			return total, lastErr
+		}
+
+		total += purged
+		if purged < instancesPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *Instance) GetInstanceStats(includeSuspended bool) (internal.InstanceStats, error) {
 	entries, err := s.NewReadSession().GetInstanceStats()
 	if err != nil {
 		return internal.InstanceStats{}, err
 	}
 
-	result := internal.InstanceStats{
-		PerGlobalAccountID: make(map[string]int),
-	}
+	result := internal.NewInstanceStats()
 	for _, e := range entries {
-		result.PerGlobalAccountID[e.GlobalAccountID] = e.Total
-		result.TotalNumberOfInstances = result.TotalNumberOfInstances + e.Total
+		result.Add(e.GlobalAccountID, e.ServicePlanName, e.ProviderRegion, e.LicenseType, e.Suspended, e.Total, includeSuspended)
 	}
 	return result, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
@@ -119,10 +120,16 @@ func TestInstance(t *testing.T) {
 		require.NotNil(t, brokerStorage)
 
 		// populate database with samples
+		suspendedInstance := fixInstance(instanceData{val: "C2", globalAccountID: "C"})
+		suspendedInstance.Parameters.ErsContext.Active = ptr.Bool(false)
+		licensedInstance := fixInstance(instanceData{val: "A2", globalAccountID: "A"})
+		licensedInstance.Parameters.ErsContext.LicenseType = ptr.String("SAPDEV")
+
 		fixInstances := []internal.Instance{
 			*fixInstance(instanceData{val: "A1", globalAccountID: "A"}),
-			*fixInstance(instanceData{val: "A2", globalAccountID: "A"}),
+			*licensedInstance,
 			*fixInstance(instanceData{val: "C1", globalAccountID: "C"}),
+			*suspendedInstance,
 		}
 
 		for _, i := range fixInstances {
@@ -131,7 +138,9 @@ func TestInstance(t *testing.T) {
 		}
 
 		// when
-		stats, err := brokerStorage.Instances().GetInstanceStats()
+		stats, err := brokerStorage.Instances().GetInstanceStats(false)
+		require.NoError(t, err)
+		statsWithSuspended, err := brokerStorage.Instances().GetInstanceStats(true)
 		require.NoError(t, err)
 		numberOfInstancesA, err := brokerStorage.Instances().GetNumberOfInstancesForGlobalAccountID("A")
 		require.NoError(t, err)
@@ -142,11 +151,18 @@ func TestInstance(t *testing.T) {
 
 		// then
 		assert.Equal(t, internal.InstanceStats{
-			TotalNumberOfInstances: 3,
-			PerGlobalAccountID:     map[string]int{"A": 2, "C": 1},
+			TotalNumberOfInstances:     3,
+			PerGlobalAccountID:         map[string]int{"A": 2, "C": 1},
+			PerServicePlan:             map[string]int{"A1": 1, "A2": 1, "C1": 1},
+			PerProviderRegion:          map[string]int{"A1": 1, "A2": 1, "C1": 1},
+			PerLicenseType:             map[string]int{"": 2, "SAPDEV": 1},
+			NumberOfSuspendedInstances: 1,
 		}, stats)
+		assert.Equal(t, 4, statsWithSuspended.TotalNumberOfInstances)
+		assert.Equal(t, map[string]int{"A": 2, "C": 2}, statsWithSuspended.PerGlobalAccountID)
+		assert.Equal(t, 1, statsWithSuspended.NumberOfSuspendedInstances)
 		assert.Equal(t, 2, numberOfInstancesA)
-		assert.Equal(t, 1, numberOfInstancesC)
+		assert.Equal(t, 2, numberOfInstancesC)
 	})
 
 	t.Run("Should fetch instances along with their operations", func(t *testing.T) {
@@ -502,6 +518,140 @@ func TestInstance(t *testing.T) {
 		require.NotEqual(t, inst3.InstanceID, out[0].InstanceID)
 		require.NotEqual(t, inst3.InstanceID, out[1].InstanceID)
 	})
+
+	t.Run("Should soft delete, provision again and purge instance", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		// given
+		instance := fixture.FixInstance("instance-id")
+		require.NoError(t, brokerStorage.Instances().Insert(instance))
+
+		// when
+		err = brokerStorage.Instances().Delete(instance.InstanceID)
+
+		// then
+		require.NoError(t, err)
+		_, err = brokerStorage.Instances().GetByID(instance.InstanceID)
+		assert.True(t, dberr.IsNotFound(err))
+
+		stats, err := brokerStorage.Instances().GetInstanceStats(true)
+		require.NoError(t, err)
+		assert.Zero(t, stats.TotalNumberOfInstances)
+
+		_, count, totalCount, err := brokerStorage.Instances().List(dbmodel.InstanceFilter{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		assert.Zero(t, totalCount)
+
+		deleted, count, _, err := brokerStorage.Instances().List(dbmodel.InstanceFilter{IncludeDeleted: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		assert.False(t, deleted[0].DeletedAt.IsZero())
+
+		// when
+		instance.DashboardURL = "https://console.new.example.com"
+		err = brokerStorage.Instances().Insert(instance)
+
+		// then
+		require.NoError(t, err)
+		got, err := brokerStorage.Instances().GetByID(instance.InstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, instance.DashboardURL, got.DashboardURL)
+
+		_, err = brokerStorage.Instances().Update(*got)
+		require.NoError(t, err)
+
+		// when
+		purged, err := brokerStorage.Instances().PurgeDeletedOlderThan(time.Hour)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+
+		// when
+		purged, err = brokerStorage.Instances().PurgeDeletedOlderThan(0)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		_, count, _, err = brokerStorage.Instances().List(dbmodel.InstanceFilter{IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		_, err = brokerStorage.Instances().GetByID(instance.InstanceID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should record parameters history on update", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		// given
+		instance := fixture.FixInstance("instance-id")
+		require.NoError(t, brokerStorage.Instances().Insert(instance))
+		initialParameters := instance.Parameters
+
+		instance.Parameters.Parameters.Name = "updated-name"
+
+		// when
+		updated, err := brokerStorage.Instances().UpdateByOperation(instance, "operation-id")
+
+		// then
+		require.NoError(t, err)
+		history, err := brokerStorage.Instances().ListParameterHistory(instance.InstanceID, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, "operation-id", history[0].OperationID)
+		assert.Equal(t, initialParameters, history[0].Parameters)
+
+		// when
+		_, err = brokerStorage.Instances().Update(instance)
+
+		// then
+		assert.True(t, dberr.IsConflict(err))
+		history, err = brokerStorage.Instances().ListParameterHistory(instance.InstanceID, 10)
+		require.NoError(t, err)
+		assert.Len(t, history, 1)
+
+		// when
+		_, err = brokerStorage.Instances().Update(*updated)
+
+		// then
+		require.NoError(t, err)
+		history, err = brokerStorage.Instances().ListParameterHistory(instance.InstanceID, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, "updated-name", history[0].Parameters.Parameters.Name)
+		assert.Empty(t, history[0].OperationID)
+
+		// when
+		deleted, err := brokerStorage.Instances().DeleteParameterHistoryOlderThan(time.Now().Add(time.Hour))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+	})
 }
 
 func assertInstanceByIgnoreTime(t *testing.T, want, got internal.Instance) {
@@ -1,11 +1,13 @@
 package postsql
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/gocraft/dbr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -107,7 +109,6 @@ func (s *operations) GetProvisioningOperationByInstanceID(instanceID string) (*i
 
 // UpdateProvisioningOperation updates ProvisioningOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateProvisioningOperation(op internal.ProvisioningOperation) (*internal.ProvisioningOperation, error) {
-	session := s.NewWriteSession()
 	op.UpdatedAt = time.Now()
 	dto, err := s.provisioningOperationToDTO(&op)
 	if err != nil {
@@ -116,7 +117,7 @@ func (s *operations) UpdateProvisioningOperation(op internal.ProvisioningOperati
 
 	var lastErr error
 	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
-		lastErr = session.UpdateOperation(dto)
+		lastErr = s.updateOperation(dto)
 		if lastErr != nil && dberr.IsNotFound(lastErr) {
 			_, lastErr = s.NewReadSession().GetOperationByID(op.ID)
 			if lastErr != nil {
@@ -237,7 +238,6 @@ func (s *operations) GetDeprovisioningOperationByInstanceID(instanceID string) (
 
 // UpdateDeprovisioningOperation updates DeprovisioningOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateDeprovisioningOperation(operation internal.DeprovisioningOperation) (*internal.DeprovisioningOperation, error) {
-	session := s.NewWriteSession()
 	operation.UpdatedAt = time.Now()
 
 	dto, err := s.deprovisioningOperationToDTO(&operation)
@@ -247,7 +247,7 @@ func (s *operations) UpdateDeprovisioningOperation(operation internal.Deprovisio
 
 	var lastErr error
 	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
-		lastErr = session.UpdateOperation(dto)
+		lastErr = s.updateOperation(dto)
 		if lastErr != nil && dberr.IsNotFound(lastErr) {
 			_, lastErr = s.NewReadSession().GetOperationByID(operation.ID)
 			if lastErr != nil {
@@ -439,7 +439,6 @@ func (s *operations) ListUpgradeKymaOperationsByInstanceID(instanceID string) ([
 
 // UpdateUpgradeKymaOperation updates UpgradeKymaOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateUpgradeKymaOperation(operation internal.UpgradeKymaOperation) (*internal.UpgradeKymaOperation, error) {
-	session := s.NewWriteSession()
 	operation.UpdatedAt = time.Now()
 	dto, err := s.upgradeKymaOperationToDTO(&operation)
 	if err != nil {
@@ -448,7 +447,7 @@ func (s *operations) UpdateUpgradeKymaOperation(operation internal.UpgradeKymaOp
 
 	var lastErr error
 	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
-		lastErr = session.UpdateOperation(dto)
+		lastErr = s.updateOperation(dto)
 		if lastErr != nil && dberr.IsNotFound(lastErr) {
 			_, lastErr = s.NewReadSession().GetOperationByID(operation.Operation.ID)
 			if lastErr != nil {
@@ -499,6 +498,38 @@ func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, e
 	return &op, nil
 }
 
+// GetLastOperationByTypes returns the newest operation of the instance with one of the given types, including pending operations
+func (s *operations) GetLastOperationByTypes(instanceID string, types []internal.OperationType) (*internal.Operation, error) {
+	session := s.NewReadSession()
+	operation := dbmodel.OperationDTO{}
+	op := internal.Operation{}
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operation, lastErr = session.GetLastOperationByTypes(instanceID, types)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				lastErr = dberr.NotFound("Operation with instance_id %s and types %v not exist", instanceID, types)
+				return false, lastErr
+			}
+			log.Errorf("while reading operation from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	err = json.Unmarshal([]byte(operation.Data), &op)
+	if err != nil {
+		return nil, errors.New("unable to unmarshall operation data")
+	}
+	op, err = s.toOperation(&operation, op.InstanceDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
 // GetOperationByID returns Operation with given ID. Returns an error if the operation does not exists.
 func (s *operations) GetOperationByID(operationID string) (*internal.Operation, error) {
 	session := s.NewReadSession()
@@ -632,6 +663,52 @@ func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.
 	return result, size, total, err
 }
 
+// ListOperationsByStates returns at most limit operations in one of the given states starting from the offset,
+// ordered by creation time and ID. All operations starting from the offset are returned when the limit is not positive
+func (s *operations) ListOperationsByStates(states []string, limit, offset int) ([]internal.Operation, error) {
+	session := s.NewReadSession()
+	var (
+		operations = make([]dbmodel.OperationDTO, 0)
+		lastErr    dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operations, lastErr = session.ListOperationsByStates(states, limit, offset)
+		if lastErr != nil {
+			log.Errorf("while getting operations from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	return s.toOperations(operations)
+}
+
+// ListOperationsInTimeRange returns the operations created from the given time (inclusive) to the given time (exclusive)
+// which match the filter, ordered by creation time and ID. The zero time means no limit
+func (s *operations) ListOperationsInTimeRange(from, to time.Time, filter dbmodel.OperationFilter) ([]internal.Operation, error) {
+	session := s.NewReadSession()
+	var (
+		operations = make([]dbmodel.OperationDTO, 0)
+		lastErr    dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operations, lastErr = session.ListOperationsInTimeRange(from, to, filter)
+		if lastErr != nil {
+			log.Errorf("while getting operations from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	return s.toOperations(operations)
+}
+
 func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeKymaOperation, int, int, error) {
 	session := s.NewReadSession()
 	var (
@@ -684,7 +761,6 @@ func (s *operations) InsertUpgradeClusterOperation(operation internal.UpgradeClu
 
 // UpdateUpgradeClusterOperation updates UpgradeClusterOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateUpgradeClusterOperation(operation internal.UpgradeClusterOperation) (*internal.UpgradeClusterOperation, error) {
-	session := s.NewWriteSession()
 	operation.UpdatedAt = time.Now()
 	dto, err := s.upgradeClusterOperationToDTO(&operation)
 	if err != nil {
@@ -693,7 +769,7 @@ func (s *operations) UpdateUpgradeClusterOperation(operation internal.UpgradeClu
 
 	var lastErr error
 	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
-		lastErr = session.UpdateOperation(dto)
+		lastErr = s.updateOperation(dto)
 		if lastErr != nil && dberr.IsNotFound(lastErr) {
 			_, lastErr = s.NewReadSession().GetOperationByID(operation.Operation.ID)
 			if lastErr != nil {
@@ -795,6 +871,13 @@ func (s *operations) ListUpgradeClusterOperationsByOrchestrationID(orchestration
 	return ret, count, totalCount, nil
 }
 
+// updateOperation updates the operation in the transaction which is retried on serialization failures and deadlocks
+func (s *operations) updateOperation(dto dbmodel.OperationDTO) error {
+	return s.InTransaction(context.Background(), func(tx *dbr.Tx) error {
+		return postsql.NewWriteSessionWithinTx(tx).UpdateOperation(dto)
+	})
+}
+
 func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO, error) {
 	err := s.cipher.EncryptBasicAuth(&op.ProvisioningParameters)
 	if err != nil {
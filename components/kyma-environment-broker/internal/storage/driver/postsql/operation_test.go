@@ -11,6 +11,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/sirupsen/logrus"
@@ -364,6 +365,107 @@ func TestOperation(t *testing.T) {
 		require.NoError(t, err)
 		assertUpgradeClusterOperation(t, *op, *got)
 	})
+
+	t.Run("Queries by types, states and time range", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		createdAt := time.Now().Truncate(time.Millisecond)
+		svc := brokerStorage.Operations()
+
+		provisioning := fixture.FixProvisioningOperation("operation-id-1", "inst-id")
+		provisioning.InputCreator = nil
+		provisioning.State = domain.Succeeded
+		provisioning.CreatedAt = createdAt
+		err = svc.InsertProvisioningOperation(provisioning)
+		require.NoError(t, err)
+
+		// operations created at the same time are inserted in the reverse order of the IDs
+		otherProvisioning := fixture.FixProvisioningOperation("operation-id-4", "other-inst-id")
+		otherProvisioning.InputCreator = nil
+		otherProvisioning.State = domain.Failed
+		otherProvisioning.CreatedAt = createdAt.Add(time.Hour)
+		err = svc.InsertProvisioningOperation(otherProvisioning)
+		require.NoError(t, err)
+
+		for _, id := range []string{"operation-id-3", "operation-id-2"} {
+			deprovisioning := fixture.FixDeprovisioningOperation(id, "inst-id")
+			deprovisioning.State = domain.Failed
+			deprovisioning.CreatedAt = createdAt.Add(time.Hour)
+			err = svc.InsertDeprovisioningOperation(deprovisioning)
+			require.NoError(t, err)
+		}
+
+		// when
+		lastOp, err := svc.GetLastOperationByTypes("inst-id", []internal.OperationType{internal.OperationTypeDeprovision})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "operation-id-3", lastOp.ID)
+		assert.Equal(t, internal.OperationTypeDeprovision, lastOp.Type)
+
+		// when
+		lastOp, err = svc.GetLastOperationByTypes("inst-id", []internal.OperationType{internal.OperationTypeProvision, internal.OperationTypeUpgradeKyma})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "operation-id-1", lastOp.ID)
+
+		// when
+		_, err = svc.GetLastOperationByTypes("inst-id", []internal.OperationType{internal.OperationTypeUpgradeCluster})
+
+		// then
+		assert.True(t, dberr.IsNotFound(err))
+
+		// when
+		ops, err := svc.ListOperationsByStates([]string{string(domain.Failed)}, 0, 0)
+
+		// then
+		require.NoError(t, err)
+		assertOperationIDs(t, []string{"operation-id-2", "operation-id-3", "operation-id-4"}, ops)
+
+		// when
+		ops, err = svc.ListOperationsByStates([]string{string(domain.Failed)}, 2, 1)
+
+		// then
+		require.NoError(t, err)
+		assertOperationIDs(t, []string{"operation-id-3", "operation-id-4"}, ops)
+
+		// when
+		ops, err = svc.ListOperationsInTimeRange(createdAt.Add(time.Hour), createdAt.Add(2*time.Hour), dbmodel.OperationFilter{
+			Types:  []string{string(internal.OperationTypeDeprovision)},
+			States: []string{string(domain.Failed)},
+		})
+
+		// then
+		require.NoError(t, err)
+		assertOperationIDs(t, []string{"operation-id-2", "operation-id-3"}, ops)
+
+		// when
+		ops, err = svc.ListOperationsInTimeRange(time.Time{}, createdAt.Add(time.Hour), dbmodel.OperationFilter{})
+
+		// then
+		require.NoError(t, err)
+		assertOperationIDs(t, []string{"operation-id-1"}, ops)
+	})
+}
+
+func assertOperationIDs(t *testing.T, expected []string, got []internal.Operation) {
+	ids := make([]string, 0, len(got))
+	for _, op := range got {
+		ids = append(ids, op.ID)
+	}
+	assert.Equal(t, expected, ids)
 }
 
 func assertProvisioningOperation(t *testing.T, expected, got internal.ProvisioningOperation) {
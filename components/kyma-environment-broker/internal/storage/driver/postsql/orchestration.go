@@ -1,6 +1,7 @@
 package postsql
 
 import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
@@ -98,6 +99,32 @@ func (s *orchestrations) List(filter dbmodel.OrchestrationFilter) ([]internal.Or
 	return orchestrations, count, totalCount, nil
 }
 
+func (s *orchestrations) GetOldestPending(orchestrationType orchestration.Type) (*internal.Orchestration, error) {
+	sess := s.NewReadSession()
+	result := internal.Orchestration{}
+	var lastErr error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		var dto dbmodel.OrchestrationDTO
+		dto, lastErr = sess.GetOldestPendingOrchestration(string(orchestrationType))
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, dberr.NotFound("Pending %s orchestration not exist", orchestrationType)
+			}
+			log.Errorf("while getting oldest pending %s orchestration: %v", orchestrationType, lastErr)
+			return false, nil
+		}
+		result, lastErr = dto.ToOrchestration()
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	if lastErr != nil {
+		return nil, errors.Wrapf(lastErr, "while converting pending %s orchestration", orchestrationType)
+	}
+	return &result, nil
+}
+
 func (s *orchestrations) Update(orchestration internal.Orchestration) error {
 	dto, err := dbmodel.NewOrchestrationDTO(orchestration)
 	if err != nil {
@@ -3,8 +3,10 @@ package postsql_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -68,4 +70,91 @@ func TestOrchestration(t *testing.T) {
 		assert.Equal(t, 1, c)
 		assert.Equal(t, 1, tc)
 	})
+
+	t.Run("Orchestrations filtered by creation time and oldest pending", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		now := time.Now().Truncate(time.Millisecond)
+		fixOrchestration := func(id string, orchestrationType orchestration.Type, state string, createdAt time.Time) internal.Orchestration {
+			o := fixture.FixOrchestration(id)
+			o.Type = orchestrationType
+			o.State = state
+			o.CreatedAt = createdAt
+			return o
+		}
+
+		svc := brokerStorage.Orchestrations()
+		for _, o := range []internal.Orchestration{
+			fixOrchestration("kyma-failed-old", orchestration.UpgradeKymaOrchestration, orchestration.Failed, now.Add(-10*24*time.Hour)),
+			fixOrchestration("kyma-failed-new", orchestration.UpgradeKymaOrchestration, orchestration.Failed, now.Add(-2*24*time.Hour)),
+			fixOrchestration("kyma-pending-new", orchestration.UpgradeKymaOrchestration, orchestration.Pending, now.Add(-1*time.Hour)),
+			fixOrchestration("kyma-pending-old", orchestration.UpgradeKymaOrchestration, orchestration.Pending, now.Add(-2*time.Hour)),
+			fixOrchestration("cluster-pending", orchestration.UpgradeClusterOrchestration, orchestration.Pending, now.Add(-3*time.Hour)),
+		} {
+			err = svc.Insert(o)
+			require.NoError(t, err)
+		}
+
+		// when
+		l, count, totalCount, err := svc.List(dbmodel.OrchestrationFilter{
+			Types:  []string{string(orchestration.UpgradeKymaOrchestration)},
+			States: []string{orchestration.Failed},
+			From:   now.Add(-7 * 24 * time.Hour),
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, l, 1)
+		assert.Equal(t, "kyma-failed-new", l[0].OrchestrationID)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 1, totalCount)
+
+		// when
+		l, count, totalCount, err = svc.List(dbmodel.OrchestrationFilter{
+			To:       now.Add(-90 * time.Minute),
+			PageSize: 2,
+			Page:     1,
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, l, 2)
+		assert.Equal(t, "kyma-failed-old", l[0].OrchestrationID)
+		assert.Equal(t, "kyma-failed-new", l[1].OrchestrationID)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 4, totalCount)
+
+		// when
+		oldest, err := svc.GetOldestPending(orchestration.UpgradeKymaOrchestration)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "kyma-pending-old", oldest.OrchestrationID)
+
+		// when
+		oldest, err = svc.GetOldestPending(orchestration.UpgradeClusterOrchestration)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "cluster-pending", oldest.OrchestrationID)
+
+		// when
+		err = svc.Update(fixOrchestration("cluster-pending", orchestration.UpgradeClusterOrchestration, orchestration.InProgress, now.Add(-3*time.Hour)))
+		require.NoError(t, err)
+		_, err = svc.GetOldestPending(orchestration.UpgradeClusterOrchestration)
+
+		// then
+		assertError(t, dberr.CodeNotFound, err)
+	})
 }
@@ -2,6 +2,7 @@ package postsql
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
 
@@ -68,6 +69,51 @@ func (s *runtimeState) ListByRuntimeID(runtimeID string) ([]internal.RuntimeStat
 	return result, nil
 }
 
+func (s *runtimeState) ListRuntimeIDs() ([]string, error) {
+	sess := s.NewReadSession()
+	var runtimeIDs []string
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		runtimeIDs, lastErr = sess.ListRuntimeStateRuntimeIDs()
+		if lastErr != nil {
+			log.Errorf("while getting runtime IDs of RuntimeStates: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	return runtimeIDs, nil
+}
+
+// DeleteStatesOlderThan deletes states of the runtime created before olderThan except the keepLast newest ones
+// and the one referenced by the last operation of the instance. States are deleted in batches to avoid long locks.
+func (s *runtimeState) DeleteStatesOlderThan(runtimeID string, keepLast int, olderThan time.Time) (int, error) {
+	sess := s.NewWriteSession()
+	total := 0
+	for {
+		deleted := 0
+		var lastErr dberr.Error
+		err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+			deleted, lastErr = sess.DeleteRuntimeStates(runtimeID, keepLast, olderThan, runtimeStatesDeleteBatchSize)
+			if lastErr != nil {
+				log.Errorf("while deleting RuntimeStates for runtime %s: %v", runtimeID, lastErr)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			return total, lastErr
+		}
+
+		total += deleted
+		if deleted < runtimeStatesDeleteBatchSize {
+			return total, nil
+		}
+	}
+}
+
 func (s *runtimeState) GetByOperationID(operationID string) (internal.RuntimeState, error) {
 	sess := s.NewReadSession()
 	state := dbmodel.RuntimeStateDTO{}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/sirupsen/logrus"
@@ -61,4 +62,60 @@ func TestRuntimeState(t *testing.T) {
 		assert.Equal(t, fixID, state.KymaConfig.Version)
 		assert.Equal(t, fixID, state.ClusterConfig.KubernetesVersion)
 	})
+	t.Run("should delete old runtime states", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		instance := fixture.FixInstance("instance-id")
+		err = brokerStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		lastOperation := fixture.FixProvisioningOperation("last-operation-id", instance.InstanceID)
+		err = brokerStorage.Operations().InsertProvisioningOperation(lastOperation)
+		require.NoError(t, err)
+
+		svc := brokerStorage.RuntimeStates()
+
+		now := time.Now()
+		for i, state := range []internal.RuntimeState{
+			{ID: "state-0", OperationID: lastOperation.ID, CreatedAt: now.Add(-100 * time.Hour)},
+			{ID: "state-1", OperationID: "operation-1", CreatedAt: now.Add(-90 * time.Hour)},
+			{ID: "state-2", OperationID: "operation-2", CreatedAt: now.Add(-80 * time.Hour)},
+			{ID: "state-3", OperationID: "operation-3", CreatedAt: now.Add(-70 * time.Hour)},
+			{ID: "state-4", OperationID: "operation-4", CreatedAt: now.Add(-time.Hour)},
+		} {
+			state.RuntimeID = instance.RuntimeID
+			err = svc.Insert(state)
+			require.NoError(t, err, "state %d", i)
+		}
+
+		// when
+		deleted, err := svc.DeleteStatesOlderThan(instance.RuntimeID, 2, now.Add(-24*time.Hour))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		runtimeIDs, err := svc.ListRuntimeIDs()
+		require.NoError(t, err)
+		assert.Equal(t, []string{instance.RuntimeID}, runtimeIDs)
+
+		runtimeStates, err := svc.ListByRuntimeID(instance.RuntimeID)
+		require.NoError(t, err)
+		var ids []string
+		for _, state := range runtimeStates {
+			ids = append(ids, state.ID)
+		}
+		assert.ElementsMatch(t, []string{"state-0", "state-3", "state-4"}, ids)
+	})
 }
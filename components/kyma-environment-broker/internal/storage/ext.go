@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
@@ -13,8 +16,13 @@ type Instances interface {
 	GetByID(instanceID string) (*internal.Instance, error)
 	Insert(instance internal.Instance) error
 	Update(instance internal.Instance) (*internal.Instance, error)
+	// UpdateByOperation updates the instance and records its previous parameters in the history with the ID of the operation making the change
+	UpdateByOperation(instance internal.Instance, operationID string) (*internal.Instance, error)
+	ListParameterHistory(instanceID string, limit int) ([]internal.InstanceParametersHistoryEntry, error)
+	DeleteParameterHistoryOlderThan(olderThan time.Time) (int, error)
 	Delete(instanceID string) error
-	GetInstanceStats() (internal.InstanceStats, error)
+	PurgeDeletedOlderThan(olderThan time.Duration) (int, error)
+	GetInstanceStats(includeSuspended bool) (internal.InstanceStats, error)
 	GetNumberOfInstancesForGlobalAccountID(globalAccountID string) (int, error)
 	List(dbmodel.InstanceFilter) ([]internal.Instance, int, int, error)
 
@@ -31,12 +39,15 @@ type Operations interface {
 	UpgradeCluster
 
 	GetLastOperation(instanceID string) (*internal.Operation, error)
+	GetLastOperationByTypes(instanceID string, types []internal.OperationType) (*internal.Operation, error)
 	GetOperationByID(operationID string) (*internal.Operation, error)
 	GetNotFinishedOperationsByType(operationType internal.OperationType) ([]internal.Operation, error)
 	GetOperationStatsByPlan() (map[string]internal.OperationStats, error)
 	GetOperationsForIDs(operationIDList []string) ([]internal.Operation, error)
 	GetOperationStatsForOrchestration(orchestrationID string) (map[string]int, error)
 	ListOperations(filter dbmodel.OperationFilter) ([]internal.Operation, int, int, error)
+	ListOperationsByStates(states []string, limit, offset int) ([]internal.Operation, error)
+	ListOperationsInTimeRange(from, to time.Time, filter dbmodel.OperationFilter) ([]internal.Operation, error)
 }
 
 type Provisioning interface {
@@ -61,12 +72,29 @@ type Orchestrations interface {
 	Update(orchestration internal.Orchestration) error
 	GetByID(orchestrationID string) (*internal.Orchestration, error)
 	List(filter dbmodel.OrchestrationFilter) ([]internal.Orchestration, int, int, error)
+	// GetOldestPending returns the pending orchestration of the given type which was created first, NotFound error is returned if there is none
+	GetOldestPending(orchestrationType orchestration.Type) (*internal.Orchestration, error)
 }
 
 type RuntimeStates interface {
 	Insert(runtimeState internal.RuntimeState) error
 	GetByOperationID(operationID string) (internal.RuntimeState, error)
 	ListByRuntimeID(runtimeID string) ([]internal.RuntimeState, error)
+	ListRuntimeIDs() ([]string, error)
+	DeleteStatesOlderThan(runtimeID string, keepLast int, olderThan time.Time) (int, error)
+}
+
+type Events interface {
+	InsertEvent(event internal.Event) error
+	ListEvents(filter dbmodel.EventFilter) ([]internal.Event, int, int, error)
+	DeleteEventsOlderThan(olderThan time.Time) (int, error)
+}
+
+// ChangeFeed delivers changes of operations and orchestrations at least once and possibly duplicated,
+// a subscriber receives internal.ResyncChange whenever changes could have been missed
+type ChangeFeed interface {
+	// Subscribe returns the channel with changes and the function which cancels the subscription
+	Subscribe() (<-chan internal.Change, func())
 }
 
 type UpgradeKyma interface {
@@ -1,6 +1,9 @@
 package postsql
 
 import (
+	"context"
+	"time"
+
 	dbr "github.com/gocraft/dbr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -13,6 +16,7 @@ type Factory interface {
 	NewReadSession() ReadSession
 	NewWriteSession() WriteSession
 	NewSessionWithinTransaction() (WriteSessionWithinTransaction, dberr.Error)
+	InTransaction(ctx context.Context, fn func(tx *dbr.Tx) error) error
 }
 
 //go:generate mockery -name=ReadSession
@@ -26,31 +30,49 @@ type ReadSession interface {
 	GetNotFinishedOperationsByType(operationType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
 	GetOperationByTypeAndInstanceID(inID string, opType internal.OperationType) (dbmodel.OperationDTO, dberr.Error)
 	GetOperationsByTypeAndInstanceID(inID string, opType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
+	GetLastOperationByTypes(instanceID string, types []internal.OperationType) (dbmodel.OperationDTO, dberr.Error)
 	GetOperationsForIDs(opIdList []string) ([]dbmodel.OperationDTO, dberr.Error)
 	ListOperations(filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, int, int, error)
+	ListOperationsByStates(states []string, limit, offset int) ([]dbmodel.OperationDTO, dberr.Error)
+	ListOperationsInTimeRange(from, to time.Time, filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, dberr.Error)
 	ListOperationsByType(operationType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
 	GetOperationStats() ([]dbmodel.OperationStatEntry, error)
-	GetInstanceStats() ([]dbmodel.InstanceByGlobalAccountIDStatEntry, error)
+	GetInstanceStats() ([]dbmodel.InstanceStatEntry, error)
 	GetNumberOfInstancesForGlobalAccountID(globalAccountID string) (int, error)
 	GetRuntimeStateByOperationID(operationID string) (dbmodel.RuntimeStateDTO, dberr.Error)
 	ListRuntimeStateByRuntimeID(runtimeID string) ([]dbmodel.RuntimeStateDTO, dberr.Error)
+	ListRuntimeStateRuntimeIDs() ([]string, dberr.Error)
 	GetOrchestrationByID(oID string) (dbmodel.OrchestrationDTO, dberr.Error)
 	ListOrchestrations(filter dbmodel.OrchestrationFilter) ([]dbmodel.OrchestrationDTO, int, int, error)
+	GetOldestPendingOrchestration(orchestrationType string) (dbmodel.OrchestrationDTO, dberr.Error)
 	ListInstances(filter dbmodel.InstanceFilter) ([]dbmodel.InstanceDTO, int, int, error)
 	ListOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, int, int, error)
 	GetOperationStatsForOrchestration(orchestrationID string) ([]dbmodel.OperationStatEntry, error)
+	ListEvents(filter dbmodel.EventFilter) ([]dbmodel.EventDTO, int, int, error)
+	ListInstanceParametersHistory(instanceID string, limit int) ([]dbmodel.InstanceParametersHistoryDTO, dberr.Error)
 }
 
 //go:generate mockery -name=WriteSession
 type WriteSession interface {
 	InsertInstance(instance dbmodel.InstanceDTO) dberr.Error
 	UpdateInstance(instance dbmodel.InstanceDTO) dberr.Error
+	InsertInstanceParametersHistory(instanceID string, version int, operationID string, createdAt time.Time) dberr.Error
+	DeleteInstanceParametersHistory(olderThan time.Time, limit int) (int, dberr.Error)
 	DeleteInstance(instanceID string) dberr.Error
+	PurgeInstances(deletedBefore time.Time, limit int) (int, dberr.Error)
 	InsertOperation(dto dbmodel.OperationDTO) dberr.Error
 	UpdateOperation(dto dbmodel.OperationDTO) dberr.Error
 	InsertOrchestration(o dbmodel.OrchestrationDTO) dberr.Error
 	UpdateOrchestration(o dbmodel.OrchestrationDTO) dberr.Error
 	InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.Error
+	DeleteRuntimeStates(runtimeID string, keepLast int, olderThan time.Time, limit int) (int, dberr.Error)
+	InsertEvent(event dbmodel.EventDTO) dberr.Error
+	DeleteEvents(olderThan time.Time, limit int) (int, dberr.Error)
+	// InsertInstances, InsertOperations and InsertRuntimeStates insert all records with a single statement and return the number of inserted ones,
+	// the records whose IDs already exist are skipped if skipExisting is set, otherwise AlreadyExists is returned and nothing is inserted
+	InsertInstances(instances []dbmodel.InstanceDTO, skipExisting bool) (int, dberr.Error)
+	InsertOperations(operations []dbmodel.OperationDTO, skipExisting bool) (int, dberr.Error)
+	InsertRuntimeStates(states []dbmodel.RuntimeStateDTO, skipExisting bool) (int, dberr.Error)
 }
 
 type Transaction interface {
@@ -99,3 +121,15 @@ func (sf *factory) NewSessionWithinTransaction() (WriteSessionWithinTransaction,
 		transaction: dbTransaction,
 	}, nil
 }
+
+func (sf *factory) InTransaction(ctx context.Context, fn func(tx *dbr.Tx) error) error {
+	return InTransaction(ctx, sf.connection, fn)
+}
+
+// NewWriteSessionWithinTx returns the write session executing statements in the given transaction,
+// which is committed or rolled back by its owner
+func NewWriteSessionWithinTx(tx *dbr.Tx) WriteSession {
+	return writeSession{
+		transaction: tx,
+	}
+}
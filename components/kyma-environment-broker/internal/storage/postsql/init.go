@@ -18,12 +18,16 @@ const (
 	OperationTableName     = "operations"
 	OrchestrationTableName = "orchestrations"
 	RuntimeStateTableName  = "runtime_states"
+	EventTableName         = "events"
 	CreatedAtField         = "created_at"
+
+	InstanceParametersHistoryTableName = "instance_parameters_history"
 )
 
-// InitializeDatabase opens database connection and initializes schema if it does not exist
-func InitializeDatabase(connectionURL string, retries int, log logrus.FieldLogger) (*dbr.Connection, error) {
-	connection, err := WaitForDatabaseAccess(connectionURL, retries, 100*time.Millisecond, log)
+// InitializeDatabase opens database connection and initializes schema if it does not exist.
+// The queries are traced unless tracing is nil.
+func InitializeDatabase(connectionURL string, retries int, tracing *Tracing, log logrus.FieldLogger) (*dbr.Connection, error) {
+	connection, err := waitForDatabaseAccess(connectionURL, retries, 100*time.Millisecond, tracing, log)
 	if err != nil {
 		return nil, err
 	}
@@ -72,10 +76,14 @@ func CheckIfDatabaseInitialized(db *dbr.Connection) (bool, error) {
 }
 
 func WaitForDatabaseAccess(connString string, retryCount int, sleepTime time.Duration, log logrus.FieldLogger) (*dbr.Connection, error) {
+	return waitForDatabaseAccess(connString, retryCount, sleepTime, nil, log)
+}
+
+func waitForDatabaseAccess(connString string, retryCount int, sleepTime time.Duration, tracing *Tracing, log logrus.FieldLogger) (*dbr.Connection, error) {
 	var connection *dbr.Connection
 	var err error
 	for ; retryCount > 0; retryCount-- {
-		connection, err = dbr.Open("postgres", connString, nil)
+		connection, err = OpenConnection(connString, tracing)
 		if err != nil {
 			return nil, errors.Wrap(err, "Invalid connection string")
 		}
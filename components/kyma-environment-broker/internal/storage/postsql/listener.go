@@ -0,0 +1,212 @@
+package postsql
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ChangesChannel is the channel notified by the triggers on operations and orchestrations tables
+	ChangesChannel = "keb_changes"
+
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+
+	subscriberBufferSize = 100
+)
+
+type notificationListener interface {
+	Listen(channel string) error
+	Ping() error
+	NotificationChannel() <-chan *pq.Notification
+	Close() error
+}
+
+// ChangeListener listens for notifications about changes of operations and orchestrations and publishes them
+// to the subscribers. The listener reconnects automatically, while it is disconnected subscribers receive
+// internal.ResyncChange every poll interval so that they can fall back to polling the storage.
+type ChangeListener struct {
+	listener     notificationListener
+	pollInterval time.Duration
+	log          logrus.FieldLogger
+
+	connected int32
+
+	mu          sync.Mutex
+	subscribers map[int]*subscriber
+	nextID      int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type subscriber struct {
+	changes chan internal.Change
+	// lost is set when the buffer of the subscriber was full and a change was dropped
+	lost bool
+}
+
+// NewChangeListener starts listening for changes on the database with the given connection URL
+func NewChangeListener(connectionURL string, pollInterval time.Duration, log logrus.FieldLogger) (*ChangeListener, error) {
+	l := newChangeListener(pollInterval, log)
+
+	listener := pq.NewListener(connectionURL, minReconnectInterval, maxReconnectInterval, l.handleEvent)
+	if err := listener.Listen(ChangesChannel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	l.start(listener)
+
+	return l, nil
+}
+
+func newChangeListener(pollInterval time.Duration, log logrus.FieldLogger) *ChangeListener {
+	return &ChangeListener{
+		pollInterval: pollInterval,
+		log:          log,
+		subscribers:  map[int]*subscriber{},
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+func (l *ChangeListener) start(listener notificationListener) {
+	l.listener = listener
+	go l.run()
+}
+
+// Subscribe returns the channel with changes and the function which cancels the subscription and closes the channel
+func (l *ChangeListener) Subscribe() (<-chan internal.Change, func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id := l.nextID
+	l.nextID++
+	sub := &subscriber{changes: make(chan internal.Change, subscriberBufferSize)}
+	l.subscribers[id] = sub
+
+	var once sync.Once
+	return sub.changes, func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if _, found := l.subscribers[id]; found {
+				delete(l.subscribers, id)
+				close(sub.changes)
+			}
+		})
+	}
+}
+
+// Close stops the listener and closes channels of all subscribers
+func (l *ChangeListener) Close() error {
+	close(l.stop)
+	<-l.done
+	err := l.listener.Close()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for id, sub := range l.subscribers {
+		delete(l.subscribers, id)
+		close(sub.changes)
+	}
+
+	return err
+}
+
+func (l *ChangeListener) handleEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnected:
+		l.log.Info("Listening for database changes")
+		atomic.StoreInt32(&l.connected, 1)
+	case pq.ListenerEventReconnected:
+		l.log.Info("Reconnected to the database, listening for database changes")
+		atomic.StoreInt32(&l.connected, 1)
+	case pq.ListenerEventDisconnected:
+		l.log.Warnf("Disconnected from the database, falling back to polling: %v", err)
+		atomic.StoreInt32(&l.connected, 0)
+	case pq.ListenerEventConnectionAttemptFailed:
+		l.log.Warnf("Failed to connect to the database, falling back to polling: %v", err)
+		atomic.StoreInt32(&l.connected, 0)
+	}
+}
+
+func (l *ChangeListener) isConnected() bool {
+	return atomic.LoadInt32(&l.connected) == 1
+}
+
+func (l *ChangeListener) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case notification := <-l.listener.NotificationChannel():
+			// nil notification is sent after reconnection, notifications sent in the meantime are lost
+			if notification == nil {
+				l.publish(internal.Change{Type: internal.ResyncChange})
+				continue
+			}
+			change := internal.Change{}
+			if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+				l.log.Warnf("Failed to decode database change %q: %s", notification.Extra, err)
+				l.publish(internal.Change{Type: internal.ResyncChange})
+				continue
+			}
+			l.publish(change)
+		case <-ticker.C:
+			if !l.isConnected() {
+				l.publish(internal.Change{Type: internal.ResyncChange})
+				continue
+			}
+			// ping detects broken connection which would not be noticed while no notifications are sent
+			if err := l.listener.Ping(); err != nil {
+				l.log.Warnf("Failed to ping the database: %s", err)
+			}
+			l.publish()
+		}
+	}
+}
+
+// publish sends the changes to all subscribers without blocking, subscribers which missed changes
+// receive internal.ResyncChange as soon as there is space in their buffer
+func (l *ChangeListener) publish(changes ...internal.Change) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, sub := range l.subscribers {
+		if sub.lost {
+			if !trySend(sub.changes, internal.Change{Type: internal.ResyncChange}) {
+				continue
+			}
+			sub.lost = false
+			continue
+		}
+		for _, change := range changes {
+			if !trySend(sub.changes, change) {
+				sub.lost = true
+				break
+			}
+		}
+	}
+}
+
+func trySend(changes chan internal.Change, change internal.Change) bool {
+	select {
+	case changes <- change:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,222 @@
+package postsql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPollInterval = 20 * time.Millisecond
+	receiveTimeout   = time.Second
+)
+
+func TestChangeListener(t *testing.T) {
+	t.Run("should deliver changes in the order of notifications", func(t *testing.T) {
+		// given
+		listener, fake := newTestChangeListener()
+		defer listener.Close()
+
+		changes, cancel := listener.Subscribe()
+		defer cancel()
+
+		// when
+		for i := 0; i < 10; i++ {
+			fake.notify(fmt.Sprintf(`{"type":"operation","id":"op-%d","state":"in progress","orchestrationId":"orch"}`, i))
+		}
+
+		// then
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, internal.Change{
+				Type:            internal.OperationChange,
+				ID:              fmt.Sprintf("op-%d", i),
+				State:           "in progress",
+				OrchestrationID: "orch",
+			}, receive(t, changes))
+		}
+	})
+
+	t.Run("should deliver duplicated notifications to every subscriber", func(t *testing.T) {
+		// given
+		listener, fake := newTestChangeListener()
+		defer listener.Close()
+
+		first, cancelFirst := listener.Subscribe()
+		defer cancelFirst()
+		second, cancelSecond := listener.Subscribe()
+		defer cancelSecond()
+
+		change := internal.Change{Type: internal.OrchestrationChange, ID: "orch", State: "succeeded", OrchestrationID: "orch"}
+
+		// when
+		fake.notify(`{"type":"orchestration","id":"orch","state":"succeeded","orchestrationId":"orch"}`)
+		fake.notify(`{"type":"orchestration","id":"orch","state":"succeeded","orchestrationId":"orch"}`)
+
+		// then
+		for _, changes := range []<-chan internal.Change{first, second} {
+			assert.Equal(t, change, receive(t, changes))
+			assert.Equal(t, change, receive(t, changes))
+		}
+	})
+
+	t.Run("should request resync after reconnection", func(t *testing.T) {
+		// given
+		listener, fake := newTestChangeListener()
+		defer listener.Close()
+
+		changes, cancel := listener.Subscribe()
+		defer cancel()
+
+		// when
+		fake.notifications <- nil
+		fake.notify(`{"type":"operation","id":"op","state":"succeeded"}`)
+
+		// then
+		assert.Equal(t, internal.ResyncChange, receive(t, changes).Type)
+		assert.Equal(t, internal.Change{Type: internal.OperationChange, ID: "op", State: "succeeded"}, receive(t, changes))
+	})
+
+	t.Run("should fall back to polling while disconnected", func(t *testing.T) {
+		// given
+		listener, fake := newTestChangeListener()
+		defer listener.Close()
+
+		changes, cancel := listener.Subscribe()
+		defer cancel()
+
+		// when
+		listener.handleEvent(pq.ListenerEventConnectionAttemptFailed, fmt.Errorf("connection refused"))
+
+		// then
+		assert.Equal(t, internal.ResyncChange, receive(t, changes).Type)
+		assert.Equal(t, internal.ResyncChange, receive(t, changes).Type)
+
+		// when
+		listener.handleEvent(pq.ListenerEventReconnected, nil)
+		fake.notify(`{"type":"operation","id":"op","state":"failed"}`)
+
+		// then
+		assert.Equal(t, internal.OperationChange, receiveSkippingResync(t, changes).Type)
+		assert.Eventually(t, func() bool { return fake.pings() > 0 }, receiveTimeout, testPollInterval)
+	})
+
+	t.Run("should request resync when subscriber missed changes", func(t *testing.T) {
+		// given
+		listener, fake := newTestChangeListener()
+		defer listener.Close()
+
+		changes, cancel := listener.Subscribe()
+		defer cancel()
+
+		// when
+		for i := 0; i < subscriberBufferSize+1; i++ {
+			fake.notify(fmt.Sprintf(`{"type":"operation","id":"op-%d","state":"succeeded"}`, i))
+		}
+
+		// then
+		for i := 0; i < subscriberBufferSize; i++ {
+			assert.Equal(t, fmt.Sprintf("op-%d", i), receive(t, changes).ID)
+		}
+		assert.Equal(t, internal.ResyncChange, receive(t, changes).Type)
+	})
+
+	t.Run("should request resync when notification cannot be decoded", func(t *testing.T) {
+		// given
+		listener, fake := newTestChangeListener()
+		defer listener.Close()
+
+		changes, cancel := listener.Subscribe()
+		defer cancel()
+
+		// when
+		fake.notify("not a json")
+
+		// then
+		assert.Equal(t, internal.ResyncChange, receive(t, changes).Type)
+	})
+
+	t.Run("should close channel when subscription is cancelled", func(t *testing.T) {
+		// given
+		listener, _ := newTestChangeListener()
+		defer listener.Close()
+
+		changes, cancel := listener.Subscribe()
+
+		// when
+		cancel()
+		cancel()
+
+		// then
+		_, open := <-changes
+		assert.False(t, open)
+	})
+}
+
+type fakeNotificationListener struct {
+	notifications chan *pq.Notification
+	pingCount     chan struct{}
+}
+
+func newTestChangeListener() (*ChangeListener, *fakeNotificationListener) {
+	fake := &fakeNotificationListener{
+		notifications: make(chan *pq.Notification),
+		pingCount:     make(chan struct{}, 1000),
+	}
+
+	listener := newChangeListener(testPollInterval, logrus.New())
+	listener.handleEvent(pq.ListenerEventConnected, nil)
+	listener.start(fake)
+
+	return listener, fake
+}
+
+func (f *fakeNotificationListener) notify(extra string) {
+	f.notifications <- &pq.Notification{Channel: ChangesChannel, Extra: extra}
+}
+
+func (f *fakeNotificationListener) pings() int {
+	return len(f.pingCount)
+}
+
+func (f *fakeNotificationListener) Listen(string) error {
+	return nil
+}
+
+func (f *fakeNotificationListener) Ping() error {
+	f.pingCount <- struct{}{}
+	return nil
+}
+
+func (f *fakeNotificationListener) NotificationChannel() <-chan *pq.Notification {
+	return f.notifications
+}
+
+func (f *fakeNotificationListener) Close() error {
+	return nil
+}
+
+func receive(t *testing.T, changes <-chan internal.Change) internal.Change {
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(receiveTimeout):
+		require.FailNow(t, "change was not received")
+		return internal.Change{}
+	}
+}
+
+// receiveSkippingResync skips resync changes which could have been published before the listener reconnected
+func receiveSkippingResync(t *testing.T, changes <-chan internal.Change) internal.Change {
+	for {
+		if change := receive(t, changes); change.Type != internal.ResyncChange {
+			return change
+		}
+	}
+}
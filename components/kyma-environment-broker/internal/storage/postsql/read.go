@@ -3,6 +3,7 @@ package postsql
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -28,6 +29,7 @@ func (r readSession) getInstancesJoinedWithOperationStatement() *dbr.SelectStmt
 			" instances.updated_at, instances.deleted_at, instances.sub_account_id, instances.service_name, instances.service_plan_name,"+
 			" instances.provider_region, instances.provider, operations.state, operations.description, operations.type, operations.created_at AS operation_created_at, operations.data").
 		From(InstancesTableName).
+		Where(instanceNotDeleted()).
 		LeftJoin(OperationTableName, join)
 	return stmt
 }
@@ -54,6 +56,7 @@ func (r readSession) GetInstanceByID(instanceID string) (dbmodel.InstanceDTO, db
 		Select("*").
 		From(InstancesTableName).
 		Where(dbr.Eq("instance_id", instanceID)).
+		Where(instanceNotDeleted()).
 		LoadOne(&instance)
 
 	if err != nil {
@@ -73,6 +76,7 @@ func (r readSession) FindAllInstancesForRuntimes(runtimeIdList []string) ([]dbmo
 		Select("*").
 		From(InstancesTableName).
 		Where("runtime_id IN ?", runtimeIdList).
+		Where(instanceNotDeleted()).
 		LoadOne(&instances)
 
 	if err != nil {
@@ -91,6 +95,7 @@ func (r readSession) FindAllInstancesForSubAccounts(subAccountslist []string) ([
 		Select("*").
 		From(InstancesTableName).
 		Where("sub_account_id IN ?", subAccountslist).
+		Where(instanceNotDeleted()).
 		LoadOne(&instances)
 
 	if err != nil {
@@ -160,6 +165,83 @@ func (r readSession) ListOperations(filter dbmodel.OperationFilter) ([]dbmodel.O
 		nil
 }
 
+// GetLastOperationByTypes returns the newest operation of the instance with one of the types, operations created at the same time
+// are ordered by ID
+func (r readSession) GetLastOperationByTypes(instanceID string, types []internal.OperationType) (dbmodel.OperationDTO, dberr.Error) {
+	typeNames := make([]string, 0, len(types))
+	for _, opType := range types {
+		typeNames = append(typeNames, string(opType))
+	}
+	condition := dbr.And(dbr.Eq("instance_id", instanceID), dbr.Eq("type", typeNames))
+	operation, err := r.getLastOperation(condition)
+	if err != nil {
+		switch {
+		case dberr.IsNotFound(err):
+			return dbmodel.OperationDTO{}, dberr.NotFound("for instance ID: %s and types %v %s", instanceID, types, err)
+		default:
+			return dbmodel.OperationDTO{}, err
+		}
+	}
+	return operation, nil
+}
+
+// ListOperationsByStates returns at most limit operations in one of the states starting from the offset, ordered by creation time and ID.
+// All operations starting from the offset are returned when the limit is not positive, operations in any state when no states are given
+func (r readSession) ListOperationsByStates(states []string, limit, offset int) ([]dbmodel.OperationDTO, dberr.Error) {
+	var operations []dbmodel.OperationDTO
+
+	stmt := r.session.Select("*").
+		From(OperationTableName).
+		OrderBy(CreatedAtField).
+		OrderBy("id")
+	if len(states) > 0 {
+		stmt.Where("state IN ?", states)
+	}
+	if limit > 0 {
+		stmt.Limit(uint64(limit))
+	}
+	if offset > 0 {
+		stmt.Offset(uint64(offset))
+	}
+
+	_, err := stmt.Load(&operations)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations: %s", err)
+	}
+	return operations, nil
+}
+
+// ListOperationsInTimeRange returns the operations created from the time (inclusive) to the time (exclusive) which match the filter,
+// ordered by creation time and ID. The zero time means no limit
+func (r readSession) ListOperationsInTimeRange(from, to time.Time, filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, dberr.Error) {
+	var operations []dbmodel.OperationDTO
+
+	stmt := r.session.Select("*").
+		From(OperationTableName).
+		OrderBy(CreatedAtField).
+		OrderBy("id")
+	if !from.IsZero() {
+		stmt.Where("created_at >= ?", from)
+	}
+	if !to.IsZero() {
+		stmt.Where("created_at < ?", to)
+	}
+
+	// Add pagination if provided
+	if filter.Page > 0 && filter.PageSize > 0 {
+		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
+	}
+
+	// Apply filtering if provided
+	addOperationFilters(stmt, filter)
+
+	_, err := stmt.Load(&operations)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations: %s", err)
+	}
+	return operations, nil
+}
+
 func (r readSession) GetOrchestrationByID(oID string) (dbmodel.OrchestrationDTO, dberr.Error) {
 	condition := dbr.Eq("orchestration_id", oID)
 	operation, err := r.getOrchestration(condition)
@@ -190,6 +272,9 @@ func (r readSession) ListOrchestrations(filter dbmodel.OrchestrationFilter) ([]d
 	addOrchestrationFilters(stmt, filter)
 
 	_, err := stmt.Load(&orchestrations)
+	if err != nil {
+		return nil, -1, -1, dberr.Internal("Failed to get orchestrations: %s", err)
+	}
 
 	totalCount, err := r.getOrchestrationCount(filter)
 	if err != nil {
@@ -202,6 +287,26 @@ func (r readSession) ListOrchestrations(filter dbmodel.OrchestrationFilter) ([]d
 		nil
 }
 
+func (r readSession) GetOldestPendingOrchestration(orchestrationType string) (dbmodel.OrchestrationDTO, dberr.Error) {
+	var dto dbmodel.OrchestrationDTO
+
+	err := r.session.
+		Select("*").
+		From(OrchestrationTableName).
+		Where(dbr.Eq("type", orchestrationType)).
+		Where(dbr.Eq("state", orchestration.Pending)).
+		OrderBy(CreatedAtField).
+		Limit(1).
+		LoadOne(&dto)
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.OrchestrationDTO{}, dberr.NotFound("cannot find pending %s orchestration: %s", orchestrationType, err)
+		}
+		return dbmodel.OrchestrationDTO{}, dberr.Internal("Failed to get pending %s orchestration: %s", orchestrationType, err)
+	}
+	return dto, nil
+}
+
 func (r readSession) GetNotFinishedOperationsByType(operationType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error) {
 	stateInProgress := dbr.Eq("state", domain.InProgress)
 	statePending := dbr.Eq("state", orchestration.Pending)
@@ -358,6 +463,69 @@ func (r readSession) ListRuntimeStateByRuntimeID(runtimeID string) ([]dbmodel.Ru
 	return states, nil
 }
 
+func (r readSession) ListRuntimeStateRuntimeIDs() ([]string, dberr.Error) {
+	var runtimeIDs []string
+
+	_, err := r.session.
+		Select("runtime_id").
+		Distinct().
+		From(RuntimeStateTableName).
+		Load(&runtimeIDs)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get runtime IDs of states: %s", err)
+	}
+	return runtimeIDs, nil
+}
+
+func (r readSession) ListEvents(filter dbmodel.EventFilter) ([]dbmodel.EventDTO, int, int, error) {
+	var events []dbmodel.EventDTO
+
+	stmt := r.session.Select("*").
+		From(EventTableName).
+		OrderBy(CreatedAtField)
+
+	// Add pagination if provided
+	if filter.Page > 0 && filter.PageSize > 0 {
+		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
+	}
+
+	// Apply filtering if provided
+	addEventFilters(stmt, filter)
+
+	_, err := stmt.Load(&events)
+	if err != nil {
+		return nil, -1, -1, dberr.Internal("Failed to get events: %s", err)
+	}
+
+	totalCount, err := r.getEventCount(filter)
+	if err != nil {
+		return nil, -1, -1, dberr.Internal("Failed to count events: %s", err)
+	}
+
+	return events,
+		len(events),
+		totalCount,
+		nil
+}
+
+// ListInstanceParametersHistory returns at most limit newest history entries of the instance, starting from the newest one
+func (r readSession) ListInstanceParametersHistory(instanceID string, limit int) ([]dbmodel.InstanceParametersHistoryDTO, dberr.Error) {
+	var entries []dbmodel.InstanceParametersHistoryDTO
+
+	_, err := r.session.Select("*").
+		From(InstanceParametersHistoryTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		OrderDesc(CreatedAtField).
+		OrderDesc("id").
+		Limit(uint64(limit)).
+		Load(&entries)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get instance parameters history: %s", err)
+	}
+
+	return entries, nil
+}
+
 func (r readSession) getOperation(condition dbr.Builder) (dbmodel.OperationDTO, dberr.Error) {
 	var operation dbmodel.OperationDTO
 
@@ -384,6 +552,7 @@ func (r readSession) getLastOperation(condition dbr.Builder) (dbmodel.OperationD
 		From(OperationTableName).
 		Where(condition).
 		OrderDesc(CreatedAtField).
+		OrderDesc("id").
 		Limit(1).
 		Load(&operation)
 	if err != nil {
@@ -431,10 +600,14 @@ func (r readSession) GetOperationStatsForOrchestration(orchestrationID string) (
 	return rows, err
 }
 
-func (r readSession) GetInstanceStats() ([]dbmodel.InstanceByGlobalAccountIDStatEntry, error) {
-	var rows []dbmodel.InstanceByGlobalAccountIDStatEntry
-	_, err := r.session.SelectBySql(fmt.Sprintf("select global_account_id, count(*) as total from %s group by global_account_id",
-		InstancesTableName)).Load(&rows)
+func (r readSession) GetInstanceStats() ([]dbmodel.InstanceStatEntry, error) {
+	var rows []dbmodel.InstanceStatEntry
+	_, err := r.session.SelectBySql(fmt.Sprintf(`select global_account_id, service_plan_name, provider_region,
+		coalesce(provisioning_parameters::json -> 'ers_context' ->> 'license_type', '') as license_type,
+		not coalesce((provisioning_parameters::json -> 'ers_context' ->> 'active')::boolean, true) as suspended,
+		count(*) as total
+		from %s where deleted_at = ? group by global_account_id, service_plan_name, provider_region, license_type, suspended`,
+		InstancesTableName), time.Time{}).Load(&rows)
 	return rows, err
 }
 
@@ -445,6 +618,7 @@ func (r readSession) GetNumberOfInstancesForGlobalAccountID(globalAccountID stri
 	err := r.session.Select("count(*) as total").
 		From(InstancesTableName).
 		Where(dbr.Eq("global_account_id", globalAccountID)).
+		Where(instanceNotDeleted()).
 		LoadOne(&res)
 
 	return res.Total, err
@@ -568,6 +742,9 @@ func buildInstanceStateFilters(table string, filter dbmodel.InstanceFilter) dbr.
 }
 
 func addInstanceFilters(stmt *dbr.SelectStmt, filter dbmodel.InstanceFilter) {
+	if !filter.IncludeDeleted {
+		stmt.Where(instanceNotDeleted())
+	}
 	if len(filter.GlobalAccountIDs) > 0 {
 		stmt.Where("instances.global_account_id IN ?", filter.GlobalAccountIDs)
 	}
@@ -595,6 +772,11 @@ func addInstanceFilters(stmt *dbr.SelectStmt, filter dbmodel.InstanceFilter) {
 	}
 }
 
+// instanceNotDeleted matches instances which are not soft deleted, their deleted_at column holds the zero time
+func instanceNotDeleted() dbr.Builder {
+	return dbr.Eq(fmt.Sprintf("%s.deleted_at", InstancesTableName), time.Time{})
+}
+
 func addOrchestrationFilters(stmt *dbr.SelectStmt, filter dbmodel.OrchestrationFilter) {
 	if len(filter.Types) > 0 {
 		stmt.Where("type IN ?", filter.Types)
@@ -602,14 +784,41 @@ func addOrchestrationFilters(stmt *dbr.SelectStmt, filter dbmodel.OrchestrationF
 	if len(filter.States) > 0 {
 		stmt.Where("state IN ?", filter.States)
 	}
+	if !filter.From.IsZero() {
+		stmt.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		stmt.Where("created_at < ?", filter.To)
+	}
 }
 
 func addOperationFilters(stmt *dbr.SelectStmt, filter dbmodel.OperationFilter) {
+	if len(filter.Types) > 0 {
+		stmt.Where("type IN ?", filter.Types)
+	}
 	if len(filter.States) > 0 {
 		stmt.Where("state IN ?", filter.States)
 	}
 }
 
+func addEventFilters(stmt *dbr.SelectStmt, filter dbmodel.EventFilter) {
+	if len(filter.InstanceIDs) > 0 {
+		stmt.Where("instance_id IN ?", filter.InstanceIDs)
+	}
+	if len(filter.OperationIDs) > 0 {
+		stmt.Where("operation_id IN ?", filter.OperationIDs)
+	}
+	if len(filter.Levels) > 0 {
+		stmt.Where("level IN ?", filter.Levels)
+	}
+	if !filter.From.IsZero() {
+		stmt.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		stmt.Where("created_at < ?", filter.To)
+	}
+}
+
 func (r readSession) getOperationCount(filter dbmodel.OperationFilter) (int, error) {
 	var res struct {
 		Total int
@@ -645,3 +854,14 @@ func (r readSession) getOrchestrationCount(filter dbmodel.OrchestrationFilter) (
 
 	return res.Total, err
 }
+
+func (r readSession) getEventCount(filter dbmodel.EventFilter) (int, error) {
+	var res struct {
+		Total int
+	}
+	stmt := r.session.Select("count(*) as total").From(EventTableName)
+	addEventFilters(stmt, filter)
+	err := stmt.LoadOne(&res)
+
+	return res.Total, err
+}
@@ -0,0 +1,287 @@
+package postsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/gocraft/dbr/dialect"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	rowsAffectedKey       = attribute.Key("db.rows_affected")
+	rowsReturnedKey       = attribute.Key("db.rows_returned")
+	slowQueryKey          = attribute.Key("db.slow_query")
+	slowQueryThresholdKey = attribute.Key("db.slow_query_threshold_ms")
+)
+
+// Tracing reports the queries and the transactions as OpenTelemetry spans.
+// Queries taking longer than SlowQueryThreshold are marked with the db.slow_query attribute.
+type Tracing struct {
+	Tracer             trace.Tracer
+	SlowQueryThreshold time.Duration
+}
+
+// OpenConnection opens the database connection, which is not traced if tracing is nil
+func OpenConnection(connectionURL string, tracing *Tracing) (*dbr.Connection, error) {
+	if tracing == nil {
+		return dbr.Open("postgres", connectionURL, nil)
+	}
+
+	connector, err := pq.NewConnector(connectionURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTracedConnection(connector, tracing), nil
+}
+
+func newTracedConnection(connector driver.Connector, tracing *Tracing) *dbr.Connection {
+	return &dbr.Connection{
+		DB:            sql.OpenDB(&tracedConnector{Connector: connector, tracing: tracing}),
+		Dialect:       dialect.PostgreSQL,
+		EventReceiver: &TracingEventReceiver{tracing: tracing},
+	}
+}
+
+// TracingEventReceiver starts a span for each query executed by dbr
+type TracingEventReceiver struct {
+	dbr.NullEventReceiver
+	tracing *Tracing
+}
+
+type querySpanKey struct{}
+
+// querySpan is ended by dbr once the query is executed, unless its rows are read,
+// in which case it is ended when the rows are closed
+type querySpan struct {
+	span      trace.Span
+	startTime time.Time
+	threshold time.Duration
+
+	mutex       sync.Mutex
+	rowsPending bool
+	ended       bool
+}
+
+func (r *TracingEventReceiver) SpanStart(ctx context.Context, eventName, query string) context.Context {
+	operation := statementOperation(query)
+	ctx, span := r.tracing.Tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+		semconv.DBStatementKey.String(query),
+		semconv.DBOperationKey.String(operation),
+		attribute.String("dbr.event", eventName),
+	))
+
+	return context.WithValue(ctx, querySpanKey{}, &querySpan{
+		span:      span,
+		startTime: time.Now(),
+		threshold: r.tracing.SlowQueryThreshold,
+	})
+}
+
+func (r *TracingEventReceiver) SpanError(ctx context.Context, err error) {
+	if qs := querySpanFrom(ctx); qs != nil {
+		qs.span.RecordError(err)
+		qs.span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func (r *TracingEventReceiver) SpanFinish(ctx context.Context) {
+	qs := querySpanFrom(ctx)
+	if qs == nil {
+		return
+	}
+
+	qs.mutex.Lock()
+	rowsPending := qs.rowsPending
+	qs.mutex.Unlock()
+	if !rowsPending {
+		qs.end()
+	}
+}
+
+func (qs *querySpan) end() {
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+	if qs.ended {
+		return
+	}
+	qs.ended = true
+
+	if qs.threshold > 0 {
+		qs.span.SetAttributes(
+			slowQueryKey.Bool(time.Since(qs.startTime) > qs.threshold),
+			slowQueryThresholdKey.Int64(qs.threshold.Milliseconds()),
+		)
+	}
+	qs.span.End()
+}
+
+func querySpanFrom(ctx context.Context) *querySpan {
+	qs, _ := ctx.Value(querySpanKey{}).(*querySpan)
+	return qs
+}
+
+// statementOperation returns the SQL command of the query, e.g. SELECT, which is used as the name of the span
+func statementOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+// tracedConnector wraps the connections of the Postgres driver to record the number of rows of the traced queries
+// and to start spans for the transactions, which are not traced by dbr
+type tracedConnector struct {
+	driver.Connector
+	tracing *Tracing
+}
+
+func (c *tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedConn{Conn: conn, tracing: c.tracing}, nil
+}
+
+type tracedConn struct {
+	driver.Conn
+	tracing *Tracing
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	result, err := execer.ExecContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if qs := querySpanFrom(ctx); qs != nil {
+		if rows, err := result.RowsAffected(); err == nil {
+			qs.span.SetAttributes(rowsAffectedKey.Int64(rows))
+		}
+	}
+
+	return result, nil
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	qs := querySpanFrom(ctx)
+	if qs == nil {
+		return rows, nil
+	}
+
+	qs.mutex.Lock()
+	qs.rowsPending = true
+	qs.mutex.Unlock()
+
+	return &tracedRows{Rows: rows, span: qs}, nil
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+// BeginTx starts the span of the transaction begin, the commit and the rollback are reported as its siblings,
+// so the time the transaction waited for the locks is visible
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	_, span := c.tracing.Tracer.Start(ctx, "BEGIN", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(semconv.DBSystemPostgreSQL))
+	defer span.End()
+
+	var tx driver.Tx
+	var err error
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = beginner.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.Conn.Begin() // nolint:staticcheck
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &tracedTx{Tx: tx, ctx: ctx, tracer: c.tracing.Tracer}, nil
+}
+
+type tracedTx struct {
+	driver.Tx
+	ctx    context.Context
+	tracer trace.Tracer
+}
+
+func (tx *tracedTx) Commit() error {
+	return tx.traced("COMMIT", tx.Tx.Commit)
+}
+
+func (tx *tracedTx) Rollback() error {
+	return tx.traced("ROLLBACK", tx.Tx.Rollback)
+}
+
+func (tx *tracedTx) traced(name string, fn func() error) error {
+	_, span := tx.tracer.Start(tx.ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(semconv.DBSystemPostgreSQL))
+	defer span.End()
+
+	err := fn()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+type tracedRows struct {
+	driver.Rows
+	span  *querySpan
+	count int64
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.count++
+	}
+
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	r.span.span.SetAttributes(rowsReturnedKey.Int64(r.count))
+	r.span.end()
+
+	return err
+}
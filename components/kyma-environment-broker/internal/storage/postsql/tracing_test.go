@@ -0,0 +1,140 @@
+package postsql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	t.Run("should trace query with returned rows", func(t *testing.T) {
+		// given
+		recorder, connection := newTracedFakeConnection(&fakeDriver{rows: []string{"a", "b", "c"}}, time.Hour)
+		var ids []string
+
+		// when
+		_, err := connection.NewSession(nil).Select("id").From(OperationTableName).Load(&ids)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, ids, 3)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "SELECT", spans[0].Name())
+		attributes := attributesOf(spans[0])
+		assert.Equal(t, "postgresql", attributes["db.system"].AsString())
+		assert.Equal(t, "SELECT id FROM operations", attributes["db.statement"].AsString())
+		assert.Equal(t, int64(3), attributes["db.rows_returned"].AsInt64())
+		assert.False(t, attributes["db.slow_query"].AsBool())
+		assert.Equal(t, int64(time.Hour.Milliseconds()), attributes["db.slow_query_threshold_ms"].AsInt64())
+	})
+
+	t.Run("should trace statement with affected rows", func(t *testing.T) {
+		// given
+		recorder, connection := newTracedFakeConnection(&fakeDriver{}, time.Hour)
+
+		// when
+		_, err := connection.NewSession(nil).Update(OperationTableName).Set("state", "succeeded").Exec()
+
+		// then
+		require.NoError(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "UPDATE", spans[0].Name())
+		assert.Equal(t, int64(1), attributesOf(spans[0])["db.rows_affected"].AsInt64())
+	})
+
+	t.Run("should mark slow query", func(t *testing.T) {
+		// given
+		recorder, connection := newTracedFakeConnection(&fakeDriver{}, time.Nanosecond)
+
+		// when
+		_, err := connection.NewSession(nil).Update(OperationTableName).Set("state", "succeeded").Exec()
+
+		// then
+		require.NoError(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.True(t, attributesOf(spans[0])["db.slow_query"].AsBool())
+	})
+
+	t.Run("should set error status of failed query", func(t *testing.T) {
+		// given
+		recorder, connection := newTracedFakeConnection(&fakeDriver{failures: 1, failureCode: "23505"}, time.Hour)
+
+		// when
+		_, err := connection.NewSession(nil).Update(OperationTableName).Set("state", "succeeded").Exec()
+
+		// then
+		require.Error(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Len(t, spans[0].Events(), 1)
+	})
+
+	t.Run("should trace transaction begin and commit", func(t *testing.T) {
+		// given
+		recorder, connection := newTracedFakeConnection(&fakeDriver{}, time.Hour)
+
+		// when
+		err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+			_, err := tx.Update(OperationTableName).Set("state", "succeeded").Exec()
+			return err
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BEGIN", "UPDATE", "COMMIT"}, spanNames(recorder.Ended()))
+	})
+
+	t.Run("should trace transaction rollback", func(t *testing.T) {
+		// given
+		recorder, connection := newTracedFakeConnection(&fakeDriver{failures: 1, failureCode: pq.ErrorCode("23505")}, time.Hour)
+
+		// when
+		err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+			_, err := tx.Update(OperationTableName).Set("state", "succeeded").Exec()
+			return err
+		})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, []string{"BEGIN", "UPDATE", "ROLLBACK"}, spanNames(recorder.Ended()))
+	})
+}
+
+func newTracedFakeConnection(fake *fakeDriver, slowQueryThreshold time.Duration) (*tracetest.SpanRecorder, *dbr.Connection) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return recorder, newTracedConnection(fake, &Tracing{
+		Tracer:             provider.Tracer("test"),
+		SlowQueryThreshold: slowQueryThreshold,
+	})
+}
+
+func attributesOf(span sdktrace.ReadOnlySpan) map[string]attribute.Value {
+	attributes := make(map[string]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attributes[string(kv.Key)] = kv.Value
+	}
+	return attributes
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	return names
+}
@@ -0,0 +1,78 @@
+package postsql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	SerializationFailureError = "40001"
+	DeadlockDetectedError     = "40P01"
+)
+
+var transactionBackoff = wait.Backoff{
+	Duration: 50 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    5,
+}
+
+// InTransaction begins the transaction, invokes fn and commits the transaction.
+// The transaction is retried with backoff when Postgres aborts it because of a serialization failure or a deadlock,
+// in all other cases it is rolled back and the error returned by fn is returned unchanged.
+func InTransaction(ctx context.Context, connection *dbr.Connection, fn func(tx *dbr.Tx) error) error {
+	return inTransaction(ctx, connection, transactionBackoff, fn)
+}
+
+func inTransaction(ctx context.Context, connection *dbr.Connection, backoff wait.Backoff, fn func(tx *dbr.Tx) error) error {
+	var lastErr error
+	_ = wait.ExponentialBackoff(backoff, func() (bool, error) {
+		lastErr = runTransaction(ctx, connection, fn)
+		if lastErr == nil {
+			return true, nil
+		}
+		if !IsRetryableTransactionError(lastErr) || ctx.Err() != nil {
+			return false, lastErr
+		}
+		log.Warnf("Retrying transaction: %s", lastErr)
+		return false, nil
+	})
+	return lastErr
+}
+
+func runTransaction(ctx context.Context, connection *dbr.Connection, fn func(tx *dbr.Tx) error) error {
+	tx, err := connection.NewSession(nil).BeginTx(ctx, nil)
+	if err != nil {
+		return dberr.Internal("Failed to start transaction: %s", err)
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dberr.InternalWithCause(err, "Failed to commit transaction: %s", err)
+	}
+
+	return nil
+}
+
+// IsRetryableTransactionError returns true if the transaction failed because of a serialization failure or a deadlock
+func IsRetryableTransactionError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == SerializationFailureError || pqErr.Code == DeadlockDetectedError
+}
@@ -0,0 +1,268 @@
+package postsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/gocraft/dbr/dialect"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var testTransactionBackoff = wait.Backoff{
+	Duration: time.Millisecond,
+	Factor:   1,
+	Steps:    3,
+}
+
+func TestInTransaction(t *testing.T) {
+	t.Run("should commit transaction", func(t *testing.T) {
+		// given
+		fake := &fakeDriver{}
+		connection := fake.connection()
+
+		// when
+		err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+			_, err := tx.Update(OperationTableName).Set("state", "succeeded").Exec()
+			return err
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, fake.stats().commits)
+		assert.Equal(t, 0, fake.stats().rollbacks)
+	})
+
+	for _, code := range []pq.ErrorCode{SerializationFailureError, DeadlockDetectedError} {
+		t.Run("should retry transaction on error "+string(code), func(t *testing.T) {
+			// given
+			fake := &fakeDriver{failures: 2, failureCode: code}
+			connection := fake.connection()
+			calls := 0
+
+			// when
+			err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+				calls++
+				_, err := tx.Update(OperationTableName).Set("state", "succeeded").Exec()
+				return err
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, 3, calls)
+			assert.Equal(t, 1, fake.stats().commits)
+			assert.Equal(t, 2, fake.stats().rollbacks)
+		})
+	}
+
+	t.Run("should retry transaction when the operation update returns serialization failure", func(t *testing.T) {
+		// given
+		fake := &fakeDriver{failures: 1, failureCode: SerializationFailureError}
+		connection := fake.connection()
+
+		// when
+		err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+			return NewWriteSessionWithinTx(tx).UpdateOperation(dbmodel.OperationDTO{ID: "op-id"})
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, fake.stats().commits)
+		assert.Equal(t, 1, fake.stats().rollbacks)
+	})
+
+	t.Run("should return serialization failure when retries are exhausted", func(t *testing.T) {
+		// given
+		fake := &fakeDriver{failures: 10, failureCode: SerializationFailureError}
+		connection := fake.connection()
+		calls := 0
+
+		// when
+		err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+			calls++
+			_, err := tx.Update(OperationTableName).Set("state", "succeeded").Exec()
+			return err
+		})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, IsRetryableTransactionError(err))
+		assert.Equal(t, testTransactionBackoff.Steps, calls)
+		assert.Equal(t, 0, fake.stats().commits)
+		assert.Equal(t, testTransactionBackoff.Steps, fake.stats().rollbacks)
+	})
+
+	t.Run("should roll back without retry on other errors", func(t *testing.T) {
+		// given
+		fake := &fakeDriver{}
+		connection := fake.connection()
+		conflict := dberr.Conflict("conflict")
+		calls := 0
+
+		// when
+		err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+			calls++
+			return conflict
+		})
+
+		// then
+		assert.Equal(t, conflict, err)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 0, fake.stats().commits)
+		assert.Equal(t, 1, fake.stats().rollbacks)
+	})
+
+	t.Run("should roll back without retry on other Postgres errors", func(t *testing.T) {
+		// given
+		fake := &fakeDriver{failures: 1, failureCode: "23505"}
+		connection := fake.connection()
+		calls := 0
+
+		// when
+		err := inTransaction(context.Background(), connection, testTransactionBackoff, func(tx *dbr.Tx) error {
+			calls++
+			_, err := tx.Update(OperationTableName).Set("state", "succeeded").Exec()
+			return err
+		})
+
+		// then
+		require.Error(t, err)
+		assert.False(t, IsRetryableTransactionError(err))
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 1, fake.stats().rollbacks)
+	})
+}
+
+func TestIsRetryableTransactionError(t *testing.T) {
+	assert.True(t, IsRetryableTransactionError(&pq.Error{Code: SerializationFailureError}))
+	assert.True(t, IsRetryableTransactionError(&pq.Error{Code: DeadlockDetectedError}))
+	assert.True(t, IsRetryableTransactionError(dberr.InternalWithCause(&pq.Error{Code: SerializationFailureError}, "error").Append("while updating")))
+	assert.False(t, IsRetryableTransactionError(&pq.Error{Code: "23505"}))
+	assert.False(t, IsRetryableTransactionError(dberr.Internal("pq: could not serialize access")))
+	assert.False(t, IsRetryableTransactionError(errors.New("error")))
+}
+
+// fakeDriver fails the given number of statements with the Postgres error code
+type fakeDriver struct {
+	mu          sync.Mutex
+	failures    int
+	failureCode pq.ErrorCode
+	rows        []string
+	commits     int
+	rollbacks   int
+}
+
+type fakeDriverStats struct {
+	commits   int
+	rollbacks int
+}
+
+func (d *fakeDriver) connection() *dbr.Connection {
+	return &dbr.Connection{
+		DB:            sql.OpenDB(d),
+		Dialect:       dialect.PostgreSQL,
+		EventReceiver: &dbr.NullEventReceiver{},
+	}
+}
+
+func (d *fakeDriver) stats() fakeDriverStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return fakeDriverStats{commits: d.commits, rollbacks: d.rollbacks}
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver {
+	return nil
+}
+
+func (d *fakeDriver) exec() (driver.Result, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.failures > 0 {
+		d.failures--
+		return nil, &pq.Error{Code: d.failureCode}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return &fakeTx{driver: c.driver}, nil
+}
+
+func (c *fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return c.driver.exec()
+}
+
+func (c *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if _, err := c.driver.exec(); err != nil {
+		return nil, err
+	}
+	return &fakeRows{values: c.driver.rows}, nil
+}
+
+// fakeRows returns single column rows with the given values
+type fakeRows struct {
+	values []string
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"id"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+	return nil
+}
+
+type fakeTx struct {
+	driver *fakeDriver
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.driver.mu.Lock()
+	defer tx.driver.mu.Unlock()
+	tx.driver.commits++
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.driver.mu.Lock()
+	defer tx.driver.mu.Unlock()
+	tx.driver.rollbacks++
+	return nil
+}
@@ -1,8 +1,12 @@
 package postsql
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 
 	"github.com/gocraft/dbr"
@@ -50,9 +54,12 @@ func (ws writeSession) InsertInstance(instance dbmodel.InstanceDTO) dberr.Error
 	return nil
 }
 
+// DeleteInstance marks the instance as deleted by setting its deleted_at column. The record is kept until it is purged.
 func (ws writeSession) DeleteInstance(instanceID string) dberr.Error {
-	_, err := ws.deleteFrom(InstancesTableName).
+	_, err := ws.update(InstancesTableName).
 		Where(dbr.Eq("instance_id", instanceID)).
+		Where(instanceNotDeleted()).
+		Set("deleted_at", time.Now()).
 		Exec()
 
 	if err != nil {
@@ -61,10 +68,28 @@ func (ws writeSession) DeleteInstance(instanceID string) dberr.Error {
 	return nil
 }
 
+// PurgeInstances permanently removes at most limit instances deleted before deletedBefore. It returns the number of removed instances.
+func (ws writeSession) PurgeInstances(deletedBefore time.Time, limit int) (int, dberr.Error) {
+	query := fmt.Sprintf(`DELETE FROM %[1]s WHERE ctid IN (
+		SELECT ctid FROM %[1]s WHERE deleted_at <> ? AND deleted_at < ? LIMIT ?)`, InstancesTableName)
+
+	res, err := ws.deleteBySql(query, time.Time{}, deletedBefore, limit).Exec()
+	if err != nil {
+		return 0, dberr.Internal("Failed to purge records from Instance table: %s", err)
+	}
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+
+	return int(purged), nil
+}
+
 func (ws writeSession) UpdateInstance(instance dbmodel.InstanceDTO) dberr.Error {
 	res, err := ws.update(InstancesTableName).
 		Where(dbr.Eq("instance_id", instance.InstanceID)).
 		Where(dbr.Eq("version", instance.Version)).
+		Where(instanceNotDeleted()).
 		Set("instance_id", instance.InstanceID).
 		Set("runtime_id", instance.RuntimeID).
 		Set("global_account_id", instance.GlobalAccountID).
@@ -92,6 +117,45 @@ func (ws writeSession) UpdateInstance(instance dbmodel.InstanceDTO) dberr.Error
 	return nil
 }
 
+// InsertInstanceParametersHistory stores the current provisioning parameters of the instance in the given version as the history entry.
+// The parameters are copied by the database as stored, so they remain encrypted if the encryption is enabled.
+// It returns NotFound if the instance in the given version does not exist.
+func (ws writeSession) InsertInstanceParametersHistory(instanceID string, version int, operationID string, createdAt time.Time) dberr.Error {
+	query := fmt.Sprintf(`INSERT INTO %s (instance_id, operation_id, provisioning_parameters, created_at)
+		SELECT instance_id, ?, provisioning_parameters, ? FROM %s WHERE instance_id = ? AND version = ? AND deleted_at = ?`,
+		InstanceParametersHistoryTableName, InstancesTableName)
+
+	res, err := ws.insertBySql(query, operationID, createdAt, instanceID, version, time.Time{}).Exec()
+	if err != nil {
+		return dberr.Internal("Failed to insert record to Instance parameters history table: %s", err)
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if inserted == int64(0) {
+		return dberr.NotFound("Cannot find Instance with ID:'%s' Version: %v", instanceID, version)
+	}
+
+	return nil
+}
+
+// DeleteInstanceParametersHistory deletes at most limit history entries created before olderThan. It returns the number of deleted entries.
+func (ws writeSession) DeleteInstanceParametersHistory(olderThan time.Time, limit int) (int, dberr.Error) {
+	query := fmt.Sprintf(`DELETE FROM %[1]s WHERE id IN (SELECT id FROM %[1]s WHERE created_at < ? LIMIT ?)`, InstanceParametersHistoryTableName)
+
+	res, err := ws.deleteBySql(query, olderThan, limit).Exec()
+	if err != nil {
+		return 0, dberr.Internal("Failed to delete records from Instance parameters history table: %s", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+
+	return int(deleted), nil
+}
+
 func (ws writeSession) InsertOperation(op dbmodel.OperationDTO) dberr.Error {
 	_, err := ws.insertInto(OperationTableName).
 		Pair("id", op.ID).
@@ -197,6 +261,69 @@ func (ws writeSession) InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.E
 	return nil
 }
 
+// DeleteRuntimeStates deletes at most limit states of the runtime created before olderThan. The keepLast newest states
+// and the state of the last operation of the runtime's instance are never deleted. It returns the number of deleted states.
+func (ws writeSession) DeleteRuntimeStates(runtimeID string, keepLast int, olderThan time.Time, limit int) (int, dberr.Error) {
+	query := fmt.Sprintf(`DELETE FROM %[1]s WHERE id IN (
+		SELECT id FROM %[1]s
+		WHERE runtime_id = ? AND created_at < ?
+		AND id NOT IN (SELECT id FROM %[1]s WHERE runtime_id = ? ORDER BY created_at DESC LIMIT ?)
+		AND COALESCE(operation_id, '') NOT IN (
+			SELECT DISTINCT ON (o.instance_id) o.id FROM %[2]s o JOIN %[3]s i ON i.instance_id = o.instance_id
+			WHERE i.runtime_id = ? AND o.state != ?
+			ORDER BY o.instance_id, o.created_at DESC)
+		LIMIT ?)`, RuntimeStateTableName, OperationTableName, InstancesTableName)
+
+	res, err := ws.deleteBySql(query, runtimeID, olderThan, runtimeID, keepLast, runtimeID, orchestration.Pending, limit).Exec()
+	if err != nil {
+		return 0, dberr.Internal("Failed to delete records from RuntimeState table: %s", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+
+	return int(deleted), nil
+}
+
+func (ws writeSession) InsertEvent(event dbmodel.EventDTO) dberr.Error {
+	_, err := ws.insertInto(EventTableName).
+		Pair("id", event.ID).
+		Pair("instance_id", event.InstanceID).
+		Pair("operation_id", event.OperationID).
+		Pair("level", event.Level).
+		Pair("message", event.Message).
+		Pair("created_at", event.CreatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("Event with id %s already exist", event.ID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to Event table: %s", err)
+	}
+
+	return nil
+}
+
+// DeleteEvents deletes at most limit events created before olderThan. It returns the number of deleted events.
+func (ws writeSession) DeleteEvents(olderThan time.Time, limit int) (int, dberr.Error) {
+	query := fmt.Sprintf(`DELETE FROM %[1]s WHERE id IN (SELECT id FROM %[1]s WHERE created_at < ? LIMIT ?)`, EventTableName)
+
+	res, err := ws.deleteBySql(query, olderThan, limit).Exec()
+	if err != nil {
+		return 0, dberr.Internal("Failed to delete records from Event table: %s", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+
+	return int(deleted), nil
+}
+
 func (ws writeSession) UpdateOperation(op dbmodel.OperationDTO) dberr.Error {
 	res, err := ws.update(OperationTableName).
 		Where(dbr.Eq("id", op.ID)).
@@ -219,7 +346,7 @@ func (ws writeSession) UpdateOperation(op dbmodel.OperationDTO) dberr.Error {
 		if err == dbr.ErrNotFound {
 			return dberr.NotFound("Cannot find Operation with ID:'%s'", op.ID)
 		}
-		return dberr.Internal("Failed to update record to Operation table: %s", err)
+		return dberr.InternalWithCause(err, "Failed to update record to Operation table: %s", err)
 	}
 	rAffected, e := res.RowsAffected()
 	if e != nil {
@@ -233,6 +360,81 @@ func (ws writeSession) UpdateOperation(op dbmodel.OperationDTO) dberr.Error {
 	return nil
 }
 
+func (ws writeSession) InsertInstances(instances []dbmodel.InstanceDTO, skipExisting bool) (int, dberr.Error) {
+	columns := []string{"instance_id", "runtime_id", "global_account_id", "sub_account_id", "service_id", "service_name", "service_plan_id",
+		"service_plan_name", "dashboard_url", "provisioning_parameters", "provider_region", "provider", "created_at", "updated_at", "deleted_at", "version"}
+
+	rows := make([][]interface{}, 0, len(instances))
+	for _, instance := range instances {
+		rows = append(rows, []interface{}{instance.InstanceID, instance.RuntimeID, instance.GlobalAccountID, instance.SubAccountID, instance.ServiceID,
+			instance.ServiceName, instance.ServicePlanID, instance.ServicePlanName, instance.DashboardURL, instance.ProvisioningParameters,
+			instance.ProviderRegion, instance.Provider, instance.CreatedAt, instance.UpdatedAt, instance.DeletedAt, instance.Version})
+	}
+
+	return ws.insertRows(InstancesTableName, columns, rows, skipExisting)
+}
+
+func (ws writeSession) InsertOperations(operations []dbmodel.OperationDTO, skipExisting bool) (int, dberr.Error) {
+	columns := []string{"id", "instance_id", "version", "created_at", "updated_at", "description", "state", "target_operation_id", "type", "data",
+		"orchestration_id", "provisioning_parameters", "finished_stages"}
+
+	rows := make([][]interface{}, 0, len(operations))
+	for _, op := range operations {
+		rows = append(rows, []interface{}{op.ID, op.InstanceID, op.Version, op.CreatedAt, op.UpdatedAt, op.Description, op.State, op.TargetOperationID,
+			op.Type, op.Data, op.OrchestrationID.String, op.ProvisioningParameters.String, op.FinishedStages})
+	}
+
+	return ws.insertRows(OperationTableName, columns, rows, skipExisting)
+}
+
+func (ws writeSession) InsertRuntimeStates(states []dbmodel.RuntimeStateDTO, skipExisting bool) (int, dberr.Error) {
+	columns := []string{"id", "operation_id", "runtime_id", "created_at", "kyma_version", "k8s_version", "kyma_config", "cluster_config"}
+
+	rows := make([][]interface{}, 0, len(states))
+	for _, state := range states {
+		rows = append(rows, []interface{}{state.ID, state.OperationID, state.RuntimeID, state.CreatedAt, state.KymaVersion, state.K8SVersion,
+			state.KymaConfig, state.ClusterConfig})
+	}
+
+	return ws.insertRows(RuntimeStateTableName, columns, rows, skipExisting)
+}
+
+// insertRows inserts the rows with a single multi-row statement, the conflicting rows are skipped if skipExisting is set
+func (ws writeSession) insertRows(table string, columns []string, rows [][]interface{}, skipExisting bool) (int, dberr.Error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+	values := make([]string, 0, len(rows))
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	for _, row := range rows {
+		values = append(values, placeholders)
+		args = append(args, row...)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+	if skipExisting {
+		query += " ON CONFLICT DO NOTHING"
+	}
+
+	res, err := ws.insertBySql(query, args...).Exec()
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return 0, dberr.AlreadyExists("record in %s table already exist: %s", table, err.Detail)
+			}
+		}
+		return 0, dberr.InternalWithCause(err, "Failed to insert records to %s table: %s", table, err)
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return 0, dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+
+	return int(inserted), nil
+}
+
 func (ws writeSession) Commit() dberr.Error {
 	err := ws.transaction.Commit()
 	if err != nil {
@@ -254,6 +456,14 @@ func (ws writeSession) insertInto(table string) *dbr.InsertStmt {
 	return ws.session.InsertInto(table)
 }
 
+func (ws writeSession) insertBySql(query string, value ...interface{}) *dbr.InsertStmt {
+	if ws.transaction != nil {
+		return ws.transaction.InsertBySql(query, value...)
+	}
+
+	return ws.session.InsertBySql(query, value...)
+}
+
 func (ws writeSession) deleteFrom(table string) *dbr.DeleteStmt {
 	if ws.transaction != nil {
 		return ws.transaction.DeleteFrom(table)
@@ -262,6 +472,14 @@ func (ws writeSession) deleteFrom(table string) *dbr.DeleteStmt {
 	return ws.session.DeleteFrom(table)
 }
 
+func (ws writeSession) deleteBySql(query string, value ...interface{}) *dbr.DeleteStmt {
+	if ws.transaction != nil {
+		return ws.transaction.DeleteBySql(query, value...)
+	}
+
+	return ws.session.DeleteBySql(query, value...)
+}
+
 func (ws writeSession) update(table string) *dbr.UpdateStmt {
 	if ws.transaction != nil {
 		return ws.transaction.Update(table)
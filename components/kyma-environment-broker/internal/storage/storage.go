@@ -2,10 +2,13 @@ package storage
 
 import (
 	"github.com/gocraft/dbr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
 	postgres "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/postsql"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 )
 
 type BrokerStorage interface {
@@ -15,17 +18,28 @@ type BrokerStorage interface {
 	Deprovisioning() Deprovisioning
 	Orchestrations() Orchestrations
 	RuntimeStates() RuntimeStates
+	Events() Events
+	ChangeFeed() ChangeFeed
 }
 
 const (
 	connectionRetries = 10
+	tracerName        = "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 )
 
 func NewFromConfig(cfg Config, cipher postgres.Cipher, log logrus.FieldLogger) (BrokerStorage, *dbr.Connection, error) {
 	log.Infof("Setting DB connection pool params: connectionMaxLifetime=%s "+
 		"maxIdleConnections=%d maxOpenConnections=%d", cfg.ConnMaxLifetime, cfg.MaxIdleConns, cfg.MaxOpenConns)
 
-	connection, err := postsql.InitializeDatabase(cfg.ConnectionURL(), connectionRetries, log)
+	var tracing *postsql.Tracing
+	if cfg.Tracing.Enabled {
+		tracing = &postsql.Tracing{
+			Tracer:             otel.Tracer(tracerName),
+			SlowQueryThreshold: cfg.Tracing.SlowQueryThreshold,
+		}
+	}
+
+	connection, err := postsql.InitializeDatabase(cfg.ConnectionURL(), connectionRetries, tracing, log)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -36,22 +50,37 @@ func NewFromConfig(cfg Config, cipher postgres.Cipher, log logrus.FieldLogger) (
 
 	fact := postsql.NewFactory(connection)
 
+	var changeFeed ChangeFeed = noopChangeFeed{}
+	if cfg.ChangeFeed.Enabled {
+		listener, err := postsql.NewChangeListener(cfg.ConnectionURL(), cfg.ChangeFeed.PollInterval, log.WithField("service", "changeFeed"))
+		if err != nil {
+			connection.Close()
+			return nil, nil, errors.Wrap(err, "while starting database change listener")
+		}
+		changeFeed = listener
+	}
+
 	operation := postgres.NewOperation(fact, cipher)
 	return storage{
 		instance:       postgres.NewInstance(fact, operation, cipher),
 		operation:      operation,
 		orchestrations: postgres.NewOrchestrations(fact),
 		runtimeStates:  postgres.NewRuntimeStates(fact, cipher),
+		events:         postgres.NewEvents(fact),
+		changeFeed:     changeFeed,
 	}, connection, nil
 }
 
 func NewMemoryStorage() BrokerStorage {
 	op := memory.NewOperation()
+	instance := memory.NewInstance(op)
 	return storage{
 		operation:      op,
-		instance:       memory.NewInstance(op),
+		instance:       instance,
 		orchestrations: memory.NewOrchestrations(),
-		runtimeStates:  memory.NewRuntimeStates(),
+		runtimeStates:  memory.NewRuntimeStates(instance),
+		events:         memory.NewEvents(),
+		changeFeed:     noopChangeFeed{},
 	}
 }
 
@@ -60,6 +89,8 @@ type storage struct {
 	operation      Operations
 	orchestrations Orchestrations
 	runtimeStates  RuntimeStates
+	events         Events
+	changeFeed     ChangeFeed
 }
 
 func (s storage) Instances() Instances {
@@ -85,3 +116,18 @@ func (s storage) Orchestrations() Orchestrations {
 func (s storage) RuntimeStates() RuntimeStates {
 	return s.runtimeStates
 }
+
+func (s storage) Events() Events {
+	return s.events
+}
+
+func (s storage) ChangeFeed() ChangeFeed {
+	return s.changeFeed
+}
+
+// noopChangeFeed never publishes changes, subscribers rely on polling the storage
+type noopChangeFeed struct{}
+
+func (noopChangeFeed) Subscribe() (<-chan internal.Change, func()) {
+	return make(chan internal.Change), func() {}
+}
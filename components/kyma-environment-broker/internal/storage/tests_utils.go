@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gocraft/dbr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -52,7 +53,7 @@ func makeConnectionString(hostname string, port string) Config {
 	return cfg
 }
 
-func CloseDatabase(t *testing.T, connection *dbr.Connection) {
+func CloseDatabase(t testing.TB, connection *dbr.Connection) {
 	if connection != nil {
 		err := connection.Close()
 		assert.Nil(t, err, "Failed to close db connection")
@@ -68,7 +69,7 @@ func closeDBConnection(connection *dbr.Connection) {
 	}
 }
 
-func InitTestDBContainer(t *testing.T, ctx context.Context, hostname string) (func(), Config, error) {
+func InitTestDBContainer(t testing.TB, ctx context.Context, hostname string) (func(), Config, error) {
 	_, err := isDockerTestNetworkPresent(ctx)
 	if err != nil {
 		return nil, Config{}, err
@@ -196,7 +197,7 @@ func SetupTestDBContainer(ctx context.Context, hostname string) (cleanupFunc fun
 	return cleanupFunc, dbCfg, err
 }
 
-func InitTestDBTables(t *testing.T, connectionURL string) (func(), error) {
+func InitTestDBTables(t testing.TB, connectionURL string) (func(), error) {
 	connection, err := postsql.WaitForDatabaseAccess(connectionURL, 10, 100*time.Millisecond, logrus.New())
 	if err != nil {
 		t.Logf("Cannot connect to database with URL %s", connectionURL)
@@ -226,6 +227,20 @@ func InitTestDBTables(t *testing.T, connectionURL string) (func(), error) {
 		t.Logf("Table %s added to database", name)
 	}
 
+	for _, v := range FixIndexes() {
+		if _, err := connection.Exec(v); err != nil {
+			t.Log("Cannot create index")
+			return nil, err
+		}
+	}
+
+	for _, v := range FixTriggers() {
+		if _, err := connection.Exec(v); err != nil {
+			t.Log("Cannot create trigger")
+			return nil, err
+		}
+	}
+
 	return cleanupFunc, nil
 }
 
@@ -259,6 +274,20 @@ func SetupTestDBTables(connectionURL string) (cleanupFunc func(), err error) {
 		log.Printf("Table %s added to database", name)
 	}
 
+	for _, v := range FixIndexes() {
+		if _, err := connection.Exec(v); err != nil {
+			log.Print("Cannot create index")
+			return nil, err
+		}
+	}
+
+	for _, v := range FixTriggers() {
+		if _, err := connection.Exec(v); err != nil {
+			log.Print("Cannot create trigger")
+			return nil, err
+		}
+	}
+
 	return cleanupFunc, nil
 }
 
@@ -385,7 +414,7 @@ func FixTables() map[string]string {
 	return map[string]string{
 		postsql.InstancesTableName: fmt.Sprintf(
 			`CREATE TABLE IF NOT EXISTS %s (
-			instance_id varchar(255) PRIMARY KEY,
+			instance_id varchar(255) NOT NULL,
 			runtime_id varchar(255) NOT NULL,
 			global_account_id varchar(255) NOT NULL,
 			sub_account_id varchar(255) NOT NULL,
@@ -440,14 +469,66 @@ func FixTables() map[string]string {
 			kyma_version text,
 			k8s_version text
 			)`, postsql.RuntimeStateTableName),
+		postsql.EventTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			instance_id varchar(255) NOT NULL,
+			operation_id varchar(255) NOT NULL DEFAULT '',
+			level varchar(32) NOT NULL,
+			message text NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+			)`, postsql.EventTableName),
+		postsql.InstanceParametersHistoryTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id bigserial PRIMARY KEY,
+			instance_id varchar(255) NOT NULL,
+			operation_id varchar(255) NOT NULL DEFAULT '',
+			provisioning_parameters text NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+			)`, postsql.InstanceParametersHistoryTableName),
+	}
+}
+
+// FixIndexes returns unique indexes which cannot be declared in the table definitions, the same as created by the migrations
+func FixIndexes() []string {
+	return []string{
+		fmt.Sprintf(`CREATE UNIQUE INDEX instances_instance_id_not_deleted ON %s USING btree (instance_id)
+			WHERE deleted_at = '0001-01-01 00:00:00+00'`, postsql.InstancesTableName),
+	}
+}
+
+// FixTriggers returns triggers notifying about changes of operations and orchestrations, the same as created by the migrations
+func FixTriggers() []string {
+	notifyFunction := `CREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'INSERT' OR NEW.state IS DISTINCT FROM OLD.state THEN
+				PERFORM pg_notify('%s', json_build_object(
+					'type', '%s',
+					'id', NEW.%s,
+					'state', NEW.state,
+					'orchestrationId', COALESCE(NEW.orchestration_id, '')
+				)::text);
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql`
+	trigger := `CREATE TRIGGER %s_notify_change AFTER INSERT OR UPDATE ON %s
+		FOR EACH ROW EXECUTE PROCEDURE %s()`
+
+	return []string{
+		fmt.Sprintf(notifyFunction, "notify_operation_change", postsql.ChangesChannel, internal.OperationChange, "id"),
+		fmt.Sprintf(notifyFunction, "notify_orchestration_change", postsql.ChangesChannel, internal.OrchestrationChange, "orchestration_id"),
+		fmt.Sprintf(trigger, postsql.OperationTableName, postsql.OperationTableName, "notify_operation_change"),
+		fmt.Sprintf(trigger, postsql.OrchestrationTableName, postsql.OrchestrationTableName, "notify_orchestration_change"),
 	}
 }
 
 func clearDBQuery() string {
-	return fmt.Sprintf("TRUNCATE TABLE %s, %s, %s, %s RESTART IDENTITY CASCADE",
+	return fmt.Sprintf("TRUNCATE TABLE %s, %s, %s, %s, %s RESTART IDENTITY CASCADE",
 		postsql.InstancesTableName,
 		postsql.OperationTableName,
 		postsql.OrchestrationTableName,
 		postsql.RuntimeStateTableName,
+		postsql.EventTableName,
 	)
 }
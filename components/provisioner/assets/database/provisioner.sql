@@ -9,9 +9,22 @@ CREATE TABLE cluster
     tenant varchar(256) NOT NULL,
     creation_timestamp timestamp without time zone NOT NULL,
     deleted boolean default false,
-    sub_account_id varchar(256)
+    sub_account_id varchar(256),
+    hibernated boolean NOT NULL default false,
+    hibernated_at timestamp without time zone,
+    last_woken_at timestamp without time zone,
+    hibernation_initiated_by varchar(256),
+    last_operation_id uuid,
+    expire_at timestamp without time zone,
+    deprovisioning_scheduled_at timestamp without time zone,
+    api_server_url text,
+    ca_certificate text
 );
 
+CREATE INDEX cluster_expire_at_idx ON cluster (expire_at) WHERE expire_at IS NOT NULL AND deleted = false;
+
+CREATE INDEX cluster_deprovisioning_scheduled_at_idx ON cluster (deprovisioning_scheduled_at) WHERE deprovisioning_scheduled_at IS NOT NULL AND deleted = false;
+
 -- Cluster Config
 
 CREATE TABLE gardener_config
@@ -35,19 +48,29 @@ CREATE TABLE gardener_config
     worker_cidr varchar(256) NOT NULL,
     auto_scaler_min integer NOT NULL,
     auto_scaler_max integer NOT NULL,
-    max_surge integer NOT NULL,
-    max_unavailable integer NOT NULL,
+    max_surge varchar(16),
+    max_unavailable varchar(16),
     enable_kubernetes_version_auto_update boolean NOT NULL,
     enable_machine_image_version_auto_update boolean NOT NULL,
     allow_privileged_containers boolean NOT NULL,
     provider_specific_config jsonb,
+    networking_type varchar(256) NOT NULL DEFAULT 'calico',
+    shoot_annotations jsonb,
+    dns_config jsonb,
+    cost_allocation jsonb,
+    cluster_autoscaler_config jsonb,
+    kube_apiserver_config jsonb,
+    dedicated_system_pool jsonb,
     UNIQUE(cluster_id),
     foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE
 );
 
+CREATE INDEX gardener_config_name_idx ON gardener_config (name);
+
 -- Operation
 
 CREATE TYPE operation_state AS ENUM (
+    'PENDING',
     'IN_PROGRESS',
     'SUCCEEDED',
     'FAILED'
@@ -59,7 +82,9 @@ CREATE TYPE operation_type AS ENUM (
     'DEPROVISION',
     'RECONNECT_RUNTIME',
     'UPGRADE_SHOOT',
-    'HIBERNATE'
+    'HIBERNATE',
+    'WAKE_UP',
+    'CLEANUP_FAILED_PROVISIONING'
     );
 
 CREATE TABLE operation
@@ -73,9 +98,22 @@ CREATE TABLE operation
     cluster_id uuid NOT NULL,
     foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE,
     stage varchar(256) NOT NULL,
-    last_transition timestamp without time zone
+    last_transition timestamp without time zone,
+    force boolean NOT NULL DEFAULT false,
+    version integer NOT NULL DEFAULT 0,
+    installation_timeout_minutes integer,
+    diagnostics text,
+    installation_triggered_at timestamp without time zone,
+    triggered_by varchar(256),
+    claimed_by varchar(256),
+    claim_expires_at timestamp without time zone,
+    failure_reason varchar(256)
 );
 
+CREATE INDEX operation_cluster_id_start_timestamp_idx ON operation (cluster_id, start_timestamp);
+
+CREATE UNIQUE INDEX operation_cluster_id_in_progress_idx ON operation (cluster_id) WHERE state IN ('PENDING', 'IN_PROGRESS');
+
 -- Kyma Release
 
 CREATE TABLE kyma_release
@@ -84,6 +122,7 @@ CREATE TABLE kyma_release
     version varchar(256) NOT NULL,
     tiller_yaml text NOT NULL,
     installer_yaml text NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT now(),
     unique(version)
 );
 
@@ -119,7 +158,7 @@ CREATE TABLE kyma_component_config
 
 -- Active Kyma Config column
 
-ALTER TABLE cluster ADD COLUMN active_kyma_config_id uuid NOT NULL;
+ALTER TABLE cluster ADD COLUMN active_kyma_config_id uuid;
 ALTER TABLE cluster ADD CONSTRAINT cluster_active_kyma_config_id_fkey foreign key (active_kyma_config_id) REFERENCES kyma_config (id) DEFERRABLE INITIALLY DEFERRED;
 
 
@@ -175,4 +214,82 @@ CREATE TABLE signing_algorithms
     oidc_config_id uuid NOT NULL,
     algorithm text NOT NULL,
     foreign key (oidc_config_id) REFERENCES oidc_config (id) ON DELETE CASCADE
-);
\ No newline at end of file
+);
+
+-- Operation queues state
+
+CREATE TABLE operation_queue_state
+(
+    operation_type operation_type PRIMARY KEY,
+    paused boolean NOT NULL DEFAULT false
+);
+
+INSERT INTO operation_queue_state (operation_type) VALUES ('PROVISION'), ('DEPROVISION'), ('UPGRADE'), ('UPGRADE_SHOOT'), ('HIBERNATE');
+
+-- Read-only maintenance mode, null values mean that the mode was not set and the configuration of the Provisioner applies
+
+CREATE TABLE read_only_mode
+(
+    id boolean PRIMARY KEY DEFAULT true CHECK (id),
+    enabled boolean,
+    message text,
+    updated_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+INSERT INTO read_only_mode (id) VALUES (true);
+
+-- API audit log
+
+CREATE TABLE api_audit_log
+(
+    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
+    tenant varchar(256),
+    sub_account_id varchar(256),
+    mutation varchar(256) NOT NULL,
+    input jsonb,
+    operation_id uuid,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX api_audit_log_tenant_created_at_idx ON api_audit_log (tenant, created_at);
+
+CREATE RULE api_audit_log_no_update AS ON UPDATE TO api_audit_log DO INSTEAD NOTHING;
+CREATE RULE api_audit_log_no_delete AS ON DELETE TO api_audit_log DO INSTEAD NOTHING;
+
+-- Stage duration
+
+CREATE TABLE stage_duration
+(
+    operation_id uuid NOT NULL,
+    operation_type varchar(256) NOT NULL,
+    stage varchar(256) NOT NULL,
+    duration_seconds double precision NOT NULL,
+    finished_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX stage_duration_type_stage_finished_at_idx ON stage_duration (operation_type, stage, finished_at);
+
+-- Idempotency key
+
+CREATE TABLE idempotency_key
+(
+    tenant varchar(256) NOT NULL,
+    key varchar(256) NOT NULL,
+    operation_type varchar(256) NOT NULL,
+    operation_id uuid NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    UNIQUE (tenant, key),
+    foreign key (operation_id) REFERENCES operation (id) ON DELETE CASCADE
+);
+
+-- Operation annotations
+
+CREATE TABLE operation_annotations
+(
+    operation_id uuid NOT NULL,
+    key varchar(63) NOT NULL,
+    value text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    PRIMARY KEY (operation_id, key),
+    foreign key (operation_id) REFERENCES operation (id) ON DELETE CASCADE
+);
@@ -16,11 +16,14 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/gardener"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/graphql"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/installation/release"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/oauth"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
@@ -32,13 +35,18 @@ import (
 
 const (
 	databaseConnectionRetries = 20
-	defaultSyncPeriod         = 10 * time.Minute
+	serverShutdownTimeout     = 10 * time.Second
+
+	schemaVersionCheckAttempts = 30
+	schemaVersionCheckDelay    = 5 * time.Second
+	schemaVersionRefreshPeriod = 1 * time.Minute
 )
 
 func newProvisioningService(
 	gardenerProject string,
 	provisioner provisioning.Provisioner,
 	dbsFactory dbsession.Factory,
+	uuidGenerator uuid.UUIDGenerator,
 	releaseProvider release.Provider,
 	directorService director.DirectorClient,
 	provisioningQueue queue.OperationQueue,
@@ -46,40 +54,72 @@ func newProvisioningService(
 	upgradeQueue queue.OperationQueue,
 	shootUpgradeQueue queue.OperationQueue,
 	hibernationQueue queue.OperationQueue,
+	provisioningThrottle *provisioning.ProvisioningThrottle,
+	progressEstimator provisioning.ProgressEstimator,
+	kubernetesVersionResolver provisioning.KubernetesVersionResolver,
+	defaultInstallationTimeout time.Duration,
+	orphanedShootsDetector provisioning.OrphanedShootsDetector,
+	runtimeStatusesConfig provisioning.RuntimeStatusesConfig,
+	idempotencyKeyTTL time.Duration,
+	machineImageDefaults provisioning.MachineImageDefaults,
+	regionPolicy provisioning.RegionPolicy,
 	defaultEnableKubernetesVersionAutoUpdate,
 	defaultEnableMachineImageVersionAutoUpdate,
-	forceAllowPrivilegedContainers bool) provisioning.Service {
-
-	uuidGenerator := uuid.NewUUIDGenerator()
-
-	inputConverter := provisioning.NewInputConverter(uuidGenerator, releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+	forceAllowPrivilegedContainers bool,
+	defaultNetworkingType model.NetworkingType,
+	defaultGCPShieldedInstanceConfig model.GCPShieldedInstanceConfig,
+	defaultAWSInstanceMetadataOptions model.AWSInstanceMetadataOptions,
+	defaultSystemWorkerPool model.SystemWorkerPool,
+	defaultKymaProfile *model.KymaProfile,
+	extraKymaComponentsAllowed bool,
+	maintenanceFreeze provisioning.MaintenanceFreeze,
+	shootNameGenerator provisioning.ShootNameGenerator) provisioning.Service {
+
+	inputConverter := provisioning.NewInputConverter(uuidGenerator, releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, defaultGCPShieldedInstanceConfig, defaultAWSInstanceMetadataOptions, defaultSystemWorkerPool, defaultKymaProfile)
 	graphQLConverter := provisioning.NewGraphQLConverter()
 
-	return provisioning.NewProvisioningService(inputConverter, graphQLConverter, directorService, dbsFactory, provisioner, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue)
+	return provisioning.NewProvisioningService(inputConverter, graphQLConverter, directorService, dbsFactory, provisioner, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue, provisioningThrottle, progressEstimator, kubernetesVersionResolver, defaultInstallationTimeout, orphanedShootsDetector, runtimeStatusesConfig, idempotencyKeyTTL, machineImageDefaults, regionPolicy, extraKymaComponentsAllowed, maintenanceFreeze, shootNameGenerator)
 }
 
-func newDirectorClient(config config) (director.DirectorClient, error) {
+func newOauthClient(config config, tracingProvider *tracing.Provider) (*oauth.CachingClient, error) {
 	secretsRepo, err := newSecretsInterface(config.OauthCredentialsNamespace)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to create secrets interface")
 	}
 
-	gqlClient := graphql.NewGraphQLClient(config.DirectorURL, true, config.SkipDirectorCertVerification)
-	oauthClient := oauth.NewOauthClient(newHTTPClient(config.SkipDirectorCertVerification), secretsRepo, config.OauthCredentialsSecretName)
+	oauthClient := oauth.NewOauthClient(newHTTPClient(config.SkipDirectorCertVerification, tracingProvider), secretsRepo, config.OauthCredentialsSecretName)
 
-	return director.NewDirectorClient(gqlClient, oauthClient), nil
+	return oauth.NewCachingClient(oauthClient), nil
 }
 
-func newShootController(gardenerNamespace string, gardenerClusterCfg *restclient.Config, dbsFactory dbsession.Factory, auditLogTenantConfigPath string) (*gardener.ShootController, error) {
+func newDirectorClient(config config, oauthClient oauth.Client, tracingProvider *tracing.Provider) director.BatchClient {
+	gqlClient := graphql.NewGraphQLClient(config.DirectorURL, true, config.SkipDirectorCertVerification, tracingProvider.WrapTransport)
+
+	return director.NewDirectorClient(gqlClient, oauthClient)
+}
 
-	syncPeriod := defaultSyncPeriod
+func newShootController(
+	gardenerNamespaces []string,
+	gardenerClusterCfg *restclient.Config,
+	dbsFactory dbsession.Factory,
+	auditLogTenantConfigPath string,
+	shootLister gardener.ShootLister,
+	resyncPeriod time.Duration,
+	maxIdleTime time.Duration) (*gardener.ShootController, error) {
+
+	options := ctrl.Options{SyncPeriod: &resyncPeriod}
+	if len(gardenerNamespaces) == 1 {
+		options.Namespace = gardenerNamespaces[0]
+	} else {
+		options.NewCache = cache.MultiNamespacedCacheBuilder(gardenerNamespaces)
+	}
 
-	mgr, err := ctrl.NewManager(gardenerClusterCfg, ctrl.Options{SyncPeriod: &syncPeriod, Namespace: gardenerNamespace})
+	mgr, err := ctrl.NewManager(gardenerClusterCfg, options)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create shoot controller manager: %w", err)
 	}
 
-	return gardener.NewShootController(mgr, dbsFactory, auditLogTenantConfigPath)
+	return gardener.NewShootController(mgr, dbsFactory, auditLogTenantConfigPath, shootLister, maxIdleTime)
 }
 
 func newSecretsInterface(namespace string) (v1.SecretInterface, error) {
@@ -114,14 +154,19 @@ func newGardenerClusterConfig(cfg config) (*restclient.Config, error) {
 		return nil, fmt.Errorf("failed to create Gardener cluster config: %s", err.Error())
 	}
 
+	// Rate limiter is shared by all clients created from the config, so the total request rate does not depend on the number of workers
+	gardenerClusterConfig.QPS = cfg.Gardener.QPS
+	gardenerClusterConfig.Burst = cfg.Gardener.Burst
+	gardenerClusterConfig.RateLimiter = gardener.NewRateLimiter(cfg.Gardener.QPS, cfg.Gardener.Burst)
+
 	return gardenerClusterConfig, nil
 }
 
-func newHTTPClient(skipCertVerification bool) *http.Client {
+func newHTTPClient(skipCertVerification bool, tracingProvider *tracing.Provider) *http.Client {
 	return &http.Client{
-		Transport: &http.Transport{
+		Transport: tracingProvider.WrapTransport(&http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: skipCertVerification},
-		},
+		}),
 		Timeout: 30 * time.Second,
 	}
 }
@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"net"
 	"net/http"
-	"sync"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
@@ -14,16 +18,26 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 
 	provisioningStages "github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/provisioning"
+	shootUpgradeStages "github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/shootupgrade"
+	upgradeStages "github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/upgrade"
 
 	retry "github.com/avast/retry-go"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/inventory"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/profiler"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/rest"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/healthz"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/api/middlewares"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/audit"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/director"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/freeze"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/recovery"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/runtime"
 
 	installationSDK "github.com/kyma-incubator/hydroform/install/installation"
@@ -31,7 +45,12 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/installation"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/database"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/readonly"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/regionpolicy"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/gardener"
@@ -47,11 +66,10 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pkg/errors"
 	"github.com/vrischmann/envconfig"
+	"golang.org/x/sync/errgroup"
 	"k8s.io/client-go/kubernetes"
 )
 
-const connStringFormat string = "host=%s port=%s user=%s password=%s dbname=%s sslmode=%s"
-
 type config struct {
 	Address                      string `envconfig:"default=127.0.0.1:3000"`
 	APIEndpoint                  string `envconfig:"default=/graphql"`
@@ -61,14 +79,9 @@ type config struct {
 	OauthCredentialsNamespace    string `envconfig:"default=kcp-system"`
 	OauthCredentialsSecretName   string `envconfig:"default=kcp-provisioner-credentials"`
 
-	Database struct {
-		User     string `envconfig:"default=postgres"`
-		Password string `envconfig:"default=password"`
-		Host     string `envconfig:"default=localhost"`
-		Port     string `envconfig:"default=5432"`
-		Name     string `envconfig:"default=provisioner"`
-		SSLMode  string `envconfig:"default=disable"`
-	}
+	DirectorStatusUpdates director.StatusUpdatesConfig
+
+	Database database.Config
 
 	ProvisioningTimeout   queue.ProvisioningTimeouts
 	DeprovisioningTimeout queue.DeprovisioningTimeouts
@@ -76,56 +89,262 @@ type config struct {
 
 	OperatorRoleBinding provisioningStages.OperatorRoleBinding
 
+	// UpgradeHealthChecks verify the cluster health before and after the Kyma upgrade
+	UpgradeHealthChecks upgradeStages.HealthChecksConfig
+	// ShootUpgradeAgentReconnection verifies the Runtime Agent connection after the Shoot upgrade and reconciles it if it is lost
+	ShootUpgradeAgentReconnection shootUpgradeStages.AgentReconnectionConfig
+
 	Gardener struct {
-		Project                                    string `envconfig:"default=gardenerProject"`
-		KubeconfigPath                             string `envconfig:"default=./dev/kubeconfig.yaml"`
-		AuditLogsPolicyConfigMap                   string `envconfig:"optional"`
-		AuditLogsTenantConfigPath                  string `envconfig:"optional"`
-		MaintenanceWindowConfigPath                string `envconfig:"optional"`
-		ClusterCleanupResourceSelector             string `envconfig:"default=https://service-manager."`
-		DefaultEnableKubernetesVersionAutoUpdate   bool   `envconfig:"default=false"`
-		DefaultEnableMachineImageVersionAutoUpdate bool   `envconfig:"default=false"`
-		ForceAllowPrivilegedContainers             bool   `envconfig:"default=false"`
+		Project                                    string                        `envconfig:"default=gardenerProject"`
+		Landscapes                                 gardener.Landscapes           `envconfig:"optional"`
+		KubeconfigPath                             string                        `envconfig:"default=./dev/kubeconfig.yaml"`
+		AuditLogsPolicyConfigMap                   string                        `envconfig:"optional"`
+		AuditLogsTenantConfigPath                  string                        `envconfig:"optional"`
+		MaintenanceWindowConfigPath                string                        `envconfig:"optional"`
+		ClusterCleanupResourceSelector             installation.CleanupSelectors `envconfig:"default=https://service-manager."`
+		DefaultEnableKubernetesVersionAutoUpdate   bool                          `envconfig:"default=false"`
+		DefaultEnableMachineImageVersionAutoUpdate bool                          `envconfig:"default=false"`
+		ForceAllowPrivilegedContainers             bool                          `envconfig:"default=false"`
+		DefaultNetworkingType                      string                        `envconfig:"default=calico"`
+		DefaultGCPEnableSecureBoot                 bool                          `envconfig:"default=false"`
+		DefaultGCPEnableIntegrityMonitoring        bool                          `envconfig:"default=false"`
+		DefaultGCPEnableVtpm                       bool                          `envconfig:"default=false"`
+		DefaultAWSHttpTokens                       string                        `envconfig:"default=required"`
+		DefaultAWSHttpPutResponseHopLimit          int                           `envconfig:"default=2"`
+		DefaultSystemPoolSize                      int                           `envconfig:"default=2"`
+		DefaultSystemPoolMachineType               string                        `envconfig:"optional"`
+		CloudProfileCacheTTL                       time.Duration                 `envconfig:"default=5m"`
+		PreflightChecksEnabled                     bool                          `envconfig:"default=true"`
+		QPS                                        float32                       `envconfig:"default=20"`
+		Burst                                      int                           `envconfig:"default=40"`
+		ShootAnnotationsAllowedPrefixes            []string                      `envconfig:"optional"`
+		AllowedFeatureGates                        []string                      `envconfig:"optional"`
+		// Kubeconfig selects whether the Shoot kubeconfigs are requested through the adminkubeconfig subresource or read from the static secret
+		Kubeconfig gardener.KubeconfigConfig
+		// ShootName configures the names generated for Shoots provisioned without the name and the validation of the requested names
+		ShootName gardener.ShootNameConfig
 	}
 
 	LatestDownloadedReleases int  `envconfig:"default=5"`
 	DownloadPreReleases      bool `envconfig:"default=true"`
+	// ReleaseDownload limits the number of releases downloaded in parallel and the time of a single artifact download,
+	// interrupted downloads are resumed with range requests within the Timeout
+	ReleaseDownload struct {
+		Concurrency int           `envconfig:"default=2"`
+		Timeout     time.Duration `envconfig:"default=5m"`
+	}
+	// ReleasePruning deletes releases not used by any cluster and older than MinAge from the database,
+	// the LatestDownloadedReleases newest releases are always kept
+	ReleasePruning struct {
+		Enabled bool          `envconfig:"default=false"`
+		MinAge  time.Duration `envconfig:"default=720h"`
+	}
 
 	EnqueueInProgressOperations bool `envconfig:"default=true"`
+	// EnqueueInProgressOperationsWindow spreads the operations re-enqueued after restart over the window,
+	// 0 enqueues all of them at once
+	EnqueueInProgressOperationsWindow time.Duration `envconfig:"default=2m"`
+	// ResumeKymaInstallation allows the operation to continue with the Kyma installation found on the cluster
+	// after the Provisioner restart instead of failing
+	ResumeKymaInstallation bool `envconfig:"default=true"`
+	// DefaultKymaProfile is installed if the Kyma configuration does not specify the profile,
+	// empty value means the default profile of the Kyma installer
+	DefaultKymaProfile string `envconfig:"optional"`
+	// ExtraKymaComponentsAllowed accepts components which are not part of the Kyma release, e.g. for custom Kyma builds,
+	// only duplicated components are rejected then
+	ExtraKymaComponentsAllowed bool `envconfig:"default=false"`
+
+	ProvisioningLimitPerGlobalAccount int    `envconfig:"default=0"`
+	ProvisioningLimitsConfigPath      string `envconfig:"optional"`
+
+	// RegionPolicy restricts providers, regions and zones of the Runtimes, everything is allowed if the config path is empty
+	RegionPolicy struct {
+		ConfigPath     string        `envconfig:"optional"`
+		ReloadInterval time.Duration `envconfig:"default=1m"`
+	}
+
+	// MaintenanceFreeze rejects upgrades during the configured windows and pauses the upgrade queues during the windows
+	// applying to all Runtimes, nothing is frozen if the config path is empty
+	MaintenanceFreeze struct {
+		ConfigPath     string        `envconfig:"optional"`
+		ReloadInterval time.Duration `envconfig:"default=1m"`
+	}
+
+	// ReadOnlyMode rejects all mutations with the message, Enabled applies until the mode is set by the admin tenant,
+	// the mode is then read from the database with the refresh interval
+	ReadOnlyMode struct {
+		Enabled         bool          `envconfig:"default=false"`
+		Message         string        `envconfig:"default=Provisioner is in read-only maintenance mode, retry the request later"`
+		RefreshInterval time.Duration `envconfig:"default=30s"`
+	}
+
+	AuditLog struct {
+		BufferSize   int  `envconfig:"default=1000"`
+		QueryEnabled bool `envconfig:"default=false"`
+	}
+
+	OperationProgress struct {
+		SampleSize int `envconfig:"default=20"`
+		MinSamples int `envconfig:"default=3"`
+	}
+
+	OrphanedShoots struct {
+		DetectionInterval time.Duration `envconfig:"default=1h"`
+	}
+
+	LastOperations struct {
+		RepairInterval time.Duration `envconfig:"default=1h"`
+	}
+
+	RuntimeExpiration struct {
+		CheckInterval time.Duration `envconfig:"default=5m"`
+	}
+
+	// ScheduledDeprovisioning waits in memory until the scheduled time, the schedules are reloaded from the database
+	// in the resync interval, so that the ones created by other replicas or before the restart are not missed
+	ScheduledDeprovisioning struct {
+		ResyncInterval time.Duration `envconfig:"default=10m"`
+	}
+
+	// OperationClaims let several replicas process the operations, each operation is claimed in the database by the replica
+	// processing it. Owner identifies the replica, if not provided the hostname is used.
+	OperationClaims struct {
+		Enabled bool          `envconfig:"default=false"`
+		Owner   string        `envconfig:"optional"`
+		TTL     time.Duration `envconfig:"default=2m"`
+	}
+
+	ShootController struct {
+		ResyncPeriod time.Duration `envconfig:"default=10m"`
+		MaxIdleTime  time.Duration `envconfig:"default=30m"`
+	}
+
+	RuntimeStatuses struct {
+		MaxBatchSize  int  `envconfig:"default=200"`
+		StrictTenancy bool `envconfig:"default=false"`
+	}
+
+	// StrictSubAccount rejects mutations without the sub-account header, otherwise they are only logged
+	StrictSubAccount bool `envconfig:"default=false"`
+
+	// IgnoreUnknownInputFields removes the input fields not defined in the schema from the requests, otherwise such requests fail,
+	// so that the clients can be upgraded to a newer schema before the Provisioner
+	IgnoreUnknownInputFields bool `envconfig:"default=true"`
+
+	// AdminTenants can annotate operations of all tenants and retry failed operations in bulk, e.g. the tenant used by the on-call engineers
+	AdminTenants []string `envconfig:"optional"`
+
+	// IdempotencyKeyTTL is the time after which the idempotency key of the mutation can be used to start a new operation,
+	// 0 means that keys never expire
+	IdempotencyKeyTTL time.Duration `envconfig:"default=24h"`
+
+	// K8sClientCache configures caching of the clients for the Runtimes built from their kubeconfigs
+	K8sClientCache k8s.ClientCacheConfig
+
+	// Server settings apply to both the API and the metrics server
+	Server struct {
+		ReadTimeout        time.Duration `envconfig:"default=30s"`
+		ReadHeaderTimeout  time.Duration `envconfig:"default=10s"`
+		WriteTimeout       time.Duration `envconfig:"default=2m"`
+		IdleTimeout        time.Duration `envconfig:"default=2m"`
+		MaxRequestBodySize int64         `envconfig:"default=2097152"`
+	}
 
 	MetricsAddress string `envconfig:"default=127.0.0.1:9000"`
 
+	EnableProfiler bool `envconfig:"default=false"`
+	Profiler       profiler.Config
+
+	EnableRuntimesExport bool `envconfig:"default=false"`
+	RuntimesExport       inventory.Config
+
+	// EnableTracing exports OpenTelemetry spans of the API requests, the operation stages and the calls to Director and release storage
+	EnableTracing bool `envconfig:"default=false"`
+	Tracing       tracing.Config
+
 	LogLevel string `envconfig:"default=info"`
 }
 
 func (c *config) String() string {
 	return fmt.Sprintf("Address: %s, APIEndpoint: %s, DirectorURL: %s, "+
 		"SkipDirectorCertVerification: %v, OauthCredentialsNamespace: %s, OauthCredentialsSecretName: %s, "+
+		"DirectorStatusUpdatesFlushInterval: %s, DirectorStatusUpdatesMaxBatchSize: %d, DirectorStatusUpdatesBatchRequests: %t, "+
+		"DirectorStatusUpdatesRetries: %d, DirectorStatusUpdatesRetryInterval: %s, "+
 		"DatabaseUser: %s, DatabaseHost: %s, DatabasePort: %s, "+
-		"DatabaseName: %s, DatabaseSSLMode: %s, "+
+		"DatabaseName: %s, DatabaseSSLMode: %s, DatabaseSSLRootCertPath: %s, DatabaseSSLCertPath: %s, DatabaseSSLKeyPath: %s, "+
+		"DatabaseQueryTimeout: %s, DatabaseSlowQueryThreshold: %s, DatabaseVerifySchemaVersion: %t, "+
 		"ProvisioningTimeoutClusterCreation: %s "+
-		"ProvisioningTimeoutInstallation: %s, ProvisioningTimeoutUpgrade: %s, "+
+		"ProvisioningTimeoutInstallation: %s, ProvisioningTimeoutMaxInstallation: %s, ProvisioningTimeoutUpgrade: %s, "+
 		"ProvisioningTimeoutAgentConfiguration: %s, ProvisioningTimeoutAgentConnection: %s, "+
 		"DeprovisioningTimeoutClusterDeletion: %s, DeprovisioningTimeoutWaitingForClusterDeletion: %s "+
-		"OperatorRoleBindingL2SubjectName: %s, OperatorRoleBindingL3SubjectName: %s, OperatorRoleBindingCreatingForAdmin: %t"+
-		"GardenerProject: %s, GardenerKubeconfigPath: %s, GardenerAuditLogsPolicyConfigMap: %s, AuditLogsTenantConfigPath: %s, "+
-		"ForceAllowPrivilegedContainers: %t, "+
-		"LatestDownloadedReleases: %d, DownloadPreReleases: %v, "+
-		"EnqueueInProgressOperations: %v"+
+		"UpgradeHealthChecksEnabled: %t, UpgradeHealthChecksDeployments: %v, UpgradeHealthChecksTimeout: %s, "+
+		"ShootUpgradeAgentReconnectionEnabled: %t, ShootUpgradeAgentReconnectionWindow: %s, "+
+		"OperatorRoleBindingL2SubjectName: %s, OperatorRoleBindingL3SubjectName: %s, OperatorRoleBindingCreatingForAdmin: %t, "+
+		"OperatorRoleBindingConfigPath: %s, OperatorRoleBindingDeleteUnconfigured: %t, "+
+		"GardenerProject: %s, GardenerLandscapes: %v, GardenerKubeconfigPath: %s, GardenerAuditLogsPolicyConfigMap: %s, AuditLogsTenantConfigPath: %s, "+
+		"ForceAllowPrivilegedContainers: %t, GardenerDefaultNetworkingType: %s, GardenerCloudProfileCacheTTL: %s, GardenerPreflightChecksEnabled: %t, GardenerQPS: %v, GardenerBurst: %d, GardenerShootAnnotationsAllowedPrefixes: %v, GardenerAllowedFeatureGates: %v, "+
+		"GardenerDefaultGCPEnableSecureBoot: %t, GardenerDefaultGCPEnableIntegrityMonitoring: %t, GardenerDefaultGCPEnableVtpm: %t, "+
+		"GardenerDefaultAWSHttpTokens: %s, GardenerDefaultAWSHttpPutResponseHopLimit: %d, GardenerDefaultSystemPoolSize: %d, GardenerDefaultSystemPoolMachineType: %s, "+
+		"GardenerKubeconfigMode: %s, GardenerKubeconfigExpiration: %s, GardenerKubeconfigRenewBefore: %s, "+
+		"GardenerShootNamePrefix: %s, GardenerShootNameLength: %d, GardenerShootNameMaxAttempts: %d, GardenerShootNameDomainSuffix: %s, "+
+		"LatestDownloadedReleases: %d, DownloadPreReleases: %v, ReleaseDownloadConcurrency: %d, ReleaseDownloadTimeout: %s, ReleasePruningEnabled: %t, ReleasePruningMinAge: %s, "+
+		"EnqueueInProgressOperations: %v, EnqueueInProgressOperationsWindow: %s, ResumeKymaInstallation: %t, DefaultKymaProfile: %s, ExtraKymaComponentsAllowed: %t, "+
+		"ProvisioningLimitPerGlobalAccount: %d, ProvisioningLimitsConfigPath: %s, "+
+		"RegionPolicyConfigPath: %s, RegionPolicyReloadInterval: %s, "+
+		"MaintenanceFreezeConfigPath: %s, MaintenanceFreezeReloadInterval: %s, "+
+		"ReadOnlyModeEnabled: %t, ReadOnlyModeMessage: %s, ReadOnlyModeRefreshInterval: %s, "+
+		"AuditLogBufferSize: %d, AuditLogQueryEnabled: %t, "+
+		"OperationProgressSampleSize: %d, OperationProgressMinSamples: %d, "+
+		"OrphanedShootsDetectionInterval: %s, LastOperationsRepairInterval: %s, RuntimeExpirationCheckInterval: %s, ScheduledDeprovisioningResyncInterval: %s, "+
+		"OperationClaimsEnabled: %t, OperationClaimsOwner: %s, OperationClaimsTTL: %s, "+
+		"ShootControllerResyncPeriod: %s, ShootControllerMaxIdleTime: %s, "+
+		"RuntimeStatusesMaxBatchSize: %d, RuntimeStatusesStrictTenancy: %t, "+
+		"StrictSubAccount: %t, IgnoreUnknownInputFields: %t, AdminTenants: %v, IdempotencyKeyTTL: %s, "+
+		"K8sClientCacheTTL: %s, K8sClientCacheMaxEntries: %d, "+
+		"ServerReadTimeout: %s, ServerReadHeaderTimeout: %s, ServerWriteTimeout: %s, ServerIdleTimeout: %s, ServerMaxRequestBodySize: %d, "+
+		"EnableProfiler: %t, ProfilerMutexProfileFraction: %d, ProfilerBlockProfileRate: %d, "+
+		"EnableRuntimesExport: %t, RuntimesExportBatchSize: %d, "+
+		"EnableTracing: %t, TracingEndpoint: %s, TracingInsecure: %t, TracingSampleRatio: %v, "+
 		"LogLevel: %s",
 		c.Address, c.APIEndpoint, c.DirectorURL,
 		c.SkipDirectorCertVerification, c.OauthCredentialsNamespace, c.OauthCredentialsSecretName,
+		c.DirectorStatusUpdates.FlushInterval.String(), c.DirectorStatusUpdates.MaxBatchSize, c.DirectorStatusUpdates.BatchRequests,
+		c.DirectorStatusUpdates.Retries, c.DirectorStatusUpdates.RetryInterval.String(),
 		c.Database.User, c.Database.Host, c.Database.Port,
-		c.Database.Name, c.Database.SSLMode,
+		c.Database.Name, c.Database.SSLMode, c.Database.SSLRootCertPath, c.Database.SSLCertPath, c.Database.SSLKeyPath,
+		c.Database.QueryTimeout.String(), c.Database.SlowQueryThreshold.String(), c.Database.VerifySchemaVersion,
 		c.ProvisioningTimeout.ClusterCreation.String(),
-		c.ProvisioningTimeout.Installation.String(), c.ProvisioningTimeout.Upgrade.String(),
+		c.ProvisioningTimeout.Installation.String(), c.ProvisioningTimeout.MaxInstallation.String(), c.ProvisioningTimeout.Upgrade.String(),
 		c.ProvisioningTimeout.AgentConfiguration.String(), c.ProvisioningTimeout.AgentConnection.String(),
 		c.DeprovisioningTimeout.ClusterDeletion.String(), c.DeprovisioningTimeout.WaitingForClusterDeletion.String(),
+		c.UpgradeHealthChecks.Enabled, c.UpgradeHealthChecks.Deployments, c.UpgradeHealthChecks.Timeout.String(),
+		c.ShootUpgradeAgentReconnection.Enabled, c.ShootUpgradeAgentReconnection.Window.String(),
 		c.OperatorRoleBinding.L2SubjectName, c.OperatorRoleBinding.L3SubjectName, c.OperatorRoleBinding.CreatingForAdmin,
-		c.Gardener.Project, c.Gardener.KubeconfigPath, c.Gardener.AuditLogsPolicyConfigMap, c.Gardener.AuditLogsTenantConfigPath,
-		c.Gardener.ForceAllowPrivilegedContainers,
-		c.LatestDownloadedReleases, c.DownloadPreReleases,
-		c.EnqueueInProgressOperations,
+		c.OperatorRoleBinding.ConfigPath, c.OperatorRoleBinding.DeleteUnconfigured,
+		c.Gardener.Project, c.Gardener.Landscapes, c.Gardener.KubeconfigPath, c.Gardener.AuditLogsPolicyConfigMap, c.Gardener.AuditLogsTenantConfigPath,
+		c.Gardener.ForceAllowPrivilegedContainers, c.Gardener.DefaultNetworkingType, c.Gardener.CloudProfileCacheTTL.String(), c.Gardener.PreflightChecksEnabled, c.Gardener.QPS, c.Gardener.Burst, c.Gardener.ShootAnnotationsAllowedPrefixes, c.Gardener.AllowedFeatureGates,
+		c.Gardener.DefaultGCPEnableSecureBoot, c.Gardener.DefaultGCPEnableIntegrityMonitoring, c.Gardener.DefaultGCPEnableVtpm,
+		c.Gardener.DefaultAWSHttpTokens, c.Gardener.DefaultAWSHttpPutResponseHopLimit, c.Gardener.DefaultSystemPoolSize, c.Gardener.DefaultSystemPoolMachineType,
+		c.Gardener.Kubeconfig.Mode, c.Gardener.Kubeconfig.Expiration.String(), c.Gardener.Kubeconfig.RenewBefore.String(),
+		c.Gardener.ShootName.Prefix, c.Gardener.ShootName.Length, c.Gardener.ShootName.MaxAttempts, c.Gardener.ShootName.DomainSuffix,
+		c.LatestDownloadedReleases, c.DownloadPreReleases, c.ReleaseDownload.Concurrency, c.ReleaseDownload.Timeout.String(), c.ReleasePruning.Enabled, c.ReleasePruning.MinAge.String(),
+		c.EnqueueInProgressOperations, c.EnqueueInProgressOperationsWindow.String(), c.ResumeKymaInstallation, c.DefaultKymaProfile, c.ExtraKymaComponentsAllowed,
+		c.ProvisioningLimitPerGlobalAccount, c.ProvisioningLimitsConfigPath,
+		c.RegionPolicy.ConfigPath, c.RegionPolicy.ReloadInterval.String(),
+		c.MaintenanceFreeze.ConfigPath, c.MaintenanceFreeze.ReloadInterval.String(),
+		c.ReadOnlyMode.Enabled, c.ReadOnlyMode.Message, c.ReadOnlyMode.RefreshInterval.String(),
+		c.AuditLog.BufferSize, c.AuditLog.QueryEnabled,
+		c.OperationProgress.SampleSize, c.OperationProgress.MinSamples,
+		c.OrphanedShoots.DetectionInterval.String(), c.LastOperations.RepairInterval.String(), c.RuntimeExpiration.CheckInterval.String(), c.ScheduledDeprovisioning.ResyncInterval.String(),
+		c.OperationClaims.Enabled, c.OperationClaims.Owner, c.OperationClaims.TTL.String(),
+		c.ShootController.ResyncPeriod.String(), c.ShootController.MaxIdleTime.String(),
+		c.RuntimeStatuses.MaxBatchSize, c.RuntimeStatuses.StrictTenancy,
+		c.StrictSubAccount, c.IgnoreUnknownInputFields, c.AdminTenants, c.IdempotencyKeyTTL.String(),
+		c.K8sClientCache.TTL.String(), c.K8sClientCache.MaxEntries,
+		c.Server.ReadTimeout.String(), c.Server.ReadHeaderTimeout.String(), c.Server.WriteTimeout.String(), c.Server.IdleTimeout.String(), c.Server.MaxRequestBodySize,
+		c.EnableProfiler, c.Profiler.MutexProfileFraction, c.Profiler.BlockProfileRate,
+		c.EnableRuntimesExport, c.RuntimesExport.BatchSize,
+		c.EnableTracing, c.Tracing.Endpoint, c.Tracing.Insecure, c.Tracing.SampleRatio,
 		c.LogLevel)
 }
 
@@ -149,10 +368,39 @@ func main() {
 	log.Infof("Starting Provisioner")
 	log.Infof("Config: %s", cfg.String())
 
-	connString := fmt.Sprintf(connStringFormat, cfg.Database.Host, cfg.Database.Port, cfg.Database.User,
-		cfg.Database.Password, cfg.Database.Name, cfg.Database.SSLMode)
+	// Listeners are bound before any component starts so that invalid addresses are detected at startup
+	apiListener, err := net.Listen("tcp", cfg.Address)
+	exitOnError(err, "Failed to listen on API address")
+
+	metricsListener, err := net.Listen("tcp", cfg.MetricsAddress)
+	exitOnError(err, "Failed to listen on metrics address")
+
+	err = cfg.Database.Validate()
+	exitOnError(err, "Invalid database configuration")
+
+	gardenerProjects := gardener.NewProjects(cfg.Gardener.Project, cfg.Gardener.Landscapes)
+
+	defaultNetworkingType := model.NetworkingType(cfg.Gardener.DefaultNetworkingType)
+	if !defaultNetworkingType.IsSupported() {
+		exitOnError(fmt.Errorf("networking type %s is not supported", defaultNetworkingType), "Invalid default Gardener networking type")
+	}
+
+	defaultAWSInstanceMetadataOptions := model.AWSInstanceMetadataOptions{
+		HTTPTokens:              cfg.Gardener.DefaultAWSHttpTokens,
+		HTTPPutResponseHopLimit: cfg.Gardener.DefaultAWSHttpPutResponseHopLimit,
+	}
+	err = defaultAWSInstanceMetadataOptions.Validate()
+	exitOnError(err, "Invalid default AWS instance metadata options")
+
+	defaultSystemWorkerPool := model.SystemWorkerPool{
+		Size:        cfg.Gardener.DefaultSystemPoolSize,
+		MachineType: cfg.Gardener.DefaultSystemPoolMachineType,
+	}
+	err = defaultSystemWorkerPool.Validate()
+	exitOnError(err, "Invalid default dedicated system pool")
 
-	gardenerNamespace := fmt.Sprintf("garden-%s", cfg.Gardener.Project)
+	defaultKymaProfile, err := model.ParseKymaProfile(cfg.DefaultKymaProfile)
+	exitOnError(err, "Invalid default Kyma profile")
 
 	gardenerClusterConfig, err := newGardenerClusterConfig(cfg)
 	exitOnError(err, "Failed to initialize Gardener cluster client")
@@ -163,27 +411,64 @@ func main() {
 	k8sCoreClientSet, err := kubernetes.NewForConfig(gardenerClusterConfig)
 	exitOnError(err, "Failed to create Kubernetes clientset")
 
-	secretsInterface := k8sCoreClientSet.CoreV1().Secrets(gardenerNamespace)
+	err = cfg.Gardener.Kubeconfig.Validate()
+	exitOnError(err, "Invalid Gardener kubeconfig configuration")
 
-	shootClient := gardenerClientSet.Shoots(gardenerNamespace)
+	secretClients := gardener.NewSecretClients(gardenerProjects, k8sCoreClientSet.CoreV1())
+	adminKubeconfigClients := gardener.NewAdminKubeconfigClients(gardenerProjects, gardenerClientSet.RESTClient())
+	kubeconfigProvider := gardener.NewKubeconfigProvider(secretClients, adminKubeconfigClients, cfg.Gardener.Kubeconfig)
 
-	connection, err := database.InitializeDatabaseConnection(connString, databaseConnectionRetries)
+	shootClients := gardener.NewShootClients(gardenerProjects, gardenerClientSet)
+
+	cloudProfileVersions := gardener.NewCloudProfileVersions(gardenerClientSet.CloudProfiles(), cfg.Gardener.CloudProfileCacheTTL)
+
+	connection, err := database.InitializeDatabaseConnection(cfg.Database.ConnectionString(), databaseConnectionRetries)
 	exitOnError(err, "Failed to initialize persistence")
 
+	schemaStatus := database.NewSchemaStatus(connection, database.MinSchemaVersion)
+
 	installationHandlerConstructor := func(c *rest.Config, o ...installationSDK.InstallationOption) (installationSDK.Installer, error) {
 		return installationSDK.NewKymaInstaller(c, o...)
 	}
 
-	dbsFactory := dbsession.NewFactory(connection)
+	uuidGenerator := uuid.NewUUIDGenerator()
+
+	dbsFactory := dbsession.NewFactory(connection, cfg.Database.QueryTimeout, cfg.Database.SlowQueryThreshold, uuidGenerator)
 	installationService := installation.NewInstallationService(cfg.ProvisioningTimeout.Installation, installationHandlerConstructor, cfg.Gardener.ClusterCleanupResourceSelector)
 
-	directorClient, err := newDirectorClient(cfg)
-	exitOnError(err, "Failed to initialize Director client")
+	tracingProvider, err := tracing.NewProvider(cfg.EnableTracing, cfg.Tracing)
+	exitOnError(err, "Failed to initialize tracing")
+
+	oauthClient, err := newOauthClient(cfg, tracingProvider)
+	exitOnError(err, "Failed to initialize OAuth client")
 
-	k8sClientProvider := k8s.NewK8sClientProvider()
+	directorClient := director.NewStatusConditionBatcher(newDirectorClient(cfg, oauthClient, tracingProvider), cfg.DirectorStatusUpdates)
+
+	k8sClientProvider := k8s.NewK8sClientProvider(cfg.K8sClientCache)
 
 	runtimeConfigurator := runtime.NewRuntimeConfigurator(k8sClientProvider, directorClient)
 
+	progressEstimator := operations.NewProgressEstimator(dbsFactory.NewReadSession(), cfg.OperationProgress.SampleSize, cfg.OperationProgress.MinSamples)
+
+	var operationClaimer *queue.OperationClaimer
+	if cfg.OperationClaims.Enabled {
+		owner := cfg.OperationClaims.Owner
+		if owner == "" {
+			owner, err = os.Hostname()
+			exitOnError(err, "Failed to get hostname identifying the replica claiming operations")
+		}
+		operationClaimer = queue.NewOperationClaimer(dbsFactory.NewWriteSession(), owner, cfg.OperationClaims.TTL, clock.RealClock{})
+	}
+
+	// The static kubeconfigs do not expire, so the kubeconfigs stored on the clusters are renewed only in the admin mode
+	var kubeconfigRenewer *operations.KubeconfigRenewer
+	if cfg.Gardener.Kubeconfig.Mode == gardener.KubeconfigModeAdmin {
+		kubeconfigRenewer = operations.NewKubeconfigRenewer(kubeconfigProvider, dbsFactory.NewWriteSession())
+	}
+
+	operatorBindings, err := provisioningStages.LoadOperatorBindings(cfg.OperatorRoleBinding)
+	exitOnError(err, "Failed to load bindings for operators")
+
 	provisioningQueue := queue.CreateProvisioningQueue(
 		cfg.ProvisioningTimeout,
 		dbsFactory,
@@ -191,39 +476,72 @@ func main() {
 		runtimeConfigurator,
 		provisioningStages.NewCompassConnectionClient,
 		directorClient,
-		shootClient,
-		secretsInterface,
+		shootClients,
+		kubeconfigProvider,
+		kubeconfigRenewer,
 		cfg.OperatorRoleBinding,
-		k8sClientProvider)
+		operatorBindings,
+		k8sClientProvider,
+		cfg.ResumeKymaInstallation,
+		progressEstimator,
+		operationClaimer)
+
+	upgradeQueue := queue.CreateUpgradeQueue(cfg.ProvisioningTimeout, dbsFactory, directorClient, installationService, k8sClientProvider, cfg.UpgradeHealthChecks, kubeconfigRenewer, progressEstimator, operationClaimer)
 
-	upgradeQueue := queue.CreateUpgradeQueue(cfg.ProvisioningTimeout, dbsFactory, directorClient, installationService)
+	deprovisioningQueue := queue.CreateDeprovisioningQueue(cfg.DeprovisioningTimeout, dbsFactory, installationService, directorClient, shootClients, 5*time.Minute, kubeconfigRenewer, progressEstimator, operationClaimer)
 
-	deprovisioningQueue := queue.CreateDeprovisioningQueue(cfg.DeprovisioningTimeout, dbsFactory, installationService, directorClient, shootClient, 5*time.Minute)
+	shootUpgradeQueue := queue.CreateShootUpgradeQueue(cfg.ProvisioningTimeout, dbsFactory, directorClient, shootClients, cfg.OperatorRoleBinding, operatorBindings, k8sClientProvider, provisioningStages.NewCompassConnectionClient, cfg.ShootUpgradeAgentReconnection, kubeconfigRenewer, progressEstimator, operationClaimer)
 
-	shootUpgradeQueue := queue.CreateShootUpgradeQueue(cfg.ProvisioningTimeout, dbsFactory, directorClient, shootClient, cfg.OperatorRoleBinding, k8sClientProvider)
+	hibernationQueue := queue.CreateHibernationQueue(cfg.HibernationTimeout, dbsFactory, directorClient, shootClients, progressEstimator, operationClaimer)
 
-	hibernationQueue := queue.CreateHibernationQueue(cfg.HibernationTimeout, dbsFactory, directorClient, shootClient)
+	var preflightChecker gardener.PreflightChecker
+	var secretBindingValidator api.SecretBindingValidator
+	if cfg.Gardener.PreflightChecksEnabled {
+		shootPreflightChecker := gardener.NewShootPreflightChecker(gardenerProjects, gardenerClientSet, k8sCoreClientSet)
+		preflightChecker = shootPreflightChecker
+		secretBindingValidator = shootPreflightChecker
+	}
 
-	provisioner := gardener.NewProvisioner(gardenerNamespace, shootClient, dbsFactory, cfg.Gardener.AuditLogsPolicyConfigMap, cfg.Gardener.MaintenanceWindowConfigPath)
-	shootController, err := newShootController(gardenerNamespace, gardenerClusterConfig, dbsFactory, cfg.Gardener.AuditLogsTenantConfigPath)
+	provisioner := gardener.NewProvisioner(shootClients, dbsFactory, cfg.Gardener.AuditLogsPolicyConfigMap, cfg.Gardener.MaintenanceWindowConfigPath, preflightChecker)
+	shootController, err := newShootController(gardenerProjects.Namespaces(), gardenerClusterConfig, dbsFactory, cfg.Gardener.AuditLogsTenantConfigPath, shootClients.ForProject(gardenerProjects.Default()), cfg.ShootController.ResyncPeriod, cfg.ShootController.MaxIdleTime)
 	exitOnError(err, "Failed to create Shoot controller.")
-	go func() {
-		err := shootController.StartShootController()
-		exitOnError(err, "Failed to start Shoot Controller")
-	}()
 
-	httpClient := newHTTPClient(false)
-	fileDownloader := release.NewFileDownloader(httpClient)
+	httpClient := newHTTPClient(false, tracingProvider)
+	fileDownloader := release.NewFileDownloader(httpClient, cfg.ReleaseDownload.Timeout)
 
-	releaseRepository := release.NewReleaseRepository(connection, uuid.NewUUIDGenerator())
+	releaseRepository := release.NewReleaseRepository(connection, uuidGenerator)
 	gcsDownloader := release.NewGCSDownloader(fileDownloader)
 
 	releaseProvider := release.NewReleaseProvider(releaseRepository, gcsDownloader)
 
+	provisioningLimits, err := provisioning.LoadProvisioningLimits(cfg.ProvisioningLimitPerGlobalAccount, cfg.ProvisioningLimitsConfigPath)
+	exitOnError(err, "Failed to load provisioning limits")
+
+	provisioningThrottle := provisioning.NewProvisioningThrottle(provisioningLimits, dbsFactory)
+	pendingProvisioningStarter := provisioning.NewPendingProvisioningStarter(provisioningThrottle, dbsFactory, provisioner, provisioningQueue)
+
+	// Shoots younger than the cluster creation timeout may belong to the clusters being provisioned
+	shootListers := []gardener.ShootLister{}
+	for _, project := range gardenerProjects.Names() {
+		shootListers = append(shootListers, shootClients.ForProject(project))
+	}
+	orphanedShootsDetector := gardener.NewOrphanedShootsDetector(shootListers, dbsFactory.NewReadSession(), cfg.ProvisioningTimeout.ClusterCreation)
+
+	err = cfg.Gardener.ShootName.Validate(gardenerProjects.Names())
+	exitOnError(err, "Invalid Shoot name configuration")
+	shootNameGenerator := gardener.NewShootNameGenerator(cfg.Gardener.ShootName, gardenerProjects, shootClients, dbsFactory.NewReadSession(), uuidGenerator)
+
+	regionPolicy, err := regionpolicy.NewLoader(cfg.RegionPolicy.ConfigPath, log.WithField("component", "region-policy"))
+	exitOnError(err, "Failed to load region policy")
+
+	maintenanceFreeze, err := freeze.NewLoader(cfg.MaintenanceFreeze.ConfigPath, []freeze.PausableQueue{upgradeQueue, shootUpgradeQueue}, log.WithField("component", "maintenance-freeze"))
+	exitOnError(err, "Failed to load maintenance freeze config")
+
 	provisioningSVC := newProvisioningService(
 		cfg.Gardener.Project,
 		provisioner,
 		dbsFactory,
+		uuidGenerator,
 		releaseProvider,
 		directorClient,
 		provisioningQueue,
@@ -231,29 +549,59 @@ func main() {
 		upgradeQueue,
 		shootUpgradeQueue,
 		hibernationQueue,
+		provisioningThrottle,
+		progressEstimator,
+		cloudProfileVersions,
+		cfg.ProvisioningTimeout.Installation,
+		orphanedShootsDetector,
+		provisioning.RuntimeStatusesConfig{MaxBatchSize: cfg.RuntimeStatuses.MaxBatchSize, StrictTenancy: cfg.RuntimeStatuses.StrictTenancy},
+		cfg.IdempotencyKeyTTL,
+		cloudProfileVersions,
+		regionPolicy,
 		cfg.Gardener.DefaultEnableKubernetesVersionAutoUpdate,
 		cfg.Gardener.DefaultEnableMachineImageVersionAutoUpdate,
-		cfg.Gardener.ForceAllowPrivilegedContainers)
-
-	validator := api.NewValidator(dbsFactory.NewReadSession())
-	resolver := api.NewResolver(provisioningSVC, validator)
+		cfg.Gardener.ForceAllowPrivilegedContainers,
+		defaultNetworkingType,
+		model.GCPShieldedInstanceConfig{
+			EnableSecureBoot:          cfg.Gardener.DefaultGCPEnableSecureBoot,
+			EnableIntegrityMonitoring: cfg.Gardener.DefaultGCPEnableIntegrityMonitoring,
+			EnableVtpm:                cfg.Gardener.DefaultGCPEnableVtpm,
+		},
+		defaultAWSInstanceMetadataOptions,
+		defaultSystemWorkerPool,
+		defaultKymaProfile,
+		cfg.ExtraKymaComponentsAllowed,
+		maintenanceFreeze,
+		shootNameGenerator)
+
+	validator := api.NewValidator(dbsFactory.NewReadSession(), secretBindingValidator, cfg.ProvisioningTimeout.MaxInstallation, cfg.Gardener.ShootAnnotationsAllowedPrefixes, cfg.Gardener.AllowedFeatureGates, gardenerProjects.Names(), cloudProfileVersions, regionPolicy, cfg.AdminTenants, maintenanceFreeze, shootNameGenerator)
+	readOnlyMode := readonly.NewMode(dbsFactory, cfg.ReadOnlyMode.Enabled, cfg.ReadOnlyMode.Message, log.WithField("component", "read-only-mode"))
+	deprovisioningScheduler := provisioning.NewDeprovisioningScheduler(provisioningThrottle, dbsFactory, provisioner, deprovisioningQueue, uuidGenerator)
+	resolver := api.NewResolver(provisioningSVC, validator, readOnlyMode, deprovisioningScheduler)
 	logger := log.WithField("Component", "Artifact Downloader")
-	downloader := release.NewArtifactsDownloader(releaseRepository, cfg.LatestDownloadedReleases, cfg.DownloadPreReleases, httpClient, fileDownloader, logger)
+	var releasePruner release.ReleasePruner
+	if cfg.ReleasePruning.Enabled {
+		releasePruner = release.NewPruner(releaseRepository, releaseProvider, cfg.LatestDownloadedReleases, cfg.ReleasePruning.MinAge, logger)
+	}
+	downloader := release.NewArtifactsDownloader(releaseRepository, cfg.LatestDownloadedReleases, cfg.DownloadPreReleases, cfg.ReleaseDownload.Concurrency, httpClient, fileDownloader, releasePruner, logger)
+
+	// Failure of any server or the Shoot controller cancels the context, which stops all components
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	group, ctx := errgroup.WithContext(signalCtx)
 
 	// Run release downloader
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	go downloader.FetchPeriodically(ctx, release.ShortInterval, release.LongInterval)
 
-	provisioningQueue.Run(ctx.Done())
-
-	deprovisioningQueue.Run(ctx.Done())
+	// Refresh Director token ahead of expiry
+	go oauthClient.Run(ctx.Done())
 
-	upgradeQueue.Run(ctx.Done())
+	// Send queued Runtime status conditions to Director in batches
+	go directorClient.Run(ctx.Done())
 
-	shootUpgradeQueue.Run(ctx.Done())
-
-	hibernationQueue.Run(ctx.Done())
+	// Store audit entries of mutations in the background
+	auditLog := audit.NewLog(dbsFactory.NewWriteSession(), cfg.AuditLog.BufferSize)
+	go auditLog.Run(ctx.Done())
 
 	gqlCfg := gqlschema.Config{
 		Resolvers: resolver,
@@ -264,54 +612,191 @@ func main() {
 
 	log.Infof("Registering endpoint on %s...", cfg.APIEndpoint)
 	router := mux.NewRouter()
+	router.Use(middlewares.LimitRequestBody(cfg.Server.MaxRequestBodySize))
 	router.Use(middlewares.ExtractTenant)
+	router.Use(middlewares.ExtractCorrelationID)
+	router.Use(tracingProvider.Middleware)
+	router.Use(middlewares.AddSchemaVersionHeader)
+
+	graphQLOptions := []handler.Option{
+		handler.ErrorPresenter(presenter.Do),
+		handler.RecoverFunc(recovery.NewGraphQLRecoverFunc(log.StandardLogger())),
+		handler.ResolverMiddleware(audit.NewQueryGuard(cfg.AuditLog.QueryEnabled)),
+		handler.ResolverMiddleware(middlewares.RequireSubAccount(cfg.StrictSubAccount, log.StandardLogger())),
+		handler.ResolverMiddleware(readOnlyMode.Guard()),
+		handler.ResolverMiddleware(audit.NewResolverMiddleware(auditLog, uuidGenerator)),
+		handler.RequestMiddleware(api.ReportIgnoredInputFields),
+	}
+	if tracingProvider != nil {
+		graphQLOptions = append(graphQLOptions,
+			handler.RequestMiddleware(middlewares.TraceOperations(tracingProvider.Tracer())),
+			handler.ResolverMiddleware(middlewares.RecordOperationID))
+	}
+
+	var graphQLHandler http.Handler = handler.GraphQL(executableSchema, graphQLOptions...)
+	if cfg.IgnoreUnknownInputFields {
+		graphQLHandler = api.IgnoreUnknownInputFields(executableSchema.Schema(), log.StandardLogger())(graphQLHandler)
+	}
 
 	router.HandleFunc("/", handler.Playground("Dataloader", cfg.PlaygroundAPIEndpoint))
-	router.HandleFunc(cfg.APIEndpoint, handler.GraphQL(executableSchema, handler.ErrorPresenter(presenter.Do)))
-	router.HandleFunc("/healthz", healthz.NewHTTPHandler(log.StandardLogger()))
+	router.Handle(cfg.APIEndpoint, graphQLHandler)
+	router.HandleFunc("/healthz", healthz.NewHTTPHandler(log.StandardLogger(), schemaStatus, readOnlyMode))
+	router.HandleFunc("/readyz", healthz.NewReadinessHandler(log.StandardLogger(), shootController))
 
 	// Metrics
-	err = metrics.Register(dbsFactory.NewReadSession())
+	operationQueues := map[model.OperationType]queue.OperationQueue{
+		model.Provision:    provisioningQueue,
+		model.Deprovision:  deprovisioningQueue,
+		model.Upgrade:      upgradeQueue,
+		model.UpgradeShoot: shootUpgradeQueue,
+		model.Hibernate:    hibernationQueue,
+	}
+
+	err = metrics.Register(dbsFactory.NewReadSession(), dbsFactory.NewReadSession(), operationQueues, oauthClient)
 	exitOnError(err, "Failed to register metrics collectors")
 
 	// Expose metrics on different port as it cannot be secured with mTLS
 	metricsRouter := mux.NewRouter()
+	metricsRouter.Use(middlewares.LimitRequestBody(cfg.Server.MaxRequestBodySize))
 	metricsRouter.Handle("/metrics", promhttp.Handler())
+	profiler.Register(metricsRouter, cfg.EnableProfiler, cfg.Profiler, log.StandardLogger())
+	err = inventory.Register(metricsRouter, cfg.EnableRuntimesExport, cfg.RuntimesExport, dbsFactory.NewReadSession(), log.StandardLogger())
+	exitOnError(err, "Failed to register Runtime inventory export")
 
-	metricsServer := &http.Server{
-		Handler: metricsRouter,
-		Addr:    cfg.MetricsAddress,
-	}
+	apiServer := newServer(router, cfg)
+	metricsServer := newServer(metricsRouter, cfg)
 
 	log.Infof("API listening on %s...", cfg.Address)
 	log.Infof("Metrics API listening on %s...", cfg.MetricsAddress)
 
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
+	group.Go(func() error {
+		return serve(apiServer, apiListener, "API server")
+	})
 
-	go func() {
-		defer wg.Done()
+	group.Go(func() error {
+		return serve(metricsServer, metricsListener, "metrics server")
+	})
 
-		if err := http.ListenAndServe(cfg.Address, router); err != nil {
-			log.Errorf("Error starting server: %s", err.Error())
-		}
-	}()
+	group.Go(func() error {
+		<-ctx.Done()
+		shutdown(apiServer, metricsServer)
+		return nil
+	})
+
+	group.Go(func() error {
+		return shootController.StartShootController(ctx)
+	})
+
+	// The schema migrator runs after the Provisioner is deployed, so the servers are started before waiting for the migrations
+	// to report the schema version through the health check in the meantime
+	if cfg.Database.VerifySchemaVersion {
+		err = schemaStatus.WaitForMigrations(schemaVersionCheckAttempts, schemaVersionCheckDelay)
+		exitOnError(err, "Database schema is not up to date")
+	} else if _, err := schemaStatus.Refresh(); err != nil {
+		log.Warnf("Failed to check database schema version: %s", err.Error())
+	}
+	go schemaStatus.Run(schemaVersionRefreshPeriod, ctx.Done())
 
-	go func() {
-		if err := metricsServer.ListenAndServe(); err != nil {
-			log.Errorf("Error starting metrics server: %s", err.Error())
-		}
-	}()
+	// The read-only mode set before the restart is read right away, the configured one applies until then
+	go readOnlyMode.Run(cfg.ReadOnlyMode.RefreshInterval, ctx.Done())
+
+	// Paused state has to be restored before workers start processing operations
+	err = restoreQueuesState(dbsFactory, operationQueues)
+	exitOnError(err, "Failed to restore operation queues state")
+
+	// The active maintenance freeze pauses the upgrade queues after their state is restored, so that it is not overwritten
+	maintenanceFreeze.Sync()
+
+	provisioningQueue.Run(ctx.Done())
+
+	deprovisioningQueue.Run(ctx.Done())
+
+	upgradeQueue.Run(ctx.Done())
+
+	shootUpgradeQueue.Run(ctx.Done())
+
+	hibernationQueue.Run(ctx.Done())
+
+	go pendingProvisioningStarter.Run(ctx.Done())
+
+	go regionPolicy.Run(cfg.RegionPolicy.ReloadInterval, ctx.Done())
+
+	go maintenanceFreeze.Run(cfg.MaintenanceFreeze.ReloadInterval, ctx.Done())
+
+	go orphanedShootsDetector.Run(cfg.OrphanedShoots.DetectionInterval, ctx.Done())
+
+	go provisioning.NewLastOperationChecker(dbsFactory).Run(cfg.LastOperations.RepairInterval, ctx.Done())
+
+	go provisioning.NewRuntimeExpirer(provisioningThrottle, dbsFactory, provisioner, deprovisioningQueue, uuidGenerator).Run(cfg.RuntimeExpiration.CheckInterval, ctx.Done())
+
+	go deprovisioningScheduler.Run(cfg.ScheduledDeprovisioning.ResyncInterval, ctx.Done())
+
+	requeuer := queue.NewRequeuer(map[model.OperationType]queue.OperationQueue{
+		model.Provision:                 provisioningQueue,
+		model.Deprovision:               deprovisioningQueue,
+		model.CleanupFailedProvisioning: deprovisioningQueue,
+		model.Upgrade:                   upgradeQueue,
+		model.UpgradeShoot:              shootUpgradeQueue,
+		// Scheduled operations wait in their first stage until the scheduled time
+		model.Hibernate: hibernationQueue,
+		model.WakeUp:    hibernationQueue,
+	}, cfg.EnqueueInProgressOperationsWindow, rand.New(rand.NewSource(time.Now().UnixNano())), log.StandardLogger())
 
 	if cfg.EnqueueInProgressOperations {
-		err = enqueueOperationsInProgress(dbsFactory, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue)
+		err = enqueueOperationsInProgress(dbsFactory, requeuer, operationClaimer)
 		exitOnError(err, "Failed to enqueue in progress operations")
 	}
 
-	wg.Wait()
+	if operationClaimer != nil {
+		go operationClaimer.RunTakeover(dbsFactory.NewReadSession(), requeuer, ctx.Done())
+	}
+
+	err = group.Wait()
+	exitOnError(err, "Provisioner stopped due to failure")
+
+	shutdownTracing(tracingProvider)
+
+	log.Info("Provisioner stopped")
+}
+
+func newServer(handler http.Handler, cfg config) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+}
+
+func serve(server *http.Server, listener net.Listener, name string) error {
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return errors.Wrapf(err, "%s failed", name)
+	}
+	return nil
+}
+
+func shutdown(servers ...*http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+
+	for _, server := range servers {
+		if err := server.Shutdown(ctx); err != nil {
+			log.Errorf("Error shutting down server: %s", err.Error())
+		}
+	}
+}
+
+func shutdownTracing(tracingProvider *tracing.Provider) {
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+
+	if err := tracingProvider.Shutdown(ctx); err != nil {
+		log.Errorf("Error exporting remaining spans: %s", err.Error())
+	}
 }
 
-func enqueueOperationsInProgress(dbFactory dbsession.Factory, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue queue.OperationQueue) error {
+func enqueueOperationsInProgress(dbFactory dbsession.Factory, requeuer *queue.Requeuer, claimer *queue.OperationClaimer) error {
 	readSession := dbFactory.NewReadSession()
 
 	var inProgressOps []model.Operation
@@ -326,37 +811,56 @@ func enqueueOperationsInProgress(dbFactory dbsession.Factory, provisioningQueue,
 			return err
 		}
 		return nil
-	}, retry.Attempts(30), retry.DelayType(retry.FixedDelay), retry.Delay(5*time.Second))
+	}, retry.Attempts(30), retry.DelayType(retry.FixedDelay), retry.Delay(5*time.Second), retry.RetryIf(isRetryableDBError))
 	if err != nil {
 		return fmt.Errorf("error enqueuing in progress operations: %s", err.Error())
 	}
 
-	for _, op := range inProgressOps {
-		if op.Type == model.Provision {
-			provisioningQueue.Add(op.ID)
-			continue
-		}
+	// Operations processed by other replicas are not enqueued, they are taken over only once their claims expire
+	requeuer.Requeue(claimer.Unclaimed(inProgressOps))
 
-		if op.Type == model.Deprovision {
-			deprovisioningQueue.Add(op.ID)
-		}
+	return nil
+}
+
+func restoreQueuesState(dbFactory dbsession.Factory, operationQueues map[model.OperationType]queue.OperationQueue) error {
+	readSession := dbFactory.NewReadSession()
+
+	var queueStates []model.QueueState
+	var err error
 
-		if op.Type == model.Upgrade {
-			upgradeQueue.Add(op.ID)
+	err = retry.Do(func() error {
+		queueStates, err = readSession.ListQueueStates()
+		if err != nil {
+			log.Warnf("failed to list operation queues state")
+			return err
 		}
+		return nil
+	}, retry.Attempts(30), retry.DelayType(retry.FixedDelay), retry.Delay(5*time.Second), retry.RetryIf(isRetryableDBError))
+	if err != nil {
+		return fmt.Errorf("error restoring operation queues state: %s", err.Error())
+	}
 
-		if op.Type == model.UpgradeShoot {
-			shootUpgradeQueue.Add(op.ID)
+	for _, state := range queueStates {
+		operationQueue, found := operationQueues[state.OperationType]
+		if !found {
+			continue
 		}
 
-		if op.Type == model.Hibernate {
-			hibernationQueue.Add(op.ID)
+		if state.Paused {
+			log.Infof("Operation queue %s is paused", state.OperationType)
 		}
+		operationQueue.SetPaused(state.Paused)
 	}
 
 	return nil
 }
 
+// isRetryableDBError stops waiting for the database on failures which do not disappear with time, e.g. invalid credentials
+func isRetryableDBError(err error) bool {
+	dbErr, ok := err.(dberrors.Error)
+	return ok && dbErr.Retryable()
+}
+
 func exitOnError(err error, context string) {
 	if err != nil {
 		wrappedError := errors.Wrap(err, context)
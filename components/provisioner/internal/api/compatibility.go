@@ -0,0 +1,310 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/sirupsen/logrus"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/lexer"
+	"github.com/vektah/gqlparser/parser"
+)
+
+// ignoredInputFieldsKey is the context key of the input fields removed from the request by IgnoreUnknownInputFields
+type ignoredInputFieldsKey struct{}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// IgnoreUnknownInputFields removes the input fields which are not defined in the schema from the GraphQL requests,
+// so that the requests of the clients using a newer schema with additional optional fields do not fail as a whole.
+// The removed fields are logged and returned in the warnings of the response by ReportIgnoredInputFields
+func IgnoreUnknownInputFields(schema *ast.Schema, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.Body == nil || r.Body == http.NoBody || isMultipart(r) {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to read request body: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+
+			filtered, ignored := removeUnknownInputFields(schema, body)
+			if len(ignored) > 0 {
+				log.Warnf("Ignoring input fields not defined in the schema version %s: %s", gqlschema.SchemaVersion, strings.Join(ignored, ", "))
+				body = filtered
+				r = r.WithContext(context.WithValue(r.Context(), ignoredInputFieldsKey{}, ignored))
+			}
+
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ReportIgnoredInputFields adds the input fields removed by IgnoreUnknownInputFields to the warnings of the response
+func ReportIgnoredInputFields(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+	ignored, _ := ctx.Value(ignoredInputFieldsKey{}).([]string)
+	for _, field := range ignored {
+		addWarning(ctx, "Input field %s is not defined in the schema version %s and was ignored", field, gqlschema.SchemaVersion)
+	}
+
+	return next(ctx)
+}
+
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// removeUnknownInputFields returns the request without the unknown input fields of the literal values and variables.
+// Requests which cannot be parsed are returned unchanged, so that the errors are reported by the GraphQL handler
+func removeUnknownInputFields(schema *ast.Schema, body []byte) ([]byte, []string) {
+	var request graphQLRequest
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil {
+		return body, nil
+	}
+
+	document, gqlErr := parser.ParseQuery(&ast.Source{Input: request.Query})
+	if gqlErr != nil {
+		return body, nil
+	}
+
+	collector := &unknownInputFieldsCollector{
+		schema:           schema,
+		document:         document,
+		visitedFragments: map[string]bool{},
+	}
+	for _, operation := range document.Operations {
+		collector.collectSelections(rootType(schema, operation.Operation), operation.SelectionSet)
+
+		for _, variable := range operation.VariableDefinitions {
+			if value, found := request.Variables[variable.Variable]; found {
+				collector.removeFromVariable(value, variable.Type)
+			}
+		}
+	}
+
+	if len(collector.ignored) == 0 {
+		return body, nil
+	}
+
+	query, err := removeLiteralFields(request.Query, collector.literalFields)
+	if err != nil {
+		return body, nil
+	}
+	request.Query = query
+
+	filtered, err := json.Marshal(request)
+	if err != nil {
+		return body, nil
+	}
+
+	sort.Strings(collector.ignored)
+	return filtered, collector.ignored
+}
+
+type unknownInputFieldsCollector struct {
+	schema           *ast.Schema
+	document         *ast.QueryDocument
+	visitedFragments map[string]bool
+
+	literalFields []*ast.ChildValue
+	ignored       []string
+}
+
+func (c *unknownInputFieldsCollector) collectSelections(parent *ast.Definition, selections ast.SelectionSet) {
+	if parent == nil {
+		return
+	}
+
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			fieldDefinition := parent.Fields.ForName(selection.Name)
+			if fieldDefinition == nil {
+				continue
+			}
+			for _, argument := range selection.Arguments {
+				if argumentDefinition := fieldDefinition.Arguments.ForName(argument.Name); argumentDefinition != nil {
+					c.collectFromValue(argument.Value, argumentDefinition.Type)
+				}
+			}
+			c.collectSelections(c.schema.Types[fieldDefinition.Type.Name()], selection.SelectionSet)
+		case *ast.InlineFragment:
+			typeCondition := parent
+			if selection.TypeCondition != "" {
+				typeCondition = c.schema.Types[selection.TypeCondition]
+			}
+			c.collectSelections(typeCondition, selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if c.visitedFragments[selection.Name] {
+				continue
+			}
+			c.visitedFragments[selection.Name] = true
+			if fragment := c.document.Fragments.ForName(selection.Name); fragment != nil {
+				c.collectSelections(c.schema.Types[fragment.TypeCondition], fragment.SelectionSet)
+			}
+		}
+	}
+}
+
+func (c *unknownInputFieldsCollector) collectFromValue(value *ast.Value, valueType *ast.Type) {
+	if value == nil || valueType == nil {
+		return
+	}
+
+	switch value.Kind {
+	case ast.ListValue:
+		for _, child := range value.Children {
+			c.collectFromValue(child.Value, valueType.Elem)
+		}
+	case ast.ObjectValue:
+		definition := c.inputObject(valueType)
+		if definition == nil {
+			return
+		}
+		for _, child := range value.Children {
+			fieldDefinition := definition.Fields.ForName(child.Name)
+			if fieldDefinition == nil {
+				c.literalFields = append(c.literalFields, child)
+				c.ignored = append(c.ignored, fmt.Sprintf("%s.%s", definition.Name, child.Name))
+				continue
+			}
+			c.collectFromValue(child.Value, fieldDefinition.Type)
+		}
+	}
+}
+
+func (c *unknownInputFieldsCollector) removeFromVariable(value interface{}, valueType *ast.Type) {
+	if valueType == nil {
+		return
+	}
+
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			c.removeFromVariable(item, valueType.Elem)
+		}
+	case map[string]interface{}:
+		definition := c.inputObject(valueType)
+		if definition == nil {
+			return
+		}
+		for key, item := range value {
+			fieldDefinition := definition.Fields.ForName(key)
+			if fieldDefinition == nil {
+				delete(value, key)
+				c.ignored = append(c.ignored, fmt.Sprintf("%s.%s", definition.Name, key))
+				continue
+			}
+			c.removeFromVariable(item, fieldDefinition.Type)
+		}
+	}
+}
+
+func (c *unknownInputFieldsCollector) inputObject(valueType *ast.Type) *ast.Definition {
+	definition := c.schema.Types[valueType.Name()]
+	if definition == nil || definition.Kind != ast.InputObject {
+		return nil
+	}
+	return definition
+}
+
+func rootType(schema *ast.Schema, operation ast.Operation) *ast.Definition {
+	switch operation {
+	case ast.Query:
+		return schema.Query
+	case ast.Mutation:
+		return schema.Mutation
+	default:
+		return schema.Subscription
+	}
+}
+
+// removeLiteralFields cuts the fields out of the query starting from the last one, so that the positions of the other fields do not change
+func removeLiteralFields(query string, fields []*ast.ChildValue) (string, error) {
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Position.Start > fields[j].Position.Start
+	})
+
+	for _, field := range fields {
+		end, err := literalFieldEnd(query, field)
+		if err != nil {
+			return "", err
+		}
+		query = query[:field.Position.Start] + query[end:]
+	}
+
+	return query, nil
+}
+
+// literalFieldEnd returns the position in the query right after the value of the field
+func literalFieldEnd(query string, field *ast.ChildValue) (int, error) {
+	if field.Position == nil {
+		return 0, fmt.Errorf("position of field %s is unknown", field.Name)
+	}
+	start := field.Position.Start
+
+	lex := lexer.New(&ast.Source{Input: query[start:]})
+
+	name, gqlErr := lex.ReadToken()
+	if gqlErr != nil {
+		return 0, gqlErr
+	}
+	if name.Kind != lexer.Name || name.Value != field.Name {
+		return 0, fmt.Errorf("field %s not found at position %d", field.Name, start)
+	}
+	colon, gqlErr := lex.ReadToken()
+	if gqlErr != nil {
+		return 0, gqlErr
+	}
+	if colon.Kind != lexer.Colon {
+		return 0, fmt.Errorf("value of field %s not found at position %d", field.Name, start)
+	}
+
+	depth := 0
+	for {
+		token, gqlErr := lex.ReadToken()
+		if gqlErr != nil {
+			return 0, gqlErr
+		}
+
+		switch token.Kind {
+		case lexer.EOF:
+			return 0, fmt.Errorf("value of field %s is not terminated", field.Name)
+		case lexer.BraceL, lexer.BracketL:
+			depth++
+			continue
+		case lexer.BraceR, lexer.BracketR:
+			depth--
+		case lexer.Dollar:
+			// The name of the variable follows
+			continue
+		}
+
+		if depth == 0 {
+			return start + token.Pos.End, nil
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+	gqlvalidator "github.com/vektah/gqlparser/validator"
+)
+
+const compatibilityTestSchema = `
+type Query {
+    runtime(id: String!): Runtime
+}
+
+type Mutation {
+    provision(input: ProvisionInput!): Runtime
+}
+
+type Runtime {
+    id: String!
+}
+
+input ProvisionInput {
+    name: String!
+    labels: [String!]
+    config: ConfigInput
+}
+
+input ConfigInput {
+    zones: [String!]
+}
+`
+
+func TestIgnoreUnknownInputFields(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: compatibilityTestSchema})
+
+	serve := func(body string) (*http.Request, string) {
+		var received *http.Request
+		var receivedBody string
+		handler := IgnoreUnknownInputFields(schema, logrus.New())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			content, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			received = r
+			receivedBody = string(content)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+
+		require.NotNil(t, received)
+		return received, receivedBody
+	}
+
+	t.Run("should remove unknown fields of literal values", func(t *testing.T) {
+		// given
+		query := `mutation { provision(input: {name: "test", newConfig: {nested: [1, {a: "}"}]}, labels: ["a"], config: {zones: ["z"], newZone: $zone}, newFlag: true}) { id } }`
+		body, err := json.Marshal(graphQLRequest{Query: query})
+		require.NoError(t, err)
+
+		// when
+		request, received := serve(string(body))
+
+		// then
+		var filtered graphQLRequest
+		require.NoError(t, json.Unmarshal([]byte(received), &filtered))
+		assert.Equal(t, `mutation { provision(input: {name: "test", , labels: ["a"], config: {zones: ["z"], }, }) { id } }`, filtered.Query)
+		assert.Empty(t, validationErrors(t, schema, filtered.Query))
+		assert.Equal(t, []string{"ConfigInput.newZone", "ProvisionInput.newConfig", "ProvisionInput.newFlag"}, request.Context().Value(ignoredInputFieldsKey{}))
+	})
+
+	t.Run("should remove unknown fields of variables", func(t *testing.T) {
+		// given
+		body := `{"query": "mutation ($input: ProvisionInput!) { provision(input: $input) { id } }", ` +
+			`"variables": {"input": {"name": "test", "newCount": 3, "config": {"zones": ["z"], "newZone": "x"}}}}`
+
+		// when
+		request, received := serve(body)
+
+		// then
+		var filtered graphQLRequest
+		require.NoError(t, json.Unmarshal([]byte(received), &filtered))
+		assert.Equal(t, map[string]interface{}{
+			"input": map[string]interface{}{
+				"name":   "test",
+				"config": map[string]interface{}{"zones": []interface{}{"z"}},
+			},
+		}, filtered.Variables)
+		assert.Equal(t, []string{"ConfigInput.newZone", "ProvisionInput.newCount"}, request.Context().Value(ignoredInputFieldsKey{}))
+	})
+
+	for _, testCase := range []struct {
+		description string
+		body        string
+	}{
+		{
+			description: "should pass request without unknown fields unchanged",
+			body:        `{"query": "mutation { provision(input: {name: \"test\", config: {zones: [\"z\"]}}) { id } }"}`,
+		},
+		{
+			description: "should pass request with invalid query unchanged",
+			body:        `{"query": "mutation { provision(input: {name: \"test\", newFlag: true}) { id }"}`,
+		},
+		{
+			description: "should pass request with invalid body unchanged",
+			body:        `{"query": `,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			request, received := serve(testCase.body)
+
+			// then
+			assert.Equal(t, testCase.body, received)
+			assert.Nil(t, request.Context().Value(ignoredInputFieldsKey{}))
+		})
+	}
+}
+
+func TestReportIgnoredInputFields(t *testing.T) {
+	// given
+	requestContext := &graphql.RequestContext{}
+	ctx := context.WithValue(context.Background(), ignoredInputFieldsKey{}, []string{"ProvisionInput.newFlag"})
+	ctx = graphql.WithRequestContext(ctx, requestContext)
+
+	// when
+	response := ReportIgnoredInputFields(ctx, func(ctx context.Context) []byte {
+		return []byte("response")
+	})
+
+	// then
+	assert.Equal(t, "response", string(response))
+	assert.Equal(t, []string{"Input field ProvisionInput.newFlag is not defined in the schema version " + gqlschema.SchemaVersion + " and was ignored"}, requestContext.Extensions["warnings"])
+}
+
+func validationErrors(t *testing.T, schema *ast.Schema, query string) []string {
+	document, err := parser.ParseQuery(&ast.Source{Input: query})
+	require.Nil(t, err)
+
+	var messages []string
+	for _, validationErr := range gqlvalidator.Validate(schema, document) {
+		messages = append(messages, validationErr.Message)
+	}
+	return messages
+}
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// LimitRequestBody rejects requests with body larger than maxBytes with 413 status code before they reach the handler.
+// Bodies of requests without declared length are buffered up to the limit, 0 disables the limit
+func LimitRequestBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if maxBytes <= 0 || r.Body == nil || r.Body == http.NoBody {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			if r.ContentLength > maxBytes {
+				rejectTooLarge(w, maxBytes)
+				return
+			}
+
+			body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to read request body: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+			if int64(len(body)) > maxBytes {
+				rejectTooLarge(w, maxBytes)
+				return
+			}
+
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+func rejectTooLarge(w http.ResponseWriter, maxBytes int64) {
+	// The connection is closed as the remaining body is not read
+	w.Header().Set("Connection", "close")
+	http.Error(w, fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
+}
@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitRequestBody(t *testing.T) {
+	const maxBytes = 16
+
+	for _, testCase := range []struct {
+		description    string
+		body           string
+		unknownLength  bool
+		limit          int64
+		expectedStatus int
+	}{
+		{
+			description:    "should pass request within the limit",
+			body:           strings.Repeat("a", maxBytes),
+			limit:          maxBytes,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "should refuse request exceeding the limit",
+			body:           strings.Repeat("a", maxBytes+1),
+			limit:          maxBytes,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			description:    "should pass request without declared length within the limit",
+			body:           strings.Repeat("a", maxBytes),
+			unknownLength:  true,
+			limit:          maxBytes,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "should refuse request without declared length exceeding the limit",
+			body:           strings.Repeat("a", maxBytes+1),
+			unknownLength:  true,
+			limit:          maxBytes,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			description:    "should pass any request when limit is disabled",
+			body:           strings.Repeat("a", maxBytes+1),
+			limit:          0,
+			expectedStatus: http.StatusOK,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			var receivedBody string
+			handler := LimitRequestBody(testCase.limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				receivedBody = string(body)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(testCase.body))
+			if testCase.unknownLength {
+				req.ContentLength = -1
+			}
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, req)
+
+			// then
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+			if testCase.expectedStatus == http.StatusOK {
+				assert.Equal(t, testCase.body, receivedBody)
+			} else {
+				assert.Empty(t, receivedBody)
+			}
+		})
+	}
+}
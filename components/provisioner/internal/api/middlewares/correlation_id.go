@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const CorrelationID Header = "X-Correlation-ID"
+
+var correlationIDHeaders = []string{string(CorrelationID), "X-Request-ID"}
+
+// ExtractCorrelationID puts correlation ID from the request headers to the context, new ID is generated if none is provided
+func ExtractCorrelationID(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID := ""
+		for _, header := range correlationIDHeaders {
+			if value := r.Header.Get(header); value != "" {
+				correlationID = value
+				break
+			}
+		}
+
+		if correlationID == "" {
+			correlationID = uuid.New().String()
+		}
+
+		ctx := context.WithValue(r.Context(), CorrelationID, correlationID)
+
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
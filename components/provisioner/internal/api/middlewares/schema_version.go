@@ -0,0 +1,17 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+// AddSchemaVersionHeader returns the version of the GraphQL schema in the header of every response,
+// so that the clients can detect the schema drift without querying the API
+func AddSchemaVersionHeader(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(gqlschema.SchemaVersionHeader, gqlschema.SchemaVersion)
+
+		handler.ServeHTTP(w, r)
+	})
+}
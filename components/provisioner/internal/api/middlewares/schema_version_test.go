@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSchemaVersionHeader(t *testing.T) {
+	// given
+	handler := AddSchemaVersionHeader(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	recorder := httptest.NewRecorder()
+
+	// when
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/graphql", nil))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, gqlschema.SchemaVersion, recorder.Header().Get(gqlschema.SchemaVersionHeader))
+}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/sirupsen/logrus"
+)
+
+// RequireSubAccount returns middleware checking if the sub-account header is passed with every mutation.
+// Mutations without the sub-account are rejected if strict, otherwise they are only logged.
+func RequireSubAccount(strict bool, log logrus.FieldLogger) graphql.FieldMiddleware {
+	return func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+		resolverContext := graphql.GetResolverContext(ctx)
+		if resolverContext == nil || resolverContext.Object != "Mutation" {
+			return next(ctx)
+		}
+
+		if subAccount, ok := ctx.Value(SubAccountID).(string); ok && subAccount != "" {
+			return next(ctx)
+		}
+
+		if strict {
+			return nil, apperrors.BadRequest("%s header is empty", SubAccountID)
+		}
+
+		tenant, _ := ctx.Value(Tenant).(string)
+		log.Warnf("Mutation %s of tenant %s requested without the %s header", resolverContext.Field.Name, tenant, SubAccountID)
+
+		return next(ctx)
+	}
+}
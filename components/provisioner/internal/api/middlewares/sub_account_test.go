@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestRequireSubAccount(t *testing.T) {
+	resolverContext := func(object, field, subAccount string) context.Context {
+		ctx := context.WithValue(context.Background(), Tenant, "tenant")
+		if subAccount != "" {
+			ctx = context.WithValue(ctx, SubAccountID, subAccount)
+		}
+		return graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+			Object: object,
+			Field:  graphql.CollectedField{Field: &ast.Field{Name: field}},
+		})
+	}
+	resolved := func(ctx context.Context) (interface{}, error) {
+		return "resolved", nil
+	}
+
+	for _, testCase := range []struct {
+		description     string
+		strict          bool
+		ctx             context.Context
+		expectedError   bool
+		expectedWarning bool
+	}{
+		{description: "should pass mutation with sub-account", strict: true, ctx: resolverContext("Mutation", "provisionRuntime", "sub-account")},
+		{description: "should pass and log mutation without sub-account", ctx: resolverContext("Mutation", "provisionRuntime", ""), expectedWarning: true},
+		{description: "should reject mutation without sub-account if strict", strict: true, ctx: resolverContext("Mutation", "provisionRuntime", ""), expectedError: true},
+		{description: "should pass query without sub-account if strict", strict: true, ctx: resolverContext("Query", "runtimeStatus", "")},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			log, hook := test.NewNullLogger()
+			middleware := RequireSubAccount(testCase.strict, log)
+
+			// when
+			result, err := middleware(testCase.ctx, resolved)
+
+			// then
+			if testCase.expectedError {
+				require.Error(t, err)
+				appErr, ok := err.(apperrors.AppError)
+				require.True(t, ok)
+				assert.Equal(t, apperrors.CodeBadRequest, appErr.Code())
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "resolved", result)
+			}
+
+			if testCase.expectedWarning {
+				require.Len(t, hook.Entries, 1)
+				assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+			} else {
+				assert.Empty(t, hook.Entries)
+			}
+		})
+	}
+}
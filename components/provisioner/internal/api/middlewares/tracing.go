@@ -0,0 +1,88 @@
+package middlewares
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/vektah/gqlparser/ast"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	graphQLOperationTypeKey = attribute.Key("graphql.operation.type")
+	graphQLOperationNameKey = attribute.Key("graphql.operation.name")
+)
+
+// TraceOperations returns middleware reporting every GraphQL operation as the span
+func TraceOperations(tracer trace.Tracer) graphql.RequestMiddleware {
+	return func(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+		requestContext := graphql.GetRequestContext(ctx)
+		operationType, operationName := describeOperation(requestContext)
+
+		attributes := []attribute.KeyValue{graphQLOperationTypeKey.String(operationType), graphQLOperationNameKey.String(operationName)}
+		if correlationID, ok := ctx.Value(CorrelationID).(string); ok {
+			attributes = append(attributes, tracing.CorrelationIDKey.String(correlationID))
+		}
+
+		ctx, span := tracer.Start(ctx, strings.TrimSpace(operationType+" "+operationName), trace.WithAttributes(attributes...))
+		defer span.End()
+
+		response := next(ctx)
+
+		if requestContext != nil && len(requestContext.Errors) > 0 {
+			span.SetStatus(codes.Error, requestContext.Errors.Error())
+		}
+
+		return response
+	}
+}
+
+// RecordOperationID adds the ID of the operation started by the mutation to the span of the request,
+// so that the request can be found by the operation ID reported with the spans of the operation stages
+func RecordOperationID(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	result, err := next(ctx)
+
+	resolverContext := graphql.GetResolverContext(ctx)
+	if err != nil || resolverContext == nil || resolverContext.Object != "Mutation" {
+		return result, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	switch status := result.(type) {
+	case *gqlschema.OperationStatus:
+		if status != nil && status.ID != nil {
+			span.SetAttributes(tracing.OperationIDKey.String(*status.ID))
+		}
+	case string:
+		if resolverContext.Field.Name == "deprovisionRuntime" {
+			span.SetAttributes(tracing.OperationIDKey.String(status))
+		}
+	}
+
+	return result, err
+}
+
+// describeOperation returns type and name of the operation, the name of the first field is used for anonymous operations
+func describeOperation(requestContext *graphql.RequestContext) (string, string) {
+	if requestContext == nil || requestContext.Doc == nil || len(requestContext.Doc.Operations) == 0 {
+		return "graphql", ""
+	}
+
+	operation := requestContext.Doc.Operations[0]
+	if operation.Name != "" {
+		return string(operation.Operation), operation.Name
+	}
+
+	for _, selection := range operation.SelectionSet {
+		if field, ok := selection.(*ast.Field); ok {
+			return string(operation.Operation), field.Name
+		}
+	}
+
+	return string(operation.Operation), ""
+}
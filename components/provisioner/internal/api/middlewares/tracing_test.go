@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceOperations(t *testing.T) {
+	requestContext := func(query string) context.Context {
+		doc, err := parser.ParseQuery(&ast.Source{Input: query})
+		require.Nil(t, err)
+
+		ctx := context.WithValue(context.Background(), CorrelationID, "correlation-id")
+		return graphql.WithRequestContext(ctx, graphql.NewRequestContext(doc, query, nil))
+	}
+	resolveMutation := func(field string, result interface{}) func(ctx context.Context) []byte {
+		return func(ctx context.Context) []byte {
+			ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+				Object: "Mutation",
+				Field:  graphql.CollectedField{Field: &ast.Field{Name: field}},
+			})
+			_, _ = RecordOperationID(ctx, func(ctx context.Context) (interface{}, error) {
+				return result, nil
+			})
+			return []byte("response")
+		}
+	}
+
+	t.Run("should report mutation with ID of the started operation", func(t *testing.T) {
+		// given
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracing.TracerName)
+
+		operationID := "operation-id"
+		ctx := requestContext(`mutation { provisionRuntime(config: {}) { id } }`)
+
+		// when
+		response := TraceOperations(tracer)(ctx, resolveMutation("provisionRuntime", &gqlschema.OperationStatus{ID: &operationID}))
+
+		// then
+		assert.Equal(t, []byte("response"), response)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "mutation provisionRuntime", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), tracing.OperationIDKey.String(operationID))
+		assert.Contains(t, spans[0].Attributes(), tracing.CorrelationIDKey.String("correlation-id"))
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+
+	t.Run("should report ID of deprovisioning operation", func(t *testing.T) {
+		// given
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracing.TracerName)
+
+		ctx := requestContext(`mutation Deprovision { deprovisionRuntime(id: "runtime-id") }`)
+
+		// when
+		TraceOperations(tracer)(ctx, resolveMutation("deprovisionRuntime", "operation-id"))
+
+		// then
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "mutation Deprovision", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), tracing.OperationIDKey.String("operation-id"))
+	})
+
+	t.Run("should mark span of failed operation", func(t *testing.T) {
+		// given
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracing.TracerName)
+
+		ctx := requestContext(`query { runtimeStatus(id: "runtime-id") { runtimeConfiguration { kubeconfig } } }`)
+
+		// when
+		TraceOperations(tracer)(ctx, func(ctx context.Context) []byte {
+			graphql.GetRequestContext(ctx).Errorf(ctx, "runtime not found")
+			return nil
+		})
+
+		// then
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "query runtimeStatus", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.NotContains(t, attributeKeys(spans[0].Attributes()), tracing.OperationIDKey)
+	})
+}
+
+func attributeKeys(attributes []attribute.KeyValue) []attribute.Key {
+	keys := make([]attribute.Key, 0, len(attributes))
+	for _, kv := range attributes {
+		keys = append(keys, kv.Key)
+	}
+	return keys
+}
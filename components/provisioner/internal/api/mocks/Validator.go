@@ -7,6 +7,8 @@ import (
 	gqlschema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Validator is an autogenerated mock type for the Validator type
@@ -14,6 +16,118 @@ type Validator struct {
 	mock.Mock
 }
 
+// ValidateAdminTenant provides a mock function with given fields: tenant
+func (_m *Validator) ValidateAdminTenant(tenant string) apperrors.AppError {
+	ret := _m.Called(tenant)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string) apperrors.AppError); ok {
+		r0 = rf(tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ValidateCleanupFailedProvisioning provides a mock function with given fields: runtimeID
+func (_m *Validator) ValidateCleanupFailedProvisioning(runtimeID string) apperrors.AppError {
+	ret := _m.Called(runtimeID)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string) apperrors.AppError); ok {
+		r0 = rf(runtimeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ValidateExpirationExtension provides a mock function with given fields: runtimeID, expireAt
+func (_m *Validator) ValidateExpirationExtension(runtimeID string, expireAt *time.Time) apperrors.AppError {
+	ret := _m.Called(runtimeID, expireAt)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string, *time.Time) apperrors.AppError); ok {
+		r0 = rf(runtimeID, expireAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ValidateForceDeprovisioning provides a mock function with given fields: runtimeID
+func (_m *Validator) ValidateForceDeprovisioning(runtimeID string) apperrors.AppError {
+	ret := _m.Called(runtimeID)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string) apperrors.AppError); ok {
+		r0 = rf(runtimeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ValidateHibernation provides a mock function with given fields: runtimeID
+func (_m *Validator) ValidateHibernation(runtimeID string) apperrors.AppError {
+	ret := _m.Called(runtimeID)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string) apperrors.AppError); ok {
+		r0 = rf(runtimeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ValidateMaintenanceFreeze provides a mock function with given fields: runtimeID, tenant, override
+func (_m *Validator) ValidateMaintenanceFreeze(runtimeID string, tenant string, override bool) apperrors.AppError {
+	ret := _m.Called(runtimeID, tenant, override)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string, string, bool) apperrors.AppError); ok {
+		r0 = rf(runtimeID, tenant, override)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ValidateOperationAnnotation provides a mock function with given fields: operationID, tenant, key, value
+func (_m *Validator) ValidateOperationAnnotation(operationID string, tenant string, key string, value string) apperrors.AppError {
+	ret := _m.Called(operationID, tenant, key, value)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string, string, string, string) apperrors.AppError); ok {
+		r0 = rf(operationID, tenant, key, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
 // ValidateProvisioningInput provides a mock function with given fields: input
 func (_m *Validator) ValidateProvisioningInput(input gqlschema.ProvisionRuntimeInput) apperrors.AppError {
 	ret := _m.Called(input)
@@ -78,13 +192,29 @@ func (_m *Validator) ValidateUpgradeInput(input gqlschema.UpgradeRuntimeInput) a
 	return r0
 }
 
-// ValidateUpgradeShootInput provides a mock function with given fields: input
-func (_m *Validator) ValidateUpgradeShootInput(input gqlschema.UpgradeShootInput) apperrors.AppError {
-	ret := _m.Called(input)
+// ValidateUpgradeShootInput provides a mock function with given fields: runtimeID, input
+func (_m *Validator) ValidateUpgradeShootInput(runtimeID string, input gqlschema.UpgradeShootInput) apperrors.AppError {
+	ret := _m.Called(runtimeID, input)
 
 	var r0 apperrors.AppError
-	if rf, ok := ret.Get(0).(func(gqlschema.UpgradeShootInput) apperrors.AppError); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeShootInput) apperrors.AppError); ok {
+		r0 = rf(runtimeID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ValidateWakeUp provides a mock function with given fields: runtimeID
+func (_m *Validator) ValidateWakeUp(runtimeID string) apperrors.AppError {
+	ret := _m.Called(runtimeID)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string) apperrors.AppError); ok {
+		r0 = rf(runtimeID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(apperrors.AppError)
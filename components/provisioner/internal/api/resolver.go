@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 
@@ -10,41 +11,72 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/readonly"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 )
 
+//go:generate mockery -name=ReadOnlyMode
+type ReadOnlyMode interface {
+	Set(enabled bool, message *string) (readonly.Status, error)
+}
+
+//go:generate mockery -name=DeprovisioningScheduler
+type DeprovisioningScheduler interface {
+	Schedule(runtimeID string, at time.Time) apperrors.AppError
+	Cancel(runtimeID string) apperrors.AppError
+	ScheduledAt(runtimeID string) (*time.Time, apperrors.AppError)
+}
+
 type Resolver struct {
-	provisioning provisioning.Service
-	validator    Validator
+	provisioning            provisioning.Service
+	validator               Validator
+	readOnlyMode            ReadOnlyMode
+	deprovisioningScheduler DeprovisioningScheduler
 }
 
 func (r *Resolver) Mutation() gqlschema.MutationResolver {
 	return &Resolver{
-		provisioning: r.provisioning,
-		validator:    r.validator,
+		provisioning:            r.provisioning,
+		validator:               r.validator,
+		readOnlyMode:            r.readOnlyMode,
+		deprovisioningScheduler: r.deprovisioningScheduler,
 	}
 }
 func (r *Resolver) Query() gqlschema.QueryResolver {
 	return &Resolver{
-		provisioning: r.provisioning,
-		validator:    r.validator,
+		provisioning:            r.provisioning,
+		validator:               r.validator,
+		readOnlyMode:            r.readOnlyMode,
+		deprovisioningScheduler: r.deprovisioningScheduler,
 	}
 }
 
-func NewResolver(provisioningService provisioning.Service, validator Validator) *Resolver {
+func NewResolver(provisioningService provisioning.Service, validator Validator, readOnlyMode ReadOnlyMode, deprovisioningScheduler DeprovisioningScheduler) *Resolver {
 	return &Resolver{
-		provisioning: provisioningService,
-		validator:    validator,
+		provisioning:            provisioningService,
+		validator:               validator,
+		readOnlyMode:            readOnlyMode,
+		deprovisioningScheduler: deprovisioningScheduler,
 	}
 }
 
-func (r *Resolver) ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, error) {
+func (r *Resolver) ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput, dryRun *bool, idempotencyKey *string) (*gqlschema.OperationStatus, error) {
+	if dryRun != nil && *dryRun {
+		return r.provisionRuntimeDryRun(ctx, config)
+	}
+
 	err := r.validator.ValidateProvisioningInput(config)
 	if err != nil {
 		log.Errorf("Failed to provision Runtime %s", err)
 		return nil, err
 	}
 
+	key, err := validateIdempotencyKey(idempotencyKey)
+	if err != nil {
+		log.Errorf("Failed to provision Runtime %s", err)
+		return nil, err
+	}
+
 	tenant, err := getTenant(ctx)
 	if err != nil {
 		log.Errorf("Failed to provision Runtime %s: %s", config.RuntimeInput.Name, err)
@@ -55,7 +87,7 @@ func (r *Resolver) ProvisionRuntime(ctx context.Context, config gqlschema.Provis
 
 	log.Infof("Requested provisioning of Runtime %s.", config.RuntimeInput.Name)
 
-	operationStatus, err := r.provisioning.ProvisionRuntime(config, tenant, subAccount)
+	operationStatus, err := r.provisioning.ProvisionRuntime(config, tenant, subAccount, key)
 	if err != nil {
 		log.Errorf("Failed to provision Runtime %s: %s", config.RuntimeInput.Name, err)
 		return nil, err
@@ -65,7 +97,42 @@ func (r *Resolver) ProvisionRuntime(ctx context.Context, config gqlschema.Provis
 	return operationStatus, nil
 }
 
-func (r *Resolver) DeprovisionRuntime(ctx context.Context, id string) (string, error) {
+// provisionRuntimeDryRun returns the validation error of the input in the dry run report instead of failing the request
+func (r *Resolver) provisionRuntimeDryRun(ctx context.Context, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, error) {
+	tenant, err := getTenant(ctx)
+	if err != nil {
+		log.Errorf("Failed to run provisioning dry run: %s", err)
+		return nil, err
+	}
+
+	err = r.validator.ValidateProvisioningInput(config)
+	if err != nil {
+		message := "Dry run: no operation created"
+
+		return &gqlschema.OperationStatus{
+			Operation: gqlschema.OperationTypeProvision,
+			State:     gqlschema.OperationStatePending,
+			Message:   &message,
+			DryRunReport: &gqlschema.ProvisioningDryRunReport{
+				Valid:    false,
+				Errors:   []string{err.Error()},
+				Warnings: []string{},
+			},
+		}, nil
+	}
+
+	log.Infof("Requested provisioning dry run of Runtime %s.", config.RuntimeInput.Name)
+
+	status, err := r.provisioning.ProvisionRuntimeDryRun(config, tenant, getSubAccount(ctx))
+	if err != nil {
+		log.Errorf("Failed to run provisioning dry run of Runtime %s: %s", config.RuntimeInput.Name, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) DeprovisionRuntime(ctx context.Context, id string, force *bool, idempotencyKey *string) (string, error) {
 	log.Infof("Requested deprovisioning of Runtime %s.", id)
 
 	tenant, err := r.getAndValidateTenant(ctx, id)
@@ -74,7 +141,24 @@ func (r *Resolver) DeprovisionRuntime(ctx context.Context, id string) (string, e
 		return "", err
 	}
 
-	operationID, err := r.provisioning.DeprovisionRuntime(id, tenant)
+	r.warnIfDeprovisioningScheduled(ctx, id)
+
+	key, err := validateIdempotencyKey(idempotencyKey)
+	if err != nil {
+		log.Errorf("Failed to deprovision Runtime %s: %s", id, err)
+		return "", err
+	}
+
+	forceDeprovisioning := force != nil && *force
+	if forceDeprovisioning {
+		err = r.validator.ValidateForceDeprovisioning(id)
+		if err != nil {
+			log.Errorf("Failed to force deprovisioning of Runtime %s: %s", id, err)
+			return "", err
+		}
+	}
+
+	operationID, err := r.provisioning.DeprovisionRuntime(id, tenant, forceDeprovisioning, key)
 	if err != nil {
 		log.Errorf("Failed to deprovision Runtime %s: %s", id, err)
 		return "", err
@@ -84,22 +168,41 @@ func (r *Resolver) DeprovisionRuntime(ctx context.Context, id string) (string, e
 	return operationID, nil
 }
 
-func (r *Resolver) UpgradeRuntime(ctx context.Context, runtimeId string, input gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, error) {
+func (r *Resolver) UpgradeRuntime(ctx context.Context, runtimeId string, input gqlschema.UpgradeRuntimeInput, idempotencyKey *string, skipHealthChecks *bool, override *bool) (*gqlschema.OperationStatus, error) {
 	log.Infof("Requested upgrade of Runtime %s.", runtimeId)
 
-	_, err := r.getAndValidateTenant(ctx, runtimeId)
+	tenant, err := r.getAndValidateTenant(ctx, runtimeId)
 	if err != nil {
 		log.Errorf("Failed to upgrade Runtime %s: %s", runtimeId, err)
 		return &gqlschema.OperationStatus{}, err
 	}
 
+	r.warnIfDeprovisioningScheduled(ctx, runtimeId)
+
 	err = r.validator.ValidateUpgradeInput(input)
 	if err != nil {
 		log.Errorf("Failed to upgrade Runtime %s: %s", runtimeId, err)
 		return nil, err
 	}
 
-	operationStatus, err := r.provisioning.UpgradeRuntime(runtimeId, input)
+	key, err := validateIdempotencyKey(idempotencyKey)
+	if err != nil {
+		log.Errorf("Failed to upgrade Runtime %s: %s", runtimeId, err)
+		return nil, err
+	}
+
+	err = r.validator.ValidateMaintenanceFreeze(runtimeId, tenant, override != nil && *override)
+	if err != nil {
+		log.Errorf("Failed to upgrade Runtime %s: %s", runtimeId, err)
+		return nil, err
+	}
+
+	skipChecks := skipHealthChecks != nil && *skipHealthChecks
+	if skipChecks {
+		log.Warnf("Health checks of the upgrade of Runtime %s are skipped", runtimeId)
+	}
+
+	operationStatus, err := r.provisioning.UpgradeRuntime(runtimeId, input, tenant, key, skipChecks)
 	if err != nil {
 		log.Errorf("Failed to upgrade Runtime %s: %s", runtimeId, err)
 		return nil, err
@@ -115,6 +218,8 @@ func (r *Resolver) RollBackUpgradeOperation(ctx context.Context, runtimeID strin
 		return nil, err
 	}
 
+	r.warnIfDeprovisioningScheduled(ctx, runtimeID)
+
 	runtimeStatus, err := r.provisioning.RollBackLastUpgrade(runtimeID)
 	if err != nil {
 		log.Errorf("Failed to roll back last Runtime upgrade: %s, Runtime ID: %s", err, runtimeID)
@@ -147,6 +252,42 @@ func (r *Resolver) RuntimeStatus(ctx context.Context, runtimeID string) (*gqlsch
 	return status, nil
 }
 
+func (r *Resolver) RuntimeStatuses(ctx context.Context, runtimeIDs []string, skipGardenerStatus *bool) ([]*gqlschema.RuntimeStatusEntry, error) {
+	log.Infof("Requested to get statuses for %d Runtimes.", len(runtimeIDs))
+
+	tenant, err := getTenant(ctx)
+	if err != nil {
+		log.Errorf("Failed to get statuses for Runtimes: %s", err)
+		return nil, err
+	}
+
+	statuses, err := r.provisioning.RuntimeStatuses(tenant, runtimeIDs, skipGardenerStatus != nil && *skipGardenerStatus)
+	if err != nil {
+		log.Errorf("Failed to get statuses for Runtimes: %s", err)
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (r *Resolver) OperationsHistory(ctx context.Context, runtimeID string, first *int, after *string) (*gqlschema.OperationsHistory, error) {
+	log.Infof("Requested to get operations history for Runtime %s.", runtimeID)
+
+	_, err := r.getAndValidateTenant(ctx, runtimeID)
+	if err != nil {
+		log.Errorf("Failed to get operations history for Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	history, err := r.provisioning.OperationsHistory(runtimeID, first, after)
+	if err != nil {
+		log.Errorf("Failed to get operations history for Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	return history, nil
+}
+
 func (r *Resolver) RuntimeOperationStatus(ctx context.Context, operationID string) (*gqlschema.OperationStatus, error) {
 	log.Infof("Requested to get Runtime operation status for Operation %s.", operationID)
 
@@ -167,22 +308,46 @@ func (r *Resolver) RuntimeOperationStatus(ctx context.Context, operationID strin
 	return status, nil
 }
 
-func (r *Resolver) UpgradeShoot(ctx context.Context, runtimeID string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, error) {
+func (r *Resolver) UpgradeShoot(ctx context.Context, runtimeID string, input gqlschema.UpgradeShootInput, dryRun *bool, idempotencyKey *string, override *bool) (*gqlschema.OperationStatus, error) {
 	log.Infof("Requested to upgrade Gardener Shoot cluster specification for Runtime : %s.", runtimeID)
 
-	_, err := r.getAndValidateTenant(ctx, runtimeID)
+	tenant, err := r.getAndValidateTenant(ctx, runtimeID)
 	if err != nil {
 		log.Errorf("Failed to upgrade Gardener Shoot cluster specification for Runtime  %s: %s", runtimeID, err)
 		return nil, err
 	}
 
-	err = r.validator.ValidateUpgradeShootInput(input)
+	r.warnIfDeprovisioningScheduled(ctx, runtimeID)
+
+	err = r.validator.ValidateUpgradeShootInput(runtimeID, input)
 	if err != nil {
 		log.Errorf("Failed to upgrade Gardener Shoot cluster specification for Runtime %s", err)
 		return nil, err
 	}
 
-	status, err := r.provisioning.UpgradeGardenerShoot(runtimeID, input)
+	if dryRun != nil && *dryRun {
+		status, err := r.provisioning.UpgradeGardenerShootDryRun(runtimeID, input)
+		if err != nil {
+			log.Errorf("Failed to compute Gardener Shoot cluster specification changes for Runtime %s: %s", runtimeID, err)
+			return nil, err
+		}
+
+		return status, nil
+	}
+
+	key, err := validateIdempotencyKey(idempotencyKey)
+	if err != nil {
+		log.Errorf("Failed to upgrade Gardener Shoot cluster specification for Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	err = r.validator.ValidateMaintenanceFreeze(runtimeID, tenant, override != nil && *override)
+	if err != nil {
+		log.Errorf("Failed to upgrade Gardener Shoot cluster specification for Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.UpgradeGardenerShoot(runtimeID, input, tenant, key)
 	if err != nil {
 		log.Errorf("Failed to upgrade Gardener Shoot cluster specification for Runtime %s: %s", runtimeID, err)
 		return nil, err
@@ -193,7 +358,7 @@ func (r *Resolver) UpgradeShoot(ctx context.Context, runtimeID string, input gql
 	return status, nil
 }
 
-func (r *Resolver) HibernateRuntime(ctx context.Context, runtimeID string) (*gqlschema.OperationStatus, error) {
+func (r *Resolver) HibernateRuntime(ctx context.Context, runtimeID string, notBefore *time.Time) (*gqlschema.OperationStatus, error) {
 	log.Infof("Requested to hibernate runtime : %s.", runtimeID)
 
 	_, err := r.getAndValidateTenant(ctx, runtimeID)
@@ -202,7 +367,15 @@ func (r *Resolver) HibernateRuntime(ctx context.Context, runtimeID string) (*gql
 		return nil, err
 	}
 
-	status, err := r.provisioning.HibernateCluster(runtimeID)
+	r.warnIfDeprovisioningScheduled(ctx, runtimeID)
+
+	err = r.validator.ValidateHibernation(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to hibernate Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.HibernateCluster(runtimeID, notBefore)
 	if err != nil {
 		log.Errorf("Failed to hibernate Runtime %s: %s", runtimeID, err)
 		return nil, err
@@ -211,6 +384,312 @@ func (r *Resolver) HibernateRuntime(ctx context.Context, runtimeID string) (*gql
 	return status, nil
 }
 
+func (r *Resolver) WakeUpRuntime(ctx context.Context, runtimeID string, notBefore *time.Time) (*gqlschema.OperationStatus, error) {
+	log.Infof("Requested to wake up runtime : %s.", runtimeID)
+
+	_, err := r.getAndValidateTenant(ctx, runtimeID)
+	if err != nil {
+		log.Errorf("Failed to wake up Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	r.warnIfDeprovisioningScheduled(ctx, runtimeID)
+
+	err = r.validator.ValidateWakeUp(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to wake up Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.WakeUpCluster(runtimeID, notBefore)
+	if err != nil {
+		log.Errorf("Failed to wake up Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) CleanupFailedProvisioning(ctx context.Context, runtimeID string) (*gqlschema.OperationStatus, error) {
+	log.Infof("Requested to clean up failed provisioning of runtime : %s.", runtimeID)
+
+	_, err := r.getAndValidateTenant(ctx, runtimeID)
+	if err != nil {
+		log.Errorf("Failed to clean up failed provisioning of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	r.warnIfDeprovisioningScheduled(ctx, runtimeID)
+
+	err = r.validator.ValidateCleanupFailedProvisioning(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to clean up failed provisioning of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.CleanupFailedProvisioning(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to clean up failed provisioning of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) ExtendRuntimeExpiration(ctx context.Context, runtimeID string, expireAt *time.Time) (*gqlschema.RuntimeStatus, error) {
+	log.Infof("Requested to extend expiration of Runtime %s.", runtimeID)
+
+	_, err := r.getAndValidateTenant(ctx, runtimeID)
+	if err != nil {
+		log.Errorf("Failed to extend expiration of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	r.warnIfDeprovisioningScheduled(ctx, runtimeID)
+
+	err = r.validator.ValidateExpirationExtension(runtimeID, expireAt)
+	if err != nil {
+		log.Errorf("Failed to extend expiration of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.ExtendRuntimeExpiration(runtimeID, expireAt)
+	if err != nil {
+		log.Errorf("Failed to extend expiration of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) ScheduleDeprovisioning(ctx context.Context, runtimeID string, at time.Time) (*gqlschema.RuntimeStatus, error) {
+	log.Infof("Requested to schedule deprovisioning of Runtime %s at %s.", runtimeID, at.Format(time.RFC3339))
+
+	_, err := r.getAndValidateTenant(ctx, runtimeID)
+	if err != nil {
+		log.Errorf("Failed to schedule deprovisioning of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	err = r.deprovisioningScheduler.Schedule(runtimeID, at)
+	if err != nil {
+		log.Errorf("Failed to schedule deprovisioning of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.RuntimeStatus(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to get status of Runtime %s scheduled for deprovisioning: %s", runtimeID, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) CancelScheduledDeprovisioning(ctx context.Context, runtimeID string) (*gqlschema.RuntimeStatus, error) {
+	log.Infof("Requested to cancel scheduled deprovisioning of Runtime %s.", runtimeID)
+
+	_, err := r.getAndValidateTenant(ctx, runtimeID)
+	if err != nil {
+		log.Errorf("Failed to cancel scheduled deprovisioning of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	err = r.deprovisioningScheduler.Cancel(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to cancel scheduled deprovisioning of Runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.RuntimeStatus(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to get status of Runtime %s after cancelling scheduled deprovisioning: %s", runtimeID, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) AnnotateOperation(ctx context.Context, operationID string, key string, value string) (*gqlschema.OperationStatus, error) {
+	log.Infof("Requested to set annotation %s of Operation %s.", key, operationID)
+
+	tenant, err := getTenant(ctx)
+	if err != nil {
+		log.Errorf("Failed to annotate Operation %s: %s", operationID, err)
+		return nil, err
+	}
+
+	err = r.validator.ValidateOperationAnnotation(operationID, tenant, key, value)
+	if err != nil {
+		log.Errorf("Failed to annotate Operation %s: %s", operationID, err)
+		return nil, err
+	}
+
+	status, err := r.provisioning.AnnotateOperation(operationID, key, value)
+	if err != nil {
+		log.Errorf("Failed to annotate Operation %s: %s", operationID, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) SetQueueState(ctx context.Context, queue gqlschema.QueueType, paused bool) (*gqlschema.QueueStatus, error) {
+	log.Infof("Requested to set %s queue paused state to %t.", queue, paused)
+
+	status, err := r.provisioning.SetQueueState(queue, paused)
+	if err != nil {
+		log.Errorf("Failed to set %s queue state: %s", queue, err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) SetReadOnlyMode(ctx context.Context, enabled bool, message *string) (*gqlschema.ReadOnlyModeStatus, error) {
+	log.Infof("Requested to set read-only mode to %t.", enabled)
+
+	tenant, err := getTenant(ctx)
+	if err != nil {
+		log.Errorf("Failed to set read-only mode: %s", err)
+		return nil, err
+	}
+
+	err = r.validator.ValidateAdminTenant(tenant)
+	if err != nil {
+		log.Errorf("Failed to set read-only mode: %s", err)
+		return nil, err
+	}
+
+	status, setErr := r.readOnlyMode.Set(enabled, message)
+	if setErr != nil {
+		log.Errorf("Failed to set read-only mode: %s", setErr)
+		return nil, apperrors.Internal("Failed to set read-only mode: %s", setErr.Error())
+	}
+
+	return &gqlschema.ReadOnlyModeStatus{Enabled: status.Enabled, Message: status.Message}, nil
+}
+
+func (r *Resolver) RetryFailedOperations(ctx context.Context, filter gqlschema.FailedOperationsFilter, dryRun *bool) (*gqlschema.RetriedOperations, error) {
+	log.Infof("Requested to retry failed operations.")
+
+	tenant, err := getTenant(ctx)
+	if err != nil {
+		log.Errorf("Failed to retry failed operations: %s", err)
+		return nil, err
+	}
+
+	err = r.validator.ValidateAdminTenant(tenant)
+	if err != nil {
+		log.Errorf("Failed to retry failed operations: %s", err)
+		return nil, err
+	}
+
+	retried, err := r.provisioning.RetryFailedOperations(filter, dryRun != nil && *dryRun)
+	if err != nil {
+		log.Errorf("Failed to retry failed operations: %s", err)
+		return nil, err
+	}
+
+	return retried, nil
+}
+
+func (r *Resolver) QueuesStatus(ctx context.Context) ([]*gqlschema.QueueStatus, error) {
+	log.Infof("Requested to get operation queues status.")
+
+	status, err := r.provisioning.QueuesStatus()
+	if err != nil {
+		log.Errorf("Failed to get operation queues status: %s", err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) MaintenanceFreeze(ctx context.Context) (*gqlschema.MaintenanceFreezeStatus, error) {
+	log.Infof("Requested to get maintenance freeze status.")
+
+	status, err := r.provisioning.MaintenanceFreeze()
+	if err != nil {
+		log.Errorf("Failed to get maintenance freeze status: %s", err)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *Resolver) AuditEntries(ctx context.Context, filter *gqlschema.AuditEntriesFilter, first *int, offset *int) ([]*gqlschema.AuditEntry, error) {
+	log.Infof("Requested to get audit entries.")
+
+	entries, err := r.provisioning.AuditEntries(filter, first, offset)
+	if err != nil {
+		log.Errorf("Failed to get audit entries: %s", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *Resolver) OrphanedShoots(ctx context.Context) ([]*gqlschema.OrphanedShoot, error) {
+	log.Infof("Requested to get orphaned Shoots.")
+
+	shoots, err := r.provisioning.OrphanedShoots()
+	if err != nil {
+		log.Errorf("Failed to get orphaned Shoots: %s", err)
+		return nil, err
+	}
+
+	return shoots, nil
+}
+
+func (r *Resolver) RuntimeByShootName(ctx context.Context, name string) (*gqlschema.ShootRuntime, error) {
+	log.Infof("Requested to get Runtime of Shoot %s.", name)
+
+	// Tenant is optional, the query is used to map Shoots referenced by Gardener alerts to Runtimes
+	tenant, _ := ctx.Value(middlewares.Tenant).(string)
+
+	runtime, err := r.provisioning.RuntimeByShootName(name, tenant)
+	if err != nil {
+		log.Errorf("Failed to get Runtime of Shoot %s: %s", name, err)
+		return nil, err
+	}
+
+	return runtime, nil
+}
+
+func (r *Resolver) ProviderDefaults(ctx context.Context, provider gqlschema.Provider) (*gqlschema.ProviderDefaults, error) {
+	log.Infof("Requested to get defaults of %s provider.", provider)
+
+	defaults, err := r.provisioning.ProviderDefaults(provider)
+	if err != nil {
+		log.Errorf("Failed to get defaults of %s provider: %s", provider, err)
+		return nil, err
+	}
+
+	return defaults, nil
+}
+
+func (r *Resolver) Info(ctx context.Context) (*gqlschema.Info, error) {
+	return &gqlschema.Info{SchemaVersion: gqlschema.SchemaVersion}, nil
+}
+
+// warnIfDeprovisioningScheduled informs the client that the Runtime it modifies is going to be deprovisioned,
+// the mutation is not rejected, as the schedule can still be cancelled
+func (r *Resolver) warnIfDeprovisioningScheduled(ctx context.Context, runtimeID string) {
+	if r.deprovisioningScheduler == nil {
+		return
+	}
+
+	scheduledAt, err := r.deprovisioningScheduler.ScheduledAt(runtimeID)
+	if err != nil {
+		log.Warnf("Failed to check deprovisioning schedule of Runtime %s: %s", runtimeID, err)
+		return
+	}
+	if scheduledAt != nil {
+		addWarning(ctx, "Runtime %s is scheduled for deprovisioning at %s", runtimeID, scheduledAt.Format(time.RFC3339))
+	}
+}
+
 func (r *Resolver) getAndValidateTenant(ctx context.Context, runtimeID string) (string, error) {
 	tenant, err := getTenant(ctx)
 	if err != nil {
@@ -16,6 +16,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 )
@@ -125,8 +126,8 @@ func newTestProvisioningConfigs() []testCase {
 func azureGardenerClusterConfigInput(zones ...string) gqlschema.ClusterConfigInput {
 	return gqlschema.ClusterConfigInput{
 		GardenerConfig: &gqlschema.GardenerConfigInput{
-			Name:              util.CreateGardenerClusterName(),
-			KubernetesVersion: "version",
+			Name:              util.StringPtr(util.CreateGardenerClusterName()),
+			KubernetesVersion: "1.15.4",
 			Purpose:           util.StringPtr("evaluation"),
 			Provider:          "Azure",
 			TargetSecret:      "secret",
@@ -138,8 +139,8 @@ func azureGardenerClusterConfigInput(zones ...string) gqlschema.ClusterConfigInp
 			WorkerCidr:        "cidr",
 			AutoScalerMin:     1,
 			AutoScalerMax:     5,
-			MaxSurge:          1,
-			MaxUnavailable:    2,
+			MaxSurge:          gqlschema.NewIntOrString(intstr.FromInt(1)),
+			MaxUnavailable:    gqlschema.NewIntOrString(intstr.FromInt(2)),
 			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 				AzureConfig: &gqlschema.AzureProviderConfigInput{
 					VnetCidr: "cidr",
@@ -154,8 +155,8 @@ func azureGardenerClusterConfigInput(zones ...string) gqlschema.ClusterConfigInp
 func azureGardenerClusterConfigInputNoSeed(zones ...string) gqlschema.ClusterConfigInput {
 	return gqlschema.ClusterConfigInput{
 		GardenerConfig: &gqlschema.GardenerConfigInput{
-			Name:              util.CreateGardenerClusterName(),
-			KubernetesVersion: "version",
+			Name:              util.StringPtr(util.CreateGardenerClusterName()),
+			KubernetesVersion: "1.15.4",
 			Purpose:           util.StringPtr("evaluation"),
 			Provider:          "Azure",
 			TargetSecret:      "secret",
@@ -166,8 +167,8 @@ func azureGardenerClusterConfigInputNoSeed(zones ...string) gqlschema.ClusterCon
 			WorkerCidr:        "cidr",
 			AutoScalerMin:     1,
 			AutoScalerMax:     5,
-			MaxSurge:          1,
-			MaxUnavailable:    2,
+			MaxSurge:          gqlschema.NewIntOrString(intstr.FromInt(1)),
+			MaxUnavailable:    gqlschema.NewIntOrString(intstr.FromInt(2)),
 			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 				AzureConfig: &gqlschema.AzureProviderConfigInput{
 					VnetCidr: "cidr",
@@ -182,8 +183,8 @@ func azureGardenerClusterConfigInputNoSeed(zones ...string) gqlschema.ClusterCon
 func openStackGardenerClusterConfigInput() gqlschema.ClusterConfigInput {
 	return gqlschema.ClusterConfigInput{
 		GardenerConfig: &gqlschema.GardenerConfigInput{
-			Name:              util.CreateGardenerClusterName(),
-			KubernetesVersion: "version",
+			Name:              util.StringPtr(util.CreateGardenerClusterName()),
+			KubernetesVersion: "1.15.4",
 			Purpose:           util.StringPtr("evaluation"),
 			Provider:          "Openstack",
 			TargetSecret:      "secret",
@@ -193,8 +194,8 @@ func openStackGardenerClusterConfigInput() gqlschema.ClusterConfigInput {
 			WorkerCidr:        "cidr",
 			AutoScalerMin:     1,
 			AutoScalerMax:     5,
-			MaxSurge:          1,
-			MaxUnavailable:    2,
+			MaxSurge:          gqlschema.NewIntOrString(intstr.FromInt(1)),
+			MaxUnavailable:    gqlschema.NewIntOrString(intstr.FromInt(2)),
 			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 				OpenStackConfig: &gqlschema.OpenStackProviderConfigInput{
 					Zones:                []string{"eu-de-1a"},
@@ -228,15 +229,15 @@ func seedConfig(seedName, auditIdentifier, provider string) *gardener_types.Seed
 func NewUpgradeShootInput() gqlschema.UpgradeShootInput {
 	return gqlschema.UpgradeShootInput{
 		GardenerConfig: &gqlschema.GardenerUpgradeInput{
-			KubernetesVersion: util.StringPtr("version2"),
+			KubernetesVersion: util.StringPtr("1.16"),
 			Purpose:           util.StringPtr("testing"),
 			MachineType:       util.StringPtr("new-machine"),
-			DiskType:          util.StringPtr("papyrus"),
+			DiskType:          util.StringPtr("Premium_LRS"),
 			VolumeSizeGb:      util.IntPtr(50),
 			AutoScalerMin:     util.IntPtr(2),
 			AutoScalerMax:     util.IntPtr(6),
-			MaxSurge:          util.IntPtr(2),
-			MaxUnavailable:    util.IntPtr(1),
+			MaxSurge:          gqlschema.NewIntOrString(intstr.FromInt(2)),
+			MaxUnavailable:    gqlschema.NewIntOrString(intstr.FromInt(1)),
 			OidcConfig:        oidcInput(),
 		},
 	}
@@ -245,13 +246,13 @@ func NewUpgradeShootInput() gqlschema.UpgradeShootInput {
 func NewUpgradeOpenStackShootInput() gqlschema.UpgradeShootInput {
 	return gqlschema.UpgradeShootInput{
 		GardenerConfig: &gqlschema.GardenerUpgradeInput{
-			KubernetesVersion: util.StringPtr("version2"),
+			KubernetesVersion: util.StringPtr("1.16"),
 			Purpose:           util.StringPtr("testing"),
 			MachineType:       util.StringPtr("new-machine"),
 			AutoScalerMin:     util.IntPtr(2),
 			AutoScalerMax:     util.IntPtr(6),
-			MaxSurge:          util.IntPtr(2),
-			MaxUnavailable:    util.IntPtr(1),
+			MaxSurge:          gqlschema.NewIntOrString(intstr.FromInt(2)),
+			MaxUnavailable:    gqlschema.NewIntOrString(intstr.FromInt(1)),
 			OidcConfig:        oidcInput(),
 		},
 	}
@@ -12,6 +12,8 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/api/fake/shoots"
 
 	provisioning2 "github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/provisioning"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/shootupgrade"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/upgrade"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/api"
 
@@ -19,6 +21,7 @@ import (
 
 	v1alpha12 "github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/apis/compass/v1alpha1"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/client/clientset/versioned/typed/compass/v1alpha1"
+	installerv1alpha1 "github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
 
@@ -33,6 +36,7 @@ import (
 	"github.com/kyma-incubator/hydroform/install/installation"
 	directormock "github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/gardener"
+	provisionerInstallation "github.com/kyma-project/control-plane/components/provisioner/internal/installation"
 	installationMocks "github.com/kyma-project/control-plane/components/provisioner/internal/installation/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/installation/release"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/database"
@@ -84,6 +88,7 @@ const (
 	defaultEnableKubernetesVersionAutoUpdate   = false
 	defaultEnableMachineImageVersionAutoUpdate = false
 	forceAllowPrivilegedContainers             = false
+	defaultNetworkingType                      = model.CalicoNetworkingType
 
 	mockedKubeconfig = `apiVersion: v1
 clusters:
@@ -113,11 +118,12 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 		mock.AnythingOfType("model.Configuration"), mock.AnythingOfType("[]model.KymaComponentConfig")).Return(nil)
 
 	installationServiceMock.On("CheckInstallationState", mock.Anything).Return(installation.InstallationState{State: "Installed"}, nil)
+	installationServiceMock.On("GetInstallationSpec", mock.Anything).Return(&installerv1alpha1.InstallationSpec{}, nil)
 
 	installationServiceMock.On("TriggerUpgrade", mock.Anything, mock.Anything, mock.AnythingOfType("model.Release"),
 		mock.AnythingOfType("model.Configuration"), mock.AnythingOfType("[]model.KymaComponentConfig")).Return(nil)
 
-	installationServiceMock.On("PerformCleanup", mock.Anything).Return(nil)
+	installationServiceMock.On("PerformCleanup", mock.Anything).Return(provisionerInstallation.CleanupSummary{}, nil)
 	installationServiceMock.On("TriggerUninstall", mock.Anything).Return(nil)
 
 	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
@@ -153,7 +159,10 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 	shootInterface := shoots.NewFakeShootsInterface(t, cfg)
 	seedInterface := seeds.NewFakeSeedsInterface(t, cfg)
 	secretsInterface := setupSecretsClient(t, cfg)
-	dbsFactory := dbsession.NewFactory(connection)
+	gardenerProjects := gardener.NewProjects("Project", nil)
+	shootClients := gardener.NewShootClients(gardenerProjects, shootsGetter{shoots: shootInterface})
+	secretClients := gardener.NewSecretClients(gardenerProjects, secretsGetter{secrets: secretsInterface})
+	dbsFactory := dbsession.NewFactory(connection, 0, 0, uuid.NewUUIDGenerator())
 
 	queueCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -164,29 +173,34 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 		runtimeConfigurator,
 		fakeCompassConnectionClientConstructor,
 		directorServiceMock,
-		shootInterface,
-		secretsInterface,
+		shootClients,
+		gardener.NewKubeconfigProvider(secretClients, nil, gardener.KubeconfigConfig{Mode: gardener.KubeconfigModeStatic}),
+		nil,
 		testOperatorRoleBinding(),
-		mockK8sClientProvider)
+		provisioning2.LegacyOperatorBindings(testOperatorRoleBinding()),
+		mockK8sClientProvider,
+		true,
+		nil,
+		nil)
 	provisioningQueue.Run(queueCtx.Done())
 
-	deprovisioningQueue := queue.CreateDeprovisioningQueue(testDeprovisioningTimeouts(), dbsFactory, installationServiceMock, directorServiceMock, shootInterface, 1*time.Second)
+	deprovisioningQueue := queue.CreateDeprovisioningQueue(testDeprovisioningTimeouts(), dbsFactory, installationServiceMock, directorServiceMock, shootClients, 1*time.Second, nil, nil, nil)
 	deprovisioningQueue.Run(queueCtx.Done())
 
-	upgradeQueue := queue.CreateUpgradeQueue(testProvisioningTimeouts(), dbsFactory, directorServiceMock, installationServiceMock)
+	upgradeQueue := queue.CreateUpgradeQueue(testProvisioningTimeouts(), dbsFactory, directorServiceMock, installationServiceMock, mockK8sClientProvider, upgrade.HealthChecksConfig{}, nil, nil, nil)
 	upgradeQueue.Run(queueCtx.Done())
 
-	shootUpgradeQueue := queue.CreateShootUpgradeQueue(testProvisioningTimeouts(), dbsFactory, directorServiceMock, shootInterface, testOperatorRoleBinding(), mockK8sClientProvider)
+	shootUpgradeQueue := queue.CreateShootUpgradeQueue(testProvisioningTimeouts(), dbsFactory, directorServiceMock, shootClients, testOperatorRoleBinding(), provisioning2.LegacyOperatorBindings(testOperatorRoleBinding()), mockK8sClientProvider, fakeCompassConnectionClientConstructor, shootupgrade.AgentReconnectionConfig{}, nil, nil, nil)
 	shootUpgradeQueue.Run(queueCtx.Done())
 
-	shootHibernationQueue := queue.CreateHibernationQueue(testHibernationTimeouts(), dbsFactory, directorServiceMock, shootInterface)
+	shootHibernationQueue := queue.CreateHibernationQueue(testHibernationTimeouts(), dbsFactory, directorServiceMock, shootClients, nil, nil)
 	shootHibernationQueue.Run(queueCtx.Done())
 
-	controler, err := gardener.NewShootController(mgr, dbsFactory, auditLogsConfigPath)
+	controler, err := gardener.NewShootController(mgr, dbsFactory, auditLogsConfigPath, shootInterface, 0)
 	require.NoError(t, err)
 
 	go func() {
-		err := controler.StartShootController()
+		err := controler.StartShootController(queueCtx)
 		require.NoError(t, err)
 	}()
 
@@ -226,19 +240,19 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 			directorServiceMock.On("SetRuntimeStatusCondition", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 			uuidGenerator := uuid.NewUUIDGenerator()
-			provisioner := gardener.NewProvisioner(namespace, shootInterface, dbsFactory, auditLogPolicyCMName, maintenanceWindowConfigPath)
+			provisioner := gardener.NewProvisioner(shootClients, dbsFactory, auditLogPolicyCMName, maintenanceWindowConfigPath, nil)
 
 			releaseRepository := release.NewReleaseRepository(connection, uuidGenerator)
 			provider := release.NewReleaseProvider(releaseRepository, nil)
 
-			inputConverter := provisioning.NewInputConverter(uuidGenerator, provider, "Project", defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+			inputConverter := provisioning.NewInputConverter(uuidGenerator, provider, "Project", defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 			graphQLConverter := provisioning.NewGraphQLConverter()
 
-			provisioningService := provisioning.NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, dbsFactory, provisioner, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, shootHibernationQueue)
+			provisioningService := provisioning.NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, dbsFactory, provisioner, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, shootHibernationQueue, nil, nil, nil, 0, nil, provisioning.RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
-			validator := api.NewValidator(dbsFactory.NewReadSession())
+			validator := api.NewValidator(dbsFactory.NewReadSession(), nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
-			resolver := api.NewResolver(provisioningService, validator)
+			resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 			err = insertDummyReleaseIfNotExist(releaseRepository, uuidGenerator.New(), kymaVersion)
 			require.NoError(t, err)
@@ -301,7 +315,7 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 func testProvisionRuntime(t *testing.T, ctx context.Context, resolver *api.Resolver, fullConfig gqlschema.ProvisionRuntimeInput, runtimeID string, shootInterface gardener_apis.ShootInterface, secretsInterface v1core.SecretInterface, auditLogTenant string) {
 
 	// when Provisioning Runtime
-	provisionRuntime, err := resolver.ProvisionRuntime(ctx, fullConfig)
+	provisionRuntime, err := resolver.ProvisionRuntime(ctx, fullConfig, nil, nil)
 
 	// then
 	require.NoError(t, err)
@@ -351,7 +365,7 @@ func testProvisionRuntime(t *testing.T, ctx context.Context, resolver *api.Resol
 func testUpgradeRuntimeAndRollback(t *testing.T, ctx context.Context, resolver *api.Resolver, dbsFactory dbsession.Factory, runtimeID string) {
 
 	// when Upgrading Runtime
-	upgradeRuntimeOp, err := resolver.UpgradeRuntime(ctx, runtimeID, gqlschema.UpgradeRuntimeInput{KymaConfig: fixKymaGraphQLConfigInput()})
+	upgradeRuntimeOp, err := resolver.UpgradeRuntime(ctx, runtimeID, gqlschema.UpgradeRuntimeInput{KymaConfig: fixKymaGraphQLConfigInput()}, nil, nil, nil)
 
 	// then
 	require.NoError(t, err)
@@ -408,7 +422,7 @@ func testUpgradeGardenerShoot(t *testing.T, ctx context.Context, resolver *api.R
 	runtimeBeforeUpgrade, err := readSession.GetCluster(runtimeID)
 	require.NoError(t, err)
 
-	upgradeShootOp, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput)
+	upgradeShootOp, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput, nil, nil, nil)
 	require.NoError(t, err)
 
 	// for wait for shoot new version step
@@ -450,7 +464,7 @@ func testDeprovisionRuntime(t *testing.T, ctx context.Context, resolver *api.Res
 	require.NoError(t, err)
 
 	// when
-	deprovisionRuntimeID, err := resolver.DeprovisionRuntime(ctx, runtimeID)
+	deprovisionRuntimeID, err := resolver.DeprovisionRuntime(ctx, runtimeID, nil, nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, deprovisionRuntimeID)
 
@@ -494,7 +508,7 @@ func testHibernateRuntime(t *testing.T, ctx context.Context, resolver *api.Resol
 	readSession := dbsFactory.NewReadSession()
 
 	// when
-	hibernationOperation, err := resolver.HibernateRuntime(ctx, runtimeID)
+	hibernationOperation, err := resolver.HibernateRuntime(ctx, runtimeID, nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, hibernationOperation.ID)
 
@@ -554,7 +568,9 @@ func testOperatorRoleBinding() provisioning2.OperatorRoleBinding {
 
 func testHibernationTimeouts() queue.HibernationTimeouts {
 	return queue.HibernationTimeouts{
+		SettingClusterHibernation:    5 * time.Minute,
 		WaitingForClusterHibernation: 5 * time.Minute,
+		WaitingForClusterWakeUp:      5 * time.Minute,
 	}
 }
 
@@ -614,6 +630,7 @@ func simulateHibernation(t *testing.T, f gardener_apis.ShootInterface, shoot *ga
 		require.NoError(t, err)
 
 		s.Status.IsHibernated = true
+		s.Status.LastOperation = &gardener_types.LastOperation{State: gardener_types.LastOperationStateSucceeded}
 
 		_, err = f.Update(context.Background(), s, metav1.UpdateOptions{})
 		require.NoError(t, err)
@@ -633,6 +650,24 @@ func createKubeconfigSecret(t *testing.T, s v1core.SecretInterface, shootName st
 	require.NoError(t, err)
 }
 
+// shootsGetter returns the same client of Shoots for namespaces of all Gardener projects
+type shootsGetter struct {
+	shoots gardener_apis.ShootInterface
+}
+
+func (g shootsGetter) Shoots(string) gardener_apis.ShootInterface {
+	return g.shoots
+}
+
+// secretsGetter returns the same client of Secrets for namespaces of all Gardener projects
+type secretsGetter struct {
+	secrets v1core.SecretInterface
+}
+
+func (g secretsGetter) Secrets(string) v1core.SecretInterface {
+	return g.secrets
+}
+
 func setupSecretsClient(t *testing.T, config *rest.Config) v1core.SecretInterface {
 	coreClient, err := v1core.NewForConfig(config)
 	require.NoError(t, err)
@@ -3,6 +3,9 @@ package api_test
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 
@@ -14,9 +17,12 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/readonly"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -40,8 +46,8 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 			WorkerCidr:             "10.10.10.10/255",
 			AutoScalerMin:          1,
 			AutoScalerMax:          3,
-			MaxSurge:               40,
-			MaxUnavailable:         1,
+			MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(40)),
+			MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
 			ProviderSpecificConfig: nil,
 			OidcConfig:             oidcInput(),
 		},
@@ -56,7 +62,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		kymaConfig := &gqlschema.KymaConfigInput{
 			Version: "1.5",
@@ -82,11 +88,11 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 			KymaConfig:    kymaConfig,
 		}
 
-		provisioningService.On("ProvisionRuntime", config, tenant, "").Return(operation, nil)
+		provisioningService.On("ProvisionRuntime", config, tenant, "", "").Return(operation, nil)
 		validator.On("ValidateProvisioningInput", config).Return(nil)
 
 		//when
-		status, err := resolver.ProvisionRuntime(ctx, config)
+		status, err := resolver.ProvisionRuntime(ctx, config, nil, nil)
 
 		//then
 		require.NoError(t, err)
@@ -104,7 +110,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		kymaConfig := &gqlschema.KymaConfigInput{
 			Version: "1.5",
@@ -115,18 +121,70 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 		validator.On("ValidateProvisioningInput", config).Return(apperrors.BadRequest("Some error"))
 
 		//when
-		status, err := provisioner.ProvisionRuntime(ctx, config)
+		status, err := provisioner.ProvisionRuntime(ctx, config, nil, nil)
 
 		//then
 		require.Error(t, err)
 		assert.Nil(t, status)
 	})
 
+	t.Run("Should return dry run report without starting provisioning when dry run requested", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{RuntimeInput: runtimeInput, ClusterConfig: clusterConfig}
+
+		operation := &gqlschema.OperationStatus{
+			Operation: gqlschema.OperationTypeProvision,
+			State:     gqlschema.OperationStatePending,
+			DryRunReport: &gqlschema.ProvisioningDryRunReport{
+				Valid:    true,
+				Errors:   []string{},
+				Warnings: []string{},
+			},
+		}
+
+		validator.On("ValidateProvisioningInput", config).Return(nil)
+		provisioningService.On("ProvisionRuntimeDryRun", config, tenant, "").Return(operation, nil)
+
+		//when
+		status, err := resolver.ProvisionRuntime(ctx, config, util.BoolPtr(true), nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operation, status)
+		provisioningService.AssertNotCalled(t, "ProvisionRuntime", config, tenant, "", "")
+	})
+
+	t.Run("Should return validation error in dry run report", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{RuntimeInput: runtimeInput, ClusterConfig: clusterConfig}
+
+		validator.On("ValidateProvisioningInput", config).Return(apperrors.BadRequest("Some error"))
+
+		//when
+		status, err := resolver.ProvisionRuntime(ctx, config, util.BoolPtr(true), nil)
+
+		//then
+		require.NoError(t, err)
+		require.NotNil(t, status.DryRunReport)
+		assert.False(t, status.DryRunReport.Valid)
+		assert.Equal(t, []string{"Some error"}, status.DryRunReport.Errors)
+		assert.Nil(t, status.ID)
+		provisioningService.AssertNotCalled(t, "ProvisionRuntimeDryRun", config, tenant, "")
+	})
+
 	t.Run("Should return error when provisioning fails", func(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		kymaConfig := &gqlschema.KymaConfigInput{
 			Version: "1.5",
@@ -140,11 +198,11 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 
 		config := gqlschema.ProvisionRuntimeInput{RuntimeInput: runtimeInput, ClusterConfig: clusterConfig, KymaConfig: kymaConfig}
 
-		provisioningService.On("ProvisionRuntime", config, tenant, "").Return(nil, apperrors.Internal("Provisioning failed"))
+		provisioningService.On("ProvisionRuntime", config, tenant, "", "").Return(nil, apperrors.Internal("Provisioning failed"))
 		validator.On("ValidateProvisioningInput", config).Return(nil)
 
 		//when
-		status, err := provisioner.ProvisionRuntime(ctx, config)
+		status, err := provisioner.ProvisionRuntime(ctx, config, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -156,7 +214,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		kymaConfig := &gqlschema.KymaConfigInput{
 			Version: "1.5",
@@ -179,7 +237,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 		ctx := context.Background()
 
 		//when
-		status, err := provisioner.ProvisionRuntime(ctx, config)
+		status, err := provisioner.ProvisionRuntime(ctx, config, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -194,15 +252,15 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return(expectedID, nil)
+		provisioningService.On("DeprovisionRuntime", runtimeID, tenant, false, "").Return(expectedID, nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
 		//when
-		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID)
+		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID, nil, nil)
 
 		//then
 		require.NoError(t, err)
@@ -213,13 +271,13 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return("", apperrors.Internal("Deprovisioning fails because reasons"))
+		provisioningService.On("DeprovisionRuntime", runtimeID, tenant, false, "").Return("", apperrors.Internal("Deprovisioning fails because reasons"))
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
 		//when
-		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID)
+		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -231,16 +289,16 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return(expectedID, nil, nil)
+		provisioningService.On("DeprovisionRuntime", runtimeID, tenant, false, "").Return(expectedID, nil, nil)
 
 		ctx := context.Background()
 
 		//when
-		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID)
+		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -251,20 +309,59 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID, tenant).Return(expectedID, nil, nil)
+		provisioningService.On("DeprovisionRuntime", runtimeID, tenant, false, "").Return(expectedID, nil, nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(apperrors.BadRequest("Very bad error"))
 
 		//when
-		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID)
+		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID, nil, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Empty(t, operationID)
+	})
+
+	t.Run("Should start force deprovisioning when it is allowed", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		expectedID := "ec781980-0533-4098-aab7-96b535569732"
+
+		provisioningService.On("DeprovisionRuntime", runtimeID, tenant, true, "").Return(expectedID, nil)
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateForceDeprovisioning", runtimeID).Return(nil)
+
+		//when
+		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID, util.BoolPtr(true), nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, operationID)
+	})
+
+	t.Run("Should fail force deprovisioning when it is not allowed", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateForceDeprovisioning", runtimeID).Return(apperrors.BadRequest("cluster is usable"))
+
+		//when
+		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID, util.BoolPtr(true), nil)
 
 		//then
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
 		assert.Empty(t, operationID)
+		provisioningService.AssertNotCalled(t, "DeprovisionRuntime", runtimeID, tenant, true, "")
 	})
 }
 
@@ -288,14 +385,15 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 			RuntimeID: util.StringPtr(runtimeID),
 		}
 
-		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput).Return(operation, nil)
+		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput, tenant, "", false).Return(operation, nil)
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateMaintenanceFreeze", runtimeID, tenant, false).Return(nil)
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
-		status, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput)
+		status, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput, nil, nil, nil)
 
 		//then
 		require.NoError(t, err)
@@ -305,25 +403,74 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 		assert.Equal(t, operation, status)
 	})
 
+	t.Run("Should start upgrade skipping health checks", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+
+		operation := &gqlschema.OperationStatus{
+			ID:        util.StringPtr(operationID),
+			Operation: gqlschema.OperationTypeUpgrade,
+			State:     gqlschema.OperationStateInProgress,
+			RuntimeID: util.StringPtr(runtimeID),
+		}
+
+		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput, tenant, "", true).Return(operation, nil)
+		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateMaintenanceFreeze", runtimeID, tenant, false).Return(nil)
+
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
+
+		//when
+		status, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput, nil, util.BoolPtr(true), nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operation, status)
+		provisioningService.AssertExpectations(t)
+	})
+
 	t.Run("Should return error when upgrade fails", func(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
 
-		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput).Return(nil, apperrors.Internal("error"))
+		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput, tenant, "", false).Return(nil, apperrors.Internal("error"))
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateMaintenanceFreeze", runtimeID, tenant, false).Return(nil)
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
-		_, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput)
+		_, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput, nil, nil, nil)
 
 		//then
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 	})
 
+	t.Run("Should return error when Runtime is frozen by maintenance window", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+
+		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateMaintenanceFreeze", runtimeID, tenant, false).Return(apperrors.ErrMaintenanceFreeze("error"))
+
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
+
+		//when
+		_, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput, nil, nil, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeForbidden)
+		provisioningService.AssertNotCalled(t, "UpgradeRuntime", runtimeID, upgradeInput, tenant, "", false)
+	})
+
 	t.Run("Should return error when tenant validation fails", func(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
@@ -332,10 +479,10 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(apperrors.BadRequest("error"))
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
-		_, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput)
+		_, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput, nil, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -350,10 +497,10 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(apperrors.BadRequest("error"))
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
-		_, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput)
+		_, err := resolver.UpgradeRuntime(ctx, runtimeID, upgradeInput, nil, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -374,7 +521,7 @@ func TestResolver_RollBackUpgradeOperation(t *testing.T) {
 		provisioningService.On("RollBackLastUpgrade", runtimeID).Return(&runtimeStatus, nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
 		status, err := resolver.RollBackUpgradeOperation(ctx, runtimeID)
@@ -392,7 +539,7 @@ func TestResolver_RollBackUpgradeOperation(t *testing.T) {
 		provisioningService.On("RollBackLastUpgrade", runtimeID).Return(nil, apperrors.Internal("error"))
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
 		_, err := resolver.RollBackUpgradeOperation(ctx, runtimeID)
@@ -407,7 +554,7 @@ func TestResolver_RollBackUpgradeOperation(t *testing.T) {
 		validator := &validatorMocks.Validator{}
 		validator.On("ValidateTenant", runtimeID, tenant).Return(apperrors.BadRequest("error"))
 
-		resolver := api.NewResolver(nil, validator)
+		resolver := api.NewResolver(nil, validator, nil, nil)
 
 		//when
 		_, err := resolver.RollBackUpgradeOperation(ctx, runtimeID)
@@ -437,13 +584,14 @@ func TestResolver_UpgradeShoot(t *testing.T) {
 		}
 
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
-		validator.On("ValidateUpgradeShootInput", upgradeShootInput).Return(nil)
-		provisioningService.On("UpgradeGardenerShoot", runtimeID, upgradeShootInput).Return(operation, nil)
+		validator.On("ValidateUpgradeShootInput", runtimeID, upgradeShootInput).Return(nil)
+		validator.On("ValidateMaintenanceFreeze", runtimeID, tenant, false).Return(nil)
+		provisioningService.On("UpgradeGardenerShoot", runtimeID, upgradeShootInput, tenant, "").Return(operation, nil)
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
-		status, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput)
+		status, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput, nil, nil, nil)
 
 		//then
 		require.NoError(t, err)
@@ -452,18 +600,73 @@ func TestResolver_UpgradeShoot(t *testing.T) {
 		require.NotNil(t, status.RuntimeID)
 		assert.Equal(t, operation, status)
 	})
+	t.Run("Should return Shoot spec changes without starting shoot upgrade when dry run requested", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+
+		operation := &gqlschema.OperationStatus{
+			Operation: gqlschema.OperationTypeUpgradeShoot,
+			State:     gqlschema.OperationStatePending,
+			RuntimeID: util.StringPtr(runtimeID),
+			ShootSpecDiff: []*gqlschema.ShootSpecChange{
+				{Path: "spec.kubernetes.version", OldValue: `"1.18.0"`, NewValue: `"1.19.0"`},
+			},
+		}
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateUpgradeShootInput", runtimeID, upgradeShootInput).Return(nil)
+		provisioningService.On("UpgradeGardenerShootDryRun", runtimeID, upgradeShootInput).Return(operation, nil)
+
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
+
+		//when
+		status, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput, util.BoolPtr(true), nil, nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operation, status)
+		provisioningService.AssertNotCalled(t, "UpgradeGardenerShoot", runtimeID, upgradeShootInput, tenant, "")
+	})
+	t.Run("Should start shoot upgrade during maintenance freeze when overridden", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+
+		operation := &gqlschema.OperationStatus{
+			ID:        util.StringPtr(operationID),
+			Operation: gqlschema.OperationTypeUpgradeShoot,
+			State:     gqlschema.OperationStateInProgress,
+			RuntimeID: util.StringPtr(runtimeID),
+		}
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateUpgradeShootInput", runtimeID, upgradeShootInput).Return(nil)
+		validator.On("ValidateMaintenanceFreeze", runtimeID, tenant, true).Return(nil)
+		provisioningService.On("UpgradeGardenerShoot", runtimeID, upgradeShootInput, tenant, "").Return(operation, nil)
+
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
+
+		//when
+		status, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput, nil, nil, util.BoolPtr(true))
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operation, status)
+		validator.AssertExpectations(t)
+	})
 	t.Run("Should return error when tenant validation fails", func(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
 
 		validator.On("ValidateTenant", runtimeID, tenant).Return(apperrors.BadRequest("error"))
-		validator.On("ValidateUpgradeShootInput", upgradeShootInput).Return(nil)
+		validator.On("ValidateUpgradeShootInput", runtimeID, upgradeShootInput).Return(nil)
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
-		_, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput)
+		_, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput, nil, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -475,12 +678,12 @@ func TestResolver_UpgradeShoot(t *testing.T) {
 		validator := &validatorMocks.Validator{}
 
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
-		validator.On("ValidateUpgradeShootInput", upgradeShootInput).Return(apperrors.BadRequest("error"))
+		validator.On("ValidateUpgradeShootInput", runtimeID, upgradeShootInput).Return(apperrors.BadRequest("error"))
 
-		resolver := api.NewResolver(provisioningService, validator)
+		resolver := api.NewResolver(provisioningService, validator, nil, nil)
 
 		//when
-		_, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput)
+		_, err := resolver.UpgradeShoot(ctx, runtimeID, upgradeShootInput, nil, nil, nil)
 
 		//then
 		require.Error(t, err)
@@ -496,7 +699,7 @@ func TestResolver_RuntimeStatus(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
 		message := "some message"
@@ -528,7 +731,7 @@ func TestResolver_RuntimeStatus(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		provisioningService.On("RuntimeStatus", runtimeID).Return(nil, apperrors.Internal("Runtime status fails"))
 		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
@@ -546,7 +749,7 @@ func TestResolver_RuntimeStatus(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		provisioningService.On("RuntimeStatus", runtimeID).Return(nil, nil)
 		validator.On("ValidateTenant", runtimeID, tenant).Return(apperrors.BadRequest("Bad error"))
@@ -561,6 +764,58 @@ func TestResolver_RuntimeStatus(t *testing.T) {
 	})
 }
 
+func TestResolver_OperationsHistory(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+	first := 10
+
+	t.Run("Should return operations history", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		history := &gqlschema.OperationsHistory{
+			Operations: []*gqlschema.OperationHistoryEntry{
+				{
+					ID:        "acc5040c-3bb6-47b8-8651-07f6950bd0a7",
+					Operation: gqlschema.OperationTypeProvision,
+					State:     gqlschema.OperationStateSucceeded,
+				},
+			},
+			TotalCount: 1,
+		}
+
+		provisioningService.On("OperationsHistory", runtimeID, &first, (*string)(nil)).Return(history, nil)
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+
+		//when
+		operationsHistory, err := provisioner.OperationsHistory(ctx, runtimeID, &first, nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, history, operationsHistory)
+	})
+
+	t.Run("Should return error when tenant header does not match tenant provided during provisioning", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(apperrors.BadRequest("Bad error"))
+
+		//when
+		operationsHistory, err := provisioner.OperationsHistory(ctx, runtimeID, &first, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.Empty(t, operationsHistory)
+		provisioningService.AssertNotCalled(t, "OperationsHistory", runtimeID, &first, (*string)(nil))
+	})
+}
+
 func TestResolver_RuntimeOperationStatus(t *testing.T) {
 	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
 	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
@@ -569,7 +824,7 @@ func TestResolver_RuntimeOperationStatus(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
 		message := "some message"
@@ -597,7 +852,7 @@ func TestResolver_RuntimeOperationStatus(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
 		message := "some message"
@@ -626,7 +881,7 @@ func TestResolver_RuntimeOperationStatus(t *testing.T) {
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
 		validator.On("ValidateTenantForOperation", operationID, tenant).Return(nil)
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		provisioningService.On("RuntimeOperationStatus", operationID).Return(nil, apperrors.Internal("Some error"))
 
@@ -648,7 +903,7 @@ func TestResolver_HibernateCluster(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
 		message := "some message"
@@ -661,11 +916,12 @@ func TestResolver_HibernateCluster(t *testing.T) {
 			Message:   &message,
 		}
 
-		provisioningService.On("HibernateCluster", operationID).Return(operationStatus, nil)
+		provisioningService.On("HibernateCluster", operationID, (*time.Time)(nil)).Return(operationStatus, nil)
 		validator.On("ValidateTenant", operationID, tenant).Return(nil)
+		validator.On("ValidateHibernation", operationID).Return(nil)
 
 		//when
-		status, err := provisioner.HibernateRuntime(ctx, operationID)
+		status, err := provisioner.HibernateRuntime(ctx, operationID, nil)
 
 		//then
 		require.NoError(t, err)
@@ -676,15 +932,16 @@ func TestResolver_HibernateCluster(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
 
-		provisioningService.On("HibernateCluster", operationID).Return(nil, apperrors.Internal("Some error"))
+		provisioningService.On("HibernateCluster", operationID, (*time.Time)(nil)).Return(nil, apperrors.Internal("Some error"))
 		validator.On("ValidateTenant", operationID, tenant).Return(nil)
+		validator.On("ValidateHibernation", operationID).Return(nil)
 
 		//when
-		status, err := provisioner.HibernateRuntime(ctx, operationID)
+		status, err := provisioner.HibernateRuntime(ctx, operationID, nil)
 
 		//then
 		require.Error(t, err)
@@ -696,7 +953,7 @@ func TestResolver_HibernateCluster(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
 		validator := &validatorMocks.Validator{}
-		provisioner := api.NewResolver(provisioningService, validator)
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
 
 		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
 		message := "some message"
@@ -709,15 +966,158 @@ func TestResolver_HibernateCluster(t *testing.T) {
 			Message:   &message,
 		}
 
-		provisioningService.On("HibernateCluster", operationID).Return(operationStatus, nil)
+		provisioningService.On("HibernateCluster", operationID, (*time.Time)(nil)).Return(operationStatus, nil)
 		validator.On("ValidateTenant", operationID, tenant).Return(apperrors.BadRequest("oh no"))
 		//when
-		status, err := provisioner.HibernateRuntime(ctx, operationID)
+		status, err := provisioner.HibernateRuntime(ctx, operationID, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.Empty(t, status)
+	})
+
+	t.Run("Should return error when hibernation validation fails", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
+
+		validator.On("ValidateTenant", operationID, tenant).Return(nil)
+		validator.On("ValidateHibernation", operationID).Return(apperrors.BadRequest("already hibernated"))
+
+		//when
+		status, err := provisioner.HibernateRuntime(ctx, operationID, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.Empty(t, status)
+		provisioningService.AssertNotCalled(t, "HibernateCluster", mock.Anything, mock.Anything)
+	})
+}
+
+func TestResolver_WakeUpCluster(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+	notBefore := time.Now().Add(time.Hour)
+
+	t.Run("Should schedule wake-up of cluster", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
+
+		operationStatus := &gqlschema.OperationStatus{
+			ID:        &operationID,
+			Operation: gqlschema.OperationTypeWakeUp,
+			State:     gqlschema.OperationStateInProgress,
+			RuntimeID: &runtimeID,
+		}
+
+		provisioningService.On("WakeUpCluster", runtimeID, &notBefore).Return(operationStatus, nil)
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateWakeUp", runtimeID).Return(nil)
+
+		//when
+		status, err := provisioner.WakeUpRuntime(ctx, runtimeID, &notBefore)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operationStatus, status)
+	})
+
+	t.Run("Should return error when cluster is not hibernated", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateWakeUp", runtimeID).Return(apperrors.BadRequest("not hibernated"))
+
+		//when
+		status, err := provisioner.WakeUpRuntime(ctx, runtimeID, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.Empty(t, status)
+		provisioningService.AssertNotCalled(t, "WakeUpCluster", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when wake-up fails", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		provisioningService.On("WakeUpCluster", runtimeID, (*time.Time)(nil)).Return(nil, apperrors.Internal("Some error"))
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateWakeUp", runtimeID).Return(nil)
+
+		//when
+		status, err := provisioner.WakeUpRuntime(ctx, runtimeID, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+		require.Empty(t, status)
+	})
+}
+
+func TestResolver_CleanupFailedProvisioning(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+
+	t.Run("Should start cleanup of failed provisioning", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
+
+		operationStatus := &gqlschema.OperationStatus{
+			ID:        &operationID,
+			Operation: gqlschema.OperationTypeCleanupFailedProvisioning,
+			State:     gqlschema.OperationStateInProgress,
+			RuntimeID: &runtimeID,
+		}
+
+		provisioningService.On("CleanupFailedProvisioning", runtimeID).Return(operationStatus, nil)
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateCleanupFailedProvisioning", runtimeID).Return(nil)
+
+		//when
+		status, err := provisioner.CleanupFailedProvisioning(ctx, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operationStatus, status)
+	})
+
+	t.Run("Should return error when provisioning did not fail", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		validator.On("ValidateCleanupFailedProvisioning", runtimeID).Return(apperrors.BadRequest("provisioning succeeded"))
+
+		//when
+		status, err := provisioner.CleanupFailedProvisioning(ctx, runtimeID)
 
 		//then
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
 		require.Empty(t, status)
+		provisioningService.AssertNotCalled(t, "CleanupFailedProvisioning", mock.Anything)
 	})
 }
 
@@ -731,3 +1131,315 @@ func oidcInput() *gqlschema.OIDCConfigInput {
 		UsernamePrefix: "-",
 	}
 }
+
+func TestResolver_AnnotateOperation(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+	operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
+
+	t.Run("Should annotate operation", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		operationStatus := &gqlschema.OperationStatus{
+			ID:        &operationID,
+			Operation: gqlschema.OperationTypeProvision,
+			State:     gqlschema.OperationStateFailed,
+			Annotations: []*gqlschema.OperationAnnotation{
+				{Key: "ticket", Value: "12345"},
+			},
+		}
+
+		validator.On("ValidateOperationAnnotation", operationID, tenant, "ticket", "12345").Return(nil)
+		provisioningService.On("AnnotateOperation", operationID, "ticket", "12345").Return(operationStatus, nil)
+
+		//when
+		status, err := provisioner.AnnotateOperation(ctx, operationID, "ticket", "12345")
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operationStatus, status)
+	})
+
+	t.Run("Should return error when annotation validation fails", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		validator.On("ValidateOperationAnnotation", operationID, tenant, "ticket id", "12345").Return(apperrors.BadRequest("oh no"))
+
+		//when
+		status, err := provisioner.AnnotateOperation(ctx, operationID, "ticket id", "12345")
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.Empty(t, status)
+		provisioningService.AssertNotCalled(t, "AnnotateOperation", operationID, "ticket id", "12345")
+	})
+
+	t.Run("Should return error when annotating operation fails", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		validator.On("ValidateOperationAnnotation", operationID, tenant, "ticket", "").Return(nil)
+		provisioningService.On("AnnotateOperation", operationID, "ticket", "").Return(nil, apperrors.Internal("Some error"))
+
+		//when
+		status, err := provisioner.AnnotateOperation(ctx, operationID, "ticket", "")
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+		require.Empty(t, status)
+	})
+}
+
+func TestResolver_ScheduleDeprovisioning(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+	scheduledAt := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should schedule deprovisioning and return Runtime status", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		scheduler := &validatorMocks.DeprovisioningScheduler{}
+		resolver := api.NewResolver(provisioningService, validator, nil, scheduler)
+
+		runtimeStatus := &gqlschema.RuntimeStatus{DeprovisioningScheduledAt: &scheduledAt}
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		scheduler.On("Schedule", runtimeID, scheduledAt).Return(nil)
+		provisioningService.On("RuntimeStatus", runtimeID).Return(runtimeStatus, nil)
+
+		//when
+		status, err := resolver.ScheduleDeprovisioning(ctx, runtimeID, scheduledAt)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, runtimeStatus, status)
+	})
+
+	t.Run("Should return error when deprovisioning cannot be scheduled", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		scheduler := &validatorMocks.DeprovisioningScheduler{}
+		resolver := api.NewResolver(provisioningService, validator, nil, scheduler)
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		scheduler.On("Schedule", runtimeID, scheduledAt).Return(apperrors.BadRequest("in the past"))
+
+		//when
+		status, err := resolver.ScheduleDeprovisioning(ctx, runtimeID, scheduledAt)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.Empty(t, status)
+		provisioningService.AssertNotCalled(t, "RuntimeStatus", runtimeID)
+	})
+}
+
+func TestResolver_CancelScheduledDeprovisioning(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+
+	t.Run("Should cancel scheduled deprovisioning and return Runtime status", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		scheduler := &validatorMocks.DeprovisioningScheduler{}
+		resolver := api.NewResolver(provisioningService, validator, nil, scheduler)
+
+		runtimeStatus := &gqlschema.RuntimeStatus{}
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		scheduler.On("Cancel", runtimeID).Return(nil)
+		provisioningService.On("RuntimeStatus", runtimeID).Return(runtimeStatus, nil)
+
+		//when
+		status, err := resolver.CancelScheduledDeprovisioning(ctx, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, runtimeStatus, status)
+	})
+
+	t.Run("Should return error when deprovisioning already started", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		scheduler := &validatorMocks.DeprovisioningScheduler{}
+		resolver := api.NewResolver(provisioningService, validator, nil, scheduler)
+
+		validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+		scheduler.On("Cancel", runtimeID).Return(apperrors.BadRequest("already started"))
+
+		//when
+		status, err := resolver.CancelScheduledDeprovisioning(ctx, runtimeID)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.Empty(t, status)
+	})
+}
+
+func TestResolver_DeprovisioningScheduledWarning(t *testing.T) {
+	scheduledAt := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		description      string
+		scheduledAt      *time.Time
+		expectedWarnings interface{}
+	}{
+		{
+			description:      "Should warn about scheduled deprovisioning",
+			scheduledAt:      &scheduledAt,
+			expectedWarnings: []string{"Runtime " + runtimeID + " is scheduled for deprovisioning at 2026-12-31T00:00:00Z"},
+		},
+		{
+			description: "Should not warn when deprovisioning is not scheduled",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			requestContext := &graphql.RequestContext{}
+			ctx := graphql.WithRequestContext(context.WithValue(context.Background(), middlewares.Tenant, tenant), requestContext)
+
+			provisioningService := &mocks.Service{}
+			validator := &validatorMocks.Validator{}
+			scheduler := &validatorMocks.DeprovisioningScheduler{}
+			resolver := api.NewResolver(provisioningService, validator, nil, scheduler)
+
+			operationStatus := &gqlschema.OperationStatus{ID: util.StringPtr(operationID)}
+			validator.On("ValidateTenant", runtimeID, tenant).Return(nil)
+			validator.On("ValidateHibernation", runtimeID).Return(nil)
+			scheduler.On("ScheduledAt", runtimeID).Return(testCase.scheduledAt, nil)
+			provisioningService.On("HibernateCluster", runtimeID, (*time.Time)(nil)).Return(operationStatus, nil)
+
+			//when
+			status, err := resolver.HibernateRuntime(ctx, runtimeID, nil)
+
+			//then
+			require.NoError(t, err)
+			assert.Equal(t, operationStatus, status)
+			assert.Equal(t, testCase.expectedWarnings, requestContext.Extensions["warnings"])
+		})
+	}
+}
+
+func TestResolver_SetReadOnlyMode(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+
+	t.Run("Should set read-only mode", func(t *testing.T) {
+		//given
+		validator := &validatorMocks.Validator{}
+		readOnlyMode := &validatorMocks.ReadOnlyMode{}
+		resolver := api.NewResolver(nil, validator, readOnlyMode, nil)
+
+		validator.On("ValidateAdminTenant", tenant).Return(nil)
+		readOnlyMode.On("Set", true, util.StringPtr("Database migration")).Return(readonly.Status{Enabled: true, Message: "Database migration"}, nil)
+
+		//when
+		status, err := resolver.SetReadOnlyMode(ctx, true, util.StringPtr("Database migration"))
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, &gqlschema.ReadOnlyModeStatus{Enabled: true, Message: "Database migration"}, status)
+	})
+
+	t.Run("Should return error when tenant is not admin", func(t *testing.T) {
+		//given
+		validator := &validatorMocks.Validator{}
+		readOnlyMode := &validatorMocks.ReadOnlyMode{}
+		resolver := api.NewResolver(nil, validator, readOnlyMode, nil)
+
+		validator.On("ValidateAdminTenant", tenant).Return(apperrors.Forbidden("not admin"))
+
+		//when
+		status, err := resolver.SetReadOnlyMode(ctx, true, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeForbidden)
+		require.Empty(t, status)
+		readOnlyMode.AssertNotCalled(t, "Set", true, (*string)(nil))
+	})
+
+	t.Run("Should return error when read-only mode cannot be stored", func(t *testing.T) {
+		//given
+		validator := &validatorMocks.Validator{}
+		readOnlyMode := &validatorMocks.ReadOnlyMode{}
+		resolver := api.NewResolver(nil, validator, readOnlyMode, nil)
+
+		validator.On("ValidateAdminTenant", tenant).Return(nil)
+		readOnlyMode.On("Set", false, (*string)(nil)).Return(readonly.Status{}, apperrors.Internal("Some error"))
+
+		//when
+		status, err := resolver.SetReadOnlyMode(ctx, false, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+		require.Empty(t, status)
+	})
+}
+
+func TestResolver_ProviderDefaults(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, tenant)
+
+	t.Run("Should return provider defaults", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		kymaVersion := "1.24.10"
+		defaults := &gqlschema.ProviderDefaults{
+			Provider:                          gqlschema.ProviderGcp,
+			EnableKubernetesVersionAutoUpdate: true,
+			Regions:                           &gqlschema.ProviderRegions{ProviderAllowed: true, AllowedRegions: []string{}, DeniedRegions: []string{}, DeniedZones: []string{}},
+			KymaVersion:                       &kymaVersion,
+		}
+		provisioningService.On("ProviderDefaults", gqlschema.ProviderGcp).Return(defaults, nil)
+
+		//when
+		result, err := provisioner.ProviderDefaults(ctx, gqlschema.ProviderGcp)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, defaults, result)
+	})
+
+	t.Run("Should return error when failed to get provider defaults", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		validator := &validatorMocks.Validator{}
+		provisioner := api.NewResolver(provisioningService, validator, nil, nil)
+
+		provisioningService.On("ProviderDefaults", gqlschema.ProviderAws).Return(nil, apperrors.Internal("Some error"))
+
+		//when
+		result, err := provisioner.ProviderDefaults(ctx, gqlschema.ProviderAws)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+		require.Empty(t, result)
+	})
+}
+
+func TestResolver_Info(t *testing.T) {
+	//given
+	provisioner := api.NewResolver(&mocks.Service{}, &validatorMocks.Validator{}, nil, nil)
+
+	//when
+	info, err := provisioner.Info(context.Background())
+
+	//then
+	require.NoError(t, err)
+	assert.Equal(t, &gqlschema.Info{SchemaVersion: gqlschema.SchemaVersion}, info)
+}
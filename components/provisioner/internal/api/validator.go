@@ -1,39 +1,145 @@
 package api
 
 import (
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/freeze"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const RuntimeAgent = "compass-runtime-agent"
 
+const maxIdempotencyKeyLength = 256
+
+// Limits of the operation annotations, the keys follow the syntax of the Kubernetes label names
+const (
+	maxOperationAnnotationKeyLength   = 63
+	maxOperationAnnotationValueLength = 1024
+	maxOperationAnnotations           = 20
+)
+
+var operationAnnotationKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+// allowedVolumeTypes lists volume types supported for the worker nodes of the given provider
+var allowedVolumeTypes = map[string][]string{
+	"gcp":   {"pd-standard", "pd-balanced", "pd-ssd"},
+	"azure": {"Standard_LRS", "StandardSSD_LRS", "Premium_LRS"},
+	"aws":   {"standard", "gp2", "gp3", "io1"},
+}
+
+// percentagePattern matches the percentages accepted by Gardener for the rollout settings of the workers, e.g. 25%
+var percentagePattern = regexp.MustCompile(`^[0-9]+%$`)
+
+// Idle connection timeout range of the Azure NAT gateway in minutes
+const (
+	minNatGatewayIdleConnectionTimeout = 4
+	maxNatGatewayIdleConnectionTimeout = 120
+)
+
 //go:generate mockery -name=Validator
 type Validator interface {
 	ValidateProvisioningInput(input gqlschema.ProvisionRuntimeInput) apperrors.AppError
 	ValidateUpgradeInput(input gqlschema.UpgradeRuntimeInput) apperrors.AppError
-	ValidateUpgradeShootInput(input gqlschema.UpgradeShootInput) apperrors.AppError
+	ValidateUpgradeShootInput(runtimeID string, input gqlschema.UpgradeShootInput) apperrors.AppError
 	ValidateTenant(runtimeID, tenant string) apperrors.AppError
 	ValidateTenantForOperation(operationID, tenant string) apperrors.AppError
+	ValidateForceDeprovisioning(runtimeID string) apperrors.AppError
+	ValidateHibernation(runtimeID string) apperrors.AppError
+	ValidateWakeUp(runtimeID string) apperrors.AppError
+	ValidateCleanupFailedProvisioning(runtimeID string) apperrors.AppError
+	ValidateExpirationExtension(runtimeID string, expireAt *time.Time) apperrors.AppError
+	ValidateOperationAnnotation(operationID, tenant, key, value string) apperrors.AppError
+	ValidateAdminTenant(tenant string) apperrors.AppError
+	ValidateMaintenanceFreeze(runtimeID, tenant string, override bool) apperrors.AppError
+}
+
+//go:generate mockery -name=SecretBindingValidator
+type SecretBindingValidator interface {
+	ValidateSecretBinding(project, name, providerType string) apperrors.AppError
+	ValidateDNSSecret(project, name string) apperrors.AppError
+}
+
+//go:generate mockery -name=VersionValidator
+type VersionValidator interface {
+	ValidateKubernetesVersion(cloudProfileName, kubernetesVersion string) apperrors.AppError
+	ValidateMachineImageVersion(cloudProfileName, imageName, imageVersion string) apperrors.AppError
+}
+
+//go:generate mockery -name=RegionPolicy
+type RegionPolicy interface {
+	ValidateRegion(provider, region string) apperrors.AppError
+	ValidateZones(provider string, zones []string) apperrors.AppError
 }
 
+//go:generate mockery -name=MaintenanceFreeze
+type MaintenanceFreeze interface {
+	ActiveWindowFor(provider, region, tenant string) (freeze.ActiveWindow, bool)
+}
+
+//go:generate mockery -name=ShootNameValidator
+type ShootNameValidator interface {
+	ValidateShootName(name, project string) error
+}
+
+// provisionerAnnotationPrefix is reserved for the annotations set by the Provisioner itself
+const provisionerAnnotationPrefix = "kcp.provisioner.kyma-project.io/"
+
 type validator struct {
-	readSession dbsession.ReadSession
+	readSession                    dbsession.ReadSession
+	secretBindingValidator         SecretBindingValidator
+	maxInstallationTimeout         time.Duration
+	allowedShootAnnotationPrefixes []string
+	allowedFeatureGates            []string
+	allowedGardenerProjects        []string
+	versionValidator               VersionValidator
+	regionPolicy                   RegionPolicy
+	adminTenants                   []string
+	maintenanceFreeze              MaintenanceFreeze
+	shootNameValidator             ShootNameValidator
 }
 
-func NewValidator(readSession dbsession.ReadSession) Validator {
+// NewValidator creates Validator, the target secret binding and DNS provider secrets are not validated if secretBindingValidator is nil
+// and the installation timeout is not limited if maxInstallationTimeout is 0.
+// Shoot annotations are accepted only if their keys start with one of the allowedShootAnnotationPrefixes,
+// only the allowedFeatureGates can be set on the kube-apiserver and Shoots can be created only in one of the allowedGardenerProjects.
+// Kubernetes and machine image versions are not checked against the Gardener CloudProfiles if versionValidator is nil
+// and providers, regions and zones are not restricted if regionPolicy is nil.
+// The adminTenants can annotate operations of all tenants, retry failed operations in bulk and override the maintenance freeze.
+// Upgrades are not frozen if maintenanceFreeze is nil and the requested Shoot names are not checked if shootNameValidator is nil.
+func NewValidator(readSession dbsession.ReadSession, secretBindingValidator SecretBindingValidator, maxInstallationTimeout time.Duration, allowedShootAnnotationPrefixes, allowedFeatureGates, allowedGardenerProjects []string, versionValidator VersionValidator, regionPolicy RegionPolicy, adminTenants []string, maintenanceFreeze MaintenanceFreeze, shootNameValidator ShootNameValidator) Validator {
 	return &validator{
-		readSession: readSession,
+		readSession:                    readSession,
+		secretBindingValidator:         secretBindingValidator,
+		maxInstallationTimeout:         maxInstallationTimeout,
+		allowedShootAnnotationPrefixes: allowedShootAnnotationPrefixes,
+		allowedFeatureGates:            allowedFeatureGates,
+		allowedGardenerProjects:        allowedGardenerProjects,
+		versionValidator:               versionValidator,
+		regionPolicy:                   regionPolicy,
+		adminTenants:                   adminTenants,
+		maintenanceFreeze:              maintenanceFreeze,
+		shootNameValidator:             shootNameValidator,
 	}
 }
 
 func (v *validator) ValidateProvisioningInput(input gqlschema.ProvisionRuntimeInput) apperrors.AppError {
-	if err := v.validateKymaConfig(input.KymaConfig); err != nil {
-		return err.Append("Kyma config validation error while starting Runtime provisioning")
+	// Kyma config is not provided if Kyma is managed externally
+	if input.KymaConfig != nil {
+		if err := v.validateKymaConfig(input.KymaConfig); err != nil {
+			return err.Append("Kyma config validation error while starting Runtime provisioning")
+		}
 	}
 
 	if input.RuntimeInput == nil {
@@ -44,6 +150,24 @@ func (v *validator) ValidateProvisioningInput(input gqlschema.ProvisionRuntimeIn
 		return err.Append("Cluster config validation error while starting Runtime provisioning")
 	}
 
+	if err := validateExpiration(input.ExpirationSeconds, input.ExpireAt); err != nil {
+		return err.Append("expiration validation error while starting Runtime provisioning")
+	}
+
+	return nil
+}
+
+func validateExpiration(expirationSeconds *int, expireAt *time.Time) apperrors.AppError {
+	if expirationSeconds != nil && expireAt != nil {
+		return apperrors.BadRequest("error: expirationSeconds and expireAt cannot be set together")
+	}
+	if expirationSeconds != nil && *expirationSeconds <= 0 {
+		return apperrors.BadRequest("error: expirationSeconds must be positive, got %d", *expirationSeconds)
+	}
+	if expireAt != nil && !expireAt.After(time.Now()) {
+		return apperrors.BadRequest("error: expireAt %s is in the past", expireAt.Format(time.RFC3339))
+	}
+
 	return nil
 }
 
@@ -56,7 +180,7 @@ func (v *validator) ValidateUpgradeInput(input gqlschema.UpgradeRuntimeInput) ap
 	return nil
 }
 
-func (v *validator) ValidateUpgradeShootInput(input gqlschema.UpgradeShootInput) apperrors.AppError {
+func (v *validator) ValidateUpgradeShootInput(runtimeID string, input gqlschema.UpgradeShootInput) apperrors.AppError {
 
 	config := input.GardenerConfig
 
@@ -64,6 +188,10 @@ func (v *validator) ValidateUpgradeShootInput(input gqlschema.UpgradeShootInput)
 		return apperrors.BadRequest("validation error while starting starting Shoot Upgrade: Gardener Config is missing")
 	}
 
+	if isEmptyShootUpgrade(input) {
+		return apperrors.BadRequest("validation error while starting Shoot Upgrade: no changes provided")
+	}
+
 	if config.MachineType != nil && *config.MachineType == "" {
 		return apperrors.BadRequest("empty machine type provided")
 	}
@@ -80,6 +208,76 @@ func (v *validator) ValidateUpgradeShootInput(input gqlschema.UpgradeShootInput)
 		return apperrors.BadRequest("empty purpose provided")
 	}
 
+	if config.KubernetesVersion != nil || config.MachineImageVersion != nil {
+		if err := v.validateVersionsUpgrade(runtimeID, config); err != nil {
+			return err
+		}
+	}
+
+	if config.DiskType != nil || config.VolumeSizeGb != nil {
+		if err := v.validateVolumeUpgrade(runtimeID, config.DiskType, config.VolumeSizeGb); err != nil {
+			return err
+		}
+	}
+
+	if config.MaxSurge != nil || config.MaxUnavailable != nil {
+		if err := v.validateRolloutSettingsUpgrade(runtimeID, config.MaxSurge, config.MaxUnavailable); err != nil {
+			return err
+		}
+	}
+
+	if config.NetworkingType != nil {
+		if err := v.validateNetworkingTypeUpgrade(runtimeID, *config.NetworkingType); err != nil {
+			return err
+		}
+	}
+
+	if err := v.validateShootAnnotations(config.ShootAnnotations); err != nil {
+		return err
+	}
+
+	if err := validateCostAllocation(config.CostAllocation); err != nil {
+		return err
+	}
+
+	if _, err := model.ClusterAutoscalerConfigFromInput(config.ClusterAutoscalerConfig, nil); err != nil {
+		return err
+	}
+
+	if err := v.validateKubeAPIServerConfig(config.KubeAPIServerConfig); err != nil {
+		return err
+	}
+
+	if config.DNSConfig != nil {
+		if err := v.validateDNSConfigUpgrade(runtimeID, config.DNSConfig); err != nil {
+			return err
+		}
+	}
+
+	if zones := requestedZones(config.ProviderSpecificConfig); len(zones) > 0 {
+		if err := v.validateZonesUpgrade(runtimeID, zones); err != nil {
+			return err
+		}
+	}
+
+	if config.ProviderSpecificConfig != nil && config.ProviderSpecificConfig.AzureConfig != nil {
+		if err := v.validateAzureConfigUpgrade(runtimeID, config.ProviderSpecificConfig.AzureConfig); err != nil {
+			return err
+		}
+	}
+
+	if config.ProviderSpecificConfig != nil && config.ProviderSpecificConfig.GcpConfig != nil {
+		if err := v.validateGCPConfigUpgrade(runtimeID, config.ProviderSpecificConfig.GcpConfig); err != nil {
+			return err
+		}
+	}
+
+	if config.ProviderSpecificConfig != nil && config.ProviderSpecificConfig.AwsConfig != nil {
+		if err := v.validateAWSConfigUpgrade(runtimeID, config.ProviderSpecificConfig.AwsConfig); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -107,6 +305,202 @@ func (v *validator) ValidateTenantForOperation(operationID, tenant string) apper
 	return nil
 }
 
+// ValidateForceDeprovisioning allows skipping in-cluster cleanup only if the cluster cannot be reached or the last operation failed
+func (v *validator) ValidateForceDeprovisioning(runtimeID string) apperrors.AppError {
+	lastOperation, dberr := v.readSession.GetLastOperation(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get last operation from database: %s", dberr.Error())
+	}
+
+	if lastOperation.State == model.Failed {
+		return nil
+	}
+
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	if cluster.Kubeconfig == nil {
+		return nil
+	}
+
+	if _, err := k8s.ParseToK8sConfig([]byte(*cluster.Kubeconfig)); err != nil {
+		return nil
+	}
+
+	return apperrors.BadRequest("error: force deprovisioning is allowed only for Runtimes with failed last operation or unusable kubeconfig")
+}
+
+func (v *validator) ValidateHibernation(runtimeID string) apperrors.AppError {
+	cluster, err := v.getClusterWithoutOperationInProgress(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	if cluster.Hibernated {
+		return apperrors.BadRequest("error: Runtime %s is already hibernated", runtimeID)
+	}
+
+	return nil
+}
+
+func (v *validator) ValidateWakeUp(runtimeID string) apperrors.AppError {
+	cluster, err := v.getClusterWithoutOperationInProgress(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	if !cluster.Hibernated {
+		return apperrors.BadRequest("error: Runtime %s is not hibernated", runtimeID)
+	}
+
+	return nil
+}
+
+func (v *validator) ValidateCleanupFailedProvisioning(runtimeID string) apperrors.AppError {
+	lastOperation, dberr := v.readSession.GetLastOperation(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get last operation from database: %s", dberr.Error())
+	}
+
+	if lastOperation.Type != model.Provision || lastOperation.State != model.Failed {
+		return apperrors.BadRequest("error: cleanup is allowed only for Runtimes with failed provisioning, last operation of Runtime %s is %s in state %s", runtimeID, lastOperation.Type, lastOperation.State)
+	}
+
+	return nil
+}
+
+// ValidateExpirationExtension allows only postponing the expiration of the Runtime which is not deleted,
+// the expiration can be removed at any time
+func (v *validator) ValidateExpirationExtension(runtimeID string, expireAt *time.Time) apperrors.AppError {
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	if cluster.Deleted {
+		return apperrors.BadRequest("error: Runtime %s is deleted", runtimeID)
+	}
+
+	if expireAt == nil {
+		return nil
+	}
+
+	if err := validateExpiration(nil, expireAt); err != nil {
+		return err
+	}
+
+	if cluster.ExpireAt != nil && expireAt.Before(*cluster.ExpireAt) {
+		return apperrors.BadRequest("error: expiration of Runtime %s can only be postponed, it expires at %s", runtimeID, cluster.ExpireAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// ValidateOperationAnnotation allows only the tenant owning the operation or the admin tenant to annotate it,
+// the number of annotations is limited, but existing ones can always be replaced or removed
+func (v *validator) ValidateOperationAnnotation(operationID, tenant, key, value string) apperrors.AppError {
+	if !v.isAdminTenant(tenant) {
+		if err := v.ValidateTenantForOperation(operationID, tenant); err != nil {
+			return err
+		}
+	}
+
+	if len(key) > maxOperationAnnotationKeyLength || !operationAnnotationKeyPattern.MatchString(key) {
+		return apperrors.BadRequest("error: invalid annotation key %q, it has to consist of at most %d alphanumeric characters, '-', '_' or '.', and start and end with an alphanumeric character",
+			key, maxOperationAnnotationKeyLength)
+	}
+
+	if len(value) > maxOperationAnnotationValueLength {
+		return apperrors.BadRequest("error: annotation value cannot be longer than %d characters", maxOperationAnnotationValueLength)
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	annotations, dberr := v.readSession.ListOperationAnnotations(operationID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get annotations of operation from database: %s", dberr.Error())
+	}
+	for _, annotation := range annotations {
+		if annotation.Key == key {
+			return nil
+		}
+	}
+	if len(annotations) >= maxOperationAnnotations {
+		return apperrors.BadRequest("error: operation %s already has the maximum of %d annotations", operationID, maxOperationAnnotations)
+	}
+
+	return nil
+}
+
+// ValidateAdminTenant allows only the admin tenants to manage operations of all tenants at once
+func (v *validator) ValidateAdminTenant(tenant string) apperrors.AppError {
+	if !v.isAdminTenant(tenant) {
+		return apperrors.Forbidden("error: tenant %s is not allowed to manage operations of all tenants", tenant)
+	}
+
+	return nil
+}
+
+// ValidateMaintenanceFreeze rejects the upgrade of the Runtime during the active maintenance freeze window applying to it,
+// only the admin tenants can override the freeze
+func (v *validator) ValidateMaintenanceFreeze(runtimeID, tenant string, override bool) apperrors.AppError {
+	if v.maintenanceFreeze == nil {
+		return nil
+	}
+
+	if override {
+		if !v.isAdminTenant(tenant) {
+			return apperrors.Forbidden("error: tenant %s is not allowed to override the maintenance freeze", tenant)
+		}
+		return nil
+	}
+
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	window, frozen := v.maintenanceFreeze.ActiveWindowFor(cluster.ClusterConfig.Provider, cluster.ClusterConfig.Region, cluster.Tenant)
+	if frozen {
+		return apperrors.ErrMaintenanceFreeze("error: upgrades of Runtime %s are frozen by the maintenance freeze window %s until %s",
+			runtimeID, window.Name, window.End.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (v *validator) isAdminTenant(tenant string) bool {
+	for _, adminTenant := range v.adminTenants {
+		if adminTenant != "" && tenant == adminTenant {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *validator) getClusterWithoutOperationInProgress(runtimeID string) (model.Cluster, apperrors.AppError) {
+	lastOperation, dberr := v.readSession.GetLastOperation(runtimeID)
+	if dberr != nil {
+		return model.Cluster{}, apperrors.Internal("Failed to get last operation from database: %s", dberr.Error())
+	}
+
+	if lastOperation.State == model.InProgress || lastOperation.State == model.Pending {
+		return model.Cluster{}, apperrors.ErrOperationInProgress("error: %s operation of Runtime %s is in progress", lastOperation.Type, runtimeID)
+	}
+
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return model.Cluster{}, apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	return cluster, nil
+}
+
 func (v *validator) validateKymaConfig(kymaConfig *gqlschema.KymaConfigInput) apperrors.AppError {
 	if kymaConfig == nil {
 		return apperrors.BadRequest("error: Kyma config not provided")
@@ -120,6 +514,31 @@ func (v *validator) validateKymaConfig(kymaConfig *gqlschema.KymaConfigInput) ap
 		return apperrors.BadRequest("error: Kyma components list does not contain Compass Runtime Agent")
 	}
 
+	if kymaConfig.Profile != nil && !kymaConfig.Profile.IsValid() {
+		return apperrors.BadRequest("error: Kyma profile %s is not supported", *kymaConfig.Profile)
+	}
+
+	if err := v.validateInstallationTimeout(kymaConfig.InstallationTimeout); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *validator) validateInstallationTimeout(installationTimeout *int) apperrors.AppError {
+	if installationTimeout == nil {
+		return nil
+	}
+
+	if *installationTimeout <= 0 {
+		return apperrors.BadRequest("error: installation timeout must be greater than 0, got %d minutes", *installationTimeout)
+	}
+
+	maxMinutes := int(v.maxInstallationTimeout.Minutes())
+	if maxMinutes > 0 && *installationTimeout > maxMinutes {
+		return apperrors.BadRequest("error: installation timeout of %d minutes exceeds the maximum of %d minutes", *installationTimeout, maxMinutes)
+	}
+
 	return nil
 }
 
@@ -139,17 +558,305 @@ func (v *validator) validateClusterConfig(clusterConfig *gqlschema.ClusterConfig
 
 	gardenerConfig := *clusterConfig.GardenerConfig
 
+	if err := v.validateGardenerProject(gardenerConfig.GardenerProject); err != nil {
+		return err
+	}
+	project := util.UnwrapStr(gardenerConfig.GardenerProject)
+
+	if err := v.validateShootName(gardenerConfig.Name, project); err != nil {
+		return err
+	}
+
+	if err := v.validateRegionPolicy(gardenerConfig); err != nil {
+		return err
+	}
+
 	if err := v.validateMachineImage(gardenerConfig); err != nil {
 		return err
 	}
 
-	if err := v.validateOpenStackVolume(gardenerConfig.DiskType, gardenerConfig.VolumeSizeGb, gardenerConfig.Provider); err != nil {
+	if err := v.validateVersions(gardenerConfig); err != nil {
+		return err
+	}
+
+	if err := v.validateVolume(gardenerConfig.DiskType, gardenerConfig.VolumeSizeGb, gardenerConfig.Provider); err != nil {
+		return err
+	}
+
+	if err := v.validateTargetSecret(project, gardenerConfig.TargetSecret, gardenerConfig.Provider); err != nil {
+		return err
+	}
+
+	if err := validateRolloutSettings(toIntOrString(gardenerConfig.MaxSurge), toIntOrString(gardenerConfig.MaxUnavailable)); err != nil {
+		return err
+	}
+
+	if gardenerConfig.ProviderSpecificConfig != nil && gardenerConfig.ProviderSpecificConfig.AzureConfig != nil {
+		if err := v.validateAzureConfig(gardenerConfig.ProviderSpecificConfig.AzureConfig); err != nil {
+			return err
+		}
+	}
+
+	if gardenerConfig.ProviderSpecificConfig != nil && gardenerConfig.ProviderSpecificConfig.GcpConfig != nil {
+		if err := validateShieldedInstanceOptions(gardenerConfig.ProviderSpecificConfig.GcpConfig, gardenerConfig.Provider); err != nil {
+			return err
+		}
+	}
+
+	if gardenerConfig.ProviderSpecificConfig != nil && gardenerConfig.ProviderSpecificConfig.AwsConfig != nil {
+		if err := validateInstanceMetadataOptions(gardenerConfig.ProviderSpecificConfig.AwsConfig, gardenerConfig.Provider); err != nil {
+			return err
+		}
+	}
+
+	if err := v.validateShootAnnotations(gardenerConfig.ShootAnnotations); err != nil {
+		return err
+	}
+
+	if err := validateCostAllocation(gardenerConfig.CostAllocation); err != nil {
+		return err
+	}
+
+	if _, err := model.ClusterAutoscalerConfigFromInput(gardenerConfig.ClusterAutoscalerConfig, nil); err != nil {
+		return err
+	}
+
+	if err := v.validateKubeAPIServerConfig(gardenerConfig.KubeAPIServerConfig); err != nil {
+		return err
+	}
+
+	if err := v.validateDNSConfig(project, gardenerConfig.DNSConfig); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (v *validator) validateDNSConfig(project string, dnsConfig *gqlschema.DNSConfigInput) apperrors.AppError {
+	if dnsConfig == nil {
+		return nil
+	}
+
+	if errs := validation.IsDNS1123Subdomain(dnsConfig.Domain); len(errs) > 0 {
+		return apperrors.BadRequest("error: invalid DNS domain %s: %s", dnsConfig.Domain, strings.Join(errs, ", "))
+	}
+
+	if len(dnsConfig.Providers) == 0 {
+		return apperrors.BadRequest("error: at least one DNS provider is required for the custom domain %s", dnsConfig.Domain)
+	}
+
+	for _, provider := range dnsConfig.Providers {
+		if provider.Type == "" {
+			return apperrors.BadRequest("error: DNS provider type not provided")
+		}
+		if provider.SecretName == "" {
+			return apperrors.BadRequest("error: secret of the %s DNS provider not provided", provider.Type)
+		}
+		for _, domain := range provider.Domains {
+			if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+				return apperrors.BadRequest("error: invalid domain %s of the %s DNS provider: %s", domain, provider.Type, strings.Join(errs, ", "))
+			}
+		}
+		if v.secretBindingValidator != nil {
+			if err := v.secretBindingValidator.ValidateDNSSecret(project, provider.SecretName); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateShootAnnotations(annotations *gqlschema.Annotations) apperrors.AppError {
+	if annotations == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(*annotations))
+	for key := range *annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return apperrors.BadRequest("error: invalid Shoot annotation key %s: %s", key, strings.Join(errs, ", "))
+		}
+		if !v.isShootAnnotationAllowed(key) {
+			return apperrors.BadRequest("error: Shoot annotation %s is not allowed, the key has to start with one of the prefixes: [%s]",
+				key, strings.Join(v.allowedShootAnnotationPrefixes, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateKubeAPIServerConfig checks if only the allowed feature gates are set and the other settings are well-formed
+func (v *validator) validateKubeAPIServerConfig(input *gqlschema.KubeAPIServerConfigInput) apperrors.AppError {
+	if input == nil {
+		return nil
+	}
+
+	if input.FeatureGates != nil {
+		featureGates := make([]string, 0, len(*input.FeatureGates))
+		for featureGate := range *input.FeatureGates {
+			featureGates = append(featureGates, featureGate)
+		}
+		sort.Strings(featureGates)
+
+		for _, featureGate := range featureGates {
+			if !v.isFeatureGateAllowed(featureGate) {
+				return apperrors.BadRequest("error: kube-apiserver feature gate %s is not allowed, the allowed feature gates are: [%s]",
+					featureGate, strings.Join(v.allowedFeatureGates, ", "))
+			}
+		}
+	}
+
+	_, err := model.KubeAPIServerConfigFromInput(input, nil)
+	return err
+}
+
+func (v *validator) isFeatureGateAllowed(featureGate string) bool {
+	for _, allowed := range v.allowedFeatureGates {
+		if allowed == featureGate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateCostAllocation checks if the identifiers provided in the input can be used as the Shoot label values
+func validateCostAllocation(input *gqlschema.CostAllocationInput) apperrors.AppError {
+	if input == nil {
+		return nil
+	}
+
+	return model.CostAllocation{
+		GlobalAccountID: util.UnwrapStr(input.GlobalAccountID),
+		SubAccountID:    util.UnwrapStr(input.SubAccountID),
+		InstanceID:      util.UnwrapStr(input.InstanceID),
+	}.Validate()
+}
+
+func (v *validator) isShootAnnotationAllowed(key string) bool {
+	if strings.HasPrefix(key, provisionerAnnotationPrefix) {
+		return false
+	}
+
+	for _, prefix := range v.allowedShootAnnotationPrefixes {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *validator) validateAzureConfig(azureConfig *gqlschema.AzureProviderConfigInput) apperrors.AppError {
+	timeout := azureConfig.IdleConnectionTimeoutMinutes
+	if timeout == nil {
+		return nil
+	}
+
+	if azureConfig.EnableNatGateway == nil || !*azureConfig.EnableNatGateway {
+		return apperrors.BadRequest("error: NAT gateway idle connection timeout provided while NAT gateway is not enabled")
+	}
+
+	if *timeout < minNatGatewayIdleConnectionTimeout || *timeout > maxNatGatewayIdleConnectionTimeout {
+		return apperrors.BadRequest("error: NAT gateway idle connection timeout must be between %d and %d minutes, got %d minutes",
+			minNatGatewayIdleConnectionTimeout, maxNatGatewayIdleConnectionTimeout, *timeout)
+	}
+
+	return nil
+}
+
+func (v *validator) validateTargetSecret(project, targetSecret, provider string) apperrors.AppError {
+	if targetSecret == "" {
+		return apperrors.BadRequest("error: target secret not provided")
+	}
+
+	if v.secretBindingValidator == nil {
+		return nil
+	}
+
+	return v.secretBindingValidator.ValidateSecretBinding(project, targetSecret, strings.ToLower(provider))
+}
+
+func (v *validator) validateGardenerProject(project *string) apperrors.AppError {
+	if project == nil {
+		return nil
+	}
+
+	for _, allowed := range v.allowedGardenerProjects {
+		if *project == allowed {
+			return nil
+		}
+	}
+
+	return apperrors.BadRequest("error: Gardener project %s is not supported, allowed projects: %s", *project, strings.Join(v.allowedGardenerProjects, ", "))
+}
+
+// validateShootName checks the requested Shoot name, the name is generated if it is not provided
+func (v *validator) validateShootName(name *string, project string) apperrors.AppError {
+	if v.shootNameValidator == nil || !util.NotNilOrEmpty(name) {
+		return nil
+	}
+
+	if err := v.shootNameValidator.ValidateShootName(*name, project); err != nil {
+		return apperrors.BadRequest("error: %s", err.Error())
+	}
+
+	return nil
+}
+
+// validateRegionPolicy checks the provider, region and zones against the region policy of the landscape
+func (v *validator) validateRegionPolicy(gardenerConfig gqlschema.GardenerConfigInput) apperrors.AppError {
+	if v.regionPolicy == nil {
+		return nil
+	}
+
+	if err := v.regionPolicy.ValidateRegion(gardenerConfig.Provider, gardenerConfig.Region); err != nil {
+		return err
+	}
+
+	return v.regionPolicy.ValidateZones(gardenerConfig.Provider, requestedZones(gardenerConfig.ProviderSpecificConfig))
+}
+
+// Workers cannot be moved to the zones denied by the region policy, the region of the cluster cannot be changed
+func (v *validator) validateZonesUpgrade(runtimeID string, zones []string) apperrors.AppError {
+	if v.regionPolicy == nil {
+		return nil
+	}
+
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	return v.regionPolicy.ValidateZones(cluster.ClusterConfig.Provider, zones)
+}
+
+// requestedZones returns the zones of the workers provided in the provider specific config
+func requestedZones(providerConfig *gqlschema.ProviderSpecificInput) []string {
+	if providerConfig == nil {
+		return nil
+	}
+
+	switch {
+	case providerConfig.GcpConfig != nil:
+		return providerConfig.GcpConfig.Zones
+	case providerConfig.AzureConfig != nil:
+		return providerConfig.AzureConfig.Zones
+	case providerConfig.AwsConfig != nil:
+		return []string{providerConfig.AwsConfig.Zone}
+	case providerConfig.OpenStackConfig != nil:
+		return providerConfig.OpenStackConfig.Zones
+	}
+
+	return nil
+}
+
 func (v *validator) validateMachineImage(gardenerConfig gqlschema.GardenerConfigInput) apperrors.AppError {
 	if util.NotNilOrEmpty(gardenerConfig.MachineImageVersion) && util.IsNilOrEmpty(gardenerConfig.MachineImage) {
 		return apperrors.BadRequest("error: Machine Image Version passed while Machine Image is empty")
@@ -157,16 +864,372 @@ func (v *validator) validateMachineImage(gardenerConfig gqlschema.GardenerConfig
 	return nil
 }
 
-// OpenStack does not accept diskType or volumeSize
-func (v *validator) validateOpenStackVolume(diskType *string, volumeSizeGb *int, provider string) apperrors.AppError {
-	if strings.ToLower(provider) == "openstack" {
+// validateVersions checks if the requested Kubernetes and machine image versions are offered by the cloud profile of the provider
+func (v *validator) validateVersions(gardenerConfig gqlschema.GardenerConfigInput) apperrors.AppError {
+	profileName := cloudProfileName(gardenerConfig)
+	if v.versionValidator == nil || profileName == "" {
+		return nil
+	}
+
+	if gardenerConfig.KubernetesVersion != "" {
+		if err := v.versionValidator.ValidateKubernetesVersion(profileName, gardenerConfig.KubernetesVersion); err != nil {
+			return err
+		}
+	}
+
+	if util.NotNilOrEmpty(gardenerConfig.MachineImageVersion) {
+		if err := v.versionValidator.ValidateMachineImageVersion(profileName, *gardenerConfig.MachineImage, *gardenerConfig.MachineImageVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloudProfileName returns the name of the cloud profile used for Shoots of the provider or empty string if it is not known
+func cloudProfileName(gardenerConfig gqlschema.GardenerConfigInput) string {
+	switch strings.ToLower(gardenerConfig.Provider) {
+	case "gcp":
+		return model.GCPGardenerConfig{}.CloudProfileName()
+	case "azure":
+		return model.AzureGardenerConfig{}.CloudProfileName()
+	case "aws":
+		return model.AWSGardenerConfig{}.CloudProfileName()
+	case "openstack":
+		if gardenerConfig.ProviderSpecificConfig != nil && gardenerConfig.ProviderSpecificConfig.OpenStackConfig != nil {
+			return gardenerConfig.ProviderSpecificConfig.OpenStackConfig.CloudProfileName
+		}
+	}
+
+	return ""
+}
+
+func (v *validator) validateVolume(diskType *string, volumeSizeGb *int, provider string) apperrors.AppError {
+	provider = strings.ToLower(provider)
+
+	// OpenStack does not accept diskType or volumeSize
+	if provider == "openstack" {
 		if diskType != nil || volumeSizeGb != nil {
 			return apperrors.BadRequest("error: OpenStack mutation does not accept diskType or volumeSizeGb parameters")
 		}
+		return nil
+	}
+
+	if volumeSizeGb != nil && *volumeSizeGb <= 0 {
+		return apperrors.BadRequest("error: volume size must be greater than 0, got %dGB", *volumeSizeGb)
+	}
+
+	allowedTypes, found := allowedVolumeTypes[provider]
+	if diskType == nil || !found {
+		return nil
+	}
+	for _, allowedType := range allowedTypes {
+		if *diskType == allowedType {
+			return nil
+		}
 	}
+
+	return apperrors.BadRequest("error: disk type %s is not supported for %s provider, supported types: %s", *diskType, provider, strings.Join(allowedTypes, ", "))
+}
+
+// validateRolloutSettings checks the maximum surge and the maximum unavailable VMs, nil means the Gardener default
+func validateRolloutSettings(maxSurge, maxUnavailable *intstr.IntOrString) apperrors.AppError {
+	if err := validateRolloutValue("maxSurge", maxSurge); err != nil {
+		return err
+	}
+	if err := validateRolloutValue("maxUnavailable", maxUnavailable); err != nil {
+		return err
+	}
+
+	// Gardener defaults are 1 for the maximum surge and 0 for the maximum unavailable VMs
+	if isZeroRolloutValue(maxUnavailable, true) && isZeroRolloutValue(maxSurge, false) {
+		return apperrors.BadRequest("error: maxSurge and maxUnavailable cannot be both 0, the nodes could not be rolled out")
+	}
+
 	return nil
 }
 
+func validateRolloutValue(name string, value *intstr.IntOrString) apperrors.AppError {
+	if value == nil {
+		return nil
+	}
+
+	if value.Type == intstr.Int {
+		if value.IntVal < 0 {
+			return apperrors.BadRequest("error: %s cannot be negative, got %d", name, value.IntVal)
+		}
+		return nil
+	}
+
+	if !percentagePattern.MatchString(value.StrVal) {
+		return apperrors.BadRequest("error: %s must be a number or a percentage, e.g. 25%%, got %q", name, value.StrVal)
+	}
+	percentage, err := strconv.Atoi(strings.TrimSuffix(value.StrVal, "%"))
+	if err != nil || percentage > 100 {
+		return apperrors.BadRequest("error: %s percentage cannot be greater than 100%%, got %s", name, value.StrVal)
+	}
+
+	return nil
+}
+
+func isZeroRolloutValue(value *intstr.IntOrString, zeroByDefault bool) bool {
+	if value == nil {
+		return zeroByDefault
+	}
+
+	return value.String() == "0" || value.String() == "0%"
+}
+
+// Rollout settings missing in the upgrade input are kept, so they are validated together with the current ones
+func (v *validator) validateRolloutSettingsUpgrade(runtimeID string, maxSurge, maxUnavailable *gqlschema.IntOrString) apperrors.AppError {
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	surge := cluster.ClusterConfig.MaxSurge
+	if maxSurge != nil {
+		surge = toIntOrString(maxSurge)
+	}
+	unavailable := cluster.ClusterConfig.MaxUnavailable
+	if maxUnavailable != nil {
+		unavailable = toIntOrString(maxUnavailable)
+	}
+
+	return validateRolloutSettings(surge, unavailable)
+}
+
+func toIntOrString(value *gqlschema.IntOrString) *intstr.IntOrString {
+	if value == nil {
+		return nil
+	}
+
+	return &value.IntOrString
+}
+
+// Volume size can only be increased as Gardener does not support shrinking worker volumes
+func (v *validator) validateVolumeUpgrade(runtimeID string, diskType *string, volumeSizeGb *int) apperrors.AppError {
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+	currentConfig := cluster.ClusterConfig
+
+	if err := v.validateVolume(diskType, volumeSizeGb, currentConfig.Provider); err != nil {
+		return err
+	}
+
+	if volumeSizeGb != nil && currentConfig.VolumeSizeGB != nil && *volumeSizeGb < *currentConfig.VolumeSizeGB {
+		return apperrors.BadRequest("error: volume size cannot be decreased from %dGB to %dGB", *currentConfig.VolumeSizeGB, *volumeSizeGb)
+	}
+
+	return nil
+}
+
+// Networking type cannot be changed as Gardener does not support migrating the Shoot to another networking extension
+func (v *validator) validateNetworkingTypeUpgrade(runtimeID string, networkingType gqlschema.NetworkingType) apperrors.AppError {
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+	currentNetworkingType := cluster.ClusterConfig.NetworkingType
+
+	if requested := model.NetworkingTypeFromGraphQL(networkingType); requested != currentNetworkingType {
+		return apperrors.BadRequest("error: networking type cannot be changed from %s to %s", currentNetworkingType, requested)
+	}
+
+	return nil
+}
+
+// Domain of the Shoot is immutable, so the DNS config can be provided only to replace the providers of the custom domain
+func (v *validator) validateDNSConfigUpgrade(runtimeID string, dnsConfig *gqlschema.DNSConfigInput) apperrors.AppError {
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	current := cluster.ClusterConfig.DNSConfig
+	if current == nil {
+		return apperrors.BadRequest("error: DNS domain cannot be set to %s, the Runtime uses the Gardener default domain", dnsConfig.Domain)
+	}
+	if current.Domain != dnsConfig.Domain {
+		return apperrors.BadRequest("error: DNS domain cannot be changed from %s to %s", current.Domain, dnsConfig.Domain)
+	}
+
+	return v.validateDNSConfig(cluster.ClusterConfig.ProjectName, dnsConfig)
+}
+
+// Zones cannot be changed as the Azure cluster would have to be recreated
+func (v *validator) validateAzureConfigUpgrade(runtimeID string, azureConfig *gqlschema.AzureProviderConfigInput) apperrors.AppError {
+	if err := v.validateAzureConfig(azureConfig); err != nil {
+		return err
+	}
+
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	providerConfig := cluster.ClusterConfig.GardenerProviderConfig
+	if providerConfig == nil {
+		return nil
+	}
+	currentConfig, ok := providerConfig.AsProviderSpecificConfig().(gqlschema.AzureProviderConfig)
+	if !ok {
+		return apperrors.BadRequest("error: Azure config provided for %s cluster", cluster.ClusterConfig.Provider)
+	}
+
+	if !sameZones(currentConfig.Zones, azureConfig.Zones) {
+		return apperrors.BadRequest("error: zones cannot be changed from [%s] to [%s], changing zones requires recreating the cluster",
+			strings.Join(currentConfig.Zones, ", "), strings.Join(azureConfig.Zones, ", "))
+	}
+
+	return nil
+}
+
+// Shielded VM options can be changed during the upgrade, which recreates the worker nodes
+func (v *validator) validateGCPConfigUpgrade(runtimeID string, gcpConfig *gqlschema.GCPProviderConfigInput) apperrors.AppError {
+	if !hasShieldedInstanceOptions(gcpConfig) {
+		return nil
+	}
+
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	return validateShieldedInstanceOptions(gcpConfig, cluster.ClusterConfig.Provider)
+}
+
+func validateShieldedInstanceOptions(gcpConfig *gqlschema.GCPProviderConfigInput, provider string) apperrors.AppError {
+	if hasShieldedInstanceOptions(gcpConfig) && provider != "gcp" {
+		return apperrors.BadRequest("error: Shielded VM options are supported only for gcp provider, got %s", provider)
+	}
+
+	return nil
+}
+
+func hasShieldedInstanceOptions(gcpConfig *gqlschema.GCPProviderConfigInput) bool {
+	return gcpConfig.EnableSecureBoot != nil || gcpConfig.EnableIntegrityMonitoring != nil || gcpConfig.EnableVtpm != nil
+}
+
+// Instance metadata options can be changed during the upgrade, which rolls the worker nodes
+func (v *validator) validateAWSConfigUpgrade(runtimeID string, awsConfig *gqlschema.AWSProviderConfigInput) apperrors.AppError {
+	if !hasInstanceMetadataOptions(awsConfig) {
+		return nil
+	}
+
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	return validateInstanceMetadataOptions(awsConfig, cluster.ClusterConfig.Provider)
+}
+
+// validateInstanceMetadataOptions accepts only the values supported by the AWS extension of Gardener
+func validateInstanceMetadataOptions(awsConfig *gqlschema.AWSProviderConfigInput, provider string) apperrors.AppError {
+	if !hasInstanceMetadataOptions(awsConfig) {
+		return nil
+	}
+
+	if !strings.EqualFold(provider, "aws") {
+		return apperrors.BadRequest("error: instance metadata options are supported only for aws provider, got %s", provider)
+	}
+
+	if awsConfig.HTTPTokens != nil && !model.IsSupportedAWSHTTPTokens(*awsConfig.HTTPTokens) {
+		return apperrors.BadRequest("error: HTTP tokens %s are not supported, supported values: %s, %s", *awsConfig.HTTPTokens, model.AWSHTTPTokensRequired, model.AWSHTTPTokensOptional)
+	}
+
+	if awsConfig.HTTPPutResponseHopLimit != nil && !model.IsSupportedAWSHTTPPutResponseHopLimit(*awsConfig.HTTPPutResponseHopLimit) {
+		return apperrors.BadRequest("error: HTTP PUT response hop limit %d is out of range, supported values: %d-%d", *awsConfig.HTTPPutResponseHopLimit, model.MinAWSHTTPPutResponseHopLimit, model.MaxAWSHTTPPutResponseHopLimit)
+	}
+
+	return nil
+}
+
+func hasInstanceMetadataOptions(awsConfig *gqlschema.AWSProviderConfigInput) bool {
+	return awsConfig.HTTPTokens != nil || awsConfig.HTTPPutResponseHopLimit != nil
+}
+
+func sameZones(current, requested []string) bool {
+	if len(current) != len(requested) {
+		return false
+	}
+
+	zones := make(map[string]bool, len(current))
+	for _, zone := range current {
+		zones[zone] = true
+	}
+	for _, zone := range requested {
+		if !zones[zone] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Kubernetes version can only be upgraded to the next minor version as Gardener does not support downgrades nor skipping minor versions
+func (v *validator) validateVersionsUpgrade(runtimeID string, config *gqlschema.GardenerUpgradeInput) apperrors.AppError {
+	cluster, dberr := v.readSession.GetCluster(runtimeID)
+	if dberr != nil {
+		return apperrors.Internal("Failed to get cluster from database: %s", dberr.Error())
+	}
+
+	if config.KubernetesVersion != nil {
+		if err := model.ValidateKubernetesVersionUpgrade(cluster.ClusterConfig.KubernetesVersion, *config.KubernetesVersion); err != nil {
+			return err
+		}
+	}
+
+	if v.versionValidator == nil || cluster.ClusterConfig.GardenerProviderConfig == nil {
+		return nil
+	}
+	profileName := cluster.ClusterConfig.GardenerProviderConfig.CloudProfileName()
+
+	if config.KubernetesVersion != nil {
+		if err := v.versionValidator.ValidateKubernetesVersion(profileName, *config.KubernetesVersion); err != nil {
+			return err
+		}
+	}
+
+	imageName := util.UnwrapStrOrDefault(config.MachineImage, util.UnwrapStr(cluster.ClusterConfig.MachineImage))
+	if util.NotNilOrEmpty(config.MachineImageVersion) && imageName != "" {
+		if err := v.versionValidator.ValidateMachineImageVersion(profileName, imageName, *config.MachineImageVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isEmptyShootUpgrade(input gqlschema.UpgradeShootInput) bool {
+	config := input.GardenerConfig
+
+	return input.Administrators == nil &&
+		config.KubernetesVersion == nil &&
+		config.MachineType == nil &&
+		config.DiskType == nil &&
+		config.VolumeSizeGb == nil &&
+		config.AutoScalerMin == nil &&
+		config.AutoScalerMax == nil &&
+		config.MachineImage == nil &&
+		config.MachineImageVersion == nil &&
+		config.MaxSurge == nil &&
+		config.MaxUnavailable == nil &&
+		config.Purpose == nil &&
+		config.EnableKubernetesVersionAutoUpdate == nil &&
+		config.EnableMachineImageVersionAutoUpdate == nil &&
+		config.ProviderSpecificConfig == nil &&
+		config.ShootAnnotations == nil &&
+		config.CostAllocation == nil &&
+		config.ClusterAutoscalerConfig == nil &&
+		config.KubeAPIServerConfig == nil &&
+		config.OidcConfig == nil &&
+		config.DNSConfig == nil
+}
+
 func configContainsRuntimeAgentComponent(components []*gqlschema.ComponentConfigurationInput) bool {
 	for _, component := range components {
 		if component.Component == RuntimeAgent {
@@ -175,3 +1238,20 @@ func configContainsRuntimeAgentComponent(components []*gqlschema.ComponentConfig
 	}
 	return false
 }
+
+// validateIdempotencyKey returns the key or empty string if it is not provided
+func validateIdempotencyKey(idempotencyKey *string) (string, apperrors.AppError) {
+	if idempotencyKey == nil {
+		return "", nil
+	}
+
+	if *idempotencyKey == "" {
+		return "", apperrors.BadRequest("error: idempotency key cannot be empty")
+	}
+
+	if len(*idempotencyKey) > maxIdempotencyKeyLength {
+		return "", apperrors.BadRequest("error: idempotency key cannot be longer than %d characters", maxIdempotencyKeyLength)
+	}
+
+	return *idempotencyKey, nil
+}
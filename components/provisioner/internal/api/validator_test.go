@@ -1,14 +1,23 @@
 package api
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/api/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/freeze"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
 	dbMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestValidator_ValidateProvisioningInput(t *testing.T) {
@@ -16,7 +25,7 @@ func TestValidator_ValidateProvisioningInput(t *testing.T) {
 
 	t.Run("Should return nil when config is correct", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		config := gqlschema.ProvisionRuntimeInput{
 			RuntimeInput:  runtimeInput,
@@ -31,9 +40,25 @@ func TestValidator_ValidateProvisioningInput(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Should return nil when Kyma config is not provided", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+	})
+
 	t.Run("Should return error when config is incorrect", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		config := gqlschema.ProvisionRuntimeInput{}
 
@@ -46,7 +71,7 @@ func TestValidator_ValidateProvisioningInput(t *testing.T) {
 
 	t.Run("Should return error when Runtime Agent component is not passed in installation config", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		kymaConfig := &gqlschema.KymaConfigInput{
 			Version: "1.5",
@@ -73,7 +98,7 @@ func TestValidator_ValidateProvisioningInput(t *testing.T) {
 
 	t.Run("should return error when machine image version is set, but machine image is empty", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		testClusterConfig := clusterConfig
 		testClusterConfig.GardenerConfig.MachineImageVersion = util.StringPtr("24.3")
@@ -91,266 +116,1743 @@ func TestValidator_ValidateProvisioningInput(t *testing.T) {
 		require.Error(t, err)
 	})
 
-	t.Run("should return error when diskType or VolumeSizeGb is passed to openstack provisioning mutation", func(t *testing.T) {
-		openStackClusterConfig := &gqlschema.ClusterConfigInput{
-			GardenerConfig: &gqlschema.GardenerConfigInput{
-				Name:                   "tets-clst",
-				KubernetesVersion:      "1.15.4",
-				VolumeSizeGb:           nil,
-				MachineType:            "n1-standard-4",
-				Region:                 "europe",
-				Provider:               "openstack",
-				Seed:                   util.StringPtr("2"),
-				TargetSecret:           "test-secret",
-				DiskType:               util.StringPtr("ssd"),
-				WorkerCidr:             "10.10.10.10/255",
-				AutoScalerMin:          1,
-				AutoScalerMax:          3,
-				MaxSurge:               40,
-				MaxUnavailable:         1,
-				ProviderSpecificConfig: nil,
-			},
+	t.Run("should return error when disk type is not supported by the provider", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.DiskType = util.StringPtr("Premium_LRS")
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
 		}
 
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("should validate target secret binding", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+
+		secretBindingValidator := &mocks.SecretBindingValidator{}
+		secretBindingValidator.On("ValidateSecretBinding", "", "test-secret", "gcp").Return(nil)
+
+		validator := NewValidator(nil, secretBindingValidator, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
 		config := gqlschema.ProvisionRuntimeInput{
 			RuntimeInput:  runtimeInput,
-			ClusterConfig: openStackClusterConfig,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+		secretBindingValidator.AssertExpectations(t)
+	})
+
+	t.Run("should validate target secret binding in Gardener project", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.GardenerProject = util.StringPtr("trial")
+
+		secretBindingValidator := &mocks.SecretBindingValidator{}
+		secretBindingValidator.On("ValidateSecretBinding", "trial", "test-secret", "gcp").Return(nil)
+
+		validator := NewValidator(nil, secretBindingValidator, 0, nil, nil, []string{"default", "trial"}, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
 			KymaConfig:    kymaConfig,
 		}
 
-		validator := NewValidator(nil)
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+		secretBindingValidator.AssertExpectations(t)
+	})
+
+	t.Run("should return error when Gardener project is not supported", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.GardenerProject = util.StringPtr("other")
+
+		validator := NewValidator(nil, nil, 0, nil, nil, []string{"default", "trial"}, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
 
 		//when
 		err := validator.ValidateProvisioningInput(config)
 
 		//then
 		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "Gardener project other is not supported")
+	})
 
-		openStackClusterConfig.GardenerConfig.VolumeSizeGb = util.IntPtr(30)
-		openStackClusterConfig.GardenerConfig.DiskType = nil
+	for _, cause := range []apperrors.CauseCode{apperrors.CredentialsNotFound, apperrors.CredentialsProviderMismatch} {
+		t.Run("should return error when target secret binding is invalid", func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+
+			secretBindingValidator := &mocks.SecretBindingValidator{}
+			secretBindingValidator.On("ValidateSecretBinding", "", "test-secret", "gcp").
+				Return(apperrors.FailedPermanently(cause, "invalid secret binding"))
+
+			validator := NewValidator(nil, secretBindingValidator, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			assert.Equal(t, cause, err.Cause())
+		})
+	}
+
+	t.Run("should return error when target secret is empty", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		gardenerConfig := *clusterConfig.GardenerConfig
+		gardenerConfig.TargetSecret = ""
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: &gqlschema.ClusterConfigInput{GardenerConfig: &gardenerConfig},
+			KymaConfig:    kymaConfig,
+		}
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		//when
-		err = validator.ValidateProvisioningInput(config)
+		err := validator.ValidateProvisioningInput(config)
 
 		//then
 		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
 	})
-}
 
-func TestValidator_ValidateUpgradeInput(t *testing.T) {
+	t.Run("should accept installation timeout not exceeding the maximum", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		kymaConfig.InstallationTimeout = util.IntPtr(120)
 
-	t.Run("Should return nil when input is correct", func(t *testing.T) {
+		validator := NewValidator(nil, nil, 2*time.Hour, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	for _, installationTimeout := range []int{0, -10, 121} {
+		t.Run("should return error when installation timeout is invalid", func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+			kymaConfig.InstallationTimeout = util.IntPtr(installationTimeout)
+
+			validator := NewValidator(nil, nil, 2*time.Hour, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		})
+	}
+
+	t.Run("should return error when Kyma profile is not supported", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		unknownProfile := gqlschema.KymaProfile("Minimal")
+		kymaConfig.Profile = &unknownProfile
 
-		kymaConfig := &gqlschema.KymaConfigInput{
-			Version: "1.5",
-			Components: []*gqlschema.ComponentConfigurationInput{
-				{
-					Component:     "core",
-					Configuration: nil,
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "Kyma profile Minimal is not supported")
+	})
+
+	t.Run("should accept Azure NAT gateway idle connection timeout within the range", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{
+			AzureConfig: &gqlschema.AzureProviderConfigInput{
+				VnetCidr:                     "10.250.0.0/19",
+				EnableNatGateway:             util.BoolPtr(true),
+				IdleConnectionTimeoutMinutes: util.IntPtr(4),
+			},
+		}
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	for _, testCase := range []struct {
+		description                  string
+		enableNatGateway             *bool
+		idleConnectionTimeoutMinutes int
+	}{
+		{description: "is too short", enableNatGateway: util.BoolPtr(true), idleConnectionTimeoutMinutes: 3},
+		{description: "is too long", enableNatGateway: util.BoolPtr(true), idleConnectionTimeoutMinutes: 121},
+		{description: "is provided while NAT gateway is disabled", enableNatGateway: util.BoolPtr(false), idleConnectionTimeoutMinutes: 10},
+		{description: "is provided while NAT gateway is not configured", idleConnectionTimeoutMinutes: 10},
+	} {
+		t.Run("should return error when Azure NAT gateway idle connection timeout "+testCase.description, func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+			clusterConfig.GardenerConfig.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{
+				AzureConfig: &gqlschema.AzureProviderConfigInput{
+					VnetCidr:                     "10.250.0.0/19",
+					EnableNatGateway:             testCase.enableNatGateway,
+					IdleConnectionTimeoutMinutes: util.IntPtr(testCase.idleConnectionTimeoutMinutes),
 				},
-				{
-					Component:     "compass-runtime-agent",
-					Configuration: nil,
+			}
+
+			validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		})
+	}
+
+	for _, testCase := range []struct {
+		provider    string
+		expectError bool
+	}{
+		{provider: "gcp", expectError: false},
+		{provider: "azure", expectError: true},
+	} {
+		t.Run("should validate Shielded VM options for "+testCase.provider+" provider", func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+			clusterConfig.GardenerConfig.Provider = testCase.provider
+			clusterConfig.GardenerConfig.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{
+				GcpConfig: &gqlschema.GCPProviderConfigInput{
+					Zones:            []string{"europe-west4-a"},
+					EnableSecureBoot: util.BoolPtr(true),
 				},
-			},
+			}
+
+			validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			if testCase.expectError {
+				require.Error(t, err)
+				util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	for _, testCase := range []struct {
+		description   string
+		provider      string
+		diskType      string
+		httpTokens    *string
+		hopLimit      *int
+		expectedError string
+	}{
+		{description: "accept IMDSv2 for aws provider", provider: "aws", diskType: "gp2", httpTokens: util.StringPtr("required"), hopLimit: util.IntPtr(2)},
+		{description: "accept optional tokens for aws provider", provider: "aws", diskType: "gp2", httpTokens: util.StringPtr("optional")},
+		{description: "reject unsupported HTTP tokens", provider: "aws", diskType: "gp2", httpTokens: util.StringPtr("disabled"), expectedError: "HTTP tokens disabled are not supported"},
+		{description: "reject hop limit out of range", provider: "aws", diskType: "gp2", hopLimit: util.IntPtr(65), expectedError: "hop limit 65 is out of range"},
+		{description: "reject instance metadata options for gcp provider", provider: "gcp", diskType: "pd-ssd", httpTokens: util.StringPtr("required"), expectedError: "supported only for aws provider"},
+	} {
+		t.Run("should "+testCase.description, func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+			clusterConfig.GardenerConfig.Provider = testCase.provider
+			clusterConfig.GardenerConfig.DiskType = util.StringPtr(testCase.diskType)
+			clusterConfig.GardenerConfig.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{
+				AwsConfig: &gqlschema.AWSProviderConfigInput{
+					Zone:                    "eu-central-1a",
+					VpcCidr:                 "10.250.0.0/16",
+					PublicCidr:              "10.250.32.0/20",
+					InternalCidr:            "10.250.48.0/20",
+					HTTPTokens:              testCase.httpTokens,
+					HTTPPutResponseHopLimit: testCase.hopLimit,
+				},
+			}
+
+			validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			if testCase.expectedError != "" {
+				require.Error(t, err)
+				util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+				assert.Contains(t, err.Error(), testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	allowedAnnotationPrefixes := []string{"dns.gardener.cloud/", "alpha.control-plane.shoot.gardener.cloud/"}
+
+	t.Run("should accept Shoot annotations with allowed key prefixes", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.ShootAnnotations = &gqlschema.Annotations{
+			"dns.gardener.cloud/zone":                          "internal",
+			"alpha.control-plane.shoot.gardener.cloud/feature": "true",
 		}
 
-		input := gqlschema.UpgradeRuntimeInput{KymaConfig: kymaConfig}
+		validator := NewValidator(nil, nil, 0, allowedAnnotationPrefixes, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
 
 		//when
-		err := validator.ValidateUpgradeInput(input)
+		err := validator.ValidateProvisioningInput(config)
 
 		//then
 		require.NoError(t, err)
 	})
 
-	t.Run("Should return error when kyma config input not provided", func(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		key         string
+		prefixes    []string
+	}{
+		{description: "key prefix is not allowed", key: "shoot.gardener.cloud/tasks", prefixes: allowedAnnotationPrefixes},
+		{description: "no key prefixes are allowed", key: "dns.gardener.cloud/zone"},
+		{description: "key is reserved for the Provisioner", key: model.ManagedAnnotationsAnnotation, prefixes: []string{"kcp."}},
+		{description: "key is invalid", key: "dns.gardener.cloud/invalid key", prefixes: allowedAnnotationPrefixes},
+	} {
+		t.Run("should return error when Shoot annotation "+testCase.description, func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+			clusterConfig.GardenerConfig.ShootAnnotations = &gqlschema.Annotations{testCase.key: "value"}
+
+			validator := NewValidator(nil, nil, 0, testCase.prefixes, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			assert.Contains(t, err.Error(), testCase.key)
+		})
+	}
+
+	t.Run("should validate Kubernetes and machine image versions against cloud profile of the provider", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.MachineImage = util.StringPtr("gardenlinux")
+		clusterConfig.GardenerConfig.MachineImageVersion = util.StringPtr("576.12.0")
 
-		config := gqlschema.UpgradeRuntimeInput{}
+		versionValidator := &mocks.VersionValidator{}
+		versionValidator.On("ValidateKubernetesVersion", "gcp", "1.15.4").Return(nil)
+		versionValidator.On("ValidateMachineImageVersion", "gcp", "gardenlinux", "576.12.0").Return(nil)
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, versionValidator, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
 
 		//when
-		err := validator.ValidateUpgradeInput(config)
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+		versionValidator.AssertExpectations(t)
+	})
+
+	t.Run("should return error when Kubernetes version is expired", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+
+		versionValidator := &mocks.VersionValidator{}
+		versionValidator.On("ValidateKubernetesVersion", "gcp", "1.15.4").
+			Return(apperrors.BadRequest("kubernetes version 1.15.4 is expired in the gcp cloud profile, the newest allowed version is 1.15.12"))
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, versionValidator, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "the newest allowed version is 1.15.12")
+	})
+
+	t.Run("should return error when region is denied by region policy", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{
+			GcpConfig: &gqlschema.GCPProviderConfigInput{Zones: []string{"europe-a"}},
+		}
+
+		regionPolicy := &mocks.RegionPolicy{}
+		regionPolicy.On("ValidateRegion", "gcp", "europe").Return(nil)
+		regionPolicy.On("ValidateZones", "gcp", []string{"europe-a"}).
+			Return(apperrors.ErrRegionNotAllowed("zone europe-a of gcp provider is denied by the region policy pattern europe-*"))
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, regionPolicy, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
 
 		//then
 		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeForbidden)
+		assert.Equal(t, apperrors.RegionNotAllowed, err.Cause())
+		regionPolicy.AssertExpectations(t)
 	})
 
-	t.Run("Should return error when Runtime Agent component is not passed in kyma input", func(t *testing.T) {
+	t.Run("should return error when requested Shoot name is invalid", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.GardenerProject = util.StringPtr("project")
+
+		shootNameValidator := &mocks.ShootNameValidator{}
+		shootNameValidator.On("ValidateShootName", "tets-clst", "project").Return(fmt.Errorf("Shoot name \"tets-clst\" is too long"))
+
+		validator := NewValidator(nil, nil, 0, nil, nil, []string{"project"}, nil, nil, nil, nil, shootNameValidator)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "too long")
+		shootNameValidator.AssertExpectations(t)
+	})
+
+	t.Run("should not validate Shoot name when it is not provided", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.Name = nil
+
+		shootNameValidator := &mocks.ShootNameValidator{}
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, shootNameValidator)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+		shootNameValidator.AssertNotCalled(t, "ValidateShootName", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when cost allocation identifier is not a valid label value", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.CostAllocation = &gqlschema.CostAllocationInput{InstanceID: util.StringPtr("instance id")}
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), model.InstanceIDLabel)
+	})
+
+	t.Run("should return error when cluster autoscaler setting is out of range", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		threshold := 1.5
+		clusterConfig.GardenerConfig.ClusterAutoscalerConfig = &gqlschema.ClusterAutoscalerConfigInput{ScaleDownUtilizationThreshold: &threshold}
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "scaleDownUtilizationThreshold")
+	})
+
+	t.Run("should return error listing allowed feature gates when kube-apiserver feature gate is not allowed", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.KubeAPIServerConfig = &gqlschema.KubeAPIServerConfigInput{
+			FeatureGates: &gqlschema.Switches{"EphemeralContainers": true, "DynamicKubeletConfig": true},
+		}
+
+		validator := NewValidator(nil, nil, 0, nil, []string{"EphemeralContainers", "TTLAfterFinished"}, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "DynamicKubeletConfig is not allowed")
+		assert.Contains(t, err.Error(), "[EphemeralContainers, TTLAfterFinished]")
+	})
+
+	t.Run("should accept allowed kube-apiserver feature gates", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.KubeAPIServerConfig = &gqlschema.KubeAPIServerConfigInput{
+			FeatureGates:  &gqlschema.Switches{"EphemeralContainers": false},
+			RuntimeConfig: &gqlschema.Switches{"batch/v2alpha1": true},
+		}
+
+		validator := NewValidator(nil, nil, 0, nil, []string{"EphemeralContainers"}, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	fixDNSConfig := func(domain string, providerDomains ...string) *gqlschema.DNSConfigInput {
+		return &gqlschema.DNSConfigInput{
+			Domain: domain,
+			Providers: []*gqlschema.DNSProviderInput{
+				{Type: "aws-route53", SecretName: "route53-credentials", Domains: providerDomains},
+			},
+		}
+	}
+
+	t.Run("should accept custom DNS domain with existing provider secret", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.DNSConfig = fixDNSConfig("runtime.customer.example.com", "customer.example.com")
+
+		secretBindingValidator := &mocks.SecretBindingValidator{}
+		secretBindingValidator.On("ValidateSecretBinding", "", "test-secret", "gcp").Return(nil)
+		secretBindingValidator.On("ValidateDNSSecret", "", "route53-credentials").Return(nil)
+
+		validator := NewValidator(nil, secretBindingValidator, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+		secretBindingValidator.AssertExpectations(t)
+	})
+
+	t.Run("should return error when DNS provider secret does not exist", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.DNSConfig = fixDNSConfig("runtime.customer.example.com")
+
+		secretBindingValidator := &mocks.SecretBindingValidator{}
+		secretBindingValidator.On("ValidateSecretBinding", "", "test-secret", "gcp").Return(nil)
+		secretBindingValidator.On("ValidateDNSSecret", "", "route53-credentials").
+			Return(apperrors.BadRequest("DNS provider secret route53-credentials not found in garden-project namespace"))
+
+		validator := NewValidator(nil, secretBindingValidator, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "route53-credentials not found")
+	})
+
+	for _, testCase := range []struct {
+		description  string
+		dnsConfig    *gqlschema.DNSConfigInput
+		errorMessage string
+	}{
+		{
+			description:  "domain is invalid",
+			dnsConfig:    fixDNSConfig("Runtime_Domain"),
+			errorMessage: "invalid DNS domain Runtime_Domain",
+		},
+		{
+			description:  "domain of the provider is invalid",
+			dnsConfig:    fixDNSConfig("runtime.customer.example.com", "*.customer.example.com"),
+			errorMessage: "invalid domain *.customer.example.com of the aws-route53 DNS provider",
+		},
+		{
+			description:  "no provider is given",
+			dnsConfig:    &gqlschema.DNSConfigInput{Domain: "runtime.customer.example.com"},
+			errorMessage: "at least one DNS provider is required",
+		},
+		{
+			description: "provider secret is empty",
+			dnsConfig: &gqlschema.DNSConfigInput{
+				Domain:    "runtime.customer.example.com",
+				Providers: []*gqlschema.DNSProviderInput{{Type: "aws-route53"}},
+			},
+			errorMessage: "secret of the aws-route53 DNS provider not provided",
+		},
+	} {
+		t.Run("should return error when DNS "+testCase.description, func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+			clusterConfig.GardenerConfig.DNSConfig = testCase.dnsConfig
+
+			validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			assert.Contains(t, err.Error(), testCase.errorMessage)
+		})
+	}
+
+	t.Run("should accept rollout settings given as percentages", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.MaxSurge = gqlschema.NewIntOrString(intstr.FromString("25%"))
+		clusterConfig.GardenerConfig.MaxUnavailable = gqlschema.NewIntOrString(intstr.FromString("0%"))
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("should accept missing rollout settings", func(t *testing.T) {
+		//given
+		clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+		clusterConfig.GardenerConfig.MaxSurge = nil
+		clusterConfig.GardenerConfig.MaxUnavailable = nil
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: clusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	for _, testCase := range []struct {
+		description    string
+		maxSurge       *gqlschema.IntOrString
+		maxUnavailable *gqlschema.IntOrString
+		errorMessage   string
+	}{
+		{
+			description:  "value is not a percentage",
+			maxSurge:     gqlschema.NewIntOrString(intstr.FromString("25")),
+			errorMessage: "maxSurge must be a number or a percentage",
+		},
+		{
+			description:    "percentage is greater than 100%",
+			maxUnavailable: gqlschema.NewIntOrString(intstr.FromString("150%")),
+			errorMessage:   "maxUnavailable percentage cannot be greater than 100%",
+		},
+		{
+			description:  "value is negative",
+			maxSurge:     gqlschema.NewIntOrString(intstr.FromInt(-1)),
+			errorMessage: "maxSurge cannot be negative",
+		},
+		{
+			description:    "both values are 0",
+			maxSurge:       gqlschema.NewIntOrString(intstr.FromString("0%")),
+			maxUnavailable: gqlschema.NewIntOrString(intstr.FromInt(0)),
+			errorMessage:   "cannot be both 0",
+		},
+		{
+			description:  "maxSurge is 0 and maxUnavailable is not provided",
+			maxSurge:     gqlschema.NewIntOrString(intstr.FromInt(0)),
+			errorMessage: "cannot be both 0",
+		},
+	} {
+		t.Run("should return error when rollout "+testCase.description, func(t *testing.T) {
+			//given
+			clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+			clusterConfig.GardenerConfig.MaxSurge = testCase.maxSurge
+			clusterConfig.GardenerConfig.MaxUnavailable = testCase.maxUnavailable
+
+			validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			config := gqlschema.ProvisionRuntimeInput{
+				RuntimeInput:  runtimeInput,
+				ClusterConfig: clusterConfig,
+				KymaConfig:    kymaConfig,
+			}
+
+			//when
+			err := validator.ValidateProvisioningInput(config)
+
+			//then
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			assert.Contains(t, err.Error(), testCase.errorMessage)
+		})
+	}
+
+	t.Run("should return error when diskType or VolumeSizeGb is passed to openstack provisioning mutation", func(t *testing.T) {
+		openStackClusterConfig := &gqlschema.ClusterConfigInput{
+			GardenerConfig: &gqlschema.GardenerConfigInput{
+				Name:                   util.StringPtr("tets-clst"),
+				KubernetesVersion:      "1.15.4",
+				VolumeSizeGb:           nil,
+				MachineType:            "n1-standard-4",
+				Region:                 "europe",
+				Provider:               "openstack",
+				Seed:                   util.StringPtr("2"),
+				TargetSecret:           "test-secret",
+				DiskType:               util.StringPtr("ssd"),
+				WorkerCidr:             "10.10.10.10/255",
+				AutoScalerMin:          1,
+				AutoScalerMax:          3,
+				MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(40)),
+				MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
+				ProviderSpecificConfig: nil,
+			},
+		}
+
+		config := gqlschema.ProvisionRuntimeInput{
+			RuntimeInput:  runtimeInput,
+			ClusterConfig: openStackClusterConfig,
+			KymaConfig:    kymaConfig,
+		}
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		//when
+		err := validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+
+		openStackClusterConfig.GardenerConfig.VolumeSizeGb = util.IntPtr(30)
+		openStackClusterConfig.GardenerConfig.DiskType = nil
+
+		//when
+		err = validator.ValidateProvisioningInput(config)
+
+		//then
+		require.Error(t, err)
+	})
+
+	t.Run("Should validate expiration of Runtime", func(t *testing.T) {
+		inFuture := time.Now().Add(time.Hour)
+		inPast := time.Now().Add(-time.Hour)
+
+		for _, testCase := range []struct {
+			description       string
+			expirationSeconds *int
+			expireAt          *time.Time
+			valid             bool
+		}{
+			{description: "no expiration", valid: true},
+			{description: "positive expiration seconds", expirationSeconds: util.IntPtr(3600), valid: true},
+			{description: "expiration time in future", expireAt: &inFuture, valid: true},
+			{description: "zero expiration seconds", expirationSeconds: util.IntPtr(0)},
+			{description: "negative expiration seconds", expirationSeconds: util.IntPtr(-1)},
+			{description: "expiration time in past", expireAt: &inPast},
+			{description: "both expiration seconds and time", expirationSeconds: util.IntPtr(3600), expireAt: &inFuture},
+		} {
+			t.Run(testCase.description, func(t *testing.T) {
+				//given
+				clusterConfig, runtimeInput, kymaConfig := initializeConfigs()
+				validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+				config := gqlschema.ProvisionRuntimeInput{
+					RuntimeInput:      runtimeInput,
+					ClusterConfig:     clusterConfig,
+					KymaConfig:        kymaConfig,
+					ExpirationSeconds: testCase.expirationSeconds,
+					ExpireAt:          testCase.expireAt,
+				}
+
+				//when
+				err := validator.ValidateProvisioningInput(config)
+
+				//then
+				if testCase.valid {
+					require.NoError(t, err)
+				} else {
+					require.Error(t, err)
+					assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+				}
+			})
+		}
+	})
+}
+
+func TestValidator_ValidateUpgradeInput(t *testing.T) {
+
+	t.Run("Should return nil when input is correct", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		kymaConfig := &gqlschema.KymaConfigInput{
+			Version: "1.5",
+			Components: []*gqlschema.ComponentConfigurationInput{
+				{
+					Component:     "core",
+					Configuration: nil,
+				},
+				{
+					Component:     "compass-runtime-agent",
+					Configuration: nil,
+				},
+			},
+		}
+
+		input := gqlschema.UpgradeRuntimeInput{KymaConfig: kymaConfig}
+
+		//when
+		err := validator.ValidateUpgradeInput(input)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("Should return error when kyma config input not provided", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.UpgradeRuntimeInput{}
+
+		//when
+		err := validator.ValidateUpgradeInput(config)
+
+		//then
+		require.Error(t, err)
+	})
+
+	t.Run("Should return error when Runtime Agent component is not passed in kyma input", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		kymaConfig := &gqlschema.KymaConfigInput{
+			Version: "1.5",
+			Components: []*gqlschema.ComponentConfigurationInput{
+				{
+					Component:     "core",
+					Configuration: nil,
+				},
+			},
+		}
+
+		input := gqlschema.UpgradeRuntimeInput{KymaConfig: kymaConfig}
+
+		//when
+		err := validator.ValidateUpgradeInput(input)
+
+		//then
+		require.Error(t, err)
+	})
+}
+
+func TestValidator_ValidateUpgradeShootInput(t *testing.T) {
+	runtimeID := "123-123-123"
+
+	fixCluster := func(provider string, volumeSizeGB int) model.Cluster {
+		return model.Cluster{
+			ID: runtimeID,
+			ClusterConfig: model.GardenerConfig{
+				Provider:          provider,
+				KubernetesVersion: "1.15.4",
+				DiskType:          util.StringPtr("pd-standard"),
+				VolumeSizeGB:      util.IntPtr(volumeSizeGB),
+				NetworkingType:    model.CalicoNetworkingType,
+			},
+		}
+	}
+
+	t.Run("Should return nil when input is correct", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("gcp", 30), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion:      util.StringPtr("1.16"),
+				MachineType:            util.StringPtr("new-machine"),
+				DiskType:               util.StringPtr("pd-ssd"),
+				Purpose:                util.StringPtr("development"),
+				VolumeSizeGb:           util.IntPtr(50),
+				AutoScalerMin:          util.IntPtr(2),
+				AutoScalerMax:          util.IntPtr(6),
+				MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(2)),
+				MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
+				ProviderSpecificConfig: nil,
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("Should return error when Gardener config input not provided", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		config := gqlschema.UpgradeShootInput{}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, config)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("Should return error when Gardener config input provide empty value for machine type", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion:      util.StringPtr("1.16"),
+				MachineType:            util.StringPtr(""),
+				DiskType:               util.StringPtr("stone"),
+				Purpose:                util.StringPtr("development"),
+				VolumeSizeGb:           util.IntPtr(50),
+				AutoScalerMin:          util.IntPtr(2),
+				AutoScalerMax:          util.IntPtr(6),
+				MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(2)),
+				MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
+				ProviderSpecificConfig: nil,
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("Should return error when Gardener config input provide empty value for disk type", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion:      util.StringPtr("1.16"),
+				MachineType:            util.StringPtr("time-machine"),
+				DiskType:               util.StringPtr(""),
+				Purpose:                util.StringPtr("evaluation"),
+				VolumeSizeGb:           util.IntPtr(50),
+				AutoScalerMin:          util.IntPtr(2),
+				AutoScalerMax:          util.IntPtr(6),
+				MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(2)),
+				MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
+				ProviderSpecificConfig: nil,
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("Should return error when Gardener config input provide empty value for purpose", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion:      util.StringPtr("1.16"),
+				MachineType:            util.StringPtr("time-machine"),
+				DiskType:               util.StringPtr("papyrus"),
+				Purpose:                util.StringPtr(""),
+				VolumeSizeGb:           util.IntPtr(50),
+				AutoScalerMin:          util.IntPtr(2),
+				AutoScalerMax:          util.IntPtr(6),
+				MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(2)),
+				MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
+				ProviderSpecificConfig: nil,
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("Should return error when Gardener config input provide empty value for kubernetes version", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion:      util.StringPtr(""),
+				MachineType:            util.StringPtr("time-machine"),
+				DiskType:               util.StringPtr("papyrus"),
+				Purpose:                util.StringPtr("evaluation"),
+				VolumeSizeGb:           util.IntPtr(50),
+				AutoScalerMin:          util.IntPtr(2),
+				AutoScalerMax:          util.IntPtr(6),
+				MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(2)),
+				MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
+				ProviderSpecificConfig: nil,
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+	t.Run("Should return error when disk type is not supported by the provider", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("azure", 30), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				DiskType: util.StringPtr("pd-ssd"),
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "disk type pd-ssd is not supported for azure provider")
+	})
+
+	t.Run("Should return error when volume size is decreased", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("gcp", 50), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				VolumeSizeGb: util.IntPtr(30),
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "volume size cannot be decreased from 50GB to 30GB")
+	})
+
+	t.Run("Should return error when kubernetes version is downgraded", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("gcp", 50), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion: util.StringPtr("1.14.10"),
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "kubernetes version cannot be downgraded from 1.15.4 to 1.14.10")
+	})
+
+	t.Run("Should return error when kubernetes version skips minor version", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("gcp", 50), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion: util.StringPtr("1.17"),
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "only upgrades to the next minor version are supported")
+	})
+
+	t.Run("Should validate versions against cloud profile of the cluster", func(t *testing.T) {
+		//given
+		gcpConfig, appErr := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"europe-west4-a"}})
+		require.NoError(t, appErr)
+		cluster := fixCluster("gcp", 50)
+		cluster.ClusterConfig.GardenerProviderConfig = gcpConfig
+		cluster.ClusterConfig.MachineImage = util.StringPtr("gardenlinux")
+
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+
+		versionValidator := &mocks.VersionValidator{}
+		versionValidator.On("ValidateKubernetesVersion", "gcp", "1.16").Return(nil)
+		versionValidator.On("ValidateMachineImageVersion", "gcp", "gardenlinux", "318.8.0").
+			Return(apperrors.BadRequest("version of the gardenlinux machine image 318.8.0 is expired in the gcp cloud profile, the newest allowed version is 576.12.0"))
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, versionValidator, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion:   util.StringPtr("1.16"),
+				MachineImageVersion: util.StringPtr("318.8.0"),
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "the newest allowed version is 576.12.0")
+		versionValidator.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when workers would be moved to zone denied by region policy", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("aws", 50), nil)
+
+		regionPolicy := &mocks.RegionPolicy{}
+		regionPolicy.On("ValidateZones", "aws", []string{"eu-central-1b"}).
+			Return(apperrors.ErrRegionNotAllowed("zone eu-central-1b of aws provider is denied by the region policy pattern eu-central-1b"))
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, regionPolicy, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+					AwsConfig: &gqlschema.AWSProviderConfigInput{Zone: "eu-central-1b"},
+				},
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeForbidden)
+		assert.Equal(t, apperrors.RegionNotAllowed, err.Cause())
+		regionPolicy.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when networking type is changed", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("gcp", 50), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		cilium := gqlschema.NetworkingTypeCilium
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion: util.StringPtr("1.16"),
+				NetworkingType:    &cilium,
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "networking type cannot be changed from calico to cilium")
+	})
+
+	t.Run("Should return nil when networking type is unchanged", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixCluster("gcp", 50), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		calico := gqlschema.NetworkingTypeCalico
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion: util.StringPtr("1.16"),
+				NetworkingType:    &calico,
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	fixAzureCluster := func(zones ...string) model.Cluster {
+		providerConfig, err := model.NewAzureGardenerConfig(&gqlschema.AzureProviderConfigInput{VnetCidr: "10.250.0.0/19", Zones: zones})
+		require.NoError(t, err)
+
+		cluster := fixCluster("azure", 50)
+		cluster.ClusterConfig.GardenerProviderConfig = providerConfig
+		return cluster
+	}
+
+	t.Run("Should return nil when Azure NAT gateway is enabled without changing zones", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixAzureCluster("1", "2"), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+					AzureConfig: &gqlschema.AzureProviderConfigInput{
+						VnetCidr:                     "10.250.0.0/19",
+						Zones:                        []string{"2", "1"},
+						EnableNatGateway:             util.BoolPtr(true),
+						IdleConnectionTimeoutMinutes: util.IntPtr(30),
+					},
+				},
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("Should return error when Azure zones are changed", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(fixAzureCluster("1", "2"), nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+					AzureConfig: &gqlschema.AzureProviderConfigInput{
+						VnetCidr:         "10.250.0.0/19",
+						Zones:            []string{"1", "2", "3"},
+						EnableNatGateway: util.BoolPtr(true),
+					},
+				},
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "zones cannot be changed from [1, 2] to [1, 2, 3]")
+	})
+
+	t.Run("Should return error when Azure NAT gateway idle connection timeout is out of range", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+					AzureConfig: &gqlschema.AzureProviderConfigInput{
+						VnetCidr:                     "10.250.0.0/19",
+						EnableNatGateway:             util.BoolPtr(true),
+						IdleConnectionTimeoutMinutes: util.IntPtr(150),
+					},
+				},
+			},
+		}
+
+		//when
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "must be between 4 and 120 minutes")
+	})
+
+	for _, testCase := range []struct {
+		provider    string
+		expectError bool
+	}{
+		{provider: "gcp", expectError: false},
+		{provider: "azure", expectError: true},
+	} {
+		t.Run("Should validate Shielded VM options upgrade for "+testCase.provider+" cluster", func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			readSession.On("GetCluster", runtimeID).Return(fixCluster(testCase.provider, 50), nil)
+
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			input := gqlschema.UpgradeShootInput{
+				GardenerConfig: &gqlschema.GardenerUpgradeInput{
+					ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+						GcpConfig: &gqlschema.GCPProviderConfigInput{
+							Zones:                     []string{"europe-west4-a"},
+							EnableIntegrityMonitoring: util.BoolPtr(true),
+						},
+					},
+				},
+			}
+
+			//when
+			err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+			//then
+			if testCase.expectError {
+				require.Error(t, err)
+				util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+				assert.Contains(t, err.Error(), "supported only for gcp provider")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	for _, testCase := range []struct {
+		provider    string
+		expectError bool
+	}{
+		{provider: "aws", expectError: false},
+		{provider: "azure", expectError: true},
+	} {
+		t.Run("Should validate instance metadata options upgrade for "+testCase.provider+" cluster", func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			readSession.On("GetCluster", runtimeID).Return(fixCluster(testCase.provider, 50), nil)
+
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			input := gqlschema.UpgradeShootInput{
+				GardenerConfig: &gqlschema.GardenerUpgradeInput{
+					ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+						AwsConfig: &gqlschema.AWSProviderConfigInput{
+							Zone:         "eu-central-1a",
+							VpcCidr:      "10.250.0.0/16",
+							PublicCidr:   "10.250.32.0/20",
+							InternalCidr: "10.250.48.0/20",
+							HTTPTokens:   util.StringPtr("required"),
+						},
+					},
+				},
+			}
+
+			//when
+			err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+			//then
+			if testCase.expectError {
+				require.Error(t, err)
+				util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+				assert.Contains(t, err.Error(), "supported only for aws provider")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Should return nil when only auto update is changed", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
-		kymaConfig := &gqlschema.KymaConfigInput{
-			Version: "1.5",
-			Components: []*gqlschema.ComponentConfigurationInput{
-				{
-					Component:     "core",
-					Configuration: nil,
-				},
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				EnableKubernetesVersionAutoUpdate:   util.BoolPtr(true),
+				EnableMachineImageVersionAutoUpdate: util.BoolPtr(false),
 			},
 		}
 
-		input := gqlschema.UpgradeRuntimeInput{KymaConfig: kymaConfig}
-
 		//when
-		err := validator.ValidateUpgradeInput(input)
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
 
 		//then
-		require.Error(t, err)
+		require.NoError(t, err)
 	})
-}
-
-func TestValidator_ValidateUpgradeShootInput(t *testing.T) {
 
-	t.Run("Should return nil when input is correct", func(t *testing.T) {
+	t.Run("Should accept upgrade removing all Shoot annotations", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		input := gqlschema.UpgradeShootInput{
 			GardenerConfig: &gqlschema.GardenerUpgradeInput{
-				KubernetesVersion:      util.StringPtr("version2"),
-				MachineType:            util.StringPtr("new-machine"),
-				DiskType:               util.StringPtr("papyrus"),
-				Purpose:                util.StringPtr("development"),
-				VolumeSizeGb:           util.IntPtr(50),
-				AutoScalerMin:          util.IntPtr(2),
-				AutoScalerMax:          util.IntPtr(6),
-				MaxSurge:               util.IntPtr(2),
-				MaxUnavailable:         util.IntPtr(1),
-				ProviderSpecificConfig: nil,
+				ShootAnnotations: &gqlschema.Annotations{},
 			},
 		}
 
 		//when
-		err := validator.ValidateUpgradeShootInput(input)
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
 
 		//then
 		require.NoError(t, err)
 	})
 
-	t.Run("Should return error when Gardener config input not provided", func(t *testing.T) {
+	t.Run("Should return error when Shoot annotation is not allowed", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, []string{"dns.gardener.cloud/"}, nil, nil, nil, nil, nil, nil, nil)
 
-		config := gqlschema.UpgradeShootInput{}
+		input := gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				ShootAnnotations: &gqlschema.Annotations{"shoot.gardener.cloud/tasks": "deployInfrastructure"},
+			},
+		}
 
 		//when
-		err := validator.ValidateUpgradeShootInput(config)
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
 
 		//then
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "shoot.gardener.cloud/tasks is not allowed")
 	})
 
-	t.Run("Should return error when Gardener config input provide empty value for machine type", func(t *testing.T) {
+	t.Run("Should validate changed maxSurge together with the current maxUnavailable", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		cluster := fixCluster("gcp", 30)
+		cluster.ClusterConfig.MaxSurge = util.IntOrStringPtr(intstr.FromInt(1))
+		cluster.ClusterConfig.MaxUnavailable = util.IntOrStringPtr(intstr.FromString("10%"))
+
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		input := gqlschema.UpgradeShootInput{
 			GardenerConfig: &gqlschema.GardenerUpgradeInput{
-				KubernetesVersion:      util.StringPtr("version2"),
-				MachineType:            util.StringPtr(""),
-				DiskType:               util.StringPtr("stone"),
-				Purpose:                util.StringPtr("development"),
-				VolumeSizeGb:           util.IntPtr(50),
-				AutoScalerMin:          util.IntPtr(2),
-				AutoScalerMax:          util.IntPtr(6),
-				MaxSurge:               util.IntPtr(2),
-				MaxUnavailable:         util.IntPtr(1),
-				ProviderSpecificConfig: nil,
+				MaxSurge: gqlschema.NewIntOrString(intstr.FromString("0%")),
 			},
 		}
 
 		//when
-		err := validator.ValidateUpgradeShootInput(input)
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
 
 		//then
-		require.Error(t, err)
-		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.NoError(t, err)
+		readSession.AssertExpectations(t)
 	})
 
-	t.Run("Should return error when Gardener config input provide empty value for disk type", func(t *testing.T) {
+	t.Run("Should return error when changed maxSurge makes both rollout settings 0", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		cluster := fixCluster("gcp", 30)
+		cluster.ClusterConfig.MaxSurge = util.IntOrStringPtr(intstr.FromInt(1))
+		cluster.ClusterConfig.MaxUnavailable = util.IntOrStringPtr(intstr.FromInt(0))
+
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		input := gqlschema.UpgradeShootInput{
 			GardenerConfig: &gqlschema.GardenerUpgradeInput{
-				KubernetesVersion:      util.StringPtr("version2"),
-				MachineType:            util.StringPtr("time-machine"),
-				DiskType:               util.StringPtr(""),
-				Purpose:                util.StringPtr("evaluation"),
-				VolumeSizeGb:           util.IntPtr(50),
-				AutoScalerMin:          util.IntPtr(2),
-				AutoScalerMax:          util.IntPtr(6),
-				MaxSurge:               util.IntPtr(2),
-				MaxUnavailable:         util.IntPtr(1),
-				ProviderSpecificConfig: nil,
+				MaxSurge: gqlschema.NewIntOrString(intstr.FromInt(0)),
 			},
 		}
 
 		//when
-		err := validator.ValidateUpgradeShootInput(input)
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
 
 		//then
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "cannot be both 0")
 	})
 
-	t.Run("Should return error when Gardener config input provide empty value for purpose", func(t *testing.T) {
+	t.Run("Should accept new DNS providers of the current domain", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		cluster := fixCluster("aws", 30)
+		cluster.ClusterConfig.DNSConfig = &model.DNSConfig{
+			Domain:    "runtime.customer.example.com",
+			Providers: []*model.DNSProvider{{Type: "aws-route53", SecretName: "route53-credentials"}},
+		}
+
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		input := gqlschema.UpgradeShootInput{
 			GardenerConfig: &gqlschema.GardenerUpgradeInput{
-				KubernetesVersion:      util.StringPtr("version2"),
-				MachineType:            util.StringPtr("time-machine"),
-				DiskType:               util.StringPtr("papyrus"),
-				Purpose:                util.StringPtr(""),
-				VolumeSizeGb:           util.IntPtr(50),
-				AutoScalerMin:          util.IntPtr(2),
-				AutoScalerMax:          util.IntPtr(6),
-				MaxSurge:               util.IntPtr(2),
-				MaxUnavailable:         util.IntPtr(1),
-				ProviderSpecificConfig: nil,
+				DNSConfig: &gqlschema.DNSConfigInput{
+					Domain:    "runtime.customer.example.com",
+					Providers: []*gqlschema.DNSProviderInput{{Type: "aws-route53", SecretName: "rotated-route53-credentials"}},
+				},
 			},
 		}
 
 		//when
-		err := validator.ValidateUpgradeShootInput(input)
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
 
 		//then
-		require.Error(t, err)
-		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		require.NoError(t, err)
 	})
 
-	t.Run("Should return error when Gardener config input provide empty value for kubernetes version", func(t *testing.T) {
+	for _, testCase := range []struct {
+		description  string
+		current      *model.DNSConfig
+		errorMessage string
+	}{
+		{
+			description:  "DNS domain is changed",
+			current:      &model.DNSConfig{Domain: "old.customer.example.com"},
+			errorMessage: "DNS domain cannot be changed from old.customer.example.com to runtime.customer.example.com",
+		},
+		{
+			description:  "DNS domain is set for Runtime with default domain",
+			errorMessage: "the Runtime uses the Gardener default domain",
+		},
+	} {
+		t.Run("Should return error when "+testCase.description, func(t *testing.T) {
+			//given
+			cluster := fixCluster("aws", 30)
+			cluster.ClusterConfig.DNSConfig = testCase.current
+
+			readSession := &dbMocks.ReadSession{}
+			readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			input := gqlschema.UpgradeShootInput{
+				GardenerConfig: &gqlschema.GardenerUpgradeInput{
+					DNSConfig: &gqlschema.DNSConfigInput{
+						Domain:    "runtime.customer.example.com",
+						Providers: []*gqlschema.DNSProviderInput{{Type: "aws-route53", SecretName: "route53-credentials"}},
+					},
+				},
+			}
+
+			//when
+			err := validator.ValidateUpgradeShootInput(runtimeID, input)
+
+			//then
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			assert.Contains(t, err.Error(), testCase.errorMessage)
+		})
+	}
+
+	t.Run("Should return error when upgrade is empty", func(t *testing.T) {
 		//given
-		validator := NewValidator(nil)
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		input := gqlschema.UpgradeShootInput{
-			GardenerConfig: &gqlschema.GardenerUpgradeInput{
-				KubernetesVersion:      util.StringPtr(""),
-				MachineType:            util.StringPtr("time-machine"),
-				DiskType:               util.StringPtr("papyrus"),
-				Purpose:                util.StringPtr("evaluation"),
-				VolumeSizeGb:           util.IntPtr(50),
-				AutoScalerMin:          util.IntPtr(2),
-				AutoScalerMax:          util.IntPtr(6),
-				MaxSurge:               util.IntPtr(2),
-				MaxUnavailable:         util.IntPtr(1),
-				ProviderSpecificConfig: nil,
-			},
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{},
 		}
 
 		//when
-		err := validator.ValidateUpgradeShootInput(input)
+		err := validator.ValidateUpgradeShootInput(runtimeID, input)
 
 		//then
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), "no changes provided")
 	})
 }
 
@@ -360,7 +1862,7 @@ func TestValidator_ValidateTenant(t *testing.T) {
 	t.Run("Should return nil when tenant matches tenant provided for Runtime", func(t *testing.T) {
 		//given
 		readSession := &dbMocks.ReadSession{}
-		validator := NewValidator(readSession)
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		expectedTenant := "tenant"
 
@@ -376,7 +1878,7 @@ func TestValidator_ValidateTenant(t *testing.T) {
 	t.Run("Should return error when tenant does not match tenant provided for Runtime", func(t *testing.T) {
 		//given
 		readSession := &dbMocks.ReadSession{}
-		validator := NewValidator(readSession)
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		expectedTenant := "otherTenant"
 
@@ -392,7 +1894,7 @@ func TestValidator_ValidateTenant(t *testing.T) {
 	t.Run("Should return error when persistence service returns error", func(t *testing.T) {
 		//given
 		readSession := &dbMocks.ReadSession{}
-		validator := NewValidator(readSession)
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		readSession.On("GetTenant", runtimeID).Return("", dberrors.Internal("Some db error"))
 
@@ -411,7 +1913,7 @@ func TestValidator_ValidateTenantForOperation(t *testing.T) {
 	t.Run("Should return nil when tenant matches tenant provided for Runtime", func(t *testing.T) {
 		//given
 		readSession := &dbMocks.ReadSession{}
-		validator := NewValidator(readSession)
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		expectedTenant := "tenant"
 
@@ -427,7 +1929,7 @@ func TestValidator_ValidateTenantForOperation(t *testing.T) {
 	t.Run("Should return error when tenant does not match tenant provided for Runtime", func(t *testing.T) {
 		//given
 		readSession := &dbMocks.ReadSession{}
-		validator := NewValidator(readSession)
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		expectedTenant := "otherTenant"
 
@@ -443,7 +1945,7 @@ func TestValidator_ValidateTenantForOperation(t *testing.T) {
 	t.Run("Should return error when persistence service returns error", func(t *testing.T) {
 		//given
 		readSession := &dbMocks.ReadSession{}
-		validator := NewValidator(readSession)
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		readSession.On("GetTenantForOperation", operationId).Return("", dberrors.Internal("Some db error"))
 
@@ -456,10 +1958,289 @@ func TestValidator_ValidateTenantForOperation(t *testing.T) {
 
 }
 
+func TestValidator_ValidateForceDeprovisioning(t *testing.T) {
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+	kubeconfig := `apiVersion: v1
+clusters:
+- cluster:
+    server: https://api.cluster.example.com
+  name: cluster
+contexts:
+- context:
+    cluster: cluster
+    user: admin
+  name: cluster
+current-context: cluster
+kind: Config
+users:
+- name: admin
+  user:
+    token: token
+`
+
+	t.Run("Should return nil when last operation failed", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		readSession.On("GetLastOperation", runtimeID).Return(model.Operation{State: model.Failed}, nil)
+
+		//when
+		err := validator.ValidateForceDeprovisioning(runtimeID)
+
+		//then
+		require.NoError(t, err)
+		readSession.AssertNotCalled(t, "GetCluster", runtimeID)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		kubeconfig  *string
+	}{
+		{description: "missing", kubeconfig: nil},
+		{description: "invalid", kubeconfig: util.StringPtr("invalid")},
+	} {
+		t.Run("Should return nil when kubeconfig is "+testCase.description, func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			readSession.On("GetLastOperation", runtimeID).Return(model.Operation{State: model.Succeeded}, nil)
+			readSession.On("GetCluster", runtimeID).Return(model.Cluster{ID: runtimeID, Kubeconfig: testCase.kubeconfig}, nil)
+
+			//when
+			err := validator.ValidateForceDeprovisioning(runtimeID)
+
+			//then
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("Should return error when cluster is usable", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		readSession.On("GetLastOperation", runtimeID).Return(model.Operation{State: model.Succeeded}, nil)
+		readSession.On("GetCluster", runtimeID).Return(model.Cluster{ID: runtimeID, Kubeconfig: &kubeconfig}, nil)
+
+		//when
+		err := validator.ValidateForceDeprovisioning(runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+	})
+
+	t.Run("Should return error when persistence service returns error", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		readSession.On("GetLastOperation", runtimeID).Return(model.Operation{}, dberrors.Internal("Some db error"))
+
+		//when
+		err := validator.ValidateForceDeprovisioning(runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeInternal, err.Code())
+	})
+}
+
+func TestValidator_ValidateHibernation(t *testing.T) {
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+
+	for _, testCase := range []struct {
+		description   string
+		lastOperation model.Operation
+		cluster       model.Cluster
+		expectedError bool
+	}{
+		{description: "Should return nil when cluster is running", lastOperation: model.Operation{State: model.Succeeded}, cluster: model.Cluster{ID: runtimeID}},
+		{description: "Should return nil when last operation failed", lastOperation: model.Operation{State: model.Failed}, cluster: model.Cluster{ID: runtimeID}},
+		{description: "Should return error when operation is in progress", lastOperation: model.Operation{Type: model.Upgrade, State: model.InProgress}, expectedError: true},
+		{description: "Should return error when operation is pending", lastOperation: model.Operation{Type: model.Provision, State: model.Pending}, expectedError: true},
+		{description: "Should return error when cluster is hibernated", lastOperation: model.Operation{State: model.Succeeded}, cluster: model.Cluster{ID: runtimeID, Hibernated: true}, expectedError: true},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			readSession.On("GetLastOperation", runtimeID).Return(testCase.lastOperation, nil)
+			readSession.On("GetCluster", runtimeID).Return(testCase.cluster, nil)
+
+			//when
+			err := validator.ValidateHibernation(runtimeID)
+
+			//then
+			if testCase.expectedError {
+				require.Error(t, err)
+				assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Should return error when persistence service returns error", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		readSession.On("GetLastOperation", runtimeID).Return(model.Operation{State: model.Succeeded}, nil)
+		readSession.On("GetCluster", runtimeID).Return(model.Cluster{}, dberrors.Internal("Some db error"))
+
+		//when
+		err := validator.ValidateHibernation(runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeInternal, err.Code())
+	})
+}
+
+func TestValidator_ValidateWakeUp(t *testing.T) {
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+
+	for _, testCase := range []struct {
+		description   string
+		lastOperation model.Operation
+		cluster       model.Cluster
+		expectedError bool
+	}{
+		{description: "Should return nil when cluster is hibernated", lastOperation: model.Operation{State: model.Succeeded}, cluster: model.Cluster{ID: runtimeID, Hibernated: true}},
+		{description: "Should return error when cluster is not hibernated", lastOperation: model.Operation{State: model.Succeeded}, cluster: model.Cluster{ID: runtimeID}, expectedError: true},
+		{description: "Should return error when hibernation is in progress", lastOperation: model.Operation{Type: model.Hibernate, State: model.InProgress}, expectedError: true},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			readSession.On("GetLastOperation", runtimeID).Return(testCase.lastOperation, nil)
+			readSession.On("GetCluster", runtimeID).Return(testCase.cluster, nil)
+
+			//when
+			err := validator.ValidateWakeUp(runtimeID)
+
+			//then
+			if testCase.expectedError {
+				require.Error(t, err)
+				assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Should return error when persistence service returns error", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		readSession.On("GetLastOperation", runtimeID).Return(model.Operation{}, dberrors.Internal("Some db error"))
+
+		//when
+		err := validator.ValidateWakeUp(runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeInternal, err.Code())
+	})
+}
+
+func TestValidator_ValidateCleanupFailedProvisioning(t *testing.T) {
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+
+	for _, testCase := range []struct {
+		description   string
+		lastOperation model.Operation
+		expectedError bool
+	}{
+		{description: "Should return nil when provisioning failed", lastOperation: model.Operation{Type: model.Provision, State: model.Failed}},
+		{description: "Should return error when provisioning succeeded", lastOperation: model.Operation{Type: model.Provision, State: model.Succeeded}, expectedError: true},
+		{description: "Should return error when provisioning is in progress", lastOperation: model.Operation{Type: model.Provision, State: model.InProgress}, expectedError: true},
+		{description: "Should return error when other operation failed", lastOperation: model.Operation{Type: model.UpgradeShoot, State: model.Failed}, expectedError: true},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			readSession.On("GetLastOperation", runtimeID).Return(testCase.lastOperation, nil)
+
+			//when
+			err := validator.ValidateCleanupFailedProvisioning(runtimeID)
+
+			//then
+			if testCase.expectedError {
+				require.Error(t, err)
+				assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Should return error when persistence service returns error", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		readSession.On("GetLastOperation", runtimeID).Return(model.Operation{}, dberrors.Internal("Some db error"))
+
+		//when
+		err := validator.ValidateCleanupFailedProvisioning(runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeInternal, err.Code())
+	})
+}
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	t.Run("Should return empty key when key is not provided", func(t *testing.T) {
+		//when
+		key, err := validateIdempotencyKey(nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Empty(t, key)
+	})
+
+	t.Run("Should return provided key", func(t *testing.T) {
+		//when
+		key, err := validateIdempotencyKey(util.StringPtr("key"))
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "key", key)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		key         string
+	}{
+		{description: "empty", key: ""},
+		{description: "too long", key: strings.Repeat("k", maxIdempotencyKeyLength+1)},
+	} {
+		t.Run("Should return error when key is "+testCase.description, func(t *testing.T) {
+			//when
+			_, err := validateIdempotencyKey(&testCase.key)
+
+			//then
+			require.Error(t, err)
+			assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+		})
+	}
+}
+
 func initializeConfigs() (*gqlschema.ClusterConfigInput, *gqlschema.RuntimeInput, *gqlschema.KymaConfigInput) {
 	clusterConfig := &gqlschema.ClusterConfigInput{
 		GardenerConfig: &gqlschema.GardenerConfigInput{
-			Name:                   "tets-clst",
+			Name:                   util.StringPtr("tets-clst"),
 			KubernetesVersion:      "1.15.4",
 			VolumeSizeGb:           util.IntPtr(30),
 			MachineType:            "n1-standard-4",
@@ -467,12 +2248,12 @@ func initializeConfigs() (*gqlschema.ClusterConfigInput, *gqlschema.RuntimeInput
 			Provider:               "gcp",
 			Seed:                   util.StringPtr("2"),
 			TargetSecret:           "test-secret",
-			DiskType:               util.StringPtr("ssd"),
+			DiskType:               util.StringPtr("pd-ssd"),
 			WorkerCidr:             "10.10.10.10/255",
 			AutoScalerMin:          1,
 			AutoScalerMax:          3,
-			MaxSurge:               40,
-			MaxUnavailable:         1,
+			MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(40)),
+			MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
 			ProviderSpecificConfig: nil,
 		},
 	}
@@ -497,3 +2278,202 @@ func initializeConfigs() (*gqlschema.ClusterConfigInput, *gqlschema.RuntimeInput
 	}
 	return clusterConfig, runtimeInput, kymaConfig
 }
+
+func TestValidator_ValidateExpirationExtension(t *testing.T) {
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+	currentExpiration := time.Now().Add(time.Hour)
+	later := currentExpiration.Add(time.Hour)
+	earlier := currentExpiration.Add(-30 * time.Minute)
+	inPast := time.Now().Add(-time.Hour)
+
+	for _, testCase := range []struct {
+		description string
+		cluster     model.Cluster
+		expireAt    *time.Time
+		valid       bool
+	}{
+		{description: "postponed expiration", cluster: model.Cluster{ExpireAt: &currentExpiration}, expireAt: &later, valid: true},
+		{description: "removed expiration", cluster: model.Cluster{ExpireAt: &currentExpiration}, valid: true},
+		{description: "expiration of Runtime without expiration", cluster: model.Cluster{}, expireAt: &later, valid: true},
+		{description: "earlier expiration", cluster: model.Cluster{ExpireAt: &currentExpiration}, expireAt: &earlier},
+		{description: "expiration in past", cluster: model.Cluster{}, expireAt: &inPast},
+		{description: "deleted Runtime", cluster: model.Cluster{ExpireAt: &currentExpiration, Deleted: true}, expireAt: &later},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			readSession.On("GetCluster", runtimeID).Return(testCase.cluster, nil)
+
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			//when
+			err := validator.ValidateExpirationExtension(runtimeID, testCase.expireAt)
+
+			//then
+			if testCase.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+			}
+		})
+	}
+
+	t.Run("should return internal error when failed to get cluster", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(model.Cluster{}, dberrors.Internal("error"))
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		//when
+		err := validator.ValidateExpirationExtension(runtimeID, &later)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeInternal, err.Code())
+	})
+}
+
+func TestValidator_ValidateOperationAnnotation(t *testing.T) {
+	operationID := "f0f8b5a2-2c3e-4b0c-9d8e-3c5c0a4f1e6d"
+	tenant := "tenant"
+	adminTenant := "admin-tenant"
+
+	maxAnnotations := make([]model.OperationAnnotation, 0, maxOperationAnnotations)
+	for i := 0; i < maxOperationAnnotations; i++ {
+		maxAnnotations = append(maxAnnotations, model.OperationAnnotation{OperationID: operationID, Key: fmt.Sprintf("key-%d", i), Value: "value"})
+	}
+
+	for _, testCase := range []struct {
+		description  string
+		tenant       string
+		key          string
+		value        string
+		annotations  []model.OperationAnnotation
+		expectedCode apperrors.ErrCode
+	}{
+		{description: "annotation set by owning tenant", tenant: tenant, key: "hyperscaler.ticket", value: "12345"},
+		{description: "annotation set by admin tenant", tenant: adminTenant, key: "ticket", value: "12345"},
+		{description: "annotation removed when limit is reached", tenant: tenant, key: "ticket", annotations: maxAnnotations},
+		{description: "annotation replaced when limit is reached", tenant: tenant, key: "key-0", value: "12345", annotations: maxAnnotations},
+		{description: "annotation set by other tenant", tenant: "other-tenant", key: "ticket", value: "12345", expectedCode: apperrors.CodeBadRequest},
+		{description: "empty key", tenant: tenant, key: "", value: "12345", expectedCode: apperrors.CodeBadRequest},
+		{description: "key with invalid characters", tenant: tenant, key: "ticket id", value: "12345", expectedCode: apperrors.CodeBadRequest},
+		{description: "key starting with dash", tenant: tenant, key: "-ticket", value: "12345", expectedCode: apperrors.CodeBadRequest},
+		{description: "too long key", tenant: tenant, key: strings.Repeat("k", maxOperationAnnotationKeyLength+1), value: "12345", expectedCode: apperrors.CodeBadRequest},
+		{description: "too long value", tenant: tenant, key: "ticket", value: strings.Repeat("v", maxOperationAnnotationValueLength+1), expectedCode: apperrors.CodeBadRequest},
+		{description: "new annotation when limit is reached", tenant: tenant, key: "ticket", value: "12345", annotations: maxAnnotations, expectedCode: apperrors.CodeBadRequest},
+	} {
+		t.Run("should validate "+testCase.description, func(t *testing.T) {
+			//given
+			readSession := &dbMocks.ReadSession{}
+			readSession.On("GetTenantForOperation", operationID).Return(tenant, nil)
+			readSession.On("ListOperationAnnotations", operationID).Return(testCase.annotations, nil)
+
+			validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, []string{adminTenant}, nil, nil)
+
+			//when
+			err := validator.ValidateOperationAnnotation(operationID, testCase.tenant, testCase.key, testCase.value)
+
+			//then
+			if testCase.expectedCode == 0 {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedCode, err.Code())
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateAdminTenant(t *testing.T) {
+	validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, []string{"admin-tenant"}, nil, nil)
+
+	t.Run("should pass for admin tenant", func(t *testing.T) {
+		//when
+		err := validator.ValidateAdminTenant("admin-tenant")
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("should return forbidden error for other tenant", func(t *testing.T) {
+		//when
+		err := validator.ValidateAdminTenant("tenant")
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeForbidden, err.Code())
+	})
+}
+
+func TestValidator_ValidateMaintenanceFreeze(t *testing.T) {
+	runtimeID := "123-123-123"
+	cluster := model.Cluster{
+		ID:            runtimeID,
+		Tenant:        "tenant",
+		ClusterConfig: model.GardenerConfig{Provider: "gcp", Region: "europe-west3"},
+	}
+	window := freeze.ActiveWindow{Name: "quarter-end", End: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("should reject upgrade during active freeze window", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		maintenanceFreeze := &mocks.MaintenanceFreeze{}
+		maintenanceFreeze.On("ActiveWindowFor", "gcp", "europe-west3", "tenant").Return(window, true)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, []string{"admin-tenant"}, maintenanceFreeze, nil)
+
+		//when
+		err := validator.ValidateMaintenanceFreeze(runtimeID, "tenant", false)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.MaintenanceFreeze, err.Cause())
+		assert.Contains(t, err.Error(), "quarter-end")
+	})
+
+	t.Run("should allow upgrade when no freeze window applies", func(t *testing.T) {
+		//given
+		readSession := &dbMocks.ReadSession{}
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		maintenanceFreeze := &mocks.MaintenanceFreeze{}
+		maintenanceFreeze.On("ActiveWindowFor", "gcp", "europe-west3", "tenant").Return(freeze.ActiveWindow{}, false)
+
+		validator := NewValidator(readSession, nil, 0, nil, nil, nil, nil, nil, []string{"admin-tenant"}, maintenanceFreeze, nil)
+
+		//when
+		err := validator.ValidateMaintenanceFreeze(runtimeID, "tenant", false)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("should allow admin tenant to override freeze", func(t *testing.T) {
+		//given
+		maintenanceFreeze := &mocks.MaintenanceFreeze{}
+
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, []string{"admin-tenant"}, maintenanceFreeze, nil)
+
+		//when
+		err := validator.ValidateMaintenanceFreeze(runtimeID, "admin-tenant", true)
+
+		//then
+		require.NoError(t, err)
+		maintenanceFreeze.AssertNotCalled(t, "ActiveWindowFor", "gcp", "europe-west3", "tenant")
+	})
+
+	t.Run("should reject override by other tenant", func(t *testing.T) {
+		//given
+		validator := NewValidator(nil, nil, 0, nil, nil, nil, nil, nil, []string{"admin-tenant"}, &mocks.MaintenanceFreeze{}, nil)
+
+		//when
+		err := validator.ValidateMaintenanceFreeze(runtimeID, "tenant", true)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeForbidden, err.Code())
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// warningsExtension is the key of the GraphQL response extension listing the warnings of the request
+const warningsExtension = "warnings"
+
+// addWarning adds the message to the warnings returned in the extensions of the GraphQL response,
+// so that the client is informed about the state of the Runtime without failing the request
+func addWarning(ctx context.Context, format string, a ...interface{}) {
+	requestContext := graphql.GetRequestContext(ctx)
+	if requestContext == nil {
+		return
+	}
+
+	if requestContext.Extensions == nil {
+		requestContext.Extensions = map[string]interface{}{}
+	}
+	warnings, _ := requestContext.Extensions[warningsExtension].([]string)
+	requestContext.Extensions[warningsExtension] = append(warnings, fmt.Sprintf(format, a...))
+}
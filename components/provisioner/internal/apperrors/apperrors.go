@@ -3,15 +3,28 @@ package apperrors
 import "fmt"
 
 const (
-	CodeBadGateway ErrCode = 502
-	CodeInternal   ErrCode = 500
-	CodeForbidden  ErrCode = 403
-	CodeBadRequest ErrCode = 400
+	CodeServiceUnavailable ErrCode = 503
+	CodeBadGateway         ErrCode = 502
+	CodeInternal           ErrCode = 500
+	CodeNotFound           ErrCode = 404
+	CodeForbidden          ErrCode = 403
+	CodeBadRequest         ErrCode = 400
 )
 
 const (
-	Unknown        CauseCode = 10
-	TenantNotFound CauseCode = 11
+	Unknown                     CauseCode = 10
+	TenantNotFound              CauseCode = 11
+	ClientCredentialsInvalid    CauseCode = 12
+	CredentialsNotFound         CauseCode = 13
+	CredentialsIncomplete       CauseCode = 14
+	QuotaExceeded               CauseCode = 15
+	CredentialsProviderMismatch CauseCode = 16
+	OperationInProgress         CauseCode = 17
+	RegionNotAllowed            CauseCode = 18
+	MaintenanceFreeze           CauseCode = 19
+	ReadOnlyMode                CauseCode = 20
+	InfraDependencies           CauseCode = 21
+	RateLimitsExceeded          CauseCode = 22
 )
 
 type ErrCode int
@@ -20,14 +33,17 @@ type CauseCode int
 
 type AppError interface {
 	Append(string, ...interface{}) AppError
+	SetComponent(ErrComponent) AppError
 	Code() ErrCode
 	Cause() CauseCode
+	Component() ErrComponent
 	Error() string
 }
 
 type appError struct {
 	code         ErrCode
 	internalCode CauseCode
+	component    ErrComponent
 	message      string
 }
 
@@ -51,13 +67,59 @@ func BadRequest(format string, a ...interface{}) AppError {
 	return errorf(CodeBadRequest, Unknown, format, a...)
 }
 
+func NotFound(format string, a ...interface{}) AppError {
+	return errorf(CodeNotFound, Unknown, format, a...)
+}
+
 func InvalidTenant(format string, a ...interface{}) AppError {
 	return errorf(CodeBadRequest, TenantNotFound, format, a...)
 }
 
+func InvalidClientCredentials(format string, a ...interface{}) AppError {
+	return errorf(CodeBadGateway, ClientCredentialsInvalid, format, a...)
+}
+
+// ErrOperationInProgress is returned when the operation cannot be started because another operation of the Runtime is in progress
+func ErrOperationInProgress(format string, a ...interface{}) AppError {
+	return errorf(CodeBadRequest, OperationInProgress, format, a...)
+}
+
+// ErrRegionNotAllowed is returned when the provider, region or zone is not allowed by the region policy of the landscape
+func ErrRegionNotAllowed(format string, a ...interface{}) AppError {
+	return errorf(CodeForbidden, RegionNotAllowed, format, a...)
+}
+
+// ErrMaintenanceFreeze is returned when the disruptive operation cannot be started during the active maintenance freeze window
+func ErrMaintenanceFreeze(format string, a ...interface{}) AppError {
+	return errorf(CodeForbidden, MaintenanceFreeze, format, a...)
+}
+
+// ErrReadOnlyMode is returned when the mutation is rejected because the Provisioner is in the read-only maintenance mode
+func ErrReadOnlyMode(format string, a ...interface{}) AppError {
+	return errorf(CodeServiceUnavailable, ReadOnlyMode, format, a...)
+}
+
+// FailedPermanently is returned when the request cannot succeed without user action, e.g. fixing the credentials.
+// The cause explains the reason of the failure.
+func FailedPermanently(cause CauseCode, format string, a ...interface{}) AppError {
+	return errorf(CodeBadRequest, cause, format, a...)
+}
+
+// FailedTemporarily is returned when the request failed because of a temporary condition, which is expected to pass without user action.
+// The cause explains the reason of the failure.
+func FailedTemporarily(cause CauseCode, format string, a ...interface{}) AppError {
+	return errorf(CodeServiceUnavailable, cause, format, a...)
+}
+
 func (ae appError) Append(additionalFormat string, a ...interface{}) AppError {
 	format := additionalFormat + ", " + ae.message
-	return errorf(ae.code, ae.internalCode, format, a...)
+	return errorf(ae.code, ae.internalCode, format, a...).SetComponent(ae.component)
+}
+
+// SetComponent marks the component which caused the error
+func (ae appError) SetComponent(component ErrComponent) AppError {
+	ae.component = component
+	return ae
 }
 
 func (ae appError) Code() ErrCode {
@@ -71,3 +133,10 @@ func (ae appError) Error() string {
 func (ae appError) Cause() CauseCode {
 	return ae.internalCode
 }
+
+func (ae appError) Component() ErrComponent {
+	if ae.component == "" {
+		return ErrComponentUnknown
+	}
+	return ae.component
+}
@@ -12,6 +12,23 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, CodeInternal, Internal("error").Code())
 		assert.Equal(t, CodeForbidden, Forbidden("error").Code())
 		assert.Equal(t, CodeBadRequest, BadRequest("error").Code())
+		assert.Equal(t, CodeNotFound, NotFound("error").Code())
+		assert.Equal(t, CodeBadRequest, FailedPermanently(QuotaExceeded, "error").Code())
+		assert.Equal(t, CodeBadRequest, ErrOperationInProgress("error").Code())
+		assert.Equal(t, CodeForbidden, ErrRegionNotAllowed("error").Code())
+		assert.Equal(t, CodeForbidden, ErrMaintenanceFreeze("error").Code())
+		assert.Equal(t, CodeServiceUnavailable, ErrReadOnlyMode("error").Code())
+		assert.Equal(t, CodeServiceUnavailable, FailedTemporarily(RateLimitsExceeded, "error").Code())
+	})
+
+	t.Run("should create permanent failure with cause", func(t *testing.T) {
+		assert.Equal(t, QuotaExceeded, FailedPermanently(QuotaExceeded, "error").Cause())
+		assert.Equal(t, CredentialsNotFound, FailedPermanently(CredentialsNotFound, "error").Append("additional message").Cause())
+		assert.Equal(t, OperationInProgress, ErrOperationInProgress("error").Cause())
+		assert.Equal(t, RegionNotAllowed, ErrRegionNotAllowed("error").Cause())
+		assert.Equal(t, MaintenanceFreeze, ErrMaintenanceFreeze("error").Cause())
+		assert.Equal(t, ReadOnlyMode, ErrReadOnlyMode("error").Cause())
+		assert.Equal(t, RateLimitsExceeded, FailedTemporarily(RateLimitsExceeded, "error").Cause())
 	})
 
 	t.Run("should create error with simple message", func(t *testing.T) {
@@ -0,0 +1,81 @@
+package apperrors
+
+import "errors"
+
+// ErrReason and ErrComponent classify errors in metrics, both have a fixed set of values to keep the label cardinality bounded
+type ErrReason string
+
+type ErrComponent string
+
+const (
+	ErrReasonInternal            ErrReason = "internal"
+	ErrReasonBadGateway          ErrReason = "bad_gateway"
+	ErrReasonForbidden           ErrReason = "forbidden"
+	ErrReasonBadRequest          ErrReason = "bad_request"
+	ErrReasonNotFound            ErrReason = "not_found"
+	ErrReasonTenantNotFound      ErrReason = "tenant_not_found"
+	ErrReasonInvalidCredentials  ErrReason = "invalid_credentials"
+	ErrReasonQuotaExceeded       ErrReason = "quota_exceeded"
+	ErrReasonOperationInProgress ErrReason = "operation_in_progress"
+	ErrReasonRegionNotAllowed    ErrReason = "region_not_allowed"
+	ErrReasonMaintenanceFreeze   ErrReason = "maintenance_freeze"
+	ErrReasonReadOnlyMode        ErrReason = "read_only_mode"
+	ErrReasonInfraDependencies   ErrReason = "infra_dependencies"
+	ErrReasonRateLimitsExceeded  ErrReason = "rate_limits_exceeded"
+)
+
+const (
+	ErrComponentUnknown     ErrComponent = "unknown"
+	ErrComponentProvisioner ErrComponent = "provisioner"
+	ErrComponentDatabase    ErrComponent = "database"
+	ErrComponentDirector    ErrComponent = "director"
+	ErrComponentGardener    ErrComponent = "gardener"
+	ErrComponentRuntime     ErrComponent = "runtime"
+)
+
+// Classify returns the reason and the component of the first AppError in the chain,
+// other errors are classified as internal errors of an unknown component
+func Classify(err error) (ErrReason, ErrComponent) {
+	var appErr AppError
+	if !errors.As(err, &appErr) {
+		return ErrReasonInternal, ErrComponentUnknown
+	}
+
+	return reason(appErr), appErr.Component()
+}
+
+func reason(err AppError) ErrReason {
+	switch err.Cause() {
+	case TenantNotFound:
+		return ErrReasonTenantNotFound
+	case ClientCredentialsInvalid, CredentialsNotFound, CredentialsIncomplete, CredentialsProviderMismatch:
+		return ErrReasonInvalidCredentials
+	case QuotaExceeded:
+		return ErrReasonQuotaExceeded
+	case OperationInProgress:
+		return ErrReasonOperationInProgress
+	case RegionNotAllowed:
+		return ErrReasonRegionNotAllowed
+	case MaintenanceFreeze:
+		return ErrReasonMaintenanceFreeze
+	case ReadOnlyMode:
+		return ErrReasonReadOnlyMode
+	case InfraDependencies:
+		return ErrReasonInfraDependencies
+	case RateLimitsExceeded:
+		return ErrReasonRateLimitsExceeded
+	}
+
+	switch err.Code() {
+	case CodeBadGateway:
+		return ErrReasonBadGateway
+	case CodeForbidden:
+		return ErrReasonForbidden
+	case CodeBadRequest:
+		return ErrReasonBadRequest
+	case CodeNotFound:
+		return ErrReasonNotFound
+	default:
+		return ErrReasonInternal
+	}
+}
@@ -0,0 +1,112 @@
+package apperrors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	for _, testCase := range []struct {
+		description       string
+		err               error
+		expectedReason    ErrReason
+		expectedComponent ErrComponent
+	}{
+		{
+			description:       "error which is not AppError",
+			err:               fmt.Errorf("error"),
+			expectedReason:    ErrReasonInternal,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "AppError without component",
+			err:               BadRequest("error"),
+			expectedReason:    ErrReasonBadRequest,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "AppError with component",
+			err:               BadGateway("error").SetComponent(ErrComponentDirector),
+			expectedReason:    ErrReasonBadGateway,
+			expectedComponent: ErrComponentDirector,
+		},
+		{
+			description:       "appended AppError",
+			err:               Internal("error").SetComponent(ErrComponentGardener).Append("additional message"),
+			expectedReason:    ErrReasonInternal,
+			expectedComponent: ErrComponentGardener,
+		},
+		{
+			description:       "wrapped AppError with cause",
+			err:               errors.Wrap(FailedPermanently(QuotaExceeded, "error").SetComponent(ErrComponentGardener), "while waiting"),
+			expectedReason:    ErrReasonQuotaExceeded,
+			expectedComponent: ErrComponentGardener,
+		},
+		{
+			description:       "not found",
+			err:               NotFound("error"),
+			expectedReason:    ErrReasonNotFound,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "invalid tenant",
+			err:               InvalidTenant("error"),
+			expectedReason:    ErrReasonTenantNotFound,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "invalid credentials",
+			err:               FailedPermanently(CredentialsNotFound, "error"),
+			expectedReason:    ErrReasonInvalidCredentials,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "operation in progress",
+			err:               ErrOperationInProgress("error"),
+			expectedReason:    ErrReasonOperationInProgress,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "region not allowed",
+			err:               ErrRegionNotAllowed("error"),
+			expectedReason:    ErrReasonRegionNotAllowed,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "maintenance freeze",
+			err:               ErrMaintenanceFreeze("error"),
+			expectedReason:    ErrReasonMaintenanceFreeze,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "read-only mode",
+			err:               ErrReadOnlyMode("error"),
+			expectedReason:    ErrReasonReadOnlyMode,
+			expectedComponent: ErrComponentUnknown,
+		},
+		{
+			description:       "missing infrastructure dependencies",
+			err:               FailedPermanently(InfraDependencies, "error").SetComponent(ErrComponentGardener),
+			expectedReason:    ErrReasonInfraDependencies,
+			expectedComponent: ErrComponentGardener,
+		},
+		{
+			description:       "rate limits exceeded",
+			err:               FailedTemporarily(RateLimitsExceeded, "error").SetComponent(ErrComponentGardener),
+			expectedReason:    ErrReasonRateLimitsExceeded,
+			expectedComponent: ErrComponentGardener,
+		},
+	} {
+		t.Run("should classify "+testCase.description, func(t *testing.T) {
+			// when
+			reason, component := Classify(testCase.err)
+
+			// then
+			assert.Equal(t, testCase.expectedReason, reason)
+			assert.Equal(t, testCase.expectedComponent, component)
+		})
+	}
+}
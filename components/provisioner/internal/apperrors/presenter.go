@@ -29,8 +29,13 @@ func (p *presenter) Do(ctx context.Context, err error) *gqlerror.Error {
 	if customErr.Code() == CodeInternal {
 		p.Logger.Errorf("Internal Server Error: %s", err.Error())
 	}
-	return newGraphqlErrorResponse(ctx, customErr.Code(), customErr.Error())
+	gqlErr := newGraphqlErrorResponse(ctx, customErr.Code(), customErr.Error())
+	if customErr.Cause() != Unknown {
+		gqlErr.Extensions["error_cause"] = customErr.Cause()
+		gqlErr.Extensions["error_reason"] = reason(customErr)
+	}
 
+	return gqlErr
 }
 
 func newGraphqlErrorResponse(ctx context.Context, errCode ErrCode, msg string, args ...interface{}) *gqlerror.Error {
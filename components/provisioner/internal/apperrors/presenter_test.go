@@ -44,6 +44,36 @@ func TestPresenter_ErrorPresenter(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("Internal Server Error: %s", errMsg), entry.Message)
 		assert.Equal(t, customErr.Code(), err.Extensions["error_code"])
 		assert.Contains(t, err.Error(), "testErr")
+		assert.NotContains(t, err.Extensions, "error_cause")
+		assert.NotContains(t, err.Extensions, "error_reason")
+		hook.Reset()
+	})
+
+	t.Run("Permanent failure", func(t *testing.T) {
+		//given
+		customErr := FailedPermanently(CredentialsNotFound, errMsg)
+
+		//when
+		err := presenter.Do(context.TODO(), customErr)
+
+		//then
+		assert.Equal(t, CodeBadRequest, err.Extensions["error_code"])
+		assert.Equal(t, CredentialsNotFound, err.Extensions["error_cause"])
+		assert.Equal(t, ErrReasonInvalidCredentials, err.Extensions["error_reason"])
+		assert.Contains(t, err.Error(), "testErr")
+		hook.Reset()
+	})
+	t.Run("Temporary failure", func(t *testing.T) {
+		//given
+		customErr := FailedTemporarily(RateLimitsExceeded, errMsg)
+
+		//when
+		err := presenter.Do(context.TODO(), customErr)
+
+		//then
+		assert.Equal(t, CodeServiceUnavailable, err.Extensions["error_code"])
+		assert.Equal(t, RateLimitsExceeded, err.Extensions["error_cause"])
+		assert.Equal(t, ErrReasonRateLimitsExceeded, err.Extensions["error_reason"])
 		hook.Reset()
 	})
 }
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	prometheusNamespace = "kcp"
+	prometheusSubsystem = "provisioner"
+)
+
+var droppedEntries = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: prometheusNamespace,
+	Subsystem: prometheusSubsystem,
+	Name:      "audit_log_dropped_entries_total",
+	Help:      "The number of audit log entries which were not stored",
+})
+
+// Collectors returns counters of the audit log to be registered in Prometheus
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{droppedEntries}
+}
+
+type EntryWriter interface {
+	InsertAuditEntry(entry model.AuditEntry) dberrors.Error
+}
+
+// Log stores audit entries asynchronously, so failures of the audit log never affect the audited requests
+type Log struct {
+	entries chan model.AuditEntry
+	writer  EntryWriter
+	log     logrus.FieldLogger
+}
+
+func NewLog(writer EntryWriter, bufferSize int) *Log {
+	return &Log{
+		entries: make(chan model.AuditEntry, bufferSize),
+		writer:  writer,
+		log:     logrus.WithField("component", "auditLog"),
+	}
+}
+
+// Record queues the entry to be stored, the entry is dropped if the buffer is full
+func (l *Log) Record(entry model.AuditEntry) {
+	select {
+	case l.entries <- entry:
+	default:
+		droppedEntries.Inc()
+		l.log.Warnf("Audit log buffer is full, dropping entry for mutation %s", entry.Mutation)
+	}
+}
+
+func (l *Log) Run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case entry := <-l.entries:
+			l.store(entry)
+		}
+	}
+}
+
+func (l *Log) store(entry model.AuditEntry) {
+	err := l.writer.InsertAuditEntry(entry)
+	if err != nil {
+		droppedEntries.Inc()
+		l.log.Errorf("Failed to store audit entry for mutation %s: %s", entry.Mutation, err.Error())
+	}
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLog(t *testing.T) {
+	entry := model.AuditEntry{ID: "entry-1", Mutation: "provisionRuntime"}
+
+	t.Run("should store recorded entry", func(t *testing.T) {
+		// given
+		stored := make(chan model.AuditEntry, 1)
+		writer := &sessionMocks.WriteSession{}
+		writer.On("InsertAuditEntry", entry).Run(func(args mock.Arguments) {
+			stored <- args.Get(0).(model.AuditEntry)
+		}).Return(nil)
+
+		auditLog := NewLog(writer, 1)
+		stop := make(chan struct{})
+		defer close(stop)
+		go auditLog.Run(stop)
+
+		// when
+		auditLog.Record(entry)
+
+		// then
+		select {
+		case storedEntry := <-stored:
+			assert.Equal(t, entry, storedEntry)
+		case <-time.After(5 * time.Second):
+			t.Fatal("entry was not stored")
+		}
+	})
+
+	t.Run("should drop entry when buffer is full", func(t *testing.T) {
+		// given
+		auditLog := NewLog(&sessionMocks.WriteSession{}, 1)
+		droppedBefore := testutil.ToFloat64(droppedEntries)
+
+		// when
+		auditLog.Record(entry)
+		auditLog.Record(entry)
+
+		// then
+		assert.Equal(t, droppedBefore+1, testutil.ToFloat64(droppedEntries))
+		assert.Len(t, auditLog.entries, 1)
+	})
+
+	t.Run("should count entry as dropped when storing fails", func(t *testing.T) {
+		// given
+		writer := &sessionMocks.WriteSession{}
+		writer.On("InsertAuditEntry", entry).Return(dberrors.Internal("error"))
+
+		auditLog := NewLog(writer, 1)
+		droppedBefore := testutil.ToFloat64(droppedEntries)
+
+		// when
+		auditLog.store(entry)
+
+		// then
+		assert.Equal(t, droppedBefore+1, testutil.ToFloat64(droppedEntries))
+		writer.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/api/middlewares"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+const (
+	auditEntriesQuery = "auditEntries"
+	redactedValue     = "[REDACTED]"
+)
+
+var sensitiveKeys = []string{"secret", "password", "token", "kubeconfig", "credentials"}
+
+// NewResolverMiddleware returns middleware recording every top level mutation in the audit log
+func NewResolverMiddleware(auditLog *Log, uuidGenerator uuid.UUIDGenerator) graphql.FieldMiddleware {
+	return func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+		resolverContext := graphql.GetResolverContext(ctx)
+		if resolverContext == nil || resolverContext.Object != "Mutation" {
+			return next(ctx)
+		}
+
+		result, err := next(ctx)
+
+		auditLog.Record(model.AuditEntry{
+			ID:           uuidGenerator.New(),
+			Tenant:       contextValue(ctx, middlewares.Tenant),
+			SubAccountID: contextValue(ctx, middlewares.SubAccountID),
+			Mutation:     resolverContext.Field.Name,
+			Input:        sanitizeInput(resolverContext.Args),
+			OperationID:  operationID(result),
+			CreatedAt:    time.Now(),
+		})
+
+		return result, err
+	}
+}
+
+// NewQueryGuard returns middleware rejecting the audit entries query unless it is enabled
+func NewQueryGuard(queryEnabled bool) graphql.FieldMiddleware {
+	return func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+		resolverContext := graphql.GetResolverContext(ctx)
+		if !queryEnabled && resolverContext != nil && resolverContext.Object == "Query" && resolverContext.Field.Name == auditEntriesQuery {
+			return nil, apperrors.Forbidden("error: %s query is disabled", auditEntriesQuery)
+		}
+
+		return next(ctx)
+	}
+}
+
+func contextValue(ctx context.Context, header middlewares.Header) *string {
+	value, ok := ctx.Value(header).(string)
+	if !ok || value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func operationID(result interface{}) *string {
+	switch value := result.(type) {
+	case *gqlschema.OperationStatus:
+		if value != nil {
+			return value.ID
+		}
+	case *gqlschema.RuntimeStatus:
+		if value != nil && value.LastOperationStatus != nil {
+			return value.LastOperationStatus.ID
+		}
+	case string:
+		if value != "" {
+			return &value
+		}
+	}
+
+	return nil
+}
+
+// sanitizeInput converts mutation arguments to JSON with values of sensitive fields and secret configuration entries redacted
+func sanitizeInput(args map[string]interface{}) string {
+	marshalled, err := json.Marshal(args)
+	if err != nil {
+		return "{}"
+	}
+
+	var input interface{}
+	err = json.Unmarshal(marshalled, &input)
+	if err != nil {
+		return "{}"
+	}
+
+	sanitized, err := json.Marshal(redact(input))
+	if err != nil {
+		return "{}"
+	}
+
+	return string(sanitized)
+}
+
+func redact(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		// Configuration entries hold confidential values if marked as secret
+		if secret, ok := typed["secret"].(bool); ok && secret {
+			if _, ok := typed["value"]; ok {
+				typed["value"] = redactedValue
+			}
+		}
+
+		for key, field := range typed {
+			if _, isString := field.(string); isString && isSensitiveKey(key) {
+				typed[key] = redactedValue
+				continue
+			}
+			typed[key] = redact(field)
+		}
+	case []interface{}:
+		for i := range typed {
+			typed[i] = redact(typed[i])
+		}
+	}
+
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+
+	return false
+}
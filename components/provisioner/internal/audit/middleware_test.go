@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/api/middlewares"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestResolverMiddleware(t *testing.T) {
+	resolverContext := func(object, field string, args map[string]interface{}) context.Context {
+		ctx := context.WithValue(context.Background(), middlewares.Tenant, "tenant")
+		return graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+			Object: object,
+			Field:  graphql.CollectedField{Field: &ast.Field{Name: field}},
+			Args:   args,
+		})
+	}
+
+	t.Run("should record mutation with redacted input", func(t *testing.T) {
+		// given
+		uuidGenerator := &mocks.UUIDGenerator{}
+		uuidGenerator.On("New").Return("entry-1")
+
+		auditLog := NewLog(nil, 1)
+		middleware := NewResolverMiddleware(auditLog, uuidGenerator)
+
+		ctx := resolverContext("Mutation", "upgradeRuntime", map[string]interface{}{
+			"id": "runtime-1",
+			"config": gqlschema.UpgradeRuntimeInput{
+				KymaConfig: &gqlschema.KymaConfigInput{
+					Version: "1.20.0",
+					Configuration: []*gqlschema.ConfigEntryInput{
+						{Key: "admin.password", Value: "secret-value", Secret: util.BoolPtr(true)},
+						{Key: "domain", Value: "kyma.local"},
+					},
+				},
+			},
+		})
+
+		// when
+		result, err := middleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return &gqlschema.OperationStatus{ID: util.StringPtr("operation-1")}, nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+
+		require.Len(t, auditLog.entries, 1)
+		entry := <-auditLog.entries
+		assert.Equal(t, "entry-1", entry.ID)
+		assert.Equal(t, util.StringPtr("tenant"), entry.Tenant)
+		assert.Nil(t, entry.SubAccountID)
+		assert.Equal(t, "upgradeRuntime", entry.Mutation)
+		assert.Equal(t, util.StringPtr("operation-1"), entry.OperationID)
+		assert.NotContains(t, entry.Input, "secret-value")
+		assert.Contains(t, entry.Input, redactedValue)
+		assert.Contains(t, entry.Input, "kyma.local")
+	})
+
+	t.Run("should record failed mutation without operation ID", func(t *testing.T) {
+		// given
+		uuidGenerator := &mocks.UUIDGenerator{}
+		uuidGenerator.On("New").Return("entry-1")
+
+		auditLog := NewLog(nil, 1)
+		middleware := NewResolverMiddleware(auditLog, uuidGenerator)
+
+		ctx := resolverContext("Mutation", "deprovisionRuntime", map[string]interface{}{"id": "runtime-1"})
+
+		// when
+		_, err := middleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return "", apperrors.BadRequest("error")
+		})
+
+		// then
+		require.Error(t, err)
+
+		require.Len(t, auditLog.entries, 1)
+		entry := <-auditLog.entries
+		assert.Equal(t, "deprovisionRuntime", entry.Mutation)
+		assert.Nil(t, entry.OperationID)
+		assert.JSONEq(t, `{"id": "runtime-1"}`, entry.Input)
+	})
+
+	t.Run("should not record queries", func(t *testing.T) {
+		// given
+		auditLog := NewLog(nil, 1)
+		middleware := NewResolverMiddleware(auditLog, &mocks.UUIDGenerator{})
+
+		ctx := resolverContext("Query", "runtimeStatus", map[string]interface{}{"id": "runtime-1"})
+
+		// when
+		_, err := middleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return &gqlschema.RuntimeStatus{}, nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, auditLog.entries, 0)
+	})
+}
+
+func TestQueryGuard(t *testing.T) {
+	ctx := graphql.WithResolverContext(context.Background(), &graphql.ResolverContext{
+		Object: "Query",
+		Field:  graphql.CollectedField{Field: &ast.Field{Name: auditEntriesQuery}},
+	})
+	next := func(ctx context.Context) (interface{}, error) {
+		return []*gqlschema.AuditEntry{}, nil
+	}
+
+	t.Run("should reject audit entries query when disabled", func(t *testing.T) {
+		// when
+		_, err := NewQueryGuard(false)(ctx, next)
+
+		// then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeForbidden)
+	})
+
+	t.Run("should allow audit entries query when enabled", func(t *testing.T) {
+		// when
+		result, err := NewQueryGuard(true)(ctx, next)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+}
+
+func TestSanitizeInput(t *testing.T) {
+	// when
+	input := sanitizeInput(map[string]interface{}{
+		"config": map[string]interface{}{
+			"kubeconfig":   "apiVersion: v1",
+			"clientSecret": "client-secret",
+			"secret":       false,
+			"value":        "public",
+			"nested": []interface{}{
+				map[string]interface{}{"key": "token", "value": "confidential", "secret": true},
+			},
+		},
+	})
+
+	// then
+	assert.JSONEq(t, `{"config": {
+		"kubeconfig": "[REDACTED]",
+		"clientSecret": "[REDACTED]",
+		"secret": false,
+		"value": "public",
+		"nested": [{"key": "token", "value": "[REDACTED]", "secret": true}]
+	}}`, input)
+}
@@ -2,6 +2,7 @@ package director
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 
@@ -31,21 +32,26 @@ type DirectorClient interface {
 	RuntimeExists(gardenerClusterName, tenant string) (bool, apperrors.AppError)
 }
 
+//go:generate mockery -name=BatchClient
+type BatchClient interface {
+	DirectorClient
+	SetRuntimeStatusConditions(statusConditions map[string]graphql.RuntimeStatusCondition, tenant string) apperrors.AppError
+}
+
 type directorClient struct {
 	gqlClient     gql.Client
 	queryProvider queryProvider
 	graphqlizer   graphqlizer.Graphqlizer
-	token         oauth.Token
 	oauthClient   oauth.Client
 }
 
-func NewDirectorClient(gqlClient gql.Client, oauthClient oauth.Client) DirectorClient {
+// NewDirectorClient returns client which additionally sends operations on multiple Runtimes of the tenant in a single Director request
+func NewDirectorClient(gqlClient gql.Client, oauthClient oauth.Client) BatchClient {
 	return &directorClient{
 		gqlClient:     gqlClient,
 		oauthClient:   oauthClient,
 		queryProvider: queryProvider{},
 		graphqlizer:   graphqlizer.Graphqlizer{},
-		token:         oauth.Token{},
 	}
 }
 
@@ -207,6 +213,61 @@ func (cc *directorClient) SetRuntimeStatusCondition(id string, statusCondition g
 	return nil
 }
 
+// SetRuntimeStatusConditions sets status conditions of the Runtimes using one batched query and one batched mutation
+func (cc *directorClient) SetRuntimeStatusConditions(statusConditions map[string]graphql.RuntimeStatusCondition, tenant string) apperrors.AppError {
+	runtimeIDs := make([]string, 0, len(statusConditions))
+	for id := range statusConditions {
+		runtimeIDs = append(runtimeIDs, id)
+	}
+	sort.Strings(runtimeIDs)
+
+	aliases := make([]string, 0, len(runtimeIDs))
+	for i := range runtimeIDs {
+		aliases = append(aliases, fmt.Sprintf("runtime%d", i))
+	}
+
+	var getResponse GetRuntimesBatchResponse
+	err := cc.executeDirectorGraphQLCall(cc.queryProvider.getRuntimesQuery(aliases, runtimeIDs), tenant, &getResponse)
+	if err != nil {
+		return err.Append("Failed to get %d runtimes from Director", len(runtimeIDs))
+	}
+
+	runtimeInputs := make([]string, 0, len(runtimeIDs))
+	for i, id := range runtimeIDs {
+		runtime := getResponse[aliases[i]]
+		if runtime == nil || runtime.ID != id {
+			return apperrors.Internal("Failed to get runtime %s from Director: received unexpected response", id)
+		}
+
+		statusCondition := statusConditions[id]
+		runtimeInput, gqlErr := cc.graphqlizer.RuntimeInputToGQL(graphql.RuntimeInput{
+			Name:            runtime.Name,
+			Description:     runtime.Description,
+			StatusCondition: &statusCondition,
+			Labels:          &runtime.Labels,
+		})
+		if gqlErr != nil {
+			return apperrors.Internal("Failed to create graphQLized Runtime input: %s", gqlErr.Error())
+		}
+		runtimeInputs = append(runtimeInputs, runtimeInput)
+	}
+
+	var updateResponse UpdateRuntimesBatchResponse
+	err = cc.executeDirectorGraphQLCall(cc.queryProvider.updateRuntimesMutation(aliases, runtimeIDs, runtimeInputs), tenant, &updateResponse)
+	if err != nil {
+		return err.Append("Failed to update %d runtimes in Director", len(runtimeIDs))
+	}
+	for i, id := range runtimeIDs {
+		runtime := updateResponse[aliases[i]]
+		if runtime == nil || runtime.ID != id {
+			return apperrors.Internal("Failed to update runtime %s in Director: received unexpected response", id)
+		}
+	}
+
+	log.Infof("Successfully set status conditions of %d Runtimes in Director for tenant %s", len(runtimeIDs), tenant)
+	return nil
+}
+
 func (cc *directorClient) GetConnectionToken(id, tenant string) (graphql.OneTimeTokenForRuntimeExt, apperrors.AppError) {
 	runtimeQuery := cc.queryProvider.requestOneTimeTokeneMutation(id)
 
@@ -225,37 +286,34 @@ func (cc *directorClient) GetConnectionToken(id, tenant string) (graphql.OneTime
 	return *response.Result, nil
 }
 
-func (cc *directorClient) getToken() apperrors.AppError {
+func (cc *directorClient) getToken() (oauth.Token, apperrors.AppError) {
 	token, err := cc.oauthClient.GetAuthorizationToken()
 	if err != nil {
-		return err.Append("Error while obtaining token")
+		return oauth.Token{}, err.Append("Error while obtaining token")
 	}
 
 	if token.EmptyOrExpired() {
-		return apperrors.Internal("Obtained empty or expired token")
+		return oauth.Token{}, apperrors.Internal("Obtained empty or expired token")
 	}
 
-	cc.token = token
-	return nil
+	return token, nil
 }
 
 func (cc *directorClient) executeDirectorGraphQLCall(directorQuery string, tenant string, response interface{}) apperrors.AppError {
-	if cc.token.EmptyOrExpired() {
-		log.Infof("Refreshing token to access Director Service")
-		if err := cc.getToken(); err != nil {
-			return err
-		}
+	token, err := cc.getToken()
+	if err != nil {
+		return err
 	}
 
 	req := gcli.NewRequest(directorQuery)
-	req.Header.Set(AuthorizationHeader, fmt.Sprintf("Bearer %s", cc.token.AccessToken))
+	req.Header.Set(AuthorizationHeader, fmt.Sprintf("Bearer %s", token.AccessToken))
 	req.Header.Set(TenantHeader, tenant)
 
 	if err := cc.gqlClient.Do(req, response); err != nil {
 		if egErr, ok := err.(gcli.ExtendedError); ok {
-			return mapDirectorErrorToProvisionerError(egErr).Append("Failed to execute GraphQL request to Director")
+			return mapDirectorErrorToProvisionerError(egErr).SetComponent(apperrors.ErrComponentDirector).Append("Failed to execute GraphQL request to Director")
 		}
-		return apperrors.Internal("Failed to execute GraphQL request to Director: %v", err)
+		return apperrors.Internal("Failed to execute GraphQL request to Director: %v", err).SetComponent(apperrors.ErrComponentDirector)
 	}
 
 	return nil
@@ -1174,3 +1174,116 @@ func TestDirectorClient_MapDirectorErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestDirectorClient_SetRuntimeStatusConditions(t *testing.T) {
+	const (
+		secondRuntimeTestingID = "test-runtime-ID-67890"
+
+		expectedGetRuntimesQuery = `query {
+    runtime0: runtime(id: "test-runtime-ID-12345") {
+         id name description labels
+}
+    runtime1: runtime(id: "test-runtime-ID-67890") {
+         id name description labels
+}}`
+
+		expectedUpdateRuntimesMutation = `mutation {
+    runtime0: updateRuntime(id: "test-runtime-ID-12345" in: {
+		name: "Runtime Test name",
+		labels: {label1:"something",},
+		statusCondition: CONNECTED,
+	}) {
+		id
+}
+    runtime1: updateRuntime(id: "test-runtime-ID-67890" in: {
+		name: "Second Runtime",
+		labels: {},
+		statusCondition: FAILED,
+	}) {
+		id
+}}`
+	)
+
+	expectedGetRequest := gcli.NewRequest(expectedGetRuntimesQuery)
+	expectedGetRequest.Header.Set(AuthorizationHeader, fmt.Sprintf("Bearer %s", validTokenValue))
+	expectedGetRequest.Header.Set(TenantHeader, tenantValue)
+
+	expectedUpdateRequest := gcli.NewRequest(expectedUpdateRuntimesMutation)
+	expectedUpdateRequest.Header.Set(AuthorizationHeader, fmt.Sprintf("Bearer %s", validTokenValue))
+	expectedUpdateRequest.Header.Set(TenantHeader, tenantValue)
+
+	statusConditions := map[string]graphql.RuntimeStatusCondition{
+		runtimeTestingID:       graphql.RuntimeStatusConditionConnected,
+		secondRuntimeTestingID: graphql.RuntimeStatusConditionFailed,
+	}
+
+	getFunction := func(t *testing.T, r interface{}) {
+		cfg, ok := r.(*GetRuntimesBatchResponse)
+		require.True(t, ok)
+		assert.Empty(t, cfg)
+		*cfg = GetRuntimesBatchResponse{
+			"runtime0": {
+				Runtime: graphql.Runtime{ID: runtimeTestingID, Name: runtimeTestingName},
+				Labels:  graphql.Labels{"label1": "something"},
+			},
+			"runtime1": {
+				Runtime: graphql.Runtime{ID: secondRuntimeTestingID, Name: "Second Runtime"},
+			},
+		}
+	}
+
+	token := oauth.Token{
+		AccessToken: validTokenValue,
+		Expiration:  futureExpirationTime,
+	}
+
+	t.Run("should set status conditions of runtimes in batched requests", func(t *testing.T) {
+		//given
+		updateFunction := func(t *testing.T, r interface{}) {
+			cfg, ok := r.(*UpdateRuntimesBatchResponse)
+			require.True(t, ok)
+			*cfg = UpdateRuntimesBatchResponse{
+				"runtime0": {ID: runtimeTestingID},
+				"runtime1": {ID: secondRuntimeTestingID},
+			}
+		}
+
+		gqlClient := gql.NewQueryAssertClient(t, nil, []*gcli.Request{expectedGetRequest, expectedUpdateRequest}, getFunction, updateFunction)
+
+		mockedOAuthClient := &oauthmocks.Client{}
+		mockedOAuthClient.On("GetAuthorizationToken").Return(token, nil)
+
+		configClient := NewDirectorClient(gqlClient, mockedOAuthClient)
+
+		//when
+		err := configClient.SetRuntimeStatusConditions(statusConditions, tenantValue)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error when runtime is missing in batched response", func(t *testing.T) {
+		//given
+		updateFunction := func(t *testing.T, r interface{}) {
+			cfg, ok := r.(*UpdateRuntimesBatchResponse)
+			require.True(t, ok)
+			*cfg = UpdateRuntimesBatchResponse{
+				"runtime0": {ID: runtimeTestingID},
+			}
+		}
+
+		gqlClient := gql.NewQueryAssertClient(t, nil, []*gcli.Request{expectedGetRequest, expectedUpdateRequest}, getFunction, updateFunction)
+
+		mockedOAuthClient := &oauthmocks.Client{}
+		mockedOAuthClient.On("GetAuthorizationToken").Return(token, nil)
+
+		configClient := NewDirectorClient(gqlClient, mockedOAuthClient)
+
+		//when
+		err := configClient.SetRuntimeStatusConditions(statusConditions, tenantValue)
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), secondRuntimeTestingID)
+	})
+}
@@ -24,6 +24,12 @@ type UpdateRuntimeResponse struct {
 	Result *graphql.Runtime `json:"result"`
 }
 
+// GetRuntimesBatchResponse maps aliases of the batched query to the Runtimes
+type GetRuntimesBatchResponse map[string]*graphql.RuntimeExt
+
+// UpdateRuntimesBatchResponse maps aliases of the batched mutation to the updated Runtimes
+type UpdateRuntimesBatchResponse map[string]*graphql.Runtime
+
 type OneTimeTokenResponse struct {
 	Result *graphql.OneTimeTokenForRuntimeExt `json:"result"`
 }
@@ -1,6 +1,9 @@
 package director
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type queryProvider struct{}
 
@@ -36,3 +39,29 @@ func (qp queryProvider) requestOneTimeTokeneMutation(runtimeID string) string {
 		token connectorURL
 }}`, runtimeID)
 }
+
+// getRuntimesQuery gets multiple Runtimes in a single request, the result of each Runtime is aliased with the alias at the same index
+func (qp queryProvider) getRuntimesQuery(aliases, runtimeIDs []string) string {
+	var queries strings.Builder
+	for i, runtimeID := range runtimeIDs {
+		queries.WriteString(fmt.Sprintf(`
+    %s: runtime(id: "%s") {
+         id name description labels
+}`, aliases[i], runtimeID))
+	}
+
+	return fmt.Sprintf(`query {%s}`, queries.String())
+}
+
+// updateRuntimesMutation updates multiple Runtimes in a single request, the result of each Runtime is aliased with the alias at the same index
+func (qp queryProvider) updateRuntimesMutation(aliases, runtimeIDs, runtimeInputs []string) string {
+	var mutations strings.Builder
+	for i, runtimeID := range runtimeIDs {
+		mutations.WriteString(fmt.Sprintf(`
+    %s: updateRuntime(id: "%s" in: %s) {
+		id
+}`, aliases[i], runtimeID, runtimeInputs[i]))
+	}
+
+	return fmt.Sprintf(`mutation {%s}`, mutations.String())
+}
@@ -0,0 +1,189 @@
+package director
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+type StatusUpdatesConfig struct {
+	// FlushInterval is the maximum time a status condition update waits in the queue
+	FlushInterval time.Duration `envconfig:"default=500ms"`
+	// MaxBatchSize flushes the queue as soon as updates of that many Runtimes are queued
+	MaxBatchSize int `envconfig:"default=50"`
+	// BatchRequests sends updates of the tenant in a single request, disable it if Director rejects batched operations
+	BatchRequests bool          `envconfig:"default=true"`
+	Retries       int           `envconfig:"default=3"`
+	RetryInterval time.Duration `envconfig:"default=1s"`
+}
+
+type statusConditionKey struct {
+	runtimeID string
+	tenant    string
+}
+
+// statusConditionUpdate holds the last status condition requested for the Runtime,
+// all callers which requested an update of the Runtime receive the result of the same Director call
+type statusConditionUpdate struct {
+	statusCondition graphql.RuntimeStatusCondition
+	results         []chan apperrors.AppError
+}
+
+func (u *statusConditionUpdate) complete(err apperrors.AppError) {
+	for _, result := range u.results {
+		result <- err
+	}
+}
+
+// StatusConditionBatcher queues Runtime status condition updates and sends them to Director periodically,
+// so the number of Director requests does not grow with the number of operations in progress
+type StatusConditionBatcher struct {
+	BatchClient
+
+	config  StatusUpdatesConfig
+	mutex   sync.Mutex
+	pending map[statusConditionKey]*statusConditionUpdate
+	flush   chan struct{}
+	stopped bool
+	log     logrus.FieldLogger
+}
+
+func NewStatusConditionBatcher(client BatchClient, config StatusUpdatesConfig) *StatusConditionBatcher {
+	if config.Retries < 1 {
+		config.Retries = 1
+	}
+
+	return &StatusConditionBatcher{
+		BatchClient: client,
+		config:      config,
+		pending:     map[statusConditionKey]*statusConditionUpdate{},
+		flush:       make(chan struct{}, 1),
+		log:         logrus.WithField("component", "statusConditionBatcher"),
+	}
+}
+
+// SetRuntimeStatusCondition queues the update and waits until it is sent to Director,
+// a queued update of the same Runtime is replaced with the latest status condition
+func (b *StatusConditionBatcher) SetRuntimeStatusCondition(id string, statusCondition graphql.RuntimeStatusCondition, tenant string) apperrors.AppError {
+	b.mutex.Lock()
+	if b.stopped {
+		b.mutex.Unlock()
+		return b.BatchClient.SetRuntimeStatusCondition(id, statusCondition, tenant)
+	}
+
+	key := statusConditionKey{runtimeID: id, tenant: tenant}
+	update, found := b.pending[key]
+	if !found {
+		update = &statusConditionUpdate{}
+		b.pending[key] = update
+	}
+	update.statusCondition = statusCondition
+	result := make(chan apperrors.AppError, 1)
+	update.results = append(update.results, result)
+
+	if len(b.pending) >= b.config.MaxBatchSize {
+		select {
+		case b.flush <- struct{}{}:
+		default:
+		}
+	}
+	b.mutex.Unlock()
+
+	return <-result
+}
+
+// Run flushes queued updates until the stop channel is closed, updates requested afterwards are sent to Director directly
+func (b *StatusConditionBatcher) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			b.mutex.Lock()
+			b.stopped = true
+			b.mutex.Unlock()
+			b.flushPending()
+			return
+		case <-ticker.C:
+			b.flushPending()
+		case <-b.flush:
+			b.flushPending()
+		}
+	}
+}
+
+func (b *StatusConditionBatcher) flushPending() {
+	b.mutex.Lock()
+	pending := b.pending
+	b.pending = map[statusConditionKey]*statusConditionUpdate{}
+	b.mutex.Unlock()
+
+	tenantUpdates := map[string]map[string]*statusConditionUpdate{}
+	for key, update := range pending {
+		if tenantUpdates[key.tenant] == nil {
+			tenantUpdates[key.tenant] = map[string]*statusConditionUpdate{}
+		}
+		tenantUpdates[key.tenant][key.runtimeID] = update
+	}
+
+	for tenant, updates := range tenantUpdates {
+		for _, batch := range b.splitIntoBatches(updates) {
+			b.send(batch, tenant)
+		}
+	}
+}
+
+func (b *StatusConditionBatcher) splitIntoBatches(updates map[string]*statusConditionUpdate) []map[string]*statusConditionUpdate {
+	runtimeIDs := make([]string, 0, len(updates))
+	for id := range updates {
+		runtimeIDs = append(runtimeIDs, id)
+	}
+	sort.Strings(runtimeIDs)
+
+	var batches []map[string]*statusConditionUpdate
+	for i, id := range runtimeIDs {
+		if b.config.MaxBatchSize <= 0 || i%b.config.MaxBatchSize == 0 {
+			batches = append(batches, map[string]*statusConditionUpdate{})
+		}
+		batches[len(batches)-1][id] = updates[id]
+	}
+
+	return batches
+}
+
+func (b *StatusConditionBatcher) send(updates map[string]*statusConditionUpdate, tenant string) {
+	if b.config.BatchRequests && len(updates) > 1 {
+		statusConditions := make(map[string]graphql.RuntimeStatusCondition, len(updates))
+		for id, update := range updates {
+			statusConditions[id] = update.statusCondition
+		}
+
+		err := b.BatchClient.SetRuntimeStatusConditions(statusConditions, tenant)
+		if err == nil {
+			for _, update := range updates {
+				update.complete(nil)
+			}
+			return
+		}
+		b.log.Warnf("Failed to set status conditions of %d Runtimes in a single request, retrying each Runtime: %s", len(updates), err.Error())
+	}
+
+	var wg sync.WaitGroup
+	for id, update := range updates {
+		wg.Add(1)
+		go func(id string, update *statusConditionUpdate) {
+			defer wg.Done()
+			err := util.RetryOnError(b.config.RetryInterval, b.config.Retries, "Error while setting Runtime status condition in Director: %s", func() apperrors.AppError {
+				return b.BatchClient.SetRuntimeStatusCondition(id, update.statusCondition, tenant)
+			})
+			update.complete(err)
+		}(id, update)
+	}
+	wg.Wait()
+}
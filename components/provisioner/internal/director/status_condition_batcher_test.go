@@ -0,0 +1,165 @@
+package director
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	firstRuntimeID  = "runtime-1"
+	secondRuntimeID = "runtime-2"
+)
+
+func TestStatusConditionBatcher_SetRuntimeStatusCondition(t *testing.T) {
+	config := StatusUpdatesConfig{
+		FlushInterval: time.Hour,
+		MaxBatchSize:  10,
+		BatchRequests: true,
+		Retries:       2,
+	}
+
+	t.Run("should send coalesced updates in a single batch", func(t *testing.T) {
+		// given
+		client := &mocks.BatchClient{}
+		client.On("SetRuntimeStatusConditions", map[string]graphql.RuntimeStatusCondition{
+			firstRuntimeID:  graphql.RuntimeStatusConditionFailed,
+			secondRuntimeID: graphql.RuntimeStatusConditionConnected,
+		}, tenantValue).Return(nil).Once()
+
+		batcher := NewStatusConditionBatcher(client, config)
+		results := make(chan apperrors.AppError, 3)
+
+		// when
+		setStatusCondition(batcher, firstRuntimeID, graphql.RuntimeStatusConditionConnected, results)
+		waitForQueuedCallers(t, batcher, 1)
+		setStatusCondition(batcher, firstRuntimeID, graphql.RuntimeStatusConditionFailed, results)
+		setStatusCondition(batcher, secondRuntimeID, graphql.RuntimeStatusConditionConnected, results)
+		waitForQueuedCallers(t, batcher, 3)
+
+		batcher.flushPending()
+
+		// then
+		for i := 0; i < 3; i++ {
+			assert.Nil(t, <-results)
+		}
+		client.AssertExpectations(t)
+	})
+
+	t.Run("should retry each update when batch fails", func(t *testing.T) {
+		// given
+		client := &mocks.BatchClient{}
+		client.On("SetRuntimeStatusConditions", map[string]graphql.RuntimeStatusCondition{
+			firstRuntimeID:  graphql.RuntimeStatusConditionConnected,
+			secondRuntimeID: graphql.RuntimeStatusConditionConnected,
+		}, tenantValue).Return(apperrors.Internal("batched operations not supported")).Once()
+		client.On("SetRuntimeStatusCondition", firstRuntimeID, graphql.RuntimeStatusConditionConnected, tenantValue).Return(apperrors.Internal("error")).Once()
+		client.On("SetRuntimeStatusCondition", firstRuntimeID, graphql.RuntimeStatusConditionConnected, tenantValue).Return(nil).Once()
+		client.On("SetRuntimeStatusCondition", secondRuntimeID, graphql.RuntimeStatusConditionConnected, tenantValue).Return(apperrors.Internal("error")).Twice()
+
+		batcher := NewStatusConditionBatcher(client, config)
+		firstResult := make(chan apperrors.AppError, 1)
+		secondResult := make(chan apperrors.AppError, 1)
+
+		// when
+		setStatusCondition(batcher, firstRuntimeID, graphql.RuntimeStatusConditionConnected, firstResult)
+		setStatusCondition(batcher, secondRuntimeID, graphql.RuntimeStatusConditionConnected, secondResult)
+		waitForQueuedCallers(t, batcher, 2)
+
+		batcher.flushPending()
+
+		// then
+		assert.Nil(t, <-firstResult)
+		assert.NotNil(t, <-secondResult)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("should update each Runtime separately when batch requests are disabled", func(t *testing.T) {
+		// given
+		client := &mocks.BatchClient{}
+		client.On("SetRuntimeStatusCondition", firstRuntimeID, graphql.RuntimeStatusConditionConnected, tenantValue).Return(nil).Once()
+		client.On("SetRuntimeStatusCondition", secondRuntimeID, graphql.RuntimeStatusConditionFailed, tenantValue).Return(nil).Once()
+
+		batcher := NewStatusConditionBatcher(client, StatusUpdatesConfig{FlushInterval: time.Hour, MaxBatchSize: 10})
+		results := make(chan apperrors.AppError, 2)
+
+		// when
+		setStatusCondition(batcher, firstRuntimeID, graphql.RuntimeStatusConditionConnected, results)
+		setStatusCondition(batcher, secondRuntimeID, graphql.RuntimeStatusConditionFailed, results)
+		waitForQueuedCallers(t, batcher, 2)
+
+		batcher.flushPending()
+
+		// then
+		assert.Nil(t, <-results)
+		assert.Nil(t, <-results)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("should flush when max batch size is reached", func(t *testing.T) {
+		// given
+		client := &mocks.BatchClient{}
+		client.On("SetRuntimeStatusConditions", map[string]graphql.RuntimeStatusCondition{
+			firstRuntimeID:  graphql.RuntimeStatusConditionConnected,
+			secondRuntimeID: graphql.RuntimeStatusConditionConnected,
+		}, tenantValue).Return(nil).Once()
+
+		batcher := NewStatusConditionBatcher(client, StatusUpdatesConfig{FlushInterval: time.Hour, MaxBatchSize: 2, BatchRequests: true})
+		stop := make(chan struct{})
+		defer close(stop)
+		go batcher.Run(stop)
+
+		results := make(chan apperrors.AppError, 2)
+
+		// when
+		setStatusCondition(batcher, firstRuntimeID, graphql.RuntimeStatusConditionConnected, results)
+		setStatusCondition(batcher, secondRuntimeID, graphql.RuntimeStatusConditionConnected, results)
+
+		// then
+		assert.Nil(t, <-results)
+		assert.Nil(t, <-results)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("should update Runtime directly when batcher is stopped", func(t *testing.T) {
+		// given
+		client := &mocks.BatchClient{}
+		client.On("SetRuntimeStatusCondition", firstRuntimeID, graphql.RuntimeStatusConditionFailed, tenantValue).Return(nil).Once()
+
+		batcher := NewStatusConditionBatcher(client, config)
+		stop := make(chan struct{})
+		close(stop)
+		batcher.Run(stop)
+
+		// when
+		err := batcher.SetRuntimeStatusCondition(firstRuntimeID, graphql.RuntimeStatusConditionFailed, tenantValue)
+
+		// then
+		require.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+}
+
+func setStatusCondition(batcher *StatusConditionBatcher, id string, statusCondition graphql.RuntimeStatusCondition, results chan<- apperrors.AppError) {
+	go func() {
+		results <- batcher.SetRuntimeStatusCondition(id, statusCondition, tenantValue)
+	}()
+}
+
+func waitForQueuedCallers(t *testing.T, batcher *StatusConditionBatcher, count int) {
+	require.Eventually(t, func() bool {
+		batcher.mutex.Lock()
+		defer batcher.mutex.Unlock()
+
+		queued := 0
+		for _, update := range batcher.pending {
+			queued += len(update.results)
+		}
+		return queued == count
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,189 @@
+package freeze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// maxRecurringDuration limits the duration of the recurring windows, so that their active occurrence is found quickly
+const maxRecurringDuration = 31 * 24 * time.Hour
+
+// Config lists the maintenance freeze windows during which the disruptive operations, i.e. the upgrades of Kyma and the Shoots, cannot be started
+type Config struct {
+	Windows []Window `json:"windows"`
+}
+
+// Window is either the fixed interval from start to end or the recurring one starting according to the schedule and lasting for the duration.
+// The window applies to the Runtimes matching all its scopes, the empty scope matches all Runtimes. The patterns of the providers and regions
+// use the shell file name pattern syntax, e.g. europe-*. The window applying to all Runtimes pauses the upgrade queues while it is active.
+type Window struct {
+	Name string `json:"name"`
+
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+
+	// Schedule is the cron expression of the window starts, e.g. "0 0 25 3,6,9,12 *", evaluated in the time zone, UTC by default
+	Schedule string `json:"schedule,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	TimeZone string `json:"timeZone,omitempty"`
+
+	Providers []string `json:"providers,omitempty"`
+	Regions   []string `json:"regions,omitempty"`
+	Tenants   []string `json:"tenants,omitempty"`
+
+	schedule schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// ActiveWindow is the occurrence of the window which is in effect
+type ActiveWindow struct {
+	Name      string
+	Start     time.Time
+	End       time.Time
+	Providers []string
+	Regions   []string
+	Tenants   []string
+}
+
+// ParseConfig decodes the windows from JSON and checks if they are valid, unknown fields are rejected to detect typos
+func ParseConfig(data []byte) (Config, error) {
+	config := Config{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return config, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("failed to decode maintenance freeze config: %s", err.Error())
+	}
+
+	names := map[string]bool{}
+	for i := range config.Windows {
+		window := &config.Windows[i]
+		if window.Name == "" || names[window.Name] {
+			return Config{}, fmt.Errorf("invalid maintenance freeze config: window name %q has to be non-empty and unique", window.Name)
+		}
+		names[window.Name] = true
+
+		if err := window.init(); err != nil {
+			return Config{}, fmt.Errorf("invalid maintenance freeze config: window %s: %s", window.Name, err.Error())
+		}
+	}
+
+	return config, nil
+}
+
+func (w *Window) init() error {
+	fixed := w.Start != nil || w.End != nil
+	recurring := w.Schedule != "" || w.Duration != "" || w.TimeZone != ""
+	switch {
+	case fixed && recurring:
+		return fmt.Errorf("either start and end or schedule and duration have to be set")
+	case fixed:
+		if w.Start == nil || w.End == nil || !w.End.After(*w.Start) {
+			return fmt.Errorf("both start and end have to be set and end has to be after start")
+		}
+	case recurring:
+		var err error
+		if w.schedule, err = parseSchedule(w.Schedule); err != nil {
+			return err
+		}
+		if w.duration, err = time.ParseDuration(w.Duration); err != nil || w.duration < time.Minute || w.duration > maxRecurringDuration {
+			return fmt.Errorf("duration %q has to be between 1m and %s", w.Duration, maxRecurringDuration)
+		}
+		if w.location, err = time.LoadLocation(w.TimeZone); err != nil {
+			return fmt.Errorf("unknown time zone %q", w.TimeZone)
+		}
+	default:
+		return fmt.Errorf("either start and end or schedule and duration have to be set")
+	}
+
+	for _, pattern := range append(append([]string{}, w.Providers...), w.Regions...) {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return fmt.Errorf("pattern %q is malformed", pattern)
+		}
+	}
+
+	return nil
+}
+
+// ActiveWindows returns the occurrences of the windows in effect at the given time
+func (c Config) ActiveWindows(at time.Time) []ActiveWindow {
+	active := make([]ActiveWindow, 0)
+	for _, window := range c.Windows {
+		if occurrence, ok := window.occurrence(at); ok {
+			active = append(active, occurrence)
+		}
+	}
+
+	return active
+}
+
+// ActiveWindowFor returns the window in effect at the given time which applies to the Runtime, the one ending last if there are many
+func (c Config) ActiveWindowFor(provider, region, tenant string, at time.Time) (ActiveWindow, bool) {
+	var found ActiveWindow
+	for _, window := range c.Windows {
+		if !window.appliesTo(provider, region, tenant) {
+			continue
+		}
+		if occurrence, ok := window.occurrence(at); ok && occurrence.End.After(found.End) {
+			found = occurrence
+		}
+	}
+
+	return found, !found.End.IsZero()
+}
+
+// Global checks if the window applies to all Runtimes
+func (w ActiveWindow) Global() bool {
+	return len(w.Providers) == 0 && len(w.Regions) == 0 && len(w.Tenants) == 0
+}
+
+func (w Window) occurrence(at time.Time) (ActiveWindow, bool) {
+	active := ActiveWindow{Name: w.Name, Providers: w.Providers, Regions: w.Regions, Tenants: w.Tenants}
+
+	if w.Start != nil {
+		active.Start, active.End = *w.Start, *w.End
+		return active, !at.Before(*w.Start) && at.Before(*w.End)
+	}
+
+	// The latest start of the window which still lasts is searched minute by minute
+	for start := at.In(w.location).Truncate(time.Minute); at.Sub(start) < w.duration; start = start.Add(-time.Minute) {
+		if w.schedule.matches(start) {
+			active.Start, active.End = start, start.Add(w.duration)
+			return active, true
+		}
+	}
+
+	return ActiveWindow{}, false
+}
+
+func (w Window) appliesTo(provider, region, tenant string) bool {
+	return matchesAny(w.Providers, strings.ToLower(provider)) && matchesAny(w.Regions, region) && containsOrEmpty(w.Tenants, tenant)
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+
+	return len(patterns) == 0
+}
+
+func containsOrEmpty(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return len(values) == 0
+}
@@ -0,0 +1,183 @@
+package freeze
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `{
+	"windows": [
+		{"name": "release", "start": "2026-12-20T00:00:00Z", "end": "2027-01-06T00:00:00Z"},
+		{"name": "quarter-end", "schedule": "0 0 25 3,6,9,12 *", "duration": "168h", "providers": ["azure"], "regions": ["westeurope"]},
+		{"name": "weekend", "schedule": "0 18 * * 5", "duration": "60h", "timeZone": "Europe/Berlin", "tenants": ["tenant"]}
+	]
+}`
+
+func TestParseConfig(t *testing.T) {
+	t.Run("should parse windows", func(t *testing.T) {
+		// when
+		config, err := ParseConfig([]byte(testConfig))
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, config.Windows, 3)
+	})
+
+	t.Run("should freeze nothing when config is empty", func(t *testing.T) {
+		// when
+		config, err := ParseConfig([]byte(" \n"))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, config.ActiveWindows(time.Now()))
+	})
+
+	for _, testCase := range []struct {
+		description string
+		config      string
+	}{
+		{description: "unknown field", config: `{"windows": [{"name": "w", "begin": "2026-12-20T00:00:00Z"}]}`},
+		{description: "missing name", config: `{"windows": [{"start": "2026-12-20T00:00:00Z", "end": "2027-01-06T00:00:00Z"}]}`},
+		{description: "duplicated name", config: `{"windows": [{"name": "w", "schedule": "0 0 * * *", "duration": "1h"}, {"name": "w", "schedule": "0 1 * * *", "duration": "1h"}]}`},
+		{description: "end before start", config: `{"windows": [{"name": "w", "start": "2027-01-06T00:00:00Z", "end": "2026-12-20T00:00:00Z"}]}`},
+		{description: "missing end", config: `{"windows": [{"name": "w", "start": "2026-12-20T00:00:00Z"}]}`},
+		{description: "both interval and schedule", config: `{"windows": [{"name": "w", "start": "2026-12-20T00:00:00Z", "end": "2027-01-06T00:00:00Z", "schedule": "0 0 * * *", "duration": "1h"}]}`},
+		{description: "non-RFC3339 start", config: `{"windows": [{"name": "w", "start": "2026-12-20", "end": "2027-01-06T00:00:00Z"}]}`},
+		{description: "schedule with too few fields", config: `{"windows": [{"name": "w", "schedule": "0 0 * *", "duration": "1h"}]}`},
+		{description: "schedule out of range", config: `{"windows": [{"name": "w", "schedule": "0 24 * * *", "duration": "1h"}]}`},
+		{description: "missing duration", config: `{"windows": [{"name": "w", "schedule": "0 0 * * *"}]}`},
+		{description: "too long duration", config: `{"windows": [{"name": "w", "schedule": "0 0 1 * *", "duration": "1000h"}]}`},
+		{description: "unknown time zone", config: `{"windows": [{"name": "w", "schedule": "0 0 * * *", "duration": "1h", "timeZone": "Mars/Olympus"}]}`},
+		{description: "malformed region pattern", config: `{"windows": [{"name": "w", "schedule": "0 0 * * *", "duration": "1h", "regions": ["[europe"]}]}`},
+	} {
+		t.Run("should reject config with "+testCase.description, func(t *testing.T) {
+			// when
+			_, err := ParseConfig([]byte(testCase.config))
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConfig_ActiveWindowFor(t *testing.T) {
+	config, err := ParseConfig([]byte(testConfig))
+	require.NoError(t, err)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		description    string
+		provider       string
+		region         string
+		tenant         string
+		at             time.Time
+		expectedWindow string
+		expectedEnd    time.Time
+	}{
+		{
+			description:    "fixed window applying to all Runtimes",
+			provider:       "gcp",
+			region:         "europe-west3",
+			tenant:         "other",
+			at:             time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC),
+			expectedWindow: "release",
+			expectedEnd:    time.Date(2027, 1, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description:    "recurring window of the provider and region",
+			provider:       "Azure",
+			region:         "westeurope",
+			tenant:         "other",
+			at:             time.Date(2026, 9, 30, 23, 59, 0, 0, time.UTC),
+			expectedWindow: "quarter-end",
+			expectedEnd:    time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description:    "recurring window of the tenant in the time zone",
+			provider:       "gcp",
+			region:         "europe-west3",
+			tenant:         "tenant",
+			at:             time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC),
+			expectedWindow: "weekend",
+			expectedEnd:    time.Date(2026, 10, 19, 6, 0, 0, 0, berlin),
+		},
+		{
+			description: "recurring window of other region",
+			provider:    "azure",
+			region:      "northeurope",
+			tenant:      "other",
+			at:          time.Date(2026, 9, 30, 23, 59, 0, 0, time.UTC),
+		},
+		{
+			description: "recurring window which has ended",
+			provider:    "azure",
+			region:      "westeurope",
+			tenant:      "other",
+			at:          time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "recurring window before its start",
+			provider:    "gcp",
+			region:      "europe-west3",
+			tenant:      "tenant",
+			at:          time.Date(2026, 10, 16, 15, 59, 0, 0, time.UTC),
+		},
+	} {
+		t.Run("should match "+testCase.description, func(t *testing.T) {
+			// when
+			window, frozen := config.ActiveWindowFor(testCase.provider, testCase.region, testCase.tenant, testCase.at)
+
+			// then
+			assert.Equal(t, testCase.expectedWindow != "", frozen)
+			assert.Equal(t, testCase.expectedWindow, window.Name)
+			if frozen {
+				assert.True(t, testCase.expectedEnd.Equal(window.End), "expected end %s, got %s", testCase.expectedEnd, window.End)
+			}
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, testCase := range []struct {
+		expression string
+		matching   []time.Time
+		other      []time.Time
+	}{
+		{
+			expression: "*/15 8-10 * * *",
+			matching:   []time.Time{time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC), time.Date(2026, 10, 15, 10, 45, 0, 0, time.UTC)},
+			other:      []time.Time{time.Date(2026, 10, 15, 8, 10, 0, 0, time.UTC), time.Date(2026, 10, 15, 11, 0, 0, 0, time.UTC)},
+		},
+		{
+			// Sunday written as 7
+			expression: "0 0 * * 7",
+			matching:   []time.Time{time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+			other:      []time.Time{time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			// The day matches either the day of month or the day of week
+			expression: "0 0 1 * 1",
+			matching:   []time.Time{time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+			other:      []time.Time{time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		},
+	} {
+		t.Run("should match times of "+testCase.expression, func(t *testing.T) {
+			// when
+			s, err := parseSchedule(testCase.expression)
+
+			// then
+			require.NoError(t, err)
+			for _, at := range testCase.matching {
+				assert.True(t, s.matches(at), "expected %s to match", at)
+			}
+			for _, at := range testCase.other {
+				assert.False(t, s.matches(at), "expected %s not to match", at)
+			}
+		})
+	}
+}
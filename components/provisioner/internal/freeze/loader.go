@@ -0,0 +1,185 @@
+package freeze
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var (
+	activeGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "maintenance_freeze_active",
+		Help:      "Indicates whether the maintenance freeze window applying to all Runtimes is active and the upgrade queues are paused",
+	})
+	activeWindowsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "maintenance_freeze_active_windows",
+		Help:      "Number of the active maintenance freeze windows, including the ones scoped to providers, regions or tenants",
+	})
+)
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{activeGauge, activeWindowsGauge}
+}
+
+// PausableQueue is the operation queue paused during the maintenance freeze
+type PausableQueue interface {
+	SetPaused(paused bool)
+	IsPaused() bool
+}
+
+// Loader keeps the maintenance freeze windows read from the file up to date and pauses the upgrade queues while the window
+// applying to all Runtimes is active. The file is usually mounted from the ConfigMap, so it is read again periodically
+// and the previous windows are kept if the new ones are invalid.
+type Loader struct {
+	configPath string
+	queues     []PausableQueue
+
+	mutex   sync.RWMutex
+	config  Config
+	content []byte
+
+	// pausedQueues are the queues paused by the freeze, the queues paused before are not resumed when it ends
+	pausedQueues []PausableQueue
+
+	now func() time.Time
+	log logrus.FieldLogger
+}
+
+// NewLoader reads the windows from the file failing if they are invalid, the empty path means that nothing is frozen
+func NewLoader(configPath string, queues []PausableQueue, log logrus.FieldLogger) (*Loader, error) {
+	loader := &Loader{
+		configPath: configPath,
+		queues:     queues,
+		now:        time.Now,
+		log:        log,
+	}
+
+	if configPath != "" {
+		content, err := ioutil.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read maintenance freeze config: %s", err.Error())
+		}
+		config, err := ParseConfig(content)
+		if err != nil {
+			return nil, err
+		}
+		loader.config, loader.content = config, content
+		log.Infof("Maintenance freeze config with %d windows loaded", len(config.Windows))
+	}
+
+	return loader, nil
+}
+
+// Run reloads the windows from the file and pauses or resumes the upgrade queues with the interval until stopped
+func (l *Loader) Run(interval time.Duration, stop <-chan struct{}) {
+	if l.configPath == "" {
+		return
+	}
+
+	wait.Until(func() {
+		l.Reload()
+		l.Sync()
+	}, interval, stop)
+}
+
+// Reload reads the windows from the file if it has changed, the invalid config is logged and ignored
+func (l *Loader) Reload() {
+	content, err := ioutil.ReadFile(l.configPath)
+	if err != nil {
+		l.log.Errorf("Failed to read maintenance freeze config, keeping the previous one: %s", err.Error())
+		return
+	}
+
+	l.mutex.RLock()
+	unchanged := bytes.Equal(content, l.content)
+	l.mutex.RUnlock()
+	if unchanged {
+		return
+	}
+
+	config, err := ParseConfig(content)
+	if err != nil {
+		l.log.Errorf("Maintenance freeze config changed but it is invalid, keeping the previous one: %s", err.Error())
+		return
+	}
+
+	l.mutex.Lock()
+	l.config, l.content = config, content
+	l.mutex.Unlock()
+
+	l.log.Infof("Maintenance freeze config with %d windows reloaded", len(config.Windows))
+}
+
+// Sync pauses the upgrade queues when the window applying to all Runtimes starts and resumes them when it ends
+func (l *Loader) Sync() {
+	active := l.ActiveWindows()
+
+	var global *ActiveWindow
+	for i := range active {
+		if active[i].Global() {
+			global = &active[i]
+			break
+		}
+	}
+
+	activeWindowsGauge.Set(float64(len(active)))
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	switch {
+	case global != nil && l.pausedQueues == nil:
+		l.pausedQueues = make([]PausableQueue, 0, len(l.queues))
+		for _, queue := range l.queues {
+			if !queue.IsPaused() {
+				queue.SetPaused(true)
+				l.pausedQueues = append(l.pausedQueues, queue)
+			}
+		}
+		activeGauge.Set(1)
+		l.log.Infof("Maintenance freeze window %s started, upgrade queues are paused until %s", global.Name, global.End.Format(time.RFC3339))
+	case global == nil && l.pausedQueues != nil:
+		for _, queue := range l.pausedQueues {
+			queue.SetPaused(false)
+		}
+		l.pausedQueues = nil
+		activeGauge.Set(0)
+		l.log.Infof("Maintenance freeze ended, upgrade queues are resumed")
+	}
+}
+
+// Frozen checks if the window applying to all Runtimes has paused the upgrade queues
+func (l *Loader) Frozen() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.pausedQueues != nil
+}
+
+// ActiveWindows returns the windows in effect now
+func (l *Loader) ActiveWindows() []ActiveWindow {
+	return l.Config().ActiveWindows(l.now())
+}
+
+// ActiveWindowFor returns the window in effect now which applies to the Runtime of the provider, region and tenant
+func (l *Loader) ActiveWindowFor(provider, region, tenant string) (ActiveWindow, bool) {
+	return l.Config().ActiveWindowFor(provider, region, tenant, l.now())
+}
+
+// Config returns the current windows
+func (l *Loader) Config() Config {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.config
+}
@@ -0,0 +1,129 @@
+package freeze
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQueue struct {
+	paused bool
+}
+
+func (q *fakeQueue) SetPaused(paused bool) {
+	q.paused = paused
+}
+
+func (q *fakeQueue) IsPaused() bool {
+	return q.paused
+}
+
+func TestLoader(t *testing.T) {
+	t.Run("should freeze nothing when config path is empty", func(t *testing.T) {
+		// when
+		loader, err := NewLoader("", nil, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		_, frozen := loader.ActiveWindowFor("gcp", "europe-west3", "tenant")
+		assert.False(t, frozen)
+		assert.Empty(t, loader.ActiveWindows())
+	})
+
+	t.Run("should fail when config is invalid at startup", func(t *testing.T) {
+		// given
+		configPath := writeConfig(t, t.TempDir(), `{"windows": [{"name": "w", "schedule": "0 0 * *", "duration": "1h"}]}`)
+
+		// when
+		_, err := NewLoader(configPath, nil, logrus.New())
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should pause upgrade queues during global window and resume only the ones it paused", func(t *testing.T) {
+		// given
+		configPath := writeConfig(t, t.TempDir(), testConfig)
+		upgradeQueue, shootUpgradeQueue := &fakeQueue{}, &fakeQueue{paused: true}
+
+		loader, err := NewLoader(configPath, []PausableQueue{upgradeQueue, shootUpgradeQueue}, logrus.New())
+		require.NoError(t, err)
+		loader.now = func() time.Time { return time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC) }
+
+		// when
+		loader.Sync()
+
+		// then
+		assert.True(t, loader.Frozen())
+		assert.True(t, upgradeQueue.IsPaused())
+		assert.True(t, shootUpgradeQueue.IsPaused())
+		assert.Equal(t, float64(1), testutil.ToFloat64(activeGauge))
+		assert.Equal(t, float64(1), testutil.ToFloat64(activeWindowsGauge))
+
+		// when
+		loader.now = func() time.Time { return time.Date(2027, 1, 6, 0, 0, 0, 0, time.UTC) }
+		loader.Sync()
+
+		// then
+		assert.False(t, loader.Frozen())
+		assert.False(t, upgradeQueue.IsPaused())
+		assert.True(t, shootUpgradeQueue.IsPaused())
+		assert.Zero(t, testutil.ToFloat64(activeGauge))
+	})
+
+	t.Run("should not pause upgrade queues during scoped window", func(t *testing.T) {
+		// given
+		configPath := writeConfig(t, t.TempDir(), testConfig)
+		upgradeQueue := &fakeQueue{}
+
+		loader, err := NewLoader(configPath, []PausableQueue{upgradeQueue}, logrus.New())
+		require.NoError(t, err)
+		loader.now = func() time.Time { return time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC) }
+
+		// when
+		loader.Sync()
+
+		// then
+		assert.False(t, loader.Frozen())
+		assert.False(t, upgradeQueue.IsPaused())
+		assert.Equal(t, float64(1), testutil.ToFloat64(activeWindowsGauge))
+	})
+
+	t.Run("should reload changed config and keep previous one when it is invalid", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath := writeConfig(t, dir, testConfig)
+
+		loader, err := NewLoader(configPath, nil, logrus.New())
+		require.NoError(t, err)
+		loader.now = func() time.Time { return time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC) }
+		require.Len(t, loader.ActiveWindows(), 1)
+
+		// when
+		writeConfig(t, dir, `{"windows": []}`)
+		loader.Reload()
+
+		// then
+		assert.Empty(t, loader.ActiveWindows())
+
+		// when
+		writeConfig(t, dir, `{"windows": [{"name": "w"}]}`)
+		loader.Reload()
+
+		// then
+		assert.Empty(t, loader.Config().Windows)
+	})
+}
+
+func writeConfig(t *testing.T, dir, config string) string {
+	configPath := filepath.Join(dir, "freeze.json")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(config), 0644))
+
+	return configPath
+}
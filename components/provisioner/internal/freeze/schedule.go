@@ -0,0 +1,123 @@
+package freeze
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule is the parsed cron expression with the minute, hour, day of month, month and day of week fields.
+// The fields accept *, values, ranges, lists and steps, e.g. */15, 1-5 or 3,6,9,12. As in cron, the day matches
+// either the day of month or the day of week if both of them are restricted.
+type schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+func parseSchedule(expression string) (schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return schedule{}, fmt.Errorf("schedule %q has to consist of 5 fields: minute, hour, day of month, month and day of week", expression)
+	}
+
+	var s schedule
+	var err error
+	for _, field := range []struct {
+		value    string
+		min, max int
+		bits     *uint64
+	}{
+		{value: fields[0], min: 0, max: 59, bits: &s.minutes},
+		{value: fields[1], min: 0, max: 23, bits: &s.hours},
+		{value: fields[2], min: 1, max: 31, bits: &s.daysOfMonth},
+		{value: fields[3], min: 1, max: 12, bits: &s.months},
+		// Sunday is either 0 or 7
+		{value: fields[4], min: 0, max: 7, bits: &s.daysOfWeek},
+	} {
+		*field.bits, err = parseField(field.value, field.min, field.max)
+		if err != nil {
+			return schedule{}, fmt.Errorf("schedule %q is malformed: %s", expression, err.Error())
+		}
+	}
+
+	if s.daysOfWeek&(1<<7) != 0 {
+		s.daysOfWeek |= 1
+	}
+	s.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	s.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+
+	return s, nil
+}
+
+func parseField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		valueRange, step := part, 1
+		if slash := strings.Index(part, "/"); slash >= 0 {
+			parsedStep, err := strconv.Atoi(part[slash+1:])
+			if err != nil || parsedStep < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			valueRange, step = part[:slash], parsedStep
+		}
+
+		first, last := min, max
+		if valueRange != "*" {
+			bounds := strings.SplitN(valueRange, "-", 2)
+			var err error
+			first, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			switch {
+			case len(bounds) == 2:
+				last, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			case step == 1:
+				last = first
+			}
+		}
+		if first < min || last > max || first > last {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+
+		for value := first; value <= last; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+// matches checks if the window starts at the minute of the given time
+func (s schedule) matches(t time.Time) bool {
+	if !hasBit(s.minutes, t.Minute()) || !hasBit(s.hours, t.Hour()) || !hasBit(s.months, int(t.Month())) {
+		return false
+	}
+
+	dayOfMonth := hasBit(s.daysOfMonth, t.Day())
+	dayOfWeek := hasBit(s.daysOfWeek, int(t.Weekday()))
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dayOfWeek
+	case s.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+func hasBit(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,87 @@
+package gardener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// AdminKubeconfig is the short-lived kubeconfig issued by Gardener through the adminkubeconfig subresource of the Shoot
+type AdminKubeconfig struct {
+	Kubeconfig []byte
+	ExpiresAt  time.Time
+}
+
+type AdminKubeconfigClient interface {
+	Create(ctx context.Context, project, shootName string, expiration time.Duration) (AdminKubeconfig, error)
+}
+
+// adminKubeconfigRequest mirrors the AdminKubeconfigRequest of the authentication.gardener.cloud/v1alpha1 API,
+// which is not available in the Gardener client used by the Provisioner
+type adminKubeconfigRequest struct {
+	v1.TypeMeta `json:",inline"`
+	Spec        adminKubeconfigRequestSpec   `json:"spec"`
+	Status      adminKubeconfigRequestStatus `json:"status,omitempty"`
+}
+
+type adminKubeconfigRequestSpec struct {
+	ExpirationSeconds int64 `json:"expirationSeconds,omitempty"`
+}
+
+type adminKubeconfigRequestStatus struct {
+	Kubeconfig          []byte  `json:"kubeconfig,omitempty"`
+	ExpirationTimestamp v1.Time `json:"expirationTimestamp,omitempty"`
+}
+
+// AdminKubeconfigClients request the kubeconfigs of the Shoots in the namespaces of the Gardener projects
+type AdminKubeconfigClients struct {
+	projects Projects
+	client   rest.Interface
+}
+
+func NewAdminKubeconfigClients(projects Projects, client rest.Interface) AdminKubeconfigClients {
+	return AdminKubeconfigClients{
+		projects: projects,
+		client:   client,
+	}
+}
+
+func (c AdminKubeconfigClients) Create(ctx context.Context, project, shootName string, expiration time.Duration) (AdminKubeconfig, error) {
+	request := adminKubeconfigRequest{
+		TypeMeta: v1.TypeMeta{APIVersion: "authentication.gardener.cloud/v1alpha1", Kind: "AdminKubeconfigRequest"},
+		Spec:     adminKubeconfigRequestSpec{ExpirationSeconds: int64(expiration.Seconds())},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return AdminKubeconfig{}, fmt.Errorf("failed to marshal admin kubeconfig request: %s", err.Error())
+	}
+
+	response, err := c.client.Post().
+		Namespace(c.projects.Namespace(project)).
+		Resource("shoots").
+		Name(shootName).
+		SubResource("adminkubeconfig").
+		Body(body).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return AdminKubeconfig{}, err
+	}
+
+	var result adminKubeconfigRequest
+	if err := json.Unmarshal(response, &result); err != nil {
+		return AdminKubeconfig{}, fmt.Errorf("failed to unmarshal admin kubeconfig response: %s", err.Error())
+	}
+	if len(result.Status.Kubeconfig) == 0 {
+		return AdminKubeconfig{}, fmt.Errorf("admin kubeconfig response does not contain kubeconfig")
+	}
+
+	return AdminKubeconfig{
+		Kubeconfig: result.Status.Kubeconfig,
+		ExpiresAt:  result.Status.ExpirationTimestamp.Time,
+	}, nil
+}
@@ -0,0 +1,237 @@
+package gardener
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+//go:generate mockery -name=CloudProfileClient
+type CloudProfileClient interface {
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.CloudProfile, error)
+}
+
+type cachedCloudProfile struct {
+	spec      v1beta1.CloudProfileSpec
+	fetchedAt time.Time
+}
+
+// CloudProfileVersions provides the Kubernetes and machine image versions offered by the Gardener CloudProfiles.
+// CloudProfiles are cached for the given TTL.
+type CloudProfileVersions struct {
+	client CloudProfileClient
+	ttl    time.Duration
+
+	mutex sync.Mutex
+	cache map[string]cachedCloudProfile
+}
+
+func NewCloudProfileVersions(client CloudProfileClient, ttl time.Duration) *CloudProfileVersions {
+	return &CloudProfileVersions{
+		client: client,
+		ttl:    ttl,
+		cache:  make(map[string]cachedCloudProfile),
+	}
+}
+
+// Resolve returns the latest supported patch version of the minor version, versions with the patch number are returned unchanged
+func (c *CloudProfileVersions) Resolve(cloudProfileName, kubernetesVersion string) (string, apperrors.AppError) {
+	if !model.IsKubernetesMinorVersion(kubernetesVersion) {
+		return kubernetesVersion, nil
+	}
+
+	return c.LatestPatchFor(cloudProfileName, kubernetesVersion)
+}
+
+// LatestPatchFor returns the latest supported patch version of the minor version, e.g. 1.24
+func (c *CloudProfileVersions) LatestPatchFor(cloudProfileName, minor string) (string, apperrors.AppError) {
+	requested, err := version.ParseGeneric(minor)
+	if err != nil {
+		return "", apperrors.BadRequest("invalid kubernetes version %s: %s", minor, err.Error())
+	}
+
+	spec, appErr := c.cloudProfile(cloudProfileName)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	latest := latestSupportedVersion(spec.Kubernetes.Versions, func(candidate *version.Version) bool {
+		return candidate.Major() == requested.Major() && candidate.Minor() == requested.Minor()
+	})
+	if latest == "" {
+		return "", apperrors.BadRequest("kubernetes version %s is not supported by the %s cloud profile", minor, cloudProfileName)
+	}
+
+	return latest, nil
+}
+
+// LatestMachineImageVersion returns the latest supported version of the machine image
+func (c *CloudProfileVersions) LatestMachineImageVersion(cloudProfileName, imageName string) (string, apperrors.AppError) {
+	spec, err := c.cloudProfile(cloudProfileName)
+	if err != nil {
+		return "", err
+	}
+
+	versions, found := machineImageVersions(spec, imageName)
+	if !found {
+		return "", apperrors.BadRequest("machine image %s is not offered by the %s cloud profile", imageName, cloudProfileName)
+	}
+
+	latest := latestSupportedVersion(versions, nil)
+	if latest == "" {
+		return "", apperrors.BadRequest("machine image %s has no supported version in the %s cloud profile", imageName, cloudProfileName)
+	}
+
+	return latest, nil
+}
+
+// DefaultMachineImage returns the machine image which Gardener applies to the workers not specifying it, that is the first
+// machine image of the cloud profile with a supported version, and its latest supported version
+func (c *CloudProfileVersions) DefaultMachineImage(cloudProfileName string) (string, string, apperrors.AppError) {
+	spec, err := c.cloudProfile(cloudProfileName)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, image := range spec.MachineImages {
+		versions, _ := machineImageVersions(spec, image.Name)
+		if latest := latestSupportedVersion(versions, nil); latest != "" {
+			return image.Name, latest, nil
+		}
+	}
+
+	return "", "", apperrors.BadRequest("the %s cloud profile has no machine image with a supported version", cloudProfileName)
+}
+
+// ValidateKubernetesVersion checks if the Kubernetes version is offered by the cloud profile and is not expired.
+// Versions without the patch number are valid if the minor version has a supported patch version.
+func (c *CloudProfileVersions) ValidateKubernetesVersion(cloudProfileName, kubernetesVersion string) apperrors.AppError {
+	if model.IsKubernetesMinorVersion(kubernetesVersion) {
+		_, err := c.LatestPatchFor(cloudProfileName, kubernetesVersion)
+		return err
+	}
+
+	spec, err := c.cloudProfile(cloudProfileName)
+	if err != nil {
+		return err
+	}
+
+	return validateOfferedVersion(spec.Kubernetes.Versions, kubernetesVersion, "kubernetes version", cloudProfileName)
+}
+
+// ValidateMachineImageVersion checks if the version of the machine image is offered by the cloud profile and is not expired
+func (c *CloudProfileVersions) ValidateMachineImageVersion(cloudProfileName, imageName, imageVersion string) apperrors.AppError {
+	spec, err := c.cloudProfile(cloudProfileName)
+	if err != nil {
+		return err
+	}
+
+	versions, found := machineImageVersions(spec, imageName)
+	if !found {
+		return apperrors.BadRequest("machine image %s is not offered by the %s cloud profile", imageName, cloudProfileName)
+	}
+
+	return validateOfferedVersion(versions, imageVersion, "version of the "+imageName+" machine image", cloudProfileName)
+}
+
+func (c *CloudProfileVersions) cloudProfile(cloudProfileName string) (v1beta1.CloudProfileSpec, apperrors.AppError) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached, found := c.cache[cloudProfileName]
+	if found && time.Since(cached.fetchedAt) < c.ttl {
+		return cached.spec, nil
+	}
+
+	cloudProfile, err := c.client.Get(context.Background(), cloudProfileName, v1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return v1beta1.CloudProfileSpec{}, apperrors.BadRequest("cloud profile %s not found", cloudProfileName)
+		}
+		return v1beta1.CloudProfileSpec{}, apperrors.Internal("failed to get cloud profile %s: %s", cloudProfileName, err.Error())
+	}
+
+	c.cache[cloudProfileName] = cachedCloudProfile{
+		spec:      cloudProfile.Spec,
+		fetchedAt: time.Now(),
+	}
+
+	return cloudProfile.Spec, nil
+}
+
+func machineImageVersions(spec v1beta1.CloudProfileSpec, imageName string) ([]v1beta1.ExpirableVersion, bool) {
+	for _, image := range spec.MachineImages {
+		if image.Name != imageName {
+			continue
+		}
+
+		versions := make([]v1beta1.ExpirableVersion, 0, len(image.Versions))
+		for _, imageVersion := range image.Versions {
+			versions = append(versions, imageVersion.ExpirableVersion)
+		}
+		return versions, true
+	}
+
+	return nil, false
+}
+
+// validateOfferedVersion rejects versions missing in the cloud profile and expired versions, naming the newest allowed version
+func validateOfferedVersion(versions []v1beta1.ExpirableVersion, requested, kind, cloudProfileName string) apperrors.AppError {
+	for _, expirableVersion := range versions {
+		if expirableVersion.Version != requested {
+			continue
+		}
+		if !isExpired(expirableVersion) {
+			return nil
+		}
+
+		latest := latestSupportedVersion(versions, nil)
+		if latest == "" {
+			return apperrors.BadRequest("%s %s is expired in the %s cloud profile", kind, requested, cloudProfileName)
+		}
+		return apperrors.BadRequest("%s %s is expired in the %s cloud profile, the newest allowed version is %s", kind, requested, cloudProfileName, latest)
+	}
+
+	return apperrors.BadRequest("%s %s is not offered by the %s cloud profile", kind, requested, cloudProfileName)
+}
+
+// latestSupportedVersion returns the latest supported version accepted by the filter or empty string if there is none
+func latestSupportedVersion(versions []v1beta1.ExpirableVersion, filter func(candidate *version.Version) bool) string {
+	var latest *version.Version
+	latestVersion := ""
+	for _, expirableVersion := range versions {
+		if !isSupported(expirableVersion) {
+			continue
+		}
+		candidate, err := version.ParseGeneric(expirableVersion.Version)
+		if err != nil || (filter != nil && !filter(candidate)) {
+			continue
+		}
+		if latest == nil || latest.LessThan(candidate) {
+			latest = candidate
+			latestVersion = expirableVersion.Version
+		}
+	}
+
+	return latestVersion
+}
+
+// isSupported excludes preview versions, which are not recommended for Shoots, and expired versions
+func isSupported(expirableVersion v1beta1.ExpirableVersion) bool {
+	if expirableVersion.Classification != nil && *expirableVersion.Classification == v1beta1.ClassificationPreview {
+		return false
+	}
+
+	return !isExpired(expirableVersion)
+}
+
+func isExpired(expirableVersion v1beta1.ExpirableVersion) bool {
+	return expirableVersion.ExpirationDate != nil && !expirableVersion.ExpirationDate.Time.After(time.Now())
+}
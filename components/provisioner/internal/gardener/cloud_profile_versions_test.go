@@ -0,0 +1,295 @@
+package gardener
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/gardener/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCloudProfileVersions_Resolve(t *testing.T) {
+	cloudProfile := fixCloudProfile()
+
+	t.Run("should resolve minor version to the latest supported patch version", func(t *testing.T) {
+		// given
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(cloudProfile, nil)
+
+		resolver := NewCloudProfileVersions(client, time.Minute)
+
+		// when
+		resolved, err := resolver.Resolve("gcp", "1.16")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1.16.15", resolved)
+	})
+
+	t.Run("should return version with patch number unchanged", func(t *testing.T) {
+		// given
+		client := &mocks.CloudProfileClient{}
+
+		resolver := NewCloudProfileVersions(client, time.Minute)
+
+		// when
+		resolved, err := resolver.Resolve("gcp", "1.16.9")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1.16.9", resolved)
+		client.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should cache cloud profile", func(t *testing.T) {
+		// given
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(cloudProfile, nil)
+
+		resolver := NewCloudProfileVersions(client, time.Minute)
+
+		// when
+		for i := 0; i < 3; i++ {
+			_, err := resolver.Resolve("gcp", "1.16")
+			require.NoError(t, err)
+		}
+
+		// then
+		client.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("should fetch cloud profile again when cache expired", func(t *testing.T) {
+		// given
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(cloudProfile, nil)
+
+		resolver := NewCloudProfileVersions(client, 0)
+
+		// when
+		for i := 0; i < 2; i++ {
+			_, err := resolver.Resolve("gcp", "1.16")
+			require.NoError(t, err)
+		}
+
+		// then
+		client.AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("should return error when minor version has no supported patch version", func(t *testing.T) {
+		// given
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(cloudProfile, nil)
+
+		resolver := NewCloudProfileVersions(client, time.Minute)
+
+		// when
+		_, err := resolver.Resolve("gcp", "1.17")
+
+		// then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("should return bad request when cloud profile does not exist", func(t *testing.T) {
+		// given
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "unknown", v1.GetOptions{}).
+			Return(nil, k8sErrors.NewNotFound(schema.GroupResource{}, "unknown"))
+
+		resolver := NewCloudProfileVersions(client, time.Minute)
+
+		// when
+		_, err := resolver.Resolve("unknown", "1.16")
+
+		// then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("should return internal error when failed to get cloud profile", func(t *testing.T) {
+		// given
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(nil, errors.New("error"))
+
+		resolver := NewCloudProfileVersions(client, time.Minute)
+
+		// when
+		_, err := resolver.Resolve("gcp", "1.16")
+
+		// then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+	})
+}
+
+func TestCloudProfileVersions_LatestMachineImageVersion(t *testing.T) {
+	client := &mocks.CloudProfileClient{}
+	client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(fixCloudProfile(), nil)
+
+	versions := NewCloudProfileVersions(client, time.Minute)
+
+	t.Run("should return the latest supported version of the machine image", func(t *testing.T) {
+		// when
+		latest, err := versions.LatestMachineImageVersion("gcp", "gardenlinux")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "576.12.0", latest)
+	})
+
+	t.Run("should return error when machine image is not offered", func(t *testing.T) {
+		// when
+		_, err := versions.LatestMachineImageVersion("gcp", "ubuntu")
+
+		// then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+}
+
+func TestCloudProfileVersions_DefaultMachineImage(t *testing.T) {
+	t.Run("should return the first machine image with a supported version", func(t *testing.T) {
+		// given
+		cloudProfile := fixCloudProfile()
+		expired := v1.NewTime(time.Now().Add(-time.Hour))
+		cloudProfile.Spec.MachineImages = append([]v1beta1.MachineImage{{
+			Name: "suse-chost",
+			Versions: []v1beta1.MachineImageVersion{
+				{ExpirableVersion: v1beta1.ExpirableVersion{Version: "15.1.20200505", ExpirationDate: &expired}},
+			},
+		}}, cloudProfile.Spec.MachineImages...)
+
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(cloudProfile, nil)
+
+		// when
+		image, imageVersion, err := NewCloudProfileVersions(client, time.Minute).DefaultMachineImage("gcp")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gardenlinux", image)
+		assert.Equal(t, "576.12.0", imageVersion)
+	})
+
+	t.Run("should return error when no machine image has a supported version", func(t *testing.T) {
+		// given
+		cloudProfile := fixCloudProfile()
+		cloudProfile.Spec.MachineImages = nil
+
+		client := &mocks.CloudProfileClient{}
+		client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(cloudProfile, nil)
+
+		// when
+		_, _, err := NewCloudProfileVersions(client, time.Minute).DefaultMachineImage("gcp")
+
+		// then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+}
+
+func TestCloudProfileVersions_ValidateKubernetesVersion(t *testing.T) {
+	client := &mocks.CloudProfileClient{}
+	client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(fixCloudProfile(), nil)
+
+	versions := NewCloudProfileVersions(client, time.Minute)
+
+	for _, testCase := range []struct {
+		description       string
+		kubernetesVersion string
+		errorMessage      string
+	}{
+		{description: "supported version", kubernetesVersion: "1.16.9"},
+		{description: "preview version", kubernetesVersion: "1.17.2"},
+		{description: "minor version with supported patch version", kubernetesVersion: "1.16"},
+		{description: "minor version without supported patch version", kubernetesVersion: "1.17", errorMessage: "kubernetes version 1.17 is not supported by the gcp cloud profile"},
+		{description: "version not offered", kubernetesVersion: "1.16.5", errorMessage: "kubernetes version 1.16.5 is not offered by the gcp cloud profile"},
+		{description: "expired version", kubernetesVersion: "1.16.4", errorMessage: "kubernetes version 1.16.4 is expired in the gcp cloud profile, the newest allowed version is 1.16.15"},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := versions.ValidateKubernetesVersion("gcp", testCase.kubernetesVersion)
+
+			// then
+			if testCase.errorMessage == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			assert.Equal(t, testCase.errorMessage, err.Error())
+		})
+	}
+}
+
+func TestCloudProfileVersions_ValidateMachineImageVersion(t *testing.T) {
+	client := &mocks.CloudProfileClient{}
+	client.On("Get", mock.Anything, "gcp", v1.GetOptions{}).Return(fixCloudProfile(), nil)
+
+	versions := NewCloudProfileVersions(client, time.Minute)
+
+	for _, testCase := range []struct {
+		description  string
+		imageName    string
+		imageVersion string
+		errorMessage string
+	}{
+		{description: "supported version", imageName: "gardenlinux", imageVersion: "576.12.0"},
+		{description: "machine image not offered", imageName: "ubuntu", imageVersion: "18.4.0", errorMessage: "machine image ubuntu is not offered by the gcp cloud profile"},
+		{description: "version not offered", imageName: "gardenlinux", imageVersion: "576.3.0", errorMessage: "version of the gardenlinux machine image 576.3.0 is not offered by the gcp cloud profile"},
+		{description: "expired version", imageName: "gardenlinux", imageVersion: "318.8.0", errorMessage: "version of the gardenlinux machine image 318.8.0 is expired in the gcp cloud profile, the newest allowed version is 576.12.0"},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := versions.ValidateMachineImageVersion("gcp", testCase.imageName, testCase.imageVersion)
+
+			// then
+			if testCase.errorMessage == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+			assert.Equal(t, testCase.errorMessage, err.Error())
+		})
+	}
+}
+
+func fixCloudProfile() *v1beta1.CloudProfile {
+	preview := v1beta1.ClassificationPreview
+	expired := v1.NewTime(time.Now().Add(-time.Hour))
+
+	return &v1beta1.CloudProfile{
+		ObjectMeta: v1.ObjectMeta{Name: "gcp"},
+		Spec: v1beta1.CloudProfileSpec{
+			Kubernetes: v1beta1.KubernetesSettings{
+				Versions: []v1beta1.ExpirableVersion{
+					{Version: "1.16.4", ExpirationDate: &expired},
+					{Version: "1.16.9"},
+					{Version: "1.16.15"},
+					{Version: "1.16.16", Classification: &preview},
+					{Version: "1.17.2", Classification: &preview},
+				},
+			},
+			MachineImages: []v1beta1.MachineImage{
+				{
+					Name: "gardenlinux",
+					Versions: []v1beta1.MachineImageVersion{
+						{ExpirableVersion: v1beta1.ExpirableVersion{Version: "318.8.0", ExpirationDate: &expired}},
+						{ExpirableVersion: v1beta1.ExpirableVersion{Version: "576.12.0"}},
+						{ExpirableVersion: v1beta1.ExpirableVersion{Version: "576.13.0", Classification: &preview}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -3,23 +3,100 @@ package gardener
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	log "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	v12 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
+const (
+	// KubeconfigModeAdmin requests short-lived kubeconfigs through the adminkubeconfig subresource of the Shoot,
+	// the static kubeconfig is used if the subresource is not available in the Gardener landscape
+	KubeconfigModeAdmin = "admin"
+	// KubeconfigModeStatic reads the static kubeconfig from the <shoot>.kubeconfig secret deprecated by Gardener
+	KubeconfigModeStatic = "static"
+)
+
+// KubeconfigConfig selects how the kubeconfigs of the Shoots are obtained, the admin kubeconfigs are renewed
+// when they expire within RenewBefore
+type KubeconfigConfig struct {
+	Mode        string        `envconfig:"default=admin"`
+	Expiration  time.Duration `envconfig:"default=24h"`
+	RenewBefore time.Duration `envconfig:"default=1h"`
+}
+
+func (c KubeconfigConfig) Validate() error {
+	if c.Mode != KubeconfigModeAdmin && c.Mode != KubeconfigModeStatic {
+		return fmt.Errorf("kubeconfig mode %s is not supported, use %s or %s", c.Mode, KubeconfigModeAdmin, KubeconfigModeStatic)
+	}
+	if c.Mode == KubeconfigModeAdmin && c.RenewBefore >= c.Expiration {
+		return fmt.Errorf("kubeconfig renewal period %s has to be shorter than expiration %s", c.RenewBefore, c.Expiration)
+	}
+
+	return nil
+}
+
+// KubeconfigProvider fetches the kubeconfigs of the Shoots, the admin kubeconfigs are cached per Shoot until they are due for renewal
 type KubeconfigProvider struct {
-	secretsClient v12.SecretInterface
+	secretClients    SecretClients
+	adminKubeconfigs AdminKubeconfigClient
+	config           KubeconfigConfig
+
+	mutex sync.Mutex
+	cache map[string]AdminKubeconfig
+
+	now func() time.Time
+}
+
+func NewKubeconfigProvider(secretClients SecretClients, adminKubeconfigs AdminKubeconfigClient, config KubeconfigConfig) *KubeconfigProvider {
+	return &KubeconfigProvider{
+		secretClients:    secretClients,
+		adminKubeconfigs: adminKubeconfigs,
+		config:           config,
+		cache:            map[string]AdminKubeconfig{},
+		now:              time.Now,
+	}
 }
 
-func NewKubeconfigProvider(secretsClient v12.SecretInterface) KubeconfigProvider {
-	return KubeconfigProvider{
-		secretsClient: secretsClient,
+func (kp *KubeconfigProvider) FetchRaw(project, shootName string) ([]byte, error) {
+	if kp.config.Mode != KubeconfigModeAdmin || kp.adminKubeconfigs == nil {
+		return kp.fetchStatic(project, shootName)
+	}
+
+	key := fmt.Sprintf("%s/%s", project, shootName)
+
+	kp.mutex.Lock()
+	cached, found := kp.cache[key]
+	kp.mutex.Unlock()
+	if found && !kp.DueForRenewal(cached.ExpiresAt) {
+		return cached.Kubeconfig, nil
+	}
+
+	kubeconfig, err := kp.adminKubeconfigs.Create(context.Background(), project, shootName, kp.config.Expiration)
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsMethodNotSupported(err) {
+			log.Warnf("Admin kubeconfig of Shoot %s is not available, falling back to static kubeconfig: %s", shootName, err.Error())
+			return kp.fetchStatic(project, shootName)
+		}
+		return nil, fmt.Errorf("error requesting admin kubeconfig: %s", err.Error())
 	}
+
+	kp.mutex.Lock()
+	kp.cache[key] = kubeconfig
+	kp.mutex.Unlock()
+
+	return kubeconfig.Kubeconfig, nil
+}
+
+// DueForRenewal checks if the kubeconfig expiring at the given time has to be renewed
+func (kp *KubeconfigProvider) DueForRenewal(expiresAt time.Time) bool {
+	return !kp.now().Add(kp.config.RenewBefore).Before(expiresAt)
 }
 
-func (kp KubeconfigProvider) FetchRaw(shootName string) ([]byte, error) {
-	secret, err := kp.secretsClient.Get(context.Background(), fmt.Sprintf("%s.kubeconfig", shootName), v1.GetOptions{})
+func (kp *KubeconfigProvider) fetchStatic(project, shootName string) ([]byte, error) {
+	secret, err := kp.secretClients.ForProject(project).Get(context.Background(), fmt.Sprintf("%s.kubeconfig", shootName), v1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching kubeconfig: %s", err.Error())
 	}
@@ -0,0 +1,152 @@
+package gardener
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	kubeconfigProject = "project"
+	kubeconfigShoot   = "shoot"
+)
+
+func TestKubeconfigProvider_FetchRaw(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	config := KubeconfigConfig{Mode: KubeconfigModeAdmin, Expiration: 2 * time.Hour, RenewBefore: 30 * time.Minute}
+
+	t.Run("should cache admin kubeconfig and renew it when it is about to expire", func(t *testing.T) {
+		// given
+		adminKubeconfigs := &fakeAdminKubeconfigs{responses: []AdminKubeconfig{
+			{Kubeconfig: []byte("first"), ExpiresAt: now.Add(2 * time.Hour)},
+			{Kubeconfig: []byte("second"), ExpiresAt: now.Add(4 * time.Hour)},
+		}}
+		provider := NewKubeconfigProvider(fixSecretClients(), adminKubeconfigs, config)
+		provider.now = func() time.Time { return now }
+
+		// when
+		kubeconfig, err := provider.FetchRaw(kubeconfigProject, kubeconfigShoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(kubeconfig))
+
+		// when
+		provider.now = func() time.Time { return now.Add(time.Hour) }
+		kubeconfig, err = provider.FetchRaw(kubeconfigProject, kubeconfigShoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(kubeconfig))
+		assert.Equal(t, 1, adminKubeconfigs.calls)
+
+		// when
+		provider.now = func() time.Time { return now.Add(90 * time.Minute) }
+		kubeconfig, err = provider.FetchRaw(kubeconfigProject, kubeconfigShoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "second", string(kubeconfig))
+		assert.Equal(t, 2, adminKubeconfigs.calls)
+		assert.Equal(t, config.Expiration, adminKubeconfigs.expiration)
+	})
+
+	t.Run("should fall back to static kubeconfig when admin kubeconfig is not available", func(t *testing.T) {
+		// given
+		adminKubeconfigs := &fakeAdminKubeconfigs{err: k8sErrors.NewNotFound(schema.GroupResource{Resource: "shoots/adminkubeconfig"}, kubeconfigShoot)}
+		provider := NewKubeconfigProvider(fixSecretClients(), adminKubeconfigs, config)
+
+		// when
+		kubeconfig, err := provider.FetchRaw(kubeconfigProject, kubeconfigShoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "static", string(kubeconfig))
+		assert.Equal(t, 1, adminKubeconfigs.calls)
+	})
+
+	t.Run("should read static kubeconfig in static mode", func(t *testing.T) {
+		// given
+		adminKubeconfigs := &fakeAdminKubeconfigs{}
+		provider := NewKubeconfigProvider(fixSecretClients(), adminKubeconfigs, KubeconfigConfig{Mode: KubeconfigModeStatic})
+
+		// when
+		kubeconfig, err := provider.FetchRaw(kubeconfigProject, kubeconfigShoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "static", string(kubeconfig))
+		assert.Zero(t, adminKubeconfigs.calls)
+	})
+
+	t.Run("should return error when admin kubeconfig cannot be requested", func(t *testing.T) {
+		// given
+		adminKubeconfigs := &fakeAdminKubeconfigs{err: errors.New("connection refused")}
+		provider := NewKubeconfigProvider(fixSecretClients(), adminKubeconfigs, config)
+
+		// when
+		_, err := provider.FetchRaw(kubeconfigProject, kubeconfigShoot)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
+
+func TestKubeconfigConfig_Validate(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		config      KubeconfigConfig
+		valid       bool
+	}{
+		{description: "admin mode", config: KubeconfigConfig{Mode: KubeconfigModeAdmin, Expiration: time.Hour, RenewBefore: time.Minute}, valid: true},
+		{description: "static mode", config: KubeconfigConfig{Mode: KubeconfigModeStatic}, valid: true},
+		{description: "unknown mode", config: KubeconfigConfig{Mode: "token"}, valid: false},
+		{description: "renewal period longer than expiration", config: KubeconfigConfig{Mode: KubeconfigModeAdmin, Expiration: time.Hour, RenewBefore: 2 * time.Hour}, valid: false},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := testCase.config.Validate()
+
+			// then
+			assert.Equal(t, testCase.valid, err == nil)
+		})
+	}
+}
+
+type fakeAdminKubeconfigs struct {
+	responses  []AdminKubeconfig
+	err        error
+	calls      int
+	expiration time.Duration
+}
+
+func (f *fakeAdminKubeconfigs) Create(_ context.Context, _, _ string, expiration time.Duration) (AdminKubeconfig, error) {
+	f.calls++
+	f.expiration = expiration
+	if f.err != nil {
+		return AdminKubeconfig{}, f.err
+	}
+
+	response := f.responses[0]
+	f.responses = f.responses[1:]
+	return response, nil
+}
+
+func fixSecretClients() SecretClients {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "shoot.kubeconfig", Namespace: ProjectNamespace(kubeconfigProject)},
+		Data:       map[string][]byte{"kubeconfig": []byte("static")},
+	})
+
+	return NewSecretClients(NewProjects(kubeconfigProject, nil), client.CoreV1())
+}
@@ -0,0 +1,125 @@
+package gardener
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var orphanedShootsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "kcp",
+	Subsystem: "provisioner",
+	Name:      "orphaned_shoots",
+	Help:      "The number of Shoots of the Gardener project without an active cluster",
+})
+
+type ShootLister interface {
+	List(ctx context.Context, opts v1.ListOptions) (*gardener_types.ShootList, error)
+}
+
+type ActiveShootsReader interface {
+	ListActiveShootNames() ([]string, dberrors.Error)
+}
+
+// OrphanedShootsDetector periodically compares Shoots of the Gardener projects with the active clusters
+// to find Shoots left behind, e.g. by failed deprovisioning. Orphaned Shoots are only reported, never deleted.
+// Shoots younger than the grace period are skipped as their clusters may not be stored yet.
+type OrphanedShootsDetector struct {
+	shootListers []ShootLister
+	reader       ActiveShootsReader
+	gracePeriod  time.Duration
+
+	mutex    sync.RWMutex
+	orphaned []model.OrphanedShoot
+
+	log logrus.FieldLogger
+}
+
+// NewOrphanedShootsDetector creates the detector of orphaned Shoots listed with the listers, one for each Gardener project
+func NewOrphanedShootsDetector(shootListers []ShootLister, reader ActiveShootsReader, gracePeriod time.Duration) *OrphanedShootsDetector {
+	return &OrphanedShootsDetector{
+		shootListers: shootListers,
+		reader:       reader,
+		gracePeriod:  gracePeriod,
+		orphaned:     []model.OrphanedShoot{},
+		log:          logrus.WithField("Component", "OrphanedShootsDetector"),
+	}
+}
+
+// Run detects orphaned Shoots immediately and then in the given interval until stopped
+func (d *OrphanedShootsDetector) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Detect(); err != nil {
+			d.log.Errorf("Failed to detect orphaned Shoots: %s", err.Error())
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Detect refreshes the orphaned Shoots, the previous result is kept if the detection fails
+func (d *OrphanedShootsDetector) Detect() error {
+	shoots := []gardener_types.Shoot{}
+	for _, shootLister := range d.shootListers {
+		list, err := shootLister.List(context.Background(), v1.ListOptions{})
+		if err != nil {
+			return errors.Wrap(err, "failed to list Shoots")
+		}
+		shoots = append(shoots, list.Items...)
+	}
+
+	activeShootNames, dberr := d.reader.ListActiveShootNames()
+	if dberr != nil {
+		return errors.Wrap(dberr, "failed to list Shoots of active clusters")
+	}
+
+	active := make(map[string]bool, len(activeShootNames))
+	for _, name := range activeShootNames {
+		active[name] = true
+	}
+
+	orphaned := []model.OrphanedShoot{}
+	for _, shoot := range shoots {
+		if active[shoot.Name] || shoot.DeletionTimestamp != nil || time.Since(shoot.CreationTimestamp.Time) < d.gracePeriod {
+			continue
+		}
+		d.log.Warnf("Shoot %s has no active cluster", shoot.Name)
+		orphaned = append(orphaned, model.OrphanedShoot{
+			Name:              shoot.Name,
+			CreationTimestamp: shoot.CreationTimestamp.Time,
+			Labels:            shoot.Labels,
+			CostAllocation:    model.CostAllocationFromLabels(shoot.Labels),
+		})
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.orphaned = orphaned
+	orphanedShootsGauge.Set(float64(len(orphaned)))
+
+	return nil
+}
+
+// OrphanedShoots returns the result of the last successful detection
+func (d *OrphanedShootsDetector) OrphanedShoots() []model.OrphanedShoot {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.orphaned
+}
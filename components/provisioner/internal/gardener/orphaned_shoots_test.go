@@ -0,0 +1,136 @@
+package gardener
+
+import (
+	"testing"
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOrphanedShootsDetector_Detect(t *testing.T) {
+	createdAt := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	deletedAt := v1.Now()
+
+	shoot := func(name string, creationTimestamp time.Time, deletionTimestamp *v1.Time) *gardener_types.Shoot {
+		return &gardener_types.Shoot{
+			ObjectMeta: v1.ObjectMeta{
+				Name:              name,
+				Namespace:         gardenerNamespace,
+				CreationTimestamp: v1.NewTime(creationTimestamp),
+				DeletionTimestamp: deletionTimestamp,
+				Labels:            map[string]string{"account": "global-account"},
+			},
+		}
+	}
+
+	t.Run("should detect Shoots without active cluster", func(t *testing.T) {
+		// given
+		shootClient := fake.NewSimpleClientset(
+			shoot("active", createdAt, nil),
+			shoot("orphaned", createdAt, nil),
+			shoot("recent", time.Now(), nil),
+			shoot("deleted", createdAt, &deletedAt),
+		).CoreV1beta1().Shoots(gardenerNamespace)
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("ListActiveShootNames").Return([]string{"active"}, nil)
+
+		detector := NewOrphanedShootsDetector([]ShootLister{shootClient}, readSession, time.Hour)
+
+		// when
+		err := detector.Detect()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []model.OrphanedShoot{
+			{Name: "orphaned", CreationTimestamp: createdAt, Labels: map[string]string{"account": "global-account"}},
+		}, detector.OrphanedShoots())
+	})
+
+	t.Run("should detect Shoots in all Gardener projects", func(t *testing.T) {
+		// given
+		otherProjectShoot := shoot("other-project-orphaned", createdAt, nil)
+		otherProjectShoot.Namespace = ProjectNamespace("other-project")
+		clientset := fake.NewSimpleClientset(shoot("orphaned", createdAt, nil), otherProjectShoot)
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("ListActiveShootNames").Return([]string{}, nil)
+
+		detector := NewOrphanedShootsDetector([]ShootLister{
+			clientset.CoreV1beta1().Shoots(gardenerNamespace),
+			clientset.CoreV1beta1().Shoots(ProjectNamespace("other-project")),
+		}, readSession, time.Hour)
+
+		// when
+		err := detector.Detect()
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"orphaned", "other-project-orphaned"}, []string{detector.OrphanedShoots()[0].Name, detector.OrphanedShoots()[1].Name})
+	})
+
+	t.Run("should read cost allocation identifiers of orphaned Shoots", func(t *testing.T) {
+		// given
+		orphaned := shoot("orphaned", createdAt, nil)
+		orphaned.Labels[model.GlobalAccountIDLabel] = "global-account"
+		orphaned.Labels[model.SubAccountIDLabel] = "sub-account"
+		orphaned.Labels[model.InstanceIDLabel] = "instance-id"
+		shootClient := fake.NewSimpleClientset(orphaned).CoreV1beta1().Shoots(gardenerNamespace)
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("ListActiveShootNames").Return([]string{}, nil)
+
+		detector := NewOrphanedShootsDetector([]ShootLister{shootClient}, readSession, time.Hour)
+
+		// when
+		err := detector.Detect()
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, detector.OrphanedShoots(), 1)
+		assert.Equal(t, model.CostAllocation{
+			GlobalAccountID: "global-account",
+			SubAccountID:    "sub-account",
+			InstanceID:      "instance-id",
+		}, detector.OrphanedShoots()[0].CostAllocation)
+	})
+
+	t.Run("should keep previous result when failed to list active clusters", func(t *testing.T) {
+		// given
+		shootClient := fake.NewSimpleClientset(shoot("orphaned", createdAt, nil)).CoreV1beta1().Shoots(gardenerNamespace)
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("ListActiveShootNames").Return([]string{}, nil).Once()
+		readSession.On("ListActiveShootNames").Return(nil, dberrors.Internal("error")).Once()
+
+		detector := NewOrphanedShootsDetector([]ShootLister{shootClient}, readSession, time.Hour)
+
+		err := detector.Detect()
+		require.NoError(t, err)
+
+		// when
+		err = detector.Detect()
+
+		// then
+		require.Error(t, err)
+		assert.Len(t, detector.OrphanedShoots(), 1)
+	})
+
+	t.Run("should return no Shoots before the first detection", func(t *testing.T) {
+		// given
+		detector := NewOrphanedShootsDetector(nil, nil, time.Hour)
+
+		// when
+		orphaned := detector.OrphanedShoots()
+
+		// then
+		assert.Empty(t, orphaned)
+	})
+}
@@ -0,0 +1,200 @@
+package gardener
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardener_apis "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// requiredCredentials lists keys of the secret required by the Gardener provider extensions
+var requiredCredentials = map[string][]string{
+	"gcp":       {"serviceaccount.json"},
+	"azure":     {"clientID", "clientSecret", "subscriptionID", "tenantID"},
+	"aws":       {"accessKeyID", "secretAccessKey"},
+	"openstack": {"domainName", "tenantName", "username", "password"},
+}
+
+// ShootPreflightChecker verifies the credentials and quotas of the Shoot before it is created,
+// so that provisioning fails immediately instead of after the Shoot creation times out.
+// Checks which cannot be performed, e.g. due to missing permissions, are skipped.
+type ShootPreflightChecker struct {
+	projects       Projects
+	gardenerClient gardener_apis.CoreV1beta1Interface
+	k8sClient      kubernetes.Interface
+
+	log logrus.FieldLogger
+}
+
+func NewShootPreflightChecker(projects Projects, gardenerClient gardener_apis.CoreV1beta1Interface, k8sClient kubernetes.Interface) *ShootPreflightChecker {
+	return &ShootPreflightChecker{
+		projects:       projects,
+		gardenerClient: gardenerClient,
+		k8sClient:      k8sClient,
+		log:            logrus.WithField("Component", "ShootPreflightChecker"),
+	}
+}
+
+func (c *ShootPreflightChecker) Check(shoot *v1beta1.Shoot) apperrors.AppError {
+	secretBinding, appErr := c.validateSecretBinding(shoot.Namespace, shoot.Spec.SecretBindingName, shoot.Spec.Provider.Type)
+	if appErr != nil || secretBinding == nil {
+		return appErr
+	}
+
+	return c.checkQuotas(secretBinding, shoot)
+}
+
+// ValidateSecretBinding verifies that the secret binding exists in the namespace of the project and its secret contains credentials of the provider
+func (c *ShootPreflightChecker) ValidateSecretBinding(project, name, providerType string) apperrors.AppError {
+	_, appErr := c.validateSecretBinding(c.projects.Namespace(project), name, providerType)
+	return appErr
+}
+
+// ValidateDNSSecret verifies that the secret with credentials to the DNS provider exists in the namespace of the project
+func (c *ShootPreflightChecker) ValidateDNSSecret(project, name string) apperrors.AppError {
+	namespace := c.projects.Namespace(project)
+	_, err := c.k8sClient.CoreV1().Secrets(namespace).Get(context.Background(), name, v1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return apperrors.BadRequest("DNS provider secret %s not found in %s namespace", name, namespace)
+		}
+		c.log.Warnf("Skipping DNS provider secret check, cannot get secret %s: %s", name, err.Error())
+	}
+
+	return nil
+}
+
+// validateSecretBinding returns nil secret binding when it cannot be read, e.g. due to missing permissions
+func (c *ShootPreflightChecker) validateSecretBinding(namespace, name, providerType string) (*v1beta1.SecretBinding, apperrors.AppError) {
+	secretBinding, err := c.gardenerClient.SecretBindings(namespace).Get(context.Background(), name, v1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, apperrors.FailedPermanently(apperrors.CredentialsNotFound, "secret binding %s not found in %s namespace", name, namespace)
+		}
+		c.log.Warnf("Skipping pre-flight checks, cannot get secret binding %s: %s", name, err.Error())
+		return nil, nil
+	}
+
+	appErr := c.checkCredentials(secretBinding, providerType)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return secretBinding, nil
+}
+
+func (c *ShootPreflightChecker) checkCredentials(secretBinding *v1beta1.SecretBinding, providerType string) apperrors.AppError {
+	secretRef := secretBinding.SecretRef
+	namespace := secretRef.Namespace
+	if namespace == "" {
+		namespace = secretBinding.Namespace
+	}
+
+	secret, err := c.k8sClient.CoreV1().Secrets(namespace).Get(context.Background(), secretRef.Name, v1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return apperrors.FailedPermanently(apperrors.CredentialsNotFound, "secret %s/%s referenced by secret binding %s not found", namespace, secretRef.Name, secretBinding.Name)
+		}
+		c.log.Warnf("Skipping credentials check, cannot get secret %s/%s: %s", namespace, secretRef.Name, err.Error())
+		return nil
+	}
+
+	missing := missingCredentials(secret, providerType)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	for otherProviderType := range requiredCredentials {
+		if otherProviderType != providerType && len(missingCredentials(secret, otherProviderType)) == 0 {
+			return apperrors.FailedPermanently(apperrors.CredentialsProviderMismatch, "secret binding %s contains %s credentials, but %s provider was requested",
+				secretBinding.Name, otherProviderType, providerType)
+		}
+	}
+
+	return apperrors.FailedPermanently(apperrors.CredentialsIncomplete, "secret %s/%s referenced by secret binding %s is missing %s credentials: %s",
+		namespace, secretRef.Name, secretBinding.Name, providerType, strings.Join(missing, ", "))
+}
+
+func missingCredentials(secret *corev1.Secret, providerType string) []string {
+	var missing []string
+	for _, key := range requiredCredentials[providerType] {
+		if len(secret.Data[key]) == 0 {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing
+}
+
+// checkQuotas verifies that the maximum size of the Shoot alone does not exceed the quotas of the secret binding.
+// Resources used by other Shoots are not taken into account.
+func (c *ShootPreflightChecker) checkQuotas(secretBinding *v1beta1.SecretBinding, shoot *v1beta1.Shoot) apperrors.AppError {
+	if len(secretBinding.Quotas) == 0 {
+		return nil
+	}
+
+	requested, found := c.requestedResources(shoot)
+	if !found {
+		return nil
+	}
+
+	for _, quotaRef := range secretBinding.Quotas {
+		quota, err := c.gardenerClient.Quotas(quotaRef.Namespace).Get(context.Background(), quotaRef.Name, v1.GetOptions{})
+		if err != nil {
+			c.log.Warnf("Skipping quota check, cannot get quota %s/%s: %s", quotaRef.Namespace, quotaRef.Name, err.Error())
+			continue
+		}
+
+		for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			limit, limited := quota.Spec.Metrics[resourceName]
+			request := requested[resourceName]
+			if limited && request.Cmp(limit) > 0 {
+				return apperrors.FailedPermanently(apperrors.QuotaExceeded, "requested %s %s exceeds %s quota %s/%s",
+					resourceName, request.String(), limit.String(), quotaRef.Namespace, quotaRef.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// requestedResources returns CPU and memory of the maximum number of machines of all worker pools
+func (c *ShootPreflightChecker) requestedResources(shoot *v1beta1.Shoot) (corev1.ResourceList, bool) {
+	cloudProfile, err := c.gardenerClient.CloudProfiles().Get(context.Background(), shoot.Spec.CloudProfileName, v1.GetOptions{})
+	if err != nil {
+		c.log.Warnf("Skipping quota check, cannot get cloud profile %s: %s", shoot.Spec.CloudProfileName, err.Error())
+		return nil, false
+	}
+
+	machineTypes := make(map[string]v1beta1.MachineType)
+	for _, machineType := range cloudProfile.Spec.MachineTypes {
+		machineTypes[machineType.Name] = machineType
+	}
+
+	cpu := resource.Quantity{}
+	memory := resource.Quantity{}
+	for _, worker := range shoot.Spec.Provider.Workers {
+		machineType, found := machineTypes[worker.Machine.Type]
+		if !found {
+			c.log.Warnf("Skipping quota check, machine type %s not found in cloud profile %s", worker.Machine.Type, shoot.Spec.CloudProfileName)
+			return nil, false
+		}
+		for i := int32(0); i < worker.Maximum; i++ {
+			cpu.Add(machineType.CPU)
+			memory.Add(machineType.Memory)
+		}
+	}
+
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    cpu,
+		corev1.ResourceMemory: memory,
+	}, true
+}
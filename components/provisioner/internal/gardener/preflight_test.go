@@ -0,0 +1,220 @@
+package gardener
+
+import (
+	"testing"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestShootPreflightChecker_Check(t *testing.T) {
+	const (
+		secretBindingName = "secret-binding"
+		secretName        = "credentials"
+		quotaNamespace    = "garden"
+		quotaName         = "trial"
+		cloudProfileName  = "gcp"
+		machineType       = "n1-standard-4"
+	)
+
+	shoot := &gardener_types.Shoot{
+		ObjectMeta: v1.ObjectMeta{Namespace: gardenerNamespace},
+		Spec: gardener_types.ShootSpec{
+			SecretBindingName: secretBindingName,
+			CloudProfileName:  cloudProfileName,
+			Provider: gardener_types.Provider{
+				Type: "gcp",
+				Workers: []gardener_types.Worker{
+					{Machine: gardener_types.Machine{Type: machineType}, Maximum: 4},
+				},
+			},
+		},
+	}
+
+	secretBinding := func(quotas ...corev1.ObjectReference) *gardener_types.SecretBinding {
+		return &gardener_types.SecretBinding{
+			ObjectMeta: v1.ObjectMeta{Name: secretBindingName, Namespace: gardenerNamespace},
+			SecretRef:  corev1.SecretReference{Name: secretName},
+			Quotas:     quotas,
+		}
+	}
+
+	secret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: secretName, Namespace: gardenerNamespace},
+			Data:       data,
+		}
+	}
+
+	quota := func(cpu string) *gardener_types.Quota {
+		return &gardener_types.Quota{
+			ObjectMeta: v1.ObjectMeta{Name: quotaName, Namespace: quotaNamespace},
+			Spec: gardener_types.QuotaSpec{
+				Metrics: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		}
+	}
+
+	cloudProfile := &gardener_types.CloudProfile{
+		ObjectMeta: v1.ObjectMeta{Name: cloudProfileName},
+		Spec: gardener_types.CloudProfileSpec{
+			MachineTypes: []gardener_types.MachineType{
+				{Name: machineType, CPU: resource.MustParse("4"), Memory: resource.MustParse("15Gi")},
+			},
+		},
+	}
+
+	quotaRef := corev1.ObjectReference{Name: quotaName, Namespace: quotaNamespace}
+	validCredentials := map[string][]byte{"serviceaccount.json": []byte("{}")}
+
+	for _, testCase := range []struct {
+		description     string
+		gardenerObjects []runtime.Object
+		k8sObjects      []runtime.Object
+		expectedCause   apperrors.CauseCode
+	}{
+		{
+			description:     "should pass when credentials are valid and the Shoot does not exceed the quota",
+			gardenerObjects: []runtime.Object{secretBinding(quotaRef), quota("16"), cloudProfile},
+			k8sObjects:      []runtime.Object{secret(validCredentials)},
+		},
+		{
+			description:     "should pass when secret binding has no quotas",
+			gardenerObjects: []runtime.Object{secretBinding()},
+			k8sObjects:      []runtime.Object{secret(validCredentials)},
+		},
+		{
+			description:     "should skip quota check when cloud profile does not exist",
+			gardenerObjects: []runtime.Object{secretBinding(quotaRef), quota("8")},
+			k8sObjects:      []runtime.Object{secret(validCredentials)},
+		},
+		{
+			description:     "should fail when secret binding does not exist",
+			gardenerObjects: []runtime.Object{},
+			k8sObjects:      []runtime.Object{secret(validCredentials)},
+			expectedCause:   apperrors.CredentialsNotFound,
+		},
+		{
+			description:     "should fail when secret does not exist",
+			gardenerObjects: []runtime.Object{secretBinding()},
+			k8sObjects:      []runtime.Object{},
+			expectedCause:   apperrors.CredentialsNotFound,
+		},
+		{
+			description:     "should fail when secret does not contain provider credentials",
+			gardenerObjects: []runtime.Object{secretBinding()},
+			k8sObjects:      []runtime.Object{secret(map[string][]byte{"accessKeyID": []byte("key")})},
+			expectedCause:   apperrors.CredentialsIncomplete,
+		},
+		{
+			description:     "should fail when secret contains credentials of another provider",
+			gardenerObjects: []runtime.Object{secretBinding()},
+			k8sObjects:      []runtime.Object{secret(map[string][]byte{"accessKeyID": []byte("key"), "secretAccessKey": []byte("secret")})},
+			expectedCause:   apperrors.CredentialsProviderMismatch,
+		},
+		{
+			description:     "should fail when the Shoot exceeds the quota",
+			gardenerObjects: []runtime.Object{secretBinding(quotaRef), quota("8"), cloudProfile},
+			k8sObjects:      []runtime.Object{secret(validCredentials)},
+			expectedCause:   apperrors.QuotaExceeded,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			gardenerClient := fake.NewSimpleClientset(testCase.gardenerObjects...).CoreV1beta1()
+			k8sClient := k8sFake.NewSimpleClientset(testCase.k8sObjects...)
+
+			checker := NewShootPreflightChecker(NewProjects(gardenerProject, nil), gardenerClient, k8sClient)
+
+			// when
+			err := checker.Check(shoot)
+
+			// then
+			if testCase.expectedCause == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+			assert.Equal(t, testCase.expectedCause, err.Cause())
+		})
+	}
+}
+
+func TestShootPreflightChecker_ValidateSecretBinding(t *testing.T) {
+	secretBinding := &gardener_types.SecretBinding{
+		ObjectMeta: v1.ObjectMeta{Name: "secret-binding", Namespace: gardenerNamespace},
+		SecretRef:  corev1.SecretReference{Name: "credentials"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "credentials", Namespace: gardenerNamespace},
+		Data:       map[string][]byte{"serviceaccount.json": []byte("{}")},
+	}
+
+	gardenerClient := fake.NewSimpleClientset(secretBinding).CoreV1beta1()
+	k8sClient := k8sFake.NewSimpleClientset(secret)
+
+	checker := NewShootPreflightChecker(NewProjects(gardenerProject, nil), gardenerClient, k8sClient)
+
+	t.Run("should accept secret binding with provider credentials", func(t *testing.T) {
+		// when
+		err := checker.ValidateSecretBinding(gardenerProject, "secret-binding", "gcp")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error when secret binding does not exist", func(t *testing.T) {
+		// when
+		err := checker.ValidateSecretBinding(gardenerProject, "other", "gcp")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CredentialsNotFound, err.Cause())
+	})
+
+	t.Run("should return error when secret binding is bound to another provider", func(t *testing.T) {
+		// when
+		err := checker.ValidateSecretBinding(gardenerProject, "secret-binding", "azure")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CredentialsProviderMismatch, err.Cause())
+	})
+}
+
+func TestShootPreflightChecker_ValidateDNSSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "route53-credentials", Namespace: gardenerNamespace},
+	}
+	otherNamespaceSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "other-credentials", Namespace: "other"},
+	}
+
+	checker := NewShootPreflightChecker(NewProjects(gardenerProject, nil), fake.NewSimpleClientset().CoreV1beta1(), k8sFake.NewSimpleClientset(secret, otherNamespaceSecret))
+
+	t.Run("should accept existing DNS provider secret", func(t *testing.T) {
+		// when
+		err := checker.ValidateDNSSecret(gardenerProject, "route53-credentials")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error when DNS provider secret is not in Gardener namespace", func(t *testing.T) {
+		// when
+		err := checker.ValidateDNSSecret(gardenerProject, "other-credentials")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+	})
+}
@@ -0,0 +1,128 @@
+package gardener
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gardener_apis "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
+	v12 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Landscapes maps the logical landscapes, e.g. trial or production, to the Gardener projects in which Shoots of the landscape are created
+type Landscapes map[string]string
+
+// Unmarshal parses landscapes in the format landscape:project separated with commas, e.g. trial:kyma-trial,production:kyma-prod
+func (l Landscapes) Unmarshal(s string) error {
+	for _, entry := range strings.Split(s, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return fmt.Errorf("invalid landscape %q, should be in the format landscape:project", entry)
+		}
+		l[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return nil
+}
+
+// Projects holds the Gardener projects in which the Provisioner manages Shoots, Runtimes without the project are assigned to the default one
+type Projects struct {
+	defaultProject string
+	projects       map[string]bool
+}
+
+func NewProjects(defaultProject string, landscapes Landscapes) Projects {
+	projects := map[string]bool{defaultProject: true}
+	for _, project := range landscapes {
+		projects[project] = true
+	}
+
+	return Projects{
+		defaultProject: defaultProject,
+		projects:       projects,
+	}
+}
+
+func (p Projects) Default() string {
+	return p.defaultProject
+}
+
+func (p Projects) Contains(project string) bool {
+	return p.projects[project]
+}
+
+// Names returns sorted names of all projects
+func (p Projects) Names() []string {
+	names := make([]string, 0, len(p.projects))
+	for project := range p.projects {
+		names = append(names, project)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Namespace returns the namespace of the project, the namespace of the default project is returned for the empty project
+func (p Projects) Namespace(project string) string {
+	if project == "" {
+		project = p.defaultProject
+	}
+
+	return ProjectNamespace(project)
+}
+
+// Namespaces returns namespaces of all projects
+func (p Projects) Namespaces() []string {
+	namespaces := make([]string, 0, len(p.projects))
+	for _, project := range p.Names() {
+		namespaces = append(namespaces, ProjectNamespace(project))
+	}
+
+	return namespaces
+}
+
+func ProjectNamespace(project string) string {
+	return fmt.Sprintf("garden-%s", project)
+}
+
+// ShootClients returns clients of Shoots in the namespaces of the Gardener projects
+type ShootClients struct {
+	projects Projects
+	client   gardener_apis.ShootsGetter
+}
+
+func NewShootClients(projects Projects, client gardener_apis.ShootsGetter) ShootClients {
+	return ShootClients{
+		projects: projects,
+		client:   client,
+	}
+}
+
+func (c ShootClients) ForProject(project string) gardener_apis.ShootInterface {
+	return c.client.Shoots(c.projects.Namespace(project))
+}
+
+func (c ShootClients) Namespace(project string) string {
+	return c.projects.Namespace(project)
+}
+
+// SecretClients returns clients of Secrets in the namespaces of the Gardener projects
+type SecretClients struct {
+	projects Projects
+	client   v12.SecretsGetter
+}
+
+func NewSecretClients(projects Projects, client v12.SecretsGetter) SecretClients {
+	return SecretClients{
+		projects: projects,
+		client:   client,
+	}
+}
+
+func (c SecretClients) ForProject(project string) v12.SecretInterface {
+	return c.client.Secrets(c.projects.Namespace(project))
+}
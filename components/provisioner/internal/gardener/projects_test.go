@@ -0,0 +1,60 @@
+package gardener
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLandscapes_Unmarshal(t *testing.T) {
+	t.Run("should parse landscapes", func(t *testing.T) {
+		// given
+		landscapes := Landscapes{}
+
+		// when
+		err := landscapes.Unmarshal("trial:kyma-trial, production:kyma-prod,")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Landscapes{"trial": "kyma-trial", "production": "kyma-prod"}, landscapes)
+	})
+
+	t.Run("should parse empty landscapes", func(t *testing.T) {
+		// given
+		landscapes := Landscapes{}
+
+		// when
+		err := landscapes.Unmarshal("")
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, landscapes)
+	})
+
+	for _, value := range []string{"trial", "trial:", ":kyma-trial", "trial:kyma:trial"} {
+		t.Run("should fail to parse "+value, func(t *testing.T) {
+			// when
+			err := Landscapes{}.Unmarshal(value)
+
+			// then
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestProjects(t *testing.T) {
+	projects := NewProjects("kyma", Landscapes{"trial": "kyma-trial", "production": "kyma"})
+
+	t.Run("should contain default project and projects of landscapes", func(t *testing.T) {
+		assert.Equal(t, []string{"kyma", "kyma-trial"}, projects.Names())
+		assert.Equal(t, []string{"garden-kyma", "garden-kyma-trial"}, projects.Namespaces())
+		assert.True(t, projects.Contains("kyma-trial"))
+		assert.False(t, projects.Contains("other"))
+	})
+
+	t.Run("should return namespace of the default project for empty project", func(t *testing.T) {
+		assert.Equal(t, "garden-kyma", projects.Namespace(""))
+		assert.Equal(t, "garden-kyma-trial", projects.Namespace("kyma-trial"))
+	})
+}
@@ -34,55 +34,48 @@ type Client interface {
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.Shoot, error)
 }
 
+//go:generate mockery -name=PreflightChecker
+type PreflightChecker interface {
+	Check(shoot *v1beta1.Shoot) apperrors.AppError
+}
+
 func NewProvisioner(
-	namespace string,
-	shootClient Client,
+	shootClients ShootClients,
 	factory dbsession.Factory,
-	policyConfigMapName string, maintenanceWindowConfigPath string) *GardenerProvisioner {
+	policyConfigMapName string, maintenanceWindowConfigPath string,
+	preflightChecker PreflightChecker) *GardenerProvisioner {
 	return &GardenerProvisioner{
-		namespace:                   namespace,
-		shootClient:                 shootClient,
+		shootClients:                shootClients,
 		dbSessionFactory:            factory,
 		policyConfigMapName:         policyConfigMapName,
 		maintenanceWindowConfigPath: maintenanceWindowConfigPath,
+		preflightChecker:            preflightChecker,
 	}
 }
 
 type GardenerProvisioner struct {
-	namespace                   string
-	shootClient                 Client
+	shootClients                ShootClients
 	dbSessionFactory            dbsession.Factory
 	directorService             director.DirectorClient
 	policyConfigMapName         string
 	maintenanceWindowConfigPath string
+	preflightChecker            PreflightChecker
 }
 
 func (g *GardenerProvisioner) ProvisionCluster(cluster model.Cluster, operationId string) apperrors.AppError {
-	shootTemplate, err := cluster.ClusterConfig.ToShootTemplate(g.namespace, cluster.Tenant, util.UnwrapStr(cluster.SubAccountId), cluster.ClusterConfig.OIDCConfig)
+	shootTemplate, err := g.shootTemplate(cluster, operationId)
 	if err != nil {
-		return err.Append("failed to convert cluster config to Shoot template")
+		return err
 	}
 
-	region := cluster.ClusterConfig.Region
-
-	if g.shouldSetMaintenanceWindow() {
-		err := g.setMaintenanceWindow(shootTemplate, region)
-
+	if g.preflightChecker != nil {
+		err := g.preflightChecker.Check(shootTemplate)
 		if err != nil {
-			return err.Append("error setting maintenance window for %s cluster", cluster.ID)
+			return err.Append("pre-flight check failed for %s cluster", cluster.ID)
 		}
 	}
 
-	annotate(shootTemplate, runtimeIDAnnotation, cluster.ID)
-	annotate(shootTemplate, operationIDAnnotation, operationId)
-	annotate(shootTemplate, legacyRuntimeIDAnnotation, cluster.ID)
-	annotate(shootTemplate, legacyOperationIDAnnotation, operationId)
-
-	if g.policyConfigMapName != "" {
-		g.applyAuditConfig(shootTemplate)
-	}
-
-	_, k8serr := g.shootClient.Create(context.Background(), shootTemplate, v1.CreateOptions{})
+	_, k8serr := g.shootClient(cluster.ClusterConfig.ProjectName).Create(context.Background(), shootTemplate, v1.CreateOptions{})
 	if k8serr != nil {
 		appError := util.K8SErrorToAppError(k8serr)
 		return appError.Append("error creating Shoot for %s cluster: %s", cluster.ID)
@@ -91,9 +84,31 @@ func (g *GardenerProvisioner) ProvisionCluster(cluster model.Cluster, operationI
 	return nil
 }
 
+// ProvisionClusterDryRun renders the Shoot of the cluster and runs the pre-flight checks without creating the Shoot
+func (g *GardenerProvisioner) ProvisionClusterDryRun(cluster model.Cluster) (model.ShootDryRun, apperrors.AppError) {
+	shootTemplate, err := g.shootTemplate(cluster, "")
+	if err != nil {
+		return model.ShootDryRun{}, err
+	}
+
+	spec, jsonErr := json.Marshal(shootTemplate.Spec)
+	if jsonErr != nil {
+		return model.ShootDryRun{}, apperrors.Internal("failed to encode Shoot spec for %s cluster: %s", cluster.ID, jsonErr.Error())
+	}
+
+	result := model.ShootDryRun{Spec: string(spec)}
+
+	if g.preflightChecker != nil {
+		result.PreflightChecked = true
+		result.PreflightError = g.preflightChecker.Check(shootTemplate)
+	}
+
+	return result, nil
+}
+
 func (g *GardenerProvisioner) UpgradeCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError {
 
-	shoot, err := g.shootClient.Get(context.Background(), upgradeConfig.Name, v1.GetOptions{})
+	shoot, err := g.shootClient(upgradeConfig.ProjectName).Get(context.Background(), upgradeConfig.Name, v1.GetOptions{})
 	if err != nil {
 		appErr := util.K8SErrorToAppError(err)
 		return appErr.Append("error getting Shoot for cluster ID %s and name %s", clusterID, upgradeConfig.Name)
@@ -106,7 +121,7 @@ func (g *GardenerProvisioner) UpgradeCluster(clusterID string, upgradeConfig mod
 	}
 
 	err = retry.Do(func() error {
-		_, err := g.shootClient.Update(context.Background(), shoot, v1.UpdateOptions{})
+		_, err := g.shootClient(upgradeConfig.ProjectName).Update(context.Background(), shoot, v1.UpdateOptions{})
 		return err
 	}, retry.Attempts(5))
 	if err != nil {
@@ -117,8 +132,25 @@ func (g *GardenerProvisioner) UpgradeCluster(clusterID string, upgradeConfig mod
 	return nil
 }
 
+func (g *GardenerProvisioner) UpgradeClusterDryRun(clusterID string, upgradeConfig model.GardenerConfig) ([]model.ShootSpecChange, apperrors.AppError) {
+	shoot, err := g.shootClient(upgradeConfig.ProjectName).Get(context.Background(), upgradeConfig.Name, v1.GetOptions{})
+	if err != nil {
+		appErr := util.K8SErrorToAppError(err)
+		return nil, appErr.Append("error getting Shoot for cluster ID %s and name %s", clusterID, upgradeConfig.Name)
+	}
+
+	desiredShoot := shoot.DeepCopy()
+
+	appErr := upgradeConfig.GardenerProviderConfig.EditShootConfig(upgradeConfig, desiredShoot)
+	if appErr != nil {
+		return nil, appErr.Append("error while computing Gardener shoot configuration")
+	}
+
+	return diffShootSpecs(shoot.Spec, desiredShoot.Spec)
+}
+
 func (g *GardenerProvisioner) HibernateCluster(clusterID string, gardenerConfig model.GardenerConfig) apperrors.AppError {
-	shoot, err := g.shootClient.Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
+	shoot, err := g.shootClient(gardenerConfig.ProjectName).Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
 	if err != nil {
 		appErr := util.K8SErrorToAppError(err)
 		return appErr.Append("error getting Shoot for cluster ID %s and name %s", clusterID, gardenerConfig.Name)
@@ -139,7 +171,36 @@ func (g *GardenerProvisioner) HibernateCluster(clusterID string, gardenerConfig
 	}
 
 	err = retry.Do(func() error {
-		_, err := g.shootClient.Update(context.Background(), shoot, v1.UpdateOptions{})
+		_, err := g.shootClient(gardenerConfig.ProjectName).Update(context.Background(), shoot, v1.UpdateOptions{})
+		return err
+	}, retry.Attempts(5))
+
+	if err != nil {
+		apperr := util.K8SErrorToAppError(err)
+		return apperr.Append("error executing update shoot configuration")
+	}
+
+	return nil
+}
+
+func (g *GardenerProvisioner) WakeUpCluster(clusterID string, gardenerConfig model.GardenerConfig) apperrors.AppError {
+	shoot, err := g.shootClient(gardenerConfig.ProjectName).Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
+	if err != nil {
+		appErr := util.K8SErrorToAppError(err)
+		return appErr.Append("error getting Shoot for cluster ID %s and name %s", clusterID, gardenerConfig.Name)
+	}
+
+	enabled := false
+	if shoot.Spec.Hibernation != nil {
+		shoot.Spec.Hibernation.Enabled = &enabled
+	} else {
+		shoot.Spec.Hibernation = &v1beta1.Hibernation{
+			Enabled: &enabled,
+		}
+	}
+
+	err = retry.Do(func() error {
+		_, err := g.shootClient(gardenerConfig.ProjectName).Update(context.Background(), shoot, v1.UpdateOptions{})
 		return err
 	}, retry.Attempts(5))
 
@@ -151,14 +212,14 @@ func (g *GardenerProvisioner) HibernateCluster(clusterID string, gardenerConfig
 	return nil
 }
 
-func (g *GardenerProvisioner) DeprovisionCluster(cluster model.Cluster, operationId string) (model.Operation, apperrors.AppError) {
-	shoot, err := g.shootClient.Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
+func (g *GardenerProvisioner) DeprovisionCluster(cluster model.Cluster, operationId string, force bool) (model.Operation, apperrors.AppError) {
+	shoot, err := g.shootClient(cluster.ClusterConfig.ProjectName).Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			message := fmt.Sprintf("Cluster %s already deleted. Proceeding to DeprovisionCluster stage.", cluster.ID)
 
 			// Shoot was deleted. In order to make sure if all clean up actions were performed we need to proceed to WaitForClusterDeletion state
-			return newDeprovisionOperation(operationId, cluster.ID, message, model.InProgress, model.WaitForClusterDeletion, time.Now()), nil
+			return newDeprovisionOperation(operationId, cluster.ID, message, model.InProgress, model.WaitForClusterDeletion, time.Now(), force), nil
 		}
 	}
 
@@ -166,7 +227,7 @@ func (g *GardenerProvisioner) DeprovisionCluster(cluster model.Cluster, operatio
 		annotate(shoot, operationIDAnnotation, operationId)
 		annotate(shoot, legacyOperationIDAnnotation, operationId)
 		message := fmt.Sprintf("Cluster %s with id %s already scheduled for deletion.", cluster.ClusterConfig.Name, cluster.ID)
-		return newDeprovisionOperation(operationId, cluster.ID, message, model.InProgress, model.WaitForClusterDeletion, shoot.DeletionTimestamp.Time), nil
+		return newDeprovisionOperation(operationId, cluster.ID, message, model.InProgress, model.WaitForClusterDeletion, shoot.DeletionTimestamp.Time, force), nil
 	}
 
 	deletionTime := time.Now()
@@ -176,18 +237,24 @@ func (g *GardenerProvisioner) DeprovisionCluster(cluster model.Cluster, operatio
 
 	annotateWithConfirmDeletion(shoot)
 
-	_, err = g.shootClient.Update(context.Background(), shoot, v1.UpdateOptions{})
+	_, err = g.shootClient(cluster.ClusterConfig.ProjectName).Update(context.Background(), shoot, v1.UpdateOptions{})
 	if err != nil {
 		appError := util.K8SErrorToAppError(err)
 		return model.Operation{}, appError.Append("error updating Shoot")
 	}
 
+	if force {
+		// Cluster is unusable, in-cluster cleanup would only hang until timeout
+		message := fmt.Sprintf("Force deprovisioning started. Skipped stages: %s, %s", model.CleanupCluster, model.TriggerKymaUninstall)
+		return newDeprovisionOperation(operationId, cluster.ID, message, model.InProgress, model.DeleteCluster, deletionTime, force), nil
+	}
+
 	message := fmt.Sprintf("Deprovisioning started")
-	return newDeprovisionOperation(operationId, cluster.ID, message, model.InProgress, model.CleanupCluster, deletionTime), nil
+	return newDeprovisionOperation(operationId, cluster.ID, message, model.InProgress, model.CleanupCluster, deletionTime, force), nil
 }
 
 func (g *GardenerProvisioner) GetHibernationStatus(clusterID string, gardenerConfig model.GardenerConfig) (model.HibernationStatus, apperrors.AppError) {
-	shoot, err := g.shootClient.Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
+	shoot, err := g.shootClient(gardenerConfig.ProjectName).Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
 	if err != nil {
 		appErr := util.K8SErrorToAppError(err)
 		return model.HibernationStatus{}, appErr.Append("error getting Shoot for cluster ID %s and name %s", clusterID, gardenerConfig.Name)
@@ -201,6 +268,83 @@ func (g *GardenerProvisioner) GetHibernationStatus(clusterID string, gardenerCon
 	}, nil
 }
 
+// GetShootStatus reads conditions and last errors of the Shoot, conditions of hibernated Shoots are not reported as
+// Gardener marks them as failed while the cluster is stopped
+func (g *GardenerProvisioner) GetShootStatus(clusterID string, gardenerConfig model.GardenerConfig) (*model.ShootStatus, apperrors.AppError) {
+	shoot, err := g.shootClient(gardenerConfig.ProjectName).Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
+	if err != nil {
+		appErr := util.K8SErrorToAppError(err)
+		return nil, appErr.Append("error getting Shoot for cluster ID %s and name %s", clusterID, gardenerConfig.Name)
+	}
+
+	status := &model.ShootStatus{
+		State:      shootState(shoot),
+		Conditions: []model.ShootCondition{},
+		LastErrors: make([]model.ShootError, 0, len(shoot.Status.LastErrors)),
+	}
+
+	if shoot.Spec.DNS != nil {
+		status.Domain = shoot.Spec.DNS.Domain
+	}
+
+	if !shoot.Status.IsHibernated {
+		for _, condition := range shoot.Status.Conditions {
+			status.Conditions = append(status.Conditions, model.ShootCondition{
+				Type:               string(condition.Type),
+				Status:             string(condition.Status),
+				Reason:             condition.Reason,
+				Message:            condition.Message,
+				LastTransitionTime: condition.LastTransitionTime.Time,
+			})
+		}
+	}
+
+	for _, lastError := range shoot.Status.LastErrors {
+		codes := make([]string, 0, len(lastError.Codes))
+		for _, code := range lastError.Codes {
+			codes = append(codes, string(code))
+		}
+		status.LastErrors = append(status.LastErrors, model.ShootError{
+			Description: lastError.Description,
+			Codes:       codes,
+		})
+	}
+
+	return status, nil
+}
+
+func shootState(shoot *v1beta1.Shoot) model.ShootState {
+	if shoot.Status.IsHibernated {
+		return model.ShootStateHibernated
+	}
+
+	if len(shoot.Status.LastErrors) > 0 {
+		return model.ShootStateUnhealthy
+	}
+
+	progressing := false
+	for _, condition := range shoot.Status.Conditions {
+		switch condition.Status {
+		case v1beta1.ConditionFalse:
+			return model.ShootStateUnhealthy
+		case v1beta1.ConditionTrue:
+		default:
+			progressing = true
+		}
+	}
+
+	lastOperation := shoot.Status.LastOperation
+	if lastOperation != nil && (lastOperation.State == v1beta1.LastOperationStateProcessing || lastOperation.State == v1beta1.LastOperationStatePending) {
+		progressing = true
+	}
+
+	if progressing {
+		return model.ShootStateProgressing
+	}
+
+	return model.ShootStateHealthy
+}
+
 func annotateWithConfirmDeletion(shoot *gardener_types.Shoot) {
 	if shoot.Annotations == nil {
 		shoot.Annotations = map[string]string{}
@@ -209,11 +353,43 @@ func annotateWithConfirmDeletion(shoot *gardener_types.Shoot) {
 	shoot.Annotations["confirmation.gardener.cloud/deletion"] = "true"
 }
 
+func (g *GardenerProvisioner) shootTemplate(cluster model.Cluster, operationId string) (*gardener_types.Shoot, apperrors.AppError) {
+	shootTemplate, err := cluster.ClusterConfig.ToShootTemplate(g.shootClients.Namespace(cluster.ClusterConfig.ProjectName), cluster.Tenant, util.UnwrapStr(cluster.SubAccountId), cluster.ClusterConfig.OIDCConfig)
+	if err != nil {
+		return nil, err.Append("failed to convert cluster config to Shoot template")
+	}
+
+	region := cluster.ClusterConfig.Region
+
+	if g.shouldSetMaintenanceWindow() {
+		err := g.setMaintenanceWindow(shootTemplate, region)
+
+		if err != nil {
+			return nil, err.Append("error setting maintenance window for %s cluster", cluster.ID)
+		}
+	}
+
+	annotate(shootTemplate, runtimeIDAnnotation, cluster.ID)
+	annotate(shootTemplate, operationIDAnnotation, operationId)
+	annotate(shootTemplate, legacyRuntimeIDAnnotation, cluster.ID)
+	annotate(shootTemplate, legacyOperationIDAnnotation, operationId)
+
+	if g.policyConfigMapName != "" {
+		g.applyAuditConfig(shootTemplate)
+	}
+
+	return shootTemplate, nil
+}
+
+func (g *GardenerProvisioner) shootClient(project string) Client {
+	return g.shootClients.ForProject(project)
+}
+
 func (g *GardenerProvisioner) shouldSetMaintenanceWindow() bool {
 	return g.maintenanceWindowConfigPath != ""
 }
 
-func newDeprovisionOperation(id, runtimeId, message string, state model.OperationState, stage model.OperationStage, startTime time.Time) model.Operation {
+func newDeprovisionOperation(id, runtimeId, message string, state model.OperationState, stage model.OperationStage, startTime time.Time, force bool) model.Operation {
 	return model.Operation{
 		ID:             id,
 		Type:           model.Deprovision,
@@ -222,6 +398,7 @@ func newDeprovisionOperation(id, runtimeId, message string, state model.Operatio
 		Stage:          stage,
 		Message:        message,
 		ClusterID:      runtimeId,
+		Force:          force,
 	}
 }
 
@@ -2,6 +2,7 @@ package gardener
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -26,10 +27,14 @@ import (
 	gardenerMocks "github.com/kyma-project/control-plane/components/provisioner/internal/gardener/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgotesting "k8s.io/client-go/testing"
 )
 
 const (
-	gardenerNamespace = "default"
+	gardenerProject   = "project"
+	gardenerNamespace = "garden-project"
 	runtimeId         = "runtimeId"
 	tenant            = "tenant"
 	operationId       = "operationId"
@@ -55,7 +60,7 @@ func TestGardenerProvisioner_ProvisionCluster(t *testing.T) {
 		// given
 		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
-		provisionerClient := NewProvisioner(gardenerNamespace, shootClient, nil, auditLogsPolicyCMName, maintWindowConfigPath)
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), nil, auditLogsPolicyCMName, maintWindowConfigPath, nil)
 
 		// when
 		apperr := provisionerClient.ProvisionCluster(cluster, operationId)
@@ -76,6 +81,125 @@ func TestGardenerProvisioner_ProvisionCluster(t *testing.T) {
 		require.NotNil(t, shoot.Spec.Maintenance.TimeWindow)
 		assert.Equal(t, auditLogsPolicyCMName, shoot.Spec.Kubernetes.KubeAPIServer.AuditConfig.AuditPolicy.ConfigMapRef.Name)
 	})
+
+	t.Run("should not create Shoot when pre-flight check failed", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset()
+		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
+
+		preflightChecker := &gardenerMocks.PreflightChecker{}
+		preflightChecker.On("Check", mock.AnythingOfType("*v1beta1.Shoot")).
+			Return(apperrors.FailedPermanently(apperrors.CredentialsNotFound, "secret binding not found"))
+
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), nil, auditLogsPolicyCMName, maintWindowConfigPath, preflightChecker)
+
+		// when
+		apperr := provisionerClient.ProvisionCluster(cluster, operationId)
+
+		// then
+		require.Error(t, apperr)
+		assert.Equal(t, apperrors.CodeBadRequest, apperr.Code())
+		assert.Equal(t, apperrors.CredentialsNotFound, apperr.Cause())
+
+		_, err := shootClient.Get(context.Background(), clusterName, v1.GetOptions{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("should create Shoot in the namespace of the cluster project", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset()
+		projects := NewProjects(gardenerProject, Landscapes{"trial": "trial-project"})
+
+		trialCluster := newClusterConfig(clusterName, nil, gcpGardenerConfig, region)
+		trialCluster.ClusterConfig.ProjectName = "trial-project"
+
+		provisionerClient := NewProvisioner(NewShootClients(projects, clientset.CoreV1beta1()), nil, auditLogsPolicyCMName, maintWindowConfigPath, nil)
+
+		// when
+		apperr := provisionerClient.ProvisionCluster(trialCluster, operationId)
+		require.NoError(t, apperr)
+
+		// then
+		shoot, err := clientset.CoreV1beta1().Shoots("garden-trial-project").Get(context.Background(), clusterName, v1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "garden-trial-project", shoot.Namespace)
+
+		_, err = clientset.CoreV1beta1().Shoots(gardenerNamespace).Get(context.Background(), clusterName, v1.GetOptions{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+}
+
+func TestGardenerProvisioner_ProvisionClusterDryRun(t *testing.T) {
+	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{
+		Zones: []string{"zone-1"},
+	})
+	require.NoError(t, err)
+
+	maintWindowConfigPath := filepath.Join("testdata", "maintwindow.json")
+
+	cluster := newClusterConfig(clusterName, nil, gcpGardenerConfig, region)
+
+	t.Run("should render Shoot spec without creating Shoot", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset()
+
+		preflightChecker := &gardenerMocks.PreflightChecker{}
+		preflightChecker.On("Check", mock.AnythingOfType("*v1beta1.Shoot")).Return(nil)
+
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), nil, auditLogsPolicyCMName, maintWindowConfigPath, preflightChecker)
+
+		// when
+		shoot, apperr := provisionerClient.ProvisionClusterDryRun(cluster)
+		require.NoError(t, apperr)
+
+		// then
+		var spec gardener_types.ShootSpec
+		require.NoError(t, json.Unmarshal([]byte(shoot.Spec), &spec))
+		assert.Equal(t, region, spec.Region)
+		require.NotNil(t, spec.Maintenance.TimeWindow)
+		assert.Equal(t, auditLogsPolicyCMName, spec.Kubernetes.KubeAPIServer.AuditConfig.AuditPolicy.ConfigMapRef.Name)
+		assert.True(t, shoot.PreflightChecked)
+		assert.Nil(t, shoot.PreflightError)
+		assert.Empty(t, clientset.Actions())
+	})
+
+	t.Run("should return pre-flight check error with rendered Shoot spec", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset()
+
+		preflightChecker := &gardenerMocks.PreflightChecker{}
+		preflightChecker.On("Check", mock.AnythingOfType("*v1beta1.Shoot")).
+			Return(apperrors.FailedPermanently(apperrors.CredentialsNotFound, "secret binding not found"))
+
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), nil, auditLogsPolicyCMName, maintWindowConfigPath, preflightChecker)
+
+		// when
+		shoot, apperr := provisionerClient.ProvisionClusterDryRun(cluster)
+		require.NoError(t, apperr)
+
+		// then
+		assert.NotEmpty(t, shoot.Spec)
+		assert.True(t, shoot.PreflightChecked)
+		require.Error(t, shoot.PreflightError)
+		assert.Equal(t, apperrors.CredentialsNotFound, shoot.PreflightError.Cause())
+		assert.Empty(t, clientset.Actions())
+	})
+
+	t.Run("should not run pre-flight checks if disabled", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset()
+
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), nil, auditLogsPolicyCMName, maintWindowConfigPath, nil)
+
+		// when
+		shoot, apperr := provisionerClient.ProvisionClusterDryRun(cluster)
+		require.NoError(t, apperr)
+
+		// then
+		assert.NotEmpty(t, shoot.Spec)
+		assert.False(t, shoot.PreflightChecked)
+		assert.Nil(t, shoot.PreflightError)
+	})
 }
 
 func TestGardenerProvisioner_DeprovisionCluster(t *testing.T) {
@@ -89,7 +213,7 @@ func TestGardenerProvisioner_DeprovisionCluster(t *testing.T) {
 			ID:                     "id",
 			ClusterID:              runtimeId,
 			Name:                   clusterName,
-			ProjectName:            "project-name",
+			ProjectName:            gardenerProject,
 			GardenerProviderConfig: gcpGardenerConfig,
 		},
 	}
@@ -106,12 +230,12 @@ func TestGardenerProvisioner_DeprovisionCluster(t *testing.T) {
 
 		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
-		provisionerClient := NewProvisioner(gardenerNamespace, shootClient, sessionFactoryMock, auditLogsPolicyCMName, "")
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactoryMock, auditLogsPolicyCMName, "", nil)
 
 		// when
 		sessionFactoryMock.On("NewWriteSession").Return(session)
 
-		operation, apperr := provisionerClient.DeprovisionCluster(cluster, operationId)
+		operation, apperr := provisionerClient.DeprovisionCluster(cluster, operationId, false)
 		require.NoError(t, apperr)
 
 		// then
@@ -124,6 +248,34 @@ func TestGardenerProvisioner_DeprovisionCluster(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should start force deprovisioning from DeprovisionCluster step", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset(
+			&gardener_types.Shoot{
+				ObjectMeta: v1.ObjectMeta{Name: clusterName, Namespace: gardenerNamespace, Finalizers: []string{"test"}},
+			})
+
+		sessionFactoryMock := &sessionMocks.Factory{}
+		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
+
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactoryMock, auditLogsPolicyCMName, "", nil)
+
+		// when
+		operation, apperr := provisionerClient.DeprovisionCluster(cluster, operationId, true)
+		require.NoError(t, apperr)
+
+		// then
+		assert.Equal(t, model.InProgress, operation.State)
+		assert.Equal(t, model.DeleteCluster, operation.Stage)
+		assert.True(t, operation.Force)
+		assert.Contains(t, operation.Message, string(model.CleanupCluster))
+		assert.Contains(t, operation.Message, string(model.TriggerKymaUninstall))
+
+		shoot, err := shootClient.Get(context.Background(), clusterName, v1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "true", shoot.Annotations["confirmation.gardener.cloud/deletion"])
+	})
+
 	t.Run("should proceed to WaitForClusterDeletion step if shoot does not exist", func(t *testing.T) {
 		// given
 		clientset := fake.NewSimpleClientset()
@@ -133,13 +285,13 @@ func TestGardenerProvisioner_DeprovisionCluster(t *testing.T) {
 
 		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
-		provisionerClient := NewProvisioner(gardenerNamespace, shootClient, sessionFactoryMock, auditLogsPolicyCMName, "")
+		provisionerClient := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactoryMock, auditLogsPolicyCMName, "", nil)
 
 		// when
 		sessionFactoryMock.On("NewWriteSession").Return(session)
 		session.On("MarkClusterAsDeleted", cluster.ID).Return(nil)
 
-		operation, apperr := provisionerClient.DeprovisionCluster(cluster, operationId)
+		operation, apperr := provisionerClient.DeprovisionCluster(cluster, operationId, false)
 		require.NoError(t, apperr)
 
 		// then
@@ -187,7 +339,7 @@ func TestGardenerProvisioner_UpgradeCluster(t *testing.T) {
 		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
 		sessionFactory := &sessionMocks.Factory{}
-		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 		// when
 		apperr := provisioner.UpgradeCluster(cluster.ID, cluster.ClusterConfig)
@@ -201,10 +353,9 @@ func TestGardenerProvisioner_UpgradeCluster(t *testing.T) {
 	})
 	t.Run("should return error when failed to get shoot from Gardener", func(t *testing.T) {
 		clientset := fake.NewSimpleClientset()
-		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
 		sessionFactory := &sessionMocks.Factory{}
-		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 		// when
 		apperr := provisioner.UpgradeCluster(cluster.ID, cluster.ClusterConfig)
@@ -224,7 +375,7 @@ func newClusterConfig(name string, subAccountID *string, providerConfig model.Ga
 			ID:                     "id",
 			ClusterID:              runtimeId,
 			Name:                   name,
-			ProjectName:            "project-name",
+			ProjectName:            gardenerProject,
 			KubernetesVersion:      "1.16",
 			VolumeSizeGB:           util.IntPtr(50),
 			DiskType:               util.StringPtr("standard"),
@@ -235,13 +386,84 @@ func newClusterConfig(name string, subAccountID *string, providerConfig model.Ga
 			WorkerCidr:             "10.10.10.10",
 			AutoScalerMin:          1,
 			AutoScalerMax:          5,
-			MaxSurge:               25,
-			MaxUnavailable:         1,
+			MaxSurge:               util.IntOrStringPtr(intstr.FromInt(25)),
+			MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
 			GardenerProviderConfig: providerConfig,
 		},
 	}
 }
 
+func TestGardenerProvisioner_UpgradeClusterDryRun(t *testing.T) {
+	initialShoot := testkit.NewTestShoot(clusterName).
+		InNamespace(gardenerNamespace).
+		WithAutoUpdate(false, false).
+		WithWorkers(testkit.NewTestWorker("peon").ToWorker()).
+		ToShoot()
+
+	upgradedShoot := testkit.NewTestShoot(clusterName).
+		InNamespace(gardenerNamespace).
+		WithKubernetesVersion("1.16").
+		WithAutoUpdate(false, false).
+		WithWorkers(
+			testkit.NewTestWorker("peon").
+				WithMachineType("n1-standard-4").
+				WithVolume("standard", 50).
+				WithMinMax(1, 5).
+				WithMaxSurge(25).
+				WithMaxUnavailable(1).
+				WithZones("zone-1").
+				ToWorker()).
+		ToShoot()
+
+	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"zone-1"}})
+	require.NoError(t, err)
+	cluster := newClusterConfig(clusterName, nil, gcpGardenerConfig, region)
+
+	t.Run("should return changes without updating shoot", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset(initialShoot)
+		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
+
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), &sessionMocks.Factory{}, auditLogsPolicyCMName, "", nil)
+
+		// when
+		changes, apperr := provisioner.UpgradeClusterDryRun(cluster.ID, cluster.ClusterConfig)
+		require.NoError(t, apperr)
+
+		// then
+		assert.Contains(t, changes, model.ShootSpecChange{Path: "spec.kubernetes.version", OldValue: `""`, NewValue: `"1.16"`})
+		assert.Contains(t, changes, model.ShootSpecChange{Path: "spec.provider.workers[0].machine.type", OldValue: `""`, NewValue: `"n1-standard-4"`})
+
+		shoot, err := shootClient.Get(context.Background(), clusterName, v1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, initialShoot, shoot)
+	})
+	t.Run("should return empty diff when upgrade does not change shoot", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset(upgradedShoot)
+
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), &sessionMocks.Factory{}, auditLogsPolicyCMName, "", nil)
+
+		// when
+		changes, apperr := provisioner.UpgradeClusterDryRun(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.NoError(t, apperr)
+		assert.Empty(t, changes)
+	})
+	t.Run("should return error when failed to get shoot from Gardener", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), &sessionMocks.Factory{}, auditLogsPolicyCMName, "", nil)
+
+		// when
+		_, apperr := provisioner.UpgradeClusterDryRun(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.Error(t, apperr)
+	})
+}
+
 func TestGardenerProvisioner_HibernateCluster(t *testing.T) {
 
 	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"zone-1"}})
@@ -250,10 +472,9 @@ func TestGardenerProvisioner_HibernateCluster(t *testing.T) {
 
 	t.Run("should return error if failed to get shoot", func(t *testing.T) {
 		clientset := fake.NewSimpleClientset()
-		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
 		sessionFactory := &sessionMocks.Factory{}
-		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 		// when
 		apperr := provisioner.HibernateCluster(cluster.ID, cluster.ClusterConfig)
@@ -270,10 +491,9 @@ func TestGardenerProvisioner_HibernateCluster(t *testing.T) {
 			ToShoot()
 
 		clientset := fake.NewSimpleClientset(shoot)
-		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
 		sessionFactory := &sessionMocks.Factory{}
-		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 		// when
 		apperr := provisioner.HibernateCluster(cluster.ID, cluster.ClusterConfig)
@@ -291,10 +511,9 @@ func TestGardenerProvisioner_HibernateCluster(t *testing.T) {
 			ToShoot()
 
 		clientset := fake.NewSimpleClientset(shoot)
-		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
 		sessionFactory := &sessionMocks.Factory{}
-		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 		// when
 		apperr := provisioner.HibernateCluster(cluster.ID, cluster.ClusterConfig)
@@ -309,13 +528,13 @@ func TestGardenerProvisioner_HibernateCluster(t *testing.T) {
 			WithHibernationState(true, false).
 			ToShoot()
 
-		shootClient := &gardenerMocks.Client{}
-
-		shootClient.On("Get", mock.Anything, clusterName, mock.Anything).Return(shoot, nil)
-		shootClient.On("Update", mock.Anything, shoot, mock.Anything).Return(nil, errors.New("some error"))
+		clientset := fake.NewSimpleClientset(shoot)
+		clientset.PrependReactor("update", "shoots", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("some error")
+		})
 
 		sessionFactory := &sessionMocks.Factory{}
-		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 		// when
 		apperr := provisioner.HibernateCluster(cluster.ID, cluster.ClusterConfig)
@@ -325,6 +544,73 @@ func TestGardenerProvisioner_HibernateCluster(t *testing.T) {
 	})
 }
 
+func TestGardenerProvisioner_WakeUpCluster(t *testing.T) {
+
+	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"zone-1"}})
+	require.NoError(t, err)
+	cluster := newClusterConfig(clusterName, nil, gcpGardenerConfig, region)
+
+	t.Run("should return error if failed to get shoot", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
+
+		// when
+		apperr := provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.Error(t, apperr)
+		assert.Equal(t, apperrors.CodeInternal, apperr.Code())
+	})
+
+	t.Run("should wake up cluster", func(t *testing.T) {
+		enabled := true
+		shoot := testkit.NewTestShoot(clusterName).
+			InNamespace(gardenerNamespace).
+			WithHibernationState(true, true).
+			ToShoot()
+		shoot.Spec.Hibernation = &gardener_types.Hibernation{Enabled: &enabled}
+
+		clientset := fake.NewSimpleClientset(shoot)
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
+
+		// when
+		apperr := provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.NoError(t, apperr)
+
+		updatedShoot, err := clientset.CoreV1beta1().Shoots(gardenerNamespace).Get(context.Background(), clusterName, v1.GetOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, updatedShoot.Spec.Hibernation.Enabled)
+		assert.False(t, *updatedShoot.Spec.Hibernation.Enabled)
+	})
+
+	t.Run("should return error if failed to wake up cluster", func(t *testing.T) {
+		shoot := testkit.NewTestShoot(clusterName).
+			InNamespace(gardenerNamespace).
+			WithHibernationState(true, true).
+			ToShoot()
+
+		clientset := fake.NewSimpleClientset(shoot)
+		clientset.PrependReactor("update", "shoots", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("some error")
+		})
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
+
+		// when
+		apperr := provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.Error(t, apperr)
+	})
+}
+
 func TestGardenerProvisioner_GetHibernationStatus(t *testing.T) {
 	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"zone-1"}})
 	require.NoError(t, err)
@@ -332,10 +618,9 @@ func TestGardenerProvisioner_GetHibernationStatus(t *testing.T) {
 
 	t.Run("should  fail when failed to get cluster", func(t *testing.T) {
 		clientset := fake.NewSimpleClientset()
-		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
 		sessionFactory := &sessionMocks.Factory{}
-		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 		// when
 		_, apperr := provisioner.GetHibernationStatus(cluster.ID, cluster.ClusterConfig)
@@ -370,10 +655,9 @@ func TestGardenerProvisioner_GetHibernationStatus(t *testing.T) {
 				ToShoot()
 
 			clientset := fake.NewSimpleClientset(shoot)
-			shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
 
 			sessionFactory := &sessionMocks.Factory{}
-			provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+			provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
 
 			// when
 			status, apperr := provisioner.GetHibernationStatus(cluster.ID, cluster.ClusterConfig)
@@ -386,6 +670,117 @@ func TestGardenerProvisioner_GetHibernationStatus(t *testing.T) {
 	}
 }
 
+func TestGardenerProvisioner_GetShootStatus(t *testing.T) {
+	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"zone-1"}})
+	require.NoError(t, err)
+	cluster := newClusterConfig(clusterName, nil, gcpGardenerConfig, region)
+
+	failedConditions := []gardener_types.Condition{
+		{Type: gardener_types.ShootAPIServerAvailable, Status: gardener_types.ConditionFalse, Reason: "HealthzRequestFailed", Message: "API server is not available"},
+		{Type: gardener_types.ShootEveryNodeReady, Status: gardener_types.ConditionFalse, Reason: "NodesNotReady", Message: "Nodes are not ready"},
+	}
+
+	t.Run("should fail when failed to get cluster", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
+
+		// when
+		_, apperr := provisioner.GetShootStatus(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.Error(t, apperr)
+	})
+
+	for _, testcase := range []struct {
+		description        string
+		shoot              *gardener_types.Shoot
+		expectedState      model.ShootState
+		expectedConditions int
+		expectedLastErrors []model.ShootError
+	}{
+		{
+			description: "should report healthy Shoot",
+			shoot: withConditions(testkit.NewTestShoot(clusterName).InNamespace(gardenerNamespace).WithOperationSucceeded().ToShoot(),
+				gardener_types.Condition{Type: gardener_types.ShootAPIServerAvailable, Status: gardener_types.ConditionTrue}),
+			expectedState:      model.ShootStateHealthy,
+			expectedConditions: 1,
+			expectedLastErrors: []model.ShootError{},
+		},
+		{
+			description:        "should report failed conditions",
+			shoot:              withConditions(testkit.NewTestShoot(clusterName).InNamespace(gardenerNamespace).WithOperationSucceeded().ToShoot(), failedConditions...),
+			expectedState:      model.ShootStateUnhealthy,
+			expectedConditions: 2,
+			expectedLastErrors: []model.ShootError{},
+		},
+		{
+			description:        "should report last errors",
+			shoot:              testkit.NewTestShoot(clusterName).InNamespace(gardenerNamespace).WithRateLimitExceededError().ToShoot(),
+			expectedState:      model.ShootStateUnhealthy,
+			expectedConditions: 0,
+			expectedLastErrors: []model.ShootError{{Codes: []string{string(gardener_types.ErrorInfraRateLimitsExceeded)}}},
+		},
+		{
+			description: "should report Shoot in progress",
+			shoot: withConditions(testkit.NewTestShoot(clusterName).InNamespace(gardenerNamespace).WithOperationProcessing().ToShoot(),
+				gardener_types.Condition{Type: gardener_types.ShootEveryNodeReady, Status: gardener_types.ConditionProgressing}),
+			expectedState:      model.ShootStateProgressing,
+			expectedConditions: 1,
+			expectedLastErrors: []model.ShootError{},
+		},
+		{
+			description:        "should report hibernated Shoot without conditions",
+			shoot:              withConditions(testkit.NewTestShoot(clusterName).InNamespace(gardenerNamespace).WithHibernationState(true, true).ToShoot(), failedConditions...),
+			expectedState:      model.ShootStateHibernated,
+			expectedConditions: 0,
+			expectedLastErrors: []model.ShootError{},
+		},
+	} {
+		t.Run(testcase.description, func(t *testing.T) {
+			// given
+			clientset := fake.NewSimpleClientset(testcase.shoot)
+
+			sessionFactory := &sessionMocks.Factory{}
+			provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
+
+			// when
+			status, apperr := provisioner.GetShootStatus(cluster.ID, cluster.ClusterConfig)
+
+			// then
+			require.NoError(t, apperr)
+			require.NotNil(t, status)
+			assert.Equal(t, testcase.expectedState, status.State)
+			assert.Len(t, status.Conditions, testcase.expectedConditions)
+			assert.Equal(t, testcase.expectedLastErrors, status.LastErrors)
+		})
+	}
+
+	t.Run("should report effective domain of the Shoot", func(t *testing.T) {
+		// given
+		shoot := testkit.NewTestShoot(clusterName).InNamespace(gardenerNamespace).WithOperationSucceeded().ToShoot()
+		shoot.Spec.DNS = &gardener_types.DNS{Domain: util.StringPtr("shoot.project.shoot.example.com")}
+
+		clientset := fake.NewSimpleClientset(shoot)
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(NewShootClients(NewProjects(gardenerProject, nil), clientset.CoreV1beta1()), sessionFactory, auditLogsPolicyCMName, "", nil)
+
+		// when
+		status, apperr := provisioner.GetShootStatus(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.NoError(t, apperr)
+		assert.Equal(t, util.StringPtr("shoot.project.shoot.example.com"), status.Domain)
+	})
+}
+
+func withConditions(shoot *gardener_types.Shoot, conditions ...gardener_types.Condition) *gardener_types.Shoot {
+	shoot.Status.Conditions = conditions
+	return shoot
+}
+
 func assertAnnotation(t *testing.T, shoot *gardener_types.Shoot, name, value string) {
 	annotations := shoot.Annotations
 	if annotations == nil {
@@ -0,0 +1,58 @@
+package gardener
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+var rateLimiterWaitDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: "kcp",
+	Subsystem: "provisioner",
+	Name:      "gardener_rate_limiter_wait_seconds",
+	Help:      "Time spent waiting for the client-side rate limiter before sending request to Gardener",
+	Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60},
+})
+
+// Collectors returns metrics of the Gardener clients, Shoot controller, orphaned Shoots and Shoot name generator to be registered in Prometheus
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		rateLimiterWaitDuration,
+		orphanedShootsGauge,
+		shootReconcilesTotal,
+		shootReconcileDuration,
+		shootLastSuccessfulReconcile,
+		shootWorkqueueDepth,
+		shootNamesGeneratedTotal,
+		shootNameCollisionsTotal,
+		shootNameGenerationFailuresTotal,
+	}
+}
+
+// NewRateLimiter returns token bucket rate limiter which records time spent waiting for a token.
+// The same rate limiter has to be set in the config of all Gardener clients to keep the total request rate under the limit.
+func NewRateLimiter(qps float32, burst int) flowcontrol.RateLimiter {
+	return &rateLimiter{
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+	}
+}
+
+type rateLimiter struct {
+	flowcontrol.RateLimiter
+}
+
+func (r *rateLimiter) Accept() {
+	start := time.Now()
+	r.RateLimiter.Accept()
+	rateLimiterWaitDuration.Observe(time.Since(start).Seconds())
+}
+
+func (r *rateLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := r.RateLimiter.Wait(ctx)
+	rateLimiterWaitDuration.Observe(time.Since(start).Seconds())
+
+	return err
+}
@@ -0,0 +1,53 @@
+package gardener
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("should wait for token when burst is exceeded", func(t *testing.T) {
+		// given
+		rateLimiter := NewRateLimiter(20, 1)
+		defer rateLimiter.Stop()
+
+		// when
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			err := rateLimiter.Wait(context.Background())
+			require.NoError(t, err)
+		}
+
+		// then
+		assert.True(t, time.Since(start) >= 90*time.Millisecond)
+	})
+
+	t.Run("should record time spent waiting", func(t *testing.T) {
+		// given
+		rateLimiter := NewRateLimiter(100, 1)
+		defer rateLimiter.Stop()
+
+		observationsBefore := waitObservations(t)
+
+		// when
+		rateLimiter.Accept()
+		err := rateLimiter.Wait(context.Background())
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, observationsBefore+2, waitObservations(t))
+	})
+}
+
+func waitObservations(t *testing.T) uint64 {
+	metric := dto.Metric{}
+	err := rateLimiterWaitDuration.Write(&metric)
+	require.NoError(t, err)
+
+	return metric.GetHistogram().GetSampleCount()
+}
@@ -1,7 +1,9 @@
 package gardener
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
 
@@ -11,28 +13,37 @@ import (
 
 	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 func NewShootController(
 	mgr manager.Manager,
 	dbsFactory dbsession.Factory,
-	auditLogTenantConfigPath string) (*ShootController, error) {
+	auditLogTenantConfigPath string,
+	shootLister ShootLister,
+	maxIdleTime time.Duration) (*ShootController, error) {
 
 	err := gardener_types.AddToScheme(mgr.GetScheme())
 	if err != nil {
 		return nil, fmt.Errorf("failed to add Gardener types to scheme: %s", err.Error())
 	}
 
+	reconciler := NewReconciler(mgr, dbsFactory, NewAuditLogConfigurator(auditLogTenantConfigPath))
+
 	err = ctrl.NewControllerManagedBy(mgr).
+		Named(shootControllerName).
 		For(&gardener_types.Shoot{}).
-		Complete(NewReconciler(mgr, dbsFactory, NewAuditLogConfigurator(auditLogTenantConfigPath)))
+		Complete(reconciler)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create controller: %w", err)
 	}
 
 	return &ShootController{
 		controllerManager: mgr,
+		reconciler:        reconciler,
+		shootLister:       shootLister,
+		maxIdleTime:       maxIdleTime,
 		log:               logrus.WithField("Component", "ShootController"),
 	}, nil
 }
@@ -40,14 +51,41 @@ func NewShootController(
 type ShootController struct {
 	namespace         string
 	controllerManager ctrl.Manager
+	reconciler        *Reconciler
+	shootLister       ShootLister
+	maxIdleTime       time.Duration
 	log               *logrus.Entry
 }
 
-func (sc *ShootController) StartShootController() error {
+// StartShootController blocks until the context is cancelled
+func (sc *ShootController) StartShootController(ctx context.Context) error {
 	// Start Controller
-	if err := sc.controllerManager.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := sc.controllerManager.Start(ctx); err != nil {
 		return fmt.Errorf("error starting shoot controller: %w", err)
 	}
 
 	return nil
 }
+
+// Ready fails if the controller has not processed any event for longer than the max idle time even though Shoots exist.
+// Every Shoot is reconciled at least once per resync period, so the max idle time has to be longer than the period.
+func (sc *ShootController) Ready() error {
+	if sc.maxIdleTime == 0 {
+		return nil
+	}
+
+	idleTime := time.Since(sc.reconciler.LastProcessed())
+	if idleTime <= sc.maxIdleTime {
+		return nil
+	}
+
+	shoots, err := sc.shootLister.List(context.Background(), v1.ListOptions{Limit: 1})
+	if err != nil {
+		return fmt.Errorf("failed to list Shoots: %w", err)
+	}
+	if len(shoots.Items) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("shoot controller has not processed any event for %s", idleTime.Round(time.Second))
+}
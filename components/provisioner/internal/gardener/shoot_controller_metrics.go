@@ -0,0 +1,85 @@
+package gardener
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	shootControllerName = "shoot"
+
+	reconcileResultSuccess = "success"
+	reconcileResultError   = "error"
+)
+
+var (
+	shootReconcilesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "shoot_controller_reconciles_total",
+		Help:      "The number of Shoot reconciliations by result",
+	}, []string{"result"})
+
+	shootReconcileDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "shoot_controller_reconcile_duration_seconds",
+		Help:      "Duration of Shoot reconciliations",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	})
+
+	shootLastSuccessfulReconcile = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "shoot_controller_last_successful_reconcile_timestamp_seconds",
+		Help:      "Unix time of the last successful Shoot reconciliation, Shoots are reconciled at least once per resync period",
+	})
+
+	shootWorkqueueDepth = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "shoot_controller_workqueue_depth",
+		Help:      "The number of Shoot events waiting to be reconciled",
+	}, func() float64 {
+		return workqueueDepth(ctrlmetrics.Registry, shootControllerName)
+	})
+)
+
+func observeReconcile(start time.Time, err error) {
+	now := time.Now()
+	shootReconcileDuration.Observe(now.Sub(start).Seconds())
+
+	if err != nil {
+		shootReconcilesTotal.WithLabelValues(reconcileResultError).Inc()
+		return
+	}
+
+	shootReconcilesTotal.WithLabelValues(reconcileResultSuccess).Inc()
+	shootLastSuccessfulReconcile.Set(float64(now.Unix()))
+}
+
+// workqueueDepth reads the depth of the controller workqueue from the metrics which controller-runtime
+// records in its own registry, the registry is not exposed by the Provisioner
+func workqueueDepth(gatherer prometheus.Gatherer, controllerName string) float64 {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return 0
+	}
+
+	for _, family := range families {
+		if family.GetName() != ctrlmetrics.WorkQueueSubsystem+"_"+ctrlmetrics.DepthKey {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "name" && label.GetValue() == controllerName {
+					return metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+
+	return 0
+}
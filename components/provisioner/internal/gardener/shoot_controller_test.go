@@ -0,0 +1,159 @@
+package gardener
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	shoot := &gardener_types.Shoot{
+		ObjectMeta: v1.ObjectMeta{Name: "shoot", Namespace: gardenerNamespace},
+	}
+	event := ctrl.Request{NamespacedName: types.NamespacedName{Name: "shoot", Namespace: gardenerNamespace}}
+
+	newReconciler := func(t *testing.T, readSession *sessionMocks.ReadSession) *Reconciler {
+		scheme := runtime.NewScheme()
+		require.NoError(t, gardener_types.AddToScheme(scheme))
+
+		dbsFactory := &sessionMocks.Factory{}
+		dbsFactory.On("NewReadSession").Return(readSession)
+
+		return &Reconciler{
+			client:               ctrlFake.NewClientBuilder().WithScheme(scheme).WithObjects(shoot).Build(),
+			scheme:               scheme,
+			log:                  logrus.WithField("Component", "ShootReconciler"),
+			dbsFactory:           dbsFactory,
+			auditLogConfigurator: NewAuditLogConfigurator(""),
+			lastProcessed:        time.Now().Add(-time.Hour).UnixNano(),
+		}
+	}
+
+	t.Run("should record successful reconciliation", func(t *testing.T) {
+		// given
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetGardenerClusterByName", "shoot").Return(model.Cluster{ID: "runtime-id"}, nil)
+
+		reconciler := newReconciler(t, readSession)
+		successes := testutil.ToFloat64(shootReconcilesTotal.WithLabelValues(reconcileResultSuccess))
+
+		// when
+		_, err := reconciler.Reconcile(context.Background(), event)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, successes+1, testutil.ToFloat64(shootReconcilesTotal.WithLabelValues(reconcileResultSuccess)))
+		assert.InDelta(t, float64(time.Now().Unix()), testutil.ToFloat64(shootLastSuccessfulReconcile), 1)
+		assert.WithinDuration(t, time.Now(), reconciler.LastProcessed(), time.Second)
+	})
+
+	t.Run("should record failed reconciliation as processed", func(t *testing.T) {
+		// given
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetGardenerClusterByName", "shoot").Return(model.Cluster{}, dberrors.Internal("error"))
+
+		reconciler := newReconciler(t, readSession)
+		failures := testutil.ToFloat64(shootReconcilesTotal.WithLabelValues(reconcileResultError))
+
+		// when
+		_, err := reconciler.Reconcile(context.Background(), event)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, failures+1, testutil.ToFloat64(shootReconcilesTotal.WithLabelValues(reconcileResultError)))
+		assert.WithinDuration(t, time.Now(), reconciler.LastProcessed(), time.Second)
+	})
+}
+
+func TestShootController_Ready(t *testing.T) {
+	shoot := &gardener_types.Shoot{
+		ObjectMeta: v1.ObjectMeta{Name: "shoot", Namespace: gardenerNamespace},
+	}
+
+	newController := func(lastProcessed time.Time, maxIdleTime time.Duration, shoots ...runtime.Object) *ShootController {
+		return &ShootController{
+			reconciler:  &Reconciler{lastProcessed: lastProcessed.UnixNano()},
+			shootLister: fake.NewSimpleClientset(shoots...).CoreV1beta1().Shoots(gardenerNamespace),
+			maxIdleTime: maxIdleTime,
+		}
+	}
+
+	t.Run("should be ready when event was processed within max idle time", func(t *testing.T) {
+		// given
+		controller := newController(time.Now().Add(-time.Minute), time.Hour, shoot)
+
+		// when
+		err := controller.Ready()
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should not be ready when no event was processed for too long and Shoots exist", func(t *testing.T) {
+		// given
+		controller := newController(time.Now().Add(-2*time.Hour), time.Hour, shoot)
+
+		// when
+		err := controller.Ready()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "has not processed any event")
+	})
+
+	t.Run("should be ready when no event was processed for too long and there are no Shoots", func(t *testing.T) {
+		// given
+		controller := newController(time.Now().Add(-2*time.Hour), time.Hour)
+
+		// when
+		err := controller.Ready()
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should be ready when check is disabled", func(t *testing.T) {
+		// given
+		controller := newController(time.Now().Add(-2*time.Hour), 0, shoot)
+
+		// when
+		err := controller.Ready()
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func TestWorkqueueDepth(t *testing.T) {
+	// given
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "workqueue_depth"}, []string{"name"})
+	depth.WithLabelValues("other").Set(7)
+	depth.WithLabelValues(shootControllerName).Set(3)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(depth)
+
+	// when
+	shootDepth := workqueueDepth(registry, shootControllerName)
+	missingDepth := workqueueDepth(prometheus.NewRegistry(), shootControllerName)
+
+	// then
+	assert.Equal(t, float64(3), shootDepth)
+	assert.Equal(t, float64(0), missingDepth)
+}
@@ -0,0 +1,129 @@
+package gardener
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+)
+
+const redactedValue = "<redacted>"
+
+// Values of the fields containing any of those words are not exposed in the diff
+var sensitiveFieldNames = []string{"secret", "password", "token", "credentials"}
+
+func diffShootSpecs(current, desired gardener_types.ShootSpec) ([]model.ShootSpecChange, apperrors.AppError) {
+	currentFields, err := toUnstructured(current)
+	if err != nil {
+		return nil, apperrors.Internal("failed to convert current Shoot spec: %s", err.Error())
+	}
+
+	desiredFields, err := toUnstructured(desired)
+	if err != nil {
+		return nil, apperrors.Internal("failed to convert desired Shoot spec: %s", err.Error())
+	}
+
+	changes := diffValues("spec", currentFields, desiredFields, []model.ShootSpecChange{})
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, nil
+}
+
+func toUnstructured(spec gardener_types.ShootSpec) (interface{}, error) {
+	marshalled, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var unstructured interface{}
+	err = json.Unmarshal(marshalled, &unstructured)
+
+	return unstructured, err
+}
+
+func diffValues(path string, current, desired interface{}, changes []model.ShootSpecChange) []model.ShootSpecChange {
+	if reflect.DeepEqual(current, desired) {
+		return changes
+	}
+
+	currentMap, currentIsMap := current.(map[string]interface{})
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+	if currentIsMap && desiredIsMap {
+		for _, key := range mergedKeys(currentMap, desiredMap) {
+			changes = diffValues(fmt.Sprintf("%s.%s", path, key), currentMap[key], desiredMap[key], changes)
+		}
+		return changes
+	}
+
+	currentSlice, currentIsSlice := current.([]interface{})
+	desiredSlice, desiredIsSlice := desired.([]interface{})
+	if currentIsSlice && desiredIsSlice {
+		for i := 0; i < len(currentSlice) || i < len(desiredSlice); i++ {
+			changes = diffValues(fmt.Sprintf("%s[%d]", path, i), elementAt(currentSlice, i), elementAt(desiredSlice, i), changes)
+		}
+		return changes
+	}
+
+	return append(changes, model.ShootSpecChange{
+		Path:     path,
+		OldValue: renderValue(path, current),
+		NewValue: renderValue(path, desired),
+	})
+}
+
+func mergedKeys(current, desired map[string]interface{}) []string {
+	keys := make([]string, 0, len(current)+len(desired))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	for key := range desired {
+		if _, found := current[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func elementAt(slice []interface{}, index int) interface{} {
+	if index < len(slice) {
+		return slice[index]
+	}
+	return nil
+}
+
+func renderValue(path string, value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	if isSensitive(path) {
+		return redactedValue
+	}
+
+	rendered, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(rendered)
+}
+
+func isSensitive(path string) bool {
+	lowerCasePath := strings.ToLower(path)
+	for _, name := range sensitiveFieldNames {
+		if strings.Contains(lowerCasePath, name) {
+			return true
+		}
+	}
+
+	return false
+}
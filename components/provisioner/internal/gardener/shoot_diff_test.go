@@ -0,0 +1,74 @@
+package gardener
+
+import (
+	"testing"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffShootSpecs(t *testing.T) {
+	t.Run("should return empty diff for equal specs", func(t *testing.T) {
+		// given
+		spec := gardener_types.ShootSpec{
+			Region:     "westeurope",
+			Kubernetes: gardener_types.Kubernetes{Version: "1.18.0"},
+		}
+
+		// when
+		changes, err := diffShootSpecs(spec, *spec.DeepCopy())
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("should return sorted changes of modified, added and removed fields", func(t *testing.T) {
+		// given
+		current := gardener_types.ShootSpec{
+			Region:     "westeurope",
+			Kubernetes: gardener_types.Kubernetes{Version: "1.18.0"},
+			Purpose:    purposePtr(gardener_types.ShootPurposeEvaluation),
+		}
+		desired := gardener_types.ShootSpec{
+			Region:     "westeurope",
+			Kubernetes: gardener_types.Kubernetes{Version: "1.19.0"},
+			Hibernation: &gardener_types.Hibernation{
+				Enabled: util.BoolPtr(true),
+			},
+		}
+
+		// when
+		changes, err := diffShootSpecs(current, desired)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []model.ShootSpecChange{
+			{Path: "spec.hibernation", OldValue: "", NewValue: `{"enabled":true}`},
+			{Path: "spec.kubernetes.version", OldValue: `"1.18.0"`, NewValue: `"1.19.0"`},
+			{Path: "spec.purpose", OldValue: `"evaluation"`, NewValue: ""},
+		}, changes)
+	})
+
+	t.Run("should redact sensitive values", func(t *testing.T) {
+		// given
+		current := gardener_types.ShootSpec{SecretBindingName: "old-binding"}
+		desired := gardener_types.ShootSpec{SecretBindingName: "new-binding"}
+
+		// when
+		changes, err := diffShootSpecs(current, desired)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []model.ShootSpecChange{
+			{Path: "spec.secretBindingName", OldValue: redactedValue, NewValue: redactedValue},
+		}, changes)
+	})
+}
+
+func purposePtr(purpose gardener_types.ShootPurpose) *gardener_types.ShootPurpose {
+	return &purpose
+}
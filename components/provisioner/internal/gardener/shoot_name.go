@@ -0,0 +1,205 @@
+package gardener
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// maxProjectAndShootNameLength is the limit of Gardener for the length of the project and Shoot names together
+const maxProjectAndShootNameLength = 21
+
+var (
+	shootNamesGeneratedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "shoot_names_generated_total",
+		Help:      "The number of Shoot names generated for Runtimes provisioned without the name",
+	})
+	shootNameCollisionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "shoot_name_collisions_total",
+		Help:      "The number of generated Shoot names discarded because they were already used, by the source of the collision",
+	}, []string{"source"})
+	shootNameGenerationFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "shoot_name_generation_failures_total",
+		Help:      "The number of Shoot name generations which failed, e.g. when all attempts collided with used names",
+	})
+)
+
+// ShootNameConfig configures the names generated for Shoots of Runtimes provisioned without the name.
+// The names consist of the Prefix followed by random characters up to the Length, and are regenerated at most MaxAttempts times
+// when they are already used. The DomainSuffix is the default domain of the landscape, the Shoot domain <shoot>.<project>.<suffix>
+// of both generated and requested names has to be a valid DNS name
+type ShootNameConfig struct {
+	Prefix       string `envconfig:"optional"`
+	Length       int    `envconfig:"default=7"`
+	MaxAttempts  int    `envconfig:"default=5"`
+	DomainSuffix string `envconfig:"optional"`
+}
+
+// Validate checks if the names generated with the config are valid in all Gardener projects
+func (c ShootNameConfig) Validate(projects []string) error {
+	if c.Length <= len(c.Prefix) {
+		return fmt.Errorf("Shoot name length %d has to be greater than the length of the prefix %q", c.Length, c.Prefix)
+	}
+	if c.MaxAttempts < 1 {
+		return fmt.Errorf("maximum number of Shoot name generation attempts has to be at least 1, got %d", c.MaxAttempts)
+	}
+
+	// The generated names always have the configured length, the random characters are lowercase letters and digits
+	example := c.Prefix + strings.Repeat("a", c.Length-len(c.Prefix))
+	for _, project := range projects {
+		if err := ValidateShootName(example, project, c.DomainSuffix); err != nil {
+			return errors.Wrap(err, "invalid Shoot name generation config")
+		}
+	}
+
+	return nil
+}
+
+// ValidateShootName checks the name of the Shoot against the constraints of Gardener: the name has to be a DNS label without
+// consecutive hyphens and cannot exceed the length limit together with the project name. When the domain suffix is known,
+// the default domain of the Shoot has to be a valid DNS name
+func ValidateShootName(name, project, domainSuffix string) error {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("Shoot name %q is invalid: %s", name, strings.Join(errs, ", "))
+	}
+	if strings.Contains(name, "--") {
+		return fmt.Errorf("Shoot name %q is invalid: must not contain consecutive hyphens", name)
+	}
+	if len(name)+len(project) > maxProjectAndShootNameLength {
+		return fmt.Errorf("Shoot name %q is too long: the Shoot and Gardener project %q names together must be no more than %d characters",
+			name, project, maxProjectAndShootNameLength)
+	}
+
+	if domainSuffix != "" {
+		domain := fmt.Sprintf("%s.%s.%s", name, project, domainSuffix)
+		if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+			return fmt.Errorf("Shoot name %q is invalid: domain %s of the Shoot is invalid: %s", name, domain, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+type ClusterByNameReader interface {
+	GetGardenerClusterByName(name string) (model.Cluster, dberrors.Error)
+}
+
+// ShootNameGenerator generates names of Shoots which are neither used by clusters stored in the database nor by Shoots
+// existing in the Gardener project, the random part of the names is taken from the UUIDs of the generator
+type ShootNameGenerator struct {
+	config        ShootNameConfig
+	projects      Projects
+	shootClients  ShootClients
+	reader        ClusterByNameReader
+	uuidGenerator uuid.UUIDGenerator
+
+	log logrus.FieldLogger
+}
+
+func NewShootNameGenerator(config ShootNameConfig, projects Projects, shootClients ShootClients, reader ClusterByNameReader, uuidGenerator uuid.UUIDGenerator) *ShootNameGenerator {
+	return &ShootNameGenerator{
+		config:        config,
+		projects:      projects,
+		shootClients:  shootClients,
+		reader:        reader,
+		uuidGenerator: uuidGenerator,
+		log:           logrus.WithField("Component", "ShootNameGenerator"),
+	}
+}
+
+// Generate returns the name of the new Shoot in the project, the default project is used if the project is empty.
+// The error is returned when all attempts collide with used names, e.g. because the names of the configured length are exhausted
+func (g *ShootNameGenerator) Generate(project string) (string, error) {
+	name, err := g.generate(project)
+	if err != nil {
+		shootNameGenerationFailuresTotal.Inc()
+		return "", err
+	}
+
+	shootNamesGeneratedTotal.Inc()
+	return name, nil
+}
+
+// ValidateShootName checks the name requested for the Shoot in the project, the default project is used if the project is empty
+func (g *ShootNameGenerator) ValidateShootName(name, project string) error {
+	return ValidateShootName(name, g.projectName(project), g.config.DomainSuffix)
+}
+
+func (g *ShootNameGenerator) generate(project string) (string, error) {
+	project = g.projectName(project)
+
+	shoots, err := g.shootClients.ForProject(project).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list Shoots of Gardener project %s", project)
+	}
+	existing := make(map[string]bool, len(shoots.Items))
+	for _, shoot := range shoots.Items {
+		existing[shoot.Name] = true
+	}
+
+	for attempt := 1; attempt <= g.config.MaxAttempts; attempt++ {
+		name := g.randomName()
+		if err := ValidateShootName(name, project, g.config.DomainSuffix); err != nil {
+			return "", err
+		}
+
+		if existing[name] {
+			g.log.Warnf("Generated Shoot name %s is used by a Shoot of Gardener project %s, attempt %d of %d", name, project, attempt, g.config.MaxAttempts)
+			shootNameCollisionsTotal.WithLabelValues("gardener").Inc()
+			continue
+		}
+
+		_, dberr := g.reader.GetGardenerClusterByName(name)
+		if dberr == nil {
+			g.log.Warnf("Generated Shoot name %s is used by a cluster, attempt %d of %d", name, attempt, g.config.MaxAttempts)
+			shootNameCollisionsTotal.WithLabelValues("database").Inc()
+			continue
+		}
+		if dberr.Code() != dberrors.CodeNotFound {
+			return "", errors.Wrapf(dberr, "failed to check if Shoot name %s is used", name)
+		}
+
+		return name, nil
+	}
+
+	return "", fmt.Errorf("failed to generate unused Shoot name for Gardener project %s in %d attempts, names of length %d with prefix %q may be exhausted",
+		project, g.config.MaxAttempts, g.config.Length, g.config.Prefix)
+}
+
+// randomName returns the prefix followed by the random hex digits of the UUIDs, the name starts with a letter as required for DNS labels
+func (g *ShootNameGenerator) randomName() string {
+	random := ""
+	for len(g.config.Prefix)+len(random) < g.config.Length {
+		random += strings.ReplaceAll(g.uuidGenerator.New(), "-", "")
+	}
+	name := g.config.Prefix + random[:g.config.Length-len(g.config.Prefix)]
+
+	if name[0] >= '0' && name[0] <= '9' {
+		// Digits are mapped to letters not used by hex digits, so that the names remain uniformly distributed
+		name = string(rune('g'+name[0]-'0')) + name[1:]
+	}
+
+	return name
+}
+
+func (g *ShootNameGenerator) projectName(project string) string {
+	if project == "" {
+		return g.projects.Default()
+	}
+	return project
+}
@@ -0,0 +1,238 @@
+package gardener
+
+import (
+	"strings"
+	"testing"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	uuidMocks "github.com/kyma-project/control-plane/components/provisioner/internal/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateShootName(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		name          string
+		project       string
+		domainSuffix  string
+		expectedError string
+	}{
+		{
+			description: "should accept valid name",
+			name:        "c-85b56ba",
+			project:     "kyma-dev",
+		},
+		{
+			description:   "should reject name with uppercase letters",
+			name:          "C85b56ba",
+			project:       "kyma-dev",
+			expectedError: "is invalid",
+		},
+		{
+			description:   "should reject name starting with hyphen",
+			name:          "-85b56ba",
+			project:       "kyma-dev",
+			expectedError: "is invalid",
+		},
+		{
+			description:   "should reject name with consecutive hyphens",
+			name:          "c--85b56",
+			project:       "kyma-dev",
+			expectedError: "consecutive hyphens",
+		},
+		{
+			description:   "should reject name exceeding length limit with project name",
+			name:          "c-85b56ba-long",
+			project:       "kyma-dev",
+			expectedError: "too long",
+		},
+		{
+			description:  "should accept name with valid domain",
+			name:         "c-85b56ba",
+			project:      "kyma-dev",
+			domainSuffix: "shoot.example.com",
+		},
+		{
+			description:   "should reject name with too long domain",
+			name:          "c-85b56ba",
+			project:       "kyma-dev",
+			domainSuffix:  strings.Repeat(strings.Repeat("a", 60)+".", 4) + "com",
+			expectedError: "domain",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := ValidateShootName(testCase.name, testCase.project, testCase.domainSuffix)
+
+			// then
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestShootNameConfig_Validate(t *testing.T) {
+	t.Run("should accept default config", func(t *testing.T) {
+		// when
+		err := ShootNameConfig{Length: 7, MaxAttempts: 5}.Validate([]string{"kyma-dev", "kyma-prod"})
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject length not greater than prefix", func(t *testing.T) {
+		// when
+		err := ShootNameConfig{Prefix: "kyma", Length: 4, MaxAttempts: 5}.Validate([]string{"kyma-dev"})
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should reject names too long for one of projects", func(t *testing.T) {
+		// when
+		err := ShootNameConfig{Length: 12, MaxAttempts: 5}.Validate([]string{"kyma-dev", "kyma-production"})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kyma-production")
+	})
+
+	t.Run("should reject invalid prefix", func(t *testing.T) {
+		// when
+		err := ShootNameConfig{Prefix: "Kyma-", Length: 9, MaxAttempts: 5}.Validate([]string{"kyma-dev"})
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should reject no attempts", func(t *testing.T) {
+		// when
+		err := ShootNameConfig{Length: 7}.Validate([]string{"kyma-dev"})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestShootNameGenerator_Generate(t *testing.T) {
+	projects := NewProjects("project", Landscapes{"trial": "trial"})
+	config := ShootNameConfig{Prefix: "k", Length: 7, MaxAttempts: 3}
+
+	shoot := func(name, project string) *gardener_types.Shoot {
+		return &gardener_types.Shoot{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: ProjectNamespace(project)}}
+	}
+
+	uuidGenerator := func(ids ...string) *uuidMocks.UUIDGenerator {
+		generator := &uuidMocks.UUIDGenerator{}
+		for _, id := range ids {
+			generator.On("New").Return(id).Once()
+		}
+		return generator
+	}
+
+	t.Run("should generate name with prefix and configured length", func(t *testing.T) {
+		// given
+		shootClients := NewShootClients(projects, fake.NewSimpleClientset().CoreV1beta1())
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetGardenerClusterByName", "k3e5a1b").Return(model.Cluster{}, dberrors.NotFound("not found"))
+
+		generator := NewShootNameGenerator(config, projects, shootClients, readSession, uuidGenerator("3e5a1b2c-0000-4000-8000-000000000000"))
+
+		// when
+		name, err := generator.Generate("")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "k3e5a1b", name)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("should start name without prefix with letter", func(t *testing.T) {
+		// given
+		shootClients := NewShootClients(projects, fake.NewSimpleClientset().CoreV1beta1())
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetGardenerClusterByName", "m4a5b6c").Return(model.Cluster{}, dberrors.NotFound("not found"))
+
+		generator := NewShootNameGenerator(ShootNameConfig{Length: 7, MaxAttempts: 1}, projects, shootClients, readSession, uuidGenerator("64a5b6c7-0000-4000-8000-000000000000"))
+
+		// when
+		name, err := generator.Generate("")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "m4a5b6c", name)
+	})
+
+	t.Run("should regenerate names used by Shoots and clusters", func(t *testing.T) {
+		// given
+		shootClients := NewShootClients(projects, fake.NewSimpleClientset(
+			shoot("kaaaaaa", "trial"),
+			shoot("kcccccc", "project"),
+		).CoreV1beta1())
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetGardenerClusterByName", "kbbbbbb").Return(model.Cluster{ID: "runtime"}, nil)
+		readSession.On("GetGardenerClusterByName", "kcccccc").Return(model.Cluster{}, dberrors.NotFound("not found"))
+
+		generator := NewShootNameGenerator(config, projects, shootClients, readSession, uuidGenerator(
+			"aaaaaaaa-0000-4000-8000-000000000000",
+			"bbbbbbbb-0000-4000-8000-000000000000",
+			"cccccccc-0000-4000-8000-000000000000",
+		))
+
+		// when
+		name, err := generator.Generate("trial")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "kcccccc", name)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("should return error when all attempts collide", func(t *testing.T) {
+		// given
+		shootClients := NewShootClients(projects, fake.NewSimpleClientset(shoot("kaaaaaa", "project")).CoreV1beta1())
+
+		generator := NewShootNameGenerator(config, projects, shootClients, &sessionMocks.ReadSession{}, uuidGenerator(
+			"aaaaaaaa-0000-4000-8000-000000000000",
+			"aaaaaaaa-0000-4000-8000-000000000000",
+			"aaaaaaaa-0000-4000-8000-000000000000",
+		))
+
+		// when
+		_, err := generator.Generate("project")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "in 3 attempts")
+	})
+
+	t.Run("should return error when used names cannot be checked", func(t *testing.T) {
+		// given
+		shootClients := NewShootClients(projects, fake.NewSimpleClientset().CoreV1beta1())
+
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetGardenerClusterByName", "kaaaaaa").Return(model.Cluster{}, dberrors.Internal("connection refused"))
+
+		generator := NewShootNameGenerator(config, projects, shootClients, readSession, uuidGenerator("aaaaaaaa-0000-4000-8000-000000000000"))
+
+		// when
+		_, err := generator.Generate("project")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
@@ -2,6 +2,10 @@ package gardener
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,6 +33,7 @@ func NewReconciler(
 
 		dbsFactory:           dbsFactory,
 		auditLogConfigurator: auditLogConfigurator,
+		lastProcessed:        time.Now().UnixNano(),
 	}
 }
 
@@ -40,9 +45,27 @@ type Reconciler struct {
 	log *logrus.Entry
 
 	auditLogConfigurator AuditLogConfigurator
+
+	// lastProcessed holds Unix time in nanoseconds of the last reconciliation regardless of its result
+	lastProcessed int64
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	result, err := r.reconcile(ctx, req)
+
+	observeReconcile(start, err)
+	atomic.StoreInt64(&r.lastProcessed, time.Now().UnixNano())
+
+	return result, err
+}
+
+// LastProcessed returns the time of the last reconciliation or the creation of the reconciler if no event was processed yet
+func (r *Reconciler) LastProcessed() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&r.lastProcessed))
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithField("Shoot", req.NamespacedName)
 	log.Infof("Reconciling Shoot")
 
@@ -56,7 +79,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
-	shouldReconcile, err := r.shouldReconcileShoot(shoot)
+	cluster, shouldReconcile, err := r.shouldReconcileShoot(shoot)
 	if err != nil {
 		log.Errorf("Failed to verify if shoot should be reconciled: %s", err.Error())
 		return ctrl.Result{}, err
@@ -68,6 +91,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	runtimeId := getRuntimeId(shoot)
 	log = log.WithField("RuntimeId", runtimeId)
 
+	err = r.reconcileHibernationStatus(log, shoot, cluster)
+	if err != nil {
+		log.Errorf("Failed to update hibernation status for %s shoot: %s", shoot.Name, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	seedName := getSeedName(shoot)
 
 	if r.auditLogConfigurator.CanEnableAuditLogsForShoot(seedName) {
@@ -80,19 +109,54 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return ctrl.Result{}, nil
 }
 
-func (r *Reconciler) shouldReconcileShoot(shoot gardener_types.Shoot) (bool, error) {
+func (r *Reconciler) shouldReconcileShoot(shoot gardener_types.Shoot) (model.Cluster, bool, error) {
 	session := r.dbsFactory.NewReadSession()
 
-	_, err := session.GetGardenerClusterByName(shoot.Name)
+	cluster, err := session.GetGardenerClusterByName(shoot.Name)
 	if err != nil {
 		if err.Code() == dberrors.CodeNotFound {
-			return false, nil
+			return model.Cluster{}, false, nil
 		}
 
-		return false, err
+		return model.Cluster{}, false, err
 	}
 
-	return true, nil
+	return cluster, true, nil
+}
+
+func (r *Reconciler) reconcileHibernationStatus(logger logrus.FieldLogger, shoot gardener_types.Shoot, cluster model.Cluster) error {
+	if cluster.Hibernated == shoot.Status.IsHibernated {
+		return nil
+	}
+
+	session := r.dbsFactory.NewReadWriteSession()
+	transitionTime := getLastOperationTime(shoot)
+
+	if shoot.Status.IsHibernated {
+		trigger := r.getHibernationTrigger(session, shoot, cluster.ID)
+		logger.Infof("Shoot hibernated, trigger: %s", trigger)
+
+		return session.MarkClusterAsHibernated(cluster.ID, transitionTime, trigger)
+	}
+
+	logger.Infof("Shoot woken up")
+	return session.MarkClusterAsWokenUp(cluster.ID, transitionTime)
+}
+
+// getHibernationTrigger treats hibernation as manual when it was requested through the Provisioner API
+// or directly in Gardener, and as scheduled when the Shoot has hibernation schedules and no operation was requested
+func (r *Reconciler) getHibernationTrigger(session dbsession.ReadSession, shoot gardener_types.Shoot, runtimeID string) model.HibernationTrigger {
+	lastOperation, err := session.GetLastOperation(runtimeID)
+	// Scheduled hibernation has not updated the Shoot yet while it waits in the StartingHibernation stage
+	if err == nil && lastOperation.Type == model.Hibernate && lastOperation.State == model.InProgress && lastOperation.Stage != model.StartingHibernation {
+		return model.HibernationTriggerManual
+	}
+
+	if shoot.Spec.Hibernation != nil && len(shoot.Spec.Hibernation.Schedules) > 0 {
+		return model.HibernationTriggerScheduled
+	}
+
+	return model.HibernationTriggerManual
 }
 
 func (r *Reconciler) updateShoot(modifiedShoot *gardener_types.Shoot) error {
@@ -127,6 +191,14 @@ func (r *Reconciler) enableAuditLogs(logger logrus.FieldLogger, shoot *gardener_
 	return r.updateShoot(shoot)
 }
 
+func getLastOperationTime(shoot gardener_types.Shoot) time.Time {
+	if shoot.Status.LastOperation != nil && !shoot.Status.LastOperation.LastUpdateTime.IsZero() {
+		return shoot.Status.LastOperation.LastUpdateTime.Time
+	}
+
+	return time.Now()
+}
+
 func getSeedName(shoot gardener_types.Shoot) string {
 	if shoot.Spec.SeedName != nil {
 		return *shoot.Spec.SeedName
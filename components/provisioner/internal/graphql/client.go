@@ -27,11 +27,17 @@ type client struct {
 	logging   bool
 }
 
-func NewGraphQLClient(graphqlEndpoint string, enableLogging bool, insecureSkipVerify bool) Client {
+// NewGraphQLClient creates the client, wrapTransport instruments the transport of the HTTP client if not nil
+func NewGraphQLClient(graphqlEndpoint string, enableLogging bool, insecureSkipVerify bool, wrapTransport func(rt http.RoundTripper) http.RoundTripper) Client {
+	var transport http.RoundTripper = &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureSkipVerify},
+	}
+	if wrapTransport != nil {
+		transport = wrapTransport(transport)
+	}
+
 	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureSkipVerify},
-		},
+		Transport: transport,
 	}
 
 	gqlClient := graphql.NewClient(graphqlEndpoint, graphql.WithHTTPClient(httpClient))
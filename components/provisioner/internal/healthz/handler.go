@@ -1,16 +1,43 @@
 package healthz
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-func NewHTTPHandler(log *logrus.Logger) func(writer http.ResponseWriter, request *http.Request) {
+// DetailsProvider adds details about the component to the verbose output of the health check
+type DetailsProvider interface {
+	HealthDetails() map[string]interface{}
+}
+
+// NewHTTPHandler responds with ok, the details of the providers are returned as JSON if the verbose query parameter is set
+func NewHTTPHandler(log *logrus.Logger, providers ...DetailsProvider) func(writer http.ResponseWriter, request *http.Request) {
 	return func(writer http.ResponseWriter, request *http.Request) {
+		body := []byte("ok")
+
+		if _, verbose := request.URL.Query()["verbose"]; verbose {
+			details := map[string]interface{}{"status": "ok"}
+			for _, provider := range providers {
+				for key, value := range provider.HealthDetails() {
+					details[key] = value
+				}
+			}
+
+			var err error
+			body, err = json.Marshal(details)
+			if err != nil {
+				log.Errorf(errors.Wrapf(err, "while marshalling health details").Error())
+				writer.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			writer.Header().Set("Content-Type", "application/json")
+		}
+
 		writer.WriteHeader(200)
-		_, err := writer.Write([]byte("ok"))
+		_, err := writer.Write(body)
 		if err != nil {
 			log.Errorf(errors.Wrapf(err, "while writing to response body").Error())
 		}
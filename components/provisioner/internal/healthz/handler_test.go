@@ -23,4 +23,24 @@ func TestNewHTTPHandler(t *testing.T) {
 		require.Equal(t, http.StatusOK, rr.Code)
 		require.Equal(t, "ok", rr.Body.String())
 	})
+	t.Run("should return details of providers when verbose", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/healthz?verbose", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(NewHTTPHandler(logrus.StandardLogger(), detailsFunc(func() map[string]interface{} {
+			return map[string]interface{}{"database": map[string]interface{}{"schemaVersion": 42, "awaitingMigration": false}}
+		})))
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"status":"ok","database":{"schemaVersion":42,"awaitingMigration":false}}`, rr.Body.String())
+	})
+}
+
+type detailsFunc func() map[string]interface{}
+
+func (f detailsFunc) HealthDetails() map[string]interface{} {
+	return f()
 }
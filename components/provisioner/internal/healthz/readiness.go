@@ -0,0 +1,33 @@
+package healthz
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ReadinessChecker reports an error when the component is not able to do its work
+type ReadinessChecker interface {
+	Ready() error
+}
+
+// NewReadinessHandler responds with 503 and the reason if any of the checkers is not ready
+func NewReadinessHandler(log *logrus.Logger, checkers ...ReadinessChecker) func(writer http.ResponseWriter, request *http.Request) {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		status, body := http.StatusOK, "ok"
+		for _, checker := range checkers {
+			if err := checker.Ready(); err != nil {
+				log.Warnf("Readiness check failed: %s", err.Error())
+				status, body = http.StatusServiceUnavailable, err.Error()
+				break
+			}
+		}
+
+		writer.WriteHeader(status)
+		_, err := writer.Write([]byte(body))
+		if err != nil {
+			log.Errorf(errors.Wrapf(err, "while writing to response body").Error())
+		}
+	}
+}
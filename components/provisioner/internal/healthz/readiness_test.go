@@ -0,0 +1,48 @@
+package healthz
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type checkerFunc func() error
+
+func (f checkerFunc) Ready() error {
+	return f()
+}
+
+func TestNewReadinessHandler(t *testing.T) {
+	ready := checkerFunc(func() error { return nil })
+	notReady := checkerFunc(func() error { return errors.New("shoot controller has not processed any event for 1h0m0s") })
+
+	t.Run("should return 200 when all checkers are ready", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/readyz", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(NewReadinessHandler(logrus.StandardLogger(), ready, ready))
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "ok", rr.Body.String())
+	})
+
+	t.Run("should return 503 with the reason when any checker is not ready", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/readyz", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(NewReadinessHandler(logrus.StandardLogger(), ready, notReady))
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		require.Equal(t, "shoot controller has not processed any event for 1h0m0s", rr.Body.String())
+	})
+}
@@ -0,0 +1,98 @@
+package installation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const DefaultCleanupTimeout = 10 * time.Minute
+
+// CleanupSelector selects resources removed from the cluster before it is deprovisioned, either Service Catalog
+// instances of brokers with the URL prefix or all resources of the kind
+type CleanupSelector struct {
+	URLPrefix string
+	Group     string
+	Kind      string
+	Timeout   time.Duration
+}
+
+func (s CleanupSelector) String() string {
+	if s.URLPrefix != "" {
+		return fmt.Sprintf("ServiceInstances of brokers %s*", s.URLPrefix)
+	}
+	if s.Group == "" {
+		return s.Kind
+	}
+
+	return fmt.Sprintf("%s.%s", s.Kind, s.Group)
+}
+
+// CleanupSelectors are processed in order, the value is either a single URL prefix or a JSON list of selectors, e.g.
+// [{"kind": "ServiceBinding", "group": "services.cloud.sap.com", "timeout": "5m"}, {"urlPrefix": "https://service-manager."}]
+type CleanupSelectors []CleanupSelector
+
+type cleanupSelectorInput struct {
+	URLPrefix string `json:"urlPrefix"`
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Timeout   string `json:"timeout"`
+}
+
+func (s *CleanupSelectors) Unmarshal(value string) error {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") {
+		*s = CleanupSelectors{{URLPrefix: value, Timeout: DefaultCleanupTimeout}}
+		return nil
+	}
+
+	var inputs []cleanupSelectorInput
+	if err := json.Unmarshal([]byte(value), &inputs); err != nil {
+		return errors.Wrap(err, "while decoding cleanup selectors")
+	}
+
+	selectors := make(CleanupSelectors, 0, len(inputs))
+	for i, input := range inputs {
+		selector := CleanupSelector{
+			URLPrefix: input.URLPrefix,
+			Group:     input.Group,
+			Kind:      input.Kind,
+			Timeout:   DefaultCleanupTimeout,
+		}
+		if (selector.URLPrefix == "") == (selector.Kind == "") {
+			return errors.Errorf("cleanup selector %d must define either urlPrefix or kind", i)
+		}
+		if input.Timeout != "" {
+			timeout, err := time.ParseDuration(input.Timeout)
+			if err != nil {
+				return errors.Wrapf(err, "while parsing timeout of cleanup selector %d", i)
+			}
+			selector.Timeout = timeout
+		}
+		selectors = append(selectors, selector)
+	}
+
+	*s = selectors
+	return nil
+}
+
+// CleanupSummary lists the selectors skipped because their cleanup did not finish within the timeout
+type CleanupSummary struct {
+	Skipped []CleanupSelector
+}
+
+func (s CleanupSummary) String() string {
+	if len(s.Skipped) == 0 {
+		return "Cluster cleanup finished"
+	}
+
+	skipped := make([]string, 0, len(s.Skipped))
+	for _, selector := range s.Skipped {
+		skipped = append(skipped, fmt.Sprintf("%s (timeout %s)", selector, selector.Timeout))
+	}
+
+	return fmt.Sprintf("Cluster cleanup finished, skipped: %s", strings.Join(skipped, ", "))
+}
@@ -0,0 +1,80 @@
+package installation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupSelectors_Unmarshal(t *testing.T) {
+	t.Run("should parse single URL prefix", func(t *testing.T) {
+		// given
+		var selectors CleanupSelectors
+
+		// when
+		err := selectors.Unmarshal("https://service-manager.")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, CleanupSelectors{{URLPrefix: "https://service-manager.", Timeout: DefaultCleanupTimeout}}, selectors)
+	})
+
+	t.Run("should parse list of selectors", func(t *testing.T) {
+		// given
+		var selectors CleanupSelectors
+
+		// when
+		err := selectors.Unmarshal(`[{"kind": "ServiceBinding", "group": "services.cloud.sap.com", "timeout": "5m"}, {"urlPrefix": "https://service-manager."}]`)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, CleanupSelectors{
+			{Kind: "ServiceBinding", Group: "services.cloud.sap.com", Timeout: 5 * time.Minute},
+			{URLPrefix: "https://service-manager.", Timeout: DefaultCleanupTimeout},
+		}, selectors)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		value       string
+	}{
+		{description: "invalid JSON", value: `[{"kind": }]`},
+		{description: "selector without urlPrefix and kind", value: `[{"group": "services.cloud.sap.com"}]`},
+		{description: "selector with both urlPrefix and kind", value: `[{"urlPrefix": "https://service-manager.", "kind": "ServiceInstance"}]`},
+		{description: "invalid timeout", value: `[{"kind": "ServiceInstance", "timeout": "five minutes"}]`},
+	} {
+		t.Run("should return error for "+testCase.description, func(t *testing.T) {
+			// given
+			var selectors CleanupSelectors
+
+			// when
+			err := selectors.Unmarshal(testCase.value)
+
+			// then
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestCleanupSummary_String(t *testing.T) {
+	t.Run("should describe finished cleanup", func(t *testing.T) {
+		assert.Equal(t, "Cluster cleanup finished", CleanupSummary{}.String())
+	})
+
+	t.Run("should list skipped selectors", func(t *testing.T) {
+		// given
+		summary := CleanupSummary{Skipped: []CleanupSelector{
+			{Kind: "ServiceBinding", Group: "services.cloud.sap.com", Timeout: 5 * time.Minute},
+			{URLPrefix: "https://service-manager.", Timeout: DefaultCleanupTimeout},
+		}}
+
+		// when
+		result := summary.String()
+
+		// then
+		assert.Equal(t, "Cluster cleanup finished, skipped: ServiceBinding.services.cloud.sap.com (timeout 5m0s), "+
+			"ServiceInstances of brokers https://service-manager.* (timeout 10m0s)", result)
+	})
+}
@@ -0,0 +1,132 @@
+package installation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const installationKind = "Installation"
+
+var yamlDocumentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// ValidateComponents cross-checks the requested components with the components of the Installation CR from the installer YAML of the release.
+// Unknown components, components in a namespace other than the one defined by the release and invalid source URLs are rejected,
+// unless extra components are allowed, e.g. for custom Kyma builds. Conflicting duplicate entries are always rejected.
+func ValidateComponents(kymaConfig model.KymaConfig, extraComponentsAllowed bool) error {
+	problems := duplicatedComponents(kymaConfig.Components)
+
+	if !extraComponentsAllowed {
+		releaseComponents := ReleaseComponents(kymaConfig.Release)
+		problems = append(problems, unknownComponents(kymaConfig.Components, releaseComponents, kymaConfig.Release.Version)...)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid components: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// ReleaseComponents returns the components defined by the Installation CR of the release, nil if the installer YAML does not contain it.
+// Documents which are not Kubernetes resources are skipped.
+func ReleaseComponents(release model.Release) []v1alpha1.KymaComponent {
+	for _, document := range yamlDocumentSeparator.Split(release.InstallerYAML, -1) {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+
+		var resource struct {
+			Kind string                    `json:"kind"`
+			Spec v1alpha1.InstallationSpec `json:"spec"`
+		}
+		if err := yaml.Unmarshal([]byte(document), &resource); err != nil {
+			continue
+		}
+
+		if resource.Kind == installationKind {
+			return resource.Spec.Components
+		}
+	}
+
+	return nil
+}
+
+func duplicatedComponents(components []model.KymaComponentConfig) []string {
+	var problems []string
+	seen := map[model.KymaComponent]model.KymaComponentConfig{}
+
+	for _, component := range components {
+		first, found := seen[component.Component]
+		if !found {
+			seen[component.Component] = component
+			continue
+		}
+
+		switch {
+		case first.Namespace != component.Namespace:
+			problems = append(problems, fmt.Sprintf("%s: duplicated with conflicting namespaces %s and %s", component.Component, first.Namespace, component.Namespace))
+		case sourceURL(first) != sourceURL(component):
+			problems = append(problems, fmt.Sprintf("%s: duplicated with conflicting source URLs", component.Component))
+		default:
+			problems = append(problems, fmt.Sprintf("%s: duplicated", component.Component))
+		}
+	}
+
+	return problems
+}
+
+func unknownComponents(components []model.KymaComponentConfig, releaseComponents []v1alpha1.KymaComponent, version string) []string {
+	var problems []string
+
+	// Releases without the Installation CR cannot be verified
+	if releaseComponents == nil {
+		return problems
+	}
+
+	namespaces := make(map[string]string, len(releaseComponents))
+	for _, component := range releaseComponents {
+		namespaces[component.Name] = component.Namespace
+	}
+
+	for _, component := range components {
+		if source := sourceURL(component); source != "" {
+			if !isValidSourceURL(source) {
+				problems = append(problems, fmt.Sprintf("%s: invalid source URL", component.Component))
+			}
+			// Components with the source URL are installed from the custom location and do not have to be part of the release
+			continue
+		}
+
+		namespace, found := namespaces[string(component.Component)]
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: not part of Kyma release %s", component.Component, version))
+			continue
+		}
+		if namespace != component.Namespace {
+			problems = append(problems, fmt.Sprintf("%s: namespace %s does not match namespace %s defined by the release", component.Component, component.Namespace, namespace))
+		}
+	}
+
+	return problems
+}
+
+func sourceURL(component model.KymaComponentConfig) string {
+	if component.SourceURL == nil {
+		return ""
+	}
+	return *component.SourceURL
+}
+
+func isValidSourceURL(source string) bool {
+	parsed, err := url.Parse(source)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
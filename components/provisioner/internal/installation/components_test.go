@@ -0,0 +1,104 @@
+package installation
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const releaseInstallerYAML = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: kyma-installer
+---
+apiVersion: "installer.kyma-project.io/v1alpha1"
+kind: Installation
+metadata:
+  name: kyma-installation
+spec:
+  components:
+    - name: "cluster-essentials"
+      namespace: "kyma-system"
+    - name: "monitoring"
+      namespace: "kyma-system"
+    - name: "application-connector"
+      namespace: "kyma-integration"
+`
+
+func TestValidateComponents(t *testing.T) {
+	kymaConfig := func(components ...model.KymaComponentConfig) model.KymaConfig {
+		return model.KymaConfig{
+			Release:    model.Release{Version: "1.24.0", InstallerYAML: releaseInstallerYAML},
+			Components: components,
+		}
+	}
+
+	t.Run("should accept components of the release", func(t *testing.T) {
+		// given
+		config := kymaConfig(
+			model.KymaComponentConfig{Component: "cluster-essentials", Namespace: "kyma-system"},
+			model.KymaComponentConfig{Component: "application-connector", Namespace: "kyma-integration"},
+			model.KymaComponentConfig{Component: "custom", Namespace: "custom-system", SourceURL: util.StringPtr("https://storage.local/custom.tgz")},
+		)
+
+		// when
+		err := ValidateComponents(config, false)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should list all invalid components", func(t *testing.T) {
+		// given
+		config := kymaConfig(
+			model.KymaComponentConfig{Component: "monitorng", Namespace: "kyma-system"},
+			model.KymaComponentConfig{Component: "application-connector", Namespace: "kyma-system"},
+			model.KymaComponentConfig{Component: "custom", Namespace: "custom-system", SourceURL: util.StringPtr("storage.local/custom.tgz")},
+			model.KymaComponentConfig{Component: "monitoring", Namespace: "kyma-system"},
+			model.KymaComponentConfig{Component: "monitoring", Namespace: "monitoring"},
+		)
+
+		// when
+		err := ValidateComponents(config, false)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "monitorng: not part of Kyma release 1.24.0")
+		assert.Contains(t, err.Error(), "application-connector: namespace kyma-system does not match namespace kyma-integration defined by the release")
+		assert.Contains(t, err.Error(), "custom: invalid source URL")
+		assert.Contains(t, err.Error(), "monitoring: duplicated with conflicting namespaces kyma-system and monitoring")
+	})
+
+	t.Run("should reject only duplicates when extra components are allowed", func(t *testing.T) {
+		// given
+		config := kymaConfig(
+			model.KymaComponentConfig{Component: "monitorng", Namespace: "kyma-system"},
+			model.KymaComponentConfig{Component: "custom", Namespace: "custom-system", SourceURL: util.StringPtr("https://storage.local/custom.tgz")},
+			model.KymaComponentConfig{Component: "custom", Namespace: "custom-system", SourceURL: util.StringPtr("https://storage.local/custom-2.tgz")},
+		)
+
+		// when
+		err := ValidateComponents(config, true)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, "invalid components: custom: duplicated with conflicting source URLs", err.Error())
+	})
+
+	t.Run("should skip release checks if installer YAML does not contain Installation CR", func(t *testing.T) {
+		// given
+		config := model.KymaConfig{
+			Release:    model.Release{Version: "1.24.0", InstallerYAML: "installer yaml"},
+			Components: []model.KymaComponentConfig{{Component: "monitorng", Namespace: "kyma-system"}},
+		}
+
+		// when
+		err := ValidateComponents(config, false)
+
+		// then
+		require.NoError(t, err)
+	})
+}
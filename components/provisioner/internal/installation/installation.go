@@ -9,6 +9,10 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 
 	"github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
+	installationClientset "github.com/kyma-project/kyma/components/kyma-operator/pkg/client/clientset/versioned"
+	installationTyped "github.com/kyma-project/kyma/components/kyma-operator/pkg/client/clientset/versioned/typed/installer/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/sirupsen/logrus"
 
@@ -25,6 +29,9 @@ const (
 
 	installAction = "installation"
 	upgradeAction = "upgrade"
+
+	kymaInstallationName      = "kyma-installation"
+	kymaInstallationNamespace = "default"
 )
 
 type InstallationHandler func(*rest.Config, ...installation.InstallationOption) (installation.Installer, error)
@@ -32,32 +39,83 @@ type InstallationHandler func(*rest.Config, ...installation.InstallationOption)
 //go:generate mockery -name=Service
 type Service interface {
 	CheckInstallationState(kubeconfig *rest.Config) (installation.InstallationState, error)
+	GetInstallationSpec(kubeconfig *rest.Config) (*v1alpha1.InstallationSpec, error)
 	TriggerInstallation(kubeconfigRaw *rest.Config, kymaProfile *model.KymaProfile, release model.Release, globalConfig model.Configuration, componentsConfig []model.KymaComponentConfig) error
 	TriggerUpgrade(kubeconfigRaw *rest.Config, kymaProfile *model.KymaProfile, release model.Release, globalConfig model.Configuration, componentsConfig []model.KymaComponentConfig) error
 	TriggerUninstall(kubeconfig *rest.Config) error
-	PerformCleanup(kubeconfig *rest.Config) error
+	PerformCleanup(kubeconfig *rest.Config) (CleanupSummary, error)
 }
 
-func NewInstallationService(installationTimeout time.Duration, installationHandler InstallationHandler, clusterCleanupResourceSelector string) Service {
+type CleanupClientConstructor func(kubeconfig *rest.Config, selector CleanupSelector) (CleanupClient, error)
+
+type InstallationClientConstructor func(kubeconfig *rest.Config) (installationTyped.InstallationInterface, error)
+
+func NewInstallationService(installationTimeout time.Duration, installationHandler InstallationHandler, clusterCleanupSelectors CleanupSelectors) Service {
 	return &installationService{
-		kymaInstallationTimeout:        installationTimeout,
-		installationHandler:            installationHandler,
-		clusterCleanupResourceSelector: clusterCleanupResourceSelector,
+		kymaInstallationTimeout: installationTimeout,
+		installationHandler:     installationHandler,
+		clusterCleanupSelectors: clusterCleanupSelectors,
+		newCleanupClient:        NewCleanupClient,
+		newInstallationClient:   NewInstallationClient,
 	}
 }
 
-type installationService struct {
-	kymaInstallationTimeout        time.Duration
-	installationHandler            InstallationHandler
-	clusterCleanupResourceSelector string
+// NewCleanupClient creates the client removing resources matched by the selector
+func NewCleanupClient(kubeconfig *rest.Config, selector CleanupSelector) (CleanupClient, error) {
+	if selector.URLPrefix != "" {
+		return NewServiceCatalogCleanupClient(kubeconfig)
+	}
+	return NewResourceKindCleanupClient(kubeconfig)
 }
 
-func (s *installationService) PerformCleanup(kubeconfig *rest.Config) error {
-	cli, err := NewServiceCatalogCleanupClient(kubeconfig)
+// NewInstallationClient creates the client of the Installation CRs in the namespace used by the Kyma Installer
+func NewInstallationClient(kubeconfig *rest.Config) (installationTyped.InstallationInterface, error) {
+	clientset, err := installationClientset.NewForConfig(kubeconfig)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return clientset.InstallerV1alpha1().Installations(kymaInstallationNamespace), nil
+}
+
+type installationService struct {
+	kymaInstallationTimeout time.Duration
+	installationHandler     InstallationHandler
+	clusterCleanupSelectors CleanupSelectors
+	newCleanupClient        CleanupClientConstructor
+	newInstallationClient   InstallationClientConstructor
+}
+
+// PerformCleanup processes the cleanup selectors in order, a selector which cleanup does not finish within its timeout
+// is skipped and reported in the summary
+func (s *installationService) PerformCleanup(kubeconfig *rest.Config) (CleanupSummary, error) {
+	summary := CleanupSummary{}
+	for _, selector := range s.clusterCleanupSelectors {
+		cli, err := s.newCleanupClient(kubeconfig, selector)
+		if err != nil {
+			return summary, pkgErrors.Wrapf(err, "while creating cleanup client for %s", selector)
+		}
+
+		timedOut, err := performCleanup(cli, selector)
+		if timedOut {
+			logrus.Warnf("Cleanup of %s not finished within %s, skipping", selector, selector.Timeout)
+			summary.Skipped = append(summary.Skipped, selector)
+			continue
+		}
+		if err != nil {
+			return summary, pkgErrors.Wrapf(err, "while cleaning up %s", selector)
+		}
 	}
-	return cli.PerformCleanup(s.clusterCleanupResourceSelector)
+
+	return summary, nil
+}
+
+func performCleanup(cli CleanupClient, selector CleanupSelector) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), selector.Timeout)
+	defer cancel()
+
+	err := cli.PerformCleanup(ctx, selector)
+	return ctx.Err() == context.DeadlineExceeded, err
 }
 
 func (s *installationService) TriggerInstallation(kubeconfig *rest.Config, kymaProfile *model.KymaProfile, release model.Release, globalConfig model.Configuration, componentsConfig []model.KymaComponentConfig) error {
@@ -122,6 +180,38 @@ func (s *installationService) CheckInstallationState(kubeconfig *rest.Config) (i
 	return installation.CheckInstallationState(kubeconfig)
 }
 
+// GetInstallationSpec returns the spec of the Kyma Installation CR or nil if there is no Installation CR on the cluster
+func (s *installationService) GetInstallationSpec(kubeconfig *rest.Config) (*v1alpha1.InstallationSpec, error) {
+	client, err := s.newInstallationClient(kubeconfig)
+	if err != nil {
+		return nil, pkgErrors.Wrap(err, "while creating Installation client")
+	}
+
+	installationCR, err := client.Get(context.Background(), kymaInstallationName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, pkgErrors.Wrap(err, "while getting Installation CR")
+	}
+
+	return &installationCR.Spec, nil
+}
+
+// IsSameInstallation checks if the Installation CR installs the given Kyma release with the given profile,
+// the version and the profile are compared only if they are set
+func IsSameInstallation(spec v1alpha1.InstallationSpec, kymaProfile *model.KymaProfile, release model.Release) bool {
+	if spec.KymaVersion != "" && release.Version != "" && spec.KymaVersion != release.Version {
+		return false
+	}
+
+	if kymaProfile != nil && spec.Profile != toKymaProfile(*kymaProfile) {
+		return false
+	}
+
+	return true
+}
+
 func (s *installationService) TriggerUninstall(kubeconfig *rest.Config) error {
 	return installation.TriggerUninstall(kubeconfig)
 }
@@ -4,12 +4,14 @@ package mocks
 
 import (
 	installation "github.com/kyma-incubator/hydroform/install/installation"
-
+	internalinstallation "github.com/kyma-project/control-plane/components/provisioner/internal/installation"
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/kyma-project/control-plane/components/provisioner/internal/model"
 
 	rest "k8s.io/client-go/rest"
+
+	v1alpha1 "github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -38,18 +40,48 @@ func (_m *Service) CheckInstallationState(kubeconfig *rest.Config) (installation
 	return r0, r1
 }
 
+// GetInstallationSpec provides a mock function with given fields: kubeconfig
+func (_m *Service) GetInstallationSpec(kubeconfig *rest.Config) (*v1alpha1.InstallationSpec, error) {
+	ret := _m.Called(kubeconfig)
+
+	var r0 *v1alpha1.InstallationSpec
+	if rf, ok := ret.Get(0).(func(*rest.Config) *v1alpha1.InstallationSpec); ok {
+		r0 = rf(kubeconfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.InstallationSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*rest.Config) error); ok {
+		r1 = rf(kubeconfig)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PerformCleanup provides a mock function with given fields: kubeconfig
-func (_m *Service) PerformCleanup(kubeconfig *rest.Config) error {
+func (_m *Service) PerformCleanup(kubeconfig *rest.Config) (internalinstallation.CleanupSummary, error) {
 	ret := _m.Called(kubeconfig)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*rest.Config) error); ok {
+	var r0 internalinstallation.CleanupSummary
+	if rf, ok := ret.Get(0).(func(*rest.Config) internalinstallation.CleanupSummary); ok {
 		r0 = rf(kubeconfig)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(internalinstallation.CleanupSummary)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*rest.Config) error); ok {
+		r1 = rf(kubeconfig)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // TriggerInstallation provides a mock function with given fields: kubeconfigRaw, kymaProfile, release, globalConfig, componentsConfig
@@ -0,0 +1,115 @@
+package installation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"sigs.k8s.io/yaml"
+)
+
+const globalOverrides = "global"
+
+var overrideKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*$`)
+
+// ResourceKind is a kind of the resource which has to exist in the cluster before the installation
+type ResourceKind string
+
+const (
+	SecretKind    ResourceKind = "Secret"
+	ConfigMapKind ResourceKind = "ConfigMap"
+)
+
+// ResourceReference is a resource referenced by the override, following the Helm charts convention of existingSecret and existingConfigMap values
+type ResourceReference struct {
+	Kind      ResourceKind
+	Name      string
+	Namespace string
+	Override  string
+}
+
+// ValidateOverrides checks syntax of keys and values of the overrides.
+// Returned error lists keys of the invalid overrides only, so values of secret overrides are never exposed.
+func ValidateOverrides(kymaConfig model.KymaConfig) error {
+	var invalid []string
+
+	invalid = append(invalid, invalidOverrides(globalOverrides, kymaConfig.GlobalConfiguration.ConfigEntries)...)
+	for _, component := range kymaConfig.Components {
+		invalid = append(invalid, invalidOverrides(string(component.Component), component.Configuration.ConfigEntries)...)
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid overrides: %s", strings.Join(invalid, "; "))
+	}
+
+	return nil
+}
+
+// ReferencedResources returns resources referenced by the component overrides, they are expected in the component namespace
+func ReferencedResources(kymaConfig model.KymaConfig) []ResourceReference {
+	var references []ResourceReference
+
+	for _, component := range kymaConfig.Components {
+		for _, entry := range component.Configuration.ConfigEntries {
+			kind, ok := referencedResourceKind(entry.Key)
+			if !ok || entry.Value == "" {
+				continue
+			}
+
+			references = append(references, ResourceReference{
+				Kind:      kind,
+				Name:      entry.Value,
+				Namespace: component.Namespace,
+				Override:  overrideName(string(component.Component), entry.Key),
+			})
+		}
+	}
+
+	return references
+}
+
+func invalidOverrides(component string, entries []model.ConfigEntry) []string {
+	var invalid []string
+
+	for _, entry := range entries {
+		if !overrideKeyRegex.MatchString(entry.Key) {
+			invalid = append(invalid, fmt.Sprintf("%s: invalid key syntax", overrideName(component, entry.Key)))
+			continue
+		}
+
+		if !isValidValue(entry.Value) {
+			invalid = append(invalid, fmt.Sprintf("%s: malformed YAML value", overrideName(component, entry.Key)))
+		}
+	}
+
+	return invalid
+}
+
+// Only structured values are parsed, as plain values (e.g. passwords) may contain characters with special meaning in YAML
+func isValidValue(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") && !strings.Contains(trimmed, "\n") {
+		return true
+	}
+
+	var parsed interface{}
+	return yaml.Unmarshal([]byte(value), &parsed) == nil
+}
+
+func referencedResourceKind(key string) (ResourceKind, bool) {
+	segments := strings.Split(key, ".")
+
+	switch strings.ToLower(segments[len(segments)-1]) {
+	case "existingsecret":
+		return SecretKind, true
+	case "existingconfigmap":
+		return ConfigMapKind, true
+	default:
+		return "", false
+	}
+}
+
+func overrideName(component, key string) string {
+	return fmt.Sprintf("%s override '%s'", component, key)
+}
@@ -0,0 +1,96 @@
+package installation
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOverrides(t *testing.T) {
+	kymaConfig := func(globalEntries []model.ConfigEntry, componentEntries []model.ConfigEntry) model.KymaConfig {
+		return model.KymaConfig{
+			GlobalConfiguration: model.Configuration{ConfigEntries: globalEntries},
+			Components: []model.KymaComponentConfig{
+				{
+					Component:     "monitoring",
+					Namespace:     "kyma-system",
+					Configuration: model.Configuration{ConfigEntries: componentEntries},
+				},
+			},
+		}
+	}
+
+	t.Run("should accept valid overrides", func(t *testing.T) {
+		// given
+		config := kymaConfig(
+			[]model.ConfigEntry{
+				model.NewConfigEntry("global.domainName", "kyma.local", false),
+				model.NewConfigEntry("global.password", "*secret: {", true),
+			},
+			[]model.ConfigEntry{
+				model.NewConfigEntry("alertmanager.config_map-name", "alerts", false),
+				model.NewConfigEntry("prometheus.resources", "{limits: {memory: 4Gi}}", false),
+				model.NewConfigEntry("prometheus.rules", "- name: rule\n  expr: up == 0", false),
+			})
+
+		// when
+		err := ValidateOverrides(config)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should list all invalid overrides without values", func(t *testing.T) {
+		// given
+		config := kymaConfig(
+			[]model.ConfigEntry{
+				model.NewConfigEntry("global..domainName", "kyma.local", false),
+			},
+			[]model.ConfigEntry{
+				model.NewConfigEntry("prometheus.resources", "{limits: [secret-value", true),
+				model.NewConfigEntry("grafana enabled", "true", false),
+			})
+
+		// when
+		err := ValidateOverrides(config)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "global override 'global..domainName': invalid key syntax")
+		assert.Contains(t, err.Error(), "monitoring override 'prometheus.resources': malformed YAML value")
+		assert.Contains(t, err.Error(), "monitoring override 'grafana enabled': invalid key syntax")
+		assert.NotContains(t, err.Error(), "secret-value")
+	})
+}
+
+func TestReferencedResources(t *testing.T) {
+	// given
+	config := model.KymaConfig{
+		GlobalConfiguration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+			model.NewConfigEntry("global.existingSecret", "global-secret", false),
+		}},
+		Components: []model.KymaComponentConfig{
+			{
+				Component: "monitoring",
+				Namespace: "kyma-system",
+				Configuration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+					model.NewConfigEntry("grafana.auth.existingSecret", "grafana-auth", false),
+					model.NewConfigEntry("alertmanager.existingConfigMap", "alerts", false),
+					model.NewConfigEntry("prometheus.existingSecret", "", false),
+					model.NewConfigEntry("grafana.enabled", "true", false),
+				}},
+			},
+		},
+	}
+
+	// when
+	references := ReferencedResources(config)
+
+	// then
+	assert.Equal(t, []ResourceReference{
+		{Kind: SecretKind, Name: "grafana-auth", Namespace: "kyma-system", Override: "monitoring override 'grafana.auth.existingSecret'"},
+		{Kind: ConfigMapKind, Name: "alerts", Namespace: "kyma-system", Override: "monitoring override 'alertmanager.existingConfigMap'"},
+	}, references)
+}
@@ -1,64 +1,137 @@
 package release
 
 import (
-	"io/ioutil"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 	"github.com/pkg/errors"
 )
 
-type httpGetter interface {
-	Get(url string) (resp *http.Response, err error)
+// maxResumeAttempts limits how many times an interrupted download is resumed with a range request
+const maxResumeAttempts = 3
+
+type httpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
 }
 
 // FileDownloader downloads text files
 type FileDownloader struct {
-	httpGetter httpGetter
+	httpClient httpDoer
+	// timeout limits a single download including its resumptions, 0 means no limit
+	timeout time.Duration
 }
 
-func NewFileDownloader(getter httpGetter) *FileDownloader {
+func NewFileDownloader(client httpDoer, timeout time.Duration) *FileDownloader {
 	return &FileDownloader{
-		httpGetter: getter,
+		httpClient: client,
+		timeout:    timeout,
 	}
 }
 
 // Download downloads text file
 func (fd *FileDownloader) Download(url string) (string, error) {
-	resp, err := fd.httpGetter.Get(url)
-	if err != nil {
-		return "", errors.Wrapf(err, "while executing get request on url: %q", url)
-	}
-	defer util.Close(resp.Body)
-
-	return fd.readResponse(resp)
+	content, _, err := fd.download(url)
+	return content, err
 }
 
 // DownloadOrEmpty downloads text file
 // If the response status is 404 return empty string with no error
 func (fd *FileDownloader) DownloadOrEmpty(url string) (string, error) {
-	resp, err := fd.httpGetter.Get(url)
-	if err != nil {
-		return "", errors.Wrapf(err, "while executing get request on url: %q", url)
-	}
-	defer util.Close(resp.Body)
-
-	if resp.StatusCode == http.StatusNotFound {
+	content, notFound, err := fd.download(url)
+	if notFound {
 		return "", nil
 	}
-
-	return fd.readResponse(resp)
+	return content, err
 }
 
-func (fd *FileDownloader) readResponse(resp *http.Response) (string, error) {
-	if resp.StatusCode != http.StatusOK {
-		return "", errors.Errorf("received unexpected http status %d", resp.StatusCode)
+// download fetches the file and resumes it with a range request if the transfer is interrupted
+// and the server accepts ranges, notFound is reported for 404 responses to the initial request
+func (fd *FileDownloader) download(url string) (content string, notFound bool, err error) {
+	ctx := context.Background()
+	if fd.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fd.timeout)
+		defer cancel()
 	}
 
-	reqBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", errors.Wrap(err, "while reading body")
+	var (
+		buffer    bytes.Buffer
+		total     int64 = -1
+		validator string
+		resumable bool
+	)
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "while creating get request on url: %q", url)
+		}
+		if buffer.Len() > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", buffer.Len()))
+			if validator != "" {
+				req.Header.Set("If-Range", validator)
+			}
+		}
+
+		resp, err := fd.httpClient.Do(req)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "while executing get request on url: %q", url)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotFound && attempt == 0:
+			util.Close(resp.Body)
+			return "", true, errors.Errorf("received unexpected http status %d", resp.StatusCode)
+		case resp.StatusCode == http.StatusOK:
+			// the server sent the whole file, either on the first request or because it ignored the range
+			buffer.Reset()
+			total = -1
+			if resp.ContentLength > 0 {
+				total = resp.ContentLength
+			}
+			validator = resp.Header.Get("ETag")
+			if validator == "" {
+				validator = resp.Header.Get("Last-Modified")
+			}
+			resumable = resp.Header.Get("Accept-Ranges") == "bytes"
+		case resp.StatusCode == http.StatusPartialContent && buffer.Len() > 0:
+			start, err := contentRangeStart(resp.Header.Get("Content-Range"))
+			if err != nil || start != int64(buffer.Len()) {
+				util.Close(resp.Body)
+				return "", false, errors.Errorf("received unexpected content range %q for url: %q", resp.Header.Get("Content-Range"), url)
+			}
+		default:
+			util.Close(resp.Body)
+			return "", false, errors.Errorf("received unexpected http status %d", resp.StatusCode)
+		}
+
+		_, err = io.Copy(&buffer, resp.Body)
+		util.Close(resp.Body)
+		if err == nil {
+			if total >= 0 && int64(buffer.Len()) != total {
+				return "", false, errors.Errorf("downloaded %d of %d bytes from url: %q", buffer.Len(), total, url)
+			}
+			return buffer.String(), false, nil
+		}
+
+		if !resumable || attempt >= maxResumeAttempts || ctx.Err() != nil {
+			return "", false, errors.Wrap(err, "while reading body")
+		}
 	}
+}
 
-	return string(reqBody), nil
+// contentRangeStart returns the first byte position of the "bytes start-end/total" header value
+func contentRangeStart(contentRange string) (int64, error) {
+	value := strings.TrimPrefix(contentRange, "bytes ")
+	dash := strings.Index(value, "-")
+	if value == contentRange || dash < 0 {
+		return 0, errors.Errorf("invalid content range %q", contentRange)
+	}
+	return strconv.ParseInt(value[:dash], 10, 64)
 }
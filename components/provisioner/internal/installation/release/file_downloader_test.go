@@ -0,0 +1,154 @@
+package release
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fileURL = "https://storage.googleapis.com/kyma-prow-artifacts/1.13.0/kyma-installer-cluster.yaml"
+
+// interruptedReader returns the content followed by an error simulating a broken connection
+type interruptedReader struct {
+	reader io.Reader
+}
+
+func (r *interruptedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset by peer")
+	}
+	return n, err
+}
+
+func TestFileDownloader_Download(t *testing.T) {
+	content := "some installer content"
+	interruptAt := 10
+
+	t.Run("should resume interrupted download with range request", func(t *testing.T) {
+		// given
+		var ranges []string
+		client := newTestClient(func(req *http.Request) *http.Response {
+			rangeHeader := req.Header.Get("Range")
+			ranges = append(ranges, rangeHeader)
+
+			if rangeHeader == "" {
+				return &http.Response{
+					StatusCode:    http.StatusOK,
+					ContentLength: int64(len(content)),
+					Header:        http.Header{"Accept-Ranges": []string{"bytes"}, "Etag": []string{`"v1"`}},
+					Body:          ioutil.NopCloser(&interruptedReader{reader: strings.NewReader(content[:interruptAt])}),
+				}
+			}
+
+			assert.Equal(t, `"v1"`, req.Header.Get("If-Range"))
+			return &http.Response{
+				StatusCode: http.StatusPartialContent,
+				Header:     http.Header{"Content-Range": []string{fmt.Sprintf("bytes %d-%d/%d", interruptAt, len(content)-1, len(content))}},
+				Body:       ioutil.NopCloser(strings.NewReader(content[interruptAt:])),
+			}
+		})
+
+		// when
+		downloaded, err := NewFileDownloader(client, time.Minute).Download(fileURL)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, content, downloaded)
+		assert.Equal(t, []string{"", fmt.Sprintf("bytes=%d-", interruptAt)}, ranges)
+	})
+
+	t.Run("should restart download when server ignores range request", func(t *testing.T) {
+		// given
+		requests := 0
+		client := newTestClient(func(req *http.Request) *http.Response {
+			requests++
+			body := io.Reader(strings.NewReader(content))
+			if requests == 1 {
+				body = &interruptedReader{reader: strings.NewReader(content[:interruptAt])}
+			}
+
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: int64(len(content)),
+				Header:        http.Header{"Accept-Ranges": []string{"bytes"}},
+				Body:          ioutil.NopCloser(body),
+			}
+		})
+
+		// when
+		downloaded, err := NewFileDownloader(client, time.Minute).Download(fileURL)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, content, downloaded)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("should fail interrupted download when server does not accept ranges", func(t *testing.T) {
+		// given
+		requests := 0
+		client := newTestClient(func(req *http.Request) *http.Response {
+			requests++
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: int64(len(content)),
+				Body:          ioutil.NopCloser(&interruptedReader{reader: strings.NewReader(content[:interruptAt])}),
+			}
+		})
+
+		// when
+		_, err := NewFileDownloader(client, time.Minute).Download(fileURL)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("should fail when downloaded content is shorter than announced", func(t *testing.T) {
+		// given
+		client := newTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: int64(len(content)),
+				Body:          ioutil.NopCloser(bytes.NewBufferString(content[:interruptAt])),
+			}
+		})
+
+		// when
+		_, err := NewFileDownloader(client, time.Minute).Download(fileURL)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should return empty content for not found file", func(t *testing.T) {
+		// given
+		client := newTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("404 not found")),
+			}
+		})
+		downloader := NewFileDownloader(client, time.Minute)
+
+		// when
+		downloaded, err := downloader.DownloadOrEmpty(fileURL)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, downloaded)
+
+		_, err = downloader.Download(fileURL)
+		require.Error(t, err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -115,7 +116,7 @@ func TestOnDemand_DownloadRelease(t *testing.T) {
 		} {
 			t.Run(testCase.description, func(t *testing.T) {
 				// given
-				fileDownloader := NewFileDownloader(testCase.httpClient)
+				fileDownloader := NewFileDownloader(testCase.httpClient, time.Minute)
 
 				onDemand := NewGCSDownloader(fileDownloader)
 
@@ -188,7 +189,7 @@ func TestOnDemand_GetReleaseByVersion_Error(t *testing.T) {
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
-			fileDownloader := NewFileDownloader(testCase.httpClient)
+			fileDownloader := NewFileDownloader(testCase.httpClient, time.Minute)
 
 			onDemand := NewGCSDownloader(fileDownloader)
 
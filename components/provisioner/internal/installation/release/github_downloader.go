@@ -8,11 +8,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 )
 
@@ -25,21 +28,40 @@ const (
 	tillerFormat      = "https://raw.githubusercontent.com/kyma-project/kyma/%s/installation/resources/tiller.yaml"
 )
 
+var (
+	downloadedBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "kyma_release_downloaded_bytes_total",
+		Help:      "Number of bytes of the verified Kyma release artifacts downloaded by the artifacts downloader",
+	}, []string{"release"})
+	downloadFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "kyma_release_download_failures_total",
+		Help:      "Number of failed downloads of Kyma release artifacts by the artifacts downloader",
+	}, []string{"release"})
+)
+
 // Deprecated
 // Should be removed or switched to fetching from GCS bucket after version 1.14 is no longer supported
 func NewArtifactsDownloader(
 	repository Repository,
 	latestReleases int,
 	includePreReleases bool,
+	concurrency int,
 	client *http.Client,
 	downloader TextFileDownloader,
+	pruner ReleasePruner,
 	log *logrus.Entry) *artifactsDownloader {
 	return &artifactsDownloader{
 		repository:         repository,
 		latestReleases:     latestReleases,
 		includePreReleases: includePreReleases,
+		concurrency:        concurrency,
 		httpClient:         client,
 		downloader:         downloader,
+		pruner:             pruner,
 		log:                log,
 	}
 }
@@ -50,9 +72,13 @@ type artifactsDownloader struct {
 	repository         Repository
 	latestReleases     int
 	includePreReleases bool
-	httpClient         *http.Client
-	downloader         TextFileDownloader
-	log                *logrus.Entry
+	// concurrency limits the number of releases downloaded in parallel
+	concurrency int
+	httpClient  *http.Client
+	downloader  TextFileDownloader
+	// pruner is optional, releases are not pruned if it is nil
+	pruner ReleasePruner
+	log    *logrus.Entry
 }
 
 func (ad artifactsDownloader) FetchPeriodically(ctx context.Context, shortInterval, longInterval time.Duration) {
@@ -66,6 +92,7 @@ func (ad artifactsDownloader) FetchPeriodically(ctx context.Context, shortInterv
 				ad.log.Errorf("Error during release fetch: %s", err.Error())
 				time.Sleep(shortInterval)
 			} else {
+				ad.pruneReleases()
 				time.Sleep(longInterval)
 			}
 		}
@@ -87,6 +114,19 @@ func (ad artifactsDownloader) fetchLatestReleases() error {
 	return ad.save(releases)
 }
 
+func (ad artifactsDownloader) pruneReleases() {
+	if ad.pruner == nil {
+		return
+	}
+
+	pruned, err := ad.pruner.Prune()
+	if err != nil {
+		ad.log.Errorf("Error during release pruning: %s", err.Error())
+		return
+	}
+	ad.log.Debugf("Release pruning finished, %d releases pruned", pruned)
+}
+
 func (ad artifactsDownloader) fetchReleases() ([]model.GithubRelease, error) {
 	responseBody, err := ad.sendRequest(releaseFetchURL)
 	if err != nil {
@@ -102,29 +142,61 @@ func (ad artifactsDownloader) fetchReleases() ([]model.GithubRelease, error) {
 	return releases, nil
 }
 
+// save downloads the releases missing in the repository in parallel and inserts each of them
+// only after all its artifacts are downloaded and verified
 func (ad artifactsDownloader) save(releases []model.GithubRelease) error {
-	for _, release := range releases {
-		artifacts, err := ad.buildRelease(release)
-		if err != nil {
-			return err
-		}
+	concurrency := ad.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
 
-		exists, err := ad.repository.ReleaseExists(artifacts.Version)
+	semaphore := make(chan struct{}, concurrency)
+	errs := make([]error, len(releases))
+
+	var wg sync.WaitGroup
+	for i, release := range releases {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, release model.GithubRelease) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			errs[i] = ad.saveRelease(release)
+		}(i, release)
+	}
+	wg.Wait()
 
+	var messages []string
+	for _, err := range errs {
 		if err != nil {
-			return err
-		}
-
-		if !exists {
-			_, err = ad.repository.SaveRelease(artifacts)
-			if err != nil {
-				return err
-			}
+			messages = append(messages, err.Error())
 		}
 	}
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "; "))
+	}
 	return nil
 }
 
+func (ad artifactsDownloader) saveRelease(release model.GithubRelease) error {
+	exists, err := ad.repository.ReleaseExists(release.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	artifacts, err := ad.buildRelease(release)
+	if err != nil {
+		downloadFailuresCounter.WithLabelValues(release.Name).Inc()
+		return err
+	}
+	downloadedBytesCounter.WithLabelValues(release.Name).Add(float64(len(artifacts.InstallerYAML) + len(artifacts.TillerYAML)))
+
+	_, err = ad.repository.SaveRelease(artifacts)
+	return err
+}
+
 func (ad artifactsDownloader) buildRelease(release model.GithubRelease) (model.Release, error) {
 	var installerURL string
 	for _, a := range release.Assets {
@@ -144,6 +216,9 @@ func (ad artifactsDownloader) buildRelease(release model.GithubRelease) (model.R
 	if err != nil {
 		return model.Release{}, err
 	}
+	if installerYAML == "" {
+		return model.Release{}, errors.New(fmt.Sprintf("Installer yaml of release %s is empty", release.Name))
+	}
 
 	tillerYAML, err := ad.downloader.DownloadOrEmpty(tillerURL)
 	if err != nil {
@@ -85,7 +85,7 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 		releases := []model.GithubRelease{testReleases[0].githubRelease, testReleases[1].githubRelease}
 
 		client := newMockClient(t, releases, installerURL, installerContent, tillerContent)
-		fileDownloader := NewFileDownloader(client)
+		fileDownloader := NewFileDownloader(client, time.Minute)
 
 		repository := &mocks.Repository{}
 		repository.On("ReleaseExists", mock.Anything).Return(false, nil)
@@ -95,7 +95,7 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 
 		entry := logrus.WithField("Component", "ArtifactsDownloaderTests")
 
-		downloader := NewArtifactsDownloader(repository, 3, true, client, fileDownloader, entry)
+		downloader := NewArtifactsDownloader(repository, 3, true, 2, client, fileDownloader, nil, entry)
 
 		ctx := context.Background()
 		ctx, _ = context.WithTimeout(ctx, 5*time.Second)
@@ -107,12 +107,40 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 		repository.AssertExpectations(t)
 	})
 
+	t.Run("Should prune releases after fetch", func(t *testing.T) {
+		//given
+		releases := []model.GithubRelease{testReleases[0].githubRelease}
+
+		client := newMockClient(t, releases, installerURL, installerContent, tillerContent)
+		fileDownloader := NewFileDownloader(client, time.Minute)
+
+		repository := &mocks.Repository{}
+		repository.On("ReleaseExists", mock.Anything).Return(true, nil)
+
+		pruner := &mocks.ReleasePruner{}
+		pruner.On("Prune").Return(1, nil)
+
+		entry := logrus.WithField("Component", "ArtifactsDownloaderTests")
+
+		downloader := NewArtifactsDownloader(repository, 3, true, 2, client, fileDownloader, pruner, entry)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		//when
+		downloader.FetchPeriodically(ctx, shortInterval, longInterval)
+
+		//then
+		repository.AssertExpectations(t)
+		pruner.AssertNumberOfCalls(t, "Prune", 1)
+	})
+
 	t.Run("Should fetch testReleases without prereleases", func(t *testing.T) {
 		//given
 		releases := []model.GithubRelease{testReleases[0].githubRelease, testReleases[1].githubRelease, testReleases[2].githubRelease}
 
 		client := newMockClient(t, releases, installerURL, installerContent, tillerContent)
-		fileDownloader := NewFileDownloader(client)
+		fileDownloader := NewFileDownloader(client, time.Minute)
 
 		repository := &mocks.Repository{}
 		repository.On("ReleaseExists", mock.Anything).Return(false, nil)
@@ -122,7 +150,7 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 
 		entry := logrus.WithField("Component", "ArtifactsDownloaderTests")
 
-		downloader := NewArtifactsDownloader(repository, 3, false, client, fileDownloader, entry)
+		downloader := NewArtifactsDownloader(repository, 3, false, 2, client, fileDownloader, nil, entry)
 
 		ctx := context.Background()
 		ctx, _ = context.WithTimeout(ctx, 5*time.Second)
@@ -138,7 +166,7 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 		releases := []model.GithubRelease{testReleases[0].githubRelease, testReleases[1].githubRelease, testReleases[2].githubRelease}
 
 		client := newMockClient(t, releases, installerURL, installerContent, tillerContent)
-		fileDownloader := NewFileDownloader(client)
+		fileDownloader := NewFileDownloader(client, time.Minute)
 
 		expectedReleaseThree := model.Release{
 			Version:       "1.9-rc2",
@@ -153,7 +181,7 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 
 		entry := logrus.WithField("Component", "ArtifactsDownloaderTests")
 
-		downloader := NewArtifactsDownloader(repository, 1, true, client, fileDownloader, entry)
+		downloader := NewArtifactsDownloader(repository, 1, true, 2, client, fileDownloader, nil, entry)
 
 		ctx := context.Background()
 		ctx, _ = context.WithTimeout(ctx, 5*time.Second)
@@ -170,7 +198,7 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 		releases := []model.GithubRelease{testReleases[2].githubRelease}
 
 		client := newMockClient(t, releases, installerURL, installerContent, "")
-		fileDownloader := NewFileDownloader(client)
+		fileDownloader := NewFileDownloader(client, time.Minute)
 
 		repository := &mocks.Repository{}
 		repository.On("ReleaseExists", mock.Anything).Return(false, nil)
@@ -179,7 +207,7 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 
 		entry := logrus.WithField("Component", "ArtifactsDownloaderTests")
 
-		downloader := NewArtifactsDownloader(repository, 3, true, client, fileDownloader, entry)
+		downloader := NewArtifactsDownloader(repository, 3, true, 2, client, fileDownloader, nil, entry)
 
 		ctx := context.Background()
 		ctx, _ = context.WithTimeout(ctx, 5*time.Second)
@@ -191,18 +219,57 @@ func TestArtifactsDownloader_FetchPeriodically(t *testing.T) {
 		repository.AssertExpectations(t)
 	})
 
+	t.Run("Should not save release with incomplete artifacts", func(t *testing.T) {
+		//given
+		releases := []model.GithubRelease{testReleases[0].githubRelease}
+
+		client := newTestClient(func(req *http.Request) *http.Response {
+			if req.URL.String() == releaseFetchURL {
+				content, err := json.Marshal(releases)
+				require.NoError(t, err)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(content)),
+				}
+			}
+
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: int64(len(installerContent)) + 10,
+				Body:          ioutil.NopCloser(bytes.NewBufferString(installerContent)),
+			}
+		})
+		fileDownloader := NewFileDownloader(client, time.Minute)
+
+		repository := &mocks.Repository{}
+		repository.On("ReleaseExists", "1.7").Return(false, nil)
+
+		entry := logrus.WithField("Component", "ArtifactsDownloaderTests")
+
+		downloader := NewArtifactsDownloader(repository, 1, true, 2, client, fileDownloader, nil, entry)
+
+		//when
+		err := downloader.fetchLatestReleases()
+
+		//then
+		require.Error(t, err)
+		repository.AssertExpectations(t)
+		repository.AssertNotCalled(t, "SaveRelease", mock.Anything)
+	})
+
 	t.Run("Should not save release if already exists in database", func(t *testing.T) {
 		releases := []model.GithubRelease{testReleases[2].githubRelease}
 
 		client := newMockClient(t, releases, installerURL, installerContent, tillerContent)
-		fileDownloader := NewFileDownloader(client)
+		fileDownloader := NewFileDownloader(client, time.Minute)
 
 		repository := &mocks.Repository{}
 		repository.On("ReleaseExists", "1.9-rc2").Return(true, nil)
 
 		entry := logrus.WithField("Component", "ArtifactsDownloaderTests")
 
-		downloader := NewArtifactsDownloader(repository, 1, true, client, fileDownloader, entry)
+		downloader := NewArtifactsDownloader(repository, 1, true, 2, client, fileDownloader, nil, entry)
 
 		ctx := context.Background()
 		ctx, _ = context.WithTimeout(ctx, 5*time.Second)
@@ -12,6 +12,29 @@ type Provider struct {
 	mock.Mock
 }
 
+// GetLatestRelease provides a mock function with given fields:
+func (_m *Provider) GetLatestRelease() (*model.Release, error) {
+	ret := _m.Called()
+
+	var r0 *model.Release
+	if rf, ok := ret.Get(0).(func() *model.Release); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Release)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReleaseByVersion provides a mock function with given fields: version
 func (_m *Provider) GetReleaseByVersion(version string) (model.Release, error) {
 	ret := _m.Called(version)
@@ -32,3 +55,24 @@ func (_m *Provider) GetReleaseByVersion(version string) (model.Release, error) {
 
 	return r0, r1
 }
+
+// LookupReleaseByVersion provides a mock function with given fields: version
+func (_m *Provider) LookupReleaseByVersion(version string) (model.Release, error) {
+	ret := _m.Called(version)
+
+	var r0 model.Release
+	if rf, ok := ret.Get(0).(func(string) model.Release); ok {
+		r0 = rf(version)
+	} else {
+		r0 = ret.Get(0).(model.Release)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -14,6 +14,29 @@ type Repository struct {
 	mock.Mock
 }
 
+// DeleteRelease provides a mock function with given fields: id
+func (_m *Repository) DeleteRelease(id string) (bool, dberrors.Error) {
+	ret := _m.Called(id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string) dberrors.Error); ok {
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetReleaseByVersion provides a mock function with given fields: version
 func (_m *Repository) GetReleaseByVersion(version string) (model.Release, dberrors.Error) {
 	ret := _m.Called(version)
@@ -37,6 +60,81 @@ func (_m *Repository) GetReleaseByVersion(version string) (model.Release, dberro
 	return r0, r1
 }
 
+// ListReferencedReleaseIDs provides a mock function with given fields:
+func (_m *Repository) ListReferencedReleaseIDs() ([]string, dberrors.Error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func() dberrors.Error); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListReleaseMetadata provides a mock function with given fields:
+func (_m *Repository) ListReleaseMetadata() ([]model.ReleaseMetadata, dberrors.Error) {
+	ret := _m.Called()
+
+	var r0 []model.ReleaseMetadata
+	if rf, ok := ret.Get(0).(func() []model.ReleaseMetadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReleaseMetadata)
+		}
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func() dberrors.Error); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListReleaseVersions provides a mock function with given fields:
+func (_m *Repository) ListReleaseVersions() ([]string, dberrors.Error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func() dberrors.Error); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
 // ReleaseExists provides a mock function with given fields: version
 func (_m *Repository) ReleaseExists(version string) (bool, dberrors.Error) {
 	ret := _m.Called(version)
@@ -2,16 +2,25 @@ package release
 
 import (
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"k8s.io/apimachinery/pkg/util/version"
 )
 
 //go:generate mockery -name=Provider
 type Provider interface {
 	GetReleaseByVersion(version string) (model.Release, error)
+	// LookupReleaseByVersion gets the release from the database or downloads it without saving it
+	LookupReleaseByVersion(version string) (model.Release, error)
+	// GetLatestRelease gets the release with the highest version from the database skipping pre-releases and on-demand versions,
+	// nil is returned if there is no such release
+	GetLatestRelease() (*model.Release, error)
 }
 
 //go:generate mockery -name=ReleaseDownloader
@@ -23,15 +32,25 @@ func NewReleaseProvider(repository Repository, downloader ReleaseDownloader) *Re
 	return &ReleaseProvider{
 		repository: repository,
 		downloader: downloader,
+		readers:    map[string]int{},
+		lastRead:   map[string]time.Time{},
 	}
 }
 
 type ReleaseProvider struct {
 	repository Repository
 	downloader ReleaseDownloader
+
+	// readers and lastRead track releases being read so that they are not pruned in the meantime
+	mutex    sync.Mutex
+	readers  map[string]int
+	lastRead map[string]time.Time
 }
 
 func (rp *ReleaseProvider) GetReleaseByVersion(version string) (model.Release, error) {
+	rp.startReading(version)
+	defer rp.finishReading(version)
+
 	release, err := rp.repository.GetReleaseByVersion(version)
 
 	if err == nil { // release found in DB
@@ -45,6 +64,99 @@ func (rp *ReleaseProvider) GetReleaseByVersion(version string) (model.Release, e
 	return model.Release{}, dberrors.Internal("failed to get Kyma release for version %s: %s", version, err.Error())
 }
 
+func (rp *ReleaseProvider) LookupReleaseByVersion(version string) (model.Release, error) {
+	rp.startReading(version)
+	defer rp.finishReading(version)
+
+	release, err := rp.repository.GetReleaseByVersion(version)
+
+	if err == nil {
+		return release, nil
+	}
+
+	if err.Code() == dberrors.CodeNotFound {
+		return rp.downloader.DownloadRelease(version)
+	}
+
+	return model.Release{}, dberrors.Internal("failed to get Kyma release for version %s: %s", version, err.Error())
+}
+
+func (rp *ReleaseProvider) GetLatestRelease() (*model.Release, error) {
+	versions, err := rp.repository.ListReleaseVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *version.Version
+	latestVersion := ""
+	for _, candidate := range versions {
+		parsed, err := version.ParseSemantic(strings.TrimPrefix(candidate, "v"))
+		if err != nil || parsed.PreRelease() != "" {
+			continue
+		}
+		if latest == nil || latest.LessThan(parsed) {
+			latest = parsed
+			latestVersion = candidate
+		}
+	}
+
+	if latest == nil {
+		return nil, nil
+	}
+
+	rp.startReading(latestVersion)
+	defer rp.finishReading(latestVersion)
+
+	release, err := rp.repository.GetReleaseByVersion(latestVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+// deleteUnlessRead deletes the release if it is not being read and was not read within the grace period,
+// readers of the release wait until the deletion finishes
+func (rp *ReleaseProvider) deleteUnlessRead(release model.ReleaseMetadata, gracePeriod time.Duration) (bool, error) {
+	rp.mutex.Lock()
+	defer rp.mutex.Unlock()
+
+	if rp.readers[release.Version] > 0 {
+		return false, nil
+	}
+	if lastRead, found := rp.lastRead[release.Version]; found && time.Since(lastRead) < gracePeriod {
+		return false, nil
+	}
+
+	deleted, err := rp.repository.DeleteRelease(release.Id)
+	if err != nil {
+		return false, err
+	}
+	if deleted {
+		delete(rp.lastRead, release.Version)
+	}
+
+	return deleted, nil
+}
+
+func (rp *ReleaseProvider) startReading(version string) {
+	rp.mutex.Lock()
+	defer rp.mutex.Unlock()
+
+	rp.readers[version]++
+}
+
+func (rp *ReleaseProvider) finishReading(version string) {
+	rp.mutex.Lock()
+	defer rp.mutex.Unlock()
+
+	rp.readers[version]--
+	if rp.readers[version] <= 0 {
+		delete(rp.readers, version)
+	}
+	rp.lastRead[version] = time.Now()
+}
+
 func (rp *ReleaseProvider) downloadRelease(version string) (model.Release, error) {
 	release, err := rp.downloader.DownloadRelease(version)
 	if err != nil {
@@ -127,3 +127,121 @@ func TestReleaseProvider_GetReleaseByVersionError(t *testing.T) {
 	})
 
 }
+
+func TestReleaseProvider_LookupReleaseByVersion(t *testing.T) {
+
+	release := model.Release{
+		Id:            "abcd-efgh",
+		Version:       kymaVersion,
+		TillerYAML:    "tiller",
+		InstallerYAML: "installer",
+	}
+
+	t.Run("should get release from database", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("GetReleaseByVersion", kymaVersion).Return(release, nil)
+		downloader := &mocks.ReleaseDownloader{}
+
+		relProvider := NewReleaseProvider(repo, downloader)
+
+		// when
+		providedRel, err := relProvider.LookupReleaseByVersion(kymaVersion)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, release, providedRel)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should download release without saving it if not found in database", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("GetReleaseByVersion", kymaVersion).Return(model.Release{}, dberrors.NotFound("error"))
+
+		downloader := &mocks.ReleaseDownloader{}
+		downloader.On("DownloadRelease", kymaVersion).Return(release, nil)
+
+		relProvider := NewReleaseProvider(repo, downloader)
+
+		// when
+		providedRel, err := relProvider.LookupReleaseByVersion(kymaVersion)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, release, providedRel)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "SaveRelease", release)
+		downloader.AssertExpectations(t)
+	})
+
+	t.Run("should return error when failed to get release from database", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("GetReleaseByVersion", kymaVersion).Return(model.Release{}, dberrors.Internal("error"))
+		downloader := &mocks.ReleaseDownloader{}
+
+		relProvider := NewReleaseProvider(repo, downloader)
+
+		// when
+		_, err := relProvider.LookupReleaseByVersion(kymaVersion)
+		require.Error(t, err)
+	})
+}
+
+func TestReleaseProvider_GetLatestRelease(t *testing.T) {
+
+	release := model.Release{
+		Id:            "abcd-efgh",
+		Version:       "1.24.10",
+		TillerYAML:    "tiller",
+		InstallerYAML: "installer",
+	}
+
+	t.Run("should get release with the highest version skipping pre-releases and on-demand versions", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseVersions").Return([]string{"1.24.2", "PR-1234", "1.25.0-rc1", "1.24.10", "master-a1b2c3d", "1.9.0"}, nil)
+		repo.On("GetReleaseByVersion", "1.24.10").Return(release, nil)
+
+		relProvider := NewReleaseProvider(repo, &mocks.ReleaseDownloader{})
+
+		// when
+		latest, err := relProvider.GetLatestRelease()
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, release, *latest)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should return nil when there is no stable release", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseVersions").Return([]string{"PR-1234", "1.25.0-rc1"}, nil)
+
+		relProvider := NewReleaseProvider(repo, &mocks.ReleaseDownloader{})
+
+		// when
+		latest, err := relProvider.GetLatestRelease()
+
+		// then
+		require.NoError(t, err)
+		require.Nil(t, latest)
+	})
+
+	t.Run("should return error when failed to list releases", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseVersions").Return(nil, dberrors.Internal("error"))
+
+		relProvider := NewReleaseProvider(repo, &mocks.ReleaseDownloader{})
+
+		// when
+		_, err := relProvider.GetLatestRelease()
+
+		// then
+		require.Error(t, err)
+	})
+}
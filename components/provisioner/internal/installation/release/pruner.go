@@ -0,0 +1,93 @@
+package release
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// readGracePeriod protects releases read recently, as they may be about to be referenced by a new cluster
+const readGracePeriod = 30 * time.Minute
+
+var prunedReleasesCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "kcp",
+	Subsystem: "provisioner",
+	Name:      "pruned_kyma_releases_total",
+	Help:      "Number of downloaded Kyma releases deleted from the database as no longer used",
+})
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{prunedReleasesCounter, downloadedBytesCounter, downloadFailuresCounter}
+}
+
+//go:generate mockery -name=ReleasePruner
+type ReleasePruner interface {
+	// Prune deletes unused releases and returns the number of deleted ones
+	Prune() (int, error)
+}
+
+func NewPruner(repository Repository, provider *ReleaseProvider, keepLatest int, minAge time.Duration, log *logrus.Entry) *pruner {
+	return &pruner{
+		repository: repository,
+		provider:   provider,
+		keepLatest: keepLatest,
+		minAge:     minAge,
+		log:        log,
+	}
+}
+
+// pruner deletes releases not referenced by any cluster which are older than minAge,
+// the keepLatest newest releases are always kept
+type pruner struct {
+	repository Repository
+	provider   *ReleaseProvider
+	keepLatest int
+	minAge     time.Duration
+	log        *logrus.Entry
+}
+
+func (p pruner) Prune() (int, error) {
+	releases, err := p.repository.ListReleaseMetadata()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(releases) <= p.keepLatest {
+		return 0, nil
+	}
+
+	referencedIDs, err := p.repository.ListReferencedReleaseIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	referenced := make(map[string]bool, len(referencedIDs))
+	for _, id := range referencedIDs {
+		referenced[id] = true
+	}
+
+	pruned := 0
+	for _, release := range releases[p.keepLatest:] {
+		if referenced[release.Id] || time.Since(release.CreatedAt) < p.minAge {
+			continue
+		}
+
+		deleted, err := p.provider.deleteUnlessRead(release, readGracePeriod)
+		if err != nil {
+			p.log.Errorf("Failed to prune Kyma release %s: %s", release.Version, err.Error())
+			continue
+		}
+		if deleted {
+			p.log.Infof("Pruned unused Kyma release %s", release.Version)
+			pruned++
+		}
+	}
+
+	if pruned > 0 {
+		p.log.Infof("Pruned %d unused Kyma releases", pruned)
+		prunedReleasesCounter.Add(float64(pruned))
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,142 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/installation/release/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	now := time.Now()
+	minAge := 24 * time.Hour
+	log := logrus.WithField("Component", "PrunerTests")
+
+	releases := []model.ReleaseMetadata{
+		{Id: "id-4", Version: "1.4", CreatedAt: now.Add(-time.Hour)},
+		{Id: "id-3", Version: "1.3", CreatedAt: now.Add(-48 * time.Hour)},
+		{Id: "id-2", Version: "1.2", CreatedAt: now.Add(-72 * time.Hour)},
+		{Id: "id-1", Version: "1.1", CreatedAt: now.Add(-96 * time.Hour)},
+	}
+
+	t.Run("should prune old releases not referenced by clusters", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseMetadata").Return(releases, nil)
+		repo.On("ListReferencedReleaseIDs").Return([]string{"id-2"}, nil)
+		repo.On("DeleteRelease", "id-3").Return(true, nil)
+		repo.On("DeleteRelease", "id-1").Return(true, nil)
+
+		pruner := NewPruner(repo, NewReleaseProvider(repo, &mocks.ReleaseDownloader{}), 1, minAge, log)
+
+		// when
+		pruned, err := pruner.Prune()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, pruned)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "DeleteRelease", "id-2")
+		repo.AssertNotCalled(t, "DeleteRelease", "id-4")
+	})
+
+	t.Run("should keep latest releases and releases younger than min age", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseMetadata").Return(releases, nil)
+		repo.On("ListReferencedReleaseIDs").Return([]string{}, nil)
+		repo.On("DeleteRelease", "id-1").Return(true, nil)
+
+		pruner := NewPruner(repo, NewReleaseProvider(repo, &mocks.ReleaseDownloader{}), 0, 80*time.Hour, log)
+
+		// when
+		pruned, err := pruner.Prune()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, pruned)
+		repo.AssertNumberOfCalls(t, "DeleteRelease", 1)
+	})
+
+	t.Run("should not prune anything if there are no more releases than kept", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseMetadata").Return(releases, nil)
+
+		pruner := NewPruner(repo, NewReleaseProvider(repo, &mocks.ReleaseDownloader{}), len(releases), minAge, log)
+
+		// when
+		pruned, err := pruner.Prune()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, pruned)
+		repo.AssertNotCalled(t, "ListReferencedReleaseIDs")
+	})
+
+	t.Run("should not prune release being read or read recently", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseMetadata").Return(releases, nil)
+		repo.On("ListReferencedReleaseIDs").Return([]string{"id-2"}, nil)
+		repo.On("GetReleaseByVersion", "1.1").Return(model.Release{Id: "id-1", Version: "1.1"}, nil)
+
+		provider := NewReleaseProvider(repo, &mocks.ReleaseDownloader{})
+		provider.startReading("1.3")
+		defer provider.finishReading("1.3")
+
+		_, err := provider.GetReleaseByVersion("1.1")
+		require.NoError(t, err)
+
+		pruner := NewPruner(repo, provider, 1, minAge, log)
+
+		// when
+		pruned, err := pruner.Prune()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, pruned)
+		repo.AssertNotCalled(t, "DeleteRelease", "id-3")
+		repo.AssertNotCalled(t, "DeleteRelease", "id-1")
+	})
+
+	t.Run("should skip releases which failed to be deleted", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseMetadata").Return(releases, nil)
+		repo.On("ListReferencedReleaseIDs").Return([]string{}, nil)
+		repo.On("DeleteRelease", "id-3").Return(false, dberrors.Internal("error"))
+		repo.On("DeleteRelease", "id-2").Return(false, nil)
+		repo.On("DeleteRelease", "id-1").Return(true, nil)
+
+		pruner := NewPruner(repo, NewReleaseProvider(repo, &mocks.ReleaseDownloader{}), 1, minAge, log)
+
+		// when
+		pruned, err := pruner.Prune()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, pruned)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when failed to list referenced releases", func(t *testing.T) {
+		// given
+		repo := &mocks.Repository{}
+		repo.On("ListReleaseMetadata").Return(releases, nil)
+		repo.On("ListReferencedReleaseIDs").Return(nil, dberrors.Internal("error"))
+
+		pruner := NewPruner(repo, NewReleaseProvider(repo, &mocks.ReleaseDownloader{}), 1, minAge, log)
+
+		// when
+		_, err := pruner.Prune()
+
+		// then
+		require.Error(t, err)
+	})
+}
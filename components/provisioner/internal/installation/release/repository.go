@@ -15,8 +15,15 @@ const (
 //go:generate mockery -name=Repository
 type Repository interface {
 	GetReleaseByVersion(version string) (model.Release, dberrors.Error)
+	ListReleaseVersions() ([]string, dberrors.Error)
+	// ListReleaseMetadata lists releases without their artifacts ordered from the newest to the oldest
+	ListReleaseMetadata() ([]model.ReleaseMetadata, dberrors.Error)
+	// ListReferencedReleaseIDs lists IDs of releases used by Kyma configs of existing clusters
+	ListReferencedReleaseIDs() ([]string, dberrors.Error)
 	ReleaseExists(version string) (bool, dberrors.Error)
 	SaveRelease(artifacts model.Release) (model.Release, dberrors.Error)
+	// DeleteRelease deletes the release unless any Kyma config references it, returns false if nothing was deleted
+	DeleteRelease(id string) (bool, dberrors.Error)
 }
 
 func NewReleaseRepository(connection *dbr.Connection, generator uuid.UUIDGenerator) *releaseRepository {
@@ -52,6 +59,80 @@ func (r releaseRepository) GetReleaseByVersion(version string) (model.Release, d
 	return release, nil
 }
 
+func (r releaseRepository) ListReleaseVersions() ([]string, dberrors.Error) {
+	session := r.connection.NewSession(nil)
+
+	var versions []string
+
+	_, err := session.
+		Select("version").
+		From("kyma_release").
+		Load(&versions)
+
+	if err != nil {
+		return nil, dberrors.Internal("Failed to list Kyma release versions: %s", err.Error())
+	}
+
+	return versions, nil
+}
+
+func (r releaseRepository) ListReleaseMetadata() ([]model.ReleaseMetadata, dberrors.Error) {
+	session := r.connection.NewSession(nil)
+
+	var releases []model.ReleaseMetadata
+
+	_, err := session.
+		Select("id", "version", "created_at").
+		From("kyma_release").
+		OrderDesc("created_at").
+		Load(&releases)
+
+	if err != nil {
+		return nil, dberrors.Internal("Failed to list Kyma releases: %s", err.Error())
+	}
+
+	return releases, nil
+}
+
+func (r releaseRepository) ListReferencedReleaseIDs() ([]string, dberrors.Error) {
+	session := r.connection.NewSession(nil)
+
+	var ids []string
+
+	_, err := session.
+		Select("DISTINCT kyma_config.release_id").
+		From("kyma_config").
+		Join("cluster", "cluster.id = kyma_config.cluster_id").
+		Load(&ids)
+
+	if err != nil {
+		return nil, dberrors.Internal("Failed to list Kyma releases referenced by clusters: %s", err.Error())
+	}
+
+	return ids, nil
+}
+
+func (r releaseRepository) DeleteRelease(id string) (bool, dberrors.Error) {
+	session := r.connection.NewSession(nil)
+
+	result, err := session.
+		DeleteFrom("kyma_release").
+		Where(dbr.Eq("id", id)).
+		Where("NOT EXISTS (SELECT 1 FROM kyma_config WHERE kyma_config.release_id = ?)", id).
+		Exec()
+
+	if err != nil {
+		return false, dberrors.Internal("Failed to delete Kyma release %s: %s", id, err.Error())
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, dberrors.Internal("Failed to get number of deleted Kyma releases: %s", err.Error())
+	}
+
+	return deleted > 0, nil
+}
+
 func (r releaseRepository) ReleaseExists(version string) (bool, dberrors.Error) {
 	_, err := r.GetReleaseByVersion(version)
 
@@ -28,8 +28,13 @@ const (
 	ClusterServiceClassRefNameLabel = "servicecatalog.k8s.io/spec.clusterServiceClassRef.name"
 )
 
+const (
+	cleanupPollInterval = 10 * time.Second
+	cleanupPollTimeout  = 2 * time.Minute
+)
+
 type CleanupClient interface {
-	PerformCleanup(resourceSelector string) error
+	PerformCleanup(ctx context.Context, selector CleanupSelector) error
 }
 
 type CrdsManager interface {
@@ -61,8 +66,8 @@ type serviceCatalogClient struct {
 	crdsManager CrdsManager
 }
 
-func (s *serviceCatalogClient) PerformCleanup(resourceSelector string) error {
-	exist, err := s.ensureCRDsExist()
+func (s *serviceCatalogClient) PerformCleanup(ctx context.Context, selector CleanupSelector) error {
+	exist, err := s.ensureCRDsExist(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "while checking CustomResourceDefinitions")
 	}
@@ -72,32 +77,32 @@ func (s *serviceCatalogClient) PerformCleanup(resourceSelector string) error {
 		return nil
 	}
 
-	clusterServiceBrokers, err := s.listClusterServiceBroker(metav1.ListOptions{})
+	clusterServiceBrokers, err := s.listClusterServiceBroker(ctx, metav1.ListOptions{})
 	if err != nil {
 		return errors.Wrapf(err, "while listing ClusterServiceBrokers")
 	}
 
-	logrus.Debugf("Filtering ClusterServiceBrokers with url prefix %s", resourceSelector)
-	brokersWithUrlPrefix := s.filterCsbWithUrlPrefix(clusterServiceBrokers, resourceSelector)
+	logrus.Debugf("Filtering ClusterServiceBrokers with url prefix %s", selector.URLPrefix)
+	brokersWithUrlPrefix := s.filterCsbWithUrlPrefix(clusterServiceBrokers, selector.URLPrefix)
 
-	cscWithMatchingLabel, err := s.getClusterServiceClassesForBrokers(brokersWithUrlPrefix)
+	cscWithMatchingLabel, err := s.getClusterServiceClassesForBrokers(ctx, brokersWithUrlPrefix)
 	if err != nil {
 		return errors.Wrapf(err, "while getting ClusterServiceClasses")
 	}
 
-	serviceInstances, err := s.getServiceInstancesForClusterServiceClasses(cscWithMatchingLabel)
+	serviceInstances, err := s.getServiceInstancesForClusterServiceClasses(ctx, cscWithMatchingLabel)
 	if err != nil {
 		return errors.Wrapf(err, "while getting ServiceInstances")
 	}
 
-	s.deleteServiceInstances(serviceInstances)
-	s.removeClusterBrokersFinalizers(clusterServiceBrokers.Items)
+	s.deleteServiceInstances(ctx, serviceInstances)
+	s.removeClusterBrokersFinalizers(ctx, clusterServiceBrokers.Items)
 
-	return nil
+	return ctx.Err()
 }
 
-func (s *serviceCatalogClient) ensureCRDsExist() (bool, error) {
-	list, err := s.crdsManager.List(context.Background(), metav1.ListOptions{})
+func (s *serviceCatalogClient) ensureCRDsExist(ctx context.Context) (bool, error) {
+	list, err := s.crdsManager.List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return false, err
 	}
@@ -120,10 +125,10 @@ func (s *serviceCatalogClient) ensureCRDExists(crdName string, list *apiServBeta
 	return false
 }
 
-func (s *serviceCatalogClient) listClusterServiceBroker(options metav1.ListOptions) (*v1beta1.ClusterServiceBrokerList, error) {
+func (s *serviceCatalogClient) listClusterServiceBroker(ctx context.Context, options metav1.ListOptions) (*v1beta1.ClusterServiceBrokerList, error) {
 	result := &v1beta1.ClusterServiceBrokerList{}
-	err := wait.PollImmediate(10*time.Second, 2*time.Minute, func() (done bool, err error) {
-		csbList, err := s.client.ServicecatalogV1beta1().ClusterServiceBrokers().List(context.Background(), options)
+	err := pollImmediate(ctx, func() (done bool, err error) {
+		csbList, err := s.client.ServicecatalogV1beta1().ClusterServiceBrokers().List(ctx, options)
 		if err != nil {
 			logrus.Errorf("while listing ClusterServiceBrokers: %s", err.Error())
 			return false, nil
@@ -134,10 +139,10 @@ func (s *serviceCatalogClient) listClusterServiceBroker(options metav1.ListOptio
 	return result, err
 }
 
-func (s *serviceCatalogClient) listClusterServiceClass(options metav1.ListOptions) (*v1beta1.ClusterServiceClassList, error) {
+func (s *serviceCatalogClient) listClusterServiceClass(ctx context.Context, options metav1.ListOptions) (*v1beta1.ClusterServiceClassList, error) {
 	result := &v1beta1.ClusterServiceClassList{}
-	err := wait.PollImmediate(10*time.Second, 2*time.Minute, func() (done bool, err error) {
-		cscList, err := s.client.ServicecatalogV1beta1().ClusterServiceClasses().List(context.Background(), options)
+	err := pollImmediate(ctx, func() (done bool, err error) {
+		cscList, err := s.client.ServicecatalogV1beta1().ClusterServiceClasses().List(ctx, options)
 		if err != nil {
 			logrus.Errorf("while listing ClusterServiceClasses: %s", err.Error())
 			return false, nil
@@ -148,10 +153,10 @@ func (s *serviceCatalogClient) listClusterServiceClass(options metav1.ListOption
 	return result, err
 }
 
-func (s *serviceCatalogClient) listServiceInstance(options metav1.ListOptions) (*v1beta1.ServiceInstanceList, error) {
+func (s *serviceCatalogClient) listServiceInstance(ctx context.Context, options metav1.ListOptions) (*v1beta1.ServiceInstanceList, error) {
 	result := &v1beta1.ServiceInstanceList{}
-	err := wait.PollImmediate(10*time.Second, 2*time.Minute, func() (done bool, err error) {
-		siList, err := s.client.ServicecatalogV1beta1().ServiceInstances(metav1.NamespaceAll).List(context.Background(), options)
+	err := pollImmediate(ctx, func() (done bool, err error) {
+		siList, err := s.client.ServicecatalogV1beta1().ServiceInstances(metav1.NamespaceAll).List(ctx, options)
 		if err != nil {
 			logrus.Errorf("while listing ServiceInstances: %s", err.Error())
 			return false, nil
@@ -173,14 +178,14 @@ func (s *serviceCatalogClient) filterCsbWithUrlPrefix(csbList *v1beta1.ClusterSe
 	return csbWithBrokerUrlPrefix
 }
 
-func (s *serviceCatalogClient) getClusterServiceClassesForBrokers(brokers []v1beta1.ClusterServiceBroker) ([]v1beta1.ClusterServiceClass, error) {
+func (s *serviceCatalogClient) getClusterServiceClassesForBrokers(ctx context.Context, brokers []v1beta1.ClusterServiceBroker) ([]v1beta1.ClusterServiceClass, error) {
 	var cscWithMatchingLabel []v1beta1.ClusterServiceClass
 
 	for _, csb := range brokers {
 		labelValue := util.GenerateSHA(csb.Name)
 		csbListOptions := fixListOptionsWithLabelSelector(ClusterServiceBrokerNameLabel, labelValue)
 
-		clusterServiceClasses, err := s.listClusterServiceClass(csbListOptions)
+		clusterServiceClasses, err := s.listClusterServiceClass(ctx, csbListOptions)
 		if err != nil {
 			return []v1beta1.ClusterServiceClass{}, errors.Wrapf(err, "while listing ClusterServiceClasses for ClusterServiceBroker %q", csb.Name)
 		}
@@ -194,7 +199,7 @@ func (s *serviceCatalogClient) getClusterServiceClassesForBrokers(brokers []v1be
 	return cscWithMatchingLabel, nil
 }
 
-func (s *serviceCatalogClient) getServiceInstancesForClusterServiceClasses(serviceClasses []v1beta1.ClusterServiceClass) ([]v1beta1.ServiceInstance, error) {
+func (s *serviceCatalogClient) getServiceInstancesForClusterServiceClasses(ctx context.Context, serviceClasses []v1beta1.ClusterServiceClass) ([]v1beta1.ServiceInstance, error) {
 	var serviceInstances []v1beta1.ServiceInstance
 
 	for _, clusterServiceClass := range serviceClasses {
@@ -202,7 +207,7 @@ func (s *serviceCatalogClient) getServiceInstancesForClusterServiceClasses(servi
 
 		options := fixListOptionsWithLabelSelector(ClusterServiceClassRefNameLabel, labelValue)
 
-		serviceInstancesList, err := s.listServiceInstance(options)
+		serviceInstancesList, err := s.listServiceInstance(ctx, options)
 		if err != nil {
 			return []v1beta1.ServiceInstance{}, errors.Wrapf(err, "while listing ServiceInstances")
 		}
@@ -215,12 +220,12 @@ func (s *serviceCatalogClient) getServiceInstancesForClusterServiceClasses(servi
 	return serviceInstances, nil
 }
 
-func (s *serviceCatalogClient) deleteServiceInstances(serviceInstances []v1beta1.ServiceInstance) {
+func (s *serviceCatalogClient) deleteServiceInstances(ctx context.Context, serviceInstances []v1beta1.ServiceInstance) {
 	for _, serviceInstance := range serviceInstances {
 		logrus.Debugf("trying to delete ServiceInstance %q", serviceInstance.Name)
 
-		_ = wait.PollImmediate(10*time.Second, 2*time.Minute, func() (done bool, err error) {
-			if err := s.client.ServicecatalogV1beta1().ServiceInstances(serviceInstance.Namespace).Delete(context.Background(), serviceInstance.Name, metav1.DeleteOptions{}); err != nil {
+		_ = pollImmediate(ctx, func() (done bool, err error) {
+			if err := s.client.ServicecatalogV1beta1().ServiceInstances(serviceInstance.Namespace).Delete(ctx, serviceInstance.Name, metav1.DeleteOptions{}); err != nil {
 				logrus.Errorf("while removing ServiceInstance %s: %s", serviceInstance.Name, err.Error())
 				if apiErrors.IsNotFound(err) {
 					return true, nil
@@ -232,13 +237,13 @@ func (s *serviceCatalogClient) deleteServiceInstances(serviceInstances []v1beta1
 	}
 }
 
-func (s *serviceCatalogClient) removeClusterBrokersFinalizers(brokers []v1beta1.ClusterServiceBroker) {
+func (s *serviceCatalogClient) removeClusterBrokersFinalizers(ctx context.Context, brokers []v1beta1.ClusterServiceBroker) {
 	for _, broker := range brokers {
 		logrus.Debugf("trying to remove finalizers from ClusterServiceBroker %q", broker.Name)
 
-		_ = wait.PollImmediate(10*time.Second, 2*time.Minute, func() (bool, error) {
+		_ = pollImmediate(ctx, func() (bool, error) {
 			broker.Finalizers = []string{}
-			if _, err := s.client.ServicecatalogV1beta1().ClusterServiceBrokers().Update(context.Background(), &broker, metav1.UpdateOptions{}); err != nil {
+			if _, err := s.client.ServicecatalogV1beta1().ClusterServiceBrokers().Update(ctx, &broker, metav1.UpdateOptions{}); err != nil {
 				if apiErrors.IsNotFound(err) {
 					return true, nil
 				}
@@ -250,6 +255,14 @@ func (s *serviceCatalogClient) removeClusterBrokersFinalizers(brokers []v1beta1.
 	}
 }
 
+// pollImmediate polls the condition until it is done, the cleanup poll timeout passes or the context is done
+func pollImmediate(ctx context.Context, condition wait.ConditionFunc) error {
+	ctx, cancel := context.WithTimeout(ctx, cleanupPollTimeout)
+	defer cancel()
+
+	return wait.PollImmediateUntil(cleanupPollInterval, condition, ctx.Done())
+}
+
 func fixListOptionsWithLabelSelector(labelName, labelValue string) metav1.ListOptions {
 	labelSelector := metav1.LabelSelector{
 		MatchLabels: map[string]string{labelName: labelValue},
@@ -49,17 +49,17 @@ func TestServiceCatalogClient_PerformCleanup(t *testing.T) {
 
 	t.Run("should perform resource cleanup succesfully with happy path", func(t *testing.T) {
 		// when
-		err := cli.PerformCleanup(TestResourceSelector)
+		err := cli.PerformCleanup(context.Background(), CleanupSelector{URLPrefix: TestResourceSelector})
 
 		// then
 		require.NoError(t, err)
 
 		// when
-		instances, err := cli.listServiceInstance(metav1.ListOptions{})
+		instances, err := cli.listServiceInstance(context.Background(), metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Nil(t, instances.Items)
 
-		brokers, err := cli.listClusterServiceBroker(metav1.ListOptions{})
+		brokers, err := cli.listClusterServiceBroker(context.Background(), metav1.ListOptions{})
 		require.NoError(t, err)
 		for _, broker := range brokers.Items {
 			assert.Empty(t, broker.Finalizers)
@@ -75,7 +75,7 @@ func TestServiceCatalogClient_PerformCleanup(t *testing.T) {
 		})
 
 		// when
-		err := cli.PerformCleanup(TestResourceSelector)
+		err := cli.PerformCleanup(context.Background(), CleanupSelector{URLPrefix: TestResourceSelector})
 
 		// then
 		require.Error(t, err)
@@ -90,7 +90,7 @@ func TestServiceCatalogClient_PerformCleanup(t *testing.T) {
 		})
 
 		// when
-		err := cli.PerformCleanup(TestResourceSelector)
+		err := cli.PerformCleanup(context.Background(), CleanupSelector{URLPrefix: TestResourceSelector})
 
 		// then
 		require.Error(t, err)
@@ -105,7 +105,7 @@ func TestServiceCatalogClient_PerformCleanup(t *testing.T) {
 		})
 
 		// when
-		err := cli.PerformCleanup(TestResourceSelector)
+		err := cli.PerformCleanup(context.Background(), CleanupSelector{URLPrefix: TestResourceSelector})
 
 		// then
 		require.Error(t, err)
@@ -116,7 +116,7 @@ func TestServiceCatalogClient_PerformCleanup(t *testing.T) {
 		cli := &serviceCatalogClient{crdsManager: newIncompleteCRDListManager()}
 
 		// when
-		err := cli.PerformCleanup(TestResourceSelector)
+		err := cli.PerformCleanup(context.Background(), CleanupSelector{URLPrefix: TestResourceSelector})
 
 		// then
 		require.NoError(t, err)
@@ -127,7 +127,7 @@ func TestServiceCatalogClient_PerformCleanup(t *testing.T) {
 		cli := &serviceCatalogClient{crdsManager: newErrorCRDListManager()}
 
 		// when
-		err := cli.PerformCleanup(TestResourceSelector)
+		err := cli.PerformCleanup(context.Background(), CleanupSelector{URLPrefix: TestResourceSelector})
 
 		// then
 		require.Error(t, err)
@@ -141,7 +141,7 @@ func TestServiceCatalogClient_ListClusterServiceBroker(t *testing.T) {
 
 	t.Run("should list ClusterServiceBrokers successfully", func(t *testing.T) {
 		// when
-		list, err := cli.listClusterServiceBroker(metav1.ListOptions{})
+		list, err := cli.listClusterServiceBroker(context.Background(), metav1.ListOptions{})
 
 		// then
 		require.NoError(t, err)
@@ -155,7 +155,7 @@ func TestServiceCatalogClient_ListClusterServiceBroker(t *testing.T) {
 
 	t.Run("should return nil if no ClusterServiceBrokers found", func(t *testing.T) {
 		// when
-		list, _ := cli.listClusterServiceBroker(metav1.ListOptions{})
+		list, _ := cli.listClusterServiceBroker(context.Background(), metav1.ListOptions{})
 		// then
 		require.Nil(t, list.Items)
 	})
@@ -168,7 +168,7 @@ func TestServiceCatalogClient_ListClusterServiceClass(t *testing.T) {
 
 	t.Run("should list cluster service classes successfully", func(t *testing.T) {
 		// when
-		list, err := cli.listClusterServiceClass(metav1.ListOptions{})
+		list, err := cli.listClusterServiceClass(context.Background(), metav1.ListOptions{})
 
 		// then
 		require.NoError(t, err)
@@ -181,7 +181,7 @@ func TestServiceCatalogClient_ListClusterServiceClass(t *testing.T) {
 
 	t.Run("should return nil if no ClusterServiceClasses found", func(t *testing.T) {
 		// when
-		list, _ := cli.listClusterServiceClass(metav1.ListOptions{})
+		list, _ := cli.listClusterServiceClass(context.Background(), metav1.ListOptions{})
 		// then
 		require.Nil(t, list.Items)
 	})
@@ -194,7 +194,7 @@ func TestServiceCatalogClient_ListServiceInstance(t *testing.T) {
 
 	t.Run("should list service instances successfully", func(t *testing.T) {
 		// when
-		list, err := cli.listServiceInstance(metav1.ListOptions{})
+		list, err := cli.listServiceInstance(context.Background(), metav1.ListOptions{})
 
 		// then
 		require.NoError(t, err)
@@ -207,7 +207,7 @@ func TestServiceCatalogClient_ListServiceInstance(t *testing.T) {
 
 	t.Run("should return nil if no ServiceInstances found", func(t *testing.T) {
 		// when
-		list, _ := cli.listServiceInstance(metav1.ListOptions{})
+		list, _ := cli.listServiceInstance(context.Background(), metav1.ListOptions{})
 		// then
 		require.Nil(t, list.Items)
 	})
@@ -245,7 +245,7 @@ func TestServiceCatalogClient_GetClusterServiceClassesForBrokers(t *testing.T) {
 
 	t.Run("should list ClusterServiceClasses for ClusterServiceBroker when provided", func(t *testing.T) {
 		// when
-		resultList, err := cli.getClusterServiceClassesForBrokers(filteredBrokers)
+		resultList, err := cli.getClusterServiceClassesForBrokers(context.Background(), filteredBrokers)
 
 		// then
 		require.NoError(t, err)
@@ -259,7 +259,7 @@ func TestServiceCatalogClient_GetClusterServiceClassesForBrokers(t *testing.T) {
 
 	t.Run("should return nil if given ClusterServiceBroker do not provide ClusterServiceClass", func(t *testing.T) {
 		// when
-		resultList, _ := cli.getClusterServiceClassesForBrokers([]v1beta1.ClusterServiceBroker{
+		resultList, _ := cli.getClusterServiceClassesForBrokers(context.Background(), []v1beta1.ClusterServiceBroker{
 			{
 				TypeMeta:   metav1.TypeMeta{},
 				ObjectMeta: metav1.ObjectMeta{},
@@ -284,7 +284,7 @@ func TestServiceCatalogClient_GetServiceInstancesForClusterServiceClasses(t *tes
 
 	t.Run("should list ServiceInstances for ClusterServiceClass", func(t *testing.T) {
 		// when
-		resultList, err := cli.getServiceInstancesForClusterServiceClasses(clusterServiceClassList)
+		resultList, err := cli.getServiceInstancesForClusterServiceClasses(context.Background(), clusterServiceClassList)
 
 		// then
 		require.NoError(t, err)
@@ -300,7 +300,7 @@ func TestServiceCatalogClient_GetServiceInstancesForClusterServiceClasses(t *tes
 
 	t.Run("should return nil if no ServiceInstances found", func(t *testing.T) {
 		// when
-		resultList, _ := cli.getServiceInstancesForClusterServiceClasses([]v1beta1.ClusterServiceClass{
+		resultList, _ := cli.getServiceInstancesForClusterServiceClasses(context.Background(), []v1beta1.ClusterServiceClass{
 			{
 				TypeMeta:   metav1.TypeMeta{},
 				ObjectMeta: metav1.ObjectMeta{},
@@ -0,0 +1,75 @@
+package installation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+func NewResourceKindCleanupClient(kubeconfig *rest.Config) (CleanupClient, error) {
+	dynamicClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return &resourceKindClient{}, err
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(kubeconfig)
+	if err != nil {
+		return &resourceKindClient{}, err
+	}
+
+	return &resourceKindClient{
+		client:     dynamicClient,
+		restMapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+// resourceKindClient deletes all resources of the selected kind and waits until they are removed
+type resourceKindClient struct {
+	client     dynamic.Interface
+	restMapper meta.RESTMapper
+}
+
+func (c *resourceKindClient) PerformCleanup(ctx context.Context, selector CleanupSelector) error {
+	mapping, err := c.restMapper.RESTMapping(schema.GroupKind{Group: selector.Group, Kind: selector.Kind})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			logrus.Infof("%s not installed. Cleanup skipped", selector)
+			return nil
+		}
+		return errors.Wrapf(err, "while getting resource of %s", selector)
+	}
+	resource := c.client.Resource(mapping.Resource)
+
+	list, err := resource.Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "while listing %s", selector)
+	}
+
+	for _, item := range list.Items {
+		logrus.Debugf("trying to delete %s %s/%s", selector, item.GetNamespace(), item.GetName())
+		err := resource.Namespace(item.GetNamespace()).Delete(ctx, item.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apiErrors.IsNotFound(err) {
+			logrus.Errorf("while removing %s %s/%s: %s", selector, item.GetNamespace(), item.GetName(), err.Error())
+		}
+	}
+
+	return wait.PollImmediateUntil(cleanupPollInterval, func() (bool, error) {
+		remaining, err := resource.Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			logrus.Errorf("while listing %s: %s", selector, err.Error())
+			return false, nil
+		}
+		return len(remaining.Items) == 0, nil
+	}, ctx.Done())
+}
@@ -0,0 +1,72 @@
+package installation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var serviceBindingGVK = schema.GroupVersionKind{Group: "services.cloud.sap.com", Version: "v1alpha1", Kind: "ServiceBinding"}
+
+func TestResourceKindClient_PerformCleanup(t *testing.T) {
+	selector := CleanupSelector{Kind: "ServiceBinding", Group: "services.cloud.sap.com", Timeout: time.Minute}
+
+	t.Run("should delete all resources of the kind", func(t *testing.T) {
+		// given
+		cli := newTestResourceKindClient(newServiceBinding("default", "first"), newServiceBinding("kyma-system", "second"))
+
+		// when
+		err := cli.PerformCleanup(context.Background(), selector)
+
+		// then
+		require.NoError(t, err)
+
+		list, err := cli.client.Resource(serviceBindingGVK.GroupVersion().WithResource("servicebindings")).
+			Namespace(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("should skip cleanup when kind is not installed", func(t *testing.T) {
+		// given
+		cli := newTestResourceKindClient()
+
+		// when
+		err := cli.PerformCleanup(context.Background(), CleanupSelector{Kind: "ServiceInstance", Group: "services.cloud.sap.com", Timeout: time.Minute})
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func newTestResourceKindClient(objects ...runtime.Object) *resourceKindClient {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{serviceBindingGVK.GroupVersion()})
+	restMapper.Add(serviceBindingGVK, meta.RESTScopeNamespace)
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(serviceBindingGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(serviceBindingGVK.GroupVersion().WithKind("ServiceBindingList"), &unstructured.UnstructuredList{})
+
+	return &resourceKindClient{
+		client:     dynamicfake.NewSimpleDynamicClient(scheme, objects...),
+		restMapper: restMapper,
+	}
+}
+
+func newServiceBinding(namespace, name string) *unstructured.Unstructured {
+	binding := &unstructured.Unstructured{}
+	binding.SetGroupVersionKind(serviceBindingGVK)
+	binding.SetNamespace(namespace)
+	binding.SetName(name)
+
+	return binding
+}
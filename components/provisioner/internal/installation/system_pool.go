@@ -0,0 +1,64 @@
+package installation
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+)
+
+const (
+	nodeSelectorOverride = "nodeSelector"
+	tolerationsOverride  = "tolerations"
+)
+
+// systemComponents are the Kyma components scheduled on the dedicated system pool
+var systemComponents = map[model.KymaComponent]bool{
+	"istio":      true,
+	"monitoring": true,
+	"logging":    true,
+	"tracing":    true,
+	"kiali":      true,
+}
+
+var (
+	systemPoolNodeSelector = fmt.Sprintf(`{"%s": "true"}`, model.SystemWorkerPoolLabel)
+	systemPoolTolerations  = fmt.Sprintf(`[{"key": "%s", "operator": "Equal", "value": "true", "effect": "NoSchedule"}]`, model.SystemWorkerPoolTaint)
+)
+
+// ClusterComponents returns the Kyma components of the cluster, with the system pool overrides if the cluster has the dedicated system pool
+func ClusterComponents(cluster model.Cluster) []model.KymaComponentConfig {
+	if cluster.ClusterConfig.DedicatedSystemPool == nil {
+		return cluster.KymaConfig.Components
+	}
+	return WithSystemPoolOverrides(cluster.KymaConfig.Components)
+}
+
+// WithSystemPoolOverrides returns the components with the nodeSelector and tolerations overrides which schedule the Kyma system components on the dedicated system pool.
+// Overrides already provided for the component are not replaced and the passed components are not modified.
+func WithSystemPoolOverrides(components []model.KymaComponentConfig) []model.KymaComponentConfig {
+	withOverrides := make([]model.KymaComponentConfig, 0, len(components))
+
+	for _, component := range components {
+		if systemComponents[component.Component] {
+			entries := make([]model.ConfigEntry, len(component.Configuration.ConfigEntries))
+			copy(entries, component.Configuration.ConfigEntries)
+
+			entries = appendIfMissing(entries, nodeSelectorOverride, systemPoolNodeSelector)
+			entries = appendIfMissing(entries, tolerationsOverride, systemPoolTolerations)
+
+			component.Configuration.ConfigEntries = entries
+		}
+		withOverrides = append(withOverrides, component)
+	}
+
+	return withOverrides
+}
+
+func appendIfMissing(entries []model.ConfigEntry, key, value string) []model.ConfigEntry {
+	for _, entry := range entries {
+		if entry.Key == key {
+			return entries
+		}
+	}
+	return append(entries, model.NewConfigEntry(key, value, false))
+}
@@ -0,0 +1,87 @@
+package installation
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSystemPoolOverrides(t *testing.T) {
+	t.Run("should add overrides to system components only", func(t *testing.T) {
+		// given
+		components := []model.KymaComponentConfig{
+			{Component: "istio", Namespace: "istio-system"},
+			{Component: "custom-component", Namespace: "kyma-system"},
+		}
+
+		// when
+		withOverrides := WithSystemPoolOverrides(components)
+
+		// then
+		require.Len(t, withOverrides, 2)
+		assert.Equal(t, []model.ConfigEntry{
+			model.NewConfigEntry("nodeSelector", `{"kyma-project.io/system-pool": "true"}`, false),
+			model.NewConfigEntry("tolerations", `[{"key": "kyma-project.io/system-pool", "operator": "Equal", "value": "true", "effect": "NoSchedule"}]`, false),
+		}, withOverrides[0].Configuration.ConfigEntries)
+		assert.Empty(t, withOverrides[1].Configuration.ConfigEntries)
+		assert.Empty(t, components[0].Configuration.ConfigEntries)
+	})
+
+	t.Run("should not replace overrides provided for the component", func(t *testing.T) {
+		// given
+		nodeSelector := model.NewConfigEntry("nodeSelector", `{"custom": "selector"}`, false)
+		components := []model.KymaComponentConfig{
+			{
+				Component:     "monitoring",
+				Namespace:     "kyma-system",
+				Configuration: model.Configuration{ConfigEntries: []model.ConfigEntry{nodeSelector}},
+			},
+		}
+
+		// when
+		withOverrides := WithSystemPoolOverrides(components)
+
+		// then
+		entries := withOverrides[0].Configuration.ConfigEntries
+		require.Len(t, entries, 2)
+		assert.Equal(t, nodeSelector, entries[0])
+		assert.Equal(t, "tolerations", entries[1].Key)
+		assert.Len(t, components[0].Configuration.ConfigEntries, 1)
+	})
+
+	t.Run("should produce valid overrides", func(t *testing.T) {
+		// when
+		withOverrides := WithSystemPoolOverrides([]model.KymaComponentConfig{{Component: "logging", Namespace: "kyma-system"}})
+
+		// then
+		assert.NoError(t, ValidateOverrides(model.KymaConfig{Components: withOverrides}))
+	})
+}
+
+func TestClusterComponents(t *testing.T) {
+	kymaConfig := &model.KymaConfig{Components: []model.KymaComponentConfig{{Component: "istio", Namespace: "istio-system"}}}
+
+	t.Run("should return components without overrides for cluster without system pool", func(t *testing.T) {
+		// when
+		components := ClusterComponents(model.Cluster{KymaConfig: kymaConfig})
+
+		// then
+		assert.Empty(t, components[0].Configuration.ConfigEntries)
+	})
+
+	t.Run("should return components with overrides for cluster with system pool", func(t *testing.T) {
+		// given
+		cluster := model.Cluster{
+			ClusterConfig: model.GardenerConfig{DedicatedSystemPool: &model.SystemWorkerPool{Size: 2}},
+			KymaConfig:    kymaConfig,
+		}
+
+		// when
+		components := ClusterComponents(cluster)
+
+		// then
+		assert.Len(t, components[0].Configuration.ConfigEntries, 2)
+	})
+}
@@ -0,0 +1,276 @@
+package inventory
+
+import (
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ExportPath = "/admin/runtimes/export"
+
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+type Config struct {
+	// Token authenticates the requests passed with the Authorization: Bearer header, it is required when the export is enabled
+	Token string `envconfig:"optional"`
+	// BatchSize is the number of Runtimes loaded from the database at once
+	BatchSize int `envconfig:"default=500"`
+}
+
+// Runtime is the inventory entry of a single Runtime
+type Runtime struct {
+	RuntimeID         string    `json:"runtimeId"`
+	Tenant            string    `json:"tenant"`
+	SubAccountID      string    `json:"subAccountId"`
+	ShootName         string    `json:"shootName"`
+	Provider          string    `json:"provider"`
+	Region            string    `json:"region"`
+	MachineType       string    `json:"machineType"`
+	AutoScalerMin     int       `json:"autoScalerMin"`
+	AutoScalerMax     int       `json:"autoScalerMax"`
+	KubernetesVersion string    `json:"kubernetesVersion"`
+	KymaVersion       string    `json:"kymaVersion"`
+	KymaProfile       string    `json:"kymaProfile"`
+	Hibernated        bool      `json:"hibernated"`
+	Deleted           bool      `json:"deleted"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+var csvHeader = []string{
+	"runtime_id", "tenant", "sub_account_id", "shoot_name", "provider", "region", "machine_type",
+	"auto_scaler_min", "auto_scaler_max", "kubernetes_version", "kyma_version", "kyma_profile",
+	"hibernated", "deleted", "created_at",
+}
+
+func (r Runtime) csvRecord() []string {
+	return []string{
+		r.RuntimeID, r.Tenant, r.SubAccountID, r.ShootName, r.Provider, r.Region, r.MachineType,
+		strconv.Itoa(r.AutoScalerMin), strconv.Itoa(r.AutoScalerMax), r.KubernetesVersion, r.KymaVersion, r.KymaProfile,
+		strconv.FormatBool(r.Hibernated), strconv.FormatBool(r.Deleted), r.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func newRuntime(cluster model.Cluster) Runtime {
+	runtime := Runtime{
+		RuntimeID:         cluster.ID,
+		Tenant:            cluster.Tenant,
+		ShootName:         cluster.ClusterConfig.Name,
+		Provider:          cluster.ClusterConfig.Provider,
+		Region:            cluster.ClusterConfig.Region,
+		MachineType:       cluster.ClusterConfig.MachineType,
+		AutoScalerMin:     cluster.ClusterConfig.AutoScalerMin,
+		AutoScalerMax:     cluster.ClusterConfig.AutoScalerMax,
+		KubernetesVersion: cluster.ClusterConfig.KubernetesVersion,
+		Hibernated:        cluster.Hibernated,
+		Deleted:           cluster.Deleted,
+		CreatedAt:         cluster.CreationTimestamp,
+	}
+	if cluster.SubAccountId != nil {
+		runtime.SubAccountID = *cluster.SubAccountId
+	}
+	if cluster.KymaConfig != nil {
+		runtime.KymaVersion = cluster.KymaConfig.Release.Version
+		if cluster.KymaConfig.Profile != nil {
+			runtime.KymaProfile = string(*cluster.KymaConfig.Profile)
+		}
+	}
+
+	return runtime
+}
+
+// Register exposes the Runtime inventory export on the router when enabled.
+// It must be used only with the internal metrics router as the export lists Runtimes of all tenants.
+func Register(router *mux.Router, enabled bool, config Config, readSession dbsession.ReadSession, log logrus.FieldLogger) error {
+	if !enabled {
+		return nil
+	}
+	if config.Token == "" {
+		return errors.New("token is required when the Runtime inventory export is enabled")
+	}
+	if config.BatchSize <= 0 {
+		return errors.Errorf("batch size must be positive, got %d", config.BatchSize)
+	}
+
+	log.Infof("Runtime inventory export is enabled under %s", ExportPath)
+
+	router.Handle(ExportPath, NewHandler(config, readSession, log)).Methods(http.MethodGet)
+
+	return nil
+}
+
+type handler struct {
+	token       string
+	batchSize   int
+	readSession dbsession.ReadSession
+	log         logrus.FieldLogger
+}
+
+// NewHandler returns the handler streaming the inventory of Runtimes in CSV or JSON format
+func NewHandler(config Config, readSession dbsession.ReadSession, log logrus.FieldLogger) http.Handler {
+	return &handler{
+		token:       config.Token,
+		batchSize:   config.BatchSize,
+		readSession: readSession,
+		log:         log,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatCSV
+	}
+	var writer recordWriter
+	switch format {
+	case formatCSV:
+		writer = &csvWriter{w: w}
+	case formatJSON:
+		writer = &jsonWriter{w: w}
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q, use %s or %s", format, formatCSV, formatJSON), http.StatusBadRequest)
+		return
+	}
+
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	written := 0
+	err = h.readSession.StreamClusters(filter, h.batchSize, func(cluster model.Cluster) error {
+		if written == 0 {
+			writer.start()
+		}
+		written++
+		return writer.write(newRuntime(cluster))
+	})
+	if err != nil {
+		h.log.Errorf("Failed to export Runtime inventory after %d Runtimes: %s", written, err.Error())
+		if written == 0 {
+			http.Error(w, "failed to export Runtime inventory", http.StatusInternalServerError)
+			return
+		}
+		// The status is already sent, the response is aborted so that the client does not take the partial export as complete
+		panic(http.ErrAbortHandler)
+	}
+
+	if written == 0 {
+		writer.start()
+	}
+	if err := writer.finish(); err != nil {
+		h.log.Errorf("Failed to finish Runtime inventory export: %s", err.Error())
+	}
+}
+
+func (h *handler) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+// filterFromQuery reads the tenant, subAccount, provider, region and deleted parameters, only Runtimes which are not deleted
+// are exported by default
+func filterFromQuery(r *http.Request) (model.ClustersFilter, error) {
+	query := r.URL.Query()
+	filter := model.ClustersFilter{}
+
+	if tenant := query.Get("tenant"); tenant != "" {
+		filter.Tenant = &tenant
+	}
+	if subAccount := query.Get("subAccount"); subAccount != "" {
+		filter.SubAccountID = &subAccount
+	}
+	if provider := query.Get("provider"); provider != "" {
+		filter.Provider = &provider
+	}
+	if region := query.Get("region"); region != "" {
+		filter.Region = &region
+	}
+
+	deleted := false
+	if value := query.Get("deleted"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return model.ClustersFilter{}, errors.Errorf("invalid deleted parameter %q", value)
+		}
+		deleted = parsed
+	}
+	filter.Deleted = &deleted
+
+	return filter, nil
+}
+
+type recordWriter interface {
+	start()
+	write(runtime Runtime) error
+	finish() error
+}
+
+type csvWriter struct {
+	w      http.ResponseWriter
+	writer *csv.Writer
+}
+
+func (c *csvWriter) start() {
+	c.w.Header().Set("Content-Type", "text/csv")
+	c.w.Header().Set("Content-Disposition", `attachment; filename="runtimes.csv"`)
+	c.writer = csv.NewWriter(c.w)
+	_ = c.writer.Write(csvHeader)
+}
+
+func (c *csvWriter) write(runtime Runtime) error {
+	return c.writer.Write(runtime.csvRecord())
+}
+
+func (c *csvWriter) finish() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// jsonWriter writes the Runtimes as a JSON array one by one instead of encoding the whole list at once
+type jsonWriter struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+	written bool
+}
+
+func (j *jsonWriter) start() {
+	j.w.Header().Set("Content-Type", "application/json")
+	j.encoder = json.NewEncoder(j.w)
+	_, _ = j.w.Write([]byte("["))
+}
+
+func (j *jsonWriter) write(runtime Runtime) error {
+	if j.written {
+		if _, err := j.w.Write([]byte(",")); err != nil {
+			return err
+		}
+	}
+	j.written = true
+	return j.encoder.Encode(runtime)
+}
+
+func (j *jsonWriter) finish() error {
+	_, err := j.w.Write([]byte("]\n"))
+	return err
+}
@@ -0,0 +1,304 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token     = "secret-token"
+	batchSize = 100
+)
+
+var createdAt = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestHandler(t *testing.T) {
+	clusters := []model.Cluster{
+		{
+			ID:                "runtime-1",
+			Tenant:            "tenant-1",
+			SubAccountId:      util.StringPtr("sub-account-1"),
+			CreationTimestamp: createdAt,
+			ClusterConfig: model.GardenerConfig{
+				Name:              "shoot-1",
+				Provider:          "azure",
+				Region:            "westeurope",
+				MachineType:       "Standard_D8_v3",
+				AutoScalerMin:     2,
+				AutoScalerMax:     10,
+				KubernetesVersion: "1.21.9",
+			},
+			KymaConfig: &model.KymaConfig{
+				Release: model.Release{Version: "2.0.4"},
+				Profile: kymaProfilePtr(model.ProductionProfile),
+			},
+		},
+		{
+			ID:                "runtime-2",
+			Tenant:            "tenant-2",
+			CreationTimestamp: createdAt,
+			Hibernated:        true,
+			ClusterConfig: model.GardenerConfig{
+				Name:              "shoot-2",
+				Provider:          "gcp",
+				Region:            "europe-west3",
+				MachineType:       "n1-standard-4",
+				AutoScalerMin:     1,
+				AutoScalerMax:     3,
+				KubernetesVersion: "1.21.9",
+			},
+		},
+	}
+
+	t.Run("should export Runtimes as CSV by default", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("StreamClusters", model.ClustersFilter{Deleted: util.BoolPtr(false)}, batchSize, mock.Anything).
+			Run(streamClusters(clusters)).
+			Return(nil)
+		server := newServer(readSession)
+		defer server.Close()
+
+		// when
+		response, err := get(server, "", token)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// then
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "text/csv", response.Header.Get("Content-Type"))
+
+		records, err := csv.NewReader(response.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			csvHeader,
+			{"runtime-1", "tenant-1", "sub-account-1", "shoot-1", "azure", "westeurope", "Standard_D8_v3", "2", "10", "1.21.9", "2.0.4", "PRODUCTION", "false", "false", "2026-10-01T12:00:00Z"},
+			{"runtime-2", "tenant-2", "", "shoot-2", "gcp", "europe-west3", "n1-standard-4", "1", "3", "1.21.9", "", "", "true", "false", "2026-10-01T12:00:00Z"},
+		}, records)
+	})
+
+	t.Run("should export Runtimes as JSON", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("StreamClusters", mock.Anything, batchSize, mock.Anything).
+			Run(streamClusters(clusters)).
+			Return(nil)
+		server := newServer(readSession)
+		defer server.Close()
+
+		// when
+		response, err := get(server, "?format=json", token)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// then
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+
+		var runtimes []Runtime
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&runtimes))
+		require.Len(t, runtimes, 2)
+		assert.Equal(t, Runtime{
+			RuntimeID:         "runtime-1",
+			Tenant:            "tenant-1",
+			SubAccountID:      "sub-account-1",
+			ShootName:         "shoot-1",
+			Provider:          "azure",
+			Region:            "westeurope",
+			MachineType:       "Standard_D8_v3",
+			AutoScalerMin:     2,
+			AutoScalerMax:     10,
+			KubernetesVersion: "1.21.9",
+			KymaVersion:       "2.0.4",
+			KymaProfile:       "PRODUCTION",
+			CreatedAt:         createdAt,
+		}, runtimes[0])
+		assert.Equal(t, "runtime-2", runtimes[1].RuntimeID)
+		assert.True(t, runtimes[1].Hibernated)
+	})
+
+	t.Run("should export empty JSON array when there are no Runtimes", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("StreamClusters", mock.Anything, batchSize, mock.Anything).Return(nil)
+		server := newServer(readSession)
+		defer server.Close()
+
+		// when
+		response, err := get(server, "?format=json", token)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// then
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		body, err := ioutil.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, "[]", string(body))
+	})
+
+	t.Run("should pass filters from query parameters", func(t *testing.T) {
+		// given
+		expectedFilter := model.ClustersFilter{
+			Tenant:       util.StringPtr("tenant-1"),
+			SubAccountID: util.StringPtr("sub-account-1"),
+			Provider:     util.StringPtr("azure"),
+			Region:       util.StringPtr("westeurope"),
+			Deleted:      util.BoolPtr(true),
+		}
+		readSession := &mocks.ReadSession{}
+		readSession.On("StreamClusters", expectedFilter, batchSize, mock.Anything).Return(nil)
+		server := newServer(readSession)
+		defer server.Close()
+
+		// when
+		response, err := get(server, "?tenant=tenant-1&subAccount=sub-account-1&provider=azure&region=westeurope&deleted=true", token)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("should abort the response when the database fails in the middle of the export", func(t *testing.T) {
+		for _, format := range []string{formatCSV, formatJSON} {
+			t.Run(format, func(t *testing.T) {
+				// given
+				readSession := &mocks.ReadSession{}
+				readSession.On("StreamClusters", mock.Anything, batchSize, mock.Anything).
+					Run(streamClusters(clusters[:1])).
+					Return(dberrors.Internal("connection reset"))
+				server := newServer(readSession)
+				defer server.Close()
+
+				// when
+				response, err := get(server, "?format="+format, token)
+				if err == nil {
+					defer response.Body.Close()
+					_, err = ioutil.ReadAll(response.Body)
+				}
+
+				// then
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("should return internal server error when the database fails before the first Runtime", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("StreamClusters", mock.Anything, batchSize, mock.Anything).Return(dberrors.Internal("connection refused"))
+		server := newServer(readSession)
+		defer server.Close()
+
+		// when
+		response, err := get(server, "", token)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	})
+
+	for _, testCase := range []struct {
+		description    string
+		query          string
+		token          string
+		expectedStatus int
+	}{
+		{description: "should reject request without token", query: "", token: "", expectedStatus: http.StatusUnauthorized},
+		{description: "should reject request with invalid token", query: "", token: "invalid", expectedStatus: http.StatusUnauthorized},
+		{description: "should reject unsupported format", query: "?format=xml", token: token, expectedStatus: http.StatusBadRequest},
+		{description: "should reject invalid deleted parameter", query: "?deleted=maybe", token: token, expectedStatus: http.StatusBadRequest},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			readSession := &mocks.ReadSession{}
+			server := newServer(readSession)
+			defer server.Close()
+
+			// when
+			response, err := get(server, testCase.query, testCase.token)
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			// then
+			assert.Equal(t, testCase.expectedStatus, response.StatusCode)
+			readSession.AssertNotCalled(t, "StreamClusters", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("should not register the export when disabled", func(t *testing.T) {
+		// given
+		router := mux.NewRouter()
+
+		// when
+		err := Register(router, false, Config{}, &mocks.ReadSession{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, router.Match(httptest.NewRequest(http.MethodGet, ExportPath, nil), &mux.RouteMatch{}))
+	})
+
+	t.Run("should require token when enabled", func(t *testing.T) {
+		// when
+		err := Register(mux.NewRouter(), true, Config{BatchSize: batchSize}, &mocks.ReadSession{}, logrus.New())
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func newServer(readSession *mocks.ReadSession) *httptest.Server {
+	router := mux.NewRouter()
+	err := Register(router, true, Config{Token: token, BatchSize: batchSize}, readSession, logrus.New())
+	if err != nil {
+		panic(err)
+	}
+
+	return httptest.NewServer(router)
+}
+
+func get(server *httptest.Server, query, token string) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, server.URL+ExportPath+query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return server.Client().Do(request)
+}
+
+func streamClusters(clusters []model.Cluster) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(2).(func(cluster model.Cluster) error)
+		for _, cluster := range clusters {
+			if err := fn(cluster); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func kymaProfilePtr(profile model.KymaProfile) *model.KymaProfile {
+	return &profile
+}
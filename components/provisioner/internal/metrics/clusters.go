@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// Clusters count is cached to not query the database on every scrape
+const clustersCacheTTL = time.Minute
+
+//go:generate mockery -name=ClustersStatsGetter
+type ClustersStatsGetter interface {
+	CountClustersGroupedBy() (model.ClustersCount, dberrors.Error)
+}
+
+type ClustersCollector struct {
+	statsGetter ClustersStatsGetter
+	cacheTTL    time.Duration
+
+	mutex       sync.Mutex
+	cached      model.ClustersCount
+	cachedAt    time.Time
+	errorsCount int
+
+	clustersDesc *prometheus.Desc
+	errorsDesc   *prometheus.Desc
+
+	log logrus.FieldLogger
+}
+
+func NewClustersCollector(statsGetter ClustersStatsGetter) *ClustersCollector {
+	return &ClustersCollector{
+		statsGetter: statsGetter,
+		cacheTTL:    clustersCacheTTL,
+
+		clustersDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "clusters"),
+			"The number of active clusters",
+			[]string{"provider", "region", "kyma_version"},
+			nil),
+		errorsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "clusters_collection_errors_total"),
+			"The number of failed attempts to count clusters",
+			[]string{},
+			nil),
+
+		log: logrus.WithField("collector", "clusters"),
+	}
+}
+
+func (c *ClustersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clustersDesc
+	ch <- c.errorsDesc
+}
+
+func (c *ClustersCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if time.Since(c.cachedAt) >= c.cacheTTL {
+		clustersCount, err := c.statsGetter.CountClustersGroupedBy()
+		if err != nil {
+			c.log.Errorf("failed to count clusters while collecting metrics: %s", err.Error())
+			c.errorsCount++
+			ch <- prometheus.MustNewConstMetric(c.errorsDesc, prometheus.CounterValue, float64(c.errorsCount))
+
+			return
+		}
+
+		c.cached = clustersCount
+		c.cachedAt = time.Now()
+	}
+
+	for group, count := range c.cached.Count {
+		ch <- prometheus.MustNewConstMetric(
+			c.clustersDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			group.Provider, group.Region, group.KymaVersion)
+	}
+	ch <- prometheus.MustNewConstMetric(c.errorsDesc, prometheus.CounterValue, float64(c.errorsCount))
+}
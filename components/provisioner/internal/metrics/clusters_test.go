@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/metrics/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClustersCollector_Collect(t *testing.T) {
+	clustersCount := model.ClustersCount{
+		Count: map[model.ClusterGroup]int{
+			{Provider: "gcp", Region: "europe-west4", KymaVersion: "1.20.0"}: 3,
+		},
+	}
+
+	t.Run("should expose clusters count and cache it", func(t *testing.T) {
+		// given
+		statsGetter := &mocks.ClustersStatsGetter{}
+		statsGetter.On("CountClustersGroupedBy").Return(clustersCount, nil).Once()
+
+		collector := NewClustersCollector(statsGetter)
+
+		for i := 0; i < 2; i++ {
+			receiver := make(chan prometheus.Metric, 2)
+
+			// when
+			collector.Collect(receiver)
+			close(receiver)
+
+			// then
+			clustersMetric := <-receiver
+			assertGaugeValue(t, clustersMetric, float64(3))
+			assert.Contains(t, clustersMetric.Desc().String(), "kcp_provisioner_clusters")
+			assertLabels(t, clustersMetric, map[string]string{"provider": "gcp", "region": "europe-west4", "kyma_version": "1.20.0"})
+
+			errorsMetric := <-receiver
+			assertCounterValue(t, errorsMetric, float64(0))
+		}
+
+		statsGetter.AssertNumberOfCalls(t, "CountClustersGroupedBy", 1)
+	})
+
+	t.Run("should expose errors count when database query fails", func(t *testing.T) {
+		// given
+		statsGetter := &mocks.ClustersStatsGetter{}
+		statsGetter.On("CountClustersGroupedBy").Return(model.ClustersCount{}, dberrors.Internal("error"))
+
+		collector := NewClustersCollector(statsGetter)
+		receiver := make(chan prometheus.Metric, 1)
+		defer close(receiver)
+
+		// when
+		collector.Collect(receiver)
+
+		// then
+		errorsMetric := <-receiver
+		assertCounterValue(t, errorsMetric, float64(1))
+		assert.Contains(t, errorsMetric.Desc().String(), "kcp_provisioner_clusters_collection_errors_total")
+	})
+}
+
+func Test_ClustersCollector_Describe(t *testing.T) {
+	collector := NewClustersCollector(nil)
+
+	receiver := make(chan *prometheus.Desc, 2)
+	defer close(receiver)
+
+	collector.Describe(receiver)
+
+	clustersDesc := <-receiver
+	assert.Contains(t, clustersDesc.String(), "kcp_provisioner_clusters")
+
+	errorsDesc := <-receiver
+	assert.Contains(t, errorsDesc.String(), "kcp_provisioner_clusters_collection_errors_total")
+}
+
+func assertLabels(t *testing.T, metric prometheus.Metric, expected map[string]string) {
+	metricDto := dto.Metric{}
+	err := metric.Write(&metricDto)
+	require.NoError(t, err)
+
+	labels := map[string]string{}
+	for _, label := range metricDto.Label {
+		labels[label.GetName()] = label.GetValue()
+	}
+	assert.Equal(t, expected, labels)
+}
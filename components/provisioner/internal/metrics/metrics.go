@@ -1,17 +1,63 @@
 package metrics
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"github.com/kyma-project/control-plane/components/provisioner/internal/audit"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/freeze"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/gardener"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/installation/release"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/database"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/readonly"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/recovery"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/regionpolicy"
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 const (
 	prometheusNamespace = "kcp"
 	prometheusSubsystem = "provisioner"
 )
 
-func Register(opsStatsGetter OperationsStatsGetter) error {
+func Register(opsStatsGetter OperationsStatsGetter, clustersStatsGetter ClustersStatsGetter, queues map[model.OperationType]queue.OperationQueue, tokenStatsGetter TokenRefreshStatsGetter) error {
 	err := prometheus.Register(NewInProgressOperationsCollector(opsStatsGetter))
 	if err != nil {
 		return err
 	}
 
+	err = prometheus.Register(NewClustersCollector(clustersStatsGetter))
+	if err != nil {
+		return err
+	}
+
+	err = prometheus.Register(NewPausedQueuesCollector(queues))
+	if err != nil {
+		return err
+	}
+
+	err = prometheus.Register(NewTokenRefreshesCollector(tokenStatsGetter))
+	if err != nil {
+		return err
+	}
+
+	collectors := append(recovery.Collectors(), audit.Collectors()...)
+	collectors = append(collectors, gardener.Collectors()...)
+	collectors = append(collectors, dbsession.Collectors()...)
+	collectors = append(collectors, operations.Collectors()...)
+	collectors = append(collectors, regionpolicy.Collectors()...)
+	collectors = append(collectors, freeze.Collectors()...)
+	collectors = append(collectors, readonly.Collectors()...)
+	collectors = append(collectors, release.Collectors()...)
+	collectors = append(collectors, database.Collectors()...)
+
+	for _, collector := range collectors {
+		err = prometheus.Register(collector)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
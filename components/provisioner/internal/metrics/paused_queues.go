@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PausedQueuesCollector struct {
+	queues map[model.OperationType]queue.OperationQueue
+
+	pausedDesc *prometheus.Desc
+}
+
+func NewPausedQueuesCollector(queues map[model.OperationType]queue.OperationQueue) *PausedQueuesCollector {
+	return &PausedQueuesCollector{
+		queues: queues,
+
+		pausedDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "queue_paused"),
+			"Indicates whether the operation queue is paused",
+			[]string{"queue"},
+			nil),
+	}
+}
+
+func (c *PausedQueuesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pausedDesc
+}
+
+func (c *PausedQueuesCollector) Collect(ch chan<- prometheus.Metric) {
+	for operationType, operationQueue := range c.queues {
+		var value float64
+		if operationQueue.IsPaused() {
+			value = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.pausedDesc,
+			prometheus.GaugeValue,
+			value,
+			strings.ToLower(string(operationType)))
+	}
+}
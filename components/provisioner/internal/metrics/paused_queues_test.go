@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PausedQueuesCollector_Collect(t *testing.T) {
+	provisioningQueue := &mocks.OperationQueue{}
+	provisioningQueue.On("IsPaused").Return(true)
+
+	collector := NewPausedQueuesCollector(map[model.OperationType]queue.OperationQueue{
+		model.Provision: provisioningQueue,
+	})
+
+	receiver := make(chan prometheus.Metric, 1)
+	defer close(receiver)
+
+	collector.Collect(receiver)
+
+	pausedMetric := <-receiver
+	assertGaugeValue(t, pausedMetric, float64(1))
+	assert.Contains(t, pausedMetric.Desc().String(), "kcp_provisioner_queue_paused")
+}
+
+func Test_PausedQueuesCollector_Describe(t *testing.T) {
+	collector := NewPausedQueuesCollector(nil)
+
+	receiver := make(chan *prometheus.Desc, 1)
+	defer close(receiver)
+
+	collector.Describe(receiver)
+
+	pausedDesc := <-receiver
+	assert.Contains(t, pausedDesc.String(), "kcp_provisioner_queue_paused")
+}
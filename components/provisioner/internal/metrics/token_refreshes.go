@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"github.com/kyma-project/control-plane/components/provisioner/internal/oauth"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+//go:generate mockery -name=TokenRefreshStatsGetter
+type TokenRefreshStatsGetter interface {
+	RefreshStats() oauth.RefreshStats
+}
+
+type TokenRefreshesCollector struct {
+	statsGetter TokenRefreshStatsGetter
+
+	refreshesDesc *prometheus.Desc
+}
+
+func NewTokenRefreshesCollector(statsGetter TokenRefreshStatsGetter) *TokenRefreshesCollector {
+	return &TokenRefreshesCollector{
+		statsGetter: statsGetter,
+
+		refreshesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "director_token_refreshes_total"),
+			"The number of Director OAuth token refreshes",
+			[]string{"result"},
+			nil),
+	}
+}
+
+func (c *TokenRefreshesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.refreshesDesc
+}
+
+func (c *TokenRefreshesCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.statsGetter.RefreshStats()
+
+	ch <- prometheus.MustNewConstMetric(c.refreshesDesc, prometheus.CounterValue, float64(stats.Succeeded), "success")
+	ch <- prometheus.MustNewConstMetric(c.refreshesDesc, prometheus.CounterValue, float64(stats.Failed), "failure")
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/metrics/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/oauth"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TokenRefreshesCollector_Collect(t *testing.T) {
+	statsGetter := &mocks.TokenRefreshStatsGetter{}
+	statsGetter.On("RefreshStats").Return(oauth.RefreshStats{Succeeded: 4, Failed: 1})
+
+	collector := NewTokenRefreshesCollector(statsGetter)
+
+	receiver := make(chan prometheus.Metric, 2)
+	defer close(receiver)
+
+	collector.Collect(receiver)
+
+	successMetric := <-receiver
+	assertCounterValue(t, successMetric, float64(4))
+	assert.Contains(t, successMetric.Desc().String(), "kcp_provisioner_director_token_refreshes_total")
+
+	failureMetric := <-receiver
+	assertCounterValue(t, failureMetric, float64(1))
+}
+
+func assertCounterValue(t *testing.T, metric prometheus.Metric, expected float64) {
+	metricDto := dto.Metric{}
+	err := metric.Write(&metricDto)
+	require.NoError(t, err)
+
+	require.NotNil(t, metricDto.Counter)
+	require.NotNil(t, metricDto.Counter.Value)
+	assert.Equal(t, expected, *metricDto.Counter.Value)
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const maxClusterAutoscalerDuration = 24 * time.Hour
+
+// ClusterAutoscalerConfig tunes the cluster autoscaler of the Shoot, the settings which are not set keep the Gardener defaults
+type ClusterAutoscalerConfig struct {
+	ScaleDownDelayAfterAdd        *v1.Duration `json:"scaleDownDelayAfterAdd,omitempty"`
+	ScaleDownUnneededTime         *v1.Duration `json:"scaleDownUnneededTime,omitempty"`
+	ScaleDownUtilizationThreshold *float64     `json:"scaleDownUtilizationThreshold,omitempty"`
+	MaxNodeProvisionTime          *v1.Duration `json:"maxNodeProvisionTime,omitempty"`
+}
+
+// ClusterAutoscalerConfigFromInput replaces the current settings with the ones provided in the input and checks if they are in the allowed ranges
+func ClusterAutoscalerConfigFromInput(input *gqlschema.ClusterAutoscalerConfigInput, current *ClusterAutoscalerConfig) (*ClusterAutoscalerConfig, apperrors.AppError) {
+	if input == nil {
+		return current, nil
+	}
+
+	config := ClusterAutoscalerConfig{}
+	if current != nil {
+		config = *current
+	}
+
+	for _, setting := range []struct {
+		name  string
+		input *string
+		value **v1.Duration
+		min   time.Duration
+	}{
+		{name: "scaleDownDelayAfterAdd", input: input.ScaleDownDelayAfterAdd, value: &config.ScaleDownDelayAfterAdd, min: 0},
+		{name: "scaleDownUnneededTime", input: input.ScaleDownUnneededTime, value: &config.ScaleDownUnneededTime, min: time.Minute},
+		{name: "maxNodeProvisionTime", input: input.MaxNodeProvisionTime, value: &config.MaxNodeProvisionTime, min: time.Minute},
+	} {
+		if setting.input == nil {
+			continue
+		}
+		duration, err := time.ParseDuration(*setting.input)
+		if err != nil || duration < setting.min || duration > maxClusterAutoscalerDuration {
+			return nil, apperrors.BadRequest("error: cluster autoscaler %s %s has to be a duration between %s and %s", setting.name, *setting.input, setting.min, maxClusterAutoscalerDuration)
+		}
+		*setting.value = &v1.Duration{Duration: duration}
+	}
+
+	if threshold := input.ScaleDownUtilizationThreshold; threshold != nil {
+		if *threshold <= 0 || *threshold > 1 {
+			return nil, apperrors.BadRequest("error: cluster autoscaler scaleDownUtilizationThreshold %v has to be greater than 0 and at most 1", *threshold)
+		}
+		config.ScaleDownUtilizationThreshold = threshold
+	}
+
+	return &config, nil
+}
+
+// applyClusterAutoscalerConfig sets only the provided settings on the Shoot, the cluster autoscaler section is not added
+// if nothing is provided, so that Gardener defaults apply
+func applyClusterAutoscalerConfig(shoot *gardener_types.Shoot, config *ClusterAutoscalerConfig) {
+	if config == nil || *config == (ClusterAutoscalerConfig{}) {
+		return
+	}
+
+	if shoot.Spec.Kubernetes.ClusterAutoscaler == nil {
+		shoot.Spec.Kubernetes.ClusterAutoscaler = &gardener_types.ClusterAutoscaler{}
+	}
+	clusterAutoscaler := shoot.Spec.Kubernetes.ClusterAutoscaler
+
+	if config.ScaleDownDelayAfterAdd != nil {
+		clusterAutoscaler.ScaleDownDelayAfterAdd = config.ScaleDownDelayAfterAdd
+	}
+	if config.ScaleDownUnneededTime != nil {
+		clusterAutoscaler.ScaleDownUnneededTime = config.ScaleDownUnneededTime
+	}
+	if config.ScaleDownUtilizationThreshold != nil {
+		clusterAutoscaler.ScaleDownUtilizationThreshold = config.ScaleDownUtilizationThreshold
+	}
+	if config.MaxNodeProvisionTime != nil {
+		clusterAutoscaler.MaxNodeProvisionTime = config.MaxNodeProvisionTime
+	}
+}
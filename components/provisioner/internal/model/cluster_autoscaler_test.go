@@ -0,0 +1,131 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGardenerConfig_ClusterAutoscalerConfig(t *testing.T) {
+	gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+
+	t.Run("should omit cluster autoscaler from Shoot template when settings are not provided", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, template.Spec.Kubernetes.ClusterAutoscaler)
+	})
+
+	t.Run("should set cluster autoscaler on Shoot template", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.ClusterAutoscalerConfig = &ClusterAutoscalerConfig{
+			ScaleDownUnneededTime:         &v1.Duration{Duration: time.Hour},
+			ScaleDownUtilizationThreshold: floatPtr(0.3),
+		}
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &gardener_types.ClusterAutoscaler{
+			ScaleDownUnneededTime:         &v1.Duration{Duration: time.Hour},
+			ScaleDownUtilizationThreshold: floatPtr(0.3),
+		}, template.Spec.Kubernetes.ClusterAutoscaler)
+	})
+
+	t.Run("should update only provided cluster autoscaler settings on Shoot upgrade", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.ClusterAutoscalerConfig = &ClusterAutoscalerConfig{MaxNodeProvisionTime: &v1.Duration{Duration: 30 * time.Minute}}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Spec.Kubernetes.ClusterAutoscaler = &gardener_types.ClusterAutoscaler{ScanInterval: &v1.Duration{Duration: 20 * time.Second}}
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &gardener_types.ClusterAutoscaler{
+			ScanInterval:         &v1.Duration{Duration: 20 * time.Second},
+			MaxNodeProvisionTime: &v1.Duration{Duration: 30 * time.Minute},
+		}, shoot.Spec.Kubernetes.ClusterAutoscaler)
+	})
+}
+
+func TestClusterAutoscalerConfigFromInput(t *testing.T) {
+	t.Run("should keep current settings when input is not provided", func(t *testing.T) {
+		// given
+		current := &ClusterAutoscalerConfig{ScaleDownUtilizationThreshold: floatPtr(0.3)}
+
+		// when
+		config, err := ClusterAutoscalerConfigFromInput(nil, current)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, current, config)
+	})
+
+	t.Run("should replace only settings provided in input", func(t *testing.T) {
+		// given
+		current := &ClusterAutoscalerConfig{
+			ScaleDownDelayAfterAdd:        &v1.Duration{Duration: time.Hour},
+			ScaleDownUtilizationThreshold: floatPtr(0.3),
+		}
+		input := &gqlschema.ClusterAutoscalerConfigInput{
+			ScaleDownDelayAfterAdd: util.StringPtr("0s"),
+			ScaleDownUnneededTime:  util.StringPtr("45m"),
+		}
+
+		// when
+		config, err := ClusterAutoscalerConfigFromInput(input, current)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &ClusterAutoscalerConfig{
+			ScaleDownDelayAfterAdd:        &v1.Duration{Duration: 0},
+			ScaleDownUnneededTime:         &v1.Duration{Duration: 45 * time.Minute},
+			ScaleDownUtilizationThreshold: floatPtr(0.3),
+		}, config)
+		assert.Equal(t, time.Hour, current.ScaleDownDelayAfterAdd.Duration)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		input       gqlschema.ClusterAutoscalerConfigInput
+	}{
+		{description: "malformed duration", input: gqlschema.ClusterAutoscalerConfigInput{ScaleDownUnneededTime: util.StringPtr("30")}},
+		{description: "negative delay", input: gqlschema.ClusterAutoscalerConfigInput{ScaleDownDelayAfterAdd: util.StringPtr("-1m")}},
+		{description: "too short unneeded time", input: gqlschema.ClusterAutoscalerConfigInput{ScaleDownUnneededTime: util.StringPtr("30s")}},
+		{description: "too long provision time", input: gqlschema.ClusterAutoscalerConfigInput{MaxNodeProvisionTime: util.StringPtr("25h")}},
+		{description: "zero threshold", input: gqlschema.ClusterAutoscalerConfigInput{ScaleDownUtilizationThreshold: floatPtr(0)}},
+		{description: "threshold greater than 1", input: gqlschema.ClusterAutoscalerConfigInput{ScaleDownUtilizationThreshold: floatPtr(1.5)}},
+	} {
+		t.Run("should reject "+testCase.description, func(t *testing.T) {
+			// when
+			_, err := ClusterAutoscalerConfigFromInput(&testCase.input, nil)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
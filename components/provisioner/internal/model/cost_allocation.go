@@ -0,0 +1,89 @@
+package model
+
+import (
+	"strings"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	CostAllocationLabelPrefix = "kcp.kyma-project.io/"
+
+	GlobalAccountIDLabel = CostAllocationLabelPrefix + "global-account-id"
+	SubAccountIDLabel    = CostAllocationLabelPrefix + "subaccount-id"
+	InstanceIDLabel      = CostAllocationLabelPrefix + "instance-id"
+)
+
+// CostAllocation identifies the accounts and the Kyma Environment Broker instance the costs of the Shoot are attributed to,
+// the identifiers are set as the Shoot labels
+type CostAllocation struct {
+	GlobalAccountID string `json:"globalAccountID,omitempty"`
+	SubAccountID    string `json:"subAccountID,omitempty"`
+	InstanceID      string `json:"instanceID,omitempty"`
+}
+
+// CostAllocationFromLabels reads the identifiers back from the Shoot labels
+func CostAllocationFromLabels(labels map[string]string) CostAllocation {
+	return CostAllocation{
+		GlobalAccountID: labels[GlobalAccountIDLabel],
+		SubAccountID:    labels[SubAccountIDLabel],
+		InstanceID:      labels[InstanceIDLabel],
+	}
+}
+
+// Labels returns the Shoot labels of the identifiers which are set
+func (c CostAllocation) Labels() map[string]string {
+	labels := make(map[string]string)
+	for key, value := range c.labelValues() {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+
+	return labels
+}
+
+// Validate checks if the identifiers can be used as the label values
+func (c CostAllocation) Validate() apperrors.AppError {
+	for _, key := range []string{GlobalAccountIDLabel, SubAccountIDLabel, InstanceIDLabel} {
+		if err := ValidateCostAllocationLabelValue(key, c.labelValues()[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateCostAllocationLabelValue checks the syntax and the length of the label value
+func ValidateCostAllocationLabelValue(key, value string) apperrors.AppError {
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return apperrors.BadRequest("error: invalid value %s of the %s label: %s", value, key, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func (c CostAllocation) labelValues() map[string]string {
+	return map[string]string{
+		GlobalAccountIDLabel: c.GlobalAccountID,
+		SubAccountIDLabel:    c.SubAccountID,
+		InstanceIDLabel:      c.InstanceID,
+	}
+}
+
+// applyCostAllocationLabels sets the labels of the identifiers on the Shoot and removes the labels of the identifiers which are not set
+func applyCostAllocationLabels(shoot *gardener_types.Shoot, costAllocation CostAllocation) {
+	for key, value := range costAllocation.labelValues() {
+		if value == "" {
+			delete(shoot.Labels, key)
+			continue
+		}
+
+		if shoot.Labels == nil {
+			shoot.Labels = make(map[string]string)
+		}
+		shoot.Labels[key] = value
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGardenerConfig_CostAllocation(t *testing.T) {
+	gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+
+	t.Run("should set cost allocation labels on Shoot template", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.CostAllocation = CostAllocation{GlobalAccountID: "account", SubAccountID: "sub-account", InstanceID: "instance-id"}
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			AccountLabel:         "account",
+			SubAccountLabel:      "sub-account",
+			GlobalAccountIDLabel: "account",
+			SubAccountIDLabel:    "sub-account",
+			InstanceIDLabel:      "instance-id",
+		}, template.Labels)
+		assert.Equal(t, gardenerConfig.CostAllocation, CostAllocationFromLabels(template.Labels))
+	})
+
+	t.Run("should reconcile cost allocation labels on Shoot upgrade", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.CostAllocation = CostAllocation{GlobalAccountID: "account", SubAccountID: "other-sub-account"}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Labels = map[string]string{
+			AccountLabel:      "account",
+			SubAccountIDLabel: "sub-account",
+			InstanceIDLabel:   "instance-id",
+			"custom":          "value",
+		}
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			AccountLabel:         "account",
+			GlobalAccountIDLabel: "account",
+			SubAccountIDLabel:    "other-sub-account",
+			"custom":             "value",
+		}, shoot.Labels)
+	})
+}
+
+func TestCostAllocation_Validate(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		costAllocation CostAllocation
+		valid          bool
+	}{
+		{description: "identifiers not set", costAllocation: CostAllocation{}, valid: true},
+		{description: "valid identifiers", costAllocation: CostAllocation{GlobalAccountID: "3e64ebae-38b5-46a0-b1ed-9ccee153a0ae", SubAccountID: "sub_account.1", InstanceID: "instance-id"}, valid: true},
+		{description: "invalid characters", costAllocation: CostAllocation{InstanceID: "instance id"}, valid: false},
+		{description: "not starting with alphanumeric character", costAllocation: CostAllocation{SubAccountID: "-sub-account"}, valid: false},
+		{description: "too long", costAllocation: CostAllocation{GlobalAccountID: strings.Repeat("a", 64)}, valid: false},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := testCase.costAllocation.Validate()
+
+			// then
+			assert.Equal(t, testCase.valid, err == nil)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 
@@ -20,8 +22,32 @@ const (
 	AccountLabel    = "account"
 
 	LicenceTypeAnnotation = "kcp.provisioner.kyma-project.io/licence-type"
+	// ManagedAnnotationsAnnotation lists keys of the Shoot annotations set from the Gardener config,
+	// only these annotations are removed from the Shoot when they are removed from the config
+	ManagedAnnotationsAnnotation = "kcp.provisioner.kyma-project.io/managed-annotations"
 )
 
+// NetworkingType is a type of the Gardener networking extension, it cannot be changed after the Shoot is created
+type NetworkingType string
+
+const (
+	CalicoNetworkingType NetworkingType = "calico"
+	CiliumNetworkingType NetworkingType = "cilium"
+)
+
+func (t NetworkingType) IsSupported() bool {
+	return t == CalicoNetworkingType || t == CiliumNetworkingType
+}
+
+func NetworkingTypeFromGraphQL(networkingType gqlschema.NetworkingType) NetworkingType {
+	switch networkingType {
+	case gqlschema.NetworkingTypeCilium:
+		return CiliumNetworkingType
+	default:
+		return CalicoNetworkingType
+	}
+}
+
 type OIDCConfig struct {
 	ClientID       string   `json:"clientID"`
 	GroupsClaim    string   `json:"groupsClaim"`
@@ -31,6 +57,18 @@ type OIDCConfig struct {
 	UsernamePrefix string   `json:"usernamePrefix"`
 }
 
+// DNSConfig is a custom domain of the Shoot with the providers managing its records, the domain is immutable
+type DNSConfig struct {
+	Domain    string         `json:"domain"`
+	Providers []*DNSProvider `json:"providers"`
+}
+
+type DNSProvider struct {
+	Type       string   `json:"type"`
+	SecretName string   `json:"secretName"`
+	Domains    []string `json:"domains"`
+}
+
 type GardenerConfig struct {
 	ID                                  string
 	ClusterID                           string
@@ -51,13 +89,20 @@ type GardenerConfig struct {
 	WorkerCidr                          string
 	AutoScalerMin                       int
 	AutoScalerMax                       int
-	MaxSurge                            int
-	MaxUnavailable                      int
+	MaxSurge                            *intstr.IntOrString `db:"-"`
+	MaxUnavailable                      *intstr.IntOrString `db:"-"`
 	EnableKubernetesVersionAutoUpdate   bool
 	EnableMachineImageVersionAutoUpdate bool
 	AllowPrivilegedContainers           bool
+	NetworkingType                      NetworkingType
+	ShootAnnotations                    map[string]string        `db:"-"`
+	CostAllocation                      CostAllocation           `db:"-"`
+	ClusterAutoscalerConfig             *ClusterAutoscalerConfig `db:"-"`
+	KubeAPIServerConfig                 *KubeAPIServerConfig     `db:"-"`
+	DedicatedSystemPool                 *SystemWorkerPool        `db:"-"`
 	GardenerProviderConfig              GardenerProviderConfig
 	OIDCConfig                          *OIDCConfig
+	DNSConfig                           *DNSConfig `db:"-"`
 }
 
 func (c GardenerConfig) ToShootTemplate(namespace string, accountId string, subAccountId string, oidcConfig *OIDCConfig) (*gardener_types.Shoot, apperrors.AppError) {
@@ -101,10 +146,11 @@ func (c GardenerConfig) ToShootTemplate(namespace string, accountId string, subA
 				},
 			},
 			Networking: gardener_types.Networking{
-				Type:  "calico",                        // Default value - we may consider adding it to API (if Hydroform will support it)
+				Type:  string(c.NetworkingType),
 				Nodes: util.StringPtr("10.250.0.0/19"), // TODO: it is required - provide configuration in API (when Hydroform will support it)
 			},
 			Purpose: purpose,
+			DNS:     gardenerDNSConfig(c.DNSConfig),
 			Maintenance: &gardener_types.Maintenance{
 				AutoUpdate: &gardener_types.MaintenanceAutoUpdate{
 					KubernetesVersion:   c.EnableKubernetesVersionAutoUpdate,
@@ -114,14 +160,58 @@ func (c GardenerConfig) ToShootTemplate(namespace string, accountId string, subA
 		},
 	}
 
+	applyShootAnnotations(shoot, c.ShootAnnotations)
+	applyCostAllocationLabels(shoot, c.CostAllocation)
+	applyClusterAutoscalerConfig(shoot, c.ClusterAutoscalerConfig)
+	applyKubeAPIServerConfig(shoot, c.KubeAPIServerConfig)
+
 	err := c.GardenerProviderConfig.ExtendShootConfig(c, shoot)
 	if err != nil {
 		return nil, err.Append("error extending shoot config with Provider")
 	}
 
+	applySystemWorkerPool(shoot, c.DedicatedSystemPool)
+
 	return shoot, nil
 }
 
+// applyShootAnnotations sets the annotations on the Shoot and removes the ones which were previously set
+// from the Gardener config but are not there anymore. Annotations set by others are never removed.
+func applyShootAnnotations(shoot *gardener_types.Shoot, annotations map[string]string) {
+	for _, key := range managedAnnotationKeys(shoot) {
+		if _, found := annotations[key]; !found {
+			delete(shoot.Annotations, key)
+		}
+	}
+
+	if len(annotations) == 0 {
+		delete(shoot.Annotations, ManagedAnnotationsAnnotation)
+		return
+	}
+
+	if shoot.Annotations == nil {
+		shoot.Annotations = make(map[string]string)
+	}
+
+	keys := make([]string, 0, len(annotations))
+	for key, value := range annotations {
+		shoot.Annotations[key] = value
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	shoot.Annotations[ManagedAnnotationsAnnotation] = strings.Join(keys, ",")
+}
+
+func managedAnnotationKeys(shoot *gardener_types.Shoot) []string {
+	managed := shoot.Annotations[ManagedAnnotationsAnnotation]
+	if managed == "" {
+		return nil
+	}
+
+	return strings.Split(managed, ",")
+}
+
 func gardenerOidcConfig(oidcConfig *OIDCConfig) *gardener_types.OIDCConfig {
 	if oidcConfig != nil {
 		return &gardener_types.OIDCConfig{
@@ -136,6 +226,36 @@ func gardenerOidcConfig(oidcConfig *OIDCConfig) *gardener_types.OIDCConfig {
 	return nil
 }
 
+// gardenerDNSConfig returns nil if the custom domain is not configured, so that Gardener assigns the default one
+func gardenerDNSConfig(dnsConfig *DNSConfig) *gardener_types.DNS {
+	if dnsConfig == nil {
+		return nil
+	}
+
+	return &gardener_types.DNS{
+		Domain:    util.StringPtr(dnsConfig.Domain),
+		Providers: gardenerDNSProviders(dnsConfig.Providers),
+	}
+}
+
+// gardenerDNSProviders marks the first provider as the primary one, which manages the records of the Shoot domain
+func gardenerDNSProviders(providers []*DNSProvider) []gardener_types.DNSProvider {
+	result := make([]gardener_types.DNSProvider, 0, len(providers))
+	for i, provider := range providers {
+		dnsProvider := gardener_types.DNSProvider{
+			Type:       util.StringPtr(provider.Type),
+			SecretName: util.StringPtr(provider.SecretName),
+			Primary:    util.BoolPtr(i == 0),
+		}
+		if len(provider.Domains) > 0 {
+			dnsProvider.Domains = &gardener_types.DNSIncludeExclude{Include: provider.Domains}
+		}
+		result = append(result, dnsProvider)
+	}
+
+	return result
+}
+
 type ProviderSpecificConfig string
 
 func (c ProviderSpecificConfig) RawJSON() string {
@@ -145,6 +265,7 @@ func (c ProviderSpecificConfig) RawJSON() string {
 type GardenerProviderConfig interface {
 	RawJSON() string
 	AsProviderSpecificConfig() gqlschema.ProviderSpecificConfig
+	CloudProfileName() string
 	ExtendShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError
 	EditShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError
 }
@@ -182,6 +303,13 @@ type GCPGardenerConfig struct {
 	input *gqlschema.GCPProviderConfigInput `db:"-"`
 }
 
+// GCPShieldedInstanceConfig holds the Shielded VM options applied to GCP Runtimes which do not specify them
+type GCPShieldedInstanceConfig struct {
+	EnableSecureBoot          bool
+	EnableIntegrityMonitoring bool
+	EnableVtpm                bool
+}
+
 func NewGCPGardenerConfig(input *gqlschema.GCPProviderConfigInput) (*GCPGardenerConfig, apperrors.AppError) {
 	config, err := json.Marshal(input)
 	if err != nil {
@@ -195,17 +323,94 @@ func NewGCPGardenerConfig(input *gqlschema.GCPProviderConfigInput) (*GCPGardener
 }
 
 func (c GCPGardenerConfig) AsProviderSpecificConfig() gqlschema.ProviderSpecificConfig {
-	return gqlschema.GCPProviderConfig{Zones: c.input.Zones}
+	return gqlschema.GCPProviderConfig{
+		Zones:                     c.input.Zones,
+		EnableSecureBoot:          c.input.EnableSecureBoot,
+		EnableIntegrityMonitoring: c.input.EnableIntegrityMonitoring,
+		EnableVtpm:                c.input.EnableVtpm,
+	}
+}
+
+func (c GCPGardenerConfig) CloudProfileName() string {
+	return "gcp"
 }
 
 func (c GCPGardenerConfig) EditShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
-	return updateShootConfig(gardenerConfig, shoot, c.input.Zones)
+	if appErr := updateShootConfig(gardenerConfig, shoot, c.input.Zones); appErr != nil {
+		return appErr
+	}
+
+	return c.updateShieldedInstanceConfig(shoot)
+}
+
+// updateShieldedInstanceConfig modifies only the Shielded VM options of all worker pools, other fields of the worker config are preserved
+func (c GCPGardenerConfig) updateShieldedInstanceConfig(shoot *gardener_types.Shoot) apperrors.AppError {
+	if c.input.EnableSecureBoot == nil && c.input.EnableIntegrityMonitoring == nil && c.input.EnableVtpm == nil {
+		return nil
+	}
+
+	shieldedInstanceConfig := NewGCPShieldedInstanceConfig(c.input)
+
+	for i := range shoot.Spec.Provider.Workers {
+		worker := &shoot.Spec.Provider.Workers[i]
+
+		workerConfig := map[string]interface{}{"apiVersion": gcpAPIVersion, "kind": workerConfigKind}
+		if worker.ProviderConfig != nil {
+			if err := json.Unmarshal(worker.ProviderConfig.Raw, &workerConfig); err != nil {
+				return apperrors.Internal("error decoding worker config: %s", err.Error())
+			}
+		} else if shieldedInstanceConfig == nil {
+			continue
+		}
+
+		if shieldedInstanceConfig != nil {
+			workerConfig["shieldedInstanceConfig"] = shieldedInstanceConfig
+		} else {
+			delete(workerConfig, "shieldedInstanceConfig")
+		}
+
+		jsonData, err := json.Marshal(workerConfig)
+		if err != nil {
+			return apperrors.Internal("error encoding worker config: %s", err.Error())
+		}
+		worker.ProviderConfig = &apimachineryRuntime.RawExtension{Raw: jsonData}
+	}
+
+	return nil
+}
+
+// MachineImageVersionPinOverridden checks if the pinned machine image version is overridden by the Gardener maintenance,
+// which updates the image of the worker nodes when the auto update of the machine image version is enabled
+func MachineImageVersionPinOverridden(config GardenerConfig) bool {
+	return util.NotNilOrEmpty(config.MachineImageVersion) && config.EnableMachineImageVersionAutoUpdate
+}
+
+// ShieldedInstanceConfigChanged checks if the upgrade changes the Shielded VM options which recreates the worker nodes
+func ShieldedInstanceConfigChanged(current, upgraded GardenerProviderConfig) bool {
+	currentConfig, ok := current.(*GCPGardenerConfig)
+	if !ok {
+		return false
+	}
+	upgradedConfig, ok := upgraded.(*GCPGardenerConfig)
+	if !ok {
+		return false
+	}
+
+	return gcpShieldedInstanceOptions(currentConfig.input) != gcpShieldedInstanceOptions(upgradedConfig.input)
 }
 
 func (c GCPGardenerConfig) ExtendShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
-	shoot.Spec.CloudProfileName = "gcp"
+	shoot.Spec.CloudProfileName = c.CloudProfileName()
 
-	workers := []gardener_types.Worker{getWorkerConfig(gardenerConfig, c.input.Zones)}
+	worker := getWorkerConfig(gardenerConfig, c.input.Zones)
+	if workerConfig := NewGCPWorkerConfig(c.input); workerConfig != nil {
+		jsonWorkerData, err := json.Marshal(workerConfig)
+		if err != nil {
+			return apperrors.Internal("error encoding worker config: %s", err.Error())
+		}
+		worker.ProviderConfig = &apimachineryRuntime.RawExtension{Raw: jsonWorkerData}
+	}
+	workers := []gardener_types.Worker{worker}
 
 	gcpInfra := NewGCPInfrastructure(gardenerConfig.WorkerCidr)
 	jsonData, err := json.Marshal(gcpInfra)
@@ -248,7 +453,12 @@ func NewAzureGardenerConfig(input *gqlschema.AzureProviderConfigInput) (*AzureGa
 }
 
 func (c AzureGardenerConfig) AsProviderSpecificConfig() gqlschema.ProviderSpecificConfig {
-	return gqlschema.AzureProviderConfig{VnetCidr: &c.input.VnetCidr, Zones: c.input.Zones}
+	return gqlschema.AzureProviderConfig{
+		VnetCidr:                     &c.input.VnetCidr,
+		Zones:                        c.input.Zones,
+		EnableNatGateway:             c.input.EnableNatGateway,
+		IdleConnectionTimeoutMinutes: c.input.IdleConnectionTimeoutMinutes,
+	}
 }
 
 type AWSGardenerConfig struct {
@@ -256,12 +466,97 @@ type AWSGardenerConfig struct {
 	input *gqlschema.AWSProviderConfigInput `db:"-"`
 }
 
+const (
+	AWSHTTPTokensRequired = "required"
+	AWSHTTPTokensOptional = "optional"
+
+	MinAWSHTTPPutResponseHopLimit = 1
+	MaxAWSHTTPPutResponseHopLimit = 64
+)
+
+// AWSInstanceMetadataOptions holds the instance metadata options applied to AWS Runtimes which do not specify them,
+// empty values leave the defaults of the AWS extension of Gardener in place
+type AWSInstanceMetadataOptions struct {
+	HTTPTokens              string
+	HTTPPutResponseHopLimit int
+}
+
+// Validate checks if the options are accepted by the AWS extension of Gardener
+func (o AWSInstanceMetadataOptions) Validate() error {
+	if o.HTTPTokens != "" && !IsSupportedAWSHTTPTokens(o.HTTPTokens) {
+		return fmt.Errorf("HTTP tokens %q are not supported, supported values: %s, %s", o.HTTPTokens, AWSHTTPTokensRequired, AWSHTTPTokensOptional)
+	}
+	if o.HTTPPutResponseHopLimit != 0 && !IsSupportedAWSHTTPPutResponseHopLimit(o.HTTPPutResponseHopLimit) {
+		return fmt.Errorf("HTTP PUT response hop limit %d is out of range, supported values: %d-%d", o.HTTPPutResponseHopLimit, MinAWSHTTPPutResponseHopLimit, MaxAWSHTTPPutResponseHopLimit)
+	}
+
+	return nil
+}
+
+func IsSupportedAWSHTTPTokens(httpTokens string) bool {
+	return httpTokens == AWSHTTPTokensRequired || httpTokens == AWSHTTPTokensOptional
+}
+
+func IsSupportedAWSHTTPPutResponseHopLimit(hopLimit int) bool {
+	return hopLimit >= MinAWSHTTPPutResponseHopLimit && hopLimit <= MaxAWSHTTPPutResponseHopLimit
+}
+
+func (c AzureGardenerConfig) CloudProfileName() string {
+	return "az"
+}
+
 func (c AzureGardenerConfig) EditShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
-	return updateShootConfig(gardenerConfig, shoot, c.input.Zones)
+	if appErr := updateShootConfig(gardenerConfig, shoot, c.input.Zones); appErr != nil {
+		return appErr
+	}
+
+	return c.updateNatGateway(shoot)
+}
+
+// updateNatGateway modifies only the NAT gateway settings, other fields of the infrastructure config are preserved
+func (c AzureGardenerConfig) updateNatGateway(shoot *gardener_types.Shoot) apperrors.AppError {
+	if c.input.EnableNatGateway == nil {
+		return nil
+	}
+
+	if shoot.Spec.Provider.InfrastructureConfig == nil {
+		return apperrors.Internal("no infrastructure config assigned to Gardener shoot '%s'", shoot.Name)
+	}
+
+	infrastructureConfig := map[string]interface{}{}
+	if err := json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infrastructureConfig); err != nil {
+		return apperrors.Internal("error decoding infrastructure config: %s", err.Error())
+	}
+
+	networks, _ := infrastructureConfig["networks"].(map[string]interface{})
+	if networks == nil {
+		networks = map[string]interface{}{}
+		infrastructureConfig["networks"] = networks
+	}
+	natGateway, _ := networks["natGateway"].(map[string]interface{})
+	if natGateway == nil {
+		natGateway = map[string]interface{}{}
+		networks["natGateway"] = natGateway
+	}
+
+	natGateway["enabled"] = *c.input.EnableNatGateway
+	if c.input.IdleConnectionTimeoutMinutes != nil {
+		natGateway["idleConnectionTimeoutMinutes"] = *c.input.IdleConnectionTimeoutMinutes
+	} else {
+		delete(natGateway, "idleConnectionTimeoutMinutes")
+	}
+
+	jsonData, err := json.Marshal(infrastructureConfig)
+	if err != nil {
+		return apperrors.Internal("error encoding infrastructure config: %s", err.Error())
+	}
+	shoot.Spec.Provider.InfrastructureConfig = &apimachineryRuntime.RawExtension{Raw: jsonData}
+
+	return nil
 }
 
 func (c AzureGardenerConfig) ExtendShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
-	shoot.Spec.CloudProfileName = "az"
+	shoot.Spec.CloudProfileName = c.CloudProfileName()
 
 	workers := []gardener_types.Worker{getWorkerConfig(gardenerConfig, c.input.Zones)}
 
@@ -301,21 +596,82 @@ func NewAWSGardenerConfig(input *gqlschema.AWSProviderConfigInput) (*AWSGardener
 
 func (c AWSGardenerConfig) AsProviderSpecificConfig() gqlschema.ProviderSpecificConfig {
 	return gqlschema.AWSProviderConfig{
-		Zone:         &c.input.Zone,
-		VpcCidr:      &c.input.VpcCidr,
-		PublicCidr:   &c.input.PublicCidr,
-		InternalCidr: &c.input.InternalCidr,
+		Zone:                    &c.input.Zone,
+		VpcCidr:                 &c.input.VpcCidr,
+		PublicCidr:              &c.input.PublicCidr,
+		InternalCidr:            &c.input.InternalCidr,
+		HTTPTokens:              c.input.HTTPTokens,
+		HTTPPutResponseHopLimit: c.input.HTTPPutResponseHopLimit,
 	}
 }
 
+func (c AWSGardenerConfig) CloudProfileName() string {
+	return "aws"
+}
+
 func (c AWSGardenerConfig) EditShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
-	return updateShootConfig(gardenerConfig, shoot, []string{c.input.Zone})
+	if appErr := updateShootConfig(gardenerConfig, shoot, []string{c.input.Zone}); appErr != nil {
+		return appErr
+	}
+
+	return c.updateInstanceMetadataOptions(shoot)
+}
+
+// updateInstanceMetadataOptions modifies only the instance metadata options of all worker pools, other fields of the worker config are preserved
+func (c AWSGardenerConfig) updateInstanceMetadataOptions(shoot *gardener_types.Shoot) apperrors.AppError {
+	instanceMetadataOptions := NewAWSInstanceMetadataOptions(c.input)
+	if instanceMetadataOptions == nil {
+		return nil
+	}
+
+	for i := range shoot.Spec.Provider.Workers {
+		worker := &shoot.Spec.Provider.Workers[i]
+
+		workerConfig := map[string]interface{}{"apiVersion": awsAPIVersion, "kind": workerConfigKind}
+		if worker.ProviderConfig != nil {
+			if err := json.Unmarshal(worker.ProviderConfig.Raw, &workerConfig); err != nil {
+				return apperrors.Internal("error decoding worker config: %s", err.Error())
+			}
+		}
+		workerConfig["instanceMetadataOptions"] = instanceMetadataOptions
+
+		jsonData, err := json.Marshal(workerConfig)
+		if err != nil {
+			return apperrors.Internal("error encoding worker config: %s", err.Error())
+		}
+		worker.ProviderConfig = &apimachineryRuntime.RawExtension{Raw: jsonData}
+	}
+
+	return nil
+}
+
+// InstanceMetadataOptionsChanged checks if the upgrade changes the instance metadata options which rolls the worker nodes
+func InstanceMetadataOptionsChanged(current, upgraded GardenerProviderConfig) bool {
+	currentConfig, ok := current.(*AWSGardenerConfig)
+	if !ok {
+		return false
+	}
+	upgradedConfig, ok := upgraded.(*AWSGardenerConfig)
+	if !ok {
+		return false
+	}
+
+	return util.UnwrapStr(currentConfig.input.HTTPTokens) != util.UnwrapStr(upgradedConfig.input.HTTPTokens) ||
+		util.UnwrapIntOrDefault(currentConfig.input.HTTPPutResponseHopLimit, 0) != util.UnwrapIntOrDefault(upgradedConfig.input.HTTPPutResponseHopLimit, 0)
 }
 
 func (c AWSGardenerConfig) ExtendShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
-	shoot.Spec.CloudProfileName = "aws"
+	shoot.Spec.CloudProfileName = c.CloudProfileName()
 
-	workers := []gardener_types.Worker{getWorkerConfig(gardenerConfig, []string{c.input.Zone})}
+	worker := getWorkerConfig(gardenerConfig, []string{c.input.Zone})
+	if workerConfig := NewAWSWorkerConfig(c.input); workerConfig != nil {
+		jsonWorkerData, err := json.Marshal(workerConfig)
+		if err != nil {
+			return apperrors.Internal("error encoding worker config: %s", err.Error())
+		}
+		worker.ProviderConfig = &apimachineryRuntime.RawExtension{Raw: jsonWorkerData}
+	}
+	workers := []gardener_types.Worker{worker}
 
 	awsInfra := NewAWSInfrastructure(gardenerConfig.WorkerCidr, c)
 	jsonData, err := json.Marshal(awsInfra)
@@ -365,12 +721,16 @@ func (c OpenStackGardenerConfig) AsProviderSpecificConfig() gqlschema.ProviderSp
 	}
 }
 
+func (c OpenStackGardenerConfig) CloudProfileName() string {
+	return c.input.CloudProfileName
+}
+
 func (c OpenStackGardenerConfig) EditShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
 	return updateShootConfig(gardenerConfig, shoot, c.input.Zones)
 }
 
 func (c OpenStackGardenerConfig) ExtendShootConfig(gardenerConfig GardenerConfig, shoot *gardener_types.Shoot) apperrors.AppError {
-	shoot.Spec.CloudProfileName = c.input.CloudProfileName
+	shoot.Spec.CloudProfileName = c.CloudProfileName()
 
 	workers := []gardener_types.Worker{getWorkerConfig(gardenerConfig, c.input.Zones)}
 
@@ -399,8 +759,8 @@ func (c OpenStackGardenerConfig) ExtendShootConfig(gardenerConfig GardenerConfig
 func getWorkerConfig(gardenerConfig GardenerConfig, zones []string) gardener_types.Worker {
 	worker := gardener_types.Worker{
 		Name:           "cpu-worker-0",
-		MaxSurge:       util.IntOrStringPtr(intstr.FromInt(gardenerConfig.MaxSurge)),
-		MaxUnavailable: util.IntOrStringPtr(intstr.FromInt(gardenerConfig.MaxUnavailable)),
+		MaxSurge:       gardenerConfig.MaxSurge,
+		MaxUnavailable: gardenerConfig.MaxUnavailable,
 		Machine:        getMachineConfig(gardenerConfig),
 		Maximum:        int32(gardenerConfig.AutoScalerMax),
 		Minimum:        int32(gardenerConfig.AutoScalerMin),
@@ -419,6 +779,11 @@ func getWorkerConfig(gardenerConfig GardenerConfig, zones []string) gardener_typ
 
 func updateShootConfig(upgradeConfig GardenerConfig, shoot *gardener_types.Shoot, zones []string) apperrors.AppError {
 
+	applyShootAnnotations(shoot, upgradeConfig.ShootAnnotations)
+	applyCostAllocationLabels(shoot, upgradeConfig.CostAllocation)
+	applyClusterAutoscalerConfig(shoot, upgradeConfig.ClusterAutoscalerConfig)
+	applyKubeAPIServerConfig(shoot, upgradeConfig.KubeAPIServerConfig)
+
 	if upgradeConfig.KubernetesVersion != "" {
 		shoot.Spec.Kubernetes.Version = upgradeConfig.KubernetesVersion
 	}
@@ -428,6 +793,12 @@ func updateShootConfig(upgradeConfig GardenerConfig, shoot *gardener_types.Shoot
 		shoot.Spec.Purpose = &purpose
 	}
 
+	if shoot.Spec.Maintenance == nil {
+		shoot.Spec.Maintenance = &gardener_types.Maintenance{}
+	}
+	if shoot.Spec.Maintenance.AutoUpdate == nil {
+		shoot.Spec.Maintenance.AutoUpdate = &gardener_types.MaintenanceAutoUpdate{}
+	}
 	shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = upgradeConfig.EnableKubernetesVersionAutoUpdate
 	shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion = upgradeConfig.EnableMachineImageVersionAutoUpdate
 
@@ -435,17 +806,28 @@ func updateShootConfig(upgradeConfig GardenerConfig, shoot *gardener_types.Shoot
 		return apperrors.Internal("no worker groups assigned to Gardener shoot '%s'", shoot.Name)
 	}
 
-	if util.NotNilOrEmpty(upgradeConfig.DiskType) {
-		shoot.Spec.Provider.Workers[0].Volume.Type = upgradeConfig.DiskType
+	if shoot.Spec.Provider.Workers[0].Volume == nil && util.NotNilOrEmpty(upgradeConfig.DiskType) && upgradeConfig.VolumeSizeGB != nil {
+		shoot.Spec.Provider.Workers[0].Volume = &gardener_types.Volume{}
 	}
 
-	if upgradeConfig.VolumeSizeGB != nil {
-		shoot.Spec.Provider.Workers[0].Volume.VolumeSize = fmt.Sprintf("%dGi", *upgradeConfig.VolumeSizeGB)
+	if shoot.Spec.Provider.Workers[0].Volume != nil {
+		if util.NotNilOrEmpty(upgradeConfig.DiskType) {
+			shoot.Spec.Provider.Workers[0].Volume.Type = upgradeConfig.DiskType
+		}
+
+		if upgradeConfig.VolumeSizeGB != nil {
+			shoot.Spec.Provider.Workers[0].Volume.VolumeSize = fmt.Sprintf("%dGi", *upgradeConfig.VolumeSizeGB)
+		}
 	}
 
-	// We support only single working group during provisioning
-	shoot.Spec.Provider.Workers[0].MaxSurge = util.IntOrStringPtr(intstr.FromInt(upgradeConfig.MaxSurge))
-	shoot.Spec.Provider.Workers[0].MaxUnavailable = util.IntOrStringPtr(intstr.FromInt(upgradeConfig.MaxUnavailable))
+	// The main worker pool is always the first one, the system worker pool is updated separately
+	// Gardener defaults are kept if the rollout settings were never provided
+	if upgradeConfig.MaxSurge != nil {
+		shoot.Spec.Provider.Workers[0].MaxSurge = upgradeConfig.MaxSurge
+	}
+	if upgradeConfig.MaxUnavailable != nil {
+		shoot.Spec.Provider.Workers[0].MaxUnavailable = upgradeConfig.MaxUnavailable
+	}
 	shoot.Spec.Provider.Workers[0].Machine.Type = upgradeConfig.MachineType
 	shoot.Spec.Provider.Workers[0].Maximum = int32(upgradeConfig.AutoScalerMax)
 	shoot.Spec.Provider.Workers[0].Minimum = int32(upgradeConfig.AutoScalerMin)
@@ -456,6 +838,10 @@ func updateShootConfig(upgradeConfig GardenerConfig, shoot *gardener_types.Shoot
 	if util.NotNilOrEmpty(upgradeConfig.MachineImageVersion) {
 		shoot.Spec.Provider.Workers[0].Machine.Image.Version = upgradeConfig.MachineImageVersion
 	}
+	// Only the providers are updated as the domain cannot be changed after the Shoot is created
+	if upgradeConfig.DNSConfig != nil && shoot.Spec.DNS != nil {
+		shoot.Spec.DNS.Providers = gardenerDNSProviders(upgradeConfig.DNSConfig.Providers)
+	}
 	if upgradeConfig.OIDCConfig != nil {
 		if shoot.Spec.Kubernetes.KubeAPIServer == nil {
 			shoot.Spec.Kubernetes.KubeAPIServer = &gardener_types.KubeAPIServerConfig{}
@@ -469,6 +855,7 @@ func updateShootConfig(upgradeConfig GardenerConfig, shoot *gardener_types.Shoot
 			UsernamePrefix: &upgradeConfig.OIDCConfig.UsernamePrefix,
 		}
 	}
+	applySystemWorkerPool(shoot, upgradeConfig.DedicatedSystemPool)
 	return nil
 }
 
@@ -340,6 +340,18 @@ func TestGardenerConfig_ToShootTemplate(t *testing.T) {
 		})
 	}
 
+	t.Run("should convert to Shoot template with Cilium networking", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpGardenerProvider)
+		gardenerConfig.NetworkingType = CiliumNetworkingType
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", oidcConfig())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "cilium", template.Spec.Networking.Type)
+	})
 }
 
 func TestEditShootConfig(t *testing.T) {
@@ -404,6 +416,16 @@ func TestEditShootConfig(t *testing.T) {
 			initialShoot:  initialShoot.DeepCopy(),
 			expectedShoot: expectedShoot.DeepCopy(),
 		},
+		{description: "should set auto update when shoot has no maintenance configured",
+			provider:      "gcp",
+			upgradeConfig: fixGardenerConfig("gcp", gcpProviderConfig),
+			initialShoot: func(s *gardener_types.Shoot) *gardener_types.Shoot {
+				shoot := s.DeepCopy()
+				shoot.Spec.Maintenance = nil
+				return shoot
+			}(initialShoot),
+			expectedShoot: expectedShoot.DeepCopy(),
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
@@ -441,6 +463,534 @@ func TestEditShootConfig(t *testing.T) {
 	}
 }
 
+func TestGardenerConfig_RolloutSettings(t *testing.T) {
+	gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+
+	t.Run("should not set rollout settings on Shoot template when none are provided", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.MaxSurge = nil
+		gardenerConfig.MaxUnavailable = nil
+
+		// when
+		shoot, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", oidcConfig())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Provider.Workers[0].MaxSurge)
+		assert.Nil(t, shoot.Spec.Provider.Workers[0].MaxUnavailable)
+	})
+
+	t.Run("should set percentage rollout settings on Shoot template", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.MaxSurge = util.IntOrStringPtr(intstr.FromString("25%"))
+		gardenerConfig.MaxUnavailable = util.IntOrStringPtr(intstr.FromString("0%"))
+
+		// when
+		shoot, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", oidcConfig())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, util.IntOrStringPtr(intstr.FromString("25%")), shoot.Spec.Provider.Workers[0].MaxSurge)
+		assert.Equal(t, util.IntOrStringPtr(intstr.FromString("0%")), shoot.Spec.Provider.Workers[0].MaxUnavailable)
+	})
+
+	t.Run("should keep worker rollout settings when none are provided in the upgrade", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.MaxSurge = nil
+		gardenerConfig.MaxUnavailable = nil
+
+		shoot := testkit.NewTestShoot("shoot").
+			WithWorkers(testkit.NewTestWorker("peon").WithMaxSurge(2).WithMaxUnavailable(1).ToWorker()).
+			ToShoot()
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, util.IntOrStringPtr(intstr.FromInt(2)), shoot.Spec.Provider.Workers[0].MaxSurge)
+		assert.Equal(t, util.IntOrStringPtr(intstr.FromInt(1)), shoot.Spec.Provider.Workers[0].MaxUnavailable)
+	})
+}
+
+func TestGardenerConfig_DNSConfig(t *testing.T) {
+	gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+
+	dnsConfig := &DNSConfig{
+		Domain: "runtime.customer.example.com",
+		Providers: []*DNSProvider{
+			{Type: "aws-route53", SecretName: "route53-credentials", Domains: []string{"customer.example.com"}},
+			{Type: "azure-dns", SecretName: "azure-dns-credentials"},
+		},
+	}
+
+	t.Run("should render custom domain with the first provider as primary", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.DNSConfig = dnsConfig
+
+		// when
+		shoot, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", oidcConfig())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &gardener_types.DNS{
+			Domain: util.StringPtr("runtime.customer.example.com"),
+			Providers: []gardener_types.DNSProvider{
+				{
+					Type:       util.StringPtr("aws-route53"),
+					SecretName: util.StringPtr("route53-credentials"),
+					Primary:    util.BoolPtr(true),
+					Domains:    &gardener_types.DNSIncludeExclude{Include: []string{"customer.example.com"}},
+				},
+				{
+					Type:       util.StringPtr("azure-dns"),
+					SecretName: util.StringPtr("azure-dns-credentials"),
+					Primary:    util.BoolPtr(false),
+				},
+			},
+		}, shoot.Spec.DNS)
+	})
+
+	t.Run("should not render DNS when custom domain is not configured", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+
+		// when
+		shoot, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", oidcConfig())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.DNS)
+	})
+
+	t.Run("should replace DNS providers keeping the domain", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.DNSConfig = &DNSConfig{
+			Domain:    "runtime.customer.example.com",
+			Providers: []*DNSProvider{{Type: "aws-route53", SecretName: "rotated-route53-credentials"}},
+		}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Spec.DNS = gardenerDNSConfig(dnsConfig)
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "runtime.customer.example.com", *shoot.Spec.DNS.Domain)
+		require.Len(t, shoot.Spec.DNS.Providers, 1)
+		assert.Equal(t, "rotated-route53-credentials", *shoot.Spec.DNS.Providers[0].SecretName)
+	})
+}
+
+func TestAzureGardenerConfig_NatGateway(t *testing.T) {
+	natGatewayInput := func(zones []string) *gqlschema.AzureProviderConfigInput {
+		input := fixAzureGardenerInput(zones)
+		input.EnableNatGateway = util.BoolPtr(true)
+		input.IdleConnectionTimeoutMinutes = util.IntPtr(30)
+		return input
+	}
+
+	t.Run("should render NAT gateway in infrastructure config", func(t *testing.T) {
+		// given
+		azureProviderConfig, err := NewAzureGardenerConfig(natGatewayInput([]string{"1"}))
+		require.NoError(t, err)
+
+		shoot := &gardener_types.Shoot{}
+
+		// when
+		err = azureProviderConfig.ExtendShootConfig(fixGardenerConfig("az", azureProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"kind":"InfrastructureConfig","apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","networks":{"vnet":{"cidr":"10.10.11.11/255"},"workers":"10.10.10.10/255","natGateway":{"enabled":true,"idleConnectionTimeoutMinutes":30}},"zoned":true}`,
+			string(shoot.Spec.Provider.InfrastructureConfig.Raw))
+	})
+
+	t.Run("should update NAT gateway preserving other infrastructure config fields", func(t *testing.T) {
+		// given
+		azureProviderConfig, err := NewAzureGardenerConfig(natGatewayInput(nil))
+		require.NoError(t, err)
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Spec.Provider.InfrastructureConfig = &apimachineryRuntime.RawExtension{
+			Raw: []byte(`{"networks":{"workers":"10.10.10.10/255","serviceEndpoints":["Microsoft.Storage"],"natGateway":{"enabled":false}},"zoned":false}`),
+		}
+
+		// when
+		err = azureProviderConfig.EditShootConfig(fixGardenerConfig("az", azureProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"networks":{"workers":"10.10.10.10/255","serviceEndpoints":["Microsoft.Storage"],"natGateway":{"enabled":true,"idleConnectionTimeoutMinutes":30}},"zoned":false}`,
+			string(shoot.Spec.Provider.InfrastructureConfig.Raw))
+	})
+
+	t.Run("should not modify infrastructure config when NAT gateway is not configured", func(t *testing.T) {
+		// given
+		azureProviderConfig, err := NewAzureGardenerConfig(fixAzureGardenerInput(nil))
+		require.NoError(t, err)
+
+		infrastructureConfig := []byte(`{"networks":{"workers":"10.10.10.10/255"},"zoned":false}`)
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Spec.Provider.InfrastructureConfig = &apimachineryRuntime.RawExtension{Raw: infrastructureConfig}
+
+		// when
+		err = azureProviderConfig.EditShootConfig(fixGardenerConfig("az", azureProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, infrastructureConfig, shoot.Spec.Provider.InfrastructureConfig.Raw)
+	})
+}
+
+func TestGCPGardenerConfig_ShieldedInstanceConfig(t *testing.T) {
+	shieldedInput := func() *gqlschema.GCPProviderConfigInput {
+		input := fixGCPGardenerInput([]string{"fix-zone-1"})
+		input.EnableSecureBoot = util.BoolPtr(true)
+		input.EnableIntegrityMonitoring = util.BoolPtr(true)
+		input.EnableVtpm = util.BoolPtr(false)
+		return input
+	}
+
+	t.Run("should render Shielded VM options in worker config", func(t *testing.T) {
+		// given
+		gcpProviderConfig, err := NewGCPGardenerConfig(shieldedInput())
+		require.NoError(t, err)
+
+		shoot := &gardener_types.Shoot{}
+
+		// when
+		err = gcpProviderConfig.ExtendShootConfig(fixGardenerConfig("gcp", gcpProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, shoot.Spec.Provider.Workers, 1)
+		assert.JSONEq(t,
+			`{"kind":"WorkerConfig","apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","shieldedInstanceConfig":{"enableSecureBoot":true,"enableIntegrityMonitoring":true,"enableVtpm":false}}`,
+			string(shoot.Spec.Provider.Workers[0].ProviderConfig.Raw))
+	})
+
+	t.Run("should not render worker config when Shielded VM options are disabled", func(t *testing.T) {
+		// given
+		input := fixGCPGardenerInput([]string{"fix-zone-1"})
+		input.EnableSecureBoot = util.BoolPtr(false)
+		gcpProviderConfig, err := NewGCPGardenerConfig(input)
+		require.NoError(t, err)
+
+		shoot := &gardener_types.Shoot{}
+
+		// when
+		err = gcpProviderConfig.ExtendShootConfig(fixGardenerConfig("gcp", gcpProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Provider.Workers[0].ProviderConfig)
+	})
+
+	t.Run("should update Shielded VM options preserving other worker config fields", func(t *testing.T) {
+		// given
+		gcpProviderConfig, err := NewGCPGardenerConfig(shieldedInput())
+		require.NoError(t, err)
+
+		worker := testkit.NewTestWorker("peon").ToWorker()
+		worker.ProviderConfig = &apimachineryRuntime.RawExtension{
+			Raw: []byte(`{"kind":"WorkerConfig","apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","minCpuPlatform":"Intel Skylake"}`),
+		}
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(worker).ToShoot()
+
+		// when
+		err = gcpProviderConfig.EditShootConfig(fixGardenerConfig("gcp", gcpProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"kind":"WorkerConfig","apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","minCpuPlatform":"Intel Skylake","shieldedInstanceConfig":{"enableSecureBoot":true,"enableIntegrityMonitoring":true,"enableVtpm":false}}`,
+			string(shoot.Spec.Provider.Workers[0].ProviderConfig.Raw))
+	})
+
+	t.Run("should not modify worker config when Shielded VM options are not configured", func(t *testing.T) {
+		// given
+		gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+		require.NoError(t, err)
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+
+		// when
+		err = gcpProviderConfig.EditShootConfig(fixGardenerConfig("gcp", gcpProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Provider.Workers[0].ProviderConfig)
+	})
+}
+
+func TestMachineImageVersionPinOverridden(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		config      GardenerConfig
+		expected    bool
+	}{
+		{description: "pinned version with auto update", config: GardenerConfig{MachineImageVersion: util.StringPtr("318.9.0"), EnableMachineImageVersionAutoUpdate: true}, expected: true},
+		{description: "pinned version without auto update", config: GardenerConfig{MachineImageVersion: util.StringPtr("318.9.0")}, expected: false},
+		{description: "auto update without pinned version", config: GardenerConfig{EnableMachineImageVersionAutoUpdate: true}, expected: false},
+		{description: "empty version with auto update", config: GardenerConfig{MachineImageVersion: util.StringPtr(""), EnableMachineImageVersionAutoUpdate: true}, expected: false},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, MachineImageVersionPinOverridden(testCase.config))
+		})
+	}
+}
+
+func TestShieldedInstanceConfigChanged(t *testing.T) {
+	gcpConfig := func(enableSecureBoot *bool) GardenerProviderConfig {
+		input := fixGCPGardenerInput([]string{"fix-zone-1"})
+		input.EnableSecureBoot = enableSecureBoot
+		config, err := NewGCPGardenerConfig(input)
+		require.NoError(t, err)
+		return config
+	}
+	azureConfig, err := NewAzureGardenerConfig(fixAzureGardenerInput(nil))
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		description string
+		current     GardenerProviderConfig
+		upgraded    GardenerProviderConfig
+		expected    bool
+	}{
+		{description: "Secure Boot enabled", current: gcpConfig(nil), upgraded: gcpConfig(util.BoolPtr(true)), expected: true},
+		{description: "Secure Boot disabled", current: gcpConfig(util.BoolPtr(true)), upgraded: gcpConfig(util.BoolPtr(false)), expected: true},
+		{description: "options not changed", current: gcpConfig(util.BoolPtr(true)), upgraded: gcpConfig(util.BoolPtr(true)), expected: false},
+		{description: "options disabled explicitly", current: gcpConfig(nil), upgraded: gcpConfig(util.BoolPtr(false)), expected: false},
+		{description: "non-GCP config", current: azureConfig, upgraded: azureConfig, expected: false},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, ShieldedInstanceConfigChanged(testCase.current, testCase.upgraded))
+		})
+	}
+}
+
+func TestAWSGardenerConfig_InstanceMetadataOptions(t *testing.T) {
+	metadataOptionsInput := func() *gqlschema.AWSProviderConfigInput {
+		input := fixAWSGardenerInput()
+		input.HTTPTokens = util.StringPtr(AWSHTTPTokensRequired)
+		input.HTTPPutResponseHopLimit = util.IntPtr(2)
+		return input
+	}
+
+	t.Run("should render instance metadata options in worker config", func(t *testing.T) {
+		// given
+		awsProviderConfig, err := NewAWSGardenerConfig(metadataOptionsInput())
+		require.NoError(t, err)
+
+		shoot := &gardener_types.Shoot{}
+
+		// when
+		err = awsProviderConfig.ExtendShootConfig(fixGardenerConfig("aws", awsProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, shoot.Spec.Provider.Workers, 1)
+		assert.JSONEq(t,
+			`{"kind":"WorkerConfig","apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","instanceMetadataOptions":{"httpTokens":"required","httpPutResponseHopLimit":2}}`,
+			string(shoot.Spec.Provider.Workers[0].ProviderConfig.Raw))
+	})
+
+	t.Run("should not render worker config when instance metadata options are not configured", func(t *testing.T) {
+		// given
+		awsProviderConfig, err := NewAWSGardenerConfig(fixAWSGardenerInput())
+		require.NoError(t, err)
+
+		shoot := &gardener_types.Shoot{}
+
+		// when
+		err = awsProviderConfig.ExtendShootConfig(fixGardenerConfig("aws", awsProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Provider.Workers[0].ProviderConfig)
+	})
+
+	t.Run("should update instance metadata options preserving other worker config fields", func(t *testing.T) {
+		// given
+		awsProviderConfig, err := NewAWSGardenerConfig(metadataOptionsInput())
+		require.NoError(t, err)
+
+		worker := testkit.NewTestWorker("peon").ToWorker()
+		worker.ProviderConfig = &apimachineryRuntime.RawExtension{
+			Raw: []byte(`{"kind":"WorkerConfig","apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","iamInstanceProfile":{"name":"nodes"},"instanceMetadataOptions":{"httpTokens":"optional"}}`),
+		}
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(worker).ToShoot()
+
+		// when
+		err = awsProviderConfig.EditShootConfig(fixGardenerConfig("aws", awsProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"kind":"WorkerConfig","apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","iamInstanceProfile":{"name":"nodes"},"instanceMetadataOptions":{"httpTokens":"required","httpPutResponseHopLimit":2}}`,
+			string(shoot.Spec.Provider.Workers[0].ProviderConfig.Raw))
+	})
+
+	t.Run("should not modify worker config when instance metadata options are not configured", func(t *testing.T) {
+		// given
+		awsProviderConfig, err := NewAWSGardenerConfig(fixAWSGardenerInput())
+		require.NoError(t, err)
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+
+		// when
+		err = awsProviderConfig.EditShootConfig(fixGardenerConfig("aws", awsProviderConfig), shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Provider.Workers[0].ProviderConfig)
+	})
+}
+
+func TestInstanceMetadataOptionsChanged(t *testing.T) {
+	awsConfig := func(httpTokens *string, hopLimit *int) GardenerProviderConfig {
+		input := fixAWSGardenerInput()
+		input.HTTPTokens = httpTokens
+		input.HTTPPutResponseHopLimit = hopLimit
+		config, err := NewAWSGardenerConfig(input)
+		require.NoError(t, err)
+		return config
+	}
+	gcpConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		description string
+		current     GardenerProviderConfig
+		upgraded    GardenerProviderConfig
+		expected    bool
+	}{
+		{description: "IMDSv2 enforced", current: awsConfig(nil, nil), upgraded: awsConfig(util.StringPtr(AWSHTTPTokensRequired), nil), expected: true},
+		{description: "hop limit changed", current: awsConfig(nil, util.IntPtr(1)), upgraded: awsConfig(nil, util.IntPtr(2)), expected: true},
+		{description: "options not changed", current: awsConfig(util.StringPtr(AWSHTTPTokensRequired), util.IntPtr(2)), upgraded: awsConfig(util.StringPtr(AWSHTTPTokensRequired), util.IntPtr(2)), expected: false},
+		{description: "non-AWS config", current: gcpConfig, upgraded: gcpConfig, expected: false},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, InstanceMetadataOptionsChanged(testCase.current, testCase.upgraded))
+		})
+	}
+}
+
+func TestAWSInstanceMetadataOptions_Validate(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		options     AWSInstanceMetadataOptions
+		expectError bool
+	}{
+		{description: "IMDSv2", options: AWSInstanceMetadataOptions{HTTPTokens: AWSHTTPTokensRequired, HTTPPutResponseHopLimit: 2}},
+		{description: "defaults of the AWS extension", options: AWSInstanceMetadataOptions{}},
+		{description: "unsupported HTTP tokens", options: AWSInstanceMetadataOptions{HTTPTokens: "disabled"}, expectError: true},
+		{description: "hop limit out of range", options: AWSInstanceMetadataOptions{HTTPPutResponseHopLimit: 65}, expectError: true},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			err := testCase.options.Validate()
+			if testCase.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGardenerConfig_ShootAnnotations(t *testing.T) {
+	gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+
+	t.Run("should set annotations on Shoot template and track them", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.ShootAnnotations = map[string]string{"dns.gardener.cloud/zone": "internal", "alpha.control-plane.shoot.gardener.cloud/feature": "true"}
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "internal", template.Annotations["dns.gardener.cloud/zone"])
+		assert.Equal(t, "true", template.Annotations["alpha.control-plane.shoot.gardener.cloud/feature"])
+		assert.Equal(t, "alpha.control-plane.shoot.gardener.cloud/feature,dns.gardener.cloud/zone", template.Annotations[ManagedAnnotationsAnnotation])
+	})
+
+	t.Run("should not track annotations on Shoot template when none are provided", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, template.Annotations, ManagedAnnotationsAnnotation)
+	})
+
+	t.Run("should remove only annotations previously set from the config", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.ShootAnnotations = map[string]string{"dns.gardener.cloud/zone": "external"}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Annotations = map[string]string{
+			"dns.gardener.cloud/zone":            "internal",
+			"dns.gardener.cloud/class":           "garden",
+			"shoot.gardener.cloud/feature":       "true",
+			ManagedAnnotationsAnnotation:         "dns.gardener.cloud/class,dns.gardener.cloud/zone",
+			"gardener.cloud/created-by":          "someone",
+			"dns.gardener.cloud/not-provisioner": "value",
+		}
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"dns.gardener.cloud/zone":            "external",
+			"shoot.gardener.cloud/feature":       "true",
+			ManagedAnnotationsAnnotation:         "dns.gardener.cloud/zone",
+			"gardener.cloud/created-by":          "someone",
+			"dns.gardener.cloud/not-provisioner": "value",
+		}, shoot.Annotations)
+	})
+
+	t.Run("should remove all annotations set from the config and the bookkeeping annotation", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.ShootAnnotations = map[string]string{}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Annotations = map[string]string{
+			"dns.gardener.cloud/zone":    "internal",
+			ManagedAnnotationsAnnotation: "dns.gardener.cloud/zone",
+			"gardener.cloud/created-by":  "someone",
+		}
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"gardener.cloud/created-by": "someone"}, shoot.Annotations)
+	})
+}
+
 func fixGardenerConfig(provider string, providerCfg GardenerProviderConfig) GardenerConfig {
 	return GardenerConfig{
 		ID:                                  "",
@@ -462,11 +1012,12 @@ func fixGardenerConfig(provider string, providerCfg GardenerProviderConfig) Gard
 		WorkerCidr:                          "10.10.10.10/255",
 		AutoScalerMin:                       1,
 		AutoScalerMax:                       3,
-		MaxSurge:                            30,
-		MaxUnavailable:                      1,
+		MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(30)),
+		MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(1)),
 		EnableKubernetesVersionAutoUpdate:   true,
 		EnableMachineImageVersionAutoUpdate: false,
 		AllowPrivilegedContainers:           false,
+		NetworkingType:                      CalicoNetworkingType,
 		GardenerProviderConfig:              providerCfg,
 		OIDCConfig:                          oidcConfig(),
 	}
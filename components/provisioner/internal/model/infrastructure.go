@@ -6,12 +6,14 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model/infrastructure/gcp"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model/infrastructure/openstack"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	infrastructureConfigKind = "InfrastructureConfig"
 	controlPlaneConfigKind   = "ControlPlaneConfig"
+	workerConfigKind         = "WorkerConfig"
 
 	gcpAPIVersion       = "gcp.provider.extensions.gardener.cloud/v1alpha1"
 	azureAPIVersion     = "azure.provider.extensions.gardener.cloud/v1alpha1"
@@ -42,6 +44,40 @@ func NewGCPControlPlane(zones []string) *gcp.ControlPlaneConfig {
 	}
 }
 
+// NewGCPWorkerConfig returns nil if none of the Shielded VM options is enabled, leaving the worker nodes unchanged
+func NewGCPWorkerConfig(input *gqlschema.GCPProviderConfigInput) *gcp.WorkerConfig {
+	shieldedInstanceConfig := NewGCPShieldedInstanceConfig(input)
+	if shieldedInstanceConfig == nil {
+		return nil
+	}
+
+	return &gcp.WorkerConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       workerConfigKind,
+			APIVersion: gcpAPIVersion,
+		},
+		ShieldedInstanceConfig: shieldedInstanceConfig,
+	}
+}
+
+// NewGCPShieldedInstanceConfig returns nil if none of the Shielded VM options is enabled
+func NewGCPShieldedInstanceConfig(input *gqlschema.GCPProviderConfigInput) *gcp.ShieldedInstanceConfig {
+	shieldedInstanceConfig := gcpShieldedInstanceOptions(input)
+	if shieldedInstanceConfig == (gcp.ShieldedInstanceConfig{}) {
+		return nil
+	}
+
+	return &shieldedInstanceConfig
+}
+
+func gcpShieldedInstanceOptions(input *gqlschema.GCPProviderConfigInput) gcp.ShieldedInstanceConfig {
+	return gcp.ShieldedInstanceConfig{
+		EnableSecureBoot:          util.UnwrapBoolOrDefault(input.EnableSecureBoot, false),
+		EnableIntegrityMonitoring: util.UnwrapBoolOrDefault(input.EnableIntegrityMonitoring, false),
+		EnableVtpm:                util.UnwrapBoolOrDefault(input.EnableVtpm, false),
+	}
+}
+
 func NewAzureInfrastructure(workerCIDR string, azConfig AzureGardenerConfig) *azure.InfrastructureConfig {
 	isZoned := len(azConfig.input.Zones) > 0
 	return &azure.InfrastructureConfig{
@@ -54,11 +90,29 @@ func NewAzureInfrastructure(workerCIDR string, azConfig AzureGardenerConfig) *az
 			VNet: azure.VNet{
 				CIDR: &azConfig.input.VnetCidr,
 			},
+			NatGateway: NewAzureNatGateway(azConfig.input),
 		},
 		Zoned: isZoned,
 	}
 }
 
+// NewAzureNatGateway returns nil if the NAT gateway is not configured, leaving the Gardener default in place
+func NewAzureNatGateway(input *gqlschema.AzureProviderConfigInput) *azure.NatGatewayConfig {
+	if input.EnableNatGateway == nil {
+		return nil
+	}
+
+	natGateway := &azure.NatGatewayConfig{
+		Enabled: *input.EnableNatGateway,
+	}
+	if input.IdleConnectionTimeoutMinutes != nil {
+		idleConnectionTimeout := int32(*input.IdleConnectionTimeoutMinutes)
+		natGateway.IdleConnectionTimeoutMinutes = &idleConnectionTimeout
+	}
+
+	return natGateway
+}
+
 func NewAzureControlPlane(zones []string) *azure.ControlPlaneConfig {
 	return &azure.ControlPlaneConfig{
 		TypeMeta: v1.TypeMeta{
@@ -99,6 +153,39 @@ func NewAWSControlPlane() *aws.ControlPlaneConfig {
 	}
 }
 
+// NewAWSWorkerConfig returns nil if the instance metadata options are not configured, leaving the worker nodes unchanged
+func NewAWSWorkerConfig(input *gqlschema.AWSProviderConfigInput) *aws.WorkerConfig {
+	instanceMetadataOptions := NewAWSInstanceMetadataOptions(input)
+	if instanceMetadataOptions == nil {
+		return nil
+	}
+
+	return &aws.WorkerConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       workerConfigKind,
+			APIVersion: awsAPIVersion,
+		},
+		InstanceMetadataOptions: instanceMetadataOptions,
+	}
+}
+
+// NewAWSInstanceMetadataOptions returns nil if none of the instance metadata options is configured
+func NewAWSInstanceMetadataOptions(input *gqlschema.AWSProviderConfigInput) *aws.InstanceMetadataOptions {
+	if input.HTTPTokens == nil && input.HTTPPutResponseHopLimit == nil {
+		return nil
+	}
+
+	instanceMetadataOptions := &aws.InstanceMetadataOptions{
+		HTTPTokens: input.HTTPTokens,
+	}
+	if input.HTTPPutResponseHopLimit != nil {
+		hopLimit := int64(*input.HTTPPutResponseHopLimit)
+		instanceMetadataOptions.HTTPPutResponseHopLimit = &hopLimit
+	}
+
+	return instanceMetadataOptions
+}
+
 func NewOpenStackInfrastructure(floatingPoolName, workerCIDR string) *openstack.InfrastructureConfig {
 	return &openstack.InfrastructureConfig{
 		TypeMeta: v1.TypeMeta{
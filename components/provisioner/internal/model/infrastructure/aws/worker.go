@@ -0,0 +1,21 @@
+package aws
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// This types are copied from https://github.com/gardener/gardener-extension-provider-aws/blob/master/pkg/apis/aws/types_worker.go
+
+// WorkerConfig contains configuration settings for the worker nodes.
+type WorkerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// InstanceMetadataOptions contains the configuration of the instance metadata service of the worker nodes.
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+}
+
+// InstanceMetadataOptions contains the configuration of the instance metadata service, changing it rolls the worker nodes.
+type InstanceMetadataOptions struct {
+	// HTTPTokens enforces the use of the session tokens (IMDSv2) if set to required.
+	HTTPTokens *string `json:"httpTokens,omitempty"`
+	// HTTPPutResponseHopLimit is the number of network hops the metadata PUT response can travel.
+	HTTPPutResponseHopLimit *int64 `json:"httpPutResponseHopLimit,omitempty"`
+}
@@ -0,0 +1,21 @@
+package gcp
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// WorkerConfig contains configuration settings for the worker nodes.
+type WorkerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ShieldedInstanceConfig contains the Shielded VM options of the worker nodes.
+	ShieldedInstanceConfig *ShieldedInstanceConfig `json:"shieldedInstanceConfig,omitempty"`
+}
+
+// ShieldedInstanceConfig contains the Shielded VM options, changing them recreates the worker nodes.
+type ShieldedInstanceConfig struct {
+	// EnableSecureBoot verifies the digital signature of all boot components.
+	EnableSecureBoot bool `json:"enableSecureBoot"`
+	// EnableIntegrityMonitoring monitors the boot integrity of the nodes.
+	EnableIntegrityMonitoring bool `json:"enableIntegrityMonitoring"`
+	// EnableVtpm enables the virtual Trusted Platform Module.
+	EnableVtpm bool `json:"enableVtpm"`
+}
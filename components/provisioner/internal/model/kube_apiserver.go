@@ -0,0 +1,139 @@
+package model
+
+import (
+	"sort"
+	"strings"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// KubeAPIServerConfig holds the kube-apiserver settings managed by the Provisioner, the settings which are not set keep the Gardener defaults
+type KubeAPIServerConfig struct {
+	FeatureGates           map[string]bool `json:"featureGates,omitempty"`
+	RuntimeConfig          map[string]bool `json:"runtimeConfig,omitempty"`
+	MaxNonMutatingInflight *int            `json:"maxNonMutatingInflight,omitempty"`
+	MaxMutatingInflight    *int            `json:"maxMutatingInflight,omitempty"`
+}
+
+// KubeAPIServerConfigFromInput replaces the current settings with the ones provided in the input and checks if they are well-formed.
+// The provided feature gates and runtime config replace the previous ones as a whole, so that the entries can be removed.
+// The feature gates are checked against the allow-list by the validator.
+func KubeAPIServerConfigFromInput(input *gqlschema.KubeAPIServerConfigInput, current *KubeAPIServerConfig) (*KubeAPIServerConfig, apperrors.AppError) {
+	if input == nil {
+		return current, nil
+	}
+
+	config := KubeAPIServerConfig{}
+	if current != nil {
+		config = *current
+	}
+
+	if input.FeatureGates != nil {
+		config.FeatureGates = switchesFromInput(*input.FeatureGates)
+	}
+
+	if input.RuntimeConfig != nil {
+		for _, key := range sortedKeys(*input.RuntimeConfig) {
+			if err := validateRuntimeConfigKey(key); err != nil {
+				return nil, err
+			}
+		}
+		config.RuntimeConfig = switchesFromInput(*input.RuntimeConfig)
+	}
+
+	for _, setting := range []struct {
+		name  string
+		input *int
+		value **int
+	}{
+		{name: "maxNonMutatingInflight", input: input.MaxNonMutatingInflight, value: &config.MaxNonMutatingInflight},
+		{name: "maxMutatingInflight", input: input.MaxMutatingInflight, value: &config.MaxMutatingInflight},
+	} {
+		if setting.input == nil {
+			continue
+		}
+		if *setting.input <= 0 {
+			return nil, apperrors.BadRequest("error: kube-apiserver %s %d has to be greater than 0", setting.name, *setting.input)
+		}
+		value := *setting.input
+		*setting.value = &value
+	}
+
+	return &config, nil
+}
+
+// validateRuntimeConfigKey checks if the key is a version, group version or group version resource, such as batch/v2alpha1 or api/all
+func validateRuntimeConfigKey(key string) apperrors.AppError {
+	parts := strings.Split(key, "/")
+	if len(parts) > 3 {
+		return apperrors.BadRequest("error: invalid kube-apiserver runtime config key %s: has to be a version, group version or group version resource", key)
+	}
+
+	for _, part := range parts {
+		if errs := validation.IsDNS1123Subdomain(part); len(errs) > 0 {
+			return apperrors.BadRequest("error: invalid kube-apiserver runtime config key %s: %s", key, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+func switchesFromInput(switches gqlschema.Switches) map[string]bool {
+	if len(switches) == 0 {
+		return nil
+	}
+
+	result := make(map[string]bool, len(switches))
+	for key, enabled := range switches {
+		result[key] = enabled
+	}
+
+	return result
+}
+
+func sortedKeys(switches map[string]bool) []string {
+	keys := make([]string, 0, len(switches))
+	for key := range switches {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// applyKubeAPIServerConfig reconciles the managed kube-apiserver settings of the Shoot with the stored ones,
+// the kube-apiserver of Shoots provisioned before the settings were introduced is not changed
+func applyKubeAPIServerConfig(shoot *gardener_types.Shoot, config *KubeAPIServerConfig) {
+	if config == nil {
+		return
+	}
+
+	if shoot.Spec.Kubernetes.KubeAPIServer == nil {
+		shoot.Spec.Kubernetes.KubeAPIServer = &gardener_types.KubeAPIServerConfig{}
+	}
+	kubeAPIServer := shoot.Spec.Kubernetes.KubeAPIServer
+
+	kubeAPIServer.FeatureGates = config.FeatureGates
+	kubeAPIServer.RuntimeConfig = config.RuntimeConfig
+
+	if config.MaxNonMutatingInflight == nil && config.MaxMutatingInflight == nil {
+		kubeAPIServer.Requests = nil
+		return
+	}
+	kubeAPIServer.Requests = &gardener_types.KubeAPIServerRequests{
+		MaxNonMutatingInflight: int32Ptr(config.MaxNonMutatingInflight),
+		MaxMutatingInflight:    int32Ptr(config.MaxMutatingInflight),
+	}
+}
+
+func int32Ptr(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+
+	result := int32(*value)
+	return &result
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"testing"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGardenerConfig_KubeAPIServerConfig(t *testing.T) {
+	gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+
+	t.Run("should keep Gardener defaults on Shoot template when settings are not provided", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, template.Spec.Kubernetes.KubeAPIServer.FeatureGates)
+		assert.Nil(t, template.Spec.Kubernetes.KubeAPIServer.RuntimeConfig)
+		assert.Nil(t, template.Spec.Kubernetes.KubeAPIServer.Requests)
+	})
+
+	t.Run("should set kube-apiserver settings on Shoot template", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.KubeAPIServerConfig = &KubeAPIServerConfig{
+			FeatureGates:        map[string]bool{"EphemeralContainers": true},
+			RuntimeConfig:       map[string]bool{"batch/v2alpha1": true},
+			MaxMutatingInflight: util.IntPtr(400),
+		}
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		kubeAPIServer := template.Spec.Kubernetes.KubeAPIServer
+		assert.Equal(t, map[string]bool{"EphemeralContainers": true}, kubeAPIServer.FeatureGates)
+		assert.Equal(t, map[string]bool{"batch/v2alpha1": true}, kubeAPIServer.RuntimeConfig)
+		require.NotNil(t, kubeAPIServer.Requests)
+		assert.Nil(t, kubeAPIServer.Requests.MaxNonMutatingInflight)
+		assert.Equal(t, int32(400), *kubeAPIServer.Requests.MaxMutatingInflight)
+		assert.NotNil(t, kubeAPIServer.EnableBasicAuthentication)
+	})
+
+	t.Run("should reconcile kube-apiserver settings on Shoot upgrade", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.KubeAPIServerConfig = &KubeAPIServerConfig{FeatureGates: map[string]bool{"EphemeralContainers": true}}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("peon").ToWorker()).ToShoot()
+		shoot.Spec.Kubernetes.KubeAPIServer = &gardener_types.KubeAPIServerConfig{
+			KubernetesConfig: gardener_types.KubernetesConfig{FeatureGates: map[string]bool{"EphemeralContainers": false, "TTLAfterFinished": true}},
+			RuntimeConfig:    map[string]bool{"batch/v2alpha1": true},
+			Requests:         &gardener_types.KubeAPIServerRequests{MaxNonMutatingInflight: int32Ptr(util.IntPtr(800))},
+		}
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		kubeAPIServer := shoot.Spec.Kubernetes.KubeAPIServer
+		assert.Equal(t, map[string]bool{"EphemeralContainers": true}, kubeAPIServer.FeatureGates)
+		assert.Nil(t, kubeAPIServer.RuntimeConfig)
+		assert.Nil(t, kubeAPIServer.Requests)
+	})
+}
+
+func TestKubeAPIServerConfigFromInput(t *testing.T) {
+	t.Run("should keep current settings when input is not provided", func(t *testing.T) {
+		// given
+		current := &KubeAPIServerConfig{FeatureGates: map[string]bool{"EphemeralContainers": true}}
+
+		// when
+		config, err := KubeAPIServerConfigFromInput(nil, current)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, current, config)
+	})
+
+	t.Run("should replace only settings provided in input", func(t *testing.T) {
+		// given
+		current := &KubeAPIServerConfig{
+			FeatureGates:           map[string]bool{"EphemeralContainers": true},
+			RuntimeConfig:          map[string]bool{"batch/v2alpha1": true},
+			MaxNonMutatingInflight: util.IntPtr(800),
+		}
+		input := &gqlschema.KubeAPIServerConfigInput{
+			FeatureGates:        &gqlschema.Switches{"TTLAfterFinished": true},
+			RuntimeConfig:       &gqlschema.Switches{},
+			MaxMutatingInflight: util.IntPtr(400),
+		}
+
+		// when
+		config, err := KubeAPIServerConfigFromInput(input, current)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &KubeAPIServerConfig{
+			FeatureGates:           map[string]bool{"TTLAfterFinished": true},
+			MaxNonMutatingInflight: util.IntPtr(800),
+			MaxMutatingInflight:    util.IntPtr(400),
+		}, config)
+		assert.Equal(t, map[string]bool{"EphemeralContainers": true}, current.FeatureGates)
+	})
+
+	t.Run("should accept runtime config keys", func(t *testing.T) {
+		// given
+		input := &gqlschema.KubeAPIServerConfigInput{
+			RuntimeConfig: &gqlschema.Switches{"v1": true, "api/all": false, "storage.k8s.io/v1alpha1": true, "apps/v1/deployments": true},
+		}
+
+		// when
+		_, err := KubeAPIServerConfigFromInput(input, nil)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		input       gqlschema.KubeAPIServerConfigInput
+	}{
+		{description: "malformed runtime config key", input: gqlschema.KubeAPIServerConfigInput{RuntimeConfig: &gqlschema.Switches{"Batch/V1": true}}},
+		{description: "too long runtime config key", input: gqlschema.KubeAPIServerConfigInput{RuntimeConfig: &gqlschema.Switches{"apps/v1/deployments/scale": true}}},
+		{description: "empty runtime config key part", input: gqlschema.KubeAPIServerConfigInput{RuntimeConfig: &gqlschema.Switches{"apps/": true}}},
+		{description: "zero non-mutating requests in flight", input: gqlschema.KubeAPIServerConfigInput{MaxNonMutatingInflight: util.IntPtr(0)}},
+		{description: "negative mutating requests in flight", input: gqlschema.KubeAPIServerConfigInput{MaxMutatingInflight: util.IntPtr(-1)}},
+	} {
+		t.Run("should reject "+testCase.description, func(t *testing.T) {
+			// when
+			_, err := KubeAPIServerConfigFromInput(&testCase.input, nil)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
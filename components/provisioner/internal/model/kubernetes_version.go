@@ -0,0 +1,48 @@
+package model
+
+import (
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// IsKubernetesMinorVersion returns true for versions without the patch number, e.g. 1.24
+func IsKubernetesMinorVersion(kubernetesVersion string) bool {
+	parsed, err := version.ParseGeneric(kubernetesVersion)
+	if err != nil {
+		return false
+	}
+
+	return len(parsed.Components()) == 2
+}
+
+// ValidateKubernetesVersionUpgrade rejects downgrades and upgrades skipping a minor version, as Gardener does not support them.
+// Requested version without the patch number is compared only by its minor version.
+func ValidateKubernetesVersionUpgrade(currentVersion, requestedVersion string) apperrors.AppError {
+	current, err := version.ParseGeneric(currentVersion)
+	if err != nil {
+		return apperrors.Internal("invalid current kubernetes version %s: %s", currentVersion, err.Error())
+	}
+
+	requested, err := version.ParseGeneric(requestedVersion)
+	if err != nil {
+		return apperrors.BadRequest("invalid kubernetes version %s: %s", requestedVersion, err.Error())
+	}
+
+	if requested.Major() != current.Major() {
+		return apperrors.BadRequest("kubernetes major version cannot be changed from %s to %s", currentVersion, requestedVersion)
+	}
+
+	if requested.Minor() < current.Minor() {
+		return apperrors.BadRequest("kubernetes version cannot be downgraded from %s to %s", currentVersion, requestedVersion)
+	}
+
+	if requested.Minor() > current.Minor()+1 {
+		return apperrors.BadRequest("kubernetes version cannot be upgraded from %s to %s, only upgrades to the next minor version are supported", currentVersion, requestedVersion)
+	}
+
+	if requested.Minor() == current.Minor() && len(requested.Components()) > 2 && requested.Patch() < current.Patch() {
+		return apperrors.BadRequest("kubernetes version cannot be downgraded from %s to %s", currentVersion, requestedVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKubernetesVersionUpgrade(t *testing.T) {
+	for _, testCase := range []struct {
+		description      string
+		currentVersion   string
+		requestedVersion string
+		valid            bool
+	}{
+		{description: "patch upgrade", currentVersion: "1.23.5", requestedVersion: "1.23.8", valid: true},
+		{description: "same version", currentVersion: "1.23.5", requestedVersion: "1.23.5", valid: true},
+		{description: "next minor version", currentVersion: "1.23.5", requestedVersion: "1.24.1", valid: true},
+		{description: "next minor version without patch", currentVersion: "1.23.5", requestedVersion: "1.24", valid: true},
+		{description: "current minor version without patch", currentVersion: "1.23.5", requestedVersion: "1.23", valid: true},
+		{description: "patch downgrade", currentVersion: "1.23.5", requestedVersion: "1.23.4", valid: false},
+		{description: "minor downgrade", currentVersion: "1.23.5", requestedVersion: "1.22.9", valid: false},
+		{description: "minor downgrade without patch", currentVersion: "1.23.5", requestedVersion: "1.22", valid: false},
+		{description: "jump of two minor versions", currentVersion: "1.23.5", requestedVersion: "1.25.0", valid: false},
+		{description: "major version change", currentVersion: "1.23.5", requestedVersion: "2.0.0", valid: false},
+		{description: "invalid version", currentVersion: "1.23.5", requestedVersion: "latest", valid: false},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := ValidateKubernetesVersionUpgrade(testCase.currentVersion, testCase.requestedVersion)
+
+			// then
+			if testCase.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		})
+	}
+}
+
+func TestIsKubernetesMinorVersion(t *testing.T) {
+	assert.True(t, IsKubernetesMinorVersion("1.24"))
+	assert.False(t, IsKubernetesMinorVersion("1.24.3"))
+	assert.False(t, IsKubernetesMinorVersion("latest"))
+}
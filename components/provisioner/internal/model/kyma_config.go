@@ -1,5 +1,11 @@
 package model
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type KymaComponent string
 
 type KymaProfile string
@@ -31,6 +37,12 @@ type Release struct {
 	InstallerYAML string
 }
 
+type ReleaseMetadata struct {
+	Id        string
+	Version   string
+	CreatedAt time.Time
+}
+
 type GithubRelease struct {
 	Id         int     `json:"id"`
 	Name       string  `json:"name"`
@@ -77,6 +89,20 @@ const (
 	ProductionProfile KymaProfile = "PRODUCTION"
 )
 
+// ParseKymaProfile returns the profile with the given case insensitive name, nil means the default profile of the Kyma installer
+func ParseKymaProfile(name string) (*KymaProfile, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	profile := KymaProfile(strings.ToUpper(name))
+	if profile != EvaluationProfile && profile != ProductionProfile {
+		return nil, fmt.Errorf("Kyma profile %s is not supported, use %s or %s", name, EvaluationProfile, ProductionProfile)
+	}
+
+	return &profile, nil
+}
+
 type ClusterAdministrator struct {
 	ID        string
 	ClusterId *string
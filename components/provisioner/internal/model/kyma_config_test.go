@@ -0,0 +1,37 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKymaProfile(t *testing.T) {
+	t.Run("should parse profile name", func(t *testing.T) {
+		for name, expected := range map[string]KymaProfile{
+			"evaluation": EvaluationProfile,
+			"Production": ProductionProfile,
+			"PRODUCTION": ProductionProfile,
+		} {
+			profile, err := ParseKymaProfile(name)
+
+			require.NoError(t, err)
+			require.NotNil(t, profile)
+			assert.Equal(t, expected, *profile)
+		}
+	})
+
+	t.Run("should return nil for empty name", func(t *testing.T) {
+		profile, err := ParseKymaProfile("")
+
+		require.NoError(t, err)
+		assert.Nil(t, profile)
+	})
+
+	t.Run("should reject unknown profile", func(t *testing.T) {
+		_, err := ParseKymaProfile("minimal")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"fmt"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SystemWorkerPoolName is the name of the Shoot worker pool dedicated to the Kyma system components
+	SystemWorkerPoolName = "system-worker-0"
+	// SystemWorkerPoolLabel is set on the nodes of the system worker pool and used as the node selector of the Kyma system components
+	SystemWorkerPoolLabel = "kyma-project.io/system-pool"
+	// SystemWorkerPoolTaint keeps the customer workloads, which do not tolerate it, away from the system worker pool
+	SystemWorkerPoolTaint = "kyma-project.io/system-pool"
+
+	systemWorkerPoolValue = "true"
+
+	maxSystemWorkerPoolSize = 10
+)
+
+// SystemWorkerPool is the fixed-size worker pool dedicated to the Kyma system components, the nodes have the same machine type as the main worker pool if it is not set
+type SystemWorkerPool struct {
+	Size        int    `json:"size"`
+	MachineType string `json:"machineType,omitempty"`
+}
+
+// SystemWorkerPoolFromInput returns the system worker pool requested in the input, based on the current one or on the defaults if it is added.
+// Once added, the pool cannot be removed, as the Kyma system components are scheduled on its nodes only.
+func SystemWorkerPoolFromInput(enabled *bool, input *gqlschema.SystemWorkerPoolInput, current *SystemWorkerPool, defaults SystemWorkerPool) (*SystemWorkerPool, apperrors.AppError) {
+	if current != nil && enabled != nil && !*enabled {
+		return nil, apperrors.BadRequest("error: dedicated system pool cannot be removed")
+	}
+
+	if current == nil && (enabled == nil || !*enabled) {
+		if input != nil {
+			return nil, apperrors.BadRequest("error: systemWorkerPool can be provided only with dedicatedSystemPool enabled")
+		}
+		return nil, nil
+	}
+
+	pool := defaults
+	if current != nil {
+		pool = *current
+	}
+
+	if input != nil {
+		if input.Size != nil {
+			pool.Size = *input.Size
+		}
+		if input.MachineType != nil {
+			pool.MachineType = *input.MachineType
+		}
+	}
+
+	if err := pool.Validate(); err != nil {
+		return nil, apperrors.BadRequest("error: %s", err.Error())
+	}
+
+	return &pool, nil
+}
+
+// Validate checks if the size of the pool is in the allowed range
+func (p SystemWorkerPool) Validate() error {
+	if p.Size < 1 || p.Size > maxSystemWorkerPoolSize {
+		return fmt.Errorf("system worker pool size %d has to be between 1 and %d", p.Size, maxSystemWorkerPoolSize)
+	}
+	return nil
+}
+
+// applySystemWorkerPool adds the system worker pool to the Shoot or updates the existing one, so that it can be added to the clusters provisioned without it.
+// The pool is created with the machine image and provider config of the main worker pool and always spans the same zones.
+func applySystemWorkerPool(shoot *gardener_types.Shoot, pool *SystemWorkerPool) {
+	if pool == nil || len(shoot.Spec.Provider.Workers) == 0 {
+		return
+	}
+
+	mainWorker := shoot.Spec.Provider.Workers[0]
+
+	index := systemWorkerPoolIndex(shoot)
+	if index < 0 {
+		worker := gardener_types.Worker{
+			Name:           SystemWorkerPoolName,
+			Machine:        *mainWorker.Machine.DeepCopy(),
+			ProviderConfig: mainWorker.ProviderConfig.DeepCopy(),
+			Volume:         mainWorker.Volume.DeepCopy(),
+		}
+		shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, worker)
+		index = len(shoot.Spec.Provider.Workers) - 1
+	}
+	worker := &shoot.Spec.Provider.Workers[index]
+
+	worker.Machine.Type = mainWorker.Machine.Type
+	if pool.MachineType != "" {
+		worker.Machine.Type = pool.MachineType
+	}
+	worker.Minimum = int32(pool.Size)
+	worker.Maximum = int32(pool.Size)
+	worker.Zones = mainWorker.Zones
+
+	if worker.Labels == nil {
+		worker.Labels = make(map[string]string)
+	}
+	worker.Labels[SystemWorkerPoolLabel] = systemWorkerPoolValue
+
+	for _, taint := range worker.Taints {
+		if taint.Key == SystemWorkerPoolTaint {
+			return
+		}
+	}
+	worker.Taints = append(worker.Taints, corev1.Taint{
+		Key:    SystemWorkerPoolTaint,
+		Value:  systemWorkerPoolValue,
+		Effect: corev1.TaintEffectNoSchedule,
+	})
+}
+
+func systemWorkerPoolIndex(shoot *gardener_types.Shoot) int {
+	for i, worker := range shoot.Spec.Provider.Workers {
+		if worker.Name == SystemWorkerPoolName {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,191 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGardenerConfig_DedicatedSystemPool(t *testing.T) {
+	gcpProviderConfig, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1", "fix-zone-2"}))
+	require.NoError(t, err)
+
+	systemPoolTaint := corev1.Taint{Key: SystemWorkerPoolTaint, Value: "true", Effect: corev1.TaintEffectNoSchedule}
+
+	t.Run("should create only the main worker pool when the system pool is not requested", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, template.Spec.Provider.Workers, 1)
+		assert.Equal(t, "cpu-worker-0", template.Spec.Provider.Workers[0].Name)
+	})
+
+	t.Run("should add tainted fixed-size system pool to Shoot template", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.DedicatedSystemPool = &SystemWorkerPool{Size: 2}
+
+		// when
+		template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", nil)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, template.Spec.Provider.Workers, 2)
+		mainWorker := template.Spec.Provider.Workers[0]
+		systemWorker := template.Spec.Provider.Workers[1]
+
+		assert.Equal(t, SystemWorkerPoolName, systemWorker.Name)
+		assert.Equal(t, int32(2), systemWorker.Minimum)
+		assert.Equal(t, int32(2), systemWorker.Maximum)
+		assert.Equal(t, mainWorker.Machine, systemWorker.Machine)
+		assert.Equal(t, mainWorker.Volume, systemWorker.Volume)
+		assert.Equal(t, []string{"fix-zone-1", "fix-zone-2"}, systemWorker.Zones)
+		assert.Equal(t, map[string]string{SystemWorkerPoolLabel: "true"}, systemWorker.Labels)
+		assert.Equal(t, []corev1.Taint{systemPoolTaint}, systemWorker.Taints)
+		assert.Empty(t, mainWorker.Taints)
+	})
+
+	t.Run("should add system pool to existing Shoot on upgrade", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.DedicatedSystemPool = &SystemWorkerPool{Size: 3, MachineType: "system-machine"}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(testkit.NewTestWorker("cpu-worker-0").WithMachineImageAndVersion("gardenlinux", "25.0.0").ToWorker()).ToShoot()
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, shoot.Spec.Provider.Workers, 2)
+		assert.Equal(t, "cpu-worker-0", shoot.Spec.Provider.Workers[0].Name)
+		assert.Equal(t, "machine", shoot.Spec.Provider.Workers[0].Machine.Type)
+		systemWorker := shoot.Spec.Provider.Workers[1]
+		assert.Equal(t, SystemWorkerPoolName, systemWorker.Name)
+		assert.Equal(t, "system-machine", systemWorker.Machine.Type)
+		assert.Equal(t, "gardenlinux", systemWorker.Machine.Image.Name)
+		assert.Equal(t, int32(3), systemWorker.Minimum)
+		assert.Equal(t, int32(3), systemWorker.Maximum)
+		assert.Equal(t, []corev1.Taint{systemPoolTaint}, systemWorker.Taints)
+	})
+
+	t.Run("should update existing system pool without duplicating it", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+		gardenerConfig.DedicatedSystemPool = &SystemWorkerPool{Size: 4}
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(
+			testkit.NewTestWorker("cpu-worker-0").ToWorker(),
+			testkit.NewTestWorker(SystemWorkerPoolName).WithMinMax(2, 2).ToWorker(),
+		).ToShoot()
+		shoot.Spec.Provider.Workers[1].Taints = []corev1.Taint{systemPoolTaint}
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, shoot.Spec.Provider.Workers, 2)
+		systemWorker := shoot.Spec.Provider.Workers[1]
+		assert.Equal(t, int32(4), systemWorker.Minimum)
+		assert.Equal(t, int32(4), systemWorker.Maximum)
+		assert.Equal(t, "machine", systemWorker.Machine.Type)
+		assert.Equal(t, []corev1.Taint{systemPoolTaint}, systemWorker.Taints)
+	})
+
+	t.Run("should keep system pool on upgrade which does not change it", func(t *testing.T) {
+		// given
+		gardenerConfig := fixGardenerConfig("gcp", gcpProviderConfig)
+
+		shoot := testkit.NewTestShoot("shoot").WithWorkers(
+			testkit.NewTestWorker("cpu-worker-0").ToWorker(),
+			testkit.NewTestWorker(SystemWorkerPoolName).WithMinMax(2, 2).ToWorker(),
+		).ToShoot()
+
+		// when
+		err := gcpProviderConfig.EditShootConfig(gardenerConfig, shoot)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, shoot.Spec.Provider.Workers, 2)
+		assert.Equal(t, int32(2), shoot.Spec.Provider.Workers[1].Minimum)
+	})
+}
+
+func TestSystemWorkerPoolFromInput(t *testing.T) {
+	defaults := SystemWorkerPool{Size: 2}
+
+	t.Run("should not create system pool when it is not requested", func(t *testing.T) {
+		// when
+		pool, err := SystemWorkerPoolFromInput(nil, nil, nil, defaults)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, pool)
+	})
+
+	t.Run("should create system pool with defaults", func(t *testing.T) {
+		// when
+		pool, err := SystemWorkerPoolFromInput(util.BoolPtr(true), nil, nil, defaults)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &SystemWorkerPool{Size: 2}, pool)
+	})
+
+	t.Run("should override defaults with input", func(t *testing.T) {
+		// given
+		input := &gqlschema.SystemWorkerPoolInput{Size: util.IntPtr(3), MachineType: util.StringPtr("n1-standard-4")}
+
+		// when
+		pool, err := SystemWorkerPoolFromInput(util.BoolPtr(true), input, nil, defaults)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &SystemWorkerPool{Size: 3, MachineType: "n1-standard-4"}, pool)
+	})
+
+	t.Run("should keep current system pool and replace only provided settings", func(t *testing.T) {
+		// given
+		current := &SystemWorkerPool{Size: 3, MachineType: "n1-standard-4"}
+		input := &gqlschema.SystemWorkerPoolInput{Size: util.IntPtr(4)}
+
+		// when
+		pool, err := SystemWorkerPoolFromInput(nil, input, current, defaults)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &SystemWorkerPool{Size: 4, MachineType: "n1-standard-4"}, pool)
+		assert.Equal(t, 3, current.Size)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		enabled     *bool
+		input       *gqlschema.SystemWorkerPoolInput
+		current     *SystemWorkerPool
+	}{
+		{description: "removal of system pool", enabled: util.BoolPtr(false), current: &SystemWorkerPool{Size: 2}},
+		{description: "settings without system pool", input: &gqlschema.SystemWorkerPoolInput{Size: util.IntPtr(2)}},
+		{description: "zero size", enabled: util.BoolPtr(true), input: &gqlschema.SystemWorkerPoolInput{Size: util.IntPtr(0)}},
+		{description: "too large size", enabled: util.BoolPtr(true), input: &gqlschema.SystemWorkerPoolInput{Size: util.IntPtr(11)}},
+	} {
+		t.Run("should reject "+testCase.description, func(t *testing.T) {
+			// when
+			_, err := SystemWorkerPoolFromInput(testCase.enabled, testCase.input, testCase.current, defaults)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
@@ -2,11 +2,14 @@ package model
 
 import (
 	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 )
 
 type OperationState string
 
 const (
+	Pending    OperationState = "PENDING"
 	InProgress OperationState = "IN_PROGRESS"
 	Succeeded  OperationState = "SUCCEEDED"
 	Failed     OperationState = "FAILED"
@@ -21,6 +24,9 @@ const (
 	Deprovision      OperationType = "DEPROVISION"
 	ReconnectRuntime OperationType = "RECONNECT_RUNTIME"
 	Hibernate        OperationType = "HIBERNATE"
+	WakeUp           OperationType = "WAKE_UP"
+
+	CleanupFailedProvisioning OperationType = "CLEANUP_FAILED_PROVISIONING"
 )
 
 type OperationStage string
@@ -28,6 +34,7 @@ type OperationStage string
 const (
 	WaitingForClusterDomain      OperationStage = "WaitingForClusterDomain"
 	WaitingForClusterCreation    OperationStage = "WaitingForClusterCreation"
+	ValidatingOverrides          OperationStage = "ValidatingOverrides"
 	CreatingBindingsForOperators OperationStage = "CreatingBindingsForOperators"
 	StartingInstallation         OperationStage = "StartingInstallation"
 	WaitingForInstallation       OperationStage = "WaitingForInstallation"
@@ -39,13 +46,20 @@ const (
 	DeleteCluster          OperationStage = "DeprovisionCluster"
 	CleanupCluster         OperationStage = "CleanupCluster"
 
-	StartingUpgrade      OperationStage = "StartingUpgrade"
-	UpdatingUpgradeState OperationStage = "UpdatingUpgradeState"
+	CheckingPreUpgradeHealth  OperationStage = "CheckingPreUpgradeHealth"
+	StartingUpgrade           OperationStage = "StartingUpgrade"
+	CheckingPostUpgradeHealth OperationStage = "CheckingPostUpgradeHealth"
+	UpdatingUpgradeState      OperationStage = "UpdatingUpgradeState"
 
-	WaitingForShootUpgrade    OperationStage = "WaitingForShootUpgrade"
-	WaitingForShootNewVersion OperationStage = "WaitingForShootNewVersion"
+	WaitingForShootUpgrade      OperationStage = "WaitingForShootUpgrade"
+	WaitingForShootNewVersion   OperationStage = "WaitingForShootNewVersion"
+	VerifyingAgentConnection    OperationStage = "VerifyingAgentConnection"
+	WaitingForAgentReconnection OperationStage = "WaitingForAgentReconnection"
 
-	WaitForHibernation OperationStage = "WaitForHibernation"
+	StartingHibernation OperationStage = "StartingHibernation"
+	WaitForHibernation  OperationStage = "WaitForHibernation"
+	StartingWakeUp      OperationStage = "StartingWakeUp"
+	WaitForWakeUp       OperationStage = "WaitForWakeUp"
 
 	FinishedStage OperationStage = "Finished"
 )
@@ -57,11 +71,32 @@ type Cluster struct {
 	Deleted            bool
 	Tenant             string
 	SubAccountId       *string
-	ActiveKymaConfigId string
+	ActiveKymaConfigId *string
 	Administrators     []string
 
+	Hibernated             bool
+	HibernatedAt           *time.Time
+	LastWokenAt            *time.Time
+	HibernationInitiatedBy *HibernationTrigger
+
+	// ExpireAt is the time after which the Runtime is deprovisioned automatically, nil if it does not expire
+	ExpireAt *time.Time
+	// DeprovisioningScheduledAt is the time at which the deprovisioning requested in advance starts, nil if it is not scheduled
+	DeprovisioningScheduledAt *time.Time
+
+	// APIServerURL and CACertificate are extracted from the kubeconfig, so that they can be exposed without the admin credentials
+	APIServerURL  *string
+	CACertificate *string
+
 	ClusterConfig GardenerConfig `db:"-"`
-	KymaConfig    KymaConfig     `db:"-"`
+	// KymaConfig is nil when Kyma is managed externally and not installed by the Provisioner
+	KymaConfig *KymaConfig `db:"-"`
+}
+
+// APIServer is the endpoint of the cluster API server with its PEM encoded CA bundle
+type APIServer struct {
+	URL           string
+	CACertificate string
 }
 
 type Operation struct {
@@ -74,6 +109,26 @@ type Operation struct {
 	ClusterID      string
 	Stage          OperationStage
 	LastTransition *time.Time
+	Force          bool
+	Version        int
+	// Kyma installation timeout of provisioning and upgrade operations
+	InstallationTimeoutMinutes *int
+	// Details collected while the Runtime Agent is not connected, helping to find out why
+	Diagnostics *string
+	// Reason of the failure of the failed operation, such as the exceeded quota of the hyperscaler account
+	FailureReason *string
+	// Time when the operation triggered Kyma installation, so that it is resumed instead of triggered again after restart
+	InstallationTriggeredAt *time.Time
+	// TriggeredBy is set if the operation was not requested through the API but started by the Provisioner
+	TriggeredBy *OperationTrigger
+	// Provisioner replica processing the operation, other replicas do not process it until the claim expires
+	ClaimedBy      *string
+	ClaimExpiresAt *time.Time
+}
+
+// ClaimedByOther checks if the operation is processed by another Provisioner replica, the expired claims are ignored
+func (o Operation) ClaimedByOther(owner string, now time.Time) bool {
+	return o.ClaimedBy != nil && *o.ClaimedBy != owner && o.ClaimExpiresAt != nil && o.ClaimExpiresAt.After(now)
 }
 
 type RuntimeAgentConnectionStatus int
@@ -89,13 +144,232 @@ type RuntimeStatus struct {
 	RuntimeConnectionStatus RuntimeAgentConnectionStatus
 	RuntimeConfiguration    Cluster
 	HibernationStatus       HibernationStatus
+	// ShootStatus is nil if the Shoot could not be read from Gardener
+	ShootStatus *ShootStatus
 }
 
 type OperationsCount struct {
 	Count map[OperationType]int
 }
 
+type ClusterGroup struct {
+	Provider    string
+	Region      string
+	KymaVersion string
+}
+
+type ClustersCount struct {
+	Count map[ClusterGroup]int
+}
+
+// OrphanedShoot is a Shoot of the Gardener project without the corresponding active cluster
+type OrphanedShoot struct {
+	Name              string
+	CreationTimestamp time.Time
+	Labels            map[string]string
+	CostAllocation    CostAllocation
+}
+
+type QueueState struct {
+	OperationType OperationType
+	Paused        bool
+}
+
+type QueueStatus struct {
+	QueueState
+	Depth int
+}
+
+// ReadOnlyMode is the read-only maintenance mode stored in the database, nil values mean that the mode was not set
+// and the configuration of the Provisioner applies
+type ReadOnlyMode struct {
+	Enabled *bool
+	Message *string
+}
+
+type AuditEntry struct {
+	ID           string
+	Tenant       *string
+	SubAccountID *string
+	Mutation     string
+	Input        string
+	OperationID  *string
+	CreatedAt    time.Time
+}
+
+type AuditEntriesFilter struct {
+	Tenant      *string
+	Mutation    *string
+	OperationID *string
+	From        *time.Time
+	To          *time.Time
+}
+
+// FailedOperationsFilter selects the failed operations to be retried, nil fields do not filter
+type FailedOperationsFilter struct {
+	Type        *OperationType
+	FailedAfter *time.Time
+	// ErrorReason is matched case-insensitively against any part of the operation message
+	ErrorReason *string
+}
+
+// ClustersFilter selects clusters listed with their last operations, nil fields do not filter
+type ClustersFilter struct {
+	Tenant             *string
+	SubAccountID       *string
+	Deleted            *bool
+	Provider           *string
+	Region             *string
+	LastOperationType  *OperationType
+	LastOperationState *OperationState
+}
+
+// ClusterWithLastOperation is the cluster without its configuration together with its last operation,
+// the last operation is nil if the cluster has no operations
+type ClusterWithLastOperation struct {
+	Cluster       Cluster
+	LastOperation *Operation
+}
+
+// IdempotencyKey maps the key passed by the client with the mutation to the operation started by the first request with the key
+type IdempotencyKey struct {
+	Tenant        string
+	Key           string
+	OperationType OperationType
+	OperationID   string
+	CreatedAt     time.Time
+}
+
+// ProviderDefaults are the defaults applied to the provisioning request of the provider which specifies only the required fields
+type ProviderDefaults struct {
+	Provider       string
+	GardenerConfig GardenerConfig
+	Regions        ProviderRegions
+	KymaVersion    *string
+	KymaProfile    *KymaProfile
+}
+
+// ProviderRegions are the patterns of the regions and zones of the provider allowed by the region policy
+type ProviderRegions struct {
+	ProviderAllowed bool
+	AllowedRegions  []string
+	DeniedRegions   []string
+	DeniedZones     []string
+}
+
+// OperationAnnotation is the note attached to the operation, e.g. by the on-call engineer investigating it
+type OperationAnnotation struct {
+	OperationID string
+	Key         string
+	Value       string
+	UpdatedAt   time.Time
+}
+
+type StageDuration struct {
+	OperationID     string
+	OperationType   OperationType
+	Stage           OperationStage
+	DurationSeconds float64
+	FinishedAt      time.Time
+}
+
+type StageDurationStats struct {
+	AverageDuration time.Duration
+	Samples         int
+}
+
+type OperationProgress struct {
+	CurrentStage        OperationStage
+	StagesDone          int
+	StagesTotal         int
+	EstimatedCompletion *time.Time
+}
+
+type OperationTrigger string
+
+const (
+	OperationTriggerExpired   OperationTrigger = "EXPIRED"
+	OperationTriggerScheduled OperationTrigger = "SCHEDULED"
+)
+
+// DeprovisioningSchedule is the deprovisioning of the Runtime requested to start at the given time
+type DeprovisioningSchedule struct {
+	RuntimeID   string
+	ScheduledAt time.Time
+}
+
+type HibernationTrigger string
+
+const (
+	HibernationTriggerManual    HibernationTrigger = "MANUAL"
+	HibernationTriggerScheduled HibernationTrigger = "SCHEDULED"
+)
+
 type HibernationStatus struct {
 	Hibernated          bool
 	HibernationPossible bool
+	HibernatedSince     *time.Time
+	LastWokenAt         *time.Time
+	Trigger             *HibernationTrigger
+}
+
+type ShootState string
+
+const (
+	ShootStateHealthy     ShootState = "HEALTHY"
+	ShootStateUnhealthy   ShootState = "UNHEALTHY"
+	ShootStateProgressing ShootState = "PROGRESSING"
+	ShootStateHibernated  ShootState = "HIBERNATED"
+)
+
+type ShootStatus struct {
+	State      ShootState
+	Conditions []ShootCondition
+	LastErrors []ShootError
+	// Domain is the effective DNS domain of the Shoot, including the default one assigned by Gardener
+	Domain *string
+}
+
+type ShootCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
+
+type ShootError struct {
+	Description string
+	Codes       []string
+}
+
+type ShootSpecChange struct {
+	Path     string
+	OldValue string
+	NewValue string
+}
+
+// ShootDryRun is the Shoot rendered for the cluster without creating it in Gardener
+type ShootDryRun struct {
+	// Spec is the JSON encoded spec of the Shoot
+	Spec string
+	// PreflightChecked is false if the pre-flight checks are disabled
+	PreflightChecked bool
+	// PreflightError is nil if the pre-flight checks passed or were not run
+	PreflightError apperrors.AppError
+}
+
+// ProvisioningDryRunReport is the result of the provisioning validated and rendered without creating any resources
+type ProvisioningDryRunReport struct {
+	Errors            []string
+	Warnings          []string
+	KymaVersion       *string
+	KubernetesVersion *string
+	ShootSpec         *string
+	// KymaConfig is nil when Kyma is managed externally or the Kyma configuration could not be resolved
+	KymaConfig *KymaConfig
+}
+
+func (r ProvisioningDryRunReport) Valid() bool {
+	return len(r.Errors) == 0
 }
@@ -0,0 +1,111 @@
+package oauth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Token is refreshed in the background after this part of its lifetime passes
+	refreshLifetimeRatio = 0.8
+	refreshRetryDelay    = 10 * time.Second
+)
+
+type RefreshStats struct {
+	Succeeded int
+	Failed    int
+}
+
+// CachingClient keeps the token obtained from the wrapped client and refreshes it ahead of expiry.
+// Concurrent callers share a single refresh, so the tokens endpoint is called once per token lifetime.
+type CachingClient struct {
+	client Client
+
+	mutex         sync.Mutex
+	token         Token
+	refreshAt     time.Time
+	refreshFailed bool
+	stats         RefreshStats
+}
+
+func NewCachingClient(client Client) *CachingClient {
+	return &CachingClient{
+		client: client,
+	}
+}
+
+func (c *CachingClient) GetAuthorizationToken() (Token, apperrors.AppError) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.token.EmptyOrExpired() {
+		return c.token, nil
+	}
+
+	return c.refresh()
+}
+
+// Run prefetches the token and keeps refreshing it until stop channel is closed
+func (c *CachingClient) Run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(c.nextRefreshIn()):
+			c.mutex.Lock()
+			_, err := c.refresh()
+			c.mutex.Unlock()
+
+			if err != nil {
+				log.Warnf("Failed to refresh token to access Director, retrying in %s: %s", refreshRetryDelay, err.Error())
+			}
+		}
+	}
+}
+
+func (c *CachingClient) RefreshStats() RefreshStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.stats
+}
+
+func (c *CachingClient) nextRefreshIn() time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.refreshFailed {
+		return refreshRetryDelay
+	}
+
+	if c.token.EmptyOrExpired() {
+		return 0
+	}
+
+	return time.Until(c.refreshAt)
+}
+
+// refresh has to be called with the mutex locked
+func (c *CachingClient) refresh() (Token, apperrors.AppError) {
+	obtainedAt := time.Now()
+
+	token, err := c.client.GetAuthorizationToken()
+	if err != nil {
+		c.refreshFailed = true
+		c.stats.Failed++
+		return Token{}, err
+	}
+
+	c.refreshFailed = false
+	c.stats.Succeeded++
+
+	lifetime := time.Unix(token.Expiration, 0).Sub(obtainedAt)
+
+	c.token = token
+	c.refreshAt = obtainedAt.Add(time.Duration(float64(lifetime) * refreshLifetimeRatio))
+
+	return token, nil
+}
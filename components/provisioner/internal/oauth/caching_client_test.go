@@ -0,0 +1,148 @@
+package oauth
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clientStub struct {
+	mutex     sync.Mutex
+	responses []clientStubResponse
+	calls     int
+}
+
+type clientStubResponse struct {
+	token Token
+	err   apperrors.AppError
+}
+
+func newClientStub(responses ...clientStubResponse) *clientStub {
+	return &clientStub{responses: responses}
+}
+
+func (c *clientStub) GetAuthorizationToken() (Token, apperrors.AppError) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	response := c.responses[c.calls%len(c.responses)]
+	c.calls++
+
+	return response.token, response.err
+}
+
+func (c *clientStub) callsCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.calls
+}
+
+func TestCachingClient_GetAuthorizationToken(t *testing.T) {
+	t.Run("Should fetch token once for concurrent calls", func(t *testing.T) {
+		//given
+		token := Token{
+			AccessToken: "12345",
+			Expiration:  time.Now().Add(time.Hour).Unix(),
+		}
+
+		oauthClient := newClientStub(clientStubResponse{token: token})
+
+		cachingClient := NewCachingClient(oauthClient)
+
+		//when
+		var waitGroup sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+
+				responseToken, err := cachingClient.GetAuthorizationToken()
+				require.NoError(t, err)
+				assert.Equal(t, token, responseToken)
+			}()
+		}
+		waitGroup.Wait()
+
+		//then
+		assert.Equal(t, 1, oauthClient.callsCount())
+		assert.Equal(t, RefreshStats{Succeeded: 1}, cachingClient.RefreshStats())
+	})
+
+	t.Run("Should fetch new token when cached one expired", func(t *testing.T) {
+		//given
+		expiredToken := Token{
+			AccessToken: "12345",
+			Expiration:  time.Now().Add(-time.Minute).Unix(),
+		}
+		validToken := Token{
+			AccessToken: "67890",
+			Expiration:  time.Now().Add(time.Hour).Unix(),
+		}
+
+		oauthClient := newClientStub(clientStubResponse{token: expiredToken}, clientStubResponse{token: validToken})
+
+		cachingClient := NewCachingClient(oauthClient)
+
+		_, err := cachingClient.GetAuthorizationToken()
+		require.NoError(t, err)
+
+		//when
+		responseToken, err := cachingClient.GetAuthorizationToken()
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, validToken, responseToken)
+		assert.Equal(t, 2, oauthClient.callsCount())
+	})
+
+	t.Run("Should count failed refreshes", func(t *testing.T) {
+		//given
+		oauthClient := newClientStub(clientStubResponse{err: apperrors.Internal("error")})
+
+		cachingClient := NewCachingClient(oauthClient)
+
+		//when
+		_, err := cachingClient.GetAuthorizationToken()
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, RefreshStats{Failed: 1}, cachingClient.RefreshStats())
+	})
+}
+
+func TestCachingClient_Run(t *testing.T) {
+	//given
+	shortLivedToken := Token{
+		AccessToken: "12345",
+		Expiration:  time.Now().Add(time.Second).Unix(),
+	}
+	token := Token{
+		AccessToken: "67890",
+		Expiration:  time.Now().Add(time.Hour).Unix(),
+	}
+
+	oauthClient := newClientStub(clientStubResponse{token: shortLivedToken}, clientStubResponse{token: token})
+
+	cachingClient := NewCachingClient(oauthClient)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	//when
+	go cachingClient.Run(stop)
+
+	//then
+	require.Eventually(t, func() bool {
+		return cachingClient.RefreshStats().Succeeded == 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	responseToken, err := cachingClient.GetAuthorizationToken()
+	require.NoError(t, err)
+	assert.Equal(t, token, responseToken)
+	assert.Equal(t, 2, oauthClient.callsCount())
+}
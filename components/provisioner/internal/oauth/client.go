@@ -8,6 +8,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
@@ -27,6 +28,9 @@ type oauthClient struct {
 	httpClient    *http.Client
 	secretsClient v1.SecretInterface
 	secretName    string
+
+	credentialsMutex sync.Mutex
+	credentials      *credentials
 }
 
 func NewOauthClient(client *http.Client, secrets v1.SecretInterface, secretName string) Client {
@@ -38,27 +42,48 @@ func NewOauthClient(client *http.Client, secrets v1.SecretInterface, secretName
 }
 
 func (c *oauthClient) GetAuthorizationToken() (Token, apperrors.AppError) {
-	credentials, err := c.getCredentials()
-
+	credentials, err := c.getCredentials(false)
 	if err != nil {
 		return Token{}, err
 	}
 
-	return c.getAuthorizationToken(credentials)
+	token, err := c.getAuthorizationToken(credentials)
+	if err != nil && err.Cause() == apperrors.ClientCredentialsInvalid {
+		// Credentials secret might have been rotated since it was read
+		log.Warnf("Client credentials rejected by the tokens endpoint, re-reading secret %s", c.secretName)
+
+		credentials, err = c.getCredentials(true)
+		if err != nil {
+			return Token{}, err
+		}
+
+		return c.getAuthorizationToken(credentials)
+	}
+
+	return token, err
 }
 
-func (c *oauthClient) getCredentials() (credentials, apperrors.AppError) {
+func (c *oauthClient) getCredentials(reload bool) (credentials, apperrors.AppError) {
+	c.credentialsMutex.Lock()
+	defer c.credentialsMutex.Unlock()
+
+	if c.credentials != nil && !reload {
+		return *c.credentials, nil
+	}
+
 	secret, err := c.secretsClient.Get(context.Background(), c.secretName, metav1.GetOptions{})
 
 	if err != nil {
 		return credentials{}, util.K8SErrorToAppError(err)
 	}
 
-	return credentials{
+	c.credentials = &credentials{
 		clientID:       string(secret.Data[clientIDKey]),
 		clientSecret:   string(secret.Data[clientSecretKey]),
 		tokensEndpoint: string(secret.Data[tokensEndpointKey]),
-	}, nil
+	}
+
+	return *c.credentials, nil
 }
 
 func (c *oauthClient) getAuthorizationToken(credentials credentials) (Token, apperrors.AppError) {
@@ -90,6 +115,9 @@ func (c *oauthClient) getAuthorizationToken(credentials credentials) (Token, app
 		if err != nil {
 			dump = []byte("failed to dump response body")
 		}
+		if isInvalidClientResponse(response) {
+			return Token{}, apperrors.InvalidClientCredentials("Get token call rejected client credentials: %s. Response dump: %s", response.Status, string(dump))
+		}
 		return Token{}, apperrors.Internal("Get token call returned unexpected status: %s. Response dump: %s", response.Status, string(dump))
 	}
 
@@ -111,3 +139,20 @@ func (c *oauthClient) getAuthorizationToken(credentials credentials) (Token, app
 
 	return tokenResponse, nil
 }
+
+func isInvalidClientResponse(response *http.Response) bool {
+	if response.StatusCode != http.StatusUnauthorized && response.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	errorResponse := struct {
+		Error string `json:"error"`
+	}{}
+
+	err := json.NewDecoder(response.Body).Decode(&errorResponse)
+	if err != nil {
+		return false
+	}
+
+	return errorResponse.Error == invalidClientError
+}
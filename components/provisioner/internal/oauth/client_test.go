@@ -70,6 +70,68 @@ func TestOauthClient_GetAuthorizationToken(t *testing.T) {
 		assert.Equal(t, token.AccessToken, responseToken.AccessToken)
 		assert.Equal(t, token.Expiration, responseToken.Expiration)
 	})
+
+	t.Run("Should re-read credentials when they were rotated", func(t *testing.T) {
+		//given
+		oldCredentials := credentials{
+			clientID:       "12345",
+			clientSecret:   "old secret",
+			tokensEndpoint: "http://hydra:4445",
+		}
+		newCredentials := credentials{
+			clientID:       "12345",
+			clientSecret:   "new secret",
+			tokensEndpoint: "http://hydra:4445",
+		}
+
+		token := Token{
+			AccessToken: "12345",
+			Expiration:  1234,
+		}
+
+		validCredentials := oldCredentials
+
+		client := NewTestClient(func(req *http.Request) *http.Response {
+			username, secret, ok := req.BasicAuth()
+
+			if ok && username == validCredentials.clientID && secret == validCredentials.clientSecret {
+				jsonToken, err := json.Marshal(&token)
+
+				require.NoError(t, err)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(jsonToken)),
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"error":"invalid_client"}`))),
+			}
+		})
+
+		coreV1 := fake.NewSimpleClientset()
+		secrets := coreV1.CoreV1().Secrets(namespace)
+
+		createFakeCredentialsSecret(t, secrets, oldCredentials)
+
+		oauthClient := NewOauthClient(client, secrets, secretName)
+
+		_, appErr := oauthClient.GetAuthorizationToken()
+		require.NoError(t, appErr)
+
+		err := secrets.Delete(context.Background(), secretName, meta.DeleteOptions{})
+		require.NoError(t, err)
+		createFakeCredentialsSecret(t, secrets, newCredentials)
+		validCredentials = newCredentials
+
+		//when
+		responseToken, appErr := oauthClient.GetAuthorizationToken()
+
+		//then
+		require.NoError(t, appErr)
+		assert.Equal(t, token.AccessToken, responseToken.AccessToken)
+	})
 }
 
 func NewTestClient(fn RoundTripFunc) *http.Client {
@@ -15,6 +15,8 @@ const (
 	clientIDKey       = "client_id"
 	clientSecretKey   = "client_secret"
 	tokensEndpointKey = "tokens_endpoint"
+
+	invalidClientError = "invalid_client"
 )
 
 type Token struct {
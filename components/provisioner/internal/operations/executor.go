@@ -1,6 +1,7 @@
 package operations
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -8,10 +9,16 @@ import (
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
 
 	retry "github.com/avast/retry-go"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/director"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -24,26 +31,39 @@ func NewExecutor(
 	operation model.OperationType,
 	stages map[model.OperationStage]Step,
 	failureHandler FailureHandler,
-	directorClient director.DirectorClient) *Executor {
+	directorClient director.DirectorClient,
+	kubeconfigRenewer *KubeconfigRenewer) *Executor {
 
 	return &Executor{
-		dbSession:      session,
-		stages:         stages,
-		operation:      operation,
-		failureHandler: failureHandler,
-		log:            logrus.WithFields(logrus.Fields{"Component": "Executor", "OperationType": operation}),
-		directorClient: directorClient,
+		dbSession:         session,
+		stages:            stages,
+		operation:         operation,
+		failureHandler:    failureHandler,
+		log:               logrus.WithFields(logrus.Fields{"Component": "Executor", "OperationType": operation}),
+		directorClient:    directorClient,
+		kubeconfigRenewer: kubeconfigRenewer,
+		failures:          newFailureRecorder(operation),
+		retries:           newRetryRecorder(operation),
+		warnings:          newTimeoutWarnings(),
+		tracer:            otel.Tracer(tracing.TracerName),
+		now:               time.Now,
 	}
 }
 
 type Executor struct {
-	dbSession      dbsession.ReadWriteSession
-	stages         map[model.OperationStage]Step
-	operation      model.OperationType
-	failureHandler FailureHandler
-	directorClient director.DirectorClient
+	dbSession         dbsession.ReadWriteSession
+	stages            map[model.OperationStage]Step
+	operation         model.OperationType
+	failureHandler    FailureHandler
+	directorClient    director.DirectorClient
+	kubeconfigRenewer *KubeconfigRenewer
+	failures          *failureRecorder
+	retries           *retryRecorder
+	warnings          *timeoutWarnings
+	tracer            trace.Tracer
 
 	log logrus.FieldLogger
+	now func() time.Time
 }
 
 func (e *Executor) Execute(operationID string) ProcessingResult {
@@ -54,40 +74,61 @@ func (e *Executor) Execute(operationID string) ProcessingResult {
 	operation, err := e.dbSession.GetOperation(operationID)
 	if err != nil {
 		log.Errorf("error getting operation while processing it: %s", err.Error())
-		return ProcessingResult{Requeue: true, Delay: defaultDelay}
+		return ProcessingResult{Requeue: isRetryable(err), Delay: defaultDelay}
 	}
 
 	log = log.WithField("RuntimeId", operation.ClusterID)
 
 	if operation.State != model.InProgress {
 		log.Infof("Operation not InProgress. State: %s", operation.State)
+		e.failures.recordProcessed(operationID)
+		e.retries.clear(operationID)
+		e.warnings.clear(operationID)
 		return ProcessingResult{Requeue: false}
 	}
 
 	cluster, err := e.dbSession.GetCluster(operation.ClusterID)
 	if err != nil {
 		log.Errorf("error getting cluster while processing operation: %s", err.Error())
-		return ProcessingResult{Requeue: true, Delay: defaultDelay}
+		return ProcessingResult{Requeue: isRetryable(err), Delay: defaultDelay}
 	}
 
 	log = log.WithField("ShootName", cluster.ClusterConfig.Name)
 
 	if operation.Type == e.operation {
-		requeue, delay, err := e.process(operation, cluster, log)
+		requeue, delay, err := e.process(&operation, cluster, log)
 		if err != nil {
+			if isConflict(err) {
+				log.Warnf("operation modified concurrently: %s", err.Error())
+				return e.handleConflict(operationID, log)
+			}
+
 			nonRecoverable := NonRecoverableError{}
 			if errors.As(err, &nonRecoverable) {
 				log.Errorf("unrecoverable error occurred while processing operation: %s", err.Error())
+				e.failures.recordFailed(operationID, err)
+				e.retries.recordFinished(operationID, operation.Stage, e.retries.count(operationID, operation.Stage))
+				e.warnings.clear(operationID)
 				e.handleOperationFailure(operation, cluster, log)
-				e.updateOperationStatus(log, operation.ID, nonRecoverable.Error(), model.Failed, time.Now())
+				e.recordFailureReason(log, operation.ID, err)
+				err = e.updateOperationStatus(log, &operation, nonRecoverable.Error(), model.Failed, e.now())
+				if isConflict(err) {
+					log.Warnf("operation modified concurrently while setting it as failed: %s", err.Error())
+				}
 				e.setRuntimeStatusCondition(log, cluster.ID, cluster.Tenant)
 
 				return ProcessingResult{Requeue: false}
 			}
 
+			e.failures.recordRetrying(operationID, err)
+			e.retries.recordRetry(operationID, operation.Stage, err)
 			return ProcessingResult{Requeue: true, Delay: defaultDelay}
 		}
 
+		e.failures.recordProcessed(operationID)
+		if !requeue {
+			e.warnings.clear(operationID)
+		}
 		return ProcessingResult{Requeue: requeue, Delay: delay}
 	}
 
@@ -97,23 +138,54 @@ func (e *Executor) Execute(operationID string) ProcessingResult {
 	}
 }
 
-func (e *Executor) process(operation model.Operation, cluster model.Cluster, logger logrus.FieldLogger) (bool, time.Duration, error) {
+// handleConflict re-reads the operation modified concurrently by another worker or replica.
+// The work item is dropped when the operation is no longer in progress, otherwise it is processed again from the current stage.
+func (e *Executor) handleConflict(operationID string, log logrus.FieldLogger) ProcessingResult {
+	operation, err := e.dbSession.GetOperation(operationID)
+	if err != nil {
+		log.Errorf("error getting operation after concurrent modification: %s", err.Error())
+		return ProcessingResult{Requeue: isRetryable(err), Delay: defaultDelay}
+	}
+
+	if operation.State != model.InProgress || operation.Type != e.operation {
+		log.Infof("Dropping operation modified concurrently. State: %s", operation.State)
+		e.retries.clear(operationID)
+		return ProcessingResult{Requeue: false}
+	}
+
+	log.Infof("Continuing operation modified concurrently from stage %s", operation.Stage)
+	return ProcessingResult{Requeue: true, Delay: 0}
+}
+
+func (e *Executor) process(operation *model.Operation, cluster model.Cluster, logger logrus.FieldLogger) (bool, time.Duration, error) {
 
 	step, found := e.stages[operation.Stage]
 	if !found {
-		return false, 0, NewNonRecoverableError(fmt.Errorf("error: step %s not found in installation stages", operation.Stage))
+		return false, 0, NewNonRecoverableError(apperrors.Internal("error: step %s not found in installation stages", operation.Stage).SetComponent(apperrors.ErrComponentProvisioner))
 	}
 
 	for operation.Stage != model.FinishedStage {
 		log := logger.WithField("Stage", step.Name())
 		log.Infof("Starting processing")
 
-		if e.timeoutReached(operation, step.TimeLimit()) {
+		timeout := stepTimeLimit(step, *operation)
+		timePassed := e.now().Sub(stageStartTime(*operation))
+		if timePassed > timeout {
 			log.Errorf("Timeout reached for operation")
-			return false, 0, NewNonRecoverableError(fmt.Errorf("error: timeout while processing operation"))
+			stageTimeoutsTotal.WithLabelValues(string(e.operation), string(step.Name())).Inc()
+			return false, 0, NewStageTimeoutError(step.Name())
+		}
+		if e.warnings.shouldWarn(*operation, timePassed, timeout) {
+			log.Warnf("Operation is close to the time limit of the stage: %s of %s passed", timePassed.Round(time.Second), timeout)
+		}
+
+		// The kubeconfig is renewed before every stage, as the short-lived kubeconfig can expire while the operation is in progress.
+		// The stored kubeconfig is used if it cannot be renewed, so that the stages relying on it fail only if it has already expired.
+		if err := e.kubeconfigRenewer.Renew(&cluster, log); err != nil {
+			log.Warnf("Failed to renew kubeconfig: %s", err.Error())
 		}
 
-		result, err := step.Run(cluster, operation, log)
+		result, err := e.runStep(step, cluster, *operation, log)
 		if err != nil {
 			log.Errorf("error while processing operation, stage failed: %s", err.Error())
 			return false, 0, err
@@ -121,17 +193,32 @@ func (e *Executor) process(operation model.Operation, cluster model.Cluster, log
 
 		if result.Stage == model.FinishedStage {
 			log.Infof("Finished processing operation")
-			e.updateOperationStage(log, operation.ID, "Provisioning steps finished", model.FinishedStage, time.Now())
+			finishTime := e.now()
+			if err := e.updateOperationStage(log, operation, "Provisioning steps finished", model.FinishedStage, finishTime); err != nil {
+				return false, 0, err
+			}
+			e.recordStageDuration(log, *operation, finishTime)
+			e.retries.recordFinished(operation.ID, step.Name(), result.Retries)
 			break
 		}
 
-		if result.Stage != step.Name() {
-			transitionTime := time.Now()
-			e.updateOperationStage(log, operation.ID, fmt.Sprintf("Operation in progress. Stage %s", result.Stage), result.Stage, transitionTime)
+		if result.Stage == step.Name() {
+			e.retries.recordRetry(operation.ID, step.Name(), nil)
+		} else {
+			transitionTime := e.now()
+			message := fmt.Sprintf("Operation in progress. Stage %s", result.Stage)
+			if result.Message != "" {
+				message = fmt.Sprintf("%s. %s", message, result.Message)
+			}
+			if err := e.updateOperationStage(log, operation, message, result.Stage, transitionTime); err != nil {
+				return false, 0, err
+			}
+			e.recordStageDuration(log, *operation, transitionTime)
+			e.retries.recordFinished(operation.ID, step.Name(), result.Retries)
 			step = e.stages[result.Stage]
 			operation.Stage = result.Stage
 			operation.LastTransition = &transitionTime
-			log.Infof("Stage completed")
+			log.Infof("Stage completed after %d retries", result.Retries)
 		}
 
 		if result.Delay > 0 {
@@ -140,21 +227,47 @@ func (e *Executor) process(operation model.Operation, cluster model.Cluster, log
 	}
 
 	logger.Infof("Setting operation to succeeded")
-	e.updateOperationStatus(logger, operation.ID, "Operation succeeded", model.Succeeded, time.Now())
+	if err := e.updateOperationStatus(logger, operation, "Operation succeeded", model.Succeeded, e.now()); err != nil {
+		return false, 0, err
+	}
 
 	return false, 0, nil
 }
 
-func (e *Executor) timeoutReached(operation model.Operation, timeout time.Duration) bool {
+// runStep reports the stage as the span with the operation ID, which correlates it with the span of the request starting the operation
+func (e *Executor) runStep(step Step, cluster model.Cluster, operation model.Operation, log logrus.FieldLogger) (StageResult, error) {
+	_, span := e.tracer.Start(context.Background(), string(step.Name()), trace.WithAttributes(
+		tracing.OperationIDKey.String(operation.ID),
+		tracing.OperationTypeKey.String(string(operation.Type)),
+		tracing.RuntimeIDKey.String(operation.ClusterID),
+	))
+	defer span.End()
 
-	lastTimestamp := operation.StartTimestamp
-	if operation.LastTransition != nil {
-		lastTimestamp = *operation.LastTransition
+	result, err := step.Run(cluster, operation, log)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	result.Retries = e.retries.count(operation.ID, step.Name())
+	return result, nil
+}
+
+func stepTimeLimit(step Step, operation model.Operation) time.Duration {
+	if limiter, ok := step.(OperationTimeLimiter); ok {
+		return limiter.OperationTimeLimit(operation)
 	}
 
-	timePassed := time.Now().Sub(lastTimestamp)
+	return step.TimeLimit()
+}
+
+func stageStartTime(operation model.Operation) time.Time {
+	if operation.LastTransition != nil {
+		return *operation.LastTransition
+	}
 
-	return timePassed > timeout
+	return operation.StartTimestamp
 }
 
 func (e *Executor) handleOperationFailure(operation model.Operation, cluster model.Cluster, log logrus.FieldLogger) {
@@ -166,13 +279,29 @@ func (e *Executor) handleOperationFailure(operation model.Operation, cluster mod
 	}
 }
 
-func (e *Executor) updateOperationStatus(log logrus.FieldLogger, id, message string, state model.OperationState, t time.Time) {
+// recordFailureReason saves the classification of the failure with the operation, so that the reason is returned with the operation status
+func (e *Executor) recordFailureReason(log logrus.FieldLogger, operationID string, err error) {
+	reason, _ := classify(err)
+	if dbErr := e.dbSession.UpdateOperationFailureReason(operationID, string(reason)); dbErr != nil {
+		log.Warnf("Cannot save reason of operation failure: %s", dbErr.Error())
+	}
+}
+
+// updateOperationStatus returns only the conflict error, as other errors do not prevent further processing
+func (e *Executor) updateOperationStatus(log logrus.FieldLogger, operation *model.Operation, message string, state model.OperationState, t time.Time) error {
 	err := retry.Do(func() error {
-		return e.dbSession.UpdateOperationState(id, message, state, t)
-	}, retry.Attempts(5))
+		return e.dbSession.UpdateOperationState(operation.ID, operation.Version, message, state, t)
+	}, retry.Attempts(5), retry.RetryIf(isRetryable), retry.LastErrorOnly(true))
+	if isConflict(err) {
+		return err
+	}
 	if err != nil {
 		log.Infof("Cannot set operation status to %s: %s", state, err.Error())
+		return nil
 	}
+
+	operation.Version++
+	return nil
 }
 
 func (e *Executor) setRuntimeStatusCondition(log logrus.FieldLogger, id, tenant string) {
@@ -184,11 +313,44 @@ func (e *Executor) setRuntimeStatusCondition(log logrus.FieldLogger, id, tenant
 	}
 }
 
-func (e *Executor) updateOperationStage(log logrus.FieldLogger, id, message string, stage model.OperationStage, t time.Time) {
+// updateOperationStage returns only the conflict error, as other errors do not prevent further processing
+func (e *Executor) updateOperationStage(log logrus.FieldLogger, operation *model.Operation, message string, stage model.OperationStage, t time.Time) error {
 	err := retry.Do(func() error {
-		return e.dbSession.TransitionOperation(id, message, stage, t)
-	}, retry.Attempts(5))
+		return e.dbSession.TransitionOperation(operation.ID, operation.Version, message, stage, t)
+	}, retry.Attempts(5), retry.RetryIf(isRetryable), retry.LastErrorOnly(true))
+	if isConflict(err) {
+		return err
+	}
 	if err != nil {
 		log.Infof("Cannot modify operation stage to %s: %s", stage, err.Error())
+		return nil
+	}
+
+	operation.Version++
+	return nil
+}
+
+func isConflict(err error) bool {
+	var dbErr dberrors.Error
+	return errors.As(err, &dbErr) && dbErr.Code() == dberrors.CodeConflict
+}
+
+// isRetryable checks if the failed database call can succeed when repeated, the conflict requires re-reading the operation instead
+func isRetryable(err error) bool {
+	var dbErr dberrors.Error
+	return errors.As(err, &dbErr) && dbErr.Retryable()
+}
+
+// recordStageDuration stores duration of the completed stage, which is used to estimate completion of the next operations
+func (e *Executor) recordStageDuration(log logrus.FieldLogger, operation model.Operation, finishTime time.Time) {
+	err := e.dbSession.InsertStageDuration(model.StageDuration{
+		OperationID:     operation.ID,
+		OperationType:   operation.Type,
+		Stage:           operation.Stage,
+		DurationSeconds: finishTime.Sub(stageStartTime(operation)).Seconds(),
+		FinishedAt:      finishTime,
+	})
+	if err != nil {
+		log.Warnf("Cannot record duration of stage %s: %s", operation.Stage, err.Error())
 	}
 }
@@ -12,10 +12,16 @@ import (
 	directorMocks "github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/failure"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 const (
@@ -45,10 +51,11 @@ func TestStagesExecutor_Execute(t *testing.T) {
 		dbSession := &mocks.ReadWriteSession{}
 		dbSession.On("GetOperation", operationId).Return(operation, nil)
 		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
-		dbSession.On("TransitionOperation", operationId, "Provisioning steps finished", model.FinishedStage, mock.AnythingOfType("time.Time")).
+		dbSession.On("TransitionOperation", operationId, 0, "Provisioning steps finished", model.FinishedStage, mock.AnythingOfType("time.Time")).
 			Return(nil)
-		dbSession.On("UpdateOperationState", operationId, "Operation succeeded", model.Succeeded, mock.AnythingOfType("time.Time")).
+		dbSession.On("UpdateOperationState", operationId, 1, "Operation succeeded", model.Succeeded, mock.AnythingOfType("time.Time")).
 			Return(nil)
+		dbSession.On("InsertStageDuration", mock.AnythingOfType("model.StageDuration")).Return(nil)
 
 		mockStage := NewMockStep(model.WaitingForInstallation, model.FinishedStage, 10*time.Second, 10*time.Second)
 
@@ -58,7 +65,7 @@ func TestStagesExecutor_Execute(t *testing.T) {
 
 		directorClient := &directorMocks.DirectorClient{}
 
-		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), directorClient)
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), directorClient, nil)
 
 		// when
 		result := executor.Execute(operationId)
@@ -68,6 +75,81 @@ func TestStagesExecutor_Execute(t *testing.T) {
 		assert.True(t, mockStage.called)
 	})
 
+	t.Run("should record duration of each completed stage", func(t *testing.T) {
+		// given
+		stageStart := time.Now().Add(-10 * time.Minute)
+		startedOperation := operation
+		startedOperation.LastTransition = &stageStart
+
+		var recorded []model.StageDuration
+
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(startedOperation, nil)
+		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
+		dbSession.On("TransitionOperation", operationId, mock.AnythingOfType("int"), mock.AnythingOfType("string"), mock.AnythingOfType("model.OperationStage"), mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("UpdateOperationState", operationId, 2, "Operation succeeded", model.Succeeded, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("InsertStageDuration", mock.AnythingOfType("model.StageDuration")).Run(func(args mock.Arguments) {
+			recorded = append(recorded, args.Get(0).(model.StageDuration))
+		}).Return(dberrors.Internal("error"))
+
+		installationStages := map[model.OperationStage]Step{
+			model.WaitingForInstallation: NewMockStep(model.WaitingForInstallation, model.ConnectRuntimeAgent, 0, 20*time.Minute),
+			model.ConnectRuntimeAgent:    NewMockStep(model.ConnectRuntimeAgent, model.FinishedStage, 0, 20*time.Minute),
+		}
+
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.False(t, result.Requeue)
+		require.Len(t, recorded, 2)
+		assert.Equal(t, model.WaitingForInstallation, recorded[0].Stage)
+		assert.Equal(t, model.Provision, recorded[0].OperationType)
+		assert.InDelta(t, (10 * time.Minute).Seconds(), recorded[0].DurationSeconds, 5)
+		assert.Equal(t, model.ConnectRuntimeAgent, recorded[1].Stage)
+		assert.InDelta(t, 0, recorded[1].DurationSeconds, 5)
+	})
+
+	t.Run("should report span of each stage with the operation ID", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
+		dbSession.On("TransitionOperation", operationId, mock.AnythingOfType("int"), mock.AnythingOfType("string"), mock.AnythingOfType("model.OperationStage"), mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("InsertStageDuration", mock.AnythingOfType("model.StageDuration")).Return(nil)
+
+		installationStages := map[model.OperationStage]Step{
+			model.WaitingForInstallation: NewMockStep(model.WaitingForInstallation, model.ConnectRuntimeAgent, 0, 20*time.Minute),
+			model.ConnectRuntimeAgent:    NewErrorStep(model.ConnectRuntimeAgent, fmt.Errorf("error"), 20*time.Minute),
+		}
+
+		recorder := tracetest.NewSpanRecorder()
+
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+		executor.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracing.TracerName)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.True(t, result.Requeue)
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, string(model.WaitingForInstallation), spans[0].Name())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, string(model.ConnectRuntimeAgent), spans[1].Name())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+		for _, span := range spans {
+			assert.Contains(t, span.Attributes(), tracing.OperationIDKey.String(operationId))
+			assert.Contains(t, span.Attributes(), tracing.RuntimeIDKey.String(clusterId))
+		}
+	})
+
 	t.Run("should requeue operation if error occurred", func(t *testing.T) {
 		// given
 		dbSession := &mocks.ReadWriteSession{}
@@ -82,7 +164,7 @@ func TestStagesExecutor_Execute(t *testing.T) {
 
 		directorClient := &directorMocks.DirectorClient{}
 
-		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), directorClient)
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), directorClient, nil)
 
 		// when
 		result := executor.Execute(operationId)
@@ -97,8 +179,9 @@ func TestStagesExecutor_Execute(t *testing.T) {
 		dbSession := &mocks.ReadWriteSession{}
 		dbSession.On("GetOperation", operationId).Return(operation, nil)
 		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
-		dbSession.On("UpdateOperationState", operationId, "error", model.Failed, mock.AnythingOfType("time.Time")).
+		dbSession.On("UpdateOperationState", operationId, 0, "error", model.Failed, mock.AnythingOfType("time.Time")).
 			Return(nil)
+		dbSession.On("UpdateOperationFailureReason", operationId, mock.AnythingOfType("string")).Return(nil)
 
 		mockStage := NewErrorStep(model.WaitingForClusterCreation, NewNonRecoverableError(fmt.Errorf("error")), 10*time.Second)
 
@@ -111,7 +194,7 @@ func TestStagesExecutor_Execute(t *testing.T) {
 
 		failureHandler := MockFailureHandler{}
 
-		executor := NewExecutor(dbSession, model.Provision, installationStages, &failureHandler, directorClient)
+		executor := NewExecutor(dbSession, model.Provision, installationStages, &failureHandler, directorClient, nil)
 
 		// when
 		result := executor.Execute(operationId)
@@ -120,6 +203,7 @@ func TestStagesExecutor_Execute(t *testing.T) {
 		assert.Equal(t, false, result.Requeue)
 		assert.True(t, mockStage.called)
 		assert.True(t, failureHandler.called)
+		dbSession.AssertCalled(t, "UpdateOperationFailureReason", operationId, string(apperrors.ErrReasonInternal))
 	})
 
 	t.Run("should not requeue operation and run failure handler if NonRecoverable error occurred but failed to update Director", func(t *testing.T) {
@@ -127,8 +211,9 @@ func TestStagesExecutor_Execute(t *testing.T) {
 		dbSession := &mocks.ReadWriteSession{}
 		dbSession.On("GetOperation", operationId).Return(operation, nil)
 		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
-		dbSession.On("UpdateOperationState", operationId, "error", model.Failed, mock.AnythingOfType("time.Time")).
+		dbSession.On("UpdateOperationState", operationId, 0, "error", model.Failed, mock.AnythingOfType("time.Time")).
 			Return(nil)
+		dbSession.On("UpdateOperationFailureReason", operationId, mock.AnythingOfType("string")).Return(nil)
 
 		mockStage := NewErrorStep(model.WaitingForClusterCreation, NewNonRecoverableError(fmt.Errorf("error")), 10*time.Second)
 
@@ -141,7 +226,7 @@ func TestStagesExecutor_Execute(t *testing.T) {
 
 		failureHandler := MockFailureHandler{}
 
-		executor := NewExecutor(dbSession, model.Provision, installationStages, &failureHandler, directorClient)
+		executor := NewExecutor(dbSession, model.Provision, installationStages, &failureHandler, directorClient, nil)
 
 		// when
 		result := executor.Execute(operationId)
@@ -157,10 +242,11 @@ func TestStagesExecutor_Execute(t *testing.T) {
 		dbSession := &mocks.ReadWriteSession{}
 		dbSession.On("GetOperation", operationId).Return(operation, nil)
 		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
-		dbSession.On("TransitionOperation", operationId, "Operation in progress", model.ConnectRuntimeAgent, mock.AnythingOfType("time.Time")).
+		dbSession.On("TransitionOperation", operationId, 0, "Operation in progress", model.ConnectRuntimeAgent, mock.AnythingOfType("time.Time")).
 			Return(nil)
-		dbSession.On("UpdateOperationState", operationId, "error: timeout while processing operation", model.Failed, mock.AnythingOfType("time.Time")).
+		dbSession.On("UpdateOperationState", operationId, 0, "error: timeout while processing operation", model.Failed, mock.AnythingOfType("time.Time")).
 			Return(nil)
+		dbSession.On("UpdateOperationFailureReason", operationId, mock.AnythingOfType("string")).Return(nil)
 
 		mockStage := NewMockStep(model.WaitingForInstallation, model.ConnectRuntimeAgent, 0, 0*time.Second)
 
@@ -173,7 +259,7 @@ func TestStagesExecutor_Execute(t *testing.T) {
 
 		failureHandler := MockFailureHandler{}
 
-		executor := NewExecutor(dbSession, model.Provision, installationStages, &failureHandler, directorClient)
+		executor := NewExecutor(dbSession, model.Provision, installationStages, &failureHandler, directorClient, nil)
 
 		// when
 		result := executor.Execute(operationId)
@@ -183,6 +269,189 @@ func TestStagesExecutor_Execute(t *testing.T) {
 		assert.False(t, mockStage.called)
 		assert.True(t, failureHandler.called)
 	})
+
+	t.Run("should apply time limit of the operation when step supports it", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
+		dbSession.On("TransitionOperation", operationId, 0, "Provisioning steps finished", model.FinishedStage, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("UpdateOperationState", operationId, 1, "Operation succeeded", model.Succeeded, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("InsertStageDuration", mock.AnythingOfType("model.StageDuration")).Return(nil)
+
+		mockStage := &mockOperationTimeLimitStep{
+			mockStep:           NewMockStep(model.WaitingForInstallation, model.FinishedStage, 0, 0*time.Second),
+			operationTimeLimit: 10 * time.Minute,
+		}
+
+		installationStages := map[model.OperationStage]Step{
+			model.WaitingForInstallation: mockStage,
+		}
+
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.False(t, result.Requeue)
+		assert.True(t, mockStage.called)
+		dbSession.AssertExpectations(t)
+	})
+
+	t.Run("should drop operation modified concurrently when it is no longer in progress", func(t *testing.T) {
+		// given
+		failedOperation := operation
+		failedOperation.State = model.Failed
+		failedOperation.Version = 1
+
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil).Once()
+		dbSession.On("GetOperation", operationId).Return(failedOperation, nil).Once()
+		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
+		dbSession.On("TransitionOperation", operationId, 0, "Operation in progress. Stage ConnectRuntimeAgent", model.ConnectRuntimeAgent, mock.AnythingOfType("time.Time")).
+			Return(dberrors.Conflict("error"))
+
+		installationStages := map[model.OperationStage]Step{
+			model.WaitingForInstallation: NewMockStep(model.WaitingForInstallation, model.ConnectRuntimeAgent, 0, 10*time.Minute),
+		}
+
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.False(t, result.Requeue)
+		dbSession.AssertNumberOfCalls(t, "TransitionOperation", 1)
+		dbSession.AssertNotCalled(t, "InsertStageDuration", mock.Anything)
+		dbSession.AssertExpectations(t)
+	})
+
+	t.Run("should requeue operation modified concurrently when it is still in progress", func(t *testing.T) {
+		// given
+		modifiedOperation := operation
+		modifiedOperation.Version = 1
+
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil).Once()
+		dbSession.On("GetOperation", operationId).Return(modifiedOperation, nil).Once()
+		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
+		dbSession.On("TransitionOperation", operationId, 0, "Provisioning steps finished", model.FinishedStage, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("InsertStageDuration", mock.AnythingOfType("model.StageDuration")).Return(nil)
+		dbSession.On("UpdateOperationState", operationId, 1, "Operation succeeded", model.Succeeded, mock.AnythingOfType("time.Time")).
+			Return(dberrors.Conflict("error"))
+
+		installationStages := map[model.OperationStage]Step{
+			model.WaitingForInstallation: NewMockStep(model.WaitingForInstallation, model.FinishedStage, 0, 10*time.Minute),
+		}
+
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.True(t, result.Requeue)
+		assert.Equal(t, time.Duration(0), result.Delay)
+		dbSession.AssertNumberOfCalls(t, "UpdateOperationState", 1)
+		dbSession.AssertExpectations(t)
+	})
+
+	t.Run("should requeue operation modified concurrently when failed to get it again", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil).Once()
+		dbSession.On("GetOperation", operationId).Return(model.Operation{}, dberrors.Connection("error")).Once()
+		dbSession.On("GetCluster", clusterId).Return(cluster, nil)
+		dbSession.On("TransitionOperation", operationId, 0, "Provisioning steps finished", model.FinishedStage, mock.AnythingOfType("time.Time")).
+			Return(dberrors.Conflict("error"))
+
+		installationStages := map[model.OperationStage]Step{
+			model.WaitingForInstallation: NewMockStep(model.WaitingForInstallation, model.FinishedStage, 0, 10*time.Minute),
+		}
+
+		executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.True(t, result.Requeue)
+		assert.Equal(t, defaultDelay, result.Delay)
+		dbSession.AssertExpectations(t)
+	})
+
+	t.Run("should requeue operation when failed to get it due to retryable error", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(model.Operation{}, dberrors.TransactionRollback("error"))
+
+		executor := NewExecutor(dbSession, model.Provision, map[model.OperationStage]Step{}, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.True(t, result.Requeue)
+		assert.Equal(t, defaultDelay, result.Delay)
+	})
+
+	t.Run("should not requeue operation when failed to get it due to permanent error", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(model.Operation{}, dberrors.NotFound("error"))
+
+		executor := NewExecutor(dbSession, model.Provision, map[model.OperationStage]Step{}, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.False(t, result.Requeue)
+		dbSession.AssertNotCalled(t, "GetCluster", mock.Anything)
+	})
+
+	t.Run("should retry setting operation status only when error is retryable", func(t *testing.T) {
+		for _, testCase := range []struct {
+			description   string
+			err           dberrors.Error
+			expectedCalls int
+		}{
+			{description: "connection error", err: dberrors.Connection("error"), expectedCalls: 5},
+			{description: "serialization failure", err: dberrors.TransactionRollback("error"), expectedCalls: 5},
+			{description: "internal error", err: dberrors.Internal("error"), expectedCalls: 1},
+			{description: "constraint violation", err: dberrors.ConstraintViolation("error"), expectedCalls: 1},
+		} {
+			t.Run(testCase.description, func(t *testing.T) {
+				// given
+				dbSession := &mocks.ReadWriteSession{}
+				dbSession.On("GetOperation", operationId).Return(operation, nil)
+				dbSession.On("GetCluster", clusterId).Return(cluster, nil)
+				dbSession.On("TransitionOperation", operationId, 0, "Provisioning steps finished", model.FinishedStage, mock.AnythingOfType("time.Time")).
+					Return(nil)
+				dbSession.On("InsertStageDuration", mock.AnythingOfType("model.StageDuration")).Return(nil)
+				dbSession.On("UpdateOperationState", operationId, 1, "Operation succeeded", model.Succeeded, mock.AnythingOfType("time.Time")).
+					Return(testCase.err)
+
+				installationStages := map[model.OperationStage]Step{
+					model.WaitingForInstallation: NewMockStep(model.WaitingForInstallation, model.FinishedStage, 0, 10*time.Minute),
+				}
+
+				executor := NewExecutor(dbSession, model.Provision, installationStages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+				// when
+				result := executor.Execute(operationId)
+
+				// then
+				assert.False(t, result.Requeue)
+				dbSession.AssertNumberOfCalls(t, "UpdateOperationState", testCase.expectedCalls)
+			})
+		}
+	})
 }
 
 type mockStep struct {
@@ -234,6 +503,15 @@ func (m mockStep) TimeLimit() time.Duration {
 	return m.timeLimit
 }
 
+type mockOperationTimeLimitStep struct {
+	*mockStep
+	operationTimeLimit time.Duration
+}
+
+func (m mockOperationTimeLimitStep) OperationTimeLimit(_ model.Operation) time.Duration {
+	return m.operationTimeLimit
+}
+
 type MockFailureHandler struct {
 	called bool
 }
@@ -0,0 +1,62 @@
+package operations
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockery -name=KubeconfigFetcher
+type KubeconfigFetcher interface {
+	FetchRaw(project, shootName string) ([]byte, error)
+}
+
+// KubeconfigRenewer refreshes the kubeconfig stored on the cluster record before the stages access the cluster.
+// The fetcher is expected to return the same kubeconfig until it is due for renewal, so the record is only updated when it changes.
+type KubeconfigRenewer struct {
+	fetcher   KubeconfigFetcher
+	dbSession dbsession.WriteSession
+}
+
+func NewKubeconfigRenewer(fetcher KubeconfigFetcher, dbSession dbsession.WriteSession) *KubeconfigRenewer {
+	return &KubeconfigRenewer{
+		fetcher:   fetcher,
+		dbSession: dbSession,
+	}
+}
+
+// Renew updates the kubeconfig of the cluster in place, clusters without the kubeconfig are not created yet and are skipped
+func (r *KubeconfigRenewer) Renew(cluster *model.Cluster, log logrus.FieldLogger) error {
+	if r == nil || cluster.Kubeconfig == nil {
+		return nil
+	}
+
+	kubeconfig, err := r.fetcher.FetchRaw(cluster.ClusterConfig.ProjectName, cluster.ClusterConfig.Name)
+	if err != nil {
+		return fmt.Errorf("error fetching kubeconfig: %s", err.Error())
+	}
+	if string(kubeconfig) == *cluster.Kubeconfig {
+		return nil
+	}
+
+	apiServer, err := k8s.ParseAPIServer(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error reading API server from kubeconfig: %s", err.Error())
+	}
+
+	dberr := r.dbSession.UpdateKubeconfig(cluster.ID, string(kubeconfig), apiServer)
+	if dberr != nil {
+		return fmt.Errorf("error storing renewed kubeconfig: %s", dberr.Error())
+	}
+
+	renewed := string(kubeconfig)
+	cluster.Kubeconfig = &renewed
+	cluster.APIServerURL = &apiServer.URL
+	cluster.CACertificate = &apiServer.CACertificate
+	log.Infof("Kubeconfig of the cluster renewed")
+
+	return nil
+}
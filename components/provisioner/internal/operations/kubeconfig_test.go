@@ -0,0 +1,185 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	directorMocks "github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/failure"
+	operationsMocks "github.com/kyma-project/control-plane/components/provisioner/internal/operations/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	kubeconfigProject = "project"
+	kubeconfigShoot   = "shoot"
+)
+
+func TestKubeconfigRenewer_Renew(t *testing.T) {
+	storedKubeconfig := fixKubeconfig("stored")
+	renewedKubeconfig := fixKubeconfig("renewed")
+
+	t.Run("should store renewed kubeconfig", func(t *testing.T) {
+		// given
+		fetcher := &operationsMocks.KubeconfigFetcher{}
+		fetcher.On("FetchRaw", kubeconfigProject, kubeconfigShoot).Return([]byte(renewedKubeconfig), nil)
+		writeSession := &mocks.WriteSession{}
+		writeSession.On("UpdateKubeconfig", clusterId, renewedKubeconfig, model.APIServer{URL: "https://api.renewed.example.com"}).Return(nil)
+
+		cluster := fixKubeconfigCluster(storedKubeconfig)
+
+		// when
+		err := NewKubeconfigRenewer(fetcher, writeSession).Renew(&cluster, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, renewedKubeconfig, *cluster.Kubeconfig)
+		assert.Equal(t, "https://api.renewed.example.com", *cluster.APIServerURL)
+		writeSession.AssertExpectations(t)
+	})
+
+	t.Run("should not update cluster when kubeconfig did not change", func(t *testing.T) {
+		// given
+		fetcher := &operationsMocks.KubeconfigFetcher{}
+		fetcher.On("FetchRaw", kubeconfigProject, kubeconfigShoot).Return([]byte(storedKubeconfig), nil)
+		writeSession := &mocks.WriteSession{}
+
+		cluster := fixKubeconfigCluster(storedKubeconfig)
+
+		// when
+		err := NewKubeconfigRenewer(fetcher, writeSession).Renew(&cluster, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		writeSession.AssertNotCalled(t, "UpdateKubeconfig", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should skip cluster without kubeconfig", func(t *testing.T) {
+		// given
+		fetcher := &operationsMocks.KubeconfigFetcher{}
+		cluster := model.Cluster{ID: clusterId}
+
+		// when
+		err := NewKubeconfigRenewer(fetcher, &mocks.WriteSession{}).Renew(&cluster, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		fetcher.AssertNotCalled(t, "FetchRaw", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should keep stored kubeconfig when it cannot be renewed", func(t *testing.T) {
+		// given
+		fetcher := &operationsMocks.KubeconfigFetcher{}
+		fetcher.On("FetchRaw", kubeconfigProject, kubeconfigShoot).Return(nil, errors.New("connection refused"))
+
+		cluster := fixKubeconfigCluster(storedKubeconfig)
+
+		// when
+		err := NewKubeconfigRenewer(fetcher, &mocks.WriteSession{}).Renew(&cluster, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, storedKubeconfig, *cluster.Kubeconfig)
+	})
+}
+
+func TestStagesExecutor_Execute_RenewsExpiringKubeconfig(t *testing.T) {
+	// given
+	tNow := time.Now()
+	storedKubeconfig := fixKubeconfig("stored")
+	renewedKubeconfig := fixKubeconfig("renewed")
+
+	operation := model.Operation{
+		ID:             operationId,
+		Type:           model.Upgrade,
+		StartTimestamp: tNow,
+		State:          model.InProgress,
+		ClusterID:      clusterId,
+		Stage:          model.WaitingForInstallation,
+		LastTransition: &tNow,
+	}
+
+	dbSession := &mocks.ReadWriteSession{}
+	dbSession.On("GetOperation", operationId).Return(operation, nil)
+	dbSession.On("GetCluster", clusterId).Return(fixKubeconfigCluster(storedKubeconfig), nil).Once()
+
+	// the fetcher returns the cached kubeconfig until it is due for renewal, the new one is issued while the stage still waits
+	fetcher := &operationsMocks.KubeconfigFetcher{}
+	fetcher.On("FetchRaw", kubeconfigProject, kubeconfigShoot).Return([]byte(storedKubeconfig), nil).Once()
+	fetcher.On("FetchRaw", kubeconfigProject, kubeconfigShoot).Return([]byte(renewedKubeconfig), nil).Once()
+	writeSession := &mocks.WriteSession{}
+	writeSession.On("UpdateKubeconfig", clusterId, renewedKubeconfig, model.APIServer{URL: "https://api.renewed.example.com"}).Return(nil)
+
+	step := &kubeconfigRecordingStep{mockStep: NewMockStep(model.WaitingForInstallation, model.WaitingForInstallation, time.Minute, time.Hour)}
+
+	executor := NewExecutor(dbSession, model.Upgrade, map[model.OperationStage]Step{step.Name(): step}, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, NewKubeconfigRenewer(fetcher, writeSession))
+
+	// when
+	result := executor.Execute(operationId)
+
+	// then
+	assert.True(t, result.Requeue)
+	assert.Equal(t, []string{storedKubeconfig}, step.kubeconfigs)
+	writeSession.AssertNotCalled(t, "UpdateKubeconfig", mock.Anything, mock.Anything, mock.Anything)
+
+	// given
+	dbSession.On("GetCluster", clusterId).Return(fixKubeconfigCluster(storedKubeconfig), nil).Once()
+
+	// when
+	result = executor.Execute(operationId)
+
+	// then
+	assert.True(t, result.Requeue)
+	assert.Equal(t, []string{storedKubeconfig, renewedKubeconfig}, step.kubeconfigs)
+	writeSession.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+}
+
+type kubeconfigRecordingStep struct {
+	*mockStep
+	kubeconfigs []string
+}
+
+func (s *kubeconfigRecordingStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (StageResult, error) {
+	s.kubeconfigs = append(s.kubeconfigs, *cluster.Kubeconfig)
+	return s.mockStep.Run(cluster, operation, logger)
+}
+
+func fixKubeconfigCluster(kubeconfig string) model.Cluster {
+	return model.Cluster{
+		ID:         clusterId,
+		Kubeconfig: util.StringPtr(kubeconfig),
+		ClusterConfig: model.GardenerConfig{
+			Name:        kubeconfigShoot,
+			ProjectName: kubeconfigProject,
+		},
+	}
+}
+
+func fixKubeconfig(server string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://api.%s.example.com
+  name: shoot
+contexts:
+- context:
+    cluster: shoot
+    user: admin
+  name: shoot
+current-context: shoot
+users:
+- name: admin
+  user:
+    token: token
+`, server)
+}
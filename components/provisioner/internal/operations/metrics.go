@@ -0,0 +1,190 @@
+package operations
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	failedOperationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "operations_failed_total",
+		Help:      "The number of operations which failed with the error reason and the component which caused the failure",
+	}, []string{"type", "reason", "component"})
+	retryingOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "operations_retrying",
+		Help:      "The number of operations in progress whose last processing failed with a recoverable error",
+	}, []string{"type", "reason", "component"})
+	stageTimeoutsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "stage_timeouts_total",
+		Help:      "The number of operations which failed because the time limit of the stage was exceeded",
+	}, []string{"operation_type", "stage"})
+	stageRetries = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "stage_retries",
+		Help:      "The number of retries the stage needed before it succeeded or failed permanently",
+		Buckets:   []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500},
+	}, []string{"operation_type", "stage"})
+	stageRetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "stage_retries_total",
+		Help:      "The number of stage retries with the reason of the error, or pending if the stage was requeued to wait for the cluster",
+	}, []string{"operation_type", "stage", "reason"})
+)
+
+// retryReasonPending labels the retries of stages which did not fail but were requeued to check the cluster again
+const retryReasonPending = "pending"
+
+// Collectors returns metrics of failed operations to be registered in Prometheus
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{failedOperationsTotal, retryingOperations, stageTimeoutsTotal, stageRetries, stageRetriesTotal}
+}
+
+// failureRecorder counts failures of operations processed by the executor, an operation failing with a recoverable error
+// is counted as retrying with the classification of its last error until it is processed successfully or fails
+type failureRecorder struct {
+	operationType model.OperationType
+
+	mutex    sync.Mutex
+	retrying map[string]prometheus.Labels
+}
+
+func newFailureRecorder(operationType model.OperationType) *failureRecorder {
+	return &failureRecorder{
+		operationType: operationType,
+		retrying:      map[string]prometheus.Labels{},
+	}
+}
+
+func (r *failureRecorder) recordRetrying(operationID string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.clearRetrying(operationID)
+	labels := r.labels(err)
+	r.retrying[operationID] = labels
+	retryingOperations.With(labels).Inc()
+}
+
+func (r *failureRecorder) recordFailed(operationID string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.clearRetrying(operationID)
+	failedOperationsTotal.With(r.labels(err)).Inc()
+}
+
+func (r *failureRecorder) recordProcessed(operationID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.clearRetrying(operationID)
+}
+
+func (r *failureRecorder) clearRetrying(operationID string) {
+	labels, found := r.retrying[operationID]
+	if !found {
+		return
+	}
+	delete(r.retrying, operationID)
+	retryingOperations.With(labels).Dec()
+}
+
+func (r *failureRecorder) labels(err error) prometheus.Labels {
+	reason, component := classify(err)
+
+	return prometheus.Labels{
+		"type":      string(r.operationType),
+		"reason":    string(reason),
+		"component": string(component),
+	}
+}
+
+func classify(err error) (apperrors.ErrReason, apperrors.ErrComponent) {
+	reason, component := apperrors.Classify(err)
+
+	var timeoutErr StageTimeoutError
+	if errors.As(err, &timeoutErr) {
+		reason = timeoutErr.Reason()
+	}
+
+	return reason, component
+}
+
+// retryRecorder counts the retries of the current stage of each operation, that is the executions which neither completed the stage
+// nor failed the operation. The number of retries is observed when the stage completes or the operation fails.
+type retryRecorder struct {
+	operationType model.OperationType
+
+	mutex   sync.Mutex
+	retries map[string]stageRetryCount
+}
+
+type stageRetryCount struct {
+	stage model.OperationStage
+	count int
+}
+
+func newRetryRecorder(operationType model.OperationType) *retryRecorder {
+	return &retryRecorder{
+		operationType: operationType,
+		retries:       map[string]stageRetryCount{},
+	}
+}
+
+// count returns the number of retries of the stage, the retries of the previous stage are not taken into account
+func (r *retryRecorder) count(operationID string, stage model.OperationStage) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	retries, found := r.retries[operationID]
+	if !found || retries.stage != stage {
+		return 0
+	}
+
+	return retries.count
+}
+
+// recordRetry counts the retry of the stage with the reason of the error, the stage requeued without the error is retried as pending
+func (r *retryRecorder) recordRetry(operationID string, stage model.OperationStage, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	retries := r.retries[operationID]
+	if retries.stage != stage {
+		retries = stageRetryCount{stage: stage}
+	}
+	retries.count++
+	r.retries[operationID] = retries
+
+	reason := retryReasonPending
+	if err != nil {
+		errReason, _ := classify(err)
+		reason = string(errReason)
+	}
+	stageRetriesTotal.WithLabelValues(string(r.operationType), string(stage), reason).Inc()
+}
+
+// recordFinished observes the number of retries of the stage which completed or failed permanently
+func (r *retryRecorder) recordFinished(operationID string, stage model.OperationStage, retries int) {
+	stageRetries.WithLabelValues(string(r.operationType), string(stage)).Observe(float64(retries))
+	r.clear(operationID)
+}
+
+func (r *retryRecorder) clear(operationID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.retries, operationID)
+}
@@ -0,0 +1,287 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	directorMocks "github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/failure"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_FailureMetrics(t *testing.T) {
+	tNow := time.Now()
+
+	operation := model.Operation{
+		ID:             operationId,
+		Type:           model.Hibernate,
+		StartTimestamp: tNow,
+		State:          model.InProgress,
+		ClusterID:      clusterId,
+		Stage:          model.WaitForHibernation,
+		LastTransition: &tNow,
+	}
+
+	t.Run("should count operation as retrying until it fails", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(model.Cluster{ID: clusterId}, nil)
+		dbSession.On("UpdateOperationState", operationId, 0, mock.AnythingOfType("string"), model.Failed, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("UpdateOperationFailureReason", operationId, mock.AnythingOfType("string")).Return(nil)
+
+		directorClient := &directorMocks.DirectorClient{}
+		directorClient.On("SetRuntimeStatusCondition", clusterId, graphql.RuntimeStatusConditionFailed, mock.AnythingOfType("string")).Return(nil)
+
+		stages := map[model.OperationStage]Step{
+			model.WaitForHibernation: NewErrorStep(model.WaitForHibernation, apperrors.BadGateway("error").SetComponent(apperrors.ErrComponentDirector), 10*time.Second),
+		}
+		executor := NewExecutor(dbSession, model.Hibernate, stages, failure.NewNoopFailureHandler(), directorClient, nil)
+
+		retrying := retryingOperations.WithLabelValues(string(model.Hibernate), "bad_gateway", "director")
+		failed := failedOperationsTotal.WithLabelValues(string(model.Hibernate), "quota_exceeded", "gardener")
+		initialFailed := testutil.ToFloat64(failed)
+
+		// when
+		executor.Execute(operationId)
+		executor.Execute(operationId)
+
+		// then
+		assert.Equal(t, float64(1), testutil.ToFloat64(retrying))
+
+		// given
+		lastErrors := []gardencorev1beta1.LastError{{Codes: []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraQuotaExceeded}}}
+		stages[model.WaitForHibernation] = NewErrorStep(model.WaitForHibernation, NewShootFailedError(lastErrors, "hibernation failed"), 10*time.Second)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		assert.Equal(t, float64(0), testutil.ToFloat64(retrying))
+		assert.Equal(t, initialFailed+1, testutil.ToFloat64(failed))
+		dbSession.AssertCalled(t, "UpdateOperationFailureReason", operationId, string(apperrors.ErrReasonQuotaExceeded))
+	})
+
+	t.Run("should classify unknown error as internal", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(model.Cluster{ID: clusterId}, nil)
+
+		stages := map[model.OperationStage]Step{
+			model.WaitForHibernation: NewErrorStep(model.WaitForHibernation, fmt.Errorf("error"), 10*time.Second),
+		}
+		executor := NewExecutor(dbSession, model.Hibernate, stages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		retrying := retryingOperations.WithLabelValues(string(model.Hibernate), "internal", "unknown")
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		assert.Equal(t, float64(1), testutil.ToFloat64(retrying))
+
+		// given
+		stages[model.WaitForHibernation] = NewMockStep(model.WaitForHibernation, model.WaitForHibernation, 10*time.Second, 10*time.Second)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		assert.Equal(t, float64(0), testutil.ToFloat64(retrying))
+	})
+}
+
+func TestExecutor_StageRetryMetrics(t *testing.T) {
+	tNow := time.Now()
+
+	operation := model.Operation{
+		ID:             operationId,
+		Type:           model.WakeUp,
+		StartTimestamp: tNow,
+		State:          model.InProgress,
+		ClusterID:      clusterId,
+		Stage:          model.WaitForWakeUp,
+		LastTransition: &tNow,
+	}
+
+	t.Run("should observe retries of stage when it completes", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(model.Cluster{ID: clusterId}, nil)
+		dbSession.On("TransitionOperation", operationId, 0, "Provisioning steps finished", model.FinishedStage, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("InsertStageDuration", mock.AnythingOfType("model.StageDuration")).Return(nil)
+		dbSession.On("UpdateOperationState", operationId, 1, "Operation succeeded", model.Succeeded, mock.AnythingOfType("time.Time")).
+			Return(nil)
+
+		stages := map[model.OperationStage]Step{
+			model.WaitForWakeUp: NewMockStep(model.WaitForWakeUp, model.WaitForWakeUp, 10*time.Second, 10*time.Second),
+		}
+		executor := NewExecutor(dbSession, model.WakeUp, stages, failure.NewNoopFailureHandler(), &directorMocks.DirectorClient{}, nil)
+
+		pending := stageRetriesTotal.WithLabelValues(string(model.WakeUp), string(model.WaitForWakeUp), retryReasonPending)
+		badGateway := stageRetriesTotal.WithLabelValues(string(model.WakeUp), string(model.WaitForWakeUp), "bad_gateway")
+		initialPending := testutil.ToFloat64(pending)
+		initialBadGateway := testutil.ToFloat64(badGateway)
+		initialCount, initialSum := histogramValues(t, stageRetries.WithLabelValues(string(model.WakeUp), string(model.WaitForWakeUp)))
+
+		// when
+		executor.Execute(operationId)
+		executor.Execute(operationId)
+
+		// then
+		assert.Equal(t, initialPending+2, testutil.ToFloat64(pending))
+
+		// given
+		stages[model.WaitForWakeUp] = NewErrorStep(model.WaitForWakeUp, apperrors.BadGateway("error").SetComponent(apperrors.ErrComponentGardener), 10*time.Second)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		assert.Equal(t, initialBadGateway+1, testutil.ToFloat64(badGateway))
+
+		// given
+		stages[model.WaitForWakeUp] = NewMockStep(model.WaitForWakeUp, model.FinishedStage, 0, 10*time.Second)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		count, sum := histogramValues(t, stageRetries.WithLabelValues(string(model.WakeUp), string(model.WaitForWakeUp)))
+		assert.Equal(t, initialCount+1, count)
+		assert.Equal(t, initialSum+3, sum)
+		assert.Zero(t, executor.retries.count(operationId, model.WaitForWakeUp))
+	})
+
+	t.Run("should observe retries of stage when operation fails", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(model.Cluster{ID: clusterId}, nil)
+		dbSession.On("UpdateOperationState", operationId, 0, mock.AnythingOfType("string"), model.Failed, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("UpdateOperationFailureReason", operationId, mock.AnythingOfType("string")).Return(nil)
+
+		directorClient := &directorMocks.DirectorClient{}
+		directorClient.On("SetRuntimeStatusCondition", clusterId, graphql.RuntimeStatusConditionFailed, mock.AnythingOfType("string")).Return(nil)
+
+		stages := map[model.OperationStage]Step{
+			model.WaitForWakeUp: NewErrorStep(model.WaitForWakeUp, fmt.Errorf("error"), 10*time.Second),
+		}
+		executor := NewExecutor(dbSession, model.WakeUp, stages, failure.NewNoopFailureHandler(), directorClient, nil)
+
+		initialCount, initialSum := histogramValues(t, stageRetries.WithLabelValues(string(model.WakeUp), string(model.WaitForWakeUp)))
+
+		// when
+		executor.Execute(operationId)
+
+		// given
+		stages[model.WaitForWakeUp] = NewErrorStep(model.WaitForWakeUp, NewNonRecoverableError(fmt.Errorf("error")), 10*time.Second)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		count, sum := histogramValues(t, stageRetries.WithLabelValues(string(model.WakeUp), string(model.WaitForWakeUp)))
+		assert.Equal(t, initialCount+1, count)
+		assert.Equal(t, initialSum+1, sum)
+	})
+}
+
+func histogramValues(t *testing.T, observer prometheus.Observer) (uint64, float64) {
+	metric := dto.Metric{}
+	err := observer.(prometheus.Histogram).Write(&metric)
+	require.NoError(t, err)
+	require.NotNil(t, metric.Histogram)
+
+	return metric.Histogram.GetSampleCount(), metric.Histogram.GetSampleSum()
+}
+
+func TestNewShootFailedError(t *testing.T) {
+	for _, testCase := range []struct {
+		code            gardencorev1beta1.ErrorCode
+		expectedReason  apperrors.ErrReason
+		expectedMessage string
+		nonRecoverable  bool
+	}{
+		{
+			code:            gardencorev1beta1.ErrorInfraUnauthorized,
+			expectedReason:  apperrors.ErrReasonInvalidCredentials,
+			expectedMessage: "shoot name failed. The hyperscaler account credentials are invalid",
+			nonRecoverable:  true,
+		},
+		{
+			code:            gardencorev1beta1.ErrorInfraQuotaExceeded,
+			expectedReason:  apperrors.ErrReasonQuotaExceeded,
+			expectedMessage: "shoot name failed. The hyperscaler account quota is exceeded",
+			nonRecoverable:  true,
+		},
+		{
+			code:            gardencorev1beta1.ErrorInfraDependencies,
+			expectedReason:  apperrors.ErrReasonInfraDependencies,
+			expectedMessage: "shoot name failed. The infrastructure required by the cluster",
+			nonRecoverable:  true,
+		},
+		{
+			code:            gardencorev1beta1.ErrorInfraRateLimitsExceeded,
+			expectedReason:  apperrors.ErrReasonRateLimitsExceeded,
+			expectedMessage: "shoot name failed. The hyperscaler account API rate limits are exceeded",
+			nonRecoverable:  false,
+		},
+		{
+			code:            gardencorev1beta1.ErrorConfigurationProblem,
+			expectedReason:  apperrors.ErrReasonBadRequest,
+			expectedMessage: "shoot name failed",
+			nonRecoverable:  true,
+		},
+		{
+			code:            gardencorev1beta1.ErrorRetryableInfraDependencies,
+			expectedReason:  apperrors.ErrReasonInternal,
+			expectedMessage: "shoot name failed",
+			nonRecoverable:  true,
+		},
+	} {
+		t.Run("should classify "+string(testCase.code), func(t *testing.T) {
+			// when
+			err := NewShootFailedError([]gardencorev1beta1.LastError{{Codes: []gardencorev1beta1.ErrorCode{testCase.code}}}, "shoot %s failed", "name")
+
+			// then
+			reason, component := apperrors.Classify(err)
+			assert.Equal(t, testCase.expectedReason, reason)
+			assert.Equal(t, apperrors.ErrComponentGardener, component)
+			assert.Contains(t, err.Error(), testCase.expectedMessage)
+			assert.Equal(t, testCase.nonRecoverable, errors.As(err, &NonRecoverableError{}))
+		})
+	}
+
+	t.Run("should report invalid credentials before exceeded quota", func(t *testing.T) {
+		// given
+		lastErrors := []gardencorev1beta1.LastError{
+			{Codes: []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraQuotaExceeded}},
+			{Codes: []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraInsufficientPrivileges}},
+		}
+
+		// when
+		err := NewShootFailedError(lastErrors, "shoot failed")
+
+		// then
+		reason, _ := apperrors.Classify(err)
+		assert.Equal(t, apperrors.ErrReasonInvalidCredentials, reason)
+	})
+}
@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
 
 // OperationQueue is an autogenerated mock type for the OperationQueue type
 type OperationQueue struct {
@@ -14,7 +18,45 @@ func (_m *OperationQueue) Add(processId string) {
 	_m.Called(processId)
 }
 
+// AddAfter provides a mock function with given fields: processId, delay
+func (_m *OperationQueue) AddAfter(processId string, delay time.Duration) {
+	_m.Called(processId, delay)
+}
+
+// IsPaused provides a mock function with given fields:
+func (_m *OperationQueue) IsPaused() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Len provides a mock function with given fields:
+func (_m *OperationQueue) Len() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // Run provides a mock function with given fields: stop
 func (_m *OperationQueue) Run(stop <-chan struct{}) {
 	_m.Called(stop)
 }
+
+// SetPaused provides a mock function with given fields: paused
+func (_m *OperationQueue) SetPaused(paused bool) {
+	_m.Called(paused)
+}
@@ -0,0 +1,120 @@
+package operations
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/sirupsen/logrus"
+)
+
+// ProgressEstimator computes progress of the operation from the ordered stages of its type.
+// Completion time is estimated from the rolling average of recorded stage durations,
+// stages with not enough samples are estimated with their time limit.
+type ProgressEstimator struct {
+	session    dbsession.ReadSession
+	sampleSize int
+	minSamples int
+
+	mutex     sync.RWMutex
+	sequences map[model.OperationType][]Step
+
+	log logrus.FieldLogger
+}
+
+func NewProgressEstimator(session dbsession.ReadSession, sampleSize, minSamples int) *ProgressEstimator {
+	return &ProgressEstimator{
+		session:    session,
+		sampleSize: sampleSize,
+		minSamples: minSamples,
+		sequences:  make(map[model.OperationType][]Step),
+		log:        logrus.WithField("Component", "ProgressEstimator"),
+	}
+}
+
+// RegisterStages sets the steps of the operation type in the order they are executed
+func (p *ProgressEstimator) RegisterStages(operationType model.OperationType, steps ...Step) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.sequences[operationType] = steps
+}
+
+// Estimate returns progress of the operation in progress.
+// Nil is returned for finished operations and for operations which stages are not registered.
+func (p *ProgressEstimator) Estimate(operation model.Operation) *model.OperationProgress {
+	if operation.State != model.InProgress {
+		return nil
+	}
+
+	p.mutex.RLock()
+	steps, found := p.sequences[operation.Type]
+	p.mutex.RUnlock()
+	if !found {
+		return nil
+	}
+
+	stagesDone, found := completedStages(steps, operation.Stage)
+	if !found {
+		return nil
+	}
+
+	return &model.OperationProgress{
+		CurrentStage:        operation.Stage,
+		StagesDone:          stagesDone,
+		StagesTotal:         len(steps),
+		EstimatedCompletion: p.estimateCompletion(operation, steps[stagesDone:]),
+	}
+}
+
+func completedStages(steps []Step, stage model.OperationStage) (int, bool) {
+	if stage == model.FinishedStage {
+		return len(steps), true
+	}
+
+	for i, step := range steps {
+		if step.Name() == stage {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// estimateCompletion returns nil when none of the remaining stages has enough recorded durations,
+// as an estimate based only on time limits would be far off
+func (p *ProgressEstimator) estimateCompletion(operation model.Operation, remaining []Step) *time.Time {
+	if len(remaining) == 0 {
+		return nil
+	}
+
+	stats, err := p.session.GetStageDurationStats(operation.Type, p.sampleSize)
+	if err != nil {
+		p.log.Warnf("Cannot get stage duration stats for %s operation: %s", operation.Type, err.Error())
+		return nil
+	}
+
+	var remainingDuration time.Duration
+	estimatedFromHistory := 0
+	for _, step := range remaining {
+		stageStats, found := stats[step.Name()]
+		if found && stageStats.Samples >= p.minSamples {
+			remainingDuration += stageStats.AverageDuration
+			estimatedFromHistory++
+			continue
+		}
+		remainingDuration += stepTimeLimit(step, operation)
+	}
+
+	if estimatedFromHistory == 0 {
+		return nil
+	}
+
+	completion := stageStartTime(operation).Add(remainingDuration)
+	if now := time.Now(); completion.Before(now) {
+		completion = now
+	}
+
+	return &completion
+}
@@ -0,0 +1,164 @@
+package operations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressEstimator_Estimate(t *testing.T) {
+	sampleSize := 20
+	minSamples := 3
+
+	steps := []Step{
+		NewMockStep(model.WaitingForClusterDomain, model.WaitingForClusterCreation, 0, 10*time.Minute),
+		NewMockStep(model.WaitingForClusterCreation, model.StartingInstallation, 0, 60*time.Minute),
+		NewMockStep(model.StartingInstallation, model.WaitingForInstallation, 0, 20*time.Minute),
+		NewMockStep(model.WaitingForInstallation, model.FinishedStage, 0, 60*time.Minute),
+	}
+
+	stageStart := time.Now().Add(-time.Minute)
+	operationInStage := func(stage model.OperationStage) model.Operation {
+		return model.Operation{
+			ID:             operationId,
+			Type:           model.Provision,
+			State:          model.InProgress,
+			Stage:          stage,
+			StartTimestamp: stageStart.Add(-time.Hour),
+			LastTransition: &stageStart,
+		}
+	}
+
+	stats := map[model.OperationStage]model.StageDurationStats{
+		model.WaitingForClusterDomain:   {AverageDuration: 2 * time.Minute, Samples: 10},
+		model.WaitingForClusterCreation: {AverageDuration: 15 * time.Minute, Samples: 10},
+		model.StartingInstallation:      {AverageDuration: 1 * time.Minute, Samples: 1},
+		model.WaitingForInstallation:    {AverageDuration: 30 * time.Minute, Samples: 10},
+	}
+
+	t.Run("should increase progress monotonically across stage transitions", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetStageDurationStats", model.Provision, sampleSize).Return(stats, nil)
+
+		estimator := NewProgressEstimator(readSession, sampleSize, minSamples)
+		estimator.RegisterStages(model.Provision, steps...)
+
+		previousStagesDone := -1
+		for _, stage := range []model.OperationStage{
+			model.WaitingForClusterDomain,
+			model.WaitingForClusterCreation,
+			model.StartingInstallation,
+			model.WaitingForInstallation,
+			model.FinishedStage,
+		} {
+			// when
+			progress := estimator.Estimate(operationInStage(stage))
+
+			// then
+			require.NotNil(t, progress)
+			assert.Equal(t, stage, progress.CurrentStage)
+			assert.Equal(t, len(steps), progress.StagesTotal)
+			assert.Greater(t, progress.StagesDone, previousStagesDone)
+			previousStagesDone = progress.StagesDone
+		}
+		assert.Equal(t, len(steps), previousStagesDone)
+	})
+
+	t.Run("should estimate completion from average durations and time limits of stages without enough samples", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetStageDurationStats", model.Provision, sampleSize).Return(stats, nil)
+
+		estimator := NewProgressEstimator(readSession, sampleSize, minSamples)
+		estimator.RegisterStages(model.Provision, steps...)
+
+		// when
+		progress := estimator.Estimate(operationInStage(model.WaitingForClusterCreation))
+
+		// then
+		require.NotNil(t, progress)
+		assert.Equal(t, 1, progress.StagesDone)
+		require.NotNil(t, progress.EstimatedCompletion)
+		assert.Equal(t, stageStart.Add(15*time.Minute+20*time.Minute+30*time.Minute), *progress.EstimatedCompletion)
+	})
+
+	t.Run("should not estimate completion when history is insufficient", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetStageDurationStats", model.Provision, sampleSize).Return(map[model.OperationStage]model.StageDurationStats{
+			model.WaitingForInstallation: {AverageDuration: 30 * time.Minute, Samples: 2},
+		}, nil)
+
+		estimator := NewProgressEstimator(readSession, sampleSize, minSamples)
+		estimator.RegisterStages(model.Provision, steps...)
+
+		// when
+		progress := estimator.Estimate(operationInStage(model.StartingInstallation))
+
+		// then
+		require.NotNil(t, progress)
+		assert.Equal(t, 2, progress.StagesDone)
+		assert.Nil(t, progress.EstimatedCompletion)
+	})
+
+	t.Run("should not estimate completion when failed to get stage duration stats", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetStageDurationStats", model.Provision, sampleSize).Return(nil, dberrors.Internal("error"))
+
+		estimator := NewProgressEstimator(readSession, sampleSize, minSamples)
+		estimator.RegisterStages(model.Provision, steps...)
+
+		// when
+		progress := estimator.Estimate(operationInStage(model.WaitingForInstallation))
+
+		// then
+		require.NotNil(t, progress)
+		assert.Equal(t, 3, progress.StagesDone)
+		assert.Nil(t, progress.EstimatedCompletion)
+	})
+
+	t.Run("should not estimate completion in the past", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetStageDurationStats", model.Provision, sampleSize).Return(stats, nil)
+
+		estimator := NewProgressEstimator(readSession, sampleSize, minSamples)
+		estimator.RegisterStages(model.Provision, steps...)
+
+		operation := operationInStage(model.WaitingForInstallation)
+		longRunningStageStart := time.Now().Add(-2 * time.Hour)
+		operation.LastTransition = &longRunningStageStart
+
+		// when
+		progress := estimator.Estimate(operation)
+
+		// then
+		require.NotNil(t, progress)
+		require.NotNil(t, progress.EstimatedCompletion)
+		assert.False(t, progress.EstimatedCompletion.Before(longRunningStageStart.Add(2*time.Hour)))
+	})
+
+	t.Run("should return nil for operations not in progress or with unknown stages", func(t *testing.T) {
+		// given
+		estimator := NewProgressEstimator(&mocks.ReadSession{}, sampleSize, minSamples)
+		estimator.RegisterStages(model.Provision, steps...)
+
+		succeeded := operationInStage(model.FinishedStage)
+		succeeded.State = model.Succeeded
+
+		deprovisioning := operationInStage(model.DeleteCluster)
+		deprovisioning.Type = model.Deprovision
+
+		// then
+		assert.Nil(t, estimator.Estimate(succeeded))
+		assert.Nil(t, estimator.Estimate(deprovisioning))
+		assert.Nil(t, estimator.Estimate(operationInStage(model.ConnectRuntimeAgent)))
+	})
+}
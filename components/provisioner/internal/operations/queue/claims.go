@@ -0,0 +1,172 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	claimRetryDelay = 2 * time.Second
+	// claimRenewalsPerTTL is the number of times the claim is renewed within its TTL, so that a single failed renewal
+	// does not let other replicas take the operation over
+	claimRenewalsPerTTL = 3
+)
+
+// OperationClaimer lets several Provisioner replicas process operations safely. Before processing the operation,
+// the replica claims it in the database, and the other replicas do not process the operation until the claim expires.
+type OperationClaimer struct {
+	session dbsession.WriteSession
+	owner   string
+	ttl     time.Duration
+	clock   clock.Clock
+	log     logrus.FieldLogger
+}
+
+// NewOperationClaimer creates the OperationClaimer claiming operations for the owner, the identity of the replica,
+// for the ttl renewed while the operation is processed
+func NewOperationClaimer(session dbsession.WriteSession, owner string, ttl time.Duration, clock clock.Clock) *OperationClaimer {
+	return &OperationClaimer{
+		session: session,
+		owner:   owner,
+		ttl:     ttl,
+		clock:   clock,
+		log:     logrus.WithFields(logrus.Fields{"Component": "OperationClaimer", "Owner": owner}),
+	}
+}
+
+// Wrap returns the executor processing only the operations claimed by the owner. If the claimer is nil, the executor
+// is returned unchanged.
+func (c *OperationClaimer) Wrap(executor Executor) Executor {
+	if c == nil {
+		return executor
+	}
+
+	return &claimingExecutor{
+		claimer:  c,
+		executor: executor,
+	}
+}
+
+// Unclaimed filters out the operations processed by other replicas, the operations with expired claims are kept
+// so that they are taken over from the replicas which stopped. If the claimer is nil, the operations are returned unchanged.
+func (c *OperationClaimer) Unclaimed(ops []model.Operation) []model.Operation {
+	if c == nil {
+		return ops
+	}
+
+	now := c.clock.Now().UTC()
+	unclaimed := make([]model.Operation, 0, len(ops))
+	for _, operation := range ops {
+		if operation.ClaimedByOther(c.owner, now) {
+			continue
+		}
+		unclaimed = append(unclaimed, operation)
+	}
+
+	return unclaimed
+}
+
+// RunTakeover re-enqueues the operations in progress which are not claimed by other replicas in the interval of the claim TTL,
+// so that the operations of the replicas which stopped are resumed once their claims expire
+func (c *OperationClaimer) RunTakeover(readSession dbsession.ReadSession, requeuer *Requeuer, stop <-chan struct{}) {
+	ticker := c.clock.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C():
+			ops, err := readSession.ListInProgressOperations()
+			if err != nil {
+				c.log.Errorf("error listing operations in progress to take over: %s", err.Error())
+				continue
+			}
+			requeuer.Requeue(c.Unclaimed(ops))
+		}
+	}
+}
+
+func (c *OperationClaimer) claim(operationID string, duration time.Duration) (bool, dberrors.Error) {
+	now := c.clock.Now().UTC()
+	return c.session.ClaimOperation(operationID, c.owner, now.Add(duration), now)
+}
+
+// renewWhileProcessing renews the claim until the returned function is called, which waits for the renewal to stop
+func (c *OperationClaimer) renewWhileProcessing(operationID string) func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-c.clock.After(c.ttl / claimRenewalsPerTTL):
+				claimed, err := c.claim(operationID, c.ttl)
+				if err != nil {
+					c.log.WithField("OperationId", operationID).Warnf("error renewing claim of operation: %s", err.Error())
+					continue
+				}
+				if !claimed {
+					c.log.WithField("OperationId", operationID).Warnf("claim of operation was taken over by another replica")
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+type claimingExecutor struct {
+	claimer  *OperationClaimer
+	executor Executor
+}
+
+func (e *claimingExecutor) Execute(operationID string) operations.ProcessingResult {
+	log := e.claimer.log.WithField("OperationId", operationID)
+
+	claimed, err := e.claimer.claim(operationID, e.claimer.ttl)
+	if err != nil {
+		log.Errorf("error claiming operation: %s", err.Error())
+		return operations.ProcessingResult{Requeue: true, Delay: claimRetryDelay}
+	}
+	if !claimed {
+		// The operation is checked again once the claim of the other replica could expire
+		log.Debugf("Operation claimed by another replica")
+		return operations.ProcessingResult{Requeue: true, Delay: e.claimer.ttl}
+	}
+
+	result := e.process(operationID)
+
+	if result.Requeue {
+		// The claim is kept until the operation is processed again, so that other replicas do not take it over between the stages
+		if _, err := e.claimer.claim(operationID, result.Delay+e.claimer.ttl); err != nil {
+			log.Warnf("error extending claim of operation: %s", err.Error())
+		}
+		return result
+	}
+
+	if err := e.claimer.session.ReleaseOperationClaim(operationID, e.claimer.owner); err != nil {
+		log.Warnf("error releasing claim of operation: %s", err.Error())
+	}
+
+	return result
+}
+
+func (e *claimingExecutor) process(operationID string) operations.ProcessingResult {
+	stopRenewal := e.claimer.renewWhileProcessing(operationID)
+	defer stopRenewal()
+
+	return e.executor.Execute(operationID)
+}
@@ -0,0 +1,237 @@
+package queue
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	claimOwner = "provisioner-0"
+	claimTTL   = 30 * time.Second
+)
+
+type executorFunc func(operationID string) operations.ProcessingResult
+
+func (f executorFunc) Execute(operationID string) operations.ProcessingResult {
+	return f(operationID)
+}
+
+func TestOperationClaimer_Wrap(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should process claimed operation and release the claim", func(t *testing.T) {
+		// given
+		session := &sessionMocks.WriteSession{}
+		session.On("ClaimOperation", "operation-1", claimOwner, now.Add(claimTTL), now).Return(true, nil)
+		session.On("ReleaseOperationClaim", "operation-1", claimOwner).Return(nil)
+
+		executor := &executorStub{}
+		claimer := NewOperationClaimer(session, claimOwner, claimTTL, clock.NewFakeClock(now))
+
+		// when
+		result := claimer.Wrap(executor).Execute("operation-1")
+
+		// then
+		assert.False(t, result.Requeue)
+		assert.Equal(t, []string{"operation-1"}, executor.processedOperations())
+		session.AssertExpectations(t)
+	})
+
+	t.Run("should keep the claim until requeued operation is processed again", func(t *testing.T) {
+		// given
+		delay := 10 * time.Second
+
+		session := &sessionMocks.WriteSession{}
+		session.On("ClaimOperation", "operation-1", claimOwner, now.Add(claimTTL), now).Return(true, nil).Once()
+		session.On("ClaimOperation", "operation-1", claimOwner, now.Add(delay+claimTTL), now).Return(true, nil).Once()
+
+		executor := executorFunc(func(operationID string) operations.ProcessingResult {
+			return operations.ProcessingResult{Requeue: true, Delay: delay}
+		})
+		claimer := NewOperationClaimer(session, claimOwner, claimTTL, clock.NewFakeClock(now))
+
+		// when
+		result := claimer.Wrap(executor).Execute("operation-1")
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{Requeue: true, Delay: delay}, result)
+		session.AssertExpectations(t)
+		session.AssertNotCalled(t, "ReleaseOperationClaim", "operation-1", claimOwner)
+	})
+
+	t.Run("should not process operation claimed by another replica", func(t *testing.T) {
+		// given
+		session := &sessionMocks.WriteSession{}
+		session.On("ClaimOperation", "operation-1", claimOwner, now.Add(claimTTL), now).Return(false, nil)
+
+		executor := &executorStub{}
+		claimer := NewOperationClaimer(session, claimOwner, claimTTL, clock.NewFakeClock(now))
+
+		// when
+		result := claimer.Wrap(executor).Execute("operation-1")
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{Requeue: true, Delay: claimTTL}, result)
+		assert.Empty(t, executor.processedOperations())
+	})
+
+	t.Run("should retry when operation cannot be claimed", func(t *testing.T) {
+		// given
+		session := &sessionMocks.WriteSession{}
+		session.On("ClaimOperation", "operation-1", claimOwner, now.Add(claimTTL), now).Return(false, dberrors.Internal("error"))
+
+		executor := &executorStub{}
+		claimer := NewOperationClaimer(session, claimOwner, claimTTL, clock.NewFakeClock(now))
+
+		// when
+		result := claimer.Wrap(executor).Execute("operation-1")
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{Requeue: true, Delay: claimRetryDelay}, result)
+		assert.Empty(t, executor.processedOperations())
+	})
+
+	t.Run("should renew the claim while operation is processed", func(t *testing.T) {
+		// given
+		fakeClock := clock.NewFakeClock(now)
+		renewedAt := now.Add(claimTTL / claimRenewalsPerTTL)
+
+		renewed := make(chan struct{})
+
+		session := &sessionMocks.WriteSession{}
+		session.On("ClaimOperation", "operation-1", claimOwner, now.Add(claimTTL), now).Return(true, nil)
+		session.On("ClaimOperation", "operation-1", claimOwner, renewedAt.Add(claimTTL), renewedAt).Return(true, nil).
+			Run(func(mock.Arguments) { close(renewed) })
+		session.On("ReleaseOperationClaim", "operation-1", claimOwner).Return(nil)
+
+		executor := executorFunc(func(operationID string) operations.ProcessingResult {
+			stepWhenWaiting(t, fakeClock, claimTTL/claimRenewalsPerTTL)
+			waitFor(t, renewed)
+			return operations.ProcessingResult{}
+		})
+		claimer := NewOperationClaimer(session, claimOwner, claimTTL, fakeClock)
+
+		// when
+		result := claimer.Wrap(executor).Execute("operation-1")
+
+		// then
+		assert.False(t, result.Requeue)
+		session.AssertExpectations(t)
+	})
+
+	t.Run("should return executor unchanged when claims are disabled", func(t *testing.T) {
+		// given
+		executor := &executorStub{}
+		var claimer *OperationClaimer
+
+		// when
+		wrapped := claimer.Wrap(executor)
+
+		// then
+		assert.Same(t, executor, wrapped)
+	})
+}
+
+func TestOperationClaimer_Unclaimed(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	owner := claimOwner
+	otherOwner := "provisioner-1"
+	validUntil := now.Add(time.Minute)
+	expiredAt := now.Add(-time.Minute)
+
+	ops := []model.Operation{
+		{ID: "unclaimed"},
+		{ID: "claimed-by-owner", ClaimedBy: &owner, ClaimExpiresAt: &validUntil},
+		{ID: "claimed-by-other", ClaimedBy: &otherOwner, ClaimExpiresAt: &validUntil},
+		{ID: "stale-claim-of-other", ClaimedBy: &otherOwner, ClaimExpiresAt: &expiredAt},
+	}
+
+	t.Run("should filter out operations claimed by other replicas", func(t *testing.T) {
+		// given
+		claimer := NewOperationClaimer(&sessionMocks.WriteSession{}, claimOwner, claimTTL, clock.NewFakeClock(now))
+
+		// when
+		unclaimed := claimer.Unclaimed(ops)
+
+		// then
+		assert.Equal(t, []string{"unclaimed", "claimed-by-owner", "stale-claim-of-other"}, operationIDs(unclaimed))
+	})
+
+	t.Run("should return all operations when claims are disabled", func(t *testing.T) {
+		// given
+		var claimer *OperationClaimer
+
+		// when
+		unclaimed := claimer.Unclaimed(ops)
+
+		// then
+		assert.Equal(t, ops, unclaimed)
+	})
+}
+
+func TestOperationClaimer_RunTakeover(t *testing.T) {
+	// given
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	otherOwner := "provisioner-1"
+	expiredAt := now.Add(-time.Minute)
+	validUntil := now.Add(time.Hour)
+
+	listed := make(chan struct{})
+
+	readSession := &sessionMocks.ReadSession{}
+	readSession.On("ListInProgressOperations").Return([]model.Operation{
+		{ID: "stale", Type: model.Provision, ClaimedBy: &otherOwner, ClaimExpiresAt: &expiredAt},
+		{ID: "claimed", Type: model.Provision, ClaimedBy: &otherOwner, ClaimExpiresAt: &validUntil},
+	}, nil).Once().Run(func(mock.Arguments) { close(listed) })
+
+	var added []addedOperation
+	requeuer := NewRequeuer(map[model.OperationType]OperationQueue{
+		model.Provision: &recordingQueue{name: "provisioning", added: &added},
+	}, 0, rand.New(rand.NewSource(1)), logrus.New())
+
+	claimer := NewOperationClaimer(&sessionMocks.WriteSession{}, claimOwner, claimTTL, fakeClock)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		claimer.RunTakeover(readSession, requeuer, stop)
+		close(done)
+	}()
+
+	// when
+	stepWhenWaiting(t, fakeClock, claimTTL)
+
+	// then
+	waitFor(t, listed)
+	close(stop)
+	<-done
+	assert.Equal(t, []string{"stale"}, addedIDs(added))
+}
+
+func waitFor(t *testing.T, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting")
+	}
+}
+
+func operationIDs(ops []model.Operation) []string {
+	ids := make([]string, 0, len(ops))
+	for _, operation := range ops {
+		ids = append(ids, operation.ID)
+	}
+	return ids
+}
@@ -0,0 +1,44 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/sirupsen/logrus"
+)
+
+const operationTypeLookupDelay = 2 * time.Second
+
+// operationTypeExecutors lets single queue process operations of several types,
+// each of them by the executor of its type
+type operationTypeExecutors struct {
+	readSession dbsession.ReadSession
+	executors   map[model.OperationType]Executor
+	log         logrus.FieldLogger
+}
+
+func newOperationTypeExecutors(readSession dbsession.ReadSession, executors map[model.OperationType]Executor) *operationTypeExecutors {
+	return &operationTypeExecutors{
+		readSession: readSession,
+		executors:   executors,
+		log:         logrus.WithField("Component", "OperationTypeExecutors"),
+	}
+}
+
+func (e *operationTypeExecutors) Execute(operationID string) operations.ProcessingResult {
+	operation, err := e.readSession.GetOperation(operationID)
+	if err != nil {
+		e.log.WithField("OperationId", operationID).Errorf("error getting operation to find its executor: %s", err.Error())
+		return operations.ProcessingResult{Requeue: true, Delay: operationTypeLookupDelay}
+	}
+
+	executor, found := e.executors[operation.Type]
+	if !found {
+		e.log.WithField("OperationId", operationID).Warnf("no executor for operation of type %s", operation.Type)
+		return operations.ProcessingResult{Requeue: false}
+	}
+
+	return executor.Execute(operationID)
+}
@@ -0,0 +1,70 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationTypeExecutors_Execute(t *testing.T) {
+	t.Run("should process operation with the executor of its type", func(t *testing.T) {
+		// given
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetOperation", "operation-1").Return(model.Operation{ID: "operation-1", Type: model.WakeUp}, nil)
+
+		hibernationExecutor := &executorStub{}
+		wakeUpExecutor := &executorStub{}
+		executor := newOperationTypeExecutors(readSession, map[model.OperationType]Executor{
+			model.Hibernate: hibernationExecutor,
+			model.WakeUp:    wakeUpExecutor,
+		})
+
+		// when
+		result := executor.Execute("operation-1")
+
+		// then
+		assert.False(t, result.Requeue)
+		assert.Empty(t, hibernationExecutor.processedOperations())
+		assert.Equal(t, []string{"operation-1"}, wakeUpExecutor.processedOperations())
+	})
+
+	t.Run("should drop operation of unknown type", func(t *testing.T) {
+		// given
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetOperation", "operation-1").Return(model.Operation{ID: "operation-1", Type: model.Provision}, nil)
+
+		hibernationExecutor := &executorStub{}
+		executor := newOperationTypeExecutors(readSession, map[model.OperationType]Executor{
+			model.Hibernate: hibernationExecutor,
+		})
+
+		// when
+		result := executor.Execute("operation-1")
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{Requeue: false}, result)
+		assert.Empty(t, hibernationExecutor.processedOperations())
+	})
+
+	t.Run("should requeue operation when failed to get it", func(t *testing.T) {
+		// given
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetOperation", "operation-1").Return(model.Operation{}, dberrors.Internal("error"))
+
+		hibernationExecutor := &executorStub{}
+		executor := newOperationTypeExecutors(readSession, map[model.OperationType]Executor{
+			model.Hibernate: hibernationExecutor,
+		})
+
+		// when
+		result := executor.Execute("operation-1")
+
+		// then
+		assert.True(t, result.Requeue)
+		assert.Empty(t, hibernationExecutor.processedOperations())
+	})
+}
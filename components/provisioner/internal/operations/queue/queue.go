@@ -2,10 +2,13 @@ package queue
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/recovery"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -13,11 +16,16 @@ import (
 //go:generate mockery -name=OperationQueue
 type OperationQueue interface {
 	Add(processId string)
+	AddAfter(processId string, delay time.Duration)
 	Run(stop <-chan struct{})
+	SetPaused(paused bool)
+	IsPaused() bool
+	Len() int
 }
 
 const (
 	workersAmount = 5
+	pausedDelay   = 10 * time.Second
 )
 
 type Executor interface {
@@ -27,6 +35,7 @@ type Executor interface {
 type Queue struct {
 	queue    workqueue.RateLimitingInterface
 	executor Executor
+	paused   int32
 }
 
 func NewQueue(executor Executor) *Queue {
@@ -36,30 +45,87 @@ func NewQueue(executor Executor) *Queue {
 	}
 }
 
+// NewQueueWithClock creates the queue which delays the operations added with AddAfter according to the given clock
+func NewQueueWithClock(executor Executor, clock clock.Clock) *Queue {
+	return &Queue{
+		queue: &rateLimitingQueue{
+			DelayingInterface: workqueue.NewDelayingQueueWithCustomClock(clock, "operations"),
+			rateLimiter:       workqueue.DefaultControllerRateLimiter(),
+		},
+		executor: executor,
+	}
+}
+
+// rateLimitingQueue adds the rate limiting to the delaying queue, as the rate limiting queue of the workqueue package
+// cannot be created with a custom clock
+type rateLimitingQueue struct {
+	workqueue.DelayingInterface
+	rateLimiter workqueue.RateLimiter
+}
+
+func (q *rateLimitingQueue) AddRateLimited(item interface{}) {
+	q.DelayingInterface.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *rateLimitingQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+func (q *rateLimitingQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
 func (q *Queue) Add(operationId string) {
 	q.queue.Add(operationId)
 }
 
+// AddAfter schedules the operation to be processed once the delay passes
+func (q *Queue) AddAfter(operationId string, delay time.Duration) {
+	q.queue.AddAfter(operationId, delay)
+}
+
+// SetPaused stops or resumes processing of the operations. Paused queue still accepts new operations.
+func (q *Queue) SetPaused(paused bool) {
+	var value int32
+	if paused {
+		value = 1
+	}
+
+	atomic.StoreInt32(&q.paused, value)
+}
+
+func (q *Queue) IsPaused() bool {
+	return atomic.LoadInt32(&q.paused) == 1
+}
+
+func (q *Queue) Len() int {
+	return q.queue.Len()
+}
+
 func (q *Queue) Run(stop <-chan struct{}) {
 	var waitGroup sync.WaitGroup
 
 	for i := 0; i < workersAmount; i++ {
-		createWorker(q.queue, q.executor.Execute, stop, &waitGroup)
+		createWorker(q.queue, q.executor.Execute, q.IsPaused, stop, &waitGroup)
 	}
 }
 
-func createWorker(queue workqueue.RateLimitingInterface, process func(id string) operations.ProcessingResult, stopCh <-chan struct{}, waitGroup *sync.WaitGroup) {
+func createWorker(queue workqueue.RateLimitingInterface, process func(id string) operations.ProcessingResult, paused func() bool, stopCh <-chan struct{}, waitGroup *sync.WaitGroup) {
 	waitGroup.Add(1)
 	go func() {
-		wait.Until(worker(queue, process), time.Second, stopCh)
+		wait.Until(worker(queue, process, paused), time.Second, stopCh)
 		waitGroup.Done()
 	}()
 }
 
-func worker(queue workqueue.RateLimitingInterface, process func(key string) operations.ProcessingResult) func() {
+func worker(queue workqueue.RateLimitingInterface, process func(key string) operations.ProcessingResult, paused func() bool) func() {
 	return func() {
 		exit := false
 		for !exit {
+			if paused() {
+				return
+			}
+
 			exit = func() bool {
 				key, quit := queue.Get()
 				logrus.Debugf("Processing operation: %s", key)
@@ -69,11 +135,19 @@ func worker(queue workqueue.RateLimitingInterface, process func(key string) oper
 				}
 				defer func() {
 					if err := recover(); err != nil {
-						logrus.Errorf("panic error while processing key %s: %s", key, err)
+						recovery.WorkerPanic(logrus.StandardLogger(), key, err)
+						// Operation is retried with backoff so it does not stay in progress until restart
+						queue.AddRateLimited(key)
 					}
 					queue.Done(key)
 				}()
 
+				// Queue might have been paused while the worker was waiting for the operation
+				if paused() {
+					queue.AddAfter(key, pausedDelay)
+					return true
+				}
+
 				result := process(key.(string))
 				if result.Requeue {
 					queue.AddAfter(key, result.Delay)
@@ -0,0 +1,121 @@
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type executorStub struct {
+	mutex     sync.Mutex
+	processed []string
+}
+
+func (e *executorStub) Execute(operationID string) operations.ProcessingResult {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.processed = append(e.processed, operationID)
+	return operations.ProcessingResult{}
+}
+
+func (e *executorStub) processedOperations() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return append([]string{}, e.processed...)
+}
+
+func TestQueue_SetPaused(t *testing.T) {
+	// given
+	executor := &executorStub{}
+	queue := NewQueue(executor)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	queue.SetPaused(true)
+	require.True(t, queue.IsPaused())
+
+	queue.Run(stop)
+
+	// when
+	queue.Add("operation-1")
+
+	// then
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, executor.processedOperations())
+	assert.Equal(t, 1, queue.Len())
+
+	// when
+	queue.SetPaused(false)
+
+	// then
+	require.False(t, queue.IsPaused())
+	require.Eventually(t, func() bool {
+		return len(executor.processedOperations()) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, []string{"operation-1"}, executor.processedOperations())
+	assert.Equal(t, 0, queue.Len())
+}
+
+type panickingExecutorStub struct {
+	executorStub
+	panicked int32
+}
+
+func (e *panickingExecutorStub) Execute(operationID string) operations.ProcessingResult {
+	if atomic.CompareAndSwapInt32(&e.panicked, 0, 1) {
+		panic("unexpected error")
+	}
+
+	return e.executorStub.Execute(operationID)
+}
+
+func TestQueue_RecoverFromPanic(t *testing.T) {
+	// given
+	executor := &panickingExecutorStub{}
+	queue := NewQueue(executor)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	queue.Run(stop)
+
+	// when
+	queue.Add("operation-1")
+
+	// then
+	require.Eventually(t, func() bool {
+		return len(executor.processedOperations()) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, []string{"operation-1"}, executor.processedOperations())
+}
+
+func TestQueue_AddAfter(t *testing.T) {
+	// given
+	executor := &executorStub{}
+	queue := NewQueue(executor)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	queue.Run(stop)
+
+	// when
+	queue.AddAfter("operation-1", 300*time.Millisecond)
+
+	// then
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, executor.processedOperations())
+
+	require.Eventually(t, func() bool {
+		return len(executor.processedOperations()) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, []string{"operation-1"}, executor.processedOperations())
+}
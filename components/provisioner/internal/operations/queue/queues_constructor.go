@@ -5,7 +5,6 @@ import (
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/hibernation"
 
-	gardener_apis "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/director"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/gardener"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/installation"
@@ -19,15 +18,16 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/runtime"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
-	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 type ProvisioningTimeouts struct {
 	ClusterCreation        time.Duration `envconfig:"default=60m"`
 	ClusterDomains         time.Duration `envconfig:"default=10m"`
+	OverridesValidation    time.Duration `envconfig:"default=5m"`
 	BindingsCreation       time.Duration `envconfig:"default=5m"`
 	InstallationTriggering time.Duration `envconfig:"default=20m"`
 	Installation           time.Duration `envconfig:"default=60m"`
+	MaxInstallation        time.Duration `envconfig:"default=180m"`
 	Upgrade                time.Duration `envconfig:"default=60m"`
 	UpgradeTriggering      time.Duration `envconfig:"default=20m"`
 	ShootUpgrade           time.Duration `envconfig:"default=30m"`
@@ -43,7 +43,9 @@ type DeprovisioningTimeouts struct {
 }
 
 type HibernationTimeouts struct {
+	SettingClusterHibernation    time.Duration `envconfig:"default=10m"`
 	WaitingForClusterHibernation time.Duration `envconfig:"default=60m"`
+	WaitingForClusterWakeUp      time.Duration `envconfig:"default=60m"`
 }
 
 func CreateProvisioningQueue(
@@ -53,18 +55,27 @@ func CreateProvisioningQueue(
 	configurator runtime.Configurator,
 	ccClientConstructor provisioning.CompassConnectionClientConstructor,
 	directorClient director.DirectorClient,
-	shootClient gardener_apis.ShootInterface,
-	secretsClient v1core.SecretInterface,
+	shootClients gardener.ShootClients,
+	kubeconfigProvider *gardener.KubeconfigProvider,
+	kubeconfigRenewer *operations.KubeconfigRenewer,
 	operatorRoleBindingConfig provisioning.OperatorRoleBinding,
-	k8sClientProvider k8s.K8sClientProvider) OperationQueue {
+	operatorBindings []provisioning.OperatorBinding,
+	k8sClientProvider k8s.K8sClientProvider,
+	resumeKymaInstallation bool,
+	progressEstimator *operations.ProgressEstimator,
+	claimer *OperationClaimer) OperationQueue {
 
-	waitForAgentToConnectStep := provisioning.NewWaitForAgentToConnectStep(ccClientConstructor, model.FinishedStage, timeouts.AgentConnection, directorClient)
+	waitForAgentToConnectStep := provisioning.NewWaitForAgentToConnectStep(ccClientConstructor, model.FinishedStage, timeouts.AgentConnection, directorClient, runtime.NewAgentDiagnostics(k8sClientProvider), factory.NewWriteSession())
 	configureAgentStep := provisioning.NewConnectAgentStep(configurator, waitForAgentToConnectStep.Name(), timeouts.AgentConfiguration)
 	waitForInstallStep := provisioning.NewWaitForInstallationStep(installationClient, configureAgentStep.Name(), timeouts.Installation, factory.NewWriteSession())
-	installStep := provisioning.NewInstallKymaStep(installationClient, waitForInstallStep.Name(), timeouts.InstallationTriggering)
-	createBindingsForOperatorsStep := provisioning.NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorRoleBindingConfig, installStep.Name(), timeouts.BindingsCreation)
-	waitForClusterCreationStep := provisioning.NewWaitForClusterCreationStep(shootClient, factory.NewReadWriteSession(), gardener.NewKubeconfigProvider(secretsClient), createBindingsForOperatorsStep.Name(), timeouts.ClusterCreation)
-	waitForClusterDomainStep := provisioning.NewWaitForClusterDomainStep(shootClient, directorClient, waitForClusterCreationStep.Name(), timeouts.ClusterDomains)
+	installStep := provisioning.NewInstallKymaStep(installationClient, waitForInstallStep.Name(), timeouts.InstallationTriggering, factory.NewWriteSession(), resumeKymaInstallation)
+	createBindingsForOperatorsStep := provisioning.NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorRoleBindingConfig, operatorBindings, installStep.Name(), timeouts.BindingsCreation)
+	validateOverridesStep := provisioning.NewValidateOverridesStep(k8sClientProvider, createBindingsForOperatorsStep.Name(), timeouts.OverridesValidation)
+	gardenerClient := func(project string) provisioning.GardenerClient {
+		return shootClients.ForProject(project)
+	}
+	waitForClusterCreationStep := provisioning.NewWaitForClusterCreationStep(gardenerClient, factory.NewReadWriteSession(), kubeconfigProvider, validateOverridesStep.Name(), timeouts.ClusterCreation)
+	waitForClusterDomainStep := provisioning.NewWaitForClusterDomainStep(gardenerClient, directorClient, waitForClusterCreationStep.Name(), timeouts.ClusterDomains)
 
 	provisionSteps := map[model.OperationStage]operations.Step{
 		model.WaitForAgentToConnect:        waitForAgentToConnectStep,
@@ -72,45 +83,70 @@ func CreateProvisioningQueue(
 		model.WaitingForInstallation:       waitForInstallStep,
 		model.StartingInstallation:         installStep,
 		model.CreatingBindingsForOperators: createBindingsForOperatorsStep,
+		model.ValidatingOverrides:          validateOverridesStep,
 		model.WaitingForClusterDomain:      waitForClusterDomainStep,
 		model.WaitingForClusterCreation:    waitForClusterCreationStep,
 	}
 
+	registerStages(progressEstimator, model.Provision,
+		waitForClusterDomainStep,
+		waitForClusterCreationStep,
+		validateOverridesStep,
+		createBindingsForOperatorsStep,
+		installStep,
+		waitForInstallStep,
+		configureAgentStep,
+		waitForAgentToConnectStep,
+	)
+
 	provisioningExecutor := operations.NewExecutor(
 		factory.NewReadWriteSession(),
 		model.Provision,
 		provisionSteps,
 		failure.NewNoopFailureHandler(),
 		directorClient,
+		kubeconfigRenewer,
 	)
 
-	return NewQueue(provisioningExecutor)
+	return NewQueue(claimer.Wrap(provisioningExecutor))
 }
 
 func CreateUpgradeQueue(
 	provisioningTimeouts ProvisioningTimeouts,
 	factory dbsession.Factory,
 	directorClient director.DirectorClient,
-	installationClient installation.Service) OperationQueue {
+	installationClient installation.Service,
+	k8sClientProvider k8s.K8sClientProvider,
+	healthChecks upgrade.HealthChecksConfig,
+	kubeconfigRenewer *operations.KubeconfigRenewer,
+	progressEstimator *operations.ProgressEstimator,
+	claimer *OperationClaimer) OperationQueue {
 
 	updatingUpgradeStep := upgrade.NewUpdateUpgradeStateStep(factory.NewWriteSession(), model.FinishedStage, 5*time.Minute)
-	waitForInstallStep := provisioning.NewWaitForInstallationStep(installationClient, updatingUpgradeStep.Name(), provisioningTimeouts.Installation, factory.NewWriteSession())
+	postUpgradeCheckStep := upgrade.NewPostUpgradeHealthCheckStep(k8sClientProvider, healthChecks, updatingUpgradeStep.Name(), healthChecks.Timeout+5*time.Minute)
+	waitForInstallStep := provisioning.NewWaitForInstallationStep(installationClient, postUpgradeCheckStep.Name(), provisioningTimeouts.Installation, factory.NewWriteSession())
 	upgradeStep := upgrade.NewUpgradeKymaStep(installationClient, waitForInstallStep.Name(), provisioningTimeouts.UpgradeTriggering)
+	preUpgradeCheckStep := upgrade.NewPreUpgradeHealthCheckStep(installationClient, k8sClientProvider, healthChecks, upgradeStep.Name(), 5*time.Minute)
 
 	upgradeSteps := map[model.OperationStage]operations.Step{
-		model.UpdatingUpgradeState:   updatingUpgradeStep,
-		model.WaitingForInstallation: waitForInstallStep,
-		model.StartingUpgrade:        upgradeStep,
+		model.UpdatingUpgradeState:      updatingUpgradeStep,
+		model.CheckingPostUpgradeHealth: postUpgradeCheckStep,
+		model.WaitingForInstallation:    waitForInstallStep,
+		model.StartingUpgrade:           upgradeStep,
+		model.CheckingPreUpgradeHealth:  preUpgradeCheckStep,
 	}
 
+	registerStages(progressEstimator, model.Upgrade, preUpgradeCheckStep, upgradeStep, waitForInstallStep, postUpgradeCheckStep, updatingUpgradeStep)
+
 	upgradeExecutor := operations.NewExecutor(factory.NewReadWriteSession(),
 		model.Upgrade,
 		upgradeSteps,
 		failure.NewUpgradeFailureHandler(factory.NewWriteSession()),
 		directorClient,
+		kubeconfigRenewer,
 	)
 
-	return NewQueue(upgradeExecutor)
+	return NewQueue(claimer.Wrap(upgradeExecutor))
 }
 
 func CreateDeprovisioningQueue(
@@ -118,13 +154,19 @@ func CreateDeprovisioningQueue(
 	factory dbsession.Factory,
 	installationClient installation.Service,
 	directorClient director.DirectorClient,
-	shootClient gardener_apis.ShootInterface,
-	deleteDelay time.Duration) OperationQueue {
+	shootClients gardener.ShootClients,
+	deleteDelay time.Duration,
+	kubeconfigRenewer *operations.KubeconfigRenewer,
+	progressEstimator *operations.ProgressEstimator,
+	claimer *OperationClaimer) OperationQueue {
 
-	waitForClusterDeletion := deprovisioning.NewWaitForClusterDeletionStep(shootClient, factory, directorClient, model.FinishedStage, timeouts.WaitingForClusterDeletion)
-	deleteCluster := deprovisioning.NewDeleteClusterStep(shootClient, waitForClusterDeletion.Name(), timeouts.ClusterDeletion)
-	triggerKymaUninstall := deprovisioning.NewTriggerKymaUninstallStep(shootClient, installationClient, deleteCluster.Name(), 5*time.Minute, deleteDelay)
-	cleanupCluster := deprovisioning.NewCleanupClusterStep(shootClient, installationClient, triggerKymaUninstall.Name(), timeouts.ClusterCleanup)
+	gardenerClient := func(project string) deprovisioning.GardenerClient {
+		return shootClients.ForProject(project)
+	}
+	waitForClusterDeletion := deprovisioning.NewWaitForClusterDeletionStep(gardenerClient, factory, directorClient, model.FinishedStage, timeouts.WaitingForClusterDeletion)
+	deleteCluster := deprovisioning.NewDeleteClusterStep(gardenerClient, waitForClusterDeletion.Name(), timeouts.ClusterDeletion)
+	triggerKymaUninstall := deprovisioning.NewTriggerKymaUninstallStep(gardenerClient, installationClient, deleteCluster.Name(), 5*time.Minute, deleteDelay)
+	cleanupCluster := deprovisioning.NewCleanupClusterStep(gardenerClient, installationClient, triggerKymaUninstall.Name(), timeouts.ClusterCleanup)
 
 	deprovisioningSteps := map[model.OperationStage]operations.Step{
 		model.CleanupCluster:         cleanupCluster,
@@ -133,65 +175,153 @@ func CreateDeprovisioningQueue(
 		model.TriggerKymaUninstall:   triggerKymaUninstall,
 	}
 
+	registerStages(progressEstimator, model.Deprovision, cleanupCluster, triggerKymaUninstall, deleteCluster, waitForClusterDeletion)
+
 	deprovisioningExecutor := operations.NewExecutor(
 		factory.NewReadWriteSession(),
 		model.Deprovision,
 		deprovisioningSteps,
 		failure.NewNoopFailureHandler(),
 		directorClient,
+		kubeconfigRenewer,
 	)
 
-	return NewQueue(deprovisioningExecutor)
+	// Cleanup of failed provisioning skips the in-cluster actions, as Kyma might not have been installed
+	cleanupSteps := map[model.OperationStage]operations.Step{
+		model.DeleteCluster:          deleteCluster,
+		model.WaitForClusterDeletion: waitForClusterDeletion,
+	}
+
+	registerStages(progressEstimator, model.CleanupFailedProvisioning, deleteCluster, waitForClusterDeletion)
+
+	cleanupExecutor := operations.NewExecutor(
+		factory.NewReadWriteSession(),
+		model.CleanupFailedProvisioning,
+		cleanupSteps,
+		failure.NewNoopFailureHandler(),
+		directorClient,
+		kubeconfigRenewer,
+	)
+
+	// Deprovisioning and cleanup of failed provisioning are processed by the same queue, so pausing it stops both
+	return NewQueue(claimer.Wrap(newOperationTypeExecutors(factory.NewReadSession(), map[model.OperationType]Executor{
+		model.Deprovision:               deprovisioningExecutor,
+		model.CleanupFailedProvisioning: cleanupExecutor,
+	})))
 }
 
 func CreateShootUpgradeQueue(
 	timeouts ProvisioningTimeouts,
 	factory dbsession.Factory,
 	directorClient director.DirectorClient,
-	shootClient gardener_apis.ShootInterface,
+	shootClients gardener.ShootClients,
 	operatorRoleBindingConfig provisioning.OperatorRoleBinding,
-	k8sClientProvider k8s.K8sClientProvider) OperationQueue {
+	operatorBindings []provisioning.OperatorBinding,
+	k8sClientProvider k8s.K8sClientProvider,
+	ccClientConstructor provisioning.CompassConnectionClientConstructor,
+	agentReconnection shootupgrade.AgentReconnectionConfig,
+	kubeconfigRenewer *operations.KubeconfigRenewer,
+	progressEstimator *operations.ProgressEstimator,
+	claimer *OperationClaimer) OperationQueue {
 
-	createBindingsForOperatorsStep := provisioning.NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorRoleBindingConfig, model.FinishedStage, timeouts.BindingsCreation)
-	waitForShootUpgrade := shootupgrade.NewWaitForShootUpgradeStep(shootClient, createBindingsForOperatorsStep.Name(), timeouts.ShootUpgrade)
-	waitForShootNewVersion := shootupgrade.NewWaitForShootNewVersionStep(shootClient, waitForShootUpgrade.Name(), timeouts.ShootRefresh)
+	gardenerClient := func(project string) shootupgrade.GardenerClient {
+		return shootClients.ForProject(project)
+	}
 
-	upgradeSteps := map[model.OperationStage]operations.Step{
-		model.CreatingBindingsForOperators: createBindingsForOperatorsStep,
-		model.WaitingForShootUpgrade:       waitForShootUpgrade,
-		model.WaitingForShootNewVersion:    waitForShootNewVersion,
+	upgradeSteps := map[model.OperationStage]operations.Step{}
+	bindingsNextStep := model.FinishedStage
+	// The Runtime Agent connection is verified after the bindings are created, as rolling the nodes can break it
+	var agentConnectionSteps []operations.Step
+	if agentReconnection.Enabled {
+		agentDiagnostics := runtime.NewAgentDiagnostics(k8sClientProvider)
+		waitForAgentReconnectionStep := shootupgrade.NewWaitForAgentReconnectionStep(ccClientConstructor, agentDiagnostics, factory.NewWriteSession(), agentReconnection.Window, model.FinishedStage)
+		verifyAgentConnectionStep := shootupgrade.NewVerifyAgentConnectionStep(ccClientConstructor, agentDiagnostics, factory.NewWriteSession(), agentReconnection.Window, waitForAgentReconnectionStep.Name(), model.FinishedStage)
+		upgradeSteps[model.VerifyingAgentConnection] = verifyAgentConnectionStep
+		upgradeSteps[model.WaitingForAgentReconnection] = waitForAgentReconnectionStep
+		agentConnectionSteps = []operations.Step{verifyAgentConnectionStep}
+		bindingsNextStep = verifyAgentConnectionStep.Name()
 	}
 
+	createBindingsForOperatorsStep := provisioning.NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorRoleBindingConfig, operatorBindings, bindingsNextStep, timeouts.BindingsCreation)
+	waitForShootUpgrade := shootupgrade.NewWaitForShootUpgradeStep(gardenerClient, createBindingsForOperatorsStep.Name(), timeouts.ShootUpgrade)
+	waitForShootNewVersion := shootupgrade.NewWaitForShootNewVersionStep(gardenerClient, waitForShootUpgrade.Name(), timeouts.ShootRefresh)
+
+	upgradeSteps[model.CreatingBindingsForOperators] = createBindingsForOperatorsStep
+	upgradeSteps[model.WaitingForShootUpgrade] = waitForShootUpgrade
+	upgradeSteps[model.WaitingForShootNewVersion] = waitForShootNewVersion
+
+	registerStages(progressEstimator, model.UpgradeShoot, append([]operations.Step{waitForShootNewVersion, waitForShootUpgrade, createBindingsForOperatorsStep}, agentConnectionSteps...)...)
+
 	upgradeClusterExecutor := operations.NewExecutor(
 		factory.NewReadWriteSession(),
 		model.UpgradeShoot,
 		upgradeSteps,
 		failure.NewNoopFailureHandler(),
 		directorClient,
+		kubeconfigRenewer,
 	)
 
-	return NewQueue(upgradeClusterExecutor)
+	return NewQueue(claimer.Wrap(upgradeClusterExecutor))
 }
 
 func CreateHibernationQueue(
 	timeouts HibernationTimeouts,
 	factory dbsession.Factory,
 	directorClient director.DirectorClient,
-	shootClient gardener_apis.ShootInterface) OperationQueue {
+	shootClients gardener.ShootClients,
+	progressEstimator *operations.ProgressEstimator,
+	claimer *OperationClaimer) OperationQueue {
 
-	waitForHibernation := hibernation.NewWaitForHibernationStep(shootClient, model.FinishedStage, timeouts.WaitingForClusterHibernation)
+	gardenerClient := func(project string) hibernation.GardenerClient {
+		return shootClients.ForProject(project)
+	}
+	hibernateCluster := hibernation.NewHibernateClusterStep(gardenerClient, model.WaitForHibernation, timeouts.SettingClusterHibernation)
+	waitForHibernation := hibernation.NewWaitForHibernationStep(gardenerClient, factory.NewWriteSession(), model.FinishedStage, timeouts.WaitingForClusterHibernation)
 
 	hibernationSteps := map[model.OperationStage]operations.Step{
-		model.WaitForHibernation: waitForHibernation,
+		model.StartingHibernation: hibernateCluster,
+		model.WaitForHibernation:  waitForHibernation,
 	}
 
+	registerStages(progressEstimator, model.Hibernate, hibernateCluster, waitForHibernation)
+
 	hibernateClusterExecutor := operations.NewExecutor(
 		factory.NewReadWriteSession(),
 		model.Hibernate,
 		hibernationSteps,
 		failure.NewNoopFailureHandler(),
 		directorClient,
+		nil,
 	)
 
-	return NewQueue(hibernateClusterExecutor)
+	wakeUpCluster := hibernation.NewWakeUpClusterStep(gardenerClient, model.WaitForWakeUp, timeouts.SettingClusterHibernation)
+	waitForWakeUp := hibernation.NewWaitForWakeUpStep(gardenerClient, factory.NewWriteSession(), model.FinishedStage, timeouts.WaitingForClusterWakeUp)
+
+	wakeUpSteps := map[model.OperationStage]operations.Step{
+		model.StartingWakeUp: wakeUpCluster,
+		model.WaitForWakeUp:  waitForWakeUp,
+	}
+
+	registerStages(progressEstimator, model.WakeUp, wakeUpCluster, waitForWakeUp)
+
+	wakeUpClusterExecutor := operations.NewExecutor(
+		factory.NewReadWriteSession(),
+		model.WakeUp,
+		wakeUpSteps,
+		failure.NewNoopFailureHandler(),
+		directorClient,
+		nil,
+	)
+
+	// Hibernation and wake-up of the cluster are processed by the same queue, so pausing it stops both
+	return NewQueue(claimer.Wrap(newOperationTypeExecutors(factory.NewReadSession(), map[model.OperationType]Executor{
+		model.Hibernate: hibernateClusterExecutor,
+		model.WakeUp:    wakeUpClusterExecutor,
+	})))
+}
+
+func registerStages(progressEstimator *operations.ProgressEstimator, operationType model.OperationType, steps ...operations.Step) {
+	if progressEstimator != nil {
+		progressEstimator.RegisterStages(operationType, steps...)
+	}
 }
@@ -0,0 +1,86 @@
+package queue
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// requeuePriorities orders the operations re-enqueued after restart, the lower the value the sooner the operation is resumed.
+// Deprovisioning goes first as it frees the resources, upgrades and hibernation can wait the longest.
+var requeuePriorities = map[model.OperationType]int{
+	model.Deprovision:               0,
+	model.CleanupFailedProvisioning: 0,
+	model.Provision:                 1,
+	model.Upgrade:                   2,
+	model.UpgradeShoot:              2,
+	model.Hibernate:                 3,
+	model.WakeUp:                    3,
+}
+
+// Requeuer adds the operations which were in progress before restart back to the queues.
+// The operations are spread over the window, so that they do not start their stages all at once.
+type Requeuer struct {
+	queues map[model.OperationType]OperationQueue
+	window time.Duration
+	random *rand.Rand
+	log    logrus.FieldLogger
+}
+
+// NewRequeuer creates the Requeuer adding the operations of each type to the given queue. If window is 0 the operations
+// are added at once.
+func NewRequeuer(queues map[model.OperationType]OperationQueue, window time.Duration, random *rand.Rand, log logrus.FieldLogger) *Requeuer {
+	return &Requeuer{
+		queues: queues,
+		window: window,
+		random: random,
+		log:    log,
+	}
+}
+
+// Requeue adds the operations to the queues ordered by the priority of their types. Each operation gets its own slot
+// of the window and is delayed by a random time within the slot, so the operations of higher priority are processed first.
+func (r *Requeuer) Requeue(operations []model.Operation) {
+	ordered := make([]model.Operation, 0, len(operations))
+	for _, operation := range operations {
+		if _, found := r.queues[operation.Type]; !found {
+			r.log.Warnf("Operation %s of type %s is not re-enqueued, there is no queue for this operation type", operation.ID, operation.Type)
+			continue
+		}
+		ordered = append(ordered, operation)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return requeuePriority(ordered[i].Type) < requeuePriority(ordered[j].Type)
+	})
+
+	if r.window <= 0 || len(ordered) == 0 {
+		for _, operation := range ordered {
+			r.queues[operation.Type].Add(operation.ID)
+		}
+		return
+	}
+
+	slot := r.window / time.Duration(len(ordered))
+	for i, operation := range ordered {
+		delay := time.Duration(i) * slot
+		if slot > 0 {
+			delay += time.Duration(r.random.Int63n(int64(slot)))
+		}
+		r.queues[operation.Type].AddAfter(operation.ID, delay)
+	}
+
+	r.log.Infof("Re-enqueued %d operations in progress over %s", len(ordered), r.window)
+}
+
+func requeuePriority(operationType model.OperationType) int {
+	priority, found := requeuePriorities[operationType]
+	if !found {
+		return len(requeuePriorities)
+	}
+
+	return priority
+}
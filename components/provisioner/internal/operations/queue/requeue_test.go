@@ -0,0 +1,170 @@
+package queue
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+type addedOperation struct {
+	queue string
+	id    string
+	delay time.Duration
+}
+
+// recordingQueue records the operations added to the queue by all queues sharing the same slice
+type recordingQueue struct {
+	OperationQueue
+	name  string
+	added *[]addedOperation
+}
+
+func (q *recordingQueue) Add(operationID string) {
+	*q.added = append(*q.added, addedOperation{queue: q.name, id: operationID})
+}
+
+func (q *recordingQueue) AddAfter(operationID string, delay time.Duration) {
+	*q.added = append(*q.added, addedOperation{queue: q.name, id: operationID, delay: delay})
+}
+
+func TestRequeuer_Requeue(t *testing.T) {
+	operations := []model.Operation{
+		{ID: "upgrade-shoot", Type: model.UpgradeShoot},
+		{ID: "provision", Type: model.Provision},
+		{ID: "hibernate", Type: model.Hibernate},
+		{ID: "deprovision", Type: model.Deprovision},
+		{ID: "upgrade", Type: model.Upgrade},
+		{ID: "cleanup", Type: model.CleanupFailedProvisioning},
+		{ID: "unknown", Type: model.OperationType("UNKNOWN")},
+	}
+
+	newQueues := func(added *[]addedOperation) map[model.OperationType]OperationQueue {
+		deprovisioningQueue := &recordingQueue{name: "deprovisioning", added: added}
+		hibernationQueue := &recordingQueue{name: "hibernation", added: added}
+		return map[model.OperationType]OperationQueue{
+			model.Provision:                 &recordingQueue{name: "provisioning", added: added},
+			model.Deprovision:               deprovisioningQueue,
+			model.CleanupFailedProvisioning: deprovisioningQueue,
+			model.Upgrade:                   &recordingQueue{name: "upgrade", added: added},
+			model.UpgradeShoot:              &recordingQueue{name: "shootUpgrade", added: added},
+			model.Hibernate:                 hibernationQueue,
+			model.WakeUp:                    hibernationQueue,
+		}
+	}
+
+	t.Run("should spread operations over the window ordered by priority", func(t *testing.T) {
+		// given
+		var added []addedOperation
+		window := 60 * time.Second
+		requeuer := NewRequeuer(newQueues(&added), window, rand.New(rand.NewSource(1)), logrus.New())
+
+		// when
+		requeuer.Requeue(operations)
+
+		// then
+		require.Len(t, added, 6)
+		assert.Equal(t, []string{"deprovision", "cleanup", "provision", "upgrade-shoot", "upgrade", "hibernate"}, addedIDs(added))
+		assert.Equal(t, "deprovisioning", added[1].queue)
+
+		slot := window / 6
+		for i, operation := range added {
+			assert.GreaterOrEqual(t, int64(operation.delay), int64(time.Duration(i)*slot), operation.id)
+			assert.Less(t, int64(operation.delay), int64(time.Duration(i+1)*slot), operation.id)
+		}
+	})
+
+	t.Run("should add operations at once when window is not set", func(t *testing.T) {
+		// given
+		var added []addedOperation
+		requeuer := NewRequeuer(newQueues(&added), 0, rand.New(rand.NewSource(1)), logrus.New())
+
+		// when
+		requeuer.Requeue(operations)
+
+		// then
+		assert.Equal(t, []string{"deprovision", "cleanup", "provision", "upgrade-shoot", "upgrade", "hibernate"}, addedIDs(added))
+		for _, operation := range added {
+			assert.Zero(t, operation.delay)
+		}
+	})
+
+	t.Run("should resume operations in priority order as time passes", func(t *testing.T) {
+		// given
+		fakeClock := clock.NewFakeClock(time.Now())
+		deprovisioningQueue := NewQueueWithClock(&executorStub{}, fakeClock)
+		provisioningQueue := NewQueueWithClock(&executorStub{}, fakeClock)
+		requeuer := NewRequeuer(map[model.OperationType]OperationQueue{
+			model.Provision:   provisioningQueue,
+			model.Deprovision: deprovisioningQueue,
+		}, 10*time.Second, rand.New(rand.NewSource(1)), logrus.New())
+
+		// when
+		requeuer.Requeue([]model.Operation{
+			{ID: "provision", Type: model.Provision},
+			{ID: "deprovision", Type: model.Deprovision},
+		})
+
+		// then
+		assert.Equal(t, 0, deprovisioningQueue.Len())
+		assert.Equal(t, 0, provisioningQueue.Len())
+
+		// when
+		stepWhenWaiting(t, fakeClock, 5*time.Second)
+
+		// then
+		require.Eventually(t, func() bool {
+			return deprovisioningQueue.Len() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 0, provisioningQueue.Len())
+
+		// when
+		stepWhenWaiting(t, fakeClock, 5*time.Second)
+
+		// then
+		require.Eventually(t, func() bool {
+			return provisioningQueue.Len() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestQueue_AddAfterWithClock(t *testing.T) {
+	// given
+	fakeClock := clock.NewFakeClock(time.Now())
+	queue := NewQueueWithClock(&executorStub{}, fakeClock)
+
+	// when
+	queue.AddAfter("operation-1", time.Minute)
+
+	// then
+	stepWhenWaiting(t, fakeClock, 59*time.Second)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, queue.Len())
+
+	// when
+	fakeClock.Step(time.Second)
+
+	// then
+	require.Eventually(t, func() bool {
+		return queue.Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// stepWhenWaiting advances the clock once the delaying queue waits for it, so that the step is not missed
+func stepWhenWaiting(t *testing.T, fakeClock *clock.FakeClock, duration time.Duration) {
+	require.Eventually(t, fakeClock.HasWaiters, 5*time.Second, 10*time.Millisecond)
+	fakeClock.Step(duration)
+}
+
+func addedIDs(added []addedOperation) []string {
+	ids := make([]string, 0, len(added))
+	for _, operation := range added {
+		ids = append(ids, operation.id)
+	}
+	return ids
+}
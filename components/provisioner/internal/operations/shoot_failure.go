@@ -0,0 +1,90 @@
+package operations
+
+import (
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+)
+
+// shootFailure describes the failure of the Shoot operation caused by the hyperscaler account of the user,
+// the remediation is appended to the operation message, so that it reaches the user through KEB
+type shootFailure struct {
+	codes       []gardencorev1beta1.ErrorCode
+	cause       apperrors.CauseCode
+	retryable   bool
+	remediation string
+}
+
+// shootFailures are matched in order, so that the credentials are reported first when Gardener returns several codes
+var shootFailures = []shootFailure{
+	{
+		codes:       []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthorized, gardencorev1beta1.ErrorInfraInsufficientPrivileges},
+		cause:       apperrors.ClientCredentialsInvalid,
+		remediation: "The hyperscaler account credentials are invalid or do not have sufficient permissions. Update the credentials and retry the operation.",
+	},
+	{
+		codes:       []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraQuotaExceeded, gardencorev1beta1.ErrorInfraResourcesDepleted},
+		cause:       apperrors.QuotaExceeded,
+		remediation: "The hyperscaler account quota is exceeded or the resources are not available in the region. Increase the quota or release unused resources and retry the operation.",
+	},
+	{
+		codes:       []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraDependencies},
+		cause:       apperrors.InfraDependencies,
+		remediation: "The infrastructure required by the cluster, such as the network or enough zones in the region, is missing or misconfigured in the hyperscaler account. Fix the hyperscaler account or choose other zones and retry the operation.",
+	},
+	{
+		codes:       []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraRateLimitsExceeded},
+		cause:       apperrors.RateLimitsExceeded,
+		retryable:   true,
+		remediation: "The hyperscaler account API rate limits are exceeded. The operation is retried automatically.",
+	},
+}
+
+// NewShootFailedError returns error of the failed Shoot operation classified by the last errors reported by Gardener,
+// failures caused by the configuration or the account of the user are reported as bad requests.
+// The failures which are expected to pass without user action are not wrapped in NonRecoverableError, so that the stage is retried.
+func NewShootFailedError(lastErrors []gardencorev1beta1.LastError, format string, a ...interface{}) error {
+	for _, failure := range shootFailures {
+		if !hasAnyErrorCode(lastErrors, failure.codes) {
+			continue
+		}
+
+		message := fmt.Sprintf("%s. %s", fmt.Sprintf(format, a...), failure.remediation)
+		if failure.retryable {
+			return apperrors.FailedTemporarily(failure.cause, "%s", message).SetComponent(apperrors.ErrComponentGardener)
+		}
+
+		return NewNonRecoverableError(apperrors.FailedPermanently(failure.cause, "%s", message).SetComponent(apperrors.ErrComponentGardener))
+	}
+
+	var err apperrors.AppError
+	if gardencorev1beta1helper.HasErrorCode(lastErrors, gardencorev1beta1.ErrorConfigurationProblem) {
+		err = apperrors.BadRequest(format, a...)
+	} else {
+		err = apperrors.Internal(format, a...)
+	}
+
+	return NewNonRecoverableError(err.SetComponent(apperrors.ErrComponentGardener))
+}
+
+func hasAnyErrorCode(lastErrors []gardencorev1beta1.LastError, codes []gardencorev1beta1.ErrorCode) bool {
+	for _, code := range codes {
+		if gardencorev1beta1helper.HasErrorCode(lastErrors, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShootErrorCodes returns the error codes of all last errors of the Shoot
+func ShootErrorCodes(lastErrors []gardencorev1beta1.LastError) []gardencorev1beta1.ErrorCode {
+	var codes []gardencorev1beta1.ErrorCode
+	for _, lastError := range lastErrors {
+		codes = append(codes, lastError.Codes...)
+	}
+
+	return codes
+}
@@ -0,0 +1,75 @@
+package operations
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+)
+
+// timeoutWarningThreshold is the part of the stage time limit after which a warning is logged, so that the operation can be looked into before it fails
+const timeoutWarningThreshold = 0.8
+
+// StageTimeoutError is returned when the operation exceeds the time limit of its stage
+type StageTimeoutError struct {
+	Stage model.OperationStage
+	error apperrors.AppError
+}
+
+func (e StageTimeoutError) Error() string {
+	return e.error.Error()
+}
+
+func (e StageTimeoutError) Unwrap() error {
+	return e.error
+}
+
+// Reason returns the failure reason distinguishing the timeout from other internal errors, e.g. timeout:WaitingForInstallation
+func (e StageTimeoutError) Reason() apperrors.ErrReason {
+	return apperrors.ErrReason(fmt.Sprintf("timeout:%s", e.Stage))
+}
+
+func NewStageTimeoutError(stage model.OperationStage) NonRecoverableError {
+	return NewNonRecoverableError(StageTimeoutError{
+		Stage: stage,
+		error: apperrors.Internal("error: timeout while processing operation").SetComponent(apperrors.ErrComponentProvisioner),
+	})
+}
+
+// timeoutWarnings remembers the stages of operations for which the warning about the approaching timeout was logged,
+// so that it is logged once per stage and not each time the operation is processed
+type timeoutWarnings struct {
+	mutex  sync.Mutex
+	warned map[string]model.OperationStage
+}
+
+func newTimeoutWarnings() *timeoutWarnings {
+	return &timeoutWarnings{
+		warned: map[string]model.OperationStage{},
+	}
+}
+
+func (w *timeoutWarnings) shouldWarn(operation model.Operation, timePassed, timeout time.Duration) bool {
+	if timePassed < time.Duration(float64(timeout)*timeoutWarningThreshold) {
+		return false
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if stage, found := w.warned[operation.ID]; found && stage == operation.Stage {
+		return false
+	}
+	w.warned[operation.ID] = operation.Stage
+
+	return true
+}
+
+func (w *timeoutWarnings) clear(operationID string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	delete(w.warned, operationID)
+}
@@ -0,0 +1,172 @@
+package operations
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	directorMocks "github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/failure"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_StageTimeouts(t *testing.T) {
+	stageStart := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	timeLimit := 10 * time.Second
+
+	operation := model.Operation{
+		ID:             operationId,
+		Type:           model.Upgrade,
+		StartTimestamp: stageStart,
+		State:          model.InProgress,
+		ClusterID:      clusterId,
+		Stage:          model.WaitingForInstallation,
+		LastTransition: &stageStart,
+	}
+
+	newExecutor := func(dbSession *mocks.ReadWriteSession, step Step, now *time.Time) (*Executor, *test.Hook) {
+		directorClient := &directorMocks.DirectorClient{}
+		directorClient.On("SetRuntimeStatusCondition", clusterId, graphql.RuntimeStatusConditionFailed, mock.AnythingOfType("string")).Return(nil)
+
+		executor := NewExecutor(dbSession, model.Upgrade, map[model.OperationStage]Step{step.Name(): step}, failure.NewNoopFailureHandler(), directorClient, nil)
+		executor.now = func() time.Time {
+			return *now
+		}
+		logger, hook := test.NewNullLogger()
+		executor.log = logger.WithField("Component", "Executor")
+
+		return executor, hook
+	}
+
+	t.Run("should fail operation with timeout reason when time limit of the stage is exceeded", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(model.Cluster{ID: clusterId}, nil)
+		dbSession.On("UpdateOperationState", operationId, 0, "error: timeout while processing operation", model.Failed, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		dbSession.On("UpdateOperationFailureReason", operationId, "timeout:WaitingForInstallation").Return(nil)
+
+		now := stageStart.Add(timeLimit + time.Second)
+		step := NewMockStep(model.WaitingForInstallation, model.WaitingForInstallation, time.Second, timeLimit)
+		executor, _ := newExecutor(dbSession, step, &now)
+
+		timeouts := stageTimeoutsTotal.WithLabelValues(string(model.Upgrade), string(model.WaitingForInstallation))
+		failed := failedOperationsTotal.WithLabelValues(string(model.Upgrade), "timeout:WaitingForInstallation", "provisioner")
+		initialTimeouts := testutil.ToFloat64(timeouts)
+		initialFailed := testutil.ToFloat64(failed)
+
+		// when
+		result := executor.Execute(operationId)
+
+		// then
+		assert.False(t, result.Requeue)
+		assert.False(t, step.called)
+		assert.Equal(t, initialTimeouts+1, testutil.ToFloat64(timeouts))
+		assert.Equal(t, initialFailed+1, testutil.ToFloat64(failed))
+		dbSession.AssertExpectations(t)
+	})
+
+	t.Run("should log warning once when operation is close to the time limit of the stage", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil)
+		dbSession.On("GetCluster", clusterId).Return(model.Cluster{ID: clusterId}, nil)
+
+		now := stageStart.Add(7 * time.Second)
+		step := NewMockStep(model.WaitingForInstallation, model.WaitingForInstallation, time.Second, timeLimit)
+		executor, hook := newExecutor(dbSession, step, &now)
+
+		timeouts := stageTimeoutsTotal.WithLabelValues(string(model.Upgrade), string(model.WaitingForInstallation))
+		initialTimeouts := testutil.ToFloat64(timeouts)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		assert.Empty(t, warnings(hook))
+
+		// given
+		now = stageStart.Add(8 * time.Second)
+
+		// when
+		executor.Execute(operationId)
+		now = stageStart.Add(9 * time.Second)
+		result := executor.Execute(operationId)
+
+		// then
+		assert.True(t, result.Requeue)
+		assert.Len(t, warnings(hook), 1)
+		assert.Equal(t, initialTimeouts, testutil.ToFloat64(timeouts))
+	})
+
+	t.Run("should log warning again for the next stage", func(t *testing.T) {
+		// given
+		dbSession := &mocks.ReadWriteSession{}
+		dbSession.On("GetOperation", operationId).Return(operation, nil).Once()
+		dbSession.On("GetCluster", clusterId).Return(model.Cluster{ID: clusterId}, nil)
+
+		now := stageStart.Add(9 * time.Second)
+		step := NewMockStep(model.WaitingForInstallation, model.WaitingForInstallation, time.Second, timeLimit)
+		executor, hook := newExecutor(dbSession, step, &now)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		assert.Len(t, warnings(hook), 1)
+
+		// given
+		transition := stageStart.Add(10 * time.Second)
+		nextStageOperation := operation
+		nextStageOperation.Stage = model.ConnectRuntimeAgent
+		nextStageOperation.LastTransition = &transition
+		dbSession.On("GetOperation", operationId).Return(nextStageOperation, nil)
+
+		nextStep := NewMockStep(model.ConnectRuntimeAgent, model.ConnectRuntimeAgent, time.Second, timeLimit)
+		executor.stages[model.ConnectRuntimeAgent] = nextStep
+		now = transition.Add(9 * time.Second)
+
+		// when
+		executor.Execute(operationId)
+
+		// then
+		assert.True(t, nextStep.called)
+		assert.Len(t, warnings(hook), 2)
+	})
+}
+
+func TestStageTimeoutError(t *testing.T) {
+	// when
+	err := NewStageTimeoutError(model.WaitingForClusterCreation)
+
+	// then
+	var timeoutErr StageTimeoutError
+	require.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, apperrors.ErrReason("timeout:WaitingForClusterCreation"), timeoutErr.Reason())
+	assert.Equal(t, "error: timeout while processing operation", err.Error())
+
+	reason, component := apperrors.Classify(err)
+	assert.Equal(t, apperrors.ErrReasonInternal, reason)
+	assert.Equal(t, apperrors.ErrComponentProvisioner, component)
+}
+
+func warnings(hook *test.Hook) []*logrus.Entry {
+	var entries []*logrus.Entry
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
@@ -17,10 +17,10 @@ type CleanupClusterStep struct {
 	installationService installation.Service
 	nextStep            model.OperationStage
 	timeLimit           time.Duration
-	gardenerClient      GardenerClient
+	gardenerClient      GardenerClientProvider
 }
 
-func NewCleanupClusterStep(gardenerClient GardenerClient, installationService installation.Service, nextStep model.OperationStage, timeLimit time.Duration) *CleanupClusterStep {
+func NewCleanupClusterStep(gardenerClient GardenerClientProvider, installationService installation.Service, nextStep model.OperationStage, timeLimit time.Duration) *CleanupClusterStep {
 	return &CleanupClusterStep{
 		installationService: installationService,
 		nextStep:            nextStep,
@@ -37,14 +37,20 @@ func (s *CleanupClusterStep) TimeLimit() time.Duration {
 	return s.timeLimit
 }
 
-func (s *CleanupClusterStep) Run(cluster model.Cluster, _ model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+func (s *CleanupClusterStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
 	logger.Debugf("Starting cleanup cluster step for %s ...", cluster.ID)
+	if operation.Force {
+		// Force deprovisioning skips in-cluster actions as the cluster is unusable
+		logger.Infof("Skipping %s stage for force deprovisioning of %s", s.Name(), cluster.ID)
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
 	if cluster.Kubeconfig == nil {
 		// Kubeconfig can be nil if Gardener failed to create cluster. We must go to the next step to finalize deprovisioning
 		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
 	}
 
-	shoot, err := s.gardenerClient.Get(context.Background(), cluster.ClusterConfig.Name, metav1.GetOptions{})
+	shoot, err := s.gardenerClient(cluster.ClusterConfig.ProjectName).Get(context.Background(), cluster.ClusterConfig.Name, metav1.GetOptions{})
 	if err != nil {
 		return operations.StageResult{}, err
 	}
@@ -60,10 +66,11 @@ func (s *CleanupClusterStep) Run(cluster model.Cluster, _ model.Operation, logge
 		return operations.StageResult{}, operations.NewNonRecoverableError(err)
 	}
 
-	err = s.installationService.PerformCleanup(k8sConfig)
+	summary, err := s.installationService.PerformCleanup(k8sConfig)
 	if err != nil {
 		return operations.StageResult{}, err
 	}
+	logger.Infof("%s for %s", summary, cluster.ID)
 
-	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	return operations.StageResult{Stage: s.nextStep, Delay: 0, Message: summary.String()}, nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/installation"
 	installationMocks "github.com/kyma-project/control-plane/components/provisioner/internal/installation/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	gardener_mocks "github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/deprovisioning/mocks"
@@ -82,7 +83,7 @@ func TestCleanupCluster_Run(t *testing.T) {
 					ToShoot()
 
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(shoot, nil)
-				installationSvc.On("PerformCleanup", mock.AnythingOfType("*rest.Config")).Return(nil)
+				installationSvc.On("PerformCleanup", mock.AnythingOfType("*rest.Config")).Return(installation.CleanupSummary{}, nil)
 			},
 			expectedStage: nextStageName,
 			expectedDelay: 0,
@@ -96,7 +97,7 @@ func TestCleanupCluster_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, installationSvc)
 
-			cleanupClusterStep := NewCleanupClusterStep(gardenerClient, installationSvc, nextStageName, 10*time.Minute)
+			cleanupClusterStep := NewCleanupClusterStep(gardenerClientProvider(gardenerClient), installationSvc, nextStageName, 10*time.Minute)
 
 			// when
 			result, err := cleanupClusterStep.Run(testCase.cluster, model.Operation{}, logrus.New())
@@ -110,6 +111,51 @@ func TestCleanupCluster_Run(t *testing.T) {
 		})
 	}
 
+	t.Run("should report selectors skipped during cleanup", func(t *testing.T) {
+		// given
+		installationSvc := &installationMocks.Service{}
+		gardenerClient := &gardener_mocks.GardenerClient{}
+
+		shoot := testkit.NewTestShoot(clusterName).
+			InNamespace(gardenerNamespace).
+			WithHibernationState(true, false).
+			ToShoot()
+		summary := installation.CleanupSummary{
+			Skipped: []installation.CleanupSelector{{Kind: "ServiceBinding", Group: "services.cloud.sap.com", Timeout: 5 * time.Minute}},
+		}
+
+		gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(shoot, nil)
+		installationSvc.On("PerformCleanup", mock.AnythingOfType("*rest.Config")).Return(summary, nil)
+
+		cleanupClusterStep := NewCleanupClusterStep(gardenerClientProvider(gardenerClient), installationSvc, nextStageName, 10*time.Minute)
+
+		// when
+		result, err := cleanupClusterStep.Run(clusterWithKubeconfig, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+		assert.Equal(t, "Cluster cleanup finished, skipped: ServiceBinding.services.cloud.sap.com (timeout 5m0s)", result.Message)
+	})
+
+	t.Run("should skip the step for force deprovisioning", func(t *testing.T) {
+		// given
+		installationSvc := &installationMocks.Service{}
+		gardenerClient := &gardener_mocks.GardenerClient{}
+
+		cleanupClusterStep := NewCleanupClusterStep(gardenerClientProvider(gardenerClient), installationSvc, nextStageName, 10*time.Minute)
+
+		// when
+		result, err := cleanupClusterStep.Run(clusterWithKubeconfig, model.Operation{Force: true}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+		assert.Equal(t, time.Duration(0), result.Delay)
+		installationSvc.AssertNotCalled(t, "PerformCleanup", mock.Anything)
+		gardenerClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	for _, testCase := range []struct {
 		description        string
 		mockFunc           func(gardenerClient *gardener_mocks.GardenerClient, installationSvc *installationMocks.Service)
@@ -145,7 +191,7 @@ func TestCleanupCluster_Run(t *testing.T) {
 					ToShoot()
 
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(shoot, nil)
-				installationSvc.On("PerformCleanup", mock.AnythingOfType("*rest.Config")).Return(errors.New("some error"))
+				installationSvc.On("PerformCleanup", mock.AnythingOfType("*rest.Config")).Return(installation.CleanupSummary{}, errors.New("some error"))
 			},
 			cluster:            clusterWithKubeconfig,
 			unrecoverableError: false,
@@ -158,7 +204,7 @@ func TestCleanupCluster_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, installationSvc)
 
-			cleanupClusterStep := NewCleanupClusterStep(gardenerClient, installationSvc, nextStageName, 10*time.Minute)
+			cleanupClusterStep := NewCleanupClusterStep(gardenerClientProvider(gardenerClient), installationSvc, nextStageName, 10*time.Minute)
 
 			// when
 			_, err := cleanupClusterStep.Run(testCase.cluster, model.Operation{}, logrus.New())
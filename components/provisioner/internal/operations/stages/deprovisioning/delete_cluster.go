@@ -2,33 +2,55 @@ package deprovisioning
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 	"github.com/sirupsen/logrus"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	deletionConfirmationAnnotation = "confirmation.gardener.cloud/deletion"
+
+	defaultDeletionStartTimeout      = 30 * time.Second
+	defaultDeletionStartPollInterval = 2 * time.Second
 )
 
 type DeleteClusterStep struct {
-	gardenerClient GardenerClient
+	gardenerClient GardenerClientProvider
 	nextStep       model.OperationStage
 	timeLimit      time.Duration
+
+	deletionStartTimeout      time.Duration
+	deletionStartPollInterval time.Duration
 }
 
 //go:generate mockery -name=GardenerClient
 type GardenerClient interface {
 	Get(ctx context.Context, name string, options metav1.GetOptions) (*gardener_types.Shoot, error)
+	Update(ctx context.Context, shoot *gardener_types.Shoot, options metav1.UpdateOptions) (*gardener_types.Shoot, error)
 	Delete(ctx context.Context, name string, options metav1.DeleteOptions) error
 }
 
-func NewDeleteClusterStep(gardenerClient GardenerClient, nextStep model.OperationStage, timeLimit time.Duration) *DeleteClusterStep {
+// GardenerClientProvider returns the client of Shoots in the namespace of the Gardener project
+type GardenerClientProvider func(project string) GardenerClient
+
+func NewDeleteClusterStep(gardenerClient GardenerClientProvider, nextStep model.OperationStage, timeLimit time.Duration) *DeleteClusterStep {
 	return &DeleteClusterStep{
-		gardenerClient: gardenerClient,
-		nextStep:       nextStep,
-		timeLimit:      timeLimit,
+		gardenerClient:            gardenerClient,
+		nextStep:                  nextStep,
+		timeLimit:                 timeLimit,
+		deletionStartTimeout:      defaultDeletionStartTimeout,
+		deletionStartPollInterval: defaultDeletionStartPollInterval,
 	}
 }
 
@@ -42,7 +64,7 @@ func (s *DeleteClusterStep) TimeLimit() time.Duration {
 
 func (s *DeleteClusterStep) Run(cluster model.Cluster, _ model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
 
-	err := s.deleteShoot(cluster.ClusterConfig.Name)
+	err := s.deleteShoot(cluster.ClusterConfig.ProjectName, cluster.ClusterConfig.Name, logger)
 	if err != nil {
 		return operations.StageResult{}, err
 	}
@@ -50,14 +72,118 @@ func (s *DeleteClusterStep) Run(cluster model.Cluster, _ model.Operation, logger
 	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
 }
 
-func (s *DeleteClusterStep) deleteShoot(gardenerClusterName string) error {
-	err := s.gardenerClient.Delete(context.Background(), gardenerClusterName, metav1.DeleteOptions{})
+// deleteShoot confirms the deletion of the Shoot and deletes it. Gardener ignores the deletion of Shoots which are not
+// confirmed, so the step checks that the deletion has started and reports the condition blocking it otherwise.
+func (s *DeleteClusterStep) deleteShoot(project, gardenerClusterName string, logger logrus.FieldLogger) error {
+	client := s.gardenerClient(project)
+
+	deleting, err := s.confirmDeletion(client, gardenerClusterName)
+	if err != nil {
+		return err
+	}
+	if deleting {
+		logger.Infof("Shoot %s is already being deleted", gardenerClusterName)
+		return nil
+	}
+
+	err = client.Delete(context.Background(), gardenerClusterName, metav1.DeleteOptions{})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			return nil
 		}
+		if k8serrors.IsForbidden(err) {
+			return apperrors.Internal("error: deletion of Shoot %s rejected by Gardener: %s", gardenerClusterName, err.Error()).
+				SetComponent(apperrors.ErrComponentGardener)
+		}
 		return err
 	}
 
-	return nil
+	return s.waitForDeletionStart(client, gardenerClusterName)
+}
+
+// confirmDeletion sets the deletion confirmation annotation on the Shoot retrying on conflicts, it returns true if
+// the Shoot does not exist or is already being deleted
+func (s *DeleteClusterStep) confirmDeletion(client GardenerClient, gardenerClusterName string) (bool, error) {
+	deleting := false
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		shoot, err := client.Get(context.Background(), gardenerClusterName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if shoot.DeletionTimestamp != nil {
+			deleting = true
+			return nil
+		}
+		if deletionConfirmed(shoot) {
+			return nil
+		}
+
+		if shoot.Annotations == nil {
+			shoot.Annotations = map[string]string{}
+		}
+		shoot.Annotations[deletionConfirmationAnnotation] = "true"
+
+		_, err = client.Update(context.Background(), shoot, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, util.K8SErrorToAppError(err).Append("error confirming deletion of Shoot %s", gardenerClusterName)
+	}
+
+	return deleting, nil
+}
+
+func (s *DeleteClusterStep) waitForDeletionStart(client GardenerClient, gardenerClusterName string) error {
+	var shoot *gardener_types.Shoot
+
+	err := wait.PollImmediate(s.deletionStartPollInterval, s.deletionStartTimeout, func() (bool, error) {
+		var err error
+		shoot, err = client.Get(context.Background(), gardenerClusterName, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+
+		return shoot.DeletionTimestamp != nil, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if err != wait.ErrWaitTimeout || shoot == nil {
+		return err
+	}
+
+	return apperrors.Internal("error: deletion of Shoot %s has not started within %s: %s",
+		gardenerClusterName, s.deletionStartTimeout, deletionBlocker(shoot)).SetComponent(apperrors.ErrComponentGardener)
+}
+
+// deletionBlocker describes why Gardener could have ignored the deletion of the Shoot
+func deletionBlocker(shoot *gardener_types.Shoot) string {
+	if !deletionConfirmed(shoot) {
+		return "the " + deletionConfirmationAnnotation + " annotation was removed from the Shoot"
+	}
+
+	protection := make([]string, 0)
+	for key, value := range shoot.Annotations {
+		if strings.Contains(key, "deletion-protection") || strings.Contains(key, "deletion-protected") {
+			protection = append(protection, key+"="+value)
+		}
+	}
+	if len(protection) > 0 {
+		sort.Strings(protection)
+		return "the Shoot is protected from deletion by the annotations " + strings.Join(protection, ", ")
+	}
+
+	return "the deletion was confirmed but Gardener did not mark the Shoot for deletion"
+}
+
+func deletionConfirmed(shoot *gardener_types.Shoot) bool {
+	return shoot.Annotations[deletionConfirmationAnnotation] == "true"
 }
@@ -6,19 +6,16 @@ import (
 	"testing"
 	"time"
 
-	"k8s.io/apimachinery/pkg/runtime/schema"
-
-	directorMocks "github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
-	installationMocks "github.com/kyma-project/control-plane/components/provisioner/internal/installation/mocks"
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
-	gardener_mocks "github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/deprovisioning/mocks"
-	dbMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
@@ -38,83 +35,204 @@ func TestDeprovisionCluster_Run(t *testing.T) {
 
 	for _, testCase := range []struct {
 		description   string
-		mockFunc      func(gardenerClient *gardener_mocks.GardenerClient)
-		expectedStage model.OperationStage
-		expectedDelay time.Duration
+		shootClient   *fakeShootClient
+		expectDeleted bool
 	}{
 		{
-			description: "should go to the next step when Shoot was deleted successfully",
-			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient) {
-				gardenerClient.On("Delete", context.Background(), clusterName, mock.Anything).Return(nil)
-			},
-			expectedStage: nextStageName,
-			expectedDelay: 0,
+			description:   "should confirm deletion and go to the next step when Shoot was deleted successfully",
+			shootClient:   &fakeShootClient{shoot: fixShoot(nil)},
+			expectDeleted: true,
+		},
+		{
+			description:   "should retry confirming deletion on conflicts",
+			shootClient:   &fakeShootClient{shoot: fixShoot(nil), conflicts: 2},
+			expectDeleted: true,
+		},
+		{
+			description:   "should delete Shoot with deletion already confirmed",
+			shootClient:   &fakeShootClient{shoot: fixShoot(map[string]string{deletionConfirmationAnnotation: "true"})},
+			expectDeleted: true,
+		},
+		{
+			description: "should go to the next step when Shoot is already being deleted",
+			shootClient: &fakeShootClient{shoot: func() *gardener_types.Shoot {
+				shoot := fixShoot(nil)
+				shoot.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return shoot
+			}()},
 		},
 		{
 			description: "should go to the next step when Shoot not exists",
-			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient) {
-				gardenerClient.On("Delete", context.Background(), clusterName, mock.Anything).Return(k8serrors.NewNotFound(schema.GroupResource{}, ""))
-			},
-			expectedStage: nextStageName,
-			expectedDelay: 0,
+			shootClient: &fakeShootClient{},
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
-			gardenerClient := &gardener_mocks.GardenerClient{}
-
-			testCase.mockFunc(gardenerClient)
-
-			deleteClusterStep := NewDeleteClusterStep(gardenerClient, nextStageName, 10*time.Minute)
+			deleteClusterStep := newTestDeleteClusterStep(testCase.shootClient)
 
 			// when
 			result, err := deleteClusterStep.Run(cluster, model.Operation{}, logrus.New())
 
 			// then
 			require.NoError(t, err)
-			assert.Equal(t, testCase.expectedStage, result.Stage)
-			assert.Equal(t, testCase.expectedDelay, result.Delay)
-			gardenerClient.AssertExpectations(t)
+			assert.Equal(t, nextStageName, result.Stage)
+			assert.Equal(t, time.Duration(0), result.Delay)
+			assert.Equal(t, testCase.expectDeleted, testCase.shootClient.deleteCalled)
+			if testCase.expectDeleted {
+				assert.NotNil(t, testCase.shootClient.shoot.DeletionTimestamp)
+				assert.Equal(t, "true", testCase.shootClient.shoot.Annotations[deletionConfirmationAnnotation])
+			}
 		})
 	}
 
 	for _, testCase := range []struct {
-		description        string
-		mockFunc           func(gardenerClient *gardener_mocks.GardenerClient)
-		cluster            model.Cluster
-		unrecoverableError bool
+		description     string
+		shootClient     *fakeShootClient
+		expectedMessage string
 	}{
 		{
-			description: "should return error when failed to delete shoot",
-			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient) {
-				gardenerClient.On("Delete", context.Background(), clusterName, mock.Anything).Return(errors.New("some error"))
+			description:     "should return error when failed to delete shoot",
+			shootClient:     &fakeShootClient{shoot: fixShoot(nil), deleteErr: errors.New("some error")},
+			expectedMessage: "some error",
+		},
+		{
+			description:     "should return error when failed to confirm deletion",
+			shootClient:     &fakeShootClient{shoot: fixShoot(nil), conflicts: 100},
+			expectedMessage: "error confirming deletion of Shoot",
+		},
+		{
+			description:     "should return error naming rejected deletion",
+			shootClient:     &fakeShootClient{shoot: fixShoot(nil), removeConfirmationOnUpdate: true},
+			expectedMessage: "deletion of Shoot " + clusterName + " rejected by Gardener",
+		},
+		{
+			description:     "should return error naming removed confirmation annotation",
+			shootClient:     &fakeShootClient{shoot: fixShoot(nil), removeConfirmationOnDelete: true},
+			expectedMessage: "the " + deletionConfirmationAnnotation + " annotation was removed from the Shoot",
+		},
+		{
+			description: "should return error naming deletion protection",
+			shootClient: &fakeShootClient{
+				shoot:     fixShoot(map[string]string{"shoot.example.com/deletion-protection": "enabled"}),
+				protected: true,
 			},
-			cluster:            cluster,
-			unrecoverableError: false,
+			expectedMessage: "protected from deletion by the annotations shoot.example.com/deletion-protection=enabled",
+		},
+		{
+			description:     "should return error when deletion did not start",
+			shootClient:     &fakeShootClient{shoot: fixShoot(nil), protected: true},
+			expectedMessage: "Gardener did not mark the Shoot for deletion",
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
-			installationSvc := &installationMocks.Service{}
-			gardenerClient := &gardener_mocks.GardenerClient{}
-			dbSessionFactory := &dbMocks.Factory{}
-			directorClient := &directorMocks.DirectorClient{}
-
-			testCase.mockFunc(gardenerClient)
-
-			deleteClusterStep := NewDeleteClusterStep(gardenerClient, nextStageName, 10*time.Minute)
+			deleteClusterStep := newTestDeleteClusterStep(testCase.shootClient)
 
 			// when
-			_, err := deleteClusterStep.Run(testCase.cluster, model.Operation{}, logrus.New())
+			_, err := deleteClusterStep.Run(cluster, model.Operation{}, logrus.New())
 
 			// then
 			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedMessage)
 			nonRecoverable := operations.NonRecoverableError{}
-			require.Equal(t, testCase.unrecoverableError, errors.As(err, &nonRecoverable))
-			installationSvc.AssertExpectations(t)
-			gardenerClient.AssertExpectations(t)
-			dbSessionFactory.AssertExpectations(t)
-			directorClient.AssertExpectations(t)
+			require.False(t, errors.As(err, &nonRecoverable))
 		})
 	}
+
+	t.Run("should classify blocked deletion as Gardener error", func(t *testing.T) {
+		// given
+		deleteClusterStep := newTestDeleteClusterStep(&fakeShootClient{shoot: fixShoot(nil), protected: true})
+
+		// when
+		_, err := deleteClusterStep.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		_, component := apperrors.Classify(err)
+		assert.Equal(t, apperrors.ErrComponentGardener, component)
+	})
+}
+
+func newTestDeleteClusterStep(client GardenerClient) *DeleteClusterStep {
+	step := NewDeleteClusterStep(gardenerClientProvider(client), nextStageName, 10*time.Minute)
+	step.deletionStartTimeout = 50 * time.Millisecond
+	step.deletionStartPollInterval = 10 * time.Millisecond
+
+	return step
+}
+
+func fixShoot(annotations map[string]string) *gardener_types.Shoot {
+	return &gardener_types.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        clusterName,
+			Annotations: annotations,
+		},
+	}
+}
+
+// fakeShootClient simulates Gardener which rejects the deletion of Shoots without the confirmation annotation and
+// ignores the deletion of protected Shoots
+type fakeShootClient struct {
+	shoot     *gardener_types.Shoot
+	conflicts int
+	deleteErr error
+	protected bool
+
+	removeConfirmationOnUpdate bool
+	removeConfirmationOnDelete bool
+
+	deleteCalled bool
+}
+
+func (c *fakeShootClient) Get(_ context.Context, name string, _ metav1.GetOptions) (*gardener_types.Shoot, error) {
+	if c.shoot == nil {
+		return nil, k8serrors.NewNotFound(schema.GroupResource{}, name)
+	}
+
+	return c.shoot.DeepCopy(), nil
+}
+
+func (c *fakeShootClient) Update(_ context.Context, shoot *gardener_types.Shoot, _ metav1.UpdateOptions) (*gardener_types.Shoot, error) {
+	if c.conflicts > 0 {
+		c.conflicts--
+		return nil, k8serrors.NewConflict(schema.GroupResource{}, shoot.Name, errors.New("object has been modified"))
+	}
+
+	c.shoot = shoot.DeepCopy()
+	if c.removeConfirmationOnUpdate {
+		delete(c.shoot.Annotations, deletionConfirmationAnnotation)
+	}
+
+	return c.shoot.DeepCopy(), nil
+}
+
+func (c *fakeShootClient) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	c.deleteCalled = true
+
+	if c.deleteErr != nil {
+		return c.deleteErr
+	}
+	if c.shoot == nil {
+		return k8serrors.NewNotFound(schema.GroupResource{}, name)
+	}
+	if !deletionConfirmed(c.shoot) {
+		return k8serrors.NewForbidden(schema.GroupResource{}, name, errors.New("must have a \"confirmation.gardener.cloud/deletion\" annotation to delete"))
+	}
+	if c.removeConfirmationOnDelete {
+		delete(c.shoot.Annotations, deletionConfirmationAnnotation)
+		return nil
+	}
+	if c.protected {
+		return nil
+	}
+
+	c.shoot.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	return nil
+}
+
+func gardenerClientProvider(client GardenerClient) GardenerClientProvider {
+	return func(string) GardenerClient {
+		return client
+	}
 }
@@ -16,13 +16,13 @@ import (
 
 type TriggerKymaUninstallStep struct {
 	installationClient installation.Service
-	gardenerClient     GardenerClient
+	gardenerClient     GardenerClientProvider
 	nextStep           model.OperationStage
 	timeLimit          time.Duration
 	delay              time.Duration
 }
 
-func NewTriggerKymaUninstallStep(gardenerClient GardenerClient, installationClient installation.Service, nextStep model.OperationStage, timeLimit time.Duration, delay time.Duration) *TriggerKymaUninstallStep {
+func NewTriggerKymaUninstallStep(gardenerClient GardenerClientProvider, installationClient installation.Service, nextStep model.OperationStage, timeLimit time.Duration, delay time.Duration) *TriggerKymaUninstallStep {
 	return &TriggerKymaUninstallStep{
 		installationClient: installationClient,
 		gardenerClient:     gardenerClient,
@@ -40,14 +40,25 @@ func (s *TriggerKymaUninstallStep) TimeLimit() time.Duration {
 	return s.timeLimit
 }
 
-func (s *TriggerKymaUninstallStep) Run(cluster model.Cluster, _ model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+func (s *TriggerKymaUninstallStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+	if operation.Force {
+		// Force deprovisioning skips in-cluster actions as the cluster is unusable
+		logger.Infof("Skipping %s stage for force deprovisioning of %s", s.Name(), cluster.ID)
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	if cluster.KymaConfig == nil {
+		// Kyma managed externally was not installed by the Provisioner, so there is nothing to uninstall
+		logger.Infof("Skipping %s stage for Runtime %s with externally managed Kyma", s.Name(), cluster.ID)
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
 
 	if cluster.Kubeconfig == nil {
 		// Kubeconfig can be nil if Gardener failed to create cluster. We must go to the next step to finalize deprovisioning
 		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
 	}
 
-	shoot, err := s.gardenerClient.Get(context.Background(), cluster.ClusterConfig.Name, metav1.GetOptions{})
+	shoot, err := s.gardenerClient(cluster.ClusterConfig.ProjectName).Get(context.Background(), cluster.ClusterConfig.Name, metav1.GetOptions{})
 	if err != nil {
 		return operations.StageResult{}, err
 	}
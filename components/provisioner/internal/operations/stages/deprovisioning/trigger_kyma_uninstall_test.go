@@ -52,12 +52,14 @@ func TestTriggerKymaUninstall_Run(t *testing.T) {
 			Name: clusterName,
 		},
 		Kubeconfig: util.StringPtr(kubeconfig),
+		KymaConfig: &model.KymaConfig{},
 	}
 
 	clusterWithoutKubeconfig := model.Cluster{
 		ClusterConfig: model.GardenerConfig{
 			Name: clusterName,
 		},
+		KymaConfig: &model.KymaConfig{},
 	}
 
 	clusterWithInvalidKubeconfig := model.Cluster{
@@ -65,6 +67,14 @@ func TestTriggerKymaUninstall_Run(t *testing.T) {
 			Name: clusterName,
 		},
 		Kubeconfig: util.StringPtr("invalid"),
+		KymaConfig: &model.KymaConfig{},
+	}
+
+	clusterWithExternallyManagedKyma := model.Cluster{
+		ClusterConfig: model.GardenerConfig{
+			Name: clusterName,
+		},
+		Kubeconfig: util.StringPtr(kubeconfig),
 	}
 
 	for _, testCase := range []struct {
@@ -82,6 +92,14 @@ func TestTriggerKymaUninstall_Run(t *testing.T) {
 			expectedDelay: 0,
 			cluster:       clusterWithoutKubeconfig,
 		},
+		{
+			description: "should go to the next step when Kyma is managed externally",
+			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, installationSvc *installationMocks.Service) {
+			},
+			expectedStage: nextStageName,
+			expectedDelay: 0,
+			cluster:       clusterWithExternallyManagedKyma,
+		},
 		{
 			description: "should go to the next step when cluster is hibernated",
 			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, installationSvc *installationMocks.Service) {
@@ -118,7 +136,7 @@ func TestTriggerKymaUninstall_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, installationSvc)
 
-			triggerKymaUninstallStep := NewTriggerKymaUninstallStep(gardenerClient, installationSvc, nextStageName, 10*time.Minute, delay)
+			triggerKymaUninstallStep := NewTriggerKymaUninstallStep(gardenerClientProvider(gardenerClient), installationSvc, nextStageName, 10*time.Minute, delay)
 
 			// when
 			result, err := triggerKymaUninstallStep.Run(testCase.cluster, model.Operation{}, logrus.New())
@@ -132,6 +150,24 @@ func TestTriggerKymaUninstall_Run(t *testing.T) {
 		})
 	}
 
+	t.Run("should skip the step for force deprovisioning", func(t *testing.T) {
+		// given
+		installationSvc := &installationMocks.Service{}
+		gardenerClient := &gardener_mocks.GardenerClient{}
+
+		triggerKymaUninstallStep := NewTriggerKymaUninstallStep(gardenerClientProvider(gardenerClient), installationSvc, nextStageName, 10*time.Minute, delay)
+
+		// when
+		result, err := triggerKymaUninstallStep.Run(clusterWithKubeconfig, model.Operation{Force: true}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+		assert.Equal(t, time.Duration(0), result.Delay)
+		installationSvc.AssertNotCalled(t, "TriggerUninstall", mock.Anything)
+		gardenerClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	for _, testCase := range []struct {
 		description        string
 		mockFunc           func(gardenerClient *gardener_mocks.GardenerClient, installationSvc *installationMocks.Service)
@@ -179,7 +215,7 @@ func TestTriggerKymaUninstall_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, installationSvc)
 
-			triggerKymaUninstallStep := NewTriggerKymaUninstallStep(gardenerClient, installationSvc, nextStageName, 10*time.Minute, delay)
+			triggerKymaUninstallStep := NewTriggerKymaUninstallStep(gardenerClientProvider(gardenerClient), installationSvc, nextStageName, 10*time.Minute, delay)
 
 			// when
 			_, err := triggerKymaUninstallStep.Run(testCase.cluster, model.Operation{}, logrus.New())
@@ -19,14 +19,14 @@ import (
 )
 
 type WaitForClusterDeletionStep struct {
-	gardenerClient GardenerClient
+	gardenerClient GardenerClientProvider
 	dbsFactory     dbsession.Factory
 	directorClient director.DirectorClient
 	nextStep       model.OperationStage
 	timeLimit      time.Duration
 }
 
-func NewWaitForClusterDeletionStep(gardenerClient GardenerClient, dbsFactory dbsession.Factory, directorClient director.DirectorClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForClusterDeletionStep {
+func NewWaitForClusterDeletionStep(gardenerClient GardenerClientProvider, dbsFactory dbsession.Factory, directorClient director.DirectorClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForClusterDeletionStep {
 	return &WaitForClusterDeletionStep{
 		gardenerClient: gardenerClient,
 		dbsFactory:     dbsFactory,
@@ -46,7 +46,7 @@ func (s *WaitForClusterDeletionStep) TimeLimit() time.Duration {
 
 func (s *WaitForClusterDeletionStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
 
-	shootExists, err := s.shootExists(cluster.ClusterConfig.Name, logger)
+	shootExists, err := s.shootExists(cluster.ClusterConfig.ProjectName, cluster.ClusterConfig.Name, logger)
 	if err != nil {
 		return operations.StageResult{}, err
 	}
@@ -63,8 +63,8 @@ func (s *WaitForClusterDeletionStep) Run(cluster model.Cluster, operation model.
 	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
 }
 
-func (s *WaitForClusterDeletionStep) shootExists(gardenerClusterName string, logger logrus.FieldLogger) (bool, error) {
-	_, err := s.gardenerClient.Get(context.Background(), gardenerClusterName, v1.GetOptions{})
+func (s *WaitForClusterDeletionStep) shootExists(project, gardenerClusterName string, logger logrus.FieldLogger) (bool, error) {
+	_, err := s.gardenerClient(project).Get(context.Background(), gardenerClusterName, v1.GetOptions{})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			return false, nil
@@ -121,7 +121,7 @@ func TestWaitForClusterDeletion_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, dbSessionFactory, directorClient)
 
-			waitForClusterDeletionStep := NewWaitForClusterDeletionStep(gardenerClient, dbSessionFactory, directorClient, nextStageName, 10*time.Minute)
+			waitForClusterDeletionStep := NewWaitForClusterDeletionStep(gardenerClientProvider(gardenerClient), dbSessionFactory, directorClient, nextStageName, 10*time.Minute)
 
 			// when
 			result, err := waitForClusterDeletionStep.Run(cluster, model.Operation{}, logrus.New())
@@ -222,7 +222,7 @@ func TestWaitForClusterDeletion_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, dbSessionFactory, directorClient)
 
-			waitForClusterDeletionStep := NewWaitForClusterDeletionStep(gardenerClient, dbSessionFactory, directorClient, nextStageName, 10*time.Minute)
+			waitForClusterDeletionStep := NewWaitForClusterDeletionStep(gardenerClientProvider(gardenerClient), dbSessionFactory, directorClient, nextStageName, 10*time.Minute)
 
 			// when
 			_, err := waitForClusterDeletionStep.Run(testCase.cluster, model.Operation{}, logrus.New())
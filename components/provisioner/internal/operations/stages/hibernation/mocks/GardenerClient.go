@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -38,3 +39,26 @@ func (_m *GardenerClient) Get(ctx context.Context, name string, options v1.GetOp
 
 	return r0, r1
 }
+
+// Update provides a mock function with given fields: ctx, shoot, options
+func (_m *GardenerClient) Update(ctx context.Context, shoot *v1beta1.Shoot, options v1.UpdateOptions) (*v1beta1.Shoot, error) {
+	ret := _m.Called(ctx, shoot, options)
+
+	var r0 *v1beta1.Shoot
+	if rf, ok := ret.Get(0).(func(context.Context, *v1beta1.Shoot, v1.UpdateOptions) *v1beta1.Shoot); ok {
+		r0 = rf(ctx, shoot, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1beta1.Shoot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1beta1.Shoot, v1.UpdateOptions) error); ok {
+		r1 = rf(ctx, shoot, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,97 @@
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetHibernation enables or disables hibernation of the Shoot once the scheduled start of the operation is reached
+type SetHibernation struct {
+	gardenerClient GardenerClientProvider
+	name           model.OperationStage
+	hibernate      bool
+	nextStep       model.OperationStage
+	timeLimit      time.Duration
+	now            func() time.Time
+}
+
+func NewHibernateClusterStep(gardenerClient GardenerClientProvider, nextStep model.OperationStage, timeLimit time.Duration) *SetHibernation {
+	return newSetHibernationStep(gardenerClient, model.StartingHibernation, true, nextStep, timeLimit)
+}
+
+func NewWakeUpClusterStep(gardenerClient GardenerClientProvider, nextStep model.OperationStage, timeLimit time.Duration) *SetHibernation {
+	return newSetHibernationStep(gardenerClient, model.StartingWakeUp, false, nextStep, timeLimit)
+}
+
+func newSetHibernationStep(gardenerClient GardenerClientProvider, name model.OperationStage, hibernate bool, nextStep model.OperationStage, timeLimit time.Duration) *SetHibernation {
+	return &SetHibernation{
+		gardenerClient: gardenerClient,
+		name:           name,
+		hibernate:      hibernate,
+		nextStep:       nextStep,
+		timeLimit:      timeLimit,
+		now:            time.Now,
+	}
+}
+
+func (s *SetHibernation) Name() model.OperationStage {
+	return s.name
+}
+
+func (s *SetHibernation) TimeLimit() time.Duration {
+	return s.timeLimit
+}
+
+func (s *SetHibernation) Run(cluster model.Cluster, operation model.Operation, log logrus.FieldLogger) (operations.StageResult, error) {
+	// Scheduled operations start at the requested time, they might be processed earlier after restart
+	if delay := operation.StartTimestamp.Sub(s.now()); delay > 0 {
+		log.Debugf("Operation scheduled to start at %s, waiting %s ...", operation.StartTimestamp, delay)
+		return operations.StageResult{Stage: s.Name(), Delay: delay}, nil
+	}
+
+	client := s.gardenerClient(cluster.ClusterConfig.ProjectName)
+
+	shoot, err := client.Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+
+	if hibernationEnabled(shoot) == s.hibernate {
+		log.Debugf("Shoot hibernation already set to %t, proceeding to the next stage ...", s.hibernate)
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	if s.hibernate {
+		condition := gardencorev1beta1helper.GetOrInitCondition(shoot.Status.Constraints, gardener_types.ShootHibernationPossible)
+		if condition.Status == gardener_types.ConditionFalse {
+			return operations.StageResult{}, operations.NewNonRecoverableError(errors.New("cannot hibernate cluster: " + condition.Message))
+		}
+	}
+
+	enabled := s.hibernate
+	if shoot.Spec.Hibernation == nil {
+		shoot.Spec.Hibernation = &gardener_types.Hibernation{}
+	}
+	shoot.Spec.Hibernation.Enabled = &enabled
+
+	_, err = client.Update(context.Background(), shoot, v1.UpdateOptions{})
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+
+	log.Debugf("Shoot hibernation set to %t, proceeding to the next stage ...", s.hibernate)
+	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+}
+
+func hibernationEnabled(shoot *gardener_types.Shoot) bool {
+	return shoot.Spec.Hibernation != nil && shoot.Spec.Hibernation.Enabled != nil && *shoot.Spec.Hibernation.Enabled
+}
@@ -0,0 +1,154 @@
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/hibernation/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetHibernation(t *testing.T) {
+
+	const clusterName = "test"
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	cluster := model.Cluster{
+		ID: "runtimeID",
+		ClusterConfig: model.GardenerConfig{
+			Name: clusterName,
+		},
+	}
+
+	hibernationEnabledMatcher := func(enabled bool) interface{} {
+		return mock.MatchedBy(func(shoot *gardener_types.Shoot) bool {
+			return hibernationEnabled(shoot) == enabled
+		})
+	}
+
+	t.Run("should wait until the scheduled start of the operation", func(t *testing.T) {
+		// given
+		gardenerClient := &mocks.GardenerClient{}
+
+		step := NewHibernateClusterStep(gardenerClientProvider(gardenerClient), model.WaitForHibernation, time.Minute)
+		step.now = func() time.Time { return now }
+
+		// when
+		result, err := step.Run(cluster, model.Operation{StartTimestamp: now.Add(time.Hour)}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.StartingHibernation, result.Stage)
+		assert.Equal(t, time.Hour, result.Delay)
+		gardenerClient.AssertExpectations(t)
+	})
+
+	t.Run("should enable hibernation of the Shoot", func(t *testing.T) {
+		// given
+		gardenerClient := &mocks.GardenerClient{}
+		gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
+			testkit.NewTestShoot(clusterName).WithHibernationState(true, false).ToShoot(), nil)
+		gardenerClient.On("Update", context.Background(), hibernationEnabledMatcher(true), mock.Anything).Return(&gardener_types.Shoot{}, nil)
+
+		step := NewHibernateClusterStep(gardenerClientProvider(gardenerClient), model.WaitForHibernation, time.Minute)
+		step.now = func() time.Time { return now }
+
+		// when
+		result, err := step.Run(cluster, model.Operation{StartTimestamp: now.Add(-time.Second)}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.WaitForHibernation, result.Stage)
+		assert.Equal(t, time.Duration(0), result.Delay)
+		gardenerClient.AssertExpectations(t)
+	})
+
+	t.Run("should disable hibernation of the Shoot", func(t *testing.T) {
+		// given
+		enabled := true
+		shoot := testkit.NewTestShoot(clusterName).WithHibernationState(true, true).ToShoot()
+		shoot.Spec.Hibernation = &gardener_types.Hibernation{Enabled: &enabled}
+
+		gardenerClient := &mocks.GardenerClient{}
+		gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(shoot, nil)
+		gardenerClient.On("Update", context.Background(), hibernationEnabledMatcher(false), mock.Anything).Return(&gardener_types.Shoot{}, nil)
+
+		step := NewWakeUpClusterStep(gardenerClientProvider(gardenerClient), model.WaitForWakeUp, time.Minute)
+		step.now = func() time.Time { return now }
+
+		// when
+		result, err := step.Run(cluster, model.Operation{StartTimestamp: now}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.WaitForWakeUp, result.Stage)
+		gardenerClient.AssertExpectations(t)
+	})
+
+	t.Run("should not update the Shoot when hibernation is already set", func(t *testing.T) {
+		// given
+		gardenerClient := &mocks.GardenerClient{}
+		gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
+			testkit.NewTestShoot(clusterName).WithHibernationState(true, false).ToShoot(), nil)
+
+		step := NewWakeUpClusterStep(gardenerClientProvider(gardenerClient), model.WaitForWakeUp, time.Minute)
+		step.now = func() time.Time { return now }
+
+		// when
+		result, err := step.Run(cluster, model.Operation{StartTimestamp: now}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.WaitForWakeUp, result.Stage)
+		gardenerClient.AssertExpectations(t)
+	})
+
+	t.Run("should return unrecoverable error when hibernation is not possible", func(t *testing.T) {
+		// given
+		gardenerClient := &mocks.GardenerClient{}
+		gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
+			testkit.NewTestShoot(clusterName).WithHibernationState(false, false).ToShoot(), nil)
+
+		step := NewHibernateClusterStep(gardenerClientProvider(gardenerClient), model.WaitForHibernation, time.Minute)
+		step.now = func() time.Time { return now }
+
+		// when
+		_, err := step.Run(cluster, model.Operation{StartTimestamp: now}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		nonRecoverable := operations.NonRecoverableError{}
+		assert.True(t, errors.As(err, &nonRecoverable))
+		gardenerClient.AssertExpectations(t)
+	})
+
+	t.Run("should return error when failed to update the Shoot", func(t *testing.T) {
+		// given
+		gardenerClient := &mocks.GardenerClient{}
+		gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
+			testkit.NewTestShoot(clusterName).WithHibernationState(true, false).ToShoot(), nil)
+		gardenerClient.On("Update", context.Background(), mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+
+		step := NewHibernateClusterStep(gardenerClientProvider(gardenerClient), model.WaitForHibernation, time.Minute)
+		step.now = func() time.Time { return now }
+
+		// when
+		_, err := step.Run(cluster, model.Operation{StartTimestamp: now}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		nonRecoverable := operations.NonRecoverableError{}
+		assert.False(t, errors.As(err, &nonRecoverable))
+		gardenerClient.AssertExpectations(t)
+	})
+}
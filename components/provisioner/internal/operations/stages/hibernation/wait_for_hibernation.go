@@ -8,6 +8,7 @@ import (
 	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -15,17 +16,23 @@ import (
 //go:generate mockery -name=GardenerClient
 type GardenerClient interface {
 	Get(ctx context.Context, name string, options v1.GetOptions) (*gardener_types.Shoot, error)
+	Update(ctx context.Context, shoot *gardener_types.Shoot, options v1.UpdateOptions) (*gardener_types.Shoot, error)
 }
 
+// GardenerClientProvider returns the client of Shoots in the namespace of the Gardener project
+type GardenerClientProvider func(project string) GardenerClient
+
 type WaitForHibernation struct {
-	gardenerClient GardenerClient
+	gardenerClient GardenerClientProvider
+	dbSession      dbsession.WriteSession
 	nextStep       model.OperationStage
 	timeLimit      time.Duration
 }
 
-func NewWaitForHibernationStep(gardenerClient GardenerClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForHibernation {
+func NewWaitForHibernationStep(gardenerClient GardenerClientProvider, dbSession dbsession.WriteSession, nextStep model.OperationStage, timeLimit time.Duration) *WaitForHibernation {
 	return &WaitForHibernation{
 		gardenerClient: gardenerClient,
+		dbSession:      dbSession,
 		nextStep:       nextStep,
 		timeLimit:      timeLimit,
 	}
@@ -42,17 +49,22 @@ func (c *WaitForHibernation) TimeLimit() time.Duration {
 func (c *WaitForHibernation) Run(cluster model.Cluster, operation model.Operation, log logrus.FieldLogger) (operations.StageResult, error) {
 
 	log.Debugf("Starting WaitForHibernation stage for %s ...", cluster.ID)
-	shoot, err := c.gardenerClient.Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
+	shoot, err := c.gardenerClient(cluster.ClusterConfig.ProjectName).Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
 	if err != nil {
 		return operations.StageResult{}, err
 	}
 
-	if shoot.Status.LastOperation.State == gardener_types.LastOperationStateFailed {
-		err := fmt.Errorf(fmt.Sprintf("Cluster hibernation failed. Last Shoot state: %s, Shoot description: %s", shoot.Status.LastOperation.State, shoot.Status.LastOperation.Description))
-		return operations.StageResult{}, operations.NewNonRecoverableError(err)
+	if shoot.Status.LastOperation != nil && shoot.Status.LastOperation.State == gardener_types.LastOperationStateFailed {
+		return operations.StageResult{}, operations.NewShootFailedError(shoot.Status.LastErrors, "Cluster hibernation failed. Last Shoot state: %s, Shoot description: %s", shoot.Status.LastOperation.State, shoot.Status.LastOperation.Description)
 	}
 
-	if shoot.Status.IsHibernated {
+	if shoot.Status.IsHibernated && hibernationReconciled(shoot) {
+		// Transition is saved only once, whichever of this step and the Shoot controller notices it first
+		dberr := c.dbSession.MarkClusterAsHibernated(cluster.ID, time.Now(), model.HibernationTriggerManual)
+		if dberr != nil {
+			return operations.StageResult{}, fmt.Errorf("failed to save hibernation status: %s", dberr.Error())
+		}
+
 		log.Debugf("Cluster: %s is hibernated, proceeding to the next stage ...", cluster.ID)
 		return operations.StageResult{
 			Stage: c.nextStep,
@@ -60,10 +72,18 @@ func (c *WaitForHibernation) Run(cluster model.Cluster, operation model.Operatio
 		}, nil
 	}
 
-	log.Debugf("Cluster: %s is not hibernated ...", cluster.ID)
+	log.Debugf("Cluster: %s is not hibernated yet ...", cluster.ID)
 
 	return operations.StageResult{
 		Stage: c.Name(),
 		Delay: 30 * time.Second,
 	}, nil
 }
+
+// hibernationReconciled checks that the Gardener shoot controller observed the latest hibernation settings of the Shoot
+// and successfully finished reconciling them, so the hibernation state is final
+func hibernationReconciled(shoot *gardener_types.Shoot) bool {
+	return shoot.Status.ObservedGeneration >= shoot.Generation &&
+		shoot.Status.LastOperation != nil &&
+		shoot.Status.LastOperation.State == gardener_types.LastOperationStateSucceeded
+}
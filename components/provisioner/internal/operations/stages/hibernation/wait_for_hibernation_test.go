@@ -9,6 +9,8 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/hibernation/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -34,13 +36,13 @@ func TestWaitForHibernation(t *testing.T) {
 
 	for _, testCase := range []struct {
 		description   string
-		mockFunc      func(gardenerClient *mocks.GardenerClient)
+		mockFunc      func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession)
 		expectedStage model.OperationStage
 		expectedDelay time.Duration
 	}{
 		{
 			description: "should wait if cluster not hibernated",
-			mockFunc: func(gardenerClient *mocks.GardenerClient) {
+			mockFunc: func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
 					testkit.NewTestShoot(clusterName).
 						WithHibernationState(true, false).
@@ -49,12 +51,40 @@ func TestWaitForHibernation(t *testing.T) {
 			expectedStage: model.WaitForHibernation,
 			expectedDelay: 30 * time.Second,
 		},
+		{
+			description: "should wait if Shoot controller did not finish reconciling hibernation",
+			mockFunc: func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession) {
+				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
+					testkit.NewTestShoot(clusterName).
+						WithHibernationState(true, true).
+						WithOperationProcessing().
+						ToShoot(), nil)
+			},
+			expectedStage: model.WaitForHibernation,
+			expectedDelay: 30 * time.Second,
+		},
+		{
+			description: "should wait if Shoot controller did not observe the latest Shoot specification",
+			mockFunc: func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession) {
+				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
+					testkit.NewTestShoot(clusterName).
+						WithHibernationState(true, true).
+						WithOperationSucceeded().
+						WithGeneration(2).
+						WithObservedGeneration(1).
+						ToShoot(), nil)
+			},
+			expectedStage: model.WaitForHibernation,
+			expectedDelay: 30 * time.Second,
+		},
 		{
 			description: "should go to the next state if cluster is hibernated",
-			mockFunc: func(gardenerClient *mocks.GardenerClient) {
+			mockFunc: func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(testkit.NewTestShoot(clusterName).
 					WithHibernationState(true, true).
+					WithOperationSucceeded().
 					ToShoot(), nil)
+				dbSession.On("MarkClusterAsHibernated", runtimeID, mock.AnythingOfType("time.Time"), model.HibernationTriggerManual).Return(nil)
 			},
 			expectedStage: nextStageName,
 			expectedDelay: 0,
@@ -63,10 +93,11 @@ func TestWaitForHibernation(t *testing.T) {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
 			gardenerClient := &mocks.GardenerClient{}
+			dbSession := &sessionMocks.WriteSession{}
 
-			testCase.mockFunc(gardenerClient)
+			testCase.mockFunc(gardenerClient, dbSession)
 
-			checkHibernationConditionStep := NewWaitForHibernationStep(gardenerClient, nextStageName, time.Minute)
+			checkHibernationConditionStep := NewWaitForHibernationStep(gardenerClientProvider(gardenerClient), dbSession, nextStageName, time.Minute)
 
 			// when
 			result, err := checkHibernationConditionStep.Run(cluster, model.Operation{}, logrus.New())
@@ -76,17 +107,18 @@ func TestWaitForHibernation(t *testing.T) {
 			assert.Equal(t, testCase.expectedStage, result.Stage)
 			assert.Equal(t, testCase.expectedDelay, result.Delay)
 			gardenerClient.AssertExpectations(t)
+			dbSession.AssertExpectations(t)
 		})
 	}
 
 	for _, testCase := range []struct {
 		description        string
-		mockFunc           func(gardenerClient *mocks.GardenerClient)
+		mockFunc           func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession)
 		unrecoverableError bool
 	}{
 		{
 			description: "should return error if failed to get shoot",
-			mockFunc: func(gardenerClient *mocks.GardenerClient) {
+			mockFunc: func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(
 					nil, errors.New("some error"))
 			},
@@ -94,21 +126,34 @@ func TestWaitForHibernation(t *testing.T) {
 		},
 		{
 			description: "should return unrecoverable error when last operation failed",
-			mockFunc: func(gardenerClient *mocks.GardenerClient) {
+			mockFunc: func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(testkit.NewTestShoot(clusterName).
 					WithOperationFailed().
 					ToShoot(), nil)
 			},
 			unrecoverableError: true,
 		},
+		{
+			description: "should return error if failed to save hibernation status",
+			mockFunc: func(gardenerClient *mocks.GardenerClient, dbSession *sessionMocks.WriteSession) {
+				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(testkit.NewTestShoot(clusterName).
+					WithHibernationState(true, true).
+					WithOperationSucceeded().
+					ToShoot(), nil)
+				dbSession.On("MarkClusterAsHibernated", runtimeID, mock.AnythingOfType("time.Time"), model.HibernationTriggerManual).
+					Return(dberrors.Internal("some error"))
+			},
+			unrecoverableError: false,
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
 			gardenerClient := &mocks.GardenerClient{}
+			dbSession := &sessionMocks.WriteSession{}
 
-			testCase.mockFunc(gardenerClient)
+			testCase.mockFunc(gardenerClient, dbSession)
 
-			checkHibernationConditionStep := NewWaitForHibernationStep(gardenerClient, nextStageName, time.Minute)
+			checkHibernationConditionStep := NewWaitForHibernationStep(gardenerClientProvider(gardenerClient), dbSession, nextStageName, time.Minute)
 
 			// when
 			_, err := checkHibernationConditionStep.Run(cluster, model.Operation{}, logrus.New())
@@ -118,6 +163,13 @@ func TestWaitForHibernation(t *testing.T) {
 			nonRecoverable := operations.NonRecoverableError{}
 			require.Equal(t, testCase.unrecoverableError, errors.As(err, &nonRecoverable))
 			gardenerClient.AssertExpectations(t)
+			dbSession.AssertExpectations(t)
 		})
 	}
 }
+
+func gardenerClientProvider(client GardenerClient) GardenerClientProvider {
+	return func(string) GardenerClient {
+		return client
+	}
+}
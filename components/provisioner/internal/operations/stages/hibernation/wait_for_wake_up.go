@@ -0,0 +1,72 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type WaitForWakeUp struct {
+	gardenerClient GardenerClientProvider
+	dbSession      dbsession.WriteSession
+	nextStep       model.OperationStage
+	timeLimit      time.Duration
+}
+
+func NewWaitForWakeUpStep(gardenerClient GardenerClientProvider, dbSession dbsession.WriteSession, nextStep model.OperationStage, timeLimit time.Duration) *WaitForWakeUp {
+	return &WaitForWakeUp{
+		gardenerClient: gardenerClient,
+		dbSession:      dbSession,
+		nextStep:       nextStep,
+		timeLimit:      timeLimit,
+	}
+}
+
+func (c *WaitForWakeUp) Name() model.OperationStage {
+	return model.WaitForWakeUp
+}
+
+func (c *WaitForWakeUp) TimeLimit() time.Duration {
+	return c.timeLimit
+}
+
+func (c *WaitForWakeUp) Run(cluster model.Cluster, operation model.Operation, log logrus.FieldLogger) (operations.StageResult, error) {
+
+	log.Debugf("Starting WaitForWakeUp stage for %s ...", cluster.ID)
+	shoot, err := c.gardenerClient(cluster.ClusterConfig.ProjectName).Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+
+	if shoot.Status.LastOperation != nil && shoot.Status.LastOperation.State == gardener_types.LastOperationStateFailed {
+		return operations.StageResult{}, operations.NewShootFailedError(shoot.Status.LastErrors, "Cluster wake-up failed. Last Shoot state: %s, Shoot description: %s", shoot.Status.LastOperation.State, shoot.Status.LastOperation.Description)
+	}
+
+	if !shoot.Status.IsHibernated && hibernationReconciled(shoot) {
+		// Transition is saved only once, whichever of this step and the Shoot controller notices it first
+		dberr := c.dbSession.MarkClusterAsWokenUp(cluster.ID, time.Now())
+		if dberr != nil {
+			return operations.StageResult{}, fmt.Errorf("failed to save wake-up status: %s", dberr.Error())
+		}
+
+		log.Debugf("Cluster: %s is woken up, proceeding to the next stage ...", cluster.ID)
+		return operations.StageResult{
+			Stage: c.nextStep,
+			Delay: 0,
+		}, nil
+	}
+
+	log.Debugf("Cluster: %s is not woken up yet ...", cluster.ID)
+
+	return operations.StageResult{
+		Stage: c.Name(),
+		Delay: 30 * time.Second,
+	}, nil
+}
@@ -3,6 +3,7 @@ package provisioning
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
@@ -26,17 +27,26 @@ const (
 
 	groupKindSubject = "Group"
 	userKindSubject  = "User"
+
+	// operatorBindingLabel marks the bindings for operators created by the Runtime Provisioner, only the marked bindings
+	// are deleted when they are no longer configured
+	operatorBindingLabel = "provisioner.kyma-project.io/operator-binding"
 )
 
+// OperatorRoleBinding configures the bindings for operators created on the clusters. The bindings are read from the YAML file
+// at the ConfigPath, if it is not set the L2 and L3 subjects are bound to the view and cluster-admin roles
 type OperatorRoleBinding struct {
-	L2SubjectName    string `envconfig:"default=runtimeOperator"`
-	L3SubjectName    string `envconfig:"default=runtimeAdmin"`
-	CreatingForAdmin bool   `envconfig:"default=false"`
+	L2SubjectName      string `envconfig:"default=runtimeOperator"`
+	L3SubjectName      string `envconfig:"default=runtimeAdmin"`
+	CreatingForAdmin   bool   `envconfig:"default=false"`
+	ConfigPath         string `envconfig:"optional"`
+	DeleteUnconfigured bool   `envconfig:"default=false"`
 }
 
 type CreateBindingsForOperatorsStep struct {
 	k8sClientProvider         k8s.K8sClientProvider
 	operatorRoleBindingConfig OperatorRoleBinding
+	operatorBindings          []OperatorBinding
 	nextStep                  model.OperationStage
 	timeLimit                 time.Duration
 }
@@ -44,12 +54,14 @@ type CreateBindingsForOperatorsStep struct {
 func NewCreateBindingsForOperatorsStep(
 	k8sClientProvider k8s.K8sClientProvider,
 	operatorRoleBindingConfig OperatorRoleBinding,
+	operatorBindings []OperatorBinding,
 	nextStep model.OperationStage,
 	timeLimit time.Duration) *CreateBindingsForOperatorsStep {
 
 	return &CreateBindingsForOperatorsStep{
 		k8sClientProvider:         k8sClientProvider,
 		operatorRoleBindingConfig: operatorRoleBindingConfig,
+		operatorBindings:          operatorBindings,
 		nextStep:                  nextStep,
 		timeLimit:                 timeLimit,
 	}
@@ -73,22 +85,11 @@ func (s *CreateBindingsForOperatorsStep) Run(cluster model.Cluster, _ model.Oper
 		return operations.StageResult{}, fmt.Errorf("failed to create k8s client: %v", err)
 	}
 
-	clusterRoleBindings := make([]v12.ClusterRoleBinding, 0)
+	if err := reconcileOperatorBindings(k8sClient.RbacV1(), s.operatorBindings, s.operatorRoleBindingConfig.DeleteUnconfigured, log); err != nil {
+		return operations.StageResult{}, fmt.Errorf("failed to reconcile bindings for operators: %v", err)
+	}
 
-	clusterRoleBindings = append(clusterRoleBindings,
-		buildClusterRoleBinding(
-			l2OperatorClusterRoleBindingName,
-			s.operatorRoleBindingConfig.L2SubjectName,
-			l2OperatorClusterRoleBindingRoleRefName,
-			groupKindSubject,
-			map[string]string{"app": "kyma"}))
-	clusterRoleBindings = append(clusterRoleBindings,
-		buildClusterRoleBinding(
-			l3OperatorClusterRoleBindingName,
-			s.operatorRoleBindingConfig.L3SubjectName,
-			l3OperatorClusterRoleBindingRoleRefName,
-			groupKindSubject,
-			map[string]string{"app": "kyma"}))
+	clusterRoleBindings := make([]v12.ClusterRoleBinding, 0)
 
 	if s.operatorRoleBindingConfig.CreatingForAdmin {
 		for i, administrator := range cluster.Administrators {
@@ -141,3 +142,181 @@ func createClusterRoleBindings(crbClient v1.ClusterRoleBindingInterface, cluster
 	}
 	return nil
 }
+
+// reconcileOperatorBindings creates the missing bindings and updates the drifted ones, so that it can be repeated on the cluster.
+// The bindings marked with the operator binding label which are no longer configured are deleted if deleteUnconfigured is set
+func reconcileOperatorBindings(rbacClient v1.RbacV1Interface, bindings []OperatorBinding, deleteUnconfigured bool, log logrus.FieldLogger) error {
+	configured := make(map[string]bool, len(bindings))
+	for _, binding := range bindings {
+		configured[bindingKey(binding.Namespace, binding.Name)] = true
+
+		if binding.Namespace == "" {
+			if err := reconcileClusterRoleBinding(rbacClient.ClusterRoleBindings(), binding); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := reconcileRoleBinding(rbacClient.RoleBindings(binding.Namespace), binding)
+		if errors.IsNotFound(err) {
+			// The namespaces created with Kyma do not exist yet during the provisioning, the binding is created by the next reconciliation
+			log.Warnf("Namespace %s of binding %s does not exist, skipping", binding.Namespace, binding.Name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if !deleteUnconfigured {
+		return nil
+	}
+	return deleteUnconfiguredBindings(rbacClient, configured, log)
+}
+
+func reconcileClusterRoleBinding(crbClient v1.ClusterRoleBindingInterface, binding OperatorBinding) error {
+	desired := buildClusterRoleBinding(binding.Name, binding.SubjectName, binding.ClusterRole, binding.SubjectKind, operatorBindingLabels())
+
+	existing, err := crbClient.Get(context.Background(), binding.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return createClusterRoleBindings(crbClient, desired)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s ClusterRoleBinding: %v", binding.Name, err)
+	}
+
+	// The role reference cannot be updated, so the binding has to be recreated
+	if !reflect.DeepEqual(existing.RoleRef, desired.RoleRef) {
+		if err := crbClient.Delete(context.Background(), binding.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s ClusterRoleBinding: %v", binding.Name, err)
+		}
+		return createClusterRoleBindings(crbClient, desired)
+	}
+
+	if reflect.DeepEqual(existing.Subjects, desired.Subjects) && hasLabels(existing.Labels, desired.Labels) {
+		return nil
+	}
+	existing.Subjects = desired.Subjects
+	existing.Labels = mergeLabels(existing.Labels, desired.Labels)
+	if _, err := crbClient.Update(context.Background(), existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update %s ClusterRoleBinding: %v", binding.Name, err)
+	}
+	return nil
+}
+
+func reconcileRoleBinding(rbClient v1.RoleBindingInterface, binding OperatorBinding) error {
+	desired := buildRoleBinding(binding.Name, binding.Namespace, binding.SubjectName, binding.ClusterRole, binding.SubjectKind, operatorBindingLabels())
+
+	existing, err := rbClient.Get(context.Background(), binding.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := rbClient.Create(context.Background(), &desired, metav1.CreateOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				return err
+			}
+			return fmt.Errorf("failed to create %s RoleBinding in namespace %s: %v", binding.Name, binding.Namespace, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s RoleBinding in namespace %s: %v", binding.Name, binding.Namespace, err)
+	}
+
+	// The role reference cannot be updated, so the binding has to be recreated
+	if !reflect.DeepEqual(existing.RoleRef, desired.RoleRef) {
+		if err := rbClient.Delete(context.Background(), binding.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s RoleBinding in namespace %s: %v", binding.Name, binding.Namespace, err)
+		}
+		if _, err := rbClient.Create(context.Background(), &desired, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create %s RoleBinding in namespace %s: %v", binding.Name, binding.Namespace, err)
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(existing.Subjects, desired.Subjects) && hasLabels(existing.Labels, desired.Labels) {
+		return nil
+	}
+	existing.Subjects = desired.Subjects
+	existing.Labels = mergeLabels(existing.Labels, desired.Labels)
+	if _, err := rbClient.Update(context.Background(), existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update %s RoleBinding in namespace %s: %v", binding.Name, binding.Namespace, err)
+	}
+	return nil
+}
+
+func deleteUnconfiguredBindings(rbacClient v1.RbacV1Interface, configured map[string]bool, log logrus.FieldLogger) error {
+	ownedSelector := metav1.ListOptions{LabelSelector: operatorBindingLabel + "=true"}
+
+	clusterRoleBindings, err := rbacClient.ClusterRoleBindings().List(context.Background(), ownedSelector)
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterRoleBindings: %v", err)
+	}
+	for _, crb := range clusterRoleBindings.Items {
+		if configured[bindingKey("", crb.Name)] {
+			continue
+		}
+		log.Infof("Deleting %s ClusterRoleBinding which is no longer configured", crb.Name)
+		if err := rbacClient.ClusterRoleBindings().Delete(context.Background(), crb.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s ClusterRoleBinding: %v", crb.Name, err)
+		}
+	}
+
+	roleBindings, err := rbacClient.RoleBindings(metav1.NamespaceAll).List(context.Background(), ownedSelector)
+	if err != nil {
+		return fmt.Errorf("failed to list RoleBindings: %v", err)
+	}
+	for _, rb := range roleBindings.Items {
+		if configured[bindingKey(rb.Namespace, rb.Name)] {
+			continue
+		}
+		log.Infof("Deleting %s RoleBinding in namespace %s which is no longer configured", rb.Name, rb.Namespace)
+		if err := rbacClient.RoleBindings(rb.Namespace).Delete(context.Background(), rb.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s RoleBinding in namespace %s: %v", rb.Name, rb.Namespace, err)
+		}
+	}
+
+	return nil
+}
+
+func buildRoleBinding(metaName, namespace, subjectName, clusterRoleName, subjectKind string, labels map[string]string) v12.RoleBinding {
+	return v12.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metaName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Subjects: []v12.Subject{{
+			Kind:     subjectKind,
+			Name:     subjectName,
+			APIGroup: "rbac.authorization.k8s.io",
+		}},
+		RoleRef: v12.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+	}
+}
+
+func operatorBindingLabels() map[string]string {
+	return map[string]string{"app": "kyma", operatorBindingLabel: "true"}
+}
+
+func hasLabels(labels, expected map[string]string) bool {
+	for key, value := range expected {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeLabels(labels, expected map[string]string) map[string]string {
+	merged := make(map[string]string, len(labels)+len(expected))
+	for key, value := range labels {
+		merged[key] = value
+	}
+	for key, value := range expected {
+		merged[key] = value
+	}
+	return merged
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -30,6 +31,7 @@ func TestCreateBindingsForOperatorsStep_Run(t *testing.T) {
 		L2SubjectName: "l2name",
 		L3SubjectName: "l3name",
 	}
+	operatorBindings := LegacyOperatorBindings(operatorBindingConfig)
 
 	t.Run("should return next step when finished", func(t *testing.T) {
 		// given
@@ -37,7 +39,7 @@ func TestCreateBindingsForOperatorsStep_Run(t *testing.T) {
 		k8sClientProvider := &mocks.K8sClientProvider{}
 		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
 
-		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, nextStageName, time.Minute)
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, operatorBindings, nextStageName, time.Minute)
 
 		// when
 		result, err := step.Run(cluster, model.Operation{}, &logrus.Entry{})
@@ -58,7 +60,7 @@ func TestCreateBindingsForOperatorsStep_Run(t *testing.T) {
 		k8sClientProvider := &mocks.K8sClientProvider{}
 		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
 
-		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, nextStageName, time.Minute)
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, operatorBindings, nextStageName, time.Minute)
 
 		// when
 		result, err := step.Run(cluster, model.Operation{}, &logrus.Entry{})
@@ -77,7 +79,7 @@ func TestCreateBindingsForOperatorsStep_Run(t *testing.T) {
 		k8sClientProvider := &mocks.K8sClientProvider{}
 		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
 
-		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, nextStageName, time.Minute)
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, operatorBindings, nextStageName, time.Minute)
 
 		// when
 		_, err := step.Run(clusterWithNilKubeconfig, model.Operation{}, &logrus.Entry{})
@@ -91,7 +93,7 @@ func TestCreateBindingsForOperatorsStep_Run(t *testing.T) {
 		k8sClientProvider := &mocks.K8sClientProvider{}
 		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(nil, apperrors.Internal("error"))
 
-		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, nextStageName, time.Minute)
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, operatorBindings, nextStageName, time.Minute)
 
 		// when
 		_, err := step.Run(cluster, model.Operation{}, &logrus.Entry{})
@@ -113,7 +115,7 @@ func TestCreateBindingsForOperatorsStep_Run(t *testing.T) {
 		k8sClientProvider := &mocks.K8sClientProvider{}
 		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
 
-		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, nextStageName, time.Minute)
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, operatorBindings, nextStageName, time.Minute)
 
 		// when
 		_, err := step.Run(cluster, model.Operation{}, &logrus.Entry{})
@@ -121,4 +123,136 @@ func TestCreateBindingsForOperatorsStep_Run(t *testing.T) {
 		// then
 		require.Error(t, err)
 	})
+
+	t.Run("should recreate bindings deleted manually on the cluster", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewSimpleClientset()
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
+
+		bindings := append(LegacyOperatorBindings(operatorBindingConfig), OperatorBinding{
+			Name:        "operator-edit",
+			SubjectKind: userKindSubject,
+			SubjectName: "operator@example.com",
+			ClusterRole: "edit",
+			Namespace:   "kyma-system",
+		})
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, bindings, nextStageName, time.Minute)
+
+		_, err := step.Run(cluster, model.Operation{}, logrus.New())
+		require.NoError(t, err)
+
+		err = k8sClient.RbacV1().ClusterRoleBindings().Delete(context.Background(), l2OperatorClusterRoleBindingName, metav1.DeleteOptions{})
+		require.NoError(t, err)
+		err = k8sClient.RbacV1().RoleBindings("kyma-system").Delete(context.Background(), "operator-edit", metav1.DeleteOptions{})
+		require.NoError(t, err)
+
+		// when
+		_, err = step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+
+		clusterRoleBinding, err := k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), l2OperatorClusterRoleBindingName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "l2name", clusterRoleBinding.Subjects[0].Name)
+		assert.Equal(t, l2OperatorClusterRoleBindingRoleRefName, clusterRoleBinding.RoleRef.Name)
+		assert.Equal(t, "true", clusterRoleBinding.Labels[operatorBindingLabel])
+
+		roleBinding, err := k8sClient.RbacV1().RoleBindings("kyma-system").Get(context.Background(), "operator-edit", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, userKindSubject, roleBinding.Subjects[0].Kind)
+		assert.Equal(t, "operator@example.com", roleBinding.Subjects[0].Name)
+		assert.Equal(t, "edit", roleBinding.RoleRef.Name)
+	})
+
+	t.Run("should update drifted bindings", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewSimpleClientset()
+		driftedSubject := buildClusterRoleBinding(l2OperatorClusterRoleBindingName, "other", l2OperatorClusterRoleBindingRoleRefName, groupKindSubject, map[string]string{"app": "kyma", "custom": "label"})
+		_, err := k8sClient.RbacV1().ClusterRoleBindings().Create(context.Background(), &driftedSubject, metav1.CreateOptions{})
+		require.NoError(t, err)
+		driftedRole := buildClusterRoleBinding(l3OperatorClusterRoleBindingName, "l3name", "view", groupKindSubject, operatorBindingLabels())
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Create(context.Background(), &driftedRole, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
+
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, operatorBindings, nextStageName, time.Minute)
+
+		// when
+		_, err = step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+
+		l2Binding, err := k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), l2OperatorClusterRoleBindingName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "l2name", l2Binding.Subjects[0].Name)
+		assert.Equal(t, "label", l2Binding.Labels["custom"])
+		assert.Equal(t, "true", l2Binding.Labels[operatorBindingLabel])
+
+		l3Binding, err := k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), l3OperatorClusterRoleBindingName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, l3OperatorClusterRoleBindingRoleRefName, l3Binding.RoleRef.Name)
+	})
+
+	t.Run("should delete only owned bindings which are no longer configured", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewSimpleClientset()
+		owned := buildClusterRoleBinding("removed-operator", "removed", "view", groupKindSubject, operatorBindingLabels())
+		_, err := k8sClient.RbacV1().ClusterRoleBindings().Create(context.Background(), &owned, metav1.CreateOptions{})
+		require.NoError(t, err)
+		ownedInNamespace := buildRoleBinding("removed-operator", "kyma-system", "removed", "edit", groupKindSubject, operatorBindingLabels())
+		_, err = k8sClient.RbacV1().RoleBindings("kyma-system").Create(context.Background(), &ownedInNamespace, metav1.CreateOptions{})
+		require.NoError(t, err)
+		foreign := buildClusterRoleBinding("customer-binding", "customer", "view", groupKindSubject, map[string]string{"app": "kyma"})
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Create(context.Background(), &foreign, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
+
+		config := operatorBindingConfig
+		config.DeleteUnconfigured = true
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, config, operatorBindings, nextStageName, time.Minute)
+
+		// when
+		_, err = step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), "removed-operator", metav1.GetOptions{})
+		assert.True(t, errors.IsNotFound(err))
+		_, err = k8sClient.RbacV1().RoleBindings("kyma-system").Get(context.Background(), "removed-operator", metav1.GetOptions{})
+		assert.True(t, errors.IsNotFound(err))
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), "customer-binding", metav1.GetOptions{})
+		assert.NoError(t, err)
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), l2OperatorClusterRoleBindingName, metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should keep unconfigured bindings if deletion is disabled", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewSimpleClientset()
+		owned := buildClusterRoleBinding("removed-operator", "removed", "view", groupKindSubject, operatorBindingLabels())
+		_, err := k8sClient.RbacV1().ClusterRoleBindings().Create(context.Background(), &owned, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
+
+		step := NewCreateBindingsForOperatorsStep(k8sClientProvider, operatorBindingConfig, operatorBindings, nextStageName, time.Minute)
+
+		// when
+		_, err = step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+
+		_, err = k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), "removed-operator", metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
 }
@@ -9,22 +9,28 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/installation"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 	"github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
 )
 
 type InstallKymaStep struct {
-	installationClient installation.Service
-	nextStep           model.OperationStage
-	timeLimit          time.Duration
+	installationClient     installation.Service
+	nextStep               model.OperationStage
+	timeLimit              time.Duration
+	dbSession              dbsession.WriteSession
+	resumeKymaInstallation bool
 }
 
-func NewInstallKymaStep(installationClient installation.Service, nextStep model.OperationStage, timeLimit time.Duration) *InstallKymaStep {
+func NewInstallKymaStep(installationClient installation.Service, nextStep model.OperationStage, timeLimit time.Duration, dbSession dbsession.WriteSession, resumeKymaInstallation bool) *InstallKymaStep {
 	return &InstallKymaStep{
-		installationClient: installationClient,
-		nextStep:           nextStep,
-		timeLimit:          timeLimit,
+		installationClient:     installationClient,
+		nextStep:               nextStep,
+		timeLimit:              timeLimit,
+		dbSession:              dbSession,
+		resumeKymaInstallation: resumeKymaInstallation,
 	}
 }
 
@@ -36,7 +42,12 @@ func (s *InstallKymaStep) TimeLimit() time.Duration {
 	return s.timeLimit
 }
 
-func (s *InstallKymaStep) Run(cluster model.Cluster, _ model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+func (s *InstallKymaStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+	if operation.InstallationTriggeredAt != nil {
+		logger.Infof("Installation already triggered by the operation at %s, proceeding to next step...", operation.InstallationTriggeredAt)
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
 	if cluster.Kubeconfig == nil {
 		return operations.StageResult{}, fmt.Errorf("error: kubeconfig is nil")
 	}
@@ -50,16 +61,14 @@ func (s *InstallKymaStep) Run(cluster model.Cluster, _ model.Operation, logger l
 	if err != nil {
 		installErr := installationSDK.InstallationError{}
 		if errors.As(err, &installErr) {
-			logger.Warnf("Installation already in progress, proceeding to next step...")
-			return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+			return s.resumeFailedInstallation(installErr, logger)
 		}
 
 		return operations.StageResult{}, fmt.Errorf("error: failed to check installation state: %s", err.Error())
 	}
 
 	if installationState.State != installationSDK.NoInstallationState && installationState.State != string(v1alpha1.StateEmpty) {
-		logger.Warnf("Installation already in progress, proceeding to next step...")
-		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+		return s.resumeInstallation(k8sConfig, cluster, operation, installationState, logger)
 	}
 
 	err = s.installationClient.TriggerInstallation(
@@ -67,11 +76,55 @@ func (s *InstallKymaStep) Run(cluster model.Cluster, _ model.Operation, logger l
 		cluster.KymaConfig.Profile,
 		cluster.KymaConfig.Release,
 		cluster.KymaConfig.GlobalConfiguration,
-		cluster.KymaConfig.Components)
+		installation.ClusterComponents(cluster))
 	if err != nil {
 		return operations.StageResult{}, fmt.Errorf("error: failed to start installation: %s", err.Error())
 	}
 
+	s.markInstallationTriggered(operation, logger)
+
 	logger.Warnf("Installation started, proceeding to next step...")
 	return operations.StageResult{Stage: s.nextStep, Delay: 30 * time.Second}, nil
 }
+
+// resumeInstallation attaches the operation to the installation found on the cluster if it installs the requested Kyma release
+func (s *InstallKymaStep) resumeInstallation(k8sConfig *rest.Config, cluster model.Cluster, operation model.Operation, installationState installationSDK.InstallationState, logger logrus.FieldLogger) (operations.StageResult, error) {
+	if !s.resumeKymaInstallation {
+		return operations.StageResult{}, operations.NewNonRecoverableError(fmt.Errorf("error: installation in state %s not triggered by the operation found on the cluster", installationState.State))
+	}
+
+	spec, err := s.installationClient.GetInstallationSpec(k8sConfig)
+	if err != nil {
+		return operations.StageResult{}, fmt.Errorf("error: failed to get installation: %s", err.Error())
+	}
+
+	if spec == nil {
+		return operations.StageResult{}, fmt.Errorf("error: installation in state %s not found on the cluster", installationState.State)
+	}
+
+	if !installation.IsSameInstallation(*spec, cluster.KymaConfig.Profile, cluster.KymaConfig.Release) {
+		return operations.StageResult{}, operations.NewNonRecoverableError(fmt.Errorf("error: installation of Kyma %s with profile %s found on the cluster does not match the requested one", spec.KymaVersion, spec.Profile))
+	}
+
+	s.markInstallationTriggered(operation, logger)
+
+	logger.Warnf("Installation already in progress in state %s, proceeding to next step...", installationState.State)
+	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+}
+
+// resumeFailedInstallation leaves retrying the recoverable installation error to the Kyma Installer
+func (s *InstallKymaStep) resumeFailedInstallation(installErr installationSDK.InstallationError, logger logrus.FieldLogger) (operations.StageResult, error) {
+	if !s.resumeKymaInstallation || !installErr.Recoverable {
+		return operations.StageResult{}, operations.NewNonRecoverableError(fmt.Errorf("error: installation found on the cluster failed: %s", installErr.Error()))
+	}
+
+	logger.Warnf("Installation already in progress and installation error occurred: %s, proceeding to next step...", installErr.Error())
+	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+}
+
+func (s *InstallKymaStep) markInstallationTriggered(operation model.Operation, logger logrus.FieldLogger) {
+	dberr := s.dbSession.MarkInstallationTriggered(operation.ID, time.Now())
+	if dberr != nil {
+		logger.Errorf("error marking installation as triggered: %s", dberr.Error())
+	}
+}
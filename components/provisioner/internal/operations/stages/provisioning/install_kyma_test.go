@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -10,8 +11,12 @@ import (
 	"github.com/kyma-incubator/hydroform/install/installation"
 	installationMocks "github.com/kyma-project/control-plane/components/provisioner/internal/installation/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	dbMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
+	"github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,7 +54,7 @@ func TestInstallKymaStep_Run(t *testing.T) {
 
 	cluster := model.Cluster{
 		Kubeconfig: util.StringPtr(kubeconfig),
-		KymaConfig: model.KymaConfig{
+		KymaConfig: &model.KymaConfig{
 			Profile:             &productionProfile,
 			Release:             release,
 			Components:          components,
@@ -57,61 +62,142 @@ func TestInstallKymaStep_Run(t *testing.T) {
 		},
 	}
 
+	operation := model.Operation{ID: "operationID"}
+
 	k8sConfig, err := k8s.ParseToK8sConfig([]byte(kubeconfig))
 	require.NoError(t, err)
 
+	sameInstallationSpec := &v1alpha1.InstallationSpec{KymaVersion: "10.0.0", Profile: v1alpha1.ProductionProfile}
+
 	for _, testCase := range []struct {
 		description string
-		mockFunc    func(installationSvc *installationMocks.Service)
+		operation   model.Operation
+		mockFunc    func(installationSvc *installationMocks.Service, dbSession *dbMocks.WriteSession)
 	}{
 		{
-			description: "should proceed to the next step when installation already in progress and installation error occurred",
-			mockFunc: func(installationSvc *installationMocks.Service) {
+			description: "should proceed to the next step when installation already triggered by the operation",
+			operation:   model.Operation{ID: "operationID", InstallationTriggeredAt: &time.Time{}},
+			mockFunc:    func(installationSvc *installationMocks.Service, dbSession *dbMocks.WriteSession) {},
+		},
+		{
+			description: "should proceed to the next step when installation already in progress and recoverable installation error occurred",
+			operation:   operation,
+			mockFunc: func(installationSvc *installationMocks.Service, dbSession *dbMocks.WriteSession) {
 				installationSvc.On("CheckInstallationState", k8sConfig).
-					Return(installation.InstallationState{}, installation.InstallationError{ShortMessage: "error"})
+					Return(installation.InstallationState{}, installation.InstallationError{ShortMessage: "error", Recoverable: true})
 			},
 		},
 		{
-			description: "should proceed to the next step when installation already in progress",
-			mockFunc: func(installationSvc *installationMocks.Service) {
+			description: "should proceed to the next step when the same installation already in progress",
+			operation:   operation,
+			mockFunc: func(installationSvc *installationMocks.Service, dbSession *dbMocks.WriteSession) {
 				installationSvc.On("CheckInstallationState", k8sConfig).
 					Return(installation.InstallationState{State: "InProgress"}, nil)
+				installationSvc.On("GetInstallationSpec", k8sConfig).Return(sameInstallationSpec, nil)
+				dbSession.On("MarkInstallationTriggered", "operationID", mock.AnythingOfType("time.Time")).Return(nil)
 			},
 		},
 		{
 			description: "should proceed to the next step after starting the installation when installer has an empty state",
-			mockFunc: func(installationSvc *installationMocks.Service) {
+			operation:   operation,
+			mockFunc: func(installationSvc *installationMocks.Service, dbSession *dbMocks.WriteSession) {
 				installationSvc.On("CheckInstallationState", k8sConfig).
 					Return(installation.InstallationState{State: ""}, nil)
 				installationSvc.On("TriggerInstallation", k8sConfig, mock.MatchedBy(getProfileMatcher(cluster.KymaConfig.Profile)), release, globalConfig, components).
 					Return(nil)
+				dbSession.On("MarkInstallationTriggered", "operationID", mock.AnythingOfType("time.Time")).Return(nil)
 			},
 		},
 		{
 			description: "should proceed to the next step after starting the installation",
-			mockFunc: func(installationSvc *installationMocks.Service) {
+			operation:   operation,
+			mockFunc: func(installationSvc *installationMocks.Service, dbSession *dbMocks.WriteSession) {
 				installationSvc.On("CheckInstallationState", k8sConfig).
 					Return(installation.InstallationState{State: installation.NoInstallationState}, nil)
 				installationSvc.On("TriggerInstallation", k8sConfig, mock.MatchedBy(getProfileMatcher(cluster.KymaConfig.Profile)), release, globalConfig, components).
 					Return(nil)
+				dbSession.On("MarkInstallationTriggered", "operationID", mock.AnythingOfType("time.Time")).Return(dberrors.Internal("error"))
 			},
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
 			installationSvc := &installationMocks.Service{}
+			dbSession := &dbMocks.WriteSession{}
 
-			testCase.mockFunc(installationSvc)
+			testCase.mockFunc(installationSvc, dbSession)
 
-			installStep := NewInstallKymaStep(installationSvc, nextStageName, 10*time.Minute)
+			installStep := NewInstallKymaStep(installationSvc, nextStageName, 10*time.Minute, dbSession, true)
 
 			// when
-			result, err := installStep.Run(cluster, model.Operation{}, logrus.New())
+			result, err := installStep.Run(cluster, testCase.operation, logrus.New())
 
 			// then
 			require.NoError(t, err)
 			assert.Equal(t, nextStageName, result.Stage)
 			installationSvc.AssertExpectations(t)
+			dbSession.AssertExpectations(t)
+		})
+	}
+
+	for _, testCase := range []struct {
+		description            string
+		resumeKymaInstallation bool
+		mockFunc               func(installationSvc *installationMocks.Service)
+	}{
+		{
+			description:            "should fail when non-recoverable installation error occurred",
+			resumeKymaInstallation: true,
+			mockFunc: func(installationSvc *installationMocks.Service) {
+				installationSvc.On("CheckInstallationState", k8sConfig).
+					Return(installation.InstallationState{}, installation.InstallationError{ShortMessage: "error"})
+			},
+		},
+		{
+			description:            "should fail when installation of different version already in progress",
+			resumeKymaInstallation: true,
+			mockFunc: func(installationSvc *installationMocks.Service) {
+				installationSvc.On("CheckInstallationState", k8sConfig).
+					Return(installation.InstallationState{State: "InProgress"}, nil)
+				installationSvc.On("GetInstallationSpec", k8sConfig).
+					Return(&v1alpha1.InstallationSpec{KymaVersion: "9.0.0", Profile: v1alpha1.ProductionProfile}, nil)
+			},
+		},
+		{
+			description:            "should fail when installation already in progress and resuming is disabled",
+			resumeKymaInstallation: false,
+			mockFunc: func(installationSvc *installationMocks.Service) {
+				installationSvc.On("CheckInstallationState", k8sConfig).
+					Return(installation.InstallationState{State: "InProgress"}, nil)
+			},
+		},
+		{
+			description:            "should fail when recoverable installation error occurred and resuming is disabled",
+			resumeKymaInstallation: false,
+			mockFunc: func(installationSvc *installationMocks.Service) {
+				installationSvc.On("CheckInstallationState", k8sConfig).
+					Return(installation.InstallationState{}, installation.InstallationError{ShortMessage: "error", Recoverable: true})
+			},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			installationSvc := &installationMocks.Service{}
+			dbSession := &dbMocks.WriteSession{}
+
+			testCase.mockFunc(installationSvc)
+
+			installStep := NewInstallKymaStep(installationSvc, nextStageName, 10*time.Minute, dbSession, testCase.resumeKymaInstallation)
+
+			// when
+			_, err := installStep.Run(cluster, operation, logrus.New())
+
+			// then
+			require.Error(t, err)
+			nonRecoverable := operations.NonRecoverableError{}
+			assert.True(t, errors.As(err, &nonRecoverable))
+			installationSvc.AssertExpectations(t)
+			dbSession.AssertExpectations(t)
 		})
 	}
 
@@ -122,15 +208,17 @@ func TestInstallKymaStep_Run(t *testing.T) {
 			Return(installation.InstallationState{State: installation.NoInstallationState}, nil)
 		installationSvc.On("TriggerInstallation", k8sConfig, mock.MatchedBy(getProfileMatcher(cluster.KymaConfig.Profile)), release, globalConfig, components).
 			Return(fmt.Errorf("error"))
+		dbSession := &dbMocks.WriteSession{}
 
-		installStep := NewInstallKymaStep(installationSvc, nextStageName, 10*time.Minute)
+		installStep := NewInstallKymaStep(installationSvc, nextStageName, 10*time.Minute, dbSession, true)
 
 		// when
-		_, err := installStep.Run(cluster, model.Operation{}, logrus.New())
+		_, err := installStep.Run(cluster, operation, logrus.New())
 
 		// then
 		require.Error(t, err)
 		installationSvc.AssertExpectations(t)
+		dbSession.AssertExpectations(t)
 	})
 }
 
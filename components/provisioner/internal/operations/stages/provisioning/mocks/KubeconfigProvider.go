@@ -9,13 +9,13 @@ type KubeconfigProvider struct {
 	mock.Mock
 }
 
-// FetchRaw provides a mock function with given fields: shootName
-func (_m *KubeconfigProvider) FetchRaw(shootName string) ([]byte, error) {
-	ret := _m.Called(shootName)
+// FetchRaw provides a mock function with given fields: project, shootName
+func (_m *KubeconfigProvider) FetchRaw(project string, shootName string) ([]byte, error) {
+	ret := _m.Called(project, shootName)
 
 	var r0 []byte
-	if rf, ok := ret.Get(0).(func(string) []byte); ok {
-		r0 = rf(shootName)
+	if rf, ok := ret.Get(0).(func(string, string) []byte); ok {
+		r0 = rf(project, shootName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -23,8 +23,8 @@ func (_m *KubeconfigProvider) FetchRaw(shootName string) ([]byte, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(shootName)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(project, shootName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,108 @@
+package provisioning
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// OperatorBinding binds the subject to the cluster role in the whole cluster, or only in the namespace if it is set
+type OperatorBinding struct {
+	Name        string `json:"name"`
+	SubjectKind string `json:"subjectKind"`
+	SubjectName string `json:"subjectName"`
+	ClusterRole string `json:"clusterRole"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type operatorBindingsConfig struct {
+	Bindings []OperatorBinding `json:"bindings"`
+}
+
+// LoadOperatorBindings reads the bindings for operators from the YAML file in the format
+// {"bindings": [{"name": "", "subjectKind": "", "subjectName": "", "clusterRole": "", "namespace": ""}]}.
+// If the config path is not set, the legacy bindings of the L2 and L3 subjects are returned
+func LoadOperatorBindings(config OperatorRoleBinding) ([]OperatorBinding, error) {
+	if config.ConfigPath == "" {
+		return LegacyOperatorBindings(config), nil
+	}
+
+	data, err := ioutil.ReadFile(config.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator bindings config: %s", err.Error())
+	}
+
+	var bindingsConfig operatorBindingsConfig
+	if err := yaml.UnmarshalStrict(data, &bindingsConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode operator bindings config: %s", err.Error())
+	}
+
+	if err := ValidateOperatorBindings(bindingsConfig.Bindings); err != nil {
+		return nil, fmt.Errorf("invalid operator bindings config: %s", err.Error())
+	}
+
+	return bindingsConfig.Bindings, nil
+}
+
+// LegacyOperatorBindings maps the L2 and L3 subjects onto the bindings to the view and cluster-admin roles created before the bindings were configurable
+func LegacyOperatorBindings(config OperatorRoleBinding) []OperatorBinding {
+	return []OperatorBinding{
+		{
+			Name:        l2OperatorClusterRoleBindingName,
+			SubjectKind: groupKindSubject,
+			SubjectName: config.L2SubjectName,
+			ClusterRole: l2OperatorClusterRoleBindingRoleRefName,
+		},
+		{
+			Name:        l3OperatorClusterRoleBindingName,
+			SubjectKind: groupKindSubject,
+			SubjectName: config.L3SubjectName,
+			ClusterRole: l3OperatorClusterRoleBindingRoleRefName,
+		},
+	}
+}
+
+// ValidateOperatorBindings checks if the bindings can be created on the clusters and if their names are unique
+func ValidateOperatorBindings(bindings []OperatorBinding) error {
+	names := make(map[string]bool, len(bindings))
+	for _, binding := range bindings {
+		if errs := validation.IsDNS1123Subdomain(binding.Name); len(errs) > 0 {
+			return fmt.Errorf("binding name %q is invalid: %s", binding.Name, strings.Join(errs, ", "))
+		}
+		if strings.HasPrefix(binding.Name, administratorOperatorClusterRoleBindingName) {
+			return fmt.Errorf("binding name %q is invalid: the %q prefix is reserved for the bindings of the cluster administrators", binding.Name, administratorOperatorClusterRoleBindingName)
+		}
+		if binding.SubjectKind != groupKindSubject && binding.SubjectKind != userKindSubject {
+			return fmt.Errorf("subject kind %q of binding %s is invalid: must be either %s or %s", binding.SubjectKind, binding.Name, groupKindSubject, userKindSubject)
+		}
+		if binding.SubjectName == "" {
+			return fmt.Errorf("subject name of binding %s is empty", binding.Name)
+		}
+		if binding.ClusterRole == "" {
+			return fmt.Errorf("cluster role of binding %s is empty", binding.Name)
+		}
+		if binding.Namespace != "" {
+			if errs := validation.IsDNS1123Label(binding.Namespace); len(errs) > 0 {
+				return fmt.Errorf("namespace %q of binding %s is invalid: %s", binding.Namespace, binding.Name, strings.Join(errs, ", "))
+			}
+		}
+
+		key := bindingKey(binding.Namespace, binding.Name)
+		if names[key] {
+			return fmt.Errorf("binding %s is duplicated", key)
+		}
+		names[key] = true
+	}
+
+	return nil
+}
+
+func bindingKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
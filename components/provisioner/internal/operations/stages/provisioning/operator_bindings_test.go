@@ -0,0 +1,114 @@
+package provisioning
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOperatorBindings(t *testing.T) {
+	t.Run("should map legacy config if config path is not set", func(t *testing.T) {
+		// when
+		bindings, err := LoadOperatorBindings(OperatorRoleBinding{L2SubjectName: "l2name", L3SubjectName: "l3name"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []OperatorBinding{
+			{Name: "l2-operator-view", SubjectKind: "Group", SubjectName: "l2name", ClusterRole: "view"},
+			{Name: "l3-operator-admin", SubjectKind: "Group", SubjectName: "l3name", ClusterRole: "cluster-admin"},
+		}, bindings)
+	})
+
+	t.Run("should load bindings from config file", func(t *testing.T) {
+		// given
+		configPath := writeBindingsConfig(t, t.TempDir(), `
+bindings:
+- name: l2-operator-view
+  subjectKind: Group
+  subjectName: runtimeOperator
+  clusterRole: view
+- name: operator-edit
+  subjectKind: User
+  subjectName: operator@example.com
+  clusterRole: edit
+  namespace: kyma-system
+`)
+
+		// when
+		bindings, err := LoadOperatorBindings(OperatorRoleBinding{L2SubjectName: "l2name", ConfigPath: configPath})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []OperatorBinding{
+			{Name: "l2-operator-view", SubjectKind: "Group", SubjectName: "runtimeOperator", ClusterRole: "view"},
+			{Name: "operator-edit", SubjectKind: "User", SubjectName: "operator@example.com", ClusterRole: "edit", Namespace: "kyma-system"},
+		}, bindings)
+	})
+
+	for _, testCase := range []struct {
+		description   string
+		config        string
+		expectedError string
+	}{
+		{
+			description:   "unknown field",
+			config:        "bindings:\n- name: view\n  subject: runtimeOperator\n",
+			expectedError: "failed to decode",
+		},
+		{
+			description:   "unsupported subject kind",
+			config:        "bindings:\n- name: view\n  subjectKind: ServiceAccount\n  subjectName: operator\n  clusterRole: view\n",
+			expectedError: "subject kind",
+		},
+		{
+			description:   "missing cluster role",
+			config:        "bindings:\n- name: view\n  subjectKind: Group\n  subjectName: operator\n",
+			expectedError: "cluster role",
+		},
+		{
+			description:   "invalid namespace",
+			config:        "bindings:\n- name: view\n  subjectKind: Group\n  subjectName: operator\n  clusterRole: view\n  namespace: Kyma_System\n",
+			expectedError: "namespace",
+		},
+		{
+			description:   "reserved name",
+			config:        "bindings:\n- name: administrator0\n  subjectKind: User\n  subjectName: admin@example.com\n  clusterRole: view\n",
+			expectedError: "reserved",
+		},
+		{
+			description:   "duplicated binding",
+			config:        "bindings:\n- name: view\n  subjectKind: Group\n  subjectName: a\n  clusterRole: view\n- name: view\n  subjectKind: Group\n  subjectName: b\n  clusterRole: view\n",
+			expectedError: "duplicated",
+		},
+	} {
+		t.Run("should reject config with "+testCase.description, func(t *testing.T) {
+			// given
+			configPath := writeBindingsConfig(t, t.TempDir(), testCase.config)
+
+			// when
+			_, err := LoadOperatorBindings(OperatorRoleBinding{ConfigPath: configPath})
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+
+	t.Run("should return error if config file does not exist", func(t *testing.T) {
+		// when
+		_, err := LoadOperatorBindings(OperatorRoleBinding{ConfigPath: filepath.Join(t.TempDir(), "missing.yaml")})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func writeBindingsConfig(t *testing.T, dir, config string) string {
+	configPath := filepath.Join(dir, "bindings.yaml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(config), 0644))
+
+	return configPath
+}
@@ -0,0 +1,105 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/installation"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ValidateOverridesStep struct {
+	k8sClientProvider k8s.K8sClientProvider
+	nextStep          model.OperationStage
+	timeLimit         time.Duration
+}
+
+func NewValidateOverridesStep(k8sClientProvider k8s.K8sClientProvider, nextStep model.OperationStage, timeLimit time.Duration) *ValidateOverridesStep {
+	return &ValidateOverridesStep{
+		k8sClientProvider: k8sClientProvider,
+		nextStep:          nextStep,
+		timeLimit:         timeLimit,
+	}
+}
+
+func (s *ValidateOverridesStep) Name() model.OperationStage {
+	return model.ValidatingOverrides
+}
+
+func (s *ValidateOverridesStep) TimeLimit() time.Duration {
+	return s.timeLimit
+}
+
+func (s *ValidateOverridesStep) Run(cluster model.Cluster, _ model.Operation, log logrus.FieldLogger) (operations.StageResult, error) {
+	if cluster.KymaConfig == nil {
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	err := installation.ValidateOverrides(*cluster.KymaConfig)
+	if err != nil {
+		return operations.StageResult{}, operations.NewNonRecoverableError(err)
+	}
+
+	references := installation.ReferencedResources(*cluster.KymaConfig)
+	if len(references) == 0 {
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	if cluster.Kubeconfig == nil {
+		return operations.StageResult{}, fmt.Errorf("cluster kubeconfig is nil")
+	}
+
+	k8sClient, err := s.k8sClientProvider.CreateK8SClient(*cluster.Kubeconfig)
+	if err != nil {
+		return operations.StageResult{}, fmt.Errorf("failed to create k8s client: %v", err)
+	}
+
+	var missing []string
+	for _, reference := range references {
+		exists, err := resourceExists(k8sClient, reference)
+		if err != nil {
+			return operations.StageResult{}, fmt.Errorf("failed to get %s %s/%s: %v", reference.Kind, reference.Namespace, reference.Name, err)
+		}
+		if !exists {
+			missing = append(missing, fmt.Sprintf("%s: %s %s/%s not found", reference.Override, reference.Kind, reference.Namespace, reference.Name))
+		}
+	}
+
+	if len(missing) > 0 {
+		return operations.StageResult{}, operations.NewNonRecoverableError(fmt.Errorf("invalid overrides: %s", strings.Join(missing, "; ")))
+	}
+
+	log.Infof("Verified %d resources referenced by overrides", len(references))
+
+	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+}
+
+func resourceExists(k8sClient kubernetes.Interface, reference installation.ResourceReference) (bool, error) {
+	var err error
+
+	switch reference.Kind {
+	case installation.SecretKind:
+		_, err = k8sClient.CoreV1().Secrets(reference.Namespace).Get(context.Background(), reference.Name, metav1.GetOptions{})
+	case installation.ConfigMapKind:
+		_, err = k8sClient.CoreV1().ConfigMaps(reference.Namespace).Get(context.Background(), reference.Name, metav1.GetOptions{})
+	default:
+		return false, fmt.Errorf("unknown kind %s", reference.Kind)
+	}
+
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,112 @@
+package provisioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidateOverridesStep_Run(t *testing.T) {
+	clusterWithOverrides := func(entries ...model.ConfigEntry) model.Cluster {
+		return model.Cluster{
+			Kubeconfig: util.StringPtr(kubeconfigRaw),
+			KymaConfig: &model.KymaConfig{
+				Components: []model.KymaComponentConfig{
+					{
+						Component:     "monitoring",
+						Namespace:     "kyma-system",
+						Configuration: model.Configuration{ConfigEntries: entries},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("should return next step when overrides are valid", func(t *testing.T) {
+		// given
+		k8sClient := fake.NewSimpleClientset(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "grafana-auth", Namespace: "kyma-system"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "alerts", Namespace: "kyma-system"}},
+		)
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(k8sClient, nil)
+
+		cluster := clusterWithOverrides(
+			model.NewConfigEntry("grafana.auth.existingSecret", "grafana-auth", false),
+			model.NewConfigEntry("alertmanager.existingConfigMap", "alerts", false),
+			model.NewConfigEntry("grafana.adminPassword", "pass", true),
+		)
+
+		step := NewValidateOverridesStep(k8sClientProvider, nextStageName, time.Minute)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+		assert.Equal(t, time.Duration(0), result.Delay)
+	})
+
+	t.Run("should not create k8s client when no resources are referenced", func(t *testing.T) {
+		// given
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		cluster := clusterWithOverrides(model.NewConfigEntry("grafana.enabled", "true", false))
+
+		step := NewValidateOverridesStep(k8sClientProvider, nextStageName, time.Minute)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+		k8sClientProvider.AssertNotCalled(t, "CreateK8SClient", kubeconfigRaw)
+	})
+
+	t.Run("should fail when referenced resource does not exist", func(t *testing.T) {
+		// given
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfigRaw).Return(fake.NewSimpleClientset(), nil)
+
+		cluster := clusterWithOverrides(model.NewConfigEntry("grafana.auth.existingSecret", "grafana-auth", false))
+
+		step := NewValidateOverridesStep(k8sClientProvider, nextStageName, time.Minute)
+
+		// when
+		_, err := step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.IsType(t, operations.NonRecoverableError{}, err)
+		assert.Contains(t, err.Error(), "monitoring override 'grafana.auth.existingSecret'")
+		assert.Contains(t, err.Error(), "Secret kyma-system/grafana-auth not found")
+	})
+
+	t.Run("should fail without exposing secret value when override is invalid", func(t *testing.T) {
+		// given
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		cluster := clusterWithOverrides(model.NewConfigEntry("grafana.adminPassword", "{secret-value", true))
+
+		step := NewValidateOverridesStep(k8sClientProvider, nextStageName, time.Minute)
+
+		// when
+		_, err := step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.IsType(t, operations.NonRecoverableError{}, err)
+		assert.Contains(t, err.Error(), "grafana.adminPassword")
+		assert.NotContains(t, err.Error(), "secret-value")
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/director"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/runtime"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/apis/compass/v1alpha1"
 	compass_conn_clientset "github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/client/clientset/versioned/typed/compass/v1alpha1"
@@ -39,6 +41,8 @@ func NewCompassConnectionClient(k8sConfig *rest.Config) (compass_conn_clientset.
 type WaitForAgentToConnectStep struct {
 	newCompassConnectionClient CompassConnectionClientConstructor
 	directorClient             director.DirectorClient
+	agentDiagnostics           runtime.AgentDiagnostics
+	dbSession                  dbsession.WriteSession
 	nextStep                   model.OperationStage
 	timeLimit                  time.Duration
 }
@@ -47,11 +51,15 @@ func NewWaitForAgentToConnectStep(
 	ccClientProvider CompassConnectionClientConstructor,
 	nextStep model.OperationStage,
 	timeLimit time.Duration,
-	directorClient director.DirectorClient) *WaitForAgentToConnectStep {
+	directorClient director.DirectorClient,
+	agentDiagnostics runtime.AgentDiagnostics,
+	dbSession dbsession.WriteSession) *WaitForAgentToConnectStep {
 
 	return &WaitForAgentToConnectStep{
 		newCompassConnectionClient: ccClientProvider,
 		directorClient:             directorClient,
+		agentDiagnostics:           agentDiagnostics,
+		dbSession:                  dbSession,
 		nextStep:                   nextStep,
 		timeLimit:                  timeLimit,
 	}
@@ -65,7 +73,7 @@ func (s *WaitForAgentToConnectStep) TimeLimit() time.Duration {
 	return s.timeLimit
 }
 
-func (s *WaitForAgentToConnectStep) Run(cluster model.Cluster, _ model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+func (s *WaitForAgentToConnectStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
 
 	if cluster.Kubeconfig == nil {
 		return operations.StageResult{}, fmt.Errorf("error: kubeconfig is nil")
@@ -85,6 +93,7 @@ func (s *WaitForAgentToConnectStep) Run(cluster model.Cluster, _ model.Operation
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			logger.Infof("Compass Connection not yet found on cluster")
+			s.saveDiagnostics(cluster, operation, nil, logger)
 			return operations.StageResult{Stage: s.Name(), Delay: 5 * time.Second}, nil
 		}
 
@@ -92,6 +101,7 @@ func (s *WaitForAgentToConnectStep) Run(cluster model.Cluster, _ model.Operation
 	}
 
 	if compassConnCR.Status.State == v1alpha1.ConnectionFailed {
+		s.saveDiagnostics(cluster, operation, compassConnCR, logger)
 		return operations.StageResult{}, fmt.Errorf("error: Compass Connection is in Failed state")
 	}
 
@@ -106,6 +116,7 @@ func (s *WaitForAgentToConnectStep) Run(cluster model.Cluster, _ model.Operation
 		}
 
 		logger.Infof("Compass Connection not yet in Synchronized state, current state: %s", compassConnCR.Status.State)
+		s.saveDiagnostics(cluster, operation, compassConnCR, logger)
 		return operations.StageResult{Stage: s.Name(), Delay: 2 * time.Second}, nil
 	}
 
@@ -123,3 +134,17 @@ func (s *WaitForAgentToConnectStep) setConnectedRuntimeStatusCondition(cluster m
 	}
 	return operations.StageResult{Stage: s.nextStep, Delay: 0}
 }
+
+// saveDiagnostics stores details explaining why the Runtime Agent is not connected with the operation,
+// the diagnostics are saved only when they change
+func (s *WaitForAgentToConnectStep) saveDiagnostics(cluster model.Cluster, operation model.Operation, compassConnection *v1alpha1.CompassConnection, logger logrus.FieldLogger) {
+	diagnostics := s.agentDiagnostics.Collect(cluster, *cluster.Kubeconfig, compassConnection)
+	if operation.Diagnostics != nil && *operation.Diagnostics == diagnostics {
+		return
+	}
+
+	logger.Infof("Runtime Agent not connected: %s", diagnostics)
+	if err := s.dbSession.UpdateOperationDiagnostics(operation.ID, diagnostics); err != nil {
+		logger.Errorf("error updating Runtime Agent diagnostics: %s", err.Error())
+	}
+}
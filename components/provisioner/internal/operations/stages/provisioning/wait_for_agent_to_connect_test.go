@@ -11,16 +11,24 @@ import (
 
 	directorMocks "github.com/kyma-project/control-plane/components/provisioner/internal/director/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	runtimeMocks "github.com/kyma-project/control-plane/components/provisioner/internal/runtime/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 	v1alpha12 "github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/apis/compass/v1alpha1"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/client/clientset/versioned/fake"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/client/clientset/versioned/typed/compass/v1alpha1"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 )
 
+const (
+	operationID = "operationID"
+	diagnostics = "Compass Connection not found"
+)
+
 func TestWaitForAgentToConnect(t *testing.T) {
 
 	cluster := model.Cluster{
@@ -52,12 +60,13 @@ func TestWaitForAgentToConnect(t *testing.T) {
 			})
 
 			directorClient := &directorMocks.DirectorClient{}
+			agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
 			directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Return(nil)
 
-			waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient)
+			waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
 
 			// when
-			result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{}, logrus.New())
+			result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
 
 			// then
 			require.NoError(t, err)
@@ -75,13 +84,14 @@ func TestWaitForAgentToConnect(t *testing.T) {
 			})
 
 			directorClient := &directorMocks.DirectorClient{}
+			agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
 			directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Once().Return(apperrors.Internal("runtime status error"))
 			directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Once().Return(nil)
 
-			waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient)
+			waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
 
 			// when
-			result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{}, logrus.New())
+			result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
 
 			// then
 			require.NoError(t, err)
@@ -99,12 +109,13 @@ func TestWaitForAgentToConnect(t *testing.T) {
 			})
 
 			directorClient := &directorMocks.DirectorClient{}
+			agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
 			directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Return(apperrors.Internal("some error"))
 
-			waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient)
+			waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
 
 			// when
-			result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{}, logrus.New())
+			result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
 
 			// then
 			require.NoError(t, err)
@@ -123,12 +134,13 @@ func TestWaitForAgentToConnect(t *testing.T) {
 		})
 
 		directorClient := &directorMocks.DirectorClient{}
+		agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
 		directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Return(nil)
 
-		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient)
+		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
 
 		// when
-		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{}, logrus.New())
+		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
 
 		// then
 		require.NoError(t, err)
@@ -146,12 +158,13 @@ func TestWaitForAgentToConnect(t *testing.T) {
 		})
 
 		directorClient := &directorMocks.DirectorClient{}
+		agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
 		directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Return(nil)
 
-		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient)
+		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
 
 		// when
-		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{}, logrus.New())
+		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
 
 		// then
 		require.NoError(t, err)
@@ -163,12 +176,13 @@ func TestWaitForAgentToConnect(t *testing.T) {
 		// given
 		clientProvider := newMockClientProvider(&v1alpha12.CompassConnection{})
 		directorClient := &directorMocks.DirectorClient{}
+		agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
 		directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Return(nil)
 
-		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient)
+		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
 
 		// when
-		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{}, logrus.New())
+		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
 
 		// then
 		require.NoError(t, err)
@@ -186,22 +200,78 @@ func TestWaitForAgentToConnect(t *testing.T) {
 		})
 
 		directorClient := &directorMocks.DirectorClient{}
+		agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
 		directorClient.On("SetRuntimeStatusCondition", cluster.ID, graphql.RuntimeStatusConditionConnected, cluster.Tenant).Return(nil)
 
-		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient)
+		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
 
 		// when
-		_, err := waitForAgentToConnectStep.Run(cluster, model.Operation{}, logrus.New())
+		_, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
 
 		// then
 		require.Error(t, err)
 	})
+
+	t.Run("should save diagnostics when Agent is not connected", func(t *testing.T) {
+		// given
+		compassConnection := &v1alpha12.CompassConnection{
+			ObjectMeta: v1.ObjectMeta{Name: defaultCompassConnectionName},
+			Status: v1alpha12.CompassConnectionStatus{
+				State: v1alpha12.Connected,
+			},
+		}
+		clientProvider := newMockClientProvider(compassConnection)
+		directorClient := &directorMocks.DirectorClient{}
+
+		agentDiagnostics := &runtimeMocks.AgentDiagnostics{}
+		agentDiagnostics.On("Collect", cluster, kubeconfig, compassConnection).Return("Compass Connection state: Connected")
+		dbSession := &sessionMocks.WriteSession{}
+		dbSession.On("UpdateOperationDiagnostics", operationID, "Compass Connection state: Connected").Return(nil)
+
+		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
+
+		// when
+		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, model.WaitForAgentToConnect, result.Stage)
+		agentDiagnostics.AssertExpectations(t)
+		dbSession.AssertExpectations(t)
+	})
+
+	t.Run("should not save unchanged diagnostics", func(t *testing.T) {
+		// given
+		clientProvider := newMockClientProvider(&v1alpha12.CompassConnection{})
+		directorClient := &directorMocks.DirectorClient{}
+		agentDiagnostics, dbSession := newDiagnosticsMocks(cluster)
+
+		waitForAgentToConnectStep := NewWaitForAgentToConnectStep(clientProvider.NewCompassConnectionClient, nextStageName, 10*time.Minute, directorClient, agentDiagnostics, dbSession)
+
+		// when
+		result, err := waitForAgentToConnectStep.Run(cluster, model.Operation{ID: operationID, Diagnostics: util.StringPtr(diagnostics)}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, model.WaitForAgentToConnect, result.Stage)
+		dbSession.AssertNotCalled(t, "UpdateOperationDiagnostics", operationID, diagnostics)
+	})
 }
 
 type mockClientProvider struct {
 	compassConnection *v1alpha12.CompassConnection
 }
 
+func newDiagnosticsMocks(cluster model.Cluster) (*runtimeMocks.AgentDiagnostics, *sessionMocks.WriteSession) {
+	agentDiagnostics := &runtimeMocks.AgentDiagnostics{}
+	agentDiagnostics.On("Collect", cluster, *cluster.Kubeconfig, mock.Anything).Return(diagnostics)
+
+	dbSession := &sessionMocks.WriteSession{}
+	dbSession.On("UpdateOperationDiagnostics", operationID, diagnostics).Return(nil)
+
+	return agentDiagnostics, dbSession
+}
+
 func newMockClientProvider(compassConnection *v1alpha12.CompassConnection) *mockClientProvider {
 	return &mockClientProvider{
 		compassConnection: compassConnection,
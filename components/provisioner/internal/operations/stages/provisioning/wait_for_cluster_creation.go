@@ -2,22 +2,21 @@ package provisioning
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type WaitForClusterCreationStep struct {
-	gardenerClient     GardenerClient
+	gardenerClient     GardenerClientProvider
 	dbSession          dbsession.ReadWriteSession
 	kubeconfigProvider KubeconfigProvider
 	nextStep           model.OperationStage
@@ -26,10 +25,10 @@ type WaitForClusterCreationStep struct {
 
 //go:generate mockery -name=KubeconfigProvider
 type KubeconfigProvider interface {
-	FetchRaw(shootName string) ([]byte, error)
+	FetchRaw(project, shootName string) ([]byte, error)
 }
 
-func NewWaitForClusterCreationStep(gardenerClient GardenerClient, dbSession dbsession.ReadWriteSession, kubeconfigProvider KubeconfigProvider, nextStep model.OperationStage, timeLimit time.Duration) *WaitForClusterCreationStep {
+func NewWaitForClusterCreationStep(gardenerClient GardenerClientProvider, dbSession dbsession.ReadWriteSession, kubeconfigProvider KubeconfigProvider, nextStep model.OperationStage, timeLimit time.Duration) *WaitForClusterCreationStep {
 	return &WaitForClusterCreationStep{
 		gardenerClient:     gardenerClient,
 		dbSession:          dbSession,
@@ -49,7 +48,7 @@ func (s *WaitForClusterCreationStep) TimeLimit() time.Duration {
 }
 
 func (s *WaitForClusterCreationStep) Run(cluster model.Cluster, _ model.Operation, logger log.FieldLogger) (operations.StageResult, error) {
-	shoot, err := s.gardenerClient.Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
+	shoot, err := s.gardenerClient(cluster.ClusterConfig.ProjectName).Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
 	if err != nil {
 		return operations.StageResult{}, err
 	}
@@ -62,15 +61,10 @@ func (s *WaitForClusterCreationStep) Run(cluster model.Cluster, _ model.Operatio
 		}
 
 		if lastOperation.State == gardencorev1beta1.LastOperationStateFailed {
-			if gardencorev1beta1helper.HasErrorCode(shoot.Status.LastErrors, gardencorev1beta1.ErrorInfraRateLimitsExceeded) {
-				return operations.StageResult{}, errors.New("error during cluster provisioning: rate limits exceeded")
-			}
+			// The failures caused by the hyperscaler account, such as exceeded quota or rate limits, are classified by the error codes reported by Gardener
+			logger.Warningf("Provisioning failed! Last state: %s, Description: %s, Error codes: %v", lastOperation.State, lastOperation.Description, operations.ShootErrorCodes(shoot.Status.LastErrors))
 
-			logger.Warningf("Provisioning failed! Last state: %s, Description: %s", lastOperation.State, lastOperation.Description)
-
-			err := errors.New(fmt.Sprintf("cluster provisioning failed. Last Shoot state: %s, Shoot description: %s", lastOperation.State, lastOperation.Description))
-
-			return operations.StageResult{}, operations.NewNonRecoverableError(err)
+			return operations.StageResult{}, operations.NewShootFailedError(shoot.Status.LastErrors, "cluster provisioning failed. Last Shoot state: %s, Shoot description: %s", lastOperation.State, lastOperation.Description)
 		}
 	}
 
@@ -90,15 +84,25 @@ func (s *WaitForClusterCreationStep) proceedToInstallation(cluster model.Cluster
 		}
 	}
 
-	kubeconfig, err := s.kubeconfigProvider.FetchRaw(shoot.Name)
+	kubeconfig, err := s.kubeconfigProvider.FetchRaw(cluster.ClusterConfig.ProjectName, shoot.Name)
 	if err != nil {
 		return operations.StageResult{}, err
 	}
 
-	dberr := s.dbSession.UpdateKubeconfig(cluster.ID, string(kubeconfig))
+	apiServer, err := k8s.ParseAPIServer(kubeconfig)
+	if err != nil {
+		return operations.StageResult{}, fmt.Errorf("error reading API server from kubeconfig: %s", err.Error())
+	}
+
+	dberr := s.dbSession.UpdateKubeconfig(cluster.ID, string(kubeconfig), apiServer)
 	if dberr != nil {
 		return operations.StageResult{}, dberr
 	}
 
+	if cluster.KymaConfig == nil {
+		// Kyma is managed externally, so there is nothing to install and configure on the cluster
+		return operations.StageResult{Stage: model.FinishedStage, Delay: 0}, nil
+	}
+
 	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
 }
@@ -21,12 +21,36 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const shootKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: shoot
+  cluster:
+    server: https://api.shoot.example.com
+    certificate-authority-data: Y2EtY2VydGlmaWNhdGU=
+contexts:
+- name: shoot
+  context:
+    cluster: shoot
+    user: shoot-token
+current-context: shoot
+users:
+- name: shoot-token
+  user:
+    token: token
+`
+
 func TestWaitForClusterInitialization_Run(t *testing.T) {
 
 	clusterName := "name"
 	runtimeID := "runtimeID"
 	tenant := "tenant"
 
+	apiServer := model.APIServer{
+		URL:           "https://api.shoot.example.com",
+		CACertificate: "ca-certificate",
+	}
+
 	cluster := model.Cluster{
 		ID:     runtimeID,
 		Tenant: tenant,
@@ -34,6 +58,7 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 			Name: clusterName,
 			Seed: "az-eu2",
 		},
+		KymaConfig: &model.KymaConfig{},
 	}
 
 	clusterWithoutSeed := model.Cluster{
@@ -42,6 +67,16 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 		ClusterConfig: model.GardenerConfig{
 			Name: clusterName,
 		},
+		KymaConfig: &model.KymaConfig{},
+	}
+
+	clusterWithExternallyManagedKyma := model.Cluster{
+		ID:     runtimeID,
+		Tenant: tenant,
+		ClusterConfig: model.GardenerConfig{
+			Name: clusterName,
+			Seed: "az-eu2",
+		},
 	}
 
 	for _, testCase := range []struct {
@@ -75,9 +110,9 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 			description: "should go to the next stage if cluster was created based on configuration with gardener seed provided",
 			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, dbSession *dbMocks.ReadWriteSession, kubeconfigProvider *provisioning_mocks.KubeconfigProvider) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(fixShootInSucceededStateWithSeed(clusterName, "az-eu2"), nil)
-				kubeconfigProvider.On("FetchRaw", clusterName).Return([]byte("kubeconfig"), nil)
+				kubeconfigProvider.On("FetchRaw", mock.Anything, clusterName).Return([]byte(shootKubeconfig), nil)
 
-				dbSession.On("UpdateKubeconfig", cluster.ID, "kubeconfig").Return(nil)
+				dbSession.On("UpdateKubeconfig", cluster.ID, shootKubeconfig, apiServer).Return(nil)
 
 			},
 			expectedStage: nextStageName,
@@ -88,9 +123,9 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 			description: "should go to the next stage if cluster was created based on configuration without gardener seed provided",
 			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, dbSession *dbMocks.ReadWriteSession, kubeconfigProvider *provisioning_mocks.KubeconfigProvider) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(fixShootInSucceededStateWithSeed(clusterName, "az-eu2"), nil)
-				kubeconfigProvider.On("FetchRaw", clusterName).Return([]byte("kubeconfig"), nil)
+				kubeconfigProvider.On("FetchRaw", mock.Anything, clusterName).Return([]byte(shootKubeconfig), nil)
 
-				dbSession.On("UpdateKubeconfig", cluster.ID, "kubeconfig").Return(nil)
+				dbSession.On("UpdateKubeconfig", cluster.ID, shootKubeconfig, apiServer).Return(nil)
 				dbSession.On("UpdateGardenerClusterConfig", cluster.ClusterConfig).Return(nil)
 
 			},
@@ -98,6 +133,18 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 			expectedDelay: 0,
 			cluster:       clusterWithoutSeed,
 		},
+		{
+			description: "should finish provisioning if cluster was created and Kyma is managed externally",
+			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, dbSession *dbMocks.ReadWriteSession, kubeconfigProvider *provisioning_mocks.KubeconfigProvider) {
+				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(fixShootInSucceededStateWithSeed(clusterName, "az-eu2"), nil)
+				kubeconfigProvider.On("FetchRaw", mock.Anything, clusterName).Return([]byte(shootKubeconfig), nil)
+
+				dbSession.On("UpdateKubeconfig", cluster.ID, shootKubeconfig, apiServer).Return(nil)
+			},
+			expectedStage: model.FinishedStage,
+			expectedDelay: 0,
+			cluster:       clusterWithExternallyManagedKyma,
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			// given
@@ -107,7 +154,7 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, dbSession, kubeconfigProvider)
 
-			waitForClusterCreationStep := NewWaitForClusterCreationStep(gardenerClient, dbSession, kubeconfigProvider, nextStageName, 10*time.Minute)
+			waitForClusterCreationStep := NewWaitForClusterCreationStep(gardenerClientProvider(gardenerClient), dbSession, kubeconfigProvider, nextStageName, 10*time.Minute)
 			// when
 			result, err := waitForClusterCreationStep.Run(testCase.cluster, model.Operation{}, logrus.New())
 
@@ -137,7 +184,7 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 			description: "should return error if failed to fetch kubeconfig",
 			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, dbSession *dbMocks.ReadWriteSession, kubeconfigProvider *provisioning_mocks.KubeconfigProvider) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(fixShootInSucceededState(clusterName), nil)
-				kubeconfigProvider.On("FetchRaw", clusterName).Return(nil, errors.New("some error"))
+				kubeconfigProvider.On("FetchRaw", mock.Anything, clusterName).Return(nil, errors.New("some error"))
 			},
 			unrecoverableError: false,
 			cluster:            cluster,
@@ -158,13 +205,22 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 			unrecoverableError: true,
 			cluster:            cluster,
 		},
+		{
+			description: "should return error if kubeconfig does not contain API server",
+			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, dbSession *dbMocks.ReadWriteSession, kubeconfigProvider *provisioning_mocks.KubeconfigProvider) {
+				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(fixShootInSucceededStateWithSeed(clusterName, "az-eu2"), nil)
+				kubeconfigProvider.On("FetchRaw", mock.Anything, clusterName).Return([]byte("invalid kubeconfig"), nil)
+			},
+			unrecoverableError: false,
+			cluster:            cluster,
+		},
 		{
 			description: "should return error if failed to update kubeconfig data in database",
 			mockFunc: func(gardenerClient *gardener_mocks.GardenerClient, dbSession *dbMocks.ReadWriteSession, kubeconfigProvider *provisioning_mocks.KubeconfigProvider) {
 				gardenerClient.On("Get", context.Background(), clusterName, mock.Anything).Return(fixShootInSucceededStateWithSeed(clusterName, "az-eu2"), nil)
-				kubeconfigProvider.On("FetchRaw", clusterName).Return([]byte("kubeconfig"), nil)
+				kubeconfigProvider.On("FetchRaw", mock.Anything, clusterName).Return([]byte(shootKubeconfig), nil)
 
-				dbSession.On("UpdateKubeconfig", cluster.ID, "kubeconfig").Return(dberrors.Internal("some error"))
+				dbSession.On("UpdateKubeconfig", cluster.ID, shootKubeconfig, apiServer).Return(dberrors.Internal("some error"))
 			},
 			unrecoverableError: false,
 			cluster:            cluster,
@@ -188,7 +244,7 @@ func TestWaitForClusterInitialization_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, dbSession, kubeconfigProvider)
 
-			waitForClusterCreationStep := NewWaitForClusterCreationStep(gardenerClient, dbSession, kubeconfigProvider, nextStageName, 10*time.Minute)
+			waitForClusterCreationStep := NewWaitForClusterCreationStep(gardenerClientProvider(gardenerClient), dbSession, kubeconfigProvider, nextStageName, 10*time.Minute)
 
 			// when
 			_, err := waitForClusterCreationStep.Run(testCase.cluster, model.Operation{}, logrus.New())
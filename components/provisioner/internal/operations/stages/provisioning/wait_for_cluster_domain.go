@@ -20,7 +20,7 @@ import (
 )
 
 type WaitForClusterDomainStep struct {
-	gardenerClient GardenerClient
+	gardenerClient GardenerClientProvider
 	directorClient director.DirectorClient
 	nextStep       model.OperationStage
 	timeLimit      time.Duration
@@ -31,7 +31,10 @@ type GardenerClient interface {
 	Get(ctx context.Context, name string, options v1.GetOptions) (*gardener_types.Shoot, error)
 }
 
-func NewWaitForClusterDomainStep(gardenerClient GardenerClient, directorClient director.DirectorClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForClusterDomainStep {
+// GardenerClientProvider returns the client of Shoots in the namespace of the Gardener project
+type GardenerClientProvider func(project string) GardenerClient
+
+func NewWaitForClusterDomainStep(gardenerClient GardenerClientProvider, directorClient director.DirectorClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForClusterDomainStep {
 	return &WaitForClusterDomainStep{
 		gardenerClient: gardenerClient,
 		directorClient: directorClient,
@@ -49,7 +52,7 @@ func (s *WaitForClusterDomainStep) TimeLimit() time.Duration {
 }
 
 func (s *WaitForClusterDomainStep) Run(cluster model.Cluster, _ model.Operation, _ logrus.FieldLogger) (operations.StageResult, error) {
-	shoot, err := s.gardenerClient.Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
+	shoot, err := s.gardenerClient(cluster.ClusterConfig.ProjectName).Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
 	if err != nil {
 		return operations.StageResult{}, err
 	}
@@ -105,7 +105,7 @@ func TestWaitForClusterDomain_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, directorClient)
 
-			waitForClusterDomainStep := NewWaitForClusterDomainStep(gardenerClient, directorClient, nextStageName, 10*time.Minute)
+			waitForClusterDomainStep := NewWaitForClusterDomainStep(gardenerClientProvider(gardenerClient), directorClient, nextStageName, 10*time.Minute)
 
 			// when
 			result, err := waitForClusterDomainStep.Run(cluster, model.Operation{}, logrus.New())
@@ -165,7 +165,7 @@ func TestWaitForClusterDomain_Run(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient, directorClient)
 
-			waitForClusterDomainStep := NewWaitForClusterDomainStep(gardenerClient, directorClient, nextStageName, 10*time.Minute)
+			waitForClusterDomainStep := NewWaitForClusterDomainStep(gardenerClientProvider(gardenerClient), directorClient, nextStageName, 10*time.Minute)
 
 			// when
 			_, err := waitForClusterDomainStep.Run(testCase.cluster, model.Operation{}, logrus.New())
@@ -203,3 +203,9 @@ func fixRuntime(runtimeId, name string, labels map[string]interface{}) graphql.R
 		Labels: labels,
 	}
 }
+
+func gardenerClientProvider(client GardenerClient) GardenerClientProvider {
+	return func(string) GardenerClient {
+		return client
+	}
+}
@@ -39,6 +39,15 @@ func (s *WaitForInstallationStep) TimeLimit() time.Duration {
 	return s.timeLimit
 }
 
+// OperationTimeLimit returns the installation timeout requested for the operation instead of the default one
+func (s *WaitForInstallationStep) OperationTimeLimit(operation model.Operation) time.Duration {
+	if operation.InstallationTimeoutMinutes != nil {
+		return time.Duration(*operation.InstallationTimeoutMinutes) * time.Minute
+	}
+
+	return s.timeLimit
+}
+
 func (s *WaitForInstallationStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
 
 	if cluster.Kubeconfig == nil {
@@ -78,14 +87,14 @@ func (s *WaitForInstallationStep) Run(cluster model.Cluster, operation model.Ope
 		return operations.StageResult{}, fmt.Errorf("installation not yet started")
 	}
 
-	message := fmt.Sprintf("Installation in progress: %s", installationState.Description)
+	message := fmt.Sprintf("Installation in progress, timeout %s: %s", s.OperationTimeLimit(operation), installationState.Description)
 	logger.Info(message)
 	s.saveInstallationState(message, logger, operation)
 	return operations.StageResult{Stage: s.Name(), Delay: 30 * time.Second}, nil
 }
 
 func (s *WaitForInstallationStep) saveInstallationState(message string, logger logrus.FieldLogger, operation model.Operation) {
-	dberr := s.dbSession.UpdateOperationState(operation.ID, message, operation.State, time.Now())
+	dberr := s.dbSession.UpdateOperationMessage(operation.ID, message)
 	if dberr != nil {
 		logger.Errorf("error updating installation state: %s", dberr.Error())
 	}
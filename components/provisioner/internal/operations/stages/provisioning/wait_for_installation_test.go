@@ -68,8 +68,7 @@ func TestWaitForInstallationStep_Run(t *testing.T) {
 			installationSvc := &installationMocks.Service{}
 			session := &mocks.WriteSession{}
 
-			session.On("UpdateOperationState", operation.ID, mock.AnythingOfType("string"),
-				operation.State, mock.AnythingOfType("time.Time")).Return(nil).Once()
+			session.On("UpdateOperationMessage", operation.ID, mock.AnythingOfType("string")).Return(nil).Once()
 
 			testCase.installationMockFunc(installationSvc)
 
@@ -112,8 +111,7 @@ func TestWaitForInstallationStep_Run(t *testing.T) {
 			Return(installation.InstallationState{}, installation.InstallationError{ShortMessage: "error", Recoverable: false})
 
 		session := &mocks.WriteSession{}
-		session.On("UpdateOperationState", operation.ID, mock.AnythingOfType("string"),
-			operation.State, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		session.On("UpdateOperationMessage", operation.ID, mock.AnythingOfType("string")).Return(nil).Once()
 
 		waitForInstallationStep := NewWaitForInstallationStep(installationSvc, nextStageName, 10*time.Minute, session)
 
@@ -127,4 +125,46 @@ func TestWaitForInstallationStep_Run(t *testing.T) {
 		session.AssertExpectations(t)
 	})
 
+	t.Run("should record installation timeout of the operation in the message", func(t *testing.T) {
+		// given
+		installationSvc := &installationMocks.Service{}
+		installationSvc.On("CheckInstallationState", mock.AnythingOfType("*rest.Config")).
+			Return(installation.InstallationState{State: "InProgress", Description: "Installing"}, nil)
+
+		operationWithTimeout := operation
+		operationWithTimeout.InstallationTimeoutMinutes = util.IntPtr(90)
+
+		session := &mocks.WriteSession{}
+		session.On("UpdateOperationMessage", operation.ID, "Installation in progress, timeout 1h30m0s: Installing").Return(nil).Once()
+
+		waitForInstallationStep := NewWaitForInstallationStep(installationSvc, nextStageName, 10*time.Minute, session)
+
+		// when
+		_, err := waitForInstallationStep.Run(cluster, operationWithTimeout, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		installationSvc.AssertExpectations(t)
+		session.AssertExpectations(t)
+	})
+}
+
+func TestWaitForInstallationStep_OperationTimeLimit(t *testing.T) {
+	waitForInstallationStep := NewWaitForInstallationStep(nil, nextStageName, 60*time.Minute, nil)
+
+	t.Run("should return installation timeout of the operation", func(t *testing.T) {
+		// when
+		timeLimit := waitForInstallationStep.OperationTimeLimit(model.Operation{InstallationTimeoutMinutes: util.IntPtr(120)})
+
+		// then
+		assert.Equal(t, 120*time.Minute, timeLimit)
+	})
+
+	t.Run("should return default time limit when operation does not specify installation timeout", func(t *testing.T) {
+		// when
+		timeLimit := waitForInstallationStep.OperationTimeLimit(model.Operation{})
+
+		// then
+		assert.Equal(t, 60*time.Minute, timeLimit)
+	})
 }
@@ -0,0 +1,232 @@
+package shootupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/provisioning"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/runtime"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/apis/compass/v1alpha1"
+	compass_conn_clientset "github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/client/clientset/versioned/typed/compass/v1alpha1"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	compassConnectionName     = "compass-connection"
+	agentConnectionCheckDelay = 10 * time.Second
+)
+
+// AgentReconnectionConfig configures the verification of the Runtime Agent connection after the Shoot upgrade rolled the nodes.
+// The connection is reconciled if the Compass Connection is not synchronized within the Window, and the operation fails
+// if it is still not synchronized within the Window after the reconciliation. The check can be disabled for Runtimes not using the agent.
+type AgentReconnectionConfig struct {
+	Enabled bool          `envconfig:"default=true"`
+	Window  time.Duration `envconfig:"default=10m"`
+}
+
+// agentConnection reads the Compass Connection of the Runtime Agent and stores the diagnostics of the connection with the operation
+type agentConnection struct {
+	newCompassConnectionClient provisioning.CompassConnectionClientConstructor
+	agentDiagnostics           runtime.AgentDiagnostics
+	dbSession                  dbsession.WriteSession
+	window                     time.Duration
+	now                        func() time.Time
+}
+
+// VerifyAgentConnectionStep waits for the Runtime Agent to be connected after the Shoot upgrade,
+// the Compass Connection is deleted to make the agent connect again if it is not synchronized within the window
+type VerifyAgentConnectionStep struct {
+	agentConnection
+	reconciliationStep model.OperationStage
+	nextStep           model.OperationStage
+	timeLimit          time.Duration
+}
+
+func NewVerifyAgentConnectionStep(
+	ccClientConstructor provisioning.CompassConnectionClientConstructor,
+	agentDiagnostics runtime.AgentDiagnostics,
+	dbSession dbsession.WriteSession,
+	window time.Duration,
+	reconciliationStep model.OperationStage,
+	nextStep model.OperationStage) *VerifyAgentConnectionStep {
+
+	return &VerifyAgentConnectionStep{
+		agentConnection:    newAgentConnection(ccClientConstructor, agentDiagnostics, dbSession, window),
+		reconciliationStep: reconciliationStep,
+		nextStep:           nextStep,
+		timeLimit:          2 * window,
+	}
+}
+
+func (s *VerifyAgentConnectionStep) Name() model.OperationStage {
+	return model.VerifyingAgentConnection
+}
+
+func (s *VerifyAgentConnectionStep) TimeLimit() time.Duration {
+	return s.timeLimit
+}
+
+func (s *VerifyAgentConnectionStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+	if cluster.KymaConfig == nil {
+		// Kyma is managed externally, so the Runtime Agent is not installed by the Provisioner
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	compassConnClient, err := s.client(cluster)
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+
+	compassConnection, connected, err := s.get(compassConnClient)
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+	if connected {
+		logger.Infof("Runtime Agent connected after Shoot upgrade")
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	connectionFailed := compassConnection != nil && compassConnection.Status.State == v1alpha1.ConnectionFailed
+	if !s.windowPassed(operation) && !connectionFailed {
+		logger.Infof("Runtime Agent not yet connected after Shoot upgrade")
+		return operations.StageResult{Stage: s.Name(), Delay: agentConnectionCheckDelay}, nil
+	}
+
+	logger.Warnf("Runtime Agent not connected after Shoot upgrade, reconciling the connection: %s", s.saveDiagnostics(cluster, operation, compassConnection, logger))
+	err = compassConnClient.Delete(context.Background(), compassConnectionName, v1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return operations.StageResult{}, fmt.Errorf("error deleting Compass Connection CR to reconcile the connection: %s", err.Error())
+	}
+
+	return operations.StageResult{Stage: s.reconciliationStep, Delay: agentConnectionCheckDelay, Message: "Runtime Agent connection reconciled"}, nil
+}
+
+// WaitForAgentReconnectionStep waits for the Runtime Agent to connect again after the Compass Connection was deleted,
+// the operation fails with the diagnostics if the agent is not connected within the window
+type WaitForAgentReconnectionStep struct {
+	agentConnection
+	nextStep  model.OperationStage
+	timeLimit time.Duration
+}
+
+func NewWaitForAgentReconnectionStep(
+	ccClientConstructor provisioning.CompassConnectionClientConstructor,
+	agentDiagnostics runtime.AgentDiagnostics,
+	dbSession dbsession.WriteSession,
+	window time.Duration,
+	nextStep model.OperationStage) *WaitForAgentReconnectionStep {
+
+	return &WaitForAgentReconnectionStep{
+		agentConnection: newAgentConnection(ccClientConstructor, agentDiagnostics, dbSession, window),
+		nextStep:        nextStep,
+		timeLimit:       2 * window,
+	}
+}
+
+func (s *WaitForAgentReconnectionStep) Name() model.OperationStage {
+	return model.WaitingForAgentReconnection
+}
+
+func (s *WaitForAgentReconnectionStep) TimeLimit() time.Duration {
+	return s.timeLimit
+}
+
+func (s *WaitForAgentReconnectionStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+	compassConnClient, err := s.client(cluster)
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+
+	compassConnection, connected, err := s.get(compassConnClient)
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+	if connected {
+		logger.Infof("Runtime Agent connected after reconciliation")
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	diagnostics := s.saveDiagnostics(cluster, operation, compassConnection, logger)
+	if s.windowPassed(operation) {
+		return operations.StageResult{}, operations.NewNonRecoverableError(fmt.Errorf("error: Runtime Agent did not connect after Shoot upgrade: %s", diagnostics))
+	}
+
+	logger.Infof("Runtime Agent not yet connected after reconciliation")
+	return operations.StageResult{Stage: s.Name(), Delay: agentConnectionCheckDelay}, nil
+}
+
+func newAgentConnection(ccClientConstructor provisioning.CompassConnectionClientConstructor, agentDiagnostics runtime.AgentDiagnostics, dbSession dbsession.WriteSession, window time.Duration) agentConnection {
+	return agentConnection{
+		newCompassConnectionClient: ccClientConstructor,
+		agentDiagnostics:           agentDiagnostics,
+		dbSession:                  dbSession,
+		window:                     window,
+		now:                        time.Now,
+	}
+}
+
+func (c agentConnection) client(cluster model.Cluster) (compass_conn_clientset.CompassConnectionInterface, error) {
+	if cluster.Kubeconfig == nil {
+		return nil, fmt.Errorf("error: kubeconfig is nil")
+	}
+
+	k8sConfig, err := k8s.ParseToK8sConfig([]byte(*cluster.Kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("error: failed to create kubernetes config from raw: %s", err.Error())
+	}
+
+	compassConnClient, err := c.newCompassConnectionClient(k8sConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error: failed to create Compass Connection client: %s", err.Error())
+	}
+
+	return compassConnClient, nil
+}
+
+// get returns the Compass Connection, which is nil if it does not exist, and checks if the Runtime Agent is connected.
+// The failed synchronization of resources or metadata does not affect the connection.
+func (c agentConnection) get(compassConnClient compass_conn_clientset.CompassConnectionInterface) (*v1alpha1.CompassConnection, bool, error) {
+	compassConnection, err := compassConnClient.Get(context.Background(), compassConnectionName, v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("error getting Compass Connection CR on the Runtime: %s", err.Error())
+	}
+
+	state := compassConnection.Status.State
+	connected := state == v1alpha1.Synchronized || state == v1alpha1.SynchronizationFailed || state == v1alpha1.MetadataUpdateFailed
+
+	return compassConnection, connected, nil
+}
+
+func (c agentConnection) windowPassed(operation model.Operation) bool {
+	stageStart := operation.StartTimestamp
+	if operation.LastTransition != nil {
+		stageStart = *operation.LastTransition
+	}
+
+	return c.now().Sub(stageStart) >= c.window
+}
+
+// saveDiagnostics stores details explaining why the Runtime Agent is not connected with the operation,
+// the diagnostics are saved only when they change
+func (c agentConnection) saveDiagnostics(cluster model.Cluster, operation model.Operation, compassConnection *v1alpha1.CompassConnection, logger logrus.FieldLogger) string {
+	diagnostics := c.agentDiagnostics.Collect(cluster, *cluster.Kubeconfig, compassConnection)
+	if operation.Diagnostics != nil && *operation.Diagnostics == diagnostics {
+		return diagnostics
+	}
+
+	if err := c.dbSession.UpdateOperationDiagnostics(operation.ID, diagnostics); err != nil {
+		logger.Errorf("error updating Runtime Agent diagnostics: %s", err.Error())
+	}
+
+	return diagnostics
+}
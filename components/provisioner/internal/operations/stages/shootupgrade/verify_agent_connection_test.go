@@ -0,0 +1,233 @@
+package shootupgrade
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	runtimeMocks "github.com/kyma-project/control-plane/components/provisioner/internal/runtime/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/apis/compass/v1alpha1"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/client/clientset/versioned/fake"
+	compass_conn_clientset "github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/client/clientset/versioned/typed/compass/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	agentOperationID    = "operationID"
+	agentDiagnostics    = "Compass Connection in ConnectionFailed state"
+	agentWindow         = 10 * time.Minute
+	agentTestKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://api.shoot.example.com
+  name: shoot
+contexts:
+- context:
+    cluster: shoot
+    user: admin
+  name: shoot
+current-context: shoot
+users:
+- name: admin
+  user:
+    token: token
+`
+)
+
+func TestVerifyAgentConnectionStep_Run(t *testing.T) {
+	now := time.Now()
+	cluster := model.Cluster{
+		ID:         "runtimeID",
+		Kubeconfig: util.StringPtr(agentTestKubeconfig),
+		KymaConfig: &model.KymaConfig{},
+	}
+
+	for _, testCase := range []struct {
+		description   string
+		state         v1alpha1.ConnectionState
+		stageStart    time.Time
+		expectedStage model.OperationStage
+		reconciled    bool
+	}{
+		{
+			description:   "should proceed when Runtime Agent is connected",
+			state:         v1alpha1.Synchronized,
+			stageStart:    now,
+			expectedStage: model.FinishedStage,
+		},
+		{
+			description:   "should proceed when only resource synchronization failed",
+			state:         v1alpha1.SynchronizationFailed,
+			stageStart:    now.Add(-time.Hour),
+			expectedStage: model.FinishedStage,
+		},
+		{
+			description:   "should wait for connection within the window",
+			state:         v1alpha1.Connected,
+			stageStart:    now.Add(-time.Minute),
+			expectedStage: model.VerifyingAgentConnection,
+		},
+		{
+			description:   "should reconcile connection not synchronized within the window",
+			state:         v1alpha1.Connected,
+			stageStart:    now.Add(-agentWindow),
+			expectedStage: model.WaitingForAgentReconnection,
+			reconciled:    true,
+		},
+		{
+			description:   "should reconcile failed connection right away",
+			state:         v1alpha1.ConnectionFailed,
+			stageStart:    now,
+			expectedStage: model.WaitingForAgentReconnection,
+			reconciled:    true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			clients := newCompassConnectionClients(fixCompassConnection(testCase.state))
+			diagnostics, dbSession := newAgentDiagnosticsMocks()
+
+			step := NewVerifyAgentConnectionStep(clients.New, diagnostics, dbSession, agentWindow, model.WaitingForAgentReconnection, model.FinishedStage)
+			step.now = func() time.Time { return now }
+
+			// when
+			result, err := step.Run(cluster, model.Operation{ID: agentOperationID, LastTransition: &testCase.stageStart}, logrus.New())
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStage, result.Stage)
+
+			_, err = clients.client.Get(context.Background(), compassConnectionName, v1.GetOptions{})
+			assert.Equal(t, testCase.reconciled, k8serrors.IsNotFound(err))
+		})
+	}
+
+	t.Run("should skip Runtime without Kyma managed by Provisioner", func(t *testing.T) {
+		// given
+		step := NewVerifyAgentConnectionStep(nil, &runtimeMocks.AgentDiagnostics{}, &sessionMocks.WriteSession{}, agentWindow, model.WaitingForAgentReconnection, model.FinishedStage)
+
+		// when
+		result, err := step.Run(model.Cluster{ID: "runtimeID"}, model.Operation{ID: agentOperationID}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.FinishedStage, result.Stage)
+	})
+
+	t.Run("should return error when Compass Connection cannot be read", func(t *testing.T) {
+		// given
+		constructor := func(k8sConfig *rest.Config) (compass_conn_clientset.CompassConnectionInterface, error) {
+			return nil, errors.New("connection refused")
+		}
+		step := NewVerifyAgentConnectionStep(constructor, &runtimeMocks.AgentDiagnostics{}, &sessionMocks.WriteSession{}, agentWindow, model.WaitingForAgentReconnection, model.FinishedStage)
+
+		// when
+		_, err := step.Run(cluster, model.Operation{ID: agentOperationID, StartTimestamp: now}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.False(t, errors.As(err, &operations.NonRecoverableError{}))
+	})
+}
+
+func TestWaitForAgentReconnectionStep_Run(t *testing.T) {
+	now := time.Now()
+	cluster := model.Cluster{
+		ID:         "runtimeID",
+		Kubeconfig: util.StringPtr(agentTestKubeconfig),
+		KymaConfig: &model.KymaConfig{},
+	}
+
+	t.Run("should proceed when Runtime Agent connected after reconciliation", func(t *testing.T) {
+		// given
+		clients := newCompassConnectionClients(fixCompassConnection(v1alpha1.Synchronized))
+		diagnostics, dbSession := newAgentDiagnosticsMocks()
+
+		step := NewWaitForAgentReconnectionStep(clients.New, diagnostics, dbSession, agentWindow, model.FinishedStage)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{ID: agentOperationID, StartTimestamp: now}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.FinishedStage, result.Stage)
+	})
+
+	t.Run("should wait until Compass Connection is recreated", func(t *testing.T) {
+		// given
+		clients := newCompassConnectionClients()
+		diagnostics, dbSession := newAgentDiagnosticsMocks()
+
+		step := NewWaitForAgentReconnectionStep(clients.New, diagnostics, dbSession, agentWindow, model.FinishedStage)
+		step.now = func() time.Time { return now.Add(time.Minute) }
+
+		// when
+		result, err := step.Run(cluster, model.Operation{ID: agentOperationID, StartTimestamp: now}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.WaitingForAgentReconnection, result.Stage)
+		assert.Equal(t, agentConnectionCheckDelay, result.Delay)
+		dbSession.AssertCalled(t, "UpdateOperationDiagnostics", agentOperationID, agentDiagnostics)
+	})
+
+	t.Run("should fail operation with diagnostics when Runtime Agent does not connect within the window", func(t *testing.T) {
+		// given
+		clients := newCompassConnectionClients(fixCompassConnection(v1alpha1.ConnectionFailed))
+		diagnostics, dbSession := newAgentDiagnosticsMocks()
+
+		step := NewWaitForAgentReconnectionStep(clients.New, diagnostics, dbSession, agentWindow, model.FinishedStage)
+		step.now = func() time.Time { return now.Add(agentWindow) }
+
+		// when
+		_, err := step.Run(cluster, model.Operation{ID: agentOperationID, StartTimestamp: now, Diagnostics: util.StringPtr(agentDiagnostics)}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &operations.NonRecoverableError{})
+		assert.Contains(t, err.Error(), agentDiagnostics)
+		dbSession.AssertNotCalled(t, "UpdateOperationDiagnostics", mock.Anything, mock.Anything)
+	})
+}
+
+type compassConnectionClients struct {
+	client compass_conn_clientset.CompassConnectionInterface
+}
+
+func newCompassConnectionClients(objects ...runtime.Object) *compassConnectionClients {
+	return &compassConnectionClients{client: fake.NewSimpleClientset(objects...).CompassV1alpha1().CompassConnections()}
+}
+
+func (c *compassConnectionClients) New(_ *rest.Config) (compass_conn_clientset.CompassConnectionInterface, error) {
+	return c.client, nil
+}
+
+func newAgentDiagnosticsMocks() (*runtimeMocks.AgentDiagnostics, *sessionMocks.WriteSession) {
+	diagnostics := &runtimeMocks.AgentDiagnostics{}
+	diagnostics.On("Collect", mock.Anything, agentTestKubeconfig, mock.Anything).Return(agentDiagnostics)
+
+	dbSession := &sessionMocks.WriteSession{}
+	dbSession.On("UpdateOperationDiagnostics", agentOperationID, agentDiagnostics).Return(nil)
+
+	return diagnostics, dbSession
+}
+
+func fixCompassConnection(state v1alpha1.ConnectionState) *v1alpha1.CompassConnection {
+	return &v1alpha1.CompassConnection{
+		ObjectMeta: v1.ObjectMeta{Name: compassConnectionName},
+		Status:     v1alpha1.CompassConnectionStatus{State: state},
+	}
+}
@@ -2,7 +2,6 @@ package shootupgrade
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -13,12 +12,12 @@ import (
 )
 
 type WaitForShootNewVersionStep struct {
-	gardenerClient GardenerClient
+	gardenerClient GardenerClientProvider
 	nextStep       model.OperationStage
 	timeLimit      time.Duration
 }
 
-func NewWaitForShootNewVersionStep(gardenerClient GardenerClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForShootNewVersionStep {
+func NewWaitForShootNewVersionStep(gardenerClient GardenerClientProvider, nextStep model.OperationStage, timeLimit time.Duration) *WaitForShootNewVersionStep {
 	return &WaitForShootNewVersionStep{
 		gardenerClient: gardenerClient,
 		nextStep:       nextStep,
@@ -38,7 +37,7 @@ func (s *WaitForShootNewVersionStep) Run(cluster model.Cluster, operation model.
 
 	gardenerConfig := cluster.ClusterConfig
 
-	shoot, err := s.gardenerClient.Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
+	shoot, err := s.gardenerClient(gardenerConfig.ProjectName).Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
 	if err != nil {
 		return operations.StageResult{}, err
 	}
@@ -48,8 +47,7 @@ func (s *WaitForShootNewVersionStep) Run(cluster model.Cluster, operation model.
 	}
 
 	if shoot.Status.LastOperation.State == gardencorev1beta1.LastOperationStateFailed {
-		err := fmt.Errorf(fmt.Sprintf("Gardener Shoot cluster upgrade failed. Last Shoot state: %s, Shoot description: %s", shoot.Status.LastOperation.State, shoot.Status.LastOperation.Description))
-		return operations.StageResult{}, operations.NewNonRecoverableError(err)
+		return operations.StageResult{}, operations.NewShootFailedError(shoot.Status.LastErrors, "Gardener Shoot cluster upgrade failed. Last Shoot state: %s, Shoot description: %s", shoot.Status.LastOperation.State, shoot.Status.LastOperation.Description)
 	}
 
 	return operations.StageResult{Stage: s.Name(), Delay: 5 * time.Second}, nil
@@ -69,7 +69,7 @@ func TestWaitForNewShootVersion(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient)
 
-			waitForShootClusterUpgradeStep := NewWaitForShootNewVersionStep(gardenerClient, model.WaitingForShootUpgrade, time.Minute)
+			waitForShootClusterUpgradeStep := NewWaitForShootNewVersionStep(gardenerClientProvider(gardenerClient), model.WaitingForShootUpgrade, time.Minute)
 
 			// when
 			result, err := waitForShootClusterUpgradeStep.Run(cluster, model.Operation{ID: operationID}, logrus.New())
@@ -116,7 +116,7 @@ func TestWaitForNewShootVersion(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient)
 
-			waitForClusterCreationStep := NewWaitForShootNewVersionStep(gardenerClient, model.FinishedStage, time.Minute)
+			waitForClusterCreationStep := NewWaitForShootNewVersionStep(gardenerClientProvider(gardenerClient), model.FinishedStage, time.Minute)
 
 			// when
 			_, err := waitForClusterCreationStep.Run(testCase.cluster, model.Operation{}, logrus.New())
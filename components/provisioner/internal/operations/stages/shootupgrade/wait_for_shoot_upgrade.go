@@ -2,12 +2,8 @@ package shootupgrade
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"time"
 
-	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
-
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
@@ -20,13 +16,16 @@ type GardenerClient interface {
 	Get(ctx context.Context, name string, options v1.GetOptions) (*gardener_types.Shoot, error)
 }
 
+// GardenerClientProvider returns the client of Shoots in the namespace of the Gardener project
+type GardenerClientProvider func(project string) GardenerClient
+
 type WaitForShootUpgradeStep struct {
-	gardenerClient GardenerClient
+	gardenerClient GardenerClientProvider
 	nextStep       model.OperationStage
 	timeLimit      time.Duration
 }
 
-func NewWaitForShootUpgradeStep(gardenerClient GardenerClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForShootUpgradeStep {
+func NewWaitForShootUpgradeStep(gardenerClient GardenerClientProvider, nextStep model.OperationStage, timeLimit time.Duration) *WaitForShootUpgradeStep {
 	return &WaitForShootUpgradeStep{
 		gardenerClient: gardenerClient,
 		nextStep:       nextStep,
@@ -46,7 +45,7 @@ func (s *WaitForShootUpgradeStep) Run(cluster model.Cluster, _ model.Operation,
 
 	gardenerConfig := cluster.ClusterConfig
 
-	shoot, err := s.gardenerClient.Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
+	shoot, err := s.gardenerClient(gardenerConfig.ProjectName).Get(context.Background(), gardenerConfig.Name, v1.GetOptions{})
 	if err != nil {
 		return operations.StageResult{}, err
 	}
@@ -59,14 +58,10 @@ func (s *WaitForShootUpgradeStep) Run(cluster model.Cluster, _ model.Operation,
 		}
 
 		if lastOperation.State == gardencorev1beta1.LastOperationStateFailed {
-			if gardencorev1beta1helper.HasErrorCode(shoot.Status.LastErrors, gardencorev1beta1.ErrorInfraRateLimitsExceeded) {
-				return operations.StageResult{}, errors.New("error during shoot cluster upgrade: rate limits exceeded")
-			}
-			logger.Warningf("Gardener Shoot cluster upgrade operation failed! Last state: %s, Description: %s", lastOperation.State, lastOperation.Description)
-
-			err := errors.New(fmt.Sprintf("Gardener Shoot cluster upgrade failed. Last Shoot state: %s, Shoot description: %s", lastOperation.State, lastOperation.Description))
+			// The failures caused by the hyperscaler account, such as exceeded quota or rate limits, are classified by the error codes reported by Gardener
+			logger.Warningf("Gardener Shoot cluster upgrade operation failed! Last state: %s, Description: %s, Error codes: %v", lastOperation.State, lastOperation.Description, operations.ShootErrorCodes(shoot.Status.LastErrors))
 
-			return operations.StageResult{}, operations.NewNonRecoverableError(err)
+			return operations.StageResult{}, operations.NewShootFailedError(shoot.Status.LastErrors, "Gardener Shoot cluster upgrade failed. Last Shoot state: %s, Shoot description: %s", lastOperation.State, lastOperation.Description)
 		}
 	}
 
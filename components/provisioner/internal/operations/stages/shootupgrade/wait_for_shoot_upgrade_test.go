@@ -99,7 +99,7 @@ func TestWaitForClusterUpgrade(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient)
 
-			waitForrShootClusterUpgradeStep := NewWaitForShootUpgradeStep(gardenerClient, model.FinishedStage, time.Minute)
+			waitForrShootClusterUpgradeStep := NewWaitForShootUpgradeStep(gardenerClientProvider(gardenerClient), model.FinishedStage, time.Minute)
 			// when
 			result, err := waitForrShootClusterUpgradeStep.Run(cluster, model.Operation{}, logrus.New())
 
@@ -155,7 +155,7 @@ func TestWaitForClusterUpgrade(t *testing.T) {
 
 			testCase.mockFunc(gardenerClient)
 
-			waitForClusterCreationStep := NewWaitForShootUpgradeStep(gardenerClient, model.FinishedStage, time.Minute)
+			waitForClusterCreationStep := NewWaitForShootUpgradeStep(gardenerClientProvider(gardenerClient), model.FinishedStage, time.Minute)
 
 			// when
 			_, err := waitForClusterCreationStep.Run(testCase.cluster, model.Operation{}, logrus.New())
@@ -168,3 +168,9 @@ func TestWaitForClusterUpgrade(t *testing.T) {
 		})
 	}
 }
+
+func gardenerClientProvider(client GardenerClient) GardenerClientProvider {
+	return func(string) GardenerClient {
+		return client
+	}
+}
@@ -0,0 +1,267 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	installationSDK "github.com/kyma-incubator/hydroform/install/installation"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/installation"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const postUpgradeCheckInterval = 20 * time.Second
+
+// DefaultHealthCheckDeployments are checked after the upgrade if the config does not specify the deployments
+var DefaultHealthCheckDeployments = []string{
+	"istio-system/istiod",
+	"kyma-system/api-gateway",
+	"compass-system/compass-runtime-agent",
+}
+
+// HealthChecksConfig configures verification of the cluster health before and after the Kyma upgrade.
+// The checks are skipped for upgrades started with skipHealthChecks, e.g. emergency upgrades of broken clusters.
+type HealthChecksConfig struct {
+	Enabled bool `envconfig:"default=false"`
+	// Deployments checked for readiness after the upgrade in the namespace/name format, DefaultHealthCheckDeployments are used if empty
+	Deployments []string      `envconfig:"optional"`
+	Timeout     time.Duration `envconfig:"default=10m"`
+}
+
+type PreUpgradeHealthCheckStep struct {
+	installationClient installation.Service
+	k8sClientProvider  k8s.K8sClientProvider
+	enabled            bool
+	nextStep           model.OperationStage
+	timeLimit          time.Duration
+}
+
+func NewPreUpgradeHealthCheckStep(installationClient installation.Service, k8sClientProvider k8s.K8sClientProvider, config HealthChecksConfig, nextStep model.OperationStage, timeLimit time.Duration) *PreUpgradeHealthCheckStep {
+	return &PreUpgradeHealthCheckStep{
+		installationClient: installationClient,
+		k8sClientProvider:  k8sClientProvider,
+		enabled:            config.Enabled,
+		nextStep:           nextStep,
+		timeLimit:          timeLimit,
+	}
+}
+
+func (s *PreUpgradeHealthCheckStep) Name() model.OperationStage {
+	return model.CheckingPreUpgradeHealth
+}
+
+func (s *PreUpgradeHealthCheckStep) TimeLimit() time.Duration {
+	return s.timeLimit
+}
+
+func (s *PreUpgradeHealthCheckStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+	if !s.enabled || operation.Force {
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	if cluster.Kubeconfig == nil {
+		return operations.StageResult{}, fmt.Errorf("error: kubeconfig is nil")
+	}
+
+	k8sConfig, err := k8s.ParseToK8sConfig([]byte(*cluster.Kubeconfig))
+	if err != nil {
+		return operations.StageResult{}, fmt.Errorf("error: failed to create kubernetes config from raw: %s", err.Error())
+	}
+
+	var problems []string
+
+	installationState, err := s.installationClient.CheckInstallationState(k8sConfig)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("Installation CR is not healthy: %s", err.Error()))
+	} else if installationState.State != "Installed" {
+		problems = append(problems, fmt.Sprintf("Installation CR is in %s state: %s", displayState(installationState), installationState.Description))
+	}
+
+	k8sClient, err := s.k8sClientProvider.CreateK8SClient(*cluster.Kubeconfig)
+	if err != nil {
+		return operations.StageResult{}, fmt.Errorf("failed to create k8s client: %v", err)
+	}
+
+	notReadyNodes, err := findNotReadyNodes(k8sClient)
+	if err != nil {
+		return operations.StageResult{}, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	if len(notReadyNodes) > 0 {
+		problems = append(problems, fmt.Sprintf("nodes not Ready: %s", strings.Join(notReadyNodes, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return operations.StageResult{}, operations.NewNonRecoverableError(fmt.Errorf("pre-upgrade health check failed: %s", strings.Join(problems, "; ")))
+	}
+
+	logger.Info("Pre-upgrade health check passed")
+	return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+}
+
+type PostUpgradeHealthCheckStep struct {
+	k8sClientProvider k8s.K8sClientProvider
+	enabled           bool
+	deployments       []string
+	timeout           time.Duration
+	nextStep          model.OperationStage
+	timeLimit         time.Duration
+}
+
+// NewPostUpgradeHealthCheckStep creates the step polling the deployments for readiness, timeLimit should exceed the timeout of the checks,
+// so that the operation fails with the report of the deployments which are not ready instead of the stage timeout
+func NewPostUpgradeHealthCheckStep(k8sClientProvider k8s.K8sClientProvider, config HealthChecksConfig, nextStep model.OperationStage, timeLimit time.Duration) *PostUpgradeHealthCheckStep {
+	deployments := config.Deployments
+	if len(deployments) == 0 {
+		deployments = DefaultHealthCheckDeployments
+	}
+
+	return &PostUpgradeHealthCheckStep{
+		k8sClientProvider: k8sClientProvider,
+		enabled:           config.Enabled,
+		deployments:       deployments,
+		timeout:           config.Timeout,
+		nextStep:          nextStep,
+		timeLimit:         timeLimit,
+	}
+}
+
+func (s *PostUpgradeHealthCheckStep) Name() model.OperationStage {
+	return model.CheckingPostUpgradeHealth
+}
+
+func (s *PostUpgradeHealthCheckStep) TimeLimit() time.Duration {
+	return s.timeLimit
+}
+
+func (s *PostUpgradeHealthCheckStep) Run(cluster model.Cluster, operation model.Operation, logger logrus.FieldLogger) (operations.StageResult, error) {
+	if !s.enabled || operation.Force {
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	if cluster.Kubeconfig == nil {
+		return operations.StageResult{}, fmt.Errorf("error: kubeconfig is nil")
+	}
+
+	k8sClient, err := s.k8sClientProvider.CreateK8SClient(*cluster.Kubeconfig)
+	if err != nil {
+		return operations.StageResult{}, fmt.Errorf("failed to create k8s client: %v", err)
+	}
+
+	var notReady []string
+	for _, deployment := range s.deployments {
+		problem, err := checkDeployment(k8sClient, deployment)
+		if err != nil {
+			return operations.StageResult{}, err
+		}
+		if problem != "" {
+			notReady = append(notReady, fmt.Sprintf("%s: %s", deployment, problem))
+		}
+	}
+
+	if len(notReady) == 0 {
+		logger.Infof("Post-upgrade health check passed, %d deployments ready", len(s.deployments))
+		return operations.StageResult{Stage: s.nextStep, Delay: 0}, nil
+	}
+
+	if time.Since(stageStartTime(operation)) > s.timeout {
+		return operations.StageResult{}, operations.NewNonRecoverableError(fmt.Errorf("post-upgrade health check failed, deployments not ready within %s: %s", s.timeout, strings.Join(notReady, "; ")))
+	}
+
+	logger.Infof("Waiting for deployments to be ready: %s", strings.Join(notReady, "; "))
+	return operations.StageResult{Stage: s.Name(), Delay: postUpgradeCheckInterval}, nil
+}
+
+func findNotReadyNodes(k8sClient kubernetes.Interface) ([]string, error) {
+	nodes, err := k8sClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var notReady []string
+	for _, node := range nodes.Items {
+		if !nodeReady(node) {
+			notReady = append(notReady, node.Name)
+		}
+	}
+	sort.Strings(notReady)
+
+	return notReady, nil
+}
+
+func nodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// checkDeployment returns the reason why the deployment is not ready, empty if it is ready
+func checkDeployment(k8sClient kubernetes.Interface, deployment string) (string, error) {
+	namespace, name, err := splitDeploymentName(deployment)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	found, err := k8sClient.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return "not found", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment %s: %v", deployment, err)
+	}
+
+	return deploymentProblem(found), nil
+}
+
+func deploymentProblem(deployment *appsv1.Deployment) string {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return "rollout not observed yet"
+	}
+	if deployment.Status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("%d of %d replicas updated", deployment.Status.UpdatedReplicas, replicas)
+	}
+	if deployment.Status.ReadyReplicas < replicas {
+		return fmt.Sprintf("%d of %d replicas ready", deployment.Status.ReadyReplicas, replicas)
+	}
+
+	return ""
+}
+
+func splitDeploymentName(deployment string) (string, string, error) {
+	parts := strings.Split(deployment, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid deployment %q, expected namespace/name", deployment)
+	}
+	return parts[0], parts[1], nil
+}
+
+func displayState(state installationSDK.InstallationState) string {
+	if state.State == "" {
+		return "unknown"
+	}
+	return state.State
+}
+
+func stageStartTime(operation model.Operation) time.Time {
+	if operation.LastTransition != nil {
+		return *operation.LastTransition
+	}
+	return operation.StartTimestamp
+}
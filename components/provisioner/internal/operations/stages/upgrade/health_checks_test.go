@@ -0,0 +1,205 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/install/installation"
+	installationMocks "github.com/kyma-project/control-plane/components/provisioner/internal/installation/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPreUpgradeHealthCheckStep_Run(t *testing.T) {
+	enabled := HealthChecksConfig{Enabled: true}
+	cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+
+	t.Run("should return next step when checks are disabled", func(t *testing.T) {
+		// given
+		step := NewPreUpgradeHealthCheckStep(nil, nil, HealthChecksConfig{}, nextStageName, time.Minute)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+	})
+
+	t.Run("should return next step when checks are skipped for the operation", func(t *testing.T) {
+		// given
+		step := NewPreUpgradeHealthCheckStep(nil, nil, enabled, nextStageName, time.Minute)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{Force: true}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+	})
+
+	t.Run("should return next step when installation is healthy and nodes are ready", func(t *testing.T) {
+		// given
+		installationClient := &installationMocks.Service{}
+		installationClient.On("CheckInstallationState", mock.Anything).Return(installation.InstallationState{State: "Installed"}, nil)
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfig).Return(fake.NewSimpleClientset(fixNode("node-1", corev1.ConditionTrue)), nil)
+
+		step := NewPreUpgradeHealthCheckStep(installationClient, k8sClientProvider, enabled, nextStageName, time.Minute)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+		assert.Equal(t, time.Duration(0), result.Delay)
+	})
+
+	t.Run("should fail with report when installation is not healthy and nodes are not ready", func(t *testing.T) {
+		// given
+		installationClient := &installationMocks.Service{}
+		installationClient.On("CheckInstallationState", mock.Anything).Return(installation.InstallationState{State: "InProgress", Description: "Installing component"}, nil)
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfig).Return(fake.NewSimpleClientset(
+			fixNode("node-2", corev1.ConditionFalse),
+			fixNode("node-1", corev1.ConditionTrue),
+			fixNode("node-0", corev1.ConditionUnknown),
+		), nil)
+
+		step := NewPreUpgradeHealthCheckStep(installationClient, k8sClientProvider, enabled, nextStageName, time.Minute)
+
+		// when
+		_, err := step.Run(cluster, model.Operation{}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.IsType(t, operations.NonRecoverableError{}, err)
+		assert.Contains(t, err.Error(), "Installation CR is in InProgress state: Installing component")
+		assert.Contains(t, err.Error(), "nodes not Ready: node-0, node-2")
+	})
+
+	t.Run("should return error when kubeconfig is nil", func(t *testing.T) {
+		// given
+		step := NewPreUpgradeHealthCheckStep(nil, nil, enabled, nextStageName, time.Minute)
+
+		// when
+		_, err := step.Run(model.Cluster{}, model.Operation{}, logrus.New())
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestPostUpgradeHealthCheckStep_Run(t *testing.T) {
+	config := HealthChecksConfig{
+		Enabled:     true,
+		Deployments: []string{"kyma-system/api-gateway", "istio-system/istiod"},
+		Timeout:     10 * time.Minute,
+	}
+	cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+
+	stepWithObjects := func(objects ...runtime.Object) *PostUpgradeHealthCheckStep {
+		k8sClientProvider := &mocks.K8sClientProvider{}
+		k8sClientProvider.On("CreateK8SClient", kubeconfig).Return(fake.NewSimpleClientset(objects...), nil)
+
+		return NewPostUpgradeHealthCheckStep(k8sClientProvider, config, nextStageName, 15*time.Minute)
+	}
+
+	t.Run("should use default deployments if none configured", func(t *testing.T) {
+		// when
+		step := NewPostUpgradeHealthCheckStep(nil, HealthChecksConfig{Enabled: true}, nextStageName, time.Minute)
+
+		// then
+		assert.Equal(t, DefaultHealthCheckDeployments, step.deployments)
+	})
+
+	t.Run("should return next step when checks are skipped for the operation", func(t *testing.T) {
+		// given
+		step := NewPostUpgradeHealthCheckStep(nil, config, nextStageName, time.Minute)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{Force: true}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+	})
+
+	t.Run("should return next step when deployments are ready", func(t *testing.T) {
+		// given
+		step := stepWithObjects(
+			fixDeployment("kyma-system", "api-gateway", 2, 2),
+			fixDeployment("istio-system", "istiod", 1, 1),
+		)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{StartTimestamp: time.Now()}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, nextStageName, result.Stage)
+		assert.Equal(t, time.Duration(0), result.Delay)
+	})
+
+	t.Run("should wait when deployments are not ready before timeout", func(t *testing.T) {
+		// given
+		step := stepWithObjects(
+			fixDeployment("kyma-system", "api-gateway", 2, 1),
+			fixDeployment("istio-system", "istiod", 1, 1),
+		)
+
+		// when
+		result, err := step.Run(cluster, model.Operation{StartTimestamp: time.Now()}, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.CheckingPostUpgradeHealth, result.Stage)
+		assert.Equal(t, postUpgradeCheckInterval, result.Delay)
+	})
+
+	t.Run("should fail with report when deployments are not ready after timeout", func(t *testing.T) {
+		// given
+		step := stepWithObjects(fixDeployment("kyma-system", "api-gateway", 2, 1))
+
+		// when
+		_, err := step.Run(cluster, model.Operation{StartTimestamp: time.Now().Add(-11 * time.Minute)}, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.IsType(t, operations.NonRecoverableError{}, err)
+		assert.Contains(t, err.Error(), "kyma-system/api-gateway: 1 of 2 replicas ready")
+		assert.Contains(t, err.Error(), "istio-system/istiod: not found")
+	})
+}
+
+func fixNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+func fixDeployment(namespace, name string, replicas, readyReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas: replicas,
+			ReadyReplicas:   readyReplicas,
+		},
+	}
+}
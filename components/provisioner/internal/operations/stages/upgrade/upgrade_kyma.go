@@ -72,7 +72,7 @@ func (s *UpgradeKymaStep) Run(cluster model.Cluster, _ model.Operation, logger l
 			cluster.KymaConfig.Profile,
 			cluster.KymaConfig.Release,
 			cluster.KymaConfig.GlobalConfiguration,
-			cluster.KymaConfig.Components)
+			installation.ClusterComponents(cluster))
 		if err != nil {
 			return operations.StageResult{}, fmt.Errorf("error: failed to trigger upgrade: %s", err.Error())
 		}
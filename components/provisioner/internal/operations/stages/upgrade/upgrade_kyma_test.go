@@ -71,7 +71,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 		upgradeStep := NewUpgradeKymaStep(installationClient, nextStageName, 0)
 
-		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig), KymaConfig: &model.KymaConfig{}}
 
 		//when
 		_, err := upgradeStep.Run(cluster, model.Operation{}, logrus.New())
@@ -87,7 +87,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 		upgradeStep := NewUpgradeKymaStep(installationClient, nextStageName, 0)
 
-		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig), KymaConfig: &model.KymaConfig{}}
 
 		//when
 		_, err := upgradeStep.Run(cluster, model.Operation{}, logrus.New())
@@ -103,7 +103,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 		upgradeStep := NewUpgradeKymaStep(installationClient, nextStageName, 0)
 
-		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig), KymaConfig: &model.KymaConfig{}}
 
 		//when
 		result, err := upgradeStep.Run(cluster, model.Operation{}, logrus.New())
@@ -121,7 +121,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 		upgradeStep := NewUpgradeKymaStep(installationClient, nextStageName, 0)
 
-		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig), KymaConfig: &model.KymaConfig{}}
 
 		//when
 		result, err := upgradeStep.Run(cluster, model.Operation{}, logrus.New())
@@ -139,7 +139,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 		upgradeStep := NewUpgradeKymaStep(installationClient, nextStageName, 0)
 
-		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig), KymaConfig: &model.KymaConfig{}}
 
 		//when
 		_, err := upgradeStep.Run(cluster, model.Operation{}, logrus.New())
@@ -156,7 +156,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 		upgradeStep := NewUpgradeKymaStep(installationClient, nextStageName, 0)
 
-		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig), KymaConfig: &model.KymaConfig{}}
 
 		//when
 		result, err := upgradeStep.Run(cluster, model.Operation{}, logrus.New())
@@ -173,7 +173,7 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 
 		upgradeStep := NewUpgradeKymaStep(installationClient, nextStageName, 0)
 
-		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig)}
+		cluster := model.Cluster{Kubeconfig: util.StringPtr(kubeconfig), KymaConfig: &model.KymaConfig{}}
 
 		//when
 		result, err := upgradeStep.Run(cluster, model.Operation{}, logrus.New())
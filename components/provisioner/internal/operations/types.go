@@ -18,9 +18,18 @@ type Step interface {
 	TimeLimit() time.Duration
 }
 
+// OperationTimeLimiter is implemented by steps whose time limit can be overridden for the operation
+type OperationTimeLimiter interface {
+	OperationTimeLimit(operation model.Operation) time.Duration
+}
+
 type StageResult struct {
 	Stage model.OperationStage
 	Delay time.Duration
+	// Message is appended to the operation message when the operation transitions to the next stage
+	Message string
+	// Retries is the number of previous executions of the stage for the operation, it is set by the executor
+	Retries int
 }
 
 type NonRecoverableError struct {
@@ -31,6 +40,10 @@ func (r NonRecoverableError) Error() string {
 	return r.error.Error()
 }
 
+func (r NonRecoverableError) Unwrap() error {
+	return r.error
+}
+
 func NewNonRecoverableError(err error) NonRecoverableError {
 	return NonRecoverableError{error: err}
 }
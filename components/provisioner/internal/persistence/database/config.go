@@ -0,0 +1,135 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const connStringFormat string = "host=%s port=%s user=%s password=%s dbname=%s sslmode=%s"
+
+var supportedSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+type Config struct {
+	User     string `envconfig:"default=postgres"`
+	Password string `envconfig:"default=password"`
+	Host     string `envconfig:"default=localhost"`
+	Port     string `envconfig:"default=5432"`
+	Name     string `envconfig:"default=provisioner"`
+	SSLMode  string `envconfig:"default=disable"`
+
+	// SSLRootCertPath is the path to the PEM file with the CA certificates used to verify the server certificate
+	SSLRootCertPath string `envconfig:"optional"`
+	// SSLCertPath and SSLKeyPath are paths to the PEM files with the client certificate and its private key,
+	// the private key file must not be accessible by group or others
+	SSLCertPath string `envconfig:"optional"`
+	SSLKeyPath  string `envconfig:"optional"`
+
+	QueryTimeout       time.Duration `envconfig:"default=30s"`
+	SlowQueryThreshold time.Duration `envconfig:"default=1s"`
+
+	// VerifySchemaVersion makes the Provisioner wait at startup for the schema migrator to apply the migrations it requires,
+	// it can be disabled for databases set up without the schema migrator
+	VerifySchemaVersion bool `envconfig:"default=true"`
+}
+
+// ConnectionString builds the connection string in the key/value format accepted by lib/pq,
+// the SSL file parameters are added only if they are configured
+func (c Config) ConnectionString() string {
+	connString := fmt.Sprintf(connStringFormat, c.Host, c.Port, c.User, c.Password, c.Name, c.SSLMode)
+
+	if c.SSLRootCertPath != "" {
+		connString += fmt.Sprintf(" sslrootcert=%s", quoteConnStringValue(c.SSLRootCertPath))
+	}
+	if c.SSLCertPath != "" {
+		connString += fmt.Sprintf(" sslcert=%s", quoteConnStringValue(c.SSLCertPath))
+	}
+	if c.SSLKeyPath != "" {
+		connString += fmt.Sprintf(" sslkey=%s", quoteConnStringValue(c.SSLKeyPath))
+	}
+
+	return connString
+}
+
+// Validate checks that the SSL mode is supported and that the referenced certificate files exist and can be parsed,
+// so that the misconfiguration is reported at startup instead of failing every connection attempt
+func (c Config) Validate() error {
+	if !isSupportedSSLMode(c.SSLMode) {
+		return errors.Errorf("SSL mode %q is not supported, use one of: %s", c.SSLMode, strings.Join(supportedSSLModes, ", "))
+	}
+
+	sslFilesConfigured := c.SSLRootCertPath != "" || c.SSLCertPath != "" || c.SSLKeyPath != ""
+	if c.SSLMode == "disable" && sslFilesConfigured {
+		return errors.New("SSL certificates are configured, but SSL mode is disable")
+	}
+
+	if c.SSLRootCertPath != "" {
+		if err := validateRootCert(c.SSLRootCertPath); err != nil {
+			return errors.Wrap(err, "invalid SSL root certificate")
+		}
+	}
+
+	if (c.SSLCertPath == "") != (c.SSLKeyPath == "") {
+		return errors.New("both SSL client certificate and key must be configured")
+	}
+
+	if c.SSLCertPath != "" {
+		if err := validateClientCert(c.SSLCertPath, c.SSLKeyPath); err != nil {
+			return errors.Wrap(err, "invalid SSL client certificate")
+		}
+	}
+
+	return nil
+}
+
+func isSupportedSSLMode(sslMode string) bool {
+	for _, mode := range supportedSSLModes {
+		if mode == sslMode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateRootCert(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read file")
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(content) {
+		return errors.Errorf("file %s does not contain PEM encoded certificates", path)
+	}
+
+	return nil
+}
+
+func validateClientCert(certPath, keyPath string) error {
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read key file")
+	}
+	// lib/pq rejects keys accessible by group or others
+	if info.Mode().Perm()&0077 != 0 {
+		return errors.Errorf("key file %s has group or world access, permissions must be u=rw (0600) or less", keyPath)
+	}
+
+	_, err = tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to load certificate and key")
+	}
+
+	return nil
+}
+
+func quoteConnStringValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return fmt.Sprintf("'%s'", replacer.Replace(value))
+}
@@ -0,0 +1,254 @@
+package database
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ConnectionString(t *testing.T) {
+	for _, testCase := range []struct {
+		description        string
+		config             Config
+		expectedConnString string
+	}{
+		{
+			description:        "should build legacy connection string with SSL disabled",
+			config:             fixConfig("disable"),
+			expectedConnString: "host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=disable",
+		},
+		{
+			description:        "should build connection string with SSL allowed",
+			config:             fixConfig("allow"),
+			expectedConnString: "host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=allow",
+		},
+		{
+			description:        "should build connection string with SSL preferred",
+			config:             fixConfig("prefer"),
+			expectedConnString: "host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=prefer",
+		},
+		{
+			description:        "should build connection string with SSL required",
+			config:             fixConfig("require"),
+			expectedConnString: "host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=require",
+		},
+		{
+			description: "should build connection string with custom root CA",
+			config: func() Config {
+				config := fixConfig("verify-ca")
+				config.SSLRootCertPath = "/etc/ssl/db/root.crt"
+				return config
+			}(),
+			expectedConnString: "host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=verify-ca sslrootcert='/etc/ssl/db/root.crt'",
+		},
+		{
+			description: "should build connection string with custom root CA and client certificate",
+			config: func() Config {
+				config := fixConfig("verify-full")
+				config.SSLRootCertPath = "/etc/ssl/db/root.crt"
+				config.SSLCertPath = "/etc/ssl/db/client.crt"
+				config.SSLKeyPath = "/etc/ssl/db/client.key"
+				return config
+			}(),
+			expectedConnString: "host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=verify-full " +
+				"sslrootcert='/etc/ssl/db/root.crt' sslcert='/etc/ssl/db/client.crt' sslkey='/etc/ssl/db/client.key'",
+		},
+		{
+			description: "should build connection string with client certificate and system root CAs",
+			config: func() Config {
+				config := fixConfig("verify-full")
+				config.SSLCertPath = "/etc/ssl/db/client.crt"
+				config.SSLKeyPath = "/etc/ssl/db/client.key"
+				return config
+			}(),
+			expectedConnString: "host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=verify-full " +
+				"sslcert='/etc/ssl/db/client.crt' sslkey='/etc/ssl/db/client.key'",
+		},
+		{
+			description: "should quote paths with spaces and quotes",
+			config: func() Config {
+				config := fixConfig("verify-full")
+				config.SSLRootCertPath = `/etc/ssl/db certs/it's root.crt`
+				return config
+			}(),
+			expectedConnString: `host=localhost port=5432 user=postgres password=password dbname=provisioner sslmode=verify-full sslrootcert='/etc/ssl/db certs/it\'s root.crt'`,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			connString := testCase.config.ConnectionString()
+
+			// then
+			assert.Equal(t, testCase.expectedConnString, connString)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	dir := t.TempDir()
+
+	rootCertPath, _ := writeTestCertificate(t, dir, "root")
+	clientCertPath, clientKeyPath := writeTestCertificate(t, dir, "client")
+	_, otherKeyPath := writeTestCertificate(t, dir, "other")
+
+	worldReadableKeyPath := filepath.Join(dir, "world-readable.key")
+	writeFile(t, worldReadableKeyPath, readFile(t, clientKeyPath), 0644)
+
+	invalidCertPath := filepath.Join(dir, "invalid.crt")
+	writeFile(t, invalidCertPath, []byte("not a certificate"), 0600)
+
+	t.Run("should accept legacy configuration with SSL disabled", func(t *testing.T) {
+		// when
+		err := fixConfig("disable").Validate()
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should accept configuration with custom root CA and client certificate", func(t *testing.T) {
+		// given
+		config := fixConfig("verify-full")
+		config.SSLRootCertPath = rootCertPath
+		config.SSLCertPath = clientCertPath
+		config.SSLKeyPath = clientKeyPath
+
+		// when
+		err := config.Validate()
+
+		// then
+		require.NoError(t, err)
+	})
+
+	for _, testCase := range []struct {
+		description   string
+		modifyConfig  func(config *Config)
+		expectedError string
+	}{
+		{
+			description: "should reject unsupported SSL mode",
+			modifyConfig: func(config *Config) {
+				config.SSLMode = "verify"
+			},
+			expectedError: `SSL mode "verify" is not supported`,
+		},
+		{
+			description: "should reject certificates when SSL is disabled",
+			modifyConfig: func(config *Config) {
+				config.SSLMode = "disable"
+				config.SSLRootCertPath = rootCertPath
+			},
+			expectedError: "SSL certificates are configured, but SSL mode is disable",
+		},
+		{
+			description: "should reject missing root CA file",
+			modifyConfig: func(config *Config) {
+				config.SSLRootCertPath = filepath.Join(dir, "missing.crt")
+			},
+			expectedError: "invalid SSL root certificate: failed to read file",
+		},
+		{
+			description: "should reject root CA file without certificates",
+			modifyConfig: func(config *Config) {
+				config.SSLRootCertPath = invalidCertPath
+			},
+			expectedError: "does not contain PEM encoded certificates",
+		},
+		{
+			description: "should reject client certificate without key",
+			modifyConfig: func(config *Config) {
+				config.SSLCertPath = clientCertPath
+			},
+			expectedError: "both SSL client certificate and key must be configured",
+		},
+		{
+			description: "should reject key accessible by others",
+			modifyConfig: func(config *Config) {
+				config.SSLCertPath = clientCertPath
+				config.SSLKeyPath = worldReadableKeyPath
+			},
+			expectedError: "has group or world access",
+		},
+		{
+			description: "should reject key not matching the client certificate",
+			modifyConfig: func(config *Config) {
+				config.SSLCertPath = clientCertPath
+				config.SSLKeyPath = otherKeyPath
+			},
+			expectedError: "failed to load certificate and key",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			config := fixConfig("verify-full")
+			testCase.modifyConfig(&config)
+
+			// when
+			err := config.Validate()
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+}
+
+func fixConfig(sslMode string) Config {
+	return Config{
+		User:     "postgres",
+		Password: "password",
+		Host:     "localhost",
+		Port:     "5432",
+		Name:     "provisioner",
+		SSLMode:  sslMode,
+	}
+}
+
+func writeTestCertificate(t *testing.T, dir, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, name+".crt")
+	keyPath := filepath.Join(dir, name+".key")
+	writeFile(t, certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0644)
+	writeFile(t, keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+
+	return certPath, keyPath
+}
+
+func writeFile(t *testing.T, path string, content []byte, perm os.FileMode) {
+	require.NoError(t, ioutil.WriteFile(path, content, perm))
+	// WriteFile does not change permissions of existing files and is subject to umask
+	require.NoError(t, os.Chmod(path, perm))
+}
+
+func readFile(t *testing.T, path string) []byte {
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return content
+}
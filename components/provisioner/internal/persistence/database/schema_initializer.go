@@ -6,6 +6,7 @@ import (
 	"time"
 
 	dbr "github.com/gocraft/dbr/v2"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
 	"github.com/lib/pq"
 
 	"github.com/pkg/errors"
@@ -104,11 +105,17 @@ func waitForDatabaseAccess(connString string, retryCount int) (*dbr.Connection,
 			return connection, nil
 		}
 
+		pingErr := dberrors.Classify(err, "Failed to access database: %s", err)
+
 		err = connection.Close()
 		if err != nil {
 			log.Info("Failed to close database ...")
 		}
 
+		if !pingErr.Retryable() {
+			return nil, pingErr
+		}
+
 		log.Info("Failed to access database, waiting 5 seconds to retry...")
 		time.Sleep(5 * time.Second)
 	}
@@ -0,0 +1,191 @@
+package database
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	dbr "github.com/gocraft/dbr/v2"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// MinSchemaVersion is the version of the latest migration the Provisioner depends on,
+// it has to be raised together with the migrations used by the code
+const MinSchemaVersion int64 = 202610151420
+
+const schemaMigrationsTable = "schema_migrations"
+
+var (
+	schemaVersionGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "db_schema_version",
+		Help:      "Version of the database schema applied by the schema migrator, 0 means that no migration was applied",
+	})
+	awaitingMigrationGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "db_schema_awaiting_migration",
+		Help:      "Set to 1 if the database schema is older than the version required by the Provisioner",
+	})
+)
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{schemaVersionGauge, awaitingMigrationGauge}
+}
+
+// SchemaVersion is the state of the migrations applied by the schema migrator,
+// Dirty is set if the last migration failed and the schema has to be fixed manually
+type SchemaVersion struct {
+	Version int64
+	Dirty   bool
+}
+
+// ReadSchemaVersion reads the version from the migration table of the schema migrator,
+// version 0 is returned if no migration was applied yet
+func ReadSchemaVersion(connection *dbr.Connection) (SchemaVersion, error) {
+	var version SchemaVersion
+
+	err := connection.QueryRow("SELECT version, dirty FROM " + schemaMigrationsTable + " LIMIT 1").Scan(&version.Version, &version.Dirty)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SchemaVersion{}, nil
+		}
+		psqlErr, converted := err.(*pq.Error)
+		if converted && psqlErr.Code == TableNotExistsError {
+			return SchemaVersion{}, nil
+		}
+		return SchemaVersion{}, errors.Wrap(err, "Failed to read database schema version")
+	}
+
+	return version, nil
+}
+
+// SchemaStatus keeps the last detected schema version to be reported by the health check and metrics
+type SchemaStatus struct {
+	readVersion func() (SchemaVersion, error)
+	minVersion  int64
+
+	mutex   sync.RWMutex
+	version SchemaVersion
+	err     error
+}
+
+func NewSchemaStatus(connection *dbr.Connection, minVersion int64) *SchemaStatus {
+	return newSchemaStatus(func() (SchemaVersion, error) {
+		return ReadSchemaVersion(connection)
+	}, minVersion)
+}
+
+func newSchemaStatus(readVersion func() (SchemaVersion, error), minVersion int64) *SchemaStatus {
+	return &SchemaStatus{
+		readVersion: readVersion,
+		minVersion:  minVersion,
+	}
+}
+
+// Refresh reads the schema version from the database and updates the metrics
+func (s *SchemaStatus) Refresh() (SchemaVersion, error) {
+	version, err := s.readVersion()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.err = err
+	if err != nil {
+		return SchemaVersion{}, err
+	}
+
+	s.version = version
+	schemaVersionGauge.Set(float64(version.Version))
+	if s.awaitingMigration() {
+		awaitingMigrationGauge.Set(1)
+	} else {
+		awaitingMigrationGauge.Set(0)
+	}
+
+	return version, nil
+}
+
+// AwaitingMigration checks if the last detected schema is older than the version required by the Provisioner
+func (s *SchemaStatus) AwaitingMigration() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.awaitingMigration()
+}
+
+func (s *SchemaStatus) awaitingMigration() bool {
+	return s.version.Version < s.minVersion
+}
+
+// HealthDetails reports the last detected schema version in the verbose output of the health check
+func (s *SchemaStatus) HealthDetails() map[string]interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	details := map[string]interface{}{
+		"schemaVersion":     s.version.Version,
+		"minSchemaVersion":  s.minVersion,
+		"dirty":             s.version.Dirty,
+		"awaitingMigration": s.awaitingMigration(),
+	}
+	if s.err != nil {
+		details["error"] = s.err.Error()
+	}
+
+	return map[string]interface{}{"database": details}
+}
+
+// WaitForMigrations waits until the schema migrator applies the migrations required by the Provisioner,
+// an error describing the detected version is returned if the schema is dirty or still too old after all attempts
+func (s *SchemaStatus) WaitForMigrations(attempts int, delay time.Duration) error {
+	var version SchemaVersion
+	var err error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		version, err = s.Refresh()
+		switch {
+		case err != nil:
+			log.Warnf("Failed to check database schema version: %s", err.Error())
+		case version.Dirty:
+			return errors.Errorf("database schema is dirty at version %d, the migration failed and has to be fixed manually", version.Version)
+		case version.Version >= s.minVersion:
+			log.Infof("Database schema version %d satisfies required version %d", version.Version, s.minVersion)
+			return nil
+		default:
+			log.Warnf("Database schema version %d is older than version %d required by the Provisioner, waiting for the schema migrator (attempt %d of %d)",
+				version.Version, s.minVersion, attempt, attempts)
+		}
+
+		if attempt < attempts {
+			time.Sleep(delay)
+		}
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "timeout waiting for database schema version")
+	}
+	return errors.Errorf("database schema version %d is older than version %d required by the Provisioner, check if the schema migrator finished successfully",
+		version.Version, s.minVersion)
+}
+
+// Run refreshes the schema version periodically, so that the metrics reflect migrations applied while the Provisioner is running
+func (s *SchemaStatus) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := s.Refresh(); err != nil {
+				log.Warnf("Failed to refresh database schema version: %s", err.Error())
+			}
+		}
+	}
+}
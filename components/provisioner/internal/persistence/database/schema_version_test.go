@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaStatus_WaitForMigrations(t *testing.T) {
+	versionsReader := func(versions ...SchemaVersion) func() (SchemaVersion, error) {
+		return func() (SchemaVersion, error) {
+			version := versions[0]
+			if len(versions) > 1 {
+				versions = versions[1:]
+			}
+			return version, nil
+		}
+	}
+
+	t.Run("should return when schema satisfies required version", func(t *testing.T) {
+		// given
+		status := newSchemaStatus(versionsReader(SchemaVersion{Version: 10}), 10)
+
+		// when
+		err := status.WaitForMigrations(3, time.Millisecond)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, status.AwaitingMigration())
+	})
+
+	t.Run("should wait until schema is migrated", func(t *testing.T) {
+		// given
+		status := newSchemaStatus(versionsReader(SchemaVersion{}, SchemaVersion{Version: 5}, SchemaVersion{Version: 11}), 10)
+
+		// when
+		err := status.WaitForMigrations(3, time.Millisecond)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, status.AwaitingMigration())
+	})
+
+	t.Run("should return error when schema is too old after all attempts", func(t *testing.T) {
+		// given
+		status := newSchemaStatus(versionsReader(SchemaVersion{Version: 5}), 10)
+
+		// when
+		err := status.WaitForMigrations(3, time.Millisecond)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database schema version 5 is older than version 10 required by the Provisioner")
+		assert.True(t, status.AwaitingMigration())
+	})
+
+	t.Run("should return error right away when schema is dirty", func(t *testing.T) {
+		// given
+		calls := 0
+		status := newSchemaStatus(func() (SchemaVersion, error) {
+			calls++
+			return SchemaVersion{Version: 10, Dirty: true}, nil
+		}, 10)
+
+		// when
+		err := status.WaitForMigrations(3, time.Millisecond)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dirty at version 10")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should return error when failed to read version", func(t *testing.T) {
+		// given
+		status := newSchemaStatus(func() (SchemaVersion, error) {
+			return SchemaVersion{}, errors.New("connection refused")
+		}, 10)
+
+		// when
+		err := status.WaitForMigrations(2, time.Millisecond)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
+
+func TestSchemaStatus_HealthDetails(t *testing.T) {
+	// given
+	status := newSchemaStatus(func() (SchemaVersion, error) {
+		return SchemaVersion{Version: 5}, nil
+	}, 10)
+
+	_, err := status.Refresh()
+	require.NoError(t, err)
+
+	// when
+	details := status.HealthDetails()
+
+	// then
+	assert.Equal(t, map[string]interface{}{
+		"database": map[string]interface{}{
+			"schemaVersion":     int64(5),
+			"minSchemaVersion":  int64(10),
+			"dirty":             false,
+			"awaitingMigration": true,
+		},
+	}, details)
+}
@@ -1,17 +1,42 @@
 package dberrors
 
-import "fmt"
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/lib/pq"
+)
+
+const (
+	CodeInternal            = 1
+	CodeNotFound            = 2
+	CodeAlreadyExists       = 3
+	CodeConflict            = 4
+	CodeOperationInProgress = 5
+	CodeConnection          = 6
+	CodeTransactionRollback = 7
+	CodeUnavailable         = 8
+	CodeConstraintViolation = 9
+	CodeSchemaNotReady      = 10
+)
 
 const (
-	CodeInternal      = 1
-	CodeNotFound      = 2
-	CodeAlreadyExists = 3
+	uniqueViolation   pq.ErrorCode = "23505"
+	undefinedTable    pq.ErrorCode = "42P01"
+	undefinedColumn   pq.ErrorCode = "42703"
+	undefinedFunction pq.ErrorCode = "42883"
 )
 
 type Error interface {
 	Append(string, ...interface{}) Error
 	Code() int
 	Error() string
+	// Retryable reports whether the failed call can succeed when repeated without any change
+	Retryable() bool
 }
 
 type dbError struct {
@@ -35,6 +60,88 @@ func AlreadyExists(format string, a ...interface{}) Error {
 	return errorf(CodeAlreadyExists, format, a...)
 }
 
+func Conflict(format string, a ...interface{}) Error {
+	return errorf(CodeConflict, format, a...)
+}
+
+func OperationInProgress(format string, a ...interface{}) Error {
+	return errorf(CodeOperationInProgress, format, a...)
+}
+
+func Connection(format string, a ...interface{}) Error {
+	return errorf(CodeConnection, format, a...)
+}
+
+func TransactionRollback(format string, a ...interface{}) Error {
+	return errorf(CodeTransactionRollback, format, a...)
+}
+
+func Unavailable(format string, a ...interface{}) Error {
+	return errorf(CodeUnavailable, format, a...)
+}
+
+func ConstraintViolation(format string, a ...interface{}) Error {
+	return errorf(CodeConstraintViolation, format, a...)
+}
+
+func SchemaNotReady(format string, a ...interface{}) Error {
+	return errorf(CodeSchemaNotReady, format, a...)
+}
+
+// Classify creates the error with the code matching the failure returned by the database driver.
+// Failures not recognized as transient are reported as internal errors, which are not retryable.
+func Classify(err error, format string, a ...interface{}) Error {
+	return errorf(classify(err), format, a...)
+}
+
+func classify(err error) int {
+	var dbErr Error
+	if errors.As(err, &dbErr) {
+		return dbErr.Code()
+	}
+
+	var psqlErr *pq.Error
+	if errors.As(err, &psqlErr) {
+		return classifyPostgresError(psqlErr.Code)
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return CodeConnection
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return CodeConnection
+	}
+
+	return CodeInternal
+}
+
+// classifyPostgresError maps the error classes described in https://www.postgresql.org/docs/current/errcodes-appendix.html
+func classifyPostgresError(code pq.ErrorCode) int {
+	switch code {
+	case uniqueViolation:
+		return CodeAlreadyExists
+	// the schema migrator runs next to the Provisioner, objects used by the code appear once it applies the migrations
+	case undefinedTable, undefinedColumn, undefinedFunction:
+		return CodeSchemaNotReady
+	}
+
+	switch code.Class() {
+	case "08": // connection exception
+		return CodeConnection
+	case "40": // transaction rollback, e.g. serialization failure or deadlock
+		return CodeTransactionRollback
+	case "53", "57": // insufficient resources, operator intervention, e.g. server shutting down
+		return CodeUnavailable
+	case "23": // integrity constraint violation
+		return CodeConstraintViolation
+	default:
+		return CodeInternal
+	}
+}
+
 func (e dbError) Append(additionalFormat string, a ...interface{}) Error {
 	format := additionalFormat + ", " + e.message
 	return errorf(e.code, format, a...)
@@ -44,6 +151,15 @@ func (e dbError) Code() int {
 	return e.code
 }
 
+func (e dbError) Retryable() bool {
+	switch e.code {
+	case CodeConnection, CodeTransactionRollback, CodeUnavailable, CodeSchemaNotReady:
+		return true
+	default:
+		return false
+	}
+}
+
 func (e dbError) Error() string {
 	return e.message
 }
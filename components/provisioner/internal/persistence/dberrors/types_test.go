@@ -1,8 +1,13 @@
 package dberrors
 
 import (
+	"database/sql/driver"
+	"io"
+	"net"
 	"testing"
 
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,18 +17,23 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, CodeInternal, Internal("error").Code())
 		assert.Equal(t, CodeNotFound, NotFound("error").Code())
 		assert.Equal(t, CodeAlreadyExists, AlreadyExists("error").Code())
+		assert.Equal(t, CodeConflict, Conflict("error").Code())
+		assert.Equal(t, CodeOperationInProgress, OperationInProgress("error").Code())
 	})
 
 	t.Run("should create error with simple message", func(t *testing.T) {
 		assert.Equal(t, "error", Internal("error").Error())
 		assert.Equal(t, "error", NotFound("error").Error())
 		assert.Equal(t, "error", AlreadyExists("error").Error())
+		assert.Equal(t, "error", Conflict("error").Error())
+		assert.Equal(t, "error", OperationInProgress("error").Error())
 	})
 
 	t.Run("should create error with formatted message", func(t *testing.T) {
 		assert.Equal(t, "code: 1, error: bug", Internal("code: %d, error: %s", 1, "bug").Error())
 		assert.Equal(t, "code: 1, error: bug", NotFound("code: %d, error: %s", 1, "bug").Error())
 		assert.Equal(t, "code: 1, error: bug", AlreadyExists("code: %d, error: %s", 1, "bug").Error())
+		assert.Equal(t, "code: 1, error: bug", Conflict("code: %d, error: %s", 1, "bug").Error())
 	})
 
 	t.Run("should append apperrors without changing error code", func(t *testing.T) {
@@ -60,3 +70,56 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, "Some additional message: error, Some AlreadyExists apperror, Some pkg err", appendedAlreadyExistsErr.Error())
 	})
 }
+
+func TestClassify(t *testing.T) {
+
+	for _, testCase := range []struct {
+		description       string
+		err               error
+		expectedCode      int
+		expectedRetryable bool
+	}{
+		{description: "connection failure", err: &pq.Error{Code: "08006"}, expectedCode: CodeConnection, expectedRetryable: true},
+		{description: "connection does not exist", err: &pq.Error{Code: "08003"}, expectedCode: CodeConnection, expectedRetryable: true},
+		{description: "serialization failure", err: &pq.Error{Code: "40001"}, expectedCode: CodeTransactionRollback, expectedRetryable: true},
+		{description: "deadlock detected", err: &pq.Error{Code: "40P01"}, expectedCode: CodeTransactionRollback, expectedRetryable: true},
+		{description: "too many connections", err: &pq.Error{Code: "53300"}, expectedCode: CodeUnavailable, expectedRetryable: true},
+		{description: "admin shutdown", err: &pq.Error{Code: "57P01"}, expectedCode: CodeUnavailable, expectedRetryable: true},
+		{description: "database starting up", err: &pq.Error{Code: "57P03"}, expectedCode: CodeUnavailable, expectedRetryable: true},
+		{description: "undefined table", err: &pq.Error{Code: "42P01"}, expectedCode: CodeSchemaNotReady, expectedRetryable: true},
+		{description: "undefined column", err: &pq.Error{Code: "42703"}, expectedCode: CodeSchemaNotReady, expectedRetryable: true},
+		{description: "unique violation", err: &pq.Error{Code: "23505"}, expectedCode: CodeAlreadyExists, expectedRetryable: false},
+		{description: "foreign key violation", err: &pq.Error{Code: "23503"}, expectedCode: CodeConstraintViolation, expectedRetryable: false},
+		{description: "not null violation", err: &pq.Error{Code: "23502"}, expectedCode: CodeConstraintViolation, expectedRetryable: false},
+		{description: "syntax error", err: &pq.Error{Code: "42601"}, expectedCode: CodeInternal, expectedRetryable: false},
+		{description: "invalid password", err: &pq.Error{Code: "28P01"}, expectedCode: CodeInternal, expectedRetryable: false},
+		{description: "wrapped postgres error", err: errors.Wrap(&pq.Error{Code: "40001"}, "query failed"), expectedCode: CodeTransactionRollback, expectedRetryable: true},
+		{description: "bad connection", err: driver.ErrBadConn, expectedCode: CodeConnection, expectedRetryable: true},
+		{description: "connection closed", err: io.ErrUnexpectedEOF, expectedCode: CodeConnection, expectedRetryable: true},
+		{description: "network error", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expectedCode: CodeConnection, expectedRetryable: true},
+		{description: "already classified error", err: NotFound("not found"), expectedCode: CodeNotFound, expectedRetryable: false},
+		{description: "unknown error", err: errors.New("some error"), expectedCode: CodeInternal, expectedRetryable: false},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//when
+			err := Classify(testCase.err, "Failed to query database: %s", testCase.err)
+
+			//then
+			assert.Equal(t, testCase.expectedCode, err.Code())
+			assert.Equal(t, testCase.expectedRetryable, err.Retryable())
+			assert.Equal(t, "Failed to query database: "+testCase.err.Error(), err.Error())
+		})
+	}
+
+	t.Run("should keep retryability when appending message", func(t *testing.T) {
+		//given
+		err := Classify(&pq.Error{Code: "40001"}, "Failed to update operation")
+
+		//when
+		appendedErr := err.Append("Some additional message")
+
+		//then
+		assert.Equal(t, CodeTransactionRollback, appendedErr.Code())
+		assert.True(t, appendedErr.Retryable())
+	})
+}
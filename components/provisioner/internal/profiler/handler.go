@@ -0,0 +1,38 @@
+package profiler
+
+import (
+	"net/http/pprof"
+	"runtime"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const pathPrefix = "/debug/pprof/"
+
+type Config struct {
+	// MutexProfileFraction reports on average 1/n of mutex contention events, 0 disables the mutex profile
+	MutexProfileFraction int `envconfig:"default=5"`
+	// BlockProfileRate samples on average one blocking event per n nanoseconds spent blocked, 0 disables the block profile
+	BlockProfileRate int `envconfig:"default=10000"`
+}
+
+// Register exposes pprof handlers on the router when enabled.
+// It must be used only with the internal metrics router as profiles reveal details of the running process.
+func Register(router *mux.Router, enabled bool, config Config, log logrus.FieldLogger) {
+	if !enabled {
+		return
+	}
+
+	log.Warnf("Profiler is enabled, pprof endpoints are exposed under %s. Do not keep it enabled in production", pathPrefix)
+
+	runtime.SetMutexProfileFraction(config.MutexProfileFraction)
+	runtime.SetBlockProfileRate(config.BlockProfileRate)
+
+	router.HandleFunc(pathPrefix+"cmdline", pprof.Cmdline)
+	router.HandleFunc(pathPrefix+"profile", pprof.Profile)
+	router.HandleFunc(pathPrefix+"symbol", pprof.Symbol)
+	router.HandleFunc(pathPrefix+"trace", pprof.Trace)
+	// Index serves named profiles such as goroutine, heap, mutex and block
+	router.PathPrefix(pathPrefix).HandlerFunc(pprof.Index)
+}
@@ -0,0 +1,56 @@
+package profiler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	config := Config{MutexProfileFraction: 5, BlockProfileRate: 10000}
+
+	defer func() {
+		runtime.SetMutexProfileFraction(0)
+		runtime.SetBlockProfileRate(0)
+	}()
+
+	t.Run("should expose goroutine profile when enabled", func(t *testing.T) {
+		// given
+		router := mux.NewRouter()
+		Register(router, true, config, logrus.New())
+
+		req, err := http.NewRequest("GET", "/debug/pprof/goroutine?debug=1", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "goroutine profile")
+		assert.Equal(t, config.MutexProfileFraction, runtime.SetMutexProfileFraction(-1))
+	})
+
+	t.Run("should not expose profiles when disabled", func(t *testing.T) {
+		// given
+		router := mux.NewRouter()
+		Register(router, false, config, logrus.New())
+
+		req, err := http.NewRequest("GET", "/debug/pprof/goroutine", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
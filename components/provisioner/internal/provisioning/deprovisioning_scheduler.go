@@ -0,0 +1,215 @@
+package provisioning
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// scheduledDeprovisioningRetryDelay is the delay after which the scheduled deprovisioning, which could not be started, is checked again
+const scheduledDeprovisioningRetryDelay = time.Minute
+
+// DeprovisioningScheduler starts the deprovisioning of Runtimes at the time requested in advance, e.g. at the end of the contract.
+// The schedule is stored with the cluster, the Runtimes are kept in the delaying queue until the time comes
+// and the queue is filled again from the database after the restart.
+type DeprovisioningScheduler struct {
+	throttle            *ProvisioningThrottle
+	dbSessionFactory    dbsession.Factory
+	provisioner         Provisioner
+	deprovisioningQueue queue.OperationQueue
+	queue               queue.OperationQueue
+	uuidGenerator       uuid.UUIDGenerator
+	now                 func() time.Time
+
+	log logrus.FieldLogger
+}
+
+func NewDeprovisioningScheduler(throttle *ProvisioningThrottle, factory dbsession.Factory, provisioner Provisioner, deprovisioningQueue queue.OperationQueue, uuidGenerator uuid.UUIDGenerator) *DeprovisioningScheduler {
+	scheduler := &DeprovisioningScheduler{
+		throttle:            throttle,
+		dbSessionFactory:    factory,
+		provisioner:         provisioner,
+		deprovisioningQueue: deprovisioningQueue,
+		uuidGenerator:       uuidGenerator,
+		now:                 time.Now,
+		log:                 logrus.WithField("component", "deprovisioning-scheduler"),
+	}
+	scheduler.queue = queue.NewQueue(scheduler)
+
+	return scheduler
+}
+
+// Run processes the scheduled deprovisioning and loads the schedules from the database immediately and then in the given interval
+// until stopped, so that the schedules created before the restart or by other replicas are not missed
+func (s *DeprovisioningScheduler) Run(resyncInterval time.Duration, stop <-chan struct{}) {
+	s.queue.Run(stop)
+	wait.Until(s.Resync, resyncInterval, stop)
+}
+
+func (s *DeprovisioningScheduler) Resync() {
+	schedules, err := s.dbSessionFactory.NewReadSession().ListDeprovisioningSchedules()
+	if err != nil {
+		s.log.Errorf("Failed to list deprovisioning schedules: %s", err.Error())
+		return
+	}
+
+	for _, schedule := range schedules {
+		s.queue.AddAfter(schedule.RuntimeID, schedule.ScheduledAt.Sub(s.now()))
+	}
+}
+
+// Schedule sets the time at which the deprovisioning of the Runtime starts, the previous schedule is replaced
+func (s *DeprovisioningScheduler) Schedule(runtimeID string, at time.Time) apperrors.AppError {
+	now := s.now()
+	if !at.After(now) {
+		return apperrors.BadRequest("error: deprovisioning of Runtime %s cannot be scheduled in the past, requested time: %s", runtimeID, at.Format(time.RFC3339))
+	}
+
+	scheduledAt := at.UTC()
+	dberr := s.dbSessionFactory.NewWriteSession().UpdateClusterDeprovisioningSchedule(runtimeID, &scheduledAt)
+	if dberr != nil {
+		if dberr.Code() == dberrors.CodeNotFound {
+			return apperrors.BadRequest("error: Runtime %s does not exist or is deleted", runtimeID)
+		}
+		return apperrors.Internal("Failed to schedule deprovisioning of Runtime %s: %s", runtimeID, dberr.Error())
+	}
+
+	s.log.Infof("Deprovisioning of Runtime %s scheduled at %s", runtimeID, scheduledAt.Format(time.RFC3339))
+	s.queue.AddAfter(runtimeID, scheduledAt.Sub(now))
+
+	return nil
+}
+
+// Cancel removes the schedule of the Runtime, it fails if the deprovisioning is not scheduled or already started
+func (s *DeprovisioningScheduler) Cancel(runtimeID string) apperrors.AppError {
+	scheduledAt, err := s.ScheduledAt(runtimeID)
+	if err != nil {
+		return err
+	}
+	if scheduledAt == nil {
+		return apperrors.BadRequest("error: deprovisioning of Runtime %s is not scheduled", runtimeID)
+	}
+
+	cleared, dberr := s.dbSessionFactory.NewWriteSession().ClearClusterDeprovisioningSchedule(runtimeID, *scheduledAt)
+	if dberr != nil {
+		return apperrors.Internal("Failed to cancel scheduled deprovisioning of Runtime %s: %s", runtimeID, dberr.Error())
+	}
+	if !cleared {
+		return apperrors.BadRequest("error: scheduled deprovisioning of Runtime %s already started or was modified, check the Runtime status", runtimeID)
+	}
+
+	s.log.Infof("Scheduled deprovisioning of Runtime %s cancelled", runtimeID)
+	return nil
+}
+
+// ScheduledAt returns the time at which the deprovisioning of the Runtime starts, nil if it is not scheduled
+func (s *DeprovisioningScheduler) ScheduledAt(runtimeID string) (*time.Time, apperrors.AppError) {
+	cluster, dberr := s.dbSessionFactory.NewReadSession().GetCluster(runtimeID)
+	if dberr != nil {
+		if dberr.Code() == dberrors.CodeNotFound {
+			return nil, apperrors.BadRequest("error: Runtime %s does not exist", runtimeID)
+		}
+		return nil, apperrors.Internal("Failed to get Runtime %s: %s", runtimeID, dberr.Error())
+	}
+	if cluster.Deleted {
+		return nil, nil
+	}
+
+	return cluster.DeprovisioningScheduledAt, nil
+}
+
+// Execute starts the deprovisioning of the Runtime if its scheduled time passed. The schedule is cleared before the deprovisioning starts,
+// which makes the cancellation fail from that moment and guarantees that only one replica starts the deprovisioning.
+func (s *DeprovisioningScheduler) Execute(runtimeID string) operations.ProcessingResult {
+	session := s.dbSessionFactory.NewReadWriteSession()
+
+	cluster, dberr := session.GetCluster(runtimeID)
+	if dberr != nil {
+		if dberr.Code() == dberrors.CodeNotFound {
+			return operations.ProcessingResult{}
+		}
+		s.log.Errorf("Failed to get Runtime %s scheduled for deprovisioning: %s", runtimeID, dberr.Error())
+		return s.retry()
+	}
+	if cluster.Deleted || cluster.DeprovisioningScheduledAt == nil {
+		return operations.ProcessingResult{}
+	}
+
+	scheduledAt := *cluster.DeprovisioningScheduledAt
+	if remaining := scheduledAt.Sub(s.now()); remaining > 0 {
+		return operations.ProcessingResult{Requeue: true, Delay: remaining}
+	}
+
+	limitReached, limit, dberr := s.throttle.DeprovisioningLimitReached(cluster.Tenant)
+	if dberr != nil {
+		s.log.Errorf("Failed to check deprovisioning limit for Runtime %s scheduled for deprovisioning: %s", runtimeID, dberr.Error())
+		return s.retry()
+	}
+	if limitReached {
+		s.log.Infof("Scheduled deprovisioning of Runtime %s postponed, global account %s reached the limit of %d operations", runtimeID, cluster.Tenant, limit)
+		return s.retry()
+	}
+
+	// The operation in progress is finished first, the schedule is kept, so it can still be cancelled
+	lastOperation, dberr := session.GetLastOperation(runtimeID)
+	if dberr != nil {
+		s.log.Errorf("Failed to get last operation of Runtime %s scheduled for deprovisioning: %s", runtimeID, dberr.Error())
+		return s.retry()
+	}
+	if lastOperation.State == model.InProgress || lastOperation.State == model.Pending {
+		s.log.Infof("Scheduled deprovisioning of Runtime %s postponed, %s operation is in progress", runtimeID, lastOperation.Type)
+		return s.retry()
+	}
+
+	cleared, dberr := session.ClearClusterDeprovisioningSchedule(runtimeID, scheduledAt)
+	if dberr != nil {
+		s.log.Errorf("Failed to clear deprovisioning schedule of Runtime %s: %s", runtimeID, dberr.Error())
+		return s.retry()
+	}
+	if !cleared {
+		s.log.Infof("Deprovisioning schedule of Runtime %s was cancelled or modified, skipping", runtimeID)
+		return operations.ProcessingResult{}
+	}
+
+	s.log.Infof("Runtime %s scheduled for deprovisioning at %s, starting deprovisioning", runtimeID, scheduledAt.Format(time.RFC3339))
+
+	operation, err := s.provisioner.DeprovisionCluster(cluster, s.uuidGenerator.New(), false)
+	if err != nil {
+		s.log.Errorf("Failed to start scheduled deprovisioning of Runtime %s: %s", runtimeID, err.Error())
+		return s.restoreSchedule(session, runtimeID, scheduledAt)
+	}
+
+	trigger := model.OperationTriggerScheduled
+	operation.TriggeredBy = &trigger
+
+	dberr = session.InsertOperation(operation)
+	if dberr != nil {
+		s.log.Errorf("Failed to insert scheduled deprovisioning operation of Runtime %s: %s", runtimeID, dberr.Error())
+		return s.restoreSchedule(session, runtimeID, scheduledAt)
+	}
+
+	s.deprovisioningQueue.Add(operation.ID)
+	return operations.ProcessingResult{}
+}
+
+// restoreSchedule brings back the schedule cleared before the deprovisioning failed to start, so that it is retried and survives the restart
+func (s *DeprovisioningScheduler) restoreSchedule(session dbsession.WriteSession, runtimeID string, scheduledAt time.Time) operations.ProcessingResult {
+	dberr := session.UpdateClusterDeprovisioningSchedule(runtimeID, &scheduledAt)
+	if dberr != nil {
+		s.log.Errorf("Failed to restore deprovisioning schedule of Runtime %s: %s", runtimeID, dberr.Error())
+	}
+
+	return s.retry()
+}
+
+func (s *DeprovisioningScheduler) retry() operations.ProcessingResult {
+	return operations.ProcessingResult{Requeue: true, Delay: scheduledDeprovisioningRetryDelay}
+}
@@ -0,0 +1,315 @@
+package provisioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	mocks2 "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/mocks"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	uuidMocks "github.com/kyma-project/control-plane/components/provisioner/internal/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprovisioningScheduler_Schedule(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	newScheduler := func(sessionFactory *sessionMocks.Factory, schedulerQueue *mocks.OperationQueue) *DeprovisioningScheduler {
+		scheduler := NewDeprovisioningScheduler(NewProvisioningThrottle(ProvisioningLimits{}, sessionFactory), sessionFactory, &mocks2.Provisioner{}, &mocks.OperationQueue{}, &uuidMocks.UUIDGenerator{})
+		scheduler.queue = schedulerQueue
+		scheduler.now = func() time.Time { return now }
+		return scheduler
+	}
+
+	t.Run("should store schedule and enqueue Runtime until scheduled time", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		writeSession := &sessionMocks.WriteSession{}
+		schedulerQueue := &mocks.OperationQueue{}
+		scheduledAt := now.Add(48 * time.Hour)
+
+		sessionFactory.On("NewWriteSession").Return(writeSession)
+		writeSession.On("UpdateClusterDeprovisioningSchedule", runtimeID, &scheduledAt).Return(nil)
+		schedulerQueue.On("AddAfter", runtimeID, 48*time.Hour).Return()
+
+		// when
+		err := newScheduler(sessionFactory, schedulerQueue).Schedule(runtimeID, scheduledAt)
+
+		// then
+		require.NoError(t, err)
+		writeSession.AssertExpectations(t)
+		schedulerQueue.AssertExpectations(t)
+	})
+
+	t.Run("should reject schedule in the past", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		schedulerQueue := &mocks.OperationQueue{}
+
+		// when
+		err := newScheduler(sessionFactory, schedulerQueue).Schedule(runtimeID, now.Add(-time.Minute))
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+		sessionFactory.AssertNotCalled(t, "NewWriteSession")
+		schedulerQueue.AssertNotCalled(t, "AddAfter", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return bad request when Runtime does not exist", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		writeSession := &sessionMocks.WriteSession{}
+		schedulerQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewWriteSession").Return(writeSession)
+		writeSession.On("UpdateClusterDeprovisioningSchedule", runtimeID, mock.Anything).Return(dberrors.NotFound("error"))
+
+		// when
+		err := newScheduler(sessionFactory, schedulerQueue).Schedule(runtimeID, now.Add(time.Hour))
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+		schedulerQueue.AssertNotCalled(t, "AddAfter", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeprovisioningScheduler_Cancel(t *testing.T) {
+	scheduledAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		description string
+		cluster     model.Cluster
+		cleared     bool
+		expectError bool
+	}{
+		{
+			description: "should cancel scheduled deprovisioning",
+			cluster:     model.Cluster{ID: runtimeID, DeprovisioningScheduledAt: &scheduledAt},
+			cleared:     true,
+		},
+		{
+			description: "should fail when deprovisioning already started",
+			cluster:     model.Cluster{ID: runtimeID, DeprovisioningScheduledAt: &scheduledAt},
+			cleared:     false,
+			expectError: true,
+		},
+		{
+			description: "should fail when deprovisioning is not scheduled",
+			cluster:     model.Cluster{ID: runtimeID},
+			expectError: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			sessionFactory := &sessionMocks.Factory{}
+			readSession := &sessionMocks.ReadSession{}
+			writeSession := &sessionMocks.WriteSession{}
+
+			sessionFactory.On("NewReadSession").Return(readSession)
+			sessionFactory.On("NewWriteSession").Return(writeSession)
+			readSession.On("GetCluster", runtimeID).Return(testCase.cluster, nil)
+			writeSession.On("ClearClusterDeprovisioningSchedule", runtimeID, scheduledAt).Return(testCase.cleared, nil)
+
+			scheduler := NewDeprovisioningScheduler(NewProvisioningThrottle(ProvisioningLimits{}, sessionFactory), sessionFactory, &mocks2.Provisioner{}, &mocks.OperationQueue{}, &uuidMocks.UUIDGenerator{})
+
+			// when
+			err := scheduler.Cancel(runtimeID)
+
+			// then
+			if testCase.expectError {
+				require.Error(t, err)
+				assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeprovisioningScheduler_Execute(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	scheduledAt := now.Add(-time.Minute)
+	cluster := model.Cluster{
+		ID:                        runtimeID,
+		Tenant:                    tenant,
+		DeprovisioningScheduledAt: &scheduledAt,
+	}
+	finishedOperation := model.Operation{
+		ID:        "provisioning-operation",
+		Type:      model.Provision,
+		State:     model.Succeeded,
+		ClusterID: runtimeID,
+	}
+	deprovisioningOperation := model.Operation{
+		ID:        operationID,
+		Type:      model.Deprovision,
+		State:     model.InProgress,
+		ClusterID: runtimeID,
+	}
+
+	newScheduler := func(sessionFactory *sessionMocks.Factory, provisioner *mocks2.Provisioner, deprovisioningQueue *mocks.OperationQueue) *DeprovisioningScheduler {
+		uuidGenerator := &uuidMocks.UUIDGenerator{}
+		uuidGenerator.On("New").Return(operationID)
+
+		scheduler := NewDeprovisioningScheduler(NewProvisioningThrottle(ProvisioningLimits{}, sessionFactory), sessionFactory, provisioner, deprovisioningQueue, uuidGenerator)
+		scheduler.now = func() time.Time { return now }
+		return scheduler
+	}
+
+	t.Run("should start deprovisioning with scheduled trigger", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(finishedOperation, nil)
+		readWriteSession.On("ClearClusterDeprovisioningSchedule", runtimeID, scheduledAt).Return(true, nil)
+		provisioner.On("DeprovisionCluster", cluster, operationID, false).Return(deprovisioningOperation, nil)
+		readWriteSession.On("InsertOperation", mock.MatchedBy(func(operation model.Operation) bool {
+			return operation.ID == operationID && operation.TriggeredBy != nil && *operation.TriggeredBy == model.OperationTriggerScheduled
+		})).Return(nil)
+		deprovisioningQueue.On("Add", operationID).Return()
+
+		// when
+		result := newScheduler(sessionFactory, provisioner, deprovisioningQueue).Execute(runtimeID)
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{}, result)
+		readWriteSession.AssertExpectations(t)
+		provisioner.AssertExpectations(t)
+		deprovisioningQueue.AssertExpectations(t)
+	})
+
+	t.Run("should requeue Runtime until scheduled time", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		futureSchedule := now.Add(time.Hour)
+
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readWriteSession.On("GetCluster", runtimeID).Return(model.Cluster{ID: runtimeID, DeprovisioningScheduledAt: &futureSchedule}, nil)
+
+		// when
+		result := newScheduler(sessionFactory, provisioner, &mocks.OperationQueue{}).Execute(runtimeID)
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{Requeue: true, Delay: time.Hour}, result)
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should skip Runtime when schedule was cancelled", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readWriteSession.On("GetCluster", runtimeID).Return(model.Cluster{ID: runtimeID}, nil)
+
+		// when
+		result := newScheduler(sessionFactory, provisioner, &mocks.OperationQueue{}).Execute(runtimeID)
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{}, result)
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should skip Runtime when schedule was cleared by another replica", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(finishedOperation, nil)
+		readWriteSession.On("ClearClusterDeprovisioningSchedule", runtimeID, scheduledAt).Return(false, nil)
+
+		// when
+		result := newScheduler(sessionFactory, provisioner, &mocks.OperationQueue{}).Execute(runtimeID)
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{}, result)
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should postpone deprovisioning when operation is in progress", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(model.Operation{Type: model.UpgradeShoot, State: model.InProgress}, nil)
+
+		// when
+		result := newScheduler(sessionFactory, provisioner, &mocks.OperationQueue{}).Execute(runtimeID)
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{Requeue: true, Delay: scheduledDeprovisioningRetryDelay}, result)
+		readWriteSession.AssertNotCalled(t, "ClearClusterDeprovisioningSchedule", mock.Anything, mock.Anything)
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should restore schedule when deprovisioning cannot be started", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(finishedOperation, nil)
+		readWriteSession.On("ClearClusterDeprovisioningSchedule", runtimeID, scheduledAt).Return(true, nil)
+		provisioner.On("DeprovisionCluster", cluster, operationID, false).Return(model.Operation{}, apperrors.Internal("error"))
+		readWriteSession.On("UpdateClusterDeprovisioningSchedule", runtimeID, &scheduledAt).Return(nil)
+
+		// when
+		result := newScheduler(sessionFactory, provisioner, deprovisioningQueue).Execute(runtimeID)
+
+		// then
+		assert.Equal(t, operations.ProcessingResult{Requeue: true, Delay: scheduledDeprovisioningRetryDelay}, result)
+		readWriteSession.AssertExpectations(t)
+		deprovisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
+
+func TestDeprovisioningScheduler_Resync(t *testing.T) {
+	// given
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	sessionFactory := &sessionMocks.Factory{}
+	readSession := &sessionMocks.ReadSession{}
+	schedulerQueue := &mocks.OperationQueue{}
+
+	sessionFactory.On("NewReadSession").Return(readSession)
+	readSession.On("ListDeprovisioningSchedules").Return([]model.DeprovisioningSchedule{
+		{RuntimeID: "runtime-1", ScheduledAt: now.Add(-time.Hour)},
+		{RuntimeID: "runtime-2", ScheduledAt: now.Add(time.Hour)},
+	}, nil)
+	schedulerQueue.On("AddAfter", "runtime-1", -time.Hour).Return()
+	schedulerQueue.On("AddAfter", "runtime-2", time.Hour).Return()
+
+	scheduler := NewDeprovisioningScheduler(NewProvisioningThrottle(ProvisioningLimits{}, sessionFactory), sessionFactory, &mocks2.Provisioner{}, &mocks.OperationQueue{}, &uuidMocks.UUIDGenerator{})
+	scheduler.queue = schedulerQueue
+	scheduler.now = func() time.Time { return now }
+
+	// when
+	scheduler.Resync()
+
+	// then
+	schedulerQueue.AssertExpectations(t)
+}
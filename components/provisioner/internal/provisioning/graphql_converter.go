@@ -1,13 +1,26 @@
 package provisioning
 
 import (
+	"github.com/kyma-project/control-plane/components/provisioner/internal/freeze"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type GraphQLConverter interface {
 	RuntimeStatusToGraphQLStatus(status model.RuntimeStatus) *gqlschema.RuntimeStatus
 	OperationStatusToGQLOperationStatus(operation model.Operation) *gqlschema.OperationStatus
+	OperationToGQLOperationHistoryEntry(operation model.Operation) *gqlschema.OperationHistoryEntry
+	QueueStatusToGraphQLStatus(status model.QueueStatus) *gqlschema.QueueStatus
+	MaintenanceFreezeToGraphQLStatus(active bool, windows []freeze.ActiveWindow) *gqlschema.MaintenanceFreezeStatus
+	AuditEntryToGraphQLAuditEntry(entry model.AuditEntry) *gqlschema.AuditEntry
+	OrphanedShootToGraphQLOrphanedShoot(shoot model.OrphanedShoot) *gqlschema.OrphanedShoot
+	OperationProgressToGQLOperationProgress(progress *model.OperationProgress) *gqlschema.OperationProgress
+	OperationAnnotationsToGQLOperationAnnotations(annotations []model.OperationAnnotation) []*gqlschema.OperationAnnotation
+	ShootSpecChangesToGQLOperationStatus(runtimeID string, changes []model.ShootSpecChange) *gqlschema.OperationStatus
+	ProvisioningDryRunReportToGQLOperationStatus(report model.ProvisioningDryRunReport) *gqlschema.OperationStatus
+	ProviderDefaultsToGraphQLProviderDefaults(provider gqlschema.Provider, defaults model.ProviderDefaults) *gqlschema.ProviderDefaults
 }
 
 func NewGraphQLConverter() GraphQLConverter {
@@ -18,14 +31,76 @@ type graphQLConverter struct{}
 
 func (c graphQLConverter) RuntimeStatusToGraphQLStatus(status model.RuntimeStatus) *gqlschema.RuntimeStatus {
 	return &gqlschema.RuntimeStatus{
-		LastOperationStatus:     c.OperationStatusToGQLOperationStatus(status.LastOperationStatus),
-		RuntimeConnectionStatus: c.runtimeConnectionStatusToGraphQLStatus(status.RuntimeConnectionStatus),
-		RuntimeConfiguration:    c.clusterToToGraphQLRuntimeConfiguration(status.RuntimeConfiguration),
-		HibernationStatus: &gqlschema.HibernationStatus{
-			HibernationPossible: &status.HibernationStatus.HibernationPossible,
-			Hibernated:          &status.HibernationStatus.Hibernated,
-		},
+		LastOperationStatus:       c.OperationStatusToGQLOperationStatus(status.LastOperationStatus),
+		RuntimeConnectionStatus:   c.runtimeConnectionStatusToGraphQLStatus(status.RuntimeConnectionStatus),
+		RuntimeConfiguration:      c.clusterToToGraphQLRuntimeConfiguration(status.RuntimeConfiguration),
+		HibernationStatus:         c.hibernationStatusToGraphQLStatus(status.HibernationStatus),
+		ShootStatus:               c.shootStatusToGraphQLStatus(status.ShootStatus),
+		ExpireAt:                  status.RuntimeConfiguration.ExpireAt,
+		DeprovisioningScheduledAt: status.RuntimeConfiguration.DeprovisioningScheduledAt,
+		SubAccountID:              status.RuntimeConfiguration.SubAccountId,
+	}
+}
+
+func (c graphQLConverter) shootStatusToGraphQLStatus(status *model.ShootStatus) *gqlschema.ShootStatus {
+	if status == nil {
+		return nil
+	}
+
+	conditions := make([]*gqlschema.ShootCondition, 0, len(status.Conditions))
+	for _, condition := range status.Conditions {
+		conditions = append(conditions, &gqlschema.ShootCondition{
+			Type:               condition.Type,
+			Status:             condition.Status,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime,
+		})
+	}
+
+	lastErrors := make([]*gqlschema.ShootError, 0, len(status.LastErrors))
+	for _, lastError := range status.LastErrors {
+		lastErrors = append(lastErrors, &gqlschema.ShootError{
+			Description: lastError.Description,
+			Codes:       lastError.Codes,
+		})
+	}
+
+	return &gqlschema.ShootStatus{
+		State:      gqlschema.ShootState(status.State),
+		Conditions: conditions,
+		LastErrors: lastErrors,
+		Domain:     status.Domain,
+	}
+}
+
+func (c graphQLConverter) hibernationStatusToGraphQLStatus(status model.HibernationStatus) *gqlschema.HibernationStatus {
+	return &gqlschema.HibernationStatus{
+		HibernationPossible: &status.HibernationPossible,
+		Hibernated:          &status.Hibernated,
+		HibernatedSince:     status.HibernatedSince,
+		LastWokenAt:         status.LastWokenAt,
+		Trigger:             c.hibernationTriggerToGraphQLTrigger(status.Trigger),
+	}
+}
+
+func (c graphQLConverter) hibernationTriggerToGraphQLTrigger(trigger *model.HibernationTrigger) *gqlschema.HibernationTrigger {
+	if trigger == nil {
+		return nil
+	}
+
+	var result gqlschema.HibernationTrigger
+
+	switch *trigger {
+	case model.HibernationTriggerManual:
+		result = gqlschema.HibernationTriggerManual
+	case model.HibernationTriggerScheduled:
+		result = gqlschema.HibernationTriggerScheduled
+	default:
+		return nil
 	}
+
+	return &result
 }
 
 func (c graphQLConverter) OperationStatusToGQLOperationStatus(operation model.Operation) *gqlschema.OperationStatus {
@@ -35,7 +110,152 @@ func (c graphQLConverter) OperationStatusToGQLOperationStatus(operation model.Op
 		State:     c.operationStateToGraphQLState(operation.State),
 		Message:   &operation.Message,
 		RuntimeID: &operation.ClusterID,
+
+		InstallationTimeout: operation.InstallationTimeoutMinutes,
+		Diagnostics:         operation.Diagnostics,
+		FailureReason:       operation.FailureReason,
+	}
+}
+
+func (c graphQLConverter) OperationToGQLOperationHistoryEntry(operation model.Operation) *gqlschema.OperationHistoryEntry {
+	var errorSummary *string
+	if operation.State == model.Failed {
+		errorSummary = &operation.Message
+	}
+
+	return &gqlschema.OperationHistoryEntry{
+		ID:             operation.ID,
+		Operation:      c.operationTypeToGraphQLType(operation.Type),
+		State:          c.operationStateToGraphQLState(operation.State),
+		StartTimestamp: operation.StartTimestamp,
+		EndTimestamp:   operation.EndTimestamp,
+		Stage:          string(operation.Stage),
+		ErrorSummary:   errorSummary,
+	}
+}
+
+func (c graphQLConverter) ShootSpecChangesToGQLOperationStatus(runtimeID string, changes []model.ShootSpecChange) *gqlschema.OperationStatus {
+	shootSpecChanges := make([]*gqlschema.ShootSpecChange, 0, len(changes))
+	for _, change := range changes {
+		shootSpecChanges = append(shootSpecChanges, &gqlschema.ShootSpecChange{
+			Path:     change.Path,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	message := "Dry run: no operation created"
+
+	return &gqlschema.OperationStatus{
+		Operation:     gqlschema.OperationTypeUpgradeShoot,
+		State:         gqlschema.OperationStatePending,
+		Message:       &message,
+		RuntimeID:     &runtimeID,
+		ShootSpecDiff: shootSpecChanges,
+	}
+}
+
+func (c graphQLConverter) ProvisioningDryRunReportToGQLOperationStatus(report model.ProvisioningDryRunReport) *gqlschema.OperationStatus {
+	var kymaConfig *gqlschema.KymaConfig
+	if report.KymaConfig != nil {
+		kymaConfig = c.kymaConfigToGraphQLConfig(report.KymaConfig)
+	}
+
+	message := "Dry run: no operation created"
+
+	return &gqlschema.OperationStatus{
+		Operation: gqlschema.OperationTypeProvision,
+		State:     gqlschema.OperationStatePending,
+		Message:   &message,
+		DryRunReport: &gqlschema.ProvisioningDryRunReport{
+			Valid:             report.Valid(),
+			Errors:            append([]string{}, report.Errors...),
+			Warnings:          append([]string{}, report.Warnings...),
+			KymaVersion:       report.KymaVersion,
+			KubernetesVersion: report.KubernetesVersion,
+			ShootSpec:         report.ShootSpec,
+			KymaConfig:        kymaConfig,
+		},
+	}
+}
+
+func (c graphQLConverter) QueueStatusToGraphQLStatus(status model.QueueStatus) *gqlschema.QueueStatus {
+	return &gqlschema.QueueStatus{
+		Queue:  c.operationTypeToGraphQLQueueType(status.OperationType),
+		Paused: status.Paused,
+		Depth:  status.Depth,
+	}
+}
+
+func (c graphQLConverter) MaintenanceFreezeToGraphQLStatus(active bool, windows []freeze.ActiveWindow) *gqlschema.MaintenanceFreezeStatus {
+	status := &gqlschema.MaintenanceFreezeStatus{
+		Active:  active,
+		Windows: make([]*gqlschema.MaintenanceFreezeWindow, 0, len(windows)),
+	}
+	for _, window := range windows {
+		status.Windows = append(status.Windows, &gqlschema.MaintenanceFreezeWindow{
+			Name:      window.Name,
+			Start:     window.Start,
+			End:       window.End,
+			Providers: append([]string{}, window.Providers...),
+			Regions:   append([]string{}, window.Regions...),
+			Tenants:   append([]string{}, window.Tenants...),
+		})
+	}
+
+	return status
+}
+
+func (c graphQLConverter) OrphanedShootToGraphQLOrphanedShoot(shoot model.OrphanedShoot) *gqlschema.OrphanedShoot {
+	labels := gqlschema.Labels{}
+	for key, value := range shoot.Labels {
+		labels[key] = value
+	}
+
+	return &gqlschema.OrphanedShoot{
+		Name:              shoot.Name,
+		CreationTimestamp: shoot.CreationTimestamp,
+		Labels:            &labels,
+		CostAllocation:    costAllocationToGraphQL(shoot.CostAllocation),
+	}
+}
+
+func (c graphQLConverter) AuditEntryToGraphQLAuditEntry(entry model.AuditEntry) *gqlschema.AuditEntry {
+	return &gqlschema.AuditEntry{
+		ID:           entry.ID,
+		Tenant:       entry.Tenant,
+		SubAccountID: entry.SubAccountID,
+		Mutation:     entry.Mutation,
+		Input:        entry.Input,
+		OperationID:  entry.OperationID,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
+
+func (c graphQLConverter) OperationProgressToGQLOperationProgress(progress *model.OperationProgress) *gqlschema.OperationProgress {
+	if progress == nil {
+		return nil
+	}
+
+	return &gqlschema.OperationProgress{
+		CurrentStage:        string(progress.CurrentStage),
+		StagesDone:          progress.StagesDone,
+		StagesTotal:         progress.StagesTotal,
+		EstimatedCompletion: progress.EstimatedCompletion,
+	}
+}
+
+func (c graphQLConverter) OperationAnnotationsToGQLOperationAnnotations(annotations []model.OperationAnnotation) []*gqlschema.OperationAnnotation {
+	result := make([]*gqlschema.OperationAnnotation, 0, len(annotations))
+	for _, annotation := range annotations {
+		result = append(result, &gqlschema.OperationAnnotation{
+			Key:       annotation.Key,
+			Value:     annotation.Value,
+			UpdatedAt: annotation.UpdatedAt,
+		})
 	}
+
+	return result
 }
 
 func (c graphQLConverter) runtimeConnectionStatusToGraphQLStatus(status model.RuntimeAgentConnectionStatus) *gqlschema.RuntimeConnectionStatus {
@@ -60,6 +280,8 @@ func (c graphQLConverter) clusterToToGraphQLRuntimeConfiguration(config model.Cl
 		ClusterConfig: c.gardenerConfigToGraphQLConfig(config.ClusterConfig),
 		KymaConfig:    c.kymaConfigToGraphQLConfig(config.KymaConfig),
 		Kubeconfig:    config.Kubeconfig,
+		APIServerURL:  config.APIServerURL,
+		CaCertificate: config.CACertificate,
 	}
 }
 
@@ -87,14 +309,198 @@ func (c graphQLConverter) gardenerConfigToGraphQLConfig(config model.GardenerCon
 		Region:                              &config.Region,
 		AutoScalerMin:                       &config.AutoScalerMin,
 		AutoScalerMax:                       &config.AutoScalerMax,
-		MaxSurge:                            &config.MaxSurge,
-		MaxUnavailable:                      &config.MaxUnavailable,
+		MaxSurge:                            intOrStringToGraphQL(config.MaxSurge),
+		MaxUnavailable:                      intOrStringToGraphQL(config.MaxUnavailable),
 		EnableKubernetesVersionAutoUpdate:   &config.EnableKubernetesVersionAutoUpdate,
 		EnableMachineImageVersionAutoUpdate: &config.EnableMachineImageVersionAutoUpdate,
 		AllowPrivilegedContainers:           &config.AllowPrivilegedContainers,
+		NetworkingType:                      c.networkingTypeToGraphQLType(config.NetworkingType),
+		ShootAnnotations:                    shootAnnotationsToGraphQL(config.ShootAnnotations),
+		CostAllocation:                      costAllocationToGraphQL(config.CostAllocation),
+		ClusterAutoscalerConfig:             clusterAutoscalerConfigToGraphQL(config.ClusterAutoscalerConfig),
+		KubeAPIServerConfig:                 kubeAPIServerConfigToGraphQL(config.KubeAPIServerConfig),
+		SystemWorkerPool:                    systemWorkerPoolToGraphQL(config.DedicatedSystemPool),
 		ProviderSpecificConfig:              providerSpecificConfig,
 		OidcConfig:                          c.oidcConfigToGraphQLConfig(config.OIDCConfig),
+		DNSConfig:                           dnsConfigToGraphQL(config.DNSConfig),
+		GardenerProject:                     &config.ProjectName,
+	}
+}
+
+func (c graphQLConverter) ProviderDefaultsToGraphQLProviderDefaults(provider gqlschema.Provider, defaults model.ProviderDefaults) *gqlschema.ProviderDefaults {
+	config := defaults.GardenerConfig
+
+	var cloudProfileName *string
+	var providerSpecificConfig gqlschema.ProviderSpecificConfig
+	if config.GardenerProviderConfig != nil {
+		if name := config.GardenerProviderConfig.CloudProfileName(); name != "" {
+			cloudProfileName = &name
+		}
+		providerSpecificConfig = config.GardenerProviderConfig.AsProviderSpecificConfig()
+	}
+
+	return &gqlschema.ProviderDefaults{
+		Provider:                            provider,
+		CloudProfileName:                    cloudProfileName,
+		EnableKubernetesVersionAutoUpdate:   config.EnableKubernetesVersionAutoUpdate,
+		EnableMachineImageVersionAutoUpdate: config.EnableMachineImageVersionAutoUpdate,
+		AllowPrivilegedContainers:           config.AllowPrivilegedContainers,
+		NetworkingType:                      c.networkingTypeToGraphQLType(config.NetworkingType),
+		MachineImage:                        config.MachineImage,
+		MachineImageVersion:                 config.MachineImageVersion,
+		Purpose:                             config.Purpose,
+		DiskType:                            config.DiskType,
+		VolumeSizeGb:                        config.VolumeSizeGB,
+		ProviderSpecificConfig:              providerSpecificConfig,
+		Regions: &gqlschema.ProviderRegions{
+			ProviderAllowed: defaults.Regions.ProviderAllowed,
+			AllowedRegions:  nonNilStrings(defaults.Regions.AllowedRegions),
+			DeniedRegions:   nonNilStrings(defaults.Regions.DeniedRegions),
+			DeniedZones:     nonNilStrings(defaults.Regions.DeniedZones),
+		},
+		KymaVersion: defaults.KymaVersion,
+		KymaProfile: c.profileToGraphQLProfile(defaults.KymaProfile),
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+func dnsConfigToGraphQL(config *model.DNSConfig) *gqlschema.DNSConfig {
+	if config == nil {
+		return nil
+	}
+
+	providers := make([]*gqlschema.DNSProvider, 0, len(config.Providers))
+	for _, provider := range config.Providers {
+		domains := provider.Domains
+		if domains == nil {
+			domains = []string{}
+		}
+		providers = append(providers, &gqlschema.DNSProvider{
+			Type:       provider.Type,
+			SecretName: provider.SecretName,
+			Domains:    domains,
+		})
+	}
+
+	return &gqlschema.DNSConfig{
+		Domain:    config.Domain,
+		Providers: providers,
+	}
+}
+
+func shootAnnotationsToGraphQL(annotations map[string]string) *gqlschema.Annotations {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	result := gqlschema.Annotations(annotations)
+	return &result
+}
+
+func costAllocationToGraphQL(costAllocation model.CostAllocation) *gqlschema.CostAllocation {
+	if costAllocation == (model.CostAllocation{}) {
+		return nil
+	}
+
+	return &gqlschema.CostAllocation{
+		GlobalAccountID: nonEmptyStringPtr(costAllocation.GlobalAccountID),
+		SubAccountID:    nonEmptyStringPtr(costAllocation.SubAccountID),
+		InstanceID:      nonEmptyStringPtr(costAllocation.InstanceID),
+	}
+}
+
+func clusterAutoscalerConfigToGraphQL(config *model.ClusterAutoscalerConfig) *gqlschema.ClusterAutoscalerConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &gqlschema.ClusterAutoscalerConfig{
+		ScaleDownDelayAfterAdd:        durationToGraphQL(config.ScaleDownDelayAfterAdd),
+		ScaleDownUnneededTime:         durationToGraphQL(config.ScaleDownUnneededTime),
+		ScaleDownUtilizationThreshold: config.ScaleDownUtilizationThreshold,
+		MaxNodeProvisionTime:          durationToGraphQL(config.MaxNodeProvisionTime),
+	}
+}
+
+func kubeAPIServerConfigToGraphQL(config *model.KubeAPIServerConfig) *gqlschema.KubeAPIServerConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &gqlschema.KubeAPIServerConfig{
+		FeatureGates:           switchesToGraphQL(config.FeatureGates),
+		RuntimeConfig:          switchesToGraphQL(config.RuntimeConfig),
+		MaxNonMutatingInflight: config.MaxNonMutatingInflight,
+		MaxMutatingInflight:    config.MaxMutatingInflight,
+	}
+}
+
+func systemWorkerPoolToGraphQL(pool *model.SystemWorkerPool) *gqlschema.SystemWorkerPool {
+	if pool == nil {
+		return nil
+	}
+
+	return &gqlschema.SystemWorkerPool{
+		Size:        pool.Size,
+		MachineType: nonEmptyStringPtr(pool.MachineType),
+	}
+}
+
+func switchesToGraphQL(switches map[string]bool) *gqlschema.Switches {
+	if len(switches) == 0 {
+		return nil
+	}
+
+	result := gqlschema.Switches(switches)
+	return &result
+}
+
+func durationToGraphQL(duration *v1.Duration) *string {
+	if duration == nil {
+		return nil
+	}
+
+	value := duration.Duration.String()
+	return &value
+}
+
+// nonEmptyStringPtr returns nil for identifiers which are not set
+func nonEmptyStringPtr(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func intOrStringToGraphQL(value *intstr.IntOrString) *gqlschema.IntOrString {
+	if value == nil {
+		return nil
 	}
+
+	return gqlschema.NewIntOrString(*value)
+}
+
+func (c graphQLConverter) networkingTypeToGraphQLType(networkingType model.NetworkingType) *gqlschema.NetworkingType {
+	var result gqlschema.NetworkingType
+
+	switch networkingType {
+	case model.CalicoNetworkingType:
+		result = gqlschema.NetworkingTypeCalico
+	case model.CiliumNetworkingType:
+		result = gqlschema.NetworkingTypeCilium
+	default:
+		return nil
+	}
+
+	return &result
 }
 
 func (c graphQLConverter) oidcConfigToGraphQLConfig(config *model.OIDCConfig) *gqlschema.OIDCConfig {
@@ -111,7 +517,11 @@ func (c graphQLConverter) oidcConfigToGraphQLConfig(config *model.OIDCConfig) *g
 	}
 }
 
-func (c graphQLConverter) kymaConfigToGraphQLConfig(config model.KymaConfig) *gqlschema.KymaConfig {
+func (c graphQLConverter) kymaConfigToGraphQLConfig(config *model.KymaConfig) *gqlschema.KymaConfig {
+	if config == nil {
+		return &gqlschema.KymaConfig{ExternallyManaged: true}
+	}
+
 	var components []*gqlschema.ComponentConfiguration
 	for _, cmp := range config.Components {
 
@@ -163,6 +573,27 @@ func (c graphQLConverter) operationTypeToGraphQLType(operationType model.Operati
 		return gqlschema.OperationTypeReconnectRuntime
 	case model.Hibernate:
 		return gqlschema.OperationTypeHibernate
+	case model.WakeUp:
+		return gqlschema.OperationTypeWakeUp
+	case model.CleanupFailedProvisioning:
+		return gqlschema.OperationTypeCleanupFailedProvisioning
+	default:
+		return ""
+	}
+}
+
+func (c graphQLConverter) operationTypeToGraphQLQueueType(operationType model.OperationType) gqlschema.QueueType {
+	switch operationType {
+	case model.Provision:
+		return gqlschema.QueueTypeProvision
+	case model.Deprovision:
+		return gqlschema.QueueTypeDeprovision
+	case model.Upgrade:
+		return gqlschema.QueueTypeUpgrade
+	case model.UpgradeShoot:
+		return gqlschema.QueueTypeUpgradeShoot
+	case model.Hibernate:
+		return gqlschema.QueueTypeHibernate
 	default:
 		return ""
 	}
@@ -170,6 +601,8 @@ func (c graphQLConverter) operationTypeToGraphQLType(operationType model.Operati
 
 func (c graphQLConverter) operationStateToGraphQLState(state model.OperationState) gqlschema.OperationState {
 	switch state {
+	case model.Pending:
+		return gqlschema.OperationStatePending
 	case model.InProgress:
 		return gqlschema.OperationStateInProgress
 	case model.Succeeded:
@@ -2,6 +2,7 @@ package provisioning
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -49,6 +51,40 @@ func TestOperationStatusToGQLOperationStatus(t *testing.T) {
 		//then
 		assert.Equal(t, expectedOperationStatus, status)
 	})
+
+	t.Run("Should include Runtime Agent diagnostics", func(t *testing.T) {
+		//given
+		diagnostics := "Compass Connection not found"
+		operation := model.Operation{
+			ID:          "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
+			Type:        model.Provision,
+			State:       model.InProgress,
+			Diagnostics: &diagnostics,
+		}
+
+		//when
+		status := graphQLConverter.OperationStatusToGQLOperationStatus(operation)
+
+		//then
+		assert.Equal(t, &diagnostics, status.Diagnostics)
+	})
+
+	t.Run("Should include failure reason", func(t *testing.T) {
+		//given
+		reason := "quota_exceeded"
+		operation := model.Operation{
+			ID:            "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
+			Type:          model.Provision,
+			State:         model.Failed,
+			FailureReason: &reason,
+		}
+
+		//when
+		status := graphQLConverter.OperationStatusToGQLOperationStatus(operation)
+
+		//then
+		assert.Equal(t, &reason, status.FailureReason)
+	})
 }
 
 func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
@@ -82,6 +118,10 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 		enableMachineImageVersionAutoUpdate := false
 		allowPrivilegedContainers := true
 
+		hibernatedSince := time.Date(2021, 6, 1, 20, 0, 0, 0, time.UTC)
+		lastWokenAt := time.Date(2021, 5, 31, 8, 0, 0, 0, time.UTC)
+		hibernationTrigger := model.HibernationTriggerScheduled
+
 		gardenerProviderConfig, err := model.NewGardenerProviderConfigFromJSON(`{"zones":["fix-gcp-zone-1","fix-gcp-zone-2"]}`)
 		require.NoError(t, err)
 
@@ -95,6 +135,7 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 			},
 			RuntimeConnectionStatus: model.RuntimeAgentConnectionStatusDisconnected,
 			RuntimeConfiguration: model.Cluster{
+				SubAccountId: util.StringPtr("sub-account"),
 				ClusterConfig: model.GardenerConfig{
 					Name:                                clusterName,
 					ProjectName:                         project,
@@ -113,20 +154,25 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 					WorkerCidr:                          cidr,
 					AutoScalerMax:                       autoScMax,
 					AutoScalerMin:                       autoScMin,
-					MaxSurge:                            surge,
-					MaxUnavailable:                      unavailable,
+					MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(surge)),
+					MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(unavailable)),
 					EnableKubernetesVersionAutoUpdate:   enableKubernetesVersionAutoUpdate,
 					EnableMachineImageVersionAutoUpdate: enableMachineImageVersionAutoUpdate,
 					AllowPrivilegedContainers:           allowPrivilegedContainers,
 					GardenerProviderConfig:              gardenerProviderConfig,
 					OIDCConfig:                          oidcConfig(),
 				},
-				Kubeconfig: &kubeconfig,
-				KymaConfig: fixKymaConfig(nil),
+				Kubeconfig:    &kubeconfig,
+				APIServerURL:  util.StringPtr("https://api.shoot.example.com"),
+				CACertificate: util.StringPtr("ca-certificate"),
+				KymaConfig:    fixKymaConfig(nil),
 			},
 			HibernationStatus: model.HibernationStatus{
 				HibernationPossible: true,
 				Hibernated:          true,
+				HibernatedSince:     &hibernatedSince,
+				LastWokenAt:         &lastWokenAt,
+				Trigger:             &hibernationTrigger,
 			},
 		}
 
@@ -136,6 +182,7 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 
 		hibernationPossible := true
 		hibernated := true
+		expectedHibernationTrigger := gqlschema.HibernationTriggerScheduled
 
 		expectedRuntimeStatus := &gqlschema.RuntimeStatus{
 			LastOperationStatus: &gqlschema.OperationStatus{
@@ -148,6 +195,7 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 			RuntimeConnectionStatus: &gqlschema.RuntimeConnectionStatus{
 				Status: gqlschema.RuntimeAgentConnectionStatusDisconnected,
 			},
+			SubAccountID: util.StringPtr("sub-account"),
 			RuntimeConfiguration: &gqlschema.RuntimeConfig{
 				ClusterConfig: &gqlschema.GardenerConfig{
 					Name:                                &clusterName,
@@ -163,11 +211,12 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 					LicenceType:                         &licenceType,
 					Seed:                                &seed,
 					TargetSecret:                        &secret,
+					GardenerProject:                     &project,
 					WorkerCidr:                          &cidr,
 					AutoScalerMax:                       &autoScMax,
 					AutoScalerMin:                       &autoScMin,
-					MaxSurge:                            &surge,
-					MaxUnavailable:                      &unavailable,
+					MaxSurge:                            gqlschema.NewIntOrString(intstr.FromInt(surge)),
+					MaxUnavailable:                      gqlschema.NewIntOrString(intstr.FromInt(unavailable)),
 					EnableKubernetesVersionAutoUpdate:   &enableKubernetesVersionAutoUpdate,
 					EnableMachineImageVersionAutoUpdate: &enableMachineImageVersionAutoUpdate,
 					AllowPrivilegedContainers:           &allowPrivilegedContainers,
@@ -183,12 +232,17 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 						UsernamePrefix: "-",
 					},
 				},
-				KymaConfig: fixKymaGraphQLConfig(nil),
-				Kubeconfig: &kubeconfig,
+				KymaConfig:    fixKymaGraphQLConfig(nil),
+				Kubeconfig:    &kubeconfig,
+				APIServerURL:  util.StringPtr("https://api.shoot.example.com"),
+				CaCertificate: util.StringPtr("ca-certificate"),
 			},
 			HibernationStatus: &gqlschema.HibernationStatus{
 				HibernationPossible: &hibernationPossible,
 				Hibernated:          &hibernated,
+				HibernatedSince:     &hibernatedSince,
+				LastWokenAt:         &lastWokenAt,
+				Trigger:             &expectedHibernationTrigger,
 			},
 		}
 
@@ -259,8 +313,8 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 					WorkerCidr:                          cidr,
 					AutoScalerMin:                       autoScMin,
 					AutoScalerMax:                       autoScMax,
-					MaxSurge:                            surge,
-					MaxUnavailable:                      unavailable,
+					MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(surge)),
+					MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(unavailable)),
 					EnableKubernetesVersionAutoUpdate:   enableKubernetesVersionAutoUpdate,
 					EnableMachineImageVersionAutoUpdate: enableMachineImageVersionAutoUpdate,
 					AllowPrivilegedContainers:           allowPrivilegedContainers,
@@ -307,11 +361,12 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 					LicenceType:                         &licenceType,
 					Seed:                                &seed,
 					TargetSecret:                        &secret,
+					GardenerProject:                     &project,
 					WorkerCidr:                          &cidr,
 					AutoScalerMax:                       &autoScMax,
 					AutoScalerMin:                       &autoScMin,
-					MaxSurge:                            &surge,
-					MaxUnavailable:                      &unavailable,
+					MaxSurge:                            gqlschema.NewIntOrString(intstr.FromInt(surge)),
+					MaxUnavailable:                      gqlschema.NewIntOrString(intstr.FromInt(unavailable)),
 					EnableKubernetesVersionAutoUpdate:   &enableKubernetesVersionAutoUpdate,
 					EnableMachineImageVersionAutoUpdate: &enableMachineImageVersionAutoUpdate,
 					AllowPrivilegedContainers:           &allowPrivilegedContainers,
@@ -335,6 +390,116 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 		//then
 		assert.Equal(t, expectedRuntimeStatus, gqlStatus)
 	})
+
+	t.Run("Should report Kyma config as externally managed when Kyma is not installed by the Provisioner", func(t *testing.T) {
+		//given
+		runtimeStatus := model.RuntimeStatus{
+			RuntimeConfiguration: model.Cluster{
+				ClusterConfig: model.GardenerConfig{},
+			},
+		}
+
+		//when
+		gqlStatus := graphQLConverter.RuntimeStatusToGraphQLStatus(runtimeStatus)
+
+		//then
+		assert.Equal(t, &gqlschema.KymaConfig{ExternallyManaged: true}, gqlStatus.RuntimeConfiguration.KymaConfig)
+	})
+
+	t.Run("Should include expiration and deprovisioning schedule", func(t *testing.T) {
+		//given
+		expireAt := time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC)
+		scheduledAt := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+		runtimeStatus := model.RuntimeStatus{
+			RuntimeConfiguration: model.Cluster{
+				ExpireAt:                  &expireAt,
+				DeprovisioningScheduledAt: &scheduledAt,
+				ClusterConfig:             model.GardenerConfig{},
+			},
+		}
+
+		//when
+		gqlStatus := graphQLConverter.RuntimeStatusToGraphQLStatus(runtimeStatus)
+
+		//then
+		assert.Equal(t, &expireAt, gqlStatus.ExpireAt)
+		assert.Equal(t, &scheduledAt, gqlStatus.DeprovisioningScheduledAt)
+	})
+
+	t.Run("Should include Shoot conditions and last errors", func(t *testing.T) {
+		//given
+		transitionTime := time.Now()
+		runtimeStatus := model.RuntimeStatus{
+			RuntimeConfiguration: model.Cluster{
+				ClusterConfig: model.GardenerConfig{},
+			},
+			ShootStatus: &model.ShootStatus{
+				State: model.ShootStateUnhealthy,
+				Conditions: []model.ShootCondition{
+					{
+						Type:               "APIServerAvailable",
+						Status:             "False",
+						Reason:             "HealthzRequestFailed",
+						Message:            "API server /healthz endpoint responded with unsuccessful status code",
+						LastTransitionTime: transitionTime,
+					},
+				},
+				LastErrors: []model.ShootError{
+					{Description: "quota exceeded", Codes: []string{"ERR_INFRA_QUOTA_EXCEEDED"}},
+				},
+			},
+		}
+
+		expectedShootStatus := &gqlschema.ShootStatus{
+			State: gqlschema.ShootStateUnhealthy,
+			Conditions: []*gqlschema.ShootCondition{
+				{
+					Type:               "APIServerAvailable",
+					Status:             "False",
+					Reason:             "HealthzRequestFailed",
+					Message:            "API server /healthz endpoint responded with unsuccessful status code",
+					LastTransitionTime: transitionTime,
+				},
+			},
+			LastErrors: []*gqlschema.ShootError{
+				{Description: "quota exceeded", Codes: []string{"ERR_INFRA_QUOTA_EXCEEDED"}},
+			},
+		}
+
+		//when
+		gqlStatus := graphQLConverter.RuntimeStatusToGraphQLStatus(runtimeStatus)
+
+		//then
+		assert.Equal(t, expectedShootStatus, gqlStatus.ShootStatus)
+	})
+
+	t.Run("Should include custom DNS config and effective domain", func(t *testing.T) {
+		//given
+		runtimeStatus := model.RuntimeStatus{
+			RuntimeConfiguration: model.Cluster{
+				ClusterConfig: model.GardenerConfig{
+					DNSConfig: &model.DNSConfig{
+						Domain:    "runtime.customer.example.com",
+						Providers: []*model.DNSProvider{{Type: "aws-route53", SecretName: "route53-credentials"}},
+					},
+				},
+			},
+			ShootStatus: &model.ShootStatus{
+				State:  model.ShootStateHealthy,
+				Domain: util.StringPtr("runtime.customer.example.com"),
+			},
+		}
+
+		//when
+		gqlStatus := graphQLConverter.RuntimeStatusToGraphQLStatus(runtimeStatus)
+
+		//then
+		assert.Equal(t, &gqlschema.DNSConfig{
+			Domain:    "runtime.customer.example.com",
+			Providers: []*gqlschema.DNSProvider{{Type: "aws-route53", SecretName: "route53-credentials", Domains: []string{}}},
+		}, gqlStatus.RuntimeConfiguration.ClusterConfig.DNSConfig)
+		assert.Equal(t, util.StringPtr("runtime.customer.example.com"), gqlStatus.ShootStatus.Domain)
+	})
 }
 
 func fixKymaGraphQLConfig(profile *gqlschema.KymaProfile) *gqlschema.KymaConfig {
@@ -386,8 +551,8 @@ func fixGQLConfigEntry(key, val string, secret *bool) *gqlschema.ConfigEntry {
 	}
 }
 
-func fixKymaConfig(profile *model.KymaProfile) model.KymaConfig {
-	return model.KymaConfig{
+func fixKymaConfig(profile *model.KymaProfile) *model.KymaConfig {
+	return &model.KymaConfig{
 		ID:                  "id",
 		Release:             fixKymaRelease(),
 		Profile:             profile,
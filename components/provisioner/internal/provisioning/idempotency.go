@@ -0,0 +1,124 @@
+package provisioning
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+)
+
+// idempotencyLocks serializes requests with the same idempotency key so that only the first one starts the operation.
+// Requests handled by other replicas are guarded by the unique constraint on the key in the database.
+type idempotencyLocks struct {
+	mutex sync.Mutex
+	locks map[string]*idempotencyLock
+}
+
+type idempotencyLock struct {
+	sync.Mutex
+	holders int
+}
+
+func newIdempotencyLocks() *idempotencyLocks {
+	return &idempotencyLocks{
+		locks: map[string]*idempotencyLock{},
+	}
+}
+
+// lock blocks until the key is free and returns the function releasing it
+func (l *idempotencyLocks) lock(tenant, key string) func() {
+	id := tenant + "/" + key
+
+	l.mutex.Lock()
+	keyLock, found := l.locks[id]
+	if !found {
+		keyLock = &idempotencyLock{}
+		l.locks[id] = keyLock
+	}
+	keyLock.holders++
+	l.mutex.Unlock()
+
+	keyLock.Lock()
+
+	return func() {
+		keyLock.Unlock()
+
+		l.mutex.Lock()
+		keyLock.holders--
+		if keyLock.holders == 0 {
+			delete(l.locks, id)
+		}
+		l.mutex.Unlock()
+	}
+}
+
+// lockIdempotencyKey returns no-op unlock function if the key is not provided
+func (r *service) lockIdempotencyKey(tenant, key string) func() {
+	if key == "" {
+		return func() {}
+	}
+
+	return r.idempotencyLocks.lock(tenant, key)
+}
+
+// findIdempotentOperation returns the operation started by the previous request with the same key if the key did not expire.
+// Reusing the key for different type of operation is rejected.
+func (r *service) findIdempotentOperation(tenant, key string, operationType model.OperationType) (model.Operation, bool, apperrors.AppError) {
+	if key == "" {
+		return model.Operation{}, false, nil
+	}
+
+	session := r.dbSessionFactory.NewReadSession()
+
+	idempotencyKey, dberr := session.GetIdempotencyKey(tenant, key)
+	if dberr != nil {
+		if dberr.Code() == dberrors.CodeNotFound {
+			return model.Operation{}, false, nil
+		}
+		return model.Operation{}, false, apperrors.Internal("failed to get idempotency key: %s", dberr.Error())
+	}
+
+	if r.idempotencyKeyExpired(idempotencyKey) {
+		return model.Operation{}, false, nil
+	}
+
+	if idempotencyKey.OperationType != operationType {
+		return model.Operation{}, false, apperrors.BadRequest("error: idempotency key %s was already used for %s operation", key, idempotencyKey.OperationType)
+	}
+
+	operation, dberr := session.GetOperation(idempotencyKey.OperationID)
+	if dberr != nil {
+		return model.Operation{}, false, apperrors.Internal("failed to get operation %s started with idempotency key %s: %s", idempotencyKey.OperationID, key, dberr.Error())
+	}
+
+	return operation, true, nil
+}
+
+// insertIdempotencyKey stores the key with the started operation, the expired key of the tenant is replaced
+func (r *service) insertIdempotencyKey(session dbsession.WriteSession, tenant, key string, operation model.Operation) dberrors.Error {
+	if key == "" {
+		return nil
+	}
+
+	if r.idempotencyKeyTTL > 0 {
+		dberr := session.DeleteExpiredIdempotencyKey(tenant, key, time.Now().Add(-r.idempotencyKeyTTL))
+		if dberr != nil {
+			return dberr
+		}
+	}
+
+	return session.InsertIdempotencyKey(model.IdempotencyKey{
+		Tenant:        tenant,
+		Key:           key,
+		OperationType: operation.Type,
+		OperationID:   operation.ID,
+		CreatedAt:     time.Now(),
+	})
+}
+
+func (r *service) idempotencyKeyExpired(idempotencyKey model.IdempotencyKey) bool {
+	return r.idempotencyKeyTTL > 0 && time.Since(idempotencyKey.CreatedAt) > r.idempotencyKeyTTL
+}
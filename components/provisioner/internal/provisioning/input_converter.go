@@ -1,6 +1,8 @@
 package provisioning
 
 import (
+	"time"
+
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/installation/release"
@@ -9,12 +11,17 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type InputConverter interface {
 	ProvisioningInputToCluster(runtimeID string, input gqlschema.ProvisionRuntimeInput, tenant, subAccountId string) (model.Cluster, apperrors.AppError)
+	// ProvisioningInputToClusterDryRun converts the input same as ProvisioningInputToCluster but does not save the downloaded Kyma release
+	ProvisioningInputToClusterDryRun(runtimeID string, input gqlschema.ProvisionRuntimeInput, tenant, subAccountId string) (model.Cluster, apperrors.AppError)
 	KymaConfigFromInput(runtimeID string, input gqlschema.KymaConfigInput) (model.KymaConfig, apperrors.AppError)
 	UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpgradeInput, existing model.GardenerConfig) (model.GardenerConfig, apperrors.AppError)
+	// ProviderDefaults converts the input of the provider which specifies only the required fields, so that the defaults are the same as for the actual requests
+	ProviderDefaults(provider string) (model.ProviderDefaults, apperrors.AppError)
 }
 
 func NewInputConverter(
@@ -23,7 +30,12 @@ func NewInputConverter(
 	gardenerProject string,
 	defaultEnableKubernetesVersionAutoUpdate,
 	defaultEnableMachineImageVersionAutoUpdate,
-	forceAllowPrivilegedContainers bool) InputConverter {
+	forceAllowPrivilegedContainers bool,
+	defaultNetworkingType model.NetworkingType,
+	defaultGCPShieldedInstanceConfig model.GCPShieldedInstanceConfig,
+	defaultAWSInstanceMetadataOptions model.AWSInstanceMetadataOptions,
+	defaultSystemWorkerPool model.SystemWorkerPool,
+	defaultKymaProfile *model.KymaProfile) InputConverter {
 
 	return &converter{
 		uuidGenerator:                              uuidGenerator,
@@ -32,6 +44,11 @@ func NewInputConverter(
 		defaultEnableKubernetesVersionAutoUpdate:   defaultEnableKubernetesVersionAutoUpdate,
 		defaultEnableMachineImageVersionAutoUpdate: defaultEnableMachineImageVersionAutoUpdate,
 		forceAllowPrivilegedContainers:             forceAllowPrivilegedContainers,
+		defaultNetworkingType:                      defaultNetworkingType,
+		defaultGCPShieldedInstanceConfig:           defaultGCPShieldedInstanceConfig,
+		defaultAWSInstanceMetadataOptions:          defaultAWSInstanceMetadataOptions,
+		defaultSystemWorkerPool:                    defaultSystemWorkerPool,
+		defaultKymaProfile:                         defaultKymaProfile,
 	}
 }
 
@@ -42,17 +59,33 @@ type converter struct {
 	defaultEnableKubernetesVersionAutoUpdate   bool
 	defaultEnableMachineImageVersionAutoUpdate bool
 	forceAllowPrivilegedContainers             bool
+	defaultNetworkingType                      model.NetworkingType
+	defaultGCPShieldedInstanceConfig           model.GCPShieldedInstanceConfig
+	defaultAWSInstanceMetadataOptions          model.AWSInstanceMetadataOptions
+	defaultSystemWorkerPool                    model.SystemWorkerPool
+	defaultKymaProfile                         *model.KymaProfile
 }
 
 func (c converter) ProvisioningInputToCluster(runtimeID string, input gqlschema.ProvisionRuntimeInput, tenant, subAccountId string) (model.Cluster, apperrors.AppError) {
+	return c.provisioningInputToCluster(runtimeID, input, tenant, subAccountId, false)
+}
+
+func (c converter) ProvisioningInputToClusterDryRun(runtimeID string, input gqlschema.ProvisionRuntimeInput, tenant, subAccountId string) (model.Cluster, apperrors.AppError) {
+	return c.provisioningInputToCluster(runtimeID, input, tenant, subAccountId, true)
+}
+
+func (c converter) provisioningInputToCluster(runtimeID string, input gqlschema.ProvisionRuntimeInput, tenant, subAccountId string, dryRun bool) (model.Cluster, apperrors.AppError) {
 	var err apperrors.AppError
 
-	var kymaConfig model.KymaConfig
+	var kymaConfig *model.KymaConfig
+	var tillerYaml string
 	if input.KymaConfig != nil {
-		kymaConfig, err = c.KymaConfigFromInput(runtimeID, *input.KymaConfig)
+		config, err := c.kymaConfigFromInput(runtimeID, *input.KymaConfig, dryRun)
 		if err != nil {
 			return model.Cluster{}, err
 		}
+		kymaConfig = &config
+		tillerYaml = config.Release.TillerYAML
 	}
 
 	if input.ClusterConfig == nil || input.ClusterConfig.GardenerConfig == nil {
@@ -61,7 +94,7 @@ func (c converter) ProvisioningInputToCluster(runtimeID string, input gqlschema.
 
 	gardenerConfigAllowPrivilegedContainers := c.shouldAllowPrivilegedContainers(
 		input.ClusterConfig.GardenerConfig.AllowPrivilegedContainers,
-		kymaConfig.Release.TillerYAML)
+		tillerYaml)
 
 	gardenerConfig, err := c.gardenerConfigFromInput(
 		runtimeID,
@@ -71,6 +104,14 @@ func (c converter) ProvisioningInputToCluster(runtimeID string, input gqlschema.
 		return model.Cluster{}, err
 	}
 
+	gardenerConfig.CostAllocation = costAllocationFromInput(input.ClusterConfig.GardenerConfig.CostAllocation, model.CostAllocation{
+		GlobalAccountID: tenant,
+		SubAccountID:    subAccountId,
+	})
+	if err := gardenerConfig.CostAllocation.Validate(); err != nil {
+		return model.Cluster{}, err
+	}
+
 	return model.Cluster{
 		ID:             runtimeID,
 		KymaConfig:     kymaConfig,
@@ -78,11 +119,94 @@ func (c converter) ProvisioningInputToCluster(runtimeID string, input gqlschema.
 		Tenant:         tenant,
 		SubAccountId:   &subAccountId,
 		Administrators: input.ClusterConfig.Administrators,
+		ExpireAt:       expirationFromInput(input.ExpirationSeconds, input.ExpireAt, time.Now()),
 	}, nil
 }
 
+func (c converter) ProviderDefaults(provider string) (model.ProviderDefaults, apperrors.AppError) {
+	providerSpecificInput, err := minimalProviderSpecificInput(provider)
+	if err != nil {
+		return model.ProviderDefaults{}, err
+	}
+
+	latestRelease, releaseErr := c.releaseProvider.GetLatestRelease()
+	if releaseErr != nil {
+		return model.ProviderDefaults{}, apperrors.Internal("failed to get the latest Kyma release: %s", releaseErr.Error())
+	}
+
+	var kymaVersion *string
+	tillerYaml := ""
+	if latestRelease != nil {
+		kymaVersion = &latestRelease.Version
+		tillerYaml = latestRelease.TillerYAML
+	}
+
+	input := &gqlschema.GardenerConfigInput{
+		Provider:               provider,
+		ProviderSpecificConfig: providerSpecificInput,
+	}
+
+	gardenerConfig, err := c.gardenerConfigFromInput("", input, c.shouldAllowPrivilegedContainers(nil, tillerYaml))
+	if err != nil {
+		return model.ProviderDefaults{}, err
+	}
+
+	return model.ProviderDefaults{
+		Provider:       provider,
+		GardenerConfig: gardenerConfig,
+		KymaVersion:    kymaVersion,
+		KymaProfile:    c.graphQLProfileToProfile(nil),
+	}, nil
+}
+
+func minimalProviderSpecificInput(provider string) (*gqlschema.ProviderSpecificInput, apperrors.AppError) {
+	switch provider {
+	case "gcp":
+		return &gqlschema.ProviderSpecificInput{GcpConfig: &gqlschema.GCPProviderConfigInput{}}, nil
+	case "azure":
+		return &gqlschema.ProviderSpecificInput{AzureConfig: &gqlschema.AzureProviderConfigInput{}}, nil
+	case "aws":
+		return &gqlschema.ProviderSpecificInput{AwsConfig: &gqlschema.AWSProviderConfigInput{}}, nil
+	case "openstack":
+		return &gqlschema.ProviderSpecificInput{OpenStackConfig: &gqlschema.OpenStackProviderConfigInput{}}, nil
+	default:
+		return nil, apperrors.BadRequest("provider %s is not supported", provider)
+	}
+}
+
+// expirationFromInput returns the time after which the Runtime expires, the expiration in seconds is counted from now
+func expirationFromInput(expirationSeconds *int, expireAt *time.Time, now time.Time) *time.Time {
+	if expireAt != nil {
+		expiration := expireAt.UTC()
+		return &expiration
+	}
+	if expirationSeconds != nil {
+		expiration := now.UTC().Add(time.Duration(*expirationSeconds) * time.Second)
+		return &expiration
+	}
+
+	return nil
+}
+
 func (c converter) gardenerConfigFromInput(runtimeID string, input *gqlschema.GardenerConfigInput, allowPrivilegedContainers bool) (model.GardenerConfig, apperrors.AppError) {
-	providerSpecificConfig, err := c.providerSpecificConfigFromInput(input.ProviderSpecificConfig)
+	providerSpecificInput := gcpConfigWithDefaults(input.ProviderSpecificConfig, c.defaultGCPProviderConfig())
+	providerSpecificInput = awsConfigWithDefaults(providerSpecificInput, c.defaultAWSProviderConfig())
+	providerSpecificConfig, err := c.providerSpecificConfigFromInput(providerSpecificInput)
+	if err != nil {
+		return model.GardenerConfig{}, err
+	}
+
+	clusterAutoscalerConfig, err := model.ClusterAutoscalerConfigFromInput(input.ClusterAutoscalerConfig, nil)
+	if err != nil {
+		return model.GardenerConfig{}, err
+	}
+
+	kubeAPIServerConfig, err := model.KubeAPIServerConfigFromInput(input.KubeAPIServerConfig, nil)
+	if err != nil {
+		return model.GardenerConfig{}, err
+	}
+
+	dedicatedSystemPool, err := model.SystemWorkerPoolFromInput(input.DedicatedSystemPool, input.SystemWorkerPool, nil, c.defaultSystemWorkerPool)
 	if err != nil {
 		return model.GardenerConfig{}, err
 	}
@@ -90,8 +214,8 @@ func (c converter) gardenerConfigFromInput(runtimeID string, input *gqlschema.Ga
 	id := c.uuidGenerator.New()
 	return model.GardenerConfig{
 		ID:                                  id,
-		Name:                                input.Name,
-		ProjectName:                         c.gardenerProject,
+		Name:                                util.UnwrapStr(input.Name),
+		ProjectName:                         util.UnwrapStrOrDefault(input.GardenerProject, c.gardenerProject),
 		KubernetesVersion:                   input.KubernetesVersion,
 		Provider:                            input.Provider,
 		Region:                              input.Region,
@@ -105,16 +229,22 @@ func (c converter) gardenerConfigFromInput(runtimeID string, input *gqlschema.Ga
 		WorkerCidr:                          input.WorkerCidr,
 		AutoScalerMin:                       input.AutoScalerMin,
 		AutoScalerMax:                       input.AutoScalerMax,
-		MaxSurge:                            input.MaxSurge,
-		MaxUnavailable:                      input.MaxUnavailable,
+		MaxSurge:                            intOrStringFromInput(input.MaxSurge, nil),
+		MaxUnavailable:                      intOrStringFromInput(input.MaxUnavailable, nil),
 		Purpose:                             input.Purpose,
 		LicenceType:                         input.LicenceType,
 		EnableKubernetesVersionAutoUpdate:   util.UnwrapBoolOrDefault(input.EnableKubernetesVersionAutoUpdate, c.defaultEnableKubernetesVersionAutoUpdate),
 		EnableMachineImageVersionAutoUpdate: util.UnwrapBoolOrDefault(input.EnableMachineImageVersionAutoUpdate, c.defaultEnableMachineImageVersionAutoUpdate),
 		AllowPrivilegedContainers:           allowPrivilegedContainers,
+		NetworkingType:                      c.networkingTypeFromInput(input.NetworkingType),
+		ShootAnnotations:                    shootAnnotationsFromInput(input.ShootAnnotations, nil),
+		ClusterAutoscalerConfig:             clusterAutoscalerConfig,
+		KubeAPIServerConfig:                 kubeAPIServerConfig,
+		DedicatedSystemPool:                 dedicatedSystemPool,
 		ClusterID:                           runtimeID,
 		GardenerProviderConfig:              providerSpecificConfig,
 		OIDCConfig:                          oidcConfigFromInput(input.OidcConfig),
+		DNSConfig:                           dnsConfigFromInput(input.DNSConfig, nil),
 	}, nil
 }
 
@@ -132,6 +262,66 @@ func oidcConfigFromInput(config *gqlschema.OIDCConfigInput) *model.OIDCConfig {
 	return nil
 }
 
+// dnsConfigFromInput replaces the current DNS config only if the input contains it
+func dnsConfigFromInput(input *gqlschema.DNSConfigInput, current *model.DNSConfig) *model.DNSConfig {
+	if input == nil {
+		return current
+	}
+
+	providers := make([]*model.DNSProvider, 0, len(input.Providers))
+	for _, provider := range input.Providers {
+		providers = append(providers, &model.DNSProvider{
+			Type:       provider.Type,
+			SecretName: provider.SecretName,
+			Domains:    provider.Domains,
+		})
+	}
+
+	return &model.DNSConfig{
+		Domain:    input.Domain,
+		Providers: providers,
+	}
+}
+
+// shootAnnotationsFromInput replaces the current annotations only if the input contains them
+func shootAnnotationsFromInput(input *gqlschema.Annotations, current map[string]string) map[string]string {
+	if input == nil {
+		return current
+	}
+
+	return *input
+}
+
+// costAllocationFromInput replaces the current identifiers with the ones provided in the input
+func costAllocationFromInput(input *gqlschema.CostAllocationInput, current model.CostAllocation) model.CostAllocation {
+	if input == nil {
+		return current
+	}
+
+	return model.CostAllocation{
+		GlobalAccountID: util.UnwrapStrOrDefault(input.GlobalAccountID, current.GlobalAccountID),
+		SubAccountID:    util.UnwrapStrOrDefault(input.SubAccountID, current.SubAccountID),
+		InstanceID:      util.UnwrapStrOrDefault(input.InstanceID, current.InstanceID),
+	}
+}
+
+// intOrStringFromInput returns the current value if the input does not contain it, nil means the Gardener default
+func intOrStringFromInput(input *gqlschema.IntOrString, current *intstr.IntOrString) *intstr.IntOrString {
+	if input == nil {
+		return current
+	}
+
+	return util.IntOrStringPtr(input.IntOrString)
+}
+
+func (c converter) networkingTypeFromInput(networkingType *gqlschema.NetworkingType) model.NetworkingType {
+	if networkingType == nil {
+		return c.defaultNetworkingType
+	}
+
+	return model.NetworkingTypeFromGraphQL(*networkingType)
+}
+
 func (c converter) shouldAllowPrivilegedContainers(inputAllowPrivilegedContainers *bool, tillerYaml string) bool {
 	if c.forceAllowPrivilegedContainers {
 		return true
@@ -145,7 +335,9 @@ func (c converter) UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpg
 	var err apperrors.AppError
 
 	if input.ProviderSpecificConfig != nil {
-		providerSpecificConfig, err = c.providerSpecificConfigFromInput(input.ProviderSpecificConfig)
+		providerSpecificInput := gcpConfigWithDefaults(input.ProviderSpecificConfig, currentGCPProviderConfig(config.GardenerProviderConfig))
+		providerSpecificInput = awsConfigWithDefaults(providerSpecificInput, currentAWSProviderConfig(config.GardenerProviderConfig))
+		providerSpecificConfig, err = c.providerSpecificConfigFromInput(providerSpecificInput)
 		if providerSpecificConfig == nil {
 			return model.GardenerConfig{}, err.Append("error converting provider specific config from input: %s", err)
 		}
@@ -153,6 +345,31 @@ func (c converter) UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpg
 		providerSpecificConfig = config.GardenerProviderConfig
 	}
 
+	// Gardener does not support changing the networking extension of the existing Shoot
+	if input.NetworkingType != nil && model.NetworkingTypeFromGraphQL(*input.NetworkingType) != config.NetworkingType {
+		return model.GardenerConfig{}, apperrors.BadRequest("error: networking type cannot be changed from %s to %s", config.NetworkingType, model.NetworkingTypeFromGraphQL(*input.NetworkingType))
+	}
+
+	// The domain of the Shoot is immutable, only its DNS providers can be replaced
+	if input.DNSConfig != nil && (config.DNSConfig == nil || config.DNSConfig.Domain != input.DNSConfig.Domain) {
+		return model.GardenerConfig{}, apperrors.BadRequest("error: DNS domain cannot be changed to %s", input.DNSConfig.Domain)
+	}
+
+	clusterAutoscalerConfig, err := model.ClusterAutoscalerConfigFromInput(input.ClusterAutoscalerConfig, config.ClusterAutoscalerConfig)
+	if err != nil {
+		return model.GardenerConfig{}, err
+	}
+
+	kubeAPIServerConfig, err := model.KubeAPIServerConfigFromInput(input.KubeAPIServerConfig, config.KubeAPIServerConfig)
+	if err != nil {
+		return model.GardenerConfig{}, err
+	}
+
+	dedicatedSystemPool, err := model.SystemWorkerPoolFromInput(input.DedicatedSystemPool, input.SystemWorkerPool, config.DedicatedSystemPool, c.defaultSystemWorkerPool)
+	if err != nil {
+		return model.GardenerConfig{}, err
+	}
+
 	return model.GardenerConfig{
 		ID:                        config.ID,
 		ClusterID:                 config.ClusterID,
@@ -164,6 +381,7 @@ func (c converter) UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpg
 		Region:                    config.Region,
 		LicenceType:               config.LicenceType,
 		AllowPrivilegedContainers: config.AllowPrivilegedContainers,
+		NetworkingType:            config.NetworkingType,
 
 		Purpose:                             util.DefaultStrIfNil(input.Purpose, config.Purpose),
 		KubernetesVersion:                   util.UnwrapStrOrDefault(input.KubernetesVersion, config.KubernetesVersion),
@@ -174,15 +392,95 @@ func (c converter) UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpg
 		MachineImageVersion:                 util.DefaultStrIfNil(input.MachineImageVersion, config.MachineImageVersion),
 		AutoScalerMin:                       util.UnwrapIntOrDefault(input.AutoScalerMin, config.AutoScalerMin),
 		AutoScalerMax:                       util.UnwrapIntOrDefault(input.AutoScalerMax, config.AutoScalerMax),
-		MaxSurge:                            util.UnwrapIntOrDefault(input.MaxSurge, config.MaxSurge),
-		MaxUnavailable:                      util.UnwrapIntOrDefault(input.MaxUnavailable, config.MaxUnavailable),
+		MaxSurge:                            intOrStringFromInput(input.MaxSurge, config.MaxSurge),
+		MaxUnavailable:                      intOrStringFromInput(input.MaxUnavailable, config.MaxUnavailable),
 		EnableKubernetesVersionAutoUpdate:   util.UnwrapBoolOrDefault(input.EnableKubernetesVersionAutoUpdate, config.EnableKubernetesVersionAutoUpdate),
 		EnableMachineImageVersionAutoUpdate: util.UnwrapBoolOrDefault(input.EnableMachineImageVersionAutoUpdate, config.EnableMachineImageVersionAutoUpdate),
+		ShootAnnotations:                    shootAnnotationsFromInput(input.ShootAnnotations, config.ShootAnnotations),
+		CostAllocation:                      costAllocationFromInput(input.CostAllocation, config.CostAllocation),
+		ClusterAutoscalerConfig:             clusterAutoscalerConfig,
+		KubeAPIServerConfig:                 kubeAPIServerConfig,
+		DedicatedSystemPool:                 dedicatedSystemPool,
 		GardenerProviderConfig:              providerSpecificConfig,
 		OIDCConfig:                          oidcConfigFromInput(input.OidcConfig),
+		DNSConfig:                           dnsConfigFromInput(input.DNSConfig, config.DNSConfig),
 	}, nil
 }
 
+// gcpConfigWithDefaults sets the Shielded VM options missing in the GCP config to the defaults without modifying the input
+func gcpConfigWithDefaults(input *gqlschema.ProviderSpecificInput, defaults gqlschema.GCPProviderConfig) *gqlschema.ProviderSpecificInput {
+	if input == nil || input.GcpConfig == nil {
+		return input
+	}
+
+	gcpConfig := *input.GcpConfig
+	gcpConfig.EnableSecureBoot = util.DefaultBoolIfNil(gcpConfig.EnableSecureBoot, defaults.EnableSecureBoot)
+	gcpConfig.EnableIntegrityMonitoring = util.DefaultBoolIfNil(gcpConfig.EnableIntegrityMonitoring, defaults.EnableIntegrityMonitoring)
+	gcpConfig.EnableVtpm = util.DefaultBoolIfNil(gcpConfig.EnableVtpm, defaults.EnableVtpm)
+
+	withDefaults := *input
+	withDefaults.GcpConfig = &gcpConfig
+
+	return &withDefaults
+}
+
+func (c converter) defaultGCPProviderConfig() gqlschema.GCPProviderConfig {
+	return gqlschema.GCPProviderConfig{
+		EnableSecureBoot:          util.BoolPtr(c.defaultGCPShieldedInstanceConfig.EnableSecureBoot),
+		EnableIntegrityMonitoring: util.BoolPtr(c.defaultGCPShieldedInstanceConfig.EnableIntegrityMonitoring),
+		EnableVtpm:                util.BoolPtr(c.defaultGCPShieldedInstanceConfig.EnableVtpm),
+	}
+}
+
+// currentGCPProviderConfig is used to keep the Shielded VM options of the cluster which are not changed by the upgrade
+func currentGCPProviderConfig(providerConfig model.GardenerProviderConfig) gqlschema.GCPProviderConfig {
+	if providerConfig == nil {
+		return gqlschema.GCPProviderConfig{}
+	}
+
+	current, _ := providerConfig.AsProviderSpecificConfig().(gqlschema.GCPProviderConfig)
+	return current
+}
+
+// awsConfigWithDefaults sets the instance metadata options missing in the AWS config to the defaults without modifying the input
+func awsConfigWithDefaults(input *gqlschema.ProviderSpecificInput, defaults gqlschema.AWSProviderConfig) *gqlschema.ProviderSpecificInput {
+	if input == nil || input.AwsConfig == nil {
+		return input
+	}
+
+	awsConfig := *input.AwsConfig
+	awsConfig.HTTPTokens = util.DefaultStrIfNil(awsConfig.HTTPTokens, defaults.HTTPTokens)
+	awsConfig.HTTPPutResponseHopLimit = util.DefaultIntIfNil(awsConfig.HTTPPutResponseHopLimit, defaults.HTTPPutResponseHopLimit)
+
+	withDefaults := *input
+	withDefaults.AwsConfig = &awsConfig
+
+	return &withDefaults
+}
+
+// defaultAWSProviderConfig does not set the instance metadata options which have no default configured
+func (c converter) defaultAWSProviderConfig() gqlschema.AWSProviderConfig {
+	var defaults gqlschema.AWSProviderConfig
+	if c.defaultAWSInstanceMetadataOptions.HTTPTokens != "" {
+		defaults.HTTPTokens = util.StringPtr(c.defaultAWSInstanceMetadataOptions.HTTPTokens)
+	}
+	if c.defaultAWSInstanceMetadataOptions.HTTPPutResponseHopLimit != 0 {
+		defaults.HTTPPutResponseHopLimit = util.IntPtr(c.defaultAWSInstanceMetadataOptions.HTTPPutResponseHopLimit)
+	}
+
+	return defaults
+}
+
+// currentAWSProviderConfig is used to keep the instance metadata options of the cluster which are not changed by the upgrade
+func currentAWSProviderConfig(providerConfig model.GardenerProviderConfig) gqlschema.AWSProviderConfig {
+	if providerConfig == nil {
+		return gqlschema.AWSProviderConfig{}
+	}
+
+	current, _ := providerConfig.AsProviderSpecificConfig().(gqlschema.AWSProviderConfig)
+	return current
+}
+
 func (c converter) providerSpecificConfigFromInput(input *gqlschema.ProviderSpecificInput) (model.GardenerProviderConfig, apperrors.AppError) {
 	if input == nil {
 		return nil, apperrors.Internal("provider config not specified")
@@ -205,7 +503,17 @@ func (c converter) providerSpecificConfigFromInput(input *gqlschema.ProviderSpec
 }
 
 func (c converter) KymaConfigFromInput(runtimeID string, input gqlschema.KymaConfigInput) (model.KymaConfig, apperrors.AppError) {
-	kymaRelease, err := c.releaseProvider.GetReleaseByVersion(input.Version)
+	return c.kymaConfigFromInput(runtimeID, input, false)
+}
+
+// kymaConfigFromInput does not save the release downloaded on dry run
+func (c converter) kymaConfigFromInput(runtimeID string, input gqlschema.KymaConfigInput, dryRun bool) (model.KymaConfig, apperrors.AppError) {
+	getRelease := c.releaseProvider.GetReleaseByVersion
+	if dryRun {
+		getRelease = c.releaseProvider.LookupReleaseByVersion
+	}
+
+	kymaRelease, err := getRelease(input.Version)
 	if err != nil {
 		return model.KymaConfig{}, apperrors.Internal("failed to get Kyma Release with version %s: %s", input.Version, err.Error())
 	}
@@ -239,9 +547,10 @@ func (c converter) KymaConfigFromInput(runtimeID string, input gqlschema.KymaCon
 	}, nil
 }
 
+// graphQLProfileToProfile returns the default profile if the input does not contain it
 func (c converter) graphQLProfileToProfile(profile *gqlschema.KymaProfile) *model.KymaProfile {
 	if profile == nil {
-		return nil
+		return c.defaultKymaProfile
 	}
 
 	var result model.KymaProfile
@@ -2,8 +2,11 @@ package provisioning
 
 import (
 	"testing"
+	"time"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
 
 	realeaseMocks "github.com/kyma-project/control-plane/components/provisioner/internal/installation/release/mocks"
 
@@ -16,6 +19,8 @@ import (
 
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -30,6 +35,7 @@ const (
 	defaultEnableKubernetesVersionAutoUpdate   = false
 	defaultEnableMachineImageVersionAutoUpdate = false
 	forceAllowPrivilegedContainers             = false
+	defaultNetworkingType                      = model.CalicoNetworkingType
 )
 
 func Test_ProvisioningInputToCluster(t *testing.T) {
@@ -54,7 +60,7 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 		},
 		ClusterConfig: &gqlschema.ClusterConfigInput{
 			GardenerConfig: &gqlschema.GardenerConfigInput{
-				Name:                              "verylon",
+				Name:                              util.StringPtr("verylon"),
 				KubernetesVersion:                 "version",
 				VolumeSizeGb:                      util.IntPtr(1024),
 				MachineType:                       "n1-standard-1",
@@ -67,8 +73,8 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 				WorkerCidr:                        "cidr",
 				AutoScalerMin:                     1,
 				AutoScalerMax:                     5,
-				MaxSurge:                          1,
-				MaxUnavailable:                    2,
+				MaxSurge:                          gqlschema.NewIntOrString(intstr.FromInt(1)),
+				MaxUnavailable:                    gqlschema.NewIntOrString(intstr.FromInt(2)),
 				EnableKubernetesVersionAutoUpdate: util.BoolPtr(true),
 				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 					GcpConfig: gcpGardenerProvider,
@@ -80,7 +86,12 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 		KymaConfig: fixKymaGraphQLConfigInput(&gqlProductionProfile),
 	}
 
-	expectedGCPProviderCfg, err := model.NewGCPGardenerConfig(gcpGardenerProvider)
+	expectedGCPProviderCfg, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{
+		Zones:                     gcpGardenerProvider.Zones,
+		EnableSecureBoot:          util.BoolPtr(false),
+		EnableIntegrityMonitoring: util.BoolPtr(false),
+		EnableVtpm:                util.BoolPtr(false),
+	})
 	require.NoError(t, err)
 
 	expectedGardenerGCPRuntimeConfig := model.Cluster{
@@ -101,12 +112,14 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 			WorkerCidr:                          "cidr",
 			AutoScalerMin:                       1,
 			AutoScalerMax:                       5,
-			MaxSurge:                            1,
-			MaxUnavailable:                      2,
+			MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(1)),
+			MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(2)),
 			ClusterID:                           "runtimeID",
 			EnableKubernetesVersionAutoUpdate:   true,
 			EnableMachineImageVersionAutoUpdate: false,
 			AllowPrivilegedContainers:           true,
+			NetworkingType:                      model.CalicoNetworkingType,
+			CostAllocation:                      model.CostAllocation{GlobalAccountID: tenant, SubAccountID: subAccountId},
 			GardenerProviderConfig:              expectedGCPProviderCfg,
 			OIDCConfig:                          oidcConfig(),
 		},
@@ -126,7 +139,7 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 			},
 			ClusterConfig: &gqlschema.ClusterConfigInput{
 				GardenerConfig: &gqlschema.GardenerConfigInput{
-					Name:                              "verylon",
+					Name:                              util.StringPtr("verylon"),
 					KubernetesVersion:                 "version",
 					VolumeSizeGb:                      util.IntPtr(1024),
 					MachineType:                       "n1-standard-1",
@@ -140,8 +153,8 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 					WorkerCidr:                        "cidr",
 					AutoScalerMin:                     1,
 					AutoScalerMax:                     5,
-					MaxSurge:                          1,
-					MaxUnavailable:                    2,
+					MaxSurge:                          gqlschema.NewIntOrString(intstr.FromInt(1)),
+					MaxUnavailable:                    gqlschema.NewIntOrString(intstr.FromInt(2)),
 					EnableKubernetesVersionAutoUpdate: util.BoolPtr(true),
 					ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 						AzureConfig: &gqlschema.AzureProviderConfigInput{
@@ -182,12 +195,14 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 				WorkerCidr:                          "cidr",
 				AutoScalerMin:                       1,
 				AutoScalerMax:                       5,
-				MaxSurge:                            1,
-				MaxUnavailable:                      2,
+				MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(2)),
 				ClusterID:                           "runtimeID",
 				EnableKubernetesVersionAutoUpdate:   true,
 				EnableMachineImageVersionAutoUpdate: false,
 				AllowPrivilegedContainers:           true,
+				NetworkingType:                      model.CalicoNetworkingType,
+				CostAllocation:                      model.CostAllocation{GlobalAccountID: tenant, SubAccountID: subAccountId},
 				GardenerProviderConfig:              expectedAzureProviderCfg,
 				OIDCConfig:                          oidcConfig(),
 			},
@@ -227,7 +242,7 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 		},
 		ClusterConfig: &gqlschema.ClusterConfigInput{
 			GardenerConfig: &gqlschema.GardenerConfigInput{
-				Name:                              "verylon",
+				Name:                              util.StringPtr("verylon"),
 				KubernetesVersion:                 "version",
 				VolumeSizeGb:                      util.IntPtr(1024),
 				MachineType:                       "n1-standard-1",
@@ -240,8 +255,8 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 				WorkerCidr:                        "cidr",
 				AutoScalerMin:                     1,
 				AutoScalerMax:                     5,
-				MaxSurge:                          1,
-				MaxUnavailable:                    2,
+				MaxSurge:                          gqlschema.NewIntOrString(intstr.FromInt(1)),
+				MaxUnavailable:                    gqlschema.NewIntOrString(intstr.FromInt(2)),
 				EnableKubernetesVersionAutoUpdate: util.BoolPtr(true),
 				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 					AwsConfig: awsGardenerProvider,
@@ -274,12 +289,14 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 			WorkerCidr:                          "cidr",
 			AutoScalerMin:                       1,
 			AutoScalerMax:                       5,
-			MaxSurge:                            1,
-			MaxUnavailable:                      2,
+			MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(1)),
+			MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(2)),
 			ClusterID:                           "runtimeID",
 			EnableKubernetesVersionAutoUpdate:   true,
 			EnableMachineImageVersionAutoUpdate: false,
 			AllowPrivilegedContainers:           true,
+			NetworkingType:                      model.CalicoNetworkingType,
+			CostAllocation:                      model.CostAllocation{GlobalAccountID: tenant, SubAccountID: subAccountId},
 			GardenerProviderConfig:              expectedAWSProviderCfg,
 			OIDCConfig:                          oidcConfig(),
 		},
@@ -305,7 +322,7 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 		},
 		ClusterConfig: &gqlschema.ClusterConfigInput{
 			GardenerConfig: &gqlschema.GardenerConfigInput{
-				Name:                              "verylon",
+				Name:                              util.StringPtr("verylon"),
 				KubernetesVersion:                 "version",
 				MachineType:                       "large.1n",
 				Region:                            "region",
@@ -316,8 +333,8 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 				WorkerCidr:                        "cidr",
 				AutoScalerMin:                     1,
 				AutoScalerMax:                     5,
-				MaxSurge:                          1,
-				MaxUnavailable:                    2,
+				MaxSurge:                          gqlschema.NewIntOrString(intstr.FromInt(1)),
+				MaxUnavailable:                    gqlschema.NewIntOrString(intstr.FromInt(2)),
 				EnableKubernetesVersionAutoUpdate: util.BoolPtr(true),
 				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 					OpenStackConfig: openstackGardenerProvider,
@@ -348,12 +365,14 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 			WorkerCidr:                          "cidr",
 			AutoScalerMin:                       1,
 			AutoScalerMax:                       5,
-			MaxSurge:                            1,
-			MaxUnavailable:                      2,
+			MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(1)),
+			MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(2)),
 			ClusterID:                           "runtimeID",
 			EnableKubernetesVersionAutoUpdate:   true,
 			EnableMachineImageVersionAutoUpdate: false,
 			AllowPrivilegedContainers:           true,
+			NetworkingType:                      model.CalicoNetworkingType,
+			CostAllocation:                      model.CostAllocation{GlobalAccountID: tenant, SubAccountID: subAccountId},
 			GardenerProviderConfig:              expectedOpenStackProviderCfg,
 			OIDCConfig:                          oidcConfig(),
 		},
@@ -421,7 +440,12 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 				gardenerProject,
 				defaultEnableKubernetesVersionAutoUpdate,
 				defaultEnableMachineImageVersionAutoUpdate,
-				forceAllowPrivilegedContainers)
+				forceAllowPrivilegedContainers,
+				defaultNetworkingType,
+				model.GCPShieldedInstanceConfig{},
+				model.AWSInstanceMetadataOptions{},
+				model.SystemWorkerPool{},
+				nil)
 
 			//when
 			runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", testCase.input, tenant, subAccountId)
@@ -433,6 +457,94 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 		})
 	}
 
+	t.Run("Should create the same runtime config when IDs are generated from the sequence", func(t *testing.T) {
+		// given
+		newInputConverter := func() InputConverter {
+			return NewInputConverter(
+				testkit.NewUUIDGenerator(),
+				releaseProvider,
+				gardenerProject,
+				defaultEnableKubernetesVersionAutoUpdate,
+				defaultEnableMachineImageVersionAutoUpdate,
+				forceAllowPrivilegedContainers,
+				defaultNetworkingType,
+				model.GCPShieldedInstanceConfig{},
+				model.AWSInstanceMetadataOptions{},
+				model.SystemWorkerPool{},
+				nil)
+		}
+
+		// when
+		first, err := newInputConverter().ProvisioningInputToCluster("runtimeID", gardenerGCPGQLInput, tenant, subAccountId)
+		require.NoError(t, err)
+		second, err := newInputConverter().ProvisioningInputToCluster("runtimeID", gardenerGCPGQLInput, tenant, subAccountId)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, first, second)
+		assert.NotEqual(t, first.ClusterConfig.ID, first.KymaConfig.ID)
+	})
+
+	t.Run("Should look up Kyma release without saving it on dry run", func(t *testing.T) {
+		// given
+		dryRunReleaseProvider := &realeaseMocks.Provider{}
+		dryRunReleaseProvider.On("LookupReleaseByVersion", kymaVersion).Return(fixKymaRelease(), nil)
+
+		inputConverter := NewInputConverter(
+			testkit.NewUUIDGenerator(),
+			dryRunReleaseProvider,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
+
+		// when
+		cluster, err := inputConverter.ProvisioningInputToClusterDryRun("runtimeID", gardenerGCPGQLInput, tenant, subAccountId)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, cluster.KymaConfig)
+		assert.Equal(t, fixKymaRelease(), cluster.KymaConfig.Release)
+		dryRunReleaseProvider.AssertExpectations(t)
+		dryRunReleaseProvider.AssertNotCalled(t, "GetReleaseByVersion", kymaVersion)
+	})
+
+	t.Run("Should create runtime config struct without Kyma config if Kyma is managed externally", func(t *testing.T) {
+		// given
+		gardenerAzureGQLInput := createGQLRuntimeInputAzure(nil)
+		gardenerAzureGQLInput.KymaConfig = nil
+
+		uuidGeneratorMock := &mocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id")
+
+		inputConverter := NewInputConverter(
+			uuidGeneratorMock,
+			releaseProvider,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
+
+		// when
+		runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", gardenerAzureGQLInput, tenant, subAccountId)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, runtimeConfig.KymaConfig)
+		assert.False(t, runtimeConfig.ClusterConfig.AllowPrivilegedContainers)
+		assert.Equal(t, "Azure", runtimeConfig.ClusterConfig.Provider)
+	})
+
 	t.Run("Should use force allow privileged containers if equals true even if everything else says false", func(t *testing.T) {
 		// given
 		gardenerAzureGQLInput := createGQLRuntimeInputAzure(nil)
@@ -455,7 +567,12 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 			gardenerProject,
 			defaultEnableKubernetesVersionAutoUpdate,
 			defaultEnableMachineImageVersionAutoUpdate,
-			forceAllowPrivilegedContainers)
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
 
 		// when
 		runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", gardenerAzureGQLInput, tenant, subAccountId)
@@ -465,6 +582,202 @@ func Test_ProvisioningInputToCluster(t *testing.T) {
 		assert.Equal(t, expectedGardenerAzureRuntimeConfig, runtimeConfig)
 		uuidGeneratorMock.AssertExpectations(t)
 	})
+
+	t.Run("Should use networking type from input instead of the default one", func(t *testing.T) {
+		// given
+		cilium := gqlschema.NetworkingTypeCilium
+		gardenerGCPGQLInputWithCilium := gardenerGCPGQLInput
+		gardenerConfigInput := *gardenerGCPGQLInput.ClusterConfig.GardenerConfig
+		gardenerConfigInput.NetworkingType = &cilium
+		gardenerGCPGQLInputWithCilium.ClusterConfig = &gqlschema.ClusterConfigInput{
+			GardenerConfig: &gardenerConfigInput,
+			Administrators: gardenerGCPGQLInput.ClusterConfig.Administrators,
+		}
+
+		uuidGeneratorMock := &mocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id").Times(6)
+		uuidGeneratorMock.On("New").Return("very-Long-ID-That-Has-More-Than-Fourteen-Characters-And-Even-Some-Hyphens")
+
+		inputConverter := NewInputConverter(
+			uuidGeneratorMock,
+			releaseProvider,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
+
+		// when
+		runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", gardenerGCPGQLInputWithCilium, tenant, subAccountId)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, model.CiliumNetworkingType, runtimeConfig.ClusterConfig.NetworkingType)
+	})
+
+	t.Run("Should use Gardener project from input instead of the default one", func(t *testing.T) {
+		// given
+		gardenerGCPGQLInputWithProject := gardenerGCPGQLInput
+		gardenerConfigInput := *gardenerGCPGQLInput.ClusterConfig.GardenerConfig
+		gardenerConfigInput.GardenerProject = util.StringPtr("trial-project")
+		gardenerGCPGQLInputWithProject.ClusterConfig = &gqlschema.ClusterConfigInput{
+			GardenerConfig: &gardenerConfigInput,
+			Administrators: gardenerGCPGQLInput.ClusterConfig.Administrators,
+		}
+
+		uuidGeneratorMock := &mocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id").Times(6)
+		uuidGeneratorMock.On("New").Return("very-Long-ID-That-Has-More-Than-Fourteen-Characters-And-Even-Some-Hyphens")
+
+		inputConverter := NewInputConverter(
+			uuidGeneratorMock,
+			releaseProvider,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
+
+		// when
+		runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", gardenerGCPGQLInputWithProject, tenant, subAccountId)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "trial-project", runtimeConfig.ClusterConfig.ProjectName)
+	})
+
+	t.Run("Should use default Shielded VM options missing in GCP input", func(t *testing.T) {
+		// given
+		gcpConfigInput := &gqlschema.GCPProviderConfigInput{Zones: []string{"fix-gcp-zone-1"}, EnableVtpm: util.BoolPtr(false)}
+		gardenerConfigInput := *gardenerGCPGQLInput.ClusterConfig.GardenerConfig
+		gardenerConfigInput.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{GcpConfig: gcpConfigInput}
+		gardenerGCPGQLInputWithShieldedVM := gardenerGCPGQLInput
+		gardenerGCPGQLInputWithShieldedVM.ClusterConfig = &gqlschema.ClusterConfigInput{
+			GardenerConfig: &gardenerConfigInput,
+			Administrators: gardenerGCPGQLInput.ClusterConfig.Administrators,
+		}
+
+		uuidGeneratorMock := &mocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id")
+
+		inputConverter := NewInputConverter(
+			uuidGeneratorMock,
+			releaseProvider,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{EnableSecureBoot: true, EnableIntegrityMonitoring: true, EnableVtpm: true},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
+
+		// when
+		runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", gardenerGCPGQLInputWithShieldedVM, tenant, subAccountId)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, gqlschema.GCPProviderConfig{
+			Zones:                     []string{"fix-gcp-zone-1"},
+			EnableSecureBoot:          util.BoolPtr(true),
+			EnableIntegrityMonitoring: util.BoolPtr(true),
+			EnableVtpm:                util.BoolPtr(false),
+		}, runtimeConfig.ClusterConfig.GardenerProviderConfig.AsProviderSpecificConfig())
+		assert.Nil(t, gcpConfigInput.EnableSecureBoot)
+	})
+
+	t.Run("Should set default instance metadata options for AWS", func(t *testing.T) {
+		// given
+		awsConfigInput := *awsGardenerProvider
+		awsConfigInput.HTTPPutResponseHopLimit = util.IntPtr(3)
+		gardenerConfigInput := *gardenerAWSGQLInput.ClusterConfig.GardenerConfig
+		gardenerConfigInput.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{AwsConfig: &awsConfigInput}
+		gardenerAWSGQLInputWithMetadataOptions := gardenerAWSGQLInput
+		gardenerAWSGQLInputWithMetadataOptions.ClusterConfig = &gqlschema.ClusterConfigInput{
+			GardenerConfig: &gardenerConfigInput,
+			Administrators: gardenerAWSGQLInput.ClusterConfig.Administrators,
+		}
+
+		uuidGeneratorMock := &mocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id")
+
+		inputConverter := NewInputConverter(
+			uuidGeneratorMock,
+			releaseProvider,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{HTTPTokens: model.AWSHTTPTokensRequired, HTTPPutResponseHopLimit: 2},
+			model.SystemWorkerPool{},
+			nil)
+
+		// when
+		runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", gardenerAWSGQLInputWithMetadataOptions, tenant, subAccountId)
+
+		// then
+		require.NoError(t, err)
+		awsConfig, ok := runtimeConfig.ClusterConfig.GardenerProviderConfig.AsProviderSpecificConfig().(gqlschema.AWSProviderConfig)
+		require.True(t, ok)
+		assert.Equal(t, util.StringPtr(model.AWSHTTPTokensRequired), awsConfig.HTTPTokens)
+		assert.Equal(t, util.IntPtr(3), awsConfig.HTTPPutResponseHopLimit)
+		assert.Nil(t, awsConfigInput.HTTPTokens)
+	})
+
+	t.Run("Should convert custom DNS config", func(t *testing.T) {
+		// given
+		gardenerConfigInput := *gardenerGCPGQLInput.ClusterConfig.GardenerConfig
+		gardenerConfigInput.DNSConfig = &gqlschema.DNSConfigInput{
+			Domain: "runtime.customer.example.com",
+			Providers: []*gqlschema.DNSProviderInput{
+				{Type: "aws-route53", SecretName: "route53-credentials", Domains: []string{"customer.example.com"}},
+			},
+		}
+		gardenerGCPGQLInputWithDNS := gardenerGCPGQLInput
+		gardenerGCPGQLInputWithDNS.ClusterConfig = &gqlschema.ClusterConfigInput{
+			GardenerConfig: &gardenerConfigInput,
+			Administrators: gardenerGCPGQLInput.ClusterConfig.Administrators,
+		}
+
+		uuidGeneratorMock := &mocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id")
+
+		inputConverter := NewInputConverter(
+			uuidGeneratorMock,
+			releaseProvider,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
+
+		// when
+		runtimeConfig, err := inputConverter.ProvisioningInputToCluster("runtimeID", gardenerGCPGQLInputWithDNS, tenant, subAccountId)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &model.DNSConfig{
+			Domain: "runtime.customer.example.com",
+			Providers: []*model.DNSProvider{
+				{Type: "aws-route53", SecretName: "route53-credentials", Domains: []string{"customer.example.com"}},
+			},
+		}, runtimeConfig.ClusterConfig.DNSConfig)
+	})
 }
 
 func oidcInput() *gqlschema.OIDCConfigInput {
@@ -535,7 +848,12 @@ func TestConverter_ParseInput(t *testing.T) {
 			gardenerProject,
 			defaultEnableKubernetesVersionAutoUpdate,
 			defaultEnableMachineImageVersionAutoUpdate,
-			forceAllowPrivilegedContainers)
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
 
 		// when
 		output, err := inputConverter.KymaConfigFromInput("runtimeID", input)
@@ -549,6 +867,63 @@ func TestConverter_ParseInput(t *testing.T) {
 	})
 }
 
+func TestConverter_KymaConfigFromInput_Profile(t *testing.T) {
+	evaluationProfile := model.EvaluationProfile
+	productionProfile := model.ProductionProfile
+	gqlProductionProfile := gqlschema.KymaProfileProduction
+
+	for _, testCase := range []struct {
+		description     string
+		defaultProfile  *model.KymaProfile
+		inputProfile    *gqlschema.KymaProfile
+		expectedProfile *model.KymaProfile
+	}{
+		{
+			description:     "should use default profile if input does not contain it",
+			defaultProfile:  &evaluationProfile,
+			expectedProfile: &evaluationProfile,
+		},
+		{
+			description:     "should use profile from input instead of default one",
+			defaultProfile:  &evaluationProfile,
+			inputProfile:    &gqlProductionProfile,
+			expectedProfile: &productionProfile,
+		},
+		{
+			description: "should leave profile empty if there is no default one",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			uuidGeneratorMock := &mocks.UUIDGenerator{}
+			uuidGeneratorMock.On("New").Return("id")
+
+			releaseProvider := &realeaseMocks.Provider{}
+			releaseProvider.On("GetReleaseByVersion", kymaVersion).Return(fixKymaRelease(), nil)
+
+			inputConverter := NewInputConverter(
+				uuidGeneratorMock,
+				releaseProvider,
+				gardenerProject,
+				defaultEnableKubernetesVersionAutoUpdate,
+				defaultEnableMachineImageVersionAutoUpdate,
+				forceAllowPrivilegedContainers,
+				defaultNetworkingType,
+				model.GCPShieldedInstanceConfig{},
+				model.AWSInstanceMetadataOptions{},
+				model.SystemWorkerPool{},
+				testCase.defaultProfile)
+
+			// when
+			output, err := inputConverter.KymaConfigFromInput("runtimeID", *fixKymaGraphQLConfigInput(testCase.inputProfile))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedProfile, output.Profile)
+		})
+	}
+}
+
 func TestConverter_ProvisioningInputToCluster_Error(t *testing.T) {
 
 	t.Run("should return error when failed to get kyma release", func(t *testing.T) {
@@ -573,7 +948,12 @@ func TestConverter_ProvisioningInputToCluster_Error(t *testing.T) {
 			gardenerProject,
 			defaultEnableKubernetesVersionAutoUpdate,
 			defaultEnableMachineImageVersionAutoUpdate,
-			forceAllowPrivilegedContainers)
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
 
 		//when
 		_, err := inputConverter.ProvisioningInputToCluster("runtimeID", input, tenant, subAccountId)
@@ -593,7 +973,12 @@ func TestConverter_ProvisioningInputToCluster_Error(t *testing.T) {
 			gardenerProject,
 			defaultEnableKubernetesVersionAutoUpdate,
 			defaultEnableMachineImageVersionAutoUpdate,
-			forceAllowPrivilegedContainers)
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
 
 		//when
 		_, err := inputConverter.ProvisioningInputToCluster("runtimeID", input, tenant, subAccountId)
@@ -618,7 +1003,12 @@ func TestConverter_ProvisioningInputToCluster_Error(t *testing.T) {
 			gardenerProject,
 			defaultEnableKubernetesVersionAutoUpdate,
 			defaultEnableMachineImageVersionAutoUpdate,
-			forceAllowPrivilegedContainers)
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
 
 		//when
 		_, err := inputConverter.ProvisioningInputToCluster("runtimeID", input, tenant, subAccountId)
@@ -646,7 +1036,12 @@ func TestConverter_ProvisioningInputToCluster_Error(t *testing.T) {
 			gardenerProject,
 			defaultEnableKubernetesVersionAutoUpdate,
 			defaultEnableMachineImageVersionAutoUpdate,
-			forceAllowPrivilegedContainers)
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
 
 		//when
 		_, err := inputConverter.ProvisioningInputToCluster("runtimeID", input, tenant, subAccountId)
@@ -658,6 +1053,78 @@ func TestConverter_ProvisioningInputToCluster_Error(t *testing.T) {
 
 }
 
+func TestConverter_ProvisioningInputToCluster_CostAllocation(t *testing.T) {
+	newInput := func(costAllocation *gqlschema.CostAllocationInput) gqlschema.ProvisionRuntimeInput {
+		return gqlschema.ProvisionRuntimeInput{
+			ClusterConfig: &gqlschema.ClusterConfigInput{
+				GardenerConfig: &gqlschema.GardenerConfigInput{
+					ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+						GcpConfig: &gqlschema.GCPProviderConfigInput{Zones: []string{"fix-gcp-zone-1"}},
+					},
+					CostAllocation: costAllocation,
+				},
+			},
+		}
+	}
+
+	newInputConverter := func() InputConverter {
+		uuidGeneratorMock := &mocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id")
+
+		return NewInputConverter(
+			uuidGeneratorMock,
+			nil,
+			gardenerProject,
+			defaultEnableKubernetesVersionAutoUpdate,
+			defaultEnableMachineImageVersionAutoUpdate,
+			forceAllowPrivilegedContainers,
+			defaultNetworkingType,
+			model.GCPShieldedInstanceConfig{},
+			model.AWSInstanceMetadataOptions{},
+			model.SystemWorkerPool{},
+			nil)
+	}
+
+	t.Run("should use tenant and sub-account if not provided in the input", func(t *testing.T) {
+		// given
+		input := newInput(&gqlschema.CostAllocationInput{InstanceID: util.StringPtr("instance-id")})
+
+		//when
+		cluster, err := newInputConverter().ProvisioningInputToCluster("runtimeID", input, tenant, subAccountId)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, model.CostAllocation{GlobalAccountID: tenant, SubAccountID: subAccountId, InstanceID: "instance-id"}, cluster.ClusterConfig.CostAllocation)
+	})
+
+	t.Run("should use identifiers provided in the input", func(t *testing.T) {
+		// given
+		input := newInput(&gqlschema.CostAllocationInput{
+			GlobalAccountID: util.StringPtr("global-account"),
+			SubAccountID:    util.StringPtr("other-sub-account"),
+		})
+
+		//when
+		cluster, err := newInputConverter().ProvisioningInputToCluster("runtimeID", input, tenant, subAccountId)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, model.CostAllocation{GlobalAccountID: "global-account", SubAccountID: "other-sub-account"}, cluster.ClusterConfig.CostAllocation)
+	})
+
+	t.Run("should return error when sub-account cannot be used as the label value", func(t *testing.T) {
+		// given
+		input := newInput(nil)
+
+		//when
+		_, err := newInputConverter().ProvisioningInputToCluster("runtimeID", input, tenant, "sub account")
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), model.SubAccountIDLabel)
+	})
+}
+
 func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 	evaluationPurpose := "evaluation"
 	testingPurpose := "testing"
@@ -687,21 +1154,246 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				Purpose:                &evaluationPurpose,
 				AutoScalerMin:          1,
 				AutoScalerMax:          2,
-				MaxSurge:               1,
-				MaxUnavailable:         1,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				GardenerProviderConfig: initialGCPProviderConfig,
+				OIDCConfig:             oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion:      "1.16",
+				VolumeSizeGB:           util.IntPtr(50),
+				DiskType:               util.StringPtr("papyrus"),
+				MachineType:            "new-machine",
+				Purpose:                &testingPurpose,
+				AutoScalerMin:          2,
+				AutoScalerMax:          6,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				GardenerProviderConfig: upgradedGCPProviderConfig,
+				OIDCConfig:             upgradedOidcConfig(),
+			},
+		},
+		{description: "GCP shoot upgrade with unchanged networking type",
+			upgradeInput: newGCPUpgradeShootInputWithNetworkingType(testingPurpose, gqlschema.NetworkingTypeCilium),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				VolumeSizeGB:           util.IntPtr(1),
+				DiskType:               util.StringPtr("ssd"),
+				MachineType:            "1",
+				Purpose:                &evaluationPurpose,
+				AutoScalerMin:          1,
+				AutoScalerMax:          2,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				NetworkingType:         model.CiliumNetworkingType,
+				GardenerProviderConfig: initialGCPProviderConfig,
+				OIDCConfig:             oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion:      "1.16",
+				VolumeSizeGB:           util.IntPtr(50),
+				DiskType:               util.StringPtr("papyrus"),
+				MachineType:            "new-machine",
+				Purpose:                &testingPurpose,
+				AutoScalerMin:          2,
+				AutoScalerMax:          6,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				NetworkingType:         model.CiliumNetworkingType,
+				GardenerProviderConfig: upgradedGCPProviderConfig,
+				OIDCConfig:             upgradedOidcConfig(),
+			},
+		},
+		{description: "GCP shoot upgrade keeping Shoot annotations",
+			upgradeInput: newGCPUpgradeShootInput(testingPurpose),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				VolumeSizeGB:           util.IntPtr(1),
+				DiskType:               util.StringPtr("ssd"),
+				MachineType:            "1",
+				Purpose:                &evaluationPurpose,
+				AutoScalerMin:          1,
+				AutoScalerMax:          2,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				ShootAnnotations:       map[string]string{"dns.gardener.cloud/zone": "internal"},
+				GardenerProviderConfig: initialGCPProviderConfig,
+				OIDCConfig:             oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion:      "1.16",
+				VolumeSizeGB:           util.IntPtr(50),
+				DiskType:               util.StringPtr("papyrus"),
+				MachineType:            "new-machine",
+				Purpose:                &testingPurpose,
+				AutoScalerMin:          2,
+				AutoScalerMax:          6,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				ShootAnnotations:       map[string]string{"dns.gardener.cloud/zone": "internal"},
+				GardenerProviderConfig: upgradedGCPProviderConfig,
+				OIDCConfig:             upgradedOidcConfig(),
+			},
+		},
+		{description: "GCP shoot upgrade replacing Shoot annotations",
+			upgradeInput: newGCPUpgradeShootInputWithShootAnnotations(testingPurpose, gqlschema.Annotations{"dns.gardener.cloud/class": "garden"}),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				VolumeSizeGB:           util.IntPtr(1),
+				DiskType:               util.StringPtr("ssd"),
+				MachineType:            "1",
+				Purpose:                &evaluationPurpose,
+				AutoScalerMin:          1,
+				AutoScalerMax:          2,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				ShootAnnotations:       map[string]string{"dns.gardener.cloud/zone": "internal"},
+				GardenerProviderConfig: initialGCPProviderConfig,
+				OIDCConfig:             oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion:      "1.16",
+				VolumeSizeGB:           util.IntPtr(50),
+				DiskType:               util.StringPtr("papyrus"),
+				MachineType:            "new-machine",
+				Purpose:                &testingPurpose,
+				AutoScalerMin:          2,
+				AutoScalerMax:          6,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				ShootAnnotations:       map[string]string{"dns.gardener.cloud/class": "garden"},
+				GardenerProviderConfig: upgradedGCPProviderConfig,
+				OIDCConfig:             upgradedOidcConfig(),
+			},
+		},
+		{description: "GCP shoot upgrade setting KEB instance ID",
+			upgradeInput: newGCPUpgradeShootInputWithCostAllocation(testingPurpose, gqlschema.CostAllocationInput{InstanceID: util.StringPtr("instance-id")}),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				VolumeSizeGB:           util.IntPtr(1),
+				DiskType:               util.StringPtr("ssd"),
+				MachineType:            "1",
+				Purpose:                &evaluationPurpose,
+				AutoScalerMin:          1,
+				AutoScalerMax:          2,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				CostAllocation:         model.CostAllocation{GlobalAccountID: tenant, SubAccountID: subAccountId},
+				GardenerProviderConfig: initialGCPProviderConfig,
+				OIDCConfig:             oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion:      "1.16",
+				VolumeSizeGB:           util.IntPtr(50),
+				DiskType:               util.StringPtr("papyrus"),
+				MachineType:            "new-machine",
+				Purpose:                &testingPurpose,
+				AutoScalerMin:          2,
+				AutoScalerMax:          6,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				CostAllocation:         model.CostAllocation{GlobalAccountID: tenant, SubAccountID: subAccountId, InstanceID: "instance-id"},
+				GardenerProviderConfig: upgradedGCPProviderConfig,
+				OIDCConfig:             upgradedOidcConfig(),
+			},
+		},
+		{description: "GCP shoot upgrade changing cluster autoscaler settings",
+			upgradeInput: newGCPUpgradeShootInputWithClusterAutoscalerConfig(testingPurpose, gqlschema.ClusterAutoscalerConfigInput{ScaleDownUnneededTime: util.StringPtr("1h")}),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:       "version",
+				VolumeSizeGB:            util.IntPtr(1),
+				DiskType:                util.StringPtr("ssd"),
+				MachineType:             "1",
+				Purpose:                 &evaluationPurpose,
+				AutoScalerMin:           1,
+				AutoScalerMax:           2,
+				MaxSurge:                util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:          util.IntOrStringPtr(intstr.FromInt(1)),
+				ClusterAutoscalerConfig: &model.ClusterAutoscalerConfig{MaxNodeProvisionTime: &v1.Duration{Duration: 20 * time.Minute}},
+				GardenerProviderConfig:  initialGCPProviderConfig,
+				OIDCConfig:              oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion: "1.16",
+				VolumeSizeGB:      util.IntPtr(50),
+				DiskType:          util.StringPtr("papyrus"),
+				MachineType:       "new-machine",
+				Purpose:           &testingPurpose,
+				AutoScalerMin:     2,
+				AutoScalerMax:     6,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
+				ClusterAutoscalerConfig: &model.ClusterAutoscalerConfig{
+					ScaleDownUnneededTime: &v1.Duration{Duration: time.Hour},
+					MaxNodeProvisionTime:  &v1.Duration{Duration: 20 * time.Minute},
+				},
+				GardenerProviderConfig: upgradedGCPProviderConfig,
+				OIDCConfig:             upgradedOidcConfig(),
+			},
+		},
+		{description: "GCP shoot upgrade changing kube-apiserver settings",
+			upgradeInput: newGCPUpgradeShootInputWithKubeAPIServerConfig(testingPurpose, gqlschema.KubeAPIServerConfigInput{FeatureGates: &gqlschema.Switches{"TTLAfterFinished": true}}),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion: "version",
+				VolumeSizeGB:      util.IntPtr(1),
+				DiskType:          util.StringPtr("ssd"),
+				MachineType:       "1",
+				Purpose:           &evaluationPurpose,
+				AutoScalerMin:     1,
+				AutoScalerMax:     2,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
+				KubeAPIServerConfig: &model.KubeAPIServerConfig{
+					FeatureGates:        map[string]bool{"EphemeralContainers": true},
+					MaxMutatingInflight: util.IntPtr(400),
+				},
+				GardenerProviderConfig: initialGCPProviderConfig,
+				OIDCConfig:             oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion: "1.16",
+				VolumeSizeGB:      util.IntPtr(50),
+				DiskType:          util.StringPtr("papyrus"),
+				MachineType:       "new-machine",
+				Purpose:           &testingPurpose,
+				AutoScalerMin:     2,
+				AutoScalerMax:     6,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
+				KubeAPIServerConfig: &model.KubeAPIServerConfig{
+					FeatureGates:        map[string]bool{"TTLAfterFinished": true},
+					MaxMutatingInflight: util.IntPtr(400),
+				},
+				GardenerProviderConfig: upgradedGCPProviderConfig,
+				OIDCConfig:             upgradedOidcConfig(),
+			},
+		},
+		{description: "GCP shoot upgrade adding dedicated system pool",
+			upgradeInput: newGCPUpgradeShootInputWithDedicatedSystemPool(testingPurpose, util.BoolPtr(true), &gqlschema.SystemWorkerPoolInput{Size: util.IntPtr(3)}),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				VolumeSizeGB:           util.IntPtr(1),
+				DiskType:               util.StringPtr("ssd"),
+				MachineType:            "1",
+				Purpose:                &evaluationPurpose,
+				AutoScalerMin:          1,
+				AutoScalerMax:          2,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
 				GardenerProviderConfig: initialGCPProviderConfig,
 				OIDCConfig:             oidcConfig(),
 			},
 			upgradedConfig: model.GardenerConfig{
-				KubernetesVersion:      "version2",
+				KubernetesVersion:      "1.16",
 				VolumeSizeGB:           util.IntPtr(50),
 				DiskType:               util.StringPtr("papyrus"),
 				MachineType:            "new-machine",
 				Purpose:                &testingPurpose,
 				AutoScalerMin:          2,
 				AutoScalerMax:          6,
-				MaxSurge:               2,
-				MaxUnavailable:         1,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				DedicatedSystemPool:    &model.SystemWorkerPool{Size: 3},
 				GardenerProviderConfig: upgradedGCPProviderConfig,
 				OIDCConfig:             upgradedOidcConfig(),
 			},
@@ -716,21 +1408,21 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				Purpose:                &evaluationPurpose,
 				AutoScalerMin:          1,
 				AutoScalerMax:          2,
-				MaxSurge:               1,
-				MaxUnavailable:         1,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
 				GardenerProviderConfig: initialAzureProviderConfig,
 				OIDCConfig:             oidcConfig(),
 			},
 			upgradedConfig: model.GardenerConfig{
-				KubernetesVersion:      "version2",
+				KubernetesVersion:      "1.16",
 				VolumeSizeGB:           util.IntPtr(50),
 				DiskType:               util.StringPtr("papyrus"),
 				MachineType:            "new-machine",
 				Purpose:                &testingPurpose,
 				AutoScalerMin:          2,
 				AutoScalerMax:          6,
-				MaxSurge:               2,
-				MaxUnavailable:         1,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
 				GardenerProviderConfig: upgradedAzureProviderConfig,
 				OIDCConfig:             upgradedOidcConfig(),
 			},
@@ -745,20 +1437,20 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				Purpose:           &evaluationPurpose,
 				AutoScalerMin:     1,
 				AutoScalerMax:     2,
-				MaxSurge:          1,
-				MaxUnavailable:    1,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
 				OIDCConfig:        oidcConfig(),
 			},
 			upgradedConfig: model.GardenerConfig{
-				KubernetesVersion: "version2",
+				KubernetesVersion: "1.16",
 				VolumeSizeGB:      util.IntPtr(50),
 				DiskType:          util.StringPtr("papyrus"),
 				MachineType:       "new-machine",
 				Purpose:           &testingPurpose,
 				AutoScalerMin:     2,
 				AutoScalerMax:     6,
-				MaxSurge:          2,
-				MaxUnavailable:    1,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
 				OIDCConfig:        upgradedOidcConfig(),
 			},
 		},
@@ -770,18 +1462,18 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				Purpose:           &evaluationPurpose,
 				AutoScalerMin:     1,
 				AutoScalerMax:     2,
-				MaxSurge:          1,
-				MaxUnavailable:    1,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
 				OIDCConfig:        oidcConfig(),
 			},
 			upgradedConfig: model.GardenerConfig{
-				KubernetesVersion: "version2",
+				KubernetesVersion: "1.16",
 				MachineType:       "new-machine",
 				Purpose:           &testingPurpose,
 				AutoScalerMin:     2,
 				AutoScalerMax:     6,
-				MaxSurge:          2,
-				MaxUnavailable:    1,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(2)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
 				OIDCConfig:        upgradedOidcConfig(),
 			},
 		},
@@ -795,8 +1487,8 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				Purpose:           &evaluationPurpose,
 				AutoScalerMin:     1,
 				AutoScalerMax:     2,
-				MaxSurge:          1,
-				MaxUnavailable:    1,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
 				OIDCConfig:        oidcConfig(),
 			},
 			upgradedConfig: model.GardenerConfig{
@@ -807,11 +1499,99 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				Purpose:           &evaluationPurpose,
 				AutoScalerMin:     1,
 				AutoScalerMax:     2,
-				MaxSurge:          1,
-				MaxUnavailable:    1,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromInt(1)),
 				OIDCConfig:        upgradedOidcConfig(),
 			},
 		},
+		{description: "shoot upgrade of max surge given as percentage",
+			upgradeInput: gqlschema.UpgradeShootInput{
+				GardenerConfig: &gqlschema.GardenerUpgradeInput{
+					MaxSurge:   gqlschema.NewIntOrString(intstr.FromString("25%")),
+					OidcConfig: upgradedOidcInput(),
+				},
+			},
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion: "version",
+				MachineType:       "1",
+				AutoScalerMin:     1,
+				AutoScalerMax:     2,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromString("10%")),
+				OIDCConfig:        oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion: "version",
+				MachineType:       "1",
+				AutoScalerMin:     1,
+				AutoScalerMax:     2,
+				MaxSurge:          util.IntOrStringPtr(intstr.FromString("25%")),
+				MaxUnavailable:    util.IntOrStringPtr(intstr.FromString("10%")),
+				OIDCConfig:        upgradedOidcConfig(),
+			},
+		},
+		{description: "shoot upgrade of DNS providers",
+			upgradeInput: gqlschema.UpgradeShootInput{
+				GardenerConfig: &gqlschema.GardenerUpgradeInput{
+					DNSConfig: &gqlschema.DNSConfigInput{
+						Domain:    "runtime.customer.example.com",
+						Providers: []*gqlschema.DNSProviderInput{{Type: "aws-route53", SecretName: "rotated-route53-credentials"}},
+					},
+					OidcConfig: upgradedOidcInput(),
+				},
+			},
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion: "version",
+				MachineType:       "1",
+				DNSConfig: &model.DNSConfig{
+					Domain:    "runtime.customer.example.com",
+					Providers: []*model.DNSProvider{{Type: "aws-route53", SecretName: "route53-credentials"}},
+				},
+				OIDCConfig: oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion: "version",
+				MachineType:       "1",
+				DNSConfig: &model.DNSConfig{
+					Domain:    "runtime.customer.example.com",
+					Providers: []*model.DNSProvider{{Type: "aws-route53", SecretName: "rotated-route53-credentials"}},
+				},
+				OIDCConfig: upgradedOidcConfig(),
+			},
+		},
+		{description: "shoot upgrade of auto update settings",
+			upgradeInput: gqlschema.UpgradeShootInput{
+				GardenerConfig: &gqlschema.GardenerUpgradeInput{
+					EnableKubernetesVersionAutoUpdate:   util.BoolPtr(true),
+					EnableMachineImageVersionAutoUpdate: util.BoolPtr(false),
+					OidcConfig:                          upgradedOidcInput(),
+				},
+			},
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:                   "version",
+				MachineType:                         "1",
+				Purpose:                             &evaluationPurpose,
+				AutoScalerMin:                       1,
+				AutoScalerMax:                       2,
+				MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(1)),
+				EnableKubernetesVersionAutoUpdate:   false,
+				EnableMachineImageVersionAutoUpdate: true,
+				OIDCConfig:                          oidcConfig(),
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion:                   "version",
+				MachineType:                         "1",
+				Purpose:                             &evaluationPurpose,
+				AutoScalerMin:                       1,
+				AutoScalerMax:                       2,
+				MaxSurge:                            util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:                      util.IntOrStringPtr(intstr.FromInt(1)),
+				EnableKubernetesVersionAutoUpdate:   true,
+				EnableMachineImageVersionAutoUpdate: false,
+				OIDCConfig:                          upgradedOidcConfig(),
+			},
+		},
 	}
 
 	casesWithErrors := []struct {
@@ -829,9 +1609,50 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				Purpose:                &evaluationPurpose,
 				AutoScalerMin:          1,
 				AutoScalerMax:          2,
-				MaxSurge:               1,
-				MaxUnavailable:         1,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				GardenerProviderConfig: initialGCPProviderConfig,
+			},
+		},
+		{description: "should return error when networking type is changed",
+			upgradeInput: newGCPUpgradeShootInputWithNetworkingType(testingPurpose, gqlschema.NetworkingTypeCilium),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				VolumeSizeGB:           util.IntPtr(1),
+				DiskType:               util.StringPtr("ssd"),
+				MachineType:            "1",
+				Purpose:                &evaluationPurpose,
+				AutoScalerMin:          1,
+				AutoScalerMax:          2,
+				MaxSurge:               util.IntOrStringPtr(intstr.FromInt(1)),
+				MaxUnavailable:         util.IntOrStringPtr(intstr.FromInt(1)),
+				NetworkingType:         model.CalicoNetworkingType,
+				GardenerProviderConfig: initialGCPProviderConfig,
+			},
+		},
+		{description: "should return error when dedicated system pool is removed",
+			upgradeInput: newGCPUpgradeShootInputWithDedicatedSystemPool(testingPurpose, util.BoolPtr(false), nil),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				MachineType:            "1",
+				GardenerProviderConfig: initialGCPProviderConfig,
+				DedicatedSystemPool:    &model.SystemWorkerPool{Size: 2},
+			},
+		},
+		{description: "should return error when DNS domain is changed",
+			upgradeInput: gqlschema.UpgradeShootInput{
+				GardenerConfig: &gqlschema.GardenerUpgradeInput{
+					DNSConfig: &gqlschema.DNSConfigInput{
+						Domain:    "new.customer.example.com",
+						Providers: []*gqlschema.DNSProviderInput{{Type: "aws-route53", SecretName: "route53-credentials"}},
+					},
+				},
+			},
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion:      "version",
+				MachineType:            "1",
 				GardenerProviderConfig: initialGCPProviderConfig,
+				DNSConfig:              &model.DNSConfig{Domain: "runtime.customer.example.com"},
 			},
 		},
 	}
@@ -847,6 +1668,11 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				defaultEnableKubernetesVersionAutoUpdate,
 				defaultEnableMachineImageVersionAutoUpdate,
 				forceAllowPrivilegedContainers,
+				defaultNetworkingType,
+				model.GCPShieldedInstanceConfig{},
+				model.AWSInstanceMetadataOptions{},
+				model.SystemWorkerPool{},
+				nil,
 			)
 
 			//when
@@ -870,6 +1696,11 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				defaultEnableKubernetesVersionAutoUpdate,
 				defaultEnableMachineImageVersionAutoUpdate,
 				forceAllowPrivilegedContainers,
+				defaultNetworkingType,
+				model.GCPShieldedInstanceConfig{},
+				model.AWSInstanceMetadataOptions{},
+				model.SystemWorkerPool{},
+				nil,
 			)
 
 			//when
@@ -885,15 +1716,15 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 func newUpgradeShootInputAwsAzureGCP(newPurpose string) gqlschema.UpgradeShootInput {
 	return gqlschema.UpgradeShootInput{
 		GardenerConfig: &gqlschema.GardenerUpgradeInput{
-			KubernetesVersion:      util.StringPtr("version2"),
+			KubernetesVersion:      util.StringPtr("1.16"),
 			Purpose:                &newPurpose,
 			MachineType:            util.StringPtr("new-machine"),
 			DiskType:               util.StringPtr("papyrus"),
 			VolumeSizeGb:           util.IntPtr(50),
 			AutoScalerMin:          util.IntPtr(2),
 			AutoScalerMax:          util.IntPtr(6),
-			MaxSurge:               util.IntPtr(2),
-			MaxUnavailable:         util.IntPtr(1),
+			MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(2)),
+			MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
 			ProviderSpecificConfig: nil,
 			OidcConfig:             upgradedOidcInput(),
 		},
@@ -904,13 +1735,13 @@ func newUpgradeShootInputAwsAzureGCP(newPurpose string) gqlschema.UpgradeShootIn
 func newUpgradeOpenStackShootInput(newPurpose string) gqlschema.UpgradeShootInput {
 	return gqlschema.UpgradeShootInput{
 		GardenerConfig: &gqlschema.GardenerUpgradeInput{
-			KubernetesVersion:      util.StringPtr("version2"),
+			KubernetesVersion:      util.StringPtr("1.16"),
 			Purpose:                &newPurpose,
 			MachineType:            util.StringPtr("new-machine"),
 			AutoScalerMin:          util.IntPtr(2),
 			AutoScalerMax:          util.IntPtr(6),
-			MaxSurge:               util.IntPtr(2),
-			MaxUnavailable:         util.IntPtr(1),
+			MaxSurge:               gqlschema.NewIntOrString(intstr.FromInt(2)),
+			MaxUnavailable:         gqlschema.NewIntOrString(intstr.FromInt(1)),
 			ProviderSpecificConfig: nil,
 			OidcConfig:             upgradedOidcInput(),
 		},
@@ -945,6 +1776,129 @@ func newGCPUpgradeShootInput(newPurpose string) gqlschema.UpgradeShootInput {
 	return input
 }
 
+func Test_UpgradeShootInputToGardenerConfig_ShieldedInstanceConfig(t *testing.T) {
+	// given
+	initialGCPProviderConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{
+		Zones:            []string{"europe-west1-a"},
+		EnableSecureBoot: util.BoolPtr(true),
+		EnableVtpm:       util.BoolPtr(false),
+	})
+	require.NoError(t, err)
+
+	inputConverter := NewInputConverter(
+		&mocks.UUIDGenerator{},
+		nil,
+		gardenerProject,
+		defaultEnableKubernetesVersionAutoUpdate,
+		defaultEnableMachineImageVersionAutoUpdate,
+		forceAllowPrivilegedContainers,
+		defaultNetworkingType,
+		model.GCPShieldedInstanceConfig{},
+		model.AWSInstanceMetadataOptions{},
+		model.SystemWorkerPool{},
+		nil)
+
+	upgradeInput := newGCPUpgradeShootInput("testing")
+	upgradeInput.GardenerConfig.ProviderSpecificConfig.GcpConfig.EnableVtpm = util.BoolPtr(true)
+
+	// when
+	shootConfig, appErr := inputConverter.UpgradeShootInputToGardenerConfig(*upgradeInput.GardenerConfig, model.GardenerConfig{GardenerProviderConfig: initialGCPProviderConfig})
+
+	// then
+	require.NoError(t, appErr)
+	assert.Equal(t, gqlschema.GCPProviderConfig{
+		Zones:            []string{"europe-west1-a", "europe-west1-b"},
+		EnableSecureBoot: util.BoolPtr(true),
+		EnableVtpm:       util.BoolPtr(true),
+	}, shootConfig.GardenerProviderConfig.AsProviderSpecificConfig())
+}
+
+func Test_UpgradeShootInputToGardenerConfig_InstanceMetadataOptions(t *testing.T) {
+	// given
+	initialAWSProviderConfig, err := model.NewAWSGardenerConfig(&gqlschema.AWSProviderConfigInput{
+		Zone:                    "eu-central-1a",
+		VpcCidr:                 "10.250.0.0/16",
+		PublicCidr:              "10.250.32.0/20",
+		InternalCidr:            "10.250.48.0/20",
+		HTTPTokens:              util.StringPtr(model.AWSHTTPTokensOptional),
+		HTTPPutResponseHopLimit: util.IntPtr(2),
+	})
+	require.NoError(t, err)
+
+	inputConverter := NewInputConverter(
+		&mocks.UUIDGenerator{},
+		nil,
+		gardenerProject,
+		defaultEnableKubernetesVersionAutoUpdate,
+		defaultEnableMachineImageVersionAutoUpdate,
+		forceAllowPrivilegedContainers,
+		defaultNetworkingType,
+		model.GCPShieldedInstanceConfig{},
+		model.AWSInstanceMetadataOptions{HTTPTokens: model.AWSHTTPTokensOptional, HTTPPutResponseHopLimit: 1},
+		model.SystemWorkerPool{},
+		nil)
+
+	upgradeInput := gqlschema.GardenerUpgradeInput{
+		ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+			AwsConfig: &gqlschema.AWSProviderConfigInput{
+				Zone:         "eu-central-1a",
+				VpcCidr:      "10.250.0.0/16",
+				PublicCidr:   "10.250.32.0/20",
+				InternalCidr: "10.250.48.0/20",
+				HTTPTokens:   util.StringPtr(model.AWSHTTPTokensRequired),
+			},
+		},
+	}
+
+	// when
+	shootConfig, appErr := inputConverter.UpgradeShootInputToGardenerConfig(upgradeInput, model.GardenerConfig{GardenerProviderConfig: initialAWSProviderConfig})
+
+	// then
+	require.NoError(t, appErr)
+	awsConfig, ok := shootConfig.GardenerProviderConfig.AsProviderSpecificConfig().(gqlschema.AWSProviderConfig)
+	require.True(t, ok)
+	assert.Equal(t, util.StringPtr(model.AWSHTTPTokensRequired), awsConfig.HTTPTokens)
+	assert.Equal(t, util.IntPtr(2), awsConfig.HTTPPutResponseHopLimit)
+	assert.Nil(t, upgradeInput.ProviderSpecificConfig.AwsConfig.HTTPPutResponseHopLimit)
+}
+
+func newGCPUpgradeShootInputWithNetworkingType(newPurpose string, networkingType gqlschema.NetworkingType) gqlschema.UpgradeShootInput {
+	input := newGCPUpgradeShootInput(newPurpose)
+	input.GardenerConfig.NetworkingType = &networkingType
+	return input
+}
+
+func newGCPUpgradeShootInputWithShootAnnotations(newPurpose string, annotations gqlschema.Annotations) gqlschema.UpgradeShootInput {
+	input := newGCPUpgradeShootInput(newPurpose)
+	input.GardenerConfig.ShootAnnotations = &annotations
+	return input
+}
+
+func newGCPUpgradeShootInputWithCostAllocation(newPurpose string, costAllocation gqlschema.CostAllocationInput) gqlschema.UpgradeShootInput {
+	input := newGCPUpgradeShootInput(newPurpose)
+	input.GardenerConfig.CostAllocation = &costAllocation
+	return input
+}
+
+func newGCPUpgradeShootInputWithClusterAutoscalerConfig(newPurpose string, clusterAutoscalerConfig gqlschema.ClusterAutoscalerConfigInput) gqlschema.UpgradeShootInput {
+	input := newGCPUpgradeShootInput(newPurpose)
+	input.GardenerConfig.ClusterAutoscalerConfig = &clusterAutoscalerConfig
+	return input
+}
+
+func newGCPUpgradeShootInputWithKubeAPIServerConfig(newPurpose string, kubeAPIServerConfig gqlschema.KubeAPIServerConfigInput) gqlschema.UpgradeShootInput {
+	input := newGCPUpgradeShootInput(newPurpose)
+	input.GardenerConfig.KubeAPIServerConfig = &kubeAPIServerConfig
+	return input
+}
+
+func newGCPUpgradeShootInputWithDedicatedSystemPool(newPurpose string, dedicatedSystemPool *bool, systemWorkerPool *gqlschema.SystemWorkerPoolInput) gqlschema.UpgradeShootInput {
+	input := newGCPUpgradeShootInput(newPurpose)
+	input.GardenerConfig.DedicatedSystemPool = dedicatedSystemPool
+	input.GardenerConfig.SystemWorkerPool = systemWorkerPool
+	return input
+}
+
 func newAzureUpgradeShootInput(newPurpose string) gqlschema.UpgradeShootInput {
 	input := newUpgradeShootInputAwsAzureGCP(newPurpose)
 	input.GardenerConfig.ProviderSpecificConfig = &gqlschema.ProviderSpecificInput{
@@ -1020,3 +1974,139 @@ func fixKymaReleaseWithoutTiller() model.Release {
 		InstallerYAML: "installer yaml",
 	}
 }
+
+func TestConverter_ProviderDefaults(t *testing.T) {
+	latestRelease := &model.Release{Id: "releaseID", Version: "1.24.10", InstallerYAML: "installer"}
+	latestReleaseWithTiller := &model.Release{Id: "releaseID", Version: "1.14.0", TillerYAML: "tiller", InstallerYAML: "installer"}
+	productionProfile := model.ProductionProfile
+
+	for _, testCase := range []struct {
+		description                    string
+		provider                       string
+		latestRelease                  *model.Release
+		enableAutoUpdate               bool
+		forceAllowPrivilegedContainers bool
+		networkingType                 model.NetworkingType
+		shieldedInstanceConfig         model.GCPShieldedInstanceConfig
+		instanceMetadataOptions        model.AWSInstanceMetadataOptions
+		kymaProfile                    *model.KymaProfile
+		expectedPrivilegedContainers   bool
+		expectedCloudProfile           string
+	}{
+		{
+			description:          "should return defaults from the configuration",
+			provider:             "azure",
+			latestRelease:        latestRelease,
+			networkingType:       model.CalicoNetworkingType,
+			expectedCloudProfile: "az",
+		},
+		{
+			description:                  "should return changed defaults from the configuration",
+			provider:                     "aws",
+			latestRelease:                latestRelease,
+			enableAutoUpdate:             true,
+			networkingType:               model.CiliumNetworkingType,
+			instanceMetadataOptions:      model.AWSInstanceMetadataOptions{HTTPTokens: model.AWSHTTPTokensRequired, HTTPPutResponseHopLimit: 2},
+			kymaProfile:                  &productionProfile,
+			expectedPrivilegedContainers: false,
+			expectedCloudProfile:         "aws",
+		},
+		{
+			description:                    "should allow privileged containers when forced in the configuration",
+			provider:                       "gcp",
+			forceAllowPrivilegedContainers: true,
+			networkingType:                 model.CalicoNetworkingType,
+			shieldedInstanceConfig:         model.GCPShieldedInstanceConfig{EnableSecureBoot: true, EnableVtpm: true},
+			expectedPrivilegedContainers:   true,
+			expectedCloudProfile:           "gcp",
+		},
+		{
+			description:                  "should allow privileged containers when the latest release contains Tiller",
+			provider:                     "openstack",
+			latestRelease:                latestReleaseWithTiller,
+			networkingType:               model.CalicoNetworkingType,
+			expectedPrivilegedContainers: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			uuidGeneratorMock := &mocks.UUIDGenerator{}
+			uuidGeneratorMock.On("New").Return("id")
+			releaseProvider := &realeaseMocks.Provider{}
+			releaseProvider.On("GetLatestRelease").Return(testCase.latestRelease, nil)
+
+			inputConverter := NewInputConverter(
+				uuidGeneratorMock,
+				releaseProvider,
+				gardenerProject,
+				testCase.enableAutoUpdate,
+				testCase.enableAutoUpdate,
+				testCase.forceAllowPrivilegedContainers,
+				testCase.networkingType,
+				testCase.shieldedInstanceConfig,
+				testCase.instanceMetadataOptions,
+				model.SystemWorkerPool{},
+				testCase.kymaProfile)
+
+			// when
+			defaults, err := inputConverter.ProviderDefaults(testCase.provider)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.provider, defaults.Provider)
+			assert.Equal(t, testCase.enableAutoUpdate, defaults.GardenerConfig.EnableKubernetesVersionAutoUpdate)
+			assert.Equal(t, testCase.enableAutoUpdate, defaults.GardenerConfig.EnableMachineImageVersionAutoUpdate)
+			assert.Equal(t, testCase.expectedPrivilegedContainers, defaults.GardenerConfig.AllowPrivilegedContainers)
+			assert.Equal(t, testCase.networkingType, defaults.GardenerConfig.NetworkingType)
+			assert.Equal(t, testCase.expectedCloudProfile, defaults.GardenerConfig.GardenerProviderConfig.CloudProfileName())
+			assert.Equal(t, gardenerProject, defaults.GardenerConfig.ProjectName)
+			assert.Nil(t, defaults.GardenerConfig.Purpose)
+			assert.Nil(t, defaults.GardenerConfig.VolumeSizeGB)
+			assert.Equal(t, testCase.kymaProfile, defaults.KymaProfile)
+			if testCase.latestRelease != nil {
+				require.NotNil(t, defaults.KymaVersion)
+				assert.Equal(t, testCase.latestRelease.Version, *defaults.KymaVersion)
+			} else {
+				assert.Nil(t, defaults.KymaVersion)
+			}
+			if testCase.provider == "gcp" {
+				gcpConfig, ok := defaults.GardenerConfig.GardenerProviderConfig.AsProviderSpecificConfig().(gqlschema.GCPProviderConfig)
+				require.True(t, ok)
+				assert.Equal(t, util.BoolPtr(testCase.shieldedInstanceConfig.EnableSecureBoot), gcpConfig.EnableSecureBoot)
+				assert.Equal(t, util.BoolPtr(testCase.shieldedInstanceConfig.EnableVtpm), gcpConfig.EnableVtpm)
+			}
+			if testCase.provider == "aws" {
+				awsConfig, ok := defaults.GardenerConfig.GardenerProviderConfig.AsProviderSpecificConfig().(gqlschema.AWSProviderConfig)
+				require.True(t, ok)
+				assert.Equal(t, util.StringPtr(testCase.instanceMetadataOptions.HTTPTokens), awsConfig.HTTPTokens)
+				assert.Equal(t, util.IntPtr(testCase.instanceMetadataOptions.HTTPPutResponseHopLimit), awsConfig.HTTPPutResponseHopLimit)
+			}
+		})
+	}
+
+	t.Run("should return error for unsupported provider", func(t *testing.T) {
+		// given
+		inputConverter := NewInputConverter(nil, &realeaseMocks.Provider{}, gardenerProject, false, false, false, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
+
+		// when
+		_, err := inputConverter.ProviderDefaults("alicloud")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+	})
+
+	t.Run("should return error when failed to get the latest release", func(t *testing.T) {
+		// given
+		releaseProvider := &realeaseMocks.Provider{}
+		releaseProvider.On("GetLatestRelease").Return(nil, dberrors.Internal("error"))
+		inputConverter := NewInputConverter(nil, releaseProvider, gardenerProject, false, false, false, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
+
+		// when
+		_, err := inputConverter.ProviderDefaults("gcp")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeInternal, err.Code())
+	})
+}
@@ -0,0 +1,41 @@
+package provisioning
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// LastOperationChecker repairs the last operations stored with the clusters which drifted from their operations,
+// e.g. when operations of the same cluster were inserted concurrently in transactions
+type LastOperationChecker struct {
+	dbSessionFactory dbsession.Factory
+
+	log logrus.FieldLogger
+}
+
+func NewLastOperationChecker(factory dbsession.Factory) *LastOperationChecker {
+	return &LastOperationChecker{
+		dbSessionFactory: factory,
+		log:              logrus.WithField("component", "last-operation-checker"),
+	}
+}
+
+// Run repairs the last operations immediately and then in the given interval until stopped
+func (c *LastOperationChecker) Run(interval time.Duration, stop <-chan struct{}) {
+	wait.Until(c.RepairLastOperations, interval, stop)
+}
+
+func (c *LastOperationChecker) RepairLastOperations() {
+	repaired, err := c.dbSessionFactory.NewWriteSession().RepairLastOperations()
+	if err != nil {
+		c.log.Errorf("Failed to repair last operations of clusters: %s", err.Error())
+		return
+	}
+
+	if repaired > 0 {
+		c.log.Warnf("Repaired last operations of %d clusters", repaired)
+	}
+}
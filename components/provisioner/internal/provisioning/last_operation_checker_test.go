@@ -0,0 +1,48 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastOperationChecker_RepairLastOperations(t *testing.T) {
+	for _, testCase := range []struct {
+		description   string
+		repaired      int
+		err           dberrors.Error
+		expectedLevel logrus.Level
+		expectedLogs  int
+	}{
+		{description: "should not log when no cluster drifted", repaired: 0},
+		{description: "should warn when clusters were repaired", repaired: 2, expectedLevel: logrus.WarnLevel, expectedLogs: 1},
+		{description: "should log error when repair failed", err: dberrors.Internal("error"), expectedLevel: logrus.ErrorLevel, expectedLogs: 1},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			sessionFactory := &sessionMocks.Factory{}
+			writeSession := &sessionMocks.WriteSession{}
+
+			sessionFactory.On("NewWriteSession").Return(writeSession)
+			writeSession.On("RepairLastOperations").Return(testCase.repaired, testCase.err)
+
+			logger, hook := test.NewNullLogger()
+			checker := NewLastOperationChecker(sessionFactory)
+			checker.log = logger
+
+			// when
+			checker.RepairLastOperations()
+
+			// then
+			writeSession.AssertExpectations(t)
+			assert.Len(t, hook.AllEntries(), testCase.expectedLogs)
+			if testCase.expectedLogs > 0 {
+				assert.Equal(t, testCase.expectedLevel, hook.LastEntry().Level)
+			}
+		})
+	}
+}
@@ -14,20 +14,20 @@ type Provisioner struct {
 	mock.Mock
 }
 
-// DeprovisionCluster provides a mock function with given fields: cluster, operationId
-func (_m *Provisioner) DeprovisionCluster(cluster model.Cluster, operationId string) (model.Operation, apperrors.AppError) {
-	ret := _m.Called(cluster, operationId)
+// DeprovisionCluster provides a mock function with given fields: cluster, operationId, force
+func (_m *Provisioner) DeprovisionCluster(cluster model.Cluster, operationId string, force bool) (model.Operation, apperrors.AppError) {
+	ret := _m.Called(cluster, operationId, force)
 
 	var r0 model.Operation
-	if rf, ok := ret.Get(0).(func(model.Cluster, string) model.Operation); ok {
-		r0 = rf(cluster, operationId)
+	if rf, ok := ret.Get(0).(func(model.Cluster, string, bool) model.Operation); ok {
+		r0 = rf(cluster, operationId, force)
 	} else {
 		r0 = ret.Get(0).(model.Operation)
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(model.Cluster, string) apperrors.AppError); ok {
-		r1 = rf(cluster, operationId)
+	if rf, ok := ret.Get(1).(func(model.Cluster, string, bool) apperrors.AppError); ok {
+		r1 = rf(cluster, operationId, force)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -60,6 +60,31 @@ func (_m *Provisioner) GetHibernationStatus(clusterID string, gardenerConfig mod
 	return r0, r1
 }
 
+// GetShootStatus provides a mock function with given fields: clusterID, gardenerConfig
+func (_m *Provisioner) GetShootStatus(clusterID string, gardenerConfig model.GardenerConfig) (*model.ShootStatus, apperrors.AppError) {
+	ret := _m.Called(clusterID, gardenerConfig)
+
+	var r0 *model.ShootStatus
+	if rf, ok := ret.Get(0).(func(string, model.GardenerConfig) *model.ShootStatus); ok {
+		r0 = rf(clusterID, gardenerConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShootStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, model.GardenerConfig) apperrors.AppError); ok {
+		r1 = rf(clusterID, gardenerConfig)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // HibernateCluster provides a mock function with given fields: clusterID, upgradeConfig
 func (_m *Provisioner) HibernateCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError {
 	ret := _m.Called(clusterID, upgradeConfig)
@@ -92,6 +117,29 @@ func (_m *Provisioner) ProvisionCluster(cluster model.Cluster, operationId strin
 	return r0
 }
 
+// ProvisionClusterDryRun provides a mock function with given fields: cluster
+func (_m *Provisioner) ProvisionClusterDryRun(cluster model.Cluster) (model.ShootDryRun, apperrors.AppError) {
+	ret := _m.Called(cluster)
+
+	var r0 model.ShootDryRun
+	if rf, ok := ret.Get(0).(func(model.Cluster) model.ShootDryRun); ok {
+		r0 = rf(cluster)
+	} else {
+		r0 = ret.Get(0).(model.ShootDryRun)
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(model.Cluster) apperrors.AppError); ok {
+		r1 = rf(cluster)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // UpgradeCluster provides a mock function with given fields: clusterID, upgradeConfig
 func (_m *Provisioner) UpgradeCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError {
 	ret := _m.Called(clusterID, upgradeConfig)
@@ -107,3 +155,44 @@ func (_m *Provisioner) UpgradeCluster(clusterID string, upgradeConfig model.Gard
 
 	return r0
 }
+
+// UpgradeClusterDryRun provides a mock function with given fields: clusterID, upgradeConfig
+func (_m *Provisioner) UpgradeClusterDryRun(clusterID string, upgradeConfig model.GardenerConfig) ([]model.ShootSpecChange, apperrors.AppError) {
+	ret := _m.Called(clusterID, upgradeConfig)
+
+	var r0 []model.ShootSpecChange
+	if rf, ok := ret.Get(0).(func(string, model.GardenerConfig) []model.ShootSpecChange); ok {
+		r0 = rf(clusterID, upgradeConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ShootSpecChange)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, model.GardenerConfig) apperrors.AppError); ok {
+		r1 = rf(clusterID, upgradeConfig)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// WakeUpCluster provides a mock function with given fields: clusterID, upgradeConfig
+func (_m *Provisioner) WakeUpCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError {
+	ret := _m.Called(clusterID, upgradeConfig)
+
+	var r0 apperrors.AppError
+	if rf, ok := ret.Get(0).(func(string, model.GardenerConfig) apperrors.AppError); ok {
+		r0 = rf(clusterID, upgradeConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(apperrors.AppError)
+		}
+	}
+
+	return r0
+}
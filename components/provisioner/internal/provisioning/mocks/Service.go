@@ -7,6 +7,8 @@ import (
 	gqlschema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -14,20 +16,22 @@ type Service struct {
 	mock.Mock
 }
 
-// DeprovisionRuntime provides a mock function with given fields: id, tenant
-func (_m *Service) DeprovisionRuntime(id string, tenant string) (string, apperrors.AppError) {
-	ret := _m.Called(id, tenant)
+// AnnotateOperation provides a mock function with given fields: operationID, key, value
+func (_m *Service) AnnotateOperation(operationID string, key string, value string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(operationID, key, value)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(id, tenant)
+	var r0 *gqlschema.OperationStatus
+	if rf, ok := ret.Get(0).(func(string, string, string) *gqlschema.OperationStatus); ok {
+		r0 = rf(operationID, key, value)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationStatus)
+		}
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, string) apperrors.AppError); ok {
-		r1 = rf(id, tenant)
+	if rf, ok := ret.Get(1).(func(string, string, string) apperrors.AppError); ok {
+		r1 = rf(operationID, key, value)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -37,13 +41,38 @@ func (_m *Service) DeprovisionRuntime(id string, tenant string) (string, apperro
 	return r0, r1
 }
 
-// HibernateCluster provides a mock function with given fields: clusterID
-func (_m *Service) HibernateCluster(clusterID string) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(clusterID)
+// AuditEntries provides a mock function with given fields: filter, first, offset
+func (_m *Service) AuditEntries(filter *gqlschema.AuditEntriesFilter, first *int, offset *int) ([]*gqlschema.AuditEntry, apperrors.AppError) {
+	ret := _m.Called(filter, first, offset)
+
+	var r0 []*gqlschema.AuditEntry
+	if rf, ok := ret.Get(0).(func(*gqlschema.AuditEntriesFilter, *int, *int) []*gqlschema.AuditEntry); ok {
+		r0 = rf(filter, first, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gqlschema.AuditEntry)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(*gqlschema.AuditEntriesFilter, *int, *int) apperrors.AppError); ok {
+		r1 = rf(filter, first, offset)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// CleanupFailedProvisioning provides a mock function with given fields: runtimeID
+func (_m *Service) CleanupFailedProvisioning(runtimeID string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(runtimeID)
 
 	var r0 *gqlschema.OperationStatus
 	if rf, ok := ret.Get(0).(func(string) *gqlschema.OperationStatus); ok {
-		r0 = rf(clusterID)
+		r0 = rf(runtimeID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -52,7 +81,180 @@ func (_m *Service) HibernateCluster(clusterID string) (*gqlschema.OperationStatu
 
 	var r1 apperrors.AppError
 	if rf, ok := ret.Get(1).(func(string) apperrors.AppError); ok {
-		r1 = rf(clusterID)
+		r1 = rf(runtimeID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeprovisionRuntime provides a mock function with given fields: id, tenant, force, idempotencyKey
+func (_m *Service) DeprovisionRuntime(id string, tenant string, force bool, idempotencyKey string) (string, apperrors.AppError) {
+	ret := _m.Called(id, tenant, force, idempotencyKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, bool, string) string); ok {
+		r0 = rf(id, tenant, force, idempotencyKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, string, bool, string) apperrors.AppError); ok {
+		r1 = rf(id, tenant, force, idempotencyKey)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ExtendRuntimeExpiration provides a mock function with given fields: runtimeID, expireAt
+func (_m *Service) ExtendRuntimeExpiration(runtimeID string, expireAt *time.Time) (*gqlschema.RuntimeStatus, apperrors.AppError) {
+	ret := _m.Called(runtimeID, expireAt)
+
+	var r0 *gqlschema.RuntimeStatus
+	if rf, ok := ret.Get(0).(func(string, *time.Time) *gqlschema.RuntimeStatus); ok {
+		r0 = rf(runtimeID, expireAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.RuntimeStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, *time.Time) apperrors.AppError); ok {
+		r1 = rf(runtimeID, expireAt)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// HibernateCluster provides a mock function with given fields: clusterID, notBefore
+func (_m *Service) HibernateCluster(clusterID string, notBefore *time.Time) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(clusterID, notBefore)
+
+	var r0 *gqlschema.OperationStatus
+	if rf, ok := ret.Get(0).(func(string, *time.Time) *gqlschema.OperationStatus); ok {
+		r0 = rf(clusterID, notBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, *time.Time) apperrors.AppError); ok {
+		r1 = rf(clusterID, notBefore)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// MaintenanceFreeze provides a mock function with given fields:
+func (_m *Service) MaintenanceFreeze() (*gqlschema.MaintenanceFreezeStatus, apperrors.AppError) {
+	ret := _m.Called()
+
+	var r0 *gqlschema.MaintenanceFreezeStatus
+	if rf, ok := ret.Get(0).(func() *gqlschema.MaintenanceFreezeStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.MaintenanceFreezeStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func() apperrors.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// OperationsHistory provides a mock function with given fields: runtimeID, first, after
+func (_m *Service) OperationsHistory(runtimeID string, first *int, after *string) (*gqlschema.OperationsHistory, apperrors.AppError) {
+	ret := _m.Called(runtimeID, first, after)
+
+	var r0 *gqlschema.OperationsHistory
+	if rf, ok := ret.Get(0).(func(string, *int, *string) *gqlschema.OperationsHistory); ok {
+		r0 = rf(runtimeID, first, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationsHistory)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, *int, *string) apperrors.AppError); ok {
+		r1 = rf(runtimeID, first, after)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// OrphanedShoots provides a mock function with given fields:
+func (_m *Service) OrphanedShoots() ([]*gqlschema.OrphanedShoot, apperrors.AppError) {
+	ret := _m.Called()
+
+	var r0 []*gqlschema.OrphanedShoot
+	if rf, ok := ret.Get(0).(func() []*gqlschema.OrphanedShoot); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gqlschema.OrphanedShoot)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func() apperrors.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProviderDefaults provides a mock function with given fields: provider
+func (_m *Service) ProviderDefaults(provider gqlschema.Provider) (*gqlschema.ProviderDefaults, apperrors.AppError) {
+	ret := _m.Called(provider)
+
+	var r0 *gqlschema.ProviderDefaults
+	if rf, ok := ret.Get(0).(func(gqlschema.Provider) *gqlschema.ProviderDefaults); ok {
+		r0 = rf(provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.ProviderDefaults)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(gqlschema.Provider) apperrors.AppError); ok {
+		r1 = rf(provider)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -62,8 +264,33 @@ func (_m *Service) HibernateCluster(clusterID string) (*gqlschema.OperationStatu
 	return r0, r1
 }
 
-// ProvisionRuntime provides a mock function with given fields: config, tenant, subAccount
-func (_m *Service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant string, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
+// ProvisionRuntime provides a mock function with given fields: config, tenant, subAccount, idempotencyKey
+func (_m *Service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant string, subAccount string, idempotencyKey string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(config, tenant, subAccount, idempotencyKey)
+
+	var r0 *gqlschema.OperationStatus
+	if rf, ok := ret.Get(0).(func(gqlschema.ProvisionRuntimeInput, string, string, string) *gqlschema.OperationStatus); ok {
+		r0 = rf(config, tenant, subAccount, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(gqlschema.ProvisionRuntimeInput, string, string, string) apperrors.AppError); ok {
+		r1 = rf(config, tenant, subAccount, idempotencyKey)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProvisionRuntimeDryRun provides a mock function with given fields: config, tenant, subAccount
+func (_m *Service) ProvisionRuntimeDryRun(config gqlschema.ProvisionRuntimeInput, tenant string, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
 	ret := _m.Called(config, tenant, subAccount)
 
 	var r0 *gqlschema.OperationStatus
@@ -87,6 +314,31 @@ func (_m *Service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tena
 	return r0, r1
 }
 
+// QueuesStatus provides a mock function with given fields:
+func (_m *Service) QueuesStatus() ([]*gqlschema.QueueStatus, apperrors.AppError) {
+	ret := _m.Called()
+
+	var r0 []*gqlschema.QueueStatus
+	if rf, ok := ret.Get(0).(func() []*gqlschema.QueueStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gqlschema.QueueStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func() apperrors.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ReconnectRuntimeAgent provides a mock function with given fields: id
 func (_m *Service) ReconnectRuntimeAgent(id string) (string, apperrors.AppError) {
 	ret := _m.Called(id)
@@ -110,6 +362,31 @@ func (_m *Service) ReconnectRuntimeAgent(id string) (string, apperrors.AppError)
 	return r0, r1
 }
 
+// RetryFailedOperations provides a mock function with given fields: filter, dryRun
+func (_m *Service) RetryFailedOperations(filter gqlschema.FailedOperationsFilter, dryRun bool) (*gqlschema.RetriedOperations, apperrors.AppError) {
+	ret := _m.Called(filter, dryRun)
+
+	var r0 *gqlschema.RetriedOperations
+	if rf, ok := ret.Get(0).(func(gqlschema.FailedOperationsFilter, bool) *gqlschema.RetriedOperations); ok {
+		r0 = rf(filter, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.RetriedOperations)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(gqlschema.FailedOperationsFilter, bool) apperrors.AppError); ok {
+		r1 = rf(filter, dryRun)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // RollBackLastUpgrade provides a mock function with given fields: runtimeID
 func (_m *Service) RollBackLastUpgrade(runtimeID string) (*gqlschema.RuntimeStatus, apperrors.AppError) {
 	ret := _m.Called(runtimeID)
@@ -135,6 +412,31 @@ func (_m *Service) RollBackLastUpgrade(runtimeID string) (*gqlschema.RuntimeStat
 	return r0, r1
 }
 
+// RuntimeByShootName provides a mock function with given fields: shootName, tenant
+func (_m *Service) RuntimeByShootName(shootName string, tenant string) (*gqlschema.ShootRuntime, apperrors.AppError) {
+	ret := _m.Called(shootName, tenant)
+
+	var r0 *gqlschema.ShootRuntime
+	if rf, ok := ret.Get(0).(func(string, string) *gqlschema.ShootRuntime); ok {
+		r0 = rf(shootName, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.ShootRuntime)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, string) apperrors.AppError); ok {
+		r1 = rf(shootName, tenant)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // RuntimeOperationStatus provides a mock function with given fields: id
 func (_m *Service) RuntimeOperationStatus(id string) (*gqlschema.OperationStatus, apperrors.AppError) {
 	ret := _m.Called(id)
@@ -185,8 +487,83 @@ func (_m *Service) RuntimeStatus(id string) (*gqlschema.RuntimeStatus, apperrors
 	return r0, r1
 }
 
-// UpgradeGardenerShoot provides a mock function with given fields: id, input
-func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+// RuntimeStatuses provides a mock function with given fields: tenant, ids, skipGardenerStatus
+func (_m *Service) RuntimeStatuses(tenant string, ids []string, skipGardenerStatus bool) ([]*gqlschema.RuntimeStatusEntry, apperrors.AppError) {
+	ret := _m.Called(tenant, ids, skipGardenerStatus)
+
+	var r0 []*gqlschema.RuntimeStatusEntry
+	if rf, ok := ret.Get(0).(func(string, []string, bool) []*gqlschema.RuntimeStatusEntry); ok {
+		r0 = rf(tenant, ids, skipGardenerStatus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gqlschema.RuntimeStatusEntry)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, []string, bool) apperrors.AppError); ok {
+		r1 = rf(tenant, ids, skipGardenerStatus)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetQueueState provides a mock function with given fields: queueType, paused
+func (_m *Service) SetQueueState(queueType gqlschema.QueueType, paused bool) (*gqlschema.QueueStatus, apperrors.AppError) {
+	ret := _m.Called(queueType, paused)
+
+	var r0 *gqlschema.QueueStatus
+	if rf, ok := ret.Get(0).(func(gqlschema.QueueType, bool) *gqlschema.QueueStatus); ok {
+		r0 = rf(queueType, paused)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.QueueStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(gqlschema.QueueType, bool) apperrors.AppError); ok {
+		r1 = rf(queueType, paused)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpgradeGardenerShoot provides a mock function with given fields: id, input, tenant, idempotencyKey
+func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput, tenant string, idempotencyKey string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(id, input, tenant, idempotencyKey)
+
+	var r0 *gqlschema.OperationStatus
+	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeShootInput, string, string) *gqlschema.OperationStatus); ok {
+		r0 = rf(id, input, tenant, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, gqlschema.UpgradeShootInput, string, string) apperrors.AppError); ok {
+		r1 = rf(id, input, tenant, idempotencyKey)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpgradeGardenerShootDryRun provides a mock function with given fields: id, input
+func (_m *Service) UpgradeGardenerShootDryRun(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
 	ret := _m.Called(id, input)
 
 	var r0 *gqlschema.OperationStatus
@@ -210,13 +587,38 @@ func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootI
 	return r0, r1
 }
 
-// UpgradeRuntime provides a mock function with given fields: id, config
-func (_m *Service) UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(id, config)
+// UpgradeRuntime provides a mock function with given fields: id, config, tenant, idempotencyKey, skipHealthChecks
+func (_m *Service) UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput, tenant string, idempotencyKey string, skipHealthChecks bool) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(id, config, tenant, idempotencyKey, skipHealthChecks)
+
+	var r0 *gqlschema.OperationStatus
+	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeRuntimeInput, string, string, bool) *gqlschema.OperationStatus); ok {
+		r0 = rf(id, config, tenant, idempotencyKey, skipHealthChecks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationStatus)
+		}
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(string, gqlschema.UpgradeRuntimeInput, string, string, bool) apperrors.AppError); ok {
+		r1 = rf(id, config, tenant, idempotencyKey, skipHealthChecks)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// WakeUpCluster provides a mock function with given fields: clusterID, notBefore
+func (_m *Service) WakeUpCluster(clusterID string, notBefore *time.Time) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(clusterID, notBefore)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeRuntimeInput) *gqlschema.OperationStatus); ok {
-		r0 = rf(id, config)
+	if rf, ok := ret.Get(0).(func(string, *time.Time) *gqlschema.OperationStatus); ok {
+		r0 = rf(clusterID, notBefore)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -224,8 +626,8 @@ func (_m *Service) UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInpu
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, gqlschema.UpgradeRuntimeInput) apperrors.AppError); ok {
-		r1 = rf(id, config)
+	if rf, ok := ret.Get(1).(func(string, *time.Time) apperrors.AppError); ok {
+		r1 = rf(clusterID, notBefore)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -6,6 +6,7 @@ import (
 	dbr "github.com/gocraft/dbr/v2"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
 )
 
 //go:generate mockery -name=Factory
@@ -19,14 +20,33 @@ type Factory interface {
 //go:generate mockery -name=ReadSession
 type ReadSession interface {
 	GetCluster(runtimeID string) (model.Cluster, dberrors.Error)
+	GetClustersByIDs(runtimeIDs []string) ([]model.Cluster, dberrors.Error)
 	GetOperation(operationID string) (model.Operation, dberrors.Error)
 	GetLastOperation(runtimeID string) (model.Operation, dberrors.Error)
+	GetLastOperationsByRuntimeIDs(runtimeIDs []string) (map[string]model.Operation, dberrors.Error)
 	GetGardenerClusterByName(name string) (model.Cluster, dberrors.Error)
 	GetTenant(runtimeID string) (string, dberrors.Error)
 	ListInProgressOperations() ([]model.Operation, dberrors.Error)
 	GetRuntimeUpgrade(operationId string) (model.RuntimeUpgrade, dberrors.Error)
 	GetTenantForOperation(operationID string) (string, dberrors.Error)
 	InProgressOperationsCount() (model.OperationsCount, dberrors.Error)
+	ListQueueStates() ([]model.QueueState, dberrors.Error)
+	GetReadOnlyMode() (model.ReadOnlyMode, dberrors.Error)
+	ListPendingOperations(operationType model.OperationType) ([]model.Operation, dberrors.Error)
+	ListFailedLastOperations(filter model.FailedOperationsFilter) ([]model.Operation, dberrors.Error)
+	InProgressOperationsCountForTenant(tenant string, operationType model.OperationType) (int, dberrors.Error)
+	ListOperationsByRuntimeID(runtimeID string, limit, offset int) ([]model.Operation, dberrors.Error)
+	OperationsCountByRuntimeID(runtimeID string) (int, dberrors.Error)
+	CountClustersGroupedBy() (model.ClustersCount, dberrors.Error)
+	ListActiveShootNames() ([]string, dberrors.Error)
+	ListAuditEntries(filter model.AuditEntriesFilter, limit, offset int) ([]model.AuditEntry, dberrors.Error)
+	GetStageDurationStats(operationType model.OperationType, sampleSize int) (map[model.OperationStage]model.StageDurationStats, dberrors.Error)
+	GetIdempotencyKey(tenant, key string) (model.IdempotencyKey, dberrors.Error)
+	ListOperationAnnotations(operationID string) ([]model.OperationAnnotation, dberrors.Error)
+	ListClustersWithLastOperation(filter model.ClustersFilter, limit, offset int) ([]model.ClusterWithLastOperation, dberrors.Error)
+	StreamClusters(filter model.ClustersFilter, batchSize int, fn func(cluster model.Cluster) error) error
+	ListExpiredClusters(now time.Time, limit int) ([]model.Cluster, dberrors.Error)
+	ListDeprovisioningSchedules() ([]model.DeprovisioningSchedule, dberrors.Error)
 }
 
 //go:generate mockery -name=WriteSession
@@ -37,15 +57,37 @@ type WriteSession interface {
 	InsertAdministrators(clusterId string, administrators []string) dberrors.Error
 	InsertKymaConfig(kymaConfig model.KymaConfig) dberrors.Error
 	InsertOperation(operation model.Operation) dberrors.Error
-	UpdateOperationState(operationID string, message string, state model.OperationState, endTime time.Time) dberrors.Error
-	TransitionOperation(operationID string, message string, stage model.OperationStage, transitionTime time.Time) dberrors.Error
-	UpdateKubeconfig(runtimeID string, kubeconfig string) dberrors.Error
+	UpdateOperationState(operationID string, expectedVersion int, message string, state model.OperationState, endTime time.Time) dberrors.Error
+	TransitionOperation(operationID string, expectedVersion int, message string, stage model.OperationStage, transitionTime time.Time) dberrors.Error
+	RetryOperation(operationID string, expectedVersion int, message string, retryTime time.Time) dberrors.Error
+	UpdateOperationMessage(operationID string, message string) dberrors.Error
+	UpdateOperationDiagnostics(operationID string, diagnostics string) dberrors.Error
+	UpdateOperationFailureReason(operationID string, reason string) dberrors.Error
+	MarkInstallationTriggered(operationID string, triggeredAt time.Time) dberrors.Error
+	UpdateKubeconfig(runtimeID string, kubeconfig string, apiServer model.APIServer) dberrors.Error
 	SetActiveKymaConfig(runtimeID string, kymaConfigId string) dberrors.Error
 	UpdateUpgradeState(operationID string, upgradeState model.UpgradeState) dberrors.Error
 	DeleteCluster(runtimeID string) dberrors.Error
 	MarkClusterAsDeleted(runtimeID string) dberrors.Error
+	MarkClusterAsHibernated(runtimeID string, hibernatedAt time.Time, initiatedBy model.HibernationTrigger) dberrors.Error
+	MarkClusterAsWokenUp(runtimeID string, wokenUpAt time.Time) dberrors.Error
+	UpdateClusterExpiration(runtimeID string, expireAt *time.Time) dberrors.Error
+	UpdateClusterDeprovisioningSchedule(runtimeID string, scheduledAt *time.Time) dberrors.Error
+	ClearClusterDeprovisioningSchedule(runtimeID string, scheduledAt time.Time) (bool, dberrors.Error)
+	ClaimOperation(operationID, owner string, expiresAt, now time.Time) (bool, dberrors.Error)
+	ReleaseOperationClaim(operationID, owner string) dberrors.Error
 	InsertRuntimeUpgrade(runtimeUpgrade model.RuntimeUpgrade) dberrors.Error
 	FixShootProvisioningStage(message string, newStage model.OperationStage, transitionTime time.Time) dberrors.Error
+	UpdateQueueState(operationType model.OperationType, paused bool) dberrors.Error
+	UpdateReadOnlyMode(enabled bool, message *string, updatedAt time.Time) dberrors.Error
+	MarkOperationAsStarted(operationID string, message string, startTime time.Time) dberrors.Error
+	InsertAuditEntry(entry model.AuditEntry) dberrors.Error
+	InsertStageDuration(duration model.StageDuration) dberrors.Error
+	InsertIdempotencyKey(idempotencyKey model.IdempotencyKey) dberrors.Error
+	DeleteExpiredIdempotencyKey(tenant, key string, createdBefore time.Time) dberrors.Error
+	UpsertOperationAnnotation(annotation model.OperationAnnotation) dberrors.Error
+	DeleteOperationAnnotation(operationID, key string) dberrors.Error
+	RepairLastOperations() (int, dberrors.Error)
 }
 
 //go:generate mockery -name=ReadWriteSession
@@ -66,32 +108,48 @@ type WriteSessionWithinTransaction interface {
 }
 
 type factory struct {
-	connection *dbr.Connection
+	connection    *dbr.Connection
+	queryTimeout  time.Duration
+	observer      *queryObserver
+	uuidGenerator uuid.UUIDGenerator
 }
 
-func NewFactory(connection *dbr.Connection) Factory {
+// NewFactory creates Factory of sessions cancelling queries exceeding the query timeout and logging queries
+// exceeding the slow query threshold, zero values disable the timeout and the logging respectively, IDs of the inserted records are created by the UUID generator
+func NewFactory(connection *dbr.Connection, queryTimeout, slowQueryThreshold time.Duration, uuidGenerator uuid.UUIDGenerator) Factory {
 	return &factory{
-		connection: connection,
+		connection:    connection,
+		queryTimeout:  queryTimeout,
+		observer:      newQueryObserver(slowQueryThreshold),
+		uuidGenerator: uuidGenerator,
 	}
 }
 
+func (sf *factory) newSession() *dbr.Session {
+	session := sf.connection.NewSession(sf.observer)
+	session.Timeout = sf.queryTimeout
+
+	return session
+}
+
 func (sf *factory) NewReadSession() ReadSession {
 	return readSession{
-		session: sf.connection.NewSession(nil),
+		session: sf.newSession(),
 	}
 }
 
 func (sf *factory) NewWriteSession() WriteSession {
 	return writeSession{
-		session: sf.connection.NewSession(nil),
+		session:       sf.newSession(),
+		uuidGenerator: sf.uuidGenerator,
 	}
 }
 
 func (sf *factory) NewReadWriteSession() ReadWriteSession {
-	session := sf.connection.NewSession(nil)
+	session := sf.newSession()
 	return readWriteSession{
 		readSession:  readSession{session: session},
-		writeSession: writeSession{session: session},
+		writeSession: writeSession{session: session, uuidGenerator: sf.uuidGenerator},
 	}
 }
 
@@ -101,15 +159,16 @@ type readWriteSession struct {
 }
 
 func (sf *factory) NewSessionWithinTransaction() (WriteSessionWithinTransaction, dberrors.Error) {
-	dbSession := sf.connection.NewSession(nil)
+	dbSession := sf.newSession()
 	dbTransaction, err := dbSession.Begin()
 
 	if err != nil {
-		return nil, dberrors.Internal("Failed to start transaction: %s", err)
+		return nil, dberrors.Classify(err, "Failed to start transaction: %s", err)
 	}
 
 	return writeSession{
-		session:     dbSession,
-		transaction: dbTransaction,
+		session:       dbSession,
+		transaction:   dbTransaction,
+		uuidGenerator: sf.uuidGenerator,
 	}, nil
 }
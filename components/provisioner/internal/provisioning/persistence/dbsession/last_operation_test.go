@@ -0,0 +1,161 @@
+package dbsession
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/database"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/testutils"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastOperation(t *testing.T) {
+	ctx := context.Background()
+
+	cleanupNetwork, err := testutils.EnsureTestNetworkForDB(t, ctx)
+	require.NoError(t, err)
+	defer cleanupNetwork()
+
+	containerCleanupFunc, connString, err := testutils.InitTestDBContainer(t, ctx, "test_DB_last_operation")
+	require.NoError(t, err)
+	defer containerCleanupFunc()
+
+	connection, err := database.InitializeDatabaseConnection(connString, 4)
+	require.NoError(t, err)
+	defer testutils.CloseDatabase(t, connection)
+
+	err = database.SetupSchema(connection, testutils.SchemaFilePath)
+	require.NoError(t, err)
+
+	uuidGenerator := uuid.NewUUIDGenerator()
+	factory := NewFactory(connection, 0, 0, uuidGenerator)
+	startTime := time.Now().UTC().Truncate(time.Second)
+
+	insertCluster := func(t *testing.T, tenant string) string {
+		cluster := model.Cluster{
+			ID:                uuidGenerator.New(),
+			Tenant:            tenant,
+			CreationTimestamp: startTime,
+		}
+		require.NoError(t, factory.NewWriteSession().InsertCluster(cluster))
+		return cluster.ID
+	}
+
+	newOperation := func(clusterID string, operationType model.OperationType, state model.OperationState, start time.Time) model.Operation {
+		return model.Operation{
+			ID:             uuidGenerator.New(),
+			Type:           operationType,
+			State:          state,
+			StartTimestamp: start,
+			ClusterID:      clusterID,
+			Stage:          model.FinishedStage,
+		}
+	}
+
+	t.Run("should keep the last operation of the cluster when operations are inserted concurrently", func(t *testing.T) {
+		// given
+		clusterID := insertCluster(t, "concurrent")
+
+		operations := make([]model.Operation, 20)
+		for i := range operations {
+			operations[i] = newOperation(clusterID, model.Upgrade, model.Succeeded, startTime.Add(time.Duration(i)*time.Minute))
+		}
+
+		// when
+		var wg sync.WaitGroup
+		for _, operation := range operations {
+			wg.Add(1)
+			go func(operation model.Operation) {
+				defer wg.Done()
+				assert.NoError(t, factory.NewWriteSession().InsertOperation(operation))
+			}(operation)
+		}
+		wg.Wait()
+
+		// then
+		clusters, dberr := factory.NewReadSession().ListClustersWithLastOperation(model.ClustersFilter{Tenant: stringPtr("concurrent")}, 10, 0)
+		require.NoError(t, dberr)
+		require.Len(t, clusters, 1)
+		require.NotNil(t, clusters[0].LastOperation)
+		assert.Equal(t, operations[len(operations)-1].ID, clusters[0].LastOperation.ID)
+
+		lastOperation, dberr := factory.NewReadSession().GetLastOperation(clusterID)
+		require.NoError(t, dberr)
+		assert.Equal(t, lastOperation.ID, clusters[0].LastOperation.ID)
+	})
+
+	t.Run("should update the last operation when it reaches terminal state", func(t *testing.T) {
+		// given
+		clusterID := insertCluster(t, "terminal")
+		operation := newOperation(clusterID, model.Provision, model.InProgress, startTime)
+		require.NoError(t, factory.NewWriteSession().InsertOperation(operation))
+
+		// when
+		dberr := factory.NewWriteSession().UpdateOperationState(operation.ID, operation.Version, "done", model.Succeeded, time.Now())
+		require.NoError(t, dberr)
+
+		// then
+		succeeded := model.Succeeded
+		clusters, dberr := factory.NewReadSession().ListClustersWithLastOperation(model.ClustersFilter{Tenant: stringPtr("terminal"), LastOperationState: &succeeded}, 10, 0)
+		require.NoError(t, dberr)
+		require.Len(t, clusters, 1)
+		assert.Equal(t, clusterID, clusters[0].Cluster.ID)
+		assert.Equal(t, model.Succeeded, clusters[0].LastOperation.State)
+	})
+
+	t.Run("should list clusters without operations", func(t *testing.T) {
+		// given
+		clusterID := insertCluster(t, "no-operations")
+
+		// when
+		clusters, dberr := factory.NewReadSession().ListClustersWithLastOperation(model.ClustersFilter{Tenant: stringPtr("no-operations")}, 10, 0)
+
+		// then
+		require.NoError(t, dberr)
+		require.Len(t, clusters, 1)
+		assert.Equal(t, clusterID, clusters[0].Cluster.ID)
+		assert.Nil(t, clusters[0].LastOperation)
+	})
+
+	t.Run("should repair drifted last operations", func(t *testing.T) {
+		// given
+		clusterID := insertCluster(t, "drift")
+		first := newOperation(clusterID, model.Provision, model.Succeeded, startTime)
+		last := newOperation(clusterID, model.Deprovision, model.InProgress, startTime.Add(time.Hour))
+		require.NoError(t, factory.NewWriteSession().InsertOperation(first))
+		require.NoError(t, factory.NewWriteSession().InsertOperation(last))
+
+		_, err := connection.NewSession(nil).
+			Update("cluster").
+			Set("last_operation_id", first.ID).
+			Where("id = ?", clusterID).
+			Exec()
+		require.NoError(t, err)
+
+		// when
+		repaired, dberr := factory.NewWriteSession().RepairLastOperations()
+
+		// then
+		require.NoError(t, dberr)
+		assert.Equal(t, 1, repaired)
+
+		deprovision := model.Deprovision
+		clusters, dberr := factory.NewReadSession().ListClustersWithLastOperation(model.ClustersFilter{Tenant: stringPtr("drift"), LastOperationType: &deprovision}, 10, 0)
+		require.NoError(t, dberr)
+		require.Len(t, clusters, 1)
+		assert.Equal(t, last.ID, clusters[0].LastOperation.ID)
+
+		repaired, dberr = factory.NewWriteSession().RepairLastOperations()
+		require.NoError(t, dberr)
+		assert.Equal(t, 0, repaired)
+	})
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
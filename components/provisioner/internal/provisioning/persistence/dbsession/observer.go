@@ -0,0 +1,89 @@
+package dbsession
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	dbr "github.com/gocraft/dbr/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const unknownQuery = "unknown"
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kcp",
+		Subsystem: "provisioner",
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of database queries by the session method executing them",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"query"})
+
+	sessionPackagePrefix = reflect.TypeOf(readSession{}).PkgPath() + "."
+)
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{queryDuration}
+}
+
+// queryObserver records the duration of queries and logs the ones exceeding the slow query threshold,
+// queries are named after the session method executing them, e.g. GetCluster
+type queryObserver struct {
+	dbr.NullEventReceiver
+
+	slowQueryThreshold time.Duration
+	log                logrus.FieldLogger
+}
+
+func newQueryObserver(slowQueryThreshold time.Duration) *queryObserver {
+	return &queryObserver{
+		slowQueryThreshold: slowQueryThreshold,
+		log:                logrus.WithField("Component", "DatabaseSession"),
+	}
+}
+
+func (o *queryObserver) TimingKv(_ string, nanoseconds int64, _ map[string]string) {
+	duration := time.Duration(nanoseconds)
+	query := queryName()
+
+	queryDuration.WithLabelValues(query).Observe(duration.Seconds())
+
+	if o.slowQueryThreshold > 0 && duration >= o.slowQueryThreshold {
+		o.log.Warnf("Slow database query %s took %s", query, duration)
+	}
+}
+
+// queryName returns the outermost session method found in the call stack, so that queries executed
+// by unexported helpers are named after the method of the session interface calling them
+func queryName() string {
+	callers := make([]uintptr, 32)
+	frames := runtime.CallersFrames(callers[:runtime.Callers(2, callers)])
+
+	query := unknownQuery
+	for {
+		frame, more := frames.Next()
+		if name, found := sessionMethodName(frame.Function); found {
+			query = name
+		}
+		if !more {
+			return query
+		}
+	}
+}
+
+// sessionMethodName extracts the method from names like dbsession.readSession.GetCluster.func1
+func sessionMethodName(function string) (string, bool) {
+	if !strings.HasPrefix(function, sessionPackagePrefix) {
+		return "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(function, sessionPackagePrefix), ".")
+	if len(parts) < 2 || (parts[0] != "readSession" && parts[0] != "writeSession") {
+		return "", false
+	}
+
+	return parts[1], true
+}
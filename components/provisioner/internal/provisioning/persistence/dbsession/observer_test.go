@@ -0,0 +1,52 @@
+package dbsession
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (r readSession) nameOfQuery() string {
+	return queryName()
+}
+
+func TestQueryName(t *testing.T) {
+	t.Run("should name query after session method", func(t *testing.T) {
+		// when
+		name := readSession{}.nameOfQuery()
+
+		// then
+		assert.Equal(t, "nameOfQuery", name)
+	})
+
+	t.Run("should return unknown when query is not executed by session", func(t *testing.T) {
+		// when
+		name := queryName()
+
+		// then
+		assert.Equal(t, unknownQuery, name)
+	})
+}
+
+func TestSessionMethodName(t *testing.T) {
+	for _, testCase := range []struct {
+		function     string
+		expectedName string
+		expectedOK   bool
+	}{
+		{function: sessionPackagePrefix + "readSession.GetCluster", expectedName: "GetCluster", expectedOK: true},
+		{function: sessionPackagePrefix + "writeSession.InsertOperation.func1", expectedName: "InsertOperation", expectedOK: true},
+		{function: sessionPackagePrefix + "(*queryObserver).TimingKv"},
+		{function: sessionPackagePrefix + "queryName"},
+		{function: "github.com/gocraft/dbr/v2.query"},
+	} {
+		t.Run(testCase.function, func(t *testing.T) {
+			// when
+			name, ok := sessionMethodName(testCase.function)
+
+			// then
+			assert.Equal(t, testCase.expectedOK, ok)
+			assert.Equal(t, testCase.expectedName, name)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package dbsession
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/database"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/testutils"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationInProgress(t *testing.T) {
+	ctx := context.Background()
+
+	cleanupNetwork, err := testutils.EnsureTestNetworkForDB(t, ctx)
+	require.NoError(t, err)
+	defer cleanupNetwork()
+
+	containerCleanupFunc, connString, err := testutils.InitTestDBContainer(t, ctx, "test_DB_operation_in_progress")
+	require.NoError(t, err)
+	defer containerCleanupFunc()
+
+	connection, err := database.InitializeDatabaseConnection(connString, 4)
+	require.NoError(t, err)
+	defer testutils.CloseDatabase(t, connection)
+
+	err = database.SetupSchema(connection, testutils.SchemaFilePath)
+	require.NoError(t, err)
+
+	uuidGenerator := uuid.NewUUIDGenerator()
+	factory := NewFactory(connection, 0, 0, uuidGenerator)
+	startTime := time.Now().UTC().Truncate(time.Second)
+
+	insertCluster := func(t *testing.T) string {
+		cluster := model.Cluster{
+			ID:                uuidGenerator.New(),
+			Tenant:            "tenant",
+			CreationTimestamp: startTime,
+		}
+		require.NoError(t, factory.NewWriteSession().InsertCluster(cluster))
+		return cluster.ID
+	}
+
+	newOperation := func(clusterID string, operationType model.OperationType, state model.OperationState) model.Operation {
+		return model.Operation{
+			ID:             uuidGenerator.New(),
+			Type:           operationType,
+			State:          state,
+			StartTimestamp: startTime,
+			ClusterID:      clusterID,
+			Stage:          model.FinishedStage,
+		}
+	}
+
+	t.Run("should start only one of the operations inserted concurrently", func(t *testing.T) {
+		// given
+		clusterID := insertCluster(t)
+		operationTypes := []model.OperationType{model.UpgradeShoot, model.Deprovision, model.Hibernate, model.Upgrade}
+
+		var started, rejected int32
+
+		// when
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(operation model.Operation, withinTransaction bool) {
+				defer wg.Done()
+
+				var dberr dberrors.Error
+				if withinTransaction {
+					dberr = insertOperationWithinTransaction(factory, operation)
+				} else {
+					dberr = factory.NewWriteSession().InsertOperation(operation)
+				}
+
+				switch {
+				case dberr == nil:
+					atomic.AddInt32(&started, 1)
+				case dberr.Code() == dberrors.CodeOperationInProgress:
+					atomic.AddInt32(&rejected, 1)
+				default:
+					assert.NoError(t, dberr)
+				}
+			}(newOperation(clusterID, operationTypes[i%len(operationTypes)], model.InProgress), i%2 == 0)
+		}
+		wg.Wait()
+
+		// then
+		assert.Equal(t, int32(1), started)
+		assert.Equal(t, int32(19), rejected)
+	})
+
+	t.Run("should not start operation while another one is pending", func(t *testing.T) {
+		// given
+		clusterID := insertCluster(t)
+		require.NoError(t, factory.NewWriteSession().InsertOperation(newOperation(clusterID, model.Provision, model.Pending)))
+
+		// when
+		dberr := factory.NewWriteSession().InsertOperation(newOperation(clusterID, model.Deprovision, model.InProgress))
+
+		// then
+		require.Error(t, dberr)
+		assert.Equal(t, dberrors.CodeOperationInProgress, dberr.Code())
+	})
+
+	t.Run("should start operation when the previous one finished", func(t *testing.T) {
+		// given
+		clusterID := insertCluster(t)
+		operation := newOperation(clusterID, model.UpgradeShoot, model.InProgress)
+		require.NoError(t, factory.NewWriteSession().InsertOperation(operation))
+		require.NoError(t, factory.NewWriteSession().UpdateOperationState(operation.ID, operation.Version, "done", model.Succeeded, time.Now()))
+
+		// when
+		dberr := factory.NewWriteSession().InsertOperation(newOperation(clusterID, model.Deprovision, model.InProgress))
+
+		// then
+		assert.NoError(t, dberr)
+	})
+
+	t.Run("should start operations of different clusters", func(t *testing.T) {
+		// given
+		firstClusterID := insertCluster(t)
+		secondClusterID := insertCluster(t)
+		require.NoError(t, factory.NewWriteSession().InsertOperation(newOperation(firstClusterID, model.UpgradeShoot, model.InProgress)))
+
+		// when
+		dberr := factory.NewWriteSession().InsertOperation(newOperation(secondClusterID, model.UpgradeShoot, model.InProgress))
+
+		// then
+		assert.NoError(t, dberr)
+	})
+}
+
+func insertOperationWithinTransaction(factory Factory, operation model.Operation) dberrors.Error {
+	txSession, dberr := factory.NewSessionWithinTransaction()
+	if dberr != nil {
+		return dberr
+	}
+	defer txSession.RollbackUnlessCommitted()
+
+	dberr = txSession.InsertOperation(operation)
+	if dberr != nil {
+		return dberr
+	}
+
+	return txSession.Commit()
+}
@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 
 	"github.com/gocraft/dbr/v2"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type readSession struct {
@@ -30,7 +33,7 @@ func (r readSession) GetTenant(runtimeID string) (string, dberrors.Error) {
 			return "", dberrors.NotFound("Cannot find Tenant for runtimeID:'%s", runtimeID)
 		}
 
-		return "", dberrors.Internal("Failed to get Tenant: %s", err)
+		return "", dberrors.Classify(err, "Failed to get Tenant: %s", err)
 	}
 	return tenant, nil
 }
@@ -50,7 +53,7 @@ func (r readSession) GetTenantForOperation(operationID string) (string, dberrors
 			return "", dberrors.NotFound("Cannot find Tenant for operationID:'%s", operationID)
 		}
 
-		return "", dberrors.Internal("Failed to get Tenant: %s", err)
+		return "", dberrors.Classify(err, "Failed to get Tenant: %s", err)
 	}
 	return tenant, nil
 }
@@ -61,7 +64,9 @@ func (r readSession) GetCluster(runtimeID string) (model.Cluster, dberrors.Error
 	err := r.session.
 		Select(
 			"id", "kubeconfig", "tenant",
-			"creation_timestamp", "deleted", "sub_account_id", "active_kyma_config_id").
+			"creation_timestamp", "deleted", "sub_account_id", "active_kyma_config_id",
+			"hibernated", "hibernated_at", "last_woken_at", "hibernation_initiated_by", "expire_at", "deprovisioning_scheduled_at",
+			"api_server_url", "ca_certificate").
 		From("cluster").
 		Where(dbr.Eq("cluster.id", runtimeID)).
 		LoadOne(&cluster)
@@ -70,7 +75,7 @@ func (r readSession) GetCluster(runtimeID string) (model.Cluster, dberrors.Error
 		if err == dbr.ErrNotFound {
 			return model.Cluster{}, dberrors.NotFound("Cannot find Cluster for runtimeID: %s", runtimeID)
 		}
-		return model.Cluster{}, dberrors.Internal("Failed to get Cluster: %s", err)
+		return model.Cluster{}, dberrors.Classify(err, "Failed to get Cluster: %s", err)
 	}
 
 	providerConfig, dberr := r.getGardenerConfig(runtimeID)
@@ -85,11 +90,13 @@ func (r readSession) GetCluster(runtimeID string) (model.Cluster, dberrors.Error
 	}
 	cluster.ClusterConfig.OIDCConfig = &oidcConfig
 
-	kymaConfig, dberr := r.getKymaConfig(runtimeID, cluster.ActiveKymaConfigId)
-	if dberr != nil {
-		return model.Cluster{}, dberr.Append("Cannot get Kyma config for runtimeID: %s", runtimeID)
+	if cluster.ActiveKymaConfigId != nil {
+		kymaConfig, dberr := r.getKymaConfig(runtimeID, *cluster.ActiveKymaConfigId)
+		if dberr != nil {
+			return model.Cluster{}, dberr.Append("Cannot get Kyma config for runtimeID: %s", runtimeID)
+		}
+		cluster.KymaConfig = &kymaConfig
 	}
-	cluster.KymaConfig = kymaConfig
 
 	clusterAdministrators, dberr := r.getClusterAdministrator(runtimeID)
 	if dberr != nil {
@@ -103,6 +110,84 @@ func (r readSession) GetCluster(runtimeID string) (model.Cluster, dberrors.Error
 	return cluster, nil
 }
 
+// GetClustersByIDs returns clusters of the given Runtimes loading each part of their configuration with a single query,
+// Runtimes which do not exist are skipped
+func (r readSession) GetClustersByIDs(runtimeIDs []string) ([]model.Cluster, dberrors.Error) {
+	clusters := []model.Cluster{}
+	if len(runtimeIDs) == 0 {
+		return clusters, nil
+	}
+
+	_, err := r.session.
+		Select(
+			"id", "kubeconfig", "tenant",
+			"creation_timestamp", "deleted", "sub_account_id", "active_kyma_config_id",
+			"hibernated", "hibernated_at", "last_woken_at", "hibernation_initiated_by", "expire_at", "deprovisioning_scheduled_at",
+			"api_server_url", "ca_certificate").
+		From("cluster").
+		Where(dbr.Eq("cluster.id", runtimeIDs)).
+		Load(&clusters)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get Clusters: %s", err)
+	}
+
+	gardenerConfigs, dberr := r.getGardenerConfigs(runtimeIDs)
+	if dberr != nil {
+		return nil, dberr.Append("Cannot get Provider configs")
+	}
+
+	gardenerConfigIDs := make([]string, 0, len(gardenerConfigs))
+	for _, gardenerConfig := range gardenerConfigs {
+		gardenerConfigIDs = append(gardenerConfigIDs, gardenerConfig.ID)
+	}
+	oidcConfigs, dberr := r.getOidcConfigs(gardenerConfigIDs)
+	if dberr != nil {
+		return nil, dberr.Append("Cannot get Oidc configs")
+	}
+
+	kymaConfigIDs := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.ActiveKymaConfigId != nil {
+			kymaConfigIDs = append(kymaConfigIDs, *cluster.ActiveKymaConfigId)
+		}
+	}
+	kymaConfigs, dberr := r.getKymaConfigs(kymaConfigIDs)
+	if dberr != nil {
+		return nil, dberr.Append("Cannot get Kyma configs")
+	}
+
+	administrators, dberr := r.getClustersAdministrators(runtimeIDs)
+	if dberr != nil {
+		return nil, dberr.Append("Cannot get Cluster administrators")
+	}
+
+	for i, cluster := range clusters {
+		gardenerConfig, found := gardenerConfigs[cluster.ID]
+		if !found {
+			return nil, dberrors.NotFound("Gardener config for %s Runtime not found", cluster.ID)
+		}
+		oidcConfig := oidcConfigs[gardenerConfig.ID]
+		gardenerConfig.OIDCConfig = &oidcConfig
+		clusters[i].ClusterConfig = gardenerConfig
+
+		if cluster.ActiveKymaConfigId != nil {
+			kymaConfig, found := kymaConfigs[*cluster.ActiveKymaConfigId]
+			if !found {
+				return nil, dberrors.NotFound("Cannot find Kyma Config for runtimeID: %s", cluster.ID)
+			}
+			clusters[i].KymaConfig = &kymaConfig
+		}
+
+		clusters[i].Administrators = administrators[cluster.ID]
+		if clusters[i].Administrators == nil {
+			clusters[i].Administrators = []string{}
+		}
+	}
+
+	return clusters, nil
+}
+
 func (r readSession) GetGardenerClusterByName(name string) (model.Cluster, dberrors.Error) {
 	var clusterWithProvider = struct {
 		model.Cluster
@@ -113,11 +198,13 @@ func (r readSession) GetGardenerClusterByName(name string) (model.Cluster, dberr
 		Select(
 			"cluster.id", "cluster.kubeconfig", "cluster.tenant",
 			"cluster.creation_timestamp", "cluster.deleted", "cluster.active_kyma_config_id",
+			"cluster.hibernated", "cluster.hibernated_at", "cluster.last_woken_at", "cluster.hibernation_initiated_by", "cluster.expire_at", "cluster.deprovisioning_scheduled_at",
+			"cluster.api_server_url", "cluster.ca_certificate",
 			"name", "project_name", "kubernetes_version",
 			"volume_size_gb", "disk_type", "machine_type", "machine_image", "machine_image_version",
 			"provider", "purpose", "seed", "target_secret", "worker_cidr", "region", "auto_scaler_min", "auto_scaler_max",
 			"max_surge", "max_unavailable", "enable_kubernetes_version_auto_update",
-			"enable_machine_image_version_auto_update", "allow_privileged_containers", "provider_specific_config").
+			"enable_machine_image_version_auto_update", "allow_privileged_containers", "provider_specific_config", "networking_type", "shoot_annotations", "dns_config", "cost_allocation", "cluster_autoscaler_config", "kube_apiserver_config", "dedicated_system_pool").
 		From("gardener_config").
 		Join("cluster", "gardener_config.cluster_id=cluster.id").
 		Where(dbr.Eq("name", name)).
@@ -128,21 +215,23 @@ func (r readSession) GetGardenerClusterByName(name string) (model.Cluster, dberr
 			return model.Cluster{}, dberrors.NotFound("Cannot find Gardener Cluster with name: %s", name)
 		}
 
-		return model.Cluster{}, dberrors.Internal("Failed to get Gardener Cluster with name: %s, error: %s", name, err)
+		return model.Cluster{}, dberrors.Classify(err, "Failed to get Gardener Cluster with name: %s, error: %s", name, err)
 	}
 	cluster := clusterWithProvider.Cluster
 
-	err = clusterWithProvider.gardenerConfigRead.DecodeProviderConfig()
+	err = clusterWithProvider.gardenerConfigRead.Decode()
 	if err != nil {
-		return model.Cluster{}, dberrors.Internal("Failed to decode Gardener provider config fetched from database: %s", err.Error())
+		return model.Cluster{}, dberrors.Internal("Failed to decode Gardener config fetched from database: %s", err.Error())
 	}
 	cluster.ClusterConfig = clusterWithProvider.gardenerConfigRead.GardenerConfig
 
-	kymaConfig, dberr := r.getKymaConfig(clusterWithProvider.Cluster.ID, cluster.ActiveKymaConfigId)
-	if dberr != nil {
-		return model.Cluster{}, dberr.Append("Cannot get Kyma config for runtimeID: %s", clusterWithProvider.Cluster.ID)
+	if cluster.ActiveKymaConfigId != nil {
+		kymaConfig, dberr := r.getKymaConfig(clusterWithProvider.Cluster.ID, *cluster.ActiveKymaConfigId)
+		if dberr != nil {
+			return model.Cluster{}, dberr.Append("Cannot get Kyma config for runtimeID: %s", clusterWithProvider.Cluster.ID)
+		}
+		cluster.KymaConfig = &kymaConfig
 	}
-	cluster.KymaConfig = kymaConfig
 
 	return cluster, nil
 }
@@ -246,7 +335,7 @@ func (r readSession) getKymaConfig(runtimeID, kymaConfigId string) (model.KymaCo
 		Load(&kymaConfig)
 
 	if err != nil {
-		return model.KymaConfig{}, dberrors.Internal("Failed to get Kyma Config: %s", err)
+		return model.KymaConfig{}, dberrors.Classify(err, "Failed to get Kyma Config: %s", err)
 	}
 
 	if rowsCount == 0 {
@@ -256,6 +345,71 @@ func (r readSession) getKymaConfig(runtimeID, kymaConfigId string) (model.KymaCo
 	return kymaConfig.parseToKymaConfig(runtimeID)
 }
 
+// getKymaConfigs returns Kyma configs by their IDs
+func (r readSession) getKymaConfigs(kymaConfigIDs []string) (map[string]model.KymaConfig, dberrors.Error) {
+	var rows kymaConfigDTO
+
+	_, err := r.session.
+		Select("kyma_config_id", "kyma_config.release_id", "kyma_config.profile", "kyma_config.global_configuration",
+			"kyma_component_config.id", "kyma_component_config.component", "kyma_component_config.namespace",
+			"kyma_component_config.source_url", "kyma_component_config.configuration",
+			"kyma_component_config.component_order",
+			"cluster_id",
+			"kyma_release.version", "kyma_release.tiller_yaml", "kyma_release.installer_yaml").
+		From("cluster").
+		Join("kyma_config", "cluster.id=kyma_config.cluster_id").
+		Join("kyma_component_config", "kyma_config.id=kyma_component_config.kyma_config_id").
+		Join("kyma_release", "kyma_config.release_id=kyma_release.id").
+		Where(dbr.Eq("kyma_config.id", kymaConfigIDs)).
+		Load(&rows)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get Kyma Configs: %s", err)
+	}
+
+	rowsByKymaConfig := make(map[string]kymaConfigDTO)
+	for _, row := range rows {
+		rowsByKymaConfig[row.KymaConfigID] = append(rowsByKymaConfig[row.KymaConfigID], row)
+	}
+
+	kymaConfigs := make(map[string]model.KymaConfig, len(rowsByKymaConfig))
+	for kymaConfigID, kymaConfigRows := range rowsByKymaConfig {
+		kymaConfig, dberr := kymaConfigRows.parseToKymaConfig(kymaConfigRows[0].ClusterID)
+		if dberr != nil {
+			return nil, dberr
+		}
+		kymaConfigs[kymaConfigID] = kymaConfig
+	}
+
+	return kymaConfigs, nil
+}
+
+// getClustersAdministrators returns emails of administrators by the cluster ID
+func (r readSession) getClustersAdministrators(runtimeIDs []string) (map[string][]string, dberrors.Error) {
+	var clusterAdministrators []model.ClusterAdministrator
+
+	_, err := r.session.
+		Select("*").
+		From("cluster_Administrator").
+		Where(dbr.Eq("cluster_id", runtimeIDs)).
+		Load(&clusterAdministrators)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get Cluster Administrators: %s", err)
+	}
+
+	administrators := make(map[string][]string)
+	for _, administrator := range clusterAdministrators {
+		if administrator.ClusterId == nil {
+			continue
+		}
+		clusterID := *administrator.ClusterId
+		administrators[clusterID] = append(administrators[clusterID], administrator.Email)
+	}
+
+	return administrators, nil
+}
+
 func (r readSession) getClusterAdministrator(runtimeID string) ([]model.ClusterAdministrator, dberrors.Error) {
 	var clusterAdministrator []model.ClusterAdministrator
 
@@ -266,7 +420,7 @@ func (r readSession) getClusterAdministrator(runtimeID string) ([]model.ClusterA
 		Load(&clusterAdministrator)
 
 	if err != nil {
-		return []model.ClusterAdministrator{}, dberrors.Internal("Failed to get Cluster Administrators: %s", err)
+		return []model.ClusterAdministrator{}, dberrors.Classify(err, "Failed to get Cluster Administrators: %s", err)
 	}
 
 	return clusterAdministrator, nil
@@ -274,19 +428,81 @@ func (r readSession) getClusterAdministrator(runtimeID string) ([]model.ClusterA
 
 type gardenerConfigRead struct {
 	model.GardenerConfig
-	ProviderSpecificConfig string `db:"provider_specific_config"`
+	ProviderSpecificConfig string  `db:"provider_specific_config"`
+	ShootAnnotationsJSON   []byte  `db:"shoot_annotations"`
+	DNSConfigJSON          []byte  `db:"dns_config"`
+	CostAllocationJSON     []byte  `db:"cost_allocation"`
+	ClusterAutoscalerJSON  []byte  `db:"cluster_autoscaler_config"`
+	KubeAPIServerJSON      []byte  `db:"kube_apiserver_config"`
+	SystemWorkerPoolJSON   []byte  `db:"dedicated_system_pool"`
+	MaxSurgeValue          *string `db:"max_surge"`
+	MaxUnavailableValue    *string `db:"max_unavailable"`
 }
 
-func (gcr *gardenerConfigRead) DecodeProviderConfig() error {
+func (gcr *gardenerConfigRead) Decode() error {
 	gardenerConfigProviderConfig, err := model.NewGardenerProviderConfigFromJSON(gcr.ProviderSpecificConfig)
 	if err != nil {
 		return fmt.Errorf("error decoding Gardener provider config: %s", err.Error())
 	}
-
 	gcr.GardenerProviderConfig = gardenerConfigProviderConfig
+
+	// Clusters provisioned before the Shoot annotations were introduced have no value
+	if len(gcr.ShootAnnotationsJSON) > 0 {
+		if err := json.Unmarshal(gcr.ShootAnnotationsJSON, &gcr.ShootAnnotations); err != nil {
+			return fmt.Errorf("error decoding Shoot annotations: %s", err.Error())
+		}
+	}
+
+	// Clusters provisioned before the custom domains were introduced have no value
+	if len(gcr.DNSConfigJSON) > 0 {
+		if err := json.Unmarshal(gcr.DNSConfigJSON, &gcr.DNSConfig); err != nil {
+			return fmt.Errorf("error decoding DNS config: %s", err.Error())
+		}
+	}
+
+	// Clusters provisioned before the cost allocation labels were introduced have no value
+	if len(gcr.CostAllocationJSON) > 0 {
+		if err := json.Unmarshal(gcr.CostAllocationJSON, &gcr.CostAllocation); err != nil {
+			return fmt.Errorf("error decoding cost allocation: %s", err.Error())
+		}
+	}
+
+	// Clusters provisioned before the cluster autoscaler settings were introduced have no value
+	if len(gcr.ClusterAutoscalerJSON) > 0 {
+		if err := json.Unmarshal(gcr.ClusterAutoscalerJSON, &gcr.ClusterAutoscalerConfig); err != nil {
+			return fmt.Errorf("error decoding cluster autoscaler config: %s", err.Error())
+		}
+	}
+
+	// Clusters provisioned before the kube-apiserver settings were introduced have no value
+	if len(gcr.KubeAPIServerJSON) > 0 {
+		if err := json.Unmarshal(gcr.KubeAPIServerJSON, &gcr.KubeAPIServerConfig); err != nil {
+			return fmt.Errorf("error decoding kube-apiserver config: %s", err.Error())
+		}
+	}
+
+	// Clusters provisioned without the dedicated system pool have no value
+	if len(gcr.SystemWorkerPoolJSON) > 0 {
+		if err := json.Unmarshal(gcr.SystemWorkerPoolJSON, &gcr.DedicatedSystemPool); err != nil {
+			return fmt.Errorf("error decoding dedicated system pool: %s", err.Error())
+		}
+	}
+
+	gcr.MaxSurge = intOrStringFromDB(gcr.MaxSurgeValue)
+	gcr.MaxUnavailable = intOrStringFromDB(gcr.MaxUnavailableValue)
+
 	return nil
 }
 
+// intOrStringFromDB returns nil if the value is not set, numbers are stored as strings as well
+func intOrStringFromDB(value *string) *intstr.IntOrString {
+	if value == nil {
+		return nil
+	}
+
+	return util.IntOrStringPtr(intstr.Parse(*value))
+}
+
 func (r readSession) getGardenerConfig(runtimeID string) (model.GardenerConfig, dberrors.Error) {
 	gardenerConfig := gardenerConfigRead{}
 
@@ -295,7 +511,7 @@ func (r readSession) getGardenerConfig(runtimeID string) (model.GardenerConfig,
 			"volume_size_gb", "disk_type", "machine_type", "machine_image", "machine_image_version", "provider", "purpose", "seed",
 			"target_secret", "worker_cidr", "region", "auto_scaler_min", "auto_scaler_max",
 			"max_surge", "max_unavailable", "enable_kubernetes_version_auto_update",
-			"enable_machine_image_version_auto_update", "allow_privileged_containers", "provider_specific_config").
+			"enable_machine_image_version_auto_update", "allow_privileged_containers", "provider_specific_config", "networking_type", "shoot_annotations", "dns_config", "cost_allocation", "cluster_autoscaler_config", "kube_apiserver_config", "dedicated_system_pool").
 		From("cluster").
 		Join("gardener_config", "cluster.id=gardener_config.cluster_id").
 		Where(dbr.Eq("cluster.id", runtimeID)).
@@ -306,20 +522,68 @@ func (r readSession) getGardenerConfig(runtimeID string) (model.GardenerConfig,
 			return model.GardenerConfig{}, dberrors.NotFound("Gardener config for %s Runtime not found: %s", runtimeID, err.Error())
 		}
 
-		return model.GardenerConfig{}, dberrors.Internal("Failed to get Gardener config for %s Runtime: %s", runtimeID, err.Error())
+		return model.GardenerConfig{}, dberrors.Classify(err, "Failed to get Gardener config for %s Runtime: %s", runtimeID, err.Error())
 	}
 
-	err = gardenerConfig.DecodeProviderConfig()
+	err = gardenerConfig.Decode()
 	if err != nil {
-		return model.GardenerConfig{}, dberrors.Internal("Failed to decode Gardener provider config fetched from database: %s", err.Error())
+		return model.GardenerConfig{}, dberrors.Internal("Failed to decode Gardener config fetched from database: %s", err.Error())
 	}
 
 	return gardenerConfig.GardenerConfig, nil
 }
 
+// getGardenerConfigs returns Gardener configs by the cluster ID
+func (r readSession) getGardenerConfigs(runtimeIDs []string) (map[string]model.GardenerConfig, dberrors.Error) {
+	var gardenerConfigs []gardenerConfigRead
+
+	_, err := r.session.
+		Select("gardener_config.id", "cluster_id", "gardener_config.name", "project_name", "kubernetes_version",
+			"volume_size_gb", "disk_type", "machine_type", "machine_image", "machine_image_version", "provider", "purpose", "seed",
+			"target_secret", "worker_cidr", "region", "auto_scaler_min", "auto_scaler_max",
+			"max_surge", "max_unavailable", "enable_kubernetes_version_auto_update",
+			"enable_machine_image_version_auto_update", "allow_privileged_containers", "provider_specific_config", "networking_type", "shoot_annotations", "dns_config", "cost_allocation", "cluster_autoscaler_config", "kube_apiserver_config", "dedicated_system_pool").
+		From("cluster").
+		Join("gardener_config", "cluster.id=gardener_config.cluster_id").
+		Where(dbr.Eq("cluster.id", runtimeIDs)).
+		Load(&gardenerConfigs)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get Gardener configs: %s", err.Error())
+	}
+
+	configs := make(map[string]model.GardenerConfig, len(gardenerConfigs))
+	for _, gardenerConfig := range gardenerConfigs {
+		err = gardenerConfig.Decode()
+		if err != nil {
+			return nil, dberrors.Internal("Failed to decode Gardener config fetched from database: %s", err.Error())
+		}
+		configs[gardenerConfig.ClusterID] = gardenerConfig.GardenerConfig
+	}
+
+	return configs, nil
+}
+
 var (
 	operationColumns = []string{
-		"id", "type", "start_timestamp", "stage", "end_timestamp", "state", "message", "cluster_id", "last_transition",
+		"id", "type", "start_timestamp", "stage", "end_timestamp", "state", "message", "cluster_id", "last_transition", "force", "version",
+		"installation_timeout_minutes", "diagnostics", "installation_triggered_at", "triggered_by", "claimed_by", "claim_expires_at",
+		"failure_reason",
+	}
+	auditEntryColumns = []string{
+		"id", "tenant", "sub_account_id", "mutation", "input", "operation_id", "created_at",
+	}
+
+	stageDurationColumns = []string{
+		"operation_id", "operation_type", "stage", "duration_seconds", "finished_at",
+	}
+
+	idempotencyKeyColumns = []string{
+		"tenant", "key", "operation_type", "operation_id", "created_at",
+	}
+
+	operationAnnotationColumns = []string{
+		"operation_id", "key", "value", "updated_at",
 	}
 )
 
@@ -336,7 +600,7 @@ func (r readSession) GetOperation(operationID string) (model.Operation, dberrors
 		if err == dbr.ErrNotFound {
 			return model.Operation{}, dberrors.NotFound("Operation not found for id: %s", operationID)
 		}
-		return model.Operation{}, dberrors.Internal("Failed to get %s operation: %s", operationID, err)
+		return model.Operation{}, dberrors.Classify(err, "Failed to get %s operation: %s", operationID, err)
 	}
 
 	return operation, nil
@@ -360,12 +624,188 @@ func (r readSession) GetLastOperation(runtimeID string) (model.Operation, dberro
 		if err == dbr.ErrNotFound {
 			return model.Operation{}, dberrors.NotFound("Last operation not found for runtime: %s", runtimeID)
 		}
-		return model.Operation{}, dberrors.Internal("Failed to get last operation: %s", err)
+		return model.Operation{}, dberrors.Classify(err, "Failed to get last operation: %s", err)
 	}
 
 	return operation, nil
 }
 
+// GetLastOperationsByRuntimeIDs returns the last operation of each of the given Runtimes by the Runtime ID
+func (r readSession) GetLastOperationsByRuntimeIDs(runtimeIDs []string) (map[string]model.Operation, dberrors.Error) {
+	lastOperations := make(map[string]model.Operation, len(runtimeIDs))
+	if len(runtimeIDs) == 0 {
+		return lastOperations, nil
+	}
+
+	lastOperationDatesSelect := r.session.
+		Select("cluster_id", "MAX(start_timestamp)").
+		From("operation").
+		Where(dbr.Eq("cluster_id", runtimeIDs)).
+		GroupBy("cluster_id")
+
+	var operations []model.Operation
+
+	_, err := r.session.
+		Select(operationColumns...).
+		From("operation").
+		Where("(cluster_id, start_timestamp) IN ?", lastOperationDatesSelect).
+		Load(&operations)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get last operations: %s", err)
+	}
+
+	for _, operation := range operations {
+		lastOperations[operation.ClusterID] = operation
+	}
+
+	return lastOperations, nil
+}
+
+// ListClustersWithLastOperation returns clusters without their configuration with the last operations, which are stored
+// with the clusters, so that the state of many Runtimes is listed without looking for the last operation of each of them
+func (r readSession) ListClustersWithLastOperation(filter model.ClustersFilter, limit, offset int) ([]model.ClusterWithLastOperation, dberrors.Error) {
+	var clusters []struct {
+		model.Cluster
+		LastOperationID *string
+	}
+
+	query := r.session.
+		Select(
+			"cluster.id", "cluster.kubeconfig", "cluster.tenant",
+			"cluster.creation_timestamp", "cluster.deleted", "cluster.sub_account_id", "cluster.active_kyma_config_id",
+			"cluster.hibernated", "cluster.hibernated_at", "cluster.last_woken_at", "cluster.hibernation_initiated_by", "cluster.expire_at", "cluster.deprovisioning_scheduled_at",
+			"cluster.last_operation_id").
+		From("cluster").
+		LeftJoin("operation", "operation.id = cluster.last_operation_id")
+
+	query = filterClusters(query, filter)
+	if filter.LastOperationType != nil {
+		query = query.Where(dbr.Eq("operation.type", *filter.LastOperationType))
+	}
+	if filter.LastOperationState != nil {
+		query = query.Where(dbr.Eq("operation.state", *filter.LastOperationState))
+	}
+
+	_, err := query.
+		OrderAsc("cluster.creation_timestamp").
+		OrderAsc("cluster.id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		Load(&clusters)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list clusters with last operations: %s", err)
+	}
+
+	lastOperationIDs := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.LastOperationID != nil {
+			lastOperationIDs = append(lastOperationIDs, *cluster.LastOperationID)
+		}
+	}
+
+	lastOperations := make(map[string]model.Operation, len(lastOperationIDs))
+	if len(lastOperationIDs) > 0 {
+		var operations []model.Operation
+
+		_, err = r.session.
+			Select(operationColumns...).
+			From("operation").
+			Where(dbr.Eq("id", lastOperationIDs)).
+			Load(&operations)
+
+		if err != nil {
+			return nil, dberrors.Classify(err, "Failed to get last operations: %s", err)
+		}
+
+		for _, operation := range operations {
+			lastOperations[operation.ID] = operation
+		}
+	}
+
+	result := make([]model.ClusterWithLastOperation, 0, len(clusters))
+	for _, cluster := range clusters {
+		clusterWithLastOperation := model.ClusterWithLastOperation{Cluster: cluster.Cluster}
+		if cluster.LastOperationID != nil {
+			if operation, found := lastOperations[*cluster.LastOperationID]; found {
+				clusterWithLastOperation.LastOperation = &operation
+			}
+		}
+		result = append(result, clusterWithLastOperation)
+	}
+
+	return result, nil
+}
+
+// StreamClusters calls fn with each cluster matching the filter, ordered by the Runtime ID. The clusters are loaded
+// in batches of the given size, so that all Runtimes are processed without loading them into memory at once.
+// The last operation fields of the filter are ignored. The error returned by fn stops the iteration and is returned as is.
+func (r readSession) StreamClusters(filter model.ClustersFilter, batchSize int, fn func(cluster model.Cluster) error) error {
+	lastID := ""
+	for {
+		var runtimeIDs []string
+
+		query := r.session.
+			Select("cluster.id").
+			From("cluster")
+		if lastID != "" {
+			query = query.Where(dbr.Gt("cluster.id", lastID))
+		}
+
+		_, err := filterClusters(query, filter).
+			OrderAsc("cluster.id").
+			Limit(uint64(batchSize)).
+			Load(&runtimeIDs)
+
+		if err != nil {
+			return dberrors.Classify(err, "Failed to list Runtime IDs: %s", err)
+		}
+		if len(runtimeIDs) == 0 {
+			return nil
+		}
+
+		clusters, dberr := r.GetClustersByIDs(runtimeIDs)
+		if dberr != nil {
+			return dberr.Append("Cannot get Clusters after Runtime ID %s", lastID)
+		}
+		sort.Slice(clusters, func(i, j int) bool {
+			return clusters[i].ID < clusters[j].ID
+		})
+
+		for _, cluster := range clusters {
+			if err := fn(cluster); err != nil {
+				return err
+			}
+		}
+
+		if len(runtimeIDs) < batchSize {
+			return nil
+		}
+		lastID = runtimeIDs[len(runtimeIDs)-1]
+	}
+}
+
+func filterClusters(query *dbr.SelectStmt, filter model.ClustersFilter) *dbr.SelectStmt {
+	if filter.Tenant != nil {
+		query = query.Where(dbr.Eq("cluster.tenant", *filter.Tenant))
+	}
+	if filter.SubAccountID != nil {
+		query = query.Where(dbr.Eq("cluster.sub_account_id", *filter.SubAccountID))
+	}
+	if filter.Deleted != nil {
+		query = query.Where(dbr.Eq("cluster.deleted", *filter.Deleted))
+	}
+	if filter.Provider != nil {
+		query = query.Where("cluster.id IN (SELECT cluster_id FROM gardener_config WHERE provider = ?)", *filter.Provider)
+	}
+	if filter.Region != nil {
+		query = query.Where("cluster.id IN (SELECT cluster_id FROM gardener_config WHERE region = ?)", *filter.Region)
+	}
+
+	return query
+}
+
 func (r readSession) ListInProgressOperations() ([]model.Operation, dberrors.Error) {
 	var operations []model.Operation
 
@@ -379,12 +819,183 @@ func (r readSession) ListInProgressOperations() ([]model.Operation, dberrors.Err
 		if err == dbr.ErrNotFound {
 			return []model.Operation{}, nil
 		}
-		return nil, dberrors.Internal("Failed to list In Progress operation: %s", err)
+		return nil, dberrors.Classify(err, "Failed to list In Progress operation: %s", err)
 	}
 
 	return operations, nil
 }
 
+func (r readSession) ListPendingOperations(operationType model.OperationType) ([]model.Operation, dberrors.Error) {
+	var operations []model.Operation
+
+	_, err := r.session.
+		Select(operationColumns...).
+		From("operation").
+		Where(dbr.And(dbr.Eq("state", model.Pending), dbr.Eq("type", operationType))).
+		OrderAsc("start_timestamp").
+		Load(&operations)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return []model.Operation{}, nil
+		}
+		return nil, dberrors.Classify(err, "Failed to list Pending operations: %s", err)
+	}
+
+	return operations, nil
+}
+
+// ListFailedLastOperations returns the failed operations matching the filter which are the last operations of the clusters
+// not marked as deleted, starting from the one which failed first
+func (r readSession) ListFailedLastOperations(filter model.FailedOperationsFilter) ([]model.Operation, dberrors.Error) {
+	var operations []model.Operation
+
+	columns := make([]string, 0, len(operationColumns))
+	for _, column := range operationColumns {
+		columns = append(columns, "operation."+column)
+	}
+
+	query := r.session.
+		Select(columns...).
+		From("operation").
+		Join("cluster", "cluster.last_operation_id = operation.id").
+		Where(dbr.And(dbr.Eq("operation.state", model.Failed), dbr.Eq("cluster.deleted", false)))
+
+	if filter.Type != nil {
+		query = query.Where(dbr.Eq("operation.type", *filter.Type))
+	}
+	if filter.FailedAfter != nil {
+		query = query.Where(dbr.Gte("operation.end_timestamp", *filter.FailedAfter))
+	}
+	if filter.ErrorReason != nil {
+		query = query.Where("operation.message ILIKE ?", "%"+escapeLikePattern(*filter.ErrorReason)+"%")
+	}
+
+	_, err := query.
+		OrderAsc("operation.end_timestamp").
+		OrderAsc("operation.id").
+		Load(&operations)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list failed operations: %s", err)
+	}
+
+	return operations, nil
+}
+
+// escapeLikePattern escapes the wildcards of the LIKE pattern, so that the text is matched literally
+func escapeLikePattern(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
+
+func (r readSession) ListOperationsByRuntimeID(runtimeID string, limit, offset int) ([]model.Operation, dberrors.Error) {
+	var operations []model.Operation
+
+	_, err := r.session.
+		Select(operationColumns...).
+		From("operation").
+		Where(dbr.Eq("cluster_id", runtimeID)).
+		OrderDesc("start_timestamp").
+		OrderDesc("id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		Load(&operations)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list operations for runtime %s: %s", runtimeID, err)
+	}
+
+	return operations, nil
+}
+
+func (r readSession) OperationsCountByRuntimeID(runtimeID string) (int, dberrors.Error) {
+	var count int
+
+	err := r.session.
+		Select("count(*)").
+		From("operation").
+		Where(dbr.Eq("cluster_id", runtimeID)).
+		LoadOne(&count)
+
+	if err != nil {
+		return 0, dberrors.Classify(err, "Failed to count operations for runtime %s: %s", runtimeID, err)
+	}
+
+	return count, nil
+}
+
+func (r readSession) ListAuditEntries(filter model.AuditEntriesFilter, limit, offset int) ([]model.AuditEntry, dberrors.Error) {
+	var entries []model.AuditEntry
+
+	query := r.session.
+		Select(auditEntryColumns...).
+		From("api_audit_log")
+
+	if filter.Tenant != nil {
+		query = query.Where(dbr.Eq("tenant", *filter.Tenant))
+	}
+	if filter.Mutation != nil {
+		query = query.Where(dbr.Eq("mutation", *filter.Mutation))
+	}
+	if filter.OperationID != nil {
+		query = query.Where(dbr.Eq("operation_id", *filter.OperationID))
+	}
+	if filter.From != nil {
+		query = query.Where(dbr.Gte("created_at", *filter.From))
+	}
+	if filter.To != nil {
+		query = query.Where(dbr.Lt("created_at", *filter.To))
+	}
+
+	_, err := query.
+		OrderDesc("created_at").
+		OrderDesc("id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		Load(&entries)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list audit entries: %s", err)
+	}
+
+	return entries, nil
+}
+
+func (r readSession) GetStageDurationStats(operationType model.OperationType, sampleSize int) (map[model.OperationStage]model.StageDurationStats, dberrors.Error) {
+	var durations []struct {
+		Stage          string
+		AverageSeconds float64
+		Samples        int
+	}
+
+	recentDurations := r.session.
+		Select("stage", "duration_seconds", "row_number() OVER (PARTITION BY stage ORDER BY finished_at DESC) AS position").
+		From("stage_duration").
+		Where(dbr.Eq("operation_type", operationType)).
+		As("recent_durations")
+
+	_, err := r.session.
+		Select("stage", "avg(duration_seconds) AS average_seconds", "count(*) AS samples").
+		From(recentDurations).
+		Where(dbr.Lte("position", sampleSize)).
+		GroupBy("stage").
+		Load(&durations)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get stage duration stats: %s", err)
+	}
+
+	stats := make(map[model.OperationStage]model.StageDurationStats, len(durations))
+	for _, duration := range durations {
+		stats[model.OperationStage(duration.Stage)] = model.StageDurationStats{
+			AverageDuration: time.Duration(duration.AverageSeconds * float64(time.Second)),
+			Samples:         duration.Samples,
+		}
+	}
+
+	return stats, nil
+}
+
 func (r readSession) GetRuntimeUpgrade(operationId string) (model.RuntimeUpgrade, dberrors.Error) {
 	var runtimeUpgrade model.RuntimeUpgrade
 
@@ -398,7 +1009,7 @@ func (r readSession) GetRuntimeUpgrade(operationId string) (model.RuntimeUpgrade
 		if err == dbr.ErrNotFound {
 			return model.RuntimeUpgrade{}, dberrors.NotFound("Runtime upgrade not found for operation with %s id", operationId)
 		}
-		return model.RuntimeUpgrade{}, dberrors.Internal("Failed to get Runtime upgrade for operation %s: %s", operationId, err)
+		return model.RuntimeUpgrade{}, dberrors.Classify(err, "Failed to get Runtime upgrade for operation %s: %s", operationId, err)
 	}
 
 	return runtimeUpgrade, nil
@@ -420,7 +1031,7 @@ func (r readSession) InProgressOperationsCount() (model.OperationsCount, dberror
 		if err == dbr.ErrNotFound {
 			return model.OperationsCount{}, dberrors.NotFound("Operations not found: %s", err.Error())
 		}
-		return model.OperationsCount{}, dberrors.Internal("Failed to count operations in progress: %s", err.Error())
+		return model.OperationsCount{}, dberrors.Classify(err, "Failed to count operations in progress: %s", err.Error())
 	}
 
 	operationsCount := model.OperationsCount{
@@ -433,6 +1044,231 @@ func (r readSession) InProgressOperationsCount() (model.OperationsCount, dberror
 	return operationsCount, nil
 }
 
+func (r readSession) CountClustersGroupedBy() (model.ClustersCount, dberrors.Error) {
+	var clustersCount []struct {
+		Provider    string
+		Region      string
+		KymaVersion string
+		Count       int
+	}
+
+	_, err := r.session.
+		Select("gardener_config.provider", "gardener_config.region", "kyma_release.version AS kyma_version", "count(*)").
+		From("cluster").
+		Join("gardener_config", "gardener_config.cluster_id=cluster.id").
+		Join("kyma_config", "cluster.active_kyma_config_id=kyma_config.id").
+		Join("kyma_release", "kyma_config.release_id=kyma_release.id").
+		Where(dbr.Eq("cluster.deleted", false)).
+		GroupBy("gardener_config.provider", "gardener_config.region", "kyma_release.version").
+		Load(&clustersCount)
+
+	if err != nil {
+		return model.ClustersCount{}, dberrors.Classify(err, "Failed to count clusters: %s", err.Error())
+	}
+
+	count := model.ClustersCount{
+		Count: make(map[model.ClusterGroup]int, len(clustersCount)),
+	}
+	for _, group := range clustersCount {
+		count.Count[model.ClusterGroup{
+			Provider:    group.Provider,
+			Region:      group.Region,
+			KymaVersion: group.KymaVersion,
+		}] = group.Count
+	}
+
+	return count, nil
+}
+
+// ListActiveShootNames returns names of the Shoots of clusters which are not deleted
+func (r readSession) ListActiveShootNames() ([]string, dberrors.Error) {
+	var names []string
+
+	_, err := r.session.
+		Select("DISTINCT gardener_config.name").
+		From("cluster").
+		Join("gardener_config", "gardener_config.cluster_id=cluster.id").
+		Where(dbr.Eq("cluster.deleted", false)).
+		Load(&names)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list Shoot names of active clusters: %s", err.Error())
+	}
+
+	return names, nil
+}
+
+// ListExpiredClusters returns at most limit clusters, which are not deleted and expired before the given time,
+// ordered from the longest expired
+// ListDeprovisioningSchedules returns the deprovisioning schedules of the clusters which are not deleted, ordered by the scheduled time
+func (r readSession) ListDeprovisioningSchedules() ([]model.DeprovisioningSchedule, dberrors.Error) {
+	var schedules []struct {
+		ID                        string
+		DeprovisioningScheduledAt time.Time
+	}
+
+	_, err := r.session.
+		Select("id", "deprovisioning_scheduled_at").
+		From("cluster").
+		Where(dbr.And(
+			dbr.Eq("deleted", false),
+			dbr.Neq("deprovisioning_scheduled_at", nil),
+		)).
+		OrderAsc("deprovisioning_scheduled_at").
+		Load(&schedules)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list deprovisioning schedules: %s", err.Error())
+	}
+
+	result := make([]model.DeprovisioningSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		result = append(result, model.DeprovisioningSchedule{
+			RuntimeID:   schedule.ID,
+			ScheduledAt: schedule.DeprovisioningScheduledAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (r readSession) ListExpiredClusters(now time.Time, limit int) ([]model.Cluster, dberrors.Error) {
+	var runtimeIDs []string
+
+	_, err := r.session.
+		Select("id").
+		From("cluster").
+		Where(dbr.And(
+			dbr.Eq("deleted", false),
+			dbr.Lte("expire_at", now),
+		)).
+		OrderAsc("expire_at").
+		Limit(uint64(limit)).
+		Load(&runtimeIDs)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list expired clusters: %s", err.Error())
+	}
+
+	clusters, dberr := r.GetClustersByIDs(runtimeIDs)
+	if dberr != nil {
+		return nil, dberr.Append("Cannot get expired clusters")
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ExpireAt.Before(*clusters[j].ExpireAt)
+	})
+
+	return clusters, nil
+}
+
+func (r readSession) InProgressOperationsCountForTenant(tenant string, operationType model.OperationType) (int, dberrors.Error) {
+	var count int
+
+	err := r.session.
+		Select("count(*)").
+		From("operation").
+		Join("cluster", "operation.cluster_id=cluster.id").
+		Where(dbr.And(
+			dbr.Eq("cluster.tenant", tenant),
+			dbr.Eq("operation.type", operationType),
+			dbr.Eq("operation.state", model.InProgress),
+		)).
+		LoadOne(&count)
+
+	if err != nil {
+		return 0, dberrors.Classify(err, "Failed to count %s operations in progress for tenant %s: %s", operationType, tenant, err)
+	}
+
+	return count, nil
+}
+
+func (r readSession) ListQueueStates() ([]model.QueueState, dberrors.Error) {
+	var queueStates []model.QueueState
+
+	_, err := r.session.
+		Select("operation_type", "paused").
+		From("operation_queue_state").
+		Load(&queueStates)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return []model.QueueState{}, nil
+		}
+		return nil, dberrors.Classify(err, "Failed to list operation queue states: %s", err)
+	}
+
+	return queueStates, nil
+}
+
+func (r readSession) GetReadOnlyMode() (model.ReadOnlyMode, dberrors.Error) {
+	var mode model.ReadOnlyMode
+
+	err := r.session.
+		Select("enabled", "message").
+		From("read_only_mode").
+		LoadOne(&mode)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return model.ReadOnlyMode{}, nil
+		}
+		return model.ReadOnlyMode{}, dberrors.Classify(err, "Failed to get read-only mode: %s", err)
+	}
+
+	return mode, nil
+}
+
+// getOidcConfigs returns OIDC configs by the Gardener config ID, OIDC config and Gardener config share the ID
+func (r readSession) getOidcConfigs(gardenerConfigIDs []string) (map[string]model.OIDCConfig, dberrors.Error) {
+	oidcConfigs := make(map[string]model.OIDCConfig, len(gardenerConfigIDs))
+	if len(gardenerConfigIDs) == 0 {
+		return oidcConfigs, nil
+	}
+
+	var oidcRows []struct {
+		model.OIDCConfig
+		GardenerConfigID string `db:"gardener_config_id"`
+	}
+
+	_, err := r.session.
+		Select("*").
+		From("oidc_config").
+		Where(dbr.Eq("gardener_config_id", gardenerConfigIDs)).
+		Load(&oidcRows)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get oidc: %s", err)
+	}
+
+	var algorithmRows []struct {
+		OidcConfigID string `db:"oidc_config_id"`
+		Algorithm    string `db:"algorithm"`
+	}
+
+	_, err = r.session.
+		Select("oidc_config_id", "algorithm").
+		From("signing_algorithms").
+		Where(dbr.Eq("oidc_config_id", gardenerConfigIDs)).
+		Load(&algorithmRows)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to get algorithm: %s", err)
+	}
+
+	algorithms := make(map[string][]string)
+	for _, row := range algorithmRows {
+		algorithms[row.OidcConfigID] = append(algorithms[row.OidcConfigID], row.Algorithm)
+	}
+
+	for _, row := range oidcRows {
+		oidcConfig := row.OIDCConfig
+		oidcConfig.SigningAlgs = algorithms[row.GardenerConfigID]
+		oidcConfigs[row.GardenerConfigID] = oidcConfig
+	}
+
+	return oidcConfigs, nil
+}
+
 func (r readSession) getOidcConfig(gardenerConfigID string) (model.OIDCConfig, dberrors.Error) {
 	var oidc model.OIDCConfig
 	var algorithms []string
@@ -444,7 +1280,7 @@ func (r readSession) getOidcConfig(gardenerConfigID string) (model.OIDCConfig, d
 		Load(&oidc)
 
 	if err != nil {
-		return model.OIDCConfig{}, dberrors.Internal("Failed to get oidc: %s", err)
+		return model.OIDCConfig{}, dberrors.Classify(err, "Failed to get oidc: %s", err)
 	}
 
 	_, err = r.session.
@@ -454,10 +1290,48 @@ func (r readSession) getOidcConfig(gardenerConfigID string) (model.OIDCConfig, d
 		Load(&algorithms)
 
 	if err != nil {
-		return model.OIDCConfig{}, dberrors.Internal("Failed to get algorithm: %s", err)
+		return model.OIDCConfig{}, dberrors.Classify(err, "Failed to get algorithm: %s", err)
 	}
 
 	oidc.SigningAlgs = algorithms
 
 	return oidc, nil
 }
+
+// ListOperationAnnotations returns annotations of the operation ordered by the key
+func (r readSession) ListOperationAnnotations(operationID string) ([]model.OperationAnnotation, dberrors.Error) {
+	var annotations []model.OperationAnnotation
+
+	_, err := r.session.
+		Select(operationAnnotationColumns...).
+		From("operation_annotations").
+		Where(dbr.Eq("operation_id", operationID)).
+		OrderBy("key").
+		Load(&annotations)
+
+	if err != nil {
+		return nil, dberrors.Classify(err, "Failed to list annotations of operation %s: %s", operationID, err)
+	}
+
+	return annotations, nil
+}
+
+func (r readSession) GetIdempotencyKey(tenant, key string) (model.IdempotencyKey, dberrors.Error) {
+	var idempotencyKey model.IdempotencyKey
+
+	err := r.session.
+		Select(idempotencyKeyColumns...).
+		From("idempotency_key").
+		Where(dbr.And(dbr.Eq("tenant", tenant), dbr.Eq("key", key))).
+		LoadOne(&idempotencyKey)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return model.IdempotencyKey{}, dberrors.NotFound("Idempotency key %s not found for tenant %s", key, tenant)
+		}
+
+		return model.IdempotencyKey{}, dberrors.Classify(err, "Failed to get idempotency key: %s", err)
+	}
+
+	return idempotencyKey, nil
+}
@@ -0,0 +1,118 @@
+package dbsession
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/database"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/testutils"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamClusters(t *testing.T) {
+	ctx := context.Background()
+
+	cleanupNetwork, err := testutils.EnsureTestNetworkForDB(t, ctx)
+	require.NoError(t, err)
+	defer cleanupNetwork()
+
+	containerCleanupFunc, connString, err := testutils.InitTestDBContainer(t, ctx, "test_DB_stream_clusters")
+	require.NoError(t, err)
+	defer containerCleanupFunc()
+
+	connection, err := database.InitializeDatabaseConnection(connString, 4)
+	require.NoError(t, err)
+	defer testutils.CloseDatabase(t, connection)
+
+	err = database.SetupSchema(connection, testutils.SchemaFilePath)
+	require.NoError(t, err)
+
+	uuidGenerator := uuid.NewUUIDGenerator()
+	factory := NewFactory(connection, 0, 0, uuidGenerator)
+	startTime := time.Now().UTC().Truncate(time.Second)
+
+	insertCluster := func(t *testing.T, tenant, region string, deleted bool) string {
+		cluster := model.Cluster{
+			ID:                uuidGenerator.New(),
+			Tenant:            tenant,
+			CreationTimestamp: startTime,
+			Deleted:           deleted,
+		}
+		require.NoError(t, factory.NewWriteSession().InsertCluster(cluster))
+		require.NoError(t, factory.NewWriteSession().InsertGardenerConfig(model.GardenerConfig{
+			ID:                     uuidGenerator.New(),
+			ClusterID:              cluster.ID,
+			Name:                   "shoot",
+			Provider:               "gcp",
+			Region:                 region,
+			MachineType:            "n1-standard-4",
+			GardenerProviderConfig: &model.GCPGardenerConfig{ProviderSpecificConfig: `{"zones":["europe-west3-b"]}`},
+		}))
+		return cluster.ID
+	}
+
+	var expectedIDs []string
+	for i := 0; i < 7; i++ {
+		expectedIDs = append(expectedIDs, insertCluster(t, "stream", "europe-west3", false))
+	}
+	sort.Strings(expectedIDs)
+	otherRegionID := insertCluster(t, "stream", "us-central1", false)
+	insertCluster(t, "stream", "europe-west3", true)
+
+	t.Run("should stream all clusters matching the filter in batches", func(t *testing.T) {
+		// given
+		var streamedIDs []string
+
+		// when
+		err := factory.NewReadSession().StreamClusters(model.ClustersFilter{Tenant: stringPtr("stream"), Region: stringPtr("europe-west3"), Deleted: boolPtr(false)}, 3, func(cluster model.Cluster) error {
+			assert.Equal(t, "n1-standard-4", cluster.ClusterConfig.MachineType)
+			streamedIDs = append(streamedIDs, cluster.ID)
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expectedIDs, streamedIDs)
+	})
+
+	t.Run("should filter clusters by provider", func(t *testing.T) {
+		// given
+		var streamedIDs []string
+
+		// when
+		err := factory.NewReadSession().StreamClusters(model.ClustersFilter{Tenant: stringPtr("stream"), Provider: stringPtr("gcp"), Region: stringPtr("us-central1")}, 10, func(cluster model.Cluster) error {
+			streamedIDs = append(streamedIDs, cluster.ID)
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{otherRegionID}, streamedIDs)
+	})
+
+	t.Run("should stop streaming when the callback fails", func(t *testing.T) {
+		// given
+		callbackErr := errors.New("write failed")
+		calls := 0
+
+		// when
+		err := factory.NewReadSession().StreamClusters(model.ClustersFilter{Tenant: stringPtr("stream")}, 2, func(cluster model.Cluster) error {
+			calls++
+			return callbackErr
+		})
+
+		// then
+		assert.Equal(t, callbackErr, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -7,27 +7,45 @@ import (
 	"time"
 
 	dbr "github.com/gocraft/dbr/v2"
-	uuid "github.com/google/uuid"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/lib/pq"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	uniqueViolationErrorCode     = "23505"
+	foreignKeyViolationErrorCode = "23503"
+
+	// operationInProgressIndex allows only one pending or in progress operation per cluster
+	operationInProgressIndex = "operation_cluster_id_in_progress_idx"
 )
 
 type writeSession struct {
-	session     *dbr.Session
-	transaction *dbr.Tx
+	session       *dbr.Session
+	transaction   *dbr.Tx
+	uuidGenerator uuid.UUIDGenerator
 }
 
 func (ws writeSession) InsertCluster(cluster model.Cluster) dberrors.Error {
+	var activeKymaConfigId *string
+	if cluster.KymaConfig != nil {
+		activeKymaConfigId = &cluster.KymaConfig.ID
+	}
+
 	_, err := ws.insertInto("cluster").
 		Pair("id", cluster.ID).
 		Pair("creation_timestamp", cluster.CreationTimestamp).
 		Pair("tenant", cluster.Tenant).
 		Pair("sub_account_id", cluster.SubAccountId).
-		Pair("active_kyma_config_id", cluster.KymaConfig.ID). // Possible due to deferred constrain
+		Pair("active_kyma_config_id", activeKymaConfigId). // Possible due to deferred constrain
+		Pair("expire_at", cluster.ExpireAt).
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to insert record to Cluster table: %s", err)
+		return dberrors.Classify(err, "Failed to insert record to Cluster table: %s", err)
 	}
 
 	dbErr := ws.InsertAdministrators(cluster.ID, cluster.Administrators)
@@ -44,17 +62,17 @@ func (ws writeSession) InsertAdministrators(clusterId string, administrators []s
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to delete record to cluster_administrator table: %s", err)
+		return dberrors.Classify(err, "Failed to delete record to cluster_administrator table: %s", err)
 	}
 
 	for _, admin := range administrators {
 		_, err := ws.insertInto("cluster_administrator").
-			Pair("id", uuid.New().String()).
+			Pair("id", ws.uuidGenerator.New()).
 			Pair("cluster_id", clusterId).
 			Pair("email", admin).Exec()
 
 		if err != nil {
-			return dberrors.Internal("Failed to insert record to cluster_administrator table: %s", err)
+			return dberrors.Classify(err, "Failed to insert record to cluster_administrator table: %s", err)
 		}
 	}
 
@@ -62,7 +80,37 @@ func (ws writeSession) InsertAdministrators(clusterId string, administrators []s
 }
 
 func (ws writeSession) InsertGardenerConfig(config model.GardenerConfig) dberrors.Error {
-	_, err := ws.insertInto("gardener_config").
+	shootAnnotations, err := json.Marshal(config.ShootAnnotations)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal Shoot annotations: %s", err.Error())
+	}
+
+	dnsConfig, err := json.Marshal(config.DNSConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal DNS config: %s", err.Error())
+	}
+
+	costAllocation, err := json.Marshal(config.CostAllocation)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal cost allocation: %s", err.Error())
+	}
+
+	clusterAutoscalerConfig, err := json.Marshal(config.ClusterAutoscalerConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal cluster autoscaler config: %s", err.Error())
+	}
+
+	kubeAPIServerConfig, err := json.Marshal(config.KubeAPIServerConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal kube-apiserver config: %s", err.Error())
+	}
+
+	dedicatedSystemPool, err := json.Marshal(config.DedicatedSystemPool)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal dedicated system pool: %s", err.Error())
+	}
+
+	_, err = ws.insertInto("gardener_config").
 		Pair("id", config.ID).
 		Pair("cluster_id", config.ClusterID).
 		Pair("project_name", config.ProjectName).
@@ -82,16 +130,23 @@ func (ws writeSession) InsertGardenerConfig(config model.GardenerConfig) dberror
 		Pair("worker_cidr", config.WorkerCidr).
 		Pair("auto_scaler_min", config.AutoScalerMin).
 		Pair("auto_scaler_max", config.AutoScalerMax).
-		Pair("max_surge", config.MaxSurge).
-		Pair("max_unavailable", config.MaxUnavailable).
+		Pair("max_surge", intOrStringToDB(config.MaxSurge)).
+		Pair("max_unavailable", intOrStringToDB(config.MaxUnavailable)).
 		Pair("enable_kubernetes_version_auto_update", config.EnableKubernetesVersionAutoUpdate).
 		Pair("enable_machine_image_version_auto_update", config.EnableMachineImageVersionAutoUpdate).
 		Pair("allow_privileged_containers", config.AllowPrivilegedContainers).
 		Pair("provider_specific_config", config.GardenerProviderConfig.RawJSON()).
+		Pair("networking_type", config.NetworkingType).
+		Pair("shoot_annotations", shootAnnotations).
+		Pair("dns_config", dnsConfig).
+		Pair("cost_allocation", costAllocation).
+		Pair("cluster_autoscaler_config", clusterAutoscalerConfig).
+		Pair("kube_apiserver_config", kubeAPIServerConfig).
+		Pair("dedicated_system_pool", dedicatedSystemPool).
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to insert record to GardenerConfig table: %s", err)
+		return dberrors.Classify(err, "Failed to insert record to GardenerConfig table: %s", err)
 	}
 
 	if config.OIDCConfig != nil {
@@ -104,6 +159,15 @@ func (ws writeSession) InsertGardenerConfig(config model.GardenerConfig) dberror
 	return nil
 }
 
+// intOrStringToDB returns nil if the value is not set, so that the Gardener default is used
+func intOrStringToDB(value *intstr.IntOrString) *string {
+	if value == nil {
+		return nil
+	}
+
+	return util.StringPtr(value.String())
+}
+
 func (ws writeSession) insertOidcConfig(config model.GardenerConfig) dberrors.Error {
 	_, err := ws.insertInto("oidc_config").
 		Pair("id", config.ID).
@@ -116,24 +180,54 @@ func (ws writeSession) insertOidcConfig(config model.GardenerConfig) dberrors.Er
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to insert record to OIDCConfig table: %s", err)
+		return dberrors.Classify(err, "Failed to insert record to OIDCConfig table: %s", err)
 	}
 
 	for _, algorithm := range config.OIDCConfig.SigningAlgs {
 		_, err = ws.insertInto("signing_algorithms").
-			Pair("id", uuid.New().String()).
+			Pair("id", ws.uuidGenerator.New()).
 			Pair("oidc_config_id", config.ID).
 			Pair("algorithm", algorithm).
 			Exec()
 
 		if err != nil {
-			return dberrors.Internal("Failed to insert record to SigningAlgorithms table: %s", err)
+			return dberrors.Classify(err, "Failed to insert record to SigningAlgorithms table: %s", err)
 		}
 	}
 	return nil
 }
 
 func (ws writeSession) UpdateGardenerClusterConfig(config model.GardenerConfig) dberrors.Error {
+	shootAnnotations, err := json.Marshal(config.ShootAnnotations)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal Shoot annotations: %s", err.Error())
+	}
+
+	dnsConfig, err := json.Marshal(config.DNSConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal DNS config: %s", err.Error())
+	}
+
+	costAllocation, err := json.Marshal(config.CostAllocation)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal cost allocation: %s", err.Error())
+	}
+
+	clusterAutoscalerConfig, err := json.Marshal(config.ClusterAutoscalerConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal cluster autoscaler config: %s", err.Error())
+	}
+
+	kubeAPIServerConfig, err := json.Marshal(config.KubeAPIServerConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal kube-apiserver config: %s", err.Error())
+	}
+
+	dedicatedSystemPool, err := json.Marshal(config.DedicatedSystemPool)
+	if err != nil {
+		return dberrors.Internal("Failed to marshal dedicated system pool: %s", err.Error())
+	}
+
 	res, err := ws.update("gardener_config").
 		Where(dbr.Eq("cluster_id", config.ClusterID)).
 		Set("kubernetes_version", config.KubernetesVersion).
@@ -142,27 +236,35 @@ func (ws writeSession) UpdateGardenerClusterConfig(config model.GardenerConfig)
 		Set("region", config.Region).
 		Set("provider", config.Provider).
 		Set("machine_type", config.MachineType).
+		Set("machine_image", config.MachineImage).
+		Set("machine_image_version", config.MachineImageVersion).
 		Set("disk_type", config.DiskType).
 		Set("volume_size_gb", config.VolumeSizeGB).
 		Set("worker_cidr", config.WorkerCidr).
 		Set("auto_scaler_min", config.AutoScalerMin).
 		Set("auto_scaler_max", config.AutoScalerMax).
-		Set("max_surge", config.MaxSurge).
-		Set("max_unavailable", config.MaxUnavailable).
+		Set("max_surge", intOrStringToDB(config.MaxSurge)).
+		Set("max_unavailable", intOrStringToDB(config.MaxUnavailable)).
 		Set("enable_kubernetes_version_auto_update", config.EnableKubernetesVersionAutoUpdate).
 		Set("enable_machine_image_version_auto_update", config.EnableMachineImageVersionAutoUpdate).
 		Set("provider_specific_config", config.GardenerProviderConfig.RawJSON()).
+		Set("shoot_annotations", shootAnnotations).
+		Set("dns_config", dnsConfig).
+		Set("cost_allocation", costAllocation).
+		Set("cluster_autoscaler_config", clusterAutoscalerConfig).
+		Set("kube_apiserver_config", kubeAPIServerConfig).
+		Set("dedicated_system_pool", dedicatedSystemPool).
 		Exec()
 
 	if config.OIDCConfig != nil {
 		err = ws.updateOidcConfig(config)
 		if err != nil {
-			return dberrors.Internal("Failed to update record for oidc config %s", err)
+			return dberrors.Classify(err, "Failed to update record for oidc config %s", err)
 		}
 	}
 
 	if err != nil {
-		return dberrors.Internal("Failed to update record of configuration for gardener shoot cluster '%s': %s", config.Name, err)
+		return dberrors.Classify(err, "Failed to update record of configuration for gardener shoot cluster '%s': %s", config.Name, err)
 	}
 
 	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update record of configuration for gardener shoot cluster '%s' state: %s", config.Name, err))
@@ -174,7 +276,7 @@ func (ws writeSession) updateOidcConfig(config model.GardenerConfig) dberrors.Er
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to delete record to OIDCConfig table: %s", err)
+		return dberrors.Classify(err, "Failed to delete record to OIDCConfig table: %s", err)
 	}
 
 	_, err = ws.insertInto("oidc_config").
@@ -188,7 +290,7 @@ func (ws writeSession) updateOidcConfig(config model.GardenerConfig) dberrors.Er
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to update record to OIDCConfig table: %s", err)
+		return dberrors.Classify(err, "Failed to update record to OIDCConfig table: %s", err)
 	}
 
 	_, err = ws.deleteFrom("signing_algorithms").
@@ -196,19 +298,19 @@ func (ws writeSession) updateOidcConfig(config model.GardenerConfig) dberrors.Er
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to delete records from SigningAlgorithms table: %s", err)
+		return dberrors.Classify(err, "Failed to delete records from SigningAlgorithms table: %s", err)
 	}
 
 	for _, algorithm := range config.OIDCConfig.SigningAlgs {
 
 		_, err = ws.insertInto("signing_algorithms").
-			Pair("id", uuid.New().String()).
+			Pair("id", ws.uuidGenerator.New()).
 			Pair("oidc_config_id", config.ID).
 			Pair("algorithm", algorithm).
 			Exec()
 
 		if err != nil {
-			return dberrors.Internal("Failed to insert record to SigningAlgorithms table: %s", err)
+			return dberrors.Classify(err, "Failed to insert record to SigningAlgorithms table: %s", err)
 		}
 	}
 	return nil
@@ -229,13 +331,13 @@ func (ws writeSession) InsertKymaConfig(kymaConfig model.KymaConfig) dberrors.Er
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to insert record to KymaConfig table: %s", err)
+		return dberrors.Classify(err, "Failed to insert record to KymaConfig table: %s", err)
 	}
 
 	for _, kymaConfigModule := range kymaConfig.Components {
 		err = ws.insertKymaComponentConfig(kymaConfigModule)
 		if err != nil {
-			return dberrors.Internal("Failed to insert record to KymaComponentConfig table: %s", err)
+			return dberrors.Classify(err, "Failed to insert record to KymaComponentConfig table: %s", err)
 		}
 	}
 
@@ -259,12 +361,14 @@ func (ws writeSession) insertKymaComponentConfig(kymaConfigModule model.KymaComp
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to insert record to KymaComponentConfig table: %s", err)
+		return dberrors.Classify(err, "Failed to insert record to KymaComponentConfig table: %s", err)
 	}
 
 	return nil
 }
 
+// InsertOperation inserts the operation and updates the last operation of its cluster.
+// It returns OperationInProgress error if another operation of the cluster is pending or in progress.
 func (ws writeSession) InsertOperation(operation model.Operation) dberrors.Error {
 	_, err := ws.insertInto("operation").
 		Columns(operationColumns...).
@@ -272,7 +376,120 @@ func (ws writeSession) InsertOperation(operation model.Operation) dberrors.Error
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to insert record to Type table: %s", err)
+		psqlErr, converted := err.(*pq.Error)
+		if converted && psqlErr.Code == uniqueViolationErrorCode && psqlErr.Constraint == operationInProgressIndex {
+			return dberrors.OperationInProgress("Another operation of cluster %s is in progress", operation.ClusterID)
+		}
+		return dberrors.Classify(err, "Failed to insert record to Type table: %s", err)
+	}
+
+	return ws.updateLastOperation(dbr.Eq("id", operation.ClusterID))
+}
+
+func (ws writeSession) InsertAuditEntry(entry model.AuditEntry) dberrors.Error {
+	_, err := ws.insertInto("api_audit_log").
+		Columns(auditEntryColumns...).
+		Record(entry).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to insert record to api_audit_log table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InsertStageDuration(duration model.StageDuration) dberrors.Error {
+	_, err := ws.insertInto("stage_duration").
+		Columns(stageDurationColumns...).
+		Record(duration).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to insert record to stage_duration table: %s", err)
+	}
+
+	return nil
+}
+
+// InsertIdempotencyKey returns AlreadyExists error if the key was already used by the tenant
+func (ws writeSession) InsertIdempotencyKey(idempotencyKey model.IdempotencyKey) dberrors.Error {
+	_, err := ws.insertInto("idempotency_key").
+		Columns(idempotencyKeyColumns...).
+		Record(idempotencyKey).
+		Exec()
+
+	if err != nil {
+		psqlErr, converted := err.(*pq.Error)
+		if converted && psqlErr.Code == uniqueViolationErrorCode {
+			return dberrors.AlreadyExists("Idempotency key %s already used by tenant %s", idempotencyKey.Key, idempotencyKey.Tenant)
+		}
+		return dberrors.Classify(err, "Failed to insert record to idempotency_key table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteExpiredIdempotencyKey(tenant, key string, createdBefore time.Time) dberrors.Error {
+	_, err := ws.deleteFrom("idempotency_key").
+		Where(dbr.And(dbr.Eq("tenant", tenant), dbr.Eq("key", key), dbr.Lt("created_at", createdBefore))).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to delete record from idempotency_key table: %s", err)
+	}
+
+	return nil
+}
+
+// UpsertOperationAnnotation sets the value of the annotation replacing the previous value of the key,
+// it returns NotFound error if the operation does not exist
+func (ws writeSession) UpsertOperationAnnotation(annotation model.OperationAnnotation) dberrors.Error {
+	res, err := ws.update("operation_annotations").
+		Where(dbr.And(dbr.Eq("operation_id", annotation.OperationID), dbr.Eq("key", annotation.Key))).
+		Set("value", annotation.Value).
+		Set("updated_at", annotation.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update record in operation_annotations table: %s", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return dberrors.Classify(err, "Failed to get number of rows affected: %s", err)
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	_, err = ws.insertInto("operation_annotations").
+		Columns(operationAnnotationColumns...).
+		Record(annotation).
+		Exec()
+
+	if err != nil {
+		psqlErr, converted := err.(*pq.Error)
+		if converted && psqlErr.Code == foreignKeyViolationErrorCode {
+			return dberrors.NotFound("Operation %s not found", annotation.OperationID)
+		}
+		if converted && psqlErr.Code == uniqueViolationErrorCode {
+			return dberrors.AlreadyExists("Annotation %s of operation %s was set concurrently", annotation.Key, annotation.OperationID)
+		}
+		return dberrors.Classify(err, "Failed to insert record to operation_annotations table: %s", err)
+	}
+
+	return nil
+}
+
+// DeleteOperationAnnotation removes the annotation, it does not fail if the annotation does not exist
+func (ws writeSession) DeleteOperationAnnotation(operationID, key string) dberrors.Error {
+	_, err := ws.deleteFrom("operation_annotations").
+		Where(dbr.And(dbr.Eq("operation_id", operationID), dbr.Eq("key", key))).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to delete record from operation_annotations table: %s", err)
 	}
 
 	return nil
@@ -284,13 +501,13 @@ func (ws writeSession) DeleteCluster(runtimeID string) dberrors.Error {
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to delete record in Cluster table: %s", err)
+		return dberrors.Classify(err, "Failed to delete record in Cluster table: %s", err)
 	}
 
 	val, err := result.RowsAffected()
 
 	if err != nil {
-		return dberrors.Internal("Could not fetch the number of rows affected: %s", err)
+		return dberrors.Classify(err, "Could not fetch the number of rows affected: %s", err)
 	}
 
 	if val == 0 {
@@ -300,37 +517,188 @@ func (ws writeSession) DeleteCluster(runtimeID string) dberrors.Error {
 	return nil
 }
 
-func (ws writeSession) UpdateOperationState(operationID string, message string, state model.OperationState, endTime time.Time) dberrors.Error {
+// UpdateOperationState updates the operation only if it was not modified since it was read with the expected version
+func (ws writeSession) UpdateOperationState(operationID string, expectedVersion int, message string, state model.OperationState, endTime time.Time) dberrors.Error {
 	res, err := ws.update("operation").
-		Where(dbr.Eq("id", operationID)).
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("version", expectedVersion))).
 		Set("state", state).
 		Set("message", message).
 		Set("end_timestamp", endTime).
+		Set("version", dbr.Expr("version + 1")).
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to update operation %s state: %s", operationID, err)
+		return dberrors.Classify(err, "Failed to update operation %s state: %s", operationID, err)
+	}
+
+	dberr := ws.operationUpdateSucceeded(res, operationID, expectedVersion)
+	if dberr != nil || (state != model.Succeeded && state != model.Failed) {
+		return dberr
 	}
 
-	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update operation %s state: %s", operationID, err))
+	return ws.updateLastOperationOf(operationID)
 }
 
-func (ws writeSession) TransitionOperation(operationID string, message string, stage model.OperationStage, transitionTime time.Time) dberrors.Error {
+// TransitionOperation updates the operation only if it was not modified since it was read with the expected version
+func (ws writeSession) TransitionOperation(operationID string, expectedVersion int, message string, stage model.OperationStage, transitionTime time.Time) dberrors.Error {
 	res, err := ws.update("operation").
-		Where(dbr.Eq("id", operationID)).
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("version", expectedVersion))).
 		Set("stage", stage).
 		Set("message", message).
 		Set("last_transition", transitionTime).
+		Set("version", dbr.Expr("version + 1")).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update operation %s stage: %s", operationID, err)
+	}
+
+	return ws.operationUpdateSucceeded(res, operationID, expectedVersion)
+}
+
+// UpdateOperationMessage updates only the message of the operation, it does not change the version
+// as the message does not affect processing of the operation
+func (ws writeSession) UpdateOperationMessage(operationID string, message string) dberrors.Error {
+	res, err := ws.update("operation").
+		Where(dbr.Eq("id", operationID)).
+		Set("message", message).
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to update operation %s stage: %s", operationID, err)
+		return dberrors.Classify(err, "Failed to update operation %s message: %s", operationID, err)
 	}
 
-	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update operation %s state: %s", operationID, err))
+	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update operation %s message: operation not found", operationID))
+}
+
+// UpdateOperationDiagnostics updates only the diagnostics of the operation, it does not change the version
+func (ws writeSession) UpdateOperationDiagnostics(operationID string, diagnostics string) dberrors.Error {
+	res, err := ws.update("operation").
+		Where(dbr.Eq("id", operationID)).
+		Set("diagnostics", diagnostics).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update operation %s diagnostics: %s", operationID, err)
+	}
+
+	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update operation %s diagnostics: operation not found", operationID))
+}
+
+// UpdateOperationFailureReason saves the reason of the operation failure, it does not change the version
+func (ws writeSession) UpdateOperationFailureReason(operationID string, reason string) dberrors.Error {
+	res, err := ws.update("operation").
+		Where(dbr.Eq("id", operationID)).
+		Set("failure_reason", reason).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update operation %s failure reason: %s", operationID, err)
+	}
+
+	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update operation %s failure reason: operation not found", operationID))
+}
+
+// MarkInstallationTriggered saves the time when the operation triggered Kyma installation, it does not change the version
+func (ws writeSession) MarkInstallationTriggered(operationID string, triggeredAt time.Time) dberrors.Error {
+	res, err := ws.update("operation").
+		Where(dbr.Eq("id", operationID)).
+		Set("installation_triggered_at", triggeredAt).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to mark installation triggered by operation %s: %s", operationID, err)
+	}
+
+	return ws.updateSucceeded(res, fmt.Sprintf("Failed to mark installation triggered by operation %s: operation not found", operationID))
 }
 
 // Clean up this code when not needed (https://github.com/kyma-project/control-plane/issues/1371)
+// RetryOperation moves the failed operation back to InProgress, so that it is resumed from the stage in which it failed,
+// the time limit of the stage starts anew. The operation is updated only if it was not modified since it was read with the expected version.
+func (ws writeSession) RetryOperation(operationID string, expectedVersion int, message string, retryTime time.Time) dberrors.Error {
+	res, err := ws.update("operation").
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("version", expectedVersion), dbr.Eq("state", model.Failed))).
+		Set("state", model.InProgress).
+		Set("message", message).
+		Set("end_timestamp", nil).
+		Set("last_transition", retryTime).
+		Set("version", dbr.Expr("version + 1")).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to retry operation %s: %s", operationID, err)
+	}
+
+	return ws.operationUpdateSucceeded(res, operationID, expectedVersion)
+}
+
+func (ws writeSession) MarkOperationAsStarted(operationID string, message string, startTime time.Time) dberrors.Error {
+	res, err := ws.update("operation").
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("state", model.Pending))).
+		Set("state", model.InProgress).
+		Set("message", message).
+		Set("start_timestamp", startTime).
+		Set("last_transition", startTime).
+		Set("version", dbr.Expr("version + 1")).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to mark operation %s as started: %s", operationID, err)
+	}
+
+	dberr := ws.updateSucceeded(res, fmt.Sprintf("Failed to mark operation %s as started: operation not found in Pending state", operationID))
+	if dberr != nil {
+		return dberr
+	}
+
+	// The start time of the operation changes, so it may no longer be the last operation of the cluster
+	return ws.updateLastOperationOf(operationID)
+}
+
+// lastOperationSelect selects the last operation of the cluster in the same order as GetLastOperation
+const lastOperationSelect = "(SELECT operation.id FROM operation WHERE operation.cluster_id = cluster.id " +
+	"ORDER BY operation.start_timestamp DESC, operation.id DESC LIMIT 1)"
+
+// updateLastOperation sets the last operation of the clusters selected by the condition from their operations,
+// so that concurrent updates of the same cluster converge on the same operation
+func (ws writeSession) updateLastOperation(condition dbr.Builder) dberrors.Error {
+	_, err := ws.update("cluster").
+		Set("last_operation_id", dbr.Expr(lastOperationSelect)).
+		Where(condition).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update last operation of cluster: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) updateLastOperationOf(operationID string) dberrors.Error {
+	return ws.updateLastOperation(dbr.Expr("id = (SELECT cluster_id FROM operation WHERE id = ?)", operationID))
+}
+
+// RepairLastOperations fixes clusters whose last operation differs from the last one of their operations
+// and returns the number of repaired clusters
+func (ws writeSession) RepairLastOperations() (int, dberrors.Error) {
+	res, err := ws.update("cluster").
+		Set("last_operation_id", dbr.Expr(lastOperationSelect)).
+		Where(dbr.Expr("last_operation_id IS DISTINCT FROM " + lastOperationSelect)).
+		Exec()
+
+	if err != nil {
+		return 0, dberrors.Classify(err, "Failed to repair last operations of clusters: %s", err)
+	}
+
+	repaired, err := res.RowsAffected()
+	if err != nil {
+		return 0, dberrors.Classify(err, "Failed to get number of rows affected: %s", err)
+	}
+
+	return int(repaired), nil
+}
+
 func (ws writeSession) FixShootProvisioningStage(message string, newStage model.OperationStage, transitionTime time.Time) dberrors.Error {
 	legacyStageCondition := dbr.Eq("stage", "ShootProvisioning")
 	provisioningOperation := dbr.Eq("type", model.Provision)
@@ -341,23 +709,27 @@ func (ws writeSession) FixShootProvisioningStage(message string, newStage model.
 		Set("stage", newStage).
 		Set("message", message).
 		Set("last_transition", transitionTime).
+		Set("version", dbr.Expr("version + 1")).
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to set stage: %v for operations", err)
+		return dberrors.Classify(err, "Failed to set stage: %v for operations", err)
 	}
 
 	return nil
 }
 
-func (ws writeSession) UpdateKubeconfig(runtimeID string, kubeconfig string) dberrors.Error {
+// UpdateKubeconfig stores the kubeconfig together with the API server read from it, so that both are always refreshed at once
+func (ws writeSession) UpdateKubeconfig(runtimeID string, kubeconfig string, apiServer model.APIServer) dberrors.Error {
 	res, err := ws.update("cluster").
 		Where(dbr.Eq("id", runtimeID)).
 		Set("kubeconfig", kubeconfig).
+		Set("api_server_url", apiServer.URL).
+		Set("ca_certificate", apiServer.CACertificate).
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to update cluster %s state: %s", runtimeID, err)
+		return dberrors.Classify(err, "Failed to update cluster %s state: %s", runtimeID, err)
 	}
 
 	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update cluster %s data: %s", runtimeID, err))
@@ -370,7 +742,7 @@ func (ws writeSession) SetActiveKymaConfig(runtimeID string, kymaConfigId string
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to update cluster %s Kyma config: %s", runtimeID, err)
+		return dberrors.Classify(err, "Failed to update cluster %s Kyma config: %s", runtimeID, err)
 	}
 
 	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update cluster %s kyma config: %s", runtimeID, err))
@@ -383,12 +755,39 @@ func (ws writeSession) UpdateUpgradeState(operationID string, upgradeState model
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to update operation %s upgrade state: %s", operationID, err)
+		return dberrors.Classify(err, "Failed to update operation %s upgrade state: %s", operationID, err)
 	}
 
 	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update operation %s upgrade state: %s", operationID, err))
 }
 
+func (ws writeSession) UpdateQueueState(operationType model.OperationType, paused bool) dberrors.Error {
+	res, err := ws.update("operation_queue_state").
+		Where(dbr.Eq("operation_type", operationType)).
+		Set("paused", paused).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update %s operation queue state: %s", operationType, err)
+	}
+
+	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update %s operation queue state: queue not found", operationType))
+}
+
+func (ws writeSession) UpdateReadOnlyMode(enabled bool, message *string, updatedAt time.Time) dberrors.Error {
+	res, err := ws.update("read_only_mode").
+		Set("enabled", enabled).
+		Set("message", message).
+		Set("updated_at", updatedAt).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update read-only mode: %s", err)
+	}
+
+	return ws.updateSucceeded(res, "Failed to update read-only mode: state not found")
+}
+
 func (ws writeSession) MarkClusterAsDeleted(runtimeID string) dberrors.Error {
 	res, err := ws.update("cluster").
 		Where(dbr.Eq("id", runtimeID)).
@@ -396,19 +795,138 @@ func (ws writeSession) MarkClusterAsDeleted(runtimeID string) dberrors.Error {
 		Exec()
 
 	if err != nil {
-		return dberrors.Internal("Failed to update cluster %s state: %s", runtimeID, err)
+		return dberrors.Classify(err, "Failed to update cluster %s state: %s", runtimeID, err)
 	}
 
 	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update cluster %s data: %s", runtimeID, err))
 }
 
+func (ws writeSession) MarkClusterAsHibernated(runtimeID string, hibernatedAt time.Time, initiatedBy model.HibernationTrigger) dberrors.Error {
+	_, err := ws.update("cluster").
+		Where(dbr.And(dbr.Eq("id", runtimeID), dbr.Eq("hibernated", false))).
+		Set("hibernated", true).
+		Set("hibernated_at", hibernatedAt).
+		Set("hibernation_initiated_by", initiatedBy).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update cluster %s hibernation status: %s", runtimeID, err)
+	}
+
+	return nil
+}
+
+// UpdateClusterExpiration sets the time after which the Runtime is deprovisioned automatically, nil removes the expiration
+func (ws writeSession) UpdateClusterExpiration(runtimeID string, expireAt *time.Time) dberrors.Error {
+	res, err := ws.update("cluster").
+		Where(dbr.And(dbr.Eq("id", runtimeID), dbr.Eq("deleted", false))).
+		Set("expire_at", expireAt).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update cluster %s expiration: %s", runtimeID, err)
+	}
+
+	return ws.updateSucceeded(res, fmt.Sprintf("Cluster %s not found or deleted", runtimeID))
+}
+
+// ClaimOperation claims the operation for the owner until expiresAt, unless another owner holds the claim which did not expire.
+// The owner renews its claim by claiming the operation again.
+func (ws writeSession) UpdateClusterDeprovisioningSchedule(runtimeID string, scheduledAt *time.Time) dberrors.Error {
+	res, err := ws.update("cluster").
+		Where(dbr.And(dbr.Eq("id", runtimeID), dbr.Eq("deleted", false))).
+		Set("deprovisioning_scheduled_at", scheduledAt).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update cluster %s deprovisioning schedule: %s", runtimeID, err)
+	}
+
+	return ws.updateSucceeded(res, fmt.Sprintf("Cluster %s not found or deleted", runtimeID))
+}
+
+// ClearClusterDeprovisioningSchedule removes the deprovisioning schedule only if it is still set to the given time,
+// false is returned when the schedule was cancelled, changed or already cleared by another replica in the meantime
+func (ws writeSession) ClearClusterDeprovisioningSchedule(runtimeID string, scheduledAt time.Time) (bool, dberrors.Error) {
+	res, err := ws.update("cluster").
+		Where(dbr.And(
+			dbr.Eq("id", runtimeID),
+			dbr.Eq("deleted", false),
+			dbr.Eq("deprovisioning_scheduled_at", scheduledAt),
+		)).
+		Set("deprovisioning_scheduled_at", nil).
+		Exec()
+
+	if err != nil {
+		return false, dberrors.Classify(err, "Failed to clear cluster %s deprovisioning schedule: %s", runtimeID, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, dberrors.Classify(err, "Failed to get number of rows affected: %s", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (ws writeSession) ClaimOperation(operationID, owner string, expiresAt, now time.Time) (bool, dberrors.Error) {
+	res, err := ws.update("operation").
+		Where(dbr.And(
+			dbr.Eq("id", operationID),
+			dbr.Or(dbr.Eq("claimed_by", nil), dbr.Eq("claimed_by", owner), dbr.Lt("claim_expires_at", now)),
+		)).
+		Set("claimed_by", owner).
+		Set("claim_expires_at", expiresAt).
+		Exec()
+
+	if err != nil {
+		return false, dberrors.Classify(err, "Failed to claim operation %s: %s", operationID, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, dberrors.Classify(err, "Failed to get number of rows affected: %s", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ReleaseOperationClaim releases the claim of the owner, the claim taken over by another owner is kept
+func (ws writeSession) ReleaseOperationClaim(operationID, owner string) dberrors.Error {
+	_, err := ws.update("operation").
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("claimed_by", owner))).
+		Set("claimed_by", nil).
+		Set("claim_expires_at", nil).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to release claim of operation %s: %s", operationID, err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) MarkClusterAsWokenUp(runtimeID string, wokenUpAt time.Time) dberrors.Error {
+	_, err := ws.update("cluster").
+		Where(dbr.And(dbr.Eq("id", runtimeID), dbr.Eq("hibernated", true))).
+		Set("hibernated", false).
+		Set("last_woken_at", wokenUpAt).
+		Exec()
+
+	if err != nil {
+		return dberrors.Classify(err, "Failed to update cluster %s hibernation status: %s", runtimeID, err)
+	}
+
+	return nil
+}
+
 func (ws writeSession) InsertRuntimeUpgrade(runtimeUpgrade model.RuntimeUpgrade) dberrors.Error {
 	_, err := ws.insertInto("runtime_upgrade").
 		Columns("id", "state", "operation_id", "pre_upgrade_kyma_config_id", "post_upgrade_kyma_config_id").
 		Record(runtimeUpgrade).
 		Exec()
 	if err != nil {
-		return dberrors.Internal("Failed to insert Runtime Upgrade: %s", err.Error())
+		return dberrors.Classify(err, "Failed to insert Runtime Upgrade: %s", err.Error())
 	}
 
 	return nil
@@ -417,7 +935,7 @@ func (ws writeSession) InsertRuntimeUpgrade(runtimeUpgrade model.RuntimeUpgrade)
 func (ws writeSession) updateSucceeded(result sql.Result, errorMsg string) dberrors.Error {
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return dberrors.Internal("Failed to get number of rows affected: %s", err)
+		return dberrors.Classify(err, "Failed to get number of rows affected: %s", err)
 	}
 
 	if rowsAffected == 0 {
@@ -427,10 +945,36 @@ func (ws writeSession) updateSucceeded(result sql.Result, errorMsg string) dberr
 	return nil
 }
 
+// operationUpdateSucceeded distinguishes operations modified concurrently from the ones which do not exist
+func (ws writeSession) operationUpdateSucceeded(result sql.Result, operationID string, expectedVersion int) dberrors.Error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dberrors.Classify(err, "Failed to get number of rows affected: %s", err)
+	}
+
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var currentVersion int
+	err = ws.selectFrom("operation", "version").
+		Where(dbr.Eq("id", operationID)).
+		LoadOne(&currentVersion)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dberrors.NotFound("Operation not found for id: %s", operationID)
+		}
+		return dberrors.Classify(err, "Failed to get %s operation version: %s", operationID, err)
+	}
+
+	return dberrors.Conflict("Operation %s was modified concurrently: expected version %d, current version %d", operationID, expectedVersion, currentVersion)
+}
+
 func (ws writeSession) Commit() dberrors.Error {
 	err := ws.transaction.Commit()
 	if err != nil {
-		return dberrors.Internal("Failed to commit transaction: %s", err)
+		return dberrors.Classify(err, "Failed to commit transaction: %s", err)
 	}
 
 	return nil
@@ -456,6 +1000,14 @@ func (ws writeSession) deleteFrom(table string) *dbr.DeleteStmt {
 	return ws.session.DeleteFrom(table)
 }
 
+func (ws writeSession) selectFrom(table string, columns ...string) *dbr.SelectStmt {
+	if ws.transaction != nil {
+		return ws.transaction.Select(columns...).From(table)
+	}
+
+	return ws.session.Select(columns...).From(table)
+}
+
 func (ws writeSession) update(table string) *dbr.UpdateStmt {
 	if ws.transaction != nil {
 		return ws.transaction.Update(table)
@@ -0,0 +1,198 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const pendingProvisioningCheckInterval = 30 * time.Second
+
+// ProvisioningLimits defines how many provisioning operations can be in progress at the same time for a global account.
+// Limit equal to 0 means no limit.
+type ProvisioningLimits struct {
+	Default        int
+	GlobalAccounts map[string]int
+}
+
+// LoadProvisioningLimits reads per global account limits overrides from JSON file in the format {"<global account ID>": <limit>}
+func LoadProvisioningLimits(defaultLimit int, configPath string) (ProvisioningLimits, error) {
+	limits := ProvisioningLimits{
+		Default:        defaultLimit,
+		GlobalAccounts: map[string]int{},
+	}
+
+	if configPath == "" {
+		return limits, nil
+	}
+
+	file, err := os.Open(configPath)
+	if err != nil {
+		return ProvisioningLimits{}, fmt.Errorf("failed to open provisioning limits config: %s", err.Error())
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(&limits.GlobalAccounts); err != nil {
+		return ProvisioningLimits{}, fmt.Errorf("failed to decode provisioning limits config: %s", err.Error())
+	}
+
+	return limits, nil
+}
+
+func (l ProvisioningLimits) For(globalAccountID string) int {
+	if limit, found := l.GlobalAccounts[globalAccountID]; found {
+		return limit
+	}
+
+	return l.Default
+}
+
+// ProvisioningThrottle limits concurrent provisioning operations per global account.
+// It has to be locked while checking the limit and starting the operation.
+type ProvisioningThrottle struct {
+	sync.Mutex
+
+	limits           ProvisioningLimits
+	dbSessionFactory dbsession.Factory
+}
+
+func NewProvisioningThrottle(limits ProvisioningLimits, factory dbsession.Factory) *ProvisioningThrottle {
+	return &ProvisioningThrottle{
+		limits:           limits,
+		dbSessionFactory: factory,
+	}
+}
+
+// LimitReached returns true and the limit if no more provisioning operations can be started for the global account
+func (t *ProvisioningThrottle) LimitReached(globalAccountID string) (bool, int, dberrors.Error) {
+	return t.limitReached(globalAccountID, model.Provision)
+}
+
+// DeprovisioningLimitReached returns true and the limit if no more deprovisioning operations can be started
+// for the global account by the Provisioner itself, the same limits apply as for provisioning
+func (t *ProvisioningThrottle) DeprovisioningLimitReached(globalAccountID string) (bool, int, dberrors.Error) {
+	return t.limitReached(globalAccountID, model.Deprovision)
+}
+
+func (t *ProvisioningThrottle) limitReached(globalAccountID string, operationType model.OperationType) (bool, int, dberrors.Error) {
+	limit := t.limits.For(globalAccountID)
+	if limit <= 0 {
+		return false, 0, nil
+	}
+
+	count, err := t.dbSessionFactory.NewReadSession().InProgressOperationsCountForTenant(globalAccountID, operationType)
+	if err != nil {
+		return false, 0, err.Append("failed to check %s limit for global account %s", operationType, globalAccountID)
+	}
+
+	return count >= limit, limit, nil
+}
+
+// FreeSlots returns how many more operations of the type can be in progress for the global account, -1 if there is no limit
+func (t *ProvisioningThrottle) FreeSlots(globalAccountID string, operationType model.OperationType) (int, dberrors.Error) {
+	limit := t.limits.For(globalAccountID)
+	if limit <= 0 {
+		return -1, nil
+	}
+
+	count, err := t.dbSessionFactory.NewReadSession().InProgressOperationsCountForTenant(globalAccountID, operationType)
+	if err != nil {
+		return 0, err.Append("failed to check %s limit for global account %s", operationType, globalAccountID)
+	}
+
+	if count >= limit {
+		return 0, nil
+	}
+	return limit - count, nil
+}
+
+// PendingProvisioningStarter starts provisioning operations held back by the throttle once the global account has free slots
+type PendingProvisioningStarter struct {
+	throttle          *ProvisioningThrottle
+	dbSessionFactory  dbsession.Factory
+	provisioner       Provisioner
+	provisioningQueue queue.OperationQueue
+
+	log logrus.FieldLogger
+}
+
+func NewPendingProvisioningStarter(throttle *ProvisioningThrottle, factory dbsession.Factory, provisioner Provisioner, provisioningQueue queue.OperationQueue) *PendingProvisioningStarter {
+	return &PendingProvisioningStarter{
+		throttle:          throttle,
+		dbSessionFactory:  factory,
+		provisioner:       provisioner,
+		provisioningQueue: provisioningQueue,
+		log:               logrus.WithField("component", "pending-provisioning-starter"),
+	}
+}
+
+func (s *PendingProvisioningStarter) Run(stop <-chan struct{}) {
+	wait.Until(s.StartPendingOperations, pendingProvisioningCheckInterval, stop)
+}
+
+func (s *PendingProvisioningStarter) StartPendingOperations() {
+	s.throttle.Lock()
+	defer s.throttle.Unlock()
+
+	readSession := s.dbSessionFactory.NewReadSession()
+
+	pendingOperations, err := readSession.ListPendingOperations(model.Provision)
+	if err != nil {
+		s.log.Errorf("Failed to list pending provisioning operations: %s", err.Error())
+		return
+	}
+
+	for _, operation := range pendingOperations {
+		cluster, err := readSession.GetCluster(operation.ClusterID)
+		if err != nil {
+			s.log.Errorf("Failed to get cluster %s for pending operation %s: %s", operation.ClusterID, operation.ID, err.Error())
+			continue
+		}
+
+		limitReached, _, err := s.throttle.LimitReached(cluster.Tenant)
+		if err != nil {
+			s.log.Errorf("Failed to check provisioning limit for pending operation %s: %s", operation.ID, err.Error())
+			continue
+		}
+		if limitReached {
+			continue
+		}
+
+		s.startOperation(operation, cluster)
+	}
+}
+
+func (s *PendingProvisioningStarter) startOperation(operation model.Operation, cluster model.Cluster) {
+	s.log.Infof("Starting pending provisioning operation %s for Runtime %s", operation.ID, cluster.ID)
+
+	writeSession := s.dbSessionFactory.NewWriteSession()
+
+	dberr := writeSession.MarkOperationAsStarted(operation.ID, "Provisioning started", time.Now())
+	if dberr != nil {
+		s.log.Errorf("Failed to mark pending operation %s as started: %s", operation.ID, dberr.Error())
+		return
+	}
+
+	err := s.provisioner.ProvisionCluster(cluster, operation.ID)
+	if err != nil {
+		s.log.Errorf("Failed to start provisioning of Runtime %s: %s", cluster.ID, err.Error())
+
+		// The version was incremented when the operation was marked as started
+		dberr = writeSession.UpdateOperationState(operation.ID, operation.Version+1, fmt.Sprintf("Failed to start provisioning: %s", err.Error()), model.Failed, time.Now())
+		if dberr != nil {
+			s.log.Errorf("Failed to set operation %s as failed: %s", operation.ID, dberr.Error())
+		}
+		return
+	}
+
+	s.provisioningQueue.Add(operation.ID)
+}
@@ -0,0 +1,192 @@
+package provisioning
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/mocks"
+	mocks2 "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/mocks"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProvisioningLimits(t *testing.T) {
+	t.Run("should use default limit when config path is empty", func(t *testing.T) {
+		// when
+		limits, err := LoadProvisioningLimits(3, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, limits.For("any-global-account"))
+	})
+
+	t.Run("should override default limit for global account", func(t *testing.T) {
+		// given
+		file, err := ioutil.TempFile("", "limits")
+		require.NoError(t, err)
+		defer os.Remove(file.Name())
+
+		_, err = file.WriteString(`{"ga-1": 5, "ga-2": 0}`)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// when
+		limits, err := LoadProvisioningLimits(1, file.Name())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 5, limits.For("ga-1"))
+		assert.Equal(t, 0, limits.For("ga-2"))
+		assert.Equal(t, 1, limits.For("ga-3"))
+	})
+
+	t.Run("should return error when config file does not exist", func(t *testing.T) {
+		// when
+		_, err := LoadProvisioningLimits(1, "/non/existing/path")
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestProvisioningThrottle_LimitReached(t *testing.T) {
+	t.Run("should not check database when there is no limit", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		throttle := NewProvisioningThrottle(ProvisioningLimits{}, sessionFactory)
+
+		// when
+		limitReached, _, err := throttle.LimitReached(tenant)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, limitReached)
+		sessionFactory.AssertNotCalled(t, "NewReadSession")
+	})
+
+	for _, testCase := range []struct {
+		description   string
+		inProgress    int
+		limitExpected bool
+	}{
+		{description: "below the limit", inProgress: 1, limitExpected: false},
+		{description: "at the limit", inProgress: 2, limitExpected: true},
+	} {
+		t.Run("should compare in progress operations with the limit when "+testCase.description, func(t *testing.T) {
+			// given
+			sessionFactory := &sessionMocks.Factory{}
+			readSession := &sessionMocks.ReadSession{}
+			sessionFactory.On("NewReadSession").Return(readSession)
+			readSession.On("InProgressOperationsCountForTenant", tenant, model.Provision).Return(testCase.inProgress, nil)
+
+			throttle := NewProvisioningThrottle(ProvisioningLimits{Default: 2}, sessionFactory)
+
+			// when
+			limitReached, limit, err := throttle.LimitReached(tenant)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.limitExpected, limitReached)
+			assert.Equal(t, 2, limit)
+		})
+	}
+}
+
+func TestPendingProvisioningStarter_StartPendingOperations(t *testing.T) {
+	pendingOperation := model.Operation{
+		ID:        operationID,
+		Type:      model.Provision,
+		State:     model.Pending,
+		ClusterID: runtimeID,
+	}
+	cluster := model.Cluster{
+		ID:     runtimeID,
+		Tenant: tenant,
+	}
+
+	t.Run("should start pending operation when limit is not reached", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		writeSession := &sessionMocks.WriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		provisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		sessionFactory.On("NewWriteSession").Return(writeSession)
+		readSession.On("ListPendingOperations", model.Provision).Return([]model.Operation{pendingOperation}, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		readSession.On("InProgressOperationsCountForTenant", tenant, model.Provision).Return(0, nil)
+		writeSession.On("MarkOperationAsStarted", operationID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+		provisioner.On("ProvisionCluster", cluster, operationID).Return(nil)
+		provisioningQueue.On("Add", operationID).Return()
+
+		throttle := NewProvisioningThrottle(ProvisioningLimits{Default: 1}, sessionFactory)
+		starter := NewPendingProvisioningStarter(throttle, sessionFactory, provisioner, provisioningQueue)
+
+		// when
+		starter.StartPendingOperations()
+
+		// then
+		writeSession.AssertExpectations(t)
+		provisioner.AssertExpectations(t)
+		provisioningQueue.AssertExpectations(t)
+	})
+
+	t.Run("should keep operation pending when limit is reached", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		provisioner := &mocks2.Provisioner{}
+		provisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("ListPendingOperations", model.Provision).Return([]model.Operation{pendingOperation}, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		readSession.On("InProgressOperationsCountForTenant", tenant, model.Provision).Return(1, nil)
+
+		throttle := NewProvisioningThrottle(ProvisioningLimits{Default: 1}, sessionFactory)
+		starter := NewPendingProvisioningStarter(throttle, sessionFactory, provisioner, provisioningQueue)
+
+		// when
+		starter.StartPendingOperations()
+
+		// then
+		sessionFactory.AssertNotCalled(t, "NewWriteSession")
+		provisioner.AssertNotCalled(t, "ProvisionCluster", mock.Anything, mock.Anything)
+		provisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+
+	t.Run("should fail operation when provisioning cannot be started", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		writeSession := &sessionMocks.WriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		provisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		sessionFactory.On("NewWriteSession").Return(writeSession)
+		readSession.On("ListPendingOperations", model.Provision).Return([]model.Operation{pendingOperation}, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		readSession.On("InProgressOperationsCountForTenant", tenant, model.Provision).Return(0, nil)
+		writeSession.On("MarkOperationAsStarted", operationID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+		writeSession.On("UpdateOperationState", operationID, pendingOperation.Version+1, mock.AnythingOfType("string"), model.Failed, mock.AnythingOfType("time.Time")).Return(nil)
+		provisioner.On("ProvisionCluster", cluster, operationID).Return(apperrors.Internal("error"))
+
+		throttle := NewProvisioningThrottle(ProvisioningLimits{Default: 1}, sessionFactory)
+		starter := NewPendingProvisioningStarter(throttle, sessionFactory, provisioner, provisioningQueue)
+
+		// when
+		starter.StartPendingOperations()
+
+		// then
+		writeSession.AssertExpectations(t)
+		provisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
@@ -0,0 +1,100 @@
+package provisioning
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// expiredClustersBatchSize limits the number of Runtimes deprovisioned in a single check, the rest is deprovisioned in the next ones
+const expiredClustersBatchSize = 100
+
+// RuntimeExpirer deprovisions Runtimes which expired, e.g. trial Runtimes, respecting the limits of the global accounts
+type RuntimeExpirer struct {
+	throttle            *ProvisioningThrottle
+	dbSessionFactory    dbsession.Factory
+	provisioner         Provisioner
+	deprovisioningQueue queue.OperationQueue
+	uuidGenerator       uuid.UUIDGenerator
+	now                 func() time.Time
+
+	log logrus.FieldLogger
+}
+
+func NewRuntimeExpirer(throttle *ProvisioningThrottle, factory dbsession.Factory, provisioner Provisioner, deprovisioningQueue queue.OperationQueue, uuidGenerator uuid.UUIDGenerator) *RuntimeExpirer {
+	return &RuntimeExpirer{
+		throttle:            throttle,
+		dbSessionFactory:    factory,
+		provisioner:         provisioner,
+		deprovisioningQueue: deprovisioningQueue,
+		uuidGenerator:       uuidGenerator,
+		now:                 time.Now,
+		log:                 logrus.WithField("component", "runtime-expirer"),
+	}
+}
+
+// Run deprovisions the expired Runtimes immediately and then in the given interval until stopped
+func (e *RuntimeExpirer) Run(interval time.Duration, stop <-chan struct{}) {
+	wait.Until(e.DeprovisionExpiredRuntimes, interval, stop)
+}
+
+func (e *RuntimeExpirer) DeprovisionExpiredRuntimes() {
+	clusters, err := e.dbSessionFactory.NewReadSession().ListExpiredClusters(e.now().UTC(), expiredClustersBatchSize)
+	if err != nil {
+		e.log.Errorf("Failed to list expired Runtimes: %s", err.Error())
+		return
+	}
+
+	for _, cluster := range clusters {
+		limitReached, limit, err := e.throttle.DeprovisioningLimitReached(cluster.Tenant)
+		if err != nil {
+			e.log.Errorf("Failed to check deprovisioning limit for expired Runtime %s: %s", cluster.ID, err.Error())
+			continue
+		}
+		if limitReached {
+			e.log.Infof("Deprovisioning of expired Runtime %s postponed, global account %s reached the limit of %d operations", cluster.ID, cluster.Tenant, limit)
+			continue
+		}
+
+		e.deprovision(cluster)
+	}
+}
+
+func (e *RuntimeExpirer) deprovision(cluster model.Cluster) {
+	session := e.dbSessionFactory.NewReadWriteSession()
+
+	// The operation in progress is finished first, the Runtime is deprovisioned in one of the next checks
+	lastOperation, dberr := session.GetLastOperation(cluster.ID)
+	if dberr != nil {
+		e.log.Errorf("Failed to get last operation of expired Runtime %s: %s", cluster.ID, dberr.Error())
+		return
+	}
+	if lastOperation.State == model.InProgress || lastOperation.State == model.Pending {
+		e.log.Infof("Deprovisioning of expired Runtime %s postponed, %s operation is in progress", cluster.ID, lastOperation.Type)
+		return
+	}
+
+	e.log.Infof("Runtime %s expired at %s, starting deprovisioning", cluster.ID, cluster.ExpireAt.Format(time.RFC3339))
+
+	operation, err := e.provisioner.DeprovisionCluster(cluster, e.uuidGenerator.New(), false)
+	if err != nil {
+		e.log.Errorf("Failed to start deprovisioning of expired Runtime %s: %s", cluster.ID, err.Error())
+		return
+	}
+
+	trigger := model.OperationTriggerExpired
+	operation.TriggeredBy = &trigger
+
+	dberr = session.InsertOperation(operation)
+	if dberr != nil {
+		e.log.Errorf("Failed to insert deprovisioning operation of expired Runtime %s: %s", cluster.ID, dberr.Error())
+		return
+	}
+
+	e.deprovisioningQueue.Add(operation.ID)
+}
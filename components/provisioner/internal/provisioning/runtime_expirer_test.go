@@ -0,0 +1,165 @@
+package provisioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/mocks"
+	mocks2 "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/mocks"
+	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	uuidMocks "github.com/kyma-project/control-plane/components/provisioner/internal/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRuntimeExpirer_DeprovisionExpiredRuntimes(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	expireAt := now.Add(-time.Hour)
+	cluster := model.Cluster{
+		ID:       runtimeID,
+		Tenant:   tenant,
+		ExpireAt: &expireAt,
+	}
+	finishedOperation := model.Operation{
+		ID:        "provisioning-operation",
+		Type:      model.Provision,
+		State:     model.Succeeded,
+		ClusterID: runtimeID,
+	}
+	deprovisioningOperation := model.Operation{
+		ID:        operationID,
+		Type:      model.Deprovision,
+		State:     model.InProgress,
+		ClusterID: runtimeID,
+	}
+
+	newExpirer := func(sessionFactory *sessionMocks.Factory, provisioner *mocks2.Provisioner, deprovisioningQueue *mocks.OperationQueue, limits ProvisioningLimits) *RuntimeExpirer {
+		uuidGenerator := &uuidMocks.UUIDGenerator{}
+		uuidGenerator.On("New").Return(operationID)
+
+		expirer := NewRuntimeExpirer(NewProvisioningThrottle(limits, sessionFactory), sessionFactory, provisioner, deprovisioningQueue, uuidGenerator)
+		expirer.now = func() time.Time { return now }
+		return expirer
+	}
+
+	t.Run("should deprovision expired Runtime with expired trigger", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readSession.On("ListExpiredClusters", now, expiredClustersBatchSize).Return([]model.Cluster{cluster}, nil)
+		readSession.On("InProgressOperationsCountForTenant", tenant, model.Deprovision).Return(1, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(finishedOperation, nil)
+		provisioner.On("DeprovisionCluster", cluster, operationID, false).Return(deprovisioningOperation, nil)
+		readWriteSession.On("InsertOperation", mock.MatchedBy(func(operation model.Operation) bool {
+			return operation.ID == operationID && operation.TriggeredBy != nil && *operation.TriggeredBy == model.OperationTriggerExpired
+		})).Return(nil)
+		deprovisioningQueue.On("Add", operationID).Return()
+
+		expirer := newExpirer(sessionFactory, provisioner, deprovisioningQueue, ProvisioningLimits{Default: 2})
+
+		// when
+		expirer.DeprovisionExpiredRuntimes()
+
+		// then
+		readWriteSession.AssertExpectations(t)
+		provisioner.AssertExpectations(t)
+		deprovisioningQueue.AssertExpectations(t)
+	})
+
+	t.Run("should postpone deprovisioning when limit of global account is reached", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("ListExpiredClusters", now, expiredClustersBatchSize).Return([]model.Cluster{cluster}, nil)
+		readSession.On("InProgressOperationsCountForTenant", tenant, model.Deprovision).Return(2, nil)
+
+		expirer := newExpirer(sessionFactory, provisioner, deprovisioningQueue, ProvisioningLimits{Default: 2})
+
+		// when
+		expirer.DeprovisionExpiredRuntimes()
+
+		// then
+		sessionFactory.AssertNotCalled(t, "NewReadWriteSession")
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
+		deprovisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+
+	t.Run("should postpone deprovisioning when operation is in progress", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readSession.On("ListExpiredClusters", now, expiredClustersBatchSize).Return([]model.Cluster{cluster}, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(model.Operation{Type: model.UpgradeShoot, State: model.InProgress}, nil)
+
+		expirer := newExpirer(sessionFactory, provisioner, deprovisioningQueue, ProvisioningLimits{})
+
+		// when
+		expirer.DeprovisionExpiredRuntimes()
+
+		// then
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
+		deprovisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+
+	t.Run("should not enqueue operation when deprovisioning cannot be started", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		sessionFactory.On("NewReadWriteSession").Return(readWriteSession)
+		readSession.On("ListExpiredClusters", now, expiredClustersBatchSize).Return([]model.Cluster{cluster}, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(finishedOperation, nil)
+		provisioner.On("DeprovisionCluster", cluster, operationID, false).Return(model.Operation{}, apperrors.Internal("error"))
+
+		expirer := newExpirer(sessionFactory, provisioner, deprovisioningQueue, ProvisioningLimits{})
+
+		// when
+		expirer.DeprovisionExpiredRuntimes()
+
+		// then
+		readWriteSession.AssertNotCalled(t, "InsertOperation", mock.Anything)
+		deprovisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+
+	t.Run("should not start deprovisioning when there are no expired Runtimes", func(t *testing.T) {
+		// given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("ListExpiredClusters", now, expiredClustersBatchSize).Return([]model.Cluster{}, nil)
+
+		expirer := newExpirer(sessionFactory, provisioner, deprovisioningQueue, ProvisioningLimits{})
+
+		// when
+		expirer.DeprovisionExpiredRuntimes()
+
+		// then
+		assert.True(t, readSession.AssertExpectations(t))
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -1,6 +1,12 @@
 package provisioning
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
@@ -17,34 +23,112 @@ import (
 
 	"github.com/kyma-project/control-plane/components/provisioner/internal/director"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/installation"
+
 	log "github.com/sirupsen/logrus"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/freeze"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/regionpolicy"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 )
 
 //go:generate mockery -name=Service
 type Service interface {
-	ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError)
-	UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError)
-	DeprovisionRuntime(id, tenant string) (string, apperrors.AppError)
-	UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError)
+	ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount, idempotencyKey string) (*gqlschema.OperationStatus, apperrors.AppError)
+	ProvisionRuntimeDryRun(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError)
+	UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput, tenant, idempotencyKey string, skipHealthChecks bool) (*gqlschema.OperationStatus, apperrors.AppError)
+	DeprovisionRuntime(id, tenant string, force bool, idempotencyKey string) (string, apperrors.AppError)
+	UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput, tenant, idempotencyKey string) (*gqlschema.OperationStatus, apperrors.AppError)
+	UpgradeGardenerShootDryRun(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError)
 	ReconnectRuntimeAgent(id string) (string, apperrors.AppError)
 	RuntimeStatus(id string) (*gqlschema.RuntimeStatus, apperrors.AppError)
+	RuntimeStatuses(tenant string, ids []string, skipGardenerStatus bool) ([]*gqlschema.RuntimeStatusEntry, apperrors.AppError)
 	RuntimeOperationStatus(id string) (*gqlschema.OperationStatus, apperrors.AppError)
+	OperationsHistory(runtimeID string, first *int, after *string) (*gqlschema.OperationsHistory, apperrors.AppError)
 	RollBackLastUpgrade(runtimeID string) (*gqlschema.RuntimeStatus, apperrors.AppError)
-	HibernateCluster(clusterID string) (*gqlschema.OperationStatus, apperrors.AppError)
+	HibernateCluster(clusterID string, notBefore *time.Time) (*gqlschema.OperationStatus, apperrors.AppError)
+	WakeUpCluster(clusterID string, notBefore *time.Time) (*gqlschema.OperationStatus, apperrors.AppError)
+	CleanupFailedProvisioning(runtimeID string) (*gqlschema.OperationStatus, apperrors.AppError)
+	ExtendRuntimeExpiration(runtimeID string, expireAt *time.Time) (*gqlschema.RuntimeStatus, apperrors.AppError)
+	AnnotateOperation(operationID, key, value string) (*gqlschema.OperationStatus, apperrors.AppError)
+	SetQueueState(queueType gqlschema.QueueType, paused bool) (*gqlschema.QueueStatus, apperrors.AppError)
+	QueuesStatus() ([]*gqlschema.QueueStatus, apperrors.AppError)
+	MaintenanceFreeze() (*gqlschema.MaintenanceFreezeStatus, apperrors.AppError)
+	AuditEntries(filter *gqlschema.AuditEntriesFilter, first, offset *int) ([]*gqlschema.AuditEntry, apperrors.AppError)
+	OrphanedShoots() ([]*gqlschema.OrphanedShoot, apperrors.AppError)
+	RuntimeByShootName(shootName, tenant string) (*gqlschema.ShootRuntime, apperrors.AppError)
+	ProviderDefaults(provider gqlschema.Provider) (*gqlschema.ProviderDefaults, apperrors.AppError)
+	RetryFailedOperations(filter gqlschema.FailedOperationsFilter, dryRun bool) (*gqlschema.RetriedOperations, apperrors.AppError)
 }
 
 //go:generate mockery -name=Provisioner
 type Provisioner interface {
 	ProvisionCluster(cluster model.Cluster, operationId string) apperrors.AppError
-	DeprovisionCluster(cluster model.Cluster, operationId string) (model.Operation, apperrors.AppError)
+	ProvisionClusterDryRun(cluster model.Cluster) (model.ShootDryRun, apperrors.AppError)
+	DeprovisionCluster(cluster model.Cluster, operationId string, force bool) (model.Operation, apperrors.AppError)
 	UpgradeCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError
+	UpgradeClusterDryRun(clusterID string, upgradeConfig model.GardenerConfig) ([]model.ShootSpecChange, apperrors.AppError)
 	HibernateCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError
+	WakeUpCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError
 	GetHibernationStatus(clusterID string, gardenerConfig model.GardenerConfig) (model.HibernationStatus, apperrors.AppError)
+	GetShootStatus(clusterID string, gardenerConfig model.GardenerConfig) (*model.ShootStatus, apperrors.AppError)
+}
+
+type ProgressEstimator interface {
+	Estimate(operation model.Operation) *model.OperationProgress
+}
+
+//go:generate mockery -name=MachineImageDefaults
+type MachineImageDefaults interface {
+	DefaultMachineImage(cloudProfileName string) (string, string, apperrors.AppError)
+}
+
+type RegionPolicy interface {
+	ProviderRules(provider string) (regionpolicy.ProviderPolicy, bool)
+}
+
+type MaintenanceFreeze interface {
+	ActiveWindows() []freeze.ActiveWindow
+	Frozen() bool
+}
+
+//go:generate mockery -name=KubernetesVersionResolver
+type KubernetesVersionResolver interface {
+	Resolve(cloudProfileName, kubernetesVersion string) (string, apperrors.AppError)
+}
+
+//go:generate mockery -name=OrphanedShootsDetector
+type OrphanedShootsDetector interface {
+	OrphanedShoots() []model.OrphanedShoot
+}
+
+//go:generate mockery -name=ShootNameGenerator
+type ShootNameGenerator interface {
+	Generate(project string) (string, error)
 }
 
+// RuntimeStatusesConfig limits the number of Runtimes requested at once, 0 means no limit.
+// Runtimes of other tenants are omitted from the result unless StrictTenancy is enabled, then the request fails.
+type RuntimeStatusesConfig struct {
+	MaxBatchSize  int
+	StrictTenancy bool
+}
+
+const (
+	defaultOperationsHistoryPageSize = 20
+	maxOperationsHistoryPageSize     = 100
+
+	defaultAuditEntriesPageSize = 50
+	maxAuditEntriesPageSize     = 500
+
+	// retryBatchSize is the number of failed operations moved back to InProgress in a single transaction
+	retryBatchSize = 50
+	// The retried operations are spread over the window growing with their number, so that they do not start their stages all at once
+	retryWindowPerOperation = 2 * time.Second
+	maxRetryWindow          = 10 * time.Minute
+)
+
 type service struct {
 	inputConverter   InputConverter
 	graphQLConverter GraphQLConverter
@@ -59,6 +143,26 @@ type service struct {
 	upgradeQueue        queue.OperationQueue
 	shootUpgradeQueue   queue.OperationQueue
 	hibernationQueue    queue.OperationQueue
+
+	provisioningThrottle *ProvisioningThrottle
+	progressEstimator    ProgressEstimator
+
+	kubernetesVersionResolver  KubernetesVersionResolver
+	defaultInstallationTimeout time.Duration
+	orphanedShootsDetector     OrphanedShootsDetector
+	runtimeStatusesConfig      RuntimeStatusesConfig
+
+	idempotencyKeyTTL time.Duration
+	idempotencyLocks  *idempotencyLocks
+
+	machineImageDefaults MachineImageDefaults
+	regionPolicy         RegionPolicy
+
+	extraKymaComponentsAllowed bool
+
+	maintenanceFreeze MaintenanceFreeze
+
+	shootNameGenerator ShootNameGenerator
 }
 
 func NewProvisioningService(
@@ -73,26 +177,196 @@ func NewProvisioningService(
 	upgradeQueue queue.OperationQueue,
 	shootUpgradeQueue queue.OperationQueue,
 	hibernationQueue queue.OperationQueue,
-
+	provisioningThrottle *ProvisioningThrottle,
+	progressEstimator ProgressEstimator,
+	kubernetesVersionResolver KubernetesVersionResolver,
+	defaultInstallationTimeout time.Duration,
+	orphanedShootsDetector OrphanedShootsDetector,
+	runtimeStatusesConfig RuntimeStatusesConfig,
+	idempotencyKeyTTL time.Duration,
+	machineImageDefaults MachineImageDefaults,
+	regionPolicy RegionPolicy,
+	extraKymaComponentsAllowed bool,
+	maintenanceFreeze MaintenanceFreeze,
+	shootNameGenerator ShootNameGenerator,
 ) Service {
 	return &service{
-		inputConverter:      inputConverter,
-		graphQLConverter:    graphQLConverter,
-		directorService:     directorService,
-		dbSessionFactory:    factory,
-		provisioner:         provisioner,
-		uuidGenerator:       generator,
-		provisioningQueue:   provisioningQueue,
-		deprovisioningQueue: deprovisioningQueue,
-		upgradeQueue:        upgradeQueue,
-		shootUpgradeQueue:   shootUpgradeQueue,
-		hibernationQueue:    hibernationQueue,
+		inputConverter:       inputConverter,
+		graphQLConverter:     graphQLConverter,
+		directorService:      directorService,
+		dbSessionFactory:     factory,
+		provisioner:          provisioner,
+		uuidGenerator:        generator,
+		provisioningQueue:    provisioningQueue,
+		deprovisioningQueue:  deprovisioningQueue,
+		upgradeQueue:         upgradeQueue,
+		shootUpgradeQueue:    shootUpgradeQueue,
+		hibernationQueue:     hibernationQueue,
+		provisioningThrottle: provisioningThrottle,
+		progressEstimator:    progressEstimator,
+
+		kubernetesVersionResolver:  kubernetesVersionResolver,
+		defaultInstallationTimeout: defaultInstallationTimeout,
+		orphanedShootsDetector:     orphanedShootsDetector,
+		runtimeStatusesConfig:      runtimeStatusesConfig,
+
+		idempotencyKeyTTL: idempotencyKeyTTL,
+		idempotencyLocks:  newIdempotencyLocks(),
+
+		machineImageDefaults: machineImageDefaults,
+		regionPolicy:         regionPolicy,
+
+		extraKymaComponentsAllowed: extraKymaComponentsAllowed,
+
+		maintenanceFreeze: maintenanceFreeze,
+
+		shootNameGenerator: shootNameGenerator,
+	}
+}
+
+// ProvisionRuntime registers the Runtime in Director and persists the provisioning operation.
+// When any step after the registration fails, the Runtime is unregistered from Director so that it is not orphaned.
+func (r *service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount, idempotencyKey string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	unlock := r.lockIdempotencyKey(tenant, idempotencyKey)
+	defer unlock()
+
+	startedOperation, found, err := r.findIdempotentOperation(tenant, idempotencyKey, model.Provision)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		log.Infof("Provisioning with idempotency key %s already started for Runtime %s", idempotencyKey, startedOperation.ClusterID)
+		return r.graphQLConverter.OperationStatusToGQLOperationStatus(startedOperation), nil
+	}
+
+	config, err = r.withShootName(config)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeID, err := r.registerRuntime(config.RuntimeInput, tenant)
+	if err != nil {
+		return nil, err.Append("Failed to register Runtime")
+	}
+
+	operation, err := r.startProvisioning(runtimeID, config, tenant, subAccount, idempotencyKey)
+	if err != nil {
+		r.unregisterFailedRuntime(runtimeID, tenant)
+		return nil, err
+	}
+
+	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
+}
+
+// ProvisionRuntimeDryRun validates and renders the provisioning without registering the Runtime in Director,
+// storing it or creating the Shoot. Problems with the configuration are returned in the report instead of the error.
+func (r *service) ProvisionRuntimeDryRun(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	report := r.provisioningDryRunReport(config, tenant, subAccount)
+
+	return r.graphQLConverter.ProvisioningDryRunReportToGQLOperationStatus(report), nil
+}
+
+func (r *service) provisioningDryRunReport(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) model.ProvisioningDryRunReport {
+	var report model.ProvisioningDryRunReport
+
+	config, err := r.withShootName(config)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report
+	}
+
+	cluster, err := r.inputConverter.ProvisioningInputToClusterDryRun(r.uuidGenerator.New(), config, tenant, subAccount)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report
+	}
+
+	if cluster.KymaConfig != nil {
+		report.KymaVersion = &cluster.KymaConfig.Release.Version
+		report.KymaConfig = cluster.KymaConfig
+
+		validationErr := r.validateKymaConfig(*cluster.KymaConfig)
+		if validationErr != nil {
+			report.Errors = append(report.Errors, validationErr.Error())
+		}
+	}
+
+	kubernetesVersion, err := r.resolveProvisioningKubernetesVersion(cluster.ClusterConfig)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	} else {
+		cluster.ClusterConfig.KubernetesVersion = kubernetesVersion
+		report.KubernetesVersion = &kubernetesVersion
+	}
+
+	if model.MachineImageVersionPinOverridden(cluster.ClusterConfig) {
+		report.Warnings = append(report.Warnings, "Machine image version auto update is enabled, the pinned version will be overridden by Gardener maintenance")
+	}
+
+	if r.provisioningThrottle != nil {
+		limitReached, limit, dberr := r.provisioningThrottle.LimitReached(tenant)
+		if dberr != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("Provisioning limit not checked: %s", dberr.Error()))
+		} else if limitReached {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("Limit of %d concurrent provisioning operations reached for the global account, provisioning would be queued", limit))
+		}
+	}
+
+	shoot, err := r.provisioner.ProvisionClusterDryRun(cluster)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report
 	}
+
+	report.ShootSpec = &shoot.Spec
+
+	if !shoot.PreflightChecked {
+		report.Warnings = append(report.Warnings, "Pre-flight checks are disabled, Gardener credentials and quotas were not checked")
+	}
+	if shoot.PreflightError != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("pre-flight check failed: %s", shoot.PreflightError.Error()))
+	}
+
+	return report
 }
 
-func (r *service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
-	runtimeInput := config.RuntimeInput
+// resolveProvisioningKubernetesVersion resolves the requested Kubernetes version to the latest patch supported by the cloud profile
+func (r *service) resolveProvisioningKubernetesVersion(config model.GardenerConfig) (string, apperrors.AppError) {
+	if r.kubernetesVersionResolver == nil || config.GardenerProviderConfig == nil {
+		return config.KubernetesVersion, nil
+	}
+
+	version, err := r.kubernetesVersionResolver.Resolve(config.GardenerProviderConfig.CloudProfileName(), config.KubernetesVersion)
+	if err != nil {
+		return "", err.Append("Failed to resolve Kubernetes version")
+	}
+
+	return version, nil
+}
+
+// withShootName returns the input with the generated Shoot name if the name is not provided, the input itself is not modified.
+// Names are not generated if the shootNameGenerator is nil
+func (r *service) withShootName(config gqlschema.ProvisionRuntimeInput) (gqlschema.ProvisionRuntimeInput, apperrors.AppError) {
+	if r.shootNameGenerator == nil || config.ClusterConfig == nil || config.ClusterConfig.GardenerConfig == nil ||
+		util.NotNilOrEmpty(config.ClusterConfig.GardenerConfig.Name) {
+		return config, nil
+	}
+
+	name, err := r.shootNameGenerator.Generate(util.UnwrapStr(config.ClusterConfig.GardenerConfig.GardenerProject))
+	if err != nil {
+		return config, apperrors.Internal("Failed to generate Shoot name: %s", err.Error())
+	}
+
+	gardenerConfig := *config.ClusterConfig.GardenerConfig
+	gardenerConfig.Name = &name
+	clusterConfig := *config.ClusterConfig
+	clusterConfig.GardenerConfig = &gardenerConfig
+	config.ClusterConfig = &clusterConfig
+
+	return config, nil
+}
 
+func (r *service) registerRuntime(runtimeInput *gqlschema.RuntimeInput, tenant string) (string, apperrors.AppError) {
 	var runtimeID string
 
 	err := util.RetryOnError(5*time.Second, 3, "Error while registering runtime in Director: %s", func() (err apperrors.AppError) {
@@ -100,44 +374,93 @@ func (r *service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenan
 		return
 	})
 
-	if err != nil {
-		return nil, err.Append("Failed to register Runtime")
-	}
+	return runtimeID, err
+}
 
+// startProvisioning persists the cluster together with the operation in a single transaction.
+// The provisioning is triggered only if the limit for the global account is not reached,
+// otherwise the operation is stored as pending.
+func (r *service) startProvisioning(runtimeID string, config gqlschema.ProvisionRuntimeInput, tenant, subAccount, idempotencyKey string) (model.Operation, apperrors.AppError) {
 	cluster, err := r.inputConverter.ProvisioningInputToCluster(runtimeID, config, tenant, subAccount)
 	if err != nil {
-		r.unregisterFailedRuntime(runtimeID, tenant)
-		return nil, err
+		return model.Operation{}, err
+	}
+
+	var installationTimeout *int
+	if cluster.KymaConfig != nil {
+		validationErr := r.validateKymaConfig(*cluster.KymaConfig)
+		if validationErr != nil {
+			return model.Operation{}, apperrors.BadRequest("error: %s", validationErr.Error())
+		}
+
+		installationTimeout = r.installationTimeout(config.KymaConfig)
+	}
+
+	limitReached, limit := false, 0
+	if r.provisioningThrottle != nil {
+		// Lock is held until the operation is committed so that concurrent requests do not exceed the limit
+		r.provisioningThrottle.Lock()
+		defer r.provisioningThrottle.Unlock()
+
+		var dberr dberrors.Error
+		limitReached, limit, dberr = r.provisioningThrottle.LimitReached(tenant)
+		if dberr != nil {
+			return model.Operation{}, apperrors.Internal(dberr.Error())
+		}
 	}
 
 	dbSession, dberr := r.dbSessionFactory.NewSessionWithinTransaction()
 	if dberr != nil {
-		return nil, apperrors.Internal("Failed to start database transaction: %s", dberr.Error())
+		return model.Operation{}, apperrors.Internal("Failed to start database transaction: %s", dberr.Error())
 	}
 	defer dbSession.RollbackUnlessCommitted()
 
+	if limitReached {
+		log.Infof("Provisioning limit of %d reached for global account %s, provisioning of Runtime %s is queued", limit, tenant, runtimeID)
+
+		message := fmt.Sprintf("Provisioning queued: limit of %d concurrent provisioning operations reached for the global account", limit)
+		operation, dberr := r.setProvisioningStarted(dbSession, runtimeID, cluster, model.Pending, message, installationTimeout)
+		if dberr != nil {
+			return model.Operation{}, apperrors.Internal(dberr.Error())
+		}
+
+		dberr = r.insertIdempotencyKey(dbSession, tenant, idempotencyKey, operation)
+		if dberr != nil {
+			return model.Operation{}, apperrors.Internal("Failed to store idempotency key: %s", dberr.Error())
+		}
+
+		dberr = dbSession.Commit()
+		if dberr != nil {
+			return model.Operation{}, apperrors.Internal("Failed to commit transaction: %s", dberr.Error())
+		}
+
+		return operation, nil
+	}
+
 	// Try to set provisioning started before triggering it (which is hard to interrupt) to verify all unique constraints
-	operation, dberr := r.setProvisioningStarted(dbSession, runtimeID, cluster)
+	operation, dberr := r.setProvisioningStarted(dbSession, runtimeID, cluster, model.InProgress, "Provisioning started", installationTimeout)
 	if dberr != nil {
-		r.unregisterFailedRuntime(runtimeID, tenant)
-		return nil, apperrors.Internal(dberr.Error())
+		return model.Operation{}, apperrors.Internal(dberr.Error())
+	}
+
+	dberr = r.insertIdempotencyKey(dbSession, tenant, idempotencyKey, operation)
+	if dberr != nil {
+		return model.Operation{}, apperrors.Internal("Failed to store idempotency key: %s", dberr.Error())
 	}
 
 	err = r.provisioner.ProvisionCluster(cluster, operation.ID)
 	if err != nil {
-		r.unregisterFailedRuntime(runtimeID, tenant)
-		return nil, err.Append("Failed to start provisioning")
+		return model.Operation{}, err.Append("Failed to start provisioning")
 	}
 
 	dberr = dbSession.Commit()
 	if dberr != nil {
-		r.unregisterFailedRuntime(runtimeID, tenant)
-		return nil, apperrors.Internal("Failed to commit transaction: %s", dberr.Error())
+		return model.Operation{}, apperrors.Internal("Failed to commit transaction: %s", dberr.Error())
 	}
 
 	r.provisioningQueue.Add(operation.ID)
 
-	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
+	return operation, nil
 }
 
 func (r *service) unregisterFailedRuntime(id, tenant string) {
@@ -151,10 +474,22 @@ func (r *service) unregisterFailedRuntime(id, tenant string) {
 	}
 }
 
-func (r *service) DeprovisionRuntime(id, tenant string) (string, apperrors.AppError) {
+func (r *service) DeprovisionRuntime(id, tenant string, force bool, idempotencyKey string) (string, apperrors.AppError) {
+	unlock := r.lockIdempotencyKey(tenant, idempotencyKey)
+	defer unlock()
+
+	startedOperation, found, err := r.findIdempotentOperation(tenant, idempotencyKey, model.Deprovision)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		log.Infof("Deprovisioning with idempotency key %s already started for Runtime %s", idempotencyKey, startedOperation.ClusterID)
+		return startedOperation.ID, nil
+	}
+
 	session := r.dbSessionFactory.NewReadWriteSession()
 
-	err := r.verifyLastOperationFinished(session, id)
+	err = r.verifyLastOperationFinished(session, id)
 	if err != nil {
 		return "", err
 	}
@@ -164,31 +499,52 @@ func (r *service) DeprovisionRuntime(id, tenant string) (string, apperrors.AppEr
 		return "", apperrors.Internal("Failed to get cluster: %s", dberr.Error())
 	}
 
-	operation, err := r.provisioner.DeprovisionCluster(cluster, r.uuidGenerator.New())
+	operation, err := r.provisioner.DeprovisionCluster(cluster, r.uuidGenerator.New(), force)
 	if err != nil {
 		return "", apperrors.Internal("Failed to start deprovisioning: %s", err.Error())
 	}
 
 	dberr = session.InsertOperation(operation)
 	if dberr != nil {
+		if isOperationInProgress(dberr) {
+			return "", operationInProgressError(id)
+		}
 		return "", apperrors.Internal("Failed to insert operation to database: %s", dberr.Error())
 	}
 
+	// Deprovisioning is already started in Gardener, so the operation is returned even if the key could not be stored
+	dberr = r.insertIdempotencyKey(session, tenant, idempotencyKey, operation)
+	if dberr != nil {
+		log.Warnf("Failed to store idempotency key %s of deprovisioning operation %s: %s", idempotencyKey, operation.ID, dberr.Error())
+	}
+
 	r.deprovisioningQueue.Add(operation.ID)
 
 	return operation.ID, nil
 }
 
-func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.UpgradeShootInput, tenant, idempotencyKey string) (*gqlschema.OperationStatus, apperrors.AppError) {
 	log.Infof("Starting Upgrade of Gardener Shoot for Runtime '%s'...", runtimeID)
 
 	if input.GardenerConfig == nil {
 		return &gqlschema.OperationStatus{}, apperrors.Internal("Error: Gardener config is nil")
 	}
 
+	unlock := r.lockIdempotencyKey(tenant, idempotencyKey)
+	defer unlock()
+
+	startedOperation, found, err := r.findIdempotentOperation(tenant, idempotencyKey, model.UpgradeShoot)
+	if err != nil {
+		return &gqlschema.OperationStatus{}, err
+	}
+	if found {
+		log.Infof("Upgrade of Gardener Shoot with idempotency key %s already started for Runtime %s", idempotencyKey, startedOperation.ClusterID)
+		return r.graphQLConverter.OperationStatusToGQLOperationStatus(startedOperation), nil
+	}
+
 	session := r.dbSessionFactory.NewReadSession()
 
-	err := r.verifyLastOperationFinished(session, runtimeID)
+	err = r.verifyLastOperationFinished(session, runtimeID)
 	if err != nil {
 		return &gqlschema.OperationStatus{}, err
 	}
@@ -198,7 +554,12 @@ func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.Upgrade
 		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to find shoot cluster to upgrade in database: %s", dberr.Error())
 	}
 
-	gardenerConfig, err := r.inputConverter.UpgradeShootInputToGardenerConfig(*input.GardenerConfig, cluster.ClusterConfig)
+	upgradeConfig, err := r.resolveKubernetesVersion(*input.GardenerConfig, cluster.ClusterConfig)
+	if err != nil {
+		return &gqlschema.OperationStatus{}, err
+	}
+
+	gardenerConfig, err := r.inputConverter.UpgradeShootInputToGardenerConfig(upgradeConfig, cluster.ClusterConfig)
 	if err != nil {
 		return &gqlschema.OperationStatus{}, err.Append("Failed to convert GardenerClusterUpgradeConfig: %s", err.Error())
 	}
@@ -209,11 +570,33 @@ func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.Upgrade
 	}
 	defer txSession.RollbackUnlessCommitted()
 
-	operation, gardError := r.setGardenerShootUpgradeStarted(txSession, cluster, gardenerConfig, input.Administrators)
+	message := "Starting Gardener Shoot upgrade"
+	if model.ShieldedInstanceConfigChanged(cluster.ClusterConfig.GardenerProviderConfig, gardenerConfig.GardenerProviderConfig) {
+		log.Warnf("Shielded VM options of Runtime '%s' changed, worker nodes will be recreated", runtimeID)
+		message = fmt.Sprintf("%s. Warning: Shielded VM options changed, worker nodes will be recreated", message)
+	}
+	if model.InstanceMetadataOptionsChanged(cluster.ClusterConfig.GardenerProviderConfig, gardenerConfig.GardenerProviderConfig) {
+		log.Warnf("Instance metadata options of Runtime '%s' changed, worker nodes will be rolled", runtimeID)
+		message = fmt.Sprintf("%s. Warning: instance metadata options changed, worker nodes will be rolled", message)
+	}
+	if model.MachineImageVersionPinOverridden(gardenerConfig) {
+		log.Warnf("Machine image version of Runtime '%s' is pinned with auto update enabled, it will be overridden by Gardener maintenance", runtimeID)
+		message = fmt.Sprintf("%s. Warning: machine image version auto update is enabled, the pinned version will be overridden by Gardener maintenance", message)
+	}
+
+	operation, gardError := r.setGardenerShootUpgradeStarted(txSession, cluster, gardenerConfig, input.Administrators, message)
 	if gardError != nil {
+		if isOperationInProgress(gardError) {
+			return &gqlschema.OperationStatus{}, operationInProgressError(runtimeID)
+		}
 		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to set shoot upgrade started: %s", gardError.Error())
 	}
 
+	dbErr = r.insertIdempotencyKey(txSession, tenant, idempotencyKey, operation)
+	if dbErr != nil {
+		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to store idempotency key: %s", dbErr.Error())
+	}
+
 	err = r.provisioner.UpgradeCluster(cluster.ID, gardenerConfig)
 	if err != nil {
 		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to upgrade Cluster: %s", err.Error())
@@ -229,9 +612,95 @@ func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.Upgrade
 	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
 }
 
-func (r *service) HibernateCluster(runtimeID string) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) UpgradeGardenerShootDryRun(runtimeID string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+	log.Infof("Computing Gardener Shoot upgrade changes for Runtime '%s'...", runtimeID)
+
+	if input.GardenerConfig == nil {
+		return &gqlschema.OperationStatus{}, apperrors.Internal("Error: Gardener config is nil")
+	}
+
+	cluster, dberr := r.dbSessionFactory.NewReadSession().GetCluster(runtimeID)
+	if dberr != nil {
+		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to find shoot cluster to upgrade in database: %s", dberr.Error())
+	}
+
+	upgradeConfig, err := r.resolveKubernetesVersion(*input.GardenerConfig, cluster.ClusterConfig)
+	if err != nil {
+		return &gqlschema.OperationStatus{}, err
+	}
+
+	gardenerConfig, err := r.inputConverter.UpgradeShootInputToGardenerConfig(upgradeConfig, cluster.ClusterConfig)
+	if err != nil {
+		return &gqlschema.OperationStatus{}, err.Append("Failed to convert GardenerClusterUpgradeConfig: %s", err.Error())
+	}
+
+	changes, err := r.provisioner.UpgradeClusterDryRun(cluster.ID, gardenerConfig)
+	if err != nil {
+		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to compute Cluster upgrade changes: %s", err.Error())
+	}
+
+	return r.graphQLConverter.ShootSpecChangesToGQLOperationStatus(runtimeID, changes), nil
+}
+
+// resolveKubernetesVersion validates the upgrade path of the requested Kubernetes version
+// and resolves minor versions to the latest patch supported by the cloud profile of the Shoot
+func (r *service) resolveKubernetesVersion(input gqlschema.GardenerUpgradeInput, currentConfig model.GardenerConfig) (gqlschema.GardenerUpgradeInput, apperrors.AppError) {
+	if input.KubernetesVersion == nil || *input.KubernetesVersion == "" {
+		return input, nil
+	}
+
+	err := model.ValidateKubernetesVersionUpgrade(currentConfig.KubernetesVersion, *input.KubernetesVersion)
+	if err != nil {
+		return input, err
+	}
+
+	if r.kubernetesVersionResolver == nil || currentConfig.GardenerProviderConfig == nil {
+		return input, nil
+	}
+
+	version, err := r.kubernetesVersionResolver.Resolve(currentConfig.GardenerProviderConfig.CloudProfileName(), *input.KubernetesVersion)
+	if err != nil {
+		return input, err.Append("Failed to resolve Kubernetes version")
+	}
+
+	input.KubernetesVersion = &version
+
+	return input, nil
+}
+
+func (r *service) HibernateCluster(runtimeID string, notBefore *time.Time) (*gqlschema.OperationStatus, apperrors.AppError) {
 	log.Infof("Starting hibernation for Runtime '%s'...", runtimeID)
 
+	return r.setClusterHibernation(runtimeID, hibernationOperation{
+		operationType:  model.Hibernate,
+		scheduledStage: model.StartingHibernation,
+		stage:          model.WaitForHibernation,
+		updateShoot:    r.provisioner.HibernateCluster,
+	}, notBefore)
+}
+
+func (r *service) WakeUpCluster(runtimeID string, notBefore *time.Time) (*gqlschema.OperationStatus, apperrors.AppError) {
+	log.Infof("Starting wake-up for Runtime '%s'...", runtimeID)
+
+	return r.setClusterHibernation(runtimeID, hibernationOperation{
+		operationType:  model.WakeUp,
+		scheduledStage: model.StartingWakeUp,
+		stage:          model.WaitForWakeUp,
+		updateShoot:    r.provisioner.WakeUpCluster,
+	}, notBefore)
+}
+
+// hibernationOperation describes how the hibernation of the cluster is changed.
+// The Shoot is updated right away and the operation waits for it to be reconciled,
+// unless the operation is scheduled, in which case it starts at scheduledStage once the scheduled time is reached
+type hibernationOperation struct {
+	operationType  model.OperationType
+	scheduledStage model.OperationStage
+	stage          model.OperationStage
+	updateShoot    func(clusterID string, gardenerConfig model.GardenerConfig) apperrors.AppError
+}
+
+func (r *service) setClusterHibernation(runtimeID string, hibernation hibernationOperation, notBefore *time.Time) (*gqlschema.OperationStatus, apperrors.AppError) {
 	session := r.dbSessionFactory.NewReadSession()
 
 	err := r.verifyLastOperationFinished(session, runtimeID)
@@ -241,7 +710,7 @@ func (r *service) HibernateCluster(runtimeID string) (*gqlschema.OperationStatus
 
 	cluster, dberr := session.GetCluster(runtimeID)
 	if dberr != nil {
-		return nil, apperrors.Internal("Failed to find shoot cluster to hibernate in database: %s", dberr.Error())
+		return nil, apperrors.Internal("Failed to find shoot cluster in database: %s", dberr.Error())
 	}
 
 	txSession, dbErr := r.dbSessionFactory.NewSessionWithinTransaction()
@@ -250,47 +719,131 @@ func (r *service) HibernateCluster(runtimeID string) (*gqlschema.OperationStatus
 	}
 	defer txSession.RollbackUnlessCommitted()
 
-	operation, gardError := r.setHibernationStarted(txSession, cluster, cluster.ClusterConfig)
-	if gardError != nil {
-		return nil, apperrors.Internal("Failed to set hibernation started: %s", gardError.Error())
+	now := time.Now()
+	scheduled := notBefore != nil && notBefore.After(now)
+
+	startTime, stage, message := now, hibernation.stage, "Starting "
+	if scheduled {
+		startTime, stage, message = *notBefore, hibernation.scheduledStage, fmt.Sprintf("Scheduled to start at %s", notBefore.UTC().Format(time.RFC3339))
 	}
 
-	err = r.provisioner.HibernateCluster(cluster.ID, cluster.ClusterConfig)
-	if err != nil {
-		return nil, apperrors.Internal("Failed to hibernate Cluster: %s", err.Error())
+	operation, dbError := r.setOperationStarted(txSession, cluster.ID, hibernation.operationType, stage, startTime, message)
+	if dbError != nil {
+		if isOperationInProgress(dbError) {
+			return nil, operationInProgressError(runtimeID)
+		}
+		return nil, apperrors.Internal("Failed to set %s operation started: %s", hibernation.operationType, dbError.Error())
+	}
+
+	if !scheduled {
+		err = hibernation.updateShoot(cluster.ID, cluster.ClusterConfig)
+		if err != nil {
+			return nil, apperrors.Internal("Failed to update hibernation of Cluster: %s", err.Error())
+		}
 	}
 
 	dbErr = txSession.Commit()
 	if dbErr != nil {
-		return nil, apperrors.Internal("Failed to commit hibernation transaction: %s", dbErr.Error())
+		return nil, apperrors.Internal("Failed to commit %s operation transaction: %s", hibernation.operationType, dbErr.Error())
+	}
+
+	if scheduled {
+		r.hibernationQueue.AddAfter(operation.ID, notBefore.Sub(now))
+	} else {
+		r.hibernationQueue.Add(operation.ID)
+	}
+
+	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
+}
+
+// CleanupFailedProvisioning starts the operation deleting the Shoot and the Runtime left behind by the failed provisioning.
+// It is processed by the deprovisioning queue, which deletes the Shoot, unregisters the Runtime from Director and marks the cluster as deleted
+func (r *service) CleanupFailedProvisioning(runtimeID string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	log.Infof("Starting cleanup of failed provisioning for Runtime '%s'...", runtimeID)
+
+	session := r.dbSessionFactory.NewReadWriteSession()
+
+	err := r.verifyLastOperationFinished(session, runtimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, dberr := r.setOperationStarted(session, runtimeID, model.CleanupFailedProvisioning, model.DeleteCluster, time.Now(), "Starting cleanup of failed provisioning")
+	if dberr != nil {
+		if isOperationInProgress(dberr) {
+			return nil, operationInProgressError(runtimeID)
+		}
+		return nil, apperrors.Internal("Failed to set cleanup operation started: %s", dberr.Error())
 	}
 
-	r.hibernationQueue.Add(operation.ID)
+	r.deprovisioningQueue.Add(operation.ID)
 
 	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
 }
 
+// ExtendRuntimeExpiration sets the time after which the Runtime is deprovisioned automatically, nil removes the expiration
+// e.g. when the trial Runtime is converted to the paid one
+func (r *service) ExtendRuntimeExpiration(runtimeID string, expireAt *time.Time) (*gqlschema.RuntimeStatus, apperrors.AppError) {
+	var expiration *time.Time
+	if expireAt != nil {
+		utc := expireAt.UTC()
+		expiration = &utc
+	}
+
+	dberr := r.dbSessionFactory.NewWriteSession().UpdateClusterExpiration(runtimeID, expiration)
+	if dberr != nil {
+		if dberr.Code() == dberrors.CodeNotFound {
+			return nil, apperrors.BadRequest("error: Runtime %s does not exist or is deleted", runtimeID)
+		}
+		return nil, apperrors.Internal("Failed to update expiration of Runtime %s: %s", runtimeID, dberr.Error())
+	}
+
+	return r.RuntimeStatus(runtimeID)
+}
+
 func (r *service) verifyLastOperationFinished(session dbsession.ReadSession, runtimeId string) apperrors.AppError {
 	lastOperation, dberr := session.GetLastOperation(runtimeId)
 	if dberr != nil {
 		return apperrors.Internal("failed to get last operation: %s", dberr.Error())
 	}
 
-	if lastOperation.State == model.InProgress {
-		return apperrors.BadRequest("cannot start new operation for %s Runtime while previous one is in progress", runtimeId)
+	if lastOperation.State == model.InProgress || lastOperation.State == model.Pending {
+		return operationInProgressError(runtimeId)
 	}
 
 	return nil
 }
 
-func (r *service) UpgradeRuntime(runtimeId string, input gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+func operationInProgressError(runtimeID string) apperrors.AppError {
+	return apperrors.ErrOperationInProgress("cannot start new operation for %s Runtime while previous one is in progress", runtimeID)
+}
+
+// isOperationInProgress checks if the operation was not inserted because another operation started concurrently after the validation
+func isOperationInProgress(err error) bool {
+	var dbErr dberrors.Error
+	return errors.As(err, &dbErr) && dbErr.Code() == dberrors.CodeOperationInProgress
+}
+
+func (r *service) UpgradeRuntime(runtimeId string, input gqlschema.UpgradeRuntimeInput, tenant, idempotencyKey string, skipHealthChecks bool) (*gqlschema.OperationStatus, apperrors.AppError) {
 	if input.KymaConfig == nil {
 		return &gqlschema.OperationStatus{}, apperrors.BadRequest("error: Kyma config is nil")
 	}
 
+	unlock := r.lockIdempotencyKey(tenant, idempotencyKey)
+	defer unlock()
+
+	startedOperation, found, err := r.findIdempotentOperation(tenant, idempotencyKey, model.Upgrade)
+	if err != nil {
+		return &gqlschema.OperationStatus{}, err
+	}
+	if found {
+		log.Infof("Upgrade with idempotency key %s already started for Runtime %s", idempotencyKey, startedOperation.ClusterID)
+		return r.graphQLConverter.OperationStatusToGQLOperationStatus(startedOperation), nil
+	}
+
 	session := r.dbSessionFactory.NewReadSession()
 
-	err := r.verifyLastOperationFinished(session, runtimeId)
+	err = r.verifyLastOperationFinished(session, runtimeId)
 	if err != nil {
 		return &gqlschema.OperationStatus{}, err
 	}
@@ -300,22 +853,44 @@ func (r *service) UpgradeRuntime(runtimeId string, input gqlschema.UpgradeRuntim
 		return &gqlschema.OperationStatus{}, err.Append("failed to convert KymaConfigInput")
 	}
 
+	validationErr := r.validateKymaConfig(kymaConfig)
+	if validationErr != nil {
+		return &gqlschema.OperationStatus{}, apperrors.BadRequest("error: %s", validationErr.Error())
+	}
+
 	cluster, dberr := session.GetCluster(runtimeId)
 	if dberr != nil {
 		return &gqlschema.OperationStatus{}, apperrors.Internal("failed to read cluster from database: %s", dberr.Error())
 	}
 
+	if cluster.KymaConfig == nil {
+		return &gqlschema.OperationStatus{}, apperrors.BadRequest("error: Kyma of Runtime %s is managed externally, upgrade Kyma with its reconciler or use upgradeShoot to upgrade the cluster", runtimeId)
+	}
+
+	// The profile is switched only if the upgrade requests it, otherwise the Runtime keeps its current profile
+	if input.KymaConfig.Profile == nil {
+		kymaConfig.Profile = cluster.KymaConfig.Profile
+	}
+
 	txSession, dberr := r.dbSessionFactory.NewSessionWithinTransaction()
 	if dberr != nil {
 		return &gqlschema.OperationStatus{}, apperrors.Internal("failed to start database transaction: %s", dberr.Error())
 	}
 	defer txSession.RollbackUnlessCommitted()
 
-	operation, dberr := r.setUpgradeStarted(txSession, cluster, kymaConfig)
+	operation, dberr := r.setUpgradeStarted(txSession, cluster, kymaConfig, r.installationTimeout(input.KymaConfig), skipHealthChecks)
 	if dberr != nil {
+		if isOperationInProgress(dberr) {
+			return &gqlschema.OperationStatus{}, operationInProgressError(runtimeId)
+		}
 		return &gqlschema.OperationStatus{}, apperrors.Internal("failed to set upgrade started: %s", dberr.Error())
 	}
 
+	dberr = r.insertIdempotencyKey(txSession, tenant, idempotencyKey, operation)
+	if dberr != nil {
+		return &gqlschema.OperationStatus{}, apperrors.Internal("failed to store idempotency key: %s", dberr.Error())
+	}
+
 	dberr = txSession.Commit()
 	if dberr != nil {
 		return &gqlschema.OperationStatus{}, apperrors.Internal("failed to commit upgrade transaction: %s", dberr.Error())
@@ -336,7 +911,69 @@ func (r *service) RuntimeStatus(runtimeID string) (*gqlschema.RuntimeStatus, app
 		return nil, apperrors.Internal("failed to get Runtime Status: %s", dberr.Error())
 	}
 
-	return r.graphQLConverter.RuntimeStatusToGraphQLStatus(runtimeStatus), nil
+	status := r.graphQLConverter.RuntimeStatusToGraphQLStatus(runtimeStatus)
+	status.LastOperationStatus.Progress = r.operationProgress(runtimeStatus.LastOperationStatus)
+
+	return status, nil
+}
+
+// RuntimeStatuses reads clusters and last operations of all requested Runtimes at once and returns their statuses in the requested order
+func (r *service) RuntimeStatuses(tenant string, runtimeIDs []string, skipGardenerStatus bool) ([]*gqlschema.RuntimeStatusEntry, apperrors.AppError) {
+	maxBatchSize := r.runtimeStatusesConfig.MaxBatchSize
+	if maxBatchSize > 0 && len(runtimeIDs) > maxBatchSize {
+		return nil, apperrors.BadRequest("error: %d Runtimes requested, at most %d Runtimes can be requested at once", len(runtimeIDs), maxBatchSize)
+	}
+
+	session := r.dbSessionFactory.NewReadSession()
+
+	clusters, dberr := session.GetClustersByIDs(runtimeIDs)
+	if dberr != nil {
+		return nil, apperrors.Internal("failed to get Runtime Statuses: %s", dberr.Error())
+	}
+
+	lastOperations, dberr := session.GetLastOperationsByRuntimeIDs(runtimeIDs)
+	if dberr != nil {
+		return nil, apperrors.Internal("failed to get Runtime Statuses: %s", dberr.Error())
+	}
+
+	tenantClusters := make(map[string]model.Cluster, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.Tenant == tenant {
+			tenantClusters[cluster.ID] = cluster
+		}
+	}
+
+	statuses := make([]*gqlschema.RuntimeStatusEntry, 0, len(runtimeIDs))
+	var notFound []string
+	for _, runtimeID := range runtimeIDs {
+		cluster, clusterFound := tenantClusters[runtimeID]
+		operation, operationFound := lastOperations[runtimeID]
+		if !clusterFound || !operationFound {
+			notFound = append(notFound, runtimeID)
+			continue
+		}
+
+		runtimeStatus, apperr := r.toRuntimeStatus(cluster, operation, !skipGardenerStatus)
+		if apperr != nil {
+			return nil, apperr.Append("failed to get status of Runtime %s", runtimeID)
+		}
+
+		status := r.graphQLConverter.RuntimeStatusToGraphQLStatus(runtimeStatus)
+		status.LastOperationStatus.Progress = r.operationProgress(operation)
+		if skipGardenerStatus {
+			status.HibernationStatus = nil
+			status.ShootStatus = nil
+		}
+
+		statuses = append(statuses, &gqlschema.RuntimeStatusEntry{RuntimeID: runtimeID, Status: status})
+	}
+
+	// Runtimes of other tenants are reported the same way as not existing ones not to reveal their existence
+	if len(notFound) > 0 && r.runtimeStatusesConfig.StrictTenancy {
+		return nil, apperrors.BadRequest("error: Runtimes not found for the tenant: %s", strings.Join(notFound, ", "))
+	}
+
+	return statuses, nil
 }
 
 func (r *service) RuntimeOperationStatus(operationID string) (*gqlschema.OperationStatus, apperrors.AppError) {
@@ -347,7 +984,121 @@ func (r *service) RuntimeOperationStatus(operationID string) (*gqlschema.Operati
 		return nil, apperrors.Internal("failed to get Runtime Operation Status: %s", dberr.Error())
 	}
 
-	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
+	annotations, dberr := readSession.ListOperationAnnotations(operationID)
+	if dberr != nil {
+		return nil, apperrors.Internal("failed to get annotations of operation: %s", dberr.Error())
+	}
+
+	status := r.graphQLConverter.OperationStatusToGQLOperationStatus(operation)
+	status.Progress = r.operationProgress(operation)
+	status.Annotations = r.graphQLConverter.OperationAnnotationsToGQLOperationAnnotations(annotations)
+
+	return status, nil
+}
+
+// AnnotateOperation sets the annotation of the operation, empty value removes it. Annotations are stored separately from the operation,
+// so they are kept when the operation changes its state.
+func (r *service) AnnotateOperation(operationID, key, value string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	writeSession := r.dbSessionFactory.NewWriteSession()
+
+	var dberr dberrors.Error
+	if value == "" {
+		dberr = writeSession.DeleteOperationAnnotation(operationID, key)
+	} else {
+		dberr = writeSession.UpsertOperationAnnotation(model.OperationAnnotation{
+			OperationID: operationID,
+			Key:         key,
+			Value:       value,
+			UpdatedAt:   time.Now(),
+		})
+	}
+	if dberr != nil {
+		if dberr.Code() == dberrors.CodeNotFound {
+			return nil, apperrors.NotFound("error: operation %s does not exist", operationID)
+		}
+		return nil, apperrors.Internal("Failed to set annotation %s of operation %s: %s", key, operationID, dberr.Error())
+	}
+
+	return r.RuntimeOperationStatus(operationID)
+}
+
+func (r *service) operationProgress(operation model.Operation) *gqlschema.OperationProgress {
+	if r.progressEstimator == nil {
+		return nil
+	}
+
+	return r.graphQLConverter.OperationProgressToGQLOperationProgress(r.progressEstimator.Estimate(operation))
+}
+
+func (r *service) OperationsHistory(runtimeID string, first *int, after *string) (*gqlschema.OperationsHistory, apperrors.AppError) {
+	limit := defaultOperationsHistoryPageSize
+	if first != nil {
+		if *first < 1 || *first > maxOperationsHistoryPageSize {
+			return nil, apperrors.BadRequest("error: first must be between 1 and %d", maxOperationsHistoryPageSize)
+		}
+		limit = *first
+	}
+
+	readSession := r.dbSessionFactory.NewReadSession()
+
+	totalCount, dberr := readSession.OperationsCountByRuntimeID(runtimeID)
+	if dberr != nil {
+		return nil, apperrors.Internal("failed to get operations history: %s", dberr.Error())
+	}
+
+	offset := 0
+	if after != nil {
+		// Cursor holds the number of older operations left to return, so operations started in the meantime do not shift the pages
+		olderCount, err := decodeOperationsHistoryCursor(*after)
+		if err != nil || olderCount > totalCount {
+			return nil, apperrors.BadRequest("error: invalid cursor %s", *after)
+		}
+		offset = totalCount - olderCount
+	}
+
+	operations, dberr := readSession.ListOperationsByRuntimeID(runtimeID, limit, offset)
+	if dberr != nil {
+		return nil, apperrors.Internal("failed to get operations history: %s", dberr.Error())
+	}
+
+	history := &gqlschema.OperationsHistory{
+		Operations: make([]*gqlschema.OperationHistoryEntry, 0, len(operations)),
+		TotalCount: totalCount,
+	}
+	for _, operation := range operations {
+		history.Operations = append(history.Operations, r.graphQLConverter.OperationToGQLOperationHistoryEntry(operation))
+	}
+
+	olderCount := totalCount - offset - len(operations)
+	if olderCount < 0 {
+		olderCount = 0
+	}
+	endCursor := encodeOperationsHistoryCursor(olderCount)
+	history.EndCursor = &endCursor
+	history.HasNextPage = olderCount > 0
+
+	return history, nil
+}
+
+func encodeOperationsHistoryCursor(olderCount int) string {
+	return base64.URLEncoding.EncodeToString([]byte(strconv.Itoa(olderCount)))
+}
+
+func decodeOperationsHistoryCursor(cursor string) (int, error) {
+	decoded, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	olderCount, err := strconv.Atoi(string(decoded))
+	if err != nil {
+		return 0, err
+	}
+	if olderCount < 0 {
+		return 0, fmt.Errorf("negative number of operations: %d", olderCount)
+	}
+
+	return olderCount, nil
 }
 
 func (r *service) RollBackLastUpgrade(runtimeID string) (*gqlschema.RuntimeStatus, apperrors.AppError) {
@@ -392,6 +1143,398 @@ func (r *service) RollBackLastUpgrade(runtimeID string) (*gqlschema.RuntimeStatu
 	return r.RuntimeStatus(runtimeID)
 }
 
+func (r *service) SetQueueState(queueType gqlschema.QueueType, paused bool) (*gqlschema.QueueStatus, apperrors.AppError) {
+	operationType, err := queueTypeToOperationType(queueType)
+	if err != nil {
+		return nil, err
+	}
+
+	dberr := r.dbSessionFactory.NewWriteSession().UpdateQueueState(operationType, paused)
+	if dberr != nil {
+		return nil, apperrors.Internal("Failed to update %s queue state: %s", operationType, dberr.Error())
+	}
+
+	operationQueue := r.operationQueues()[operationType]
+	operationQueue.SetPaused(paused)
+	log.Infof("Operation queue %s paused: %t", operationType, paused)
+
+	return r.graphQLConverter.QueueStatusToGraphQLStatus(queueStatus(operationType, operationQueue)), nil
+}
+
+func (r *service) QueuesStatus() ([]*gqlschema.QueueStatus, apperrors.AppError) {
+	queues := r.operationQueues()
+
+	statuses := make([]*gqlschema.QueueStatus, 0, len(queuedOperationTypes))
+	for _, operationType := range queuedOperationTypes {
+		statuses = append(statuses, r.graphQLConverter.QueueStatusToGraphQLStatus(queueStatus(operationType, queues[operationType])))
+	}
+
+	return statuses, nil
+}
+
+// MaintenanceFreeze returns the active maintenance freeze windows, nothing is frozen if the windows are not configured
+func (r *service) MaintenanceFreeze() (*gqlschema.MaintenanceFreezeStatus, apperrors.AppError) {
+	if r.maintenanceFreeze == nil {
+		return r.graphQLConverter.MaintenanceFreezeToGraphQLStatus(false, nil), nil
+	}
+
+	return r.graphQLConverter.MaintenanceFreezeToGraphQLStatus(r.maintenanceFreeze.Frozen(), r.maintenanceFreeze.ActiveWindows()), nil
+}
+
+func (r *service) AuditEntries(filter *gqlschema.AuditEntriesFilter, first, offset *int) ([]*gqlschema.AuditEntry, apperrors.AppError) {
+	limit := defaultAuditEntriesPageSize
+	if first != nil {
+		if *first < 1 || *first > maxAuditEntriesPageSize {
+			return nil, apperrors.BadRequest("error: first must be between 1 and %d", maxAuditEntriesPageSize)
+		}
+		limit = *first
+	}
+
+	skip := 0
+	if offset != nil {
+		if *offset < 0 {
+			return nil, apperrors.BadRequest("error: offset must not be negative")
+		}
+		skip = *offset
+	}
+
+	entriesFilter := model.AuditEntriesFilter{}
+	if filter != nil {
+		entriesFilter = model.AuditEntriesFilter{
+			Tenant:      filter.Tenant,
+			Mutation:    filter.Mutation,
+			OperationID: filter.OperationID,
+			From:        filter.From,
+			To:          filter.To,
+		}
+	}
+
+	entries, dberr := r.dbSessionFactory.NewReadSession().ListAuditEntries(entriesFilter, limit, skip)
+	if dberr != nil {
+		return nil, apperrors.Internal("failed to list audit entries: %s", dberr.Error())
+	}
+
+	auditEntries := make([]*gqlschema.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		auditEntries = append(auditEntries, r.graphQLConverter.AuditEntryToGraphQLAuditEntry(entry))
+	}
+
+	return auditEntries, nil
+}
+
+// RetryFailedOperations moves the failed last operations of the Runtimes matching the filter back to InProgress in batches
+// and enqueues them with random delays. The throttle is locked for the whole request, so the provisioning and deprovisioning
+// operations retried by it count towards the limits of their global accounts.
+func (r *service) RetryFailedOperations(filter gqlschema.FailedOperationsFilter, dryRun bool) (*gqlschema.RetriedOperations, apperrors.AppError) {
+	operationsFilter := model.FailedOperationsFilter{
+		FailedAfter: filter.FailedAfter,
+		ErrorReason: filter.ErrorReason,
+	}
+	if filter.Type != nil {
+		operationType, err := graphQLTypeToOperationType(*filter.Type)
+		if err != nil {
+			return nil, err
+		}
+		operationsFilter.Type = &operationType
+	}
+
+	if r.provisioningThrottle != nil {
+		r.provisioningThrottle.Lock()
+		defer r.provisioningThrottle.Unlock()
+	}
+
+	readSession := r.dbSessionFactory.NewReadSession()
+
+	failedOperations, dberr := readSession.ListFailedLastOperations(operationsFilter)
+	if dberr != nil {
+		return nil, apperrors.Internal("Failed to list failed operations: %s", dberr.Error())
+	}
+
+	operations, err := r.retryableOperations(readSession, failedOperations)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		operations, err = r.retryOperations(operations)
+		r.requeueRetriedOperations(operations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	operationIDs := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		operationIDs = append(operationIDs, operation.ID)
+	}
+
+	return &gqlschema.RetriedOperations{
+		Count:        len(operationIDs),
+		OperationIDs: operationIDs,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// retryableOperations drops the operations which cannot be enqueued and the provisioning and deprovisioning operations
+// of the global accounts which would exceed their limits, the operations retried by the same request count towards the limits
+func (r *service) retryableOperations(readSession dbsession.ReadSession, operations []model.Operation) ([]model.Operation, apperrors.AppError) {
+	queues := r.retryQueues()
+	freeSlots := map[string]int{}
+
+	retryable := make([]model.Operation, 0, len(operations))
+	for _, operation := range operations {
+		if _, found := queues[operation.Type]; !found {
+			log.Infof("Failed operation %s of type %s is not retried, there is no queue for this operation type", operation.ID, operation.Type)
+			continue
+		}
+
+		if r.provisioningThrottle == nil || (operation.Type != model.Provision && operation.Type != model.Deprovision) {
+			retryable = append(retryable, operation)
+			continue
+		}
+
+		tenant, dberr := readSession.GetTenantForOperation(operation.ID)
+		if dberr != nil {
+			return nil, apperrors.Internal("Failed to get tenant of operation %s: %s", operation.ID, dberr.Error())
+		}
+
+		key := fmt.Sprintf("%s/%s", tenant, operation.Type)
+		slots, found := freeSlots[key]
+		if !found {
+			slots, dberr = r.provisioningThrottle.FreeSlots(tenant, operation.Type)
+			if dberr != nil {
+				return nil, apperrors.Internal("Failed to check limit of operation %s: %s", operation.ID, dberr.Error())
+			}
+		}
+		if slots == 0 {
+			log.Infof("Failed operation %s is not retried, global account %s reached the limit of %s operations in progress", operation.ID, tenant, operation.Type)
+			continue
+		}
+		if slots > 0 {
+			slots--
+		}
+		freeSlots[key] = slots
+
+		retryable = append(retryable, operation)
+	}
+
+	return retryable, nil
+}
+
+// retryOperations moves the operations back to InProgress in batches, the operations modified concurrently are skipped.
+// The operations retried before an error are returned with it, as their batches are already committed.
+func (r *service) retryOperations(operations []model.Operation) ([]model.Operation, apperrors.AppError) {
+	retryTime := time.Now()
+
+	retried := make([]model.Operation, 0, len(operations))
+	for start := 0; start < len(operations); start += retryBatchSize {
+		end := start + retryBatchSize
+		if end > len(operations) {
+			end = len(operations)
+		}
+
+		batch, err := r.retryOperationsBatch(operations[start:end], retryTime)
+		if err != nil {
+			return retried, err
+		}
+		retried = append(retried, batch...)
+	}
+
+	return retried, nil
+}
+
+func (r *service) retryOperationsBatch(operations []model.Operation, retryTime time.Time) ([]model.Operation, apperrors.AppError) {
+	session, dberr := r.dbSessionFactory.NewSessionWithinTransaction()
+	if dberr != nil {
+		return nil, apperrors.Internal("Failed to start database transaction: %s", dberr.Error())
+	}
+	defer session.RollbackUnlessCommitted()
+
+	retried := make([]model.Operation, 0, len(operations))
+	for _, operation := range operations {
+		dberr := session.RetryOperation(operation.ID, operation.Version, "Operation retried after failure", retryTime)
+		if dberr != nil {
+			if dberr.Code() == dberrors.CodeConflict || dberr.Code() == dberrors.CodeNotFound {
+				log.Infof("Failed operation %s is not retried, it was modified concurrently: %s", operation.ID, dberr.Error())
+				continue
+			}
+			return nil, apperrors.Internal("Failed to retry operation %s: %s", operation.ID, dberr.Error())
+		}
+		retried = append(retried, operation)
+	}
+
+	dberr = session.Commit()
+	if dberr != nil {
+		return nil, apperrors.Internal("Failed to commit retried operations: %s", dberr.Error())
+	}
+
+	return retried, nil
+}
+
+func (r *service) requeueRetriedOperations(operations []model.Operation) {
+	if len(operations) == 0 {
+		return
+	}
+
+	window := time.Duration(len(operations)) * retryWindowPerOperation
+	if window > maxRetryWindow {
+		window = maxRetryWindow
+	}
+
+	queue.NewRequeuer(r.retryQueues(), window, rand.New(rand.NewSource(time.Now().UnixNano())), log.StandardLogger()).Requeue(operations)
+}
+
+func (r *service) OrphanedShoots() ([]*gqlschema.OrphanedShoot, apperrors.AppError) {
+	if r.orphanedShootsDetector == nil {
+		return nil, apperrors.Internal("orphaned Shoots detection is not enabled")
+	}
+
+	orphaned := r.orphanedShootsDetector.OrphanedShoots()
+
+	shoots := make([]*gqlschema.OrphanedShoot, 0, len(orphaned))
+	for _, shoot := range orphaned {
+		shoots = append(shoots, r.graphQLConverter.OrphanedShootToGraphQLOrphanedShoot(shoot))
+	}
+
+	return shoots, nil
+}
+
+// RuntimeByShootName returns the Runtime of the Shoot, the tenant is returned only if it matches the tenant of the caller
+func (r *service) RuntimeByShootName(shootName, tenant string) (*gqlschema.ShootRuntime, apperrors.AppError) {
+	session := r.dbSessionFactory.NewReadSession()
+
+	cluster, dberr := session.GetGardenerClusterByName(shootName)
+	if dberr != nil {
+		if dberr.Code() == dberrors.CodeNotFound {
+			return nil, apperrors.NotFound("error: Runtime with Shoot %s not found", shootName)
+		}
+		return nil, apperrors.Internal("failed to get Runtime of Shoot %s: %s", shootName, dberr.Error())
+	}
+
+	shootRuntime := &gqlschema.ShootRuntime{
+		RuntimeID: cluster.ID,
+		Provider:  cluster.ClusterConfig.Provider,
+	}
+	if tenant != "" && tenant == cluster.Tenant {
+		shootRuntime.Tenant = &cluster.Tenant
+	}
+
+	lastOperation, dberr := session.GetLastOperation(cluster.ID)
+	if dberr != nil && dberr.Code() != dberrors.CodeNotFound {
+		return nil, apperrors.Internal("failed to get last operation of Runtime %s: %s", cluster.ID, dberr.Error())
+	}
+	if dberr == nil {
+		shootRuntime.LastOperation = r.graphQLConverter.OperationToGQLOperationHistoryEntry(lastOperation)
+	}
+
+	return shootRuntime, nil
+}
+
+// ProviderDefaults completes the defaults applied by the input converter with the default machine image of the cloud
+// profile and the rules of the region policy
+func (r *service) ProviderDefaults(provider gqlschema.Provider) (*gqlschema.ProviderDefaults, apperrors.AppError) {
+	providerName := strings.ToLower(string(provider))
+
+	defaults, err := r.inputConverter.ProviderDefaults(providerName)
+	if err != nil {
+		return nil, err.Append("failed to get defaults of %s provider", provider)
+	}
+
+	cloudProfileName := defaults.GardenerConfig.GardenerProviderConfig.CloudProfileName()
+	if r.machineImageDefaults != nil && cloudProfileName != "" && defaults.GardenerConfig.MachineImage == nil {
+		image, imageVersion, err := r.machineImageDefaults.DefaultMachineImage(cloudProfileName)
+		if err != nil {
+			return nil, err.Append("failed to get default machine image of %s provider", provider)
+		}
+		defaults.GardenerConfig.MachineImage = &image
+		defaults.GardenerConfig.MachineImageVersion = &imageVersion
+	}
+
+	defaults.Regions = model.ProviderRegions{ProviderAllowed: true}
+	if r.regionPolicy != nil {
+		rules, allowed := r.regionPolicy.ProviderRules(providerName)
+		defaults.Regions = model.ProviderRegions{
+			ProviderAllowed: allowed,
+			AllowedRegions:  rules.AllowedRegions,
+			DeniedRegions:   rules.DeniedRegions,
+			DeniedZones:     rules.DeniedZones,
+		}
+	}
+
+	return r.graphQLConverter.ProviderDefaultsToGraphQLProviderDefaults(provider, defaults), nil
+}
+
+func (r *service) operationQueues() map[model.OperationType]queue.OperationQueue {
+	return map[model.OperationType]queue.OperationQueue{
+		model.Provision:    r.provisioningQueue,
+		model.Deprovision:  r.deprovisioningQueue,
+		model.Upgrade:      r.upgradeQueue,
+		model.UpgradeShoot: r.shootUpgradeQueue,
+		model.Hibernate:    r.hibernationQueue,
+	}
+}
+
+// retryQueues maps the types of the operations which can be retried to their queues, the same as after restart
+func (r *service) retryQueues() map[model.OperationType]queue.OperationQueue {
+	queues := r.operationQueues()
+	queues[model.CleanupFailedProvisioning] = r.deprovisioningQueue
+	queues[model.WakeUp] = r.hibernationQueue
+
+	return queues
+}
+
+var queuedOperationTypes = []model.OperationType{model.Provision, model.Deprovision, model.Upgrade, model.UpgradeShoot, model.Hibernate}
+
+func queueStatus(operationType model.OperationType, operationQueue queue.OperationQueue) model.QueueStatus {
+	return model.QueueStatus{
+		QueueState: model.QueueState{
+			OperationType: operationType,
+			Paused:        operationQueue.IsPaused(),
+		},
+		Depth: operationQueue.Len(),
+	}
+}
+
+func queueTypeToOperationType(queueType gqlschema.QueueType) (model.OperationType, apperrors.AppError) {
+	switch queueType {
+	case gqlschema.QueueTypeProvision:
+		return model.Provision, nil
+	case gqlschema.QueueTypeDeprovision:
+		return model.Deprovision, nil
+	case gqlschema.QueueTypeUpgrade:
+		return model.Upgrade, nil
+	case gqlschema.QueueTypeUpgradeShoot:
+		return model.UpgradeShoot, nil
+	case gqlschema.QueueTypeHibernate:
+		return model.Hibernate, nil
+	default:
+		return "", apperrors.BadRequest("unknown queue type: %s", queueType)
+	}
+}
+
+func graphQLTypeToOperationType(operationType gqlschema.OperationType) (model.OperationType, apperrors.AppError) {
+	switch operationType {
+	case gqlschema.OperationTypeProvision:
+		return model.Provision, nil
+	case gqlschema.OperationTypeDeprovision:
+		return model.Deprovision, nil
+	case gqlschema.OperationTypeUpgrade:
+		return model.Upgrade, nil
+	case gqlschema.OperationTypeUpgradeShoot:
+		return model.UpgradeShoot, nil
+	case gqlschema.OperationTypeReconnectRuntime:
+		return model.ReconnectRuntime, nil
+	case gqlschema.OperationTypeHibernate:
+		return model.Hibernate, nil
+	case gqlschema.OperationTypeWakeUp:
+		return model.WakeUp, nil
+	case gqlschema.OperationTypeCleanupFailedProvisioning:
+		return model.CleanupFailedProvisioning, nil
+	default:
+		return "", apperrors.BadRequest("unknown operation type: %s", operationType)
+	}
+}
+
 func (r *service) getRuntimeStatus(runtimeID string) (model.RuntimeStatus, error) {
 	session := r.dbSessionFactory.NewReadSession()
 
@@ -405,19 +1548,72 @@ func (r *service) getRuntimeStatus(runtimeID string) (model.RuntimeStatus, error
 		return model.RuntimeStatus{}, err
 	}
 
-	hibernationStatus, apperr := r.provisioner.GetHibernationStatus(runtimeID, cluster.ClusterConfig)
+	runtimeStatus, apperr := r.toRuntimeStatus(cluster, operation, true)
 	if apperr != nil {
 		return model.RuntimeStatus{}, apperr
 	}
 
-	return model.RuntimeStatus{
+	return runtimeStatus, nil
+}
+
+// toRuntimeStatus reads the hibernation and Shoot statuses from Gardener only if requested, otherwise they are left empty.
+// Failure to read the Shoot status is not returned, the status is left empty instead
+func (r *service) toRuntimeStatus(cluster model.Cluster, operation model.Operation, withHibernationStatus bool) (model.RuntimeStatus, apperrors.AppError) {
+	runtimeStatus := model.RuntimeStatus{
 		LastOperationStatus:  operation,
 		RuntimeConfiguration: cluster,
-		HibernationStatus:    hibernationStatus,
-	}, nil
+	}
+
+	if !withHibernationStatus {
+		return runtimeStatus, nil
+	}
+
+	hibernationStatus, apperr := r.provisioner.GetHibernationStatus(cluster.ID, cluster.ClusterConfig)
+	if apperr != nil {
+		return model.RuntimeStatus{}, apperr
+	}
+
+	if hibernationStatus.Hibernated {
+		hibernationStatus.HibernatedSince = cluster.HibernatedAt
+		hibernationStatus.Trigger = cluster.HibernationInitiatedBy
+	}
+	hibernationStatus.LastWokenAt = cluster.LastWokenAt
+	runtimeStatus.HibernationStatus = hibernationStatus
+
+	shootStatus, apperr := r.provisioner.GetShootStatus(cluster.ID, cluster.ClusterConfig)
+	if apperr != nil {
+		log.Warnf("Failed to get Shoot status of Runtime %s: %s", cluster.ID, apperr.Error())
+	} else {
+		runtimeStatus.ShootStatus = shootStatus
+	}
+
+	return runtimeStatus, nil
+}
+
+// validateKymaConfig checks the overrides and the requested components against the release
+func (r *service) validateKymaConfig(kymaConfig model.KymaConfig) error {
+	if err := installation.ValidateOverrides(kymaConfig); err != nil {
+		return err
+	}
+
+	return installation.ValidateComponents(kymaConfig, r.extraKymaComponentsAllowed)
+}
+
+// installationTimeout returns the installation timeout in minutes requested in the Kyma config or the default one
+func (r *service) installationTimeout(kymaConfig *gqlschema.KymaConfigInput) *int {
+	if kymaConfig != nil && kymaConfig.InstallationTimeout != nil {
+		return kymaConfig.InstallationTimeout
+	}
+
+	if r.defaultInstallationTimeout == 0 {
+		return nil
+	}
+
+	minutes := int(r.defaultInstallationTimeout.Minutes())
+	return &minutes
 }
 
-func (r *service) setProvisioningStarted(dbSession dbsession.WriteSession, runtimeID string, cluster model.Cluster) (model.Operation, dberrors.Error) {
+func (r *service) setProvisioningStarted(dbSession dbsession.WriteSession, runtimeID string, cluster model.Cluster, state model.OperationState, message string, installationTimeout *int) (model.Operation, dberrors.Error) {
 	timestamp := time.Now()
 
 	cluster.CreationTimestamp = timestamp
@@ -432,12 +1628,14 @@ func (r *service) setProvisioningStarted(dbSession dbsession.WriteSession, runti
 		return model.Operation{}, dberrors.Internal("Failed to set provisioning started: %s", err)
 	}
 
-	err = dbSession.InsertKymaConfig(cluster.KymaConfig)
-	if err != nil {
-		return model.Operation{}, dberrors.Internal("Failed to set provisioning started: %s", err)
+	if cluster.KymaConfig != nil {
+		err = dbSession.InsertKymaConfig(*cluster.KymaConfig)
+		if err != nil {
+			return model.Operation{}, dberrors.Internal("Failed to set provisioning started: %s", err)
+		}
 	}
 
-	operation, err := r.setOperationStarted(dbSession, runtimeID, model.Provision, model.WaitingForClusterDomain, timestamp, "Provisioning started")
+	operation, err := r.insertOperation(dbSession, runtimeID, model.Provision, model.WaitingForClusterDomain, state, timestamp, message, installationTimeout)
 	if err != nil {
 		return model.Operation{}, err.Append("Failed to set provisioning started: %s")
 	}
@@ -445,7 +1643,7 @@ func (r *service) setProvisioningStarted(dbSession dbsession.WriteSession, runti
 	return operation, nil
 }
 
-func (r *service) setGardenerShootUpgradeStarted(txSession dbsession.WriteSession, currentCluster model.Cluster, gardenerConfig model.GardenerConfig, administrators []string) (model.Operation, error) {
+func (r *service) setGardenerShootUpgradeStarted(txSession dbsession.WriteSession, currentCluster model.Cluster, gardenerConfig model.GardenerConfig, administrators []string, message string) (model.Operation, error) {
 	log.Infof("Starting Upgrade of Gardener Shoot operation")
 
 	dberr := txSession.UpdateGardenerClusterConfig(gardenerConfig)
@@ -458,7 +1656,7 @@ func (r *service) setGardenerShootUpgradeStarted(txSession dbsession.WriteSessio
 		return model.Operation{}, dberrors.Internal("Failed to set Shoot Upgrade started: %s", dberr.Error())
 	}
 
-	operation, dbError := r.setOperationStarted(txSession, currentCluster.ID, model.UpgradeShoot, model.WaitingForShootNewVersion, time.Now(), "Starting Gardener Shoot upgrade")
+	operation, dbError := r.setOperationStarted(txSession, currentCluster.ID, model.UpgradeShoot, model.WaitingForShootNewVersion, time.Now(), message)
 
 	if dbError != nil {
 		return model.Operation{}, dbError.Append("Failed to start operation of Gardener Shoot upgrade %s", dbError.Error())
@@ -467,14 +1665,30 @@ func (r *service) setGardenerShootUpgradeStarted(txSession dbsession.WriteSessio
 	return operation, nil
 }
 
-func (r *service) setUpgradeStarted(txSession dbsession.WriteSession, cluster model.Cluster, kymaConfig model.KymaConfig) (model.Operation, dberrors.Error) {
+func (r *service) setUpgradeStarted(txSession dbsession.WriteSession, cluster model.Cluster, kymaConfig model.KymaConfig, installationTimeout *int, skipHealthChecks bool) (model.Operation, dberrors.Error) {
 
 	err := txSession.InsertKymaConfig(kymaConfig)
 	if err != nil {
 		return model.Operation{}, err.Append("Failed to insert Kyma Config")
 	}
 
-	operation, err := r.setOperationStarted(txSession, cluster.ID, model.Upgrade, model.StartingUpgrade, time.Now(), "Starting Kyma upgrade")
+	timestamp := time.Now()
+	// Force upgrade skips the health checks of the cluster before and after the upgrade
+	operation := model.Operation{
+		ID:             r.uuidGenerator.New(),
+		Type:           model.Upgrade,
+		StartTimestamp: timestamp,
+		State:          model.InProgress,
+		Message:        "Starting Kyma upgrade",
+		ClusterID:      cluster.ID,
+		Stage:          model.CheckingPreUpgradeHealth,
+		LastTransition: &timestamp,
+		Force:          skipHealthChecks,
+
+		InstallationTimeoutMinutes: installationTimeout,
+	}
+
+	err = txSession.InsertOperation(operation)
 	if err != nil {
 		return model.Operation{}, err.Append("Failed to set operation started")
 	}
@@ -500,18 +1714,6 @@ func (r *service) setUpgradeStarted(txSession dbsession.WriteSession, cluster mo
 	return operation, nil
 }
 
-func (r *service) setHibernationStarted(txSession dbsession.WriteSession, currentCluster model.Cluster, gardenerConfig model.GardenerConfig) (model.Operation, error) {
-	log.Infof("Starting hibernation operation")
-
-	operation, dbError := r.setOperationStarted(txSession, currentCluster.ID, model.Hibernate, model.WaitForHibernation, time.Now(), "Starting ")
-
-	if dbError != nil {
-		return model.Operation{}, dbError.Append("Failed to start hibernation operation:  %s", dbError.Error())
-	}
-
-	return operation, nil
-}
-
 func (r *service) setOperationStarted(
 	dbSession dbsession.WriteSession,
 	runtimeID string,
@@ -519,17 +1721,31 @@ func (r *service) setOperationStarted(
 	operationStage model.OperationStage,
 	timestamp time.Time,
 	message string) (model.Operation, dberrors.Error) {
+	return r.insertOperation(dbSession, runtimeID, operationType, operationStage, model.InProgress, timestamp, message, nil)
+}
+
+func (r *service) insertOperation(
+	dbSession dbsession.WriteSession,
+	runtimeID string,
+	operationType model.OperationType,
+	operationStage model.OperationStage,
+	state model.OperationState,
+	timestamp time.Time,
+	message string,
+	installationTimeout *int) (model.Operation, dberrors.Error) {
 	id := r.uuidGenerator.New()
 
 	operation := model.Operation{
 		ID:             id,
 		Type:           operationType,
 		StartTimestamp: timestamp,
-		State:          model.InProgress,
+		State:          state,
 		Message:        message,
 		ClusterID:      runtimeID,
 		Stage:          operationStage,
 		LastTransition: &timestamp,
+
+		InstallationTimeoutMinutes: installationTimeout,
 	}
 
 	err := dbSession.InsertOperation(operation)
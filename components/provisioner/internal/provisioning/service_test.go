@@ -1,6 +1,8 @@
 package provisioning
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 
 	mocks2 "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/mocks"
 	sessionMocks "github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/regionpolicy"
 
 	releaseMocks "github.com/kyma-project/control-plane/components/provisioner/internal/installation/release/mocks"
 
@@ -51,7 +54,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 	releaseProvider := &releaseMocks.Provider{}
 	releaseProvider.On("GetReleaseByVersion", kymaVersion).Return(kymaRelease, nil)
 
-	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 	graphQLConverter := NewGraphQLConverter()
 	uuidGenerator := uuid.NewUUIDGenerator()
 
@@ -91,6 +94,65 @@ func TestService_ProvisionRuntime(t *testing.T) {
 	clusterMatcher := getClusterMatcher(expectedCluster)
 	operationMatcher := getOperationMatcher(expectedOperation)
 
+	for _, testCase := range []struct {
+		description                  string
+		requestedInstallationTimeout *int
+		expectedInstallationTimeout  int
+	}{
+		{
+			description:                 "Should persist default installation timeout with the operation",
+			expectedInstallationTimeout: 60,
+		},
+		{
+			description:                  "Should persist installation timeout requested in Kyma config with the operation",
+			requestedInstallationTimeout: util.IntPtr(120),
+			expectedInstallationTimeout:  120,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			sessionFactoryMock := &sessionMocks.Factory{}
+			writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+			directorServiceMock := &directormock.DirectorClient{}
+			provisioner := &mocks2.Provisioner{}
+
+			provisioningQueue := &mocks.OperationQueue{}
+
+			kymaConfigInput := fixKymaGraphQLConfigInput(nil)
+			kymaConfigInput.InstallationTimeout = testCase.requestedInstallationTimeout
+
+			input := provisionRuntimeInput
+			input.KymaConfig = kymaConfigInput
+
+			installationTimeoutMatcher := func(operation model.Operation) bool {
+				return operation.InstallationTimeoutMinutes != nil && *operation.InstallationTimeoutMinutes == testCase.expectedInstallationTimeout
+			}
+
+			directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+			sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+			writeSessionWithinTransactionMock.On("InsertCluster", mock.MatchedBy(clusterMatcher)).Return(nil)
+			writeSessionWithinTransactionMock.On("InsertGardenerConfig", mock.AnythingOfType("model.GardenerConfig")).Return(nil)
+			writeSessionWithinTransactionMock.On("InsertKymaConfig", mock.AnythingOfType("model.KymaConfig")).Return(nil)
+			writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(installationTimeoutMatcher)).Return(nil)
+			writeSessionWithinTransactionMock.On("Commit").Return(nil)
+			writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+			provisioner.On("ProvisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher)).Return(nil)
+
+			provisioningQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+			service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil, nil, nil, nil, time.Hour, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+			//when
+			operationStatus, err := service.ProvisionRuntime(input, tenant, subAccountId, "")
+			require.NoError(t, err)
+
+			//then
+			require.NotNil(t, operationStatus.InstallationTimeout)
+			assert.Equal(t, testCase.expectedInstallationTimeout, *operationStatus.InstallationTimeout)
+			writeSessionWithinTransactionMock.AssertExpectations(t)
+		})
+	}
+
 	t.Run("Should start runtime provisioning of Gardener cluster and return operation ID", func(t *testing.T) {
 		//given
 		sessionFactoryMock := &sessionMocks.Factory{}
@@ -112,10 +174,10 @@ func TestService_ProvisionRuntime(t *testing.T) {
 
 		provisioningQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
 		require.NoError(t, err)
 
 		//then
@@ -128,6 +190,149 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		releaseProvider.AssertExpectations(t)
 	})
 
+	t.Run("Should provision Shoot with generated name when name is not provided", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		directorServiceMock := &directormock.DirectorClient{}
+		provisioner := &mocks2.Provisioner{}
+		shootNameGenerator := &mocks2.ShootNameGenerator{}
+
+		provisioningQueue := &mocks.OperationQueue{}
+
+		generatedNameMatcher := func(cluster model.Cluster) bool {
+			return cluster.ID == runtimeID && cluster.ClusterConfig.Name == "kgen123"
+		}
+
+		shootNameGenerator.On("Generate", "").Return("kgen123", nil)
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+		writeSessionWithinTransactionMock.On("InsertCluster", mock.MatchedBy(clusterMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertGardenerConfig", mock.MatchedBy(func(config model.GardenerConfig) bool { return config.Name == "kgen123" })).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", mock.AnythingOfType("model.KymaConfig")).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+		provisioner.On("ProvisionCluster", mock.MatchedBy(generatedNameMatcher), mock.MatchedBy(notEmptyUUIDMatcher)).Return(nil)
+
+		provisioningQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, shootNameGenerator)
+
+		//when
+		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, runtimeID, *operationStatus.RuntimeID)
+		assert.Nil(t, provisionRuntimeInput.ClusterConfig.GardenerConfig.Name)
+		shootNameGenerator.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		provisioner.AssertExpectations(t)
+	})
+
+	t.Run("Should return error without registering Runtime when Shoot name cannot be generated", func(t *testing.T) {
+		//given
+		directorServiceMock := &directormock.DirectorClient{}
+		shootNameGenerator := &mocks2.ShootNameGenerator{}
+
+		shootNameGenerator.On("Generate", "").Return("", fmt.Errorf("failed to generate unused Shoot name for Gardener project project in 5 attempts"))
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, nil, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, shootNameGenerator)
+
+		//when
+		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+		assert.Contains(t, err.Error(), "in 5 attempts")
+		directorServiceMock.AssertNotCalled(t, "CreateRuntime", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should not generate Shoot name when name is provided", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		directorServiceMock := &directormock.DirectorClient{}
+		provisioner := &mocks2.Provisioner{}
+		shootNameGenerator := &mocks2.ShootNameGenerator{}
+
+		provisioningQueue := &mocks.OperationQueue{}
+
+		gardenerConfig := *clusterConfig.GardenerConfig
+		gardenerConfig.Name = util.StringPtr("requested")
+		input := provisionRuntimeInput
+		input.ClusterConfig = &gqlschema.ClusterConfigInput{GardenerConfig: &gardenerConfig}
+
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+		writeSessionWithinTransactionMock.On("InsertCluster", mock.MatchedBy(clusterMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertGardenerConfig", mock.MatchedBy(func(config model.GardenerConfig) bool { return config.Name == "requested" })).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", mock.AnythingOfType("model.KymaConfig")).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+		provisioner.On("ProvisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher)).Return(nil)
+
+		provisioningQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, shootNameGenerator)
+
+		//when
+		_, err := service.ProvisionRuntime(input, tenant, subAccountId, "")
+
+		//then
+		require.NoError(t, err)
+		shootNameGenerator.AssertNotCalled(t, "Generate", mock.Anything)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
+
+	t.Run("Should queue runtime provisioning when provisioning limit for global account is reached", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSessionMock := &sessionMocks.ReadSession{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		directorServiceMock := &directormock.DirectorClient{}
+		provisioner := &mocks2.Provisioner{}
+
+		provisioningQueue := &mocks.OperationQueue{}
+
+		pendingOperationMatcher := getOperationMatcher(model.Operation{
+			ClusterID: runtimeID,
+			State:     model.Pending,
+			Type:      model.Provision,
+			Stage:     model.WaitingForClusterDomain,
+		})
+
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		sessionFactoryMock.On("NewReadSession").Return(readSessionMock)
+		readSessionMock.On("InProgressOperationsCountForTenant", tenant, model.Provision).Return(1, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+		writeSessionWithinTransactionMock.On("InsertCluster", mock.MatchedBy(clusterMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertGardenerConfig", mock.AnythingOfType("model.GardenerConfig")).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", mock.AnythingOfType("model.KymaConfig")).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(pendingOperationMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		throttle := NewProvisioningThrottle(ProvisioningLimits{Default: 1}, sessionFactoryMock)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil, throttle, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, runtimeID, *operationStatus.RuntimeID)
+		assert.Equal(t, gqlschema.OperationStatePending, operationStatus.State)
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		provisioner.AssertNotCalled(t, "ProvisionCluster", mock.Anything, mock.Anything)
+		provisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+
 	t.Run("Should return error and unregister Runtime when failed to commit transaction", func(t *testing.T) {
 		//given
 		sessionFactoryMock := &sessionMocks.Factory{}
@@ -146,10 +351,10 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		provisioner.On("ProvisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher)).Return(nil)
 		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Return(nil)
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
 		require.Error(t, err)
 
 		//then
@@ -178,10 +383,10 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		provisioner.On("ProvisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher)).Return(apperrors.Internal("error"))
 		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Return(nil)
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -194,22 +399,160 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		releaseProvider.AssertExpectations(t)
 	})
 
+	t.Run("Should return error and unregister Runtime when failed to start transaction", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		directorServiceMock := &directormock.DirectorClient{}
+		provisioner := &mocks2.Provisioner{}
+
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(nil, dberrors.Internal("error"))
+		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+
+		//then
+		assert.Contains(t, err.Error(), "Failed to start database transaction")
+		sessionFactoryMock.AssertExpectations(t)
+		directorServiceMock.AssertExpectations(t)
+		provisioner.AssertNotCalled(t, "ProvisionCluster", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error, roll back transaction and unregister Runtime when failed to persist cluster", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		directorServiceMock := &directormock.DirectorClient{}
+		provisioner := &mocks2.Provisioner{}
+
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+		writeSessionWithinTransactionMock.On("InsertCluster", mock.MatchedBy(clusterMatcher)).Return(dberrors.Internal("error"))
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+
+		//then
+		assert.Contains(t, err.Error(), "Failed to set provisioning started")
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+		directorServiceMock.AssertExpectations(t)
+		provisioner.AssertNotCalled(t, "ProvisionCluster", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should retry unregistering Runtime when failed to persist operation", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		directorServiceMock := &directormock.DirectorClient{}
+		provisioner := &mocks2.Provisioner{}
+
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+		writeSessionWithinTransactionMock.On("InsertCluster", mock.MatchedBy(clusterMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertGardenerConfig", mock.AnythingOfType("model.GardenerConfig")).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", mock.AnythingOfType("model.KymaConfig")).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(dberrors.Internal("error"))
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Once().Return(apperrors.Internal("unregistering error"))
+		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Once().Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+
+		//then
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+		directorServiceMock.AssertExpectations(t)
+		directorServiceMock.AssertNumberOfCalls(t, "DeleteRuntime", 2)
+		provisioner.AssertNotCalled(t, "ProvisionCluster", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error and unregister Runtime when overrides are invalid", func(t *testing.T) {
+		//given
+		directorServiceMock := &directormock.DirectorClient{}
+
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Return(nil)
+
+		kymaConfigInput := fixKymaGraphQLConfigInput(nil)
+		kymaConfigInput.Configuration = append(kymaConfigInput.Configuration, fixGQLConfigEntryInput("global..domain", "kyma.local", util.BoolPtr(false)))
+		invalidInput := provisionRuntimeInput
+		invalidInput.KymaConfig = kymaConfigInput
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, nil, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.ProvisionRuntime(invalidInput, tenant, subAccountId, "")
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+
+		//then
+		assert.Contains(t, err.Error(), "global override 'global..domain': invalid key syntax")
+		directorServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should return error and unregister Runtime when components are duplicated", func(t *testing.T) {
+		//given
+		directorServiceMock := &directormock.DirectorClient{}
+
+		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return(runtimeID, nil)
+		directorServiceMock.On("DeleteRuntime", runtimeID, tenant).Return(nil)
+
+		kymaConfigInput := fixKymaGraphQLConfigInput(nil)
+		kymaConfigInput.Components = append(kymaConfigInput.Components, &gqlschema.ComponentConfigurationInput{
+			Component: coreComponent,
+			Namespace: kymaIntegrationNamespace,
+		})
+		invalidInput := provisionRuntimeInput
+		invalidInput.KymaConfig = kymaConfigInput
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, nil, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, true, nil, nil)
+
+		//when
+		_, err := service.ProvisionRuntime(invalidInput, tenant, subAccountId, "")
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+
+		//then
+		assert.Contains(t, err.Error(), "core: duplicated with conflicting namespaces kyma-system and kyma-integration")
+		directorServiceMock.AssertExpectations(t)
+	})
+
 	t.Run("Should return error when failed to register Runtime", func(t *testing.T) {
 		//given
 		directorServiceMock := &directormock.DirectorClient{}
 
 		directorServiceMock.On("CreateRuntime", mock.Anything, tenant).Return("", apperrors.Internal("registering error"))
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, nil, nil, uuidGenerator, nil, nil, nil, nil, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, nil, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
 		//then
 		assert.Contains(t, err.Error(), "Failed to register Runtime")
 		directorServiceMock.AssertExpectations(t)
+		directorServiceMock.AssertNotCalled(t, "DeleteRuntime", mock.Anything, mock.Anything)
 	})
 
 	t.Run("Should retry when failed to register Runtime and start runtime provisioning of Gardener cluster", func(t *testing.T) {
@@ -234,10 +577,10 @@ func TestService_ProvisionRuntime(t *testing.T) {
 
 		provisioningQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, provisioningQueue, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId, "")
 		require.NoError(t, err)
 
 		//then
@@ -254,7 +597,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 
 func TestService_DeprovisionRuntime(t *testing.T) {
 
-	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 	graphQLConverter := NewGraphQLConverter()
 	lastOperation := model.Operation{State: model.Succeeded}
 
@@ -287,13 +630,13 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSession)
 		readWriteSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
 		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
-		provisioner.On("DeprovisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher)).Return(operation, nil)
+		provisioner.On("DeprovisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher), false).Return(operation, nil)
 		readWriteSession.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, deprovisioningQueue, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, deprovisioningQueue, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		opID, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		opID, err := resolver.DeprovisionRuntime(runtimeID, tenant, false, "")
 		require.NoError(t, err)
 
 		//then
@@ -312,12 +655,12 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSession)
 		readWriteSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
 		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
-		provisioner.On("DeprovisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher)).Return(model.Operation{}, apperrors.Internal("error"))
+		provisioner.On("DeprovisionCluster", mock.MatchedBy(clusterMatcher), mock.MatchedBy(notEmptyUUIDMatcher), false).Return(model.Operation{}, apperrors.Internal("error"))
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(runtimeID, tenant, false, "")
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -337,10 +680,10 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		readWriteSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
 		readWriteSession.On("GetCluster", runtimeID).Return(model.Cluster{}, dberrors.Internal("error"))
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(runtimeID, tenant, false, "")
 		require.Error(t, err)
 
 		//then
@@ -359,10 +702,10 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSession)
 		readWriteSession.On("GetLastOperation", runtimeID).Return(operation, nil)
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(runtimeID, tenant, false, "")
 		require.Error(t, err)
 
 		//then
@@ -379,10 +722,10 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSession)
 		readWriteSession.On("GetLastOperation", runtimeID).Return(model.Operation{}, dberrors.Internal("error"))
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		_, err := resolver.DeprovisionRuntime(runtimeID, tenant)
+		_, err := resolver.DeprovisionRuntime(runtimeID, tenant, false, "")
 		require.Error(t, err)
 
 		//then
@@ -392,51 +735,309 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 	})
 }
 
-func TestService_RuntimeOperationStatus(t *testing.T) {
-	uuidGenerator := &uuidMocks.UUIDGenerator{}
-	inputConverter := NewInputConverter(uuidGenerator, nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+func TestService_IdempotencyKey(t *testing.T) {
 	graphQLConverter := NewGraphQLConverter()
+	idempotencyKey := "retried-request"
 
 	operation := model.Operation{
-		ID:        operationID,
-		Type:      model.Provision,
-		State:     model.InProgress,
-		Message:   "Message",
-		ClusterID: runtimeID,
+		ID:             operationID,
+		Type:           model.Deprovision,
+		State:          model.InProgress,
+		StartTimestamp: time.Now(),
+		Message:        "Deprovisioning started",
+		ClusterID:      runtimeID,
 	}
 
-	t.Run("Should return operation status", func(t *testing.T) {
+	cluster := model.Cluster{
+		ID:     runtimeID,
+		Tenant: tenant,
+	}
+
+	t.Run("Should return operation started by the request with the same key", func(t *testing.T) {
 		//given
 		sessionFactoryMock := &sessionMocks.Factory{}
 		readSession := &sessionMocks.ReadSession{}
+		provisioner := &mocks2.Provisioner{}
 
 		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("GetIdempotencyKey", tenant, idempotencyKey).Return(model.IdempotencyKey{
+			Tenant:        tenant,
+			Key:           idempotencyKey,
+			OperationType: model.Deprovision,
+			OperationID:   operationID,
+			CreatedAt:     time.Now(),
+		}, nil)
 		readSession.On("GetOperation", operationID).Return(operation, nil)
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil)
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, time.Hour, nil, nil, false, nil, nil)
 
 		//when
-		status, err := resolver.RuntimeOperationStatus(operationID)
+		opID, err := service.DeprovisionRuntime(runtimeID, tenant, false, idempotencyKey)
+
 		//then
 		require.NoError(t, err)
-		assert.Equal(t, gqlschema.OperationTypeProvision, status.Operation)
-		assert.Equal(t, gqlschema.OperationStateInProgress, status.State)
-		assert.Equal(t, operation.ClusterID, *status.RuntimeID)
-		assert.Equal(t, operation.ID, *status.ID)
-		assert.Equal(t, operation.Message, *status.Message)
-		sessionFactoryMock.AssertExpectations(t)
-		readSession.AssertExpectations(t)
+		assert.Equal(t, operationID, opID)
+		provisioner.AssertNotCalled(t, "DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("Should return error when failed to get operation status", func(t *testing.T) {
+	t.Run("Should return original operation and Runtime ID for repeated provisioning request", func(t *testing.T) {
 		//given
+		provisioningOperation := model.Operation{ID: operationID, Type: model.Provision, State: model.InProgress, ClusterID: runtimeID}
+
 		sessionFactoryMock := &sessionMocks.Factory{}
 		readSession := &sessionMocks.ReadSession{}
+		directorServiceMock := &directormock.DirectorClient{}
 
 		sessionFactoryMock.On("NewReadSession").Return(readSession)
-		readSession.On("GetOperation", operationID).Return(model.Operation{}, dberrors.Internal("error"))
+		readSession.On("GetIdempotencyKey", tenant, idempotencyKey).Return(model.IdempotencyKey{
+			Tenant:        tenant,
+			Key:           idempotencyKey,
+			OperationType: model.Provision,
+			OperationID:   operationID,
+			CreatedAt:     time.Now(),
+		}, nil)
+		readSession.On("GetOperation", operationID).Return(provisioningOperation, nil)
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil)
+		service := NewProvisioningService(nil, graphQLConverter, directorServiceMock, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, time.Hour, nil, nil, false, nil, nil)
+
+		//when
+		operationStatus, err := service.ProvisionRuntime(gqlschema.ProvisionRuntimeInput{}, tenant, subAccountId, idempotencyKey)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operationID, *operationStatus.ID)
+		assert.Equal(t, runtimeID, *operationStatus.RuntimeID)
+		directorServiceMock.AssertNotCalled(t, "CreateRuntime", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should reject key used for different operation", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("GetIdempotencyKey", tenant, idempotencyKey).Return(model.IdempotencyKey{
+			Tenant:        tenant,
+			Key:           idempotencyKey,
+			OperationType: model.Provision,
+			OperationID:   operationID,
+			CreatedAt:     time.Now(),
+		}, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, time.Hour, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.DeprovisionRuntime(runtimeID, tenant, false, idempotencyKey)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("Should start new operation when key expired", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSession)
+		readSession.On("GetIdempotencyKey", tenant, idempotencyKey).Return(model.IdempotencyKey{
+			Tenant:        tenant,
+			Key:           idempotencyKey,
+			OperationType: model.Provision,
+			OperationID:   "expired-operation",
+			CreatedAt:     time.Now().Add(-2 * time.Hour),
+		}, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(model.Operation{State: model.Succeeded}, nil)
+		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		provisioner.On("DeprovisionCluster", cluster, mock.MatchedBy(notEmptyUUIDMatcher), false).Return(operation, nil)
+		readWriteSession.On("InsertOperation", operation).Return(nil)
+		readWriteSession.On("DeleteExpiredIdempotencyKey", tenant, idempotencyKey, mock.AnythingOfType("time.Time")).Return(nil)
+		readWriteSession.On("InsertIdempotencyKey", mock.MatchedBy(func(key model.IdempotencyKey) bool {
+			return key.Tenant == tenant && key.Key == idempotencyKey && key.OperationID == operationID && key.OperationType == model.Deprovision
+		})).Return(nil)
+		deprovisioningQueue.On("Add", operationID).Return()
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, deprovisioningQueue, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, time.Hour, nil, nil, false, nil, nil)
+
+		//when
+		opID, err := service.DeprovisionRuntime(runtimeID, tenant, false, idempotencyKey)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, operationID, opID)
+		readWriteSession.AssertExpectations(t)
+		deprovisioningQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should start single operation for simultaneous requests with the same key", func(t *testing.T) {
+		//given
+		const requests = 5
+
+		var storedKeyMutex sync.Mutex
+		var storedKey *model.IdempotencyKey
+
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		readWriteSession := &sessionMocks.ReadWriteSession{}
+		provisioner := &mocks2.Provisioner{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSession)
+		readSession.On("GetIdempotencyKey", tenant, idempotencyKey).Return(
+			func(string, string) model.IdempotencyKey {
+				storedKeyMutex.Lock()
+				defer storedKeyMutex.Unlock()
+				if storedKey == nil {
+					return model.IdempotencyKey{}
+				}
+				return *storedKey
+			},
+			func(string, string) dberrors.Error {
+				storedKeyMutex.Lock()
+				defer storedKeyMutex.Unlock()
+				if storedKey == nil {
+					return dberrors.NotFound("not found")
+				}
+				return nil
+			})
+		readSession.On("GetOperation", operationID).Return(operation, nil)
+		readWriteSession.On("GetLastOperation", runtimeID).Return(model.Operation{State: model.Succeeded}, nil)
+		readWriteSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		// Starting the operation takes time so that the requests overlap
+		provisioner.On("DeprovisionCluster", cluster, mock.MatchedBy(notEmptyUUIDMatcher), false).Return(operation, nil).Run(func(mock.Arguments) {
+			time.Sleep(50 * time.Millisecond)
+		})
+		readWriteSession.On("InsertOperation", operation).Return(nil)
+		readWriteSession.On("DeleteExpiredIdempotencyKey", tenant, idempotencyKey, mock.AnythingOfType("time.Time")).Return(nil)
+		readWriteSession.On("InsertIdempotencyKey", mock.AnythingOfType("model.IdempotencyKey")).Return(nil).Run(func(args mock.Arguments) {
+			key := args.Get(0).(model.IdempotencyKey)
+			storedKeyMutex.Lock()
+			defer storedKeyMutex.Unlock()
+			storedKey = &key
+		})
+		deprovisioningQueue.On("Add", operationID).Return()
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, deprovisioningQueue, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, time.Hour, nil, nil, false, nil, nil)
+
+		//when
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		operationIDs := make(chan string, requests)
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				opID, err := service.DeprovisionRuntime(runtimeID, tenant, false, idempotencyKey)
+				assert.NoError(t, err)
+				operationIDs <- opID
+			}()
+		}
+		close(start)
+		wg.Wait()
+		close(operationIDs)
+
+		//then
+		for opID := range operationIDs {
+			assert.Equal(t, operationID, opID)
+		}
+		provisioner.AssertNumberOfCalls(t, "DeprovisionCluster", 1)
+		readWriteSession.AssertNumberOfCalls(t, "InsertOperation", 1)
+		deprovisioningQueue.AssertNumberOfCalls(t, "Add", 1)
+	})
+}
+
+func TestService_RuntimeOperationStatus(t *testing.T) {
+	uuidGenerator := &uuidMocks.UUIDGenerator{}
+	inputConverter := NewInputConverter(uuidGenerator, nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
+	graphQLConverter := NewGraphQLConverter()
+
+	operation := model.Operation{
+		ID:        operationID,
+		Type:      model.Provision,
+		State:     model.InProgress,
+		Message:   "Message",
+		ClusterID: runtimeID,
+	}
+
+	t.Run("Should return operation status", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		annotatedAt := time.Now()
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("GetOperation", operationID).Return(operation, nil)
+		readSession.On("ListOperationAnnotations", operationID).
+			Return([]model.OperationAnnotation{{OperationID: operationID, Key: "ticket", Value: "waiting on hyperscaler ticket 12345", UpdatedAt: annotatedAt}}, nil)
+
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := resolver.RuntimeOperationStatus(operationID)
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, gqlschema.OperationTypeProvision, status.Operation)
+		assert.Equal(t, gqlschema.OperationStateInProgress, status.State)
+		assert.Equal(t, operation.ClusterID, *status.RuntimeID)
+		assert.Equal(t, operation.ID, *status.ID)
+		assert.Equal(t, operation.Message, *status.Message)
+		assert.Nil(t, status.Progress)
+		assert.Equal(t, []*gqlschema.OperationAnnotation{{Key: "ticket", Value: "waiting on hyperscaler ticket 12345", UpdatedAt: annotatedAt}}, status.Annotations)
+		sessionFactoryMock.AssertExpectations(t)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should return operation status with progress", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("GetOperation", operationID).Return(operation, nil)
+		readSession.On("ListOperationAnnotations", operationID).Return(nil, nil)
+
+		estimatedCompletion := time.Now().Add(30 * time.Minute)
+		progressEstimator := progressEstimatorStub{progress: &model.OperationProgress{
+			CurrentStage:        model.WaitingForInstallation,
+			StagesDone:          5,
+			StagesTotal:         8,
+			EstimatedCompletion: &estimatedCompletion,
+		}}
+
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, progressEstimator, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := resolver.RuntimeOperationStatus(operationID)
+
+		//then
+		require.NoError(t, err)
+		require.NotNil(t, status.Progress)
+		assert.Equal(t, &gqlschema.OperationProgress{
+			CurrentStage:        string(model.WaitingForInstallation),
+			StagesDone:          5,
+			StagesTotal:         8,
+			EstimatedCompletion: &estimatedCompletion,
+		}, status.Progress)
+		sessionFactoryMock.AssertExpectations(t)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when failed to get operation status", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("GetOperation", operationID).Return(model.Operation{}, dberrors.Internal("error"))
+
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
 		_, err := resolver.RuntimeOperationStatus(operationID)
@@ -448,9 +1049,184 @@ func TestService_RuntimeOperationStatus(t *testing.T) {
 	})
 }
 
+func TestService_AnnotateOperation(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+
+	operation := model.Operation{
+		ID:        operationID,
+		Type:      model.Provision,
+		State:     model.Failed,
+		ClusterID: runtimeID,
+	}
+
+	t.Run("Should set annotation and return operation status", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		writeSession := &sessionMocks.WriteSession{}
+
+		sessionFactoryMock.On("NewWriteSession").Return(writeSession)
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		writeSession.On("UpsertOperationAnnotation", mock.MatchedBy(func(annotation model.OperationAnnotation) bool {
+			return annotation.OperationID == operationID && annotation.Key == "ticket" && annotation.Value == "12345" && !annotation.UpdatedAt.IsZero()
+		})).Return(nil)
+		readSession.On("GetOperation", operationID).Return(operation, nil)
+		readSession.On("ListOperationAnnotations", operationID).Return([]model.OperationAnnotation{{OperationID: operationID, Key: "ticket", Value: "12345"}}, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := service.AnnotateOperation(operationID, "ticket", "12345")
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, status.Annotations, 1)
+		assert.Equal(t, "12345", status.Annotations[0].Value)
+		writeSession.AssertExpectations(t)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should remove annotation when value is empty", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		writeSession := &sessionMocks.WriteSession{}
+
+		sessionFactoryMock.On("NewWriteSession").Return(writeSession)
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		writeSession.On("DeleteOperationAnnotation", operationID, "ticket").Return(nil)
+		readSession.On("GetOperation", operationID).Return(operation, nil)
+		readSession.On("ListOperationAnnotations", operationID).Return(nil, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := service.AnnotateOperation(operationID, "ticket", "")
+
+		//then
+		require.NoError(t, err)
+		assert.Empty(t, status.Annotations)
+		writeSession.AssertExpectations(t)
+	})
+
+	t.Run("Should return not found error when operation does not exist", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSession := &sessionMocks.WriteSession{}
+
+		sessionFactoryMock.On("NewWriteSession").Return(writeSession)
+		writeSession.On("UpsertOperationAnnotation", mock.AnythingOfType("model.OperationAnnotation")).Return(dberrors.NotFound("Operation %s not found", operationID))
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.AnnotateOperation(operationID, "ticket", "12345")
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeNotFound, err.Code())
+	})
+}
+
+func TestService_OperationsHistory(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+
+	operations := []model.Operation{
+		{ID: "operation-3", Type: model.Upgrade, State: model.Failed, Message: "upgrade failed", ClusterID: runtimeID},
+		{ID: "operation-2", Type: model.UpgradeShoot, State: model.Succeeded, Message: "succeeded", ClusterID: runtimeID},
+	}
+
+	t.Run("Should return first page of operations history", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("OperationsCountByRuntimeID", runtimeID).Return(3, nil)
+		readSession.On("ListOperationsByRuntimeID", runtimeID, 2, 0).Return(operations, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		history, err := service.OperationsHistory(runtimeID, util.IntPtr(2), nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 3, history.TotalCount)
+		assert.True(t, history.HasNextPage)
+		require.NotNil(t, history.EndCursor)
+		require.Len(t, history.Operations, 2)
+		assert.Equal(t, "operation-3", history.Operations[0].ID)
+		assert.Equal(t, gqlschema.OperationStateFailed, history.Operations[0].State)
+		require.NotNil(t, history.Operations[0].ErrorSummary)
+		assert.Equal(t, "upgrade failed", *history.Operations[0].ErrorSummary)
+		assert.Nil(t, history.Operations[1].ErrorSummary)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should return next page when new operations were started", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		olderOperation := model.Operation{ID: "operation-1", Type: model.Provision, State: model.Succeeded, ClusterID: runtimeID}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("OperationsCountByRuntimeID", runtimeID).Return(5, nil)
+		readSession.On("ListOperationsByRuntimeID", runtimeID, 2, 4).Return([]model.Operation{olderOperation}, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+		cursor := encodeOperationsHistoryCursor(1)
+
+		//when
+		history, err := service.OperationsHistory(runtimeID, util.IntPtr(2), &cursor)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 5, history.TotalCount)
+		assert.False(t, history.HasNextPage)
+		require.Len(t, history.Operations, 1)
+		assert.Equal(t, "operation-1", history.Operations[0].ID)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when cursor is invalid", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("OperationsCountByRuntimeID", runtimeID).Return(3, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+		cursor := "invalid"
+
+		//when
+		_, err := service.OperationsHistory(runtimeID, nil, &cursor)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+		readSession.AssertNotCalled(t, "ListOperationsByRuntimeID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when page size is out of range", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.OperationsHistory(runtimeID, util.IntPtr(maxOperationsHistoryPageSize+1), nil)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+		sessionFactoryMock.AssertNotCalled(t, "NewReadSession")
+	})
+}
+
 func TestService_RuntimeStatus(t *testing.T) {
 	uuidGenerator := &uuidMocks.UUIDGenerator{}
-	inputConverter := NewInputConverter(uuidGenerator, nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+	inputConverter := NewInputConverter(uuidGenerator, nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 	graphQLConverter := NewGraphQLConverter()
 
 	operation := model.Operation{
@@ -481,8 +1257,11 @@ func TestService_RuntimeStatus(t *testing.T) {
 			HibernationPossible: true,
 			Hibernated:          true,
 		}, nil)
+		provisioner.On("GetShootStatus", mock.AnythingOfType("string"), cluster.ClusterConfig).Return(&model.ShootStatus{
+			State: model.ShootStateHibernated,
+		}, nil)
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
 		status, err := resolver.RuntimeStatus(operationID)
@@ -491,10 +1270,38 @@ func TestService_RuntimeStatus(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, cluster.ID, *status.LastOperationStatus.RuntimeID)
 		assert.Equal(t, cluster.Kubeconfig, status.RuntimeConfiguration.Kubeconfig)
+		require.NotNil(t, status.ShootStatus)
+		assert.Equal(t, gqlschema.ShootStateHibernated, status.ShootStatus.State)
 		sessionFactoryMock.AssertExpectations(t)
 		readSession.AssertExpectations(t)
 	})
 
+	t.Run("Should return runtime status without Shoot status when failed to read it from Gardener", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("GetLastOperation", operationID).Return(operation, nil)
+		readSession.On("GetCluster", operationID).Return(cluster, nil)
+
+		provisioner := &mocks2.Provisioner{}
+		provisioner.On("GetHibernationStatus", mock.AnythingOfType("string"), cluster.ClusterConfig).Return(model.HibernationStatus{}, nil)
+		provisioner.On("GetShootStatus", mock.AnythingOfType("string"), cluster.ClusterConfig).Return(nil, apperrors.Internal("some error"))
+
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := resolver.RuntimeStatus(operationID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, cluster.ID, *status.LastOperationStatus.RuntimeID)
+		assert.NotNil(t, status.HibernationStatus)
+		assert.Nil(t, status.ShootStatus)
+		provisioner.AssertExpectations(t)
+	})
+
 	t.Run("Should return error when failed to get cluster", func(t *testing.T) {
 		//given
 		sessionFactoryMock := &sessionMocks.Factory{}
@@ -504,7 +1311,7 @@ func TestService_RuntimeStatus(t *testing.T) {
 		readSession.On("GetLastOperation", operationID).Return(operation, nil)
 		readSession.On("GetCluster", operationID).Return(model.Cluster{}, dberrors.Internal("error"))
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
 		_, err := resolver.RuntimeStatus(operationID)
@@ -523,7 +1330,7 @@ func TestService_RuntimeStatus(t *testing.T) {
 		sessionFactoryMock.On("NewReadSession").Return(readSession)
 		readSession.On("GetLastOperation", operationID).Return(model.Operation{}, dberrors.Internal("error"))
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
 		_, err := resolver.RuntimeStatus(operationID)
@@ -545,7 +1352,7 @@ func TestService_RuntimeStatus(t *testing.T) {
 		readSession.On("GetCluster", operationID).Return(cluster, nil)
 		provisioner.On("GetHibernationStatus", mock.AnythingOfType("string"), cluster.ClusterConfig).Return(model.HibernationStatus{}, apperrors.Internal("some error"))
 
-		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil)
+		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
 		_, err := resolver.RuntimeStatus(operationID)
@@ -557,10 +1364,129 @@ func TestService_RuntimeStatus(t *testing.T) {
 	})
 }
 
-func TestService_UpgradeRuntime(t *testing.T) {
+func TestService_RuntimeStatuses(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+
+	const (
+		otherRuntimeID   = "other-runtime"
+		foreignRuntimeID = "foreign-runtime"
+		missingRuntimeID = "missing-runtime"
+	)
+
+	runtimeIDs := []string{otherRuntimeID, runtimeID, foreignRuntimeID, missingRuntimeID}
+
+	fixOperation := func(clusterID string) model.Operation {
+		return model.Operation{ID: "operation-" + clusterID, Type: model.Provision, State: model.Succeeded, ClusterID: clusterID}
+	}
+	lastOperations := map[string]model.Operation{
+		runtimeID:        fixOperation(runtimeID),
+		otherRuntimeID:   fixOperation(otherRuntimeID),
+		foreignRuntimeID: fixOperation(foreignRuntimeID),
+	}
+	clusters := []model.Cluster{
+		{ID: runtimeID, Tenant: tenant},
+		{ID: otherRuntimeID, Tenant: tenant},
+		{ID: foreignRuntimeID, Tenant: "other-tenant"},
+	}
+
+	newSessionFactory := func() *sessionMocks.Factory {
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetClustersByIDs", runtimeIDs).Return(clusters, nil)
+		readSession.On("GetLastOperationsByRuntimeIDs", runtimeIDs).Return(lastOperations, nil)
+
+		sessionFactoryMock := &sessionMocks.Factory{}
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		return sessionFactoryMock
+	}
+
+	t.Run("Should return statuses of tenant Runtimes in requested order", func(t *testing.T) {
+		//given
+		provisioner := &mocks2.Provisioner{}
+		provisioner.On("GetHibernationStatus", mock.AnythingOfType("string"), mock.Anything).Return(model.HibernationStatus{HibernationPossible: true}, nil)
+		provisioner.On("GetShootStatus", mock.AnythingOfType("string"), mock.Anything).Return(&model.ShootStatus{State: model.ShootStateHealthy}, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, newSessionFactory(), provisioner, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{MaxBatchSize: 10}, 0, nil, nil, false, nil, nil)
+
+		//when
+		statuses, err := service.RuntimeStatuses(tenant, runtimeIDs, false)
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		assert.Equal(t, otherRuntimeID, statuses[0].RuntimeID)
+		assert.Equal(t, runtimeID, statuses[1].RuntimeID)
+		assert.Equal(t, runtimeID, *statuses[1].Status.LastOperationStatus.RuntimeID)
+		assert.NotNil(t, statuses[1].Status.HibernationStatus)
+		assert.NotNil(t, statuses[1].Status.ShootStatus)
+		provisioner.AssertNumberOfCalls(t, "GetHibernationStatus", 2)
+		provisioner.AssertNumberOfCalls(t, "GetShootStatus", 2)
+	})
+
+	t.Run("Should skip reading hibernation status from Gardener", func(t *testing.T) {
+		//given
+		provisioner := &mocks2.Provisioner{}
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, newSessionFactory(), provisioner, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		statuses, err := service.RuntimeStatuses(tenant, runtimeIDs, true)
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		assert.Nil(t, statuses[0].Status.HibernationStatus)
+		assert.Nil(t, statuses[0].Status.ShootStatus)
+		provisioner.AssertNotCalled(t, "GetHibernationStatus", mock.Anything, mock.Anything)
+		provisioner.AssertNotCalled(t, "GetShootStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error for Runtimes of other tenants when strict tenancy is enabled", func(t *testing.T) {
+		//given
+		service := NewProvisioningService(nil, graphQLConverter, nil, newSessionFactory(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{StrictTenancy: true}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.RuntimeStatuses(tenant, runtimeIDs, true)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		assert.Contains(t, err.Error(), foreignRuntimeID+", "+missingRuntimeID)
+	})
+
+	t.Run("Should return error when too many Runtimes are requested", func(t *testing.T) {
+		//given
+		service := NewProvisioningService(nil, graphQLConverter, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{MaxBatchSize: 3}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.RuntimeStatuses(tenant, runtimeIDs, true)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("Should return error when failed to get clusters", func(t *testing.T) {
+		//given
+		readSession := &sessionMocks.ReadSession{}
+		readSession.On("GetClustersByIDs", runtimeIDs).Return(nil, dberrors.Internal("error"))
+		sessionFactoryMock := &sessionMocks.Factory{}
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.RuntimeStatuses(tenant, runtimeIDs, true)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+	})
+}
+
+func TestService_UpgradeRuntime(t *testing.T) {
 	releaseProvider := &releaseMocks.Provider{}
 	releaseProvider.On("GetReleaseByVersion", kymaVersion).Return(kymaRelease, nil)
-	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 	graphQLConverter := NewGraphQLConverter()
 	uuidGenerator := uuid.NewUUIDGenerator()
 
@@ -570,7 +1496,7 @@ func TestService_UpgradeRuntime(t *testing.T) {
 
 	cluster := model.Cluster{
 		ID: runtimeID,
-		KymaConfig: model.KymaConfig{
+		KymaConfig: &model.KymaConfig{
 			ID: oldKymaConfigId,
 		},
 	}
@@ -579,7 +1505,7 @@ func TestService_UpgradeRuntime(t *testing.T) {
 		ClusterID: runtimeID,
 		State:     model.InProgress,
 		Type:      model.Upgrade,
-		Stage:     model.StartingUpgrade,
+		Stage:     model.CheckingPreUpgradeHealth,
 	}
 
 	runtimeUpgradeMatcher := func(rUp model.RuntimeUpgrade) bool {
@@ -614,10 +1540,10 @@ func TestService_UpgradeRuntime(t *testing.T) {
 		writeSession.On("RollbackUnlessCommitted").Return()
 		upgradeQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, upgradeShootQueue, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, upgradeShootQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		operationStatus, err := service.UpgradeRuntime(runtimeID, upgradeInput)
+		operationStatus, err := service.UpgradeRuntime(runtimeID, upgradeInput, tenant, "", false)
 		require.NoError(t, err)
 
 		//then
@@ -630,6 +1556,106 @@ func TestService_UpgradeRuntime(t *testing.T) {
 		releaseProvider.AssertExpectations(t)
 	})
 
+	t.Run("Should start upgrade skipping health checks", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		writeSession := &sessionMocks.WriteSessionWithinTransaction{}
+		readSession := &sessionMocks.ReadSession{}
+		upgradeQueue := &mocks.OperationQueue{}
+
+		sessionFactory.On("NewReadSession").Return(readSession, nil)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		sessionFactory.On("NewSessionWithinTransaction").Return(writeSession, nil)
+		writeSession.On("InsertKymaConfig", mock.AnythingOfType("model.KymaConfig")).Return(nil)
+		writeSession.On("InsertRuntimeUpgrade", mock.MatchedBy(runtimeUpgradeMatcher)).Return(nil)
+		writeSession.On("SetActiveKymaConfig", runtimeID, mock.AnythingOfType("string")).Return(nil)
+		writeSession.On("InsertOperation", mock.MatchedBy(func(op model.Operation) bool {
+			return operationMatcher(op) && op.Force
+		})).Return(nil)
+		writeSession.On("Commit").Return(nil)
+		writeSession.On("RollbackUnlessCommitted").Return()
+		upgradeQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, nil, nil, upgradeQueue, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.UpgradeRuntime(runtimeID, upgradeInput, tenant, "", true)
+		require.NoError(t, err)
+
+		//then
+		writeSession.AssertExpectations(t)
+		upgradeQueue.AssertExpectations(t)
+	})
+
+	evaluationProfile := model.EvaluationProfile
+	productionProfile := model.ProductionProfile
+	gqlProductionProfile := gqlschema.KymaProfileProduction
+
+	for _, testCase := range []struct {
+		description      string
+		currentProfile   *model.KymaProfile
+		requestedProfile *gqlschema.KymaProfile
+		expectedProfile  *model.KymaProfile
+	}{
+		{
+			description:     "Should keep current Kyma profile if upgrade does not request it",
+			currentProfile:  &evaluationProfile,
+			expectedProfile: &evaluationProfile,
+		},
+		{
+			description:      "Should switch Kyma profile requested by upgrade",
+			currentProfile:   &evaluationProfile,
+			requestedProfile: &gqlProductionProfile,
+			expectedProfile:  &productionProfile,
+		},
+		{
+			description:      "Should set Kyma profile requested by upgrade if Runtime has default profile",
+			requestedProfile: &gqlProductionProfile,
+			expectedProfile:  &productionProfile,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			sessionFactory := &sessionMocks.Factory{}
+			writeSession := &sessionMocks.WriteSessionWithinTransaction{}
+			readSession := &sessionMocks.ReadSession{}
+			upgradeQueue := &mocks.OperationQueue{}
+
+			currentCluster := model.Cluster{
+				ID: runtimeID,
+				KymaConfig: &model.KymaConfig{
+					ID:      oldKymaConfigId,
+					Profile: testCase.currentProfile,
+				},
+			}
+
+			sessionFactory.On("NewReadSession").Return(readSession, nil)
+			readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+			readSession.On("GetCluster", runtimeID).Return(currentCluster, nil)
+			sessionFactory.On("NewSessionWithinTransaction").Return(writeSession, nil)
+			writeSession.On("InsertKymaConfig", mock.MatchedBy(func(kymaConfig model.KymaConfig) bool {
+				return assert.Equal(t, testCase.expectedProfile, kymaConfig.Profile)
+			})).Return(nil)
+			writeSession.On("InsertRuntimeUpgrade", mock.MatchedBy(runtimeUpgradeMatcher)).Return(nil)
+			writeSession.On("SetActiveKymaConfig", runtimeID, mock.AnythingOfType("string")).Return(nil)
+			writeSession.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+			writeSession.On("Commit").Return(nil)
+			writeSession.On("RollbackUnlessCommitted").Return()
+			upgradeQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, nil, nil, upgradeQueue, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+			//when
+			_, err := service.UpgradeRuntime(runtimeID, gqlschema.UpgradeRuntimeInput{KymaConfig: fixKymaGraphQLConfigInput(testCase.requestedProfile)}, tenant, "", false)
+
+			//then
+			require.NoError(t, err)
+			writeSession.AssertExpectations(t)
+			upgradeQueue.AssertExpectations(t)
+		})
+	}
+
 	for _, testCase := range []struct {
 		description string
 		mockFunc    func(sessionFactory *sessionMocks.Factory, writeSession *sessionMocks.WriteSessionWithinTransaction, readSession *sessionMocks.ReadSession)
@@ -681,10 +1707,10 @@ func TestService_UpgradeRuntime(t *testing.T) {
 
 			testCase.mockFunc(sessionFactory, writeSession, readSession)
 
-			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, upgradeShootQueue, nil)
+			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, upgradeShootQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 			//when
-			_, err := service.UpgradeRuntime(runtimeID, upgradeInput)
+			_, err := service.UpgradeRuntime(runtimeID, upgradeInput, tenant, "", false)
 			require.Error(t, err)
 
 			// then
@@ -694,10 +1720,57 @@ func TestService_UpgradeRuntime(t *testing.T) {
 			releaseProvider.AssertExpectations(t)
 		})
 	}
+
+	t.Run("Should return error when overrides are invalid", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactory.On("NewReadSession").Return(readSession, nil)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+
+		kymaConfigInput := fixKymaGraphQLConfigInput(nil)
+		kymaConfigInput.Configuration = append(kymaConfigInput.Configuration, fixGQLConfigEntryInput("global.certificates", "{not: valid", util.BoolPtr(false)))
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.UpgradeRuntime(runtimeID, gqlschema.UpgradeRuntimeInput{KymaConfig: kymaConfigInput}, tenant, "", false)
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+
+		//then
+		assert.Contains(t, err.Error(), "global override 'global.certificates': malformed YAML value")
+		assert.NotContains(t, err.Error(), "{not: valid")
+		sessionFactory.AssertExpectations(t)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when Kyma is managed externally", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactory.On("NewReadSession").Return(readSession, nil)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSession.On("GetCluster", runtimeID).Return(model.Cluster{ID: runtimeID}, nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.UpgradeRuntime(runtimeID, upgradeInput, tenant, "", false)
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+
+		//then
+		assert.Contains(t, err.Error(), "managed externally")
+		sessionFactory.AssertExpectations(t)
+		readSession.AssertExpectations(t)
+	})
 }
 
 func TestService_UpgradeGardenerShoot(t *testing.T) {
-	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 	graphQLConverter := NewGraphQLConverter()
 	uuidGenerator := uuid.NewUUIDGenerator()
 
@@ -708,6 +1781,7 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 		ID: runtimeID,
 		ClusterConfig: model.GardenerConfig{
 			ClusterID:              runtimeID,
+			KubernetesVersion:      "1.15.4",
 			Purpose:                util.StringPtr("evaluation"),
 			LicenceType:            util.StringPtr("license"),
 			GardenerProviderConfig: providerConfig,
@@ -749,10 +1823,10 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 		writeSession.On("Commit").Return(nil)
 		upgradeShootQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		operationStatus, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput)
+		operationStatus, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput, tenant, "")
 		require.NoError(t, err)
 
 		//then
@@ -764,6 +1838,147 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 		upgradeShootQueue.AssertExpectations(t)
 	})
 
+	t.Run("Should warn that worker nodes are recreated when Shielded VM options change", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		writeSession := &sessionMocks.WriteSessionWithinTransaction{}
+		upgradeShootQueue := &mocks.OperationQueue{}
+		provisioner := &mocks2.Provisioner{}
+
+		shieldedVMInput := newGCPUpgradeShootInput("testing")
+		shieldedVMInput.GardenerConfig.ProviderSpecificConfig.GcpConfig.EnableSecureBoot = util.BoolPtr(true)
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		sessionFactory.On("NewSessionWithinTransaction").Return(writeSession, nil)
+		writeSession.On("UpdateGardenerClusterConfig", mock.AnythingOfType("model.GardenerConfig")).Return(nil)
+		writeSession.On("RollbackUnlessCommitted").Return()
+		writeSession.On("InsertAdministrators", runtimeID, mock.Anything).Return(nil)
+		writeSession.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		provisioner.On("UpgradeCluster", runtimeID, mock.AnythingOfType("model.GardenerConfig")).Return(nil)
+		writeSession.On("Commit").Return(nil)
+		upgradeShootQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		operationStatus, err := service.UpgradeGardenerShoot(runtimeID, shieldedVMInput, tenant, "")
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, "Starting Gardener Shoot upgrade. Warning: Shielded VM options changed, worker nodes will be recreated", *operationStatus.Message)
+		writeSession.AssertExpectations(t)
+		provisioner.AssertExpectations(t)
+	})
+
+	t.Run("Should warn that pinned machine image version is overridden when its auto update is enabled", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		writeSession := &sessionMocks.WriteSessionWithinTransaction{}
+		upgradeShootQueue := &mocks.OperationQueue{}
+		provisioner := &mocks2.Provisioner{}
+
+		pinnedImageInput := newGCPUpgradeShootInput("testing")
+		pinnedImageInput.GardenerConfig.MachineImage = util.StringPtr("gardenlinux")
+		pinnedImageInput.GardenerConfig.MachineImageVersion = util.StringPtr("318.9.0")
+		pinnedImageInput.GardenerConfig.EnableMachineImageVersionAutoUpdate = util.BoolPtr(true)
+
+		pinnedImageMatcher := func(config model.GardenerConfig) bool {
+			return util.UnwrapStr(config.MachineImage) == "gardenlinux" && util.UnwrapStr(config.MachineImageVersion) == "318.9.0"
+		}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		sessionFactory.On("NewSessionWithinTransaction").Return(writeSession, nil)
+		writeSession.On("UpdateGardenerClusterConfig", mock.MatchedBy(pinnedImageMatcher)).Return(nil)
+		writeSession.On("RollbackUnlessCommitted").Return()
+		writeSession.On("InsertAdministrators", runtimeID, mock.Anything).Return(nil)
+		writeSession.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		provisioner.On("UpgradeCluster", runtimeID, mock.MatchedBy(pinnedImageMatcher)).Return(nil)
+		writeSession.On("Commit").Return(nil)
+		upgradeShootQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		operationStatus, err := service.UpgradeGardenerShoot(runtimeID, pinnedImageInput, tenant, "")
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, "Starting Gardener Shoot upgrade. Warning: machine image version auto update is enabled, the pinned version will be overridden by Gardener maintenance", *operationStatus.Message)
+		writeSession.AssertExpectations(t)
+		provisioner.AssertExpectations(t)
+	})
+
+	t.Run("Should resolve Kubernetes minor version to the latest supported patch version", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		writeSession := &sessionMocks.WriteSessionWithinTransaction{}
+		upgradeShootQueue := &mocks.OperationQueue{}
+		provisioner := &mocks2.Provisioner{}
+		kubernetesVersionResolver := &mocks2.KubernetesVersionResolver{}
+
+		input := newUpgradeShootInputAwsAzureGCP("testing")
+		input.GardenerConfig.KubernetesVersion = util.StringPtr("1.16")
+
+		resolvedConfig := upgradedConfig
+		resolvedConfig.KubernetesVersion = "1.16.15"
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		kubernetesVersionResolver.On("Resolve", "gcp", "1.16").Return("1.16.15", nil)
+		sessionFactory.On("NewSessionWithinTransaction").Return(writeSession, nil)
+		writeSession.On("UpdateGardenerClusterConfig", resolvedConfig).Return(nil)
+		writeSession.On("RollbackUnlessCommitted").Return()
+		writeSession.On("InsertAdministrators", runtimeID, mock.Anything).Return(nil)
+		writeSession.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		provisioner.On("UpgradeCluster", runtimeID, resolvedConfig).Return(nil)
+		writeSession.On("Commit").Return(nil)
+		upgradeShootQueue.On("Add", mock.AnythingOfType("string")).Return(nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue, nil, nil, nil, kubernetesVersionResolver, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.UpgradeGardenerShoot(runtimeID, input, tenant, "")
+		require.NoError(t, err)
+
+		//then
+		kubernetesVersionResolver.AssertExpectations(t)
+		provisioner.AssertExpectations(t)
+		writeSession.AssertExpectations(t)
+	})
+
+	t.Run("Should reject Kubernetes version downgrade", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		kubernetesVersionResolver := &mocks2.KubernetesVersionResolver{}
+
+		input := newUpgradeShootInputAwsAzureGCP("testing")
+		input.GardenerConfig.KubernetesVersion = util.StringPtr("1.14")
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, kubernetesVersionResolver, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.UpgradeGardenerShoot(runtimeID, input, tenant, "")
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+		kubernetesVersionResolver.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+		sessionFactory.AssertNotCalled(t, "NewSessionWithinTransaction")
+	})
+
 	for _, testCase := range []struct {
 		description string
 		mockFunc    func(sessionFactory *sessionMocks.Factory, readSession *sessionMocks.ReadSession, writeSession *sessionMocks.WriteSessionWithinTransaction, provisioner *mocks2.Provisioner)
@@ -846,10 +2061,10 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 
 			testCase.mockFunc(sessionFactory, readSession, writeSessionWithinTransaction, provisioner)
 
-			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue, nil)
+			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, nil, nil, nil, upgradeShootQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 			//when
-			_, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput)
+			_, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput, tenant, "")
 			require.Error(t, err)
 
 			// then
@@ -860,17 +2075,223 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 	}
 }
 
-func TestService_RollBackLastUpgrade(t *testing.T) {
-	releaseProvider := &releaseMocks.Provider{}
-	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+func TestService_UpgradeGardenerShootDryRun(t *testing.T) {
+	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), nil, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 	graphQLConverter := NewGraphQLConverter()
-	uuidGenerator := uuid.NewUUIDGenerator()
-
-	lastOperation := model.Operation{ID: operationID, State: model.Succeeded, Type: model.Upgrade}
-
-	oldKymaConfigId := "old-kyma-config-id"
 
-	runtimeUpgrade := model.RuntimeUpgrade{
+	providerConfig, _ := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"europe-west1-a"}})
+	cluster := model.Cluster{
+		ID: runtimeID,
+		ClusterConfig: model.GardenerConfig{
+			ClusterID:              runtimeID,
+			KubernetesVersion:      "1.15.4",
+			Purpose:                util.StringPtr("evaluation"),
+			LicenceType:            util.StringPtr("license"),
+			GardenerProviderConfig: providerConfig,
+			OIDCConfig:             oidcConfig(),
+		},
+	}
+
+	upgradeShootInput := newUpgradeShootInputAwsAzureGCP("testing")
+	upgradedConfig, err := inputConverter.UpgradeShootInputToGardenerConfig(*upgradeShootInput.GardenerConfig, cluster.ClusterConfig)
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		description     string
+		changes         []model.ShootSpecChange
+		expectedChanges []*gqlschema.ShootSpecChange
+	}{
+		{
+			description: "should return Shoot spec changes without starting operation",
+			changes: []model.ShootSpecChange{
+				{Path: "spec.kubernetes.version", OldValue: `"1.18.0"`, NewValue: `"1.19.0"`},
+			},
+			expectedChanges: []*gqlschema.ShootSpecChange{
+				{Path: "spec.kubernetes.version", OldValue: `"1.18.0"`, NewValue: `"1.19.0"`},
+			},
+		},
+		{
+			description:     "should return empty diff for no-op upgrade",
+			changes:         []model.ShootSpecChange{},
+			expectedChanges: []*gqlschema.ShootSpecChange{},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			sessionFactory := &sessionMocks.Factory{}
+			readSession := &sessionMocks.ReadSession{}
+			upgradeShootQueue := &mocks.OperationQueue{}
+			provisioner := &mocks2.Provisioner{}
+
+			sessionFactory.On("NewReadSession").Return(readSession)
+			readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+			provisioner.On("UpgradeClusterDryRun", runtimeID, upgradedConfig).Return(testCase.changes, nil)
+
+			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, upgradeShootQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+			//when
+			operationStatus, err := service.UpgradeGardenerShootDryRun(runtimeID, upgradeShootInput)
+			require.NoError(t, err)
+
+			//then
+			assert.Nil(t, operationStatus.ID)
+			assert.Equal(t, gqlschema.OperationStatePending, operationStatus.State)
+			assert.Equal(t, testCase.expectedChanges, operationStatus.ShootSpecDiff)
+			sessionFactory.AssertNotCalled(t, "NewSessionWithinTransaction")
+			upgradeShootQueue.AssertNotCalled(t, "Add", mock.Anything)
+			provisioner.AssertExpectations(t)
+		})
+	}
+
+	t.Run("should return error when failed to compute changes", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		provisioner := &mocks2.Provisioner{}
+
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetCluster", runtimeID).Return(cluster, nil)
+		provisioner.On("UpgradeClusterDryRun", runtimeID, upgradedConfig).Return(nil, apperrors.Internal("error"))
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.UpgradeGardenerShootDryRun(runtimeID, upgradeShootInput)
+
+		//then
+		require.Error(t, err)
+	})
+}
+
+func TestService_ProvisionRuntimeDryRun(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+
+	provisionRuntimeInput := gqlschema.ProvisionRuntimeInput{
+		RuntimeInput: &gqlschema.RuntimeInput{Name: runtimeName},
+		ClusterConfig: &gqlschema.ClusterConfigInput{
+			GardenerConfig: &gqlschema.GardenerConfigInput{
+				KubernetesVersion: "1.16",
+				ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+					GcpConfig: &gqlschema.GCPProviderConfigInput{},
+				},
+			},
+		},
+		KymaConfig: fixKymaGraphQLConfigInput(nil),
+	}
+
+	resolvedVersionMatcher := func(cluster model.Cluster) bool {
+		return cluster.ClusterConfig.KubernetesVersion == "1.16.15"
+	}
+
+	for _, testCase := range []struct {
+		description      string
+		shootDryRun      model.ShootDryRun
+		expectedValid    bool
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			description:      "should return valid report with resolved versions",
+			shootDryRun:      model.ShootDryRun{Spec: `{"region":"europe-west1"}`, PreflightChecked: true},
+			expectedValid:    true,
+			expectedErrors:   []string{},
+			expectedWarnings: []string{},
+		},
+		{
+			description:      "should warn when pre-flight checks are disabled",
+			shootDryRun:      model.ShootDryRun{Spec: `{"region":"europe-west1"}`},
+			expectedValid:    true,
+			expectedErrors:   []string{},
+			expectedWarnings: []string{"Pre-flight checks are disabled, Gardener credentials and quotas were not checked"},
+		},
+		{
+			description:      "should report failed pre-flight check",
+			shootDryRun:      model.ShootDryRun{Spec: `{"region":"europe-west1"}`, PreflightChecked: true, PreflightError: apperrors.BadRequest("secret binding not found")},
+			expectedValid:    false,
+			expectedErrors:   []string{"pre-flight check failed: secret binding not found"},
+			expectedWarnings: []string{},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//given
+			releaseProvider := &releaseMocks.Provider{}
+			releaseProvider.On("LookupReleaseByVersion", kymaVersion).Return(kymaRelease, nil)
+			inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
+
+			sessionFactory := &sessionMocks.Factory{}
+			directorService := &directormock.DirectorClient{}
+			provisioningQueue := &mocks.OperationQueue{}
+			provisioner := &mocks2.Provisioner{}
+			kubernetesVersionResolver := &mocks2.KubernetesVersionResolver{}
+
+			kubernetesVersionResolver.On("Resolve", mock.Anything, "1.16").Return("1.16.15", nil)
+			provisioner.On("ProvisionClusterDryRun", mock.MatchedBy(resolvedVersionMatcher)).Return(testCase.shootDryRun, nil)
+
+			service := NewProvisioningService(inputConverter, graphQLConverter, directorService, sessionFactory, provisioner, uuid.NewUUIDGenerator(), provisioningQueue, nil, nil, nil, nil, nil, nil, kubernetesVersionResolver, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+			//when
+			operationStatus, err := service.ProvisionRuntimeDryRun(provisionRuntimeInput, tenant, subAccountId)
+			require.NoError(t, err)
+
+			//then
+			assert.Nil(t, operationStatus.ID)
+			assert.Nil(t, operationStatus.RuntimeID)
+			assert.Equal(t, gqlschema.OperationTypeProvision, operationStatus.Operation)
+			require.NotNil(t, operationStatus.DryRunReport)
+
+			report := operationStatus.DryRunReport
+			assert.Equal(t, testCase.expectedValid, report.Valid)
+			assert.Equal(t, testCase.expectedErrors, report.Errors)
+			assert.Equal(t, testCase.expectedWarnings, report.Warnings)
+			assert.Equal(t, util.StringPtr(kymaVersion), report.KymaVersion)
+			assert.Equal(t, util.StringPtr("1.16.15"), report.KubernetesVersion)
+			assert.Equal(t, util.StringPtr(testCase.shootDryRun.Spec), report.ShootSpec)
+			require.NotNil(t, report.KymaConfig)
+			assert.Equal(t, util.StringPtr(kymaVersion), report.KymaConfig.Version)
+
+			releaseProvider.AssertNotCalled(t, "GetReleaseByVersion", mock.Anything)
+			directorService.AssertNotCalled(t, "CreateRuntime", mock.Anything, mock.Anything)
+			sessionFactory.AssertNotCalled(t, "NewSessionWithinTransaction")
+			provisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+			provisioner.AssertExpectations(t)
+		})
+	}
+
+	t.Run("should report Kyma release which cannot be found", func(t *testing.T) {
+		//given
+		releaseProvider := &releaseMocks.Provider{}
+		releaseProvider.On("LookupReleaseByVersion", kymaVersion).Return(model.Release{}, dberrors.NotFound("release not found"))
+		inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
+
+		provisioner := &mocks2.Provisioner{}
+
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, nil, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		operationStatus, err := service.ProvisionRuntimeDryRun(provisionRuntimeInput, tenant, subAccountId)
+		require.NoError(t, err)
+
+		//then
+		require.NotNil(t, operationStatus.DryRunReport)
+		assert.False(t, operationStatus.DryRunReport.Valid)
+		require.Len(t, operationStatus.DryRunReport.Errors, 1)
+		assert.Contains(t, operationStatus.DryRunReport.Errors[0], "failed to get Kyma Release")
+		assert.Nil(t, operationStatus.DryRunReport.ShootSpec)
+		provisioner.AssertNotCalled(t, "ProvisionClusterDryRun", mock.Anything)
+	})
+}
+
+func TestService_RollBackLastUpgrade(t *testing.T) {
+	releaseProvider := &releaseMocks.Provider{}
+	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
+	graphQLConverter := NewGraphQLConverter()
+	uuidGenerator := uuid.NewUUIDGenerator()
+
+	lastOperation := model.Operation{ID: operationID, State: model.Succeeded, Type: model.Upgrade}
+
+	oldKymaConfigId := "old-kyma-config-id"
+
+	runtimeUpgrade := model.RuntimeUpgrade{
 		State:                   model.UpgradeSucceeded,
 		OperationId:             operationID,
 		PreUpgradeKymaConfigId:  oldKymaConfigId,
@@ -879,7 +2300,7 @@ func TestService_RollBackLastUpgrade(t *testing.T) {
 
 	cluster := model.Cluster{
 		ID: runtimeID,
-		KymaConfig: model.KymaConfig{
+		KymaConfig: &model.KymaConfig{
 			ID: oldKymaConfigId,
 		},
 	}
@@ -907,8 +2328,9 @@ func TestService_RollBackLastUpgrade(t *testing.T) {
 			HibernationPossible: true,
 			Hibernated:          true,
 		}, nil)
+		provisioner.On("GetShootStatus", mock.Anything, mock.Anything).Return(&model.ShootStatus{State: model.ShootStateHibernated}, nil)
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil)
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
 		runtimeStatus, err := service.RollBackLastUpgrade(runtimeID)
@@ -954,7 +2376,7 @@ func TestService_RollBackLastUpgrade(t *testing.T) {
 
 			testCase.mockFunc(sessionFactoryMock, writeSessionWithinTransactionMock, readSessionMock)
 
-			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil)
+			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 			//when
 			_, err := service.RollBackLastUpgrade(runtimeID)
@@ -970,7 +2392,7 @@ func TestService_RollBackLastUpgrade(t *testing.T) {
 
 func TestService_HibernateShoot(t *testing.T) {
 	releaseProvider := &releaseMocks.Provider{}
-	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
+	inputConverter := NewInputConverter(uuid.NewUUIDGenerator(), releaseProvider, gardenerProject, defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers, defaultNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
 	uuidGenerator := uuid.NewUUIDGenerator()
 	graphQLConverter := NewGraphQLConverter()
 
@@ -1073,10 +2495,10 @@ func TestService_HibernateShoot(t *testing.T) {
 
 			testCase.mockFunc(sessionFactoryMock, writeSessionWithinTransactionMock, readSessionMock, provisioner)
 
-			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil)
+			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 			//when
-			_, err := service.HibernateCluster(runtimeID)
+			_, err := service.HibernateCluster(runtimeID, nil)
 			require.Error(t, err)
 
 			//then
@@ -1105,10 +2527,10 @@ func TestService_HibernateShoot(t *testing.T) {
 		writeSessionWithinTransactionMock.On("Commit").Return(nil)
 		hibernationQueue.On("Add", mock.AnythingOfType("string")).Return()
 
-		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisionerMock, uuidGenerator, nil, nil, nil, nil, hibernationQueue)
+		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisionerMock, uuidGenerator, nil, nil, nil, nil, hibernationQueue, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
 
 		//when
-		runtimeStatus, err := service.HibernateCluster(runtimeID)
+		runtimeStatus, err := service.HibernateCluster(runtimeID, nil)
 		require.NoError(t, err)
 
 		//then
@@ -1120,6 +2542,466 @@ func TestService_HibernateShoot(t *testing.T) {
 	})
 }
 
+func TestService_ScheduledHibernation(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+	uuidGenerator := uuid.NewUUIDGenerator()
+
+	lastOperation := model.Operation{ID: operationID, State: model.Succeeded, Type: model.Upgrade}
+
+	cluster := model.Cluster{
+		ID:         runtimeID,
+		Hibernated: true,
+	}
+
+	t.Run("Should schedule hibernation of cluster without updating the Shoot", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		readSessionMock := &sessionMocks.ReadSession{}
+		provisionerMock := &mocks2.Provisioner{}
+		hibernationQueue := &mocks.OperationQueue{}
+
+		notBefore := time.Now().Add(time.Hour)
+
+		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		readSessionMock.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSessionMock.On("GetCluster", runtimeID).Return(cluster, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(func(operation model.Operation) bool {
+			return operation.Type == model.Hibernate && operation.Stage == model.StartingHibernation &&
+				operation.State == model.InProgress && operation.StartTimestamp.Equal(notBefore)
+		})).Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		hibernationQueue.On("AddAfter", mock.AnythingOfType("string"), mock.MatchedBy(func(delay time.Duration) bool {
+			return delay > 59*time.Minute && delay <= time.Hour
+		})).Return()
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, provisionerMock, uuidGenerator, nil, nil, nil, nil, hibernationQueue, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := service.HibernateCluster(runtimeID, &notBefore)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, gqlschema.OperationTypeHibernate, status.Operation)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		hibernationQueue.AssertExpectations(t)
+		provisionerMock.AssertNotCalled(t, "HibernateCluster", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should wake up cluster right away when scheduled time passed", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		readSessionMock := &sessionMocks.ReadSession{}
+		provisionerMock := &mocks2.Provisioner{}
+		hibernationQueue := &mocks.OperationQueue{}
+
+		notBefore := time.Now().Add(-time.Hour)
+
+		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		readSessionMock.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+		readSessionMock.On("GetCluster", runtimeID).Return(cluster, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(getOperationMatcher(model.Operation{
+			Type:      model.WakeUp,
+			ClusterID: runtimeID,
+			State:     model.InProgress,
+			Stage:     model.WaitForWakeUp,
+		}))).Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return(nil)
+		provisionerMock.On("WakeUpCluster", cluster.ID, cluster.ClusterConfig).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		hibernationQueue.On("Add", mock.AnythingOfType("string")).Return()
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, provisionerMock, uuidGenerator, nil, nil, nil, nil, hibernationQueue, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := service.WakeUpCluster(runtimeID, &notBefore)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, gqlschema.OperationTypeWakeUp, status.Operation)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		provisionerMock.AssertExpectations(t)
+		hibernationQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should not wake up cluster when operation is in progress", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSessionMock := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		readSessionMock.On("GetLastOperation", runtimeID).Return(model.Operation{ID: operationID, State: model.InProgress, Type: model.Hibernate}, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, &mocks2.Provisioner{}, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.WakeUpCluster(runtimeID, nil)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+	})
+}
+
+func TestService_CleanupFailedProvisioning(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+	uuidGenerator := uuid.NewUUIDGenerator()
+
+	t.Run("Should start cleanup of failed provisioning", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readWriteSessionMock := &sessionMocks.ReadWriteSession{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSessionMock)
+		readWriteSessionMock.On("GetLastOperation", runtimeID).Return(model.Operation{ID: operationID, State: model.Failed, Type: model.Provision}, nil)
+		readWriteSessionMock.On("InsertOperation", mock.MatchedBy(getOperationMatcher(model.Operation{
+			Type:      model.CleanupFailedProvisioning,
+			ClusterID: runtimeID,
+			State:     model.InProgress,
+			Stage:     model.DeleteCluster,
+		}))).Return(nil)
+		deprovisioningQueue.On("Add", mock.AnythingOfType("string")).Return()
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, deprovisioningQueue, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := service.CleanupFailedProvisioning(runtimeID)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, gqlschema.OperationTypeCleanupFailedProvisioning, status.Operation)
+		readWriteSessionMock.AssertExpectations(t)
+		deprovisioningQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should not start cleanup when operation is in progress", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readWriteSessionMock := &sessionMocks.ReadWriteSession{}
+
+		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSessionMock)
+		readWriteSessionMock.On("GetLastOperation", runtimeID).Return(model.Operation{ID: operationID, State: model.InProgress, Type: model.Provision}, nil)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.CleanupFailedProvisioning(runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+		assert.Equal(t, apperrors.OperationInProgress, err.Cause())
+		readWriteSessionMock.AssertNotCalled(t, "InsertOperation", mock.Anything)
+	})
+
+	t.Run("Should not start cleanup when operation was started concurrently", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readWriteSessionMock := &sessionMocks.ReadWriteSession{}
+		deprovisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactoryMock.On("NewReadWriteSession").Return(readWriteSessionMock)
+		readWriteSessionMock.On("GetLastOperation", runtimeID).Return(model.Operation{ID: operationID, State: model.Failed, Type: model.Provision}, nil)
+		readWriteSessionMock.On("InsertOperation", mock.AnythingOfType("model.Operation")).Return(dberrors.OperationInProgress("error"))
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, uuidGenerator, nil, deprovisioningQueue, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.CleanupFailedProvisioning(runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+		assert.Equal(t, apperrors.OperationInProgress, err.Cause())
+		deprovisioningQueue.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
+
+func TestService_SetQueueState(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+
+	t.Run("Should persist queue state and pause the queue", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionMock := &sessionMocks.WriteSession{}
+		provisioningQueue := &mocks.OperationQueue{}
+
+		sessionFactoryMock.On("NewWriteSession").Return(writeSessionMock)
+		writeSessionMock.On("UpdateQueueState", model.Provision, true).Return(nil)
+		provisioningQueue.On("SetPaused", true).Return()
+		provisioningQueue.On("IsPaused").Return(true)
+		provisioningQueue.On("Len").Return(3)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, provisioningQueue, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		status, err := service.SetQueueState(gqlschema.QueueTypeProvision, true)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, &gqlschema.QueueStatus{Queue: gqlschema.QueueTypeProvision, Paused: true, Depth: 3}, status)
+		writeSessionMock.AssertExpectations(t)
+		provisioningQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should not pause the queue when failed to persist queue state", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionMock := &sessionMocks.WriteSession{}
+		hibernationQueue := &mocks.OperationQueue{}
+
+		sessionFactoryMock.On("NewWriteSession").Return(writeSessionMock)
+		writeSessionMock.On("UpdateQueueState", model.Hibernate, true).Return(dberrors.Internal("error"))
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, hibernationQueue, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.SetQueueState(gqlschema.QueueTypeHibernate, true)
+
+		//then
+		require.Error(t, err)
+		hibernationQueue.AssertNotCalled(t, "SetPaused", mock.Anything)
+	})
+
+	t.Run("Should return error for unknown queue", func(t *testing.T) {
+		//given
+		service := NewProvisioningService(nil, graphQLConverter, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.SetQueueState("Unknown", true)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+	})
+}
+
+func TestService_QueuesStatus(t *testing.T) {
+	//given
+	queue := func(paused bool, depth int) *mocks.OperationQueue {
+		operationQueue := &mocks.OperationQueue{}
+		operationQueue.On("IsPaused").Return(paused)
+		operationQueue.On("Len").Return(depth)
+		return operationQueue
+	}
+
+	service := NewProvisioningService(nil, NewGraphQLConverter(), nil, nil, nil, nil,
+		queue(true, 1), queue(false, 2), queue(false, 0), queue(true, 0), queue(false, 5), nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+	//when
+	statuses, err := service.QueuesStatus()
+	require.NoError(t, err)
+
+	//then
+	assert.Equal(t, []*gqlschema.QueueStatus{
+		{Queue: gqlschema.QueueTypeProvision, Paused: true, Depth: 1},
+		{Queue: gqlschema.QueueTypeDeprovision, Paused: false, Depth: 2},
+		{Queue: gqlschema.QueueTypeUpgrade, Paused: false, Depth: 0},
+		{Queue: gqlschema.QueueTypeUpgradeShoot, Paused: true, Depth: 0},
+		{Queue: gqlschema.QueueTypeHibernate, Paused: false, Depth: 5},
+	}, statuses)
+}
+
+func TestService_RetryFailedOperations(t *testing.T) {
+	graphQLConverter := NewGraphQLConverter()
+	failedAfter := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	errorReason := "gardener"
+
+	failedProvisioning := model.Operation{ID: "provisioning", Type: model.Provision, State: model.Failed, Version: 3}
+	failedUpgrade := model.Operation{ID: "upgrade", Type: model.UpgradeShoot, State: model.Failed, Version: 5}
+	failedReconnect := model.Operation{ID: "reconnect", Type: model.ReconnectRuntime, State: model.Failed, Version: 1}
+
+	t.Run("Should return operations which would be retried without changing them", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSessionMock := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSessionMock)
+		provisionType := model.Provision
+		readSessionMock.On("ListFailedLastOperations", model.FailedOperationsFilter{Type: &provisionType, FailedAfter: &failedAfter, ErrorReason: &errorReason}).
+			Return([]model.Operation{failedProvisioning}, nil)
+
+		provisioningQueue := &mocks.OperationQueue{}
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, provisioningQueue, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		operationType := gqlschema.OperationTypeProvision
+		retried, err := service.RetryFailedOperations(gqlschema.FailedOperationsFilter{Type: &operationType, FailedAfter: &failedAfter, ErrorReason: &errorReason}, true)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, &gqlschema.RetriedOperations{Count: 1, OperationIDs: []string{"provisioning"}, DryRun: true}, retried)
+		sessionFactoryMock.AssertNotCalled(t, "NewSessionWithinTransaction")
+		provisioningQueue.AssertNotCalled(t, "AddAfter", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should retry and enqueue failed operations skipping the ones modified concurrently", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSessionMock := &sessionMocks.ReadSession{}
+		writeSessionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		provisioningQueue := &mocks.OperationQueue{}
+		shootUpgradeQueue := &mocks.OperationQueue{}
+
+		concurrentlyModified := model.Operation{ID: "concurrent", Type: model.UpgradeShoot, State: model.Failed, Version: 2}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSessionMock)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionMock, nil)
+		readSessionMock.On("ListFailedLastOperations", model.FailedOperationsFilter{}).
+			Return([]model.Operation{failedProvisioning, failedUpgrade, concurrentlyModified, failedReconnect}, nil)
+		writeSessionMock.On("RetryOperation", "provisioning", 3, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+		writeSessionMock.On("RetryOperation", "upgrade", 5, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+		writeSessionMock.On("RetryOperation", "concurrent", 2, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(dberrors.Conflict("modified"))
+		writeSessionMock.On("Commit").Return(nil)
+		writeSessionMock.On("RollbackUnlessCommitted").Return()
+		provisioningQueue.On("AddAfter", "provisioning", mock.AnythingOfType("time.Duration")).Return()
+		shootUpgradeQueue.On("AddAfter", "upgrade", mock.AnythingOfType("time.Duration")).Return()
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, provisioningQueue, nil, nil, shootUpgradeQueue, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		retried, err := service.RetryFailedOperations(gqlschema.FailedOperationsFilter{}, false)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, &gqlschema.RetriedOperations{Count: 2, OperationIDs: []string{"provisioning", "upgrade"}, DryRun: false}, retried)
+		writeSessionMock.AssertExpectations(t)
+		provisioningQueue.AssertExpectations(t)
+		shootUpgradeQueue.AssertExpectations(t)
+		writeSessionMock.AssertNotCalled(t, "RetryOperation", "reconnect", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should not retry operations exceeding the limit of the global account", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSessionMock := &sessionMocks.ReadSession{}
+
+		secondProvisioning := model.Operation{ID: "second", Type: model.Provision, State: model.Failed, Version: 1}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSessionMock)
+		readSessionMock.On("ListFailedLastOperations", model.FailedOperationsFilter{}).
+			Return([]model.Operation{failedProvisioning, secondProvisioning, failedUpgrade}, nil)
+		readSessionMock.On("GetTenantForOperation", mock.AnythingOfType("string")).Return(tenant, nil)
+		readSessionMock.On("InProgressOperationsCountForTenant", tenant, model.Provision).Return(1, nil).Once()
+
+		throttle := NewProvisioningThrottle(ProvisioningLimits{Default: 2}, sessionFactoryMock)
+
+		service := NewProvisioningService(nil, graphQLConverter, nil, sessionFactoryMock, nil, nil, &mocks.OperationQueue{}, nil, nil, &mocks.OperationQueue{}, nil, throttle, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		retried, err := service.RetryFailedOperations(gqlschema.FailedOperationsFilter{}, true)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, []string{"provisioning", "upgrade"}, retried.OperationIDs)
+		readSessionMock.AssertExpectations(t)
+	})
+
+	t.Run("Should return error for unknown operation type", func(t *testing.T) {
+		//given
+		service := NewProvisioningService(nil, graphQLConverter, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		operationType := gqlschema.OperationType("Unknown")
+		_, err := service.RetryFailedOperations(gqlschema.FailedOperationsFilter{Type: &operationType}, true)
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeBadRequest, err.Code())
+	})
+}
+
+func TestService_AuditEntries(t *testing.T) {
+	tenant := "tenant"
+	entries := []model.AuditEntry{
+		{ID: "entry-1", Tenant: &tenant, Mutation: "provisionRuntime", Input: "{}", OperationID: util.StringPtr("operation-1")},
+	}
+
+	t.Run("Should return audit entries matching filter", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("ListAuditEntries", model.AuditEntriesFilter{Tenant: &tenant}, 10, 20).Return(entries, nil)
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		auditEntries, err := service.AuditEntries(&gqlschema.AuditEntriesFilter{Tenant: &tenant}, util.IntPtr(10), util.IntPtr(20))
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, auditEntries, 1)
+		assert.Equal(t, "entry-1", auditEntries[0].ID)
+		assert.Equal(t, "provisionRuntime", auditEntries[0].Mutation)
+		assert.Equal(t, util.StringPtr("operation-1"), auditEntries[0].OperationID)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should use default page size when no arguments provided", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("ListAuditEntries", model.AuditEntriesFilter{}, defaultAuditEntriesPageSize, 0).Return(entries, nil)
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		auditEntries, err := service.AuditEntries(nil, nil, nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Len(t, auditEntries, 1)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when arguments are out of range", func(t *testing.T) {
+		//given
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.AuditEntries(nil, util.IntPtr(maxAuditEntriesPageSize+1), nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+
+		//when
+		_, err = service.AuditEntries(nil, nil, util.IntPtr(-1))
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeBadRequest)
+	})
+
+	t.Run("Should return error when failed to list audit entries", func(t *testing.T) {
+		//given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+
+		sessionFactoryMock.On("NewReadSession").Return(readSession)
+		readSession.On("ListAuditEntries", model.AuditEntriesFilter{}, defaultAuditEntriesPageSize, 0).Return(nil, dberrors.Internal("error"))
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, sessionFactoryMock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.AuditEntries(nil, nil, nil)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+	})
+}
+
 func getOperationMatcher(expected model.Operation) func(model.Operation) bool {
 	return func(op model.Operation) bool {
 		return op.Type == expected.Type && op.ClusterID == expected.ClusterID &&
@@ -1136,3 +3018,243 @@ func getClusterMatcher(expected model.Cluster) func(model.Cluster) bool {
 func notEmptyUUIDMatcher(id string) bool {
 	return len(id) > 0
 }
+
+type progressEstimatorStub struct {
+	progress *model.OperationProgress
+}
+
+func (s progressEstimatorStub) Estimate(_ model.Operation) *model.OperationProgress {
+	return s.progress
+}
+
+func TestService_OrphanedShoots(t *testing.T) {
+	t.Run("Should return orphaned Shoots detected by the last check", func(t *testing.T) {
+		//given
+		createdAt := time.Now()
+
+		detector := &mocks2.OrphanedShootsDetector{}
+		detector.On("OrphanedShoots").Return([]model.OrphanedShoot{
+			{Name: "shoot", CreationTimestamp: createdAt, Labels: map[string]string{"account": "global-account"}},
+		})
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, detector, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		shoots, err := service.OrphanedShoots()
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []*gqlschema.OrphanedShoot{
+			{Name: "shoot", CreationTimestamp: createdAt, Labels: &gqlschema.Labels{"account": "global-account"}},
+		}, shoots)
+	})
+
+	t.Run("Should return error when detection is not enabled", func(t *testing.T) {
+		//given
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.OrphanedShoots()
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+	})
+}
+
+func TestService_RuntimeByShootName(t *testing.T) {
+	shootName := "shoot"
+	cluster := model.Cluster{
+		ID:            runtimeID,
+		Tenant:        tenant,
+		ClusterConfig: model.GardenerConfig{Name: shootName, Provider: "gcp"},
+	}
+	lastOperation := model.Operation{
+		ID:             operationID,
+		Type:           model.Provision,
+		State:          model.Succeeded,
+		Stage:          model.FinishedStage,
+		StartTimestamp: time.Now(),
+	}
+
+	t.Run("Should return Runtime of the Shoot with tenant of the caller", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetGardenerClusterByName", shootName).Return(cluster, nil)
+		readSession.On("GetLastOperation", runtimeID).Return(lastOperation, nil)
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, sessionFactory, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		shootRuntime, err := service.RuntimeByShootName(shootName, tenant)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, runtimeID, shootRuntime.RuntimeID)
+		assert.Equal(t, util.StringPtr(tenant), shootRuntime.Tenant)
+		assert.Equal(t, "gcp", shootRuntime.Provider)
+		require.NotNil(t, shootRuntime.LastOperation)
+		assert.Equal(t, operationID, shootRuntime.LastOperation.ID)
+		assert.Equal(t, gqlschema.OperationStateSucceeded, shootRuntime.LastOperation.State)
+		readSession.AssertExpectations(t)
+	})
+
+	t.Run("Should not return tenant when Runtime belongs to another tenant", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetGardenerClusterByName", shootName).Return(cluster, nil)
+		readSession.On("GetLastOperation", runtimeID).Return(model.Operation{}, dberrors.NotFound("error"))
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, sessionFactory, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		shootRuntime, err := service.RuntimeByShootName(shootName, "other-tenant")
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, runtimeID, shootRuntime.RuntimeID)
+		assert.Nil(t, shootRuntime.Tenant)
+		assert.Nil(t, shootRuntime.LastOperation)
+	})
+
+	t.Run("Should return not found error when Shoot is not managed by the Provisioner", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetGardenerClusterByName", shootName).Return(model.Cluster{}, dberrors.NotFound("error"))
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, sessionFactory, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.RuntimeByShootName(shootName, tenant)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeNotFound)
+	})
+
+	t.Run("Should return internal error when failed to read Runtime", func(t *testing.T) {
+		//given
+		sessionFactory := &sessionMocks.Factory{}
+		readSession := &sessionMocks.ReadSession{}
+		sessionFactory.On("NewReadSession").Return(readSession)
+		readSession.On("GetGardenerClusterByName", shootName).Return(model.Cluster{}, dberrors.Internal("error"))
+
+		service := NewProvisioningService(nil, NewGraphQLConverter(), nil, sessionFactory, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, nil, nil, false, nil, nil)
+
+		//when
+		_, err := service.RuntimeByShootName(shootName, tenant)
+
+		//then
+		require.Error(t, err)
+		util.CheckErrorType(t, err, apperrors.CodeInternal)
+	})
+}
+
+func TestService_ProviderDefaults(t *testing.T) {
+	latestRelease := &model.Release{Id: "releaseID", Version: "1.24.10", InstallerYAML: "installer"}
+
+	newService := func(enableAutoUpdate bool, machineImageDefaults MachineImageDefaults, regionPolicy RegionPolicy) Service {
+		uuidGenerator := &uuidMocks.UUIDGenerator{}
+		uuidGenerator.On("New").Return("id")
+		releaseProvider := &releaseMocks.Provider{}
+		releaseProvider.On("GetLatestRelease").Return(latestRelease, nil)
+
+		inputConverter := NewInputConverter(uuidGenerator, releaseProvider, "gardener-project", enableAutoUpdate, enableAutoUpdate, false, model.CiliumNetworkingType, model.GCPShieldedInstanceConfig{}, model.AWSInstanceMetadataOptions{}, model.SystemWorkerPool{}, nil)
+
+		return NewProvisioningService(inputConverter, NewGraphQLConverter(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, nil, RuntimeStatusesConfig{}, 0, machineImageDefaults, regionPolicy, false, nil, nil)
+	}
+
+	t.Run("should return defaults of the provider", func(t *testing.T) {
+		//given
+		machineImageDefaults := &mocks2.MachineImageDefaults{}
+		machineImageDefaults.On("DefaultMachineImage", "gcp").Return("gardenlinux", "576.12.0", nil)
+
+		policy, err := regionpolicy.ParsePolicy([]byte(`{"providers": {"gcp": {"allowedRegions": ["europe-*"], "deniedZones": ["europe-west4-c"]}}}`))
+		require.NoError(t, err)
+
+		service := newService(true, machineImageDefaults, policy)
+
+		//when
+		defaults, appErr := service.ProviderDefaults(gqlschema.ProviderGcp)
+
+		//then
+		require.NoError(t, appErr)
+		assert.Equal(t, gqlschema.ProviderGcp, defaults.Provider)
+		assert.Equal(t, util.StringPtr("gcp"), defaults.CloudProfileName)
+		assert.True(t, defaults.EnableKubernetesVersionAutoUpdate)
+		assert.True(t, defaults.EnableMachineImageVersionAutoUpdate)
+		assert.False(t, defaults.AllowPrivilegedContainers)
+		assert.Equal(t, gqlschema.NetworkingTypeCilium, *defaults.NetworkingType)
+		assert.Equal(t, util.StringPtr("gardenlinux"), defaults.MachineImage)
+		assert.Equal(t, util.StringPtr("576.12.0"), defaults.MachineImageVersion)
+		assert.Nil(t, defaults.Purpose)
+		assert.Nil(t, defaults.VolumeSizeGb)
+		assert.Equal(t, &gqlschema.ProviderRegions{
+			ProviderAllowed: true,
+			AllowedRegions:  []string{"europe-*"},
+			DeniedRegions:   []string{},
+			DeniedZones:     []string{"europe-west4-c"},
+		}, defaults.Regions)
+		assert.Equal(t, util.StringPtr("1.24.10"), defaults.KymaVersion)
+		assert.Nil(t, defaults.KymaProfile)
+	})
+
+	t.Run("should follow the configuration and the region policy", func(t *testing.T) {
+		//given
+		machineImageDefaults := &mocks2.MachineImageDefaults{}
+		machineImageDefaults.On("DefaultMachineImage", "aws").Return("gardenlinux", "318.8.0", nil)
+
+		policy, err := regionpolicy.ParsePolicy([]byte(`{"providers": {"gcp": {}}}`))
+		require.NoError(t, err)
+
+		service := newService(false, machineImageDefaults, policy)
+
+		//when
+		defaults, appErr := service.ProviderDefaults(gqlschema.ProviderAws)
+
+		//then
+		require.NoError(t, appErr)
+		assert.False(t, defaults.EnableKubernetesVersionAutoUpdate)
+		assert.False(t, defaults.EnableMachineImageVersionAutoUpdate)
+		assert.Equal(t, util.StringPtr("318.8.0"), defaults.MachineImageVersion)
+		assert.False(t, defaults.Regions.ProviderAllowed)
+	})
+
+	t.Run("should not resolve machine image without cloud profile", func(t *testing.T) {
+		//given
+		machineImageDefaults := &mocks2.MachineImageDefaults{}
+
+		service := newService(false, machineImageDefaults, nil)
+
+		//when
+		defaults, appErr := service.ProviderDefaults(gqlschema.ProviderOpenStack)
+
+		//then
+		require.NoError(t, appErr)
+		assert.Nil(t, defaults.CloudProfileName)
+		assert.Nil(t, defaults.MachineImage)
+		assert.True(t, defaults.Regions.ProviderAllowed)
+		machineImageDefaults.AssertNotCalled(t, "DefaultMachineImage", mock.Anything)
+	})
+
+	t.Run("should return error when failed to get default machine image", func(t *testing.T) {
+		//given
+		machineImageDefaults := &mocks2.MachineImageDefaults{}
+		machineImageDefaults.On("DefaultMachineImage", "az").Return("", "", apperrors.Internal("error"))
+
+		service := newService(false, machineImageDefaults, nil)
+
+		//when
+		_, appErr := service.ProviderDefaults(gqlschema.ProviderAzure)
+
+		//then
+		require.Error(t, appErr)
+		util.CheckErrorType(t, appErr, apperrors.CodeInternal)
+	})
+}
@@ -0,0 +1,28 @@
+package readonly
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+)
+
+// setReadOnlyModeMutation is not rejected, so that the mode can be disabled
+const setReadOnlyModeMutation = "setReadOnlyMode"
+
+// Guard returns middleware rejecting all mutations while the read-only mode is enabled, queries are not affected
+// and the operations already in the queues continue
+func (m *Mode) Guard() graphql.FieldMiddleware {
+	return func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+		resolverContext := graphql.GetResolverContext(ctx)
+		if resolverContext == nil || resolverContext.Object != "Mutation" || resolverContext.Field.Name == setReadOnlyModeMutation {
+			return next(ctx)
+		}
+
+		if status := m.Status(); status.Enabled {
+			return nil, apperrors.ErrReadOnlyMode("%s", status.Message)
+		}
+
+		return next(ctx)
+	}
+}
@@ -0,0 +1,157 @@
+package readonly
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var enabledGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "kcp",
+	Subsystem: "provisioner",
+	Name:      "read_only_mode",
+	Help:      "Set to 1 if the Provisioner is in the read-only maintenance mode and rejects the mutations",
+})
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{enabledGauge}
+}
+
+// Status is the read-only mode in effect, Message is returned in the error of the rejected mutations
+type Status struct {
+	Enabled bool
+	Message string
+}
+
+// Mode keeps the read-only maintenance mode stored in the database. The mode is read again periodically, so that all replicas
+// follow the change made through any of them, and the configured defaults apply until the mode is set for the first time.
+type Mode struct {
+	sessionFactory dbsession.Factory
+	defaults       Status
+
+	mutex  sync.RWMutex
+	status Status
+	err    error
+
+	now func() time.Time
+	log logrus.FieldLogger
+}
+
+func NewMode(sessionFactory dbsession.Factory, enabled bool, message string, log logrus.FieldLogger) *Mode {
+	defaults := Status{Enabled: enabled, Message: message}
+	setGauge(defaults)
+
+	return &Mode{
+		sessionFactory: sessionFactory,
+		defaults:       defaults,
+		status:         defaults,
+		now:            time.Now,
+		log:            log,
+	}
+}
+
+// Refresh reads the mode from the database, the previous mode is kept if it cannot be read
+func (m *Mode) Refresh() error {
+	stored, dberr := m.sessionFactory.NewReadSession().GetReadOnlyMode()
+	if dberr != nil {
+		m.mutex.Lock()
+		m.err = dberr
+		m.mutex.Unlock()
+		return errors.Wrap(dberr, "failed to read read-only mode")
+	}
+
+	status := m.defaults
+	if stored.Enabled != nil {
+		status.Enabled = *stored.Enabled
+	}
+	if stored.Message != nil && *stored.Message != "" {
+		status.Message = *stored.Message
+	}
+
+	m.update(status)
+	return nil
+}
+
+// Set stores the mode in the database and applies it right away, the configured message is used if the message is empty
+func (m *Mode) Set(enabled bool, message *string) (Status, error) {
+	if message != nil && *message == "" {
+		message = nil
+	}
+
+	dberr := m.sessionFactory.NewWriteSession().UpdateReadOnlyMode(enabled, message, m.now())
+	if dberr != nil {
+		return Status{}, errors.Wrap(dberr, "failed to update read-only mode")
+	}
+
+	status := Status{Enabled: enabled, Message: m.defaults.Message}
+	if message != nil {
+		status.Message = *message
+	}
+
+	m.update(status)
+	return status, nil
+}
+
+func (m *Mode) update(status Status) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if status.Enabled != m.status.Enabled {
+		if status.Enabled {
+			m.log.Warnf("Read-only mode enabled, mutations are rejected: %s", status.Message)
+		} else {
+			m.log.Infof("Read-only mode disabled, mutations are accepted")
+		}
+	}
+
+	m.status, m.err = status, nil
+	setGauge(status)
+}
+
+// Status returns the mode in effect
+func (m *Mode) Status() Status {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.status
+}
+
+// Run refreshes the mode with the interval until stopped
+func (m *Mode) Run(interval time.Duration, stop <-chan struct{}) {
+	wait.Until(func() {
+		if err := m.Refresh(); err != nil {
+			m.log.Warnf("Failed to refresh read-only mode, keeping the previous one: %s", err.Error())
+		}
+	}, interval, stop)
+}
+
+// HealthDetails reports the mode in the verbose output of the health check
+func (m *Mode) HealthDetails() map[string]interface{} {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	details := map[string]interface{}{
+		"enabled": m.status.Enabled,
+	}
+	if m.status.Enabled {
+		details["message"] = m.status.Message
+	}
+	if m.err != nil {
+		details["error"] = m.err.Error()
+	}
+
+	return map[string]interface{}{"readOnlyMode": details}
+}
+
+func setGauge(status Status) {
+	if status.Enabled {
+		enabledGauge.Set(1)
+	} else {
+		enabledGauge.Set(0)
+	}
+}
@@ -0,0 +1,168 @@
+package readonly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession/mocks"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+)
+
+const defaultMessage = "Provisioner is under maintenance"
+
+func TestMode_Guard(t *testing.T) {
+	resolverContext := func(object, field string) context.Context {
+		return graphql.WithResolverContext(context.Background(), &graphql.ResolverContext{
+			Object: object,
+			Field:  graphql.CollectedField{Field: &ast.Field{Name: field}},
+		})
+	}
+	resolved := func(ctx context.Context) (interface{}, error) {
+		return "resolved", nil
+	}
+
+	t.Run("should block and unblock mutations without restart", func(t *testing.T) {
+		// given
+		writeSession := &mocks.WriteSession{}
+		writeSession.On("UpdateReadOnlyMode", true, util.StringPtr("Database migration"), mock.AnythingOfType("time.Time")).Return(nil)
+		writeSession.On("UpdateReadOnlyMode", false, (*string)(nil), mock.AnythingOfType("time.Time")).Return(nil)
+		sessionFactory := &mocks.Factory{}
+		sessionFactory.On("NewWriteSession").Return(writeSession)
+
+		mode := NewMode(sessionFactory, false, defaultMessage, logrus.New())
+		guard := mode.Guard()
+
+		// when
+		_, err := mode.Set(true, util.StringPtr("Database migration"))
+		require.NoError(t, err)
+		result, err := guard(resolverContext("Mutation", "provisionRuntime"), resolved)
+
+		// then
+		require.Error(t, err)
+		appErr, ok := err.(apperrors.AppError)
+		require.True(t, ok)
+		assert.Equal(t, apperrors.CodeServiceUnavailable, appErr.Code())
+		assert.Equal(t, apperrors.ReadOnlyMode, appErr.Cause())
+		assert.Equal(t, "Database migration", appErr.Error())
+		assert.Nil(t, result)
+		assert.Equal(t, float64(1), testutil.ToFloat64(enabledGauge))
+
+		// when
+		result, err = guard(resolverContext("Query", "runtimeStatus"), resolved)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "resolved", result)
+
+		// when
+		result, err = guard(resolverContext("Mutation", setReadOnlyModeMutation), resolved)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "resolved", result)
+
+		// when
+		_, err = mode.Set(false, nil)
+		require.NoError(t, err)
+		result, err = guard(resolverContext("Mutation", "provisionRuntime"), resolved)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "resolved", result)
+		assert.Zero(t, testutil.ToFloat64(enabledGauge))
+		writeSession.AssertExpectations(t)
+	})
+}
+
+func TestMode_Refresh(t *testing.T) {
+	t.Run("should apply configured mode when mode is not stored", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetReadOnlyMode").Return(model.ReadOnlyMode{}, nil)
+		sessionFactory := &mocks.Factory{}
+		sessionFactory.On("NewReadSession").Return(readSession)
+
+		mode := NewMode(sessionFactory, true, defaultMessage, logrus.New())
+
+		// when
+		err := mode.Refresh()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Status{Enabled: true, Message: defaultMessage}, mode.Status())
+	})
+
+	t.Run("should follow mode set by another replica", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetReadOnlyMode").Return(model.ReadOnlyMode{Enabled: util.BoolPtr(true)}, nil).Once()
+		readSession.On("GetReadOnlyMode").Return(model.ReadOnlyMode{Enabled: util.BoolPtr(false)}, nil).Once()
+		sessionFactory := &mocks.Factory{}
+		sessionFactory.On("NewReadSession").Return(readSession)
+
+		mode := NewMode(sessionFactory, false, defaultMessage, logrus.New())
+
+		// when
+		err := mode.Refresh()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Status{Enabled: true, Message: defaultMessage}, mode.Status())
+		assert.Equal(t, map[string]interface{}{
+			"readOnlyMode": map[string]interface{}{"enabled": true, "message": defaultMessage},
+		}, mode.HealthDetails())
+
+		// when
+		err = mode.Refresh()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, mode.Status().Enabled)
+		assert.Zero(t, testutil.ToFloat64(enabledGauge))
+	})
+
+	t.Run("should keep previous mode when it cannot be read", func(t *testing.T) {
+		// given
+		readSession := &mocks.ReadSession{}
+		readSession.On("GetReadOnlyMode").Return(model.ReadOnlyMode{}, dberrors.Internal("connection refused"))
+		sessionFactory := &mocks.Factory{}
+		sessionFactory.On("NewReadSession").Return(readSession)
+
+		mode := NewMode(sessionFactory, true, defaultMessage, logrus.New())
+
+		// when
+		err := mode.Refresh()
+
+		// then
+		require.Error(t, err)
+		assert.True(t, mode.Status().Enabled)
+		assert.Contains(t, mode.HealthDetails()["readOnlyMode"], "error")
+	})
+
+	t.Run("should keep previous mode when it cannot be stored", func(t *testing.T) {
+		// given
+		writeSession := &mocks.WriteSession{}
+		writeSession.On("UpdateReadOnlyMode", true, (*string)(nil), mock.AnythingOfType("time.Time")).Return(dberrors.Internal("connection refused"))
+		sessionFactory := &mocks.Factory{}
+		sessionFactory.On("NewWriteSession").Return(writeSession)
+
+		mode := NewMode(sessionFactory, false, defaultMessage, logrus.New())
+
+		// when
+		_, err := mode.Set(true, util.StringPtr(""))
+
+		// then
+		require.Error(t, err)
+		assert.False(t, mode.Status().Enabled)
+	})
+}
@@ -0,0 +1,78 @@
+package recovery
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/api/middlewares"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	prometheusNamespace = "kcp"
+	prometheusSubsystem = "provisioner"
+)
+
+var (
+	graphQLPanics = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Subsystem: prometheusSubsystem,
+		Name:      "graphql_panics_total",
+		Help:      "The number of panics recovered while resolving GraphQL requests",
+	})
+	workerPanics = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Subsystem: prometheusSubsystem,
+		Name:      "operation_worker_panics_total",
+		Help:      "The number of panics recovered while processing operations",
+	})
+)
+
+// Collectors returns counters of recovered panics to be registered in Prometheus
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{graphQLPanics, workerPanics}
+}
+
+// NewGraphQLRecoverFunc returns function recovering panics in resolvers, the caller gets only generic internal error
+func NewGraphQLRecoverFunc(logger logrus.FieldLogger) graphql.RecoverFunc {
+	return func(ctx context.Context, err interface{}) error {
+		graphQLPanics.Inc()
+
+		logger.WithFields(logrus.Fields{
+			"tenant":        contextValue(ctx, middlewares.Tenant),
+			"correlationID": contextValue(ctx, middlewares.CorrelationID),
+			"query":         queryName(ctx),
+		}).Errorf("Panic while resolving GraphQL request: %v\n%s", err, debug.Stack())
+
+		return apperrors.Internal("internal server error")
+	}
+}
+
+// WorkerPanic logs panic recovered by the operation queue worker
+func WorkerPanic(logger logrus.FieldLogger, operationID interface{}, err interface{}) {
+	workerPanics.Inc()
+
+	logger.WithField("operationID", operationID).Errorf("Panic while processing operation: %v\n%s", err, debug.Stack())
+}
+
+func contextValue(ctx context.Context, header middlewares.Header) string {
+	value, _ := ctx.Value(header).(string)
+	return value
+}
+
+func queryName(ctx context.Context) string {
+	resolverContext := graphql.GetResolverContext(ctx)
+	if resolverContext == nil {
+		return ""
+	}
+
+	// The top level field is the query or mutation being executed
+	for resolverContext.Parent != nil {
+		resolverContext = resolverContext.Parent
+	}
+
+	return resolverContext.Field.Name
+}
@@ -0,0 +1,66 @@
+package recovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/api/middlewares"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestGraphQLRecoverFunc(t *testing.T) {
+	// given
+	logger, hook := test.NewNullLogger()
+	recoverFunc := NewGraphQLRecoverFunc(logger)
+
+	ctx := context.WithValue(context.Background(), middlewares.Tenant, "tenant")
+	ctx = context.WithValue(ctx, middlewares.CorrelationID, "correlation-id")
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{Field: graphql.CollectedField{Field: &ast.Field{Name: "provisionRuntime"}}})
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{Field: graphql.CollectedField{Field: &ast.Field{Name: "id"}}})
+
+	panicsBefore := testutil.ToFloat64(graphQLPanics)
+
+	// when
+	err := recoverFunc(ctx, "nil pointer dereference")
+
+	// then
+	require.Error(t, err)
+	appErr, ok := err.(apperrors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeInternal, appErr.Code())
+	assert.NotContains(t, err.Error(), "nil pointer dereference")
+
+	assert.Equal(t, panicsBefore+1, testutil.ToFloat64(graphQLPanics))
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	assert.Equal(t, "tenant", entry.Data["tenant"])
+	assert.Equal(t, "correlation-id", entry.Data["correlationID"])
+	assert.Equal(t, "provisionRuntime", entry.Data["query"])
+	assert.Contains(t, entry.Message, "nil pointer dereference")
+}
+
+func TestWorkerPanic(t *testing.T) {
+	// given
+	logger, hook := test.NewNullLogger()
+	panicsBefore := testutil.ToFloat64(workerPanics)
+
+	// when
+	WorkerPanic(logger, "operation-1", "unexpected error")
+
+	// then
+	assert.Equal(t, panicsBefore+1, testutil.ToFloat64(workerPanics))
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, "operation-1", entry.Data["operationID"])
+	assert.Contains(t, entry.Message, "unexpected error")
+}
@@ -0,0 +1,130 @@
+package regionpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var denyRulesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "kcp",
+	Subsystem: "provisioner",
+	Name:      "region_policy_deny_rules",
+	Help:      "Number of rules of the region policy denying regions and zones, 0 means that the regions are not restricted",
+})
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{denyRulesGauge}
+}
+
+// Loader keeps the region policy read from the file up to date. The file is usually mounted from the ConfigMap,
+// so it is read again periodically and the previous policy is kept if the new one is invalid.
+type Loader struct {
+	configPath string
+
+	mutex   sync.RWMutex
+	policy  Policy
+	content []byte
+
+	log logrus.FieldLogger
+}
+
+// NewLoader reads the policy from the file failing if it is invalid, the empty path means that everything is allowed
+func NewLoader(configPath string, log logrus.FieldLogger) (*Loader, error) {
+	loader := &Loader{
+		configPath: configPath,
+		log:        log,
+	}
+
+	if configPath != "" {
+		content, err := ioutil.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read region policy: %s", err.Error())
+		}
+		policy, err := ParsePolicy(content)
+		if err != nil {
+			return nil, err
+		}
+		loader.policy, loader.content = policy, content
+	}
+
+	loader.report()
+
+	return loader, nil
+}
+
+// Run reloads the policy from the file with the interval until stopped
+func (l *Loader) Run(interval time.Duration, stop <-chan struct{}) {
+	if l.configPath == "" {
+		return
+	}
+
+	wait.Until(l.Reload, interval, stop)
+}
+
+// Reload reads the policy from the file if it has changed, the invalid policy is logged and ignored
+func (l *Loader) Reload() {
+	content, err := ioutil.ReadFile(l.configPath)
+	if err != nil {
+		l.log.Errorf("Failed to read region policy, keeping the previous one: %s", err.Error())
+		return
+	}
+
+	l.mutex.RLock()
+	unchanged := bytes.Equal(content, l.content)
+	l.mutex.RUnlock()
+	if unchanged {
+		return
+	}
+
+	policy, err := ParsePolicy(content)
+	if err != nil {
+		l.log.Errorf("Region policy changed but it is invalid, keeping the previous one: %s", err.Error())
+		return
+	}
+
+	l.mutex.Lock()
+	l.policy, l.content = policy, content
+	l.mutex.Unlock()
+
+	l.report()
+}
+
+// Policy returns the current policy
+func (l *Loader) Policy() Policy {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.policy
+}
+
+func (l *Loader) ValidateRegion(provider, region string) apperrors.AppError {
+	return l.Policy().ValidateRegion(provider, region)
+}
+
+func (l *Loader) ValidateZones(provider string, zones []string) apperrors.AppError {
+	return l.Policy().ValidateZones(provider, zones)
+}
+
+func (l *Loader) ProviderRules(provider string) (ProviderPolicy, bool) {
+	return l.Policy().ProviderRules(provider)
+}
+
+func (l *Loader) report() {
+	policy := l.Policy()
+
+	denyRulesGauge.Set(float64(policy.DenyRules()))
+
+	if policy.DenyRules() == 0 && len(policy.Providers) == 0 {
+		l.log.Warnf("Region policy does not restrict providers, regions nor zones")
+		return
+	}
+	l.log.Infof("Region policy with %d deny rules: %s", policy.DenyRules(), policy.String())
+}
@@ -0,0 +1,70 @@
+package regionpolicy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader(t *testing.T) {
+	t.Run("should allow everything when config path is empty", func(t *testing.T) {
+		// when
+		loader, err := NewLoader("", logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, loader.ValidateRegion("gcp", "europe-west3"))
+		assert.Zero(t, testutil.ToFloat64(denyRulesGauge))
+	})
+
+	t.Run("should fail when policy is invalid at startup", func(t *testing.T) {
+		// given
+		configPath := writePolicy(t, t.TempDir(), `{"providers": {"gcp": {"deniedRegions": ["[europe"]}}}`)
+
+		// when
+		_, err := NewLoader(configPath, logrus.New())
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should reload changed policy and keep previous one when it is invalid", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath := writePolicy(t, dir, testPolicy)
+
+		loader, err := NewLoader(configPath, logrus.New())
+		require.NoError(t, err)
+		require.Equal(t, float64(2), testutil.ToFloat64(denyRulesGauge))
+		require.NotNil(t, loader.ValidateRegion("gcp", "europe-west3"))
+
+		// when
+		writePolicy(t, dir, `{"providers": {"gcp": {"deniedRegions": ["asia-*"]}}}`)
+		loader.Reload()
+
+		// then
+		assert.Nil(t, loader.ValidateRegion("gcp", "europe-west3"))
+		assert.NotNil(t, loader.ValidateRegion("gcp", "asia-east1"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(denyRulesGauge))
+
+		// when
+		writePolicy(t, dir, `{"providers": {"gcp": {"deniedRegions": ["[asia"]}}}`)
+		loader.Reload()
+
+		// then
+		assert.NotNil(t, loader.ValidateRegion("gcp", "asia-east1"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(denyRulesGauge))
+	})
+}
+
+func writePolicy(t *testing.T, dir, policy string) string {
+	configPath := filepath.Join(dir, "policy.json")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(policy), 0644))
+
+	return configPath
+}
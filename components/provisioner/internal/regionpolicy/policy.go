@@ -0,0 +1,170 @@
+package regionpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+)
+
+// Policy restricts the providers, regions and zones in which Runtimes can be created, e.g. because of the export control.
+// The patterns use the shell file name pattern syntax, e.g. europe-*.
+type Policy struct {
+	// Providers lists the allowed providers by their lowercase names, all providers are allowed if the list is empty
+	Providers map[string]ProviderPolicy `json:"providers"`
+}
+
+type ProviderPolicy struct {
+	// AllowedRegions lists the patterns of the allowed regions, all regions are allowed if the list is empty
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+	// DeniedRegions lists the patterns of the denied regions, it takes precedence over the allowed regions
+	DeniedRegions []string `json:"deniedRegions,omitempty"`
+	// DeniedZones lists the patterns of the zones in which the workers cannot be placed
+	DeniedZones []string `json:"deniedZones,omitempty"`
+}
+
+// ParsePolicy decodes the policy from JSON and checks if all patterns are valid, unknown fields are rejected to detect typos
+func ParsePolicy(data []byte) (Policy, error) {
+	policy := Policy{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return policy, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return Policy{}, fmt.Errorf("failed to decode region policy: %s", err.Error())
+	}
+
+	if err := policy.validate(); err != nil {
+		return Policy{}, err
+	}
+
+	return policy, nil
+}
+
+func (p Policy) validate() error {
+	for provider, providerPolicy := range p.Providers {
+		if provider == "" || provider != strings.ToLower(provider) {
+			return fmt.Errorf("invalid region policy: provider name %q has to be non-empty and lowercase", provider)
+		}
+
+		patterns := append(append(append([]string{}, providerPolicy.AllowedRegions...), providerPolicy.DeniedRegions...), providerPolicy.DeniedZones...)
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+				return fmt.Errorf("invalid region policy: pattern %q of %s provider is malformed", pattern, provider)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DenyRules returns the number of rules denying regions and zones, the policy without them does not restrict the regions
+func (p Policy) DenyRules() int {
+	rules := 0
+	for _, providerPolicy := range p.Providers {
+		rules += len(providerPolicy.DeniedRegions) + len(providerPolicy.DeniedZones)
+	}
+
+	return rules
+}
+
+// ValidateRegion checks if the Runtime of the provider can be created in the region
+func (p Policy) ValidateRegion(provider, region string) apperrors.AppError {
+	providerPolicy, err := p.providerPolicy(provider)
+	if err != nil {
+		return err
+	}
+
+	if pattern, denied := matchAny(providerPolicy.DeniedRegions, region); denied {
+		return apperrors.ErrRegionNotAllowed("error: region %s of %s provider is denied by the region policy pattern %s", region, provider, pattern)
+	}
+
+	if len(providerPolicy.AllowedRegions) == 0 {
+		return nil
+	}
+	if _, allowed := matchAny(providerPolicy.AllowedRegions, region); !allowed {
+		return apperrors.ErrRegionNotAllowed("error: region %s of %s provider is not allowed by the region policy, allowed regions: %s",
+			region, provider, strings.Join(providerPolicy.AllowedRegions, ", "))
+	}
+
+	return nil
+}
+
+// ValidateZones checks if the workers of the provider can be placed in the zones
+func (p Policy) ValidateZones(provider string, zones []string) apperrors.AppError {
+	providerPolicy, err := p.providerPolicy(provider)
+	if err != nil {
+		return err
+	}
+
+	for _, zone := range zones {
+		if pattern, denied := matchAny(providerPolicy.DeniedZones, zone); denied {
+			return apperrors.ErrRegionNotAllowed("error: zone %s of %s provider is denied by the region policy pattern %s", zone, provider, pattern)
+		}
+	}
+
+	return nil
+}
+
+// ProviderRules returns the rules restricting the regions and zones of the provider and false if the provider is not allowed
+func (p Policy) ProviderRules(provider string) (ProviderPolicy, bool) {
+	providerPolicy, err := p.providerPolicy(provider)
+
+	return providerPolicy, err == nil
+}
+
+func (p Policy) providerPolicy(provider string) (ProviderPolicy, apperrors.AppError) {
+	if len(p.Providers) == 0 {
+		return ProviderPolicy{}, nil
+	}
+
+	providerPolicy, found := p.Providers[strings.ToLower(provider)]
+	if !found {
+		return ProviderPolicy{}, apperrors.ErrRegionNotAllowed("error: %s provider is not allowed by the region policy, allowed providers: %s",
+			provider, strings.Join(p.providerNames(), ", "))
+	}
+
+	return providerPolicy, nil
+}
+
+func (p Policy) providerNames() []string {
+	names := make([]string, 0, len(p.Providers))
+	for name := range p.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// String describes the effective policy for the logs
+func (p Policy) String() string {
+	if len(p.Providers) == 0 {
+		return "all providers, regions and zones are allowed"
+	}
+
+	descriptions := make([]string, 0, len(p.Providers))
+	for _, name := range p.providerNames() {
+		providerPolicy := p.Providers[name]
+		descriptions = append(descriptions, fmt.Sprintf("%s: {allowedRegions: [%s], deniedRegions: [%s], deniedZones: [%s]}", name,
+			strings.Join(providerPolicy.AllowedRegions, ", "), strings.Join(providerPolicy.DeniedRegions, ", "), strings.Join(providerPolicy.DeniedZones, ", ")))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+func matchAny(patterns []string, value string) (string, bool) {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,128 @@
+package regionpolicy
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `{
+	"providers": {
+		"gcp": {"allowedRegions": ["europe-*", "us-*"], "deniedRegions": ["europe-west3"], "deniedZones": ["europe-west4-c"]},
+		"azure": {}
+	}
+}`
+
+func TestParsePolicy(t *testing.T) {
+	t.Run("should parse policy and count deny rules", func(t *testing.T) {
+		// when
+		policy, err := ParsePolicy([]byte(testPolicy))
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, policy.Providers, 2)
+		assert.Equal(t, 2, policy.DenyRules())
+	})
+
+	t.Run("should allow everything when policy is empty", func(t *testing.T) {
+		// when
+		policy, err := ParsePolicy([]byte(" \n"))
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, policy.DenyRules())
+		assert.Nil(t, policy.ValidateRegion("aws", "eu-central-1"))
+	})
+
+	for _, testCase := range []struct {
+		description string
+		policy      string
+	}{
+		{description: "malformed JSON", policy: `{"providers": `},
+		{description: "unknown field", policy: `{"providers": {"gcp": {"deniedRegion": ["europe-west3"]}}}`},
+		{description: "malformed pattern", policy: `{"providers": {"gcp": {"deniedRegions": ["europe-[west3"]}}}`},
+		{description: "empty pattern", policy: `{"providers": {"gcp": {"deniedZones": [""]}}}`},
+		{description: "uppercase provider", policy: `{"providers": {"GCP": {}}}`},
+	} {
+		t.Run("should reject policy with "+testCase.description, func(t *testing.T) {
+			// when
+			_, err := ParsePolicy([]byte(testCase.policy))
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		description string
+		provider    string
+		region      string
+		zones       []string
+		allowed     bool
+	}{
+		{description: "allowed region", provider: "gcp", region: "europe-west4", zones: []string{"europe-west4-a"}, allowed: true},
+		{description: "provider name in different case", provider: "GCP", region: "us-east1", allowed: true},
+		{description: "provider without restrictions", provider: "azure", region: "westeurope", zones: []string{"1", "2"}, allowed: true},
+		{description: "not allowed provider", provider: "aws", region: "eu-central-1"},
+		{description: "denied region", provider: "gcp", region: "europe-west3"},
+		{description: "region not matching allowed patterns", provider: "gcp", region: "asia-east1"},
+		{description: "denied zone", provider: "gcp", region: "europe-west4", zones: []string{"europe-west4-a", "europe-west4-c"}},
+	} {
+		t.Run("should validate "+testCase.description, func(t *testing.T) {
+			// when
+			err := policy.ValidateRegion(testCase.provider, testCase.region)
+			if err == nil {
+				err = policy.ValidateZones(testCase.provider, testCase.zones)
+			}
+
+			// then
+			if testCase.allowed {
+				assert.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			assert.Equal(t, apperrors.RegionNotAllowed, err.Cause())
+			assert.Equal(t, apperrors.CodeForbidden, err.Code())
+		})
+	}
+}
+
+func TestPolicy_ProviderRules(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	t.Run("should return rules of allowed provider", func(t *testing.T) {
+		// when
+		rules, allowed := policy.ProviderRules("GCP")
+
+		// then
+		assert.True(t, allowed)
+		assert.Equal(t, []string{"europe-*", "us-*"}, rules.AllowedRegions)
+		assert.Equal(t, []string{"europe-west3"}, rules.DeniedRegions)
+		assert.Equal(t, []string{"europe-west4-c"}, rules.DeniedZones)
+	})
+
+	t.Run("should not allow provider missing in the policy", func(t *testing.T) {
+		// when
+		_, allowed := policy.ProviderRules("aws")
+
+		// then
+		assert.False(t, allowed)
+	})
+
+	t.Run("should allow all providers without rules when policy is empty", func(t *testing.T) {
+		// when
+		rules, allowed := Policy{}.ProviderRules("aws")
+
+		// then
+		assert.True(t, allowed)
+		assert.Equal(t, ProviderPolicy{}, rules)
+	})
+}
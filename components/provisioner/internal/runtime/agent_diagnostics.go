@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/apis/compass/v1alpha1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultRuntimeAgentNamespace = "compass-system"
+	runtimeAgentPodSelector      = "app=" + runtimeAgentComponentName
+
+	// MaxAgentDiagnosticsLength is the maximum length of the diagnostics stored with the operation
+	MaxAgentDiagnosticsLength = 1024
+	truncatedSuffix           = "...(truncated)"
+)
+
+var (
+	secretValuePattern = regexp.MustCompile(`(?i)((?:token|password|secret)["']?\s*[=:]\s*["']?)[^\s"'&,;:]+`)
+	bearerTokenPattern = regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`)
+)
+
+//go:generate mockery -name=AgentDiagnostics
+type AgentDiagnostics interface {
+	// Collect describes the state of the Compass Connection and Runtime Agent pods,
+	// the Compass Connection is nil when it does not exist on the Runtime
+	Collect(cluster model.Cluster, kubeconfigRaw string, compassConnection *v1alpha1.CompassConnection) string
+}
+
+type agentDiagnostics struct {
+	k8sClientProvider k8s.K8sClientProvider
+}
+
+func NewAgentDiagnostics(k8sClientProvider k8s.K8sClientProvider) AgentDiagnostics {
+	return &agentDiagnostics{
+		k8sClientProvider: k8sClientProvider,
+	}
+}
+
+func (d *agentDiagnostics) Collect(cluster model.Cluster, kubeconfigRaw string, compassConnection *v1alpha1.CompassConnection) string {
+	details := []string{compassConnectionDiagnostics(compassConnection)}
+	details = append(details, d.agentPodsDiagnostics(cluster, kubeconfigRaw)...)
+
+	return capDiagnostics(redactSecrets(strings.Join(details, "; ")))
+}
+
+func compassConnectionDiagnostics(compassConnection *v1alpha1.CompassConnection) string {
+	if compassConnection == nil {
+		return "Compass Connection not found"
+	}
+
+	status := compassConnection.Status
+	details := fmt.Sprintf("Compass Connection state: %s", status.State)
+	if status.ConnectionStatus != nil && status.ConnectionStatus.Error != "" {
+		details += fmt.Sprintf(", connection error: %s", status.ConnectionStatus.Error)
+	}
+	if status.SynchronizationStatus != nil && status.SynchronizationStatus.Error != "" {
+		details += fmt.Sprintf(", synchronization error: %s", status.SynchronizationStatus.Error)
+	}
+
+	return details
+}
+
+func (d *agentDiagnostics) agentPodsDiagnostics(cluster model.Cluster, kubeconfigRaw string) []string {
+	k8sClient, err := d.k8sClientProvider.CreateK8SClient(kubeconfigRaw)
+	if err != nil {
+		return []string{fmt.Sprintf("failed to create Kubernetes client: %s", err.Error())}
+	}
+
+	namespace := defaultRuntimeAgentNamespace
+	if cluster.KymaConfig != nil {
+		if component, found := cluster.KymaConfig.GetComponentConfig(runtimeAgentComponentName); found && component.Namespace != "" {
+			namespace = component.Namespace
+		}
+	}
+
+	pods, listErr := k8sClient.CoreV1().Pods(namespace).List(context.Background(), meta.ListOptions{LabelSelector: runtimeAgentPodSelector})
+	if listErr != nil {
+		return []string{fmt.Sprintf("failed to list Runtime Agent pods: %s", listErr.Error())}
+	}
+	if len(pods.Items) == 0 {
+		return []string{fmt.Sprintf("Runtime Agent pod not found in %s namespace", namespace)}
+	}
+
+	details := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		details = append(details, podDiagnostics(pod))
+	}
+
+	return details
+}
+
+func podDiagnostics(pod core.Pod) string {
+	details := fmt.Sprintf("Runtime Agent pod %s phase: %s", pod.Name, pod.Status.Phase)
+
+	for _, container := range pod.Status.ContainerStatuses {
+		details += fmt.Sprintf(", container %s restarts: %d", container.Name, container.RestartCount)
+		if waiting := container.State.Waiting; waiting != nil {
+			details += fmt.Sprintf(", waiting: %s", waiting.Reason)
+			if waiting.Message != "" {
+				details += fmt.Sprintf(" (%s)", waiting.Message)
+			}
+		}
+		if terminated := container.LastTerminationState.Terminated; terminated != nil {
+			details += fmt.Sprintf(", last terminated: %s with exit code %d", terminated.Reason, terminated.ExitCode)
+		}
+	}
+
+	return details
+}
+
+func redactSecrets(diagnostics string) string {
+	diagnostics = secretValuePattern.ReplaceAllString(diagnostics, "${1}<redacted>")
+	return bearerTokenPattern.ReplaceAllString(diagnostics, "${1}<redacted>")
+}
+
+func capDiagnostics(diagnostics string) string {
+	if len(diagnostics) <= MaxAgentDiagnosticsLength {
+		return diagnostics
+	}
+
+	// cut at the beginning of a rune to keep the diagnostics valid UTF-8
+	cut := MaxAgentDiagnosticsLength - len(truncatedSuffix)
+	for cut > 0 && !utf8.RuneStart(diagnostics[cut]) {
+		cut--
+	}
+
+	return diagnostics[:cut] + truncatedSuffix
+}
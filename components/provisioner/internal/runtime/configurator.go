@@ -41,6 +41,10 @@ func NewRuntimeConfigurator(builder k8s.K8sClientProvider, directorClient direct
 }
 
 func (c *configurator) ConfigureRuntime(cluster model.Cluster, kubeconfigRaw string) apperrors.AppError {
+	if cluster.KymaConfig == nil {
+		return nil
+	}
+
 	runtimeAgentComponent, found := cluster.KymaConfig.GetComponentConfig(runtimeAgentComponentName)
 	if found {
 		err := c.configureAgent(cluster, runtimeAgentComponent.Namespace, kubeconfigRaw)
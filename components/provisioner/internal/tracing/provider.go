@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies the spans reported by the Provisioner
+const TracerName = "github.com/kyma-project/control-plane/components/provisioner"
+
+const serviceName = "provisioner"
+
+// Attributes correlating the spans of the operation stages with the span of the request which started the operation
+const (
+	OperationIDKey   = attribute.Key("provisioner.operation.id")
+	OperationTypeKey = attribute.Key("provisioner.operation.type")
+	RuntimeIDKey     = attribute.Key("provisioner.runtime.id")
+	CorrelationIDKey = attribute.Key("provisioner.correlation.id")
+)
+
+type Config struct {
+	// Endpoint is the host and port of the OTLP/HTTP collector receiving the spans
+	Endpoint string `envconfig:"default=localhost:4318"`
+	// Insecure sends the spans over plain HTTP
+	Insecure bool `envconfig:"default=false"`
+	// SampleRatio is the fraction of traces started by the Provisioner which are sampled, the decision of the caller is respected
+	SampleRatio float64 `envconfig:"default=1"`
+}
+
+// Provider exports the spans to the OTLP collector. The nil Provider means that tracing is disabled,
+// the global no-op tracer provider is used and the clients and handlers are not instrumented.
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// NewProvider registers the global tracer provider exporting the spans when enabled, otherwise nil is returned
+func NewProvider(enabled bool, config Config) (*Provider, error) {
+	if !enabled {
+		return nil, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return &Provider{tracerProvider: tracerProvider}, nil
+}
+
+// Tracer returns the tracer of the Provisioner, which is no-op if tracing is disabled
+func (p *Provider) Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// WrapTransport reports the requests sent with the transport as client spans, the transport is returned unchanged if tracing is disabled
+func (p *Provider) WrapTransport(transport http.RoundTripper) http.RoundTripper {
+	if p == nil {
+		return transport
+	}
+
+	return otelhttp.NewTransport(transport)
+}
+
+// Middleware reports the received requests as server spans continuing the traces of the callers,
+// the handler is returned unchanged if tracing is disabled
+func (p *Provider) Middleware(handler http.Handler) http.Handler {
+	if p == nil {
+		return handler
+	}
+
+	return otelhttp.NewHandler(handler, "http.request")
+}
+
+// Shutdown exports the remaining spans
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	return p.tracerProvider.Shutdown(ctx)
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewProvider(t *testing.T) {
+	t.Run("should not instrument clients and handlers when disabled", func(t *testing.T) {
+		// given
+		provider, err := NewProvider(false, Config{Endpoint: "localhost:4318"})
+		require.NoError(t, err)
+
+		transport := &http.Transport{}
+		handler := http.NewServeMux()
+
+		// when
+		_, span := provider.Tracer().Start(context.Background(), "span")
+
+		// then
+		assert.Nil(t, provider)
+		assert.Same(t, transport, provider.WrapTransport(transport))
+		assert.Same(t, handler, provider.Middleware(handler))
+		assert.False(t, span.IsRecording())
+		assert.NoError(t, provider.Shutdown(context.Background()))
+	})
+
+	t.Run("should propagate trace and export spans when enabled", func(t *testing.T) {
+		// given
+		defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+		var exported int32
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/traces" {
+				atomic.AddInt32(&exported, 1)
+			}
+		}))
+		defer collector.Close()
+
+		var traceParent string
+		director := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceParent = r.Header.Get("traceparent")
+		}))
+		defer director.Close()
+
+		provider, err := NewProvider(true, Config{Endpoint: strings.TrimPrefix(collector.URL, "http://"), Insecure: true, SampleRatio: 1})
+		require.NoError(t, err)
+
+		client := &http.Client{Transport: provider.WrapTransport(http.DefaultTransport)}
+
+		// when
+		ctx, span := provider.Tracer().Start(context.Background(), "operation")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, director.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		span.End()
+
+		err = provider.Shutdown(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, traceParent, span.SpanContext().TraceID().String())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&exported))
+	})
+}
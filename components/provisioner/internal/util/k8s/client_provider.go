@@ -1,32 +1,153 @@
 package k8s
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 )
 
+// authErrorsThreshold is the number of consecutive Unauthorized responses after which the cached client is considered
+// to use revoked credentials, e.g. after the kubeconfig was rotated
+const authErrorsThreshold = 3
+
 //go:generate mockery -name=K8sClientProvider
 type K8sClientProvider interface {
 	CreateK8SClient(kubeconfigRaw string) (kubernetes.Interface, apperrors.AppError)
 }
 
-type k8sClientBuilder struct{}
+// ClientCacheConfig configures caching of the clients built from kubeconfigs, 0 in any of the fields disables the cache
+type ClientCacheConfig struct {
+	TTL        time.Duration `envconfig:"default=30m"`
+	MaxEntries int           `envconfig:"default=500"`
+}
+
+type cachedClient struct {
+	client kubernetes.Interface
+}
+
+type k8sClientBuilder struct {
+	config ClientCacheConfig
+
+	mutex   sync.Mutex
+	clients *cache.LRUExpireCache
+
+	newClient func(k8sConfig *restclient.Config) (kubernetes.Interface, error)
+}
+
+func NewK8sClientProvider(config ClientCacheConfig) K8sClientProvider {
+	builder := &k8sClientBuilder{
+		config: config,
+		newClient: func(k8sConfig *restclient.Config) (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(k8sConfig)
+		},
+	}
+	if builder.cacheEnabled() {
+		builder.clients = cache.NewLRUExpireCache(config.MaxEntries)
+	}
 
-func NewK8sClientProvider() K8sClientProvider {
-	return &k8sClientBuilder{}
+	return builder
 }
 
 func (c *k8sClientBuilder) CreateK8SClient(kubeconfigRaw string) (kubernetes.Interface, apperrors.AppError) {
+	if !c.cacheEnabled() {
+		return c.createK8SClient(kubeconfigRaw, nil)
+	}
+
+	key := kubeconfigHash(kubeconfigRaw)
+
+	// Clients are built while holding the lock, so that parallel stages using the same kubeconfig share a single client
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cached, found := c.clients.Get(key); found {
+		return cached.(*cachedClient).client, nil
+	}
+
+	entry := &cachedClient{}
+	client, err := c.createK8SClient(kubeconfigRaw, func(rt http.RoundTripper) http.RoundTripper {
+		return &authErrorsTracker{
+			delegate: rt,
+			onPersistentAuthErrors: func() {
+				c.invalidate(key, entry)
+			},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	entry.client = client
+	c.clients.Add(key, entry, c.config.TTL)
+
+	return client, nil
+}
+
+func (c *k8sClientBuilder) createK8SClient(kubeconfigRaw string, wrapTransport func(rt http.RoundTripper) http.RoundTripper) (kubernetes.Interface, apperrors.AppError) {
 	k8sConfig, err := ParseToK8sConfig([]byte(kubeconfigRaw))
 
 	if err != nil {
 		return nil, apperrors.Internal("failed to parse kubeconfig: %s", err.Error())
 	}
 
-	coreClientset, err := kubernetes.NewForConfig(k8sConfig)
+	if wrapTransport != nil {
+		k8sConfig.Wrap(wrapTransport)
+	}
+
+	coreClientset, err := c.newClient(k8sConfig)
 	if err != nil {
 		return nil, apperrors.Internal("failed to create k8s core client: %s", err.Error())
 	}
 
 	return coreClientset, nil
 }
+
+// invalidate removes the client from the cache unless it was already replaced with a newer one
+func (c *k8sClientBuilder) invalidate(key string, entry *cachedClient) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cached, found := c.clients.Get(key); found && cached.(*cachedClient) == entry {
+		c.clients.Remove(key)
+	}
+}
+
+func (c *k8sClientBuilder) cacheEnabled() bool {
+	return c.config.TTL > 0 && c.config.MaxEntries > 0
+}
+
+func kubeconfigHash(kubeconfigRaw string) string {
+	hash := sha256.Sum256([]byte(kubeconfigRaw))
+	return hex.EncodeToString(hash[:])
+}
+
+type authErrorsTracker struct {
+	delegate               http.RoundTripper
+	onPersistentAuthErrors func()
+
+	consecutiveAuthErrors int32
+}
+
+func (t *authErrorsTracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.delegate.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		atomic.StoreInt32(&t.consecutiveAuthErrors, 0)
+		return resp, nil
+	}
+
+	if atomic.AddInt32(&t.consecutiveAuthErrors, 1) >= authErrorsThreshold {
+		t.onPersistentAuthErrors()
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,193 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+)
+
+const kubeconfigTemplate = `
+apiVersion: v1
+kind: Config
+current-context: runtime
+clusters:
+- name: runtime
+  cluster:
+    server: %s
+contexts:
+- name: runtime
+  context:
+    cluster: runtime
+    user: admin
+users:
+- name: admin
+  user:
+    token: %s
+`
+
+func TestK8sClientProvider_CreateK8SClient(t *testing.T) {
+	cacheConfig := ClientCacheConfig{TTL: time.Hour, MaxEntries: 10}
+
+	t.Run("should build single client for kubeconfig used in parallel", func(t *testing.T) {
+		//given
+		provider, builds := newCountingProvider(cacheConfig)
+		kubeconfig := fmt.Sprintf(kubeconfigTemplate, "https://api.runtime.example.com", "token")
+
+		clients := make([]kubernetes.Interface, 50)
+		var wg sync.WaitGroup
+
+		//when
+		for i := range clients {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				client, err := provider.CreateK8SClient(kubeconfig)
+				assert.NoError(t, err)
+				clients[i] = client
+			}(i)
+		}
+		wg.Wait()
+
+		//then
+		assert.Equal(t, int32(1), atomic.LoadInt32(builds))
+		for _, client := range clients {
+			assert.Same(t, clients[0], client)
+		}
+	})
+
+	t.Run("should build separate clients for different kubeconfigs", func(t *testing.T) {
+		//given
+		provider, builds := newCountingProvider(cacheConfig)
+
+		//when
+		first, err := provider.CreateK8SClient(fmt.Sprintf(kubeconfigTemplate, "https://api.runtime.example.com", "token"))
+		require.NoError(t, err)
+		second, err := provider.CreateK8SClient(fmt.Sprintf(kubeconfigTemplate, "https://api.runtime.example.com", "rotated-token"))
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, int32(2), atomic.LoadInt32(builds))
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("should build client on every call when cache is disabled", func(t *testing.T) {
+		//given
+		provider, builds := newCountingProvider(ClientCacheConfig{})
+		kubeconfig := fmt.Sprintf(kubeconfigTemplate, "https://api.runtime.example.com", "token")
+
+		//when
+		for i := 0; i < 3; i++ {
+			_, err := provider.CreateK8SClient(kubeconfig)
+			require.NoError(t, err)
+		}
+
+		//then
+		assert.Equal(t, int32(3), atomic.LoadInt32(builds))
+	})
+
+	t.Run("should return error when kubeconfig is invalid", func(t *testing.T) {
+		//given
+		provider, builds := newCountingProvider(cacheConfig)
+
+		//when
+		_, err := provider.CreateK8SClient("invalid")
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, int32(0), atomic.LoadInt32(builds))
+	})
+}
+
+func TestK8sClientProvider_AuthErrors(t *testing.T) {
+	cacheConfig := ClientCacheConfig{TTL: time.Hour, MaxEntries: 10}
+
+	newServer := func(status *int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(int(atomic.LoadInt32(status)))
+			_, _ = w.Write([]byte(`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"default"}}`))
+		}))
+	}
+
+	t.Run("should rebuild client after persistent auth errors", func(t *testing.T) {
+		//given
+		status := int32(http.StatusUnauthorized)
+		server := newServer(&status)
+		defer server.Close()
+
+		provider := NewK8sClientProvider(cacheConfig)
+		kubeconfig := fmt.Sprintf(kubeconfigTemplate, server.URL, "revoked-token")
+
+		client, err := provider.CreateK8SClient(kubeconfig)
+		require.NoError(t, err)
+
+		//when
+		for i := 0; i < authErrorsThreshold; i++ {
+			_, getErr := client.CoreV1().Namespaces().Get(context.Background(), "default", v1.GetOptions{})
+			require.Error(t, getErr)
+		}
+		rebuilt, err := provider.CreateK8SClient(kubeconfig)
+		require.NoError(t, err)
+
+		//then
+		assert.NotSame(t, client, rebuilt)
+	})
+
+	t.Run("should keep client when auth errors are not persistent", func(t *testing.T) {
+		//given
+		status := int32(http.StatusUnauthorized)
+		server := newServer(&status)
+		defer server.Close()
+
+		provider := NewK8sClientProvider(cacheConfig)
+		kubeconfig := fmt.Sprintf(kubeconfigTemplate, server.URL, "token")
+
+		client, err := provider.CreateK8SClient(kubeconfig)
+		require.NoError(t, err)
+
+		//when
+		for i := 0; i < authErrorsThreshold-1; i++ {
+			_, getErr := client.CoreV1().Namespaces().Get(context.Background(), "default", v1.GetOptions{})
+			require.Error(t, getErr)
+		}
+		atomic.StoreInt32(&status, http.StatusOK)
+		_, getErr := client.CoreV1().Namespaces().Get(context.Background(), "default", v1.GetOptions{})
+		require.NoError(t, getErr)
+
+		atomic.StoreInt32(&status, http.StatusUnauthorized)
+		_, getErr = client.CoreV1().Namespaces().Get(context.Background(), "default", v1.GetOptions{})
+		require.Error(t, getErr)
+
+		cached, err := provider.CreateK8SClient(kubeconfig)
+		require.NoError(t, err)
+
+		//then
+		assert.Same(t, client, cached)
+	})
+}
+
+func newCountingProvider(config ClientCacheConfig) (K8sClientProvider, *int32) {
+	builds := int32(0)
+
+	provider := NewK8sClientProvider(config).(*k8sClientBuilder)
+	provider.newClient = func(_ *restclient.Config) (kubernetes.Interface, error) {
+		atomic.AddInt32(&builds, 1)
+		// Simulates the time needed to build the client, so that parallel calls overlap
+		time.Sleep(10 * time.Millisecond)
+		return fake.NewSimpleClientset(), nil
+	}
+
+	return provider, &builds
+}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"fmt"
 
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -20,3 +21,19 @@ func ParseToK8sConfig(kubeconfigRaw []byte) (*restclient.Config, error) {
 
 	return clientConfig, nil
 }
+
+// ParseAPIServer reads the URL and the CA bundle of the API server from the kubeconfig
+func ParseAPIServer(kubeconfigRaw []byte) (model.APIServer, error) {
+	clientConfig, err := ParseToK8sConfig(kubeconfigRaw)
+	if err != nil {
+		return model.APIServer{}, err
+	}
+	if clientConfig.Host == "" {
+		return model.APIServer{}, fmt.Errorf("kubeconfig does not contain the API server URL")
+	}
+
+	return model.APIServer{
+		URL:           clientConfig.Host,
+		CACertificate: string(clientConfig.CAData),
+	}, nil
+}
@@ -72,6 +72,14 @@ func DefaultStrIfNil(ptr *string, def *string) *string {
 	return ptr
 }
 
+// DefaultBoolIfNil returns default bool pointer if passed pointer is nil
+func DefaultBoolIfNil(ptr *bool, def *bool) *bool {
+	if ptr == nil {
+		return def
+	}
+	return ptr
+}
+
 // DefaultIntIfNil returns default int pointer if passed pointer is nil
 func DefaultIntIfNil(ptr *int, def *int) *int {
 	if ptr == nil {
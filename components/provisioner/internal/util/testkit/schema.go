@@ -0,0 +1,22 @@
+package testkit
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AssertBackwardCompatibleSchema fails the test if the current GraphQL schema breaks the clients of the previous one,
+// for example, if a field is removed or its type is changed
+func AssertBackwardCompatibleSchema(t *testing.T, previousSchemaPath, currentSchemaPath string) {
+	t.Helper()
+
+	previous, err := gqlschema.LoadSchemaFile(previousSchemaPath)
+	require.NoError(t, err)
+	current, err := gqlschema.LoadSchemaFile(currentSchemaPath)
+	require.NoError(t, err)
+
+	assert.Empty(t, gqlschema.BreakingChanges(previous, current), "schema %s is not backward compatible with %s", currentSchemaPath, previousSchemaPath)
+}
@@ -0,0 +1,13 @@
+package testkit
+
+import (
+	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
+)
+
+// uuidSeed is the seed of IDs generated in tests, it must not be changed as golden files rely on the generated IDs
+const uuidSeed = 1
+
+// NewUUIDGenerator creates generator returning the same sequence of IDs in each test run
+func NewUUIDGenerator() uuid.UUIDGenerator {
+	return uuid.NewSequenceUUIDGenerator(uuidSeed)
+}
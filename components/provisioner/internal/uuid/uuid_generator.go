@@ -1,6 +1,10 @@
 package uuid
 
 import (
+	"fmt"
+	"math/rand"
+	"sync"
+
 	"github.com/google/uuid"
 )
 
@@ -12,6 +16,7 @@ type UUIDGenerator interface {
 type uuidGenerator struct {
 }
 
+// NewUUIDGenerator returns generator of random UUIDs (version 4)
 func NewUUIDGenerator() UUIDGenerator {
 	return uuidGenerator{}
 }
@@ -19,3 +24,54 @@ func NewUUIDGenerator() UUIDGenerator {
 func (u uuidGenerator) New() string {
 	return uuid.New().String()
 }
+
+type sequenceUUIDGenerator struct {
+	mutex  sync.Mutex
+	random *rand.Rand
+}
+
+// NewSequenceUUIDGenerator returns generator of UUIDs (version 4) read from the pseudo-random sequence of the seed,
+// generators created with the same seed return the same IDs in the same order, e.g. to compare outputs with golden files
+func NewSequenceUUIDGenerator(seed int64) UUIDGenerator {
+	return &sequenceUUIDGenerator{
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (u *sequenceUUIDGenerator) New() string {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	id, err := uuid.NewRandomFromReader(u.random)
+	if err != nil {
+		// Reading from math/rand never fails
+		panic(fmt.Sprintf("failed to generate UUID from sequence: %s", err.Error()))
+	}
+
+	return id.String()
+}
+
+type nameBasedUUIDGenerator struct {
+	namespace uuid.UUID
+	name      string
+
+	mutex    sync.Mutex
+	sequence int
+}
+
+// NewNameBasedUUIDGenerator returns generator of UUIDs (version 5) derived from the namespace, the tenant and the name of the Runtime,
+// and the sequence number of the ID, so that replaying the provisioning of the Runtime returns the same IDs
+func NewNameBasedUUIDGenerator(namespace uuid.UUID, tenant, runtimeName string) UUIDGenerator {
+	return &nameBasedUUIDGenerator{
+		namespace: namespace,
+		name:      fmt.Sprintf("%s/%s", tenant, runtimeName),
+	}
+}
+
+func (u *nameBasedUUIDGenerator) New() string {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.sequence++
+	return uuid.NewSHA1(u.namespace, []byte(fmt.Sprintf("%s/%d", u.name, u.sequence))).String()
+}
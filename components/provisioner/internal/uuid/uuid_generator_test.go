@@ -0,0 +1,71 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceUUIDGenerator(t *testing.T) {
+	t.Run("should generate the same sequence of IDs for the same seed", func(t *testing.T) {
+		//given
+		first := NewSequenceUUIDGenerator(1)
+		second := NewSequenceUUIDGenerator(1)
+
+		//when
+		firstIDs := []string{first.New(), first.New(), first.New()}
+		secondIDs := []string{second.New(), second.New(), second.New()}
+
+		//then
+		assert.Equal(t, firstIDs, secondIDs)
+		assert.NotEqual(t, firstIDs[0], firstIDs[1])
+		for _, id := range firstIDs {
+			parsed, err := uuid.Parse(id)
+			require.NoError(t, err)
+			assert.Equal(t, uuid.Version(4), parsed.Version())
+		}
+	})
+
+	t.Run("should generate different IDs for different seeds", func(t *testing.T) {
+		//when
+		first := NewSequenceUUIDGenerator(1).New()
+		second := NewSequenceUUIDGenerator(2).New()
+
+		//then
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestNameBasedUUIDGenerator(t *testing.T) {
+	namespace := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	t.Run("should generate the same sequence of IDs for the same Runtime", func(t *testing.T) {
+		//given
+		first := NewNameBasedUUIDGenerator(namespace, "tenant", "runtime")
+		second := NewNameBasedUUIDGenerator(namespace, "tenant", "runtime")
+
+		//when
+		firstIDs := []string{first.New(), first.New()}
+		secondIDs := []string{second.New(), second.New()}
+
+		//then
+		assert.Equal(t, firstIDs, secondIDs)
+		assert.NotEqual(t, firstIDs[0], firstIDs[1])
+		parsed, err := uuid.Parse(firstIDs[0])
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Version(5), parsed.Version())
+	})
+
+	t.Run("should generate different IDs for different tenants and Runtimes", func(t *testing.T) {
+		//when
+		id := NewNameBasedUUIDGenerator(namespace, "tenant", "runtime").New()
+		otherTenantID := NewNameBasedUUIDGenerator(namespace, "other-tenant", "runtime").New()
+		otherRuntimeID := NewNameBasedUUIDGenerator(namespace, "tenant", "other-runtime").New()
+
+		//then
+		assert.NotEqual(t, id, otherTenantID)
+		assert.NotEqual(t, id, otherRuntimeID)
+	})
+}
@@ -0,0 +1,44 @@
+package gqlschema
+
+import (
+	"io"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/scalar"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Annotations are Kubernetes annotations, unlike Labels their values have to be strings
+type Annotations map[string]string
+
+func (y *Annotations) UnmarshalGQL(v interface{}) error {
+	if v == nil {
+		return errors.New("input should not be nil")
+	}
+
+	value, ok := v.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("unexpected Annotations type: %T, should be map[string]interface{}", v)
+	}
+
+	annotations := make(Annotations, len(value))
+	for key, val := range value {
+		stringValue, ok := val.(string)
+		if !ok {
+			return errors.Errorf("unexpected type of annotation %s value: %T, should be string", key, val)
+		}
+		annotations[key] = stringValue
+	}
+
+	*y = annotations
+
+	return nil
+}
+
+func (y Annotations) MarshalGQL(w io.Writer) {
+	err := scalar.WriteMarshalled(y, w)
+	if err != nil {
+		log.Errorf("while writing %T: %s", y, err)
+		return
+	}
+}
@@ -0,0 +1,184 @@
+package gqlschema
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// LoadSchemaFile parses the GraphQL schema from the file, the built-in types are added to the schema
+func LoadSchemaFile(path string) (*ast.Schema, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %s", path, err.Error())
+	}
+
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: path, Input: string(content)})
+	if gqlErr != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %s", path, gqlErr.Error())
+	}
+
+	return schema, nil
+}
+
+// BreakingChanges lists the changes of the current schema which break the clients using the previous one.
+// Types, fields, arguments, enum values, and union members cannot be removed, and their types cannot be changed,
+// except for output fields which become non-null and arguments or input fields which become nullable.
+// New arguments and input fields have to be nullable or have a default value
+func BreakingChanges(previous, current *ast.Schema) []string {
+	var changes []string
+
+	for _, name := range typeNames(previous) {
+		previousType := previous.Types[name]
+		if previousType.BuiltIn {
+			continue
+		}
+
+		currentType, found := current.Types[name]
+		if !found {
+			changes = append(changes, fmt.Sprintf("type %s removed", name))
+			continue
+		}
+		if currentType.Kind != previousType.Kind {
+			changes = append(changes, fmt.Sprintf("type %s changed from %s to %s", name, previousType.Kind, currentType.Kind))
+			continue
+		}
+
+		switch previousType.Kind {
+		case ast.Object, ast.Interface:
+			changes = append(changes, outputFieldsChanges(previousType, currentType)...)
+		case ast.InputObject:
+			changes = append(changes, inputValuesChanges(name, previousType.Fields, currentType.Fields)...)
+		case ast.Enum:
+			for _, value := range previousType.EnumValues {
+				if currentType.EnumValues.ForName(value.Name) == nil {
+					changes = append(changes, fmt.Sprintf("enum value %s.%s removed", name, value.Name))
+				}
+			}
+		case ast.Union:
+			for _, member := range previousType.Types {
+				if !contains(currentType.Types, member) {
+					changes = append(changes, fmt.Sprintf("type %s removed from union %s", member, name))
+				}
+			}
+		}
+	}
+
+	return changes
+}
+
+func outputFieldsChanges(previous, current *ast.Definition) []string {
+	var changes []string
+
+	for _, previousField := range previous.Fields {
+		fieldName := fmt.Sprintf("%s.%s", previous.Name, previousField.Name)
+
+		currentField := current.Fields.ForName(previousField.Name)
+		if currentField == nil {
+			changes = append(changes, fmt.Sprintf("field %s removed", fieldName))
+			continue
+		}
+		if !isCompatibleType(previousField.Type, currentField.Type, true) {
+			changes = append(changes, fmt.Sprintf("type of field %s changed from %s to %s", fieldName, previousField.Type, currentField.Type))
+		}
+
+		changes = append(changes, argumentsChanges(fieldName, previousField.Arguments, currentField.Arguments)...)
+	}
+
+	return changes
+}
+
+func argumentsChanges(fieldName string, previous, current ast.ArgumentDefinitionList) []string {
+	var changes []string
+
+	for _, previousArgument := range previous {
+		currentArgument := current.ForName(previousArgument.Name)
+		if currentArgument == nil {
+			changes = append(changes, fmt.Sprintf("argument %s of field %s removed", previousArgument.Name, fieldName))
+			continue
+		}
+		if !isCompatibleType(previousArgument.Type, currentArgument.Type, false) {
+			changes = append(changes, fmt.Sprintf("type of argument %s of field %s changed from %s to %s",
+				previousArgument.Name, fieldName, previousArgument.Type, currentArgument.Type))
+		}
+	}
+
+	for _, currentArgument := range current {
+		if previous.ForName(currentArgument.Name) == nil && isRequired(currentArgument.Type, currentArgument.DefaultValue) {
+			changes = append(changes, fmt.Sprintf("required argument %s of field %s added", currentArgument.Name, fieldName))
+		}
+	}
+
+	return changes
+}
+
+func inputValuesChanges(typeName string, previous, current ast.FieldList) []string {
+	var changes []string
+
+	for _, previousField := range previous {
+		fieldName := fmt.Sprintf("%s.%s", typeName, previousField.Name)
+
+		currentField := current.ForName(previousField.Name)
+		if currentField == nil {
+			changes = append(changes, fmt.Sprintf("input field %s removed", fieldName))
+			continue
+		}
+		if !isCompatibleType(previousField.Type, currentField.Type, false) {
+			changes = append(changes, fmt.Sprintf("type of input field %s changed from %s to %s", fieldName, previousField.Type, currentField.Type))
+		}
+	}
+
+	for _, currentField := range current {
+		if previous.ForName(currentField.Name) == nil && isRequired(currentField.Type, currentField.DefaultValue) {
+			changes = append(changes, fmt.Sprintf("required input field %s.%s added", typeName, currentField.Name))
+		}
+	}
+
+	return changes
+}
+
+// isCompatibleType checks if the clients of the previous type can use the current one,
+// the output values can only become non-null and the input values can only become nullable
+func isCompatibleType(previous, current *ast.Type, output bool) bool {
+	if previous.NamedType != current.NamedType {
+		return false
+	}
+
+	if previous.NonNull != current.NonNull {
+		if output && previous.NonNull || !output && current.NonNull {
+			return false
+		}
+	}
+
+	if previous.Elem == nil || current.Elem == nil {
+		return previous.Elem == nil && current.Elem == nil
+	}
+
+	return isCompatibleType(previous.Elem, current.Elem, output)
+}
+
+func isRequired(valueType *ast.Type, defaultValue *ast.Value) bool {
+	return valueType.NonNull && defaultValue == nil
+}
+
+func typeNames(schema *ast.Schema) []string {
+	names := make([]string, 0, len(schema.Types))
+	for name := range schema.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
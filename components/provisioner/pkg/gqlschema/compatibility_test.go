@@ -0,0 +1,228 @@
+package gqlschema_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/util/testkit"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// previousReleaseSchema is the copy of the schema served by the previous release of the Provisioner,
+// replace it with the current schema when the Provisioner is released
+const previousReleaseSchema = "testdata/previous_release_schema.graphql"
+
+func TestSchemaBackwardCompatibility(t *testing.T) {
+	testkit.AssertBackwardCompatibleSchema(t, previousReleaseSchema, "schema.graphql")
+}
+
+func TestBreakingChanges(t *testing.T) {
+	const previousSchema = `
+type Query {
+    runtime(id: String!, verbose: Boolean): Runtime
+    runtimes: [Runtime!]
+}
+
+type Runtime {
+    id: String!
+    name: String
+    state: State
+    config: Config
+}
+
+enum State {
+    Succeeded
+    Failed
+}
+
+union Config = GCPConfig | AzureConfig
+
+type GCPConfig {
+    zone: String
+}
+
+type AzureConfig {
+    zone: String
+}
+
+type Mutation {
+    provision(input: ProvisionInput!): Runtime
+}
+
+input ProvisionInput {
+    name: String!
+    labels: [String!]
+}
+`
+
+	for _, testCase := range []struct {
+		description     string
+		currentSchema   string
+		expectedChanges []string
+	}{
+		{
+			description: "should accept unchanged schema",
+		},
+		{
+			description: "should accept extended schema",
+			currentSchema: `
+type Query {
+    runtime(id: String!, verbose: Boolean): Runtime
+    runtimes: [Runtime!]
+}
+
+type Runtime {
+    id: String!
+    name: String!
+    state: State
+    config: Config
+    region: String
+}
+
+enum State {
+    Succeeded
+    Failed
+    InProgress
+}
+
+union Config = GCPConfig | AzureConfig | AWSConfig
+
+type GCPConfig {
+    zone: String
+}
+
+type AzureConfig {
+    zone: String
+}
+
+type AWSConfig {
+    zone: String
+}
+
+type Mutation {
+    provision(input: ProvisionInput!, dryRun: Boolean, force: Boolean! = false): Runtime
+    deprovision(id: String!): Runtime
+}
+
+input ProvisionInput {
+    name: String
+    labels: [String]
+    region: String
+    purpose: String! = "testing"
+}
+`,
+		},
+		{
+			description: "should reject removed types, fields, arguments and values",
+			currentSchema: `
+type Query {
+    runtime(id: String!): Runtime
+}
+
+type Runtime {
+    id: String!
+    state: State
+    config: Config
+}
+
+enum State {
+    Succeeded
+}
+
+union Config = GCPConfig
+
+type GCPConfig {
+    zone: String
+}
+
+type Mutation {
+    provision(input: ProvisionInput!): Runtime
+}
+
+input ProvisionInput {
+    name: String!
+}
+`,
+			expectedChanges: []string{
+				"type AzureConfig removed",
+				"type AzureConfig removed from union Config",
+				"input field ProvisionInput.labels removed",
+				"argument verbose of field Query.runtime removed",
+				"field Query.runtimes removed",
+				"field Runtime.name removed",
+				"enum value State.Failed removed",
+			},
+		},
+		{
+			description: "should reject changed types and new required inputs",
+			currentSchema: `
+type Query {
+    runtime(id: String, verbose: Boolean!): Runtime
+    runtimes: [Runtime]
+}
+
+type Runtime {
+    id: String
+    name: Int
+    state: State
+    config: Config
+}
+
+scalar State
+
+union Config = GCPConfig | AzureConfig
+
+type GCPConfig {
+    zone: String
+}
+
+type AzureConfig {
+    zone: String
+}
+
+type Mutation {
+    provision(input: ProvisionInput!, region: String!): Runtime
+}
+
+input ProvisionInput {
+    name: String!
+    labels: [String!]!
+    region: String!
+}
+`,
+			expectedChanges: []string{
+				"required argument region of field Mutation.provision added",
+				"type of input field ProvisionInput.labels changed from [String!] to [String!]!",
+				"required input field ProvisionInput.region added",
+				"type of argument verbose of field Query.runtime changed from Boolean to Boolean!",
+				"type of field Query.runtimes changed from [Runtime!] to [Runtime]",
+				"type of field Runtime.id changed from String! to String",
+				"type of field Runtime.name changed from String to Int",
+				"type State changed from ENUM to SCALAR",
+			},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// given
+			current := previousSchema
+			if testCase.currentSchema != "" {
+				current = testCase.currentSchema
+			}
+
+			// when
+			changes := gqlschema.BreakingChanges(loadSchema(t, previousSchema), loadSchema(t, current))
+
+			// then
+			assert.Equal(t, testCase.expectedChanges, changes)
+		})
+	}
+}
+
+func loadSchema(t *testing.T, schema string) *ast.Schema {
+	loaded, err := gqlparser.LoadSchema(&ast.Source{Input: schema})
+	require.Nil(t, err)
+	return loaded
+}
@@ -0,0 +1,59 @@
+package gqlschema
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// IntOrString holds either an absolute number or a string such as a percentage, e.g. 2 or "25%"
+type IntOrString struct {
+	intstr.IntOrString
+}
+
+func (y *IntOrString) UnmarshalGQL(v interface{}) error {
+	switch value := v.(type) {
+	case string:
+		y.IntOrString = intstr.FromString(value)
+	case int:
+		y.IntOrString = intstr.FromInt(value)
+	case int64:
+		return y.fromInt64(value)
+	case json.Number:
+		number, err := value.Int64()
+		if err != nil {
+			return errors.Errorf("unexpected IntOrString value: %s, should be an integer or a string", value)
+		}
+		return y.fromInt64(number)
+	default:
+		return errors.Errorf("unexpected IntOrString type: %T, should be an integer or a string", v)
+	}
+
+	return nil
+}
+
+func (y *IntOrString) fromInt64(value int64) error {
+	if value > math.MaxInt32 || value < math.MinInt32 {
+		return errors.Errorf("IntOrString value %d is out of range", value)
+	}
+	y.IntOrString = intstr.FromInt(int(value))
+
+	return nil
+}
+
+func (y IntOrString) MarshalGQL(w io.Writer) {
+	if y.Type == intstr.Int {
+		_, _ = io.WriteString(w, strconv.Itoa(int(y.IntVal)))
+		return
+	}
+	_, _ = io.WriteString(w, strconv.Quote(y.StrVal))
+}
+
+// NewIntOrString returns a pointer to IntOrString holding the given value
+func NewIntOrString(value intstr.IntOrString) *IntOrString {
+	return &IntOrString{IntOrString: value}
+}
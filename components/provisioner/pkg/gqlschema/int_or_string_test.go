@@ -0,0 +1,79 @@
+package gqlschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIntOrString_UnmarshalGQL(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		input       interface{}
+		expected    intstr.IntOrString
+	}{
+		{description: "int", input: 2, expected: intstr.FromInt(2)},
+		{description: "int64", input: int64(3), expected: intstr.FromInt(3)},
+		{description: "json number", input: json.Number("4"), expected: intstr.FromInt(4)},
+		{description: "percentage", input: "25%", expected: intstr.FromString("25%")},
+	} {
+		t.Run("should unmarshal "+testCase.description, func(t *testing.T) {
+			//given
+			value := IntOrString{}
+
+			//when
+			err := value.UnmarshalGQL(testCase.input)
+
+			//then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, value.IntOrString)
+		})
+	}
+
+	for _, testCase := range []struct {
+		description string
+		input       interface{}
+	}{
+		{description: "float", input: 2.5},
+		{description: "non integer json number", input: json.Number("2.5")},
+		{description: "out of range int64", input: int64(1) << 40},
+		{description: "boolean", input: true},
+	} {
+		t.Run("should return error when value is "+testCase.description, func(t *testing.T) {
+			//given
+			value := IntOrString{}
+
+			//when
+			err := value.UnmarshalGQL(testCase.input)
+
+			//then
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestIntOrString_MarshalGQL(t *testing.T) {
+	for _, testCase := range []struct {
+		description string
+		value       *IntOrString
+		expected    string
+	}{
+		{description: "int", value: NewIntOrString(intstr.FromInt(2)), expected: `2`},
+		{description: "percentage", value: NewIntOrString(intstr.FromString("25%")), expected: `"25%"`},
+	} {
+		t.Run("should marshal "+testCase.description, func(t *testing.T) {
+			//given
+			buffer := &bytes.Buffer{}
+
+			//when
+			testCase.value.MarshalGQL(buffer)
+
+			//then
+			assert.Equal(t, testCase.expected, buffer.String())
+		})
+	}
+}
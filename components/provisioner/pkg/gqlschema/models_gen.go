@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 )
 
 type ProviderSpecificConfig interface {
@@ -13,31 +14,71 @@ type ProviderSpecificConfig interface {
 }
 
 type AWSProviderConfig struct {
-	Zone         *string `json:"zone"`
-	VpcCidr      *string `json:"vpcCidr"`
-	PublicCidr   *string `json:"publicCidr"`
-	InternalCidr *string `json:"internalCidr"`
+	Zone                    *string `json:"zone"`
+	VpcCidr                 *string `json:"vpcCidr"`
+	PublicCidr              *string `json:"publicCidr"`
+	InternalCidr            *string `json:"internalCidr"`
+	HTTPTokens              *string `json:"httpTokens"`
+	HTTPPutResponseHopLimit *int    `json:"httpPutResponseHopLimit"`
 }
 
 func (AWSProviderConfig) IsProviderSpecificConfig() {}
 
 type AWSProviderConfigInput struct {
-	Zone         string `json:"zone"`
-	VpcCidr      string `json:"vpcCidr"`
-	PublicCidr   string `json:"publicCidr"`
-	InternalCidr string `json:"internalCidr"`
+	Zone                    string  `json:"zone"`
+	VpcCidr                 string  `json:"vpcCidr"`
+	PublicCidr              string  `json:"publicCidr"`
+	InternalCidr            string  `json:"internalCidr"`
+	HTTPTokens              *string `json:"httpTokens"`
+	HTTPPutResponseHopLimit *int    `json:"httpPutResponseHopLimit"`
+}
+
+type AuditEntriesFilter struct {
+	Tenant      *string    `json:"tenant"`
+	Mutation    *string    `json:"mutation"`
+	OperationID *string    `json:"operationID"`
+	From        *time.Time `json:"from"`
+	To          *time.Time `json:"to"`
+}
+
+type AuditEntry struct {
+	ID           string    `json:"id"`
+	Tenant       *string   `json:"tenant"`
+	SubAccountID *string   `json:"subAccountID"`
+	Mutation     string    `json:"mutation"`
+	Input        string    `json:"input"`
+	OperationID  *string   `json:"operationID"`
+	CreatedAt    time.Time `json:"createdAt"`
 }
 
 type AzureProviderConfig struct {
-	VnetCidr *string  `json:"vnetCidr"`
-	Zones    []string `json:"zones"`
+	VnetCidr                     *string  `json:"vnetCidr"`
+	Zones                        []string `json:"zones"`
+	EnableNatGateway             *bool    `json:"enableNatGateway"`
+	IdleConnectionTimeoutMinutes *int     `json:"idleConnectionTimeoutMinutes"`
 }
 
 func (AzureProviderConfig) IsProviderSpecificConfig() {}
 
 type AzureProviderConfigInput struct {
-	VnetCidr string   `json:"vnetCidr"`
-	Zones    []string `json:"zones"`
+	VnetCidr                     string   `json:"vnetCidr"`
+	Zones                        []string `json:"zones"`
+	EnableNatGateway             *bool    `json:"enableNatGateway"`
+	IdleConnectionTimeoutMinutes *int     `json:"idleConnectionTimeoutMinutes"`
+}
+
+type ClusterAutoscalerConfig struct {
+	ScaleDownDelayAfterAdd        *string  `json:"scaleDownDelayAfterAdd"`
+	ScaleDownUnneededTime         *string  `json:"scaleDownUnneededTime"`
+	ScaleDownUtilizationThreshold *float64 `json:"scaleDownUtilizationThreshold"`
+	MaxNodeProvisionTime          *string  `json:"maxNodeProvisionTime"`
+}
+
+type ClusterAutoscalerConfigInput struct {
+	ScaleDownDelayAfterAdd        *string  `json:"scaleDownDelayAfterAdd"`
+	ScaleDownUnneededTime         *string  `json:"scaleDownUnneededTime"`
+	ScaleDownUtilizationThreshold *float64 `json:"scaleDownUtilizationThreshold"`
+	MaxNodeProvisionTime          *string  `json:"maxNodeProvisionTime"`
 }
 
 type ClusterConfigInput struct {
@@ -72,108 +113,216 @@ type ConfigEntryInput struct {
 	Secret *bool  `json:"secret"`
 }
 
+type CostAllocation struct {
+	GlobalAccountID *string `json:"globalAccountID"`
+	SubAccountID    *string `json:"subAccountID"`
+	InstanceID      *string `json:"instanceID"`
+}
+
+type CostAllocationInput struct {
+	GlobalAccountID *string `json:"globalAccountID"`
+	SubAccountID    *string `json:"subAccountID"`
+	InstanceID      *string `json:"instanceID"`
+}
+
+type DNSConfig struct {
+	Domain    string         `json:"domain"`
+	Providers []*DNSProvider `json:"providers"`
+}
+
+type DNSConfigInput struct {
+	Domain    string              `json:"domain"`
+	Providers []*DNSProviderInput `json:"providers"`
+}
+
+type DNSProvider struct {
+	Type       string   `json:"type"`
+	SecretName string   `json:"secretName"`
+	Domains    []string `json:"domains"`
+}
+
+type DNSProviderInput struct {
+	Type       string   `json:"type"`
+	SecretName string   `json:"secretName"`
+	Domains    []string `json:"domains"`
+}
+
 type Error struct {
 	Message *string `json:"message"`
 }
 
+type FailedOperationsFilter struct {
+	Type        *OperationType `json:"type"`
+	FailedAfter *time.Time     `json:"failedAfter"`
+	ErrorReason *string        `json:"errorReason"`
+}
+
 type GCPProviderConfig struct {
-	Zones []string `json:"zones"`
+	Zones                     []string `json:"zones"`
+	EnableSecureBoot          *bool    `json:"enableSecureBoot"`
+	EnableIntegrityMonitoring *bool    `json:"enableIntegrityMonitoring"`
+	EnableVtpm                *bool    `json:"enableVtpm"`
 }
 
 func (GCPProviderConfig) IsProviderSpecificConfig() {}
 
 type GCPProviderConfigInput struct {
-	Zones []string `json:"zones"`
+	Zones                     []string `json:"zones"`
+	EnableSecureBoot          *bool    `json:"enableSecureBoot"`
+	EnableIntegrityMonitoring *bool    `json:"enableIntegrityMonitoring"`
+	EnableVtpm                *bool    `json:"enableVtpm"`
 }
 
 type GardenerConfig struct {
-	Name                                *string                `json:"name"`
-	KubernetesVersion                   *string                `json:"kubernetesVersion"`
-	TargetSecret                        *string                `json:"targetSecret"`
-	Provider                            *string                `json:"provider"`
-	Region                              *string                `json:"region"`
-	Seed                                *string                `json:"seed"`
-	MachineType                         *string                `json:"machineType"`
-	MachineImage                        *string                `json:"machineImage"`
-	MachineImageVersion                 *string                `json:"machineImageVersion"`
-	DiskType                            *string                `json:"diskType"`
-	VolumeSizeGb                        *int                   `json:"volumeSizeGB"`
-	WorkerCidr                          *string                `json:"workerCidr"`
-	AutoScalerMin                       *int                   `json:"autoScalerMin"`
-	AutoScalerMax                       *int                   `json:"autoScalerMax"`
-	MaxSurge                            *int                   `json:"maxSurge"`
-	MaxUnavailable                      *int                   `json:"maxUnavailable"`
-	Purpose                             *string                `json:"purpose"`
-	LicenceType                         *string                `json:"licenceType"`
-	EnableKubernetesVersionAutoUpdate   *bool                  `json:"enableKubernetesVersionAutoUpdate"`
-	EnableMachineImageVersionAutoUpdate *bool                  `json:"enableMachineImageVersionAutoUpdate"`
-	AllowPrivilegedContainers           *bool                  `json:"allowPrivilegedContainers"`
-	ProviderSpecificConfig              ProviderSpecificConfig `json:"providerSpecificConfig"`
-	OidcConfig                          *OIDCConfig            `json:"oidcConfig"`
+	Name                                *string                  `json:"name"`
+	KubernetesVersion                   *string                  `json:"kubernetesVersion"`
+	TargetSecret                        *string                  `json:"targetSecret"`
+	Provider                            *string                  `json:"provider"`
+	Region                              *string                  `json:"region"`
+	Seed                                *string                  `json:"seed"`
+	MachineType                         *string                  `json:"machineType"`
+	MachineImage                        *string                  `json:"machineImage"`
+	MachineImageVersion                 *string                  `json:"machineImageVersion"`
+	DiskType                            *string                  `json:"diskType"`
+	VolumeSizeGb                        *int                     `json:"volumeSizeGB"`
+	WorkerCidr                          *string                  `json:"workerCidr"`
+	AutoScalerMin                       *int                     `json:"autoScalerMin"`
+	AutoScalerMax                       *int                     `json:"autoScalerMax"`
+	MaxSurge                            *IntOrString             `json:"maxSurge"`
+	MaxUnavailable                      *IntOrString             `json:"maxUnavailable"`
+	Purpose                             *string                  `json:"purpose"`
+	LicenceType                         *string                  `json:"licenceType"`
+	EnableKubernetesVersionAutoUpdate   *bool                    `json:"enableKubernetesVersionAutoUpdate"`
+	EnableMachineImageVersionAutoUpdate *bool                    `json:"enableMachineImageVersionAutoUpdate"`
+	AllowPrivilegedContainers           *bool                    `json:"allowPrivilegedContainers"`
+	NetworkingType                      *NetworkingType          `json:"networkingType"`
+	ShootAnnotations                    *Annotations             `json:"shootAnnotations"`
+	CostAllocation                      *CostAllocation          `json:"costAllocation"`
+	ClusterAutoscalerConfig             *ClusterAutoscalerConfig `json:"clusterAutoscalerConfig"`
+	KubeAPIServerConfig                 *KubeAPIServerConfig     `json:"kubeAPIServerConfig"`
+	SystemWorkerPool                    *SystemWorkerPool        `json:"systemWorkerPool"`
+	ProviderSpecificConfig              ProviderSpecificConfig   `json:"providerSpecificConfig"`
+	OidcConfig                          *OIDCConfig              `json:"oidcConfig"`
+	DNSConfig                           *DNSConfig               `json:"dnsConfig"`
+	GardenerProject                     *string                  `json:"gardenerProject"`
 }
 
 type GardenerConfigInput struct {
-	Name                                string                 `json:"name"`
-	KubernetesVersion                   string                 `json:"kubernetesVersion"`
-	Provider                            string                 `json:"provider"`
-	TargetSecret                        string                 `json:"targetSecret"`
-	Region                              string                 `json:"region"`
-	MachineType                         string                 `json:"machineType"`
-	MachineImage                        *string                `json:"machineImage"`
-	MachineImageVersion                 *string                `json:"machineImageVersion"`
-	DiskType                            *string                `json:"diskType"`
-	VolumeSizeGb                        *int                   `json:"volumeSizeGB"`
-	WorkerCidr                          string                 `json:"workerCidr"`
-	AutoScalerMin                       int                    `json:"autoScalerMin"`
-	AutoScalerMax                       int                    `json:"autoScalerMax"`
-	MaxSurge                            int                    `json:"maxSurge"`
-	MaxUnavailable                      int                    `json:"maxUnavailable"`
-	Purpose                             *string                `json:"purpose"`
-	LicenceType                         *string                `json:"licenceType"`
-	EnableKubernetesVersionAutoUpdate   *bool                  `json:"enableKubernetesVersionAutoUpdate"`
-	EnableMachineImageVersionAutoUpdate *bool                  `json:"enableMachineImageVersionAutoUpdate"`
-	AllowPrivilegedContainers           *bool                  `json:"allowPrivilegedContainers"`
-	ProviderSpecificConfig              *ProviderSpecificInput `json:"providerSpecificConfig"`
-	Seed                                *string                `json:"seed"`
-	OidcConfig                          *OIDCConfigInput       `json:"oidcConfig"`
+	Name                                *string                       `json:"name"`
+	KubernetesVersion                   string                        `json:"kubernetesVersion"`
+	Provider                            string                        `json:"provider"`
+	TargetSecret                        string                        `json:"targetSecret"`
+	Region                              string                        `json:"region"`
+	MachineType                         string                        `json:"machineType"`
+	MachineImage                        *string                       `json:"machineImage"`
+	MachineImageVersion                 *string                       `json:"machineImageVersion"`
+	DiskType                            *string                       `json:"diskType"`
+	VolumeSizeGb                        *int                          `json:"volumeSizeGB"`
+	WorkerCidr                          string                        `json:"workerCidr"`
+	AutoScalerMin                       int                           `json:"autoScalerMin"`
+	AutoScalerMax                       int                           `json:"autoScalerMax"`
+	MaxSurge                            *IntOrString                  `json:"maxSurge"`
+	MaxUnavailable                      *IntOrString                  `json:"maxUnavailable"`
+	Purpose                             *string                       `json:"purpose"`
+	LicenceType                         *string                       `json:"licenceType"`
+	EnableKubernetesVersionAutoUpdate   *bool                         `json:"enableKubernetesVersionAutoUpdate"`
+	EnableMachineImageVersionAutoUpdate *bool                         `json:"enableMachineImageVersionAutoUpdate"`
+	AllowPrivilegedContainers           *bool                         `json:"allowPrivilegedContainers"`
+	NetworkingType                      *NetworkingType               `json:"networkingType"`
+	ProviderSpecificConfig              *ProviderSpecificInput        `json:"providerSpecificConfig"`
+	Seed                                *string                       `json:"seed"`
+	ShootAnnotations                    *Annotations                  `json:"shootAnnotations"`
+	CostAllocation                      *CostAllocationInput          `json:"costAllocation"`
+	ClusterAutoscalerConfig             *ClusterAutoscalerConfigInput `json:"clusterAutoscalerConfig"`
+	KubeAPIServerConfig                 *KubeAPIServerConfigInput     `json:"kubeAPIServerConfig"`
+	DedicatedSystemPool                 *bool                         `json:"dedicatedSystemPool"`
+	SystemWorkerPool                    *SystemWorkerPoolInput        `json:"systemWorkerPool"`
+	OidcConfig                          *OIDCConfigInput              `json:"oidcConfig"`
+	DNSConfig                           *DNSConfigInput               `json:"dnsConfig"`
+	GardenerProject                     *string                       `json:"gardenerProject"`
 }
 
 type GardenerUpgradeInput struct {
-	KubernetesVersion                   *string                `json:"kubernetesVersion"`
-	MachineType                         *string                `json:"machineType"`
-	DiskType                            *string                `json:"diskType"`
-	VolumeSizeGb                        *int                   `json:"volumeSizeGB"`
-	AutoScalerMin                       *int                   `json:"autoScalerMin"`
-	AutoScalerMax                       *int                   `json:"autoScalerMax"`
-	MachineImage                        *string                `json:"machineImage"`
-	MachineImageVersion                 *string                `json:"machineImageVersion"`
-	MaxSurge                            *int                   `json:"maxSurge"`
-	MaxUnavailable                      *int                   `json:"maxUnavailable"`
-	Purpose                             *string                `json:"purpose"`
-	EnableKubernetesVersionAutoUpdate   *bool                  `json:"enableKubernetesVersionAutoUpdate"`
-	EnableMachineImageVersionAutoUpdate *bool                  `json:"enableMachineImageVersionAutoUpdate"`
-	ProviderSpecificConfig              *ProviderSpecificInput `json:"providerSpecificConfig"`
-	OidcConfig                          *OIDCConfigInput       `json:"oidcConfig"`
+	KubernetesVersion                   *string                       `json:"kubernetesVersion"`
+	MachineType                         *string                       `json:"machineType"`
+	DiskType                            *string                       `json:"diskType"`
+	VolumeSizeGb                        *int                          `json:"volumeSizeGB"`
+	AutoScalerMin                       *int                          `json:"autoScalerMin"`
+	AutoScalerMax                       *int                          `json:"autoScalerMax"`
+	MachineImage                        *string                       `json:"machineImage"`
+	MachineImageVersion                 *string                       `json:"machineImageVersion"`
+	MaxSurge                            *IntOrString                  `json:"maxSurge"`
+	MaxUnavailable                      *IntOrString                  `json:"maxUnavailable"`
+	Purpose                             *string                       `json:"purpose"`
+	EnableKubernetesVersionAutoUpdate   *bool                         `json:"enableKubernetesVersionAutoUpdate"`
+	EnableMachineImageVersionAutoUpdate *bool                         `json:"enableMachineImageVersionAutoUpdate"`
+	NetworkingType                      *NetworkingType               `json:"networkingType"`
+	ShootAnnotations                    *Annotations                  `json:"shootAnnotations"`
+	CostAllocation                      *CostAllocationInput          `json:"costAllocation"`
+	ClusterAutoscalerConfig             *ClusterAutoscalerConfigInput `json:"clusterAutoscalerConfig"`
+	KubeAPIServerConfig                 *KubeAPIServerConfigInput     `json:"kubeAPIServerConfig"`
+	DedicatedSystemPool                 *bool                         `json:"dedicatedSystemPool"`
+	SystemWorkerPool                    *SystemWorkerPoolInput        `json:"systemWorkerPool"`
+	ProviderSpecificConfig              *ProviderSpecificInput        `json:"providerSpecificConfig"`
+	OidcConfig                          *OIDCConfigInput              `json:"oidcConfig"`
+	DNSConfig                           *DNSConfigInput               `json:"dnsConfig"`
 }
 
 type HibernationStatus struct {
-	Hibernated          *bool `json:"hibernated"`
-	HibernationPossible *bool `json:"hibernationPossible"`
+	Hibernated          *bool               `json:"hibernated"`
+	HibernationPossible *bool               `json:"hibernationPossible"`
+	HibernatedSince     *time.Time          `json:"hibernatedSince"`
+	LastWokenAt         *time.Time          `json:"lastWokenAt"`
+	Trigger             *HibernationTrigger `json:"trigger"`
+}
+
+type Info struct {
+	SchemaVersion string `json:"schemaVersion"`
+}
+
+type KubeAPIServerConfig struct {
+	FeatureGates           *Switches `json:"featureGates"`
+	RuntimeConfig          *Switches `json:"runtimeConfig"`
+	MaxNonMutatingInflight *int      `json:"maxNonMutatingInflight"`
+	MaxMutatingInflight    *int      `json:"maxMutatingInflight"`
+}
+
+type KubeAPIServerConfigInput struct {
+	FeatureGates           *Switches `json:"featureGates"`
+	RuntimeConfig          *Switches `json:"runtimeConfig"`
+	MaxNonMutatingInflight *int      `json:"maxNonMutatingInflight"`
+	MaxMutatingInflight    *int      `json:"maxMutatingInflight"`
 }
 
 type KymaConfig struct {
-	Version       *string                   `json:"version"`
-	Profile       *KymaProfile              `json:"profile"`
-	Components    []*ComponentConfiguration `json:"components"`
-	Configuration []*ConfigEntry            `json:"configuration"`
+	Version           *string                   `json:"version"`
+	Profile           *KymaProfile              `json:"profile"`
+	Components        []*ComponentConfiguration `json:"components"`
+	Configuration     []*ConfigEntry            `json:"configuration"`
+	ExternallyManaged bool                      `json:"externallyManaged"`
 }
 
 type KymaConfigInput struct {
-	Version          string                         `json:"version"`
-	Profile          *KymaProfile                   `json:"profile"`
-	Components       []*ComponentConfigurationInput `json:"components"`
-	Configuration    []*ConfigEntryInput            `json:"configuration"`
-	ConflictStrategy *ConflictStrategy              `json:"conflictStrategy"`
+	Version             string                         `json:"version"`
+	Profile             *KymaProfile                   `json:"profile"`
+	Components          []*ComponentConfigurationInput `json:"components"`
+	Configuration       []*ConfigEntryInput            `json:"configuration"`
+	ConflictStrategy    *ConflictStrategy              `json:"conflictStrategy"`
+	InstallationTimeout *int                           `json:"installationTimeout"`
+}
+
+type MaintenanceFreezeStatus struct {
+	Active  bool                       `json:"active"`
+	Windows []*MaintenanceFreezeWindow `json:"windows"`
+}
+
+type MaintenanceFreezeWindow struct {
+	Name      string    `json:"name"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Providers []string  `json:"providers"`
+	Regions   []string  `json:"regions"`
+	Tenants   []string  `json:"tenants"`
 }
 
 type OIDCConfig struct {
@@ -210,12 +359,81 @@ type OpenStackProviderConfigInput struct {
 	LoadBalancerProvider string   `json:"loadBalancerProvider"`
 }
 
+type OperationAnnotation struct {
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type OperationHistoryEntry struct {
+	ID             string         `json:"id"`
+	Operation      OperationType  `json:"operation"`
+	State          OperationState `json:"state"`
+	StartTimestamp time.Time      `json:"startTimestamp"`
+	EndTimestamp   *time.Time     `json:"endTimestamp"`
+	Stage          string         `json:"stage"`
+	ErrorSummary   *string        `json:"errorSummary"`
+}
+
+type OperationProgress struct {
+	CurrentStage        string     `json:"currentStage"`
+	StagesDone          int        `json:"stagesDone"`
+	StagesTotal         int        `json:"stagesTotal"`
+	EstimatedCompletion *time.Time `json:"estimatedCompletion"`
+}
+
 type OperationStatus struct {
-	ID        *string        `json:"id"`
-	Operation OperationType  `json:"operation"`
-	State     OperationState `json:"state"`
-	Message   *string        `json:"message"`
-	RuntimeID *string        `json:"runtimeID"`
+	ID                  *string                   `json:"id"`
+	Operation           OperationType             `json:"operation"`
+	State               OperationState            `json:"state"`
+	Message             *string                   `json:"message"`
+	RuntimeID           *string                   `json:"runtimeID"`
+	ShootSpecDiff       []*ShootSpecChange        `json:"shootSpecDiff"`
+	DryRunReport        *ProvisioningDryRunReport `json:"dryRunReport"`
+	Progress            *OperationProgress        `json:"progress"`
+	InstallationTimeout *int                      `json:"installationTimeout"`
+	Diagnostics         *string                   `json:"diagnostics"`
+	FailureReason       *string                   `json:"failureReason"`
+	Annotations         []*OperationAnnotation    `json:"annotations"`
+}
+
+type OperationsHistory struct {
+	Operations  []*OperationHistoryEntry `json:"operations"`
+	TotalCount  int                      `json:"totalCount"`
+	EndCursor   *string                  `json:"endCursor"`
+	HasNextPage bool                     `json:"hasNextPage"`
+}
+
+type OrphanedShoot struct {
+	Name              string          `json:"name"`
+	CreationTimestamp time.Time       `json:"creationTimestamp"`
+	Labels            *Labels         `json:"labels"`
+	CostAllocation    *CostAllocation `json:"costAllocation"`
+}
+
+type ProviderDefaults struct {
+	Provider                            Provider               `json:"provider"`
+	CloudProfileName                    *string                `json:"cloudProfileName"`
+	EnableKubernetesVersionAutoUpdate   bool                   `json:"enableKubernetesVersionAutoUpdate"`
+	EnableMachineImageVersionAutoUpdate bool                   `json:"enableMachineImageVersionAutoUpdate"`
+	AllowPrivilegedContainers           bool                   `json:"allowPrivilegedContainers"`
+	NetworkingType                      *NetworkingType        `json:"networkingType"`
+	MachineImage                        *string                `json:"machineImage"`
+	MachineImageVersion                 *string                `json:"machineImageVersion"`
+	Purpose                             *string                `json:"purpose"`
+	DiskType                            *string                `json:"diskType"`
+	VolumeSizeGb                        *int                   `json:"volumeSizeGB"`
+	ProviderSpecificConfig              ProviderSpecificConfig `json:"providerSpecificConfig"`
+	Regions                             *ProviderRegions       `json:"regions"`
+	KymaVersion                         *string                `json:"kymaVersion"`
+	KymaProfile                         *KymaProfile           `json:"kymaProfile"`
+}
+
+type ProviderRegions struct {
+	ProviderAllowed bool     `json:"providerAllowed"`
+	AllowedRegions  []string `json:"allowedRegions"`
+	DeniedRegions   []string `json:"deniedRegions"`
+	DeniedZones     []string `json:"deniedZones"`
 }
 
 type ProviderSpecificInput struct {
@@ -226,15 +444,46 @@ type ProviderSpecificInput struct {
 }
 
 type ProvisionRuntimeInput struct {
-	RuntimeInput  *RuntimeInput       `json:"runtimeInput"`
-	ClusterConfig *ClusterConfigInput `json:"clusterConfig"`
-	KymaConfig    *KymaConfigInput    `json:"kymaConfig"`
+	RuntimeInput      *RuntimeInput       `json:"runtimeInput"`
+	ClusterConfig     *ClusterConfigInput `json:"clusterConfig"`
+	KymaConfig        *KymaConfigInput    `json:"kymaConfig"`
+	ExpirationSeconds *int                `json:"expirationSeconds"`
+	ExpireAt          *time.Time          `json:"expireAt"`
+}
+
+type ProvisioningDryRunReport struct {
+	Valid             bool        `json:"valid"`
+	Errors            []string    `json:"errors"`
+	Warnings          []string    `json:"warnings"`
+	KymaVersion       *string     `json:"kymaVersion"`
+	KubernetesVersion *string     `json:"kubernetesVersion"`
+	ShootSpec         *string     `json:"shootSpec"`
+	KymaConfig        *KymaConfig `json:"kymaConfig"`
+}
+
+type QueueStatus struct {
+	Queue  QueueType `json:"queue"`
+	Paused bool      `json:"paused"`
+	Depth  int       `json:"depth"`
+}
+
+type ReadOnlyModeStatus struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
+
+type RetriedOperations struct {
+	Count        int      `json:"count"`
+	OperationIDs []string `json:"operationIDs"`
+	DryRun       bool     `json:"dryRun"`
 }
 
 type RuntimeConfig struct {
 	ClusterConfig *GardenerConfig `json:"clusterConfig"`
 	KymaConfig    *KymaConfig     `json:"kymaConfig"`
 	Kubeconfig    *string         `json:"kubeconfig"`
+	APIServerURL  *string         `json:"apiServerURL"`
+	CaCertificate *string         `json:"caCertificate"`
 }
 
 type RuntimeConnectionStatus struct {
@@ -249,10 +498,62 @@ type RuntimeInput struct {
 }
 
 type RuntimeStatus struct {
-	LastOperationStatus     *OperationStatus         `json:"lastOperationStatus"`
-	RuntimeConnectionStatus *RuntimeConnectionStatus `json:"runtimeConnectionStatus"`
-	RuntimeConfiguration    *RuntimeConfig           `json:"runtimeConfiguration"`
-	HibernationStatus       *HibernationStatus       `json:"hibernationStatus"`
+	LastOperationStatus       *OperationStatus         `json:"lastOperationStatus"`
+	RuntimeConnectionStatus   *RuntimeConnectionStatus `json:"runtimeConnectionStatus"`
+	RuntimeConfiguration      *RuntimeConfig           `json:"runtimeConfiguration"`
+	HibernationStatus         *HibernationStatus       `json:"hibernationStatus"`
+	ShootStatus               *ShootStatus             `json:"shootStatus"`
+	ExpireAt                  *time.Time               `json:"expireAt"`
+	DeprovisioningScheduledAt *time.Time               `json:"deprovisioningScheduledAt"`
+	SubAccountID              *string                  `json:"subAccountID"`
+}
+
+type RuntimeStatusEntry struct {
+	RuntimeID string         `json:"runtimeID"`
+	Status    *RuntimeStatus `json:"status"`
+}
+
+type ShootCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason"`
+	Message            string    `json:"message"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+type ShootError struct {
+	Description string   `json:"description"`
+	Codes       []string `json:"codes"`
+}
+
+type ShootRuntime struct {
+	RuntimeID     string                 `json:"runtimeID"`
+	Tenant        *string                `json:"tenant"`
+	Provider      string                 `json:"provider"`
+	LastOperation *OperationHistoryEntry `json:"lastOperation"`
+}
+
+type ShootSpecChange struct {
+	Path     string `json:"path"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+}
+
+type ShootStatus struct {
+	State      ShootState        `json:"state"`
+	Conditions []*ShootCondition `json:"conditions"`
+	LastErrors []*ShootError     `json:"lastErrors"`
+	Domain     *string           `json:"domain"`
+}
+
+type SystemWorkerPool struct {
+	Size        int     `json:"size"`
+	MachineType *string `json:"machineType"`
+}
+
+type SystemWorkerPoolInput struct {
+	Size        *int    `json:"size"`
+	MachineType *string `json:"machineType"`
 }
 
 type UpgradeRuntimeInput struct {
@@ -305,6 +606,47 @@ func (e ConflictStrategy) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type HibernationTrigger string
+
+const (
+	HibernationTriggerManual    HibernationTrigger = "Manual"
+	HibernationTriggerScheduled HibernationTrigger = "Scheduled"
+)
+
+var AllHibernationTrigger = []HibernationTrigger{
+	HibernationTriggerManual,
+	HibernationTriggerScheduled,
+}
+
+func (e HibernationTrigger) IsValid() bool {
+	switch e {
+	case HibernationTriggerManual, HibernationTriggerScheduled:
+		return true
+	}
+	return false
+}
+
+func (e HibernationTrigger) String() string {
+	return string(e)
+}
+
+func (e *HibernationTrigger) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HibernationTrigger(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HibernationTrigger", str)
+	}
+	return nil
+}
+
+func (e HibernationTrigger) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type KymaProfile string
 
 const (
@@ -346,6 +688,47 @@ func (e KymaProfile) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type NetworkingType string
+
+const (
+	NetworkingTypeCalico NetworkingType = "Calico"
+	NetworkingTypeCilium NetworkingType = "Cilium"
+)
+
+var AllNetworkingType = []NetworkingType{
+	NetworkingTypeCalico,
+	NetworkingTypeCilium,
+}
+
+func (e NetworkingType) IsValid() bool {
+	switch e {
+	case NetworkingTypeCalico, NetworkingTypeCilium:
+		return true
+	}
+	return false
+}
+
+func (e NetworkingType) String() string {
+	return string(e)
+}
+
+func (e *NetworkingType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NetworkingType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NetworkingType", str)
+	}
+	return nil
+}
+
+func (e NetworkingType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type OperationState string
 
 const (
@@ -394,12 +777,14 @@ func (e OperationState) MarshalGQL(w io.Writer) {
 type OperationType string
 
 const (
-	OperationTypeProvision        OperationType = "Provision"
-	OperationTypeUpgrade          OperationType = "Upgrade"
-	OperationTypeUpgradeShoot     OperationType = "UpgradeShoot"
-	OperationTypeDeprovision      OperationType = "Deprovision"
-	OperationTypeReconnectRuntime OperationType = "ReconnectRuntime"
-	OperationTypeHibernate        OperationType = "Hibernate"
+	OperationTypeProvision                 OperationType = "Provision"
+	OperationTypeUpgrade                   OperationType = "Upgrade"
+	OperationTypeUpgradeShoot              OperationType = "UpgradeShoot"
+	OperationTypeDeprovision               OperationType = "Deprovision"
+	OperationTypeReconnectRuntime          OperationType = "ReconnectRuntime"
+	OperationTypeHibernate                 OperationType = "Hibernate"
+	OperationTypeWakeUp                    OperationType = "WakeUp"
+	OperationTypeCleanupFailedProvisioning OperationType = "CleanupFailedProvisioning"
 )
 
 var AllOperationType = []OperationType{
@@ -409,11 +794,13 @@ var AllOperationType = []OperationType{
 	OperationTypeDeprovision,
 	OperationTypeReconnectRuntime,
 	OperationTypeHibernate,
+	OperationTypeWakeUp,
+	OperationTypeCleanupFailedProvisioning,
 }
 
 func (e OperationType) IsValid() bool {
 	switch e {
-	case OperationTypeProvision, OperationTypeUpgrade, OperationTypeUpgradeShoot, OperationTypeDeprovision, OperationTypeReconnectRuntime, OperationTypeHibernate:
+	case OperationTypeProvision, OperationTypeUpgrade, OperationTypeUpgradeShoot, OperationTypeDeprovision, OperationTypeReconnectRuntime, OperationTypeHibernate, OperationTypeWakeUp, OperationTypeCleanupFailedProvisioning:
 		return true
 	}
 	return false
@@ -440,6 +827,98 @@ func (e OperationType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type Provider string
+
+const (
+	ProviderGcp       Provider = "GCP"
+	ProviderAzure     Provider = "Azure"
+	ProviderAws       Provider = "AWS"
+	ProviderOpenStack Provider = "OpenStack"
+)
+
+var AllProvider = []Provider{
+	ProviderGcp,
+	ProviderAzure,
+	ProviderAws,
+	ProviderOpenStack,
+}
+
+func (e Provider) IsValid() bool {
+	switch e {
+	case ProviderGcp, ProviderAzure, ProviderAws, ProviderOpenStack:
+		return true
+	}
+	return false
+}
+
+func (e Provider) String() string {
+	return string(e)
+}
+
+func (e *Provider) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Provider(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Provider", str)
+	}
+	return nil
+}
+
+func (e Provider) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type QueueType string
+
+const (
+	QueueTypeProvision    QueueType = "Provision"
+	QueueTypeDeprovision  QueueType = "Deprovision"
+	QueueTypeUpgrade      QueueType = "Upgrade"
+	QueueTypeUpgradeShoot QueueType = "UpgradeShoot"
+	QueueTypeHibernate    QueueType = "Hibernate"
+)
+
+var AllQueueType = []QueueType{
+	QueueTypeProvision,
+	QueueTypeDeprovision,
+	QueueTypeUpgrade,
+	QueueTypeUpgradeShoot,
+	QueueTypeHibernate,
+}
+
+func (e QueueType) IsValid() bool {
+	switch e {
+	case QueueTypeProvision, QueueTypeDeprovision, QueueTypeUpgrade, QueueTypeUpgradeShoot, QueueTypeHibernate:
+		return true
+	}
+	return false
+}
+
+func (e QueueType) String() string {
+	return string(e)
+}
+
+func (e *QueueType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QueueType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QueueType", str)
+	}
+	return nil
+}
+
+func (e QueueType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type RuntimeAgentConnectionStatus string
 
 const (
@@ -482,3 +961,48 @@ func (e *RuntimeAgentConnectionStatus) UnmarshalGQL(v interface{}) error {
 func (e RuntimeAgentConnectionStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type ShootState string
+
+const (
+	ShootStateHealthy     ShootState = "HEALTHY"
+	ShootStateUnhealthy   ShootState = "UNHEALTHY"
+	ShootStateProgressing ShootState = "PROGRESSING"
+	ShootStateHibernated  ShootState = "HIBERNATED"
+)
+
+var AllShootState = []ShootState{
+	ShootStateHealthy,
+	ShootStateUnhealthy,
+	ShootStateProgressing,
+	ShootStateHibernated,
+}
+
+func (e ShootState) IsValid() bool {
+	switch e {
+	case ShootStateHealthy, ShootStateUnhealthy, ShootStateProgressing, ShootStateHibernated:
+		return true
+	}
+	return false
+}
+
+func (e ShootState) String() string {
+	return string(e)
+}
+
+func (e *ShootState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ShootState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ShootState", str)
+	}
+	return nil
+}
+
+func (e ShootState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
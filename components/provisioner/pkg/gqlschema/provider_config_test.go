@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestGardenerConfig_UnmarshalJSON(t *testing.T) {
@@ -91,7 +92,7 @@ func fixGardenerConfig(providerName string) GardenerConfig {
 		WorkerCidr:        util.StringPtr("10.10.10.10/25"),
 		AutoScalerMin:     util.IntPtr(1),
 		AutoScalerMax:     util.IntPtr(4),
-		MaxSurge:          util.IntPtr(25),
-		MaxUnavailable:    util.IntPtr(2),
+		MaxSurge:          NewIntOrString(intstr.FromInt(25)),
+		MaxUnavailable:    NewIntOrString(intstr.FromInt(2)),
 	}
 }
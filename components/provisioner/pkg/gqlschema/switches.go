@@ -0,0 +1,44 @@
+package gqlschema
+
+import (
+	"io"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/scalar"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Switches map names to the enabled flag, for example, the feature gates or APIs of the kube-apiserver
+type Switches map[string]bool
+
+func (y *Switches) UnmarshalGQL(v interface{}) error {
+	if v == nil {
+		return errors.New("input should not be nil")
+	}
+
+	value, ok := v.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("unexpected Switches type: %T, should be map[string]interface{}", v)
+	}
+
+	switches := make(Switches, len(value))
+	for key, val := range value {
+		enabled, ok := val.(bool)
+		if !ok {
+			return errors.Errorf("unexpected type of switch %s value: %T, should be bool", key, val)
+		}
+		switches[key] = enabled
+	}
+
+	*y = switches
+
+	return nil
+}
+
+func (y Switches) MarshalGQL(w io.Writer) {
+	err := scalar.WriteMarshalled(y, w)
+	if err != nil {
+		log.Errorf("while writing %T: %s", y, err)
+		return
+	}
+}
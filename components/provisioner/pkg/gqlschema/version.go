@@ -0,0 +1,10 @@
+package gqlschema
+
+const (
+	// SchemaVersion is the version of the GraphQL schema served by the Provisioner. Increase the minor version when the schema is extended,
+	// and the major version when a backward incompatible change, which has to be coordinated with the clients, is made
+	SchemaVersion = "1.2.0"
+
+	// SchemaVersionHeader is the header of the responses of the GraphQL endpoint containing the SchemaVersion
+	SchemaVersionHeader = "X-Provisioner-Schema-Version"
+)
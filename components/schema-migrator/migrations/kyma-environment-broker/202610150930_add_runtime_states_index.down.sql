@@ -0,0 +1 @@
+DROP INDEX runtime_states_by_runtime_id;
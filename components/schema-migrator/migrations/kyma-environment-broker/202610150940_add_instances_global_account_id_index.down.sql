@@ -0,0 +1 @@
+DROP INDEX instances_by_global_account_id;
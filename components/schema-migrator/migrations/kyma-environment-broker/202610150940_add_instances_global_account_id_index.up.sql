@@ -0,0 +1 @@
+CREATE INDEX instances_by_global_account_id ON instances USING btree (global_account_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS events (
+    id varchar(255) PRIMARY KEY,
+    instance_id varchar(255) NOT NULL,
+    operation_id varchar(255) NOT NULL DEFAULT '',
+    level varchar(32) NOT NULL,
+    message text NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX events_by_instance_id ON events USING btree (instance_id, created_at);
+CREATE INDEX events_by_created_at ON events USING btree (created_at);
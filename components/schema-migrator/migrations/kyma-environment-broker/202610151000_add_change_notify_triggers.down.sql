@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS operations_notify_change ON operations;
+DROP TRIGGER IF EXISTS orchestrations_notify_change ON orchestrations;
+DROP FUNCTION IF EXISTS notify_operation_change();
+DROP FUNCTION IF EXISTS notify_orchestration_change();
@@ -0,0 +1,35 @@
+CREATE OR REPLACE FUNCTION notify_operation_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.state IS DISTINCT FROM OLD.state THEN
+        PERFORM pg_notify('keb_changes', json_build_object(
+            'type', 'operation',
+            'id', NEW.id,
+            'state', NEW.state,
+            'orchestrationId', COALESCE(NEW.orchestration_id, '')
+        )::text);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_orchestration_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.state IS DISTINCT FROM OLD.state THEN
+        PERFORM pg_notify('keb_changes', json_build_object(
+            'type', 'orchestration',
+            'id', NEW.orchestration_id,
+            'state', NEW.state,
+            'orchestrationId', NEW.orchestration_id
+        )::text);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS operations_notify_change ON operations;
+CREATE TRIGGER operations_notify_change AFTER INSERT OR UPDATE ON operations
+    FOR EACH ROW EXECUTE PROCEDURE notify_operation_change();
+
+DROP TRIGGER IF EXISTS orchestrations_notify_change ON orchestrations;
+CREATE TRIGGER orchestrations_notify_change AFTER INSERT OR UPDATE ON orchestrations
+    FOR EACH ROW EXECUTE PROCEDURE notify_orchestration_change();
@@ -0,0 +1,5 @@
+DELETE FROM instances WHERE deleted_at <> '0001-01-01 00:00:00+00';
+DROP INDEX instances_by_deleted_at;
+DROP INDEX instances_by_instance_id;
+DROP INDEX instances_instance_id_not_deleted;
+ALTER TABLE instances ADD PRIMARY KEY (instance_id);
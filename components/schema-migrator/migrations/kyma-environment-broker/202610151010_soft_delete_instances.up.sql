@@ -0,0 +1,4 @@
+ALTER TABLE instances DROP CONSTRAINT instances_pkey;
+CREATE UNIQUE INDEX instances_instance_id_not_deleted ON instances USING btree (instance_id) WHERE deleted_at = '0001-01-01 00:00:00+00';
+CREATE INDEX instances_by_instance_id ON instances USING btree (instance_id);
+CREATE INDEX instances_by_deleted_at ON instances USING btree (deleted_at) WHERE deleted_at <> '0001-01-01 00:00:00+00';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS instance_parameters_history (
+    id bigserial PRIMARY KEY,
+    instance_id varchar(255) NOT NULL,
+    operation_id varchar(255) NOT NULL DEFAULT '',
+    provisioning_parameters text NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX instance_parameters_history_by_instance_id ON instance_parameters_history USING btree (instance_id, created_at);
+CREATE INDEX instance_parameters_history_by_created_at ON instance_parameters_history USING btree (created_at);
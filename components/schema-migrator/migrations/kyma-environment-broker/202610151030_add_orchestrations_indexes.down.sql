@@ -0,0 +1,2 @@
+DROP INDEX orchestrations_by_created_at;
+DROP INDEX orchestrations_by_type_state_created_at;
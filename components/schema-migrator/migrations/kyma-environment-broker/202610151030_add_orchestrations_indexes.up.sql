@@ -0,0 +1,2 @@
+CREATE INDEX orchestrations_by_type_state_created_at ON orchestrations USING btree (type, state, created_at);
+CREATE INDEX orchestrations_by_created_at ON orchestrations USING btree (created_at);
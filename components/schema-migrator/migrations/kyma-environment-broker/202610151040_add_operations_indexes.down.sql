@@ -0,0 +1,3 @@
+DROP INDEX operations_by_created_at;
+DROP INDEX operations_by_state_created_at;
+DROP INDEX operations_by_instance_id_type_created_at;
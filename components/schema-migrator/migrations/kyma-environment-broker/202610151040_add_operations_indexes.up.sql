@@ -0,0 +1,3 @@
+CREATE INDEX operations_by_instance_id_type_created_at ON operations USING btree (instance_id, type, created_at, id);
+CREATE INDEX operations_by_state_created_at ON operations USING btree (state, created_at, id);
+CREATE INDEX operations_by_created_at ON operations USING btree (created_at, id);
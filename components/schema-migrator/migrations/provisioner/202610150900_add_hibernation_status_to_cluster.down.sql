@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE cluster DROP COLUMN hibernated;
+ALTER TABLE cluster DROP COLUMN hibernated_at;
+ALTER TABLE cluster DROP COLUMN last_woken_at;
+ALTER TABLE cluster DROP COLUMN hibernation_initiated_by;
+
+COMMIT;
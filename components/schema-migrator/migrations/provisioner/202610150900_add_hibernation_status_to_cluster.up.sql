@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE cluster ADD COLUMN hibernated boolean NOT NULL DEFAULT false;
+ALTER TABLE cluster ADD COLUMN hibernated_at timestamp without time zone;
+ALTER TABLE cluster ADD COLUMN last_woken_at timestamp without time zone;
+ALTER TABLE cluster ADD COLUMN hibernation_initiated_by varchar(256);
+
+COMMIT;
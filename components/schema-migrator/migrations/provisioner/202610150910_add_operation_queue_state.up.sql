@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE operation_queue_state
+(
+    operation_type operation_type PRIMARY KEY,
+    paused boolean NOT NULL DEFAULT false
+);
+
+INSERT INTO operation_queue_state (operation_type) VALUES ('PROVISION'), ('DEPROVISION'), ('UPGRADE'), ('UPGRADE_SHOOT'), ('HIBERNATE');
+
+COMMIT;
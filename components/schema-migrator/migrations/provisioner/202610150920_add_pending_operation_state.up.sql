@@ -0,0 +1 @@
+ALTER TYPE operation_state ADD VALUE 'PENDING' BEFORE 'IN_PROGRESS';
\ No newline at end of file
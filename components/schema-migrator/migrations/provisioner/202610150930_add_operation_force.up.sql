@@ -0,0 +1 @@
+ALTER TABLE operation ADD COLUMN force boolean NOT NULL DEFAULT false;
@@ -0,0 +1,15 @@
+CREATE TABLE api_audit_log
+(
+    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
+    tenant varchar(256),
+    sub_account_id varchar(256),
+    mutation varchar(256) NOT NULL,
+    input jsonb,
+    operation_id uuid,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX api_audit_log_tenant_created_at_idx ON api_audit_log (tenant, created_at);
+
+CREATE RULE api_audit_log_no_update AS ON UPDATE TO api_audit_log DO INSTEAD NOTHING;
+CREATE RULE api_audit_log_no_delete AS ON DELETE TO api_audit_log DO INSTEAD NOTHING;
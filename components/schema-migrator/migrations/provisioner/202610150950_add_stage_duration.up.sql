@@ -0,0 +1,10 @@
+CREATE TABLE stage_duration
+(
+    operation_id uuid NOT NULL,
+    operation_type varchar(256) NOT NULL,
+    stage varchar(256) NOT NULL,
+    duration_seconds double precision NOT NULL,
+    finished_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX stage_duration_type_stage_finished_at_idx ON stage_duration (operation_type, stage, finished_at);
@@ -0,0 +1 @@
+ALTER TABLE gardener_config ADD COLUMN networking_type varchar(256) NOT NULL DEFAULT 'calico';
@@ -0,0 +1 @@
+ALTER TABLE operation ADD COLUMN version integer NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE operation ADD COLUMN installation_timeout_minutes integer;
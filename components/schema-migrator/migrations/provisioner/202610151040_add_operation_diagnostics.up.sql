@@ -0,0 +1 @@
+ALTER TABLE operation ADD COLUMN diagnostics text;
@@ -0,0 +1 @@
+ALTER TABLE cluster ALTER COLUMN active_kyma_config_id SET NOT NULL;
@@ -0,0 +1 @@
+DROP INDEX gardener_config_name_idx;
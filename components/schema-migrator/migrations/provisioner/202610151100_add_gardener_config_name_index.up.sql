@@ -0,0 +1 @@
+CREATE INDEX gardener_config_name_idx ON gardener_config (name);
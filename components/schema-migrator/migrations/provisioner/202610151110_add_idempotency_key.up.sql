@@ -0,0 +1,10 @@
+CREATE TABLE idempotency_key
+(
+    tenant varchar(256) NOT NULL,
+    key varchar(256) NOT NULL,
+    operation_type varchar(256) NOT NULL,
+    operation_id uuid NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    UNIQUE (tenant, key),
+    foreign key (operation_id) REFERENCES operation (id) ON DELETE CASCADE
+);
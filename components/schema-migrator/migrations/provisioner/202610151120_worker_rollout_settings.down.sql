@@ -0,0 +1,8 @@
+UPDATE gardener_config SET max_surge = '1' WHERE max_surge IS NULL;
+UPDATE gardener_config SET max_unavailable = '0' WHERE max_unavailable IS NULL;
+
+ALTER TABLE gardener_config
+    ALTER COLUMN max_surge TYPE integer USING max_surge::integer,
+    ALTER COLUMN max_surge SET NOT NULL,
+    ALTER COLUMN max_unavailable TYPE integer USING max_unavailable::integer,
+    ALTER COLUMN max_unavailable SET NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE gardener_config
+    ALTER COLUMN max_surge TYPE varchar(16) USING max_surge::varchar,
+    ALTER COLUMN max_surge DROP NOT NULL,
+    ALTER COLUMN max_unavailable TYPE varchar(16) USING max_unavailable::varchar,
+    ALTER COLUMN max_unavailable DROP NOT NULL;
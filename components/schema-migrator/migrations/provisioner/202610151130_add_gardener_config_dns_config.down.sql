@@ -0,0 +1 @@
+ALTER TABLE gardener_config DROP COLUMN dns_config;
@@ -0,0 +1 @@
+ALTER TABLE gardener_config ADD COLUMN dns_config jsonb;
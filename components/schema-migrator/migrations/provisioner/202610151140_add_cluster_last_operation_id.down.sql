@@ -0,0 +1,3 @@
+DROP INDEX operation_cluster_id_start_timestamp_idx;
+
+ALTER TABLE cluster DROP COLUMN last_operation_id;
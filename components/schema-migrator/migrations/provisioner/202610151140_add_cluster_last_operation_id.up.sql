@@ -0,0 +1,10 @@
+ALTER TABLE cluster ADD COLUMN last_operation_id uuid;
+
+CREATE INDEX operation_cluster_id_start_timestamp_idx ON operation (cluster_id, start_timestamp);
+
+UPDATE cluster SET last_operation_id = (
+    SELECT operation.id FROM operation
+    WHERE operation.cluster_id = cluster.id
+    ORDER BY operation.start_timestamp DESC, operation.id DESC
+    LIMIT 1
+);
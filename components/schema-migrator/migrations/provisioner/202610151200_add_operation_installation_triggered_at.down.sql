@@ -0,0 +1 @@
+ALTER TABLE operation DROP COLUMN installation_triggered_at;
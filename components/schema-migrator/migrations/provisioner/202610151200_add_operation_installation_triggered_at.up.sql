@@ -0,0 +1 @@
+ALTER TABLE operation ADD COLUMN installation_triggered_at timestamp without time zone;
@@ -0,0 +1 @@
+ALTER TABLE gardener_config ADD COLUMN cost_allocation jsonb;
@@ -0,0 +1,30 @@
+BEGIN;
+
+DELETE FROM operation WHERE type = 'CLEANUP_FAILED_PROVISIONING';
+
+UPDATE cluster SET last_operation_id = (
+    SELECT operation.id FROM operation
+    WHERE operation.cluster_id = cluster.id
+    ORDER BY operation.start_timestamp DESC, operation.id DESC
+    LIMIT 1
+)
+WHERE last_operation_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM operation WHERE operation.id = cluster.last_operation_id);
+
+ALTER TYPE operation_type RENAME TO operation_type_old;
+
+CREATE TYPE operation_type AS ENUM (
+    'PROVISION',
+    'UPGRADE',
+    'DEPROVISION',
+    'RECONNECT_RUNTIME',
+    'UPGRADE_SHOOT',
+    'HIBERNATE',
+    'WAKE_UP'
+    );
+
+ALTER TABLE operation ALTER COLUMN type TYPE operation_type USING type::text::operation_type;
+ALTER TABLE operation_queue_state ALTER COLUMN operation_type TYPE operation_type USING operation_type::text::operation_type;
+
+DROP TYPE operation_type_old;
+
+COMMIT;
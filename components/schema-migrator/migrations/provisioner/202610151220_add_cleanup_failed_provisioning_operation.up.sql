@@ -0,0 +1 @@
+ALTER TYPE operation_type ADD VALUE 'CLEANUP_FAILED_PROVISIONING' AFTER 'WAKE_UP';
@@ -0,0 +1 @@
+DROP INDEX operation_cluster_id_in_progress_idx;
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX operation_cluster_id_in_progress_idx ON operation (cluster_id) WHERE state IN ('PENDING', 'IN_PROGRESS');
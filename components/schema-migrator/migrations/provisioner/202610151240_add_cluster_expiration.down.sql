@@ -0,0 +1,5 @@
+DROP INDEX cluster_expire_at_idx;
+
+ALTER TABLE operation DROP COLUMN triggered_by;
+
+ALTER TABLE cluster DROP COLUMN expire_at;
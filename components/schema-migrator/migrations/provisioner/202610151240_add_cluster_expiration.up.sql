@@ -0,0 +1,5 @@
+ALTER TABLE cluster ADD COLUMN expire_at timestamp without time zone;
+
+ALTER TABLE operation ADD COLUMN triggered_by varchar(256);
+
+CREATE INDEX cluster_expire_at_idx ON cluster (expire_at) WHERE expire_at IS NOT NULL AND deleted = false;
@@ -0,0 +1,3 @@
+ALTER TABLE operation DROP COLUMN claim_expires_at;
+
+ALTER TABLE operation DROP COLUMN claimed_by;
@@ -0,0 +1,3 @@
+ALTER TABLE operation ADD COLUMN claimed_by varchar(256);
+
+ALTER TABLE operation ADD COLUMN claim_expires_at timestamp without time zone;
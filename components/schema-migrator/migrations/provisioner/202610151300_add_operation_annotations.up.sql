@@ -0,0 +1,9 @@
+CREATE TABLE operation_annotations
+(
+    operation_id uuid NOT NULL,
+    key varchar(63) NOT NULL,
+    value text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    PRIMARY KEY (operation_id, key),
+    foreign key (operation_id) REFERENCES operation (id) ON DELETE CASCADE
+);
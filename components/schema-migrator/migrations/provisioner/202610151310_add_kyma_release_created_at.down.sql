@@ -0,0 +1 @@
+ALTER TABLE kyma_release DROP COLUMN created_at;
@@ -0,0 +1 @@
+ALTER TABLE kyma_release ADD COLUMN created_at timestamp without time zone NOT NULL DEFAULT now();
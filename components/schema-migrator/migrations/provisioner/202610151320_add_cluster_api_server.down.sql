@@ -0,0 +1,3 @@
+ALTER TABLE cluster DROP COLUMN ca_certificate;
+
+ALTER TABLE cluster DROP COLUMN api_server_url;
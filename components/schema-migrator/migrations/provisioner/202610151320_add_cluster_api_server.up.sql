@@ -0,0 +1,3 @@
+ALTER TABLE cluster ADD COLUMN api_server_url text;
+
+ALTER TABLE cluster ADD COLUMN ca_certificate text;
@@ -0,0 +1 @@
+ALTER TABLE gardener_config ADD COLUMN cluster_autoscaler_config jsonb;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE read_only_mode;
+
+COMMIT;
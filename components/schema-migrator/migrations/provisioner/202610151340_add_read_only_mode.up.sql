@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE TABLE read_only_mode
+(
+    id boolean PRIMARY KEY DEFAULT true CHECK (id),
+    enabled boolean,
+    message text,
+    updated_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+INSERT INTO read_only_mode (id) VALUES (true);
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TABLE gardener_config ADD COLUMN kube_apiserver_config jsonb;
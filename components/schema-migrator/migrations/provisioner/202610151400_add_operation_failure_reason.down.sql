@@ -0,0 +1 @@
+ALTER TABLE operation DROP COLUMN failure_reason;
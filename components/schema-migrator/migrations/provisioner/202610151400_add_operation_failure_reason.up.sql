@@ -0,0 +1 @@
+ALTER TABLE operation ADD COLUMN failure_reason varchar(256);
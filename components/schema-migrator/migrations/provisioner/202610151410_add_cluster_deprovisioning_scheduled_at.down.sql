@@ -0,0 +1,3 @@
+DROP INDEX cluster_deprovisioning_scheduled_at_idx;
+
+ALTER TABLE cluster DROP COLUMN deprovisioning_scheduled_at;
@@ -0,0 +1,3 @@
+ALTER TABLE cluster ADD COLUMN deprovisioning_scheduled_at timestamp without time zone;
+
+CREATE INDEX cluster_deprovisioning_scheduled_at_idx ON cluster (deprovisioning_scheduled_at) WHERE deprovisioning_scheduled_at IS NOT NULL AND deleted = false;
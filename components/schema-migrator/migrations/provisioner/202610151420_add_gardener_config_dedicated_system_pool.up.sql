@@ -0,0 +1 @@
+ALTER TABLE gardener_config ADD COLUMN dedicated_system_pool jsonb;